@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// client calls the Media Tools API with an API key.
+type client struct {
+	httpClient *http.Client
+	server     string
+	apiKey     string
+}
+
+// newClient creates a client for the configured server and key.
+func newClient(cfg *config) *client {
+	return &client{
+		// Generous, since uploads of large recordings go through it
+		httpClient: &http.Client{Timeout: 10 * time.Minute},
+		server:     cfg.Server,
+		apiKey:     cfg.APIKey,
+	}
+}
+
+// apiError is an error response from the API.
+type apiError struct {
+	Status  int
+	Code    string // The response's "error" field, e.g. "not_found"
+	Message string
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("server returned %d", e.Status)
+	}
+	return fmt.Sprintf("%s (%d %s)", e.Message, e.Status, e.Code)
+}
+
+// do sends a request to path (e.g. "/api/v1/transcripts") and returns the
+// response body, or an *apiError for a non-2xx status.
+func (c *client) do(method, path string, body io.Reader, contentType string) ([]byte, error) {
+	resp, err := c.send(method, path, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return data, nil
+}
+
+// send sends a request, returning the response for the caller to read and
+// close, or an *apiError for a non-2xx status.
+func (c *client) send(method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("no API key; run \"mta login\" or set MTA_API_KEY")
+	}
+
+	req, err := http.NewRequest(method, c.server+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+	req.Header.Set("User-Agent", "mta/"+Version)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		apiErr := &apiError{Status: resp.StatusCode}
+		var errResp struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&errResp) == nil {
+			apiErr.Code = errResp.Error
+			apiErr.Message = errResp.Message
+		}
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// upload sends a file as the "file" part of a multipart request, streaming
+// it rather than reading it into memory.
+func (c *client) upload(path, filename string) ([]byte, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("file", filepath.Base(filename))
+		if err == nil {
+			_, err = io.Copy(part, f)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	data, err := c.do("POST", path, pr, mw.FormDataContentType())
+	pr.Close() // Stops the copy if the server answered before reading it all
+	return data, err
+}
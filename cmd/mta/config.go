@@ -0,0 +1,75 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultServer is the API the CLI talks to until login names another.
+const defaultServer = "http://localhost:8080"
+
+// config is what "mta login" stores: the server and the API key used for
+// every other command.
+type config struct {
+	Server string `json:"server"`
+	APIKey string `json:"api_key"`
+}
+
+// configPath returns where the config is stored: mta/config.json in the
+// user's config directory (~/.config on Linux). MTA_CONFIG overrides it.
+func configPath() (string, error) {
+	if path := os.Getenv("MTA_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("can't find a config directory: %w", err)
+	}
+	return filepath.Join(dir, "mta", "config.json"), nil
+}
+
+// loadConfig reads the stored config, with MTA_SERVER and MTA_API_KEY
+// overriding it so scripts can run without logging in. A missing config
+// isn't an error; a missing key is reported when a command needs one.
+func loadConfig() (*config, error) {
+	cfg := &config{}
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", path, err)
+		}
+	}
+
+	cfg.Server = strings.TrimRight(cmp.Or(os.Getenv("MTA_SERVER"), cfg.Server, defaultServer), "/")
+	cfg.APIKey = cmp.Or(os.Getenv("MTA_API_KEY"), cfg.APIKey)
+	return cfg, nil
+}
+
+// save writes the config, readable only by the user since it holds the key.
+func (cfg *config) save() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, _ := json.MarshalIndent(cfg, "", "  ")
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write config: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,333 @@
+// Command mta is a command-line client for the Media Tools API. It submits
+// YouTube URLs, uploads audio and PDFs, waits for results, downloads
+// exports, and searches, using an API key stored by "mta login" — so
+// scripts don't need to hand-roll curl against the REST endpoints.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is set at build time via -ldflags.
+var Version = "dev"
+
+// pollInterval is how often -wait checks on an item.
+var pollInterval = 2 * time.Second
+
+const usage = `usage: mta <command> [flags] [args]
+
+commands:
+  login [-server URL]             store an API key, read from stdin, for the
+                                  other commands
+  submit [-wait] URL              extract a YouTube video's transcript
+  upload [-wait] FILE             transcribe an audio file (mp3, wav, m4a, ogg,
+                                  flac, webm) or extract a PDF's text
+  status [-type T] [-wait] ID     show an item's status
+  export [-type T] [-format F] [-o FILE] ID
+                                  download a transcript as txt, md, srt, vtt,
+                                  json, ... (default txt, to stdout)
+  search [-type T,...] [-n N] QUERY
+                                  search transcripts, audio, and PDFs by text
+
+T is transcript (the default), audio, or pdf. -wait polls until the item
+completes, exiting with status 1 if it fails. submit, upload, status, and
+search take -json to print the API's response as-is.
+
+The server and key are stored in mta/config.json in your config directory;
+MTA_SERVER and MTA_API_KEY override them.`
+
+// itemPaths maps an item type to the API path its items are read from.
+var itemPaths = map[string]string{
+	"transcript": "/api/v1/transcripts/",
+	"audio":      "/api/v1/audio/transcriptions/",
+	"pdf":        "/api/v1/pdf/extractions/",
+}
+
+// item is the part of a transcript, audio transcription, or PDF extraction
+// the CLI shows.
+type item struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Title        string `json:"title"`         // Transcripts
+	OriginalName string `json:"original_name"` // Audio and PDFs
+	ErrorMessage string `json:"error_message"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "mta: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command in args. Results go to stdout; prompts and notes go
+// to stderr, so they don't end up in a script's output.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprintln(stdout, usage)
+		return nil
+	}
+	if args[0] == "version" {
+		fmt.Fprintln(stdout, "mta", Version)
+		return nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	switch cmd, rest := args[0], args[1:]; cmd {
+	case "login":
+		return runLogin(cfg, rest, stdin, stderr)
+	case "submit":
+		return runSubmit(newClient(cfg), rest, stdout)
+	case "upload":
+		return runUpload(newClient(cfg), rest, stdout)
+	case "status":
+		return runStatus(newClient(cfg), rest, stdout)
+	case "export":
+		return runExport(newClient(cfg), rest, stdout)
+	case "search":
+		return runSearch(newClient(cfg), rest, stdout, stderr)
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", cmd, usage)
+	}
+}
+
+// parseFlags parses a command's flags, requiring exactly one argument
+// after them, which it returns.
+func parseFlags(fs *flag.FlagSet, args []string, argName string) (string, error) {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return "", fmt.Errorf("%s: %w\n\n%s", fs.Name(), err, usage)
+	}
+	if fs.NArg() != 1 {
+		return "", fmt.Errorf("%s takes one %s\n\n%s", fs.Name(), argName, usage)
+	}
+	return fs.Arg(0), nil
+}
+
+// runLogin checks an API key read from stdin against the server and stores
+// both for later commands.
+func runLogin(cfg *config, args []string, stdin io.Reader, stderr io.Writer) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	server := fs.String("server", cfg.Server, "API server URL")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return fmt.Errorf("usage: mta login [-server URL] < keyfile")
+	}
+
+	// The key is read from stdin rather than an argument so it stays out
+	// of shell history and process listings
+	fmt.Fprint(stderr, "API key: ")
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read the API key: %w", err)
+	}
+	fmt.Fprintln(stderr)
+	cfg.APIKey = strings.TrimSpace(line)
+	cfg.Server = strings.TrimRight(*server, "/")
+	if cfg.APIKey == "" {
+		return fmt.Errorf("no API key given")
+	}
+
+	if _, err := newClient(cfg).do("GET", "/api/v1/tiers", nil, ""); err != nil {
+		return fmt.Errorf("%s didn't accept the key: %w", cfg.Server, err)
+	}
+	path, err := cfg.save()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "Logged in to %s; key saved to %s\n", cfg.Server, path)
+	return nil
+}
+
+// runSubmit queues a YouTube URL for transcript extraction.
+func runSubmit(c *client, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	wait := fs.Bool("wait", false, "wait for the transcript")
+	asJSON := fs.Bool("json", false, "print the API response")
+	videoURL, err := parseFlags(fs, args, "URL")
+	if err != nil {
+		return err
+	}
+
+	body, _ := json.Marshal(map[string]string{"url": videoURL})
+	data, err := c.do("POST", "/api/v1/transcripts", bytes.NewReader(body), "application/json")
+	if err != nil {
+		return err
+	}
+	return finish(c, "transcript", data, *wait, *asJSON, stdout)
+}
+
+// runUpload uploads an audio file for transcription, or a PDF for text
+// extraction, depending on its extension.
+func runUpload(c *client, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("upload", flag.ContinueOnError)
+	wait := fs.Bool("wait", false, "wait for the transcription")
+	asJSON := fs.Bool("json", false, "print the API response")
+	filename, err := parseFlags(fs, args, "FILE")
+	if err != nil {
+		return err
+	}
+
+	itemType, path := "audio", "/api/v1/audio/transcribe"
+	if strings.EqualFold(filepath.Ext(filename), ".pdf") {
+		itemType, path = "pdf", "/api/v1/pdf/extract"
+	}
+	data, err := c.upload(path, filename)
+	if err != nil {
+		return err
+	}
+	return finish(c, itemType, data, *wait, *asJSON, stdout)
+}
+
+// runStatus shows an item's status.
+func runStatus(c *client, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	itemType := fs.String("type", "transcript", "item type: transcript, audio, or pdf")
+	wait := fs.Bool("wait", false, "wait for the item to finish")
+	asJSON := fs.Bool("json", false, "print the API response")
+	id, err := parseFlags(fs, args, "ID")
+	if err != nil {
+		return err
+	}
+	path, ok := itemPaths[*itemType]
+	if !ok {
+		return fmt.Errorf("status: -type must be transcript, audio, or pdf")
+	}
+
+	data, err := c.do("GET", path+url.PathEscape(id), nil, "")
+	if err != nil {
+		return err
+	}
+	return finish(c, *itemType, data, *wait, *asJSON, stdout)
+}
+
+// finish prints an item from an API response, first polling until it's
+// done if wait is set. A failed item is an error once it's printed.
+func finish(c *client, itemType string, data []byte, wait, asJSON bool, stdout io.Writer) error {
+	var it item
+	if err := json.Unmarshal(data, &it); err != nil {
+		return fmt.Errorf("unexpected response: %w", err)
+	}
+	for wait && it.Status != "completed" && it.Status != "failed" {
+		time.Sleep(pollInterval)
+		var err error
+		if data, err = c.do("GET", itemPaths[itemType]+url.PathEscape(it.ID), nil, ""); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &it); err != nil {
+			return fmt.Errorf("unexpected response: %w", err)
+		}
+	}
+
+	if asJSON {
+		stdout.Write(data)
+		fmt.Fprintln(stdout)
+	} else {
+		// Tab-separated so scripts can cut out the ID
+		fmt.Fprintf(stdout, "%s\t%s\t%s\t%s\n", itemType, it.ID, it.Status, strings.TrimSpace(it.Title+it.OriginalName))
+	}
+	if wait && it.Status == "failed" {
+		return fmt.Errorf("%s %s failed: %s", itemType, it.ID, it.ErrorMessage)
+	}
+	return nil
+}
+
+// runExport downloads a transcript or audio transcription export.
+func runExport(c *client, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	itemType := fs.String("type", "transcript", "item type: transcript or audio")
+	format := fs.String("format", "txt", "export format")
+	output := fs.String("o", "", "write to this file instead of stdout")
+	id, err := parseFlags(fs, args, "ID")
+	if err != nil {
+		return err
+	}
+	if *itemType != "transcript" && *itemType != "audio" {
+		return fmt.Errorf("export: -type must be transcript or audio")
+	}
+
+	path := itemPaths[*itemType] + url.PathEscape(id) + "/export?format=" + url.QueryEscape(*format)
+	resp, err := c.send("GET", path, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	out := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("failed to download export: %w", err)
+	}
+	return nil
+}
+
+// runSearch searches items by text, printing one result per line.
+func runSearch(c *client, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	types := fs.String("type", "", "comma-separated item types (default all)")
+	limit := fs.Int("n", 20, "number of results, up to 100")
+	asJSON := fs.Bool("json", false, "print the API response")
+	query, err := parseFlags(fs, args, "QUERY")
+	if err != nil {
+		return err
+	}
+	if *limit < 1 || *limit > 100 {
+		return fmt.Errorf("search: -n must be between 1 and 100")
+	}
+
+	params := url.Values{"q": {query}, "per_page": {strconv.Itoa(*limit)}}
+	if *types != "" {
+		params.Set("type", *types)
+	}
+	data, err := c.do("GET", "/api/v1/search/text?"+params.Encode(), nil, "")
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		stdout.Write(data)
+		fmt.Fprintln(stdout)
+		return nil
+	}
+
+	var page struct {
+		Data []struct {
+			Type  string `json:"type"`
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"data"`
+		TotalItems int `json:"total_items"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return fmt.Errorf("unexpected response: %w", err)
+	}
+	for _, r := range page.Data {
+		fmt.Fprintf(stdout, "%s\t%s\t%s\n", r.Type, r.ID, r.Title)
+	}
+	if page.TotalItems > len(page.Data) {
+		fmt.Fprintf(stderr, "(%d of %d results; raise -n for more)\n", len(page.Data), page.TotalItems)
+	}
+	return nil
+}
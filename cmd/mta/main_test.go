@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeAPI serves the endpoints the CLI calls. A transcript is pending on
+// its first read and completed after that.
+func fakeAPI(t *testing.T) *httptest.Server {
+	reads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "mta_good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_api_key","message":"Invalid or inactive API key","code":401}`))
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/tiers":
+			w.Write([]byte(`[]`))
+		case "POST /api/v1/transcripts":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["url"] != "https://youtu.be/abc" {
+				t.Errorf("submitted %v", req)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":"t1","status":"pending"}`))
+		case "GET /api/v1/transcripts/t1":
+			reads++
+			if reads == 1 {
+				w.Write([]byte(`{"id":"t1","status":"processing"}`))
+				return
+			}
+			w.Write([]byte(`{"id":"t1","status":"completed","title":"A Talk"}`))
+		case "POST /api/v1/pdf/extract":
+			f, header, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("FormFile: %v", err)
+				return
+			}
+			content, _ := io.ReadAll(f)
+			if header.Filename != "scan.pdf" || string(content) != "%PDF-1.4" {
+				t.Errorf("uploaded %s = %q", header.Filename, content)
+			}
+			w.Write([]byte(`{"id":"p1","status":"completed","original_name":"scan.pdf"}`))
+		case "GET /api/v1/audio/transcriptions/a1/export":
+			w.Write([]byte("WEBVTT\n\n" + r.URL.Query().Get("format")))
+		case "GET /api/v1/search/text":
+			if r.URL.Query().Get("q") != "kubernetes" || r.URL.Query().Get("type") != "pdf" {
+				t.Errorf("search query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data":[{"type":"pdf","id":"p1","title":"scan.pdf"}],"total_items":3}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not_found","message":"Transcript not found","code":404}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCommands(t *testing.T) {
+	srv := fakeAPI(t)
+	dir := t.TempDir()
+	t.Setenv("MTA_CONFIG", filepath.Join(dir, "config.json"))
+	t.Setenv("MTA_SERVER", "")
+	t.Setenv("MTA_API_KEY", "")
+	pollInterval = 0
+
+	mta := func(stdin string, args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		err := run(args, strings.NewReader(stdin), &stdout, &stderr)
+		return stdout.String(), stderr.String(), err
+	}
+
+	// Commands need a key until login stores one
+	if _, _, err := mta("", "status", "t1"); err == nil || !strings.Contains(err.Error(), "mta login") {
+		t.Fatalf("status before login = %v, want a hint to log in", err)
+	}
+	if _, _, err := mta("mta_bad\n", "login", "-server", srv.URL); err == nil || !strings.Contains(err.Error(), "Invalid or inactive API key") {
+		t.Fatalf("login with a bad key = %v", err)
+	}
+	if _, _, err := mta("mta_good\n", "login", "-server", srv.URL+"/"); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "config.json"))
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("config file = %v, %v; want mode 0600", info, err)
+	}
+
+	out, _, err := mta("", "submit", "-wait", "https://youtu.be/abc")
+	if err != nil || out != "transcript\tt1\tcompleted\tA Talk\n" {
+		t.Errorf("submit -wait = %q, %v", out, err)
+	}
+
+	pdf := filepath.Join(dir, "scan.pdf")
+	os.WriteFile(pdf, []byte("%PDF-1.4"), 0o644)
+	out, _, err = mta("", "upload", "-json", pdf)
+	if err != nil || !strings.HasPrefix(out, `{"id":"p1"`) {
+		t.Errorf("upload -json = %q, %v", out, err)
+	}
+
+	out, _, err = mta("", "export", "-type", "audio", "-format", "vtt", "a1")
+	if err != nil || out != "WEBVTT\n\nvtt" {
+		t.Errorf("export = %q, %v", out, err)
+	}
+
+	out, notes, err := mta("", "search", "-type", "pdf", "kubernetes")
+	if err != nil || out != "pdf\tp1\tscan.pdf\n" || !strings.Contains(notes, "1 of 3") {
+		t.Errorf("search = %q (stderr %q), %v", out, notes, err)
+	}
+
+	if _, _, err := mta("", "status", "-type", "audio", "missing"); err == nil || !strings.Contains(err.Error(), "404 not_found") {
+		t.Errorf("status of a missing item = %v, want the API's error", err)
+	}
+	if _, _, err := mta("", "status", "-type", "video", "t1"); err == nil {
+		t.Error("status with an unknown -type succeeded")
+	}
+}
@@ -2,19 +2,35 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"github.com/Shimizu-Technology/media-tools-api/internal/config"
 	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/grpcapi"
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/router"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/billing"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/mailer"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/notion"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/oauth"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/settings"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/spend"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/storage"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/transcript"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/webhook"
@@ -25,17 +41,56 @@ import (
 var Version = "dev"
 
 func main() {
+	configFile := flag.String("config", "", "YAML or TOML config file; environment variables override it (default $CONFIG_FILE)")
+	validateConfig := flag.Bool("validate-config", false, "check the configuration, print the effective values, and exit")
+	flag.Parse()
+
+	// --validate-config checks a deployment's settings without starting anything
+	if *validateConfig {
+		cfg, err := config.Load(*configFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Invalid configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(cfg.Report())
+		fmt.Println("✅ Configuration is valid")
+		return
+	}
+
+	// "migrate ..." manages the schema and exits without starting the server
+	if flag.Arg(0) == "migrate" {
+		cfg, err := config.Load(*configFile)
+		if err != nil {
+			log.Fatalf("❌ Failed to load config: %v", err)
+		}
+		dbURL := cfg.DatabaseURL
+		if cfg.DatabaseURLDirect != "" {
+			dbURL = cfg.DatabaseURLDirect
+		}
+		db, err := database.New(dbURL)
+		if err != nil {
+			log.Fatalf("❌ Failed to connect to database: %v", err)
+		}
+		err = runMigrate(db, flag.Args()[1:])
+		db.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 	log.Printf("🚀 Media Tools API %s starting...", Version)
 
 	// Step 1: Load Configuration
-	cfg, err := config.Load()
+	cfg, err := config.Load(*configFile)
 	if err != nil {
 		log.Fatalf("❌ Failed to load config: %v", err)
 	}
 
 	log.Printf("📋 Config loaded: port=%s, workers=%d, gin_mode=%s", cfg.Port, cfg.WorkerCount, cfg.GinMode)
-	log.Printf("🔧 yt-dlp path: %s", cfg.YtDlpPath)
+	log.Printf("⚙️  Effective configuration:\n%s", cfg.Report())
 
 	os.Setenv("GIN_MODE", cfg.GinMode)
 
@@ -52,14 +107,31 @@ func main() {
 	defer db.Close()
 	log.Println("✅ Database connected")
 
+	if cfg.DatabaseReplicaURL != "" {
+		if err := db.ConnectReplica(cfg.DatabaseReplicaURL); err != nil {
+			log.Fatalf("❌ Failed to connect to read replica: %v", err)
+		}
+		log.Println("✅ Read replica connected (lists, search, exports, and stats read from it)")
+	}
+
 	// Run migrations
-	if err := db.RunMigrations("migrations"); err != nil {
+	if err := db.RunMigrations(migrationsPath); err != nil {
 		log.Fatalf("❌ Migration failed: %v", err)
 	}
 
 	// Step 3: Create Services
 	extractor := transcript.NewExtractor(cfg.YtDlpPath)
 	summarizer := summary.New(cfg.OpenRouterAPIKey, cfg.OpenRouterModel)
+	summarizer.SetMaxRetries(cfg.OpenRouterMaxRetries)
+	if len(cfg.OpenRouterFallbackModels) > 0 {
+		summarizer.SetFallbackModels(cfg.OpenRouterFallbackModels)
+		log.Printf("✅ LLM fallback chain: %s → %v", cfg.OpenRouterModel, cfg.OpenRouterFallbackModels)
+	}
+	summarizer.SetAllowedModels(cfg.OpenRouterAllowedModels)
+	if cfg.AICacheTTL > 0 {
+		summarizer.SetCache(db, cfg.AICacheTTL)
+		log.Printf("✅ Summary and chat completions cached for %s", cfg.AICacheTTL)
+	}
 
 	// Configure YouTube proxy if provided (residential proxy to bypass IP blocks)
 	if cfg.YouTubeProxy != "" {
@@ -79,15 +151,153 @@ func main() {
 	} else {
 		log.Println("⚠️  Audio transcription disabled (set OPENAI_API_KEY to enable)")
 	}
+	var audioPreprocessor *audio.Preprocessor
+	if cfg.AudioPreprocess != "" {
+		audioPreprocessor = audio.NewPreprocessor(cfg.FFmpegPath, cfg.AudioPreprocess)
+		log.Printf("✅ Audio preprocessing enabled (ffmpeg, %s)", cfg.AudioPreprocess)
+	}
 
 	// Webhook notification service (MTA-18)
 	webhookService := webhook.New(db)
 	log.Println("✅ Webhook notification service initialized")
+	if cfg.EncryptionKey != "" {
+		box, err := secretbox.New(cfg.EncryptionKey)
+		if err != nil {
+			log.Fatalf("❌ Invalid ENCRYPTION_KEY: %v", err)
+		}
+		webhookService.SetSecretBox(box)
+		db.SetSecretBox(box)
+		if n, err := db.SealIntegrationTokens(context.Background()); err != nil {
+			log.Fatalf("❌ Failed to encrypt integration tokens: %v", err)
+		} else if n > 0 {
+			log.Printf("✅ Encrypted %d integration tokens stored before encryption was added", n)
+		}
+		log.Println("✅ Webhook headers, basic auth, and integrations enabled")
+	} else {
+		log.Println("⚠️  Webhook headers, basic auth, and integrations disabled (set ENCRYPTION_KEY to enable)")
+	}
+
+	// Stripe billing — usage of keys linked to a customer is reported as
+	// meter events; keys are suspended while their subscription is unpaid
+	billingClient := billing.New(billing.Config{
+		SecretKey:     cfg.StripeSecretKey,
+		WebhookSecret: cfg.StripeWebhookSecret,
+		ReturnURL:     cmp.Or(cfg.StripePortalReturnURL, cfg.AppURL),
+	})
+	billingReporter := billing.NewReporter(db, billingClient)
+	if billingClient.IsConfigured() {
+		log.Println("✅ Stripe billing enabled (metered usage, customer portal)")
+	}
+
+	// AI spend accounting — per-key monthly limits, warned at 80%
+	spendTracker := spend.New(db, webhookService)
+	spendTracker.SetBillingReporter(billingReporter)
+	summarizer.SetSpendRecorder(spendTracker)
+	summarizer.SetBudgetModel(cfg.OpenRouterBudgetModel)
 
 	// Step 4: Create and Start Worker Pool
 	wp := worker.NewPool(cfg.WorkerCount, cfg.JobQueueSize, db, extractor, summarizer)
 	wp.SetWebhookService(webhookService) // MTA-18: wire webhooks into worker for job notifications
 	wp.SetAudioTranscriber(audioTranscriber) // Wire audio transcriber for async Whisper jobs
+	wp.SetAudioPreprocessor(audioPreprocessor)
+	wp.SetExportDir(cfg.ExportDir)
+	wp.SetNotionClient(notion.New()) // Notion steps of pipelines
+	wp.SetBillingReporter(billingReporter)
+	wp.SetJobTimeouts(map[worker.JobType]time.Duration{
+		worker.JobTranscriptExtraction: cfg.TranscriptJobTimeout,
+		worker.JobSummaryGeneration:    cfg.SummaryJobTimeout,
+		worker.JobAudioTranscription:   cfg.AudioJobTimeout,
+		worker.JobBulkExport:           cfg.ExportJobTimeout,
+	}, cfg.JobTimeoutMax)
+
+	// Original uploads — kept for reprocessing when a backend is configured
+	originals, err := storage.New(storage.Config{
+		Backend:         cfg.StorageBackend,
+		Dir:             cfg.StorageDir,
+		SigningKey:      cfg.JWTSecret,
+		Bucket:          cfg.S3Bucket,
+		Region:          cfg.S3Region,
+		Endpoint:        cfg.S3Endpoint,
+		AccessKeyID:     cfg.S3AccessKeyID,
+		SecretAccessKey: cfg.S3SecretAccessKey,
+		PathStyle:       cfg.S3PathStyle,
+	})
+	if err != nil {
+		log.Fatalf("❌ Failed to set up original storage: %v", err)
+	}
+	if originals != nil {
+		wp.SetOriginalStore(originals, cfg.OriginalsRetention)
+		log.Printf("✅ Original uploads kept in %s storage (retention: %s)", cfg.StorageBackend, cfg.OriginalsRetention)
+
+		// Offloaded transcript text lives alongside the originals. The store
+		// is set even with offloading off so earlier offloaded rows still load.
+		db.SetTextStore(originals, cfg.TextOffloadKB*1024)
+		if cfg.TextOffloadKB > 0 {
+			log.Printf("✅ Transcripts over %d KB kept in %s storage", cfg.TextOffloadKB, cfg.StorageBackend)
+		}
+
+		// Index bodies offloaded before search covered them, in the background
+		go func() {
+			if n, err := db.FillOffloadedVectors(context.Background()); err != nil {
+				log.Printf("⚠️  Failed to index offloaded transcripts: %v", err)
+			} else if n > 0 {
+				log.Printf("✅ Indexed %d offloaded transcripts for search", n)
+			}
+		}()
+	}
+	wp.SetPurgeInterval(cfg.RetentionPurgeInterval)
+	wp.SetDeletionGrace(cfg.AccountDeletionGrace)
+
+	// Watch folder — self-hosted installs process recordings dropped in a
+	// directory (a NAS share, say) without uploading them
+	if cfg.WatchDir != "" {
+		var watchKeyID *string
+		if cfg.WatchKeyID != "" {
+			watchKeyID = &cfg.WatchKeyID
+		}
+		wp.SetWatchFolder(cfg.WatchDir, cfg.WatchInterval, watchKeyID)
+		log.Printf("✅ Watching %s for new audio and PDFs every %s", cfg.WatchDir, cfg.WatchInterval)
+	}
+
+	// Runtime settings — configured values, overridden by admins through
+	// /api/v1/admin/settings. Loaded before the workers start so a stored
+	// worker count applies from the outset.
+	runtimeSettings := settings.New(db, settings.Values{
+		DefaultModel:         cfg.OpenRouterModel,
+		DefaultTier:          cfg.DefaultTier,
+		MaxKeysPerUser:       cfg.MaxKeysPerUser,
+		WorkerCount:          cfg.WorkerCount,
+		RequireVerifiedEmail: cfg.RequireVerifiedEmail,
+	})
+	runtimeSettings.OnChange(func(v settings.Values) {
+		summarizer.SetModel(v.DefaultModel)
+		wp.SetWorkerCount(v.WorkerCount)
+	})
+	if err := runtimeSettings.Reload(context.Background()); err != nil {
+		log.Printf("⚠️  Failed to load runtime settings: %v", err)
+	}
+	settingsCtx, stopSettingsWatch := context.WithCancel(context.Background())
+	defer stopSettingsWatch()
+	go runtimeSettings.Watch(settingsCtx, cfg.SettingsRefreshInterval)
+
+	// Email for verification links, password resets, and weekly digests
+	mail, err := mailer.New(mailer.Config{
+		Backend:  cfg.Mailer,
+		From:     cfg.MailFrom,
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+	})
+	if err != nil {
+		log.Fatalf("❌ Failed to set up mailer: %v", err)
+	}
+	if _, ok := mail.(mailer.LogMailer); ok {
+		log.Println("⚠️  Emails are printed to the log, not sent (set MAILER=smtp in production)")
+	}
+
+	wp.SetDigests(cfg.DigestInterval, mail)
+
 	wp.Start()
 	defer wp.Stop()
 
@@ -98,18 +308,57 @@ func main() {
 		log.Println("⚠️  No admin API key set (API key creation is open — set ADMIN_API_KEY in production)")
 	}
 
+	// OAuth sign-in — providers without a client ID stay disabled
+	oauthClient := oauth.New(oauth.Config{
+		GoogleClientID:     cfg.GoogleClientID,
+		GoogleClientSecret: cfg.GoogleClientSecret,
+		GitHubClientID:     cfg.GitHubClientID,
+		GitHubClientSecret: cfg.GitHubClientSecret,
+		RedirectBaseURL:    cfg.OAuthRedirectBaseURL,
+		SuccessRedirectURL: cfg.OAuthSuccessRedirect,
+	})
+	if providers := oauthClient.Providers(); len(providers) > 0 {
+		log.Printf("✅ OAuth sign-in enabled: %s", strings.Join(providers, ", "))
+	}
+
 	// Step 5: Setup HTTP Router
+	// One rate limiter for both REST and gRPC so a key's hourly budget is shared.
+	rateLimiter := middleware.NewRateLimiter(cfg.OwnerAPIKeyID, cfg.OwnerAPIKeyPrefix)
+
+	// CORS origins: the configured ones, plus any admins added at runtime,
+	// re-read periodically so every instance sees additions
+	corsOrigins, err := middleware.NewOriginMatcher(cfg.AllowedOrigins)
+	if err != nil {
+		log.Fatalf("❌ CORS_ORIGIN: %v", err)
+	}
+	if err := corsOrigins.Reload(context.Background(), db); err != nil {
+		log.Printf("⚠️  Failed to load CORS origins: %v", err)
+	}
+	corsCtx, stopCORSWatch := context.WithCancel(context.Background())
+	defer stopCORSWatch()
+	go corsOrigins.Watch(corsCtx, db, cfg.CORSRefreshInterval)
+
 	r := router.Setup(
 		db,
 		wp,
 		audioTranscriber,
 		webhookService,
 		summarizer,
+		oauthClient,
+		billingClient,
+		mail,
+		rateLimiter,
 		cfg.JWTSecret,
 		cfg.AdminAPIKey,
 		cfg.OwnerAPIKeyID,
 		cfg.OwnerAPIKeyPrefix,
-		cfg.AllowedOrigins,
+		cfg.AppURL,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+		runtimeSettings,
+		corsOrigins,
+		cfg.TrustedProxies,
+		cfg.CompressMinBytes,
 	)
 
 	// Step 6: Start the HTTP Server
@@ -130,6 +379,24 @@ func main() {
 		}
 	}()
 
+	// Optional gRPC server on its own port, serving plaintext HTTP/2 ("h2c")
+	// as gRPC clients expect by default.
+	var grpcSrv *grpc.Server
+	if cfg.GRPCPort != "" {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPCPort))
+		if err != nil {
+			log.Fatalf("❌ gRPC server failed: %v", err)
+		}
+		grpcSrv = grpcapi.New(db, wp, rateLimiter, cfg.OwnerAPIKeyID, cfg.OwnerAPIKeyPrefix)
+
+		go func() {
+			log.Printf("🔌 gRPC server listening on :%s", cfg.GRPCPort)
+			if err := grpcSrv.Serve(lis); err != nil {
+				log.Fatalf("❌ gRPC server failed: %v", err)
+			}
+		}()
+	}
+
 	// Step 7: Graceful Shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -147,6 +414,21 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("⚠️  Server forced to shutdown: %v", err)
 	}
+	if grpcSrv != nil {
+		// GracefulStop waits for open WatchTranscript streams, so cut them
+		// off once the shutdown deadline passes.
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcSrv.Stop()
+			log.Printf("⚠️  gRPC server forced to shutdown: %v", ctx.Err())
+		}
+	}
 
 	log.Println("👋 Server stopped. Goodbye!")
 }
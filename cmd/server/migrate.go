@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+)
+
+// migrationsPath is where the server looks for migration files, relative to
+// its working directory.
+const migrationsPath = "migrations"
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up             apply all pending migrations
+  down [N]       roll back the last N migrations (default 1)
+  version        print the applied version and whether it is dirty
+  force VERSION  set the version and clear the dirty flag without running
+                 any SQL (-1 for none); use after repairing a failed migration`
+
+// runMigrate implements the migrate subcommand, which manages the schema
+// explicitly instead of at startup.
+func runMigrate(db *database.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch cmd, rest := args[0], args[1:]; cmd {
+	case "up":
+		if len(rest) != 0 {
+			return errors.New(migrateUsage)
+		}
+		return db.RunMigrations(migrationsPath)
+
+	case "down":
+		steps := 1
+		switch len(rest) {
+		case 0:
+		case 1:
+			n, err := strconv.Atoi(rest[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("down: N must be a positive number, got %q", rest[0])
+			}
+			steps = n
+		default:
+			return errors.New(migrateUsage)
+		}
+		if err := db.RollbackMigrations(migrationsPath, steps); err != nil {
+			return err
+		}
+		return printMigrationVersion(db)
+
+	case "version":
+		if len(rest) != 0 {
+			return errors.New(migrateUsage)
+		}
+		return printMigrationVersion(db)
+
+	case "force":
+		if len(rest) != 1 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.Atoi(rest[0])
+		if err != nil {
+			return fmt.Errorf("force: VERSION must be a number, got %q", rest[0])
+		}
+		if err := db.ForceMigrationVersion(migrationsPath, version); err != nil {
+			return err
+		}
+		return printMigrationVersion(db)
+
+	default:
+		return fmt.Errorf("unknown migrate command %q\n\n%s", cmd, migrateUsage)
+	}
+}
+
+// printMigrationVersion prints the schema's current migration version.
+func printMigrationVersion(db *database.DB) error {
+	version, dirty, err := db.MigrationVersion(migrationsPath)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		fmt.Println("📦 No migrations applied")
+		return nil
+	}
+	fmt.Printf("📦 Schema version %d (dirty: %v)\n", version, dirty)
+	return nil
+}
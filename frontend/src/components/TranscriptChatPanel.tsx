@@ -135,7 +135,7 @@ export function TranscriptChatPanel({ itemId, itemType }: TranscriptChatPanelPro
                       border: isUser ? 'none' : '1px solid var(--color-border)',
                     }}
                   >
-                    <MarkdownMessage content={m.content} isUser={isUser} />
+                    <MarkdownMessage content={m.linked_content || m.content} isUser={isUser} />
                   </div>
                 </motion.div>
               );
@@ -23,10 +23,24 @@ export interface Transcript {
   word_count: number;
   status: 'pending' | 'processing' | 'completed' | 'failed';
   error_message?: string;
+  notes: string;
+  tags?: string[];
+  edited_at?: string;
+  usage?: ProcessingUsage;
   created_at: string;
   updated_at: string;
 }
 
+// What processing an item took; absent until it's processed
+export interface ProcessingUsage {
+  processing_ms: number;
+  whisper_minutes?: number;
+  summary_ms?: number;
+  prompt_tokens?: number;
+  completion_tokens?: number;
+  cost_usd?: number;
+}
+
 export interface Summary {
   id: string;
   transcript_id: string;
@@ -36,6 +50,8 @@ export interface Summary {
   key_points: string[];
   length: string;
   style: string;
+  stale: boolean; // transcript was corrected after this was generated
+  usage?: ProcessingUsage;
   created_at: string;
 }
 
@@ -58,6 +74,14 @@ export interface ChatMessage {
   content: string;
   model_used?: string;
   created_at: string;
+  citations?: ChatCitation[]; // Timestamps cited in answers about a YouTube transcript
+  linked_content?: string; // content with each citation linked to that moment of the video
+}
+
+export interface ChatCitation {
+  text: string; // e.g. "[12:34]"
+  seconds: number;
+  url: string;
 }
 
 export interface ChatResponse {
@@ -163,20 +187,37 @@ export interface Batch {
 export interface BatchResponse {
   batch: Batch;
   transcripts: Transcript[];
+  audio_transcriptions?: AudioTranscription[];
+  pdf_extractions?: PDFExtraction[];
+}
+
+export interface UploadBatchOptions {
+  redact?: boolean;
+  tables?: boolean;
+  markdown?: boolean;
 }
 
 export type AudioContentType = 'general' | 'phone_call' | 'meeting' | 'voice_memo' | 'interview' | 'lecture';
 
+export interface SpeakerSummary {
+  speaker: string;
+  summary: string;
+  commitments: string[];
+  questions: string[];
+}
+
 export interface AudioTranscription {
   id: string;
   filename: string;
   original_name: string;
+  title?: string;
   duration: number;
   language: string;
   transcript_text: string;
   word_count: number;
   status: 'pending' | 'processing' | 'completed' | 'failed';
   error_message?: string;
+  batch_id?: string;
   content_type: AudioContentType;
   summary_text?: string;
   key_points: string[];
@@ -184,23 +225,75 @@ export interface AudioTranscription {
   decisions: string[];
   summary_model?: string;
   summary_status: 'none' | 'processing' | 'completed' | 'failed';
+  speaker_summaries?: SpeakerSummary[];
+  notes: string;
+  tags?: string[];
+  metadata?: Record<string, string>;
+  usage?: ProcessingUsage;
   created_at: string;
+  updated_at: string;
 }
 
 export interface PDFExtraction {
   id: string;
   filename: string;
   original_name: string;
+  title?: string;
   page_count: number;
+  first_page?: number;
+  last_page?: number;
+  source_type: 'pdf' | 'epub' | 'article';
+  source_url?: string;
+  table_count: number;
+  document_info?: PDFDocumentInfo;
+  outline?: PDFOutlineItem[];
   text_content: string;
+  markdown_content?: string;
   word_count: number;
-  status: 'completed' | 'failed';
+  status: 'pending' | 'processing' | 'completed' | 'failed';
   error_message?: string;
+  batch_id?: string;
+  notes: string;
+  tags?: string[];
+  usage?: ProcessingUsage;
   created_at: string;
+  updated_at: string;
+}
+
+export interface PDFDocumentInfo {
+  title?: string;
+  author?: string;
+  subject?: string;
+  keywords?: string;
+  creator?: string;
+  producer?: string;
+  created_at?: string;
+  modified_at?: string;
+}
+
+export interface PDFOutlineItem {
+  title: string;
+  page?: number;
+  children?: PDFOutlineItem[];
+}
+
+export interface PDFTable {
+  page: number;
+  rows: string[][];
+}
+
+export interface PDFPage {
+  extraction_id: string;
+  page: number;
+  page_count: number;
+  text: string;
+  word_count: number;
 }
 
 export interface AuthResponse {
   token: string;
+  refresh_token?: string;
+  expires_in?: number;
   user: { id: string; email: string; name: string; created_at: string };
 }
 
@@ -308,12 +401,14 @@ export async function listTranscripts(params?: {
   per_page?: number;
   status?: string;
   search?: string;
+  include?: 'text'; // Lists omit transcript_text unless requested
 }): Promise<PaginatedResponse<Transcript>> {
   const searchParams = new URLSearchParams();
   if (params?.page) searchParams.set('page', String(params.page));
   if (params?.per_page) searchParams.set('per_page', String(params.per_page));
   if (params?.status) searchParams.set('status', params.status);
   if (params?.search) searchParams.set('search', params.search);
+  if (params?.include) searchParams.set('include', params.include);
   const res = await fetch(`${API_BASE}/transcripts?${searchParams}`, { headers: getHeaders() });
   return handleResponse<PaginatedResponse<Transcript>>(res);
 }
@@ -351,6 +446,27 @@ export async function sendChatMessage(
   return handleResponse<ChatResponse>(res);
 }
 
+export interface ItemMetadataUpdate {
+  title?: string;
+  notes?: string;
+  tags?: string[];
+  content_type?: AudioContentType; // audio only
+}
+
+const itemPaths = { transcript: 'transcripts', audio: 'audio/transcriptions', pdf: 'pdf/extractions' } as const;
+
+// Omitted fields are left unchanged; tags replaces the whole list.
+export async function updateItemMetadata<T>(
+  itemType: keyof typeof itemPaths,
+  id: string,
+  update: ItemMetadataUpdate,
+): Promise<T> {
+  const res = await fetch(`${API_BASE}/${itemPaths[itemType]}/${id}`, {
+    method: 'PATCH', headers: getHeaders(), body: JSON.stringify(update),
+  });
+  return handleResponse<T>(res);
+}
+
 export async function deleteTranscript(id: string): Promise<void> {
   const res = await fetch(`${API_BASE}/transcripts/${id}`, { method: 'DELETE', headers: getHeaders() });
   if (!res.ok && res.status !== 404) {
@@ -361,6 +477,236 @@ export async function deleteTranscript(id: string): Promise<void> {
   }
 }
 
+export interface DuplicateGroup {
+  youtube_id: string;
+  matched_by: ('youtube_id' | 'content_hash')[];
+  keep_id: string; // The oldest completed transcript
+  transcripts: Transcript[]; // Without their text
+}
+
+// Copies of the same video, by youtube_id or identical extracted text.
+export async function getDuplicateTranscripts(): Promise<{ groups: DuplicateGroup[] }> {
+  const res = await fetch(`${API_BASE}/transcripts/duplicates`, { headers: getHeaders() });
+  return handleResponse<{ groups: DuplicateGroup[] }>(res);
+}
+
+// Moves the duplicates' summaries and chats to keepId, then deletes them.
+export async function mergeTranscripts(
+  keepId: string,
+  duplicateIds: string[],
+): Promise<{ transcript: Transcript; merged_ids: string[] }> {
+  const res = await fetch(`${API_BASE}/transcripts/${keepId}/merge`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify({ duplicate_ids: duplicateIds }),
+  });
+  return handleResponse<{ transcript: Transcript; merged_ids: string[] }>(res);
+}
+
+export interface KeyMoment {
+  seconds: number;
+  timestamp: string;
+  title: string;
+  description: string;
+  url: string;
+}
+
+// Finds a video's most important moments, linked to where they start.
+export async function getKeyMoments(
+  transcriptId: string,
+  count = 5,
+): Promise<{ transcript_id: string; moments: KeyMoment[]; approximate: boolean; model: string }> {
+  const res = await fetch(`${API_BASE}/transcripts/${transcriptId}/moments`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify({ count }),
+  });
+  return handleResponse<{ transcript_id: string; moments: KeyMoment[]; approximate: boolean; model: string }>(res);
+}
+
+export interface VideoChapters {
+  transcript_id: string;
+  chapters: { seconds: number; timestamp: string; title: string }[];
+  chapters_text: string;
+  description: string;
+  hashtags: string[];
+  text: string;
+  approximate: boolean;
+  model: string;
+}
+
+// Writes a YouTube chapter list and description, ready to paste.
+export async function getVideoChapters(transcriptId: string): Promise<VideoChapters> {
+  const res = await fetch(`${API_BASE}/transcripts/${transcriptId}/chapters`, {
+    method: 'POST', headers: getHeaders(),
+  });
+  return handleResponse<VideoChapters>(res);
+}
+
+export interface SocialPost {
+  platform: 'twitter' | 'linkedin' | 'newsletter';
+  parts: string[];
+  text: string;
+  characters: number;
+  limit: number;
+}
+
+// Repurposes an item's summary into social posts.
+export async function createSocialPosts(
+  itemType: 'transcript' | 'audio',
+  id: string,
+  options: { platforms?: SocialPost['platform'][]; tone?: string } = {},
+): Promise<{ tone: string; posts: SocialPost[]; model: string }> {
+  const path = itemType === 'audio' ? `audio/transcriptions/${id}` : `transcripts/${id}`;
+  const res = await fetch(`${API_BASE}/${path}/social`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify(options),
+  });
+  return handleResponse<{ tone: string; posts: SocialPost[]; model: string }>(res);
+}
+
+export interface Article {
+  id: string;
+  transcript_id: string;
+  model_used: string;
+  content: {
+    title_options: string[];
+    introduction: string;
+    sections: { heading: string; paragraphs: string[] }[];
+    pull_quotes: string[];
+    conclusion: string;
+  };
+  stale: boolean;
+  created_at: string;
+}
+
+// Writes and stores a long-form article draft from a transcript.
+export async function createArticle(transcriptId: string): Promise<Article> {
+  const res = await fetch(`${API_BASE}/transcripts/${transcriptId}/article`, {
+    method: 'POST', headers: getHeaders(),
+  });
+  return handleResponse<Article>(res);
+}
+
+export interface Comparison {
+  sources: { number: number; item_type: string; item_id: string; title: string }[];
+  overview: string;
+  common_themes: { theme: string; description: string; sources: number[] }[];
+  contradictions: { topic: string; positions: { source: number; position: string }[] }[];
+  unique_points: { source: number; points: string[] }[];
+  model: string;
+}
+
+// Compares 2-5 items: shared themes, contradictions, and unique points.
+export async function compareItems(
+  items: { item_type: 'transcript' | 'audio' | 'pdf'; item_id: string }[],
+): Promise<Comparison> {
+  const res = await fetch(`${API_BASE}/analysis/compare`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify({ items }),
+  });
+  return handleResponse<Comparison>(res);
+}
+
+export type EntityType = 'person' | 'company' | 'product' | 'place';
+
+export interface Entity {
+  id: string;
+  name: string;
+  type: EntityType;
+  item_count: number;
+  mention_count: number;
+}
+
+export interface EntityMention {
+  item_type: 'transcript' | 'audio' | 'pdf';
+  item_id: string;
+  title: string;
+  mentions: number;
+  context: string;
+  created_at: string;
+}
+
+export interface ItemEntities {
+  item_type: 'transcript' | 'audio' | 'pdf';
+  item_id: string;
+  entities: { entity_id: string; name: string; type: EntityType; mentions: number; context: string }[];
+  model_used: string;
+}
+
+// Finds the people, companies, products, and places an item mentions,
+// replacing the ones found before.
+export async function extractEntities(itemType: ChatItemType, itemId: string): Promise<ItemEntities> {
+  const path = itemType === 'audio' ? 'audio/transcriptions' : itemType === 'pdf' ? 'pdf/extractions' : 'transcripts';
+  const res = await fetch(`${API_BASE}/${path}/${itemId}/entities`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify({}),
+  });
+  return handleResponse<ItemEntities>(res);
+}
+
+export async function listEntities(params?: {
+  type?: EntityType;
+  search?: string;
+  page?: number;
+  per_page?: number;
+}): Promise<PaginatedResponse<Entity>> {
+  const searchParams = new URLSearchParams();
+  if (params?.type) searchParams.set('type', params.type);
+  if (params?.search) searchParams.set('search', params.search);
+  if (params?.page) searchParams.set('page', String(params.page));
+  if (params?.per_page) searchParams.set('per_page', String(params.per_page));
+  const res = await fetch(`${API_BASE}/entities?${searchParams}`, { headers: getHeaders() });
+  return handleResponse<PaginatedResponse<Entity>>(res);
+}
+
+export async function listEntityMentions(entityId: string, page = 1): Promise<PaginatedResponse<EntityMention>> {
+  const res = await fetch(`${API_BASE}/entities/${entityId}/mentions?page=${page}`, { headers: getHeaders() });
+  return handleResponse<PaginatedResponse<EntityMention>>(res);
+}
+
+export interface Digest {
+  id: string;
+  user_id: string;
+  period_start: string;
+  period_end: string;
+  item_count: number;
+  model_used: string;
+  content: {
+    overview: string;
+    items: { number: number; item_type: string; item_id: string; title: string; created_at: string }[];
+    themes: { theme: string; description: string; items: number[] }[];
+    action_items: { item: number; task: string }[];
+    quotes: { quote: string; item: number }[];
+  };
+  delivered_at?: string;
+  created_at: string;
+}
+
+export interface DigestSettings {
+  enabled: boolean;
+  email: boolean;
+  webhook: boolean;
+}
+
+export async function getDigestSettings(): Promise<DigestSettings> {
+  const res = await fetch(`${API_BASE}/me/digest`, { headers: getHeaders() });
+  return handleResponse<DigestSettings>(res);
+}
+
+export async function updateDigestSettings(settings: DigestSettings): Promise<DigestSettings> {
+  const res = await fetch(`${API_BASE}/me/digest`, {
+    method: 'PUT', headers: getHeaders(), body: JSON.stringify(settings),
+  });
+  return handleResponse<DigestSettings>(res);
+}
+
+export async function listDigests(): Promise<Digest[]> {
+  const res = await fetch(`${API_BASE}/me/digests`, { headers: getHeaders() });
+  return handleResponse<Digest[]>(res);
+}
+
+// Builds and stores a digest of the past `days` (default 7) without sending it.
+export async function createDigest(days?: number): Promise<Digest> {
+  const res = await fetch(`${API_BASE}/me/digests`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify(days ? { days } : {}),
+  });
+  return handleResponse<Digest>(res);
+}
+
 // ── Summaries ──
 
 export async function createSummary(
@@ -391,6 +737,25 @@ export async function createBatch(urls: string[]): Promise<BatchResponse> {
   return handleResponse<BatchResponse>(res);
 }
 
+export async function uploadBatch(files: File[], options?: UploadBatchOptions): Promise<BatchResponse> {
+  const formData = new FormData();
+  files.forEach((file) => formData.append('files', file));
+  if (options?.redact) formData.append('redact', 'true');
+  if (options?.tables) formData.append('tables', 'true');
+  if (options?.markdown) formData.append('markdown', 'true');
+  const res = await fetch(`${API_BASE}/batches/upload`, {
+    method: 'POST', headers: getUploadHeaders(), body: formData,
+  });
+  return handleResponse<BatchResponse>(res);
+}
+
+export async function uploadBatchFromURLs(urls: string[], options?: UploadBatchOptions): Promise<BatchResponse> {
+  const res = await fetch(`${API_BASE}/batches/upload`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify({ urls, ...options }),
+  });
+  return handleResponse<BatchResponse>(res);
+}
+
 export async function getBatch(batchId: string): Promise<BatchResponse> {
   const res = await fetch(`${API_BASE}/batches/${batchId}`, { headers: getHeaders() });
   return handleResponse<BatchResponse>(res);
@@ -408,6 +773,13 @@ export async function downloadExport(transcriptId: string, format: ExportFormat)
   return res.blob();
 }
 
+// A chat conversation (ChatResponse.session.id) with its item's metadata.
+export async function downloadChatExport(sessionId: string, format: 'md' | 'json' | 'txt' = 'md'): Promise<Blob> {
+  const res = await fetch(`${API_BASE}/chat/sessions/${sessionId}/export?format=${format}`, { headers: getHeaders() });
+  if (!res.ok) throw new Error(`Export failed: ${res.statusText}`);
+  return res.blob();
+}
+
 // ── LocalStorage History ──
 
 const HISTORY_KEY = 'mta_transcript_ids';
@@ -436,9 +808,16 @@ export function removeTranscriptsFromHistory(idsToRemove: string[]): void {
 
 // ── Audio Transcription (MTA-16) ──
 
-export async function transcribeAudio(file: File): Promise<AudioTranscription> {
+export async function transcribeAudio(file: File, details?: {
+  title?: string;
+  notes?: string;
+  metadata?: Record<string, string>;
+}): Promise<AudioTranscription> {
   const formData = new FormData();
   formData.append('file', file);
+  if (details?.title) formData.append('title', details.title);
+  if (details?.notes) formData.append('notes', details.notes);
+  if (details?.metadata) formData.append('metadata', JSON.stringify(details.metadata));
   const res = await fetch(`${API_BASE}/audio/transcribe`, {
     method: 'POST', headers: getUploadHeaders(), body: formData,
   });
@@ -450,9 +829,25 @@ export async function getAudioTranscription(id: string): Promise<AudioTranscript
   return handleResponse<AudioTranscription>(res);
 }
 
-export async function listAudioTranscriptions(): Promise<AudioTranscription[]> {
-  const res = await fetch(`${API_BASE}/audio/transcriptions`, { headers: getHeaders() });
-  return handleResponse<AudioTranscription[]>(res);
+export async function listAudioTranscriptions(params?: {
+  page?: number;
+  per_page?: number;
+  status?: string;
+  content_type?: string;
+  search?: string;
+  sort_by?: 'created_at' | 'duration' | 'word_count';
+  sort_dir?: 'asc' | 'desc';
+}): Promise<PaginatedResponse<AudioTranscription>> {
+  const searchParams = new URLSearchParams();
+  if (params?.page) searchParams.set('page', String(params.page));
+  if (params?.per_page) searchParams.set('per_page', String(params.per_page));
+  if (params?.status) searchParams.set('status', params.status);
+  if (params?.content_type) searchParams.set('content_type', params.content_type);
+  if (params?.search) searchParams.set('search', params.search);
+  if (params?.sort_by) searchParams.set('sort_by', params.sort_by);
+  if (params?.sort_dir) searchParams.set('sort_dir', params.sort_dir);
+  const res = await fetch(`${API_BASE}/audio/transcriptions?${searchParams}`, { headers: getHeaders() });
+  return handleResponse<PaginatedResponse<AudioTranscription>>(res);
 }
 
 export async function deleteAudioTranscription(id: string): Promise<void> {
@@ -478,20 +873,120 @@ export async function summarizeAudio(
   return handleResponse<AudioTranscription>(res);
 }
 
-// MTA-25: Search audio transcriptions
+// MTA-25: Search audio transcriptions. With q, results are best match first
+// and carry a rank, match count, and snippet (HTML-escaped, matches in <mark>).
+export interface AudioSearchResult extends AudioTranscription {
+  rank?: number;
+  snippet?: string;
+  match_count?: number;
+}
+
 export async function searchAudioTranscriptions(params?: {
   q?: string;
   content_type?: string;
   page?: number;
   per_page?: number;
-}): Promise<PaginatedResponse<AudioTranscription>> {
+}): Promise<PaginatedResponse<AudioSearchResult>> {
   const searchParams = new URLSearchParams();
   if (params?.q) searchParams.set('q', params.q);
   if (params?.content_type) searchParams.set('content_type', params.content_type);
   if (params?.page) searchParams.set('page', String(params.page));
   if (params?.per_page) searchParams.set('per_page', String(params.per_page));
   const res = await fetch(`${API_BASE}/audio/transcriptions/search?${searchParams}`, { headers: getHeaders() });
-  return handleResponse<PaginatedResponse<AudioTranscription>>(res);
+  return handleResponse<PaginatedResponse<AudioSearchResult>>(res);
+}
+
+// Full-text search across transcripts, audio, and PDFs
+export interface SearchResult {
+  type: 'transcript' | 'audio' | 'pdf';
+  id: string;
+  title: string;
+  status: string;
+  rank: number;
+  snippet: string; // HTML-escaped, matches wrapped in <mark>
+  created_at: string;
+}
+
+export async function searchText(params: {
+  q: string;
+  type?: string;
+  page?: number;
+  per_page?: number;
+}): Promise<PaginatedResponse<SearchResult>> {
+  const searchParams = new URLSearchParams({ q: params.q });
+  if (params.type) searchParams.set('type', params.type);
+  if (params.page) searchParams.set('page', String(params.page));
+  if (params.per_page) searchParams.set('per_page', String(params.per_page));
+  const res = await fetch(`${API_BASE}/search/text?${searchParams}`, { headers: getHeaders() });
+  return handleResponse<PaginatedResponse<SearchResult>>(res);
+}
+
+// Saved searches; notify sends search.matched webhooks for new matches
+export interface SavedSearch {
+  id: string;
+  api_key_id: string;
+  name: string;
+  query: string;
+  types: Array<'transcript' | 'audio' | 'pdf'>;
+  notify: boolean;
+  last_matched_at?: string;
+  created_at: string;
+}
+
+export async function createSavedSearch(search: {
+  name: string;
+  query: string;
+  types?: Array<'transcript' | 'audio' | 'pdf'>;
+  notify?: boolean;
+}): Promise<SavedSearch> {
+  const res = await fetch(`${API_BASE}/searches`, {
+    method: 'POST',
+    headers: getHeaders(),
+    body: JSON.stringify(search),
+  });
+  return handleResponse<SavedSearch>(res);
+}
+
+export async function listSavedSearches(): Promise<SavedSearch[]> {
+  const res = await fetch(`${API_BASE}/searches`, { headers: getHeaders() });
+  return handleResponse<SavedSearch[]>(res);
+}
+
+export async function runSavedSearch(id: string, page = 1): Promise<PaginatedResponse<SearchResult>> {
+  const res = await fetch(`${API_BASE}/searches/${id}/results?page=${page}`, { headers: getHeaders() });
+  return handleResponse<PaginatedResponse<SearchResult>>(res);
+}
+
+export async function deleteSavedSearch(id: string): Promise<void> {
+  const res = await fetch(`${API_BASE}/searches/${id}`, { method: 'DELETE', headers: getHeaders() });
+  await handleResponse<{ message: string }>(res);
+}
+
+// Usage stats for the dashboard
+export interface StatsTotals {
+  items: number;
+  completed: number;
+  failed: number;
+  failure_rate: number;
+  minutes_transcribed: number;
+  words_extracted: number;
+  avg_processing_seconds: number | null;
+}
+
+export interface UsageStats {
+  date_from: string;
+  date_to: string;
+  totals: StatsTotals;
+  by_type: Record<'transcript' | 'audio' | 'pdf', StatsTotals>;
+  daily: Array<StatsTotals & { date: string }>;
+}
+
+export async function getStats(params?: { date_from?: string; date_to?: string }): Promise<UsageStats> {
+  const searchParams = new URLSearchParams();
+  if (params?.date_from) searchParams.set('date_from', params.date_from);
+  if (params?.date_to) searchParams.set('date_to', params.date_to);
+  const res = await fetch(`${API_BASE}/stats?${searchParams}`, { headers: getHeaders() });
+  return handleResponse<UsageStats>(res);
 }
 
 // MTA-26: Export audio transcription
@@ -507,23 +1002,68 @@ export async function downloadAudioExport(id: string, format: 'txt' | 'md' | 'js
 
 // ── PDF Extraction (MTA-17) ──
 
-export async function extractPDF(file: File): Promise<PDFExtraction> {
+export async function extractPDF(file: File, options?: { pages?: string; tables?: boolean; markdown?: boolean }): Promise<PDFExtraction> {
   const formData = new FormData();
   formData.append('file', file);
+  if (options?.pages) formData.append('pages', options.pages);
+  if (options?.tables) formData.append('tables', 'true');
+  if (options?.markdown) formData.append('markdown', 'true');
   const res = await fetch(`${API_BASE}/pdf/extract`, {
     method: 'POST', headers: getUploadHeaders(), body: formData,
   });
   return handleResponse<PDFExtraction>(res);
 }
 
+export async function extractPDFFromURL(url: string, options?: { pages?: string; tables?: boolean; markdown?: boolean }): Promise<PDFExtraction> {
+  const res = await fetch(`${API_BASE}/pdf/extract-url`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify({ url, ...options }),
+  });
+  return handleResponse<PDFExtraction>(res);
+}
+
+export async function extractArticle(url: string): Promise<PDFExtraction> {
+  const res = await fetch(`${API_BASE}/articles/extract`, {
+    method: 'POST', headers: getHeaders(), body: JSON.stringify({ url }),
+  });
+  return handleResponse<PDFExtraction>(res);
+}
+
 export async function getPDFExtraction(id: string): Promise<PDFExtraction> {
   const res = await fetch(`${API_BASE}/pdf/extractions/${id}`, { headers: getHeaders() });
   return handleResponse<PDFExtraction>(res);
 }
 
-export async function listPDFExtractions(): Promise<PDFExtraction[]> {
-  const res = await fetch(`${API_BASE}/pdf/extractions`, { headers: getHeaders() });
-  return handleResponse<PDFExtraction[]>(res);
+export async function getPDFPage(id: string, page: number): Promise<PDFPage> {
+  const res = await fetch(`${API_BASE}/pdf/extractions/${id}/pages/${page}`, { headers: getHeaders() });
+  return handleResponse<PDFPage>(res);
+}
+
+export async function getPDFTables(id: string): Promise<PDFTable[]> {
+  const res = await fetch(`${API_BASE}/pdf/extractions/${id}/tables`, { headers: getHeaders() });
+  return handleResponse<PDFTable[]>(res);
+}
+
+export function getPDFTableCSVUrl(id: string, table: number): string {
+  return `${API_BASE}/pdf/extractions/${id}/tables?format=csv&table=${table}`;
+}
+
+export async function listPDFExtractions(params?: {
+  page?: number;
+  per_page?: number;
+  status?: string;
+  search?: string;
+  sort_by?: 'created_at' | 'page_count' | 'word_count';
+  sort_dir?: 'asc' | 'desc';
+}): Promise<PaginatedResponse<PDFExtraction>> {
+  const searchParams = new URLSearchParams();
+  if (params?.page) searchParams.set('page', String(params.page));
+  if (params?.per_page) searchParams.set('per_page', String(params.per_page));
+  if (params?.status) searchParams.set('status', params.status);
+  if (params?.search) searchParams.set('search', params.search);
+  if (params?.sort_by) searchParams.set('sort_by', params.sort_by);
+  if (params?.sort_dir) searchParams.set('sort_dir', params.sort_dir);
+  const res = await fetch(`${API_BASE}/pdf/extractions?${searchParams}`, { headers: getHeaders() });
+  return handleResponse<PaginatedResponse<PDFExtraction>>(res);
 }
 
 export async function deletePDFExtraction(id: string): Promise<void> {
@@ -556,14 +1096,35 @@ export async function login(email: string, password: string): Promise<AuthRespon
   return handleResponse<AuthResponse>(res);
 }
 
-export async function refreshToken(): Promise<AuthResponse> {
+export async function refreshToken(token: string): Promise<AuthResponse> {
   const res = await fetch(`${API_BASE}/auth/refresh`, {
     method: 'POST',
-    headers: getHeaders(),
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ refresh_token: token }),
   });
   return handleResponse<AuthResponse>(res);
 }
 
+export interface UserSession {
+  id: string;
+  user_agent: string;
+  ip_address: string;
+  created_at: string;
+  last_used_at: string;
+  expires_at: string;
+  current: boolean;
+}
+
+export async function listSessions(): Promise<UserSession[]> {
+  const res = await fetch(`${API_BASE}/auth/sessions`, { headers: getHeaders() });
+  return handleResponse<UserSession[]>(res);
+}
+
+export async function revokeSession(id: string): Promise<void> {
+  const res = await fetch(`${API_BASE}/auth/sessions/${id}`, { method: 'DELETE', headers: getHeaders() });
+  await handleResponse<{ message: string }>(res);
+}
+
 // ── Workspace (MTA-20) ──
 
 export async function getWorkspace(): Promise<WorkspaceResponse> {
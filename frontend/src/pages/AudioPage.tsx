@@ -306,8 +306,8 @@ export function AudioPage() {
   const loadHistory = async () => {
     setHistoryLoading(true);
     try {
-      const items = await listAudioTranscriptions();
-      setHistoryItems(items);
+      const result = await listAudioTranscriptions({ per_page: 50 });
+      setHistoryItems(result.data);
     } catch {
       setError('Failed to load history.');
     }
@@ -319,9 +319,14 @@ export function AudioPage() {
     setShowHistory(!showHistory);
   };
 
-  const loadFromHistory = (item: AudioTranscription) => {
-    setResult(item);
-    setShowHistory(false);
+  const loadFromHistory = async (item: AudioTranscription) => {
+    // History rows omit the transcript text, so load the full record
+    try {
+      setResult(await getAudioTranscription(item.id));
+      setShowHistory(false);
+    } catch {
+      setError('Transcription not found');
+    }
   };
 
   // ── Reset ──
@@ -19,6 +19,7 @@ import {
 } from 'lucide-react';
 import {
   listTranscripts,
+  getTranscript,
   getStoredTranscriptIds,
   removeTranscriptsFromHistory,
   type Transcript,
@@ -121,8 +122,18 @@ export function HistoryPage() {
     setIsDeleting(false);
   };
 
-  const handleExportSelected = () => {
-    const selected = sortedTranscripts.filter((t) => selectedIds.has(t.id));
+  const handleExportSelected = async () => {
+    // The list omits transcript text, so fetch each selected transcript in full
+    let selected: Transcript[];
+    try {
+      selected = await Promise.all(
+        sortedTranscripts.filter((t) => selectedIds.has(t.id)).map((t) => getTranscript(t.id)),
+      );
+    } catch (err: unknown) {
+      const apiErr = err as { message?: string };
+      setError(apiErr.message || 'Failed to load transcripts for export');
+      return;
+    }
     const exportData = selected.map((t) => ({
       title: t.title,
       channel: t.channel_name,
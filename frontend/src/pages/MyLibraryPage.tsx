@@ -118,8 +118,17 @@ export function MyLibraryPage() {
       }
       
       if (activeTab === 'all' || activeTab === 'audio') {
-        const audioResult = await listAudioTranscriptions();
-        audioResult.forEach((a) => {
+        const audioResult = await listAudioTranscriptions({
+          page: activeTab === 'audio' ? page : 1,
+          per_page: activeTab === 'audio' ? perPage : 50,
+          search: searchQuery || undefined,
+        });
+        
+        if (activeTab === 'audio') {
+          setTotalPages(audioResult.total_pages);
+        }
+        
+        audioResult.data.forEach((a) => {
           unified.push({
             id: a.id,
             type: 'audio',
@@ -135,8 +144,17 @@ export function MyLibraryPage() {
       }
       
       if (activeTab === 'all' || activeTab === 'pdf') {
-        const pdfResult = await listPDFExtractions();
-        pdfResult.forEach((p) => {
+        const pdfResult = await listPDFExtractions({
+          page: activeTab === 'pdf' ? page : 1,
+          per_page: activeTab === 'pdf' ? perPage : 50,
+          search: searchQuery || undefined,
+        });
+        
+        if (activeTab === 'pdf') {
+          setTotalPages(pdfResult.total_pages);
+        }
+        
+        pdfResult.data.forEach((p) => {
           unified.push({
             id: p.id,
             type: 'pdf',
@@ -178,8 +196,8 @@ export function MyLibraryPage() {
   const filteredItems = useMemo(() => {
     let result = [...items];
     
-    // Filter by search (client-side for audio/pdf, server-side for youtube)
-    if (searchInput && activeTab !== 'youtube') {
+    // Filter by search (client-side for the combined view, server-side per tab)
+    if (searchInput && activeTab === 'all') {
       const q = searchInput.toLowerCase();
       result = result.filter(
         (item) =>
@@ -659,8 +677,8 @@ export function MyLibraryPage() {
         </motion.div>
       )}
 
-      {/* Pagination (only for YouTube when filtered) */}
-      {!isLoading && activeTab === 'youtube' && totalPages > 1 && (
+      {/* Pagination (only when filtered to one type) */}
+      {!isLoading && activeTab !== 'all' && totalPages > 1 && (
         <motion.div
           initial={{ opacity: 0 }}
           animate={{ opacity: 1 }}
@@ -59,8 +59,8 @@ export function PdfPage() {
 
   const validateFile = (f: File): string | null => {
     const ext = '.' + f.name.split('.').pop()?.toLowerCase();
-    if (ext !== '.pdf') {
-      return `Unsupported format "${ext}". Only PDF files are accepted.`;
+    if (ext !== '.pdf' && ext !== '.epub') {
+      return `Unsupported format "${ext}". Only PDF and EPUB files are accepted.`;
     }
     if (f.size > maxSizeMB * 1024 * 1024) {
       return `File too large (${(f.size / 1024 / 1024).toFixed(1)}MB). Max: ${maxSizeMB}MB`;
@@ -233,7 +233,7 @@ export function PdfPage() {
             <input
               ref={inputRef}
               type="file"
-              accept=".pdf"
+              accept=".pdf,.epub"
               onChange={(e) => {
                 const f = e.target.files?.[0];
                 if (f) handleFile(f);
@@ -15,6 +15,7 @@ const ALL_EVENTS = [
   'audio.completed', 'audio.failed',
   'pdf.completed', 'pdf.failed',
   'batch.completed',
+  'search.matched',
 ];
 
 /**
@@ -1,7 +1,8 @@
 /**
  * Auth store using Zustand (MTA-20).
- * Manages JWT token, user state, and auth persistence via localStorage.
- * Includes automatic token refresh to maintain sessions.
+ * Manages the access token (JWT), refresh token, user state, and auth
+ * persistence via localStorage. Access tokens are short-lived, so they're
+ * renewed with the refresh token, which rotates on every use.
  */
 import { create } from 'zustand';
 
@@ -17,7 +18,7 @@ interface AuthState {
   user: User | null;
   isAuthenticated: boolean;
   isLoading: boolean;
-  login: (token: string, user: User) => void;
+  login: (token: string, user: User, refreshToken?: string) => void;
   logout: () => void;
   setLoading: (loading: boolean) => void;
   initialize: () => Promise<void>;
@@ -27,7 +28,8 @@ interface AuthState {
 }
 
 const TOKEN_KEY = 'mta_jwt_token';
-const REFRESH_INTERVAL_MS = 60 * 60 * 1000; // Refresh every hour
+const REFRESH_TOKEN_KEY = 'mta_refresh_token';
+const REFRESH_INTERVAL_MS = 10 * 60 * 1000; // Access tokens last 15 minutes
 
 let refreshIntervalId: ReturnType<typeof setInterval> | null = null;
 
@@ -37,8 +39,11 @@ export const useAuthStore = create<AuthState>((set, get) => ({
   isAuthenticated: false,
   isLoading: true,
 
-  login: (token: string, user: User) => {
+  login: (token: string, user: User, refreshToken?: string) => {
     localStorage.setItem(TOKEN_KEY, token);
+    if (refreshToken) {
+      localStorage.setItem(REFRESH_TOKEN_KEY, refreshToken);
+    }
     set({ token, user, isAuthenticated: true });
     // Start token refresh when logging in
     get().startTokenRefresh();
@@ -46,7 +51,17 @@ export const useAuthStore = create<AuthState>((set, get) => ({
 
   logout: () => {
     get().stopTokenRefresh();
+    // Revoke the session server-side so the tokens can't be reused
+    const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+    if (refreshToken) {
+      fetch('/api/v1/auth/logout', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ refresh_token: refreshToken }),
+      }).catch(() => {});
+    }
     localStorage.removeItem(TOKEN_KEY);
+    localStorage.removeItem(REFRESH_TOKEN_KEY);
     set({ token: null, user: null, isAuthenticated: false });
   },
 
@@ -69,8 +84,12 @@ export const useAuthStore = create<AuthState>((set, get) => ({
         set({ token, user, isAuthenticated: true, isLoading: false });
         // Start token refresh for existing sessions
         get().startTokenRefresh();
+      } else if (await get().refreshToken()) {
+        // Access token expired — the refresh token renewed it
+        set({ isAuthenticated: true, isLoading: false });
+        get().startTokenRefresh();
       } else {
-        // Token expired or invalid
+        // Session expired or revoked
         localStorage.removeItem(TOKEN_KEY);
         set({ isLoading: false });
       }
@@ -80,21 +99,20 @@ export const useAuthStore = create<AuthState>((set, get) => ({
   },
 
   refreshToken: async () => {
-    const currentToken = get().token || localStorage.getItem(TOKEN_KEY);
-    if (!currentToken) return false;
+    const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+    if (!refreshToken) return false;
 
     try {
       const res = await fetch('/api/v1/auth/refresh', {
         method: 'POST',
-        headers: { 
-          'Content-Type': 'application/json',
-          Authorization: `Bearer ${currentToken}`,
-        },
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ refresh_token: refreshToken }),
       });
 
       if (res.ok) {
         const data = await res.json();
         localStorage.setItem(TOKEN_KEY, data.token);
+        localStorage.setItem(REFRESH_TOKEN_KEY, data.refresh_token);
         set({ token: data.token, user: data.user });
         return true;
       } else {
@@ -0,0 +1,79 @@
+// file.go reads optional YAML or TOML config files. Keys are the settings'
+// environment variable names, in any case:
+//
+//	port: 8080
+//	cors_origin: [https://app.example.com, "https://*.preview.example.com"]
+//	job_timeout_transcript: 45m
+//
+// Lists become comma-separated values, as in the environment.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// readConfigFile parses a config file into raw values by upper-case key.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("config file %s: use a .yaml, .yml, or .toml file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, v := range raw {
+		name := strings.ToUpper(key)
+		if _, dup := values[name]; dup {
+			return nil, fmt.Errorf("config file %s: %q is set more than once", path, key)
+		}
+		value, err := fileValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %s: %w", path, key, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// fileValue turns a parsed value into the string its environment variable
+// would hold.
+func fileValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			part, err := fileValue(item)
+			if err != nil || strings.Contains(part, ",") {
+				return "", fmt.Errorf("list items must be plain values without commas")
+			}
+			parts[i] = part
+		}
+		return strings.Join(parts, ","), nil
+	case map[string]interface{}:
+		return "", fmt.Errorf("nested settings aren't supported; use flat keys like smtp_host")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,60 @@
+// report.go describes the effective configuration, with secrets masked, so
+// operators can see what the server is actually running with.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+)
+
+// Where a setting's value came from.
+const (
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// Setting is one configuration value and where it came from.
+type Setting struct {
+	Name   string // Environment variable name
+	Value  string // Raw value, before parsing
+	Source string // SourceEnv, SourceFile, or SourceDefault
+	Secret bool   // Masked in reports
+}
+
+// Settings returns every setting in load order.
+func (c *Config) Settings() []Setting {
+	return c.settings
+}
+
+// Report renders the effective settings as a table, one per line. Secrets
+// show only whether they're set, and passwords in URLs are redacted.
+func (c *Config) Report() string {
+	var b strings.Builder
+	if c.ConfigFile != "" {
+		fmt.Fprintf(&b, "config file: %s\n", c.ConfigFile)
+	}
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, s := range c.settings {
+		fmt.Fprintf(w, "%s\t%s\t(%s)\n", s.Name, s.maskedValue(), s.Source)
+	}
+	w.Flush()
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// maskedValue is the value as it's safe to print.
+func (s Setting) maskedValue() string {
+	switch {
+	case s.Value == "":
+		return `""`
+	case s.Secret:
+		return "********"
+	case strings.Contains(s.Value, "://"):
+		if u, err := url.Parse(s.Value); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+	}
+	return s.Value
+}
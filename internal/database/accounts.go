@@ -0,0 +1,228 @@
+// accounts.go contains database operations for account data exports and
+// account erasure.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ownedByUser matches rows a user owns directly or through one of their
+// API keys. $1 is the user ID.
+var ownedByUser = userScope("$1")
+
+// userScope is ownedByUser with the user ID at any placeholder.
+func userScope(placeholder string) string {
+	return fmt.Sprintf(`(user_id = %[1]s OR api_key_id IN (SELECT id FROM api_keys WHERE user_id = %[1]s))`, placeholder)
+}
+
+// callerScope returns the condition limiting rows to what a caller may
+// list, and its argument: an API key's rows (see keyScope) or, without a
+// key, a user's (see userScope). It returns "" if neither is set.
+func callerScope(apiKeyID, userID *string, placeholder string) (string, interface{}) {
+	switch {
+	case apiKeyID != nil:
+		return keyScope(placeholder), *apiKeyID
+	case userID != nil:
+		return userScope(placeholder), *userID
+	}
+	return "", nil
+}
+
+// ListUserAPIKeys returns the API keys linked to a user, oldest first.
+func (db *DB) ListUserAPIKeys(ctx context.Context, userID string) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := db.SelectContext(ctx, &keys,
+		`SELECT * FROM api_keys WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// ListAccountItems returns every transcript, audio transcription, and PDF
+// extraction a user owns, in any status, oldest first.
+func (db *DB) ListAccountItems(ctx context.Context, userID string) ([]models.ExportItem, error) {
+	query := fmt.Sprintf(`
+		SELECT type, id FROM (
+			SELECT 'transcript' AS type, id, created_at FROM transcripts WHERE %[1]s
+			UNION ALL
+			SELECT 'audio', id, created_at FROM audio_transcriptions WHERE %[1]s
+			UNION ALL
+			SELECT 'pdf', id, created_at FROM pdf_extractions WHERE %[1]s
+		) items
+		ORDER BY created_at`, ownedByUser)
+
+	var items []models.ExportItem
+	if err := db.reader().SelectContext(ctx, &items, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list account items: %w", err)
+	}
+	return items, nil
+}
+
+// ListAccountChats returns the chat sessions a user holds, directly or
+// with their API keys, each with its full message history.
+func (db *DB) ListAccountChats(ctx context.Context, userID string) ([]models.AccountChat, error) {
+	var sessions []models.TranscriptChatSession
+	err := db.SelectContext(ctx, &sessions, `
+		SELECT * FROM transcript_chat_sessions
+		WHERE user_id = $1 OR api_key_id IN (SELECT id FROM api_keys WHERE user_id = $1)
+		ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account chats: %w", err)
+	}
+
+	chats := make([]models.AccountChat, 0, len(sessions))
+	for _, s := range sessions {
+		chat := models.AccountChat{TranscriptChatSession: s, Messages: []models.TranscriptChatMessage{}}
+		err := db.SelectContext(ctx, &chat.Messages,
+			`SELECT * FROM transcript_chat_messages WHERE session_id = $1 ORDER BY created_at`, s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chat messages: %w", err)
+		}
+		chats = append(chats, chat)
+	}
+	return chats, nil
+}
+
+// ScheduleUserDeletion schedules a user's account for erasure at the given
+// time. If erasure is already scheduled, the existing time is kept.
+// Returns the scheduled time.
+func (db *DB) ScheduleUserDeletion(ctx context.Context, userID string, at time.Time) (time.Time, error) {
+	var scheduled time.Time
+	err := db.QueryRowContext(ctx, `
+		UPDATE users SET deletion_scheduled_at = COALESCE(deletion_scheduled_at, $2)
+		WHERE id = $1
+		RETURNING deletion_scheduled_at`, userID, at).Scan(&scheduled)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+	return scheduled, nil
+}
+
+// CancelUserDeletion clears a pending account erasure. Returns false if
+// none was scheduled.
+func (db *DB) CancelUserDeletion(ctx context.Context, userID string) (bool, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE users SET deletion_scheduled_at = NULL
+		WHERE id = $1 AND deletion_scheduled_at IS NOT NULL`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ListUsersDueForDeletion returns up to limit users whose scheduled
+// erasure time has passed.
+func (db *DB) ListUsersDueForDeletion(ctx context.Context, limit int) ([]string, error) {
+	var ids []string
+	err := db.SelectContext(ctx, &ids, `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= NOW()
+		ORDER BY deletion_scheduled_at
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts due for deletion: %w", err)
+	}
+	return ids, nil
+}
+
+// EraseUser permanently deletes a user and everything they own: their
+// items (and, by cascade, summaries and other generated data), chats,
+// exports, purge log entries, API keys (with their webhooks, shares, and
+// saved searches), and the user record itself. It runs in one transaction
+// and only if the user's erasure is still due, so a cancellation that
+// lands first wins. Returns nil if there was nothing to erase.
+//
+// Stored originals and export archives live outside the database; their
+// keys and paths are returned for the caller to remove. Offloaded text is
+// removed from the text store here, once the erasure commits.
+func (db *DB) EraseUser(ctx context.Context, userID string) (*models.AccountErasure, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start account erasure: %w", err)
+	}
+	defer tx.Rollback()
+
+	var due bool
+	err = tx.GetContext(ctx, &due, `
+		SELECT deletion_scheduled_at <= NOW() FROM users
+		WHERE id = $1 AND deletion_scheduled_at IS NOT NULL
+		FOR UPDATE`, userID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !due) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock user for erasure: %w", err)
+	}
+
+	erasure := &models.AccountErasure{UserID: userID}
+	err = tx.SelectContext(ctx, &erasure.Originals, fmt.Sprintf(`
+		SELECT filename FROM audio_transcriptions WHERE original_stored_at IS NOT NULL AND %[1]s
+		UNION ALL
+		SELECT filename FROM pdf_extractions WHERE original_stored_at IS NOT NULL AND %[1]s`, ownedByUser), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list originals for erasure: %w", err)
+	}
+	var textKeys []*string
+	err = tx.SelectContext(ctx, &textKeys, fmt.Sprintf(`
+		SELECT text_key FROM transcripts WHERE text_key IS NOT NULL AND %[1]s
+		UNION ALL
+		SELECT original_text_key FROM transcripts WHERE original_text_key IS NOT NULL AND %[1]s
+		UNION ALL
+		SELECT text_key FROM audio_transcriptions WHERE text_key IS NOT NULL AND %[1]s`, ownedByUser), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offloaded text for erasure: %w", err)
+	}
+	err = tx.SelectContext(ctx, &erasure.ExportFiles, fmt.Sprintf(`
+		SELECT file_path FROM exports WHERE file_path <> '' AND %s`, ownedByUser), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exports for erasure: %w", err)
+	}
+
+	// Chats on the user's items or held by them or their keys. Sessions on audio
+	// and PDFs don't cascade from the item, so they go first.
+	statements := []string{
+		fmt.Sprintf(`
+			DELETE FROM transcript_chat_sessions
+			WHERE user_id = $1 OR api_key_id IN (SELECT id FROM api_keys WHERE user_id = $1)
+				OR item_id IN (
+					SELECT id FROM transcripts WHERE %[1]s
+					UNION ALL SELECT id FROM audio_transcriptions WHERE %[1]s
+					UNION ALL SELECT id FROM pdf_extractions WHERE %[1]s
+				)`, ownedByUser),
+		fmt.Sprintf(`DELETE FROM exports WHERE %s`, ownedByUser),
+		fmt.Sprintf(`DELETE FROM purges WHERE %s`, ownedByUser),
+	}
+	for _, query := range statements {
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return nil, fmt.Errorf("failed to erase account data: %w", err)
+		}
+	}
+	for _, table := range []string{"transcripts", "audio_transcriptions", "pdf_extractions"} {
+		result, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s`, table, ownedByUser), userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase %s: %w", table, err)
+		}
+		n, _ := result.RowsAffected()
+		erasure.Items += int(n)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM api_keys WHERE user_id = $1`, userID); err != nil {
+		return nil, fmt.Errorf("failed to erase API keys: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID); err != nil {
+		return nil, fmt.Errorf("failed to erase user: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit account erasure: %w", err)
+	}
+	db.deleteText(ctx, textKeys...)
+	return erasure, nil
+}
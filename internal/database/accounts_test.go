@@ -0,0 +1,66 @@
+//go:build cgo
+
+// accounts_test.go contains tests for scoping lists to a logged-in user.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestListsForUsers verifies lists for a user hold only their own items and
+// those of keys linked to them.
+func TestListsForUsers(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	ada := &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	if err := db.CreateUser(ctx, ada); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	adaKey := &models.APIKey{KeyHash: "hash-ada", KeyPrefix: "mta_ada", Name: "ada", Active: true, Tier: models.TierFree, Role: "member"}
+	otherKey := &models.APIKey{KeyHash: "hash-other", KeyPrefix: "mta_other", Name: "other", Active: true, Tier: models.TierFree, Role: "member"}
+	for _, k := range []*models.APIKey{adaKey, otherKey} {
+		if err := db.CreateAPIKey(ctx, k); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+	}
+	if err := db.LinkAPIKeyToUser(ctx, adaKey.ID, ada.ID); err != nil {
+		t.Fatalf("LinkAPIKeyToUser: %v", err)
+	}
+
+	create := func(id string, apiKeyID *string) string {
+		tr := &models.Transcript{YouTubeURL: "https://youtu.be/" + id, YouTubeID: id, Title: id, Status: models.StatusCompleted, APIKeyID: apiKeyID}
+		if err := db.CreateTranscript(ctx, tr); err != nil {
+			t.Fatalf("CreateTranscript: %v", err)
+		}
+		return tr.ID
+	}
+	adaOwn := create("ada-own", nil)
+	if _, err := db.ExecContext(ctx, `UPDATE transcripts SET user_id = $1 WHERE id = $2`, ada.ID, adaOwn); err != nil {
+		t.Fatalf("set user_id: %v", err)
+	}
+	adaByKey := create("ada-key", &adaKey.ID)
+	create("other-key", &otherKey.ID)
+	create("unowned", nil)
+
+	want := map[string]bool{adaOwn: true, adaByKey: true}
+
+	transcripts, total, err := db.ListTranscripts(ctx, models.TranscriptListParams{UserID: &ada.ID})
+	if err != nil {
+		t.Fatalf("ListTranscripts: %v", err)
+	}
+	if total != 2 || len(transcripts) != 2 || !want[transcripts[0].ID] || !want[transcripts[1].ID] {
+		t.Errorf("ListTranscripts for a user = %d rows (total %d); want only %s and %s", len(transcripts), total, adaOwn, adaByKey)
+	}
+
+	items, total, err := db.ListItems(ctx, models.ItemListParams{UserID: &ada.ID}, nil)
+	if err != nil {
+		t.Fatalf("ListItems: %v", err)
+	}
+	if total != 2 || len(items) != 2 || !want[items[0].ID] || !want[items[1].ID] {
+		t.Errorf("ListItems for a user = %d rows (total %d); want only %s and %s", len(items), total, adaOwn, adaByKey)
+	}
+}
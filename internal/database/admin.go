@@ -0,0 +1,149 @@
+// admin.go contains system-wide queries for the admin endpoints. Unlike the
+// rest of the package, none of these are scoped to an API key.
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// adminItemSources maps item types to the query that projects each table
+// onto the columns admin reports use. Failed transcripts often never got a
+// title, so they fall back to the URL.
+var adminItemSources = map[string]string{
+	"transcript": "SELECT 'transcript' AS type, id, COALESCE(NULLIF(title, ''), youtube_url) AS title, status, error_message, word_count, api_key_id, created_at FROM transcripts",
+	"audio":      "SELECT 'audio' AS type, id, COALESCE(NULLIF(title, ''), original_name) AS title, status, error_message, word_count, api_key_id, created_at FROM audio_transcriptions",
+	"pdf":        "SELECT 'pdf' AS type, id, COALESCE(NULLIF(title, ''), original_name) AS title, status, error_message, word_count, api_key_id, created_at FROM pdf_extractions",
+}
+
+// adminItems returns the union of all item tables for admin queries.
+func adminItems() string {
+	var parts []string
+	for _, t := range itemTypes {
+		parts = append(parts, adminItemSources[t])
+	}
+	return strings.Join(parts, " UNION ALL ")
+}
+
+// CountItemsByStatus returns item counts by type and status across all keys.
+// Every type is present, even with no items.
+func (db *DB) CountItemsByStatus(ctx context.Context) (map[string]map[string]int, error) {
+	var rows []struct {
+		Type   string `db:"type"`
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	query := fmt.Sprintf("SELECT type, status, COUNT(*) AS count FROM (%s) i GROUP BY type, status", adminItems())
+	if err := db.reader().SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	counts := make(map[string]map[string]int, len(itemTypes))
+	for _, t := range itemTypes {
+		counts[t] = map[string]int{}
+	}
+	for _, r := range rows {
+		counts[r.Type][r.Status] = r.Count
+	}
+	return counts, nil
+}
+
+// ListAPIKeyVolumes returns every API key with its item volume, busiest in
+// the last 30 days first.
+func (db *DB) ListAPIKeyVolumes(ctx context.Context) ([]models.APIKeyVolume, error) {
+	query := fmt.Sprintf(`
+		SELECT k.id, k.name, k.key_prefix, k.active, k.tier, k.last_used_at,
+			COALESCE(v.items, 0) AS items, COALESCE(v.failed, 0) AS failed,
+			COALESCE(v.items_30d, 0) AS items_30d, COALESCE(v.words, 0) AS words
+		FROM api_keys k
+		LEFT JOIN (
+			SELECT api_key_id,
+				COUNT(*) AS items,
+				COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+				COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days') AS items_30d,
+				SUM(word_count) FILTER (WHERE status = 'completed') AS words
+			FROM (%s) i
+			WHERE api_key_id IS NOT NULL
+			GROUP BY api_key_id
+		) v ON v.api_key_id = k.id
+		ORDER BY items_30d DESC, items DESC, k.created_at`, adminItems())
+
+	var volumes []models.APIKeyVolume
+	if err := db.reader().SelectContext(ctx, &volumes, query); err != nil {
+		return nil, fmt.Errorf("failed to list API key volumes: %w", err)
+	}
+	return volumes, nil
+}
+
+// ListRecentFailures returns the most recently submitted failed items.
+func (db *DB) ListRecentFailures(ctx context.Context, limit int) ([]models.FailedItem, error) {
+	query := fmt.Sprintf(`
+		SELECT type, id, title, error_message, api_key_id, created_at
+		FROM (%s) i
+		WHERE status = 'failed'
+		ORDER BY created_at DESC, id
+		LIMIT $1`, adminItems())
+
+	var failures []models.FailedItem
+	if err := db.SelectContext(ctx, &failures, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list failures: %w", err)
+	}
+	return failures, nil
+}
+
+// ListWebhookHealth returns every webhook with its delivery outcomes over
+// the last 24 hours, those with the most failures first.
+func (db *DB) ListWebhookHealth(ctx context.Context) ([]models.WebhookHealth, error) {
+	query := `
+		SELECT w.id, w.api_key_id, w.url, w.active,
+			COUNT(d.id) FILTER (WHERE d.status = 'success') AS delivered,
+			COUNT(d.id) FILTER (WHERE d.status = 'failed') AS failed,
+			COUNT(d.id) FILTER (WHERE d.status = 'pending') AS pending,
+			MAX(d.delivered_at) AS last_delivered_at,
+			(SELECT last_error FROM webhook_deliveries
+				WHERE webhook_id = w.id AND status = 'failed'
+				ORDER BY created_at DESC LIMIT 1) AS last_error
+		FROM webhooks w
+		LEFT JOIN webhook_deliveries d
+			ON d.webhook_id = w.id AND d.created_at >= NOW() - INTERVAL '24 hours'
+		GROUP BY w.id
+		ORDER BY failed DESC, w.created_at DESC`
+
+	var health []models.WebhookHealth
+	if err := db.SelectContext(ctx, &health, query); err != nil {
+		return nil, fmt.Errorf("failed to list webhook health: %w", err)
+	}
+	return health, nil
+}
+
+// ListChatFeedbackStats returns answer counts and ratings for chat answers
+// given since then, by model and item type, most answers first.
+func (db *DB) ListChatFeedbackStats(ctx context.Context, since time.Time) ([]models.ChatFeedbackStats, error) {
+	query := `
+		SELECT m.model_used AS model, s.item_type,
+			COUNT(*) AS answers,
+			COUNT(m.feedback) AS rated,
+			COUNT(*) FILTER (WHERE m.feedback = 'up') AS up,
+			COUNT(*) FILTER (WHERE m.feedback = 'down') AS down
+		FROM transcript_chat_messages m
+		JOIN transcript_chat_sessions s ON s.id = m.session_id
+		WHERE m.role = 'assistant' AND m.created_at >= $1
+		GROUP BY m.model_used, s.item_type
+		ORDER BY answers DESC, model, s.item_type`
+
+	var stats []models.ChatFeedbackStats
+	if err := db.reader().SelectContext(ctx, &stats, query, since); err != nil {
+		return nil, fmt.Errorf("failed to list chat feedback stats: %w", err)
+	}
+	for i := range stats {
+		if stats[i].Rated > 0 {
+			approval := float64(stats[i].Up) / float64(stats[i].Rated)
+			stats[i].Approval = &approval
+		}
+	}
+	return stats, nil
+}
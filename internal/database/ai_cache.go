@@ -0,0 +1,47 @@
+// ai_cache.go stores AI completions so identical requests are answered
+// without calling OpenRouter again.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetCachedCompletion returns the unexpired completion cached under key
+// and the model that served it. ok is false when there isn't one.
+func (db *DB) GetCachedCompletion(ctx context.Context, key string) (content, model string, ok bool, err error) {
+	var row struct {
+		Content string `db:"content"`
+		Model   string `db:"model"`
+	}
+	err = db.GetContext(ctx, &row, `
+		SELECT content, model FROM ai_cache WHERE key = $1 AND expires_at > NOW()`, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to read cached completion: %w", err)
+	}
+	return row.Content, row.Model, true, nil
+}
+
+// CacheCompletion caches a completion under key for ttl, replacing any
+// earlier one, and prunes completions that have expired.
+func (db *DB) CacheCompletion(ctx context.Context, key, content, model string, ttl time.Duration) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO ai_cache (key, content, model, expires_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE SET
+			content = EXCLUDED.content, model = EXCLUDED.model,
+			created_at = NOW(), expires_at = EXCLUDED.expires_at`,
+		key, content, model, time.Now().Add(ttl))
+	if err != nil {
+		return fmt.Errorf("failed to cache completion: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM ai_cache WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to prune cached completions: %w", err)
+	}
+	return nil
+}
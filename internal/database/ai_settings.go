@@ -0,0 +1,107 @@
+// ai_settings.go stores per-key and per-user defaults for AI requests.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// aiSettingsOwners are the ai_settings columns a row can belong to.
+var aiSettingsOwners = map[string]bool{"api_key_id": true, "user_id": true}
+
+// GetKeyAISettings returns an API key's AI defaults, empty if it has none.
+func (db *DB) GetKeyAISettings(ctx context.Context, keyID string) (models.AISettings, error) {
+	return db.getAISettings(ctx, "api_key_id", keyID)
+}
+
+// GetUserAISettings returns a user's AI defaults, empty if they have none.
+func (db *DB) GetUserAISettings(ctx context.Context, userID string) (models.AISettings, error) {
+	return db.getAISettings(ctx, "user_id", userID)
+}
+
+// SaveKeyAISettings replaces an API key's AI defaults.
+func (db *DB) SaveKeyAISettings(ctx context.Context, keyID string, s models.AISettings) error {
+	return db.saveAISettings(ctx, "api_key_id", keyID, s)
+}
+
+// SaveUserAISettings replaces a user's AI defaults.
+func (db *DB) SaveUserAISettings(ctx context.Context, userID string, s models.AISettings) error {
+	return db.saveAISettings(ctx, "user_id", userID, s)
+}
+
+// ResolveAISettings returns the AI defaults for a request made with keyID
+// by userID, either of which may be nil: each of the key's settings, or
+// failing that the user's.
+func (db *DB) ResolveAISettings(ctx context.Context, keyID, userID *string) (models.AISettings, error) {
+	var rows []struct {
+		models.AISettings
+		APIKeyID *string `db:"api_key_id"`
+	}
+	err := db.SelectContext(ctx, &rows, `
+		SELECT api_key_id, model, length, style, language FROM ai_settings
+		WHERE api_key_id = $1 OR user_id = $2`, keyID, userID)
+	if err != nil {
+		return models.AISettings{}, fmt.Errorf("failed to load AI settings: %w", err)
+	}
+
+	var keySettings, userSettings models.AISettings
+	for _, r := range rows {
+		if r.APIKeyID != nil {
+			keySettings = r.AISettings
+		} else {
+			userSettings = r.AISettings
+		}
+	}
+	return models.AISettings{
+		Model:    firstNonEmpty(keySettings.Model, userSettings.Model),
+		Length:   firstNonEmpty(keySettings.Length, userSettings.Length),
+		Style:    firstNonEmpty(keySettings.Style, userSettings.Style),
+		Language: firstNonEmpty(keySettings.Language, userSettings.Language),
+	}, nil
+}
+
+func (db *DB) getAISettings(ctx context.Context, owner, id string) (models.AISettings, error) {
+	if !aiSettingsOwners[owner] {
+		return models.AISettings{}, fmt.Errorf("unknown AI settings owner %q", owner)
+	}
+	var s models.AISettings
+	err := db.GetContext(ctx, &s, fmt.Sprintf(
+		`SELECT model, length, style, language FROM ai_settings WHERE %s = $1`, owner), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.AISettings{}, nil
+	}
+	if err != nil {
+		return models.AISettings{}, fmt.Errorf("failed to load AI settings: %w", err)
+	}
+	return s, nil
+}
+
+func (db *DB) saveAISettings(ctx context.Context, owner, id string, s models.AISettings) error {
+	if !aiSettingsOwners[owner] {
+		return fmt.Errorf("unknown AI settings owner %q", owner)
+	}
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO ai_settings (%[1]s, model, length, style, language) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (%[1]s) DO UPDATE SET
+			model = EXCLUDED.model, length = EXCLUDED.length, style = EXCLUDED.style,
+			language = EXCLUDED.language, updated_at = NOW()`, owner),
+		id, s.Model, s.Length, s.Style, s.Language)
+	if err != nil {
+		return fmt.Errorf("failed to save AI settings: %w", err)
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first of values that isn't empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
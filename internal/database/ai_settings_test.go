@@ -0,0 +1,54 @@
+//go:build cgo
+
+// ai_settings_test.go contains tests for per-key and per-user AI defaults,
+// run against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestResolveAISettings verifies a key's settings win field by field over
+// its user's, and that saving replaces a row.
+func TestResolveAISettings(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	user := &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	if err := db.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	key := &models.APIKey{KeyHash: "hash", KeyPrefix: "mta_abc", Name: "dev", Active: true, Tier: models.TierFree, Role: "member", UserID: &user.ID}
+	if err := db.CreateAPIKey(ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+
+	if got, err := db.ResolveAISettings(ctx, &key.ID, &user.ID); err != nil || got != (models.AISettings{}) {
+		t.Fatalf("ResolveAISettings with none saved = %+v, %v", got, err)
+	}
+
+	if err := db.SaveUserAISettings(ctx, user.ID, models.AISettings{Model: "user-model", Length: "short", Language: "French"}); err != nil {
+		t.Fatalf("SaveUserAISettings: %v", err)
+	}
+	if err := db.SaveKeyAISettings(ctx, key.ID, models.AISettings{Model: "key-model", Style: "narrative"}); err != nil {
+		t.Fatalf("SaveKeyAISettings: %v", err)
+	}
+	if err := db.SaveKeyAISettings(ctx, key.ID, models.AISettings{Model: "key-model-2", Style: "academic"}); err != nil {
+		t.Fatalf("SaveKeyAISettings again: %v", err)
+	}
+
+	want := models.AISettings{Model: "key-model-2", Length: "short", Style: "academic", Language: "French"}
+	if got, err := db.ResolveAISettings(ctx, &key.ID, &user.ID); err != nil || got != want {
+		t.Errorf("ResolveAISettings = %+v, %v; want %+v", got, err, want)
+	}
+	want = models.AISettings{Model: "user-model", Length: "short", Language: "French"}
+	if got, err := db.ResolveAISettings(ctx, nil, &user.ID); err != nil || got != want {
+		t.Errorf("ResolveAISettings for the user = %+v, %v; want %+v", got, err, want)
+	}
+	if got, err := db.GetKeyAISettings(ctx, key.ID); err != nil || got.Model != "key-model-2" || got.Length != "" {
+		t.Errorf("GetKeyAISettings = %+v, %v", got, err)
+	}
+}
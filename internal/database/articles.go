@@ -0,0 +1,33 @@
+// articles.go contains database operations for article drafts.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CreateArticle inserts a newly generated article draft.
+func (db *DB) CreateArticle(ctx context.Context, a *models.Article) error {
+	query := `
+		INSERT INTO articles (transcript_id, api_key_id, model_used, content)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		a.TranscriptID, a.APIKeyID, a.ModelUsed, a.Content,
+	).Scan(&a.ID, &a.CreatedAt)
+}
+
+// GetLatestArticle returns the most recent article draft for a transcript.
+// Returns sql.ErrNoRows (wrapped) if none has been generated yet.
+func (db *DB) GetLatestArticle(ctx context.Context, transcriptID string) (*models.Article, error) {
+	var a models.Article
+	err := db.GetContext(ctx, &a,
+		`SELECT * FROM articles WHERE transcript_id = $1 ORDER BY created_at DESC LIMIT 1`, transcriptID)
+	if err != nil {
+		return nil, fmt.Errorf("article not found: %w", err)
+	}
+	return &a, nil
+}
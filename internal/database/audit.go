@@ -0,0 +1,57 @@
+// audit.go contains database operations for the audit log of admin keys
+// acting on other keys' data.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// RecordAudit adds an entry to the audit log.
+func (db *DB) RecordAudit(ctx context.Context, e *models.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_api_key_id, target_api_key_id, action, method, path, ip)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := db.QueryRowContext(ctx, query,
+		e.ActorAPIKeyID, e.TargetAPIKeyID, e.Action, e.Method, e.Path, e.IP,
+	).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditLog returns audit log entries matching params, newest first.
+func (db *DB) ListAuditLog(ctx context.Context, params models.AuditLogParams) ([]models.AuditEntry, error) {
+	query := `SELECT * FROM audit_log WHERE 1=1`
+	var args []interface{}
+	argIdx := 1
+
+	if params.ActorAPIKeyID != "" {
+		query += fmt.Sprintf(" AND actor_api_key_id = $%d", argIdx)
+		args = append(args, params.ActorAPIKeyID)
+		argIdx++
+	}
+	if params.TargetAPIKeyID != "" {
+		query += fmt.Sprintf(" AND target_api_key_id = $%d", argIdx)
+		args = append(args, params.TargetAPIKeyID)
+		argIdx++
+	}
+	if params.Action != "" {
+		query += fmt.Sprintf(" AND action = $%d", argIdx)
+		args = append(args, params.Action)
+		argIdx++
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", argIdx)
+	args = append(args, params.Limit)
+
+	var entries []models.AuditEntry
+	if err := db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,58 @@
+//go:build cgo
+
+// audit_test.go contains tests for the audit log, run against an in-memory
+// SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestAuditLog verifies entries are recorded, filtered, and listed newest
+// first, and that GetAPIKeyByID skips revoked keys.
+func TestAuditLog(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	admin := &models.APIKey{KeyHash: "hash-1", KeyPrefix: "mta_adm", Name: "admin", Active: true, Tier: models.TierFree, Role: "admin"}
+	member := &models.APIKey{KeyHash: "hash-2", KeyPrefix: "mta_mem", Name: "member", Active: true, Tier: models.TierFree, Role: "member"}
+	for _, key := range []*models.APIKey{admin, member} {
+		if err := db.CreateAPIKey(ctx, key); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+	}
+	if got, err := db.GetAPIKeyByID(ctx, member.ID); err != nil || got.Name != "member" {
+		t.Errorf("GetAPIKeyByID = %+v, %v", got, err)
+	}
+
+	for _, action := range []string{models.AuditActionImpersonate, models.AuditActionQuery} {
+		e := &models.AuditEntry{ActorAPIKeyID: &admin.ID, TargetAPIKeyID: &member.ID, Action: action, Method: "GET", Path: "/api/v1/transcripts", IP: "203.0.113.7"}
+		if err := db.RecordAudit(ctx, e); err != nil {
+			t.Fatalf("RecordAudit: %v", err)
+		}
+	}
+
+	entries, err := db.ListAuditLog(ctx, models.AuditLogParams{TargetAPIKeyID: member.ID, Limit: 50})
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("ListAuditLog = %d entries, %v; want 2", len(entries), err)
+	}
+	if *entries[0].ActorAPIKeyID != admin.ID || entries[0].IP != "203.0.113.7" {
+		t.Errorf("entry = %+v", entries[0])
+	}
+	if entries, err := db.ListAuditLog(ctx, models.AuditLogParams{Action: models.AuditActionQuery, Limit: 50}); err != nil || len(entries) != 1 {
+		t.Errorf("ListAuditLog(action=query) = %d entries, %v; want 1", len(entries), err)
+	}
+	if entries, err := db.ListAuditLog(ctx, models.AuditLogParams{ActorAPIKeyID: member.ID, Limit: 50}); err != nil || len(entries) != 0 {
+		t.Errorf("ListAuditLog(actor=member) = %d entries, %v; want 0", len(entries), err)
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE api_keys SET active = false WHERE id = $1`, member.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, err := db.GetAPIKeyByID(ctx, member.ID); err == nil {
+		t.Error("GetAPIKeyByID returned a revoked key")
+	}
+}
@@ -41,10 +41,13 @@ func (db *DB) GetBatch(ctx context.Context, id string) (*models.Batch, error) {
 func (db *DB) GetTranscriptsByBatch(ctx context.Context, batchID string) ([]models.Transcript, error) {
 	var transcripts []models.Transcript
 	err := db.SelectContext(ctx, &transcripts,
-		`SELECT * FROM transcripts WHERE batch_id = $1 ORDER BY created_at ASC`, batchID)
+		`SELECT `+transcriptColumns+` FROM transcripts WHERE batch_id = $1 ORDER BY created_at ASC`, batchID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list batch transcripts: %w", err)
 	}
+	if err := db.loadTranscriptTexts(ctx, transcripts); err != nil {
+		return nil, err
+	}
 	return transcripts, nil
 }
 
@@ -54,36 +57,84 @@ func (db *DB) GetTranscriptsByBatch(ctx context.Context, batchID string) ([]mode
 // two explicit functions makes the intent clearer and avoids nil-pointer issues.
 func (db *DB) CreateTranscriptWithBatch(ctx context.Context, t *models.Transcript) error {
 	query := `
-		INSERT INTO transcripts (youtube_url, youtube_id, title, channel_name, duration, language, transcript_text, word_count, status, error_message, batch_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO transcripts (youtube_url, youtube_id, title, channel_name, duration, language, transcript_text, word_count, status, error_message, batch_id, segments)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id, created_at, updated_at`
 
-	return db.QueryRowContext(ctx, query,
+	err := db.QueryRowContext(ctx, query,
 		t.YouTubeURL, t.YouTubeID, t.Title, t.ChannelName,
 		t.Duration, t.Language, t.TranscriptText, t.WordCount,
-		t.Status, t.ErrorMessage, t.BatchID,
+		t.Status, t.ErrorMessage, t.BatchID, t.Segments,
 	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Text reused from an earlier transcript can be large enough to offload,
+	// which needs the ID the insert just generated
+	if _, key := db.offloadText(ctx, "transcript", t.ID, t.TranscriptText); key != nil {
+		_, err = db.ExecContext(ctx, `UPDATE transcripts SET transcript_text = '', text_key = $2, offloaded_vector = `+
+			db.offloadedVector("$3")+` WHERE id = $1`, t.ID, key, t.TranscriptText)
+		db.replaceText(ctx, err, nil, key)
+		if err == nil {
+			t.TextKey = key
+		}
+	}
+	return nil
+}
+
+// GetAudioTranscriptionsByBatch returns the audio transcriptions belonging
+// to an upload batch, in the order they were submitted.
+func (db *DB) GetAudioTranscriptionsByBatch(ctx context.Context, batchID string) ([]models.AudioTranscription, error) {
+	var audio []models.AudioTranscription
+	err := db.SelectContext(ctx, &audio,
+		`SELECT `+audioColumns+` FROM audio_transcriptions WHERE batch_id = $1 ORDER BY created_at ASC`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch audio transcriptions: %w", err)
+	}
+	if err := db.loadAudioTexts(ctx, audio); err != nil {
+		return nil, err
+	}
+	return audio, nil
+}
+
+// GetPDFExtractionsByBatch returns the PDF extractions belonging to an
+// upload batch, in the order they were submitted.
+func (db *DB) GetPDFExtractionsByBatch(ctx context.Context, batchID string) ([]models.PDFExtraction, error) {
+	var extractions []models.PDFExtraction
+	err := db.SelectContext(ctx, &extractions,
+		`SELECT `+pdfColumns+` FROM pdf_extractions WHERE batch_id = $1 ORDER BY created_at ASC`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch PDF extractions: %w", err)
+	}
+	return extractions, nil
 }
 
 // UpdateBatchCounts recalculates the batch's progress counters by querying
-// the actual transcript statuses. This is more reliable than incrementing
+// the actual item statuses. This is more reliable than incrementing
 // counters — if a worker crashes mid-update, the counts self-heal on the
 // next check.
 //
-// Go Pattern: Using a subquery to count statuses in one round-trip instead
-// of multiple queries. The CASE/WHEN pattern is PostgreSQL's equivalent of
-// a conditional count.
+// Go Pattern: The CTE gathers the statuses of every item in the batch —
+// transcripts, or uploaded audio and PDFs — so each count is a subquery
+// over one list. The CASE/WHEN pattern is SQL's equivalent of a
+// conditional count.
 func (db *DB) UpdateBatchCounts(ctx context.Context, batchID string) error {
 	query := `
+		WITH items AS (
+			SELECT status FROM transcripts WHERE batch_id = $1
+			UNION ALL SELECT status FROM audio_transcriptions WHERE batch_id = $1
+			UNION ALL SELECT status FROM pdf_extractions WHERE batch_id = $1
+		)
 		UPDATE batches SET
-			completed_count = (SELECT COUNT(*) FROM transcripts WHERE batch_id = $1 AND status = 'completed'),
-			failed_count = (SELECT COUNT(*) FROM transcripts WHERE batch_id = $1 AND status = 'failed'),
+			completed_count = (SELECT COUNT(*) FROM items WHERE status = 'completed'),
+			failed_count = (SELECT COUNT(*) FROM items WHERE status = 'failed'),
 			status = CASE
-				WHEN (SELECT COUNT(*) FROM transcripts WHERE batch_id = $1 AND status IN ('pending', 'processing')) = 0
-					AND (SELECT COUNT(*) FROM transcripts WHERE batch_id = $1 AND status = 'failed') > 0
-					AND (SELECT COUNT(*) FROM transcripts WHERE batch_id = $1 AND status = 'completed') = 0
+				WHEN (SELECT COUNT(*) FROM items WHERE status IN ('pending', 'processing')) = 0
+					AND (SELECT COUNT(*) FROM items WHERE status = 'failed') > 0
+					AND (SELECT COUNT(*) FROM items WHERE status = 'completed') = 0
 				THEN 'failed'
-				WHEN (SELECT COUNT(*) FROM transcripts WHERE batch_id = $1 AND status IN ('pending', 'processing')) = 0
+				WHEN (SELECT COUNT(*) FROM items WHERE status IN ('pending', 'processing')) = 0
 				THEN 'completed'
 				ELSE 'processing'
 			END,
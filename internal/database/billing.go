@@ -0,0 +1,54 @@
+// billing.go contains database operations linking API keys to Stripe
+// customers and suspending them while their subscription is unpaid.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// SetAPIKeyStripeCustomer links an active API key to a Stripe customer, or
+// unlinks it when customerID is nil. Unlinking also lifts a suspension.
+func (db *DB) SetAPIKeyStripeCustomer(ctx context.Context, keyID string, customerID *string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := db.GetContext(ctx, &key, `
+		UPDATE api_keys
+		SET stripe_customer_id = $2,
+			billing_suspended = CASE WHEN $2 IS NULL THEN false ELSE billing_suspended END
+		WHERE id = $1 AND active = true
+		RETURNING *`, keyID, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("API key not found: %w", err)
+	}
+	return &key, nil
+}
+
+// GetAPIKeyStripeCustomer returns the Stripe customer an API key is linked
+// to, or "" if it isn't linked.
+func (db *DB) GetAPIKeyStripeCustomer(ctx context.Context, keyID string) (string, error) {
+	var customerID *string
+	err := db.GetContext(ctx, &customerID,
+		`SELECT stripe_customer_id FROM api_keys WHERE id = $1`, keyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get stripe customer: %w", err)
+	}
+	if customerID == nil {
+		return "", nil
+	}
+	return *customerID, nil
+}
+
+// SetBillingSuspended suspends or reinstates every API key linked to a
+// Stripe customer, returning how many keys changed.
+func (db *DB) SetBillingSuspended(ctx context.Context, customerID string, suspended bool) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE api_keys SET billing_suspended = $2
+		WHERE stripe_customer_id = $1 AND billing_suspended != $2`,
+		customerID, suspended)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update billing suspension: %w", err)
+	}
+	return result.RowsAffected()
+}
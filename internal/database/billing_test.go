@@ -0,0 +1,66 @@
+//go:build cgo
+
+// billing_test.go contains tests for linking API keys to Stripe customers,
+// run against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestKeyBilling verifies keys are linked to Stripe customers, suspended
+// and reinstated per customer, and reinstated when unlinked.
+func TestKeyBilling(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	var keys []*models.APIKey
+	for _, prefix := range []string{"mta_a", "mta_b", "mta_c"} {
+		key := &models.APIKey{KeyHash: "hash-" + prefix, KeyPrefix: prefix, Name: prefix, Active: true, Tier: models.TierFree, Role: "member"}
+		if err := db.CreateAPIKey(ctx, key); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+		keys = append(keys, key)
+	}
+
+	customer := "cus_123"
+	for _, key := range keys[:2] {
+		got, err := db.SetAPIKeyStripeCustomer(ctx, key.ID, &customer)
+		if err != nil || got.StripeCustomerID == nil || *got.StripeCustomerID != customer {
+			t.Fatalf("SetAPIKeyStripeCustomer = %+v, %v; want linked to %s", got, err, customer)
+		}
+	}
+	if _, err := db.SetAPIKeyStripeCustomer(ctx, "missing", &customer); err == nil {
+		t.Error("SetAPIKeyStripeCustomer(missing key) succeeded")
+	}
+	if id, err := db.GetAPIKeyStripeCustomer(ctx, keys[0].ID); err != nil || id != customer {
+		t.Errorf("GetAPIKeyStripeCustomer = %q, %v; want %q", id, err, customer)
+	}
+	if id, err := db.GetAPIKeyStripeCustomer(ctx, keys[2].ID); err != nil || id != "" {
+		t.Errorf("GetAPIKeyStripeCustomer(unlinked) = %q, %v; want \"\"", id, err)
+	}
+
+	if n, err := db.SetBillingSuspended(ctx, customer, true); err != nil || n != 2 {
+		t.Fatalf("SetBillingSuspended = %d, %v; want 2 keys", n, err)
+	}
+	if n, err := db.SetBillingSuspended(ctx, customer, true); err != nil || n != 0 {
+		t.Errorf("SetBillingSuspended again = %d, %v; want 0 keys", n, err)
+	}
+	if key, _ := db.GetAPIKeyByID(ctx, keys[2].ID); key.BillingSuspended {
+		t.Error("unlinked key was suspended")
+	}
+
+	got, err := db.SetAPIKeyStripeCustomer(ctx, keys[0].ID, nil)
+	if err != nil || got.StripeCustomerID != nil || got.BillingSuspended {
+		t.Errorf("unlinking = %+v, %v; want unlinked and reinstated", got, err)
+	}
+	if key, _ := db.GetAPIKeyByID(ctx, keys[1].ID); !key.BillingSuspended {
+		t.Error("unlinking one key reinstated another")
+	}
+	if n, err := db.SetBillingSuspended(ctx, customer, false); err != nil || n != 1 {
+		t.Errorf("reinstating = %d, %v; want 1 key", n, err)
+	}
+}
@@ -0,0 +1,175 @@
+//go:build cgo
+
+// chat_test.go contains tests for chat history paging, summaries, answer
+// feedback, and suggested questions, run against an in-memory SQLite
+// database.
+package database
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestChatHistory verifies paging back through a session's messages and
+// listing the messages its history summary doesn't cover.
+func TestChatHistory(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	session, err := db.GetOrCreateChatSession(ctx, "pdf", "00000000-0000-0000-0000-000000000001", nil, nil)
+	if err != nil {
+		t.Fatalf("GetOrCreateChatSession: %v", err)
+	}
+	var sent []models.TranscriptChatMessage
+	for i := 0; i < 5; i++ {
+		msg := &models.TranscriptChatMessage{SessionID: session.ID, Role: "user", Content: "message " + strconv.Itoa(i)}
+		if err := db.CreateChatMessage(ctx, msg); err != nil {
+			t.Fatalf("CreateChatMessage: %v", err)
+		}
+		sent = append(sent, *msg)
+	}
+
+	// Pages of 2 run back from the newest, each oldest first
+	var got []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("paging didn't stop")
+		}
+		page, err := db.ListChatMessages(ctx, session.ID, 2, cursor)
+		if err != nil {
+			t.Fatalf("ListChatMessages: %v", err)
+		}
+		var contents []string
+		for _, m := range page {
+			contents = append(contents, m.Content)
+		}
+		got = append(contents, got...)
+		if len(page) == 0 {
+			break
+		}
+		cursor = NextCreatedAtCursor(len(page), 2, page[0].ID, page[0].CreatedAt)
+		if cursor == "" {
+			break
+		}
+	}
+	if len(got) != len(sent) {
+		t.Fatalf("paged through %v, want %d messages", got, len(sent))
+	}
+	for i, m := range sent {
+		if got[i] != m.Content {
+			t.Errorf("message %d = %q, want %q", i, got[i], m.Content)
+		}
+	}
+
+	if err := db.SetChatHistorySummary(ctx, session.ID, "the first three messages", sent[2].ID); err != nil {
+		t.Fatalf("SetChatHistorySummary: %v", err)
+	}
+	session, err = db.GetOrCreateChatSession(ctx, "pdf", session.ItemID, nil, nil)
+	if err != nil {
+		t.Fatalf("GetOrCreateChatSession: %v", err)
+	}
+	if session.HistorySummary != "the first three messages" {
+		t.Errorf("HistorySummary = %q", session.HistorySummary)
+	}
+	rest, err := db.ListUnsummarizedChatMessages(ctx, session)
+	if err != nil {
+		t.Fatalf("ListUnsummarizedChatMessages: %v", err)
+	}
+	if len(rest) != 2 || rest[0].ID != sent[3].ID || rest[1].ID != sent[4].ID {
+		t.Errorf("ListUnsummarizedChatMessages = %+v, want the last two messages", rest)
+	}
+}
+
+// TestChatFeedback verifies rating answers and the per-model, per-type
+// feedback stats.
+func TestChatFeedback(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	var answers []string
+	for i, itemType := range []string{"pdf", "pdf", "pdf", "audio"} {
+		session, err := db.GetOrCreateChatSession(ctx, itemType, "00000000-0000-0000-0000-00000000000"+strconv.Itoa(i), nil, nil)
+		if err != nil {
+			t.Fatalf("GetOrCreateChatSession: %v", err)
+		}
+		msg := &models.TranscriptChatMessage{SessionID: session.ID, Role: "assistant", Content: "answer", ModelUsed: "m/one"}
+		if err := db.CreateChatMessage(ctx, msg); err != nil {
+			t.Fatalf("CreateChatMessage: %v", err)
+		}
+		answers = append(answers, msg.ID)
+	}
+
+	// Rating again replaces the earlier feedback
+	for _, f := range []struct{ id, rating, comment string }{
+		{answers[0], "down", ""},
+		{answers[0], "up", "clear"},
+		{answers[1], "down", "wrong page"},
+		{answers[3], "up", ""},
+	} {
+		if err := db.SetChatFeedback(ctx, f.id, f.rating, f.comment); err != nil {
+			t.Fatalf("SetChatFeedback: %v", err)
+		}
+	}
+	msg, err := db.GetChatMessage(ctx, answers[0])
+	if err != nil {
+		t.Fatalf("GetChatMessage: %v", err)
+	}
+	if msg.Feedback == nil || *msg.Feedback != "up" || msg.FeedbackComment != "clear" || msg.FeedbackAt == nil {
+		t.Errorf("feedback = %v, %q, %v; want up, clear, set", msg.Feedback, msg.FeedbackComment, msg.FeedbackAt)
+	}
+
+	stats, err := db.ListChatFeedbackStats(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ListChatFeedbackStats: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("ListChatFeedbackStats = %+v, want pdf and audio rows", stats)
+	}
+	pdf := stats[0]
+	if pdf.ItemType != "pdf" || pdf.Model != "m/one" || pdf.Answers != 3 || pdf.Rated != 2 || pdf.Up != 1 || pdf.Down != 1 {
+		t.Errorf("pdf stats = %+v", pdf)
+	}
+	if pdf.Approval == nil || *pdf.Approval != 0.5 {
+		t.Errorf("pdf approval = %v, want 0.5", pdf.Approval)
+	}
+
+	if stats, err := db.ListChatFeedbackStats(ctx, time.Now().Add(time.Hour)); err != nil || len(stats) != 0 {
+		t.Errorf("ListChatFeedbackStats(future) = %+v, %v; want none", stats, err)
+	}
+}
+
+// TestChatSuggestions verifies suggested questions are kept per item and
+// only returned for the text they were generated from.
+func TestChatSuggestions(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	pe := &models.PDFExtraction{Filename: "notes.pdf", OriginalName: "notes.pdf", Status: "completed"}
+	if err := db.CreatePDFExtraction(ctx, pe); err != nil {
+		t.Fatalf("CreatePDFExtraction: %v", err)
+	}
+	if got, err := db.GetChatSuggestions(ctx, "pdf", pe.ID, "hash1"); err != nil || got != nil {
+		t.Fatalf("GetChatSuggestions before saving = %v, %v; want none", got, err)
+	}
+
+	questions := []string{"What's due?", "Who's presenting?", "Where do we meet?"}
+	if err := db.SaveChatSuggestions(ctx, "pdf", pe.ID, "hash1", questions); err != nil {
+		t.Fatalf("SaveChatSuggestions: %v", err)
+	}
+	got, err := db.GetChatSuggestions(ctx, "pdf", pe.ID, "hash1")
+	if err != nil || !reflect.DeepEqual(got, questions) {
+		t.Errorf("GetChatSuggestions = %v, %v; want %v", got, err, questions)
+	}
+	if got, err := db.GetChatSuggestions(ctx, "pdf", pe.ID, "hash2"); err != nil || got != nil {
+		t.Errorf("GetChatSuggestions for changed text = %v, %v; want none", got, err)
+	}
+	if _, err := db.GetChatSuggestions(ctx, "video", pe.ID, "hash1"); err == nil {
+		t.Error("GetChatSuggestions accepted an unknown item type")
+	}
+}
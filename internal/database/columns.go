@@ -0,0 +1,67 @@
+// columns.go builds explicit column lists for queries on the item tables.
+// These tables have columns the models don't map (search_vector and
+// offloaded_vector), so queries name their columns rather than using
+// SELECT *, and list queries can also leave out large text bodies.
+//
+// Go Pattern: The lists are derived from the models' `db` tags with
+// reflection, once at startup, so adding a column to a model doesn't
+// require remembering to update a hand-written SELECT.
+package database
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// Every mapped column of each item table.
+var (
+	transcriptColumns = selectColumns(models.Transcript{})
+	audioColumns      = selectColumns(models.AudioTranscription{})
+	pdfColumns        = selectColumns(models.PDFExtraction{})
+)
+
+// Column lists used by list endpoints when ?include=text isn't requested.
+var (
+	transcriptSummaryColumns = selectColumns(models.Transcript{}, "transcript_text", "segments", "original_text", "original_segments")
+	audioSummaryColumns      = selectColumns(models.AudioTranscription{}, "transcript_text", "segments", "source_text")
+	pdfSummaryColumns        = selectColumns(models.PDFExtraction{}, "text_content", "markdown_content", "tables", "outline")
+)
+
+// selectColumns returns the comma-separated db columns of model, minus omit.
+func selectColumns(model interface{}, omit ...string) string {
+	skip := make(map[string]bool, len(omit))
+	for _, col := range omit {
+		skip[col] = true
+	}
+
+	t := reflect.TypeOf(model)
+	var cols []string
+	for i := 0; i < t.NumField(); i++ {
+		col := t.Field(i).Tag.Get("db")
+		if col == "" || col == "-" || skip[col] {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	return strings.Join(cols, ", ")
+}
+
+// qualifiedColumns prefixes each column in cols with a table alias, for
+// queries that join other tables with overlapping column names.
+func qualifiedColumns(cols, alias string) string {
+	parts := strings.Split(cols, ", ")
+	for i, col := range parts {
+		parts[i] = alias + "." + col
+	}
+	return strings.Join(parts, ", ")
+}
+
+// listColumns picks the full or summary column list.
+func listColumns(includeText bool, full, summary string) string {
+	if includeText {
+		return full
+	}
+	return summary
+}
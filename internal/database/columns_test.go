@@ -0,0 +1,65 @@
+// columns_test.go contains tests for the list query column helpers.
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSelectColumns verifies text columns are left out and others kept.
+func TestSelectColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns string
+		want    []string
+		omitted []string
+	}{
+		{"transcripts", transcriptSummaryColumns, []string{"id", "title", "word_count", "status", "api_key_id", "updated_at"}, []string{"transcript_text", "segments", "original_text", "original_segments"}},
+		{"audio", audioSummaryColumns, []string{"id", "original_name", "summary_text", "created_at"}, []string{"transcript_text", "segments"}},
+		{"pdfs", pdfSummaryColumns, []string{"id", "original_name", "page_count"}, []string{"text_content"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols := map[string]bool{}
+			for _, c := range strings.Split(tt.columns, ", ") {
+				cols[c] = true
+			}
+			for _, c := range tt.want {
+				if !cols[c] {
+					t.Errorf("missing column %q in %q", c, tt.columns)
+				}
+			}
+			for _, c := range tt.omitted {
+				if cols[c] {
+					t.Errorf("column %q should be omitted from %q", c, tt.columns)
+				}
+			}
+		})
+	}
+}
+
+// TestSelectColumnsSkipsNonColumns verifies db:"-" fields are never selected.
+func TestSelectColumnsSkipsNonColumns(t *testing.T) {
+	type row struct {
+		ID       string `db:"id"`
+		Computed string `db:"-"`
+		Untagged string
+	}
+	if got := selectColumns(row{}); got != "id" {
+		t.Errorf("selectColumns() = %q, want %q", got, "id")
+	}
+	if got := listColumns(true, "id, body", "id"); got != "id, body" {
+		t.Errorf("listColumns(true) = %q, want the full list", got)
+	}
+	if got := listColumns(false, "id, body", "id"); got != "id" {
+		t.Errorf("listColumns(false) = %q, want the summary list", got)
+	}
+}
+
+// TestQualifiedColumns verifies every column gets the table alias.
+func TestQualifiedColumns(t *testing.T) {
+	if got := qualifiedColumns("id, title, created_at", "t"); got != "t.id, t.title, t.created_at" {
+		t.Errorf("qualifiedColumns() = %q", got)
+	}
+}
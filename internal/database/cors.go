@@ -0,0 +1,45 @@
+// cors.go stores CORS origins added at runtime.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ListCORSOrigins returns the origins added at runtime, oldest first.
+func (db *DB) ListCORSOrigins(ctx context.Context) ([]models.CORSOrigin, error) {
+	var origins []models.CORSOrigin
+	if err := db.SelectContext(ctx, &origins, `SELECT * FROM cors_origins ORDER BY created_at`); err != nil {
+		return nil, fmt.Errorf("failed to list CORS origins: %w", err)
+	}
+	return origins, nil
+}
+
+// AddCORSOrigin allows an origin pattern. Adding one that's already there
+// returns the existing record.
+func (db *DB) AddCORSOrigin(ctx context.Context, origin string) (*models.CORSOrigin, error) {
+	var o models.CORSOrigin
+	err := db.GetContext(ctx, &o, `
+		INSERT INTO cors_origins (origin) VALUES ($1)
+		ON CONFLICT (origin) DO UPDATE SET origin = EXCLUDED.origin
+		RETURNING *`, origin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add CORS origin: %w", err)
+	}
+	return &o, nil
+}
+
+// DeleteCORSOrigin removes an origin added at runtime.
+func (db *DB) DeleteCORSOrigin(ctx context.Context, id string) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM cors_origins WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete CORS origin: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("CORS origin not found")
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// cursor.go implements keyset ("cursor") pagination for list queries.
+//
+// OFFSET pagination makes Postgres walk and discard every skipped row, so
+// page 2,000 is far slower than page 1. A cursor instead remembers the sort
+// value and ID of the last row returned, and the next page asks for rows
+// strictly after that position — an index range scan that costs the same
+// at any depth.
+//
+// Cursors are opaque to clients: base64url-encoded JSON holding the sort
+// column and direction as well as the position, so a cursor always
+// continues the ordering it was created with.
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ErrInvalidCursor is returned when a cursor can't be decoded or doesn't
+// fit the list it was passed to.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursor is the decoded form of a pagination cursor.
+type cursor struct {
+	SortBy  string `json:"s"`
+	SortDir string `json:"d"`
+	Value   string `json:"v"`  // Sort value of the last row, as text
+	ID      string `json:"id"` // ID of the last row, to break ties
+}
+
+// sortCasts maps sortable columns to the SQL type their cursor value is
+// cast to. Columns not listed here can't be used with cursors.
+var sortCasts = map[string]string{
+	"created_at": "timestamptz",
+	"title":      "text",
+	"word_count": "integer",
+	"duration":   "float8", // Whole seconds for transcripts, fractional for audio
+	"page_count": "integer",
+	"rank":       "real",
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a cursor and checks its sort column is one of
+// allowed. An empty string decodes to nil.
+func decodeCursor(s string, allowed map[string]bool) (*cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if !allowed[c.SortBy] || (c.SortDir != "asc" && c.SortDir != "desc") || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	// Catch malformed values here rather than as a SQL cast error
+	switch sortCasts[c.SortBy] {
+	case "timestamptz":
+		if _, err := time.Parse(time.RFC3339Nano, c.Value); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	case "integer":
+		if _, err := strconv.Atoi(c.Value); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	case "real":
+		if _, err := strconv.ParseFloat(c.Value, 32); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	case "float8":
+		if _, err := strconv.ParseFloat(c.Value, 64); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &c, nil
+}
+
+// condition returns the WHERE clause selecting rows after the cursor,
+// using placeholders $argNum and $argNum+1. The row comparison matches
+// an ORDER BY of "<sort column> <dir>, id <dir>".
+func (c *cursor) condition(argNum int) (string, []interface{}) {
+	op := "<"
+	if c.SortDir == "asc" {
+		op = ">"
+	}
+	clause := fmt.Sprintf("(%s, id) %s ($%d::%s, $%d::uuid)", c.SortBy, op, argNum, sortCasts[c.SortBy], argNum+1)
+	var value interface{} = c.Value
+	if sortCasts[c.SortBy] == "timestamptz" {
+		// Bound as a time so SQLite compares it in its stored text format;
+		// decodeCursor has checked it parses
+		value, _ = time.Parse(time.RFC3339Nano, c.Value)
+	}
+	return clause, []interface{}{value, c.ID}
+}
+
+// cursorTime formats a timestamp as a cursor value. Postgres stores
+// microseconds, so nothing is lost.
+func cursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// NextTranscriptCursor returns the cursor for the page after rows, or ""
+// if rows is the last page. params must be the ones rows were listed with.
+func NextTranscriptCursor(params models.TranscriptListParams, rows []models.Transcript) string {
+	sortBy, sortDir, perPage := transcriptListOrder(params)
+	if len(rows) == 0 || len(rows) < perPage {
+		return ""
+	}
+	last := rows[len(rows)-1]
+	c := cursor{SortBy: sortBy, SortDir: sortDir, ID: last.ID}
+	switch sortBy {
+	case "title":
+		c.Value = last.Title
+	case "word_count":
+		c.Value = strconv.Itoa(last.WordCount)
+	case "duration":
+		c.Value = strconv.Itoa(last.Duration)
+	default:
+		c.Value = cursorTime(last.CreatedAt)
+	}
+	return encodeCursor(c)
+}
+
+// NextItemCursor is NextTranscriptCursor for ListItems.
+func NextItemCursor(params models.ItemListParams, rows []models.Item) string {
+	sortBy, sortDir, perPage := itemListOrder(params)
+	if len(rows) == 0 || len(rows) < perPage {
+		return ""
+	}
+	last := rows[len(rows)-1]
+	c := cursor{SortBy: sortBy, SortDir: sortDir, ID: last.ID}
+	switch sortBy {
+	case "title":
+		c.Value = last.Title
+	case "word_count":
+		c.Value = strconv.Itoa(last.WordCount)
+	default:
+		c.Value = cursorTime(last.CreatedAt)
+	}
+	return encodeCursor(c)
+}
+
+// NextAudioCursor is NextTranscriptCursor for ListAudioTranscriptions.
+func NextAudioCursor(params models.AudioListParams, rows []models.AudioTranscription) string {
+	sortBy, sortDir, perPage := audioListOrder(params)
+	if len(rows) == 0 || len(rows) < perPage {
+		return ""
+	}
+	last := rows[len(rows)-1]
+	c := cursor{SortBy: sortBy, SortDir: sortDir, ID: last.ID}
+	switch sortBy {
+	case "duration":
+		c.Value = strconv.FormatFloat(last.Duration, 'g', -1, 64)
+	case "word_count":
+		c.Value = strconv.Itoa(last.WordCount)
+	default:
+		c.Value = cursorTime(last.CreatedAt)
+	}
+	return encodeCursor(c)
+}
+
+// NextPDFCursor is NextTranscriptCursor for ListPDFExtractions.
+func NextPDFCursor(params models.PDFListParams, rows []models.PDFExtraction) string {
+	sortBy, sortDir, perPage := pdfListOrder(params)
+	if len(rows) == 0 || len(rows) < perPage {
+		return ""
+	}
+	last := rows[len(rows)-1]
+	c := cursor{SortBy: sortBy, SortDir: sortDir, ID: last.ID}
+	switch sortBy {
+	case "page_count":
+		c.Value = strconv.Itoa(last.PageCount)
+	case "word_count":
+		c.Value = strconv.Itoa(last.WordCount)
+	default:
+		c.Value = cursorTime(last.CreatedAt)
+	}
+	return encodeCursor(c)
+}
+
+// NextCreatedAtCursor returns the cursor after the last of n rows for
+// newest-first lists, or "" if fewer than limit rows came back.
+func NextCreatedAtCursor(n, limit int, lastID string, lastCreatedAt time.Time) string {
+	if n == 0 || n < limit {
+		return ""
+	}
+	return encodeCursor(cursor{SortBy: "created_at", SortDir: "desc", Value: cursorTime(lastCreatedAt), ID: lastID})
+}
+
+// NextRankCursor returns the cursor after the last of n rows for
+// relevance-ordered search results, or "" if fewer than limit rows came back.
+func NextRankCursor(n, limit int, lastID string, lastRank float64) string {
+	if n == 0 || n < limit {
+		return ""
+	}
+	// Ranks are float4 in Postgres; format at that precision so the value
+	// casts back to exactly the same rank
+	value := strconv.FormatFloat(float64(float32(lastRank)), 'g', -1, 32)
+	return encodeCursor(cursor{SortBy: "rank", SortDir: "desc", Value: value, ID: lastID})
+}
+
+// createdAtOnly is the allowed sort set for lists that are always newest first.
+var createdAtOnly = map[string]bool{"created_at": true}
+
+// rankOnly is the allowed sort set for relevance-ordered search results.
+var rankOnly = map[string]bool{"rank": true}
@@ -0,0 +1,153 @@
+// cursor_test.go contains tests for keyset pagination cursors.
+package database
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestDecodeCursor verifies cursors round-trip and bad ones are rejected.
+func TestDecodeCursor(t *testing.T) {
+	valid := cursor{SortBy: "word_count", SortDir: "asc", Value: "42", ID: "abc"}
+
+	tests := []struct {
+		name    string
+		input   string
+		allowed map[string]bool
+		want    *cursor
+		wantErr bool
+	}{
+		{"empty", "", transcriptSortColumns, nil, false},
+		{"round trip", encodeCursor(valid), transcriptSortColumns, &valid, false},
+		{"not base64", "!!!", transcriptSortColumns, nil, true},
+		{"not json", "bm90IGpzb24", transcriptSortColumns, nil, true},
+		{"sort not allowed", encodeCursor(valid), createdAtOnly, nil, true},
+		{"bad direction", encodeCursor(cursor{SortBy: "title", SortDir: "up", Value: "a", ID: "abc"}), transcriptSortColumns, nil, true},
+		{"missing id", encodeCursor(cursor{SortBy: "title", SortDir: "asc", Value: "a"}), transcriptSortColumns, nil, true},
+		{"bad integer", encodeCursor(cursor{SortBy: "duration", SortDir: "asc", Value: "x", ID: "abc"}), transcriptSortColumns, nil, true},
+		{"bad time", encodeCursor(cursor{SortBy: "created_at", SortDir: "desc", Value: "yesterday", ID: "abc"}), createdAtOnly, nil, true},
+		{"bad rank", encodeCursor(cursor{SortBy: "rank", SortDir: "desc", Value: "high", ID: "abc"}), rankOnly, nil, true},
+		{"rank not allowed", encodeCursor(cursor{SortBy: "rank", SortDir: "desc", Value: "0.5", ID: "abc"}), createdAtOnly, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.input, tt.allowed)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Fatalf("decodeCursor() error = %v, want ErrInvalidCursor", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCursorCondition verifies the comparison follows the sort direction,
+// and that timestamps are bound as times.
+func TestCursorCondition(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	tests := []struct {
+		c         cursor
+		want      string
+		wantValue interface{}
+	}{
+		{cursor{SortBy: "created_at", SortDir: "desc", Value: cursorTime(created), ID: "i"}, "(created_at, id) < ($3::timestamptz, $4::uuid)", created},
+		{cursor{SortBy: "title", SortDir: "asc", Value: "v", ID: "i"}, "(title, id) > ($3::text, $4::uuid)", "v"},
+	}
+
+	for _, tt := range tests {
+		clause, args := tt.c.condition(3)
+		if clause != tt.want {
+			t.Errorf("condition() = %q, want %q", clause, tt.want)
+		}
+		if !reflect.DeepEqual(args, []interface{}{tt.wantValue, "i"}) {
+			t.Errorf("condition() args = %v", args)
+		}
+	}
+}
+
+// TestNextTranscriptCursor verifies a cursor is only issued for full pages
+// and carries the last row's sort value.
+func TestNextTranscriptCursor(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	rows := []models.Transcript{{ID: "a", WordCount: 10}, {ID: "b", WordCount: 7, CreatedAt: created}}
+
+	if got := NextTranscriptCursor(models.TranscriptListParams{PerPage: 3}, rows); got != "" {
+		t.Errorf("short page got cursor %q, want none", got)
+	}
+
+	next := NextTranscriptCursor(models.TranscriptListParams{PerPage: 2, SortBy: "word_count", SortDir: "asc"}, rows)
+	c, err := decodeCursor(next, transcriptSortColumns)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	want := cursor{SortBy: "word_count", SortDir: "asc", Value: "7", ID: "b"}
+	if *c != want {
+		t.Errorf("cursor = %+v, want %+v", *c, want)
+	}
+
+	// A follow-up request keeps the cursor's ordering even if sort_by changes
+	following := NextTranscriptCursor(models.TranscriptListParams{PerPage: 2, SortBy: "title", Cursor: next}, rows)
+	if c, _ := decodeCursor(following, transcriptSortColumns); c == nil || c.SortBy != "word_count" {
+		t.Errorf("follow-up cursor = %+v, want word_count ordering", c)
+	}
+
+	byDate := NextTranscriptCursor(models.TranscriptListParams{PerPage: 2}, rows)
+	if c, _ := decodeCursor(byDate, transcriptSortColumns); c == nil || c.Value != "2026-01-02T03:04:05.000006Z" || c.SortDir != "desc" {
+		t.Errorf("default cursor = %+v", c)
+	}
+}
+
+// TestNextAudioCursor verifies fractional durations round-trip through
+// an audio cursor and sort_by falls back to created_at when not allowed.
+func TestNextAudioCursor(t *testing.T) {
+	rows := []models.AudioTranscription{{ID: "a", Duration: 12.5}, {ID: "b", Duration: 3.25}}
+
+	next := NextAudioCursor(models.AudioListParams{PerPage: 2, SortBy: "duration"}, rows)
+	got, err := decodeCursor(next, audioSortColumns)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	want := &cursor{SortBy: "duration", SortDir: "desc", Value: "3.25", ID: "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cursor = %+v, want %+v", got, want)
+	}
+
+	// title isn't sortable for audio
+	byTitle := NextAudioCursor(models.AudioListParams{PerPage: 2, SortBy: "title"}, rows)
+	if c, _ := decodeCursor(byTitle, audioSortColumns); c == nil || c.SortBy != "created_at" {
+		t.Errorf("title cursor = %+v, want created_at ordering", c)
+	}
+	if _, err := decodeCursor(next, pdfSortColumns); err != ErrInvalidCursor {
+		t.Errorf("audio duration cursor on PDF list: error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+// TestNextRankCursor verifies rank cursors keep float4 precision and are only
+// issued for full pages.
+func TestNextRankCursor(t *testing.T) {
+	if got := NextRankCursor(1, 2, "a", 0.5); got != "" {
+		t.Errorf("NextRankCursor() on short page = %q, want empty", got)
+	}
+
+	rank := float64(float32(0.0607927))
+	got, err := decodeCursor(NextRankCursor(2, 2, "b", rank), rankOnly)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	want := &cursor{SortBy: "rank", SortDir: "desc", Value: "0.0607927", ID: "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cursor = %+v, want %+v", got, want)
+	}
+}
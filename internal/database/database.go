@@ -13,8 +13,11 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/lib/pq" // PostgreSQL driver + helpers
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
 )
 
 // DB wraps the sqlx database connection with our application-specific methods.
@@ -29,10 +33,21 @@ import (
 // plus we can add our own. This is Go's version of inheritance — composition.
 type DB struct {
 	*sqlx.DB
+	replica       *sqlx.DB       // Optional read replica for heavy reads (see reader)
+	textStore     TextStore      // Where large transcript bodies are offloaded (see offload.go)
+	textThreshold int            // Bodies over this many bytes are offloaded; 0 disables
+	schemaVersion uint           // Migration version RunMigrations brought the schema to
+	box           *secretbox.Box // Seals integration tokens (see integrations.go)
 }
 
 // New creates a new database connection with connection pooling configured.
+// A sqlite: or file: URL opens an embedded SQLite database instead of
+// PostgreSQL (see sqlite.go).
 func New(databaseURL string) (*DB, error) {
+	if dsn, ok := sqliteDSN(databaseURL); ok {
+		return newSQLite(dsn)
+	}
+
 	// sqlx.Connect both opens the connection and pings the database
 	db, err := sqlx.Connect("postgres", databaseURL)
 	if err != nil {
@@ -48,7 +63,64 @@ func New(databaseURL string) (*DB, error) {
 	db.SetConnMaxLifetime(2 * time.Minute) // Recycle connections frequently
 	db.SetConnMaxIdleTime(30 * time.Second) // Close idle connections before Neon does
 
-	return &DB{db}, nil
+	return &DB{DB: db}, nil
+}
+
+// newSQLite opens a SQLite database. An in-memory database lives only as
+// long as its connection, so the pool is held to one connection that's
+// never recycled.
+func newSQLite(dsn string) (*DB, error) {
+	db, err := sqlx.Connect(sqliteDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	if strings.HasPrefix(dsn, "file::memory:") {
+		db.SetMaxOpenConns(1)
+		db.SetConnMaxLifetime(0)
+		db.SetConnMaxIdleTime(0)
+	}
+	return &DB{DB: db}, nil
+}
+
+// ConnectReplica opens a read-only PostgreSQL replica for heavy reads.
+// Writes, and reads that must see them immediately, stay on the primary.
+func (db *DB) ConnectReplica(replicaURL string) error {
+	if db.isSQLite() {
+		return errors.New("read replicas require PostgreSQL")
+	}
+	replica, err := sqlx.Connect("postgres", replicaURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+	replica.SetMaxOpenConns(10)
+	replica.SetMaxIdleConns(2)
+	replica.SetConnMaxLifetime(2 * time.Minute)
+	replica.SetConnMaxIdleTime(30 * time.Second)
+	db.replica = replica
+	return nil
+}
+
+// reader returns the connection for list, search, export, and reporting
+// queries: the replica when one is configured, otherwise the primary.
+// Replicas lag the primary slightly, so only queries that can tolerate
+// missing the last moment's writes belong here — never a read that
+// follows a write in the same request.
+func (db *DB) reader() *sqlx.DB {
+	if db.replica != nil {
+		return db.replica
+	}
+	return db.DB
+}
+
+// Close closes the primary connection pool and the replica's, if any.
+func (db *DB) Close() error {
+	if db.replica != nil {
+		if err := db.replica.Close(); err != nil {
+			db.DB.Close()
+			return err
+		}
+	}
+	return db.DB.Close()
 }
 
 // HealthCheck verifies the database connection is alive.
@@ -59,6 +131,23 @@ func (db *DB) HealthCheck(ctx context.Context) error {
 	return db.PingContext(ctx)
 }
 
+// HasReplica reports whether a read replica is configured.
+func (db *DB) HasReplica() bool {
+	return db.replica != nil
+}
+
+// ReplicaHealthCheck verifies the read replica is reachable and reports
+// how far it lags the primary.
+func (db *DB) ReplicaHealthCheck(ctx context.Context) (time.Duration, error) {
+	var lag sql.NullFloat64
+	err := db.replica.GetContext(ctx, &lag,
+		`SELECT EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp())`)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(lag.Float64 * float64(time.Second)), nil
+}
+
 // --- Transcript Operations ---
 
 // CreateTranscript inserts a new transcript record.
@@ -84,54 +173,99 @@ func (db *DB) GetTranscript(ctx context.Context, id string) (*models.Transcript,
 	var t models.Transcript
 	// GetContext is sqlx's convenience method — it scans directly into a struct
 	// using the `db:"column_name"` tags we defined on the model.
-	err := db.GetContext(ctx, &t, `SELECT * FROM transcripts WHERE id = $1`, id)
+	err := db.GetContext(ctx, &t, `SELECT `+transcriptColumns+` FROM transcripts WHERE id = $1`, id)
 	if err != nil {
 		return nil, fmt.Errorf("transcript not found: %w", err)
 	}
+	if err := db.loadTranscriptText(ctx, &t); err != nil {
+		return nil, err
+	}
 	return &t, nil
 }
 
 // GetTranscriptByYouTubeID checks if we already have a transcript for this video.
 func (db *DB) GetTranscriptByYouTubeID(ctx context.Context, youtubeID string) (*models.Transcript, error) {
 	var t models.Transcript
-	err := db.GetContext(ctx, &t, `SELECT * FROM transcripts WHERE youtube_id = $1`, youtubeID)
+	err := db.GetContext(ctx, &t, `SELECT `+transcriptColumns+` FROM transcripts WHERE youtube_id = $1`, youtubeID)
 	if err != nil {
 		return nil, err
 	}
+	if err := db.loadTranscriptText(ctx, &t); err != nil {
+		return nil, err
+	}
 	return &t, nil
 }
 
 // UpdateTranscript updates a transcript's fields after processing.
+// A text body over the offload threshold is stored in the text store, and
+// the text is hashed for finding duplicates (see duplicates.go).
 func (db *DB) UpdateTranscript(ctx context.Context, t *models.Transcript) error {
 	query := `
 		UPDATE transcripts
 		SET title = $2, channel_name = $3, duration = $4, language = $5,
 			transcript_text = $6, word_count = $7, status = $8, error_message = $9,
-			updated_at = NOW()
+			segments = $10, text_key = $11, usage = $12, content_hash = $13, updated_at = NOW(),
+			offloaded_vector = ` + db.offloadedVector("$14") + `,
+			processed_at = CASE WHEN $8 IN ('completed', 'failed') THEN COALESCE(processed_at, NOW()) END
 		WHERE id = $1
 		RETURNING updated_at`
 
-	return db.QueryRowContext(ctx, query,
+	oldKey := db.storedTextKey(ctx, "transcripts", t.ID)
+	text, key := db.offloadText(ctx, "transcript", t.ID, t.TranscriptText)
+	t.ContentHash = contentHash(t.TranscriptText)
+	err := db.QueryRowContext(ctx, query,
 		t.ID, t.Title, t.ChannelName, t.Duration, t.Language,
-		t.TranscriptText, t.WordCount, t.Status, t.ErrorMessage,
+		text, t.WordCount, t.Status, t.ErrorMessage, t.Segments, key, t.Usage,
+		t.ContentHash, offloadedBody(t.TranscriptText, key),
 	).Scan(&t.UpdatedAt)
+	db.replaceText(ctx, err, oldKey, key)
+	if err == nil {
+		t.TextKey = key
+	}
+	return err
+}
+
+// transcriptSortColumns are the columns ListTranscripts can sort by.
+var transcriptSortColumns = map[string]bool{
+	"created_at": true, "title": true, "word_count": true, "duration": true,
+}
+
+// transcriptListOrder applies ListTranscripts' defaults and returns the
+// effective sort column, direction, and page size. A cursor carries its
+// own sort, which wins over sort_by/sort_dir.
+func transcriptListOrder(params models.TranscriptListParams) (string, string, int) {
+	perPage := params.PerPage
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	if c, err := decodeCursor(params.Cursor, transcriptSortColumns); err == nil && c != nil {
+		return c.SortBy, c.SortDir, perPage
+	}
+	sortBy, sortDir := params.SortBy, params.SortDir
+	// Validate sort column to prevent SQL injection
+	if !transcriptSortColumns[sortBy] {
+		sortBy = "created_at"
+	}
+	if sortDir != "asc" {
+		sortDir = "desc"
+	}
+	return sortBy, sortDir, perPage
 }
 
 // ListTranscripts returns a paginated list of transcripts with optional filters.
+// With params.Cursor set it returns the page after the cursor (keyset
+// pagination) and ignores params.Page.
 func (db *DB) ListTranscripts(ctx context.Context, params models.TranscriptListParams) ([]models.Transcript, int, error) {
+	after, err := decodeCursor(params.Cursor, transcriptSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Set defaults
 	if params.Page < 1 {
 		params.Page = 1
 	}
-	if params.PerPage < 1 || params.PerPage > 100 {
-		params.PerPage = 20
-	}
-	if params.SortBy == "" {
-		params.SortBy = "created_at"
-	}
-	if params.SortDir == "" {
-		params.SortDir = "desc"
-	}
+	params.SortBy, params.SortDir, params.PerPage = transcriptListOrder(params)
 
 	// Build WHERE clause dynamically
 	// Go Pattern: Strings.Builder is the efficient way to build strings
@@ -165,9 +299,9 @@ func (db *DB) ListTranscripts(ctx context.Context, params models.TranscriptListP
 		argNum++
 	}
 
-	if params.APIKeyID != nil {
-		conditions = append(conditions, fmt.Sprintf("api_key_id = $%d", argNum))
-		args = append(args, *params.APIKeyID)
+	if scope, arg := callerScope(params.APIKeyID, params.UserID, fmt.Sprintf("$%d", argNum)); scope != "" {
+		conditions = append(conditions, scope)
+		args = append(args, arg)
 		argNum++
 	}
 
@@ -176,21 +310,10 @@ func (db *DB) ListTranscripts(ctx context.Context, params models.TranscriptListP
 		whereClause = "WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	// Validate sort column to prevent SQL injection
-	validSortColumns := map[string]bool{
-		"created_at": true, "title": true, "word_count": true, "duration": true,
-	}
-	if !validSortColumns[params.SortBy] {
-		params.SortBy = "created_at"
-	}
-	if params.SortDir != "asc" && params.SortDir != "desc" {
-		params.SortDir = "desc"
-	}
-
 	// Count total matching records
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM transcripts %s", whereClause)
 	var total int
-	err := db.GetContext(ctx, &total, countQuery, args...)
+	err = db.reader().GetContext(ctx, &total, countQuery, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			total = 0
@@ -199,51 +322,107 @@ func (db *DB) ListTranscripts(ctx context.Context, params models.TranscriptListP
 		}
 	}
 
-	// Fetch page of results
+	// Fetch page of results. The cursor condition is added after counting
+	// so total_items still covers every match.
 	offset := (params.Page - 1) * params.PerPage
+	if after != nil {
+		clause, cursorArgs := after.condition(argNum)
+		conditions = append(conditions, clause)
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		args = append(args, cursorArgs...)
+		argNum += len(cursorArgs)
+		offset = 0
+	}
+	// id breaks ties so cursors have a unique position to resume from
 	selectQuery := fmt.Sprintf(
-		"SELECT * FROM transcripts %s ORDER BY %s %s LIMIT $%d OFFSET $%d",
-		whereClause, params.SortBy, params.SortDir, argNum, argNum+1,
+		"SELECT %s FROM transcripts %s ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d",
+		listColumns(params.IncludeText, transcriptColumns, transcriptSummaryColumns), whereClause, params.SortBy, params.SortDir, params.SortDir, argNum, argNum+1,
 	)
 	args = append(args, params.PerPage, offset)
 
 	var transcripts []models.Transcript
-	err = db.SelectContext(ctx, &transcripts, selectQuery, args...)
+	err = db.reader().SelectContext(ctx, &transcripts, selectQuery, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("list query failed: %w", err)
 	}
+	if params.IncludeText {
+		if err := db.loadTranscriptTexts(ctx, transcripts); err != nil {
+			return nil, 0, err
+		}
+	}
 
 	return transcripts, total, nil
 }
 
 // DeleteTranscript removes a transcript by ID.
 func (db *DB) DeleteTranscript(ctx context.Context, id string) error {
-	result, err := db.ExecContext(ctx, `DELETE FROM transcripts WHERE id = $1`, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete transcript: %w", err)
+	var keys struct {
+		Text     *string `db:"text_key"`
+		Original *string `db:"original_text_key"`
 	}
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
+	err := db.GetContext(ctx, &keys,
+		`DELETE FROM transcripts WHERE id = $1 RETURNING text_key, original_text_key`, id)
+	if errors.Is(err, sql.ErrNoRows) {
 		return fmt.Errorf("transcript not found")
 	}
+	if err != nil {
+		return fmt.Errorf("failed to delete transcript: %w", err)
+	}
+	db.deleteText(ctx, keys.Text, keys.Original)
 	return nil
 }
 
 // --- Summary Operations ---
 
-// CreateSummary inserts a new summary record.
+// CreateSummary inserts a new summary record. A summary without a status
+// is stored as completed.
 func (db *DB) CreateSummary(ctx context.Context, s *models.Summary) error {
+	if s.Status == "" {
+		s.Status = models.StatusCompleted
+	}
 	query := `
-		INSERT INTO summaries (transcript_id, model_used, prompt_used, summary_text, key_points, length, style)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO summaries (transcript_id, model_used, prompt_used, summary_text, key_points, length, style, status, error_message, usage)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, created_at`
 
 	return db.QueryRowContext(ctx, query,
 		s.TranscriptID, s.ModelUsed, s.PromptUsed,
-		s.SummaryText, s.KeyPoints, s.Length, s.Style,
+		s.SummaryText, s.KeyPoints, s.Length, s.Style, s.Status, s.ErrorMessage, s.Usage,
 	).Scan(&s.ID, &s.CreatedAt)
 }
 
+// UpdateSummary stores a summary's generated content and status.
+func (db *DB) UpdateSummary(ctx context.Context, s *models.Summary) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE summaries SET model_used = $2, prompt_used = $3, summary_text = $4, key_points = $5,
+			status = $6, error_message = $7, usage = $8
+		WHERE id = $1`,
+		s.ID, s.ModelUsed, s.PromptUsed, s.SummaryText, s.KeyPoints, s.Status, s.ErrorMessage, s.Usage)
+	if err != nil {
+		return fmt.Errorf("failed to update summary: %w", err)
+	}
+	return nil
+}
+
+// SetSummaryStatus moves a summary to status, with an error message for
+// failures.
+func (db *DB) SetSummaryStatus(ctx context.Context, id string, status models.TranscriptStatus, errMsg string) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE summaries SET status = $2, error_message = $3 WHERE id = $1`, id, status, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to update summary status: %w", err)
+	}
+	return nil
+}
+
+// DeleteSummary removes a summary.
+func (db *DB) DeleteSummary(ctx context.Context, id string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM summaries WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete summary: %w", err)
+	}
+	return nil
+}
+
 // GetSummary retrieves a single summary by ID.
 func (db *DB) GetSummary(ctx context.Context, id string) (*models.Summary, error) {
 	var s models.Summary
@@ -265,10 +444,124 @@ func (db *DB) GetSummariesByTranscript(ctx context.Context, transcriptID string)
 	return summaries, nil
 }
 
+// ListSummaries returns a page of summaries, newest first, with optional
+// filters. params.APIKeyID limits it to summaries of transcripts that key
+// (or its organization) created.
+func (db *DB) ListSummaries(ctx context.Context, params models.SummaryListParams) ([]models.Summary, int, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+
+	var conditions []string
+	var args []interface{}
+	add := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(clause, fmt.Sprintf("$%d", len(args))))
+	}
+	if params.TranscriptID != "" {
+		add("transcript_id = %s", params.TranscriptID)
+	}
+	if params.Model != "" {
+		add("model_used = %s", params.Model)
+	}
+	if params.Length != "" {
+		add("length = %s", params.Length)
+	}
+	if params.Style != "" {
+		add("style = %s", params.Style)
+	}
+	if params.Status != "" {
+		add("status = %s", params.Status)
+	}
+	if params.DateFrom != "" {
+		add("created_at >= %s", params.DateFrom)
+	}
+	if params.DateTo != "" {
+		add("created_at <= %s", params.DateTo)
+	}
+	if scope, arg := callerScope(params.APIKeyID, params.UserID, fmt.Sprintf("$%d", len(args)+1)); scope != "" {
+		args = append(args, arg)
+		conditions = append(conditions, "transcript_id IN (SELECT id FROM transcripts WHERE "+scope+")")
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := db.reader().GetContext(ctx, &total, "SELECT COUNT(*) FROM summaries "+whereClause, args...); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	query := fmt.Sprintf("SELECT * FROM summaries %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
+		whereClause, len(args)+1, len(args)+2)
+	args = append(args, params.PerPage, (params.Page-1)*params.PerPage)
+
+	var summaries []models.Summary
+	if err := db.reader().SelectContext(ctx, &summaries, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	return summaries, total, nil
+}
+
+// GetLatestSummary returns a transcript's newest completed summary, or nil
+// if it has none.
+func (db *DB) GetLatestSummary(ctx context.Context, transcriptID string) (*models.Summary, error) {
+	var s models.Summary
+	err := db.GetContext(ctx, &s, `
+		SELECT * FROM summaries WHERE transcript_id = $1 AND status = 'completed'
+		ORDER BY created_at DESC LIMIT 1`, transcriptID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest summary: %w", err)
+	}
+	return &s, nil
+}
+
+// GetLatestSummaryTexts returns the newest completed summary text for each of
+// the given transcripts, keyed by transcript ID. Transcripts without one are
+// absent.
+func (db *DB) GetLatestSummaryTexts(ctx context.Context, transcriptIDs []string) (map[string]string, error) {
+	texts := make(map[string]string, len(transcriptIDs))
+	if len(transcriptIDs) == 0 {
+		return texts, nil
+	}
+
+	// Number each transcript's summaries newest first and keep the first
+	rows, err := db.QueryContext(ctx,
+		`SELECT transcript_id, summary_text FROM (
+			SELECT transcript_id, summary_text,
+				ROW_NUMBER() OVER (PARTITION BY transcript_id ORDER BY created_at DESC) AS n
+			FROM summaries
+			WHERE transcript_id = ANY($1) AND status = 'completed'
+		 ) latest
+		 WHERE n = 1`, pq.Array(transcriptIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest summaries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, text string
+		if err := rows.Scan(&id, &text); err != nil {
+			return nil, fmt.Errorf("failed to scan summary: %w", err)
+		}
+		texts[id] = text
+	}
+	return texts, rows.Err()
+}
+
 // --- Chat Operations (MTA-27) ---
 
-// GetOrCreateChatSession finds or creates a chat session for an item.
-func (db *DB) GetOrCreateChatSession(ctx context.Context, itemType, itemID string, apiKeyID *string) (*models.TranscriptChatSession, error) {
+// GetOrCreateChatSession finds or creates a chat session for an item, held
+// with an API key or by a logged-in user.
+func (db *DB) GetOrCreateChatSession(ctx context.Context, itemType, itemID string, apiKeyID, userID *string) (*models.TranscriptChatSession, error) {
 	var session models.TranscriptChatSession
 	var err error
 	itemTypeLit := pq.QuoteLiteral(itemType)
@@ -277,9 +570,13 @@ func (db *DB) GetOrCreateChatSession(ctx context.Context, itemType, itemID strin
 	if apiKeyID != nil {
 		apiKeyClause = "api_key_id = " + pq.QuoteLiteral(*apiKeyID)
 	}
+	userClause := "user_id IS NULL"
+	if userID != nil {
+		userClause = "user_id = " + pq.QuoteLiteral(*userID)
+	}
 	selectQuery := fmt.Sprintf(
-		`SELECT * FROM transcript_chat_sessions WHERE item_type = %s AND item_id = %s AND %s`,
-		itemTypeLit, itemIDLit, apiKeyClause,
+		`SELECT * FROM transcript_chat_sessions WHERE item_type = %s AND item_id = %s AND %s AND %s`,
+		itemTypeLit, itemIDLit, apiKeyClause, userClause,
 	)
 	err = db.GetContext(ctx, &session, selectQuery)
 
@@ -306,17 +603,20 @@ func (db *DB) GetOrCreateChatSession(ctx context.Context, itemType, itemID strin
 	if apiKeyID != nil {
 		apiKeyLit = pq.QuoteLiteral(*apiKeyID)
 	}
+	userLit := "NULL"
+	if userID != nil {
+		userLit = pq.QuoteLiteral(*userID)
+	}
 	insertQuery := fmt.Sprintf(
-		`INSERT INTO transcript_chat_sessions (item_type, item_id, transcript_id, api_key_id)
-		 VALUES (%s, %s, %s, %s)
+		`INSERT INTO transcript_chat_sessions (item_type, item_id, transcript_id, api_key_id, user_id)
+		 VALUES (%s, %s, %s, %s, %s)
 		 RETURNING id, created_at, updated_at`,
-		itemTypeLit, itemIDLit, transcriptIDLit, apiKeyLit,
+		itemTypeLit, itemIDLit, transcriptIDLit, apiKeyLit, userLit,
 	)
 	err = db.QueryRowContext(ctx, insertQuery).
 		Scan(&session.ID, &session.CreatedAt, &session.UpdatedAt)
-	if apiKeyID != nil {
-		session.APIKeyID = apiKeyID
-	}
+	session.APIKeyID = apiKeyID
+	session.UserID = userID
 	if err != nil {
 		return nil, fmt.Errorf("failed to create chat session: %w", err)
 	}
@@ -329,24 +629,68 @@ func (db *DB) GetOrCreateChatSession(ctx context.Context, itemType, itemID strin
 	return &session, nil
 }
 
-// ListChatMessages returns chat messages for a session.
-func (db *DB) ListChatMessages(ctx context.Context, sessionID string, limit int) ([]models.TranscriptChatMessage, error) {
+// ListChatMessages returns a page of a session's chat messages, oldest
+// first. Pages run back from the newest message: with cursor set it returns
+// the messages before it, and NextCreatedAtCursor on the page's first
+// message gives the cursor for the page before that.
+func (db *DB) ListChatMessages(ctx context.Context, sessionID string, limit int, cursor string) ([]models.TranscriptChatMessage, error) {
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
+	before, err := decodeCursor(cursor, createdAtOnly)
+	if err != nil {
+		return nil, err
+	}
+	where := "WHERE session_id = $1"
+	args := []interface{}{sessionID}
+	if before != nil {
+		clause, cursorArgs := before.condition(2)
+		where += " AND " + clause
+		args = append(args, cursorArgs...)
+	}
+
 	var messages []models.TranscriptChatMessage
-	sessionIDLit := pq.QuoteLiteral(sessionID)
-	selectQuery := fmt.Sprintf(
-		`SELECT * FROM transcript_chat_messages WHERE session_id = %s ORDER BY created_at ASC LIMIT %d`,
-		sessionIDLit, limit,
+	query := fmt.Sprintf(
+		`SELECT * FROM transcript_chat_messages %s ORDER BY created_at DESC, id DESC LIMIT %d`,
+		where, limit,
 	)
-	err := db.SelectContext(ctx, &messages, selectQuery)
-	if err != nil {
+	if err := db.SelectContext(ctx, &messages, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list chat messages: %w", err)
+	}
+	slices.Reverse(messages)
+	return messages, nil
+}
+
+// ListUnsummarizedChatMessages returns the session's messages its history
+// summary doesn't cover yet, oldest first.
+func (db *DB) ListUnsummarizedChatMessages(ctx context.Context, session *models.TranscriptChatSession) ([]models.TranscriptChatMessage, error) {
+	query := `SELECT * FROM transcript_chat_messages WHERE session_id = $1`
+	args := []interface{}{session.ID}
+	if session.SummarizedThroughID != nil {
+		query += ` AND (created_at, id) > (SELECT created_at, id FROM transcript_chat_messages WHERE id = $2)`
+		args = append(args, *session.SummarizedThroughID)
+	}
+	query += ` ORDER BY created_at, id`
+
+	var messages []models.TranscriptChatMessage
+	if err := db.SelectContext(ctx, &messages, query, args...); err != nil {
 		return nil, fmt.Errorf("failed to list chat messages: %w", err)
 	}
 	return messages, nil
 }
 
+// SetChatHistorySummary stores a session's condensed history, covering
+// messages up to and including throughID.
+func (db *DB) SetChatHistorySummary(ctx context.Context, sessionID, summary, throughID string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE transcript_chat_sessions SET history_summary = $2, summarized_through_id = $3
+		WHERE id = $1`, sessionID, summary, throughID)
+	if err != nil {
+		return fmt.Errorf("failed to save chat history summary: %w", err)
+	}
+	return nil
+}
+
 // CreateChatMessage inserts a chat message.
 func (db *DB) CreateChatMessage(ctx context.Context, msg *models.TranscriptChatMessage) error {
 	query := `
@@ -365,17 +709,50 @@ func (db *DB) CreateChatMessage(ctx context.Context, msg *models.TranscriptChatM
 	return nil
 }
 
+// GetChatMessage returns a chat message by ID, wrapping sql.ErrNoRows if
+// there isn't one.
+func (db *DB) GetChatMessage(ctx context.Context, id string) (*models.TranscriptChatMessage, error) {
+	var msg models.TranscriptChatMessage
+	if err := db.GetContext(ctx, &msg, `SELECT * FROM transcript_chat_messages WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to get chat message: %w", err)
+	}
+	return &msg, nil
+}
+
+// GetChatSession returns a chat session by ID, wrapping sql.ErrNoRows if
+// there isn't one.
+func (db *DB) GetChatSession(ctx context.Context, id string) (*models.TranscriptChatSession, error) {
+	var session models.TranscriptChatSession
+	if err := db.GetContext(ctx, &session, `SELECT * FROM transcript_chat_sessions WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to get chat session: %w", err)
+	}
+	return &session, nil
+}
+
+// SetChatFeedback records a rating and comment on a chat message,
+// replacing any earlier feedback.
+func (db *DB) SetChatFeedback(ctx context.Context, messageID, rating, comment string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE transcript_chat_messages SET feedback = $2, feedback_comment = $3, feedback_at = NOW()
+		WHERE id = $1`, messageID, rating, comment)
+	if err != nil {
+		return fmt.Errorf("failed to save chat feedback: %w", err)
+	}
+	return nil
+}
+
 // --- API Key Operations ---
 
 // CreateAPIKey inserts a new API key record.
 func (db *DB) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
 	query := `
-		INSERT INTO api_keys (key_hash, key_prefix, name, active, rate_limit)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO api_keys (key_hash, key_prefix, name, active, tier, org_id, role, allowed_origins, allowed_ips)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, '{}'::text[]), COALESCE($9, '{}'::text[]))
 		RETURNING id, created_at`
 
 	return db.QueryRowContext(ctx, query,
-		key.KeyHash, key.KeyPrefix, key.Name, key.Active, key.RateLimit,
+		key.KeyHash, key.KeyPrefix, key.Name, key.Active, key.Tier, key.OrgID, key.Role,
+		key.AllowedOrigins, key.AllowedIPs,
 	).Scan(&key.ID, &key.CreatedAt)
 }
 
@@ -390,6 +767,17 @@ func (db *DB) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey,
 	return &key, nil
 }
 
+// GetAPIKeyByID retrieves an active API key by its ID.
+func (db *DB) GetAPIKeyByID(ctx context.Context, id string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := db.GetContext(ctx, &key,
+		`SELECT * FROM api_keys WHERE id = $1 AND active = true`, id)
+	if err != nil {
+		return nil, fmt.Errorf("API key not found: %w", err)
+	}
+	return &key, nil
+}
+
 // UpdateAPIKeyLastUsed bumps the last_used_at timestamp.
 func (db *DB) UpdateAPIKeyLastUsed(ctx context.Context, id string) error {
 	_, err := db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`, id)
@@ -424,9 +812,10 @@ func (db *DB) RevokeAPIKey(ctx context.Context, id string) error {
 // CreateAudioTranscription inserts a new audio transcription record.
 func (db *DB) CreateAudioTranscription(ctx context.Context, at *models.AudioTranscription) error {
 	query := `
-		INSERT INTO audio_transcriptions (filename, original_name, duration, language, transcript_text, word_count, status, error_message, content_type, api_key_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, created_at`
+		INSERT INTO audio_transcriptions (filename, original_name, duration, language, transcript_text, word_count, status, error_message, content_type, api_key_id, language_hint,
+			title, notes, metadata, batch_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14::jsonb, $15)
+		RETURNING id, created_at, updated_at`
 
 	if at.ContentType == "" {
 		at.ContentType = models.ContentGeneral
@@ -435,32 +824,48 @@ func (db *DB) CreateAudioTranscription(ctx context.Context, at *models.AudioTran
 	return db.QueryRowContext(ctx, query,
 		at.Filename, at.OriginalName, at.Duration, at.Language,
 		at.TranscriptText, at.WordCount, at.Status, at.ErrorMessage,
-		at.ContentType, at.APIKeyID,
-	).Scan(&at.ID, &at.CreatedAt)
+		at.ContentType, at.APIKeyID, at.LanguageHint,
+		at.Title, at.Notes, metadataJSON(at.Metadata), at.BatchID,
+	).Scan(&at.ID, &at.CreatedAt, &at.UpdatedAt)
 }
 
 // GetAudioTranscription retrieves a single audio transcription by ID.
 func (db *DB) GetAudioTranscription(ctx context.Context, id string) (*models.AudioTranscription, error) {
 	var at models.AudioTranscription
-	err := db.GetContext(ctx, &at, `SELECT * FROM audio_transcriptions WHERE id = $1`, id)
+	err := db.GetContext(ctx, &at, `SELECT `+audioColumns+` FROM audio_transcriptions WHERE id = $1`, id)
 	if err != nil {
 		return nil, fmt.Errorf("audio transcription not found: %w", err)
 	}
+	if err := db.loadAudioText(ctx, &at); err != nil {
+		return nil, err
+	}
 	return &at, nil
 }
 
 // UpdateAudioTranscription updates an audio transcription record after processing.
+// A text body over the offload threshold is stored in the text store.
 func (db *DB) UpdateAudioTranscription(ctx context.Context, at *models.AudioTranscription) error {
 	query := `
 		UPDATE audio_transcriptions
 		SET duration = $2, language = $3, transcript_text = $4, word_count = $5,
-			status = $6, error_message = $7
+			status = $6, error_message = $7, segments = $8, text_key = $9,
+			language_hint = $10, language_confidence = $11, translated = $12, source_text = $13, usage = $14,
+			offloaded_vector = ` + db.offloadedVector("$15") + `,
+			processed_at = CASE WHEN $6 IN ('completed', 'failed') THEN COALESCE(processed_at, NOW()) END
 		WHERE id = $1`
 
+	oldKey := db.storedTextKey(ctx, "audio_transcriptions", at.ID)
+	text, key := db.offloadText(ctx, "audio", at.ID, at.TranscriptText)
 	_, err := db.ExecContext(ctx, query,
-		at.ID, at.Duration, at.Language, at.TranscriptText,
-		at.WordCount, at.Status, at.ErrorMessage,
+		at.ID, at.Duration, at.Language, text,
+		at.WordCount, at.Status, at.ErrorMessage, at.Segments, key,
+		at.LanguageHint, at.LanguageConfidence, at.Translated, at.SourceText, at.Usage,
+		offloadedBody(at.TranscriptText, key),
 	)
+	db.replaceText(ctx, err, oldKey, key)
+	if err == nil {
+		at.TextKey = key
+	}
 	return err
 }
 
@@ -469,40 +874,200 @@ func (db *DB) UpdateAudioSummary(ctx context.Context, at *models.AudioTranscript
 	query := `
 		UPDATE audio_transcriptions
 		SET content_type = $2, summary_text = $3, key_points = $4, action_items = $5,
-			decisions = $6, summary_model = $7, summary_status = $8
+			decisions = $6, summary_model = $7, summary_status = $8, speaker_summaries = $9, usage = $10
 		WHERE id = $1`
 
+	speakers := at.SpeakerSummaries
+	if len(speakers) == 0 {
+		speakers = json.RawMessage(`[]`)
+	}
 	_, err := db.ExecContext(ctx, query,
 		at.ID, at.ContentType, at.SummaryText, at.KeyPoints,
-		at.ActionItems, at.Decisions, at.SummaryModel, at.SummaryStatus,
+		at.ActionItems, at.Decisions, at.SummaryModel, at.SummaryStatus, speakers, at.Usage,
 	)
 	return err
 }
 
-// ListAudioTranscriptions returns recent audio transcriptions.
-func (db *DB) ListAudioTranscriptions(ctx context.Context, limit int, apiKeyID *string) ([]models.AudioTranscription, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
+// UpdateAudioAnalysis updates the conversation analysis fields of an audio transcription.
+func (db *DB) UpdateAudioAnalysis(ctx context.Context, at *models.AudioTranscription) error {
+	query := `
+		UPDATE audio_transcriptions
+		SET analysis = $2, analysis_model = $3, analysis_status = $4
+		WHERE id = $1`
+
+	analysis := at.Analysis
+	if len(analysis) == 0 {
+		analysis = json.RawMessage(`{}`)
 	}
-	var transcriptions []models.AudioTranscription
-	var err error
-	query := fmt.Sprintf(
-		`SELECT * FROM audio_transcriptions
-		 %s
-		 ORDER BY created_at DESC
-		 LIMIT %d`,
-		buildAPIKeyWhereClause(apiKeyID), limit,
+
+	_, err := db.ExecContext(ctx, query, at.ID, analysis, at.AnalysisModel, at.AnalysisStatus)
+	return err
+}
+
+// UpdateAudioMinutes stores generated meeting minutes for an audio transcription.
+func (db *DB) UpdateAudioMinutes(ctx context.Context, at *models.AudioTranscription) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE audio_transcriptions SET minutes = $2, minutes_model = $3 WHERE id = $1`,
+		at.ID, at.Minutes, at.MinutesModel,
 	)
-	err = db.SelectContext(ctx, &transcriptions, query)
+	return err
+}
+
+// MaxListExportRows caps how many rows a CSV export of a list view returns.
+const MaxListExportRows = 5000
+
+// audioSortColumns are the columns ListAudioTranscriptions can sort by.
+var audioSortColumns = map[string]bool{"created_at": true, "duration": true, "word_count": true}
+
+// pdfSortColumns are the columns ListPDFExtractions can sort by.
+var pdfSortColumns = map[string]bool{"created_at": true, "page_count": true, "word_count": true}
+
+// listOrder is transcriptListOrder for the audio and PDF lists, whose
+// sortable columns are allowed.
+func listOrder(cursorParam, sortBy, sortDir string, perPage int, allowed map[string]bool) (string, string, int) {
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	if c, err := decodeCursor(cursorParam, allowed); err == nil && c != nil {
+		return c.SortBy, c.SortDir, perPage
+	}
+	// Validate sort column to prevent SQL injection
+	if !allowed[sortBy] {
+		sortBy = "created_at"
+	}
+	if sortDir != "asc" {
+		sortDir = "desc"
+	}
+	return sortBy, sortDir, perPage
+}
+
+// audioListOrder is listOrder for ListAudioTranscriptions.
+func audioListOrder(params models.AudioListParams) (string, string, int) {
+	return listOrder(params.Cursor, params.SortBy, params.SortDir, params.PerPage, audioSortColumns)
+}
+
+// pdfListOrder is listOrder for ListPDFExtractions.
+func pdfListOrder(params models.PDFListParams) (string, string, int) {
+	return listOrder(params.Cursor, params.SortBy, params.SortDir, params.PerPage, pdfSortColumns)
+}
 
+// uploadListFilter holds the WHERE conditions and args shared by the audio
+// and PDF lists, whose rows are named by title or original filename.
+type uploadListFilter struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add appends a condition, with %s standing for the next placeholder.
+func (f *uploadListFilter) add(clause string, arg interface{}) {
+	f.args = append(f.args, arg)
+	f.conditions = append(f.conditions, strings.ReplaceAll(clause, "%s", fmt.Sprintf("$%d", len(f.args))))
+}
+
+// newUploadListFilter builds the filters the audio and PDF lists share.
+func newUploadListFilter(status, search, dateFrom, dateTo string, apiKeyID, userID *string) *uploadListFilter {
+	f := &uploadListFilter{}
+	if status != "" {
+		f.add("status = %s", status)
+	}
+	if search != "" {
+		f.add("(title ILIKE %s OR original_name ILIKE %s)", "%"+search+"%")
+	}
+	if dateFrom != "" {
+		f.add("created_at >= %s", dateFrom)
+	}
+	if dateTo != "" {
+		f.add("created_at <= %s", dateTo)
+	}
+	if scope, arg := callerScope(apiKeyID, userID, "%s"); scope != "" {
+		f.add(scope, arg)
+	}
+	return f
+}
+
+// listPage counts the rows of table matching f, then selects one page of
+// columns into dest: the page after the cursor if there is one, else the
+// OFFSET page.
+func (db *DB) listPage(ctx context.Context, dest interface{}, table, columns string, f *uploadListFilter, after *cursor, sortBy, sortDir string, perPage, page int) (int, error) {
+	whereClause := ""
+	if len(f.conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(f.conditions, " AND ")
+	}
+	var total int
+	if err := db.reader().GetContext(ctx, &total, "SELECT COUNT(*) FROM "+table+" "+whereClause, f.args...); err != nil {
+		return 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	// The cursor condition is added after counting so total_items still
+	// covers every match
+	conditions, args := f.conditions, f.args
+	offset := (page - 1) * perPage
+	if after != nil {
+		clause, cursorArgs := after.condition(len(args) + 1)
+		conditions = append(conditions[:len(conditions):len(conditions)], clause)
+		args = append(args[:len(args):len(args)], cursorArgs...)
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		offset = 0
+	}
+	// id breaks ties so cursors have a unique position to resume from
+	query := fmt.Sprintf("SELECT %s FROM %s %s ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d",
+		columns, table, whereClause, sortBy, sortDir, sortDir, len(args)+1, len(args)+2)
+	args = append(args, perPage, offset)
+	if err := db.reader().SelectContext(ctx, dest, query, args...); err != nil {
+		return 0, fmt.Errorf("list query failed: %w", err)
+	}
+	return total, nil
+}
+
+// ListAudioTranscriptions returns a page of audio transcriptions with
+// optional filters, plus the total matching count. With params.Cursor set
+// it returns the page after the cursor and ignores params.Page. Transcript
+// text and segments are left empty unless params.IncludeText is set.
+func (db *DB) ListAudioTranscriptions(ctx context.Context, params models.AudioListParams) ([]models.AudioTranscription, int, error) {
+	after, err := decodeCursor(params.Cursor, audioSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	sortBy, sortDir, perPage := audioListOrder(params)
+
+	f := newUploadListFilter(params.Status, params.Search, params.DateFrom, params.DateTo, params.APIKeyID, params.UserID)
+	if params.ContentType != "" {
+		f.add("content_type = %s", params.ContentType)
+	}
+
+	var transcriptions []models.AudioTranscription
+	total, err := db.listPage(ctx, &transcriptions, "audio_transcriptions",
+		listColumns(params.IncludeText, audioColumns, audioSummaryColumns), f, after, sortBy, sortDir, perPage, params.Page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list audio transcriptions: %w", err)
+		return nil, 0, fmt.Errorf("failed to list audio transcriptions: %w", err)
 	}
-	return transcriptions, nil
+	if params.IncludeText {
+		if err := db.loadAudioTexts(ctx, transcriptions); err != nil {
+			return nil, 0, err
+		}
+	}
+	return transcriptions, total, nil
 }
 
 // SearchAudioTranscriptions performs full-text search across transcripts and summaries (MTA-25).
-func (db *DB) SearchAudioTranscriptions(ctx context.Context, params models.AudioSearchParams) ([]models.AudioTranscription, int, error) {
+// With a query, results are ordered by relevance and carry a rank, a snippet
+// of the matching transcript text, and a match count; without one they're
+// newest first. With params.Cursor set it returns the page after the cursor
+// and ignores params.Page.
+func (db *DB) SearchAudioTranscriptions(ctx context.Context, params models.AudioSearchParams) ([]models.AudioSearchResult, int, error) {
+	// SQLite has no ranking, so its matches are listed newest first
+	ranked := params.Query != "" && !db.isSQLite()
+	allowed := createdAtOnly
+	if ranked {
+		allowed = rankOnly
+	}
+	after, err := decodeCursor(params.Cursor, allowed)
+	if err != nil {
+		return nil, 0, err
+	}
 	if params.Page < 1 {
 		params.Page = 1
 	}
@@ -514,11 +1079,15 @@ func (db *DB) SearchAudioTranscriptions(ctx context.Context, params models.Audio
 	var args []interface{}
 	argNum := 1
 
-	if params.Query != "" {
-		conditions = append(conditions, fmt.Sprintf(
-			"to_tsvector('english', transcript_text || ' ' || summary_text) @@ plainto_tsquery('english', $%d)", argNum))
+	if ranked {
+		conditions = append(conditions, "search_vector @@ plainto_tsquery('english', $1)")
 		args = append(args, params.Query)
 		argNum++
+	} else if params.Query != "" {
+		match, matchArgs := sqliteMatch(params.Query, argNum)
+		conditions = append(conditions, match)
+		args = append(args, matchArgs...)
+		argNum += len(matchArgs)
 	}
 
 	if params.ContentType != "" {
@@ -535,35 +1104,90 @@ func (db *DB) SearchAudioTranscriptions(ctx context.Context, params models.Audio
 	// Count total
 	var total int
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM audio_transcriptions %s", whereClause)
-	if err := db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := db.reader().GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, fmt.Errorf("count query failed: %w", err)
 	}
 
 	// Fetch page
 	offset := (params.Page - 1) * params.PerPage
-	selectQuery := fmt.Sprintf(
-		"SELECT * FROM audio_transcriptions %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d",
-		whereClause, argNum, argNum+1)
-	args = append(args, params.PerPage, offset)
+	// A rank cursor filters the ranked hits, since rank isn't a column
+	cursorWhere := ""
+	if after != nil {
+		clause, cursorArgs := after.condition(argNum)
+		if !ranked {
+			conditions = append(conditions, clause)
+			whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		} else {
+			cursorWhere = "WHERE " + clause
+		}
+		args = append(args, cursorArgs...)
+		argNum += len(cursorArgs)
+		offset = 0
+	}
+	columns := listColumns(params.IncludeText, audioColumns, audioSummaryColumns)
+
+	var selectQuery string
+	if !ranked {
+		selectQuery = fmt.Sprintf(
+			"SELECT %s FROM audio_transcriptions %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
+			columns, whereClause, argNum, argNum+1)
+		args = append(args, params.PerPage, offset)
+	} else {
+		// Rank and page first, then build snippets and match counts for just
+		// that page, since ts_headline is expensive
+		selectQuery = fmt.Sprintf(`
+			SELECT %s, page.rank,
+				ts_headline('english', a.transcript_text, plainto_tsquery('english', $1), $%d) AS snippet,
+				(SELECT COALESCE(SUM(cardinality(v.positions)), 0) FROM unnest(a.search_vector) v
+					WHERE v.lexeme = ANY(tsvector_to_array(to_tsvector('english', $1)))) AS match_count
+			FROM (
+				SELECT id, rank FROM (
+					SELECT id, ts_rank(search_vector, plainto_tsquery('english', $1)) AS rank
+					FROM audio_transcriptions %s
+				) hits %s
+				ORDER BY rank DESC, id DESC
+				LIMIT $%d OFFSET $%d
+			) page
+			JOIN audio_transcriptions a ON a.id = page.id
+			ORDER BY page.rank DESC, page.id DESC`,
+			qualifiedColumns(columns, "a"), argNum, whereClause, cursorWhere, argNum+1, argNum+2)
+		args = append(args, headlineOptions, params.PerPage, offset)
+	}
 
-	var results []models.AudioTranscription
-	if err := db.SelectContext(ctx, &results, selectQuery, args...); err != nil {
+	var results []models.AudioSearchResult
+	if err := db.reader().SelectContext(ctx, &results, selectQuery, args...); err != nil {
 		return nil, 0, fmt.Errorf("search query failed: %w", err)
 	}
+	for i := range results {
+		results[i].Snippet = formatSnippet(results[i].Snippet)
+		if ranked && results[i].Snippet == "" && results[i].TextKey != nil {
+			snippet, err := db.offloadedSnippet(ctx, *results[i].TextKey, params.Query, "plainto_tsquery")
+			if err != nil {
+				log.Printf("⚠️  Failed to build snippet for audio %s: %v", results[i].ID, err)
+			}
+			results[i].Snippet = snippet
+		}
+		if params.IncludeText {
+			if err := db.loadAudioText(ctx, &results[i].AudioTranscription); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
 
 	return results, total, nil
 }
 
 // DeleteAudioTranscription removes an audio transcription by ID.
 func (db *DB) DeleteAudioTranscription(ctx context.Context, id string) error {
-	result, err := db.ExecContext(ctx, `DELETE FROM audio_transcriptions WHERE id = $1`, id)
+	var key *string
+	err := db.GetContext(ctx, &key, `DELETE FROM audio_transcriptions WHERE id = $1 RETURNING text_key`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("audio transcription not found")
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete audio transcription: %w", err)
 	}
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("audio transcription not found")
-	}
+	db.deleteText(ctx, key)
 	return nil
 }
 
@@ -572,53 +1196,81 @@ func (db *DB) DeleteAudioTranscription(ctx context.Context, id string) error {
 // CreatePDFExtraction inserts a new PDF extraction record.
 func (db *DB) CreatePDFExtraction(ctx context.Context, pe *models.PDFExtraction) error {
 	query := `
-		INSERT INTO pdf_extractions (filename, original_name, page_count, text_content, word_count, status, error_message, api_key_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, created_at`
+		INSERT INTO pdf_extractions (filename, original_name, page_count, first_page, last_page, text_content, word_count,
+			status, error_message, api_key_id, tables, table_count, document_info, outline, source_type, source_url, markdown_content, batch_id, usage)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING id, created_at, updated_at`
 
 	return db.QueryRowContext(ctx, query,
-		pe.Filename, pe.OriginalName, pe.PageCount, pe.TextContent,
-		pe.WordCount, pe.Status, pe.ErrorMessage, pe.APIKeyID,
-	).Scan(&pe.ID, &pe.CreatedAt)
+		pe.Filename, pe.OriginalName, pe.PageCount, pe.FirstPage, pe.LastPage, pe.TextContent,
+		pe.WordCount, pe.Status, pe.ErrorMessage, pe.APIKeyID, jsonOr(pe.Tables, `[]`), pe.TableCount,
+		jsonOr(pe.DocumentInfo, `{}`), jsonOr(pe.Outline, `[]`), pdfSourceType(pe.SourceType), pe.SourceURL,
+		pe.MarkdownContent, pe.BatchID, pe.Usage,
+	).Scan(&pe.ID, &pe.CreatedAt, &pe.UpdatedAt)
 }
 
 // GetPDFExtraction retrieves a single PDF extraction by ID.
 func (db *DB) GetPDFExtraction(ctx context.Context, id string) (*models.PDFExtraction, error) {
 	var pe models.PDFExtraction
-	err := db.GetContext(ctx, &pe, `SELECT * FROM pdf_extractions WHERE id = $1`, id)
+	err := db.GetContext(ctx, &pe, `SELECT `+pdfColumns+` FROM pdf_extractions WHERE id = $1`, id)
 	if err != nil {
 		return nil, fmt.Errorf("pdf extraction not found: %w", err)
 	}
 	return &pe, nil
 }
 
-// ListPDFExtractions returns recent PDF extractions.
-func (db *DB) ListPDFExtractions(ctx context.Context, limit int, apiKeyID *string) ([]models.PDFExtraction, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
-	var extractions []models.PDFExtraction
-	var err error
-	query := fmt.Sprintf(
-		`SELECT * FROM pdf_extractions
-		 %s
-		 ORDER BY created_at DESC
-		 LIMIT %d`,
-		buildAPIKeyWhereClause(apiKeyID), limit,
+// UpdatePDFExtraction saves the result of re-extracting a PDF.
+func (db *DB) UpdatePDFExtraction(ctx context.Context, pe *models.PDFExtraction) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE pdf_extractions
+		SET page_count = $2, first_page = $3, last_page = $4, text_content = $5, word_count = $6,
+			status = $7, error_message = $8, tables = $9, table_count = $10, document_info = $11, outline = $12,
+			markdown_content = $13, usage = $14
+		WHERE id = $1`,
+		pe.ID, pe.PageCount, pe.FirstPage, pe.LastPage, pe.TextContent, pe.WordCount, pe.Status, pe.ErrorMessage,
+		jsonOr(pe.Tables, `[]`), pe.TableCount, jsonOr(pe.DocumentInfo, `{}`), jsonOr(pe.Outline, `[]`),
+		pe.MarkdownContent, pe.Usage,
 	)
-	err = db.SelectContext(ctx, &extractions, query)
+	return err
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pdf extractions: %w", err)
+// pdfSourceType is an extraction's source type, defaulting to a PDF upload.
+func pdfSourceType(sourceType string) string {
+	if sourceType == "" {
+		return models.SourcePDF
+	}
+	return sourceType
+}
+
+// jsonOr is v as stored in a NOT NULL JSON column, with empty as the
+// column's empty value.
+func jsonOr(v json.RawMessage, empty string) json.RawMessage {
+	if len(v) == 0 {
+		return json.RawMessage(empty)
 	}
-	return extractions, nil
+	return v
 }
 
-func buildAPIKeyWhereClause(apiKeyID *string) string {
-	if apiKeyID == nil {
-		return ""
+// ListPDFExtractions is ListAudioTranscriptions for PDF extractions.
+// Extracted text is left empty unless params.IncludeText is set.
+func (db *DB) ListPDFExtractions(ctx context.Context, params models.PDFListParams) ([]models.PDFExtraction, int, error) {
+	after, err := decodeCursor(params.Cursor, pdfSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	sortBy, sortDir, perPage := pdfListOrder(params)
+
+	f := newUploadListFilter(params.Status, params.Search, params.DateFrom, params.DateTo, params.APIKeyID, params.UserID)
+	var extractions []models.PDFExtraction
+	total, err := db.listPage(ctx, &extractions, "pdf_extractions",
+		listColumns(params.IncludeText, pdfColumns, pdfSummaryColumns), f, after, sortBy, sortDir, perPage, params.Page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pdf extractions: %w", err)
 	}
-	return "WHERE api_key_id = " + pq.QuoteLiteral(*apiKeyID)
+	return extractions, total, nil
 }
 
 // DeletePDFExtraction removes a PDF extraction by ID.
@@ -0,0 +1,57 @@
+//go:build cgo
+
+// database_test.go contains tests for read replica routing, using two
+// in-memory SQLite databases as primary and replica.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestReplicaRouting verifies that heavy reads go to the replica when one
+// is configured, while writes and point reads stay on the primary.
+func TestReplicaRouting(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	if err := db.ConnectReplica("postgres://replica.invalid/media_tools"); err == nil {
+		t.Error("ConnectReplica succeeded on a SQLite database")
+	}
+	if db.HasReplica() || db.reader() != db.DB {
+		t.Fatal("reader() without a replica should be the primary")
+	}
+
+	tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Title: "Primary only", Status: "completed"}
+	if err := db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+	if _, total, err := db.SearchText(ctx, models.TextSearchParams{Query: "primary"}, nil); err != nil || total != 1 {
+		t.Fatalf("SearchText on primary = %d, %v; want 1", total, err)
+	}
+
+	// The replica hasn't caught up, so it's empty
+	db.replica = openSQLite(t).DB
+	if !db.HasReplica() {
+		t.Fatal("HasReplica() = false with a replica")
+	}
+	if _, total, err := db.SearchText(ctx, models.TextSearchParams{Query: "primary"}, nil); err != nil || total != 0 {
+		t.Errorf("SearchText with replica = %d, %v; want 0 from the replica", total, err)
+	}
+	if items, err := db.ListExportItems(ctx, models.ExportFilter{}, nil, nil, 10); err != nil || len(items) != 0 {
+		t.Errorf("ListExportItems with replica = %d items, %v; want 0 from the replica", len(items), err)
+	}
+	if count, err := db.CountItemsByStatus(ctx); err != nil || count["transcript"]["completed"] != 0 {
+		t.Errorf("CountItemsByStatus with replica = %v, %v; want no items from the replica", count, err)
+	}
+
+	key := &models.APIKey{KeyHash: "hash", KeyPrefix: "mta_abc", Name: "dev", Active: true, Tier: models.TierFree, Role: "member"}
+	if err := db.CreateAPIKey(ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if got, err := db.GetAPIKeyByHash(ctx, "hash"); err != nil || got.ID != key.ID {
+		t.Errorf("GetAPIKeyByHash = %+v, %v; want the primary's row", got, err)
+	}
+}
@@ -0,0 +1,132 @@
+// digests.go contains database operations for weekly digests and users'
+// digest subscriptions.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// GetDigestSettings returns a user's digest subscription; Enabled is false
+// if they haven't subscribed.
+func (db *DB) GetDigestSettings(ctx context.Context, userID string) (models.DigestSettings, error) {
+	var s models.DigestSettings
+	err := db.GetContext(ctx, &s,
+		`SELECT email, webhook FROM digest_subscriptions WHERE user_id = $1`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DigestSettings{Email: true}, nil
+	}
+	if err != nil {
+		return models.DigestSettings{}, fmt.Errorf("failed to load digest settings: %w", err)
+	}
+	s.Enabled = true
+	return s, nil
+}
+
+// SaveDigestSettings subscribes a user to weekly digests, or unsubscribes
+// them if s.Enabled is false. Their past digests are kept either way.
+func (db *DB) SaveDigestSettings(ctx context.Context, userID string, s models.DigestSettings) error {
+	var err error
+	if s.Enabled {
+		_, err = db.ExecContext(ctx, `
+			INSERT INTO digest_subscriptions (user_id, email, webhook) VALUES ($1, $2, $3)
+			ON CONFLICT (user_id) DO UPDATE SET email = EXCLUDED.email, webhook = EXCLUDED.webhook`,
+			userID, s.Email, s.Webhook)
+	} else {
+		_, err = db.ExecContext(ctx, `DELETE FROM digest_subscriptions WHERE user_id = $1`, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save digest settings: %w", err)
+	}
+	return nil
+}
+
+// ListUsersDueForDigest returns subscribed users with no digest ending
+// after since — none in the past week, when since is a week ago. Accounts
+// pending deletion are skipped.
+func (db *DB) ListUsersDueForDigest(ctx context.Context, since time.Time, limit int) ([]string, error) {
+	var ids []string
+	err := db.SelectContext(ctx, &ids, `
+		SELECT s.user_id FROM digest_subscriptions s
+		JOIN users u ON u.id = s.user_id
+		WHERE u.deletion_scheduled_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM digests d WHERE d.user_id = s.user_id AND d.period_end > $1)
+		ORDER BY s.created_at
+		LIMIT $2`, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users due for a digest: %w", err)
+	}
+	return ids, nil
+}
+
+// ListDigestItems returns a user's completed items created in [start, end),
+// oldest first, up to limit.
+func (db *DB) ListDigestItems(ctx context.Context, userID string, start, end time.Time, limit int) ([]models.ExportItem, error) {
+	query := fmt.Sprintf(`
+		SELECT type, id FROM (
+			SELECT 'transcript' AS type, id, created_at FROM transcripts
+			WHERE %[1]s AND status = 'completed' AND created_at >= $2 AND created_at < $3
+			UNION ALL
+			SELECT 'audio', id, created_at FROM audio_transcriptions
+			WHERE %[1]s AND status = 'completed' AND created_at >= $2 AND created_at < $3
+			UNION ALL
+			SELECT 'pdf', id, created_at FROM pdf_extractions
+			WHERE %[1]s AND status = 'completed' AND created_at >= $2 AND created_at < $3
+		) items
+		ORDER BY created_at
+		LIMIT $4`, ownedByUser)
+
+	var items []models.ExportItem
+	if err := db.reader().SelectContext(ctx, &items, query, userID, start, end, limit); err != nil {
+		return nil, fmt.Errorf("failed to list digest items: %w", err)
+	}
+	return items, nil
+}
+
+// CreateDigest inserts a newly generated digest.
+func (db *DB) CreateDigest(ctx context.Context, d *models.Digest) error {
+	query := `
+		INSERT INTO digests (user_id, period_start, period_end, item_count, model_used, content)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		d.UserID, d.PeriodStart, d.PeriodEnd, d.ItemCount, d.ModelUsed, d.Content,
+	).Scan(&d.ID, &d.CreatedAt)
+}
+
+// MarkDigestDelivered records that a digest was sent.
+func (db *DB) MarkDigestDelivered(ctx context.Context, id string) error {
+	_, err := db.ExecContext(ctx, `UPDATE digests SET delivered_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark digest delivered: %w", err)
+	}
+	return nil
+}
+
+// ListDigests returns a user's most recent digests, newest first.
+func (db *DB) ListDigests(ctx context.Context, userID string, limit int) ([]models.Digest, error) {
+	digests := []models.Digest{}
+	err := db.SelectContext(ctx, &digests,
+		`SELECT * FROM digests WHERE user_id = $1 ORDER BY period_end DESC LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digests: %w", err)
+	}
+	return digests, nil
+}
+
+// GetDigest returns one of a user's digests. Returns sql.ErrNoRows
+// (wrapped) if it doesn't exist or belongs to someone else.
+func (db *DB) GetDigest(ctx context.Context, userID, id string) (*models.Digest, error) {
+	var d models.Digest
+	err := db.GetContext(ctx, &d, `SELECT * FROM digests WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("digest not found: %w", err)
+	}
+	return &d, nil
+}
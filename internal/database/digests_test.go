@@ -0,0 +1,95 @@
+//go:build cgo
+
+// digests_test.go contains tests for weekly digest subscriptions and the
+// items a digest covers, run against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestDigests verifies subscribed users are due until a digest covers the
+// past week, and that only their completed items in the period are listed.
+func TestDigests(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	user := &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	if err := db.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	key := &models.APIKey{KeyHash: "hash", KeyPrefix: "mta_abc", Name: "dev", Active: true, Tier: models.TierFree, Role: "member"}
+	if err := db.CreateAPIKey(ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if err := db.LinkAPIKeyToUser(ctx, key.ID, user.ID); err != nil {
+		t.Fatalf("LinkAPIKeyToUser: %v", err)
+	}
+
+	if s, err := db.GetDigestSettings(ctx, user.ID); err != nil || s.Enabled {
+		t.Fatalf("GetDigestSettings before subscribing = %+v, %v", s, err)
+	}
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	if ids, err := db.ListUsersDueForDigest(ctx, weekAgo, 10); err != nil || len(ids) != 0 {
+		t.Fatalf("ListUsersDueForDigest before subscribing = %v, %v", ids, err)
+	}
+	if err := db.SaveDigestSettings(ctx, user.ID, models.DigestSettings{Enabled: true, Webhook: true}); err != nil {
+		t.Fatalf("SaveDigestSettings: %v", err)
+	}
+	if s, err := db.GetDigestSettings(ctx, user.ID); err != nil || s != (models.DigestSettings{Enabled: true, Webhook: true}) {
+		t.Fatalf("GetDigestSettings = %+v, %v", s, err)
+	}
+	if ids, err := db.ListUsersDueForDigest(ctx, weekAgo, 10); err != nil || len(ids) != 1 || ids[0] != user.ID {
+		t.Fatalf("ListUsersDueForDigest = %v, %v; want [%s]", ids, err, user.ID)
+	}
+
+	create := func(status models.TranscriptStatus, apiKeyID *string) *models.Transcript {
+		tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Status: status, APIKeyID: apiKeyID}
+		if err := db.CreateTranscript(ctx, tr); err != nil {
+			t.Fatalf("CreateTranscript: %v", err)
+		}
+		return tr
+	}
+	done := create(models.StatusCompleted, &key.ID)
+	create(models.StatusProcessing, &key.ID)
+	create(models.StatusCompleted, nil)
+	old := create(models.StatusCompleted, &key.ID)
+	if _, err := db.ExecContext(ctx, `UPDATE transcripts SET created_at = $1 WHERE id = $2`, weekAgo.Add(-time.Hour), old.ID); err != nil {
+		t.Fatalf("backdating transcript: %v", err)
+	}
+
+	now := time.Now().Add(time.Minute)
+	items, err := db.ListDigestItems(ctx, user.ID, weekAgo, now, 10)
+	if err != nil || len(items) != 1 || items[0].ID != done.ID {
+		t.Fatalf("ListDigestItems = %+v, %v; want only %s", items, err, done.ID)
+	}
+
+	digest := &models.Digest{UserID: user.ID, PeriodStart: weekAgo, PeriodEnd: now, ItemCount: 1, Content: []byte("{}")}
+	if err := db.CreateDigest(ctx, digest); err != nil {
+		t.Fatalf("CreateDigest: %v", err)
+	}
+	if ids, err := db.ListUsersDueForDigest(ctx, weekAgo, 10); err != nil || len(ids) != 0 {
+		t.Errorf("ListUsersDueForDigest after a digest = %v, %v; want none", ids, err)
+	}
+	if err := db.MarkDigestDelivered(ctx, digest.ID); err != nil {
+		t.Fatalf("MarkDigestDelivered: %v", err)
+	}
+	got, err := db.GetDigest(ctx, user.ID, digest.ID)
+	if err != nil || got.DeliveredAt == nil {
+		t.Errorf("GetDigest = %+v, %v; want delivered", got, err)
+	}
+	if _, err := db.GetDigest(ctx, "00000000-0000-0000-0000-000000000000", digest.ID); err == nil {
+		t.Error("GetDigest for another user succeeded")
+	}
+
+	if err := db.SaveDigestSettings(ctx, user.ID, models.DigestSettings{}); err != nil {
+		t.Fatalf("SaveDigestSettings to unsubscribe: %v", err)
+	}
+	if list, err := db.ListDigests(ctx, user.ID, 10); err != nil || len(list) != 1 {
+		t.Errorf("ListDigests after unsubscribing = %d, %v; want the digest kept", len(list), err)
+	}
+}
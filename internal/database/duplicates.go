@@ -0,0 +1,247 @@
+// duplicates.go contains database operations for finding and merging
+// transcripts of the same video that were extracted more than once (a
+// forced refresh, or different keys with the global cache off).
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxDuplicateCandidates caps the transcripts FindDuplicateTranscripts
+// groups in one call; merging the groups found shrinks the rest.
+const maxDuplicateCandidates = 1000
+
+// contentHash returns the hex SHA-256 of a transcript's text, or nil for
+// no text.
+func contentHash(text string) *string {
+	if text == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(text))
+	hash := hex.EncodeToString(sum[:])
+	return &hash
+}
+
+// FindDuplicateTranscripts groups finished transcripts that share a
+// youtube_id or content hash with another of the same redaction state,
+// scoped like ListTranscripts when apiKeyID or userID is set. Groups
+// without a completed transcript to keep are left out.
+func (db *DB) FindDuplicateTranscripts(ctx context.Context, apiKeyID, userID *string) ([]models.DuplicateGroup, error) {
+	scope := ""
+	var args []interface{}
+	if condition, arg := callerScope(apiKeyID, userID, "$1"); condition != "" {
+		scope = "AND " + condition
+		args = append(args, arg)
+	}
+	query := fmt.Sprintf(`
+		WITH visible AS (
+			SELECT %s FROM transcripts
+			WHERE status IN ('completed', 'failed') %s
+		)
+		SELECT t.* FROM visible t
+		WHERE EXISTS (
+			SELECT 1 FROM visible d
+			WHERE d.id <> t.id
+				AND (d.redaction IS NULL) = (t.redaction IS NULL)
+				AND (d.youtube_id = t.youtube_id OR d.content_hash = t.content_hash)
+		)
+		ORDER BY t.created_at, t.id
+		LIMIT %d`, transcriptSummaryColumns, scope, maxDuplicateCandidates)
+
+	var transcripts []models.Transcript
+	if err := db.reader().SelectContext(ctx, &transcripts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find duplicate transcripts: %w", err)
+	}
+	return groupDuplicates(transcripts), nil
+}
+
+// groupDuplicates links transcripts (oldest first) into groups through
+// shared youtube_ids and content hashes.
+func groupDuplicates(transcripts []models.Transcript) []models.DuplicateGroup {
+	// Union-find over indexes into transcripts
+	parent := make([]int, len(transcripts))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// The first transcript seen for each key; keys carry the redaction
+	// state so redacted and unredacted copies stay apart
+	firstByKey := make(map[string]int)
+	link := func(i int, key string) {
+		if first, ok := firstByKey[key]; ok {
+			parent[find(i)] = find(first)
+		} else {
+			firstByKey[key] = i
+		}
+	}
+	for i, t := range transcripts {
+		redacted := fmt.Sprint(t.Redaction != nil)
+		link(i, redacted+"/youtube_id/"+t.YouTubeID)
+		if t.ContentHash != nil {
+			link(i, redacted+"/content_hash/"+*t.ContentHash)
+		}
+	}
+
+	var groups []models.DuplicateGroup
+	byRoot := make(map[int]int) // Root index → index into groups
+	for i, t := range transcripts {
+		root := find(i)
+		g, ok := byRoot[root]
+		if !ok {
+			g = len(groups)
+			byRoot[root] = g
+			groups = append(groups, models.DuplicateGroup{YouTubeID: t.YouTubeID})
+		}
+		groups[g].Transcripts = append(groups[g].Transcripts, t)
+	}
+
+	kept := groups[:0]
+	for _, g := range groups {
+		for _, t := range g.Transcripts {
+			if t.Status == models.StatusCompleted {
+				g.KeepID, g.YouTubeID = t.ID, t.YouTubeID
+				break
+			}
+		}
+		if g.KeepID == "" || len(g.Transcripts) < 2 {
+			continue
+		}
+		g.MatchedBy = matchedBy(g.Transcripts)
+		kept = append(kept, g)
+	}
+	return kept
+}
+
+// matchedBy reports which keys link a group's transcripts: "youtube_id"
+// when some of them share one, "content_hash" when some share a hash.
+func matchedBy(transcripts []models.Transcript) []string {
+	youtubeIDs := make(map[string]bool)
+	hashes := make(map[string]bool)
+	var sharedID, sharedHash bool
+	for _, t := range transcripts {
+		sharedID = sharedID || youtubeIDs[t.YouTubeID]
+		youtubeIDs[t.YouTubeID] = true
+		if t.ContentHash != nil {
+			sharedHash = sharedHash || hashes[*t.ContentHash]
+			hashes[*t.ContentHash] = true
+		}
+	}
+
+	var by []string
+	if sharedID {
+		by = append(by, "youtube_id")
+	}
+	if sharedHash {
+		by = append(by, "content_hash")
+	}
+	return by
+}
+
+// MergeTranscripts folds duplicateIDs into keepID in one transaction: their
+// summaries, study aids, articles, pipeline runs, and share links move to
+// the kept transcript, as do chat sessions and workspace entries unless the
+// same key or user already has one on it. The duplicates are then deleted, with
+// anything left on them.
+func (db *DB) MergeTranscripts(ctx context.Context, keepID string, duplicateIDs []string) error {
+	keys, err := db.mergeTranscripts(ctx, keepID, duplicateIDs)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		db.deleteText(ctx, k.Text, k.Original)
+	}
+	return nil
+}
+
+// textKeys are the storage keys of a deleted transcript's offloaded text.
+type textKeys struct {
+	Text     *string `db:"text_key"`
+	Original *string `db:"original_text_key"`
+}
+
+// mergeTranscripts runs MergeTranscripts' transaction, returning the text
+// keys of the deleted duplicates.
+func (db *DB) mergeTranscripts(ctx context.Context, keepID string, duplicateIDs []string) ([]textKeys, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge transcripts: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := pq.Array(duplicateIDs)
+	for _, query := range []string{
+		`UPDATE summaries SET transcript_id = $1 WHERE transcript_id = ANY($2)`,
+		`UPDATE study_aids SET transcript_id = $1 WHERE transcript_id = ANY($2)`,
+		`UPDATE articles SET transcript_id = $1 WHERE transcript_id = ANY($2)`,
+		`UPDATE pipeline_runs SET transcript_id = $1 WHERE transcript_id = ANY($2)`,
+		`UPDATE share_links SET item_id = $1 WHERE item_type = 'transcript' AND item_id = ANY($2)`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, keepID, ids); err != nil {
+			return nil, fmt.Errorf("failed to merge transcripts: %w", err)
+		}
+	}
+
+	// Chat sessions, workspace entries, and entity mentions are unique per
+	// key, user, or entity, so they move one duplicate at a time, each only
+	// where the kept transcript has none yet
+	for _, id := range duplicateIDs {
+		for _, query := range []string{
+			`UPDATE transcript_chat_sessions SET transcript_id = $1, item_id = $1
+			WHERE item_type = 'transcript' AND item_id = $2
+				AND NOT EXISTS (
+					SELECT 1 FROM transcript_chat_sessions k
+					WHERE k.item_type = 'transcript' AND k.item_id = $1
+						AND k.api_key_id = transcript_chat_sessions.api_key_id
+				)`,
+			`UPDATE workspace_items SET item_id = $1
+			WHERE item_type = 'transcript' AND item_id = $2
+				AND NOT EXISTS (
+					SELECT 1 FROM workspace_items k
+					WHERE k.item_type = 'transcript' AND k.item_id = $1
+						AND k.user_id = workspace_items.user_id
+				)`,
+			`UPDATE entity_mentions SET item_id = $1
+			WHERE item_type = 'transcript' AND item_id = $2
+				AND NOT EXISTS (
+					SELECT 1 FROM entity_mentions k
+					WHERE k.item_type = 'transcript' AND k.item_id = $1
+						AND k.entity_id = entity_mentions.entity_id
+				)`,
+		} {
+			if _, err := tx.ExecContext(ctx, query, keepID, id); err != nil {
+				return nil, fmt.Errorf("failed to merge transcripts: %w", err)
+			}
+		}
+	}
+	for _, table := range []string{"transcript_chat_sessions", "workspace_items", "entity_mentions"} {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE item_type = 'transcript' AND item_id = ANY($1)`, table)
+		if _, err := tx.ExecContext(ctx, query, ids); err != nil {
+			return nil, fmt.Errorf("failed to merge transcripts: %w", err)
+		}
+	}
+
+	var keys []textKeys
+	err = tx.SelectContext(ctx, &keys,
+		`DELETE FROM transcripts WHERE id = ANY($1) RETURNING text_key, original_text_key`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete merged transcripts: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to merge transcripts: %w", err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,79 @@
+//go:build cgo
+
+// duplicates_test.go contains tests for finding and merging duplicate
+// transcripts, run against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestDuplicateTranscripts verifies copies of a video are grouped by
+// youtube_id or content hash, apart from redacted copies, and that merging
+// moves their summaries and chats to the kept transcript.
+func TestDuplicateTranscripts(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	create := func(youtubeID, text string, status models.TranscriptStatus) *models.Transcript {
+		tr := &models.Transcript{YouTubeURL: "https://youtu.be/" + youtubeID, YouTubeID: youtubeID, Status: status, TranscriptText: text}
+		if err := db.CreateTranscript(ctx, tr); err != nil {
+			t.Fatalf("CreateTranscript: %v", err)
+		}
+		if err := db.UpdateTranscript(ctx, tr); err != nil {
+			t.Fatalf("UpdateTranscript: %v", err)
+		}
+		return tr
+	}
+	keep := create("abc", "the same words", models.StatusCompleted)
+	refresh := create("abc", "the same words", models.StatusCompleted)
+	failed := create("abc", "", models.StatusFailed)
+	reupload := create("xyz", "the same words", models.StatusCompleted)
+	redacted := create("abc", "the [NAME] words", models.StatusCompleted)
+	if _, err := db.ExecContext(ctx, `UPDATE transcripts SET redaction = '{}' WHERE id = $1`, redacted.ID); err != nil {
+		t.Fatalf("marking redacted: %v", err)
+	}
+	create("other", "different words", models.StatusCompleted)
+
+	groups, err := db.FindDuplicateTranscripts(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("FindDuplicateTranscripts: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %+v", len(groups), groups)
+	}
+	g := groups[0]
+	if g.KeepID != keep.ID || len(g.Transcripts) != 4 || len(g.MatchedBy) != 2 {
+		t.Errorf("group = keep %s, %d transcripts, matched by %v; want %s, 4, both", g.KeepID, len(g.Transcripts), g.MatchedBy, keep.ID)
+	}
+
+	for _, tr := range []*models.Transcript{keep, refresh} {
+		if _, err := db.GetOrCreateChatSession(ctx, "transcript", tr.ID, nil, nil); err != nil {
+			t.Fatalf("GetOrCreateChatSession: %v", err)
+		}
+	}
+	if err := db.CreateSummary(ctx, &models.Summary{TranscriptID: refresh.ID, ModelUsed: "m", SummaryText: "s", KeyPoints: []byte("[]")}); err != nil {
+		t.Fatalf("CreateSummary: %v", err)
+	}
+
+	if err := db.MergeTranscripts(ctx, keep.ID, []string{refresh.ID, failed.ID, reupload.ID}); err != nil {
+		t.Fatalf("MergeTranscripts: %v", err)
+	}
+	for _, tr := range []*models.Transcript{refresh, failed, reupload} {
+		if _, err := db.GetTranscript(ctx, tr.ID); err == nil {
+			t.Errorf("duplicate %s wasn't deleted", tr.YouTubeID)
+		}
+	}
+	var summaries, sessions int
+	db.GetContext(ctx, &summaries, `SELECT COUNT(*) FROM summaries WHERE transcript_id = $1`, keep.ID)
+	db.GetContext(ctx, &sessions, `SELECT COUNT(*) FROM transcript_chat_sessions WHERE item_id = $1 AND transcript_id = $1`, keep.ID)
+	if summaries != 1 || sessions != 2 {
+		t.Errorf("kept transcript has %d summaries and %d chat sessions, want 1 and 2", summaries, sessions)
+	}
+	if groups, _ := db.FindDuplicateTranscripts(ctx, nil, nil); len(groups) != 0 {
+		t.Errorf("groups after merging = %+v, want none", groups)
+	}
+}
@@ -0,0 +1,103 @@
+// edits.go contains database operations for manual transcript corrections.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CorrectTranscript replaces a transcript's text and segments with a manual
+// correction and records it in the edit history, in one transaction.
+//
+// The first correction copies the extracted text and segments into
+// original_text/original_segments. Summaries, study aids, and articles of
+// the transcript are flagged stale, since they describe the old text.
+//
+// A corrected body over the offload threshold is stored in the text store.
+// An offloaded extracted text becomes the original as is; later versions
+// are removed once they're superseded. The edit history keeps every
+// version in the database.
+func (db *DB) CorrectTranscript(ctx context.Context, e *models.TranscriptEdit) error {
+	text, key := db.offloadText(ctx, "transcript", e.TranscriptID, e.TranscriptText)
+	superseded, err := db.correctTranscript(ctx, e, text, key)
+	db.replaceText(ctx, err, superseded, key)
+	return err
+}
+
+// correctTranscript runs CorrectTranscript's transaction, storing text and
+// key as the new body. It returns the key of an offloaded body the
+// correction replaced, unless that body became the original.
+func (db *DB) correctTranscript(ctx context.Context, e *models.TranscriptEdit, text string, key *string) (*string, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save transcript correction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current struct {
+		TextKey  *string    `db:"text_key"`
+		EditedAt *time.Time `db:"edited_at"`
+	}
+	err = tx.GetContext(ctx, &current,
+		`SELECT text_key, edited_at FROM transcripts WHERE id = $1 FOR UPDATE`, e.TranscriptID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("transcript not found: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save transcript correction: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE transcripts SET
+			original_text = CASE WHEN edited_at IS NULL THEN transcript_text ELSE original_text END,
+			original_text_key = CASE WHEN edited_at IS NULL THEN text_key ELSE original_text_key END,
+			original_segments = CASE WHEN edited_at IS NULL THEN segments ELSE original_segments END,
+			transcript_text = $2, segments = $3, word_count = $4, text_key = $5, edited_at = NOW(),
+			offloaded_vector = `+db.offloadedVector("$6")+`
+		WHERE id = $1`,
+		e.TranscriptID, text, e.Segments, e.WordCount, key, offloadedBody(e.TranscriptText, key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save transcript correction: %w", err)
+	}
+
+	for _, table := range []string{"summaries", "study_aids", "articles"} {
+		query := fmt.Sprintf(`UPDATE %s SET stale = TRUE WHERE transcript_id = $1`, table)
+		if _, err := tx.ExecContext(ctx, query, e.TranscriptID); err != nil {
+			return nil, fmt.Errorf("failed to mark %s stale: %w", table, err)
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO transcript_edits (transcript_id, transcript_text, segments, word_count, api_key_id, user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		e.TranscriptID, e.TranscriptText, e.Segments, e.WordCount, e.APIKeyID, e.UserID,
+	).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save transcript correction: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to save transcript correction: %w", err)
+	}
+
+	if current.EditedAt == nil {
+		return nil, nil // The extracted text is now the original
+	}
+	return current.TextKey, nil
+}
+
+// ListTranscriptEdits returns a transcript's corrections, newest first.
+func (db *DB) ListTranscriptEdits(ctx context.Context, transcriptID string) ([]models.TranscriptEdit, error) {
+	var edits []models.TranscriptEdit
+	err := db.SelectContext(ctx, &edits,
+		`SELECT * FROM transcript_edits WHERE transcript_id = $1 ORDER BY created_at DESC`, transcriptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transcript edits: %w", err)
+	}
+	return edits, nil
+}
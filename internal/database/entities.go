@@ -0,0 +1,170 @@
+// entities.go contains database operations for entities — people,
+// companies, products, and places — and the items that mention them.
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// entityItems joins entity_mentions (as m) to the items they point at, so
+// mentions of deleted items drop out and items can be scoped by key.
+func entityItems() string {
+	parts := make([]string, 0, len(itemTypes))
+	for _, t := range itemTypes {
+		parts = append(parts, itemSources[t])
+	}
+	return fmt.Sprintf("JOIN (%s) items ON items.type = m.item_type AND items.id = m.item_id",
+		strings.Join(parts, " UNION ALL "))
+}
+
+// SaveItemEntities replaces the entities found in an item, creating
+// entities seen for the first time. An entity is matched by type and its
+// lowercased name; the first spelling seen is kept. Each entity's
+// EntityID is set.
+func (db *DB) SaveItemEntities(ctx context.Context, itemType, itemID string, found []models.ItemEntity) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to save entities: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM entity_mentions WHERE item_type = $1 AND item_id = $2`, itemType, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to clear entities: %w", err)
+	}
+	for i := range found {
+		e := &found[i]
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO entities (name, entity_type, normalized_name) VALUES ($1, $2, $3)
+			ON CONFLICT (entity_type, normalized_name) DO UPDATE SET name = entities.name
+			RETURNING id`,
+			e.Name, e.Type, strings.ToLower(e.Name),
+		).Scan(&e.EntityID)
+		if err != nil {
+			return fmt.Errorf("failed to save entity %q: %w", e.Name, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO entity_mentions (entity_id, item_type, item_id, mention_count, context)
+			VALUES ($1, $2, $3, $4, $5)`,
+			e.EntityID, itemType, itemID, e.Mentions, e.Context)
+		if err != nil {
+			return fmt.Errorf("failed to save mention of %q: %w", e.Name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save entities: %w", err)
+	}
+	return nil
+}
+
+// ListEntities returns a page of entities mentioned in items, most widely
+// mentioned first, plus the total matching count. With params.APIKeyID set
+// only that key's and its organization's items count, and entities none of
+// them mention are left out.
+func (db *DB) ListEntities(ctx context.Context, params models.EntityListParams) ([]models.Entity, int, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if params.Type != "" {
+		conditions = append(conditions, fmt.Sprintf("e.entity_type = $%d", argNum))
+		args = append(args, params.Type)
+		argNum++
+	}
+	if params.Search != "" {
+		conditions = append(conditions, fmt.Sprintf("e.name ILIKE $%d", argNum))
+		args = append(args, "%"+params.Search+"%")
+		argNum++
+	}
+	if scope, arg := callerScope(params.APIKeyID, params.UserID, fmt.Sprintf("$%d", argNum)); scope != "" {
+		conditions = append(conditions, scope)
+		args = append(args, arg)
+		argNum++
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	from := fmt.Sprintf("FROM entities e JOIN entity_mentions m ON m.entity_id = e.id %s %s", entityItems(), whereClause)
+
+	var total int
+	if err := db.reader().GetContext(ctx, &total, "SELECT COUNT(DISTINCT e.id) "+from, args...); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	// id breaks ties so pages don't overlap
+	query := fmt.Sprintf(`
+		SELECT e.id, e.name, e.entity_type, COUNT(*) AS item_count, SUM(m.mention_count) AS mention_count
+		%s
+		GROUP BY e.id, e.name, e.entity_type
+		ORDER BY item_count DESC, mention_count DESC, e.name, e.id
+		LIMIT $%d OFFSET $%d`, from, argNum, argNum+1)
+	args = append(args, params.PerPage, (params.Page-1)*params.PerPage)
+
+	entities := []models.Entity{}
+	if err := db.reader().SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	return entities, total, nil
+}
+
+// GetEntity returns an entity by ID, without counts.
+func (db *DB) GetEntity(ctx context.Context, id string) (*models.Entity, error) {
+	var e models.Entity
+	err := db.GetContext(ctx, &e, `SELECT id, name, entity_type FROM entities WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("entity not found: %w", err)
+	}
+	return &e, nil
+}
+
+// ListEntityMentions returns a page of the items that mention an entity,
+// newest first, plus the total count. params.APIKeyID scopes the items
+// like ListEntities.
+func (db *DB) ListEntityMentions(ctx context.Context, entityID string, params models.EntityMentionParams) ([]models.EntityMention, int, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+
+	where := "WHERE m.entity_id = $1"
+	args := []interface{}{entityID}
+	if scope, arg := callerScope(params.APIKeyID, params.UserID, "$2"); scope != "" {
+		where += " AND " + scope
+		args = append(args, arg)
+	}
+	from := fmt.Sprintf("FROM entity_mentions m %s %s", entityItems(), where)
+
+	var total int
+	if err := db.reader().GetContext(ctx, &total, "SELECT COUNT(*) "+from, args...); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT m.item_type, m.item_id, items.title, m.mention_count, m.context, items.created_at
+		%s
+		ORDER BY items.created_at DESC, m.item_id
+		LIMIT $%d OFFSET $%d`, from, len(args)+1, len(args)+2)
+	args = append(args, params.PerPage, (params.Page-1)*params.PerPage)
+
+	mentions := []models.EntityMention{}
+	if err := db.reader().SelectContext(ctx, &mentions, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list entity mentions: %w", err)
+	}
+	return mentions, total, nil
+}
@@ -0,0 +1,88 @@
+//go:build cgo
+
+// entities_test.go contains tests for saving the entities found in items
+// and listing them, run against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestEntities verifies entities are shared across items by type and
+// lowercased name, that re-extracting replaces an item's mentions, and that
+// listings only count the caller's items.
+func TestEntities(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	mine := &models.APIKey{KeyHash: "hash1", KeyPrefix: "mta_abc", Name: "mine", Active: true, Tier: models.TierFree, Role: "member"}
+	theirs := &models.APIKey{KeyHash: "hash2", KeyPrefix: "mta_def", Name: "theirs", Active: true, Tier: models.TierFree, Role: "member"}
+	for _, k := range []*models.APIKey{mine, theirs} {
+		if err := db.CreateAPIKey(ctx, k); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+	}
+	create := func(keyID string) *models.Transcript {
+		tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Status: models.StatusCompleted, APIKeyID: &keyID}
+		if err := db.CreateTranscript(ctx, tr); err != nil {
+			t.Fatalf("CreateTranscript: %v", err)
+		}
+		return tr
+	}
+	first, second, other := create(mine.ID), create(mine.ID), create(theirs.ID)
+
+	save := func(id string, found ...models.ItemEntity) []models.ItemEntity {
+		if err := db.SaveItemEntities(ctx, "transcript", id, found); err != nil {
+			t.Fatalf("SaveItemEntities: %v", err)
+		}
+		return found
+	}
+	saved := save(first.ID,
+		models.ItemEntity{Name: "Ada Lovelace", Type: "person", Mentions: 3, Context: "Ada Lovelace wrote it."},
+		models.ItemEntity{Name: "London", Type: "place", Mentions: 1},
+	)
+	again := save(second.ID, models.ItemEntity{Name: "ada lovelace", Type: "person", Mentions: 2})
+	save(other.ID, models.ItemEntity{Name: "Ada Lovelace", Type: "person", Mentions: 5})
+	if saved[0].EntityID == "" || again[0].EntityID != saved[0].EntityID {
+		t.Fatalf("entity IDs = %q, %q; want the same entity", saved[0].EntityID, again[0].EntityID)
+	}
+
+	entities, total, err := db.ListEntities(ctx, models.EntityListParams{APIKeyID: &mine.ID})
+	if err != nil || total != 2 || len(entities) != 2 {
+		t.Fatalf("ListEntities = %+v, %d, %v; want 2", entities, total, err)
+	}
+	if e := entities[0]; e.Name != "Ada Lovelace" || e.ItemCount != 2 || e.MentionCount != 5 {
+		t.Errorf("top entity = %+v; want Ada Lovelace in 2 items, 5 mentions", e)
+	}
+	if entities, total, err := db.ListEntities(ctx, models.EntityListParams{Type: "place", Search: "lon"}); err != nil || total != 1 || entities[0].Name != "London" {
+		t.Errorf("ListEntities(place, lon) = %+v, %d, %v", entities, total, err)
+	}
+
+	mentions, total, err := db.ListEntityMentions(ctx, saved[0].EntityID, models.EntityMentionParams{APIKeyID: &mine.ID})
+	if err != nil || total != 2 || len(mentions) != 2 {
+		t.Fatalf("ListEntityMentions = %+v, %d, %v; want 2", mentions, total, err)
+	}
+	if _, total, _ := db.ListEntityMentions(ctx, saved[0].EntityID, models.EntityMentionParams{}); total != 3 {
+		t.Errorf("ListEntityMentions unscoped total = %d; want 3", total)
+	}
+
+	// Re-extracting replaces the item's mentions
+	save(first.ID, models.ItemEntity{Name: "Paris", Type: "place", Mentions: 1})
+	if _, total, _ := db.ListEntityMentions(ctx, saved[0].EntityID, models.EntityMentionParams{APIKeyID: &mine.ID}); total != 1 {
+		t.Errorf("ListEntityMentions after re-extracting = %d; want 1", total)
+	}
+
+	// Mentions of deleted items drop out
+	if err := db.DeleteTranscript(ctx, second.ID); err != nil {
+		t.Fatalf("DeleteTranscript: %v", err)
+	}
+	if _, total, _ := db.ListEntityMentions(ctx, saved[0].EntityID, models.EntityMentionParams{APIKeyID: &mine.ID}); total != 0 {
+		t.Errorf("ListEntityMentions after deleting = %d; want 0", total)
+	}
+	if e, err := db.GetEntity(ctx, saved[0].EntityID); err != nil || e.Name != "Ada Lovelace" || e.Type != "person" {
+		t.Errorf("GetEntity = %+v, %v", e, err)
+	}
+}
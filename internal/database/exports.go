@@ -0,0 +1,112 @@
+// exports.go contains database operations for bulk ZIP exports.
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CreateExport inserts a pending export record.
+func (db *DB) CreateExport(ctx context.Context, e *models.Export) error {
+	if e.Kind == "" {
+		e.Kind = models.ExportKindItems
+	}
+	query := `
+		INSERT INTO exports (api_key_id, user_id, kind, status, format, items, item_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		e.APIKeyID, e.UserID, e.Kind, e.Status, e.Format, e.Items, e.ItemCount,
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+// GetExport retrieves a single export by ID.
+func (db *DB) GetExport(ctx context.Context, id string) (*models.Export, error) {
+	var e models.Export
+	err := db.GetContext(ctx, &e, `SELECT * FROM exports WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("export not found: %w", err)
+	}
+	return &e, nil
+}
+
+// UpdateExport saves the processing result of an export.
+func (db *DB) UpdateExport(ctx context.Context, e *models.Export) error {
+	query := `
+		UPDATE exports
+		SET status = $2, skipped_count = $3, file_path = $4, file_size = $5,
+			error_message = $6, completed_at = $7
+		WHERE id = $1`
+
+	_, err := db.ExecContext(ctx, query,
+		e.ID, e.Status, e.SkippedCount, e.FilePath, e.FileSize, e.ErrorMessage, e.CompletedAt,
+	)
+	return err
+}
+
+// exportSources maps export item types to their tables.
+var exportSources = map[string]string{
+	"transcript": "transcripts",
+	"audio":      "audio_transcriptions",
+	"pdf":        "pdf_extractions",
+}
+
+// ListExportItems resolves a filter into completed item references, newest first.
+// Results are scoped to apiKeyID (and its organization's keys) when set,
+// and otherwise to the items userID owns directly or through its keys.
+func (db *DB) ListExportItems(ctx context.Context, filter models.ExportFilter, apiKeyID, userID *string, limit int) ([]models.ExportItem, error) {
+	types := filter.Types
+	if len(types) == 0 {
+		types = []string{"transcript", "audio", "pdf"}
+	}
+
+	// Shared conditions; every subquery reuses the same positional args
+	conditions := []string{"status = 'completed'"}
+	var args []interface{}
+	argNum := 1
+
+	if apiKeyID != nil {
+		conditions = append(conditions, keyScope(fmt.Sprintf("$%d", argNum)))
+		args = append(args, *apiKeyID)
+		argNum++
+	} else if userID != nil {
+		conditions = append(conditions, userScope(fmt.Sprintf("$%d", argNum)))
+		args = append(args, *userID)
+		argNum++
+	}
+	if filter.DateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, filter.DateFrom)
+		argNum++
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argNum))
+		args = append(args, filter.DateTo)
+		argNum++
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var parts []string
+	for _, t := range types {
+		table, ok := exportSources[t]
+		if !ok {
+			return nil, fmt.Errorf("unknown item type %q", t)
+		}
+		// Table names come from the fixed map above, never from user input
+		parts = append(parts, fmt.Sprintf("SELECT '%s' AS type, id, created_at FROM %s WHERE %s", t, table, where))
+	}
+
+	query := fmt.Sprintf("SELECT type, id FROM (%s) items ORDER BY created_at DESC LIMIT $%d",
+		strings.Join(parts, " UNION ALL "), argNum)
+	args = append(args, limit)
+
+	var items []models.ExportItem
+	if err := db.reader().SelectContext(ctx, &items, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list export items: %w", err)
+	}
+	return items, nil
+}
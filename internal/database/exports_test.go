@@ -0,0 +1,77 @@
+//go:build cgo
+
+// exports_test.go contains tests for resolving export filters.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestListExportItemsScope verifies a filter only matches the caller's
+// items: a key's own, or a user's own and those of keys linked to them.
+func TestListExportItemsScope(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	ada := &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	bob := &models.User{Email: "bob@example.com", PasswordHash: "x", Name: "Bob"}
+	for _, u := range []*models.User{ada, bob} {
+		if err := db.CreateUser(ctx, u); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+	adaKey := &models.APIKey{KeyHash: "hash-ada", KeyPrefix: "mta_ada", Name: "ada", Active: true, Tier: models.TierFree, Role: "member"}
+	bobKey := &models.APIKey{KeyHash: "hash-bob", KeyPrefix: "mta_bob", Name: "bob", Active: true, Tier: models.TierFree, Role: "member"}
+	for _, k := range []*models.APIKey{adaKey, bobKey} {
+		if err := db.CreateAPIKey(ctx, k); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+	}
+	if err := db.LinkAPIKeyToUser(ctx, adaKey.ID, ada.ID); err != nil {
+		t.Fatalf("LinkAPIKeyToUser: %v", err)
+	}
+
+	create := func(title string, apiKeyID, userID *string) string {
+		tr := &models.Transcript{YouTubeURL: "https://youtu.be/" + title, YouTubeID: title, Title: title, Status: "completed", APIKeyID: apiKeyID}
+		if err := db.CreateTranscript(ctx, tr); err != nil {
+			t.Fatalf("CreateTranscript: %v", err)
+		}
+		if userID != nil {
+			if _, err := db.ExecContext(ctx, `UPDATE transcripts SET user_id = $1 WHERE id = $2`, *userID, tr.ID); err != nil {
+				t.Fatalf("set user_id: %v", err)
+			}
+		}
+		return tr.ID
+	}
+	adaOwn := create("ada-own", nil, &ada.ID)
+	adaByKey := create("ada-key", &adaKey.ID, nil)
+	bobOwn := create("bob-own", nil, &bob.ID)
+	bobByKey := create("bob-key", &bobKey.ID, nil)
+
+	ids := func(items []models.ExportItem) map[string]bool {
+		got := make(map[string]bool, len(items))
+		for _, it := range items {
+			got[it.ID] = true
+		}
+		return got
+	}
+
+	items, err := db.ListExportItems(ctx, models.ExportFilter{}, nil, &ada.ID, 10)
+	if err != nil {
+		t.Fatalf("ListExportItems for a user: %v", err)
+	}
+	if got := ids(items); len(got) != 2 || !got[adaOwn] || !got[adaByKey] {
+		t.Errorf("ListExportItems for a user = %v; want only %s and %s", items, adaOwn, adaByKey)
+	}
+
+	items, err = db.ListExportItems(ctx, models.ExportFilter{}, &bobKey.ID, nil, 10)
+	if err != nil {
+		t.Fatalf("ListExportItems for a key: %v", err)
+	}
+	if got := ids(items); len(got) != 1 || !got[bobByKey] || got[bobOwn] {
+		t.Errorf("ListExportItems for a key = %v; want only %s", items, bobByKey)
+	}
+}
@@ -0,0 +1,151 @@
+// integrations.go contains database operations for task manager integrations.
+//
+// Integration tokens are sealed with the server's ENCRYPTION_KEY before
+// they're stored, and opened again when an integration is loaded. Sealed
+// tokens carry sealedTokenPrefix; tokens saved before sealing was added
+// don't, and are sealed at startup by SealIntegrationTokens.
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+)
+
+// sealedTokenPrefix marks an integration token sealed with the secret box.
+const sealedTokenPrefix = "sealed:"
+
+// integrationColumns lists an integration's columns, except its token.
+const integrationColumns = `id, user_id, provider, config, created_at, updated_at`
+
+// SetSecretBox sets the box integration tokens are sealed with. Without
+// one, integrations can't be saved.
+func (db *DB) SetSecretBox(box *secretbox.Box) {
+	db.box = box
+}
+
+// sealToken seals an integration token for storage. It returns
+// secretbox.ErrNoKey if no box is set.
+func (db *DB) sealToken(token string) (string, error) {
+	if db.box == nil {
+		return "", secretbox.ErrNoKey
+	}
+	sealed, err := db.box.Seal([]byte(token))
+	if err != nil {
+		return "", err
+	}
+	return sealedTokenPrefix + sealed, nil
+}
+
+// openToken opens a stored integration token. Tokens stored before sealing
+// was added are returned as they are.
+func (db *DB) openToken(stored string) (string, error) {
+	sealed, ok := strings.CutPrefix(stored, sealedTokenPrefix)
+	if !ok {
+		return stored, nil
+	}
+	if db.box == nil {
+		return "", secretbox.ErrNoKey
+	}
+	token, err := db.box.Open(sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+// UpsertIntegration creates or replaces a user's integration for a provider,
+// sealing its token. It returns secretbox.ErrNoKey if no box is set.
+func (db *DB) UpsertIntegration(ctx context.Context, i *models.Integration) error {
+	sealed, err := db.sealToken(i.Token)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO integrations (user_id, provider, token, config)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, provider) DO UPDATE
+			SET token = EXCLUDED.token, config = EXCLUDED.config, updated_at = NOW()
+		RETURNING id, created_at, updated_at`
+
+	return db.QueryRowContext(ctx, query,
+		i.UserID, i.Provider, sealed, i.Config,
+	).Scan(&i.ID, &i.CreatedAt, &i.UpdatedAt)
+}
+
+// GetIntegration returns a user's integration for a provider, with its
+// token opened.
+func (db *DB) GetIntegration(ctx context.Context, userID, provider string) (*models.Integration, error) {
+	var i models.Integration
+	err := db.GetContext(ctx, &i,
+		`SELECT * FROM integrations WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return nil, fmt.Errorf("integration not found: %w", err)
+	}
+	if i.Token, err = db.openToken(i.Token); err != nil {
+		return nil, fmt.Errorf("failed to open %s integration token: %w", provider, err)
+	}
+	return &i, nil
+}
+
+// ListIntegrations returns all integrations configured by a user, without
+// their tokens.
+func (db *DB) ListIntegrations(ctx context.Context, userID string) ([]models.Integration, error) {
+	var integrations []models.Integration
+	err := db.SelectContext(ctx, &integrations,
+		`SELECT `+integrationColumns+` FROM integrations WHERE user_id = $1 ORDER BY provider`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+// DeleteIntegration removes a user's integration for a provider.
+func (db *DB) DeleteIntegration(ctx context.Context, userID, provider string) error {
+	result, err := db.ExecContext(ctx,
+		`DELETE FROM integrations WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete integration: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("integration not found")
+	}
+	return nil
+}
+
+// SealIntegrationTokens seals tokens stored before sealing was added,
+// returning how many it sealed. It returns secretbox.ErrNoKey if no box is
+// set.
+func (db *DB) SealIntegrationTokens(ctx context.Context) (int, error) {
+	if db.box == nil {
+		return 0, secretbox.ErrNoKey
+	}
+	var plain []struct {
+		ID    string `db:"id"`
+		Token string `db:"token"`
+	}
+	err := db.SelectContext(ctx, &plain,
+		`SELECT id, token FROM integrations WHERE token NOT LIKE $1`, sealedTokenPrefix+"%")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unsealed integration tokens: %w", err)
+	}
+
+	for n, row := range plain {
+		sealed, err := db.sealToken(row.Token)
+		if err != nil {
+			return n, err
+		}
+		// Only replace the token that was read, in case it was saved again since
+		_, err = db.ExecContext(ctx,
+			`UPDATE integrations SET token = $1 WHERE id = $2 AND token = $3`, sealed, row.ID, row.Token)
+		if err != nil {
+			return n, fmt.Errorf("failed to seal integration token: %w", err)
+		}
+	}
+	return len(plain), nil
+}
@@ -0,0 +1,73 @@
+//go:build cgo
+
+// integrations_test.go contains tests for storing integration tokens.
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+)
+
+// TestIntegrationTokens verifies tokens are stored sealed, opened when an
+// integration is loaded, refused without a key, and that tokens stored in
+// plaintext before sealing was added get sealed.
+func TestIntegrationTokens(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	user := &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	if err := db.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	storedToken := func(provider string) string {
+		var token string
+		if err := db.GetContext(ctx, &token,
+			`SELECT token FROM integrations WHERE user_id = $1 AND provider = $2`, user.ID, provider); err != nil {
+			t.Fatalf("select token: %v", err)
+		}
+		return token
+	}
+
+	github := &models.Integration{UserID: user.ID, Provider: "github", Token: "ghp_secret", Config: []byte(`{}`)}
+	if err := db.UpsertIntegration(ctx, github); !errors.Is(err, secretbox.ErrNoKey) {
+		t.Fatalf("UpsertIntegration without a key = %v, want ErrNoKey", err)
+	}
+
+	box, _ := secretbox.New("test-key")
+	db.SetSecretBox(box)
+	if err := db.UpsertIntegration(ctx, github); err != nil {
+		t.Fatalf("UpsertIntegration: %v", err)
+	}
+	if stored := storedToken("github"); !strings.HasPrefix(stored, sealedTokenPrefix) || strings.Contains(stored, "ghp_secret") {
+		t.Errorf("stored token = %q, want it sealed", stored)
+	}
+	if got, err := db.GetIntegration(ctx, user.ID, "github"); err != nil || got.Token != "ghp_secret" {
+		t.Errorf("GetIntegration = %+v, %v; want the opened token", got, err)
+	}
+	if list, err := db.ListIntegrations(ctx, user.ID); err != nil || len(list) != 1 || list[0].Token != "" {
+		t.Errorf("ListIntegrations = %+v, %v; want one integration without its token", list, err)
+	}
+
+	// A token saved before sealing was added
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO integrations (user_id, provider, token, config) VALUES ($1, 'todoist', 'td_plain', '{}')`, user.ID); err != nil {
+		t.Fatalf("insert plaintext token: %v", err)
+	}
+	if n, err := db.SealIntegrationTokens(ctx); err != nil || n != 1 {
+		t.Fatalf("SealIntegrationTokens = %d, %v; want 1", n, err)
+	}
+	if stored := storedToken("todoist"); !strings.HasPrefix(stored, sealedTokenPrefix) {
+		t.Errorf("stored token = %q, want it sealed", stored)
+	}
+	if got, err := db.GetIntegration(ctx, user.ID, "todoist"); err != nil || got.Token != "td_plain" {
+		t.Errorf("GetIntegration = %+v, %v; want the opened token", got, err)
+	}
+	if n, err := db.SealIntegrationTokens(ctx); err != nil || n != 0 {
+		t.Errorf("SealIntegrationTokens again = %d, %v; want 0", n, err)
+	}
+}
@@ -0,0 +1,144 @@
+// items.go lists transcripts, audio transcriptions, and PDF extractions
+// together for GET /api/v1/items.
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// itemSources maps item types to the query that projects each table onto
+// models.Item. Audio and PDFs without a user-set title show their filename.
+var itemSources = map[string]string{
+	"transcript": "SELECT 'transcript' AS type, id, title, status, word_count, created_at, api_key_id, user_id FROM transcripts",
+	"audio":      "SELECT 'audio' AS type, id, COALESCE(NULLIF(title, ''), original_name) AS title, status, word_count, created_at, api_key_id, user_id FROM audio_transcriptions",
+	"pdf":        "SELECT 'pdf' AS type, id, COALESCE(NULLIF(title, ''), original_name) AS title, status, word_count, created_at, api_key_id, user_id FROM pdf_extractions",
+}
+
+// itemTypes lists the valid item types in display order.
+var itemTypes = []string{"transcript", "audio", "pdf"}
+
+// itemSortColumns are the columns ListItems can sort by.
+var itemSortColumns = map[string]bool{"created_at": true, "title": true, "word_count": true}
+
+// itemListOrder is transcriptListOrder for ListItems.
+func itemListOrder(params models.ItemListParams) (string, string, int) {
+	perPage := params.PerPage
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	if c, err := decodeCursor(params.Cursor, itemSortColumns); err == nil && c != nil {
+		return c.SortBy, c.SortDir, perPage
+	}
+	sortBy, sortDir := params.SortBy, params.SortDir
+	// Validate sort column to prevent SQL injection
+	if !itemSortColumns[sortBy] {
+		sortBy = "created_at"
+	}
+	if sortDir != "asc" {
+		sortDir = "desc"
+	}
+	return sortBy, sortDir, perPage
+}
+
+// ListItems returns a page of items across all three tables, plus the total
+// matching count. types limits which tables are included (all if empty).
+// With params.Cursor set it returns the page after the cursor.
+func (db *DB) ListItems(ctx context.Context, params models.ItemListParams, types []string) ([]models.Item, int, error) {
+	after, err := decodeCursor(params.Cursor, itemSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	params.SortBy, params.SortDir, params.PerPage = itemListOrder(params)
+	if len(types) == 0 {
+		types = itemTypes
+	}
+
+	var parts []string
+	for _, t := range types {
+		source, ok := itemSources[t]
+		if !ok {
+			return nil, 0, fmt.Errorf("unknown item type %q", t)
+		}
+		parts = append(parts, source)
+	}
+	// Filters apply to the merged rows so each condition is written once
+	union := strings.Join(parts, " UNION ALL ")
+
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if params.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argNum))
+		args = append(args, params.Status)
+		argNum++
+	}
+	if params.Search != "" {
+		conditions = append(conditions, fmt.Sprintf("title ILIKE $%d", argNum))
+		args = append(args, "%"+params.Search+"%")
+		argNum++
+	}
+	if params.DateFrom != "" {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, params.DateFrom)
+		argNum++
+	}
+	if params.DateTo != "" {
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argNum))
+		args = append(args, params.DateTo)
+		argNum++
+	}
+	if scope, arg := callerScope(params.APIKeyID, params.UserID, fmt.Sprintf("$%d", argNum)); scope != "" {
+		conditions = append(conditions, scope)
+		args = append(args, arg)
+		argNum++
+	}
+	if params.OrgID != nil {
+		conditions = append(conditions, orgScope(fmt.Sprintf("$%d", argNum)))
+		args = append(args, *params.OrgID)
+		argNum++
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) items %s", union, whereClause)
+	if err := db.reader().GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	// The cursor condition is added after counting so total still covers
+	// every match
+	offset := (params.Page - 1) * params.PerPage
+	if after != nil {
+		clause, cursorArgs := after.condition(argNum)
+		conditions = append(conditions, clause)
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		args = append(args, cursorArgs...)
+		argNum += len(cursorArgs)
+		offset = 0
+	}
+
+	// id breaks ties so pages don't overlap when sort values are equal
+	selectQuery := fmt.Sprintf(
+		"SELECT type, id, title, status, word_count, created_at FROM (%s) items %s ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d",
+		union, whereClause, params.SortBy, params.SortDir, params.SortDir, argNum, argNum+1,
+	)
+	args = append(args, params.PerPage, offset)
+
+	var items []models.Item
+	if err := db.reader().SelectContext(ctx, &items, selectQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	return items, total, nil
+}
@@ -0,0 +1,96 @@
+// metadata.go contains database operations for user-editable item metadata
+// (title, notes, tags, and audio content type and custom metadata).
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// itemTables maps item types to their tables.
+var itemTables = map[string]string{
+	"transcript": "transcripts",
+	"audio":      "audio_transcriptions",
+	"pdf":        "pdf_extractions",
+}
+
+// UpdateItemMetadata applies the non-nil fields of update to one item.
+// Callers validate the values; ContentType is only valid for audio.
+func (db *DB) UpdateItemMetadata(ctx context.Context, itemType, id string, update models.UpdateItemRequest) error {
+	table, ok := itemTables[itemType]
+	if !ok {
+		return fmt.Errorf("unknown item type %q", itemType)
+	}
+
+	var sets []string
+	var args []interface{}
+	argNum := 1
+
+	if update.Title != nil {
+		sets = append(sets, fmt.Sprintf("title = $%d", argNum))
+		args = append(args, *update.Title)
+		argNum++
+	}
+	if update.Notes != nil {
+		sets = append(sets, fmt.Sprintf("notes = $%d", argNum))
+		args = append(args, *update.Notes)
+		argNum++
+	}
+	if update.Tags != nil {
+		tags, err := json.Marshal(*update.Tags)
+		if err != nil {
+			return fmt.Errorf("failed to encode tags: %w", err)
+		}
+		sets = append(sets, fmt.Sprintf("tags = $%d::jsonb", argNum))
+		args = append(args, string(tags))
+		argNum++
+	}
+	if update.ContentType != nil {
+		if itemType != "audio" {
+			return fmt.Errorf("content_type can't be set on %s items", itemType)
+		}
+		sets = append(sets, fmt.Sprintf("content_type = $%d", argNum))
+		args = append(args, *update.ContentType)
+		argNum++
+	}
+	if update.Metadata != nil {
+		if itemType != "audio" {
+			return fmt.Errorf("metadata can't be set on %s items", itemType)
+		}
+		metadata, err := json.Marshal(*update.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode metadata: %w", err)
+		}
+		sets = append(sets, fmt.Sprintf("metadata = $%d::jsonb", argNum))
+		args = append(args, metadataJSON(metadata))
+		argNum++
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d", table, strings.Join(sets, ", "), argNum)
+	args = append(args, id)
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", itemType, err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("%s not found", itemType)
+	}
+	return nil
+}
+
+// metadataJSON is the stored form of an item's custom metadata: its JSON
+// object, or an empty one when there's none.
+func metadataJSON(metadata json.RawMessage) string {
+	if len(metadata) == 0 || string(metadata) == "null" {
+		return "{}"
+	}
+	return string(metadata)
+}
@@ -3,34 +3,64 @@
 // Migrations are SQL files in the migrations/ directory. Each migration
 // has an "up" (apply) and "down" (rollback) file. The migrate library
 // tracks which migrations have been applied in a schema_migrations table.
+//
+// SQLite databases use their own migrations in migrations/sqlite. They
+// start from a baseline matching the PostgreSQL schema at the same version;
+// schema changes after it need a migration in both directories, under the
+// same number.
 package database
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"path/filepath"
 
 	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file" // File source driver
 )
 
-// RunMigrations applies all pending database migrations.
-// This is called at application startup to ensure the schema is up to date.
-func (db *DB) RunMigrations(migrationsPath string) error {
-	// Create a postgres driver instance for golang-migrate
-	driver, err := postgres.WithInstance(db.DB.DB, &postgres.Config{})
+// newMigrator returns a golang-migrate instance for db's dialect, reading
+// from migrationsPath (or its sqlite subdirectory).
+func (db *DB) newMigrator(migrationsPath string) (*migrate.Migrate, error) {
+	// Create a driver instance for golang-migrate
+	var driver migratedb.Driver
+	var err error
+	dialect := "postgres"
+	if db.isSQLite() {
+		dialect = "sqlite3"
+		migrationsPath = filepath.Join(migrationsPath, "sqlite")
+		driver, err = sqlite3.WithInstance(db.DB.DB, &sqlite3.Config{})
+	} else {
+		driver, err = postgres.WithInstance(db.DB.DB, &postgres.Config{})
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create migration driver: %w", err)
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
 	}
 
 	// Create the migrate instance pointing to our SQL files
 	m, err := migrate.NewWithDatabaseInstance(
 		"file://"+migrationsPath,
-		"postgres",
+		dialect,
 		driver,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create migrator: %w", err)
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	return m, nil
+}
+
+// RunMigrations applies all pending database migrations.
+// This is called at application startup to ensure the schema is up to date.
+func (db *DB) RunMigrations(migrationsPath string) error {
+	m, err := db.newMigrator(migrationsPath)
+	if err != nil {
+		return err
 	}
 
 	// Run all pending migrations
@@ -39,12 +69,95 @@ func (db *DB) RunMigrations(migrationsPath string) error {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
+	version, dirty, verr := m.Version()
+	if verr != nil && verr != migrate.ErrNilVersion {
+		return fmt.Errorf("failed to read migration version: %w", verr)
+	}
+	db.schemaVersion = version
+
 	if err == migrate.ErrNoChange {
 		log.Println("📦 Database: no new migrations to apply")
 	} else {
-		version, dirty, _ := m.Version()
 		log.Printf("📦 Database: migrated to version %d (dirty: %v)", version, dirty)
 	}
 
 	return nil
 }
+
+// RollbackMigrations reverts the last steps applied migrations by running
+// their down files.
+func (db *DB) RollbackMigrations(migrationsPath string, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+	m, err := db.newMigrator(migrationsPath)
+	if err != nil {
+		return err
+	}
+	if err := m.Steps(-steps); err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+	return nil
+}
+
+// MigrationVersion returns the applied schema version and whether the last
+// migration failed partway (dirty). Version 0 means none are applied.
+func (db *DB) MigrationVersion(migrationsPath string) (uint, bool, error) {
+	m, err := db.newMigrator(migrationsPath)
+	if err != nil {
+		return 0, false, err
+	}
+	version, dirty, err := m.Version()
+	if err == migrate.ErrNilVersion {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// ForceMigrationVersion records version as applied and clears the dirty
+// flag without running any SQL. It's for recovering from a failed
+// migration once the schema has been repaired by hand; -1 records that no
+// migrations are applied.
+func (db *DB) ForceMigrationVersion(migrationsPath string, version int) error {
+	if version < -1 {
+		return fmt.Errorf("invalid version %d", version)
+	}
+	m, err := db.newMigrator(migrationsPath)
+	if err != nil {
+		return err
+	}
+	if err := m.Force(version); err != nil {
+		return fmt.Errorf("force failed: %w", err)
+	}
+	return nil
+}
+
+// CheckMigrations verifies the schema is still at the version RunMigrations
+// applied, and not left dirty by a failed migration. Another instance may
+// have migrated further, which is fine. It returns the current version.
+func (db *DB) CheckMigrations(ctx context.Context) (uint, error) {
+	if db.schemaVersion == 0 {
+		return 0, errors.New("migrations have not been run")
+	}
+
+	var version uint
+	var dirty bool
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.New("no migrations applied")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	switch {
+	case dirty:
+		return version, fmt.Errorf("migration %d is dirty", version)
+	case version < db.schemaVersion:
+		return version, fmt.Errorf("schema at version %d, expected %d", version, db.schemaVersion)
+	}
+	return version, nil
+}
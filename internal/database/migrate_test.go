@@ -0,0 +1,48 @@
+//go:build cgo
+
+// migrate_test.go contains tests for the migration commands, run against an
+// in-memory SQLite database.
+package database
+
+import "testing"
+
+// TestMigrationCommands verifies rolling back, reading, and forcing the
+// schema version.
+func TestMigrationCommands(t *testing.T) {
+	const path = "../../migrations"
+	db := openSQLite(t)
+
+	if version, dirty, err := db.MigrationVersion(path); err != nil || version != 79 || dirty {
+		t.Fatalf("MigrationVersion = %d, %v, %v; want 79, false", version, dirty, err)
+	}
+
+	if err := db.RollbackMigrations(path, 0); err == nil {
+		t.Error("RollbackMigrations(0) succeeded")
+	}
+	if err := db.RollbackMigrations(path, 34); err != nil {
+		t.Fatalf("RollbackMigrations: %v", err)
+	}
+	if version, _, err := db.MigrationVersion(path); err != nil || version != 45 {
+		t.Errorf("MigrationVersion after rollback = %d, %v; want 45", version, err)
+	}
+	if err := db.RollbackMigrations(path, 1); err != nil {
+		t.Fatalf("RollbackMigrations: %v", err)
+	}
+	if version, _, err := db.MigrationVersion(path); err != nil || version != 0 {
+		t.Errorf("MigrationVersion after rollback = %d, %v; want 0", version, err)
+	}
+	var tables int
+	if err := db.Get(&tables, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'transcripts'`); err != nil || tables != 0 {
+		t.Errorf("transcripts table still exists after rollback (%d, %v)", tables, err)
+	}
+
+	if err := db.ForceMigrationVersion(path, -2); err == nil {
+		t.Error("ForceMigrationVersion(-2) succeeded")
+	}
+	if err := db.ForceMigrationVersion(path, 79); err != nil {
+		t.Fatalf("ForceMigrationVersion: %v", err)
+	}
+	if version, dirty, err := db.MigrationVersion(path); err != nil || version != 79 || dirty {
+		t.Errorf("MigrationVersion after force = %d, %v, %v; want 79, false", version, dirty, err)
+	}
+}
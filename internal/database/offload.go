@@ -0,0 +1,229 @@
+// offload.go moves very large transcript bodies out of the database and
+// into object storage.
+//
+// Multi-hour recordings produce megabyte transcript_text values that bloat
+// the tables and every query that selects them. With a text store
+// configured, bodies over the threshold are written to storage and the
+// row keeps an empty transcript_text plus the object's key. Reads that
+// select the text load it back, so callers see the full body either way.
+// The row also keeps the body's search vector (offloaded_vector), which the
+// generated search_vector takes in, so search still finds offloaded text.
+//
+// Keys are unique per version ("transcript-<id>-<random>.txt"): a new body
+// is written before the row points at it, and the old object is removed
+// only after, so a failed write never leaves a row without its text.
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/storage"
+)
+
+// TextStore holds offloaded transcript bodies. storage.Store satisfies it.
+type TextStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// SetTextStore offloads transcript bodies larger than threshold bytes to
+// store. Rows offloaded earlier are still read from store when threshold
+// is 0, so offloading can be turned off without moving text back.
+func (db *DB) SetTextStore(store TextStore, threshold int) {
+	db.textStore = store
+	db.textThreshold = threshold
+}
+
+// offloadText writes text to the text store when it's over the threshold,
+// returning the value to keep in the row and the object's key. Text under
+// the threshold, or that fails to upload, stays in the row with a nil key.
+func (db *DB) offloadText(ctx context.Context, itemType, id, text string) (string, *string) {
+	if db.textStore == nil || db.textThreshold <= 0 || len(text) <= db.textThreshold {
+		return text, nil
+	}
+	key := fmt.Sprintf("%s-%s-%s.txt", itemType, id, uuid.NewString()[:8])
+	err := db.textStore.Put(ctx, key, strings.NewReader(text), int64(len(text)), "text/plain; charset=utf-8")
+	if err != nil {
+		log.Printf("⚠️  Failed to offload %s %s text, keeping it in the database: %v", itemType, id, err)
+		return text, nil
+	}
+	return "", &key
+}
+
+// offloadedVector returns the SQL for an offloaded_vector value computed
+// from the text bound to placeholder ph, weighted like the body's part of
+// search_vector; on SQLite it's the lowercased text. A NULL text, for a
+// body kept in the row, gives NULL.
+func (db *DB) offloadedVector(ph string) string {
+	if db.isSQLite() {
+		return "lower(" + ph + ")"
+	}
+	return "setweight(to_tsvector('english', left(" + ph + ", 500000)), 'B')"
+}
+
+// offloadedBody returns the text to compute offloaded_vector from: text
+// when it was offloaded under key, or nil when it stays in the row.
+func offloadedBody(text string, key *string) *string {
+	if key == nil {
+		return nil
+	}
+	return &text
+}
+
+// FillOffloadedVectors computes offloaded_vector for bodies offloaded
+// before the column existed, loading each from the text store, and returns
+// how many rows it filled. A body that fails to load is logged and skipped.
+func (db *DB) FillOffloadedVectors(ctx context.Context) (int, error) {
+	if db.textStore == nil {
+		return 0, nil
+	}
+	filled := 0
+	for _, table := range []string{"transcripts", "audio_transcriptions"} {
+		var rows []struct {
+			ID      string `db:"id"`
+			TextKey string `db:"text_key"`
+		}
+		err := db.SelectContext(ctx, &rows, fmt.Sprintf(`
+			SELECT id, text_key FROM %s
+			WHERE text_key IS NOT NULL AND transcript_text = '' AND offloaded_vector IS NULL`, table))
+		if err != nil {
+			return filled, fmt.Errorf("failed to list offloaded %s: %w", table, err)
+		}
+		for _, row := range rows {
+			text, err := db.loadText(ctx, row.TextKey)
+			if err != nil {
+				log.Printf("⚠️  Failed to index offloaded text of %s %s: %v", table, row.ID, err)
+				continue
+			}
+			// The key check skips rows rewritten since they were listed
+			_, err = db.ExecContext(ctx, fmt.Sprintf(
+				`UPDATE %s SET offloaded_vector = %s WHERE id = $1 AND text_key = $2`, table, db.offloadedVector("$3")),
+				row.ID, row.TextKey, text)
+			if err != nil {
+				return filled, fmt.Errorf("failed to index offloaded %s: %w", table, err)
+			}
+			filled++
+		}
+	}
+	return filled, nil
+}
+
+// storedTextKey returns the key of a row's offloaded text as stored now,
+// so an update can remove the object it replaces.
+func (db *DB) storedTextKey(ctx context.Context, table, id string) *string {
+	if db.textStore == nil {
+		return nil
+	}
+	var key *string
+	if err := db.GetContext(ctx, &key, fmt.Sprintf(`SELECT text_key FROM %s WHERE id = $1`, table), id); err != nil {
+		return nil
+	}
+	return key
+}
+
+// replaceText finishes an update that wrote newKey: on failure it removes
+// the new object, which no row points at, and on success the old one.
+func (db *DB) replaceText(ctx context.Context, err error, oldKey, newKey *string) {
+	if err != nil {
+		db.deleteText(ctx, newKey)
+		return
+	}
+	if oldKey != nil && (newKey == nil || *oldKey != *newKey) {
+		db.deleteText(ctx, oldKey)
+	}
+}
+
+// loadText reads an offloaded body back from the text store.
+func (db *DB) loadText(ctx context.Context, key string) (string, error) {
+	if db.textStore == nil {
+		return "", fmt.Errorf("text %s is offloaded but no text store is configured", key)
+	}
+	rc, err := db.textStore.Open(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to load offloaded text %s: %w", key, err)
+	}
+	defer rc.Close()
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		return "", fmt.Errorf("failed to load offloaded text %s: %w", key, err)
+	}
+	return string(body), nil
+}
+
+// deleteText removes offloaded bodies that no row points at anymore.
+// Failures are logged; an orphaned object costs storage, not correctness.
+func (db *DB) deleteText(ctx context.Context, keys ...*string) {
+	if db.textStore == nil {
+		return
+	}
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+		if err := db.textStore.Delete(ctx, *key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("⚠️  Failed to delete offloaded text %s: %v", *key, err)
+		}
+	}
+}
+
+// loadTranscriptText fills in t's offloaded text and original text. Only
+// call it on rows selected with their text; list summaries leave it out.
+func (db *DB) loadTranscriptText(ctx context.Context, t *models.Transcript) error {
+	if t.TextKey != nil && t.TranscriptText == "" {
+		text, err := db.loadText(ctx, *t.TextKey)
+		if err != nil {
+			return err
+		}
+		t.TranscriptText = text
+	}
+	if t.OriginalTextKey != nil && t.OriginalText != nil && *t.OriginalText == "" {
+		text, err := db.loadText(ctx, *t.OriginalTextKey)
+		if err != nil {
+			return err
+		}
+		t.OriginalText = &text
+	}
+	return nil
+}
+
+// loadAudioText fills in at's offloaded transcript text.
+func (db *DB) loadAudioText(ctx context.Context, at *models.AudioTranscription) error {
+	if at.TextKey == nil || at.TranscriptText != "" {
+		return nil
+	}
+	text, err := db.loadText(ctx, *at.TextKey)
+	if err != nil {
+		return err
+	}
+	at.TranscriptText = text
+	return nil
+}
+
+// loadTranscriptTexts is loadTranscriptText for each transcript.
+func (db *DB) loadTranscriptTexts(ctx context.Context, transcripts []models.Transcript) error {
+	for i := range transcripts {
+		if err := db.loadTranscriptText(ctx, &transcripts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadAudioTexts is loadAudioText for each audio transcription.
+func (db *DB) loadAudioTexts(ctx context.Context, audio []models.AudioTranscription) error {
+	for i := range audio {
+		if err := db.loadAudioText(ctx, &audio[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
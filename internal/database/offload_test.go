@@ -0,0 +1,217 @@
+//go:build cgo
+
+// offload_test.go contains tests for offloading large transcript bodies to
+// a text store, run against an in-memory SQLite database.
+package database
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/storage"
+)
+
+// memTextStore is an in-memory TextStore.
+type memTextStore struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func (s *memTextStore) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = string(data)
+	return nil
+}
+
+func (s *memTextStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader([]byte(data))), nil
+}
+
+func (s *memTextStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[key]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(s.objects, key)
+	return nil
+}
+
+// keys returns the stored objects' keys.
+func (s *memTextStore) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// TestTextOffload verifies that large bodies move to the text store, load
+// back on reads, follow corrections, and are removed with their item.
+func TestTextOffload(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	store := &memTextStore{objects: map[string]string{}}
+	db.SetTextStore(store, 64)
+
+	short := "a short transcript"
+	long := strings.Repeat("a very long transcript ", 10)
+
+	tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Status: "completed", TranscriptText: short}
+	if err := db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+	if err := db.UpdateTranscript(ctx, tr); err != nil {
+		t.Fatalf("UpdateTranscript (short): %v", err)
+	}
+	if tr.TextKey != nil || len(store.keys()) != 0 {
+		t.Fatalf("short text was offloaded: key %v, store %v", tr.TextKey, store.keys())
+	}
+
+	tr.TranscriptText = long
+	if err := db.UpdateTranscript(ctx, tr); err != nil {
+		t.Fatalf("UpdateTranscript (long): %v", err)
+	}
+	if tr.TextKey == nil || store.objects[*tr.TextKey] != long {
+		t.Fatalf("long text wasn't offloaded: key %v, store %v", tr.TextKey, store.keys())
+	}
+	extracted := *tr.TextKey
+	var inline string
+	if err := db.GetContext(ctx, &inline, `SELECT transcript_text FROM transcripts WHERE id = $1`, tr.ID); err != nil || inline != "" {
+		t.Errorf("transcript_text in the row = %q, %v; want empty", inline, err)
+	}
+	if got, err := db.GetTranscript(ctx, tr.ID); err != nil || got.TranscriptText != long {
+		t.Errorf("GetTranscript text = %q, %v; want the offloaded body", got.TranscriptText, err)
+	}
+
+	corrected := strings.Repeat("a corrected long transcript ", 10)
+	if err := db.CorrectTranscript(ctx, &models.TranscriptEdit{TranscriptID: tr.ID, TranscriptText: corrected}); err != nil {
+		t.Fatalf("CorrectTranscript: %v", err)
+	}
+	got, err := db.GetTranscript(ctx, tr.ID)
+	if err != nil {
+		t.Fatalf("GetTranscript: %v", err)
+	}
+	if got.TranscriptText != corrected || got.OriginalText == nil || *got.OriginalText != long {
+		t.Errorf("after correction text = %q, original = %v", got.TranscriptText, got.OriginalText)
+	}
+	if got.OriginalTextKey == nil || *got.OriginalTextKey != extracted {
+		t.Errorf("original_text_key = %v, want %s", got.OriginalTextKey, extracted)
+	}
+
+	// A second correction replaces the first one's object, not the original's
+	if err := db.CorrectTranscript(ctx, &models.TranscriptEdit{TranscriptID: tr.ID, TranscriptText: short}); err != nil {
+		t.Fatalf("CorrectTranscript (short): %v", err)
+	}
+	if keys := store.keys(); len(keys) != 1 || keys[0] != extracted {
+		t.Errorf("store after second correction = %v, want just %s", keys, extracted)
+	}
+
+	if err := db.DeleteTranscript(ctx, tr.ID); err != nil {
+		t.Fatalf("DeleteTranscript: %v", err)
+	}
+	if keys := store.keys(); len(keys) != 0 {
+		t.Errorf("store after delete = %v, want empty", keys)
+	}
+
+	at := &models.AudioTranscription{Filename: "a.mp3", OriginalName: "a.mp3", Status: "completed"}
+	if err := db.CreateAudioTranscription(ctx, at); err != nil {
+		t.Fatalf("CreateAudioTranscription: %v", err)
+	}
+	at.TranscriptText = long
+	if err := db.UpdateAudioTranscription(ctx, at); err != nil {
+		t.Fatalf("UpdateAudioTranscription: %v", err)
+	}
+	if got, err := db.GetAudioTranscription(ctx, at.ID); err != nil || got.TranscriptText != long || got.TextKey == nil {
+		t.Errorf("GetAudioTranscription = %+v, %v", got, err)
+	}
+	if list, _, err := db.ListAudioTranscriptions(ctx, models.AudioListParams{IncludeText: true}); err != nil || len(list) != 1 || list[0].TranscriptText != long {
+		t.Errorf("ListAudioTranscriptions = %+v, %v", list, err)
+	}
+	if err := db.DeleteAudioTranscription(ctx, at.ID); err != nil {
+		t.Fatalf("DeleteAudioTranscription: %v", err)
+	}
+	if keys := store.keys(); len(keys) != 0 {
+		t.Errorf("store after audio delete = %v, want empty", keys)
+	}
+}
+
+// TestOffloadedTextSearch verifies offloaded bodies stay searchable, with
+// snippets cut from the text store, including bodies offloaded before
+// offloaded_vector was filled in.
+func TestOffloadedTextSearch(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	store := &memTextStore{objects: map[string]string{}}
+	db.SetTextStore(store, 64)
+
+	long := "the zebra crossing " + strings.Repeat("a very long transcript ", 10)
+	tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Title: "Walks", Status: "completed"}
+	if err := db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+	tr.TranscriptText = long
+	if err := db.UpdateTranscript(ctx, tr); err != nil {
+		t.Fatalf("UpdateTranscript: %v", err)
+	}
+	if tr.TextKey == nil {
+		t.Fatal("long text was not offloaded")
+	}
+
+	search := func() []models.SearchResult {
+		t.Helper()
+		results, _, err := db.SearchText(ctx, models.TextSearchParams{Query: "zebra"}, nil)
+		if err != nil {
+			t.Fatalf("SearchText: %v", err)
+		}
+		return results
+	}
+	results := search()
+	if len(results) != 1 || results[0].ID != tr.ID {
+		t.Fatalf("SearchText = %+v, want the offloaded transcript", results)
+	}
+	if !strings.Contains(results[0].Snippet, "zebra") {
+		t.Errorf("snippet = %q, want it cut from the offloaded text", results[0].Snippet)
+	}
+
+	// Rows offloaded before the column existed are found once it's filled
+	if _, err := db.ExecContext(ctx, `UPDATE transcripts SET offloaded_vector = NULL`); err != nil {
+		t.Fatalf("clear offloaded_vector: %v", err)
+	}
+	if results := search(); len(results) != 0 {
+		t.Fatalf("SearchText without offloaded_vector = %+v, want none", results)
+	}
+	if n, err := db.FillOffloadedVectors(ctx); err != nil || n != 1 {
+		t.Fatalf("FillOffloadedVectors = %d, %v; want 1, nil", n, err)
+	}
+	if results := search(); len(results) != 1 {
+		t.Errorf("SearchText after FillOffloadedVectors = %+v, want the transcript", results)
+	}
+
+	// A body short enough to keep in the row clears the offloaded vector
+	tr.TranscriptText = "a short transcript"
+	if err := db.UpdateTranscript(ctx, tr); err != nil {
+		t.Fatalf("UpdateTranscript: %v", err)
+	}
+	if results := search(); len(results) != 0 {
+		t.Errorf("SearchText after shrinking = %+v, want none", results)
+	}
+}
@@ -0,0 +1,383 @@
+// organizations.go contains database operations for organizations, their
+// memberships and invitations, and organization-scoped API keys.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// keyScope matches rows created by the API key in placeholder, or by any
+// other key in the same organization. Keys outside an organization only
+// match themselves.
+func keyScope(placeholder string) string {
+	return fmt.Sprintf(`api_key_id IN (
+		SELECT k.id FROM api_keys k
+		WHERE k.id = %[1]s OR k.org_id = (SELECT o.org_id FROM api_keys o WHERE o.id = %[1]s)
+	)`, placeholder)
+}
+
+// orgScope matches rows created by any of an organization's API keys.
+func orgScope(placeholder string) string {
+	return fmt.Sprintf(`api_key_id IN (SELECT k.id FROM api_keys k WHERE k.org_id = %s)`, placeholder)
+}
+
+// CreateOrganization inserts an organization with userID as its owner.
+func (db *DB) CreateOrganization(ctx context.Context, org *models.Organization, userID string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO organizations (name) VALUES ($1) RETURNING id, created_at`, org.Name,
+	).Scan(&org.ID, &org.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO org_memberships (org_id, user_id, role) VALUES ($1, $2, 'owner')`, org.ID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to add organization owner: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	org.Role = models.OrgRoleOwner
+	return nil
+}
+
+// ListUserOrganizations returns the organizations a user belongs to, with
+// their role in each.
+func (db *DB) ListUserOrganizations(ctx context.Context, userID string) ([]models.Organization, error) {
+	var orgs []models.Organization
+	err := db.SelectContext(ctx, &orgs, `
+		SELECT o.id, o.name, m.role, o.created_at
+		FROM organizations o
+		JOIN org_memberships m ON m.org_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// GetOrganizationForMember returns an organization with the user's role in
+// it. It fails if the user isn't a member.
+func (db *DB) GetOrganizationForMember(ctx context.Context, orgID, userID string) (*models.Organization, error) {
+	var org models.Organization
+	err := db.GetContext(ctx, &org, `
+		SELECT o.id, o.name, m.role, o.created_at
+		FROM organizations o
+		JOIN org_memberships m ON m.org_id = o.id
+		WHERE o.id = $1 AND m.user_id = $2`, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+	return &org, nil
+}
+
+// DeleteOrganization deletes an organization and its memberships and
+// invitations. Its API keys are revoked first, so they don't live on as
+// unscoped keys; their items are kept.
+func (db *DB) DeleteOrganization(ctx context.Context, orgID string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE api_keys SET active = false WHERE org_id = $1`, orgID); err != nil {
+		return fmt.Errorf("failed to revoke organization API keys: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM organizations WHERE id = $1`, orgID); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ListOrgMembers returns an organization's members, owners first.
+func (db *DB) ListOrgMembers(ctx context.Context, orgID string) ([]models.OrgMember, error) {
+	var members []models.OrgMember
+	err := db.SelectContext(ctx, &members, `
+		SELECT m.org_id, m.user_id, m.role, u.email, u.name, m.created_at
+		FROM org_memberships m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, u.name`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	return members, nil
+}
+
+// GetOrgMember returns one membership.
+func (db *DB) GetOrgMember(ctx context.Context, orgID, userID string) (*models.OrgMember, error) {
+	var m models.OrgMember
+	err := db.GetContext(ctx, &m, `
+		SELECT m.org_id, m.user_id, m.role, u.email, u.name, m.created_at
+		FROM org_memberships m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1 AND m.user_id = $2`, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("member not found: %w", err)
+	}
+	return &m, nil
+}
+
+// CountOrgOwners returns how many owners an organization has.
+func (db *DB) CountOrgOwners(ctx context.Context, orgID string) (int, error) {
+	var n int
+	err := db.GetContext(ctx, &n,
+		`SELECT COUNT(*) FROM org_memberships WHERE org_id = $1 AND role = 'owner'`, orgID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count organization owners: %w", err)
+	}
+	return n, nil
+}
+
+// UpdateOrgMemberRole changes a member's role.
+func (db *DB) UpdateOrgMemberRole(ctx context.Context, orgID, userID, role string) error {
+	result, err := db.ExecContext(ctx,
+		`UPDATE org_memberships SET role = $3 WHERE org_id = $1 AND user_id = $2`, orgID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
+
+// RemoveOrgMember removes a user from an organization.
+func (db *DB) RemoveOrgMember(ctx context.Context, orgID, userID string) error {
+	result, err := db.ExecContext(ctx,
+		`DELETE FROM org_memberships WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
+
+// CreateOrgInvitation inserts an invitation, replacing any pending one for
+// the same email.
+func (db *DB) CreateOrgInvitation(ctx context.Context, inv *models.OrgInvitation) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM org_invitations
+		WHERE org_id = $1 AND lower(email) = lower($2) AND accepted_at IS NULL`,
+		inv.OrgID, inv.Email)
+	if err != nil {
+		return fmt.Errorf("failed to replace invitation: %w", err)
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO org_invitations (org_id, email, role, token_hash, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		inv.OrgID, inv.Email, inv.Role, inv.TokenHash, inv.InvitedBy, inv.ExpiresAt,
+	).Scan(&inv.ID, &inv.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ListOrgInvitations returns an organization's pending, unexpired
+// invitations, newest first.
+func (db *DB) ListOrgInvitations(ctx context.Context, orgID string) ([]models.OrgInvitation, error) {
+	var invitations []models.OrgInvitation
+	err := db.SelectContext(ctx, &invitations, `
+		SELECT * FROM org_invitations
+		WHERE org_id = $1 AND accepted_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// DeleteOrgInvitation withdraws a pending invitation.
+func (db *DB) DeleteOrgInvitation(ctx context.Context, orgID, id string) error {
+	result, err := db.ExecContext(ctx,
+		`DELETE FROM org_invitations WHERE id = $1 AND org_id = $2 AND accepted_at IS NULL`, id, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("invitation not found")
+	}
+	return nil
+}
+
+// AcceptOrgInvitation redeems a pending, unexpired invitation addressed to
+// email and adds userID to the organization. Existing members keep their
+// current role. Fails if no such invitation exists.
+func (db *DB) AcceptOrgInvitation(ctx context.Context, tokenHash, userID, email string) (*models.OrgMember, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	defer tx.Rollback()
+
+	var inv struct {
+		OrgID string `db:"org_id"`
+		Role  string `db:"role"`
+	}
+	err = tx.GetContext(ctx, &inv, `
+		UPDATE org_invitations SET accepted_at = NOW()
+		WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > NOW()
+			AND lower(email) = lower($2)
+		RETURNING org_id, role`, tokenHash, email)
+	if err != nil {
+		return nil, fmt.Errorf("invitation not found: %w", err)
+	}
+
+	var m models.OrgMember
+	err = tx.GetContext(ctx, &m, `
+		INSERT INTO org_memberships (org_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET role = org_memberships.role
+		RETURNING org_id, user_id, role, created_at`, inv.OrgID, userID, inv.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	return &m, nil
+}
+
+// ListOrgAPIKeys returns an organization's API keys, newest first.
+func (db *DB) ListOrgAPIKeys(ctx context.Context, orgID string) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := db.SelectContext(ctx, &keys,
+		`SELECT * FROM api_keys WHERE org_id = $1 ORDER BY created_at DESC`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeOrgAPIKey deactivates one of an organization's API keys.
+func (db *DB) RevokeOrgAPIKey(ctx context.Context, orgID, keyID string) error {
+	result, err := db.ExecContext(ctx,
+		`UPDATE api_keys SET active = false WHERE id = $1 AND org_id = $2`, keyID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke key: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("API key not found")
+	}
+	return nil
+}
+
+// administeredOrgs selects the organizations a user ($n) is an owner or
+// admin of.
+func administeredOrgs(placeholder string) string {
+	return `SELECT org_id FROM org_memberships WHERE user_id = ` + placeholder + ` AND role IN ('owner', 'admin')`
+}
+
+// IsOrgAdmin reports whether a user is an owner or admin of any organization.
+func (db *DB) IsOrgAdmin(ctx context.Context, userID string) (bool, error) {
+	var ok bool
+	err := db.GetContext(ctx, &ok, `SELECT EXISTS (`+administeredOrgs("$1")+`)`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization roles: %w", err)
+	}
+	return ok, nil
+}
+
+// ListAdministeredAPIKeys returns the API keys of every organization a user
+// is an owner or admin of, newest first.
+func (db *DB) ListAdministeredAPIKeys(ctx context.Context, userID string) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := db.SelectContext(ctx, &keys,
+		`SELECT * FROM api_keys WHERE org_id IN (`+administeredOrgs("$1")+`) ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAdministeredAPIKey deactivates an API key belonging to an
+// organization the user is an owner or admin of.
+func (db *DB) RevokeAdministeredAPIKey(ctx context.Context, userID, keyID string) error {
+	result, err := db.ExecContext(ctx,
+		`UPDATE api_keys SET active = false WHERE id = $1 AND org_id IN (`+administeredOrgs("$2")+`)`, keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke key: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("API key not found")
+	}
+	return nil
+}
+
+// UserManagesAPIKey reports whether an API key is the user's own or
+// belongs to an organization they're an owner or admin of.
+func (db *DB) UserManagesAPIKey(ctx context.Context, userID, keyID string) (bool, error) {
+	var ok bool
+	err := db.GetContext(ctx, &ok, `
+		SELECT EXISTS (
+			SELECT 1 FROM api_keys
+			WHERE id = $1 AND (user_id = $2 OR org_id IN (`+administeredOrgs("$2")+`))
+		)`, keyID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check API key access: %w", err)
+	}
+	return ok, nil
+}
+
+// UserAdministersAPIKey reports whether an API key belongs to an
+// organization the user is an owner or admin of. Unlike UserManagesAPIKey,
+// the user's own keys don't count.
+func (db *DB) UserAdministersAPIKey(ctx context.Context, userID, keyID string) (bool, error) {
+	var ok bool
+	err := db.GetContext(ctx, &ok, `
+		SELECT EXISTS (
+			SELECT 1 FROM api_keys WHERE id = $1 AND org_id IN (`+administeredOrgs("$2")+`)
+		)`, keyID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check API key access: %w", err)
+	}
+	return ok, nil
+}
+
+// GetAPIKeyOrgID returns the organization an API key belongs to, or nil.
+func (db *DB) GetAPIKeyOrgID(ctx context.Context, keyID string) (*string, error) {
+	var orgID *string
+	if err := db.GetContext(ctx, &orgID, `SELECT org_id FROM api_keys WHERE id = $1`, keyID); err != nil {
+		return nil, fmt.Errorf("API key not found: %w", err)
+	}
+	return orgID, nil
+}
+
+// VisibleAPIKeyIDs returns the API keys whose items keyID can see: itself
+// and, for an organization key, every key in the organization.
+func (db *DB) VisibleAPIKeyIDs(ctx context.Context, keyID string) ([]string, error) {
+	var ids []string
+	query := `
+		SELECT id FROM api_keys
+		WHERE id = $1 OR org_id = (SELECT org_id FROM api_keys WHERE id = $1)`
+	if err := db.SelectContext(ctx, &ids, query, keyID); err != nil {
+		return nil, fmt.Errorf("failed to list visible API keys: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,59 @@
+// originals.go tracks which uploads have their original file in storage.
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// originalTables maps the item types that keep originals to their tables.
+var originalTables = map[string]string{
+	"audio": "audio_transcriptions",
+	"pdf":   "pdf_extractions",
+}
+
+// MarkOriginalStored records that an item's original file is in storage.
+func (db *DB) MarkOriginalStored(ctx context.Context, itemType, id string) error {
+	return db.setOriginalStored(ctx, itemType, id, "NOW()")
+}
+
+// ClearOriginalStored records that an item's original file was removed.
+func (db *DB) ClearOriginalStored(ctx context.Context, itemType, id string) error {
+	return db.setOriginalStored(ctx, itemType, id, "NULL")
+}
+
+func (db *DB) setOriginalStored(ctx context.Context, itemType, id, value string) error {
+	table, ok := originalTables[itemType]
+	if !ok {
+		return fmt.Errorf("unknown item type %q", itemType)
+	}
+	query := fmt.Sprintf("UPDATE %s SET original_stored_at = %s WHERE id = $1", table, value)
+	if _, err := db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to update original for %s %s: %w", itemType, id, err)
+	}
+	return nil
+}
+
+// ListExpiredOriginals returns up to limit items whose original was stored
+// before the cutoff, oldest first.
+func (db *DB) ListExpiredOriginals(ctx context.Context, before time.Time, limit int) ([]models.StoredOriginal, error) {
+	query := `
+		SELECT type, id, filename FROM (
+			SELECT 'audio' AS type, id, filename, original_stored_at FROM audio_transcriptions
+			WHERE original_stored_at < $1
+			UNION ALL
+			SELECT 'pdf' AS type, id, filename, original_stored_at FROM pdf_extractions
+			WHERE original_stored_at < $1
+		) o
+		ORDER BY original_stored_at
+		LIMIT $2`
+
+	var originals []models.StoredOriginal
+	if err := db.SelectContext(ctx, &originals, query, before, limit); err != nil {
+		return nil, fmt.Errorf("failed to list expired originals: %w", err)
+	}
+	return originals, nil
+}
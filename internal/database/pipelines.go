@@ -0,0 +1,112 @@
+// pipelines.go contains database operations for pipelines and their runs.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CreatePipeline inserts a new pipeline.
+func (db *DB) CreatePipeline(ctx context.Context, p *models.Pipeline) error {
+	query := `
+		INSERT INTO pipelines (api_key_id, name, steps)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`
+
+	return db.QueryRowContext(ctx, query, p.APIKeyID, p.Name, p.Steps).
+		Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+}
+
+// GetPipeline retrieves a single pipeline by ID.
+func (db *DB) GetPipeline(ctx context.Context, id string) (*models.Pipeline, error) {
+	var p models.Pipeline
+	if err := db.GetContext(ctx, &p, `SELECT * FROM pipelines WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("pipeline not found: %w", err)
+	}
+	return &p, nil
+}
+
+// ListPipelines returns an API key's pipelines, newest first.
+func (db *DB) ListPipelines(ctx context.Context, apiKeyID string) ([]models.Pipeline, error) {
+	var pipelines []models.Pipeline
+	err := db.SelectContext(ctx, &pipelines,
+		`SELECT * FROM pipelines WHERE api_key_id = $1 ORDER BY created_at DESC`, apiKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+	return pipelines, nil
+}
+
+// UpdatePipeline replaces a pipeline's name and steps.
+func (db *DB) UpdatePipeline(ctx context.Context, p *models.Pipeline) error {
+	err := db.QueryRowContext(ctx, `
+		UPDATE pipelines SET name = $2, steps = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`, p.ID, p.Name, p.Steps).Scan(&p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update pipeline: %w", err)
+	}
+	return nil
+}
+
+// DeletePipeline removes a pipeline and its runs.
+func (db *DB) DeletePipeline(ctx context.Context, id string) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM pipelines WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete pipeline: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("pipeline not found")
+	}
+	return nil
+}
+
+// CreatePipelineRun inserts a new pipeline run.
+func (db *DB) CreatePipelineRun(ctx context.Context, r *models.PipelineRun) error {
+	query := `
+		INSERT INTO pipeline_runs (pipeline_id, api_key_id, user_id, transcript_id, status, steps)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	return db.QueryRowContext(ctx, query,
+		r.PipelineID, r.APIKeyID, r.UserID, r.TranscriptID, r.Status, r.Steps,
+	).Scan(&r.ID, &r.CreatedAt, &r.UpdatedAt)
+}
+
+// GetPipelineRun retrieves a single pipeline run by ID.
+func (db *DB) GetPipelineRun(ctx context.Context, id string) (*models.PipelineRun, error) {
+	var r models.PipelineRun
+	if err := db.GetContext(ctx, &r, `SELECT * FROM pipeline_runs WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("pipeline run not found: %w", err)
+	}
+	return &r, nil
+}
+
+// ListPipelineRuns returns a pipeline's most recent runs, newest first.
+func (db *DB) ListPipelineRuns(ctx context.Context, pipelineID string, limit int) ([]models.PipelineRun, error) {
+	var runs []models.PipelineRun
+	err := db.SelectContext(ctx, &runs,
+		`SELECT * FROM pipeline_runs WHERE pipeline_id = $1 ORDER BY created_at DESC LIMIT $2`, pipelineID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline runs: %w", err)
+	}
+	return runs, nil
+}
+
+// UpdatePipelineRun saves a run's progress.
+func (db *DB) UpdatePipelineRun(ctx context.Context, r *models.PipelineRun) error {
+	err := db.QueryRowContext(ctx, `
+		UPDATE pipeline_runs
+		SET status = $2, current_step = $3, steps = $4, error_message = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		r.ID, r.Status, r.CurrentStep, r.Steps, r.ErrorMessage,
+	).Scan(&r.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update pipeline run: %w", err)
+	}
+	return nil
+}
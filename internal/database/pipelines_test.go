@@ -0,0 +1,70 @@
+//go:build cgo
+
+// pipelines_test.go contains tests for pipeline and pipeline run storage,
+// run against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestPipelines verifies pipelines are stored per key and that runs save
+// their progress and go away with their pipeline.
+func TestPipelines(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	key := &models.APIKey{KeyHash: "hash", KeyPrefix: "mta_abc", Name: "dev", Active: true, Tier: models.TierFree, Role: "member"}
+	if err := db.CreateAPIKey(ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	tr := &models.Transcript{YouTubeURL: "https://www.youtube.com/watch?v=abc", YouTubeID: "abc", Status: models.StatusPending, APIKeyID: &key.ID}
+	if err := db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+
+	p := &models.Pipeline{APIKeyID: key.ID, Name: "Lectures", Steps: []byte(`[{"type":"extract"}]`)}
+	if err := db.CreatePipeline(ctx, p); err != nil {
+		t.Fatalf("CreatePipeline: %v", err)
+	}
+	p.Name = "Talks"
+	p.Steps = []byte(`[{"type":"extract"},{"type":"webhook"}]`)
+	if err := db.UpdatePipeline(ctx, p); err != nil {
+		t.Fatalf("UpdatePipeline: %v", err)
+	}
+	if got, err := db.GetPipeline(ctx, p.ID); err != nil || got.Name != "Talks" || string(got.Steps) != string(p.Steps) {
+		t.Errorf("GetPipeline = %+v, %v", got, err)
+	}
+	if list, err := db.ListPipelines(ctx, key.ID); err != nil || len(list) != 1 {
+		t.Errorf("ListPipelines = %d pipelines, %v; want 1", len(list), err)
+	}
+
+	run := &models.PipelineRun{PipelineID: p.ID, APIKeyID: key.ID, TranscriptID: &tr.ID, Status: "pending", Steps: p.Steps}
+	if err := db.CreatePipelineRun(ctx, run); err != nil {
+		t.Fatalf("CreatePipelineRun: %v", err)
+	}
+	run.Status, run.CurrentStep, run.ErrorMessage = "failed", 1, "boom"
+	if err := db.UpdatePipelineRun(ctx, run); err != nil {
+		t.Fatalf("UpdatePipelineRun: %v", err)
+	}
+	got, err := db.GetPipelineRun(ctx, run.ID)
+	if err != nil || got.Status != "failed" || got.CurrentStep != 1 || got.ErrorMessage != "boom" || got.UserID != nil {
+		t.Errorf("GetPipelineRun = %+v, %v", got, err)
+	}
+	if runs, err := db.ListPipelineRuns(ctx, p.ID, 50); err != nil || len(runs) != 1 {
+		t.Errorf("ListPipelineRuns = %d runs, %v; want 1", len(runs), err)
+	}
+
+	if err := db.DeletePipeline(ctx, p.ID); err != nil {
+		t.Fatalf("DeletePipeline: %v", err)
+	}
+	if _, err := db.GetPipelineRun(ctx, run.ID); err == nil {
+		t.Error("run survived its pipeline's deletion")
+	}
+	if err := db.DeletePipeline(ctx, p.ID); err == nil {
+		t.Error("deleting a missing pipeline succeeded")
+	}
+}
@@ -0,0 +1,22 @@
+// redaction.go records PII redaction reports for items masked before storage.
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SaveRedaction stores the redaction report for an item whose text was
+// masked before it was saved.
+func (db *DB) SaveRedaction(ctx context.Context, itemType, id string, report json.RawMessage) error {
+	table, ok := itemTables[itemType]
+	if !ok {
+		return fmt.Errorf("unknown item type %q", itemType)
+	}
+	query := fmt.Sprintf("UPDATE %s SET redaction = $2::jsonb WHERE id = $1", table)
+	if _, err := db.ExecContext(ctx, query, id, string(report)); err != nil {
+		return fmt.Errorf("failed to save redaction for %s %s: %w", itemType, id, err)
+	}
+	return nil
+}
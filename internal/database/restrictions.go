@@ -0,0 +1,48 @@
+// restrictions.go updates the origin and IP allowlists of API keys, scoped
+// the same way as revoking them.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// SetAPIKeyRestrictions replaces any API key's allowlists.
+func (db *DB) SetAPIKeyRestrictions(ctx context.Context, keyID string, origins, ips pq.StringArray) (*models.APIKey, error) {
+	return db.setAPIKeyRestrictions(ctx, "", keyID, origins, ips)
+}
+
+// SetOrgAPIKeyRestrictions replaces the allowlists of an organization's key.
+func (db *DB) SetOrgAPIKeyRestrictions(ctx context.Context, orgID, keyID string, origins, ips pq.StringArray) (*models.APIKey, error) {
+	return db.setAPIKeyRestrictions(ctx, "AND org_id = $4", keyID, origins, ips, orgID)
+}
+
+// SetAdministeredAPIKeyRestrictions replaces the allowlists of a key
+// belonging to an organization the user is an owner or admin of.
+func (db *DB) SetAdministeredAPIKeyRestrictions(ctx context.Context, userID, keyID string, origins, ips pq.StringArray) (*models.APIKey, error) {
+	return db.setAPIKeyRestrictions(ctx, "AND org_id IN ("+administeredOrgs("$4")+")", keyID, origins, ips, userID)
+}
+
+// SetUserAPIKeyRestrictions replaces the allowlists of one of a user's keys.
+func (db *DB) SetUserAPIKeyRestrictions(ctx context.Context, userID, keyID string, origins, ips pq.StringArray) (*models.APIKey, error) {
+	return db.setAPIKeyRestrictions(ctx, "AND user_id = $4", keyID, origins, ips, userID)
+}
+
+// setAPIKeyRestrictions updates a key's allowlists when it also matches
+// scope, an extra condition whose argument is $4.
+func (db *DB) setAPIKeyRestrictions(ctx context.Context, scope, keyID string, origins, ips pq.StringArray, scopeArgs ...interface{}) (*models.APIKey, error) {
+	var key models.APIKey
+	args := append([]interface{}{keyID, origins, ips}, scopeArgs...)
+	err := db.GetContext(ctx, &key, `
+		UPDATE api_keys SET allowed_origins = COALESCE($2, '{}'::text[]), allowed_ips = COALESCE($3, '{}'::text[])
+		WHERE id = $1 `+scope+`
+		RETURNING *`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("API key not found: %w", err)
+	}
+	return &key, nil
+}
@@ -0,0 +1,219 @@
+// retention.go contains database operations for retention policies and
+// the purge audit log.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// retentionOwners maps a policy scope to its owner column.
+var retentionOwners = map[string]string{
+	"api_key": "api_key_id",
+	"user":    "user_id",
+}
+
+// purgeTarget is a table retention policies purge, with the SQL for each
+// item's audit log title, its stored original (if any), and its offloaded
+// text and original text (if any).
+type purgeTarget struct {
+	itemType, table, title, original, text, originalText string
+}
+
+var purgeTargets = []purgeTarget{
+	{"transcript", "transcripts", "t.title", "NULL::text", "t.text_key", "t.original_text_key"},
+	{"audio", "audio_transcriptions", "COALESCE(NULLIF(t.title, ''), t.original_name)", "CASE WHEN t.original_stored_at IS NOT NULL THEN t.filename END", "t.text_key", "NULL::text"},
+	{"pdf", "pdf_extractions", "COALESCE(NULLIF(t.title, ''), t.original_name)", "CASE WHEN t.original_stored_at IS NOT NULL THEN t.filename END", "NULL::text", "NULL::text"},
+}
+
+// purgedItem is a purge log entry with the offloaded text its item had,
+// which is removed from the text store once the purge commits.
+type purgedItem struct {
+	models.Purge
+	TextKey         *string `db:"text_key"`
+	OriginalTextKey *string `db:"original_text_key"`
+}
+
+// logPurged removes purged items' offloaded text and returns their log
+// entries.
+func (db *DB) logPurged(ctx context.Context, items []purgedItem) []models.Purge {
+	purged := make([]models.Purge, 0, len(items))
+	for _, item := range items {
+		db.deleteText(ctx, item.TextKey, item.OriginalTextKey)
+		purged = append(purged, item.Purge)
+	}
+	return purged
+}
+
+// GetRetentionPolicy returns the policy for an API key or user. With no
+// policy set, RetentionDays is nil (keep forever).
+func (db *DB) GetRetentionPolicy(ctx context.Context, scope, ownerID string) (*models.RetentionPolicy, error) {
+	column, ok := retentionOwners[scope]
+	if !ok {
+		return nil, fmt.Errorf("unknown retention scope %q", scope)
+	}
+	p := models.RetentionPolicy{Scope: scope}
+	query := fmt.Sprintf(`SELECT retention_days, updated_at FROM retention_policies WHERE %s = $1`, column)
+	err := db.GetContext(ctx, &p, query, ownerID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get retention policy: %w", err)
+	}
+	return &p, nil
+}
+
+// SetRetentionPolicy sets how many days an API key's or user's items are
+// kept. days <= 0 removes the policy, keeping items forever.
+func (db *DB) SetRetentionPolicy(ctx context.Context, scope, ownerID string, days int) error {
+	column, ok := retentionOwners[scope]
+	if !ok {
+		return fmt.Errorf("unknown retention scope %q", scope)
+	}
+
+	var err error
+	if days <= 0 {
+		_, err = db.ExecContext(ctx,
+			fmt.Sprintf(`DELETE FROM retention_policies WHERE %s = $1`, column), ownerID)
+	} else {
+		_, err = db.ExecContext(ctx, fmt.Sprintf(`
+			INSERT INTO retention_policies (%[1]s, retention_days) VALUES ($1, $2)
+			ON CONFLICT (%[1]s) DO UPDATE SET retention_days = $2, updated_at = NOW()`, column),
+			ownerID, days)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set retention policy: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpiredItems deletes up to limit items of each type that are older
+// than their owner's retention policy, logging each one in purges. Items
+// saved to any workspace are exempt. When an item's API key and user both
+// have a policy, the shorter one applies.
+func (db *DB) PurgeExpiredItems(ctx context.Context, limit int) ([]models.Purge, error) {
+	if db.isSQLite() {
+		return db.purgeExpiredItemsSQLite(ctx, limit)
+	}
+	var purged []models.Purge
+	for _, target := range purgeTargets {
+		query := fmt.Sprintf(`
+			WITH expired AS (
+				SELECT t.id, LEAST(kp.retention_days, up.retention_days) AS retention_days
+				FROM %[1]s t
+				LEFT JOIN retention_policies kp ON kp.api_key_id = t.api_key_id
+				LEFT JOIN retention_policies up ON up.user_id = t.user_id
+				WHERE t.created_at < NOW() - make_interval(days => LEAST(kp.retention_days, up.retention_days))
+					AND NOT EXISTS (
+						SELECT 1 FROM workspace_items w WHERE w.item_type = '%[2]s' AND w.item_id = t.id
+					)
+				ORDER BY t.created_at
+				LIMIT $1
+				FOR UPDATE OF t SKIP LOCKED
+			), deleted AS (
+				DELETE FROM %[1]s t USING expired e WHERE t.id = e.id
+				RETURNING t.id, %[3]s AS title, t.api_key_id, t.user_id, e.retention_days,
+					%[4]s AS original_key, t.created_at, %[5]s AS text_key, %[6]s AS original_text_key
+			), logged AS (
+				INSERT INTO purges (item_type, item_id, title, api_key_id, user_id, retention_days, original_key, item_created_at)
+				SELECT '%[2]s', id, COALESCE(title, ''), api_key_id, user_id, retention_days, original_key, created_at FROM deleted
+				RETURNING *
+			)
+			SELECT logged.*, deleted.text_key, deleted.original_text_key
+			FROM logged JOIN deleted ON deleted.id = logged.item_id`,
+			target.table, target.itemType, target.title, target.original, target.text, target.originalText)
+
+		var rows []purgedItem
+		if err := db.SelectContext(ctx, &rows, query, limit); err != nil {
+			return purged, fmt.Errorf("failed to purge expired %s items: %w", target.itemType, err)
+		}
+		purged = append(purged, db.logPurged(ctx, rows)...)
+	}
+	return purged, nil
+}
+
+// purgeExpiredItemsSQLite is PurgeExpiredItems for SQLite, which has no
+// data-modifying CTEs: each expired item is deleted and logged in turn,
+// one transaction per item type.
+func (db *DB) purgeExpiredItemsSQLite(ctx context.Context, limit int) ([]models.Purge, error) {
+	var purged []models.Purge
+	for _, target := range purgeTargets {
+		rows, err := db.purgeExpiredTargetSQLite(ctx, target, limit)
+		if err != nil {
+			return purged, fmt.Errorf("failed to purge expired %s items: %w", target.itemType, err)
+		}
+		purged = append(purged, db.logPurged(ctx, rows)...)
+	}
+	return purged, nil
+}
+
+func (db *DB) purgeExpiredTargetSQLite(ctx context.Context, target purgeTarget, limit int) ([]purgedItem, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var expired []purgedItem
+	err = tx.SelectContext(ctx, &expired, fmt.Sprintf(`
+		SELECT t.id AS item_id, COALESCE(%[3]s, '') AS title, t.api_key_id, t.user_id,
+			LEAST(kp.retention_days, up.retention_days) AS retention_days,
+			%[4]s AS original_key, t.created_at AS item_created_at,
+			%[5]s AS text_key, %[6]s AS original_text_key
+		FROM %[1]s t
+		LEFT JOIN retention_policies kp ON kp.api_key_id = t.api_key_id
+		LEFT JOIN retention_policies up ON up.user_id = t.user_id
+		WHERE t.created_at < NOW() - make_interval(days => LEAST(kp.retention_days, up.retention_days))
+			AND NOT EXISTS (
+				SELECT 1 FROM workspace_items w WHERE w.item_type = '%[2]s' AND w.item_id = t.id
+			)
+		ORDER BY t.created_at
+		LIMIT $1`, target.table, target.itemType, target.title, target.original, target.text, target.originalText), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	purged := make([]purgedItem, 0, len(expired))
+	for _, e := range expired {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, target.table), e.ItemID); err != nil {
+			return nil, err
+		}
+		p := purgedItem{TextKey: e.TextKey, OriginalTextKey: e.OriginalTextKey}
+		err := tx.GetContext(ctx, &p.Purge, `
+			INSERT INTO purges (item_type, item_id, title, api_key_id, user_id, retention_days, original_key, item_created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING *`,
+			target.itemType, e.ItemID, e.Title, e.APIKeyID, e.UserID, e.RetentionDays, e.OriginalKey, e.ItemCreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		purged = append(purged, p)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+// ListPurges returns the purge log for an API key or user, newest first.
+// With neither set it returns every entry (for admins).
+func (db *DB) ListPurges(ctx context.Context, apiKeyID, userID *string, limit int) ([]models.Purge, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	query := `
+		SELECT * FROM purges
+		WHERE ($1::uuid IS NULL AND $2::uuid IS NULL)
+			OR ($1::uuid IS NOT NULL AND api_key_id = $1)
+			OR ($2::uuid IS NOT NULL AND user_id = $2)
+		ORDER BY purged_at DESC
+		LIMIT $3`
+
+	var purges []models.Purge
+	if err := db.SelectContext(ctx, &purges, query, apiKeyID, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list purges: %w", err)
+	}
+	return purges, nil
+}
@@ -0,0 +1,156 @@
+// saved_searches.go contains database operations for saved searches and
+// the matching behind search.matched alerts.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+const savedSearchColumns = `id, api_key_id, name, query, types, notify, last_matched_at, created_at`
+
+// scanSavedSearch reads a row selected with savedSearchColumns.
+func scanSavedSearch(row interface{ Scan(...interface{}) error }) (models.SavedSearch, error) {
+	var s models.SavedSearch
+	err := row.Scan(&s.ID, &s.APIKeyID, &s.Name, &s.Query, pq.Array(&s.Types), &s.Notify, &s.LastMatchedAt, &s.CreatedAt)
+	return s, err
+}
+
+// CreateSavedSearch inserts a new saved search.
+func (db *DB) CreateSavedSearch(ctx context.Context, s *models.SavedSearch) error {
+	query := `
+		INSERT INTO saved_searches (api_key_id, name, query, types, notify)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		s.APIKeyID, s.Name, s.Query, pq.Array(s.Types), s.Notify,
+	).Scan(&s.ID, &s.CreatedAt)
+}
+
+// GetSavedSearch retrieves a single saved search by ID.
+func (db *DB) GetSavedSearch(ctx context.Context, id string) (*models.SavedSearch, error) {
+	s, err := scanSavedSearch(db.QueryRowContext(ctx,
+		`SELECT `+savedSearchColumns+` FROM saved_searches WHERE id = $1`, id))
+	if err != nil {
+		return nil, fmt.Errorf("saved search not found: %w", err)
+	}
+	return &s, nil
+}
+
+// ListSavedSearches returns an API key's saved searches, newest first.
+func (db *DB) ListSavedSearches(ctx context.Context, apiKeyID string) ([]models.SavedSearch, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT `+savedSearchColumns+` FROM saved_searches WHERE api_key_id = $1 ORDER BY created_at DESC`, apiKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches []models.SavedSearch
+	for rows.Next() {
+		s, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+		searches = append(searches, s)
+	}
+	return searches, rows.Err()
+}
+
+// DeleteSavedSearch removes a saved search by ID.
+func (db *DB) DeleteSavedSearch(ctx context.Context, id string) error {
+	result, err := db.ExecContext(ctx, `DELETE FROM saved_searches WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("saved search not found")
+	}
+	return nil
+}
+
+// MatchSavedSearches finds the notifying saved searches, owned by the same
+// API key as the item, whose query and type filter match the given item, and
+// stamps their last_matched_at. Items without an API key match nothing.
+func (db *DB) MatchSavedSearches(ctx context.Context, itemType, itemID string) ([]models.SavedSearchMatch, error) {
+	source, ok := searchSources[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unknown item type %q", itemType)
+	}
+	if db.isSQLite() {
+		return db.matchSavedSearchesSQLite(ctx, source, itemType, itemID)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE saved_searches s SET last_matched_at = NOW()
+		FROM (%s) i
+		WHERE i.id = $1 AND s.notify AND s.api_key_id = i.api_key_id
+			AND (cardinality(s.types) = 0 OR i.type = ANY(s.types))
+			AND i.search_vector @@ websearch_to_tsquery('english', s.query)
+		RETURNING s.id, s.name, s.query, s.api_key_id, i.title`, source)
+
+	rows, err := db.QueryContext(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []models.SavedSearchMatch
+	for rows.Next() {
+		m := models.SavedSearchMatch{ItemType: itemType, ItemID: itemID}
+		if err := rows.Scan(&m.SavedSearchID, &m.Name, &m.Query, &m.APIKeyID, &m.ItemTitle); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search match: %w", err)
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// matchSavedSearchesSQLite is MatchSavedSearches for SQLite. Each saved
+// query is matched with sqliteMatch, since SQLite can't parse queries
+// stored in a column, and matches are stamped afterwards.
+func (db *DB) matchSavedSearchesSQLite(ctx context.Context, source, itemType, itemID string) ([]models.SavedSearchMatch, error) {
+	var candidates []struct {
+		ID       string `db:"id"`
+		Name     string `db:"name"`
+		Query    string `db:"query"`
+		APIKeyID string `db:"api_key_id"`
+		Title    string `db:"title"`
+	}
+	err := db.SelectContext(ctx, &candidates, fmt.Sprintf(`
+		SELECT s.id, s.name, s.query, s.api_key_id, i.title
+		FROM saved_searches s
+		JOIN (%s) i ON i.api_key_id = s.api_key_id
+		WHERE i.id = $1 AND s.notify
+			AND (cardinality(s.types) = 0 OR i.type = ANY(s.types))`, source), itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match saved searches: %w", err)
+	}
+
+	var matches []models.SavedSearchMatch
+	for _, c := range candidates {
+		match, args := sqliteMatch(c.Query, 2)
+		var found bool
+		query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM (%s) i WHERE id = $1 AND %s)`, source, match)
+		if err := db.GetContext(ctx, &found, query, append([]interface{}{itemID}, args...)...); err != nil {
+			return nil, fmt.Errorf("failed to match saved search: %w", err)
+		}
+		if !found {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, `UPDATE saved_searches SET last_matched_at = NOW() WHERE id = $1`, c.ID); err != nil {
+			return nil, fmt.Errorf("failed to stamp saved search: %w", err)
+		}
+		matches = append(matches, models.SavedSearchMatch{
+			SavedSearchID: c.ID, Name: c.Name, Query: c.Query, APIKeyID: c.APIKeyID,
+			ItemType: itemType, ItemID: itemID, ItemTitle: c.Title,
+		})
+	}
+	return matches, nil
+}
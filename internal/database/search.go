@@ -0,0 +1,250 @@
+// search.go implements full-text search across transcripts, audio
+// transcriptions, and PDF extractions for GET /api/v1/search/text.
+package database
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// searchSources maps item types to the query that projects each table onto
+// the searchable columns.
+var searchSources = map[string]string{
+	"transcript": "SELECT 'transcript' AS type, id, title, status, created_at, api_key_id, user_id, search_vector FROM transcripts",
+	"audio":      "SELECT 'audio' AS type, id, COALESCE(NULLIF(title, ''), original_name) AS title, status, created_at, api_key_id, user_id, search_vector FROM audio_transcriptions",
+	"pdf":        "SELECT 'pdf' AS type, id, COALESCE(NULLIF(title, ''), original_name) AS title, status, created_at, api_key_id, user_id, search_vector FROM pdf_extractions",
+}
+
+// snippetBody fetches the text a hit's snippet is cut from. It's looked up
+// only for the rows on the returned page, since ts_headline is expensive.
+const snippetBody = `CASE page.type
+	WHEN 'transcript' THEN (SELECT transcript_text FROM transcripts WHERE id = page.id)
+	WHEN 'audio' THEN (SELECT transcript_text FROM audio_transcriptions WHERE id = page.id)
+	ELSE (SELECT text_content FROM pdf_extractions WHERE id = page.id)
+END`
+
+// Snippet match markers. ts_headline doesn't escape the text around its
+// markers, so it marks matches with control characters that can't collide
+// with HTML, and formatSnippet swaps them for <mark> after escaping.
+const (
+	snippetStart = "\x01"
+	snippetStop  = "\x02"
+)
+
+// headlineOptions configures ts_headline for short multi-fragment snippets.
+const headlineOptions = `StartSel="` + snippetStart + `", StopSel="` + snippetStop + `", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+// SearchText returns a page of items matching a web-style query (quoted
+// phrases, OR, and -exclusions work) ranked by relevance, plus the total
+// match count. types limits which tables are searched (all if empty).
+func (db *DB) SearchText(ctx context.Context, params models.TextSearchParams, types []string) ([]models.SearchResult, int, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+	if len(types) == 0 {
+		types = itemTypes
+	}
+
+	var parts []string
+	for _, t := range types {
+		source, ok := searchSources[t]
+		if !ok {
+			return nil, 0, fmt.Errorf("unknown item type %q", t)
+		}
+		parts = append(parts, source)
+	}
+	union := strings.Join(parts, " UNION ALL ")
+
+	conditions := []string{"search_vector @@ websearch_to_tsquery('english', $1)"}
+	args := []interface{}{params.Query}
+	if db.isSQLite() {
+		match, matchArgs := sqliteMatch(params.Query, 1)
+		conditions, args = []string{match}, matchArgs
+	}
+	argNum := len(args) + 1
+
+	if scope, arg := callerScope(params.APIKeyID, params.UserID, fmt.Sprintf("$%d", argNum)); scope != "" {
+		conditions = append(conditions, scope)
+		args = append(args, arg)
+		argNum++
+	}
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) hits %s", union, whereClause)
+	if err := db.reader().GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	// Rank and page first, then build snippets for just that page
+	offset := (params.Page - 1) * params.PerPage
+	if db.isSQLite() {
+		results, err := db.searchTextSQLite(ctx, union, whereClause, args, params.PerPage, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		db.fillOffloadedSnippets(ctx, results, params.Query)
+		return results, total, nil
+	}
+	selectQuery := fmt.Sprintf(`
+		SELECT page.type, page.id, page.title, page.status, page.created_at, page.rank,
+			ts_headline('english', %s, websearch_to_tsquery('english', $1), $%d) AS snippet
+		FROM (
+			SELECT type, id, title, status, created_at,
+				ts_rank(search_vector, websearch_to_tsquery('english', $1)) AS rank
+			FROM (%s) hits %s
+			ORDER BY rank DESC, created_at DESC, id
+			LIMIT $%d OFFSET $%d
+		) page
+		ORDER BY page.rank DESC, page.created_at DESC, page.id`,
+		snippetBody, argNum, union, whereClause, argNum+1, argNum+2,
+	)
+	args = append(args, headlineOptions, params.PerPage, offset)
+
+	var results []models.SearchResult
+	if err := db.reader().SelectContext(ctx, &results, selectQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("search query failed: %w", err)
+	}
+	for i := range results {
+		results[i].Snippet = formatSnippet(results[i].Snippet)
+	}
+	db.fillOffloadedSnippets(ctx, results, params.Query)
+	return results, total, nil
+}
+
+// formatSnippet HTML-escapes a ts_headline snippet, wraps its matches in
+// <mark>, and collapses whitespace so line breaks in the source don't leak in.
+func formatSnippet(s string) string {
+	s = html.EscapeString(strings.Join(strings.Fields(s), " "))
+	s = strings.ReplaceAll(s, snippetStart, "<mark>")
+	return strings.ReplaceAll(s, snippetStop, "</mark>")
+}
+
+// searchTextTables maps item types to the tables whose bodies can be
+// offloaded (see offload.go).
+var searchTextTables = map[string]string{
+	"transcript": "transcripts",
+	"audio":      "audio_transcriptions",
+}
+
+// fillOffloadedSnippets cuts snippets for hits whose body is offloaded,
+// which snippetBody sees as empty, from the text store. A body that can't
+// be loaded leaves its hit without a snippet.
+func (db *DB) fillOffloadedSnippets(ctx context.Context, results []models.SearchResult, query string) {
+	for i := range results {
+		table, ok := searchTextTables[results[i].Type]
+		if !ok || results[i].Snippet != "" {
+			continue
+		}
+		key := db.storedTextKey(ctx, table, results[i].ID)
+		if key == nil {
+			continue
+		}
+		snippet, err := db.offloadedSnippet(ctx, *key, query, "websearch_to_tsquery")
+		if err != nil {
+			log.Printf("⚠️  Failed to build snippet for %s %s: %v", results[i].Type, results[i].ID, err)
+			continue
+		}
+		results[i].Snippet = snippet
+	}
+}
+
+// offloadedSnippet cuts a formatted snippet for query from the offloaded
+// body under key, as the search queries do for bodies in the row. parser
+// is the tsquery function the search matched with.
+func (db *DB) offloadedSnippet(ctx context.Context, key, query, parser string) (string, error) {
+	text, err := db.loadText(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if db.isSQLite() {
+		if runes := []rune(text); len(runes) > 200 {
+			text = string(runes[:200])
+		}
+		return formatSnippet(text), nil
+	}
+	var snippet string
+	err = db.reader().GetContext(ctx, &snippet,
+		fmt.Sprintf(`SELECT ts_headline('english', $1, %s('english', $2), $3)`, parser), text, query, headlineOptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to build snippet: %w", err)
+	}
+	return formatSnippet(snippet), nil
+}
+
+// searchTextSQLite fetches a page of SearchText hits on SQLite. Without
+// full-text ranking, hits are newest first, with the start of the text as
+// their snippet.
+func (db *DB) searchTextSQLite(ctx context.Context, union, whereClause string, args []interface{}, perPage, offset int) ([]models.SearchResult, error) {
+	argNum := len(args) + 1
+	selectQuery := fmt.Sprintf(`
+		SELECT page.type, page.id, page.title, page.status, page.created_at, 0 AS rank,
+			substr(%s, 1, 200) AS snippet
+		FROM (
+			SELECT type, id, title, status, created_at
+			FROM (%s) hits %s
+			ORDER BY created_at DESC, id
+			LIMIT $%d OFFSET $%d
+		) page
+		ORDER BY page.created_at DESC, page.id`,
+		snippetBody, union, whereClause, argNum, argNum+1,
+	)
+	args = append(args, perPage, offset)
+
+	var results []models.SearchResult
+	if err := db.SelectContext(ctx, &results, selectQuery, args...); err != nil {
+		return nil, fmt.Errorf("search query failed: %w", err)
+	}
+	for i := range results {
+		results[i].Snippet = formatSnippet(results[i].Snippet)
+	}
+	return results, nil
+}
+
+// sqliteMatch approximates websearch_to_tsquery matching on SQLite, where
+// search_vector is lowercased text: every word or "quoted phrase" must
+// appear in it and every -word must not. OR is treated as an ordinary
+// word, and there's no stemming. Placeholders are numbered from argNum.
+func sqliteMatch(query string, argNum int) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, term := range searchTerms(query) {
+		op := "LIKE"
+		if strings.HasPrefix(term, "-") && len(term) > 1 {
+			op, term = "NOT LIKE", term[1:]
+		}
+		conditions = append(conditions, fmt.Sprintf(`search_vector %s $%d ESCAPE '\'`, op, argNum))
+		args = append(args, "%"+likeEscaper.Replace(strings.ToLower(term))+"%")
+		argNum++
+	}
+	if len(conditions) == 0 {
+		return "FALSE", nil
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", args
+}
+
+// likeEscaper escapes LIKE wildcards.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// searchTerms splits a web-style query into words and quoted phrases.
+func searchTerms(query string) []string {
+	var terms []string
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(part), " "); phrase != "" {
+				terms = append(terms, phrase)
+			}
+			continue
+		}
+		terms = append(terms, strings.Fields(part)...)
+	}
+	return terms
+}
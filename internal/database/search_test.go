@@ -0,0 +1,35 @@
+// search_test.go contains tests for full-text search helpers.
+package database
+
+import "testing"
+
+// TestFormatSnippet verifies snippets are escaped before matches are marked.
+func TestFormatSnippet(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "no matches here", "no matches here"},
+		{"match", "deploy to \x01kubernetes\x02 today", "deploy to <mark>kubernetes</mark> today"},
+		{"html escaped", "<b>\x01go\x02</b> & more", "&lt;b&gt;<mark>go</mark>&lt;/b&gt; &amp; more"},
+		{"whitespace collapsed", "line one\n\n  \x01two\x02\tthree", "line one <mark>two</mark> three"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSnippet(tt.in); got != tt.want {
+				t.Errorf("formatSnippet(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSearchSourcesCoverItemTypes verifies every item type is searchable.
+func TestSearchSourcesCoverItemTypes(t *testing.T) {
+	for _, typ := range itemTypes {
+		if _, ok := searchSources[typ]; !ok {
+			t.Errorf("searchSources is missing %q", typ)
+		}
+	}
+}
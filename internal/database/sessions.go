@@ -0,0 +1,118 @@
+// sessions.go handles login sessions and their rotating refresh tokens.
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CreateUserSession starts a session for a user holding the hash of its
+// first refresh token.
+func (db *DB) CreateUserSession(ctx context.Context, userID, tokenHash, userAgent, ipAddress string, ttl time.Duration) (*models.UserSession, error) {
+	var s models.UserSession
+	err := db.GetContext(ctx, &s, `
+		INSERT INTO user_sessions (user_id, refresh_token_hash, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING *`, userID, tokenHash, userAgent, ipAddress, time.Now().Add(ttl))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return &s, nil
+}
+
+// RotateUserSession swaps a live session's refresh token for a new one and
+// extends the session. The old hash is kept to detect it being replayed.
+func (db *DB) RotateUserSession(ctx context.Context, tokenHash, newTokenHash string, ttl time.Duration) (*models.UserSession, error) {
+	var s models.UserSession
+	err := db.GetContext(ctx, &s, `
+		UPDATE user_sessions
+		SET previous_token_hash = refresh_token_hash, refresh_token_hash = $2,
+		    last_used_at = NOW(), expires_at = $3
+		WHERE refresh_token_hash = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		RETURNING *`, tokenHash, newTokenHash, time.Now().Add(ttl))
+	if err != nil {
+		return nil, fmt.Errorf("session not found: %w", err)
+	}
+	return &s, nil
+}
+
+// RevokeReusedSession revokes the session whose previous refresh token has
+// the given hash. A rotated-out token coming back means it was copied, so
+// neither copy can be trusted. It reports whether a session was revoked.
+func (db *DB) RevokeReusedSession(ctx context.Context, tokenHash string) (bool, error) {
+	result, err := db.ExecContext(ctx,
+		`UPDATE user_sessions SET revoked_at = NOW() WHERE previous_token_hash = $1 AND revoked_at IS NULL`, tokenHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// RevokeSessionByToken revokes the session holding a refresh token.
+// Unknown or already revoked tokens are not an error.
+func (db *DB) RevokeSessionByToken(ctx context.Context, tokenHash string) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE user_sessions SET revoked_at = NOW() WHERE refresh_token_hash = $1 AND revoked_at IS NULL`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// ListUserSessions returns a user's live sessions, most recently used first.
+func (db *DB) ListUserSessions(ctx context.Context, userID string) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := db.SelectContext(ctx, &sessions, `
+		SELECT * FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// RevokeUserSession revokes one of a user's sessions.
+func (db *DB) RevokeUserSession(ctx context.Context, userID, sessionID string) error {
+	result, err := db.ExecContext(ctx,
+		`UPDATE user_sessions SET revoked_at = NOW() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`,
+		sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("session not found")
+	}
+	return nil
+}
+
+// RevokeUserSessions revokes every session a user has, signing them out
+// everywhere.
+func (db *DB) RevokeUserSessions(ctx context.Context, userID string) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE user_sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// GetUserBySession returns a user if the session is theirs and still live.
+// It's how access tokens are checked against revocation.
+func (db *DB) GetUserBySession(ctx context.Context, userID, sessionID string) (*models.User, error) {
+	var u models.User
+	err := db.GetContext(ctx, &u, `
+		SELECT u.* FROM users u
+		JOIN user_sessions s ON s.user_id = u.id
+		WHERE u.id = $1 AND s.id = $2 AND s.revoked_at IS NULL AND s.expires_at > NOW()`,
+		userID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("session not found: %w", err)
+	}
+	return &u, nil
+}
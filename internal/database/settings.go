@@ -0,0 +1,46 @@
+// settings.go stores admin overrides of runtime settings.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ListSettingOverrides returns every stored setting override.
+func (db *DB) ListSettingOverrides(ctx context.Context) ([]models.SettingOverride, error) {
+	var overrides []models.SettingOverride
+	if err := db.SelectContext(ctx, &overrides, `SELECT * FROM runtime_settings ORDER BY key`); err != nil {
+		return nil, fmt.Errorf("failed to list runtime settings: %w", err)
+	}
+	return overrides, nil
+}
+
+// SetSettingOverrides stores overrides by key, JSON-encoded, all or none.
+func (db *DB) SetSettingOverrides(ctx context.Context, values map[string]string) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for key, value := range values {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO runtime_settings (key, value) VALUES ($1, $2)
+			ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`, key, value)
+		if err != nil {
+			return fmt.Errorf("failed to save runtime setting %s: %w", key, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteSettingOverride removes an override, if any, restoring the
+// configured value.
+func (db *DB) DeleteSettingOverride(ctx context.Context, key string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM runtime_settings WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("failed to delete runtime setting: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// shares.go contains database operations for public share links.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CreateShareLink inserts a new share link.
+func (db *DB) CreateShareLink(ctx context.Context, s *models.ShareLink) error {
+	query := `
+		INSERT INTO share_links (token_hash, token_prefix, item_type, item_id, api_key_id, user_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		s.TokenHash, s.TokenPrefix, s.ItemType, s.ItemID, s.APIKeyID, s.UserID, s.ExpiresAt,
+	).Scan(&s.ID, &s.CreatedAt)
+}
+
+// GetShareLink retrieves a share link by ID.
+func (db *DB) GetShareLink(ctx context.Context, id string) (*models.ShareLink, error) {
+	var s models.ShareLink
+	err := db.GetContext(ctx, &s, `SELECT * FROM share_links WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("share link not found: %w", err)
+	}
+	return &s, nil
+}
+
+// GetActiveShareLinkByHash looks up an unrevoked, unexpired share link by
+// token hash and counts the view.
+func (db *DB) GetActiveShareLinkByHash(ctx context.Context, hash string) (*models.ShareLink, error) {
+	var s models.ShareLink
+	query := `
+		UPDATE share_links SET view_count = view_count + 1
+		WHERE token_hash = $1 AND revoked_at IS NULL
+			AND (expires_at IS NULL OR expires_at > NOW())
+		RETURNING *`
+	if err := db.GetContext(ctx, &s, query, hash); err != nil {
+		return nil, fmt.Errorf("share link not found: %w", err)
+	}
+	return &s, nil
+}
+
+// ListShareLinks returns the share links created by an API key or user,
+// newest first.
+func (db *DB) ListShareLinks(ctx context.Context, apiKeyID, userID *string) ([]models.ShareLink, error) {
+	query := `
+		SELECT * FROM share_links
+		WHERE ($1::uuid IS NOT NULL AND api_key_id = $1) OR ($2::uuid IS NOT NULL AND user_id = $2)
+		ORDER BY created_at DESC`
+
+	var links []models.ShareLink
+	if err := db.SelectContext(ctx, &links, query, apiKeyID, userID); err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	return links, nil
+}
+
+// RevokeShareLink marks a share link revoked. Revoking twice is a no-op.
+func (db *DB) RevokeShareLink(ctx context.Context, id string) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE share_links SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// spend.go records what API keys' AI completions cost and stores their
+// monthly spend limits.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// RecordAISpend records the tokens and cost of a completion made for an
+// API key.
+func (db *DB) RecordAISpend(ctx context.Context, keyID, model string, promptTokens, completionTokens int, costUSD float64) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO ai_usage (api_key_id, model, prompt_tokens, completion_tokens, cost_usd)
+		VALUES ($1, $2, $3, $4, $5)`,
+		keyID, model, promptTokens, completionTokens, costUSD)
+	if err != nil {
+		return fmt.Errorf("failed to record AI spend: %w", err)
+	}
+	return nil
+}
+
+// GetKeySpend returns an API key's AI spend for the calendar month (UTC)
+// containing now, and its limit if it has one.
+func (db *DB) GetKeySpend(ctx context.Context, keyID string, now time.Time) (*models.SpendStatus, error) {
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	status := &models.SpendStatus{APIKeyID: keyID, Month: monthStart.Format("2006-01")}
+	err := db.GetContext(ctx, status, `
+		SELECT COUNT(*) AS requests,
+			COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens,
+			COALESCE(SUM(completion_tokens), 0) AS completion_tokens,
+			COALESCE(SUM(cost_usd), 0) AS spent_usd
+		FROM ai_usage WHERE api_key_id = $1 AND created_at >= $2`, keyID, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum AI spend: %w", err)
+	}
+
+	var limit struct {
+		MonthlyLimitUSD float64 `db:"monthly_limit_usd"`
+		Action          string  `db:"action"`
+	}
+	err = db.GetContext(ctx, &limit, `
+		SELECT monthly_limit_usd, action FROM spend_limits WHERE api_key_id = $1`, keyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spend limit: %w", err)
+	}
+	status.LimitUSD = &limit.MonthlyLimitUSD
+	status.Action = limit.Action
+	return status, nil
+}
+
+// SetSpendLimit caps an API key's monthly AI spend, replacing any earlier
+// limit. The 80% warning can go out again under the new limit.
+func (db *DB) SetSpendLimit(ctx context.Context, keyID string, limitUSD float64, action string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO spend_limits (api_key_id, monthly_limit_usd, action) VALUES ($1, $2, $3)
+		ON CONFLICT (api_key_id) DO UPDATE SET
+			monthly_limit_usd = EXCLUDED.monthly_limit_usd, action = EXCLUDED.action,
+			warned_month = '', updated_at = NOW()`,
+		keyID, limitUSD, action)
+	if err != nil {
+		return fmt.Errorf("failed to set spend limit: %w", err)
+	}
+	return nil
+}
+
+// DeleteSpendLimit removes an API key's spend limit.
+func (db *DB) DeleteSpendLimit(ctx context.Context, keyID string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM spend_limits WHERE api_key_id = $1`, keyID); err != nil {
+		return fmt.Errorf("failed to delete spend limit: %w", err)
+	}
+	return nil
+}
+
+// MarkSpendWarned records that an API key was warned about its spend in
+// month, reporting false if it already was — so concurrent completions
+// crossing the threshold send one warning between them.
+func (db *DB) MarkSpendWarned(ctx context.Context, keyID, month string) (bool, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE spend_limits SET warned_month = $2
+		WHERE api_key_id = $1 AND warned_month <> $2`, keyID, month)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark spend warning: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark spend warning: %w", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,70 @@
+//go:build cgo
+
+// spend_test.go contains tests for AI spend accounting and limits, run
+// against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestKeySpend verifies spend is summed for the current month only, that
+// limits are reported, and that a month's warning is marked once.
+func TestKeySpend(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	key := &models.APIKey{KeyHash: "hash", KeyPrefix: "mta_abc", Name: "dev", Active: true, Tier: models.TierFree, Role: "member"}
+	if err := db.CreateAPIKey(ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	for _, cost := range []float64{0.25, 0.5} {
+		if err := db.RecordAISpend(ctx, key.ID, "m", 100, 20, cost); err != nil {
+			t.Fatalf("RecordAISpend: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO ai_usage (api_key_id, model, cost_usd, created_at) VALUES ($1, 'm', 5, $2)`,
+		key.ID, time.Now().AddDate(0, -1, -1)); err != nil {
+		t.Fatalf("insert last month's spend: %v", err)
+	}
+
+	status, err := db.GetKeySpend(ctx, key.ID, time.Now())
+	if err != nil {
+		t.Fatalf("GetKeySpend: %v", err)
+	}
+	if status.Requests != 2 || status.PromptTokens != 200 || status.SpentUSD != 0.75 || status.LimitUSD != nil {
+		t.Errorf("GetKeySpend = %+v", status)
+	}
+	if status.Month != time.Now().UTC().Format("2006-01") {
+		t.Errorf("Month = %q", status.Month)
+	}
+
+	if ok, err := db.MarkSpendWarned(ctx, key.ID, status.Month); err != nil || ok {
+		t.Errorf("MarkSpendWarned without a limit = %v, %v; want false", ok, err)
+	}
+	if err := db.SetSpendLimit(ctx, key.ID, 1, models.SpendActionDowngrade); err != nil {
+		t.Fatalf("SetSpendLimit: %v", err)
+	}
+	status, err = db.GetKeySpend(ctx, key.ID, time.Now())
+	if err != nil || status.LimitUSD == nil || *status.LimitUSD != 1 || status.Action != models.SpendActionDowngrade {
+		t.Fatalf("GetKeySpend with a limit = %+v, %v", status, err)
+	}
+	if ok, err := db.MarkSpendWarned(ctx, key.ID, status.Month); err != nil || !ok {
+		t.Errorf("first MarkSpendWarned = %v, %v; want true", ok, err)
+	}
+	if ok, err := db.MarkSpendWarned(ctx, key.ID, status.Month); err != nil || ok {
+		t.Errorf("second MarkSpendWarned = %v, %v; want false", ok, err)
+	}
+
+	if err := db.DeleteSpendLimit(ctx, key.ID); err != nil {
+		t.Fatalf("DeleteSpendLimit: %v", err)
+	}
+	if status, err := db.GetKeySpend(ctx, key.ID, time.Now()); err != nil || status.LimitUSD != nil {
+		t.Errorf("GetKeySpend after delete = %+v, %v", status, err)
+	}
+}
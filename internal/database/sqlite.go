@@ -0,0 +1,51 @@
+// sqlite.go adds an embedded SQLite backend for local development and tests,
+// selected by a sqlite: or file: DATABASE_URL.
+//
+// Queries are written for PostgreSQL. Rather than keep two copies of every
+// query, SQLite connections go through a driver that translates the
+// PostgreSQL-isms the queries use ($N placeholders, ::casts, ILIKE,
+// row locks, = ANY(array), NOW() ± INTERVAL) and registers the PostgreSQL
+// functions they call. Features with no SQLite equivalent — full-text
+// search, data-modifying CTEs — are handled where they're used; see
+// isSQLite.
+//
+// The driver itself (sqlite_driver.go) needs cgo. Builds without it, like
+// the Docker image, support PostgreSQL only.
+package database
+
+import "strings"
+
+// sqliteDriverName is the database/sql driver SQLite connections use.
+const sqliteDriverName = "sqlite3_mta"
+
+// sqliteDSN converts a sqlite: or file: database URL to a go-sqlite3 DSN,
+// reporting false for other URLs. sqlite::memory: opens a private
+// in-memory database. Query parameters are passed through.
+func sqliteDSN(databaseURL string) (string, bool) {
+	var path string
+	switch {
+	case strings.HasPrefix(databaseURL, "sqlite://"):
+		path = strings.TrimPrefix(databaseURL, "sqlite://")
+	case strings.HasPrefix(databaseURL, "sqlite:"):
+		path = strings.TrimPrefix(databaseURL, "sqlite:")
+	case strings.HasPrefix(databaseURL, "file:"):
+		path = strings.TrimPrefix(databaseURL, "file:")
+	default:
+		return "", false
+	}
+
+	path, query, _ := strings.Cut(path, "?")
+	params := []string{"_foreign_keys=1", "_busy_timeout=5000", "_txlock=immediate"}
+	if path != ":memory:" {
+		params = append(params, "_journal_mode=WAL")
+	}
+	if query != "" {
+		params = append(params, query)
+	}
+	return "file:" + path + "?" + strings.Join(params, "&"), true
+}
+
+// isSQLite reports whether db is backed by SQLite.
+func (db *DB) isSQLite() bool {
+	return db.DriverName() == sqliteDriverName
+}
@@ -0,0 +1,272 @@
+//go:build cgo
+
+// sqlite_driver.go implements the translating SQLite driver on top of
+// go-sqlite3, which needs cgo.
+//
+// Go Pattern: database/sql drivers are just interfaces, so wrapping one to
+// rewrite queries on the way in and values on the way out is a small
+// decorator rather than a fork.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteTimeFormat is how timestamps are stored: UTC, in the first layout
+// go-sqlite3 parses, so stored values compare correctly as text.
+var sqliteTimeFormat = sqlite3.SQLiteTimestampFormats[0]
+
+func init() {
+	sql.Register(sqliteDriverName, &sqliteDriver{
+		SQLiteDriver: &sqlite3.SQLiteDriver{ConnectHook: registerSQLiteFunctions},
+	})
+	// Queries keep PostgreSQL's $N bind style; the driver rewrites it
+	sqlx.BindDriver(sqliteDriverName, sqlx.DOLLAR)
+}
+
+// registerSQLiteFunctions adds the PostgreSQL functions queries and the
+// schema rely on to each new connection.
+func registerSQLiteFunctions(conn *sqlite3.SQLiteConn) error {
+	functions := []struct {
+		name string
+		impl interface{}
+		pure bool
+	}{
+		{"now", func() string { return time.Now().UTC().Format(sqliteTimeFormat) }, false},
+		{"gen_random_uuid", func() string { return uuid.NewString() }, false},
+		{"pg_now_shift", sqliteNowShift, false},
+		{"pg_array_contains", sqliteArrayContains, true},
+		{"cardinality", sqliteCardinality, true},
+		{"least", func(args ...interface{}) interface{} { return sqliteExtreme(args, -1) }, true},
+		{"greatest", func(args ...interface{}) interface{} { return sqliteExtreme(args, 1) }, true},
+	}
+	for _, f := range functions {
+		if err := conn.RegisterFunc(f.name, f.impl, f.pure); err != nil {
+			return fmt.Errorf("failed to register %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// sqliteNowShift implements NOW() ± INTERVAL 'n unit' and
+// NOW() ± make_interval(unit => n).
+func sqliteNowShift(sign, unit string, n int64) (string, error) {
+	if sign == "-" {
+		n = -n
+	}
+	now := time.Now().UTC()
+	var t time.Time
+	switch strings.TrimSuffix(strings.ToLower(unit), "s") {
+	case "second", "sec":
+		t = now.Add(time.Duration(n) * time.Second)
+	case "minute", "min":
+		t = now.Add(time.Duration(n) * time.Minute)
+	case "hour":
+		t = now.Add(time.Duration(n) * time.Hour)
+	case "day":
+		t = now.AddDate(0, 0, int(n))
+	case "week":
+		t = now.AddDate(0, 0, 7*int(n))
+	case "month":
+		t = now.AddDate(0, int(n), 0)
+	case "year":
+		t = now.AddDate(int(n), 0, 0)
+	default:
+		return "", fmt.Errorf("unsupported interval unit %q", unit)
+	}
+	return t.Format(sqliteTimeFormat), nil
+}
+
+// sqliteArrayContains implements value = ANY(array) for arrays stored as
+// PostgreSQL array literals.
+func sqliteArrayContains(array, value string) (bool, error) {
+	var elems pq.StringArray
+	if err := elems.Scan(array); err != nil {
+		return false, err
+	}
+	for _, e := range elems {
+		if e == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// sqliteCardinality implements cardinality(array) for arrays stored as
+// PostgreSQL array literals.
+func sqliteCardinality(array string) (int, error) {
+	var elems pq.StringArray
+	if err := elems.Scan(array); err != nil {
+		return 0, err
+	}
+	return len(elems), nil
+}
+
+// sqliteExtreme implements LEAST (dir -1) and GREATEST (dir 1), which
+// unlike SQLite's min and max ignore NULL arguments.
+func sqliteExtreme(args []interface{}, dir int) interface{} {
+	var best interface{}
+	for _, a := range args {
+		if a == nil {
+			continue
+		}
+		if best == nil || compareSQLite(a, best)*dir > 0 {
+			best = a
+		}
+	}
+	return best
+}
+
+// compareSQLite orders two non-NULL SQLite values of the same kind.
+func compareSQLite(a, b interface{}) int {
+	switch x := a.(type) {
+	case int64:
+		if y, ok := b.(int64); ok {
+			return compareOrdered(x, y)
+		}
+	case float64:
+		if y, ok := b.(float64); ok {
+			return compareOrdered(x, y)
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// sqliteRewrites translate PostgreSQL syntax to SQLite, in order.
+var sqliteRewrites = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	// $1 binds by position in PostgreSQL but by first appearance in SQLite
+	{regexp.MustCompile(`\$(\d+)`), "?$1"},
+	{regexp.MustCompile(`(?i)NOW\(\) ([+-]) INTERVAL '(\d+) (\w+)'`), "pg_now_shift('$1', '$3', $2)"},
+	{regexp.MustCompile(`(?i)NOW\(\) ([+-]) make_interval\((\w+) => `), "pg_now_shift('$1', '$2', "},
+	{regexp.MustCompile(`::[A-Za-z_][A-Za-z0-9_]*(\[\])?`), ""},
+	{regexp.MustCompile(`\bILIKE\b`), "LIKE"},
+	{regexp.MustCompile(`\s+FOR UPDATE(\s+OF\s+\w+)?(\s+SKIP LOCKED|\s+NOWAIT)?`), ""},
+	{regexp.MustCompile(`([\w.?]+) = ANY\(([\w.?]+)\)`), "pg_array_contains($2, $1)"},
+}
+
+// translateSQL rewrites a PostgreSQL query for SQLite.
+func translateSQL(query string) string {
+	for _, r := range sqliteRewrites {
+		query = r.pattern.ReplaceAllString(query, r.replace)
+	}
+	return query
+}
+
+// sqliteDriver opens translating connections.
+type sqliteDriver struct {
+	*sqlite3.SQLiteDriver
+}
+
+func (d *sqliteDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+// sqliteConn translates queries before handing them to go-sqlite3.
+type sqliteConn struct {
+	*sqlite3.SQLiteConn
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.SQLiteConn.PrepareContext(ctx, translateSQL(query))
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteStmt{stmt.(*sqlite3.SQLiteStmt)}, nil
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.SQLiteConn.ExecContext(ctx, translateSQL(query), args)
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.SQLiteConn.QueryContext(ctx, translateSQL(query), args)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows}, nil
+}
+
+// CheckNamedValue stores times in UTC so they compare correctly as text.
+// Everything else gets database/sql's default conversion.
+func (c *sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if t, ok := nv.Value.(time.Time); ok {
+		nv.Value = t.UTC()
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+// sqliteStmt wraps prepared statements so their rows are converted too.
+type sqliteStmt struct {
+	*sqlite3.SQLiteStmt
+}
+
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.SQLiteStmt.QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows}, nil
+}
+
+// sqliteTimestamp matches text in the layout timestamps are stored in.
+var sqliteTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d+)?[+-]\d{2}:\d{2}$`)
+
+// sqliteRows converts values the way lib/pq returns them: text as []byte,
+// and timestamps as time.Time even where SQLite doesn't know the column's
+// type (RETURNING, expressions, unions).
+type sqliteRows struct {
+	driver.Rows
+}
+
+func (r *sqliteRows) Next(dest []driver.Value) error {
+	if err := r.Rows.Next(dest); err != nil {
+		return err
+	}
+	for i, v := range dest {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if sqliteTimestamp.MatchString(s) {
+			if t, err := time.Parse(sqliteTimeFormat, s); err == nil {
+				dest[i] = t
+				continue
+			}
+		}
+		dest[i] = []byte(s)
+	}
+	return nil
+}
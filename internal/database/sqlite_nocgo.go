@@ -0,0 +1,22 @@
+//go:build !cgo
+
+// sqlite_nocgo.go stands in for the SQLite driver in builds without cgo,
+// so a sqlite: DATABASE_URL fails with an explanation.
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+func init() {
+	sql.Register(sqliteDriverName, noSQLiteDriver{})
+}
+
+// noSQLiteDriver refuses every connection.
+type noSQLiteDriver struct{}
+
+func (noSQLiteDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("SQLite support requires a build with cgo (CGO_ENABLED=1)")
+}
@@ -0,0 +1,446 @@
+//go:build cgo
+
+// sqlite_test.go contains tests for the SQLite backend: query translation,
+// and the query layer running against an in-memory database.
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// openSQLite returns a migrated in-memory SQLite database.
+func openSQLite(t *testing.T) *DB {
+	t.Helper()
+	db, err := New("sqlite::memory:")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.RunMigrations("../../migrations"); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	return db
+}
+
+// TestSQLiteDSN verifies which database URLs select SQLite and the DSNs
+// they map to.
+func TestSQLiteDSN(t *testing.T) {
+	tests := []struct {
+		url    string
+		want   string
+		sqlite bool
+	}{
+		{"postgres://localhost/media_tools", "", false},
+		{"sqlite::memory:", "file::memory:?_foreign_keys=1&_busy_timeout=5000&_txlock=immediate", true},
+		{"sqlite://dev.db", "file:dev.db?_foreign_keys=1&_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL", true},
+		{"sqlite:///tmp/dev.db?cache=private", "file:/tmp/dev.db?_foreign_keys=1&_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL&cache=private", true},
+		{"file:data/dev.db", "file:data/dev.db?_foreign_keys=1&_busy_timeout=5000&_txlock=immediate&_journal_mode=WAL", true},
+	}
+	for _, tt := range tests {
+		got, ok := sqliteDSN(tt.url)
+		if ok != tt.sqlite || got != tt.want {
+			t.Errorf("sqliteDSN(%q) = %q, %v; want %q, %v", tt.url, got, ok, tt.want, tt.sqlite)
+		}
+	}
+}
+
+// TestTranslateSQL verifies the PostgreSQL constructs the driver rewrites.
+func TestTranslateSQL(t *testing.T) {
+	tests := []struct {
+		name, query, want string
+	}{
+		{"placeholders", "SELECT * FROM t WHERE a = $2 AND b = $1", "SELECT * FROM t WHERE a = ?2 AND b = ?1"},
+		{"casts", "SELECT NULL::timestamptz, $1::uuid, '{}'::text[]", "SELECT NULL, ?1, '{}'"},
+		{"ilike", "WHERE title ILIKE $1", "WHERE title LIKE ?1"},
+		{"row locks", "SELECT 1 FROM t WHERE id = $1\n\t\tFOR UPDATE OF t SKIP LOCKED", "SELECT 1 FROM t WHERE id = ?1"},
+		{"any", "WHERE $1 = ANY(events) OR t.id = ANY($2)", "WHERE pg_array_contains(events, ?1) OR pg_array_contains(?2, t.id)"},
+		{"interval", "created_at >= NOW() - INTERVAL '24 hours'", "created_at >= pg_now_shift('-', 'hours', 24)"},
+		{"make_interval", "created_at < NOW() - make_interval(days => LEAST(a, b))", "created_at < pg_now_shift('-', 'days', LEAST(a, b))"},
+	}
+	for _, tt := range tests {
+		if got := translateSQL(tt.query); got != tt.want {
+			t.Errorf("%s: translateSQL() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestSQLiteQueries runs a cross-section of the query layer against an
+// in-memory SQLite database.
+func TestSQLiteQueries(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	if _, err := db.CheckMigrations(ctx); err != nil {
+		t.Fatalf("CheckMigrations: %v", err)
+	}
+
+	user := &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	if err := db.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if user.ID == "" || user.CreatedAt.IsZero() {
+		t.Fatalf("CreateUser didn't return generated fields: %+v", user)
+	}
+
+	key := &models.APIKey{KeyHash: "hash", KeyPrefix: "mta_abc", Name: "dev", Active: true, Tier: models.TierFree, Role: "member"}
+	if err := db.CreateAPIKey(ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	got, err := db.GetAPIKeyByHash(ctx, "hash")
+	if err != nil {
+		t.Fatalf("GetAPIKeyByHash: %v", err)
+	}
+	if got.ID != key.ID || !got.Active || got.AllowedOrigins == nil {
+		t.Errorf("GetAPIKeyByHash = %+v", got)
+	}
+
+	hook := &models.Webhook{APIKeyID: key.ID, URL: "https://example.com/hook", Events: []string{"transcript.completed", "audio.completed"}, Active: true}
+	if err := db.CreateWebhook(ctx, hook); err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	hooks, err := db.GetActiveWebhooksForAPIKeyEvent(ctx, key.ID, "audio.completed")
+	if err != nil || len(hooks) != 1 || len(hooks[0].Events) != 2 {
+		t.Errorf("GetActiveWebhooksForAPIKeyEvent = %+v, %v", hooks, err)
+	}
+	if hooks, _ := db.GetActiveWebhooksForOwner(ctx, &key.ID, nil, "pdf.completed"); len(hooks) != 0 {
+		t.Errorf("GetActiveWebhooksForOwner(pdf.completed) = %d hooks, want 0", len(hooks))
+	}
+	// Items are only announced to their owner's webhooks
+	userKey := &models.APIKey{KeyHash: "user-hash", KeyPrefix: "mta_usr", Name: "ada's", Active: true, Tier: models.TierFree, Role: "member"}
+	if err := db.CreateAPIKey(ctx, userKey); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if err := db.LinkAPIKeyToUser(ctx, userKey.ID, user.ID); err != nil {
+		t.Fatalf("LinkAPIKeyToUser: %v", err)
+	}
+	userHook := &models.Webhook{APIKeyID: userKey.ID, URL: "https://example.com/ada", Events: []string{"audio.completed"}, Active: true}
+	if err := db.CreateWebhook(ctx, userHook); err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	for _, tt := range []struct {
+		apiKeyID, userID *string
+		want             string
+	}{
+		{&key.ID, nil, hook.ID},
+		{nil, &user.ID, userHook.ID},
+		{&userKey.ID, nil, userHook.ID},
+	} {
+		if hooks, err := db.GetActiveWebhooksForOwner(ctx, tt.apiKeyID, tt.userID, "audio.completed"); err != nil || len(hooks) != 1 || hooks[0].ID != tt.want {
+			t.Errorf("GetActiveWebhooksForOwner(%v, %v) = %+v, %v; want only %s", tt.apiKeyID, tt.userID, hooks, err, tt.want)
+		}
+	}
+	if hooks, _ := db.GetActiveWebhooksForOwner(ctx, nil, nil, "audio.completed"); len(hooks) != 0 {
+		t.Errorf("GetActiveWebhooksForOwner for an unowned item = %d hooks, want 0", len(hooks))
+	}
+	if err := db.DeleteWebhook(ctx, userHook.ID); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+	hook.PayloadMode, hook.PayloadFields, hook.MaxPayloadBytes = models.WebhookPayloadFields, []string{"title", "transcript.status"}, 4096
+	hook.Credentials, hook.HeaderNames, hook.HasBasicAuth = "c2VhbGVk", []string{"X-Gateway-Key"}, true
+	if err := db.UpdateWebhook(ctx, hook); err != nil {
+		t.Fatalf("UpdateWebhook: %v", err)
+	}
+	if got, err := db.GetWebhook(ctx, hook.ID); err != nil || got.PayloadMode != "fields" || len(got.PayloadFields) != 2 || got.MaxPayloadBytes != 4096 ||
+		got.Credentials != "c2VhbGVk" || len(got.HeaderNames) != 1 || !got.HasBasicAuth {
+		t.Errorf("GetWebhook = %+v, %v", got, err)
+	}
+
+	tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Title: "Gophers in Space", Status: "completed", TranscriptText: "the gopher floats", APIKeyID: &key.ID}
+	if err := db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+	if err := db.UpdateTranscript(ctx, tr); err != nil {
+		t.Fatalf("UpdateTranscript: %v", err)
+	}
+	// segments and redaction are NULL, which must scan as nil
+	if got, err := db.GetTranscript(ctx, tr.ID); err != nil || got.Segments != nil || got.Redaction != nil {
+		t.Errorf("GetTranscript = %+v, %v", got, err)
+	}
+	if list, total, err := db.ListTranscripts(ctx, models.TranscriptListParams{IncludeText: true}); err != nil || total != 1 || list[0].Redaction != nil {
+		t.Errorf("ListTranscripts = %+v (%d), %v", list, total, err)
+	}
+	if err := db.CreateSummary(ctx, &models.Summary{TranscriptID: tr.ID, ModelUsed: "m", SummaryText: "old", KeyPoints: []byte("[]")}); err != nil {
+		t.Fatalf("CreateSummary: %v", err)
+	}
+	if err := db.CreateSummary(ctx, &models.Summary{TranscriptID: tr.ID, ModelUsed: "m", SummaryText: "new", KeyPoints: []byte("[]")}); err != nil {
+		t.Fatalf("CreateSummary: %v", err)
+	}
+	if texts, err := db.GetLatestSummaryTexts(ctx, []string{tr.ID}); err != nil || texts[tr.ID] != "new" {
+		t.Errorf("GetLatestSummaryTexts = %v, %v", texts, err)
+	}
+	// A pending summary isn't the latest until it's completed
+	pending := &models.Summary{TranscriptID: tr.ID, KeyPoints: []byte("[]"), Status: models.StatusPending}
+	if err := db.CreateSummary(ctx, pending); err != nil {
+		t.Fatalf("CreateSummary (pending): %v", err)
+	}
+	if latest, err := db.GetLatestSummary(ctx, tr.ID); err != nil || latest == nil || latest.SummaryText != "new" {
+		t.Errorf("GetLatestSummary = %+v, %v, want the completed summary", latest, err)
+	}
+	if err := db.SetSummaryStatus(ctx, pending.ID, models.StatusProcessing, ""); err != nil {
+		t.Fatalf("SetSummaryStatus: %v", err)
+	}
+	pending.SummaryText, pending.Status = "newest", models.StatusCompleted
+	if err := db.UpdateSummary(ctx, pending); err != nil {
+		t.Fatalf("UpdateSummary: %v", err)
+	}
+	if latest, err := db.GetLatestSummary(ctx, tr.ID); err != nil || latest == nil || latest.ID != pending.ID || latest.Status != models.StatusCompleted {
+		t.Errorf("GetLatestSummary = %+v, %v, want the updated summary", latest, err)
+	}
+	if list, total, err := db.ListSummaries(ctx, models.SummaryListParams{Model: "m", PerPage: 1, APIKeyID: &key.ID}); err != nil || total != 2 || len(list) != 1 || list[0].SummaryText != "new" {
+		t.Errorf("ListSummaries = %+v (%d), %v; want the newest of 2", list, total, err)
+	}
+	other := "00000000-0000-0000-0000-000000000000"
+	if list, total, err := db.ListSummaries(ctx, models.SummaryListParams{APIKeyID: &other}); err != nil || total != 0 || len(list) != 0 {
+		t.Errorf("ListSummaries (another key) = %+v (%d), %v", list, total, err)
+	}
+	if err := db.DeleteSummary(ctx, pending.ID); err != nil {
+		t.Fatalf("DeleteSummary: %v", err)
+	}
+
+	results, total, err := db.SearchText(ctx, models.TextSearchParams{Query: "gopher"}, nil)
+	if err != nil || total != 1 || len(results) != 1 || results[0].ID != tr.ID {
+		t.Errorf("SearchText = %+v (%d), %v", results, total, err)
+	}
+
+	if err := db.CacheCompletion(ctx, "hash", "cached answer", "m", time.Hour); err != nil {
+		t.Fatalf("CacheCompletion: %v", err)
+	}
+	if content, model, ok, err := db.GetCachedCompletion(ctx, "hash"); err != nil || !ok || content != "cached answer" || model != "m" {
+		t.Errorf("GetCachedCompletion = %q, %q, %v, %v", content, model, ok, err)
+	}
+	if err := db.CacheCompletion(ctx, "stale", "old answer", "m", -time.Hour); err != nil {
+		t.Fatalf("CacheCompletion (expired): %v", err)
+	}
+	if _, _, ok, err := db.GetCachedCompletion(ctx, "stale"); err != nil || ok {
+		t.Errorf("GetCachedCompletion returned an expired completion (%v)", err)
+	}
+
+	if _, err := db.ListAPIKeyVolumes(ctx); err != nil {
+		t.Errorf("ListAPIKeyVolumes: %v", err)
+	}
+	if health, err := db.ListWebhookHealth(ctx); err != nil || len(health) != 1 {
+		t.Errorf("ListWebhookHealth = %+v, %v", health, err)
+	}
+	session, err := db.CreateUserSession(ctx, user.ID, "refresh", "go-test", "127.0.0.1", time.Hour)
+	if err != nil {
+		t.Fatalf("CreateUserSession: %v", err)
+	}
+	if rotated, err := db.RotateUserSession(ctx, "refresh", "refresh2", time.Hour); err != nil || rotated.ID != session.ID {
+		t.Errorf("RotateUserSession = %+v, %v", rotated, err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	stats, err := db.GetUsageStats(ctx, models.StatsParams{}, today, today)
+	if err != nil {
+		t.Fatalf("GetUsageStats: %v", err)
+	}
+	if stats.Totals.Items != 1 || stats.Totals.Completed != 1 {
+		t.Errorf("GetUsageStats totals = %+v", stats.Totals)
+	}
+
+	saved := &models.SavedSearch{APIKeyID: key.ID, Name: "gophers", Query: `"gopher floats" -rust`, Types: []string{"transcript"}, Notify: true}
+	if err := db.CreateSavedSearch(ctx, saved); err != nil {
+		t.Fatalf("CreateSavedSearch: %v", err)
+	}
+	matches, err := db.MatchSavedSearches(ctx, "transcript", tr.ID)
+	if err != nil || len(matches) != 1 || matches[0].ItemTitle != tr.Title {
+		t.Errorf("MatchSavedSearches = %+v, %v", matches, err)
+	}
+
+	if err := db.SetRetentionPolicy(ctx, "api_key", key.ID, 30); err != nil {
+		t.Fatalf("SetRetentionPolicy: %v", err)
+	}
+	if purged, err := db.PurgeExpiredItems(ctx, 10); err != nil || len(purged) != 0 {
+		t.Fatalf("PurgeExpiredItems (fresh item) = %+v, %v", purged, err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE transcripts SET created_at = $1 WHERE id = $2`, time.Now().AddDate(0, 0, -31), tr.ID); err != nil {
+		t.Fatalf("backdate transcript: %v", err)
+	}
+	purged, err := db.PurgeExpiredItems(ctx, 10)
+	if err != nil || len(purged) != 1 || purged[0].ItemID != tr.ID || purged[0].RetentionDays != 30 {
+		t.Errorf("PurgeExpiredItems = %+v, %v", purged, err)
+	}
+
+	org := &models.Organization{Name: "Gophers"}
+	if err := db.CreateOrganization(ctx, org, user.ID); err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	if orgs, err := db.ListUserOrganizations(ctx, user.ID); err != nil || len(orgs) != 1 {
+		t.Errorf("ListUserOrganizations = %+v, %v", orgs, err)
+	}
+
+	if err := db.CreateUserToken(ctx, user.ID, "verify_email", "tok", time.Hour); err != nil {
+		t.Fatalf("CreateUserToken: %v", err)
+	}
+	if err := db.CreateUserToken(ctx, user.ID, "verify_email", "tok2", time.Hour); err != nil {
+		t.Fatalf("CreateUserToken (replace): %v", err)
+	}
+	if _, err := db.ConsumeUserToken(ctx, "verify_email", "tok"); err == nil {
+		t.Error("ConsumeUserToken accepted a replaced token")
+	}
+	if id, err := db.ConsumeUserToken(ctx, "verify_email", "tok2"); err != nil || id != user.ID {
+		t.Errorf("ConsumeUserToken = %q, %v", id, err)
+	}
+}
+
+// TestSQLiteUploadLists verifies the audio and PDF lists' filters, sorting,
+// and cursor paging.
+func TestSQLiteUploadLists(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	for i, d := range []float64{30.5, 12.25, 90, 45.75} {
+		at := &models.AudioTranscription{Filename: "f.mp3", OriginalName: "call-" + string(rune('a'+i)) + ".mp3", Duration: d, Status: "completed"}
+		if i == 3 {
+			at.Status, at.ContentType = "failed", models.ContentMeeting
+		}
+		if err := db.CreateAudioTranscription(ctx, at); err != nil {
+			t.Fatalf("CreateAudioTranscription: %v", err)
+		}
+	}
+
+	params := models.AudioListParams{PerPage: 2, Status: "completed", SortBy: "duration", SortDir: "asc"}
+	list, total, err := db.ListAudioTranscriptions(ctx, params)
+	if err != nil || total != 3 || len(list) != 2 || list[0].Duration != 12.25 || list[1].Duration != 30.5 {
+		t.Fatalf("ListAudioTranscriptions = %+v (%d), %v", list, total, err)
+	}
+	params.Cursor = NextAudioCursor(params, list)
+	if list, _, err := db.ListAudioTranscriptions(ctx, params); err != nil || len(list) != 1 || list[0].Duration != 90 {
+		t.Errorf("ListAudioTranscriptions (page 2) = %+v, %v", list, err)
+	}
+	if list, total, err := db.ListAudioTranscriptions(ctx, models.AudioListParams{ContentType: "meeting"}); err != nil || total != 1 || list[0].Status != "failed" {
+		t.Errorf("ListAudioTranscriptions (meeting) = %+v (%d), %v", list, total, err)
+	}
+	if _, total, err := db.ListAudioTranscriptions(ctx, models.AudioListParams{Search: "CALL-B"}); err != nil || total != 1 {
+		t.Errorf("ListAudioTranscriptions (search) total = %d, %v", total, err)
+	}
+
+	// Notes and metadata are searchable, and metadata can be replaced
+	tagged := &models.AudioTranscription{Filename: "f.mp3", OriginalName: "recording_2398.m4a", Title: "Call with Acme", Notes: "pricing",
+		Metadata: []byte(`{"deal":"Zephyr"}`), Status: "completed"}
+	if err := db.CreateAudioTranscription(ctx, tagged); err != nil {
+		t.Fatalf("CreateAudioTranscription: %v", err)
+	}
+	if results, total, err := db.SearchAudioTranscriptions(ctx, models.AudioSearchParams{Query: "zephyr"}); err != nil || total != 1 || results[0].ID != tagged.ID {
+		t.Errorf("SearchAudioTranscriptions(metadata) = %+v (%d), %v", results, total, err)
+	}
+	if err := db.UpdateItemMetadata(ctx, "audio", tagged.ID, models.UpdateItemRequest{Metadata: &map[string]string{"deal": "Aurora"}}); err != nil {
+		t.Fatalf("UpdateItemMetadata: %v", err)
+	}
+	if got, err := db.GetAudioTranscription(ctx, tagged.ID); err != nil || string(got.Metadata) != `{"deal":"Aurora"}` || got.Notes != "pricing" {
+		t.Errorf("GetAudioTranscription = %+v, %v", got, err)
+	}
+
+	// Speaker summaries default to [] and are saved with the summary
+	at, err := db.GetAudioTranscription(ctx, tagged.ID)
+	if err != nil || string(at.SpeakerSummaries) != "[]" {
+		t.Fatalf("GetAudioTranscription speaker_summaries = %s, %v", at.SpeakerSummaries, err)
+	}
+	at.SummaryText, at.SummaryStatus = "Pricing call", "completed"
+	at.SpeakerSummaries = []byte(`[{"speaker":"Alice","summary":"","commitments":["Send quote"],"questions":[]}]`)
+	if err := db.UpdateAudioSummary(ctx, at); err != nil {
+		t.Fatalf("UpdateAudioSummary: %v", err)
+	}
+	if got, err := db.GetAudioTranscription(ctx, tagged.ID); err != nil || string(got.SpeakerSummaries) != string(at.SpeakerSummaries) {
+		t.Errorf("GetAudioTranscription speaker_summaries = %s, %v", got.SpeakerSummaries, err)
+	}
+
+	for _, pages := range []int{3, 12} {
+		if err := db.CreatePDFExtraction(ctx, &models.PDFExtraction{Filename: "f.pdf", OriginalName: "doc.pdf", PageCount: pages, Status: "completed"}); err != nil {
+			t.Fatalf("CreatePDFExtraction: %v", err)
+		}
+	}
+	if list, total, err := db.ListPDFExtractions(ctx, models.PDFListParams{SortBy: "page_count"}); err != nil || total != 2 || list[0].PageCount != 12 {
+		t.Errorf("ListPDFExtractions = %+v (%d), %v", list, total, err)
+	}
+
+	// Detected tables are stored with the extraction but left out of lists
+	tabled := &models.PDFExtraction{Filename: "f.pdf", OriginalName: "report.pdf", PageCount: 1, Status: "completed",
+		Tables: []byte(`[{"page":1,"rows":[["a","b"]]}]`), TableCount: 1}
+	if err := db.CreatePDFExtraction(ctx, tabled); err != nil {
+		t.Fatalf("CreatePDFExtraction: %v", err)
+	}
+	if got, err := db.GetPDFExtraction(ctx, tabled.ID); err != nil || string(got.Tables) != string(tabled.Tables) || got.TableCount != 1 {
+		t.Errorf("GetPDFExtraction tables = %s (%d), %v", got.Tables, got.TableCount, err)
+	}
+	if list, _, err := db.ListPDFExtractions(ctx, models.PDFListParams{PerPage: 1}); err != nil || len(list[0].Tables) != 0 || list[0].TableCount != 1 {
+		t.Errorf("ListPDFExtractions tables = %+v, %v", list, err)
+	}
+
+	// So are the document info, outline, and Markdown; lists keep the info only
+	structured := &models.PDFExtraction{Filename: "g.pdf", OriginalName: "manual.pdf", PageCount: 2, Status: "completed",
+		DocumentInfo: []byte(`{"title":"Manual"}`), Outline: []byte(`[{"title":"Setup","page":2}]`), MarkdownContent: "# Manual"}
+	if err := db.CreatePDFExtraction(ctx, structured); err != nil {
+		t.Fatalf("CreatePDFExtraction: %v", err)
+	}
+	if got, err := db.GetPDFExtraction(ctx, structured.ID); err != nil ||
+		string(got.DocumentInfo) != string(structured.DocumentInfo) || string(got.Outline) != string(structured.Outline) ||
+		got.MarkdownContent != structured.MarkdownContent {
+		t.Errorf("GetPDFExtraction structure = %s %s %q, %v", got.DocumentInfo, got.Outline, got.MarkdownContent, err)
+	} else if got.SourceType != models.SourcePDF {
+		t.Errorf("GetPDFExtraction source_type = %q, want the pdf default", got.SourceType)
+	}
+	if list, _, err := db.ListPDFExtractions(ctx, models.PDFListParams{PerPage: 1}); err != nil ||
+		string(list[0].DocumentInfo) != `{"title":"Manual"}` || len(list[0].Outline) != 0 || list[0].MarkdownContent != "" {
+		t.Errorf("ListPDFExtractions structure = %+v, %v", list, err)
+	}
+
+	// Articles and EPUBs record where they came from
+	article := &models.PDFExtraction{Filename: "a.html", OriginalName: "A Post", PageCount: 1, Status: "completed",
+		SourceType: models.SourceArticle, SourceURL: "https://example.com/post"}
+	if err := db.CreatePDFExtraction(ctx, article); err != nil {
+		t.Fatalf("CreatePDFExtraction: %v", err)
+	}
+	if got, err := db.GetPDFExtraction(ctx, article.ID); err != nil || got.SourceType != models.SourceArticle || got.SourceURL != article.SourceURL {
+		t.Errorf("GetPDFExtraction source = %q %q, %v", got.SourceType, got.SourceURL, err)
+	}
+}
+
+// TestSQLiteUploadBatches verifies batch counts cover uploaded audio and
+// PDFs, and the batch's items are listed.
+func TestSQLiteUploadBatches(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	b := &models.Batch{Status: models.StatusPending, TotalCount: 2}
+	if err := db.CreateBatch(ctx, b); err != nil {
+		t.Fatalf("CreateBatch: %v", err)
+	}
+	at := &models.AudioTranscription{Filename: "a.mp3", OriginalName: "call.mp3", Status: "pending", BatchID: &b.ID}
+	if err := db.CreateAudioTranscription(ctx, at); err != nil {
+		t.Fatalf("CreateAudioTranscription: %v", err)
+	}
+	pe := &models.PDFExtraction{Filename: "p.pdf", OriginalName: "deck.pdf", Status: "completed", BatchID: &b.ID}
+	if err := db.CreatePDFExtraction(ctx, pe); err != nil {
+		t.Fatalf("CreatePDFExtraction: %v", err)
+	}
+
+	if err := db.UpdateBatchCounts(ctx, b.ID); err != nil {
+		t.Fatalf("UpdateBatchCounts: %v", err)
+	}
+	if got, err := db.GetBatch(ctx, b.ID); err != nil || got.Status != models.StatusProcessing || got.CompletedCount != 1 || got.FailedCount != 0 {
+		t.Errorf("GetBatch with audio pending = %+v, %v; want processing, 1 completed", got, err)
+	}
+
+	at.Status = "failed"
+	if err := db.UpdateAudioTranscription(ctx, at); err != nil {
+		t.Fatalf("UpdateAudioTranscription: %v", err)
+	}
+	db.UpdateBatchCounts(ctx, b.ID)
+	if got, err := db.GetBatch(ctx, b.ID); err != nil || got.Status != models.StatusCompleted || got.CompletedCount != 1 || got.FailedCount != 1 {
+		t.Errorf("GetBatch when done = %+v, %v; want completed, 1 completed, 1 failed", got, err)
+	}
+
+	audio, err := db.GetAudioTranscriptionsByBatch(ctx, b.ID)
+	if err != nil || len(audio) != 1 || audio[0].ID != at.ID || audio[0].BatchID == nil || *audio[0].BatchID != b.ID {
+		t.Errorf("GetAudioTranscriptionsByBatch = %+v, %v", audio, err)
+	}
+	pdfs, err := db.GetPDFExtractionsByBatch(ctx, b.ID)
+	if err != nil || len(pdfs) != 1 || pdfs[0].ID != pe.ID {
+		t.Errorf("GetPDFExtractionsByBatch = %+v, %v", pdfs, err)
+	}
+}
@@ -0,0 +1,148 @@
+// stats.go aggregates usage statistics for GET /api/v1/stats.
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// statsSources maps item types to the query that projects each table onto
+// the columns usage stats are computed from. PDFs are extracted during the
+// upload request, so they have no media duration or processing latency.
+var statsSources = map[string]string{
+	"transcript": "SELECT 'transcript' AS type, status, created_at, processed_at, duration::float8 AS duration, word_count, api_key_id, user_id FROM transcripts",
+	"audio":      "SELECT 'audio' AS type, status, created_at, processed_at, duration::float8 AS duration, word_count, api_key_id, user_id FROM audio_transcriptions",
+	"pdf":        "SELECT 'pdf' AS type, status, created_at, NULL::timestamptz AS processed_at, 0::float8 AS duration, word_count, api_key_id, user_id FROM pdf_extractions",
+}
+
+// GetUsageStats returns activity between from and to (UTC dates, both
+// inclusive), totalled overall, per item type, and per day. Items count
+// toward the day they were submitted.
+func (db *DB) GetUsageStats(ctx context.Context, params models.StatsParams, from, to time.Time) (*models.UsageStats, error) {
+	var parts []string
+	for _, t := range itemTypes {
+		parts = append(parts, statsSources[t])
+	}
+
+	conditions := []string{"created_at >= $1", "created_at < $2"}
+	args := []interface{}{from, to.AddDate(0, 0, 1)}
+	if params.APIKeyID != nil {
+		conditions = append(conditions, "api_key_id = $3")
+		args = append(args, *params.APIKeyID)
+	} else if params.UserID != nil {
+		conditions = append(conditions, userScope("$3"))
+		args = append(args, *params.UserID)
+	}
+
+	// Timestamps are stored as UTC text on SQLite
+	day := "(created_at AT TIME ZONE 'UTC')::date"
+	latency := "EXTRACT(EPOCH FROM processed_at - created_at)"
+	if db.isSQLite() {
+		day = "date(created_at) || ' 00:00:00+00:00'"
+		latency = "(julianday(processed_at) - julianday(created_at)) * 86400"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS date, type,
+			COUNT(*) AS items,
+			COUNT(*) FILTER (WHERE status = 'completed') AS completed,
+			COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+			COALESCE(SUM(duration) FILTER (WHERE status = 'completed'), 0) AS seconds,
+			COALESCE(SUM(word_count) FILTER (WHERE status = 'completed'), 0) AS words,
+			COALESCE(SUM(%s)
+				FILTER (WHERE status = 'completed' AND processed_at IS NOT NULL), 0) AS latency_seconds,
+			COUNT(*) FILTER (WHERE status = 'completed' AND processed_at IS NOT NULL) AS latency_count
+		FROM (%s) s
+		WHERE %s
+		GROUP BY 1, 2
+		ORDER BY 1, 2`,
+		day, latency, strings.Join(parts, " UNION ALL "), strings.Join(conditions, " AND "))
+
+	var buckets []models.StatsBucket
+	if err := db.reader().SelectContext(ctx, &buckets, query, args...); err != nil {
+		return nil, fmt.Errorf("stats query failed: %w", err)
+	}
+	return summarizeUsage(buckets, from, to), nil
+}
+
+// statsTally accumulates buckets into totals.
+type statsTally struct {
+	items, completed, failed int
+	seconds, latencySeconds  float64
+	words                    int64
+	latencyCount             int
+}
+
+func (t *statsTally) add(b models.StatsBucket) {
+	t.items += b.Items
+	t.completed += b.Completed
+	t.failed += b.Failed
+	t.seconds += b.Seconds
+	t.words += b.Words
+	t.latencySeconds += b.LatencySeconds
+	t.latencyCount += b.LatencyCount
+}
+
+func (t *statsTally) totals() models.StatsTotals {
+	s := models.StatsTotals{
+		Items:              t.items,
+		Completed:          t.completed,
+		Failed:             t.failed,
+		MinutesTranscribed: t.seconds / 60,
+		WordsExtracted:     t.words,
+	}
+	if finished := t.completed + t.failed; finished > 0 {
+		s.FailureRate = float64(t.failed) / float64(finished)
+	}
+	if t.latencyCount > 0 {
+		avg := t.latencySeconds / float64(t.latencyCount)
+		s.AvgProcessingSeconds = &avg
+	}
+	return s
+}
+
+// summarizeUsage rolls per-day, per-type buckets up into UsageStats, with
+// every type and every day from from to to present even if empty.
+func summarizeUsage(buckets []models.StatsBucket, from, to time.Time) *models.UsageStats {
+	var all statsTally
+	byType := map[string]*statsTally{}
+	for _, t := range itemTypes {
+		byType[t] = &statsTally{}
+	}
+	byDay := map[string]*statsTally{}
+
+	for _, b := range buckets {
+		all.add(b)
+		if t, ok := byType[b.Type]; ok {
+			t.add(b)
+		}
+		day := b.Date.Format("2006-01-02")
+		if byDay[day] == nil {
+			byDay[day] = &statsTally{}
+		}
+		byDay[day].add(b)
+	}
+
+	stats := &models.UsageStats{
+		DateFrom: from.Format("2006-01-02"),
+		DateTo:   to.Format("2006-01-02"),
+		Totals:   all.totals(),
+		ByType:   make(map[string]models.StatsTotals, len(byType)),
+	}
+	for t, tally := range byType {
+		stats.ByType[t] = tally.totals()
+	}
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		day := d.Format("2006-01-02")
+		tally := byDay[day]
+		if tally == nil {
+			tally = &statsTally{}
+		}
+		stats.Daily = append(stats.Daily, models.DailyStats{Date: day, StatsTotals: tally.totals()})
+	}
+	return stats
+}
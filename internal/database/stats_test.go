@@ -0,0 +1,56 @@
+// stats_test.go contains tests for usage stats aggregation.
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestSummarizeUsage verifies totals, per-type rollups, rates, latency, and
+// that empty days and types are filled in.
+func TestSummarizeUsage(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	buckets := []models.StatsBucket{
+		{Date: day(1), Type: "transcript", Items: 4, Completed: 3, Failed: 1, Seconds: 600, Words: 900, LatencySeconds: 30, LatencyCount: 3},
+		{Date: day(1), Type: "audio", Items: 1, Completed: 1, Seconds: 120, Words: 200, LatencySeconds: 50, LatencyCount: 1},
+		{Date: day(3), Type: "pdf", Items: 2, Completed: 1, Words: 1000},
+	}
+
+	stats := summarizeUsage(buckets, day(1), day(3))
+
+	if stats.DateFrom != "2026-03-01" || stats.DateTo != "2026-03-03" {
+		t.Errorf("range = %s..%s", stats.DateFrom, stats.DateTo)
+	}
+	tot := stats.Totals
+	if tot.Items != 7 || tot.Completed != 5 || tot.Failed != 1 || tot.WordsExtracted != 2100 {
+		t.Errorf("totals = %+v", tot)
+	}
+	if tot.MinutesTranscribed != 12 {
+		t.Errorf("minutes = %v, want 12", tot.MinutesTranscribed)
+	}
+	if tot.FailureRate != 1.0/6 {
+		t.Errorf("failure rate = %v, want %v", tot.FailureRate, 1.0/6)
+	}
+	if tot.AvgProcessingSeconds == nil || *tot.AvgProcessingSeconds != 20 {
+		t.Errorf("avg latency = %v, want 20", tot.AvgProcessingSeconds)
+	}
+
+	if pdf := stats.ByType["pdf"]; pdf.Items != 2 || pdf.AvgProcessingSeconds != nil || pdf.FailureRate != 0 {
+		t.Errorf("pdf = %+v", pdf)
+	}
+	if len(stats.ByType) != len(itemTypes) {
+		t.Errorf("by_type has %d types, want %d", len(stats.ByType), len(itemTypes))
+	}
+
+	if len(stats.Daily) != 3 {
+		t.Fatalf("daily has %d days, want 3", len(stats.Daily))
+	}
+	if d := stats.Daily[1]; d.Date != "2026-03-02" || d.Items != 0 || d.AvgProcessingSeconds != nil {
+		t.Errorf("empty day = %+v", d)
+	}
+	if d := stats.Daily[0]; d.Items != 5 || d.WordsExtracted != 1100 {
+		t.Errorf("first day = %+v", d)
+	}
+}
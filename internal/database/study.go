@@ -0,0 +1,33 @@
+// study.go contains database operations for study aids (flashcards + quizzes).
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CreateStudyAid inserts a newly generated study aid.
+func (db *DB) CreateStudyAid(ctx context.Context, s *models.StudyAid) error {
+	query := `
+		INSERT INTO study_aids (transcript_id, api_key_id, model_used, flashcards, quiz)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		s.TranscriptID, s.APIKeyID, s.ModelUsed, s.Flashcards, s.Quiz,
+	).Scan(&s.ID, &s.CreatedAt)
+}
+
+// GetLatestStudyAid returns the most recent study aid for a transcript.
+// Returns sql.ErrNoRows (wrapped) if none has been generated yet.
+func (db *DB) GetLatestStudyAid(ctx context.Context, transcriptID string) (*models.StudyAid, error) {
+	var s models.StudyAid
+	err := db.GetContext(ctx, &s,
+		`SELECT * FROM study_aids WHERE transcript_id = $1 ORDER BY created_at DESC LIMIT 1`, transcriptID)
+	if err != nil {
+		return nil, fmt.Errorf("study aid not found: %w", err)
+	}
+	return &s, nil
+}
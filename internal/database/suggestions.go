@@ -0,0 +1,52 @@
+// suggestions.go stores the suggested chat questions generated for an item.
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetChatSuggestions returns the suggested questions stored for an item,
+// or nil if there are none or they were generated from other text than
+// textHash.
+func (db *DB) GetChatSuggestions(ctx context.Context, itemType, id, textHash string) ([]string, error) {
+	table, ok := itemTables[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unknown item type %q", itemType)
+	}
+	var row struct {
+		Suggestions *string `db:"chat_suggestions"`
+		Hash        *string `db:"chat_suggestions_hash"`
+	}
+	query := fmt.Sprintf("SELECT chat_suggestions, chat_suggestions_hash FROM %s WHERE id = $1", table)
+	if err := db.GetContext(ctx, &row, query, id); err != nil {
+		return nil, fmt.Errorf("failed to get chat suggestions for %s %s: %w", itemType, id, err)
+	}
+	if row.Suggestions == nil || row.Hash == nil || *row.Hash != textHash {
+		return nil, nil
+	}
+	var questions []string
+	if err := json.Unmarshal([]byte(*row.Suggestions), &questions); err != nil {
+		return nil, fmt.Errorf("failed to decode chat suggestions for %s %s: %w", itemType, id, err)
+	}
+	return questions, nil
+}
+
+// SaveChatSuggestions stores the suggested questions generated for an
+// item from text hashing to textHash, replacing any earlier ones.
+func (db *DB) SaveChatSuggestions(ctx context.Context, itemType, id, textHash string, questions []string) error {
+	table, ok := itemTables[itemType]
+	if !ok {
+		return fmt.Errorf("unknown item type %q", itemType)
+	}
+	encoded, err := json.Marshal(questions)
+	if err != nil {
+		return fmt.Errorf("failed to encode chat suggestions: %w", err)
+	}
+	query := fmt.Sprintf("UPDATE %s SET chat_suggestions = $2::jsonb, chat_suggestions_hash = $3 WHERE id = $1", table)
+	if _, err := db.ExecContext(ctx, query, id, string(encoded), textHash); err != nil {
+		return fmt.Errorf("failed to save chat suggestions for %s %s: %w", itemType, id, err)
+	}
+	return nil
+}
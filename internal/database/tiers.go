@@ -0,0 +1,39 @@
+// tiers.go contains database operations for API key tiers and the monthly
+// item counts their quotas are measured against.
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// SetAPIKeyTier moves an active API key to another tier.
+func (db *DB) SetAPIKeyTier(ctx context.Context, keyID, tier string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := db.GetContext(ctx, &key, `
+		UPDATE api_keys SET tier = $2
+		WHERE id = $1 AND active = true
+		RETURNING *`, keyID, tier)
+	if err != nil {
+		return nil, fmt.Errorf("API key not found: %w", err)
+	}
+	return &key, nil
+}
+
+// CountMonthlyItems returns how many transcripts, audio transcriptions,
+// and PDF extractions an API key submitted in the calendar month (UTC)
+// containing now.
+func (db *DB) CountMonthlyItems(ctx context.Context, keyID string, now time.Time) (int, error) {
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM (%s) i WHERE api_key_id = $1 AND created_at >= $2`, adminItems())
+	if err := db.GetContext(ctx, &count, query, keyID, monthStart); err != nil {
+		return 0, fmt.Errorf("failed to count monthly items: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,52 @@
+//go:build cgo
+
+// tiers_test.go contains tests for API key tiers and monthly item counts,
+// run against an in-memory SQLite database.
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestKeyTiers verifies keys move between tiers, and that monthly counts
+// cover every item type in the current month only.
+func TestKeyTiers(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	key := &models.APIKey{KeyHash: "hash", KeyPrefix: "mta_abc", Name: "dev", Active: true, Tier: models.TierFree, Role: "member"}
+	if err := db.CreateAPIKey(ctx, key); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	got, err := db.SetAPIKeyTier(ctx, key.ID, models.TierPro)
+	if err != nil || got.Tier != models.TierPro {
+		t.Fatalf("SetAPIKeyTier = %+v, %v; want the pro tier", got, err)
+	}
+	if _, err := db.SetAPIKeyTier(ctx, "missing", models.TierPro); err == nil {
+		t.Error("SetAPIKeyTier(missing key) succeeded")
+	}
+
+	for _, videoID := range []string{"dQw4w9WgXcQ", "9bZkp7q19f0"} {
+		tr := &models.Transcript{YouTubeURL: "https://youtu.be/" + videoID, YouTubeID: videoID, Status: models.StatusPending, APIKeyID: &key.ID}
+		if err := db.CreateTranscript(ctx, tr); err != nil {
+			t.Fatalf("CreateTranscript: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO pdf_extractions (filename, original_name, api_key_id, created_at)
+		VALUES ('a.pdf', 'a.pdf', $1, $2), ('b.pdf', 'b.pdf', $1, $3)`,
+		key.ID, time.Now(), time.Now().AddDate(0, -1, -1)); err != nil {
+		t.Fatalf("insert PDF extractions: %v", err)
+	}
+
+	if n, err := db.CountMonthlyItems(ctx, key.ID, time.Now()); err != nil || n != 3 {
+		t.Errorf("CountMonthlyItems = %d, %v; want 3", n, err)
+	}
+	if n, err := db.CountMonthlyItems(ctx, "other", time.Now()); err != nil || n != 0 {
+		t.Errorf("CountMonthlyItems(other key) = %d, %v; want 0", n, err)
+	}
+}
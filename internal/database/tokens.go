@@ -0,0 +1,66 @@
+// tokens.go handles one-time user tokens for email verification and
+// password resets.
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateUserToken stores a one-time token's hash for a user, replacing any
+// unused token they have for the same purpose.
+func (db *DB) CreateUserToken(ctx context.Context, userID, purpose, tokenHash string, ttl time.Duration) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create token: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM user_tokens WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`, userID, purpose)
+	if err != nil {
+		return fmt.Errorf("failed to replace token: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_tokens (user_id, purpose, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)`, userID, purpose, tokenHash, time.Now().Add(ttl))
+	if err != nil {
+		return fmt.Errorf("failed to create token: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ConsumeUserToken marks an unused, unexpired token as used and returns the
+// user it belongs to.
+func (db *DB) ConsumeUserToken(ctx context.Context, purpose, tokenHash string) (string, error) {
+	var userID string
+	err := db.GetContext(ctx, &userID, `
+		UPDATE user_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id`, tokenHash, purpose)
+	if err != nil {
+		return "", fmt.Errorf("token not found: %w", err)
+	}
+	return userID, nil
+}
+
+// MarkEmailVerified records that a user's email is verified. Verifying again
+// keeps the original time.
+func (db *DB) MarkEmailVerified(ctx context.Context, userID string) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()) WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+	return nil
+}
+
+// UpdateUserPassword replaces a user's password hash.
+func (db *DB) UpdateUserPassword(ctx context.Context, userID, passwordHash string) error {
+	_, err := db.ExecContext(ctx, `UPDATE users SET password_hash = $2 WHERE id = $1`, userID, passwordHash)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return nil
+}
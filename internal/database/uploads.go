@@ -0,0 +1,74 @@
+// uploads.go contains database operations for resumable (tus) uploads.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CreateUpload inserts a new resumable upload.
+func (db *DB) CreateUpload(ctx context.Context, u *models.Upload) error {
+	query := `
+		INSERT INTO uploads (item_type, filename, upload_length, timeout_seconds, redact, language, translate, keep_source,
+			title, notes, metadata, api_key_id, user_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11::jsonb, $12, $13, $14)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		u.ItemType, u.Filename, u.Length, u.TimeoutSeconds, u.Redact, u.Language, u.Translate, u.KeepSource,
+		u.Title, u.Notes, metadataJSON(u.Metadata), u.APIKeyID, u.UserID, u.ExpiresAt,
+	).Scan(&u.ID, &u.CreatedAt)
+}
+
+// GetUpload retrieves an unexpired upload by ID.
+func (db *DB) GetUpload(ctx context.Context, id string) (*models.Upload, error) {
+	var u models.Upload
+	err := db.GetContext(ctx, &u, `SELECT * FROM uploads WHERE id = $1 AND expires_at > NOW()`, id)
+	if err != nil {
+		return nil, fmt.Errorf("upload not found: %w", err)
+	}
+	return &u, nil
+}
+
+// AdvanceUpload moves an upload's offset from one value to another. It
+// fails if another request already moved it.
+func (db *DB) AdvanceUpload(ctx context.Context, id string, from, to int64) error {
+	result, err := db.ExecContext(ctx,
+		`UPDATE uploads SET upload_offset = $3 WHERE id = $1 AND upload_offset = $2`, id, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to update upload offset: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("upload offset changed concurrently")
+	}
+	return nil
+}
+
+// CompleteUpload records the item a finished upload became.
+func (db *DB) CompleteUpload(ctx context.Context, id, itemID string) error {
+	_, err := db.ExecContext(ctx, `UPDATE uploads SET item_id = $2 WHERE id = $1`, id, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to complete upload: %w", err)
+	}
+	return nil
+}
+
+// DeleteUpload removes an upload by ID.
+func (db *DB) DeleteUpload(ctx context.Context, id string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM uploads WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredUploads removes expired uploads, returning their IDs so
+// their files can be deleted too.
+func (db *DB) DeleteExpiredUploads(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := db.SelectContext(ctx, &ids, `DELETE FROM uploads WHERE expires_at <= NOW() RETURNING id`); err != nil {
+		return nil, fmt.Errorf("failed to delete expired uploads: %w", err)
+	}
+	return ids, nil
+}
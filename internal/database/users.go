@@ -30,6 +30,32 @@ func (db *DB) GetUserByEmail(ctx context.Context, email string) (*models.User, e
 	return &u, nil
 }
 
+// GetUserByIdentity returns the user linked to a provider account.
+func (db *DB) GetUserByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	var u models.User
+	err := db.GetContext(ctx, &u,
+		`SELECT u.* FROM users u
+		 JOIN user_identities i ON i.user_id = u.id
+		 WHERE i.provider = $1 AND i.subject = $2`, provider, subject)
+	if err != nil {
+		return nil, fmt.Errorf("identity not found: %w", err)
+	}
+	return &u, nil
+}
+
+// LinkUserIdentity links a provider account to a user. Linking an account
+// that's already linked is a no-op.
+func (db *DB) LinkUserIdentity(ctx context.Context, userID, provider, subject, email string) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO user_identities (provider, subject, user_id, email)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (provider, subject) DO NOTHING`, provider, subject, userID, email)
+	if err != nil {
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+	return nil
+}
+
 // GetUserByID retrieves a user by ID.
 func (db *DB) GetUserByID(ctx context.Context, id string) (*models.User, error) {
 	var u models.User
@@ -78,13 +104,16 @@ func (db *DB) GetWorkspaceItems(ctx context.Context, userID string) ([]models.Wo
 func (db *DB) GetWorkspaceTranscripts(ctx context.Context, userID string) ([]models.Transcript, error) {
 	var transcripts []models.Transcript
 	err := db.SelectContext(ctx, &transcripts,
-		`SELECT t.* FROM transcripts t
+		`SELECT `+qualifiedColumns(transcriptColumns, "t")+` FROM transcripts t
 		 JOIN workspace_items wi ON wi.item_id = t.id AND wi.item_type = 'transcript'
 		 WHERE wi.user_id = $1
 		 ORDER BY wi.created_at DESC LIMIT 50`, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workspace transcripts: %w", err)
 	}
+	if err := db.loadTranscriptTexts(ctx, transcripts); err != nil {
+		return nil, err
+	}
 	return transcripts, nil
 }
 
@@ -92,13 +121,16 @@ func (db *DB) GetWorkspaceTranscripts(ctx context.Context, userID string) ([]mod
 func (db *DB) GetWorkspaceAudio(ctx context.Context, userID string) ([]models.AudioTranscription, error) {
 	var audio []models.AudioTranscription
 	err := db.SelectContext(ctx, &audio,
-		`SELECT a.* FROM audio_transcriptions a
+		`SELECT `+qualifiedColumns(audioColumns, "a")+` FROM audio_transcriptions a
 		 JOIN workspace_items wi ON wi.item_id = a.id AND wi.item_type = 'audio'
 		 WHERE wi.user_id = $1
 		 ORDER BY wi.created_at DESC LIMIT 50`, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workspace audio: %w", err)
 	}
+	if err := db.loadAudioTexts(ctx, audio); err != nil {
+		return nil, err
+	}
 	return audio, nil
 }
 
@@ -106,7 +138,7 @@ func (db *DB) GetWorkspaceAudio(ctx context.Context, userID string) ([]models.Au
 func (db *DB) GetWorkspacePDFs(ctx context.Context, userID string) ([]models.PDFExtraction, error) {
 	var pdfs []models.PDFExtraction
 	err := db.SelectContext(ctx, &pdfs,
-		`SELECT p.* FROM pdf_extractions p
+		`SELECT `+qualifiedColumns(pdfColumns, "p")+` FROM pdf_extractions p
 		 JOIN workspace_items wi ON wi.item_id = p.id AND wi.item_type = 'pdf'
 		 WHERE wi.user_id = $1
 		 ORDER BY wi.created_at DESC LIMIT 50`, userID)
@@ -121,3 +153,48 @@ func (db *DB) LinkAPIKeyToUser(ctx context.Context, apiKeyID, userID string) err
 	_, err := db.ExecContext(ctx, `UPDATE api_keys SET user_id = $2 WHERE id = $1`, apiKeyID, userID)
 	return err
 }
+
+// GetAPIKeyUserID returns the user an API key is linked to, or nil.
+func (db *DB) GetAPIKeyUserID(ctx context.Context, keyID string) (*string, error) {
+	var userID *string
+	if err := db.GetContext(ctx, &userID, `SELECT user_id FROM api_keys WHERE id = $1`, keyID); err != nil {
+		return nil, fmt.Errorf("API key not found: %w", err)
+	}
+	return userID, nil
+}
+
+// CountUserAPIKeys returns how many active API keys are linked to a user.
+func (db *DB) CountUserAPIKeys(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := db.GetContext(ctx, &n,
+		`SELECT COUNT(*) FROM api_keys WHERE user_id = $1 AND active = true`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count user API keys: %w", err)
+	}
+	return n, nil
+}
+
+// RenameUserAPIKey renames one of a user's API keys and returns it.
+func (db *DB) RenameUserAPIKey(ctx context.Context, userID, keyID, name string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := db.GetContext(ctx, &key,
+		`UPDATE api_keys SET name = $3 WHERE id = $1 AND user_id = $2 RETURNING *`, keyID, userID, name)
+	if err != nil {
+		return nil, fmt.Errorf("API key not found: %w", err)
+	}
+	return &key, nil
+}
+
+// RevokeUserAPIKey deactivates one of a user's API keys.
+func (db *DB) RevokeUserAPIKey(ctx context.Context, userID, keyID string) error {
+	result, err := db.ExecContext(ctx,
+		`UPDATE api_keys SET active = false WHERE id = $1 AND user_id = $2`, keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke key: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("API key not found")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// watch.go contains database operations for the watch folder's record of
+// the files it has picked up.
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// RecordWatchedFile records a file the watch folder picked up.
+func (db *DB) RecordWatchedFile(ctx context.Context, f *models.WatchedFile) error {
+	query := `
+		INSERT INTO watched_files (path, size, item_type, item_id, error_message)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := db.QueryRowContext(ctx, query,
+		f.Path, f.Size, f.ItemType, f.ItemID, f.ErrorMessage,
+	).Scan(&f.ID, &f.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record watched file: %w", err)
+	}
+	return nil
+}
+
+// ListWatchedPaths returns the path of every file the watch folder has
+// picked up.
+func (db *DB) ListWatchedPaths(ctx context.Context) (map[string]bool, error) {
+	var paths []string
+	if err := db.SelectContext(ctx, &paths, `SELECT path FROM watched_files`); err != nil {
+		return nil, fmt.Errorf("failed to list watched files: %w", err)
+	}
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		seen[path] = true
+	}
+	return seen, nil
+}
+
+// ListWatchedFiles returns the files the watch folder picked up, newest
+// first.
+func (db *DB) ListWatchedFiles(ctx context.Context, limit int) ([]models.WatchedFile, error) {
+	var files []models.WatchedFile
+	err := db.SelectContext(ctx, &files,
+		`SELECT * FROM watched_files ORDER BY created_at DESC, id LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched files: %w", err)
+	}
+	return files, nil
+}
@@ -4,30 +4,48 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 )
 
-// CreateWebhook inserts a new webhook record.
+const webhookColumns = `id, api_key_id, url, events, secret, active, payload_mode, payload_fields, max_payload_bytes,
+	credentials, header_names, has_basic_auth, previous_secret, previous_secret_expires_at, created_at`
+
+// scanWebhook reads a row selected with webhookColumns.
+func scanWebhook(row interface{ Scan(...interface{}) error }) (models.Webhook, error) {
+	var w models.Webhook
+	err := row.Scan(&w.ID, &w.APIKeyID, &w.URL, pq.Array(&w.Events), &w.Secret, &w.Active,
+		&w.PayloadMode, pq.Array(&w.PayloadFields), &w.MaxPayloadBytes,
+		&w.Credentials, pq.Array(&w.HeaderNames), &w.HasBasicAuth, &w.PreviousSecret, &w.PreviousSecretExpiresAt, &w.CreatedAt)
+	return w, err
+}
+
+// CreateWebhook inserts a new webhook record. An empty PayloadMode is
+// stored as full.
 func (db *DB) CreateWebhook(ctx context.Context, w *models.Webhook) error {
+	if w.PayloadMode == "" {
+		w.PayloadMode = models.WebhookPayloadFull
+	}
 	query := `
-		INSERT INTO webhooks (api_key_id, url, events, secret, active)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO webhooks (api_key_id, url, events, secret, active, payload_mode, payload_fields, max_payload_bytes,
+			credentials, header_names, has_basic_auth)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, '{}'::text[]), $8, $9, COALESCE($10, '{}'::text[]), $11)
 		RETURNING id, created_at`
 
 	return db.QueryRowContext(ctx, query,
 		w.APIKeyID, w.URL, pq.Array(w.Events), w.Secret, w.Active,
+		w.PayloadMode, pq.Array(w.PayloadFields), w.MaxPayloadBytes,
+		w.Credentials, pq.Array(w.HeaderNames), w.HasBasicAuth,
 	).Scan(&w.ID, &w.CreatedAt)
 }
 
 // GetWebhook retrieves a single webhook by ID.
 func (db *DB) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
-	var w models.Webhook
-	query := `SELECT id, api_key_id, url, events, secret, active, created_at FROM webhooks WHERE id = $1`
-	row := db.QueryRowContext(ctx, query, id)
-	err := row.Scan(&w.ID, &w.APIKeyID, &w.URL, pq.Array(&w.Events), &w.Secret, &w.Active, &w.CreatedAt)
+	w, err := scanWebhook(db.QueryRowContext(ctx,
+		`SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id))
 	if err != nil {
 		return nil, fmt.Errorf("webhook not found: %w", err)
 	}
@@ -36,7 +54,7 @@ func (db *DB) GetWebhook(ctx context.Context, id string) (*models.Webhook, error
 
 // ListWebhooksByAPIKey returns all webhooks for a given API key.
 func (db *DB) ListWebhooksByAPIKey(ctx context.Context, apiKeyID string) ([]models.Webhook, error) {
-	query := `SELECT id, api_key_id, url, events, secret, active, created_at FROM webhooks WHERE api_key_id = $1 ORDER BY created_at DESC`
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE api_key_id = $1 ORDER BY created_at DESC`
 	rows, err := db.QueryContext(ctx, query, apiKeyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list webhooks: %w", err)
@@ -45,8 +63,8 @@ func (db *DB) ListWebhooksByAPIKey(ctx context.Context, apiKeyID string) ([]mode
 
 	var webhooks []models.Webhook
 	for rows.Next() {
-		var w models.Webhook
-		if err := rows.Scan(&w.ID, &w.APIKeyID, &w.URL, pq.Array(&w.Events), &w.Secret, &w.Active, &w.CreatedAt); err != nil {
+		w, err := scanWebhook(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan webhook: %w", err)
 		}
 		webhooks = append(webhooks, w)
@@ -54,9 +72,15 @@ func (db *DB) ListWebhooksByAPIKey(ctx context.Context, apiKeyID string) ([]mode
 	return webhooks, nil
 }
 
-// UpdateWebhookActive toggles a webhook's active state.
-func (db *DB) UpdateWebhookActive(ctx context.Context, id string, active bool) error {
-	result, err := db.ExecContext(ctx, `UPDATE webhooks SET active = $2 WHERE id = $1`, id, active)
+// UpdateWebhook saves a webhook's active state, payload settings, and
+// credentials.
+func (db *DB) UpdateWebhook(ctx context.Context, w *models.Webhook) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE webhooks SET active = $2, payload_mode = $3, payload_fields = COALESCE($4, '{}'::text[]), max_payload_bytes = $5,
+			credentials = $6, header_names = COALESCE($7, '{}'::text[]), has_basic_auth = $8
+		WHERE id = $1`,
+		w.ID, w.Active, w.PayloadMode, pq.Array(w.PayloadFields), w.MaxPayloadBytes,
+		w.Credentials, pq.Array(w.HeaderNames), w.HasBasicAuth)
 	if err != nil {
 		return fmt.Errorf("failed to update webhook: %w", err)
 	}
@@ -67,6 +91,28 @@ func (db *DB) UpdateWebhookActive(ctx context.Context, id string, active bool) e
 	return nil
 }
 
+// RotateWebhookSecret replaces a webhook's secret. The old one is kept as
+// its previous secret until graceUntil, or dropped if graceUntil is nil.
+func (db *DB) RotateWebhookSecret(ctx context.Context, w *models.Webhook, secret string, graceUntil *time.Time) error {
+	previous := ""
+	if graceUntil != nil {
+		previous = w.Secret
+	}
+	result, err := db.ExecContext(ctx, `
+		UPDATE webhooks SET secret = $2, previous_secret = $3, previous_secret_expires_at = $4
+		WHERE id = $1`,
+		w.ID, secret, previous, graceUntil)
+	if err != nil {
+		return fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("webhook not found")
+	}
+	w.Secret, w.PreviousSecret, w.PreviousSecretExpiresAt = secret, previous, graceUntil
+	return nil
+}
+
 // DeleteWebhook removes a webhook by ID.
 func (db *DB) DeleteWebhook(ctx context.Context, id string) error {
 	result, err := db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
@@ -80,10 +126,15 @@ func (db *DB) DeleteWebhook(ctx context.Context, id string) error {
 	return nil
 }
 
-// GetActiveWebhooksForEvent returns all active webhooks that subscribe to a given event.
-func (db *DB) GetActiveWebhooksForEvent(ctx context.Context, event string) ([]models.Webhook, error) {
-	query := `SELECT id, api_key_id, url, events, secret, active, created_at FROM webhooks WHERE active = true AND $1 = ANY(events)`
-	rows, err := db.QueryContext(ctx, query, event)
+// GetActiveWebhooksForOwner returns the active webhooks subscribed to an
+// event about an item: those of the API key that created it, and of any key
+// belonging to the user who owns it. Either ID may be nil; an item with
+// neither has no webhooks.
+func (db *DB) GetActiveWebhooksForOwner(ctx context.Context, apiKeyID, userID *string, event string) ([]models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks
+		WHERE active = true AND $1 = ANY(events)
+		  AND (api_key_id = $2 OR api_key_id IN (SELECT id FROM api_keys WHERE user_id = $3))`
+	rows, err := db.QueryContext(ctx, query, event, apiKeyID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get webhooks for event: %w", err)
 	}
@@ -91,8 +142,29 @@ func (db *DB) GetActiveWebhooksForEvent(ctx context.Context, event string) ([]mo
 
 	var webhooks []models.Webhook
 	for rows.Next() {
-		var w models.Webhook
-		if err := rows.Scan(&w.ID, &w.APIKeyID, &w.URL, pq.Array(&w.Events), &w.Secret, &w.Active, &w.CreatedAt); err != nil {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, nil
+}
+
+// GetActiveWebhooksForAPIKeyEvent returns an API key's active webhooks that
+// subscribe to a given event.
+func (db *DB) GetActiveWebhooksForAPIKeyEvent(ctx context.Context, apiKeyID, event string) ([]models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE api_key_id = $1 AND active = true AND $2 = ANY(events)`
+	rows, err := db.QueryContext(ctx, query, apiKeyID, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks for event: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan webhook: %w", err)
 		}
 		webhooks = append(webhooks, w)
@@ -157,3 +229,37 @@ func (db *DB) ListAllDeliveriesByAPIKey(ctx context.Context, apiKeyID string, li
 	}
 	return deliveries, nil
 }
+
+// GetWebhookDelivery retrieves one of a webhook's deliveries.
+func (db *DB) GetWebhookDelivery(ctx context.Context, webhookID, id string) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := db.GetContext(ctx, &d,
+		`SELECT * FROM webhook_deliveries WHERE id = $1 AND webhook_id = $2`, id, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("webhook delivery not found: %w", err)
+	}
+	return &d, nil
+}
+
+// CreateWebhookDeliveryAttempt records one HTTP attempt at a delivery.
+func (db *DB) CreateWebhookDeliveryAttempt(ctx context.Context, a *models.WebhookDeliveryAttempt) error {
+	query := `
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempt, response_code, response_body, response_truncated, latency_ms, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	return db.QueryRowContext(ctx, query,
+		a.DeliveryID, a.Attempt, a.ResponseCode, a.ResponseBody, a.ResponseTruncated, a.LatencyMS, a.Error,
+	).Scan(&a.ID, &a.CreatedAt)
+}
+
+// ListWebhookDeliveryAttempts returns a delivery's attempts, oldest first.
+func (db *DB) ListWebhookDeliveryAttempts(ctx context.Context, deliveryID string) ([]models.WebhookDeliveryAttempt, error) {
+	var attempts []models.WebhookDeliveryAttempt
+	err := db.SelectContext(ctx, &attempts,
+		`SELECT * FROM webhook_delivery_attempts WHERE delivery_id = $1 ORDER BY attempt`, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook delivery attempts: %w", err)
+	}
+	return attempts, nil
+}
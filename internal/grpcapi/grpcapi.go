@@ -0,0 +1,233 @@
+// Package grpcapi serves the gRPC API defined in
+// proto/mediatools/v1/media_tools.proto: transcript submission, status
+// streaming, and search, for backend consumers that want typed clients and
+// server push instead of REST polling.
+//
+// Go Pattern: The service is built on google.golang.org/grpc with stubs
+// generated from the proto ("make proto"). Cross-cutting concerns live in
+// interceptors, the gRPC equivalent of gin middleware: one turns panics and
+// unexpected errors into an opaque Internal status, and one authenticates
+// the caller. Auth and rate limiting reuse the REST API's API keys and
+// token buckets, so a key's limit is shared across both APIs. The server
+// also speaks gzip and exposes the standard health and reflection services,
+// which need no API key.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip" // Lets clients send and accept gzip-compressed messages
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+	mediatoolsv1 "github.com/Shimizu-Technology/media-tools-api/proto/mediatools/v1"
+)
+
+// servicePrefix is the gRPC path prefix for every method in the service.
+// Only these methods require an API key.
+var servicePrefix = "/" + mediatoolsv1.MediaTools_ServiceDesc.ServiceName + "/"
+
+// Server implements the MediaTools gRPC service.
+type Server struct {
+	mediatoolsv1.UnimplementedMediaToolsServer
+
+	db      *database.DB
+	worker  *worker.Pool
+	limiter *middleware.RateLimiter
+
+	ownerKeyID     string
+	ownerKeyPrefix string
+
+	// watchInterval is how often WatchTranscript re-checks the database.
+	watchInterval time.Duration
+
+	// authenticate resolves the caller's API key; swapped out in tests.
+	authenticate func(ctx context.Context, rawKey string) (*models.APIKey, error)
+}
+
+// New creates a gRPC server backed by the same database, worker pool, and
+// rate limiter as the REST API.
+func New(db *database.DB, wp *worker.Pool, limiter *middleware.RateLimiter, ownerKeyID, ownerKeyPrefix string) *grpc.Server {
+	s := &Server{
+		db:             db,
+		worker:         wp,
+		limiter:        limiter,
+		ownerKeyID:     ownerKeyID,
+		ownerKeyPrefix: ownerKeyPrefix,
+		watchInterval:  2 * time.Second,
+	}
+	s.authenticate = func(ctx context.Context, rawKey string) (*models.APIKey, error) {
+		return db.GetAPIKeyByHash(ctx, middleware.HashAPIKey(rawKey))
+	}
+	return s.newGRPCServer(s)
+}
+
+// newGRPCServer registers svc behind s's interceptors, along with the
+// health and reflection services. Tests pass a fake svc.
+func (s *Server) newGRPCServer(svc mediatoolsv1.MediaToolsServer) *grpc.Server {
+	gs := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryErrors, s.unaryAuth),
+		grpc.ChainStreamInterceptor(streamErrors, s.streamAuth),
+	)
+	mediatoolsv1.RegisterMediaToolsServer(gs, svc)
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(mediatoolsv1.MediaTools_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(gs, healthSrv)
+	reflection.Register(gs)
+	return gs
+}
+
+// unaryErrors reports panics and errors that aren't gRPC statuses as an
+// opaque Internal status, logging the details server-side.
+func unaryErrors(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("❌ gRPC %s panicked: %v\n%s", info.FullMethod, p, debug.Stack())
+			resp, err = nil, status.Error(codes.Internal, "internal error")
+		}
+	}()
+	resp, err = handler(ctx, req)
+	return resp, statusError(info.FullMethod, err)
+}
+
+// streamErrors is unaryErrors for streaming calls.
+func streamErrors(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("❌ gRPC %s panicked: %v\n%s", info.FullMethod, p, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return statusError(info.FullMethod, handler(srv, ss))
+}
+
+// statusError passes gRPC statuses through and maps everything else.
+func statusError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "call canceled")
+	}
+	log.Printf("❌ gRPC %s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// apiKeyContextKey is the context key the caller's API key is stored under.
+type apiKeyContextKey struct{}
+
+// apiKeyFrom returns the API key the auth interceptor stored in ctx.
+func apiKeyFrom(ctx context.Context) *models.APIKey {
+	key, _ := ctx.Value(apiKeyContextKey{}).(*models.APIKey)
+	return key
+}
+
+// unaryAuth authorizes calls to the MediaTools service and passes the
+// caller's API key to the method through its context.
+func (s *Server) unaryAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, servicePrefix) {
+		return handler(ctx, req)
+	}
+	key, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, apiKeyContextKey{}, key), req)
+}
+
+// streamAuth is unaryAuth for streaming calls.
+func (s *Server) streamAuth(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !strings.HasPrefix(info.FullMethod, servicePrefix) {
+		return handler(srv, ss)
+	}
+	key, err := s.authorize(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &keyedStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), apiKeyContextKey{}, key)})
+}
+
+// keyedStream overrides a stream's context to carry the caller's API key.
+type keyedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ks *keyedStream) Context() context.Context {
+	return ks.ctx
+}
+
+// authorize checks the x-api-key metadata, the key's origin and IP
+// restrictions, its billing standing, and its rate limit, just like the
+// DualAuth, RequireBillingStanding, and RateLimit middleware do for REST.
+// The gRPC port is reached directly, so the peer address is the client IP.
+func (s *Server) authorize(ctx context.Context) (*models.APIKey, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	rawKey := firstValue(md, "x-api-key")
+	if rawKey == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing x-api-key metadata")
+	}
+	key, err := s.authenticate(ctx, rawKey)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or revoked API key")
+	}
+
+	var clientIP string
+	if p, ok := peer.FromContext(ctx); ok {
+		clientIP, _, _ = net.SplitHostPort(p.Addr.String())
+	}
+	// Metadata travels as the call's HTTP/2 headers, so Origin and Referer
+	// are read the same way as for REST.
+	headers := http.Header{"Origin": md.Get("origin"), "Referer": md.Get("referer")}
+	if err := middleware.CheckKeyRestrictions(key, clientIP, middleware.RequestOrigin(&http.Request{Header: headers})); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Message)
+	}
+	if apiErr := middleware.CheckBillingStanding(key); apiErr != nil {
+		return nil, status.Error(codes.PermissionDenied, apiErr.Message)
+	}
+	if s.limiter != nil && !s.limiter.Allow(key) {
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded, try again later")
+	}
+	if s.db != nil {
+		go s.db.UpdateAPIKeyLastUsed(context.Background(), key.ID)
+	}
+	return key, nil
+}
+
+// firstValue returns the first value of a metadata key, or "".
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// isOwner reports whether key is the configured owner key, which may wait
+// for queue space instead of being turned away.
+func (s *Server) isOwner(key *models.APIKey) bool {
+	return middleware.IsOwnerAPIKey(key, s.ownerKeyID, s.ownerKeyPrefix)
+}
@@ -0,0 +1,224 @@
+// grpcapi_test.go checks the interceptors and the extra services end to end.
+//
+// Go Pattern: The tests run a real gRPC server on a loopback port and call
+// it with the generated client, with a fake service registered in place of
+// the database-backed one. That exercises the actual status codes,
+// metadata, and compression a client would see.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	mediatoolsv1 "github.com/Shimizu-Technology/media-tools-api/proto/mediatools/v1"
+)
+
+func TestStatusMapping(t *testing.T) {
+	for status, v := range statusToProto {
+		if got := statusFromProto(v); got != status {
+			t.Errorf("statusFromProto(%v) = %q, want %q", v, got, status)
+		}
+	}
+	if got := statusFromProto(mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_UNSPECIFIED); got != "" {
+		t.Errorf("unspecified status = %q, want empty", got)
+	}
+}
+
+// fakeService answers GetTranscript and WatchTranscript without a
+// database; SearchTranscripts is left unimplemented.
+type fakeService struct {
+	mediatoolsv1.UnimplementedMediaToolsServer
+}
+
+func (fakeService) GetTranscript(ctx context.Context, req *mediatoolsv1.GetTranscriptRequest) (*mediatoolsv1.Transcript, error) {
+	switch req.GetId() {
+	case "missing":
+		return nil, status.Error(codes.NotFound, "transcript not found")
+	case "explode":
+		return nil, errors.New("database is on fire")
+	case "panic":
+		panic("nil map")
+	}
+	return &mediatoolsv1.Transcript{Id: req.GetId(), Title: "owned by " + apiKeyFrom(ctx).ID}, nil
+}
+
+func (fakeService) WatchTranscript(req *mediatoolsv1.GetTranscriptRequest, stream grpc.ServerStreamingServer[mediatoolsv1.Transcript]) error {
+	for _, st := range []mediatoolsv1.TranscriptStatus{
+		mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_PENDING,
+		mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_PROCESSING,
+		mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_COMPLETED,
+	} {
+		if err := stream.Send(&mediatoolsv1.Transcript{Id: req.GetId(), Status: st}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeServer serves fakeService with in-memory auth and returns a client
+// connection to it.
+func fakeServer(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	s := &Server{
+		authenticate: func(ctx context.Context, rawKey string) (*models.APIKey, error) {
+			switch rawKey {
+			case "good-key":
+				return &models.APIKey{ID: "key-1"}, nil
+			case "office-key":
+				return &models.APIKey{ID: "key-2", AllowedIPs: []string{"203.0.113.0/24"}}, nil
+			}
+			return nil, errors.New("no such key")
+		},
+	}
+	gs := s.newGRPCServer(fakeService{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// withKey attaches an API key to a call's metadata.
+func withKey(apiKey string) context.Context {
+	if apiKey == "" {
+		return context.Background()
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", apiKey)
+}
+
+func TestUnaryCalls(t *testing.T) {
+	client := mediatoolsv1.NewMediaToolsClient(fakeServer(t))
+
+	tests := []struct {
+		name        string
+		apiKey      string
+		id          string
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{"success", "good-key", "abc", codes.OK, ""},
+		{"missing API key", "", "abc", codes.Unauthenticated, "missing x-api-key metadata"},
+		{"invalid API key", "bad-key", "abc", codes.Unauthenticated, "invalid or revoked API key"},
+		{"key restricted to other IPs", "office-key", "abc", codes.PermissionDenied, "This API key can't be used from IP address 127.0.0.1"},
+		{"status errors pass through", "good-key", "missing", codes.NotFound, "transcript not found"},
+		{"other errors are hidden", "good-key", "explode", codes.Internal, "internal error"},
+		{"panics are recovered", "good-key", "panic", codes.Internal, "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetTranscript(withKey(tt.apiKey), &mediatoolsv1.GetTranscriptRequest{Id: tt.id})
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Errorf("status = %s %q, want %s %q", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+			if err == nil && (got.GetId() != "abc" || got.GetTitle() != "owned by key-1") {
+				t.Errorf("response = %+v", got)
+			}
+		})
+	}
+
+	_, err := client.SearchTranscripts(withKey("good-key"), &mediatoolsv1.SearchTranscriptsRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("unimplemented method: %v, want Unimplemented", err)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	client := mediatoolsv1.NewMediaToolsClient(fakeServer(t))
+	got, err := client.GetTranscript(withKey("good-key"), &mediatoolsv1.GetTranscriptRequest{Id: "abc"},
+		grpc.UseCompressor(gzip.Name))
+	if err != nil || got.GetId() != "abc" {
+		t.Errorf("gzip call = %+v, %v", got, err)
+	}
+}
+
+func TestWatchTranscript(t *testing.T) {
+	client := mediatoolsv1.NewMediaToolsClient(fakeServer(t))
+
+	// A server-streaming call's status arrives with the first Recv.
+	stream, err := client.WatchTranscript(withKey(""), &mediatoolsv1.GetTranscriptRequest{Id: "w"})
+	if err != nil {
+		t.Fatalf("WatchTranscript: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("stream without a key: %v, want Unauthenticated", err)
+	}
+
+	stream, err = client.WatchTranscript(withKey("good-key"), &mediatoolsv1.GetTranscriptRequest{Id: "w"})
+	if err != nil {
+		t.Fatalf("WatchTranscript: %v", err)
+	}
+	var got []mediatoolsv1.TranscriptStatus
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got = append(got, msg.GetStatus())
+	}
+	if len(got) != 3 || got[2] != mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_COMPLETED {
+		t.Errorf("statuses = %v, want pending, processing, completed", got)
+	}
+}
+
+// TestHealthAndReflection verifies the standard services answer without an
+// API key.
+func TestHealthAndReflection(t *testing.T) {
+	conn := fakeServer(t)
+	ctx := context.Background()
+
+	health := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", "mediatools.v1.MediaTools"} {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Check(%q) = %v, %v; want SERVING", service, resp, err)
+		}
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("ServerReflectionInfo: %v", err)
+	}
+	req := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}
+	if err := stream.Send(req); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	var found bool
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		found = found || svc.GetName() == "mediatools.v1.MediaTools"
+	}
+	if !found {
+		t.Errorf("reflection services = %v, want mediatools.v1.MediaTools", resp.GetListServicesResponse().GetService())
+	}
+}
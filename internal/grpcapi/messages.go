@@ -0,0 +1,64 @@
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	mediatoolsv1 "github.com/Shimizu-Technology/media-tools-api/proto/mediatools/v1"
+)
+
+// The message types are generated from proto/mediatools/v1/media_tools.proto
+// ("make proto"); this file converts between them and the database models.
+
+var statusToProto = map[models.TranscriptStatus]mediatoolsv1.TranscriptStatus{
+	models.StatusPending:    mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_PENDING,
+	models.StatusProcessing: mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_PROCESSING,
+	models.StatusCompleted:  mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_COMPLETED,
+	models.StatusFailed:     mediatoolsv1.TranscriptStatus_TRANSCRIPT_STATUS_FAILED,
+}
+
+// statusFromProto is the inverse of statusToProto ("" for unspecified).
+func statusFromProto(v mediatoolsv1.TranscriptStatus) models.TranscriptStatus {
+	for s, p := range statusToProto {
+		if p == v {
+			return s
+		}
+	}
+	return ""
+}
+
+// newTranscript converts a database record. The full text is only copied
+// when asked for, since it can be hundreds of kilobytes.
+func newTranscript(t *models.Transcript, includeText bool) *mediatoolsv1.Transcript {
+	out := &mediatoolsv1.Transcript{
+		Id:              t.ID,
+		YoutubeUrl:      t.YouTubeURL,
+		YoutubeId:       t.YouTubeID,
+		Title:           t.Title,
+		ChannelName:     t.ChannelName,
+		DurationSeconds: int32(t.Duration),
+		Language:        t.Language,
+		WordCount:       int32(t.WordCount),
+		Status:          statusToProto[t.Status],
+		ErrorMessage:    t.ErrorMessage,
+		CreatedAt:       timestamp(t.CreatedAt),
+		UpdatedAt:       timestamp(t.UpdatedAt),
+	}
+	if includeText {
+		out.TranscriptText = t.TranscriptText
+	}
+	if t.BatchID != nil {
+		out.BatchId = *t.BatchID
+	}
+	return out
+}
+
+// timestamp converts a time, leaving zero times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
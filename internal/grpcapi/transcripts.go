@@ -0,0 +1,175 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/transcript"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+	mediatoolsv1 "github.com/Shimizu-Technology/media-tools-api/proto/mediatools/v1"
+)
+
+// SubmitTranscript mirrors POST /api/v1/transcripts.
+func (s *Server) SubmitTranscript(ctx context.Context, req *mediatoolsv1.SubmitTranscriptRequest) (*mediatoolsv1.Transcript, error) {
+	key := apiKeyFrom(ctx)
+	youtubeURL, videoID, err := transcript.ParseYouTubeURL(req.GetUrl())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Already extracted? Hand back the finished transcript.
+	existing, _ := s.db.GetTranscriptByYouTubeID(ctx, videoID)
+	if existing != nil && existing.Status == models.StatusCompleted {
+		return newTranscript(existing, false), nil
+	}
+	if _, _, apiErr := middleware.CheckQuota(ctx, s.db, key, s.ownerKeyID, s.ownerKeyPrefix); apiErr != nil {
+		return nil, status.Error(codes.ResourceExhausted, apiErr.Message)
+	}
+
+	t := &models.Transcript{
+		YouTubeURL: youtubeURL,
+		YouTubeID:  videoID,
+		Status:     models.StatusPending,
+		APIKeyID:   &key.ID,
+	}
+	if err := s.db.CreateTranscript(ctx, t); err != nil {
+		return nil, err
+	}
+
+	job := worker.Job{
+		ID:        t.ID,
+		Type:      worker.JobTranscriptExtraction,
+		CreatedAt: time.Now(),
+	}
+	if err := s.worker.Submit(job); err != nil {
+		queued := false
+		if s.isOwner(key) {
+			waitCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+			defer cancel()
+			queued = s.worker.SubmitBlocking(waitCtx, job) == nil
+		}
+		if !queued {
+			// Same as REST: fail the record and tell the client when to resubmit.
+			log.Printf("⚠️  Failed to queue extraction job %s (gRPC): %v", t.ID, err)
+			t.Status = models.StatusFailed
+			t.ErrorMessage = "Job queue is full, please try again later"
+			s.db.UpdateTranscript(ctx, t)
+			return nil, status.Errorf(codes.Unavailable, "job queue is full, try again in %d seconds", int(s.worker.RetryAfter().Seconds()))
+		}
+	}
+
+	return newTranscript(t, false), nil
+}
+
+// GetTranscript mirrors GET /api/v1/transcripts/:id.
+func (s *Server) GetTranscript(ctx context.Context, req *mediatoolsv1.GetTranscriptRequest) (*mediatoolsv1.Transcript, error) {
+	t, err := s.loadTranscript(ctx, apiKeyFrom(ctx), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return newTranscript(t, req.GetIncludeText()), nil
+}
+
+// WatchTranscript streams a transcript's status until it finishes. This
+// replaces polling GET /transcripts/:id: the server does the polling
+// against its own database and only sends when something changed.
+func (s *Server) WatchTranscript(req *mediatoolsv1.GetTranscriptRequest, stream grpc.ServerStreamingServer[mediatoolsv1.Transcript]) error {
+	ctx := stream.Context()
+	key := apiKeyFrom(ctx)
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	var lastStatus models.TranscriptStatus
+	var lastUpdate time.Time
+	for {
+		t, err := s.loadTranscript(ctx, key, req.GetId())
+		if err != nil {
+			return err
+		}
+
+		if t.Status != lastStatus || !t.UpdatedAt.Equal(lastUpdate) {
+			lastStatus, lastUpdate = t.Status, t.UpdatedAt
+			if err := stream.Send(newTranscript(t, req.GetIncludeText())); err != nil {
+				return err
+			}
+		}
+		if t.Status == models.StatusCompleted || t.Status == models.StatusFailed {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SearchTranscripts mirrors GET /api/v1/transcripts?search=..., scoped to
+// the caller's API key.
+func (s *Server) SearchTranscripts(ctx context.Context, req *mediatoolsv1.SearchTranscriptsRequest) (*mediatoolsv1.SearchTranscriptsResponse, error) {
+	params := models.TranscriptListParams{
+		Page:        int(req.GetPage()),
+		PerPage:     int(req.GetPageSize()),
+		Status:      statusFromProto(req.GetStatus()),
+		Search:      req.GetQuery(),
+		APIKeyID:    &apiKeyFrom(ctx).ID,
+		IncludeText: req.GetIncludeText(), // Don't load text the response won't carry
+	}
+	// Apply the same defaults as ListTranscripts so the response echoes
+	// the page actually returned.
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+
+	transcripts, total, err := s.db.ListTranscripts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &mediatoolsv1.SearchTranscriptsResponse{
+		Total:    int32(total),
+		Page:     int32(params.Page),
+		PageSize: int32(params.PerPage),
+	}
+	for i := range transcripts {
+		resp.Transcripts = append(resp.Transcripts, newTranscript(&transcripts[i], req.GetIncludeText()))
+	}
+	return resp, nil
+}
+
+// loadTranscript fetches a transcript and checks the caller may see it.
+func (s *Server) loadTranscript(ctx context.Context, key *models.APIKey, id string) (*models.Transcript, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	t, err := s.db.GetTranscript(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "transcript not found")
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Malformed UUIDs fail inside Postgres; treat them as not found too.
+		log.Printf("gRPC: failed to load transcript %s: %v", id, err)
+		return nil, status.Error(codes.NotFound, "transcript not found")
+	}
+	if !middleware.CanViewItem(ctx, s.db, key, nil, s.ownerKeyID, s.ownerKeyPrefix, t.APIKeyID, t.UserID) {
+		return nil, status.Error(codes.PermissionDenied, "you can only access your own or your organization's transcripts")
+	}
+	return t, nil
+}
@@ -0,0 +1,282 @@
+//go:build cgo
+
+// access_test.go contains tests for ownership checks that look up API
+// keys, run against an in-memory SQLite database.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// openSQLite returns a migrated in-memory database.
+func openSQLite(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.New("sqlite::memory:")
+	if err != nil {
+		t.Fatalf("database.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.RunMigrations("../../migrations"); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	return db
+}
+
+// accessFixture holds two users, a key linked to the first, and a key
+// linked to no one.
+type accessFixture struct {
+	db              *database.DB
+	ada, bob        *models.User
+	adaKey, loneKey *models.APIKey
+}
+
+func newAccessFixture(t *testing.T) *accessFixture {
+	t.Helper()
+	ctx := context.Background()
+	f := &accessFixture{db: openSQLite(t)}
+
+	f.ada = &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	f.bob = &models.User{Email: "bob@example.com", PasswordHash: "x", Name: "Bob"}
+	for _, u := range []*models.User{f.ada, f.bob} {
+		if err := f.db.CreateUser(ctx, u); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+	f.adaKey = &models.APIKey{KeyHash: "hash-ada", KeyPrefix: "mta_ada", Name: "ada", Active: true, Tier: models.TierFree, Role: models.KeyRoleMember}
+	f.loneKey = &models.APIKey{KeyHash: "hash-lone", KeyPrefix: "mta_lone", Name: "lone", Active: true, Tier: models.TierFree, Role: models.KeyRoleMember}
+	for _, k := range []*models.APIKey{f.adaKey, f.loneKey} {
+		if err := f.db.CreateAPIKey(ctx, k); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+	}
+	if err := f.db.LinkAPIKeyToUser(ctx, f.adaKey.ID, f.ada.ID); err != nil {
+		t.Fatalf("LinkAPIKeyToUser: %v", err)
+	}
+	return f
+}
+
+// callerContext returns a request context authenticated as key or user,
+// using the auth middleware's context keys.
+func callerContext(key *models.APIKey, user *models.User) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if key != nil {
+		c.Set("api_key", key)
+	}
+	if user != nil {
+		c.Set("user", user)
+	}
+	return c
+}
+
+// TestCanManageItem verifies logged-in users only manage their own items
+// and those of keys linked to them, never unowned ones.
+func TestCanManageItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newAccessFixture(t)
+	h := &Handler{DB: f.db}
+
+	tests := []struct {
+		name      string
+		key       *models.APIKey
+		user      *models.User
+		ownerKey  *string
+		ownerUser *string
+		want      bool
+	}{
+		{name: "api key, own item", key: f.loneKey, ownerKey: &f.loneKey.ID, want: true},
+		{name: "api key, other key's item", key: f.loneKey, ownerKey: &f.adaKey.ID, want: false},
+		{name: "api key, unowned item", key: f.loneKey, want: true},
+		{name: "owning user", user: f.ada, ownerUser: &f.ada.ID, want: true},
+		{name: "user, linked key's item", user: f.ada, ownerKey: &f.adaKey.ID, want: true},
+		{name: "unrelated user", user: f.bob, ownerUser: &f.ada.ID, want: false},
+		{name: "unrelated user, other key's item", user: f.bob, ownerKey: &f.adaKey.ID, want: false},
+		{name: "user, unowned item", user: f.bob, want: false},
+		{name: "no credentials", ownerKey: &f.loneKey.ID, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.canManageItem(callerContext(tt.key, tt.user), tt.ownerKey, tt.ownerUser); got != tt.want {
+				t.Errorf("canManageItem() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExportChatSessionOwnership verifies only the user holding a chat
+// session can export it.
+func TestExportChatSessionOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	h := &Handler{DB: f.db}
+
+	tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Title: "Ada's talk", Status: models.StatusCompleted}
+	if err := f.db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+	if _, err := f.db.ExecContext(ctx, `UPDATE transcripts SET user_id = $1 WHERE id = $2`, f.ada.ID, tr.ID); err != nil {
+		t.Fatalf("set user_id: %v", err)
+	}
+	adaSession, err := f.db.GetOrCreateChatSession(ctx, "transcript", tr.ID, nil, &f.ada.ID)
+	if err != nil {
+		t.Fatalf("GetOrCreateChatSession: %v", err)
+	}
+	// Held by no one, like sessions from before users had their own
+	legacySession, err := f.db.GetOrCreateChatSession(ctx, "transcript", tr.ID, nil, nil)
+	if err != nil {
+		t.Fatalf("GetOrCreateChatSession: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		user    *models.User
+		session string
+		want    int
+	}{
+		{"owning user", f.ada, adaSession.ID, http.StatusOK},
+		{"unrelated user", f.bob, adaSession.ID, http.StatusForbidden},
+		{"session held by no one", f.ada, legacySession.ID, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/chat/sessions/"+tt.session+"/export", nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.session}}
+			c.Set("user", tt.user)
+			h.ExportChatSession(c)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+// TestChatFeedbackOwnership verifies only the user holding a chat session
+// can rate its answers.
+func TestChatFeedbackOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	h := &Handler{DB: f.db}
+
+	tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Title: "Ada's talk", Status: models.StatusCompleted, APIKeyID: &f.adaKey.ID}
+	if err := f.db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+	session, err := f.db.GetOrCreateChatSession(ctx, "transcript", tr.ID, &f.adaKey.ID, nil)
+	if err != nil {
+		t.Fatalf("GetOrCreateChatSession: %v", err)
+	}
+	msg := &models.TranscriptChatMessage{SessionID: session.ID, Role: "assistant", Content: "It's about Go."}
+	if err := f.db.CreateChatMessage(ctx, msg); err != nil {
+		t.Fatalf("CreateChatMessage: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  *models.APIKey
+		user *models.User
+		want int
+	}{
+		{"key holding the session", f.adaKey, nil, http.StatusOK},
+		{"user linked to that key", nil, f.ada, http.StatusOK},
+		{"other key", f.loneKey, nil, http.StatusForbidden},
+		{"unrelated user", nil, f.bob, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/chat/messages/"+msg.ID+"/feedback",
+				strings.NewReader(`{"rating": "down"}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: msg.ID}}
+			if tt.key != nil {
+				c.Set("api_key", tt.key)
+			}
+			if tt.user != nil {
+				c.Set("user", tt.user)
+			}
+			h.PostChatFeedback(c)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+// TestGraphQLBatchOwnership verifies the GraphQL batch query checks the
+// batch's items the same way GET /batches/:id does.
+func TestGraphQLBatchOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	h := &Handler{DB: f.db}
+
+	b := &models.Batch{Status: models.StatusPending, TotalCount: 1}
+	if err := f.db.CreateBatch(ctx, b); err != nil {
+		t.Fatalf("CreateBatch: %v", err)
+	}
+	tr := &models.Transcript{YouTubeURL: "https://youtu.be/abc", YouTubeID: "abc", Title: "Ada's talk", Status: models.StatusCompleted, APIKeyID: &f.adaKey.ID, BatchID: &b.ID}
+	if err := f.db.CreateTranscript(ctx, tr); err != nil {
+		t.Fatalf("CreateTranscript: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		key    *models.APIKey
+		user   *models.User
+		wantOK bool
+	}{
+		{"key that submitted the batch", f.adaKey, nil, true},
+		{"user linked to that key", nil, f.ada, true},
+		{"other key", f.loneKey, nil, false},
+		{"unrelated user", nil, f.bob, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			body := `{"query": "{ batch(id: \"` + b.ID + `\") { id transcripts { id } } }"}`
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.key != nil {
+				c.Set("api_key", tt.key)
+			}
+			if tt.user != nil {
+				c.Set("user", tt.user)
+			}
+			h.GraphQL(c)
+
+			var resp struct {
+				Data struct {
+					Batch *struct {
+						Transcripts []struct{ ID string } `json:"transcripts"`
+					} `json:"batch"`
+				} `json:"data"`
+				Errors []struct{ Message string } `json:"errors"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode %s: %v", w.Body, err)
+			}
+			if got := resp.Data.Batch != nil && len(resp.Errors) == 0; got != tt.wantOK {
+				t.Errorf("batch visible = %v, want %v: %s", got, tt.wantOK, w.Body)
+			}
+			if tt.wantOK && len(resp.Data.Batch.Transcripts) != 1 {
+				t.Errorf("transcripts = %v, want Ada's one", resp.Data.Batch.Transcripts)
+			}
+		})
+	}
+}
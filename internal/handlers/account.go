@@ -0,0 +1,150 @@
+// account.go handles account data portability and erasure: exporting
+// everything a user owns, and deleting the account after a grace period.
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+// ExportAccount queues a ZIP of all of the user's data: their profile, API
+// key metadata, workspace, chats, and every transcript, audio
+// transcription, and PDF extraction they own, as JSON. Poll and download it
+// like any export.
+// POST /api/v1/auth/me/export
+func (h *Handler) ExportAccount(c *gin.Context) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	items, err := h.DB.ListAccountItems(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("❌ Failed to list account items: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to look up account data",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	itemsJSON, _ := json.Marshal(items)
+	e := &models.Export{
+		UserID:    &user.ID,
+		Kind:      models.ExportKindAccount,
+		Status:    "pending",
+		Format:    "json",
+		Items:     itemsJSON,
+		ItemCount: len(items),
+	}
+	if err := h.DB.CreateExport(c.Request.Context(), e); err != nil {
+		log.Printf("❌ Failed to create account export: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create export record",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	job := worker.Job{
+		ID:        e.ID,
+		Type:      worker.JobBulkExport,
+		CreatedAt: time.Now(),
+	}
+	if err := h.Worker.Submit(job); err != nil {
+		log.Printf("Failed to queue account export job %s: %v", e.ID, err)
+		e.Status = "failed"
+		e.ErrorMessage = "Job queue is full, please try again later"
+		h.DB.UpdateExport(c.Request.Context(), e)
+
+		c.JSON(http.StatusServiceUnavailable, h.queueFull(c))
+		return
+	}
+
+	h.setQueueHeaders(c)
+	c.JSON(http.StatusAccepted, e)
+}
+
+// DeleteAccount schedules the user's account for erasure once the grace
+// period passes. Until then everything keeps working and the deletion can
+// be cancelled; afterwards the account, its API keys, and everything they
+// own are permanently deleted. Repeat requests keep the original schedule.
+// DELETE /api/v1/auth/me
+func (h *Handler) DeleteAccount(c *gin.Context) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	at, err := h.DB.ScheduleUserDeletion(c.Request.Context(), user.ID, time.Now().Add(h.Worker.DeletionGrace()))
+	if err != nil {
+		log.Printf("❌ Failed to schedule account deletion: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to schedule account deletion",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.AccountDeletionResponse{
+		Message:             "Account scheduled for deletion; cancel before then to keep it",
+		DeletionScheduledAt: at,
+	})
+}
+
+// CancelAccountDeletion cancels a pending account deletion.
+// POST /api/v1/auth/me/cancel-deletion
+func (h *Handler) CancelAccountDeletion(c *gin.Context) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	cancelled, err := h.DB.CancelUserDeletion(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("❌ Failed to cancel account deletion: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to cancel account deletion",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if !cancelled {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_scheduled",
+			Message: "Account deletion is not scheduled",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	user.DeletionScheduledAt = nil
+	c.JSON(http.StatusOK, user)
+}
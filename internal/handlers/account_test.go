@@ -0,0 +1,80 @@
+// account_test.go contains tests for account export and deletion handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestAccountHandlersRequireUser verifies the account endpoints reject
+// requests without a logged-in user before touching the database.
+func TestAccountHandlersRequireUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	handlers := map[string]gin.HandlerFunc{
+		"export":          h.ExportAccount,
+		"delete":          h.DeleteAccount,
+		"cancel deletion": h.CancelAccountDeletion,
+	}
+	for name, handle := range handlers {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/me", nil)
+
+		handle(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, w.Code)
+		}
+	}
+}
+
+// TestCanAccessExport verifies item exports follow the owning key or
+// user, and account exports are only visible to their user via JWT.
+func TestCanAccessExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key1, user1 := "key-1", "user-1"
+
+	tests := []struct {
+		name   string
+		export models.Export
+		key    *models.APIKey
+		user   *models.User
+		want   bool
+	}{
+		{name: "own key", export: models.Export{APIKeyID: &key1}, key: &models.APIKey{ID: "key-1"}, want: true},
+		{name: "other key", export: models.Export{APIKeyID: &key1}, key: &models.APIKey{ID: "key-2"}, want: false},
+		{name: "own user", export: models.Export{UserID: &user1}, user: &models.User{ID: "user-1"}, want: true},
+		{name: "other user", export: models.Export{UserID: &user1}, user: &models.User{ID: "user-2"}, want: false},
+		{name: "account, own user", export: models.Export{Kind: models.ExportKindAccount, UserID: &user1}, user: &models.User{ID: "user-1"}, want: true},
+		{name: "account, other user", export: models.Export{Kind: models.ExportKindAccount, UserID: &user1}, user: &models.User{ID: "user-2"}, want: false},
+		{
+			name:   "account, api key",
+			export: models.Export{Kind: models.ExportKindAccount, UserID: &user1},
+			key:    &models.APIKey{ID: "key-1", UserID: &user1},
+			want:   false,
+		},
+		{name: "account, erased user", export: models.Export{Kind: models.ExportKindAccount}, user: &models.User{ID: "user-1"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			// The auth middleware's context keys
+			if tt.key != nil {
+				c.Set("api_key", tt.key)
+			}
+			if tt.user != nil {
+				c.Set("user", tt.user)
+			}
+			if got := canAccessExport(c, &tt.export); got != tt.want {
+				t.Errorf("canAccessExport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// admin.go serves system-wide operational views behind the admin key, so
+// operators can check queue depth, per-key volume, failures, webhook health,
+// and dependencies without direct database access.
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// AdminOverview returns worker queue stats and item counts by status.
+// GET /api/v1/admin/overview
+func (h *Handler) AdminOverview(c *gin.Context) {
+	counts, err := h.DB.CountItemsByStatus(c.Request.Context())
+	if err != nil {
+		log.Printf("❌ Failed to count items: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to count items",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AdminOverview{
+		Queue: models.QueueStats{
+			Depth:    h.Worker.QueueSize(),
+			Capacity: h.Worker.QueueCapacity(),
+			Workers:  h.Worker.WorkerCount(),
+		},
+		Items: counts,
+	})
+}
+
+// AdminListKeyVolumes returns every API key with its processing volume.
+// GET /api/v1/admin/keys
+func (h *Handler) AdminListKeyVolumes(c *gin.Context) {
+	volumes, err := h.DB.ListAPIKeyVolumes(c.Request.Context())
+	if err != nil {
+		log.Printf("❌ Failed to list API key volumes: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list API key volumes",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if volumes == nil {
+		volumes = []models.APIKeyVolume{}
+	}
+
+	c.JSON(http.StatusOK, volumes)
+}
+
+// AdminListFailures returns recently failed items with their errors.
+// GET /api/v1/admin/failures?limit=50
+func (h *Handler) AdminListFailures(c *gin.Context) {
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 200 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_params",
+				Message: "limit must be between 1 and 200",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		limit = n
+	}
+
+	failures, err := h.DB.ListRecentFailures(c.Request.Context(), limit)
+	if err != nil {
+		log.Printf("❌ Failed to list failures: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list failures",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if failures == nil {
+		failures = []models.FailedItem{}
+	}
+
+	c.JSON(http.StatusOK, failures)
+}
+
+// AdminWebhookHealth returns every webhook's delivery outcomes over the last
+// 24 hours.
+// GET /api/v1/admin/webhooks
+func (h *Handler) AdminWebhookHealth(c *gin.Context) {
+	health, err := h.DB.ListWebhookHealth(c.Request.Context())
+	if err != nil {
+		log.Printf("❌ Failed to list webhook health: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list webhook health",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if health == nil {
+		health = []models.WebhookHealth{}
+	}
+
+	c.JSON(http.StatusOK, health)
+}
+
+// AdminDependencies returns the same dependency probes as the deep health
+// check.
+// GET /api/v1/admin/dependencies
+func (h *Handler) AdminDependencies(c *gin.Context) {
+	c.JSON(http.StatusOK, h.probeDependencies(c.Request.Context()))
+}
+
+// AdminChatFeedback returns chat answer counts and ratings by model and
+// item type over the last days (default 30).
+// GET /api/v1/admin/chat-feedback?days=30
+func (h *Handler) AdminChatFeedback(c *gin.Context) {
+	days := 30
+	if v := c.Query("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 365 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_params",
+				Message: "days must be between 1 and 365",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		days = n
+	}
+
+	stats, err := h.DB.ListChatFeedbackStats(c.Request.Context(), time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		log.Printf("❌ Failed to list chat feedback stats: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list chat feedback",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if stats == nil {
+		stats = []models.ChatFeedbackStats{}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
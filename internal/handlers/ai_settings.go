@@ -0,0 +1,265 @@
+// ai_settings.go lets API keys and users save defaults for AI requests —
+// model, summary length and style, and output language — used whenever a
+// request leaves those options out.
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// aiLanguage matches an output language name, e.g. "French" or
+// "Portuguese (Brazil)".
+var aiLanguage = regexp.MustCompile(`^[\p{L} ()-]{1,40}$`)
+
+var (
+	validSummaryLengths = map[string]bool{"short": true, "medium": true, "detailed": true}
+	validSummaryStyles  = map[string]bool{"bullet": true, "narrative": true, "academic": true}
+)
+
+// GetKeyAISettings returns the AI defaults of the caller's own API key, or
+// of a key it manages (see ListAPIKeys).
+// GET /api/v1/keys/:id/settings
+func (h *Handler) GetKeyAISettings(c *gin.Context) {
+	keyID := c.Param("id")
+	if !h.canEditKeySettings(c, keyID) {
+		return
+	}
+
+	settings, err := h.DB.GetKeyAISettings(c.Request.Context(), keyID)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to get AI settings",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateKeyAISettings changes the AI defaults of the caller's own API key,
+// or of a key it manages. They take precedence over the key's user's.
+// PATCH /api/v1/keys/:id/settings
+func (h *Handler) UpdateKeyAISettings(c *gin.Context) {
+	keyID := c.Param("id")
+	if !h.canEditKeySettings(c, keyID) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	settings, err := h.DB.GetKeyAISettings(ctx, keyID)
+	if err == nil {
+		if !h.bindAISettings(c, &settings) {
+			return
+		}
+		err = h.DB.SaveKeyAISettings(ctx, keyID, settings)
+	}
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save AI settings",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// GetMyAISettings returns the logged-in user's AI defaults.
+// GET /api/v1/me/settings
+func (h *Handler) GetMyAISettings(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.DB.GetUserAISettings(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to get AI settings",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateMyAISettings changes the logged-in user's AI defaults, which apply
+// to their session and to their API keys without settings of their own.
+// PATCH /api/v1/me/settings
+func (h *Handler) UpdateMyAISettings(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	settings, err := h.DB.GetUserAISettings(ctx, user.ID)
+	if err == nil {
+		if !h.bindAISettings(c, &settings) {
+			return
+		}
+		err = h.DB.SaveUserAISettings(ctx, user.ID, settings)
+	}
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save AI settings",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// canEditKeySettings reports whether the caller may see and change a key's
+// AI settings: its own key, any key for an unscoped admin key, its
+// organization's keys for a scoped one, and for a user their own keys and
+// those of organizations they administer. Otherwise it writes a 404.
+func (h *Handler) canEditKeySettings(c *gin.Context, keyID string) bool {
+	ctx := c.Request.Context()
+	allowed := false
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		if apiKey.ID == keyID {
+			return true
+		}
+		if middleware.KeyRole(apiKey, h.OwnerAPIKeyID, h.OwnerAPIKeyPrefix) == models.KeyRoleAdmin {
+			keyOrgID, err := h.DB.GetAPIKeyOrgID(ctx, keyID)
+			managed := h.managedOrgID(apiKey)
+			allowed = err == nil && (managed == nil || (keyOrgID != nil && *keyOrgID == *managed))
+		}
+	} else if user := middleware.GetUser(c); user != nil {
+		ok, err := h.DB.UserManagesAPIKey(ctx, user.ID, keyID)
+		if err != nil {
+			log.Printf("❌ %v", err)
+		}
+		allowed = ok
+	}
+	if !allowed {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+	}
+	return allowed
+}
+
+// bindAISettings applies an update request to settings. On bad input it
+// writes a 400 and returns false.
+func (h *Handler) bindAISettings(c *gin.Context, settings *models.AISettings) bool {
+	var req models.UpdateAISettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return false
+	}
+	applyAISettings(settings, req)
+
+	modelAllowed := func(string) bool { return true }
+	if h.Summarizer != nil {
+		modelAllowed = h.Summarizer.ModelAllowed
+	}
+	if msg := validateAISettings(*settings, modelAllowed); msg != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_settings",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		})
+		return false
+	}
+	return true
+}
+
+// applyAISettings copies the fields an update request sets onto settings.
+func applyAISettings(settings *models.AISettings, req models.UpdateAISettingsRequest) {
+	if req.Model != nil {
+		settings.Model = *req.Model
+	}
+	if req.Length != nil {
+		settings.Length = *req.Length
+	}
+	if req.Style != nil {
+		settings.Style = *req.Style
+	}
+	if req.Language != nil {
+		settings.Language = *req.Language
+	}
+}
+
+// validateAISettings returns a client-facing message if settings are
+// invalid, or "". Empty fields are always valid.
+func validateAISettings(s models.AISettings, modelAllowed func(string) bool) string {
+	switch {
+	case s.Model != "" && !modelAllowed(s.Model):
+		return "model \"" + s.Model + "\" isn't available; GET /api/v1/models lists the models you can use"
+	case s.Length != "" && !validSummaryLengths[s.Length]:
+		return "length must be short, medium, or detailed"
+	case s.Style != "" && !validSummaryStyles[s.Style]:
+		return "style must be bullet, narrative, or academic"
+	case s.Language != "" && !aiLanguage.MatchString(s.Language):
+		return "language must be a language name of at most 40 letters, e.g. \"French\""
+	}
+	return ""
+}
+
+// aiDefaults returns the caller's saved AI defaults: its key's settings,
+// falling back to its user's. A saved model that's since left the
+// catalog is dropped, and lookup failures are logged and treated as no
+// defaults, so settings never break a request.
+func (h *Handler) aiDefaults(c *gin.Context) models.AISettings {
+	var keyID, userID *string
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		keyID, userID = &apiKey.ID, apiKey.UserID
+	} else if user := middleware.GetUser(c); user != nil {
+		userID = &user.ID
+	}
+	if h.DB == nil || (keyID == nil && userID == nil) {
+		return models.AISettings{}
+	}
+
+	settings, err := h.DB.ResolveAISettings(c.Request.Context(), keyID, userID)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return models.AISettings{}
+	}
+	if settings.Model != "" && h.Summarizer != nil && !h.Summarizer.ModelAllowed(settings.Model) {
+		settings.Model = ""
+	}
+	return settings
+}
+
+// fillDefault sets *field to def when the request left it empty.
+func fillDefault(field *string, def string) {
+	if *field == "" {
+		*field = def
+	}
+}
+
+// languageError returns a 400 error if a request's output language isn't
+// a plausible language name, or nil.
+func languageError(language string) *models.ErrorResponse {
+	if language == "" || aiLanguage.MatchString(language) {
+		return nil
+	}
+	return &models.ErrorResponse{
+		Error:   "invalid_language",
+		Message: "language must be a language name of at most 40 letters, e.g. \"French\"",
+		Code:    http.StatusBadRequest,
+	}
+}
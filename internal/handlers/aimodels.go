@@ -0,0 +1,39 @@
+// aimodels.go lists the AI models clients may pick and rejects model
+// overrides outside that list.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// ListModels returns the models requests may pick, with names, context
+// sizes, and pricing. The default model is flagged.
+// GET /api/v1/models
+func (h *Handler) ListModels(c *gin.Context) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusOK, []summary.ModelInfo{})
+		return
+	}
+	c.JSON(http.StatusOK, h.Summarizer.Catalog(c.Request.Context()))
+}
+
+// modelError returns a 400 error if a request's model or fallback
+// overrides aren't in the model catalog, or nil.
+func (h *Handler) modelError(model string, fallbacks []string) *models.ErrorResponse {
+	if h.Summarizer == nil {
+		return nil
+	}
+	if err := h.Summarizer.CheckModels(model, fallbacks); err != nil {
+		return &models.ErrorResponse{
+			Error:   "invalid_model",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
@@ -4,10 +4,12 @@ package handlers
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
@@ -19,9 +21,12 @@ import (
 // Security: This endpoint requires the X-Admin-Key header in production.
 // In development (when ADMIN_API_KEY is not set), the endpoint is open for bootstrapping.
 //
-// Request body:
+// Request body (tier is "free", "pro", or "enterprise", defaulting to the
+// default_tier setting; role is "admin" or "member", the default; the
+// optional allowlists restrict where the key can be used from):
 //
-//	{"name": "My App", "rate_limit": 200}
+//	{"name": "My App", "tier": "pro", "role": "member",
+//	 "allowed_origins": ["https://app.example.com"], "allowed_ips": ["203.0.113.0/24"]}
 //
 // Response includes the raw key — SAVE IT! It's only shown once.
 func (h *Handler) CreateAPIKey(c *gin.Context) {
@@ -50,16 +55,17 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "invalid_request",
-			Message: "name is required",
+			Message: "name is required; tier must be free, pro, or enterprise; role must be admin or member",
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
+	origins, ips, ok := bindKeyRestrictions(c, req.AllowedOrigins, req.AllowedIPs)
+	if !ok {
+		return
+	}
 
-	// Generate a secure random API key
-	// Go Pattern: crypto/rand is the cryptographically secure random source.
-	// NEVER use math/rand for security-sensitive things like API keys!
-	rawKey, err := generateAPIKey()
+	key, rawKey, err := newAPIKey(req, h.Settings.Current().DefaultTier)
 	if err != nil {
 		log.Printf("❌ Failed to generate API key: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -69,21 +75,7 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 		})
 		return
 	}
-
-	// Set default rate limit if not specified
-	rateLimit := req.RateLimit
-	if rateLimit <= 0 {
-		rateLimit = 100 // Default: 100 requests/hour
-	}
-
-	// Create the key record with the HASH (never store the raw key)
-	key := &models.APIKey{
-		KeyHash:   middleware.HashAPIKey(rawKey),
-		KeyPrefix: rawKey[:8] + "...", // Show first 8 chars for identification
-		Name:      req.Name,
-		Active:    true,
-		RateLimit: rateLimit,
-	}
+	key.AllowedOrigins, key.AllowedIPs = origins, ips
 
 	if err := h.DB.CreateAPIKey(c.Request.Context(), key); err != nil {
 		log.Printf("❌ Failed to create API key: %v", err)
@@ -102,11 +94,27 @@ func (h *Handler) CreateAPIKey(c *gin.Context) {
 	})
 }
 
-// ListAPIKeys returns all API keys (without the raw key values).
+// ListAPIKeys returns the API keys the caller manages (without the raw key
+// values): every key for an admin key, the organization's keys for an
+// organization's admin key, and the keys of each organization a user is an
+// owner or admin of. The route requires the admin role.
 // GET /api/v1/keys
 func (h *Handler) ListAPIKeys(c *gin.Context) {
-	keys, err := h.DB.ListAPIKeys(c.Request.Context())
+	ctx := c.Request.Context()
+
+	var keys []models.APIKey
+	var err error
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		if orgID := h.managedOrgID(apiKey); orgID != nil {
+			keys, err = h.DB.ListOrgAPIKeys(ctx, *orgID)
+		} else {
+			keys, err = h.DB.ListAPIKeys(ctx)
+		}
+	} else if user := middleware.GetUser(c); user != nil {
+		keys, err = h.DB.ListAdministeredAPIKeys(ctx, user.ID)
+	}
 	if err != nil {
+		log.Printf("❌ %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "database_error",
 			Message: "Failed to list API keys",
@@ -122,12 +130,24 @@ func (h *Handler) ListAPIKeys(c *gin.Context) {
 	c.JSON(http.StatusOK, keys)
 }
 
-// RevokeAPIKey deactivates an API key.
+// RevokeAPIKey deactivates an API key the caller manages (see ListAPIKeys).
+// Keys outside the caller's reach are reported as not found.
 // DELETE /api/v1/keys/:id
 func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
 	id := c.Param("id")
 
-	if err := h.DB.RevokeAPIKey(c.Request.Context(), id); err != nil {
+	var err error
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		if orgID := h.managedOrgID(apiKey); orgID != nil {
+			err = h.DB.RevokeOrgAPIKey(ctx, *orgID, id)
+		} else {
+			err = h.DB.RevokeAPIKey(ctx, id)
+		}
+	} else if user := middleware.GetUser(c); user != nil {
+		err = h.DB.RevokeAdministeredAPIKey(ctx, user.ID, id)
+	}
+	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Error:   "not_found",
 			Message: "API key not found",
@@ -139,6 +159,104 @@ func (h *Handler) RevokeAPIKey(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
 }
 
+// SetAPIKeyRestrictions replaces the origin and IP allowlists of an API key
+// the caller manages (see ListAPIKeys). Empty lists lift a restriction.
+// PUT /api/v1/keys/:id/restrictions
+func (h *Handler) SetAPIKeyRestrictions(c *gin.Context) {
+	var req models.KeyRestrictionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "allowed_origins and allowed_ips must be lists",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	origins, ips, ok := bindKeyRestrictions(c, req.AllowedOrigins, req.AllowedIPs)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var key *models.APIKey
+	err := fmt.Errorf("not authenticated")
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		if orgID := h.managedOrgID(apiKey); orgID != nil {
+			key, err = h.DB.SetOrgAPIKeyRestrictions(ctx, *orgID, id, origins, ips)
+		} else {
+			key, err = h.DB.SetAPIKeyRestrictions(ctx, id, origins, ips)
+		}
+	} else if user := middleware.GetUser(c); user != nil {
+		key, err = h.DB.SetAdministeredAPIKeyRestrictions(ctx, user.ID, id, origins, ips)
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// bindKeyRestrictions validates and normalizes a key's allowlists. On bad
+// input it writes a 400 and returns false.
+func bindKeyRestrictions(c *gin.Context, origins, ips []string) (pq.StringArray, pq.StringArray, bool) {
+	normOrigins, normIPs, err := middleware.NormalizeKeyRestrictions(origins, ips)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_restrictions",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return nil, nil, false
+	}
+	return normOrigins, normIPs, true
+}
+
+// managedOrgID returns the organization an admin key's management is limited
+// to, or nil when it manages every key. The owner key is never limited.
+func (h *Handler) managedOrgID(apiKey *models.APIKey) *string {
+	if middleware.IsOwnerAPIKey(apiKey, h.OwnerAPIKeyID, h.OwnerAPIKeyPrefix) {
+		return nil
+	}
+	return apiKey.OrgID
+}
+
+// newAPIKey generates a key for a create request, returning the record to
+// store (with only the key's HASH — never the raw key) and the raw key to
+// show once. Keys get defaultTier unless the request picks one.
+func newAPIKey(req models.CreateAPIKeyRequest, defaultTier string) (*models.APIKey, string, error) {
+	// Go Pattern: crypto/rand is the cryptographically secure random source.
+	// NEVER use math/rand for security-sensitive things like API keys!
+	rawKey, err := generateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	tier := req.Tier
+	if tier == "" {
+		tier = defaultTier // Adjustable by admins
+	}
+
+	key := &models.APIKey{
+		KeyHash:   middleware.HashAPIKey(rawKey),
+		KeyPrefix: rawKey[:8] + "...", // Show first 8 chars for identification
+		Name:      req.Name,
+		Active:    true,
+		Tier:      tier,
+		Role:      req.Role,
+	}
+	if key.Role == "" {
+		key.Role = models.KeyRoleMember
+	}
+	return key, rawKey, nil
+}
+
 // generateAPIKey creates a cryptographically secure random API key.
 // Format: "mta_" prefix + 32 random hex characters = 36 chars total.
 // The prefix makes it easy to identify keys from this service.
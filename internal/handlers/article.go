@@ -0,0 +1,225 @@
+// article.go handles long-form article drafts written from transcripts —
+// title options, headed sections, pull quotes — and their export.
+//
+// Drafts are generated once (POST) and stored, then rendered on demand
+// (GET) as Markdown or Word, like meeting minutes.
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// CreateArticle writes an article draft from a completed transcript.
+// POST /api/v1/transcripts/:id/article
+//
+// Generation is synchronous; long transcripts take one AI call per chunk
+// plus one for the article. The response is the stored draft.
+func (h *Handler) CreateArticle(c *gin.Context) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.CreateArticleRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	t, ok := h.loadCompletedTranscript(c, "write articles from")
+	if !ok {
+		return
+	}
+
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	draft, err := h.Summarizer.GenerateArticle(ctx, t.Title, t.TranscriptText, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Article generation failed for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to write article: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	content, _ := json.Marshal(draft)
+	article := &models.Article{
+		TranscriptID: t.ID,
+		ModelUsed:    draft.Model,
+		Content:      content,
+	}
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		article.APIKeyID = &apiKey.ID
+	}
+
+	if err := h.DB.CreateArticle(c.Request.Context(), article); err != nil {
+		log.Printf("Failed to save article for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save article",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, article)
+}
+
+// GetArticle returns or downloads the latest article draft for a transcript.
+// GET /api/v1/transcripts/:id/article?format=json|md|docx
+func (h *Handler) GetArticle(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	validFormats := map[string]bool{"json": true, "md": true, "docx": true}
+	if !validFormats[format] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Supported formats: json, md, docx",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	t, ok := h.loadCompletedTranscript(c, "read articles from")
+	if !ok {
+		return
+	}
+
+	article, err := h.DB.GetLatestArticle(c.Request.Context(), t.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "No article has been generated for this transcript",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		log.Printf("Failed to load article for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load article",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, article)
+		return
+	}
+
+	var draft summary.Article
+	if err := json.Unmarshal(article.Content, &draft); err != nil {
+		log.Printf("Invalid article content for %s: %v", article.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "export_failed",
+			Message: "Failed to render article",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	doc := buildArticleDocument(t, &draft)
+
+	var (
+		data        []byte
+		contentType string
+	)
+	switch format {
+	case "md":
+		data, contentType = doc.Markdown(), "text/markdown; charset=utf-8"
+	case "docx":
+		data, err = doc.DOCX()
+		contentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	}
+	if err != nil {
+		log.Printf("Failed to render article %s as %s: %v", article.ID, format, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "export_failed",
+			Message: "Failed to render article",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	filename := sanitizeFilename(t.Title)
+	if filename == "" {
+		filename = t.YouTubeID
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s - article.%s"`, filename, format))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// buildArticleDocument lays out a draft under its first title option, with
+// the alternatives and the source noted up front for the editor.
+func buildArticleDocument(t *models.Transcript, a *summary.Article) *document.Document {
+	title := t.Title
+	if len(a.TitleOptions) > 0 {
+		title = a.TitleOptions[0]
+	}
+
+	doc := document.New(title)
+	if len(a.TitleOptions) > 1 {
+		doc.Field("Other titles", strings.Join(a.TitleOptions[1:], " / "))
+	}
+	doc.Field("Source", t.YouTubeURL)
+
+	if a.Introduction != "" {
+		doc.Paragraph(a.Introduction)
+	}
+	for _, sec := range a.Sections {
+		if sec.Heading != "" {
+			doc.Heading(sec.Heading)
+		}
+		for _, p := range sec.Paragraphs {
+			doc.Paragraph(p)
+		}
+	}
+	if a.Conclusion != "" {
+		doc.Heading("Conclusion").Paragraph(a.Conclusion)
+	}
+	if len(a.PullQuotes) > 0 {
+		quotes := make([]string, len(a.PullQuotes))
+		for i, q := range a.PullQuotes {
+			quotes[i] = "“" + q + "”"
+		}
+		doc.Heading("Pull Quotes").Bullets(quotes)
+	}
+	return doc
+}
@@ -0,0 +1,43 @@
+// article_test.go tests laying out article drafts for export.
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+func TestBuildArticleDocument(t *testing.T) {
+	tr := &models.Transcript{Title: "Talk", YouTubeURL: "https://youtu.be/abc"}
+	draft := &summary.Article{
+		TitleOptions: []string{"Delete More Code", "Is Less Code Better?"},
+		Introduction: "Intro paragraph.",
+		Sections:     []summary.ArticleSection{{Heading: "Why", Paragraphs: []string{"First.", "Second."}}},
+		PullQuotes:   []string{"The best code is the code you delete."},
+		Conclusion:   "Closing.",
+	}
+
+	md := string(buildArticleDocument(tr, draft).Markdown())
+	for _, want := range []string{
+		"# Delete More Code",
+		"Is Less Code Better?",
+		"https://youtu.be/abc",
+		"## Why",
+		"Second.",
+		"## Conclusion",
+		"“The best code is the code you delete.”",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown is missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Index(md, "Intro paragraph.") > strings.Index(md, "## Why") {
+		t.Error("introduction should come before the first section")
+	}
+
+	if md := string(buildArticleDocument(tr, &summary.Article{}).Markdown()); !strings.Contains(md, "# Talk") {
+		t.Errorf("draft without title options should use the video title:\n%s", md)
+	}
+}
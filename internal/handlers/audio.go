@@ -2,27 +2,30 @@
 //
 // POST /api/v1/audio/transcribe — Upload audio file for Whisper transcription
 // GET  /api/v1/audio/transcriptions/:id — Get transcription result by ID
-// GET  /api/v1/audio/transcriptions — List recent transcriptions
+// GET  /api/v1/audio/transcriptions — List transcriptions (paginated)
 package handlers
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"math"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
 )
@@ -45,6 +48,8 @@ const maxAudioSize = 25 << 20 // 25MB
 //
 // Accepts multipart file upload with field name "file".
 // Supported formats: mp3, wav, m4a, ogg, flac, webm
+// Optional title, notes, and metadata (a JSON object of string values)
+// fields describe the recording, as PATCH would.
 //
 // Returns 202 Accepted immediately with the transcription record.
 // Frontend should poll GET /api/v1/audio/transcriptions/:id for completion.
@@ -60,60 +65,59 @@ func (h *Handler) TranscribeAudio(c *gin.Context) {
 		return
 	}
 
-	// Get the uploaded file
-	file, header, err := c.Request.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "No audio file provided. Upload a file with the field name 'file'. Max size: 25MB.",
-			Code:    http.StatusBadRequest,
-		})
+	// Stream the upload to disk, checking the format before reading it
+	up, apiErr := receiveUpload(c, "audio", maxAudioSize, func(filename string) *models.ErrorResponse {
+		if ext := strings.ToLower(filepath.Ext(filename)); !allowedAudioTypes[ext] {
+			return &models.ErrorResponse{
+				Error:   "invalid_file_type",
+				Message: fmt.Sprintf("Unsupported audio format '%s'. Supported formats: mp3, wav, m4a, ogg, flac, webm", ext),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		return nil
+	})
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
 		return
 	}
-	defer file.Close()
 
-	// Check file size (25MB limit for Whisper API)
-	if header.Size > maxAudioSize {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "file_too_large",
-			Message: fmt.Sprintf("File size (%.1f MB) exceeds maximum (25 MB).", float64(header.Size)/(1024*1024)),
-			Code:    http.StatusBadRequest,
-		})
+	timeoutSeconds := 0
+	if v := up.Fields["timeout_seconds"]; v != "" {
+		var err error
+		if timeoutSeconds, err = strconv.Atoi(v); err != nil {
+			timeoutSeconds = -1 // Rejected by jobTimeout below
+		}
+	}
+	timeout, apiErr := h.jobTimeout(timeoutSeconds)
+	if apiErr != nil {
+		up.remove()
+		c.JSON(http.StatusBadRequest, *apiErr)
 		return
 	}
-
-	// Validate file extension
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if !allowedAudioTypes[ext] {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_file_type",
-			Message: fmt.Sprintf("Unsupported audio format '%s'. Supported formats: mp3, wav, m4a, ogg, flac, webm", ext),
-			Code:    http.StatusBadRequest,
-		})
+	redacted, apiErr := parseRedactFlag(up.Fields["redact"])
+	if apiErr != nil {
+		up.remove()
+		c.JSON(apiErr.Code, *apiErr)
 		return
 	}
-
-	// Generate unique identifiers
-	storedFilename := uuid.New().String() + ext
-
-	// Save the uploaded file to a temp location for async processing
-	tempDir := os.TempDir()
-	tempFilePath := filepath.Join(tempDir, storedFilename)
-
-	tempFile, err := os.Create(tempFilePath)
-	if err != nil {
-		log.Printf("Failed to create temp file: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "server_error",
-			Message: "Failed to process uploaded file",
-			Code:    http.StatusInternalServerError,
-		})
+	opts, apiErr := uploadTranscribeOptions(up.Fields)
+	if apiErr != nil {
+		up.remove()
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	details, apiErr := uploadAudioDetails(up.Fields)
+	if apiErr != nil {
+		up.remove()
+		c.JSON(apiErr.Code, *apiErr)
 		return
 	}
 
-	if _, err := io.Copy(tempFile, file); err != nil {
-		tempFile.Close()
-		os.Remove(tempFilePath)
+	// Name the temp file after the stored filename for async processing
+	storedFilename := uuid.New().String() + strings.ToLower(filepath.Ext(up.Filename))
+	tempFilePath := filepath.Join(filepath.Dir(up.Path), storedFilename)
+	if err := os.Rename(up.Path, tempFilePath); err != nil {
+		up.remove()
 		log.Printf("Failed to save temp file: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "server_error",
@@ -122,8 +126,23 @@ func (h *Handler) TranscribeAudio(c *gin.Context) {
 		})
 		return
 	}
-	tempFile.Close()
 
+	at, apiErr := h.queueAudio(c, tempFilePath, storedFilename, up.Filename, up.Size, timeout, redacted, opts, details, nil)
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	// Return 202 Accepted — frontend should poll for completion
+	c.JSON(http.StatusAccepted, at)
+}
+
+// queueAudio creates a pending transcription for an upload saved at
+// tempFilePath and queues it, in batchID's batch if that's set. The temp
+// file is removed if it can't be queued; the transcription, saved as
+// failed, is still returned with the queue_full error. Shared by direct,
+// resumable, and batch uploads.
+func (h *Handler) queueAudio(c *gin.Context, tempFilePath, storedFilename, originalName string, size int64, timeout time.Duration, redacted bool, opts audio.TranscribeOptions, details audioDetails, batchID *string) (*models.AudioTranscription, *models.ErrorResponse) {
 	// Get the API key from context (set by auth middleware)
 	var apiKeyID *string
 	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
@@ -133,39 +152,44 @@ func (h *Handler) TranscribeAudio(c *gin.Context) {
 	// Create a pending record in the database
 	at := &models.AudioTranscription{
 		Filename:     storedFilename,
-		OriginalName: header.Filename,
+		OriginalName: originalName,
 		Status:       "pending",
 		APIKeyID:     apiKeyID,
+		LanguageHint: opts.Language,
+		Title:        details.Title,
+		Notes:        details.Notes,
+		Metadata:     details.Metadata,
+		BatchID:      batchID,
 	}
 
 	if err := h.DB.CreateAudioTranscription(c.Request.Context(), at); err != nil {
 		os.Remove(tempFilePath) // Clean up temp file on error
 		log.Printf("Failed to create audio transcription record: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "database_error",
 			Message: "Failed to create transcription record",
 			Code:    http.StatusInternalServerError,
-		})
-		return
+		}
 	}
 
 	// Create the job payload
 	payload := worker.AudioPayload{
 		AudioID:      at.ID,
 		TempFilePath: tempFilePath,
-		OriginalName: header.Filename,
+		OriginalName: originalName,
+		Options:      opts,
+		Redact:       redacted,
 	}
 
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		os.Remove(tempFilePath)
 		log.Printf("Failed to marshal audio payload: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "server_error",
 			Message: "Failed to queue transcription job",
 			Code:    http.StatusInternalServerError,
-		})
-		return
+		}
 	}
 
 	// Submit the job to the worker pool
@@ -173,6 +197,8 @@ func (h *Handler) TranscribeAudio(c *gin.Context) {
 		ID:        at.ID,
 		Type:      worker.JobAudioTranscription,
 		Payload:   payloadJSON,
+		Timeout:   timeout,
+		Priority:  jobPriority(c),
 		CreatedAt: time.Now(),
 	}
 
@@ -182,9 +208,9 @@ func (h *Handler) TranscribeAudio(c *gin.Context) {
 			defer cancel()
 			if err := h.Worker.SubmitBlocking(ctx, job); err == nil {
 				log.Printf("📤 Audio transcription job queued (blocking): %s (%s, %.1f MB)",
-					at.ID, header.Filename, float64(header.Size)/(1024*1024))
-				c.JSON(http.StatusAccepted, at)
-				return
+					at.ID, originalName, float64(size)/(1024*1024))
+				h.setQueueHeaders(c)
+				return at, nil
 			}
 		}
 
@@ -193,23 +219,19 @@ func (h *Handler) TranscribeAudio(c *gin.Context) {
 		at.ErrorMessage = "Job queue is full, please try again later"
 		h.DB.UpdateAudioTranscription(c.Request.Context(), at)
 
-		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
-			Error:   "queue_full",
-			Message: "Server is busy. Please try again in a moment.",
-			Code:    http.StatusServiceUnavailable,
-		})
-		return
+		return at, h.queueFull(c)
 	}
 
 	log.Printf("📤 Audio transcription job queued: %s (%s, %.1f MB)",
-		at.ID, header.Filename, float64(header.Size)/(1024*1024))
-
-	// Return 202 Accepted — frontend should poll for completion
-	c.JSON(http.StatusAccepted, at)
+		at.ID, originalName, float64(size)/(1024*1024))
+	h.setQueueHeaders(c)
+	return at, nil
 }
 
 // GetAudioTranscription retrieves a single audio transcription by ID.
 // GET /api/v1/audio/transcriptions/:id
+//
+// Supports If-None-Match / If-Modified-Since; unchanged transcriptions get a 304.
 func (h *Handler) GetAudioTranscription(c *gin.Context) {
 	id := c.Param("id")
 
@@ -222,20 +244,54 @@ func (h *Handler) GetAudioTranscription(c *gin.Context) {
 		})
 		return
 	}
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, at)
+	writeConditionalJSON(c, at, at.UpdatedAt)
 }
 
-// ListAudioTranscriptions returns recent audio transcriptions for the authenticated API key.
-// GET /api/v1/audio/transcriptions
+// ListAudioTranscriptions returns a paginated list of audio transcriptions.
+// GET /api/v1/audio/transcriptions?page=1&per_page=20&status=completed&content_type=meeting
+//
+// Takes the same paging, search, date range, and sort parameters as
+// GET /transcripts; sort_by is created_at, duration, or word_count. Add
+// format=csv for a spreadsheet export. Transcript text and segments are
+// omitted unless ?include=text is passed.
 func (h *Handler) ListAudioTranscriptions(c *gin.Context) {
-	// Get the API key from context to filter by owner
-	var apiKeyID *string
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		apiKeyID = &apiKey.ID
+	var params models.AudioListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid query parameters: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Filter by the authenticated API key, or the one an admin key names
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
 	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+	params.IncludeText = includeText(c)
 
-	transcriptions, err := h.DB.ListAudioTranscriptions(c.Request.Context(), 50, apiKeyID)
+	if c.Query("format") == "csv" {
+		h.exportAudioCSV(c, params)
+		return
+	}
+
+	transcriptions, total, err := h.DB.ListAudioTranscriptions(c.Request.Context(), params)
+	if writeCursorError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to list audio transcriptions: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -250,7 +306,19 @@ func (h *Handler) ListAudioTranscriptions(c *gin.Context) {
 		transcriptions = []models.AudioTranscription{}
 	}
 
-	c.JSON(http.StatusOK, transcriptions)
+	perPage := params.PerPage
+	if perPage < 1 {
+		perPage = 20
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.AudioTranscription]{
+		Data:       transcriptions,
+		Page:       responsePage(params.Page, params.Cursor),
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+		NextCursor: database.NextAudioCursor(params, transcriptions),
+	})
 }
 
 // SummarizeAudio generates an AI summary for an audio transcription (MTA-22).
@@ -263,6 +331,9 @@ func (h *Handler) ListAudioTranscriptions(c *gin.Context) {
 //	  "model": "openai/gpt-4o",     // override AI model
 //	  "length": "medium"             // short, medium, detailed
 //	}
+//
+// When the transcript has two or more labelled speakers, speaker_summaries
+// also gets a section per speaker with their commitments and questions.
 func (h *Handler) SummarizeAudio(c *gin.Context) {
 	id := c.Param("id")
 
@@ -287,6 +358,15 @@ func (h *Handler) SummarizeAudio(c *gin.Context) {
 		return
 	}
 
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only summarize your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
 	if at.Status != "completed" {
 		c.JSON(http.StatusConflict, models.ErrorResponse{
 			Error:   "not_ready",
@@ -322,6 +402,22 @@ func (h *Handler) SummarizeAudio(c *gin.Context) {
 		})
 		return
 	}
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	fillDefault(&req.Length, defaults.Length)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
 
 	// Mark as processing
 	at.SummaryStatus = "processing"
@@ -330,12 +426,21 @@ func (h *Handler) SummarizeAudio(c *gin.Context) {
 
 	// Generate summary
 	opts := summary.Options{
-		Model:       req.Model,
-		Length:      req.Length,
-		ContentType: string(contentType),
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Length:         req.Length,
+		ContentType:    string(contentType),
+		Language:       req.Language,
 	}
 
-	result, err := h.Summarizer.SummarizeAudio(c.Request.Context(), at.TranscriptText, opts)
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	tally := &summary.Tally{}
+	ctx = summary.WithTally(ctx, tally)
+	start := time.Now()
+	result, err := h.Summarizer.SummarizeAudio(ctx, at.TranscriptText, opts)
 	if err != nil {
 		log.Printf("Audio summary failed for %s: %v", id, err)
 		at.SummaryStatus = "failed"
@@ -366,6 +471,18 @@ func (h *Handler) SummarizeAudio(c *gin.Context) {
 		decisionsJSON = []byte("[]")
 	}
 
+	// Per-speaker sections for diarized audio. They're an extra, so a failure
+	// here keeps the global summary rather than failing the request.
+	at.SpeakerSummaries = json.RawMessage(`[]`)
+	if labelled := speakerTranscript(at); summary.Speakers(labelled) != nil {
+		speakers, _, err := h.Summarizer.SummarizeSpeakers(ctx, labelled, opts)
+		if err != nil {
+			log.Printf("Speaker summaries failed for %s: %v", id, err)
+		} else if speakersJSON, err := json.Marshal(speakers); err == nil {
+			at.SpeakerSummaries = speakersJSON
+		}
+	}
+
 	// Update record
 	at.SummaryText = result.Summary
 	at.KeyPoints = keyPointsJSON
@@ -374,6 +491,7 @@ func (h *Handler) SummarizeAudio(c *gin.Context) {
 	at.SummaryModel = result.Model
 	at.SummaryStatus = "completed"
 	at.ContentType = contentType
+	at.Usage = withSummaryUsage(at.Usage, time.Since(start), tally.Usage())
 
 	if err := h.DB.UpdateAudioSummary(c.Request.Context(), at); err != nil {
 		log.Printf("Failed to save audio summary for %s: %v", id, err)
@@ -382,8 +500,160 @@ func (h *Handler) SummarizeAudio(c *gin.Context) {
 	c.JSON(http.StatusOK, at)
 }
 
+// withSummaryUsage adds an AI summary's time and tokens to an audio
+// transcription's usage, replacing those of any earlier summary.
+func withSummaryUsage(usage models.NullJSON, elapsed time.Duration, used summary.Usage) models.NullJSON {
+	var u models.ProcessingUsage
+	if len(usage) > 0 {
+		json.Unmarshal(usage, &u) // Keeps the transcription's time and minutes
+	}
+	u.SummaryMS = elapsed.Milliseconds()
+	u.PromptTokens, u.CompletionTokens, u.CostUSD = used.PromptTokens, used.CompletionTokens, used.CostUSD
+	return u.JSON()
+}
+
+// speakerTranscript returns the transcript with a "Speaker: text" line per
+// turn, built from diarized segments. Without speaker segments it returns the
+// stored text, which may already be labelled by hand.
+func speakerTranscript(at *models.AudioTranscription) string {
+	var sb strings.Builder
+	last := ""
+	for _, seg := range export.ParseSegments(at.Segments) {
+		if seg.Speaker == "" {
+			return at.TranscriptText
+		}
+		if seg.Speaker != last {
+			if last != "" {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(seg.Speaker + ":")
+			last = seg.Speaker
+		}
+		sb.WriteString(" " + seg.Text)
+	}
+	if last == "" {
+		return at.TranscriptText
+	}
+	return sb.String()
+}
+
+// AnalyzeAudio runs sentiment and talk-pattern analysis on a phone call or meeting.
+// POST /api/v1/audio/transcriptions/:id/analyze
+//
+// The transcription must already have content_type phone_call or meeting
+// (set it via the summarize endpoint). Results are stored in the analysis
+// field alongside the summary.
+func (h *Handler) AnalyzeAudio(c *gin.Context) {
+	id := c.Param("id")
+
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI analysis is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	at, err := h.DB.GetAudioTranscription(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Audio transcription not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only analyze your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	if at.ContentType != models.ContentPhoneCall && at.ContentType != models.ContentMeeting {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "unsupported_content_type",
+			Message: fmt.Sprintf("Analysis is only available for phone_call and meeting content (this transcription is '%s')", at.ContentType),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if at.Status != "completed" || at.TranscriptText == "" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Audio transcription is not completed yet (status: " + at.Status + ")",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	var req models.AnalyzeAudioRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	at.AnalysisStatus = "processing"
+	h.DB.UpdateAudioAnalysis(c.Request.Context(), at)
+
+	result, err := h.Summarizer.AnalyzeConversation(aiContext(c), at.TranscriptText, string(at.ContentType), summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Audio analysis failed for %s: %v", id, err)
+		at.AnalysisStatus = "failed"
+		h.DB.UpdateAudioAnalysis(c.Request.Context(), at)
+
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "analysis_failed",
+			Message: "Failed to analyze conversation: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	analysisJSON, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("Failed to marshal analysis for %s: %v", id, err)
+		analysisJSON = []byte("{}")
+	}
+
+	at.Analysis = analysisJSON
+	at.AnalysisModel = result.Model
+	at.AnalysisStatus = "completed"
+
+	if err := h.DB.UpdateAudioAnalysis(c.Request.Context(), at); err != nil {
+		log.Printf("Failed to save audio analysis for %s: %v", id, err)
+	}
+
+	c.JSON(http.StatusOK, at)
+}
+
 // SearchAudioTranscriptions searches audio transcriptions with full-text search (MTA-25).
 // GET /api/v1/audio/transcriptions/search?q=keyword&content_type=phone_call&page=1&per_page=20
+// With q, results are best match first and include rank, match_count, and an
+// HTML-escaped snippet with matches in <mark> tags. Add include=text to get
+// transcript text and segments in the results, and cursor=<next_cursor> to
+// page without OFFSET.
 func (h *Handler) SearchAudioTranscriptions(c *gin.Context) {
 	var params models.AudioSearchParams
 	if err := c.ShouldBindQuery(&params); err != nil {
@@ -394,8 +664,12 @@ func (h *Handler) SearchAudioTranscriptions(c *gin.Context) {
 		})
 		return
 	}
+	params.IncludeText = includeText(c)
 
 	results, total, err := h.DB.SearchAudioTranscriptions(c.Request.Context(), params)
+	if writeCursorError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Printf("Audio search failed: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -407,32 +681,48 @@ func (h *Handler) SearchAudioTranscriptions(c *gin.Context) {
 	}
 
 	if results == nil {
-		results = []models.AudioTranscription{}
+		results = []models.AudioSearchResult{}
 	}
 
 	perPage := params.PerPage
-	if perPage < 1 {
+	if perPage < 1 || perPage > 100 {
 		perPage = 20
 	}
-	page := params.Page
-	if page < 1 {
-		page = 1
+	var next string
+	if n := len(results); n > 0 {
+		last := results[n-1]
+		if params.Query != "" {
+			next = database.NextRankCursor(n, perPage, last.ID, last.Rank)
+		} else {
+			next = database.NextCreatedAtCursor(n, perPage, last.ID, last.CreatedAt)
+		}
 	}
 
-	c.JSON(http.StatusOK, models.PaginatedResponse[models.AudioTranscription]{
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.AudioSearchResult]{
 		Data:       results,
-		Page:       page,
+		Page:       responsePage(params.Page, params.Cursor),
 		PerPage:    perPage,
 		TotalItems: total,
 		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+		NextCursor: next,
 	})
 }
 
 // ExportAudioTranscription exports a transcription in the requested format (MTA-26).
-// GET /api/v1/audio/transcriptions/:id/export?format=md
+// GET /api/v1/audio/transcriptions/:id/export?format=txt|md|obsidian|json|pdf|vtt
+//
+// vtt uses Whisper's segment timings; add speakers=true to emit <v> voice
+// tags for cues that start with a speaker label. obsidian takes callouts=true
+// to include the summary sections as callouts. redact=true masks PII in the
+// transcript and everything generated from it.
 func (h *Handler) ExportAudioTranscription(c *gin.Context) {
 	id := c.Param("id")
 	format := c.DefaultQuery("format", "txt")
+	redacted, apiErr := parseRedactFlag(c.Query("redact"))
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
 
 	at, err := h.DB.GetAudioTranscription(c.Request.Context(), id)
 	if err != nil {
@@ -443,9 +733,23 @@ func (h *Handler) ExportAudioTranscription(c *gin.Context) {
 		})
 		return
 	}
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only export your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
 
 	baseName := strings.TrimSuffix(at.OriginalName, filepath.Ext(at.OriginalName))
 
+	if redacted {
+		r := h.redactor(c, at.TranscriptText)
+		redactAudio(r, at)
+		setRedactionHeaders(c, r)
+	}
+
 	switch format {
 	case "txt":
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s_transcript.txt", baseName))
@@ -456,14 +760,23 @@ func (h *Handler) ExportAudioTranscription(c *gin.Context) {
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s_summary.md", baseName))
 		c.Data(http.StatusOK, "text/markdown", []byte(md))
 
+	case "obsidian":
+		sendObsidian(c, export.AudioObsidian(at, c.Query("callouts") == "true"), baseName)
+
 	case "json":
 		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s_data.json", baseName))
 		c.JSON(http.StatusOK, at)
 
+	case "pdf":
+		sendPDF(c, export.AudioDocument(at), baseName+"_transcript")
+
+	case "vtt":
+		exportVTT(c, export.ParseSegments(at.Segments), c.Query("speakers") == "true", baseName+"_transcript")
+
 	default:
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "invalid_format",
-			Message: "Supported formats: txt, md, json",
+			Message: "Supported formats: txt, md, obsidian, json, pdf, vtt",
 			Code:    http.StatusBadRequest,
 		})
 	}
@@ -527,19 +840,18 @@ func buildMarkdownExport(at *models.AudioTranscription) string {
 func (h *Handler) DeleteAudioTranscription(c *gin.Context) {
 	id := c.Param("id")
 
+	at, err := h.DB.GetAudioTranscription(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Audio transcription not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
 	// Verify ownership: only delete if it belongs to the authenticated API key
 	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		at, err := h.DB.GetAudioTranscription(c.Request.Context(), id)
-		if err != nil {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "not_found",
-				Message: "Audio transcription not found",
-				Code:    http.StatusNotFound,
-			})
-			return
-		}
-
-		// Check ownership
 		if at.APIKeyID != nil && *at.APIKeyID != apiKey.ID {
 			c.JSON(http.StatusForbidden, models.ErrorResponse{
 				Error:   "forbidden",
@@ -559,5 +871,7 @@ func (h *Handler) DeleteAudioTranscription(c *gin.Context) {
 		return
 	}
 
+	h.deleteOriginal(c.Request.Context(), at.Filename, at.OriginalStoredAt)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Audio transcription deleted"})
 }
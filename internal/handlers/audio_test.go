@@ -0,0 +1,38 @@
+// audio_test.go contains tests for audio summary helpers.
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestSpeakerTranscript verifies diarized segments become one labelled line
+// per turn, and other transcripts are passed through unchanged.
+func TestSpeakerTranscript(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments string
+		want     string
+	}{
+		{
+			name:     "diarized",
+			segments: `[{"start":0,"end":1,"text":"Hi.","speaker":"A"},{"start":1,"end":2,"text":"Got a minute?","speaker":"A"},{"start":2,"end":3,"text":"Sure.","speaker":"B"}]`,
+			want:     "A: Hi. Got a minute?\nB: Sure.",
+		},
+		{
+			name:     "whisper segments",
+			segments: `[{"start":0,"end":1,"text":"Hi."}]`,
+			want:     "stored text",
+		},
+		{name: "no segments", want: "stored text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := &models.AudioTranscription{TranscriptText: "stored text", Segments: models.NullJSON(tt.segments)}
+			if got := speakerTranscript(at); got != tt.want {
+				t.Errorf("speakerTranscript() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
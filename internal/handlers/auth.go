@@ -63,9 +63,10 @@ func (h *Handler) Register(c *gin.Context) {
 		})
 		return
 	}
+	h.sendVerificationEmailAsync(user)
 
-	// Generate JWT
-	token, err := middleware.GenerateJWT(user, h.JWTSecret)
+	// Start a session
+	resp, err := h.issueTokens(c, user)
 	if err != nil {
 		log.Printf("❌ Failed to generate token: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -76,13 +77,10 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, models.AuthResponse{
-		Token: token,
-		User:  *user,
-	})
+	c.JSON(http.StatusCreated, resp)
 }
 
-// Login authenticates a user and returns a JWT token.
+// Login authenticates a user and returns an access token and refresh token.
 // POST /api/v1/auth/login
 func (h *Handler) Login(c *gin.Context) {
 	var req models.LoginRequest
@@ -116,8 +114,8 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	// Generate JWT
-	token, err := middleware.GenerateJWT(user, h.JWTSecret)
+	// Start a session
+	resp, err := h.issueTokens(c, user)
 	if err != nil {
 		log.Printf("❌ Failed to generate token: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -128,10 +126,7 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.AuthResponse{
-		Token: token,
-		User:  *user,
-	})
+	c.JSON(http.StatusOK, resp)
 }
 
 // GetMe returns the current authenticated user.
@@ -149,38 +144,3 @@ func (h *Handler) GetMe(c *gin.Context) {
 
 	c.JSON(http.StatusOK, user)
 }
-
-// RefreshToken issues a new JWT token for an authenticated user.
-// POST /api/v1/auth/refresh
-//
-// This endpoint allows clients to obtain a fresh token before the current
-// one expires. Call this periodically (e.g., every hour) to maintain
-// the session without requiring re-login.
-func (h *Handler) RefreshToken(c *gin.Context) {
-	user := middleware.GetUser(c)
-	if user == nil {
-		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Not authenticated",
-			Code:    http.StatusUnauthorized,
-		})
-		return
-	}
-
-	// Generate a fresh JWT
-	token, err := middleware.GenerateJWT(user, h.JWTSecret)
-	if err != nil {
-		log.Printf("❌ Failed to refresh token: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "token_error",
-			Message: "Failed to refresh token",
-			Code:    http.StatusInternalServerError,
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, models.AuthResponse{
-		Token: token,
-		User:  *user,
-	})
-}
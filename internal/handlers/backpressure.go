@@ -0,0 +1,50 @@
+// backpressure.go tells clients how busy the job queue is, so well-behaved
+// clients back off before and after it fills up.
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// setQueueHeaders reports queue depth, capacity, and utilization (0 to 1)
+// on a job submission response.
+func (h *Handler) setQueueHeaders(c *gin.Context) {
+	depth, capacity := h.Worker.QueueSize(), h.Worker.QueueCapacity()
+	c.Header("X-Queue-Depth", strconv.Itoa(depth))
+	c.Header("X-Queue-Capacity", strconv.Itoa(capacity))
+	c.Header("X-Queue-Utilization", strconv.FormatFloat(queueUtilization(depth, capacity), 'f', 2, 64))
+}
+
+// queueFull sets the queue headers and Retry-After, and returns the 503
+// error for a job the queue had no room for.
+func (h *Handler) queueFull(c *gin.Context) *models.ErrorResponse {
+	h.setQueueHeaders(c)
+	secs := h.setRetryAfter(c)
+	return &models.ErrorResponse{
+		Error:   "queue_full",
+		Message: "Server is busy. Please try again in " + secs + " seconds.",
+		Code:    http.StatusServiceUnavailable,
+	}
+}
+
+// setRetryAfter sets Retry-After to the worker pool's estimate of when the
+// queue has room again, returning the seconds.
+func (h *Handler) setRetryAfter(c *gin.Context) string {
+	secs := strconv.Itoa(int(h.Worker.RetryAfter().Seconds()))
+	c.Header("Retry-After", secs)
+	return secs
+}
+
+// queueUtilization returns how full the queue is, from 0 to 1.
+func queueUtilization(depth, capacity int) float64 {
+	if capacity <= 0 {
+		return 1
+	}
+	return math.Min(float64(depth)/float64(capacity), 1)
+}
@@ -0,0 +1,23 @@
+// backpressure_test.go contains tests for queue backpressure headers.
+package handlers
+
+import "testing"
+
+// TestQueueUtilization verifies utilization is a 0-1 ratio, and a queue
+// with no capacity reads as full.
+func TestQueueUtilization(t *testing.T) {
+	tests := []struct {
+		depth, capacity int
+		want            float64
+	}{
+		{0, 100, 0},
+		{25, 100, 0.25},
+		{100, 100, 1},
+		{5, 0, 1},
+	}
+	for _, tt := range tests {
+		if got := queueUtilization(tt.depth, tt.capacity); got != tt.want {
+			t.Errorf("queueUtilization(%d, %d) = %v, want %v", tt.depth, tt.capacity, got, tt.want)
+		}
+	}
+}
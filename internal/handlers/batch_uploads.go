@@ -0,0 +1,398 @@
+// batch_uploads.go handles batches of audio files and PDFs.
+//
+// POST /api/v1/batches/upload — Upload up to 10 files, or send their URLs
+//
+// Each file becomes its own audio transcription or PDF extraction, linked
+// to a single batch like the transcripts of POST /transcripts/batch, and is
+// processed by the worker pool. GET /api/v1/batches/:id reports progress.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+const (
+	// maxBatchFiles is the most files one batch takes, as for URL batches.
+	maxBatchFiles = 10
+
+	// maxBatchUploadSize caps a whole multipart batch; each file also has
+	// its type's limit.
+	maxBatchUploadSize = 200 << 20 // 200MB
+)
+
+// downloadTypes maps the Content-Types accepted for a downloaded batch
+// file to the extension it's processed as, for URLs whose path doesn't
+// end in one.
+var downloadTypes = map[string]string{
+	"application/pdf":   ".pdf",
+	"application/x-pdf": ".pdf",
+	"audio/mpeg":        ".mp3",
+	"audio/mp3":         ".mp3",
+	"audio/wav":         ".wav",
+	"audio/x-wav":       ".wav",
+	"audio/wave":        ".wav",
+	"audio/mp4":         ".m4a",
+	"audio/x-m4a":       ".m4a",
+	"audio/ogg":         ".ogg",
+	"audio/flac":        ".flac",
+	"audio/x-flac":      ".flac",
+	"audio/webm":        ".webm",
+	"video/webm":        ".webm",
+}
+
+// batchFile is one file of an upload batch, saved to a temp file.
+type batchFile struct {
+	itemType  string // "audio" or "pdf"
+	path      string
+	name      string // The client's filename, or the downloaded file's
+	size      int64
+	sourceURL string // Where a downloaded file came from
+}
+
+// batchOptions apply to every file in a batch.
+type batchOptions struct {
+	redact         bool
+	tables         bool // PDFs only
+	markdown       bool // PDFs only
+	timeoutSeconds int
+}
+
+// CreateUploadBatch starts processing several audio files and PDFs at once.
+// POST /api/v1/batches/upload
+//
+// Either a multipart upload of up to 10 files (field name "files", with
+// optional redact, tables, markdown, and timeout_seconds fields), or a
+// JSON body listing their URLs:
+//
+//	{"urls": ["https://bucket.example.com/call.mp3", "https://bucket.example.com/deck.pdf"]}
+//
+// URLs are downloaded before anything is created, so a bad one fails the
+// whole request, as an invalid URL does in POST /transcripts/batch.
+// Response: 202 with the batch and its pending items.
+func (h *Handler) CreateUploadBatch(c *gin.Context) {
+	var files []batchFile
+	var opts batchOptions
+	var apiErr *models.ErrorResponse
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		files, opts, apiErr = h.receiveBatchFiles(c)
+	} else {
+		files, opts, apiErr = h.downloadBatchFiles(c)
+	}
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	if apiErr := h.checkBatch(files, opts); apiErr != nil {
+		removeBatchFiles(files)
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	timeout, _ := h.jobTimeout(opts.timeoutSeconds)
+
+	batch := &models.Batch{
+		Status:     models.StatusPending,
+		TotalCount: len(files),
+	}
+	if err := h.DB.CreateBatch(c.Request.Context(), batch); err != nil {
+		removeBatchFiles(files)
+		log.Printf("Failed to create batch: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create batch record",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	resp := models.BatchResponse{Transcripts: []models.Transcript{}}
+	rejected := false
+	for _, f := range files {
+		switch f.itemType {
+		case "audio":
+			// Name the temp file after the stored filename, as direct uploads do
+			storedFilename := uuid.New().String() + strings.ToLower(filepath.Ext(f.name))
+			tempFilePath := filepath.Join(filepath.Dir(f.path), storedFilename)
+			if err := os.Rename(f.path, tempFilePath); err != nil {
+				os.Remove(f.path)
+				log.Printf("Failed to stage batch file %s: %v", f.name, err)
+				continue
+			}
+			at, apiErr := h.queueAudio(c, tempFilePath, storedFilename, f.name, f.size, timeout, opts.redact, audio.TranscribeOptions{}, audioDetails{}, &batch.ID)
+			if apiErr != nil {
+				rejected = rejected || apiErr.Error == "queue_full"
+			}
+			if at != nil {
+				resp.AudioTranscriptions = append(resp.AudioTranscriptions, *at)
+			}
+		case "pdf":
+			pe, apiErr := h.queuePDF(c, f, timeout, opts, batch.ID)
+			if apiErr != nil {
+				rejected = rejected || apiErr.Error == "queue_full"
+			}
+			if pe != nil {
+				resp.PDFExtractions = append(resp.PDFExtractions, *pe)
+			}
+		}
+	}
+
+	// Items the full queue turned away are already failed
+	if rejected {
+		if err := h.DB.UpdateBatchCounts(c.Request.Context(), batch.ID); err != nil {
+			log.Printf("Failed to update batch counts: %v", err)
+		}
+		if b, err := h.DB.GetBatch(c.Request.Context(), batch.ID); err == nil {
+			batch = b
+		}
+	}
+	resp.Batch = *batch
+
+	h.setQueueHeaders(c)
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// receiveBatchFiles saves a multipart batch's files and reads its options.
+func (h *Handler) receiveBatchFiles(c *gin.Context) ([]batchFile, batchOptions, *models.ErrorResponse) {
+	uploads, fields, apiErr := receiveUploads(c, maxBatchFiles, maxBatchUploadSize, func(filename string) (string, int64, *models.ErrorResponse) {
+		itemType, maxSize, apiErr := batchItemType(filename)
+		return batchKind(itemType), maxSize, apiErr
+	})
+	if apiErr != nil {
+		return nil, batchOptions{}, apiErr
+	}
+	files := make([]batchFile, len(uploads))
+	for i, up := range uploads {
+		itemType, _, _ := batchItemType(up.Filename)
+		files[i] = batchFile{itemType: itemType, path: up.Path, name: up.Filename, size: up.Size}
+	}
+	if len(files) == 0 {
+		return nil, batchOptions{}, &models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("No files provided. Upload 1-%d files with the field name 'files'.", maxBatchFiles),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	var opts batchOptions
+	for _, flag := range []struct {
+		name string
+		dst  *bool
+	}{{"redact", &opts.redact}, {"tables", &opts.tables}, {"markdown", &opts.markdown}} {
+		if *flag.dst, apiErr = parseFlag(flag.name, fields[flag.name]); apiErr != nil {
+			removeBatchFiles(files)
+			return nil, batchOptions{}, apiErr
+		}
+	}
+	if v := fields["timeout_seconds"]; v != "" {
+		var err error
+		if opts.timeoutSeconds, err = strconv.Atoi(v); err != nil {
+			opts.timeoutSeconds = -1 // Rejected by checkBatch
+		}
+	}
+	return files, opts, nil
+}
+
+// downloadBatchFiles downloads the files of a JSON batch request.
+func (h *Handler) downloadBatchFiles(c *gin.Context) ([]batchFile, batchOptions, *models.ErrorResponse) {
+	var req models.CreateUploadBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, batchOptions{}, &models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("Upload 1-%d files as multipart/form-data, or provide a 'urls' array of 1-%d URLs", maxBatchFiles, maxBatchFiles),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	opts := batchOptions{redact: req.Redact, tables: req.Tables, markdown: req.Markdown, timeoutSeconds: req.TimeoutSeconds}
+	if _, apiErr := h.jobTimeout(opts.timeoutSeconds); apiErr != nil {
+		return nil, batchOptions{}, apiErr // Before spending time on downloads
+	}
+
+	contentTypes := []string{"application/octet-stream", "binary/octet-stream"}
+	for contentType := range downloadTypes {
+		contentTypes = append(contentTypes, contentType)
+	}
+
+	files := make([]batchFile, 0, len(req.URLs))
+	for i, rawURL := range req.URLs {
+		f, apiErr := h.downloadBatchFile(c, rawURL, contentTypes)
+		if apiErr != nil {
+			removeBatchFiles(files)
+			apiErr.Message = fmt.Sprintf("URL at index %d: %s", i, apiErr.Message)
+			return nil, batchOptions{}, apiErr
+		}
+		files = append(files, *f)
+	}
+	return files, opts, nil
+}
+
+// downloadBatchFile downloads one file of a batch to a temp file, working
+// out from its name or Content-Type whether it's audio or a PDF.
+func (h *Handler) downloadBatchFile(c *gin.Context, rawURL string, contentTypes []string) (*batchFile, *models.ErrorResponse) {
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		log.Printf("Failed to create temp file: %v", err)
+		return nil, &models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to process the file",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+	defer file.Close()
+
+	// Every type fits in the PDF limit; audio is checked against its own below
+	res, err := h.Fetcher.Get(c.Request.Context(), rawURL, file, maxPDFSize, contentTypes...)
+	if err != nil {
+		os.Remove(file.Name())
+		return nil, fetchError(err, "file", "invalid_file_type", maxPDFSize)
+	}
+
+	name := res.Name
+	itemType, maxSize, apiErr := batchItemType(name)
+	if apiErr != nil {
+		mediaType, _, _ := mime.ParseMediaType(res.ContentType)
+		if ext, ok := downloadTypes[mediaType]; ok {
+			name += ext
+			itemType, maxSize, apiErr = batchItemType(name)
+		}
+	}
+	if apiErr == nil && res.Size > maxSize {
+		apiErr = tooLarge(batchKind(itemType), maxSize)
+	}
+	if apiErr != nil {
+		os.Remove(file.Name())
+		return nil, apiErr
+	}
+	return &batchFile{itemType: itemType, path: file.Name(), name: name, size: res.Size, sourceURL: res.URL}, nil
+}
+
+// checkBatch validates a batch's options against its files before any
+// records are created.
+func (h *Handler) checkBatch(files []batchFile, opts batchOptions) *models.ErrorResponse {
+	if _, apiErr := h.jobTimeout(opts.timeoutSeconds); apiErr != nil {
+		return apiErr
+	}
+	for _, f := range files {
+		if f.itemType == "audio" && (h.AudioTranscriber == nil || !h.AudioTranscriber.IsConfigured()) {
+			return &models.ErrorResponse{
+				Error:   "service_unavailable",
+				Message: "Audio transcription is not configured. Set the OPENAI_API_KEY environment variable to enable Whisper transcription.",
+				Code:    http.StatusServiceUnavailable,
+			}
+		}
+	}
+	return nil
+}
+
+// queuePDF creates a pending extraction for a batch's PDF and queues it.
+// The temp file is removed if it can't be queued; the extraction, saved as
+// failed, is still returned with the queue_full error.
+func (h *Handler) queuePDF(c *gin.Context, f batchFile, timeout time.Duration, opts batchOptions, batchID string) (*models.PDFExtraction, *models.ErrorResponse) {
+	var apiKeyID *string
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		apiKeyID = &apiKey.ID
+	}
+
+	pe := &models.PDFExtraction{
+		Filename:     uuid.New().String() + ".pdf",
+		OriginalName: f.name,
+		SourceType:   models.SourcePDF,
+		SourceURL:    f.sourceURL,
+		Status:       "pending",
+		APIKeyID:     apiKeyID,
+		BatchID:      &batchID,
+	}
+	if err := h.DB.CreatePDFExtraction(c.Request.Context(), pe); err != nil {
+		os.Remove(f.path)
+		log.Printf("Failed to create PDF extraction record: %v", err)
+		return nil, &models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create extraction record",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	payload, _ := json.Marshal(worker.PDFPayload{
+		PDFID:        pe.ID,
+		TempFilePath: f.path,
+		Options:      pdfservice.ExtractOptions{Tables: opts.tables, Markdown: opts.markdown},
+		Redact:       opts.redact,
+	})
+	job := worker.Job{
+		ID:        pe.ID,
+		Type:      worker.JobPDFExtraction,
+		Payload:   payload,
+		Timeout:   timeout,
+		Priority:  jobPriority(c),
+		CreatedAt: time.Now(),
+	}
+	if err := h.Worker.Submit(job); err != nil {
+		if h.isOwnerRequest(c) {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+			defer cancel()
+			if err := h.Worker.SubmitBlocking(ctx, job); err == nil {
+				log.Printf("📤 PDF extraction job queued (blocking): %s (%s, %.1f MB)", pe.ID, f.name, float64(f.size)/(1024*1024))
+				return pe, nil
+			}
+		}
+
+		os.Remove(f.path)
+		pe.Status = "failed"
+		pe.ErrorMessage = "Job queue is full, please try again later"
+		h.DB.UpdatePDFExtraction(c.Request.Context(), pe)
+		return pe, h.queueFull(c)
+	}
+
+	log.Printf("📤 PDF extraction job queued: %s (%s, %.1f MB)", pe.ID, f.name, float64(f.size)/(1024*1024))
+	return pe, nil
+}
+
+// batchItemType is the item type a file in a batch becomes, by extension,
+// and its size limit.
+func batchItemType(filename string) (string, int64, *models.ErrorResponse) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch {
+	case ext == ".pdf":
+		return "pdf", maxPDFSize, nil
+	case allowedAudioTypes[ext]:
+		return "audio", maxAudioSize, nil
+	}
+	return "", 0, &models.ErrorResponse{
+		Error:   "invalid_file_type",
+		Message: fmt.Sprintf("Unsupported file '%s'. Audio: mp3, wav, m4a, ogg, flac, webm. PDF: pdf.", filename),
+		Code:    http.StatusBadRequest,
+	}
+}
+
+// batchKind names an item type in error messages.
+func batchKind(itemType string) string {
+	if itemType == "pdf" {
+		return "PDF"
+	}
+	return itemType
+}
+
+// removeBatchFiles deletes the temp files of a batch that won't be queued.
+func removeBatchFiles(files []batchFile) {
+	for _, f := range files {
+		os.Remove(f.path)
+	}
+}
@@ -7,6 +7,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -43,14 +44,28 @@ func (h *Handler) CreateBatch(c *gin.Context) {
 		return
 	}
 
-	// Enforce the 10-URL limit explicitly (belt + suspenders with the binding tag)
-	if len(req.URLs) > 10 {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+	resp, apiErr, status := h.startBatch(c, req)
+	if apiErr != nil {
+		c.JSON(status, *apiErr)
+		return
+	}
+
+	// Return 202 Accepted with the batch and all transcript records
+	c.JSON(status, resp)
+}
+
+// startBatch creates a batch and a transcript record per URL, queueing
+// extraction for any video we haven't already transcribed. Shared by REST
+// and GraphQL.
+func (h *Handler) startBatch(c *gin.Context, req models.CreateBatchRequest) (*models.BatchResponse, *models.ErrorResponse, int) {
+	urls := req.URLs
+	// Enforce the 1-10 URL limit explicitly (belt + suspenders with the binding tag)
+	if len(urls) == 0 || len(urls) > 10 {
+		return nil, &models.ErrorResponse{
 			Error:   "too_many_urls",
 			Message: "Maximum 10 URLs per batch request",
 			Code:    http.StatusBadRequest,
-		})
-		return
+		}, http.StatusBadRequest
 	}
 
 	// Step 1: Validate ALL URLs before creating any records.
@@ -60,21 +75,29 @@ func (h *Handler) CreateBatch(c *gin.Context) {
 		fullURL string
 		videoID string
 	}
-	parsed := make([]parsedURL, 0, len(req.URLs))
+	parsed := make([]parsedURL, 0, len(urls))
 
-	for i, url := range req.URLs {
+	for i, url := range urls {
 		fullURL, videoID, err := transcript.ParseYouTubeURL(url)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			return nil, &models.ErrorResponse{
 				Error:   "invalid_url",
 				Message: "Invalid YouTube URL at index " + intToStr(i) + ": " + err.Error(),
 				Code:    http.StatusBadRequest,
-			})
-			return
+			}, http.StatusBadRequest
 		}
 		parsed = append(parsed, parsedURL{fullURL: fullURL, videoID: videoID})
 	}
 
+	timeout, apiErr := h.jobTimeout(req.TimeoutSeconds)
+	if apiErr != nil {
+		return nil, apiErr, http.StatusBadRequest
+	}
+	autoSummary, apiErr := h.autoSummary(c, req.AutoSummarizeOptions)
+	if apiErr != nil {
+		return nil, apiErr, apiErr.Code
+	}
+
 	// Step 2: Create the batch record
 	batch := &models.Batch{
 		Status:     models.StatusPending,
@@ -83,16 +106,16 @@ func (h *Handler) CreateBatch(c *gin.Context) {
 
 	if err := h.DB.CreateBatch(c.Request.Context(), batch); err != nil {
 		log.Printf("Failed to create batch: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "database_error",
 			Message: "Failed to create batch record",
 			Code:    http.StatusInternalServerError,
-		})
-		return
+		}, http.StatusInternalServerError
 	}
 
 	// Step 3: Create a transcript record for each URL, linked to the batch
 	transcripts := make([]models.Transcript, 0, len(parsed))
+	rejected := false
 
 	for _, p := range parsed {
 		// Check for existing completed transcript for this video
@@ -115,6 +138,7 @@ func (h *Handler) CreateBatch(c *gin.Context) {
 				Duration:       existing.Duration,
 				TranscriptText: existing.TranscriptText,
 				WordCount:      existing.WordCount,
+				Segments:       existing.Segments,
 			}
 			needsExtraction = false
 			log.Printf("Reusing existing transcript for %s (already extracted)", p.videoID)
@@ -135,13 +159,20 @@ func (h *Handler) CreateBatch(c *gin.Context) {
 			continue
 		}
 
-		// Only submit extraction job if this is a new transcript
+		// Only submit extraction job if this is a new transcript; a reused
+		// one is summarized right away
+		chained := h.chainSummary(c, t, autoSummary)
 		if needsExtraction {
 			job := worker.Job{
 				ID:        t.ID,
 				Type:      worker.JobTranscriptExtraction,
+				Timeout:   timeout,
+				Priority:  jobPriority(c),
 				CreatedAt: time.Now(),
 			}
+			if chained != nil {
+				job.Payload, _ = json.Marshal(worker.TranscriptPayload{Summary: chained})
+			}
 
 			if err := h.Worker.Submit(job); err != nil {
 				if h.isOwnerRequest(c) {
@@ -154,17 +185,30 @@ func (h *Handler) CreateBatch(c *gin.Context) {
 					}
 				}
 				log.Printf("Failed to queue extraction job for %s: %v", t.ID, err)
+				t.Status = models.StatusFailed
+				t.ErrorMessage = "Job queue is full, please try again later"
+				h.DB.UpdateTranscript(c.Request.Context(), t)
+				if chained != nil {
+					h.DB.SetSummaryStatus(c.Request.Context(), chained.SummaryID, models.StatusFailed, t.ErrorMessage)
+				}
+				rejected = true
 			}
 		}
 
 		transcripts = append(transcripts, *t)
 	}
 
-	// Return 202 Accepted with the batch and all transcript records
-	c.JSON(http.StatusAccepted, models.BatchResponse{
+	// Partial success still returns 202; failed items tell the client when
+	// to resubmit them.
+	h.setQueueHeaders(c)
+	if rejected {
+		h.setRetryAfter(c)
+	}
+
+	return &models.BatchResponse{
 		Batch:       *batch,
 		Transcripts: transcripts,
-	})
+	}, nil, http.StatusAccepted
 }
 
 // GetBatch retrieves the status of a batch and its transcripts.
@@ -200,12 +244,72 @@ func (h *Handler) GetBatch(c *gin.Context) {
 		transcripts = []models.Transcript{} // Return empty array, not error
 	}
 
+	audioItems, err := h.DB.GetAudioTranscriptionsByBatch(c.Request.Context(), id)
+	if err != nil {
+		log.Printf("Failed to get batch audio transcriptions: %v", err)
+	}
+	pdfItems, err := h.DB.GetPDFExtractionsByBatch(c.Request.Context(), id)
+	if err != nil {
+		log.Printf("Failed to get batch PDF extractions: %v", err)
+	}
+
+	if !h.canViewBatchItems(c, transcripts, audioItems, pdfItems) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view your own or your organization's batches",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, models.BatchStatusResponse{
-		Batch:       *batch,
-		Transcripts: transcripts,
+		Batch:               *batch,
+		Transcripts:         transcripts,
+		AudioTranscriptions: audioItems,
+		PDFExtractions:      pdfItems,
 	})
 }
 
+// canViewBatchItems reports whether the caller can see every item in a
+// batch. Batches belong to the key or user that submitted their items.
+func (h *Handler) canViewBatchItems(c *gin.Context, transcripts []models.Transcript, audioItems []models.AudioTranscription, pdfItems []models.PDFExtraction) bool {
+	type itemOwner struct{ apiKeyID, userID *string }
+	owners := make([]itemOwner, 0, len(transcripts)+len(audioItems)+len(pdfItems))
+	for _, t := range transcripts {
+		owners = append(owners, itemOwner{t.APIKeyID, t.UserID})
+	}
+	for _, at := range audioItems {
+		owners = append(owners, itemOwner{at.APIKeyID, at.UserID})
+	}
+	for _, pe := range pdfItems {
+		owners = append(owners, itemOwner{pe.APIKeyID, pe.UserID})
+	}
+	for _, owner := range owners {
+		if !h.canViewItem(c, owner.apiKeyID, owner.userID) {
+			return false
+		}
+	}
+	return true
+}
+
+// canViewBatch loads a batch's items and checks them with canViewItem.
+func (h *Handler) canViewBatch(c *gin.Context, id string) (bool, error) {
+	ctx := c.Request.Context()
+	transcripts, err := h.DB.GetTranscriptsByBatch(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	audioItems, err := h.DB.GetAudioTranscriptionsByBatch(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	pdfItems, err := h.DB.GetPDFExtractionsByBatch(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return h.canViewBatchItems(c, transcripts, audioItems, pdfItems), nil
+}
+
 // intToStr is a tiny helper to convert an int to string for error messages.
 // Go Pattern: We could use strconv.Itoa, but for simple cases like error
 // messages, fmt.Sprintf is cleaner and more readable.
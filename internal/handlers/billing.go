@@ -0,0 +1,150 @@
+// billing.go links API keys to Stripe customers, opens the customer portal,
+// and receives Stripe's subscription webhooks, suspending the keys of
+// customers whose subscription goes unpaid.
+package handlers
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/billing"
+)
+
+// maxWebhookBody caps the Stripe webhook payloads read.
+const maxWebhookBody = 1 << 20
+
+// BillingPortal returns a link to the Stripe customer portal for the
+// caller's API key, where its invoices and payment method are managed.
+// Suspended keys can reach it, so they can pay.
+// GET /api/v1/billing/portal
+func (h *Handler) BillingPortal(c *gin.Context) {
+	if !h.Billing.IsConfigured() {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "Billing is not configured. Set STRIPE_SECRET_KEY to enable it.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil || apiKey.StripeCustomerID == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_billed",
+			Message: "Billing is per API key, and this request's key isn't linked to a Stripe customer",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	url, err := h.Billing.PortalURL(c.Request.Context(), *apiKey.StripeCustomerID)
+	if err != nil {
+		log.Printf("Stripe portal session failed for key %s: %v", apiKey.ID, err)
+		c.JSON(http.StatusBadGateway, models.ErrorResponse{
+			Error:   "stripe_error",
+			Message: "Failed to open the billing portal",
+			Code:    http.StatusBadGateway,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BillingPortalResponse{URL: url})
+}
+
+// StripeWebhook receives Stripe events. Subscription events suspend the
+// keys of a customer whose subscription is past due, unpaid, or canceled,
+// and reinstate them once it's active again. The Stripe-Signature header
+// is the credential.
+// POST /api/v1/billing/webhook
+func (h *Handler) StripeWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBody))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to read the webhook body",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	event, err := h.Billing.ParseWebhook(payload, c.GetHeader("Stripe-Signature"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_signature",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if !strings.HasPrefix(event.Type, "customer.subscription.") {
+		c.JSON(http.StatusOK, gin.H{"received": true})
+		return
+	}
+
+	sub, err := billing.ParseSubscription(event)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// A failure is answered with a 500 so Stripe retries the event
+	changed, err := h.DB.SetBillingSuspended(c.Request.Context(), sub.Customer, sub.Delinquent())
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to update the customer's API keys",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if changed > 0 {
+		log.Printf("💳 Subscription %s is %s: %d API key(s) of %s updated (suspended: %t)",
+			sub.ID, sub.Status, changed, sub.Customer, sub.Delinquent())
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
+
+// AdminSetKeyBilling links an API key to a Stripe customer, whose metered
+// usage it's then reported under, or unlinks it with an empty ID.
+// PUT /api/v1/admin/keys/:id/billing
+func (h *Handler) AdminSetKeyBilling(c *gin.Context) {
+	var req models.SetKeyBillingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "stripe_customer_id must be a Stripe customer ID (cus_...), or empty to unlink",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	var customerID *string
+	if req.StripeCustomerID != "" {
+		customerID = &req.StripeCustomerID
+	}
+	key, err := h.DB.SetAPIKeyStripeCustomer(c.Request.Context(), c.Param("id"), customerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
@@ -0,0 +1,157 @@
+// chapters.go writes YouTube chapter lists and descriptions from a
+// transcript's caption timings, ready for creators to paste into YouTube
+// Studio.
+//
+// Like key moments, chapters aren't stored; repeats are answered from the
+// AI completion cache.
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// minChapterSeconds is the shortest chapter YouTube accepts.
+const minChapterSeconds = 10
+
+// CreateChapters generates a chapter list and description for a completed
+// transcript.
+// POST /api/v1/transcripts/:id/chapters
+//
+// Transcripts extracted before caption timings were stored get chapters at
+// estimated times, flagged as approximate.
+func (h *Handler) CreateChapters(c *gin.Context) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.CreateChaptersRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	t, ok := h.loadCompletedTranscript(c, "write chapters for")
+	if !ok {
+		return
+	}
+
+	segments, approximate := transcriptTimings(t)
+
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	timed := export.TimedText(export.MergeSegments(segments, momentBlockSeconds))
+	result, err := h.Summarizer.GenerateChapters(ctx, t.Title, timed, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Chapter generation failed for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to generate chapters: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	chapters := youtubeChapters(result.Chapters, t.Duration)
+	resp := models.ChaptersResponse{
+		TranscriptID: t.ID,
+		Chapters:     chapters,
+		ChaptersText: chapterList(chapters),
+		Description:  result.Description,
+		Hashtags:     result.Hashtags,
+		Approximate:  approximate,
+		Model:        result.Model,
+	}
+	resp.Text = youtubeDescription(resp)
+	c.JSON(http.StatusOK, resp)
+}
+
+// youtubeChapters applies YouTube's rules to a chapter list: the first
+// chapter starts at 0:00, and each lasts at least minChapterSeconds,
+// ending by the end of the video. Chapters that break them are dropped.
+func youtubeChapters(chapters []summary.Chapter, duration int) []models.VideoChapter {
+	// A video an hour or longer lists every chapter as h:mm:ss
+	long := duration >= 3600 ||
+		(len(chapters) > 0 && chapters[len(chapters)-1].Seconds >= 3600)
+
+	list := []models.VideoChapter{}
+	for i, ch := range chapters {
+		if i == 0 {
+			ch.Seconds = 0
+		}
+		if n := len(list); n > 0 && ch.Seconds-list[n-1].Seconds < minChapterSeconds {
+			continue
+		}
+		if duration > 0 && duration-ch.Seconds < minChapterSeconds {
+			break
+		}
+		list = append(list, models.VideoChapter{
+			Seconds:   ch.Seconds,
+			Timestamp: chapterStamp(ch.Seconds, long),
+			Title:     ch.Title,
+		})
+	}
+	return list
+}
+
+// chapterStamp formats a chapter's start the way YouTube chapter lists are
+// usually written: 02:15, or 0:02:15 when the video runs an hour or more.
+func chapterStamp(seconds int, long bool) string {
+	if long {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// chapterList renders chapters one per line, as YouTube reads them from a
+// description.
+func chapterList(chapters []models.VideoChapter) string {
+	lines := make([]string, len(chapters))
+	for i, ch := range chapters {
+		lines[i] = ch.Timestamp + " " + ch.Title
+	}
+	return strings.Join(lines, "\n")
+}
+
+// youtubeDescription lays out a full video description: the text, then the
+// chapters, then hashtags, which YouTube shows above the title.
+func youtubeDescription(resp models.ChaptersResponse) string {
+	var parts []string
+	for _, part := range []string{resp.Description, resp.ChaptersText, strings.Join(resp.Hashtags, " ")} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
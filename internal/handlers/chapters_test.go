@@ -0,0 +1,53 @@
+// chapters_test.go tests fitting generated chapters to YouTube's rules and
+// laying out the pasteable description.
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+func TestYouTubeChapters(t *testing.T) {
+	chapters := []summary.Chapter{
+		{Seconds: 4, Title: "Intro"},
+		{Seconds: 9, Title: "Too soon"},
+		{Seconds: 135, Title: "Setup"},
+		{Seconds: 595, Title: "Too close to the end"},
+	}
+
+	tests := []struct {
+		name     string
+		duration int
+		want     string
+	}{
+		{"short video", 600, "00:00 Intro\n02:15 Setup"},
+		{"unknown duration", 0, "00:00 Intro\n02:15 Setup\n09:55 Too close to the end"},
+		{"long video", 4000, "0:00:00 Intro\n0:02:15 Setup\n0:09:55 Too close to the end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chapterList(youtubeChapters(chapters, tt.duration)); got != tt.want {
+				t.Errorf("chapters =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYouTubeDescription(t *testing.T) {
+	resp := models.ChaptersResponse{
+		Description:  "Learn Go channels.",
+		ChaptersText: "00:00 Intro",
+		Hashtags:     []string{"#golang", "#concurrency"},
+	}
+	want := "Learn Go channels.\n\n00:00 Intro\n\n#golang #concurrency"
+	if got := youtubeDescription(resp); got != want {
+		t.Errorf("youtubeDescription() = %q, want %q", got, want)
+	}
+
+	resp.Hashtags = nil
+	if got := youtubeDescription(resp); got != "Learn Go channels.\n\n00:00 Intro" {
+		t.Errorf("youtubeDescription() without hashtags = %q", got)
+	}
+}
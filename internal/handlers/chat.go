@@ -2,25 +2,60 @@
 package handlers
 
 import (
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
 )
 
+// maxFeedbackCommentLength caps the comment left with a chat answer rating.
+const maxFeedbackCommentLength = 2000
+
+// Chat prompts carry at most chatPromptMessages earlier messages. Once a
+// session has more than that its history summary doesn't cover, all but
+// the newest chatKeepMessages are condensed into the summary.
+const (
+	chatPromptMessages = 40
+	chatKeepMessages   = 20
+)
+
 type chatTarget struct {
 	ItemType     string
 	ItemID       string
 	ContextLabel string
 	Text         string
 	APIKeyID     *string
+	UserID       *string
+
+	// YouTube transcripts with stored segments are chatted about as
+	// timestamped lines, so answers can cite moments of the video
+	TimedText string
+	YouTubeID string
+	Duration  int
+}
+
+// citationRe matches a timestamp cited in a chat answer, like [12:34] or
+// [1:02:03].
+var citationRe = regexp.MustCompile(`\[(?:(\d{1,2}):)?(\d{1,2}):(\d{2})\]`)
+
+// videoURL links to seconds into a YouTube video.
+func videoURL(youtubeID string, seconds int) string {
+	return fmt.Sprintf("https://www.youtube.com/watch?v=%s&t=%ds", youtubeID, seconds)
 }
 
 func (h *Handler) loadTranscriptChatTarget(c *gin.Context) (*chatTarget, *models.ErrorResponse, int) {
@@ -48,26 +83,28 @@ func (h *Handler) loadTranscriptChatTarget(c *gin.Context) (*chatTarget, *models
 			Code:    http.StatusConflict,
 		}, http.StatusConflict
 	}
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		if t.APIKeyID != nil && *t.APIKeyID != apiKey.ID {
-			return nil, &models.ErrorResponse{
-				Error:   "forbidden",
-				Message: "You can only chat about your own transcripts",
-				Code:    http.StatusForbidden,
-			}, http.StatusForbidden
-		}
-	}
-	var apiKeyID *string
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		apiKeyID = &apiKey.ID
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		return nil, &models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only chat about your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		}, http.StatusForbidden
 	}
-	return &chatTarget{
+	apiKeyID, userID := chatOwner(c)
+	target := &chatTarget{
 		ItemType:     "transcript",
 		ItemID:       t.ID,
 		ContextLabel: "YouTube transcript",
 		Text:         t.TranscriptText,
 		APIKeyID:     apiKeyID,
-	}, nil, 0
+		UserID:       userID,
+		YouTubeID:    t.YouTubeID,
+		Duration:     t.Duration,
+	}
+	if segments := export.ParseSegments(t.Segments); len(segments) > 0 {
+		target.TimedText = export.TimedText(segments)
+	}
+	return target, nil, 0
 }
 
 func (h *Handler) loadAudioChatTarget(c *gin.Context) (*chatTarget, *models.ErrorResponse, int) {
@@ -95,25 +132,21 @@ func (h *Handler) loadAudioChatTarget(c *gin.Context) (*chatTarget, *models.Erro
 			Code:    http.StatusConflict,
 		}, http.StatusConflict
 	}
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		if at.APIKeyID != nil && *at.APIKeyID != apiKey.ID {
-			return nil, &models.ErrorResponse{
-				Error:   "forbidden",
-				Message: "You can only chat about your own transcriptions",
-				Code:    http.StatusForbidden,
-			}, http.StatusForbidden
-		}
-	}
-	var apiKeyID *string
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		apiKeyID = &apiKey.ID
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		return nil, &models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only chat about your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		}, http.StatusForbidden
 	}
+	apiKeyID, userID := chatOwner(c)
 	return &chatTarget{
 		ItemType:     "audio",
 		ItemID:       at.ID,
 		ContextLabel: "audio transcription",
 		Text:         at.TranscriptText,
 		APIKeyID:     apiKeyID,
+		UserID:       userID,
 	}, nil, 0
 }
 
@@ -142,30 +175,80 @@ func (h *Handler) loadPDFChatTarget(c *gin.Context) (*chatTarget, *models.ErrorR
 			Code:    http.StatusConflict,
 		}, http.StatusConflict
 	}
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		if pe.APIKeyID != nil && *pe.APIKeyID != apiKey.ID {
-			return nil, &models.ErrorResponse{
-				Error:   "forbidden",
-				Message: "You can only chat about your own PDF extractions",
-				Code:    http.StatusForbidden,
-			}, http.StatusForbidden
-		}
+	if !h.canViewItem(c, pe.APIKeyID, pe.UserID) {
+		return nil, &models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only chat about your own or your organization's PDF extractions",
+			Code:    http.StatusForbidden,
+		}, http.StatusForbidden
 	}
-	var apiKeyID *string
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		apiKeyID = &apiKey.ID
+	apiKeyID, userID := chatOwner(c)
+	// Markdown keeps the headings and tables that locate an answer
+	text := pe.TextContent
+	if pe.MarkdownContent != "" {
+		text = pe.MarkdownContent
 	}
 	return &chatTarget{
 		ItemType:     "pdf",
 		ItemID:       pe.ID,
 		ContextLabel: "PDF text extraction",
-		Text:         pe.TextContent,
+		Text:         text,
 		APIKeyID:     apiKeyID,
+		UserID:       userID,
 	}, nil, 0
 }
 
+// chatOwner returns who the caller's chat sessions are held by: their API
+// key, or the logged-in user.
+func chatOwner(c *gin.Context) (apiKeyID, userID *string) {
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		return &apiKey.ID, nil
+	}
+	if user := middleware.GetUser(c); user != nil {
+		return nil, &user.ID
+	}
+	return nil, nil
+}
+
+// ownsChatSession reports whether the caller holds a chat session and may
+// still see the item it's about. API keys hold their own sessions; users
+// hold their own and those of keys linked to them. Sessions on deleted
+// items only need the first check.
+func (h *Handler) ownsChatSession(c *gin.Context, session *models.TranscriptChatSession) (bool, error) {
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		if session.APIKeyID == nil || *session.APIKeyID != apiKey.ID {
+			return false, nil
+		}
+	} else if !h.canManageItem(c, session.APIKeyID, session.UserID) {
+		return false, nil
+	}
+
+	_, itemAPIKeyID, itemUserID, err := h.loadItem(c.Request.Context(), session.ItemType, session.ItemID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return h.canViewItem(c, itemAPIKeyID, itemUserID), nil
+}
+
 func (h *Handler) getChatResponse(c *gin.Context, target *chatTarget) {
-	session, err := h.DB.GetOrCreateChatSession(c.Request.Context(), target.ItemType, target.ItemID, target.APIKeyID)
+	limit := 100
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 200 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_params",
+				Message: "limit must be between 1 and 200",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		limit = n
+	}
+
+	session, err := h.DB.GetOrCreateChatSession(c.Request.Context(), target.ItemType, target.ItemID, target.APIKeyID, target.UserID)
 	if err != nil {
 		log.Printf("Chat session load failed (%s:%s): %v", target.ItemType, target.ItemID, err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -176,7 +259,10 @@ func (h *Handler) getChatResponse(c *gin.Context, target *chatTarget) {
 		return
 	}
 
-	messages, err := h.DB.ListChatMessages(c.Request.Context(), session.ID, 100)
+	messages, err := h.DB.ListChatMessages(c.Request.Context(), session.ID, limit, c.Query("cursor"))
+	if writeCursorError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Printf("Chat messages load failed (session %s): %v", session.ID, err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -187,14 +273,84 @@ func (h *Handler) getChatResponse(c *gin.Context, target *chatTarget) {
 		return
 	}
 
-	if messages == nil {
-		messages = []models.TranscriptChatMessage{}
+	resp := models.ChatResponse{Session: *session, Messages: messages}
+	if len(messages) > 0 {
+		// Pages run backwards, so the next one is older than the first message
+		resp.NextCursor = database.NextCreatedAtCursor(len(messages), limit, messages[0].ID, messages[0].CreatedAt)
+	}
+	if resp.Messages == nil {
+		resp.Messages = []models.TranscriptChatMessage{}
 	}
+	for i := range resp.Messages {
+		target.linkCitations(&resp.Messages[i])
+	}
+	if c.Query("cursor") == "" {
+		resp.SuggestedQuestions = h.chatSuggestions(aiContext(c), target)
+	}
+	c.JSON(http.StatusOK, resp)
+}
 
-	c.JSON(http.StatusOK, models.ChatResponse{
-		Session:  *session,
-		Messages: messages,
-	})
+// chatSuggestions returns starter questions for a chat about target,
+// generating them the first time and again once its text changes. Chat
+// still opens without them if AI isn't configured or generation fails.
+func (h *Handler) chatSuggestions(ctx context.Context, target *chatTarget) []string {
+	if h.Summarizer == nil || !h.Summarizer.IsConfigured() {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(target.Text))
+	textHash := hex.EncodeToString(sum[:])
+
+	questions, err := h.DB.GetChatSuggestions(ctx, target.ItemType, target.ItemID, textHash)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	if questions != nil {
+		return questions
+	}
+
+	questions, _, err = h.Summarizer.SuggestQuestions(ctx, target.ContextLabel, target.Text)
+	if err != nil {
+		log.Printf("⚠️  Chat suggestions failed (%s:%s): %v", target.ItemType, target.ItemID, err)
+		return nil
+	}
+	if err := h.DB.SaveChatSuggestions(ctx, target.ItemType, target.ItemID, textHash, questions); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	return questions
+}
+
+// chatHistory returns the history summary and earlier messages to prompt
+// with, first condensing older turns into the session's summary if there
+// are too many. If condensing fails, the prompt makes do with the newest
+// messages and it's tried again on the next message.
+func (h *Handler) chatHistory(ctx context.Context, session *models.TranscriptChatSession) (string, []models.TranscriptChatMessage, error) {
+	messages, err := h.DB.ListUnsummarizedChatMessages(ctx, session)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(messages) <= chatPromptMessages {
+		return session.HistorySummary, messages, nil
+	}
+
+	split := len(messages) - chatKeepMessages
+	older, recent := messages[:split], messages[split:]
+	turns := make([]summary.ChatMessage, len(older))
+	for i, m := range older {
+		turns[i] = summary.ChatMessage{Role: m.Role, Content: m.Content}
+	}
+	condensed, err := h.Summarizer.CondenseChat(ctx, session.HistorySummary, turns)
+	if err != nil {
+		log.Printf("⚠️  Chat history summary failed (session %s): %v", session.ID, err)
+		return session.HistorySummary, messages[len(messages)-chatPromptMessages:], nil
+	}
+
+	through := older[len(older)-1].ID
+	if err := h.DB.SetChatHistorySummary(ctx, session.ID, condensed, through); err != nil {
+		log.Printf("⚠️  %v (session %s)", err, session.ID)
+	}
+	session.HistorySummary = condensed
+	session.SummarizedThroughID = &through
+	return condensed, recent, nil
 }
 
 func (h *Handler) postChatResponse(c *gin.Context, target *chatTarget, req models.CreateChatMessageRequest) {
@@ -216,8 +372,23 @@ func (h *Handler) postChatResponse(c *gin.Context, target *chatTarget, req model
 		})
 		return
 	}
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
 
-	session, err := h.DB.GetOrCreateChatSession(c.Request.Context(), target.ItemType, target.ItemID, target.APIKeyID)
+	session, err := h.DB.GetOrCreateChatSession(c.Request.Context(), target.ItemType, target.ItemID, target.APIKeyID, target.UserID)
 	if err != nil {
 		log.Printf("Chat session load failed (%s:%s): %v", target.ItemType, target.ItemID, err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -228,7 +399,7 @@ func (h *Handler) postChatResponse(c *gin.Context, target *chatTarget, req model
 		return
 	}
 
-	history, err := h.DB.ListChatMessages(c.Request.Context(), session.ID, 40)
+	historySummary, history, err := h.chatHistory(aiContext(c), session)
 	if err != nil {
 		log.Printf("Chat history load failed (session %s): %v", session.ID, err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -267,12 +438,21 @@ func (h *Handler) postChatResponse(c *gin.Context, target *chatTarget, req model
 		Content: req.Message,
 	})
 
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	text, opts := target.Text, summary.Options{Model: req.Model, FallbackModels: req.FallbackModels, Language: req.Language}
+	if target.TimedText != "" {
+		text, opts.Timestamps = target.TimedText, true
+	}
 	answer, modelUsed, err := h.Summarizer.ChatTranscript(
-		c.Request.Context(),
+		ctx,
 		target.ContextLabel,
-		target.Text,
+		text,
+		historySummary,
 		chatHistory,
-		req.Model,
+		opts,
 	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -299,12 +479,59 @@ func (h *Handler) postChatResponse(c *gin.Context, target *chatTarget, req model
 		return
 	}
 
+	target.linkCitations(assistantMsg)
 	c.JSON(http.StatusOK, models.ChatResponse{
 		Session:  *session,
 		Messages: []models.TranscriptChatMessage{*userMsg, *assistantMsg},
 	})
 }
 
+// linkCitations fills in the citations and linked_content of an answer
+// about a YouTube transcript: each [m:ss] or [h:mm:ss] it cites becomes a
+// link to that moment of the video. Timestamps past the end of the video
+// are left alone.
+func (t *chatTarget) linkCitations(m *models.TranscriptChatMessage) {
+	if t.YouTubeID == "" || m.Role != "assistant" {
+		return
+	}
+
+	var linked strings.Builder
+	last := 0
+	for _, loc := range citationRe.FindAllStringSubmatchIndex(m.Content, -1) {
+		// Skip timestamps the model already wrote as Markdown links
+		if strings.HasPrefix(m.Content[loc[1]:], "(") {
+			continue
+		}
+		hours := 0
+		if loc[2] >= 0 {
+			hours, _ = strconv.Atoi(m.Content[loc[2]:loc[3]])
+		}
+		minutes, _ := strconv.Atoi(m.Content[loc[4]:loc[5]])
+		secs, _ := strconv.Atoi(m.Content[loc[6]:loc[7]])
+		if secs >= 60 || (loc[2] >= 0 && minutes >= 60) {
+			continue
+		}
+		seconds := hours*3600 + minutes*60 + secs
+		if t.Duration > 0 && seconds > t.Duration {
+			continue
+		}
+
+		cite := models.ChatCitation{
+			Text:    m.Content[loc[0]:loc[1]],
+			Seconds: seconds,
+			URL:     videoURL(t.YouTubeID, seconds),
+		}
+		m.Citations = append(m.Citations, cite)
+		linked.WriteString(m.Content[last:loc[0]])
+		linked.WriteString(cite.Text + "(" + cite.URL + ")")
+		last = loc[1]
+	}
+	if len(m.Citations) > 0 {
+		linked.WriteString(m.Content[last:])
+		m.LinkedContent = linked.String()
+	}
+}
+
 // GetTranscriptChat returns the chat session and messages for a transcript.
 // GET /api/v1/transcripts/:id/chat
 func (h *Handler) GetTranscriptChat(c *gin.Context) {
@@ -398,3 +625,217 @@ func (h *Handler) PostPDFChat(c *gin.Context) {
 	}
 	h.postChatResponse(c, target, req)
 }
+
+// PostChatFeedback rates an assistant answer up or down, with an optional
+// comment. Rating again replaces the earlier feedback.
+// POST /api/v1/chat/messages/:id/feedback
+func (h *Handler) PostChatFeedback(c *gin.Context) {
+	var req models.ChatFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "rating is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if msg := validateChatFeedback(&req); msg != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	msg, err := h.DB.GetChatMessage(ctx, c.Param("id"))
+	var session *models.TranscriptChatSession
+	if err == nil {
+		session, err = h.DB.GetChatSession(ctx, msg.SessionID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Chat message not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		log.Printf("❌ Failed to load chat message %s: %v", c.Param("id"), err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load chat message",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	owns, err := h.ownsChatSession(c, session)
+	if err != nil {
+		log.Printf("❌ Failed to load %s %s: %v", session.ItemType, session.ItemID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load the chat's item",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if !owns {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only rate answers in your own chats",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if msg.Role != "assistant" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Only assistant answers can be rated",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.DB.SetChatFeedback(ctx, msg.ID, req.Rating, req.Comment); err != nil {
+		log.Printf("❌ %v (message %s)", err, msg.ID)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save feedback",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if msg, err = h.DB.GetChatMessage(ctx, msg.ID); err != nil {
+		log.Printf("❌ Failed to reload chat message %s: %v", c.Param("id"), err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load chat message",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, msg)
+}
+
+// ExportChatSession downloads a chat conversation, headed by the metadata of
+// the item it's about, so Q&A sessions can be saved and shared.
+// GET /api/v1/chat/sessions/:id/export?format=md|json|txt
+func (h *Handler) ExportChatSession(c *gin.Context) {
+	format := c.DefaultQuery("format", "md")
+	if !export.ChatFormats[format] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Supported formats: md, json, txt",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	session, err := h.DB.GetChatSession(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Chat session not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		log.Printf("❌ Failed to load chat session %s: %v", c.Param("id"), err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load chat session",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	owns, err := h.ownsChatSession(c, session)
+	if err != nil {
+		log.Printf("❌ Failed to load %s %s: %v", session.ItemType, session.ItemID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load the chat's item",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if !owns {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only export your own chats",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	item, _, _, err := h.loadItem(ctx, session.ItemType, session.ItemID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("❌ Failed to load %s %s: %v", session.ItemType, session.ItemID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load the chat's item",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	// An item deleted since leaves the conversation headed by its ID
+	source := export.ChatSource{ItemType: session.ItemType, ItemID: session.ItemID, Title: session.ItemID}
+	switch item := item.(type) {
+	case *models.Transcript:
+		source = export.TranscriptChatSource(item)
+	case *models.AudioTranscription:
+		source = export.AudioChatSource(item)
+	case *models.PDFExtraction:
+		source = export.PDFChatSource(item)
+	}
+
+	// A session with nothing summarized lists every message
+	messages, err := h.DB.ListUnsummarizedChatMessages(ctx, &models.TranscriptChatSession{ID: session.ID})
+	if err != nil {
+		log.Printf("❌ Chat messages load failed (session %s): %v", session.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load chat messages",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if messages == nil {
+		messages = []models.TranscriptChatMessage{}
+	}
+
+	f, err := export.ChatFile(session, source, messages, format)
+	if err != nil {
+		log.Printf("❌ Chat export failed (session %s): %v", session.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "export_error",
+			Message: "Failed to generate chat export",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	contentTypes := map[string]string{
+		"md":   "text/markdown; charset=utf-8",
+		"json": "application/json; charset=utf-8",
+		"txt":  "text/plain; charset=utf-8",
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, f.Name))
+	c.Data(http.StatusOK, contentTypes[format], f.Data)
+}
+
+// validateChatFeedback trims the comment and returns a client-facing
+// message if the feedback is invalid, or "".
+func validateChatFeedback(req *models.ChatFeedbackRequest) string {
+	req.Comment = strings.TrimSpace(req.Comment)
+	if req.Rating != "up" && req.Rating != "down" {
+		return `rating must be "up" or "down"`
+	}
+	if len(req.Comment) > maxFeedbackCommentLength {
+		return "comment must be at most " + intToStr(maxFeedbackCommentLength) + " characters"
+	}
+	return ""
+}
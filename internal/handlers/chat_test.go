@@ -0,0 +1,96 @@
+// chat_test.go contains tests for chat answer feedback validation and
+// timestamp citations.
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestValidateChatFeedback verifies ratings, comment limits, and trimming.
+func TestValidateChatFeedback(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         models.ChatFeedbackRequest
+		wantErr     string
+		wantComment string
+	}{
+		{"thumbs up", models.ChatFeedbackRequest{Rating: "up"}, "", ""},
+		{"thumbs down with comment", models.ChatFeedbackRequest{Rating: "down", Comment: "  Missed the point.  "}, "", "Missed the point."},
+		{"unknown rating", models.ChatFeedbackRequest{Rating: "meh"}, "rating must be", ""},
+		{"comment too long", models.ChatFeedbackRequest{Rating: "up", Comment: strings.Repeat("c", maxFeedbackCommentLength+1)}, "comment must be", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := validateChatFeedback(&tt.req)
+			if tt.wantErr != "" {
+				if !strings.Contains(msg, tt.wantErr) {
+					t.Fatalf("validateChatFeedback() error = %q, want %q", msg, tt.wantErr)
+				}
+				return
+			}
+			if msg != "" {
+				t.Fatalf("validateChatFeedback() error = %q", msg)
+			}
+			if tt.req.Comment != tt.wantComment {
+				t.Errorf("comment = %q, want %q", tt.req.Comment, tt.wantComment)
+			}
+		})
+	}
+}
+
+// TestLinkCitations verifies timestamps cited in answers about a YouTube
+// transcript link to that moment, skipping impossible or already linked
+// ones and anything that isn't an answer about a video.
+func TestLinkCitations(t *testing.T) {
+	video := &chatTarget{YouTubeID: "abc", Duration: 4000}
+	tests := []struct {
+		name       string
+		target     *chatTarget
+		msg        models.TranscriptChatMessage
+		wantLinked string
+		wantSecs   []int
+	}{
+		{
+			name:       "cited",
+			target:     video,
+			msg:        models.TranscriptChatMessage{Role: "assistant", Content: "Select waits [12:34], then returns [1:02:03]."},
+			wantLinked: "Select waits [12:34](https://www.youtube.com/watch?v=abc&t=754s), then returns [1:02:03](https://www.youtube.com/watch?v=abc&t=3723s).",
+			wantSecs:   []int{754, 3723},
+		},
+		{
+			name:   "impossible or linked",
+			target: video,
+			msg:    models.TranscriptChatMessage{Role: "assistant", Content: "At [12:75], [2:00:00], and [0:05](https://youtu.be/abc)."},
+		},
+		{
+			name:   "question",
+			target: video,
+			msg:    models.TranscriptChatMessage{Role: "user", Content: "What happens at [12:34]?"},
+		},
+		{
+			name:   "not a video",
+			target: &chatTarget{ItemType: "pdf"},
+			msg:    models.TranscriptChatMessage{Role: "assistant", Content: "See [12:34]."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.target.linkCitations(&tt.msg)
+			if tt.msg.LinkedContent != tt.wantLinked {
+				t.Errorf("linked_content = %q, want %q", tt.msg.LinkedContent, tt.wantLinked)
+			}
+			var secs []int
+			for _, cite := range tt.msg.Citations {
+				secs = append(secs, cite.Seconds)
+			}
+			if !reflect.DeepEqual(secs, tt.wantSecs) {
+				t.Errorf("cited seconds = %v, want %v", secs, tt.wantSecs)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// compare.go compares 2-5 items — transcripts, recordings, PDFs — side by
+// side: common themes, contradictions, and points unique to each source,
+// for comparing earnings calls or competing product reviews.
+//
+// Comparisons aren't stored; repeats are answered from the AI completion
+// cache.
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// comparisonResponse is a comparison with the items its source numbers
+// refer to.
+type comparisonResponse struct {
+	Sources []models.ComparedItem `json:"sources"`
+	*summary.Comparison
+}
+
+// CompareItems compares the content of several items.
+// POST /api/v1/analysis/compare
+//
+// The caller must be able to view every item, and each must be completed.
+func (h *Handler) CompareItems(c *gin.Context) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.CompareItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Request body must be JSON with 2-5 items, each an item_type (transcript, audio, pdf) and item_id",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	seen := make(map[models.ItemRef]bool)
+	for _, ref := range req.Items {
+		if seen[ref] {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Each item can only be compared once: " + ref.ItemID,
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		seen[ref] = true
+	}
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	sources := make([]summary.CompareSource, len(req.Items))
+	items := make([]models.ComparedItem, len(req.Items))
+	for i, ref := range req.Items {
+		item, apiKeyID, userID, err := h.loadItem(c.Request.Context(), ref.ItemType, ref.ItemID)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				log.Printf("Failed to load %s %s: %v", ref.ItemType, ref.ItemID, err)
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+					Error:   "database_error",
+					Message: "Failed to load items",
+					Code:    http.StatusInternalServerError,
+				})
+				return
+			}
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Item not found: " + ref.ItemID,
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		if !h.canViewItem(c, apiKeyID, userID) {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "forbidden",
+				Message: "You can only compare your own or your organization's items",
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+
+		src, title, ready := compareSource(item)
+		if !ready {
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Error:   "not_ready",
+				Message: "Item " + ref.ItemID + " hasn't finished processing",
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+		sources[i] = src
+		items[i] = models.ComparedItem{Number: i + 1, ItemType: ref.ItemType, ItemID: ref.ItemID, Title: title}
+	}
+
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	comparison, err := h.Summarizer.CompareSources(ctx, sources, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Comparison of %d items failed: %v", len(sources), err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to compare items: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, comparisonResponse{Sources: items, Comparison: comparison})
+}
+
+// compareSource returns an item's text and label for a comparison, and its
+// title; ready is false until the item has finished processing.
+func compareSource(item any) (src summary.CompareSource, title string, ready bool) {
+	switch it := item.(type) {
+	case *models.Transcript:
+		title = it.Title
+		if title == "" {
+			title = it.YouTubeID
+		}
+		src = summary.CompareSource{Label: title + " (YouTube video)", Text: it.TranscriptText}
+		ready = it.Status == models.StatusCompleted
+	case *models.AudioTranscription:
+		title = it.DisplayName()
+		src = summary.CompareSource{Label: title + " (audio recording)", Text: it.TranscriptText}
+		ready = it.Status == "completed"
+	case *models.PDFExtraction:
+		title = it.DisplayName()
+		src = summary.CompareSource{Label: title + " (PDF document)", Text: it.TextContent}
+		ready = it.Status == "completed"
+	}
+	return src, title, ready && src.Text != ""
+}
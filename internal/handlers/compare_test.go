@@ -0,0 +1,48 @@
+// compare_test.go tests reading items' content for comparisons.
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+func TestCompareSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		item      any
+		wantLabel string
+		wantReady bool
+	}{
+		{
+			name:      "transcript",
+			item:      &models.Transcript{Title: "Q3 call", Status: models.StatusCompleted, TranscriptText: "text"},
+			wantLabel: "Q3 call (YouTube video)",
+			wantReady: true,
+		},
+		{
+			name:      "untitled transcript",
+			item:      &models.Transcript{YouTubeID: "abc", Status: models.StatusCompleted, TranscriptText: "text"},
+			wantLabel: "abc (YouTube video)",
+			wantReady: true,
+		},
+		{
+			name:      "processing audio",
+			item:      &models.AudioTranscription{OriginalName: "call.mp3", Status: "processing"},
+			wantLabel: "call.mp3 (audio recording)",
+		},
+		{
+			name:      "empty pdf",
+			item:      &models.PDFExtraction{OriginalName: "review.pdf", Status: "completed"},
+			wantLabel: "review.pdf (PDF document)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, _, ready := compareSource(tt.item)
+			if src.Label != tt.wantLabel || ready != tt.wantReady {
+				t.Errorf("compareSource() = %q, ready %v; want %q, ready %v", src.Label, ready, tt.wantLabel, tt.wantReady)
+			}
+		})
+	}
+}
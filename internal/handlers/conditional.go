@@ -0,0 +1,73 @@
+// conditional.go implements conditional GETs (ETag / If-None-Match and
+// Last-Modified / If-Modified-Since) for single-item endpoints, so clients
+// polling a transcript get an empty 304 until it actually changes.
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeConditionalJSON responds 200 with v as JSON, or 304 with no body if
+// the request's validators show the client already has this version.
+//
+// The ETag is a hash of the response body, so it changes whenever anything
+// the client would see changes, including fields that don't bump updated_at.
+func writeConditionalJSON(c *gin.Context, v any, lastModified time.Time) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		c.JSON(http.StatusOK, v)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	// Responses are per-caller, and must be revalidated before reuse
+	c.Header("Cache-Control", "private, no-cache")
+
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// notModified evaluates If-None-Match, falling back to If-Modified-Since
+// only when no If-None-Match was sent (RFC 9110 §13.2.2).
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatches(inm, etag)
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have whole-second precision
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches reports whether an If-None-Match list contains etag, using
+// the weak comparison GET requires (W/ prefixes are ignored).
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// conditional_test.go contains tests for conditional GET handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestEtagMatches verifies If-None-Match list parsing and weak comparison.
+func TestEtagMatches(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{`"xyz", "abc"`, true},
+		{`*`, true},
+		{`"xyz"`, false},
+		{`abc`, false},
+	}
+
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, `"abc"`); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+// TestWriteConditionalJSON verifies 200 responses carry validators and
+// matching validators produce an empty 304.
+func TestWriteConditionalJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	modified := time.Date(2026, 3, 4, 5, 6, 7, 500, time.UTC)
+	body := map[string]string{"id": "t1", "status": "completed"}
+
+	respond := func(header, value string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			c.Request.Header.Set(header, value)
+		}
+		writeConditionalJSON(c, body, modified)
+		c.Writer.WriteHeaderNow()
+		return w
+	}
+
+	first := respond("", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first response = %d with ETag %q, want 200 with an ETag", first.Code, etag)
+	}
+	if got := first.Header().Get("Last-Modified"); got != "Wed, 04 Mar 2026 05:06:07 GMT" {
+		t.Errorf("Last-Modified = %q", got)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"matching etag", "If-None-Match", etag, http.StatusNotModified},
+		{"stale etag", "If-None-Match", `"stale"`, http.StatusOK},
+		{"modified since is current", "If-Modified-Since", "Wed, 04 Mar 2026 05:06:07 GMT", http.StatusNotModified},
+		{"modified since is older", "If-Modified-Since", "Wed, 04 Mar 2026 05:06:06 GMT", http.StatusOK},
+		{"unparseable date", "If-Modified-Since", "yesterday", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := respond(tt.header, tt.value)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 has a body: %q", w.Body.String())
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("ETag = %q, want %q", w.Header().Get("ETag"), etag)
+			}
+		})
+	}
+}
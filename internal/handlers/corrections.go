@@ -0,0 +1,227 @@
+// corrections.go lets owners fix transcript text (auto-captions often get
+// names and jargon wrong) while keeping the extracted original and a
+// history of every correction.
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CorrectTranscript replaces a transcript's text with a manual correction.
+// PUT /api/v1/transcripts/:id/text
+//
+// Request body, either the whole text:
+//
+//	{"transcript_text": "...", "segments": [{"start": 0, "end": 2.5, "text": "..."}]}
+//
+// or edits to individual segments (transcript_text is rebuilt from them):
+//
+//	{"segment_edits": [{"index": 12, "text": "Kubernetes, not Cooper Netties"}]}
+//
+// Sending transcript_text without segments drops the stored cue timings,
+// since they no longer match the text. Existing summaries and study aids
+// are flagged stale. Responds with the updated transcript.
+func (h *Handler) CorrectTranscript(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.CorrectTranscriptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Request body must be JSON with transcript_text or segment_edits",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	t, ok := h.loadEditableTranscript(c, id)
+	if !ok {
+		return
+	}
+	if t.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Only completed transcripts can be corrected",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	text, segments, msg := applyCorrection(t.Segments, req)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	edit := &models.TranscriptEdit{
+		TranscriptID:   id,
+		TranscriptText: text,
+		Segments:       segments,
+		WordCount:      len(strings.Fields(text)),
+	}
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		edit.APIKeyID = &apiKey.ID
+	}
+	if user := middleware.GetUser(c); user != nil {
+		edit.UserID = &user.ID
+	}
+	if err := h.DB.CorrectTranscript(c.Request.Context(), edit); err != nil {
+		log.Printf("❌ Failed to correct transcript %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save correction",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if updated, err := h.DB.GetTranscript(c.Request.Context(), id); err == nil {
+		t = updated
+	}
+	c.JSON(http.StatusOK, t)
+}
+
+// GetTranscriptEdits returns the extracted original and every correction.
+// GET /api/v1/transcripts/:id/edits
+func (h *Handler) GetTranscriptEdits(c *gin.Context) {
+	id := c.Param("id")
+
+	t, ok := h.loadEditableTranscript(c, id)
+	if !ok {
+		return
+	}
+
+	edits, err := h.DB.ListTranscriptEdits(c.Request.Context(), id)
+	if err != nil {
+		log.Printf("❌ Failed to list edits for transcript %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load edit history",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if edits == nil {
+		edits = []models.TranscriptEdit{}
+	}
+
+	// Until the first correction, the current text is the original
+	history := models.TranscriptHistory{
+		TranscriptID:     id,
+		OriginalText:     t.TranscriptText,
+		OriginalSegments: t.Segments,
+		Edits:            edits,
+	}
+	if t.EditedAt != nil {
+		history.OriginalSegments = t.OriginalSegments
+		if t.OriginalText != nil {
+			history.OriginalText = *t.OriginalText
+		}
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// loadEditableTranscript fetches a transcript the caller owns, writing a
+// 404 or 403 and returning false otherwise.
+func (h *Handler) loadEditableTranscript(c *gin.Context, id string) (*models.Transcript, bool) {
+	t, err := h.DB.GetTranscript(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Transcript not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	if !h.canManageItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only correct your own transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return nil, false
+	}
+	return t, true
+}
+
+// applyCorrection works out the corrected text and segments from a request
+// and the transcript's current segments. It returns an error message, or ""
+// if the request is valid.
+func applyCorrection(current []byte, req models.CorrectTranscriptRequest) (string, []byte, string) {
+	hasText, hasEdits := req.TranscriptText != nil, len(req.SegmentEdits) > 0
+	if hasText == hasEdits {
+		return "", nil, "Send either transcript_text or segment_edits"
+	}
+
+	if hasText {
+		text := strings.TrimSpace(*req.TranscriptText)
+		if text == "" {
+			return "", nil, "transcript_text can't be empty"
+		}
+		if len(req.Segments) == 0 {
+			return text, nil, ""
+		}
+		for i, seg := range req.Segments {
+			if seg.Start < 0 || seg.End < seg.Start {
+				return "", nil, "segments[" + intToStr(i) + "] has an invalid time range"
+			}
+		}
+		segments, _ := json.Marshal(req.Segments)
+		return text, segments, ""
+	}
+
+	if len(req.Segments) > 0 {
+		return "", nil, "segments can only be sent with transcript_text"
+	}
+	var segments []models.Segment
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &segments); err != nil {
+			segments = nil
+		}
+	}
+	if len(segments) == 0 {
+		return "", nil, "This transcript has no segments; send transcript_text instead"
+	}
+
+	edited := map[int]bool{}
+	for _, e := range req.SegmentEdits {
+		if e.Index < 0 || e.Index >= len(segments) {
+			return "", nil, "segment index " + intToStr(e.Index) + " is out of range (0-" + intToStr(len(segments)-1) + ")"
+		}
+		if edited[e.Index] {
+			return "", nil, "segment " + intToStr(e.Index) + " is edited more than once"
+		}
+		edited[e.Index] = true
+		segments[e.Index].Text = strings.TrimSpace(e.Text)
+		if e.Speaker != nil {
+			segments[e.Index].Speaker = strings.TrimSpace(*e.Speaker)
+		}
+	}
+
+	// Rebuild the text the way the extractor joins caption cues, skipping
+	// segments that were blanked out
+	var parts []string
+	for _, seg := range segments {
+		if seg.Text != "" {
+			parts = append(parts, seg.Text)
+		}
+	}
+	text := strings.Join(parts, " ")
+	if text == "" {
+		return "", nil, "Edits would leave the transcript empty"
+	}
+	encoded, _ := json.Marshal(segments)
+	return text, encoded, ""
+}
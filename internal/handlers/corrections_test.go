@@ -0,0 +1,84 @@
+// corrections_test.go contains tests for transcript correction handling.
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestApplyCorrection verifies whole-text and segment-level corrections.
+func TestApplyCorrection(t *testing.T) {
+	current, _ := json.Marshal([]models.Segment{
+		{Start: 0, End: 2, Text: "hello from"},
+		{Start: 2, End: 4, Text: "cooper netties", Speaker: "Host"},
+		{Start: 4, End: 6, Text: "thanks"},
+	})
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name         string
+		current      json.RawMessage
+		req          models.CorrectTranscriptRequest
+		wantText     string
+		wantSegments bool
+		wantErr      string
+	}{
+		{"nothing sent", current, models.CorrectTranscriptRequest{}, "", false, "either"},
+		{"both sent", current, models.CorrectTranscriptRequest{TranscriptText: str("x"), SegmentEdits: []models.SegmentEdit{{Index: 0, Text: "y"}}}, "", false, "either"},
+		{"text drops timings", current, models.CorrectTranscriptRequest{TranscriptText: str("  fixed text ")}, "fixed text", false, ""},
+		{"text with segments", current, models.CorrectTranscriptRequest{TranscriptText: str("a b"), Segments: []models.Segment{{Start: 0, End: 1, Text: "a b"}}}, "a b", true, ""},
+		{"empty text", current, models.CorrectTranscriptRequest{TranscriptText: str(" ")}, "", false, "can't be empty"},
+		{"bad segment times", current, models.CorrectTranscriptRequest{TranscriptText: str("a"), Segments: []models.Segment{{Start: 3, End: 1, Text: "a"}}}, "", false, "invalid time range"},
+		{"segment edit", current, models.CorrectTranscriptRequest{SegmentEdits: []models.SegmentEdit{{Index: 1, Text: "Kubernetes"}}}, "hello from Kubernetes thanks", true, ""},
+		{"blanked segment skipped", current, models.CorrectTranscriptRequest{SegmentEdits: []models.SegmentEdit{{Index: 2, Text: ""}}}, "hello from cooper netties", true, ""},
+		{"index out of range", current, models.CorrectTranscriptRequest{SegmentEdits: []models.SegmentEdit{{Index: 3, Text: "x"}}}, "", false, "out of range"},
+		{"duplicate index", current, models.CorrectTranscriptRequest{SegmentEdits: []models.SegmentEdit{{Index: 0, Text: "a"}, {Index: 0, Text: "b"}}}, "", false, "more than once"},
+		{"no stored segments", nil, models.CorrectTranscriptRequest{SegmentEdits: []models.SegmentEdit{{Index: 0, Text: "a"}}}, "", false, "no segments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, segments, msg := applyCorrection(tt.current, tt.req)
+			if tt.wantErr != "" {
+				if !strings.Contains(msg, tt.wantErr) {
+					t.Fatalf("applyCorrection() error = %q, want %q", msg, tt.wantErr)
+				}
+				return
+			}
+			if msg != "" {
+				t.Fatalf("applyCorrection() error = %q", msg)
+			}
+			if text != tt.wantText {
+				t.Errorf("text = %q, want %q", text, tt.wantText)
+			}
+			if (len(segments) > 0) != tt.wantSegments {
+				t.Errorf("segments = %s, want present=%v", segments, tt.wantSegments)
+			}
+		})
+	}
+}
+
+// TestApplyCorrectionSpeaker verifies segment edits keep timings and can
+// relabel the speaker.
+func TestApplyCorrectionSpeaker(t *testing.T) {
+	current, _ := json.Marshal([]models.Segment{{Start: 1.5, End: 3, Text: "hi", Speaker: "Speaker 1"}})
+	speaker := "Dana"
+
+	_, raw, msg := applyCorrection(current, models.CorrectTranscriptRequest{
+		SegmentEdits: []models.SegmentEdit{{Index: 0, Text: "Hi!", Speaker: &speaker}},
+	})
+	if msg != "" {
+		t.Fatalf("applyCorrection() error = %q", msg)
+	}
+	var got []models.Segment
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("invalid segments JSON: %v", err)
+	}
+	want := models.Segment{Start: 1.5, End: 3, Text: "Hi!", Speaker: "Dana"}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("segments = %+v, want [%+v]", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+// cors_origins.go lets admins allow extra browser origins at runtime, e.g.
+// a wildcard for preview deployments, without redeploying the API.
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// AdminListCORSOrigins returns the allowed origins: the configured ones and
+// those added at runtime.
+// GET /api/v1/admin/cors-origins
+func (h *Handler) AdminListCORSOrigins(c *gin.Context) {
+	added, err := h.DB.ListCORSOrigins(c.Request.Context())
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list CORS origins",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if added == nil {
+		added = []models.CORSOrigin{}
+	}
+
+	configured := []string{}
+	if h.CORSOrigins != nil {
+		configured = h.CORSOrigins.Configured()
+	}
+
+	c.JSON(http.StatusOK, models.CORSOriginsResponse{Configured: configured, Added: added})
+}
+
+// AdminAddCORSOrigin allows an origin pattern. It takes effect on this
+// instance immediately and on others at their next reload.
+// POST /api/v1/admin/cors-origins
+func (h *Handler) AdminAddCORSOrigin(c *gin.Context) {
+	var req models.CORSOriginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "origin is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	origin, err := middleware.NormalizeOriginPattern(req.Origin)
+	if err == nil && origin == "*" {
+		// Credentials are allowed cross-origin, so "*" hands every site the
+		// user's session; that has to be a deliberate config choice
+		err = errAnyOrigin
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_origin",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	added, err := h.DB.AddCORSOrigin(c.Request.Context(), origin)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to add CORS origin",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	h.reloadCORSOrigins(c)
+
+	c.JSON(http.StatusCreated, added)
+}
+
+// AdminDeleteCORSOrigin removes an origin added at runtime.
+// DELETE /api/v1/admin/cors-origins/:id
+func (h *Handler) AdminDeleteCORSOrigin(c *gin.Context) {
+	if err := h.DB.DeleteCORSOrigin(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "CORS origin not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	h.reloadCORSOrigins(c)
+
+	c.JSON(http.StatusOK, gin.H{"message": "CORS origin removed"})
+}
+
+// errAnyOrigin refuses "*" as a runtime origin.
+var errAnyOrigin = errors.New(`"*" can only be set through CORS_ORIGIN`)
+
+// reloadCORSOrigins applies a change to this instance's origins right away.
+// On failure the periodic reload catches up.
+func (h *Handler) reloadCORSOrigins(c *gin.Context) {
+	if h.CORSOrigins == nil {
+		return
+	}
+	if err := h.CORSOrigins.Reload(c.Request.Context(), h.DB); err != nil {
+		log.Printf("⚠️  Failed to reload CORS origins: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+// cors_origins_test.go contains tests for the runtime CORS origin endpoints.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestAdminAddCORSOriginValidates verifies bad origin patterns, and "*",
+// are rejected before anything is stored.
+func TestAdminAddCORSOriginValidates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	tests := []struct {
+		name      string
+		body      string
+		wantError string
+	}{
+		{"missing origin", `{}`, "invalid_request"},
+		{"not an origin", `{"origin":"example.com"}`, "invalid_origin"},
+		{"wildcard over TLD", `{"origin":"https://*.app"}`, "invalid_origin"},
+		{"any origin", `{"origin":"*"}`, "invalid_origin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/cors-origins", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.AdminAddCORSOrigin(c)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", w.Code)
+			}
+			if !strings.Contains(w.Body.String(), `"error":"`+tt.wantError+`"`) {
+				t.Errorf("body = %s, want error %q", w.Body.String(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// csv.go renders list views as CSV for spreadsheet reporting.
+//
+// GET /api/v1/transcripts?format=csv and GET /api/v1/audio/transcriptions?format=csv
+// return one metadata row per item instead of JSON. Filters still apply, but
+// pagination doesn't — the export covers every matching row, up to
+// database.MaxListExportRows.
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// csvExcerptLength is how many characters of a summary go in the excerpt column.
+const csvExcerptLength = 200
+
+// exportTranscriptsCSV writes every transcript matching params as CSV.
+func (h *Handler) exportTranscriptsCSV(c *gin.Context, params models.TranscriptListParams) {
+	ctx := c.Request.Context()
+
+	// ListTranscripts pages at most 100 rows at a time, so walk the pages
+	// by cursor (the export always starts from the first page)
+	params.PerPage = 100
+	params.Cursor = ""
+	var transcripts []models.Transcript
+	for len(transcripts) < database.MaxListExportRows {
+		batch, _, err := h.DB.ListTranscripts(ctx, params)
+		if err != nil {
+			log.Printf("❌ Failed to list transcripts for CSV: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to list transcripts",
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		transcripts = append(transcripts, batch...)
+		if params.Cursor = database.NextTranscriptCursor(params, batch); params.Cursor == "" {
+			break
+		}
+	}
+	if len(transcripts) > database.MaxListExportRows {
+		transcripts = transcripts[:database.MaxListExportRows]
+	}
+
+	ids := make([]string, len(transcripts))
+	for i, t := range transcripts {
+		ids[i] = t.ID
+	}
+	summaries, err := h.DB.GetLatestSummaryTexts(ctx, ids)
+	if err != nil {
+		// Non-fatal — export without excerpts rather than failing the report
+		log.Printf("⚠️  Failed to load summaries for CSV: %v", err)
+	}
+
+	rows := [][]string{{"id", "title", "channel", "duration_seconds", "word_count", "status", "created_at", "summary_excerpt"}}
+	for _, t := range transcripts {
+		rows = append(rows, []string{
+			t.ID,
+			t.Title,
+			t.ChannelName,
+			fmt.Sprintf("%d", t.Duration),
+			fmt.Sprintf("%d", t.WordCount),
+			string(t.Status),
+			t.CreatedAt.UTC().Format(time.RFC3339),
+			excerpt(summaries[t.ID], csvExcerptLength),
+		})
+	}
+
+	sendCSV(c, rows, "transcripts")
+}
+
+// exportAudioCSV writes every audio transcription matching params as CSV.
+func (h *Handler) exportAudioCSV(c *gin.Context, params models.AudioListParams) {
+	// Walked by cursor like exportTranscriptsCSV, and without text bodies,
+	// which the export doesn't use
+	params.PerPage = 100
+	params.Cursor = ""
+	params.IncludeText = false
+	var transcriptions []models.AudioTranscription
+	for len(transcriptions) < database.MaxListExportRows {
+		batch, _, err := h.DB.ListAudioTranscriptions(c.Request.Context(), params)
+		if err != nil {
+			log.Printf("Failed to list audio transcriptions for CSV: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to list audio transcriptions",
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		transcriptions = append(transcriptions, batch...)
+		if params.Cursor = database.NextAudioCursor(params, batch); params.Cursor == "" {
+			break
+		}
+	}
+	if len(transcriptions) > database.MaxListExportRows {
+		transcriptions = transcriptions[:database.MaxListExportRows]
+	}
+
+	rows := [][]string{{"id", "title", "content_type", "duration_seconds", "word_count", "status", "created_at", "summary_excerpt"}}
+	for _, at := range transcriptions {
+		rows = append(rows, []string{
+			at.ID,
+			at.OriginalName,
+			string(at.ContentType),
+			fmt.Sprintf("%.0f", at.Duration),
+			fmt.Sprintf("%d", at.WordCount),
+			at.Status,
+			at.CreatedAt.UTC().Format(time.RFC3339),
+			excerpt(at.SummaryText, csvExcerptLength),
+		})
+	}
+
+	sendCSV(c, rows, "audio-transcriptions")
+}
+
+// sendCSV writes rows as a dated CSV file download.
+func sendCSV(c *gin.Context, rows [][]string, name string) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = csvSafe(cell)
+		}
+		w.Write(row)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "export_error",
+			Message: "Failed to generate CSV export",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.csv", name, time.Now().Format("2006-01-02"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// csvSafe neutralizes spreadsheet formula injection. Titles come from
+// YouTube and uploaded file names, so a cell like "=HYPERLINK(...)" must
+// not be evaluated when the report is opened in Excel or Sheets. Plain
+// numbers such as "-1,200.50" are left alone so they stay numeric.
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		if _, err := strconv.ParseFloat(strings.ReplaceAll(cell, ",", ""), 64); err == nil {
+			return cell
+		}
+		return "'" + cell
+	}
+	return cell
+}
+
+// excerpt collapses whitespace and shortens text to at most n characters.
+func excerpt(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > n {
+		return strings.TrimSpace(string(r[:n-1])) + "…"
+	}
+	return text
+}
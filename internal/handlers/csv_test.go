@@ -0,0 +1,60 @@
+// csv_test.go contains tests for the list-view CSV helpers.
+package handlers
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCSVSafe verifies formula-like cells are neutralized for spreadsheets.
+func TestCSVSafe(t *testing.T) {
+	tests := []struct {
+		name string
+		cell string
+		want string
+	}{
+		{"plain text", "Weekly Sync", "Weekly Sync"},
+		{"formula", "=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"plus", "+1 555 0100", "'+1 555 0100"},
+		{"at sign", "@SUM(A1)", "'@SUM(A1)"},
+		{"empty", "", ""},
+		{"formula char mid-string is fine", "a=b", "a=b"},
+		{"negative number", "-1,200.50", "-1,200.50"},
+		{"minus formula", "-1+SUM(A1)", "'-1+SUM(A1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csvSafe(tt.cell); got != tt.want {
+				t.Errorf("csvSafe(%q) = %q, want %q", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExcerpt verifies summary excerpts are single-line and bounded.
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want string
+	}{
+		{"short text unchanged", "A short summary.", 200, "A short summary."},
+		{"newlines collapsed", "Line one.\n\n- Line two", 200, "Line one. - Line two"},
+		{"long text truncated", strings.Repeat("word ", 10), 12, "word word w…"},
+		{"multi-byte runes", "héllo wörld", 6, "héllo…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := excerpt(tt.text, tt.n)
+			if got != tt.want {
+				t.Errorf("excerpt() = %q, want %q", got, tt.want)
+			}
+			if len([]rune(got)) > tt.n {
+				t.Errorf("excerpt() has %d runes, limit %d", len([]rune(got)), tt.n)
+			}
+		})
+	}
+}
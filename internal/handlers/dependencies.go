@@ -0,0 +1,157 @@
+// dependencies.go probes the services the API depends on, for the deep
+// health check and the admin dependencies view.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// dependencyCheckTimeout bounds each dependency check.
+const dependencyCheckTimeout = 5 * time.Second
+
+// keyCheckTTL is how long API key check results are reused, so frequent
+// readiness probes don't call OpenRouter and OpenAI every time.
+const keyCheckTTL = time.Minute
+
+// minFreeTempBytes is the free space the temp directory needs for uploads
+// and downloaded audio.
+const minFreeTempBytes = 512 << 20
+
+// probeDependencies checks the database and its read replica, yt-dlp, ffmpeg, the OpenRouter
+// and OpenAI API keys, the worker queue, and temp disk space.
+func (h *Handler) probeDependencies(ctx context.Context) []models.DependencyStatus {
+	deps := []models.DependencyStatus{
+		checkDependency(ctx, "database", func(ctx context.Context) (string, error) {
+			return "", h.DB.HealthCheck(ctx)
+		}),
+	}
+
+	replica := models.DependencyStatus{Name: "database_replica", Status: "not_configured"}
+	if h.DB.HasReplica() {
+		replica = checkDependency(ctx, "database_replica", func(ctx context.Context) (string, error) {
+			lag, err := h.DB.ReplicaHealthCheck(ctx)
+			return "lag " + lag.Round(time.Millisecond).String(), err
+		})
+	}
+	deps = append(deps, replica)
+
+	ytDlp := models.DependencyStatus{Name: "yt-dlp", Status: "not_configured"}
+	if v, ok := h.Worker.Extractor().(interface {
+		Version(context.Context) (string, error)
+	}); ok {
+		ytDlp = checkDependency(ctx, "yt-dlp", v.Version)
+	}
+	deps = append(deps, ytDlp)
+
+	ffmpeg := models.DependencyStatus{Name: "ffmpeg", Status: "not_configured"}
+	if pre := h.Worker.AudioPreprocessor(); pre != nil {
+		ffmpeg = checkDependency(ctx, "ffmpeg", pre.Version)
+	}
+	deps = append(deps, ffmpeg)
+
+	openRouter := models.DependencyStatus{Name: "openrouter", Status: "not_configured"}
+	if h.Summarizer != nil && h.Summarizer.IsConfigured() {
+		openRouter = h.keyChecks.check(ctx, "openrouter", func(ctx context.Context) (string, error) {
+			return "API key valid", h.Summarizer.CheckKey(ctx)
+		})
+	}
+	openAI := models.DependencyStatus{Name: "openai", Status: "not_configured"}
+	if h.AudioTranscriber != nil && h.AudioTranscriber.IsConfigured() {
+		openAI = h.keyChecks.check(ctx, "openai", func(ctx context.Context) (string, error) {
+			return "API key valid", h.AudioTranscriber.CheckKey(ctx)
+		})
+	}
+	deps = append(deps, openRouter, openAI)
+
+	deps = append(deps,
+		checkDependency(ctx, "queue", func(context.Context) (string, error) {
+			return queueStatus(h.Worker.QueueSize(), h.Worker.QueueCapacity())
+		}),
+		checkDependency(ctx, "temp_disk", func(context.Context) (string, error) {
+			free, err := diskFree(os.TempDir())
+			if err != nil {
+				return "", err
+			}
+			return diskStatus(free, minFreeTempBytes)
+		}),
+	)
+	return deps
+}
+
+// checkDependency runs a dependency check with a timeout and reports its
+// outcome. check returns optional detail, such as a version.
+func checkDependency(ctx context.Context, name string, check func(context.Context) (string, error)) models.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	detail, err := check(ctx)
+	status := models.DependencyStatus{Name: name, Status: "ok", Detail: detail, Latency: time.Since(start).Milliseconds()}
+	if err != nil {
+		status.Status = "error"
+		status.Detail = err.Error()
+	}
+	return status
+}
+
+// queueStatus reports the worker queue as unhealthy once it's full, since
+// new jobs are then rejected.
+func queueStatus(depth, capacity int) (string, error) {
+	detail := fmt.Sprintf("%d/%d jobs queued", depth, capacity)
+	if depth >= capacity {
+		return "", errors.New("queue full: " + detail)
+	}
+	return detail, nil
+}
+
+// diskStatus reports free disk space as unhealthy below min bytes.
+func diskStatus(free, min uint64) (string, error) {
+	detail := fmt.Sprintf("%d MB free", free>>20)
+	if free < min {
+		return "", fmt.Errorf("low disk space: %s, need %d MB", detail, min>>20)
+	}
+	return detail, nil
+}
+
+// checkCache remembers recent check results for keyCheckTTL. A nil cache
+// runs every check.
+type checkCache struct {
+	mu      sync.Mutex
+	results map[string]cachedCheck
+}
+
+type cachedCheck struct {
+	status  models.DependencyStatus
+	checked time.Time
+}
+
+func newCheckCache() *checkCache {
+	return &checkCache{results: map[string]cachedCheck{}}
+}
+
+// check returns the cached result for name, or runs check and caches it.
+func (cc *checkCache) check(ctx context.Context, name string, check func(context.Context) (string, error)) models.DependencyStatus {
+	if cc == nil {
+		return checkDependency(ctx, name, check)
+	}
+
+	cc.mu.Lock()
+	cached, ok := cc.results[name]
+	cc.mu.Unlock()
+	if ok && time.Since(cached.checked) < keyCheckTTL {
+		return cached.status
+	}
+
+	status := checkDependency(ctx, name, check)
+	cc.mu.Lock()
+	cc.results[name] = cachedCheck{status: status, checked: time.Now()}
+	cc.mu.Unlock()
+	return status
+}
@@ -0,0 +1,73 @@
+// dependencies_test.go contains tests for dependency probes.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestCheckDependency verifies check outcomes map to statuses.
+func TestCheckDependency(t *testing.T) {
+	ok := checkDependency(context.Background(), "yt-dlp", func(context.Context) (string, error) {
+		return "2026.01.01", nil
+	})
+	if ok.Status != "ok" || ok.Detail != "2026.01.01" {
+		t.Errorf("passing check = %+v", ok)
+	}
+
+	failed := checkDependency(context.Background(), "database", func(context.Context) (string, error) {
+		return "", errors.New("connection refused")
+	})
+	if failed.Status != "error" || failed.Detail != "connection refused" {
+		t.Errorf("failing check = %+v", failed)
+	}
+}
+
+// TestCheckCache verifies key checks are reused within the TTL.
+func TestCheckCache(t *testing.T) {
+	calls := 0
+	check := func(context.Context) (string, error) {
+		calls++
+		return "", errors.New("invalid key")
+	}
+
+	cache := newCheckCache()
+	for i := 0; i < 3; i++ {
+		if got := cache.check(context.Background(), "openai", check); got.Status != "error" {
+			t.Errorf("check %d = %+v", i, got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("cached check ran %d times, want 1", calls)
+	}
+
+	var nilCache *checkCache
+	nilCache.check(context.Background(), "openai", check)
+	if calls != 2 {
+		t.Errorf("nil cache ran %d checks, want 2", calls)
+	}
+}
+
+// TestQueueAndDiskStatus verifies capacity thresholds.
+func TestQueueAndDiskStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{"queue has room", second(queueStatus(3, 100)), false},
+		{"queue full", second(queueStatus(100, 100)), true},
+		{"disk above minimum", second(diskStatus(1<<30, minFreeTempBytes)), false},
+		{"disk below minimum", second(diskStatus(100<<20, minFreeTempBytes)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", tt.err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func second(_ string, err error) error { return err }
@@ -0,0 +1,226 @@
+// digests.go handles weekly digests: a report on everything a user
+// processed in a week — top themes, every action item, notable quotes.
+//
+// Subscribers get one each week from the worker pool, by email and/or a
+// digest.ready webhook. Users can also build one on demand.
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+// Digest limits: how far back an on-demand digest can look, and how many
+// past digests are listed.
+const (
+	maxDigestDays    = 31
+	maxListedDigests = 52
+)
+
+// GetDigestSettings returns the logged-in user's digest subscription.
+// GET /api/v1/me/digest
+func (h *Handler) GetDigestSettings(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.DB.GetDigestSettings(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to get digest settings",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateDigestSettings subscribes the logged-in user to weekly digests, or
+// unsubscribes them. The first digest goes out at the next check.
+// PUT /api/v1/me/digest
+func (h *Handler) UpdateDigestSettings(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	var settings models.DigestSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Request body must be JSON with enabled, email, and webhook",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if settings.Enabled && !settings.Email && !settings.Webhook {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Turn on email or webhook delivery, or set enabled to false",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if !settings.Enabled {
+		settings = models.DigestSettings{Email: true}
+	}
+
+	if err := h.DB.SaveDigestSettings(c.Request.Context(), user.ID, settings); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save digest settings",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// CreateDigest builds and stores a digest of the logged-in user's items
+// from the past week, or the past days given. It isn't delivered.
+// POST /api/v1/me/digests
+func (h *Handler) CreateDigest(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.CreateDigestRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	if req.Days < 0 || req.Days > maxDigestDays {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "days must be between 1 and " + strconv.Itoa(maxDigestDays),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+
+	period := worker.DigestPeriod
+	if req.Days > 0 {
+		period = time.Duration(req.Days) * 24 * time.Hour
+	}
+	end := time.Now()
+
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	digest, err := h.Worker.BuildDigest(ctx, user.ID, end.Add(-period), end, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Digest generation failed for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to build digest: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if digest.ItemCount == 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "no_items",
+			Message: "You have no completed items from this period",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	if err := h.DB.CreateDigest(c.Request.Context(), digest); err != nil {
+		log.Printf("Failed to save digest for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save digest",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, digest)
+}
+
+// ListDigests returns the logged-in user's digests from the past year,
+// newest first, including quiet weeks with no items.
+// GET /api/v1/me/digests
+func (h *Handler) ListDigests(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	digests, err := h.DB.ListDigests(c.Request.Context(), user.ID, maxListedDigests)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list digests",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, digests)
+}
+
+// GetDigest returns one of the logged-in user's digests.
+// GET /api/v1/me/digests/:id
+func (h *Handler) GetDigest(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	digest, err := h.DB.GetDigest(c.Request.Context(), user.ID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Digest not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to get digest",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, digest)
+}
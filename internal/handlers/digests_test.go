@@ -0,0 +1,59 @@
+// digests_test.go contains tests for weekly digest handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestDigestHandlersRequireUser verifies the digest endpoints reject
+// requests without a logged-in user before touching the database.
+func TestDigestHandlersRequireUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	handlers := map[string]gin.HandlerFunc{
+		"settings":        h.GetDigestSettings,
+		"update settings": h.UpdateDigestSettings,
+		"create":          h.CreateDigest,
+		"list":            h.ListDigests,
+		"get":             h.GetDigest,
+	}
+	for name, handle := range handlers {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/me/digests", nil)
+
+		handle(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, w.Code)
+		}
+	}
+}
+
+// TestUpdateDigestSettingsNeedsDelivery verifies a subscription must turn
+// on email or webhook delivery.
+func TestUpdateDigestSettingsNeedsDelivery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/me/digest",
+		strings.NewReader(`{"enabled": true, "email": false, "webhook": false}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user", &models.User{ID: "u1"})
+
+	h.UpdateDigestSettings(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, body = %s; want 400", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package handlers
+
+import "errors"
+
+// diskFree isn't implemented off Unix; the deep health check reports the
+// temp disk as an error there.
+func diskFree(path string) (uint64, error) {
+	return 0, errors.New("disk space check not supported on this platform")
+}
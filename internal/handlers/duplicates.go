@@ -0,0 +1,162 @@
+// duplicates.go finds transcripts of the same video that were extracted
+// more than once — a forced refresh, or different keys with the global
+// cache off — and merges them into one, keeping their summaries and chats.
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ListDuplicateTranscripts lists groups of the caller's transcripts that
+// share a youtube_id or identical extracted text.
+// GET /api/v1/transcripts/duplicates
+func (h *Handler) ListDuplicateTranscripts(c *gin.Context) {
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+
+	groups, err := h.DB.FindDuplicateTranscripts(c.Request.Context(), apiKeyID, userID)
+	if err != nil {
+		log.Printf("❌ Failed to find duplicate transcripts: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to find duplicate transcripts",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if groups == nil {
+		groups = []models.DuplicateGroup{}
+	}
+	c.JSON(http.StatusOK, models.DuplicateGroupsResponse{Groups: groups})
+}
+
+// MergeTranscripts folds duplicates into the transcript in the path: their
+// summaries, chats, study aids, and share links move to it, and the
+// duplicates are deleted. The caller must own every transcript involved.
+// POST /api/v1/transcripts/:id/merge
+func (h *Handler) MergeTranscripts(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.MergeTranscriptsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Request body must be JSON with duplicate_ids (1-50 transcript IDs)",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	keep, ok := h.loadMergeTranscript(c, id)
+	if !ok {
+		return
+	}
+	if keep.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Duplicates can only be merged into a completed transcript",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	var ids []string
+	seen := map[string]bool{id: true}
+	for _, dupID := range req.DuplicateIDs {
+		if seen[dupID] {
+			continue
+		}
+		seen[dupID] = true
+
+		dup, ok := h.loadMergeTranscript(c, dupID)
+		if !ok {
+			return
+		}
+		if apiErr := checkDuplicate(keep, dup); apiErr != nil {
+			c.JSON(apiErr.Code, *apiErr)
+			return
+		}
+		ids = append(ids, dupID)
+	}
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "duplicate_ids must name transcripts other than the one kept",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.DB.MergeTranscripts(c.Request.Context(), id, ids); err != nil {
+		log.Printf("❌ Failed to merge transcripts into %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to merge transcripts",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if updated, err := h.DB.GetTranscript(c.Request.Context(), id); err == nil {
+		keep = updated
+	}
+	c.JSON(http.StatusOK, models.MergeTranscriptsResponse{Transcript: *keep, MergedIDs: ids})
+}
+
+// loadMergeTranscript loads a transcript the caller may merge, writing the
+// error response if there isn't one.
+func (h *Handler) loadMergeTranscript(c *gin.Context, id string) (*models.Transcript, bool) {
+	t, err := h.DB.GetTranscript(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Transcript not found: " + id,
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	if !h.canManageItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only merge your own transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return nil, false
+	}
+	return t, true
+}
+
+// checkDuplicate reports why dup can't be merged into keep, or nil if it
+// can: it must be finished, copy the same video or text, and match keep's
+// redaction, so merging never brings back PII a redacted copy masked.
+func checkDuplicate(keep, dup *models.Transcript) *models.ErrorResponse {
+	switch {
+	case dup.Status != models.StatusCompleted && dup.Status != models.StatusFailed:
+		return &models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Transcript " + dup.ID + " is still being extracted",
+			Code:    http.StatusConflict,
+		}
+	case (dup.Redaction != nil) != (keep.Redaction != nil):
+		return &models.ErrorResponse{
+			Error:   "redaction_mismatch",
+			Message: "Transcript " + dup.ID + " and the kept transcript differ in PII redaction",
+			Code:    http.StatusBadRequest,
+		}
+	case dup.YouTubeID != keep.YouTubeID &&
+		(dup.ContentHash == nil || keep.ContentHash == nil || *dup.ContentHash != *keep.ContentHash):
+		return &models.ErrorResponse{
+			Error:   "not_duplicate",
+			Message: "Transcript " + dup.ID + " isn't a copy of the same video",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
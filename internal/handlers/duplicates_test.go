@@ -0,0 +1,40 @@
+// duplicates_test.go contains tests for merging duplicate transcripts.
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestCheckDuplicate verifies only finished copies of the same video or
+// text, redacted alike, can be merged into a transcript.
+func TestCheckDuplicate(t *testing.T) {
+	hash, other := "h1", "h2"
+	keep := &models.Transcript{ID: "keep", YouTubeID: "abc", Status: models.StatusCompleted, ContentHash: &hash}
+
+	tests := []struct {
+		name      string
+		dup       models.Transcript
+		wantError string
+	}{
+		{name: "same video", dup: models.Transcript{YouTubeID: "abc", Status: models.StatusCompleted, ContentHash: &other}},
+		{name: "failed copy", dup: models.Transcript{YouTubeID: "abc", Status: models.StatusFailed}},
+		{name: "same text", dup: models.Transcript{YouTubeID: "xyz", Status: models.StatusCompleted, ContentHash: &hash}},
+		{name: "different video", dup: models.Transcript{YouTubeID: "xyz", Status: models.StatusCompleted, ContentHash: &other}, wantError: "not_duplicate"},
+		{name: "unhashed", dup: models.Transcript{YouTubeID: "xyz", Status: models.StatusFailed}, wantError: "not_duplicate"},
+		{name: "processing", dup: models.Transcript{YouTubeID: "abc", Status: models.StatusProcessing}, wantError: "not_ready"},
+		{name: "redacted", dup: models.Transcript{YouTubeID: "abc", Status: models.StatusCompleted, Redaction: []byte(`{}`)}, wantError: "redaction_mismatch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := checkDuplicate(keep, &tt.dup)
+			switch {
+			case tt.wantError == "" && apiErr != nil:
+				t.Errorf("unexpected error: %+v", apiErr)
+			case tt.wantError != "" && (apiErr == nil || apiErr.Error != tt.wantError):
+				t.Errorf("error = %+v, want %s", apiErr, tt.wantError)
+			}
+		})
+	}
+}
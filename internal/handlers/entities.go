@@ -0,0 +1,260 @@
+// entities.go extracts the people, companies, products, and places items
+// mention, and lists them, so content can be browsed by who and what it
+// discusses.
+//
+// Entities are shared across all items; listings only count the items the
+// caller can see.
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// ExtractTranscriptEntities finds the entities a transcript mentions.
+// POST /api/v1/transcripts/:id/entities
+func (h *Handler) ExtractTranscriptEntities(c *gin.Context) {
+	h.extractEntities(c, "transcript")
+}
+
+// ExtractAudioEntities finds the entities an audio transcription mentions.
+// POST /api/v1/audio/transcriptions/:id/entities
+func (h *Handler) ExtractAudioEntities(c *gin.Context) {
+	h.extractEntities(c, "audio")
+}
+
+// ExtractPDFEntities finds the entities a PDF extraction mentions.
+// POST /api/v1/pdf/extractions/:id/entities
+func (h *Handler) ExtractPDFEntities(c *gin.Context) {
+	h.extractEntities(c, "pdf")
+}
+
+// extractEntities is the shared implementation of the Extract*Entities
+// handlers. The entities found replace the item's earlier ones.
+//
+// Request body (optional):
+//
+//	{"model": "openai/gpt-4o-mini", "force_refresh": false}
+func (h *Handler) extractEntities(c *gin.Context, itemType string) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.ExtractEntitiesRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	fillDefault(&req.Model, h.aiDefaults(c).Model)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	id := c.Param("id")
+	item, apiKeyID, userID, err := h.loadItem(c.Request.Context(), itemType, id)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Failed to load %s %s: %v", itemType, id, err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to load item",
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Item not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, apiKeyID, userID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only extract entities from your own or your organization's items",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	src, title, ready := compareSource(item)
+	if !ready {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Item hasn't finished processing",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	found, model, err := h.Summarizer.ExtractEntities(ctx, title, src.Text, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+	})
+	if err != nil {
+		log.Printf("Entity extraction failed for %s %s: %v", itemType, id, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to extract entities: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	entities := make([]models.ItemEntity, len(found))
+	for i, e := range found {
+		entities[i] = models.ItemEntity{Name: e.Name, Type: e.Type, Mentions: e.Mentions, Context: e.Context}
+	}
+	if err := h.DB.SaveItemEntities(c.Request.Context(), itemType, id, entities); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save entities",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ExtractEntitiesResponse{
+		ItemType:  itemType,
+		ItemID:    id,
+		Entities:  entities,
+		ModelUsed: model,
+	})
+}
+
+// ListEntities lists the entities mentioned in the caller's items, those
+// in the most items first.
+// GET /api/v1/entities?type=person&search=ada&page=1&per_page=20
+func (h *Handler) ListEntities(c *gin.Context) {
+	var params models.EntityListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid query parameters: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if params.Type != "" && !models.ValidEntityTypes[params.Type] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_type",
+			Message: "type must be one of: person, company, product, place",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+
+	entities, total, err := h.DB.ListEntities(c.Request.Context(), params)
+	if err != nil {
+		log.Printf("❌ Failed to list entities: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list entities",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	perPage := params.PerPage
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.Entity]{
+		Data:       entities,
+		Page:       responsePage(params.Page, ""),
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+	})
+}
+
+// ListEntityMentions lists the caller's items that mention an entity,
+// newest first, with how often and where each mentions it.
+// GET /api/v1/entities/:id/mentions?page=1&per_page=20
+func (h *Handler) ListEntityMentions(c *gin.Context) {
+	var params models.EntityMentionParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid query parameters: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	id := c.Param("id")
+	if _, err := h.DB.GetEntity(c.Request.Context(), id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Entity not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to get entity",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+
+	mentions, total, err := h.DB.ListEntityMentions(c.Request.Context(), id, params)
+	if err != nil {
+		log.Printf("❌ Failed to list mentions of entity %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list entity mentions",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	perPage := params.PerPage
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.EntityMention]{
+		Data:       mentions,
+		Page:       responsePage(params.Page, ""),
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+	})
+}
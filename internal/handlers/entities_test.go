@@ -0,0 +1,50 @@
+// entities_test.go contains tests for entity extraction and listing.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestExtractEntitiesRequiresAI verifies extraction is refused when AI
+// generation isn't configured, for every item type.
+func TestExtractEntitiesRequiresAI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	for name, handle := range map[string]gin.HandlerFunc{
+		"transcript": h.ExtractTranscriptEntities,
+		"audio":      h.ExtractAudioEntities,
+		"pdf":        h.ExtractPDFEntities,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/transcripts/t1/entities", nil)
+
+		handle(c)
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: status = %d, want 503", name, w.Code)
+		}
+	}
+}
+
+// TestListEntitiesRejectsUnknownType verifies the type filter is checked
+// before touching the database.
+func TestListEntitiesRejectsUnknownType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/entities?type=date", nil)
+
+	h.ListEntities(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, body = %s; want 400", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,75 @@
+// epub.go extracts EPUB e-books uploaded to POST /api/v1/pdf/extract.
+//
+// Books are stored as extractions like PDFs, with each chapter as a page,
+// so page lookups, chat, summaries, and exports work the same way.
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/epub"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+)
+
+// extractEPUB reads an uploaded EPUB and saves its text, one page per
+// chapter, with the chapter titles as the outline.
+func (h *Handler) extractEPUB(c *gin.Context, r io.ReaderAt, size int64, originalName string, redacted bool) (*models.PDFExtraction, *models.ErrorResponse) {
+	start := time.Now()
+	book, err := epub.Read(r, size)
+	if errors.Is(err, epub.ErrInvalid) {
+		return nil, &models.ErrorResponse{
+			Error:   "invalid_epub",
+			Message: "The uploaded file does not appear to be a valid EPUB: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if err != nil {
+		log.Printf("EPUB extraction failed for %s: %v", originalName, err)
+		return nil, &models.ErrorResponse{
+			Error:   "extraction_failed",
+			Message: "EPUB text extraction failed: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	var apiKeyID *string
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		apiKeyID = &apiKey.ID
+	}
+
+	pages := make([]string, len(book.Chapters))
+	outline := make([]pdfservice.OutlineItem, len(book.Chapters))
+	for i, ch := range book.Chapters {
+		pages[i] = ch.Text
+		outline[i] = pdfservice.OutlineItem{Title: ch.Title, Page: i + 1}
+	}
+	text := pdfservice.JoinPages(pages)
+
+	pe := &models.PDFExtraction{
+		Filename:     uuid.New().String() + ".epub",
+		OriginalName: originalName,
+		SourceType:   models.SourceEPUB,
+		PageCount:    len(pages),
+		TextContent:  text,
+		WordCount:    len(strings.Fields(text)),
+		Status:       "completed",
+		APIKeyID:     apiKeyID,
+		Usage:        models.ProcessingUsage{ProcessingMS: time.Since(start).Milliseconds()}.JSON(),
+	}
+	if len(pages) > 0 {
+		pe.FirstPage, pe.LastPage = 1, len(pages)
+	}
+	setPDFStructure(pe, pdfservice.DocumentInfo{Title: book.Title, Author: book.Author}, outline)
+	h.saveExtraction(c, pe, redacted)
+	return pe, nil
+}
@@ -0,0 +1,117 @@
+// events.go streams job status changes over Server-Sent Events.
+//
+// Instead of polling GET /transcripts/:id every couple of seconds, a
+// frontend opens one EventSource and gets a "status" event each time one
+// of its jobs moves between pending, processing, completed, and failed.
+//
+// Go Pattern: The worker pool publishes to an in-memory broker; each
+// connection subscribes with a filter and forwards what it receives.
+// Events only exist in this process, so clients should load current state
+// over REST first and treat the stream as a change feed.
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/events"
+)
+
+// heartbeatInterval keeps idle connections from being closed by proxies.
+const heartbeatInterval = 25 * time.Second
+
+// StreamEvents streams status changes for the caller's jobs.
+// GET /api/v1/events
+//
+// Optional query params:
+//
+//	?ids=<id>,<id>  only stream events for these records
+func (h *Handler) StreamEvents(c *gin.Context) {
+	var apiKeyID, userID string
+	if key := middleware.GetAPIKey(c); key != nil {
+		apiKeyID = key.ID
+	} else if user := middleware.GetUser(c); user != nil {
+		userID = user.ID
+	} else {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var ids []string
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	ch, unsubscribe := h.Worker.Events().Subscribe(eventFilter(apiKeyID, userID, ids))
+	defer unsubscribe()
+
+	// The server's WriteTimeout would otherwise cut the stream off after a
+	// minute. Not every ResponseWriter supports deadlines, which is fine.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Stop nginx from buffering the stream
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case e, ok := <-ch:
+			if !ok {
+				return false
+			}
+			c.SSEvent("status", e)
+		case <-heartbeat.C:
+			// Lines starting with ":" are comments; EventSource ignores them.
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// eventFilter decides which events a subscriber sees. Callers always get
+// events for jobs they own. Jobs with no owner (which anyone can already
+// read over REST) are only streamed when asked for by ID, so one caller
+// doesn't see everyone else's anonymous work. A non-empty ids list also
+// narrows the stream to just those records.
+func eventFilter(apiKeyID, userID string, ids []string) func(events.Event) bool {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	return func(e events.Event) bool {
+		if len(wanted) > 0 && !wanted[e.ID] {
+			return false
+		}
+		if e.APIKeyID == nil && e.UserID == nil {
+			return wanted[e.ID]
+		}
+		if apiKeyID != "" && e.APIKeyID != nil && *e.APIKeyID == apiKeyID {
+			return true
+		}
+		return userID != "" && e.UserID != nil && *e.UserID == userID
+	}
+}
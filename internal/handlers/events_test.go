@@ -0,0 +1,40 @@
+// events_test.go contains tests for the SSE stream's event filter.
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/events"
+)
+
+// TestEventFilter verifies callers only see their own jobs, plus unowned
+// jobs they explicitly asked for.
+func TestEventFilter(t *testing.T) {
+	keyA, keyB, userA := "key-a", "key-b", "user-a"
+
+	tests := []struct {
+		name     string
+		apiKeyID string
+		userID   string
+		ids      []string
+		event    events.Event
+		want     bool
+	}{
+		{"own API key job", keyA, "", nil, events.Event{ID: "1", APIKeyID: &keyA}, true},
+		{"other API key job", keyA, "", nil, events.Event{ID: "1", APIKeyID: &keyB}, false},
+		{"own user job", "", userA, nil, events.Event{ID: "1", UserID: &userA}, true},
+		{"user does not match key-owned job", "", userA, nil, events.Event{ID: "1", APIKeyID: &keyA}, false},
+		{"unowned job not requested", keyA, "", nil, events.Event{ID: "1"}, false},
+		{"unowned job requested by id", "", userA, []string{"1"}, events.Event{ID: "1"}, true},
+		{"ids narrow own jobs", keyA, "", []string{"2"}, events.Event{ID: "1", APIKeyID: &keyA}, false},
+		{"ids don't unlock other owners", keyA, "", []string{"1"}, events.Event{ID: "1", APIKeyID: &keyB}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventFilter(tt.apiKeyID, tt.userID, tt.ids)(tt.event); got != tt.want {
+				t.Errorf("eventFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
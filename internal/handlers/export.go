@@ -3,8 +3,11 @@
 // Supported formats:
 //   - txt  — Plain text transcript
 //   - md   — Markdown with metadata header
+//   - obsidian — Obsidian note with YAML frontmatter (callouts=true adds the summary)
 //   - srt  — SubRip subtitle format with timestamps
+//   - vtt  — WebVTT captions with the source's real cue timings
 //   - json — Full JSON with all metadata
+//   - pdf  — Typeset PDF with a title page, summary, and transcript
 //
 // Go Pattern: Each export format is its own function. This makes it easy
 // to add new formats later — just add a case to the switch and a new
@@ -21,10 +24,17 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
 )
 
 // ExportTranscript exports a transcript in the requested format.
-// GET /api/v1/transcripts/:id/export?format=txt|md|srt|json
+// GET /api/v1/transcripts/:id/export?format=txt|md|obsidian|srt|vtt|json|pdf
+//
+// For vtt, add speakers=true to turn leading "Name:" labels into <v> voice tags.
+// For obsidian, add callouts=true to include the latest summary as callouts.
+// Add redact=true to mask emails, phone numbers, card numbers, and names;
+// the X-Redactions headers report what was masked.
 //
 // Response headers are set for file download:
 //   - Content-Type: appropriate MIME type
@@ -34,15 +44,20 @@ func (h *Handler) ExportTranscript(c *gin.Context) {
 	format := c.DefaultQuery("format", "txt")
 
 	// Validate format before doing any database work
-	validFormats := map[string]bool{"txt": true, "md": true, "srt": true, "json": true}
+	validFormats := map[string]bool{"txt": true, "md": true, "obsidian": true, "srt": true, "vtt": true, "json": true, "pdf": true}
 	if !validFormats[format] {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "invalid_format",
-			Message: "Supported formats: txt, md, srt, json",
+			Message: "Supported formats: txt, md, obsidian, srt, vtt, json, pdf",
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
+	redacted, apiErr := parseRedactFlag(c.Query("redact"))
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
 
 	// Get the transcript
 	t, err := h.DB.GetTranscript(c.Request.Context(), id)
@@ -54,6 +69,14 @@ func (h *Handler) ExportTranscript(c *gin.Context) {
 		})
 		return
 	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only export your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
 
 	// Only export completed transcripts
 	if t.Status != models.StatusCompleted {
@@ -73,6 +96,25 @@ func (h *Handler) ExportTranscript(c *gin.Context) {
 		filename = t.YouTubeID
 	}
 
+	// PDFs and Obsidian callouts include the most recent summary, if one
+	// has been generated
+	var latest *models.Summary
+	callouts := c.Query("callouts") == "true"
+	if format == "pdf" || (format == "obsidian" && callouts) {
+		if s, err := h.DB.GetLatestSummary(c.Request.Context(), t.ID); err == nil {
+			latest = s
+		}
+	}
+
+	if redacted {
+		r := h.redactor(c, t.TranscriptText)
+		redactTranscript(r, t)
+		if latest != nil {
+			redactSummary(r, latest)
+		}
+		setRedactionHeaders(c, r)
+	}
+
 	// Route to the appropriate formatter
 	// Go Pattern: Switch on the format string — clean and extensible.
 	switch format {
@@ -80,10 +122,16 @@ func (h *Handler) ExportTranscript(c *gin.Context) {
 		exportTXT(c, t, filename)
 	case "md":
 		exportMarkdown(c, t, filename)
+	case "obsidian":
+		sendObsidian(c, export.TranscriptObsidian(t, latest, callouts), filename)
 	case "srt":
 		exportSRT(c, t, filename)
+	case "vtt":
+		exportVTT(c, export.ParseSegments(t.Segments), c.Query("speakers") == "true", filename)
 	case "json":
 		exportJSON(c, t, filename)
+	case "pdf":
+		exportPDF(c, t, latest, filename)
 	}
 }
 
@@ -119,14 +167,20 @@ func exportMarkdown(c *gin.Context, t *models.Transcript, filename string) {
 
 // exportSRT returns the transcript in SubRip subtitle format.
 //
-// Since our transcripts don't have per-word timestamps (yt-dlp gives us
-// the full text), we generate approximate timestamps based on word count
-// and video duration. Each "cue" is roughly 10 words.
+// Transcripts extracted since segments were stored use the real caption
+// cues. Older ones only have the full text, so we generate approximate
+// timestamps based on word count and video duration. Each "cue" is
+// roughly 10 words.
 //
 // Go Pattern: This is a good example of "make it work with what you have."
-// Perfect timestamps would require parsing VTT cue data, but approximate
-// timestamps are useful for subtitle overlays and reading along.
+// Approximate timestamps are still useful for subtitle overlays and reading along.
 func exportSRT(c *gin.Context, t *models.Transcript, filename string) {
+	if segments := export.ParseSegments(t.Segments); len(segments) > 0 {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.srt"`, filename))
+		c.Data(http.StatusOK, "text/srt; charset=utf-8", export.SRT(segments))
+		return
+	}
+
 	var sb strings.Builder
 	words := strings.Fields(t.TranscriptText)
 
@@ -178,6 +232,29 @@ func exportSRT(c *gin.Context, t *models.Transcript, filename string) {
 	c.Data(http.StatusOK, "text/srt; charset=utf-8", []byte(sb.String()))
 }
 
+// sendObsidian writes an Obsidian note as a Markdown file download.
+func sendObsidian(c *gin.Context, note []byte, filename string) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.md"`, filename))
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", note)
+}
+
+// exportVTT returns WebVTT captions built from stored segments.
+// Unlike SRT, we don't fabricate timings — records processed before
+// segments were stored get an error asking for a re-run instead.
+func exportVTT(c *gin.Context, segments []models.Segment, speakers bool, filename string) {
+	if len(segments) == 0 {
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Error:   "no_timing_data",
+			Message: "No caption timings are stored for this item; re-process it to export VTT",
+			Code:    http.StatusUnprocessableEntity,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.vtt"`, filename))
+	c.Data(http.StatusOK, "text/vtt; charset=utf-8", export.VTT(segments, speakers))
+}
+
 // exportJSON returns the full transcript data as JSON.
 // This includes all metadata — useful for programmatic consumption.
 func exportJSON(c *gin.Context, t *models.Transcript, filename string) {
@@ -213,6 +290,28 @@ func exportJSON(c *gin.Context, t *models.Transcript, filename string) {
 	c.Data(http.StatusOK, "application/json; charset=utf-8", jsonBytes)
 }
 
+// exportPDF returns a typeset PDF: a title page with video metadata, then
+// the latest summary (if any), then the full transcript.
+func exportPDF(c *gin.Context, t *models.Transcript, s *models.Summary, filename string) {
+	sendPDF(c, export.TranscriptDocument(t, s), filename)
+}
+
+// sendPDF renders a document as PDF and writes it as a file download.
+func sendPDF(c *gin.Context, doc *document.Document, filename string) {
+	data, err := doc.PDF()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "export_error",
+			Message: "Failed to generate PDF export",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, filename))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
 // --- Helper Functions ---
 
 // formatSRTTime converts seconds to SRT timestamp format: HH:MM:SS,mmm
@@ -226,16 +325,7 @@ func formatSRTTime(seconds float64) string {
 
 // formatDuration converts seconds to a human-readable duration string.
 func formatDuration(seconds int) string {
-	h := seconds / 3600
-	m := (seconds % 3600) / 60
-	s := seconds % 60
-	if h > 0 {
-		return fmt.Sprintf("%dh %dm %ds", h, m, s)
-	}
-	if m > 0 {
-		return fmt.Sprintf("%dm %ds", m, s)
-	}
-	return fmt.Sprintf("%ds", s)
+	return export.FormatDuration(seconds)
 }
 
 // sanitizeFilename removes characters that aren't safe for filenames.
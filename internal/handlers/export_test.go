@@ -7,8 +7,15 @@
 package handlers
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
 )
 
 // TestFormatSRTTime verifies the SRT timestamp formatting.
@@ -133,3 +140,43 @@ func TestSanitizeFilename(t *testing.T) {
 		})
 	}
 }
+
+// TestExportPDF verifies format=pdf exports of transcripts and audio
+// transcriptions download as PDF files.
+func TestExportPDF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	transcript := &models.Transcript{ID: "t-1", Title: "Weekly Sync", TranscriptText: "Hello everyone.", Status: "completed"}
+	summary := &models.Summary{SummaryText: "A short meeting."}
+	audio := &models.AudioTranscription{ID: "a-1", OriginalName: "standup.mp3", TranscriptText: "Morning all.", Status: "completed"}
+
+	tests := []struct {
+		name     string
+		send     func(c *gin.Context)
+		filename string
+	}{
+		{"transcript", func(c *gin.Context) { exportPDF(c, transcript, summary, "Weekly Sync") }, "Weekly Sync.pdf"},
+		{"audio", func(c *gin.Context) { sendPDF(c, export.AudioDocument(audio), "standup_transcript") }, "standup_transcript.pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/export?format=pdf", nil)
+
+			tt.send(c)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+				t.Errorf("Content-Type = %q, want application/pdf", ct)
+			}
+			if !strings.HasPrefix(w.Body.String(), "%PDF-") {
+				t.Errorf("body starts with %q, want %%PDF-", w.Body.String()[:min(8, w.Body.Len())])
+			}
+			if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, tt.filename) {
+				t.Errorf("Content-Disposition = %q, want filename %s", cd, tt.filename)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// exports.go handles bulk ZIP export endpoints.
+//
+// Exports can be large (hundreds of PDFs), so the archive is built by the
+// worker pool. The client creates an export, polls its status, and then
+// downloads the ZIP from download_url once it's completed.
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+// maxExportItems caps how many items one export can contain.
+const maxExportItems = 500
+
+// CreateExport queues a bulk ZIP export.
+// POST /api/v1/exports
+//
+// Request body (pick one way to select items):
+//
+//	{"format": "pdf", "items": [{"type": "transcript", "id": "..."}, {"type": "audio", "id": "..."}]}
+//	{"format": "md", "filter": {"types": ["audio"], "date_from": "2026-01-01"}}
+//	{"format": "txt", "collection": "workspace"}
+func (h *Handler) CreateExport(c *gin.Context) {
+	var req models.CreateExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Provide 'format' and one of 'items', 'filter', or 'collection'",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if !export.Formats[req.Format] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Format must be one of: txt, md, json, pdf",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	selectors := 0
+	if len(req.Items) > 0 {
+		selectors++
+	}
+	if req.Filter != nil {
+		selectors++
+	}
+	if req.Collection != "" {
+		selectors++
+	}
+	if selectors != 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Provide exactly one of 'items', 'filter', or 'collection'",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	apiKey := middleware.GetAPIKey(c)
+	user := middleware.GetUser(c)
+
+	items, errResp := h.resolveExportItems(c, req, apiKey, user)
+	if errResp != nil {
+		c.JSON(errResp.Code, errResp)
+		return
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "no_items",
+			Message: "Nothing matched the export request",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if len(items) > maxExportItems {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "too_many_items",
+			Message: fmt.Sprintf("Maximum %d items per export", maxExportItems),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	itemsJSON, _ := json.Marshal(items)
+	e := &models.Export{
+		Status:    "pending",
+		Format:    req.Format,
+		Items:     itemsJSON,
+		ItemCount: len(items),
+	}
+	if apiKey != nil {
+		e.APIKeyID = &apiKey.ID
+	}
+	if user != nil {
+		e.UserID = &user.ID
+	}
+
+	if err := h.DB.CreateExport(c.Request.Context(), e); err != nil {
+		log.Printf("Failed to create export: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create export record",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	job := worker.Job{
+		ID:        e.ID,
+		Type:      worker.JobBulkExport,
+		Priority:  jobPriority(c),
+		CreatedAt: time.Now(),
+	}
+	if err := h.Worker.Submit(job); err != nil {
+		log.Printf("Failed to queue export job %s: %v", e.ID, err)
+		e.Status = "failed"
+		e.ErrorMessage = "Job queue is full, please try again later"
+		h.DB.UpdateExport(c.Request.Context(), e)
+
+		c.JSON(http.StatusServiceUnavailable, h.queueFull(c))
+		return
+	}
+
+	h.setQueueHeaders(c)
+	c.JSON(http.StatusAccepted, e)
+}
+
+// resolveExportItems turns an export request into a concrete list of items.
+func (h *Handler) resolveExportItems(c *gin.Context, req models.CreateExportRequest, apiKey *models.APIKey, user *models.User) ([]models.ExportItem, *models.ErrorResponse) {
+	switch {
+	case len(req.Items) > 0:
+		for i, item := range req.Items {
+			if !models.ValidExportItemTypes[item.Type] || item.ID == "" {
+				return nil, &models.ErrorResponse{
+					Error:   "invalid_item",
+					Message: "Item at index " + intToStr(i) + " needs a 'type' (transcript, audio, pdf) and an 'id'",
+					Code:    http.StatusBadRequest,
+				}
+			}
+		}
+		return req.Items, nil
+
+	case req.Filter != nil:
+		filter := *req.Filter
+		for _, t := range filter.Types {
+			if !models.ValidExportItemTypes[t] {
+				return nil, &models.ErrorResponse{
+					Error:   "invalid_filter",
+					Message: "Filter types must be transcript, audio, or pdf",
+					Code:    http.StatusBadRequest,
+				}
+			}
+		}
+
+		var err error
+		if filter.DateFrom, err = normalizeExportDate(filter.DateFrom, false); err == nil {
+			filter.DateTo, err = normalizeExportDate(filter.DateTo, true)
+		}
+		if err != nil {
+			return nil, &models.ErrorResponse{
+				Error:   "invalid_filter",
+				Message: "Dates must be YYYY-MM-DD or RFC 3339",
+				Code:    http.StatusBadRequest,
+			}
+		}
+
+		// Match only the caller's items: its key's (and organization's), or
+		// a logged-in user's own
+		var apiKeyID, userID *string
+		switch {
+		case apiKey != nil:
+			apiKeyID = &apiKey.ID
+		case user != nil:
+			userID = &user.ID
+		default:
+			return nil, &models.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Filter exports require an API key or a logged-in user",
+				Code:    http.StatusUnauthorized,
+			}
+		}
+		// Fetch one past the cap so an oversized filter is reported, not truncated
+		items, err := h.DB.ListExportItems(c.Request.Context(), filter, apiKeyID, userID, maxExportItems+1)
+		if err != nil {
+			log.Printf("Failed to resolve export filter: %v", err)
+			return nil, &models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to look up items",
+				Code:    http.StatusInternalServerError,
+			}
+		}
+		return items, nil
+
+	case req.Collection == "workspace":
+		userID := ""
+		if user != nil {
+			userID = user.ID
+		} else if apiKey != nil && apiKey.UserID != nil {
+			userID = *apiKey.UserID
+		}
+		if userID == "" {
+			return nil, &models.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Workspace exports require a logged-in user or an API key linked to one",
+				Code:    http.StatusUnauthorized,
+			}
+		}
+
+		saved, err := h.DB.GetWorkspaceItems(c.Request.Context(), userID)
+		if err != nil {
+			log.Printf("Failed to get workspace items for export: %v", err)
+			return nil, &models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to look up workspace items",
+				Code:    http.StatusInternalServerError,
+			}
+		}
+		items := make([]models.ExportItem, 0, len(saved))
+		for _, wi := range saved {
+			if models.ValidExportItemTypes[wi.ItemType] {
+				items = append(items, models.ExportItem{Type: wi.ItemType, ID: wi.ItemID})
+			}
+		}
+		return items, nil
+	}
+
+	return nil, &models.ErrorResponse{
+		Error:   "invalid_collection",
+		Message: "Collection must be 'workspace'",
+		Code:    http.StatusBadRequest,
+	}
+}
+
+// normalizeExportDate converts a YYYY-MM-DD or RFC 3339 date to RFC 3339.
+// A bare end date covers the whole day.
+func normalizeExportDate(value string, endOfDay bool) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return "", err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+// GetExport returns an export's status, with a download URL once it's ready.
+// GET /api/v1/exports/:id
+func (h *Handler) GetExport(c *gin.Context) {
+	e, ok := h.loadExport(c)
+	if !ok {
+		return
+	}
+
+	if e.Status == "completed" {
+		e.DownloadURL = "/api/v1/exports/" + e.ID + "/download"
+	}
+	c.JSON(http.StatusOK, e)
+}
+
+// DownloadExport streams a completed export's ZIP archive.
+// GET /api/v1/exports/:id/download
+func (h *Handler) DownloadExport(c *gin.Context) {
+	e, ok := h.loadExport(c)
+	if !ok {
+		return
+	}
+
+	if e.Status != "completed" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Export is " + e.Status,
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	prefix := "export"
+	if e.Kind == models.ExportKindAccount {
+		prefix = "account-export"
+	}
+	filename := fmt.Sprintf("%s-%s.zip", prefix, e.CreatedAt.Format("2006-01-02"))
+	c.FileAttachment(e.FilePath, filename)
+}
+
+// loadExport fetches the export named in the URL and checks the caller owns it.
+// On failure it writes the error response and returns false.
+func (h *Handler) loadExport(c *gin.Context) (*models.Export, bool) {
+	e, err := h.DB.GetExport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Export not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+
+	if !canAccessExport(c, e) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You don't have access to this export",
+			Code:    http.StatusForbidden,
+		})
+		return nil, false
+	}
+
+	return e, true
+}
+
+// canAccessExport reports whether the caller may see an export: an API key
+// sees its own exports, a user theirs. Account exports hold everything a
+// user owns, so only that user, logged in, can see them.
+func canAccessExport(c *gin.Context, e *models.Export) bool {
+	if e.Kind == models.ExportKindAccount {
+		user := middleware.GetUser(c)
+		return user != nil && e.UserID != nil && *e.UserID == user.ID
+	}
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		return e.APIKeyID == nil || *e.APIKeyID == apiKey.ID
+	}
+	if user := middleware.GetUser(c); user != nil {
+		return e.UserID == nil || *e.UserID == user.ID
+	}
+	return true
+}
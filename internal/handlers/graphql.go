@@ -0,0 +1,648 @@
+// graphql.go serves the GraphQL endpoint. It exposes the same records as the
+// REST API — transcripts, summaries, audio, PDFs, batches, and chat — so a
+// screen can fetch everything it needs (e.g. transcript → summaries → chat)
+// in one request.
+//
+// Go Pattern: The schema lives in schema.graphql and is executed by
+// github.com/graph-gophers/graphql-go, which handles parsing, validation,
+// variables, fragments, introspection, and the spec's error format. Each
+// GraphQL type is resolved by a struct that embeds its model: plain fields
+// are read straight off the model, and methods cover the rest (IDs, ints,
+// timestamps, raw JSON, and relations).
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+//go:embed schema.graphql
+var graphqlSDL string
+
+// graphqlMaxDepth caps how deeply selections can nest, so a single request
+// can't fan out into an unbounded number of database queries.
+const graphqlMaxDepth = 10
+
+// graphqlSchema is parsed once at startup; a resolver that doesn't match
+// the schema is a programming error, so it panics.
+var graphqlSchema = graphql.MustParseSchema(graphqlSDL, &graphqlRoot{},
+	graphql.UseFieldResolvers(),
+	graphql.UseStringDescriptions(),
+	graphql.MaxDepth(graphqlMaxDepth),
+	graphql.PanicHandler(graphqlPanics{}),
+)
+
+// graphqlRequestBody is the standard GraphQL-over-HTTP request body.
+type graphqlRequestBody struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQL executes a GraphQL query or mutation.
+// POST /api/graphql
+//
+// Request body:
+//
+//	{"query": "{ transcript(id: \"...\") { title summaries { summary_text } } }", "variables": {}}
+//
+// Field names match the REST JSON (snake_case). Errors are returned in the
+// standard "errors" array with a 200 status, as GraphQL clients expect.
+func (h *Handler) GraphQL(c *gin.Context) {
+	var req graphqlRequestBody
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Query) == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Provide a GraphQL 'query' in the request body",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if middleware.GetAPIKey(c) == nil && middleware.GetUser(c) == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	// Lists and chat threads belong to the API key, or without one to the
+	// user (lists also take in the keys linked to them)
+	apiKeyID, userID := chatOwner(c)
+	ctx := context.WithValue(c.Request.Context(), graphqlRequestKey{}, &graphqlRequest{
+		h: h, c: c, apiKeyID: apiKeyID, userID: userID,
+	})
+	c.JSON(http.StatusOK, graphqlSchema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+// graphqlRequest is what resolvers need from the HTTP request: the handler's
+// dependencies and the gin context, so they see the same API key or user
+// as the REST handlers.
+type graphqlRequest struct {
+	h        *Handler
+	c        *gin.Context
+	apiKeyID *string
+	userID   *string
+}
+
+type graphqlRequestKey struct{}
+
+// graphqlRequestFrom returns the request GraphQL stored in ctx.
+func graphqlRequestFrom(ctx context.Context) *graphqlRequest {
+	return ctx.Value(graphqlRequestKey{}).(*graphqlRequest)
+}
+
+// graphqlPanics reports resolver panics as an opaque error; the library
+// logs the panic and its stack.
+type graphqlPanics struct{}
+
+func (graphqlPanics) MakePanicError(ctx context.Context, value interface{}) *gqlerrors.QueryError {
+	return gqlerrors.Errorf("internal error")
+}
+
+// graphqlRoot resolves the Query and Mutation types.
+type graphqlRoot struct{}
+
+func (graphqlRoot) Transcript(ctx context.Context, args struct{ ID graphql.ID }) (*transcriptResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	t, err := r.h.DB.GetTranscript(ctx, string(args.ID))
+	if err != nil {
+		return nil, graphqlLoadError("transcript", string(args.ID), err)
+	}
+	if !r.h.canViewItem(r.c, t.APIKeyID, t.UserID) {
+		return nil, errors.New("you can only view your own or your organization's transcripts")
+	}
+	return &transcriptResolver{Transcript: *t, req: r}, nil
+}
+
+func (graphqlRoot) Transcripts(ctx context.Context, args struct {
+	Page, PerPage                   *int32
+	Status, Search, SortBy, SortDir *string
+}) (*transcriptPageResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	params := models.TranscriptListParams{
+		Page:        intArg(args.Page, 1),
+		PerPage:     intArg(args.PerPage, 20),
+		Status:      models.TranscriptStatus(stringArg(args.Status)),
+		Search:      stringArg(args.Search),
+		SortBy:      stringArg(args.SortBy),
+		SortDir:     stringArg(args.SortDir),
+		APIKeyID:    r.apiKeyID,
+		UserID:      r.userID,
+		IncludeText: true, // Clients pick fields, so text is only sent if selected
+	}
+	transcripts, total, err := r.h.DB.ListTranscripts(ctx, params)
+	if err != nil {
+		log.Printf("GraphQL: failed to list transcripts: %v", err)
+		return nil, errors.New("failed to list transcripts")
+	}
+	if params.PerPage < 1 || params.PerPage > 100 {
+		params.PerPage = 20
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	return &transcriptPageResolver{
+		data:       r.transcripts(transcripts),
+		page:       params.Page,
+		perPage:    params.PerPage,
+		totalItems: total,
+		totalPages: int(math.Ceil(float64(total) / float64(params.PerPage))),
+	}, nil
+}
+
+func (graphqlRoot) AudioTranscription(ctx context.Context, args struct{ ID graphql.ID }) (*audioResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	at, err := r.h.DB.GetAudioTranscription(ctx, string(args.ID))
+	if err != nil {
+		return nil, graphqlLoadError("audio transcription", string(args.ID), err)
+	}
+	if !r.h.canViewItem(r.c, at.APIKeyID, at.UserID) {
+		return nil, errors.New("you can only view your own or your organization's transcriptions")
+	}
+	return &audioResolver{AudioTranscription: *at, req: r}, nil
+}
+
+func (graphqlRoot) AudioTranscriptions(ctx context.Context, args struct{ Limit *int32 }) (*[]*audioResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	transcriptions, _, err := r.h.DB.ListAudioTranscriptions(ctx, models.AudioListParams{
+		PerPage:     clampLimit(intArg(args.Limit, 50)),
+		APIKeyID:    r.apiKeyID,
+		UserID:      r.userID,
+		IncludeText: true,
+	})
+	if err != nil {
+		log.Printf("GraphQL: failed to list audio transcriptions: %v", err)
+		return nil, errors.New("failed to list audio transcriptions")
+	}
+	out := make([]*audioResolver, len(transcriptions))
+	for i := range transcriptions {
+		out[i] = &audioResolver{AudioTranscription: transcriptions[i], req: r}
+	}
+	return &out, nil
+}
+
+func (graphqlRoot) PdfExtraction(ctx context.Context, args struct{ ID graphql.ID }) (*pdfResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	pe, err := r.h.DB.GetPDFExtraction(ctx, string(args.ID))
+	if err != nil {
+		return nil, graphqlLoadError("PDF extraction", string(args.ID), err)
+	}
+	if !r.h.canViewItem(r.c, pe.APIKeyID, pe.UserID) {
+		return nil, errors.New("you can only view your own or your organization's PDF extractions")
+	}
+	return &pdfResolver{PDFExtraction: *pe, req: r}, nil
+}
+
+func (graphqlRoot) PdfExtractions(ctx context.Context, args struct{ Limit *int32 }) (*[]*pdfResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	extractions, _, err := r.h.DB.ListPDFExtractions(ctx, models.PDFListParams{
+		PerPage:     clampLimit(intArg(args.Limit, 50)),
+		APIKeyID:    r.apiKeyID,
+		UserID:      r.userID,
+		IncludeText: true,
+	})
+	if err != nil {
+		log.Printf("GraphQL: failed to list PDF extractions: %v", err)
+		return nil, errors.New("failed to list PDF extractions")
+	}
+	out := make([]*pdfResolver, len(extractions))
+	for i := range extractions {
+		out[i] = &pdfResolver{PDFExtraction: extractions[i], req: r}
+	}
+	return &out, nil
+}
+
+func (graphqlRoot) Batch(ctx context.Context, args struct{ ID graphql.ID }) (*batchResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	id := string(args.ID)
+	// Same ownership check as GET /batches/:id, made before the recount so
+	// callers can't touch other tenants' batches
+	if ok, err := r.h.canViewBatch(r.c, id); err != nil {
+		log.Printf("GraphQL: failed to check batch %s: %v", id, err)
+		return nil, errors.New("failed to load batch")
+	} else if !ok {
+		return nil, errors.New("you can only view your own or your organization's batches")
+	}
+	// Same self-healing recount as GET /batches/:id
+	if err := r.h.DB.UpdateBatchCounts(ctx, id); err != nil {
+		log.Printf("GraphQL: failed to update batch counts: %v", err)
+	}
+	b, err := r.h.DB.GetBatch(ctx, id)
+	if err != nil {
+		return nil, graphqlLoadError("batch", id, err)
+	}
+	return &batchResolver{Batch: *b, req: r}, nil
+}
+
+// Mutations go through the same helpers as the REST endpoints, so
+// validation, ownership, and queueing behave identically.
+
+func (graphqlRoot) CreateTranscript(ctx context.Context, args struct{ URL, VideoID *string }) (*transcriptResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	req := models.CreateTranscriptRequest{URL: stringArg(args.URL), VideoID: stringArg(args.VideoID)}
+	if req.URL == "" && req.VideoID == "" {
+		return nil, errors.New("provide either 'url' or 'video_id'")
+	}
+	if _, _, apiErr := middleware.CheckQuota(ctx, r.h.DB, middleware.GetAPIKey(r.c), r.h.OwnerAPIKeyID, r.h.OwnerAPIKeyPrefix); apiErr != nil {
+		return nil, errors.New(apiErr.Message)
+	}
+	t, apiErr, _ := r.h.startTranscript(r.c, req)
+	if apiErr != nil {
+		return nil, errors.New(apiErr.Message)
+	}
+	return &transcriptResolver{Transcript: *t, req: r}, nil
+}
+
+func (graphqlRoot) CreateBatch(ctx context.Context, args struct{ URLs []string }) (*batchResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	apiKey := middleware.GetAPIKey(r.c)
+	if apiErr := middleware.CheckFeature(apiKey, models.FeatureBatch, r.h.OwnerAPIKeyID, r.h.OwnerAPIKeyPrefix); apiErr != nil {
+		return nil, errors.New(apiErr.Message)
+	}
+	if _, _, apiErr := middleware.CheckQuota(ctx, r.h.DB, apiKey, r.h.OwnerAPIKeyID, r.h.OwnerAPIKeyPrefix); apiErr != nil {
+		return nil, errors.New(apiErr.Message)
+	}
+	resp, apiErr, _ := r.h.startBatch(r.c, models.CreateBatchRequest{URLs: args.URLs})
+	if apiErr != nil {
+		return nil, errors.New(apiErr.Message)
+	}
+	return &batchResolver{Batch: resp.Batch, req: r}, nil
+}
+
+func (graphqlRoot) CreateSummary(ctx context.Context, args struct {
+	TranscriptID         graphql.ID
+	Model, Length, Style *string
+	FallbackModels       *[]string
+	ForceRefresh         *bool
+}) (*summaryJobResolver, error) {
+	r := graphqlRequestFrom(ctx)
+	req := models.CreateSummaryRequest{
+		TranscriptID: string(args.TranscriptID),
+		Model:        stringArg(args.Model),
+		Length:       stringArg(args.Length),
+		Style:        stringArg(args.Style),
+		ForceRefresh: args.ForceRefresh != nil && *args.ForceRefresh,
+	}
+	if args.FallbackModels != nil {
+		req.FallbackModels = *args.FallbackModels
+	}
+	if req.TranscriptID == "" {
+		return nil, errors.New("transcript_id is required")
+	}
+	if apiErr := middleware.CheckFeature(middleware.GetAPIKey(r.c), models.FeatureAI, r.h.OwnerAPIKeyID, r.h.OwnerAPIKeyPrefix); apiErr != nil {
+		return nil, errors.New(apiErr.Message)
+	}
+	if r.h.Settings.Current().RequireVerifiedEmail {
+		if verified, err := middleware.EmailVerified(r.c, r.h.DB); err != nil || !verified {
+			return nil, errors.New("verify your email to use AI features")
+		}
+	}
+	accepted, apiErr, _ := r.h.startSummary(r.c, req)
+	if apiErr != nil {
+		return nil, errors.New(apiErr.Message)
+	}
+	return &summaryJobResolver{accepted: accepted}, nil
+}
+
+// transcripts wraps transcripts for resolving.
+func (r *graphqlRequest) transcripts(transcripts []models.Transcript) []*transcriptResolver {
+	out := make([]*transcriptResolver, len(transcripts))
+	for i := range transcripts {
+		out[i] = &transcriptResolver{Transcript: transcripts[i], req: r}
+	}
+	return out
+}
+
+// chat resolves an item's chat thread. Items that aren't finished
+// processing have no chat yet, so the field is null.
+func (r *graphqlRequest) chat(ctx context.Context, itemType, id string, ready bool) (*chatResolver, error) {
+	if !ready {
+		return nil, nil
+	}
+	session, err := r.h.DB.GetOrCreateChatSession(ctx, itemType, id, r.apiKeyID, r.userID)
+	if err != nil {
+		log.Printf("GraphQL: chat session load failed (%s:%s): %v", itemType, id, err)
+		return nil, errors.New("failed to load chat session")
+	}
+	messages, err := r.h.DB.ListChatMessages(ctx, session.ID, 100, "")
+	if err != nil {
+		log.Printf("GraphQL: chat messages load failed (session %s): %v", session.ID, err)
+		return nil, errors.New("failed to load chat messages")
+	}
+	return &chatResolver{session: session, messages: messages}, nil
+}
+
+type transcriptResolver struct {
+	models.Transcript
+	req *graphqlRequest
+}
+
+func (t *transcriptResolver) ID() graphql.ID          { return graphql.ID(t.Transcript.ID) }
+func (t *transcriptResolver) Duration() int32         { return int32(t.Transcript.Duration) }
+func (t *transcriptResolver) WordCount() int32        { return int32(t.Transcript.WordCount) }
+func (t *transcriptResolver) Status() string          { return string(t.Transcript.Status) }
+func (t *transcriptResolver) BatchID() *graphql.ID    { return optionalID(t.Transcript.BatchID) }
+func (t *transcriptResolver) UserID() *graphql.ID     { return optionalID(t.Transcript.UserID) }
+func (t *transcriptResolver) APIKeyID() *graphql.ID   { return optionalID(t.Transcript.APIKeyID) }
+func (t *transcriptResolver) Segments() *jsonScalar   { return optionalJSON(t.Transcript.Segments) }
+func (t *transcriptResolver) Tags() *jsonScalar       { return optionalJSON(t.Transcript.Tags) }
+func (t *transcriptResolver) EditedAt() *graphql.Time { return optionalTime(t.Transcript.EditedAt) }
+func (t *transcriptResolver) Redaction() *jsonScalar  { return optionalJSON(t.Transcript.Redaction) }
+func (t *transcriptResolver) Usage() *jsonScalar      { return optionalJSON(t.Transcript.Usage) }
+func (t *transcriptResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: t.Transcript.CreatedAt}
+}
+func (t *transcriptResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: t.Transcript.UpdatedAt}
+}
+
+func (t *transcriptResolver) Summaries(ctx context.Context) ([]*summaryResolver, error) {
+	summaries, err := t.req.h.DB.GetSummariesByTranscript(ctx, t.Transcript.ID)
+	if err != nil {
+		log.Printf("GraphQL: failed to load summaries for %s: %v", t.Transcript.ID, err)
+		return nil, errors.New("failed to load summaries")
+	}
+	out := make([]*summaryResolver, len(summaries))
+	for i := range summaries {
+		out[i] = &summaryResolver{summaries[i]}
+	}
+	return out, nil
+}
+
+func (t *transcriptResolver) Chat(ctx context.Context) (*chatResolver, error) {
+	return t.req.chat(ctx, "transcript", t.Transcript.ID, t.Transcript.Status == models.StatusCompleted && t.TranscriptText != "")
+}
+
+type transcriptPageResolver struct {
+	data                                  []*transcriptResolver
+	page, perPage, totalItems, totalPages int
+}
+
+func (p *transcriptPageResolver) Data() []*transcriptResolver { return p.data }
+func (p *transcriptPageResolver) Page() int32                 { return int32(p.page) }
+func (p *transcriptPageResolver) PerPage() int32              { return int32(p.perPage) }
+func (p *transcriptPageResolver) TotalItems() int32           { return int32(p.totalItems) }
+func (p *transcriptPageResolver) TotalPages() int32           { return int32(p.totalPages) }
+
+type summaryResolver struct {
+	models.Summary
+}
+
+func (s *summaryResolver) ID() graphql.ID           { return graphql.ID(s.Summary.ID) }
+func (s *summaryResolver) TranscriptID() graphql.ID { return graphql.ID(s.Summary.TranscriptID) }
+func (s *summaryResolver) KeyPoints() *jsonScalar   { return optionalJSON(s.Summary.KeyPoints) }
+func (s *summaryResolver) Status() string           { return string(s.Summary.Status) }
+func (s *summaryResolver) Usage() *jsonScalar       { return optionalJSON(s.Summary.Usage) }
+func (s *summaryResolver) CreatedAt() graphql.Time  { return graphql.Time{Time: s.Summary.CreatedAt} }
+
+// summaryJobResolver resolves the 202 body startSummary returns.
+type summaryJobResolver struct {
+	accepted gin.H
+}
+
+func (j *summaryJobResolver) field(name string) string { return fmt.Sprint(j.accepted[name]) }
+func (j *summaryJobResolver) Message() string          { return j.field("message") }
+func (j *summaryJobResolver) SummaryID() graphql.ID    { return graphql.ID(j.field("summary_id")) }
+func (j *summaryJobResolver) Status() string           { return j.field("status") }
+func (j *summaryJobResolver) TranscriptID() graphql.ID { return graphql.ID(j.field("transcript_id")) }
+func (j *summaryJobResolver) Length() string           { return j.field("length") }
+func (j *summaryJobResolver) Style() string            { return j.field("style") }
+
+type audioResolver struct {
+	models.AudioTranscription
+	req *graphqlRequest
+}
+
+func (a *audioResolver) ID() graphql.ID         { return graphql.ID(a.AudioTranscription.ID) }
+func (a *audioResolver) WordCount() int32       { return int32(a.AudioTranscription.WordCount) }
+func (a *audioResolver) BatchID() *graphql.ID   { return optionalID(a.AudioTranscription.BatchID) }
+func (a *audioResolver) ContentType() string    { return string(a.AudioTranscription.ContentType) }
+func (a *audioResolver) KeyPoints() *jsonScalar { return optionalJSON(a.AudioTranscription.KeyPoints) }
+func (a *audioResolver) ActionItems() *jsonScalar {
+	return optionalJSON(a.AudioTranscription.ActionItems)
+}
+func (a *audioResolver) Decisions() *jsonScalar { return optionalJSON(a.AudioTranscription.Decisions) }
+func (a *audioResolver) SpeakerSummaries() *jsonScalar {
+	return optionalJSON(a.AudioTranscription.SpeakerSummaries)
+}
+func (a *audioResolver) Analysis() *jsonScalar  { return optionalJSON(a.AudioTranscription.Analysis) }
+func (a *audioResolver) Minutes() *jsonScalar   { return optionalJSON(a.AudioTranscription.Minutes) }
+func (a *audioResolver) UserID() *graphql.ID    { return optionalID(a.AudioTranscription.UserID) }
+func (a *audioResolver) APIKeyID() *graphql.ID  { return optionalID(a.AudioTranscription.APIKeyID) }
+func (a *audioResolver) Segments() *jsonScalar  { return optionalJSON(a.AudioTranscription.Segments) }
+func (a *audioResolver) Tags() *jsonScalar      { return optionalJSON(a.AudioTranscription.Tags) }
+func (a *audioResolver) Metadata() *jsonScalar  { return optionalJSON(a.AudioTranscription.Metadata) }
+func (a *audioResolver) Redaction() *jsonScalar { return optionalJSON(a.AudioTranscription.Redaction) }
+func (a *audioResolver) Usage() *jsonScalar     { return optionalJSON(a.AudioTranscription.Usage) }
+func (a *audioResolver) OriginalStoredAt() *graphql.Time {
+	return optionalTime(a.AudioTranscription.OriginalStoredAt)
+}
+func (a *audioResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: a.AudioTranscription.CreatedAt}
+}
+func (a *audioResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: a.AudioTranscription.UpdatedAt}
+}
+
+func (a *audioResolver) Chat(ctx context.Context) (*chatResolver, error) {
+	return a.req.chat(ctx, "audio", a.AudioTranscription.ID, a.AudioTranscription.Status == "completed" && a.TranscriptText != "")
+}
+
+type pdfResolver struct {
+	models.PDFExtraction
+	req *graphqlRequest
+}
+
+func (p *pdfResolver) ID() graphql.ID            { return graphql.ID(p.PDFExtraction.ID) }
+func (p *pdfResolver) PageCount() int32          { return int32(p.PDFExtraction.PageCount) }
+func (p *pdfResolver) FirstPage() int32          { return int32(p.PDFExtraction.FirstPage) }
+func (p *pdfResolver) LastPage() int32           { return int32(p.PDFExtraction.LastPage) }
+func (p *pdfResolver) TableCount() int32         { return int32(p.PDFExtraction.TableCount) }
+func (p *pdfResolver) DocumentInfo() *jsonScalar { return optionalJSON(p.PDFExtraction.DocumentInfo) }
+func (p *pdfResolver) Outline() *jsonScalar      { return optionalJSON(p.PDFExtraction.Outline) }
+func (p *pdfResolver) WordCount() int32          { return int32(p.PDFExtraction.WordCount) }
+func (p *pdfResolver) BatchID() *graphql.ID      { return optionalID(p.PDFExtraction.BatchID) }
+func (p *pdfResolver) UserID() *graphql.ID       { return optionalID(p.PDFExtraction.UserID) }
+func (p *pdfResolver) APIKeyID() *graphql.ID     { return optionalID(p.PDFExtraction.APIKeyID) }
+func (p *pdfResolver) Tags() *jsonScalar         { return optionalJSON(p.PDFExtraction.Tags) }
+func (p *pdfResolver) Redaction() *jsonScalar    { return optionalJSON(p.PDFExtraction.Redaction) }
+func (p *pdfResolver) Usage() *jsonScalar        { return optionalJSON(p.PDFExtraction.Usage) }
+func (p *pdfResolver) OriginalStoredAt() *graphql.Time {
+	return optionalTime(p.PDFExtraction.OriginalStoredAt)
+}
+func (p *pdfResolver) CreatedAt() graphql.Time { return graphql.Time{Time: p.PDFExtraction.CreatedAt} }
+func (p *pdfResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: p.PDFExtraction.UpdatedAt} }
+
+func (p *pdfResolver) Chat(ctx context.Context) (*chatResolver, error) {
+	return p.req.chat(ctx, "pdf", p.PDFExtraction.ID, p.PDFExtraction.Status == "completed" && p.TextContent != "")
+}
+
+type batchResolver struct {
+	models.Batch
+	req *graphqlRequest
+}
+
+func (b *batchResolver) ID() graphql.ID          { return graphql.ID(b.Batch.ID) }
+func (b *batchResolver) Status() string          { return string(b.Batch.Status) }
+func (b *batchResolver) TotalCount() int32       { return int32(b.Batch.TotalCount) }
+func (b *batchResolver) CompletedCount() int32   { return int32(b.Batch.CompletedCount) }
+func (b *batchResolver) FailedCount() int32      { return int32(b.Batch.FailedCount) }
+func (b *batchResolver) CreatedAt() graphql.Time { return graphql.Time{Time: b.Batch.CreatedAt} }
+func (b *batchResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: b.Batch.UpdatedAt} }
+
+// Transcripts lists the batch's transcripts the caller can see.
+func (b *batchResolver) Transcripts(ctx context.Context) ([]*transcriptResolver, error) {
+	transcripts, err := b.req.h.DB.GetTranscriptsByBatch(ctx, b.Batch.ID)
+	if err != nil {
+		log.Printf("GraphQL: failed to load transcripts for batch %s: %v", b.Batch.ID, err)
+		return nil, errors.New("failed to load batch transcripts")
+	}
+	visible := []models.Transcript{}
+	for _, t := range transcripts {
+		if b.req.h.canViewItem(b.req.c, t.APIKeyID, t.UserID) {
+			visible = append(visible, t)
+		}
+	}
+	return b.req.transcripts(visible), nil
+}
+
+type chatResolver struct {
+	session  *models.TranscriptChatSession
+	messages []models.TranscriptChatMessage
+}
+
+func (c *chatResolver) Session() *chatSessionResolver { return &chatSessionResolver{*c.session} }
+
+func (c *chatResolver) Messages() []*chatMessageResolver {
+	out := make([]*chatMessageResolver, len(c.messages))
+	for i := range c.messages {
+		out[i] = &chatMessageResolver{c.messages[i]}
+	}
+	return out
+}
+
+type chatSessionResolver struct {
+	models.TranscriptChatSession
+}
+
+func (s *chatSessionResolver) ID() graphql.ID { return graphql.ID(s.TranscriptChatSession.ID) }
+func (s *chatSessionResolver) TranscriptID() *graphql.ID {
+	return optionalID(s.TranscriptChatSession.TranscriptID)
+}
+func (s *chatSessionResolver) ItemID() graphql.ID { return graphql.ID(s.TranscriptChatSession.ItemID) }
+func (s *chatSessionResolver) APIKeyID() *graphql.ID {
+	return optionalID(s.TranscriptChatSession.APIKeyID)
+}
+func (s *chatSessionResolver) UserID() *graphql.ID { return optionalID(s.TranscriptChatSession.UserID) }
+func (s *chatSessionResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: s.TranscriptChatSession.CreatedAt}
+}
+func (s *chatSessionResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: s.TranscriptChatSession.UpdatedAt}
+}
+
+type chatMessageResolver struct {
+	models.TranscriptChatMessage
+}
+
+func (m *chatMessageResolver) ID() graphql.ID { return graphql.ID(m.TranscriptChatMessage.ID) }
+func (m *chatMessageResolver) SessionID() graphql.ID {
+	return graphql.ID(m.TranscriptChatMessage.SessionID)
+}
+func (m *chatMessageResolver) FeedbackAt() *graphql.Time {
+	return optionalTime(m.TranscriptChatMessage.FeedbackAt)
+}
+func (m *chatMessageResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: m.TranscriptChatMessage.CreatedAt}
+}
+
+// jsonScalar is the schema's JSON scalar: raw JSON from a model, returned
+// as-is.
+type jsonScalar json.RawMessage
+
+func (jsonScalar) ImplementsGraphQLType(name string) bool { return name == "JSON" }
+
+func (j *jsonScalar) UnmarshalGraphQL(input interface{}) error {
+	data, err := json.Marshal(input)
+	*j = jsonScalar(data)
+	return err
+}
+
+func (j jsonScalar) MarshalJSON() ([]byte, error) { return []byte(j), nil }
+
+// optionalJSON returns nil (GraphQL null) for empty JSON.
+func optionalJSON(raw []byte) *jsonScalar {
+	if len(raw) == 0 {
+		return nil
+	}
+	j := jsonScalar(raw)
+	return &j
+}
+
+// optionalID converts a nullable foreign key.
+func optionalID(id *string) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	gid := graphql.ID(*id)
+	return &gid
+}
+
+// optionalTime converts a nullable timestamp.
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+// intArg returns an optional Int argument, or def if it's null.
+func intArg(v *int32, def int) int {
+	if v == nil {
+		return def
+	}
+	return int(*v)
+}
+
+// stringArg returns an optional String argument, or "" if it's null.
+func stringArg(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// graphqlLoadError maps a failed lookup to a client-facing error, logging
+// anything that isn't a plain "not found".
+func graphqlLoadError(kind, id string, err error) error {
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("GraphQL: failed to load %s %s: %v", kind, id, err)
+	}
+	return fmt.Errorf("%s not found", kind)
+}
+
+// clampLimit keeps list sizes in the same 1–50 range the REST lists use.
+func clampLimit(limit int) int {
+	if limit < 1 || limit > 50 {
+		return 50
+	}
+	return limit
+}
@@ -0,0 +1,179 @@
+//go:build cgo
+
+// graphql_test.go runs queries through the GraphQL handler against an
+// in-memory SQLite database and checks the JSON clients would see.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// graphqlResponse is the spec's response shape.
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message   string        `json:"message"`
+		Path      []interface{} `json:"path"`
+		Locations []struct {
+			Line, Column int
+		} `json:"locations"`
+	} `json:"errors"`
+}
+
+// postGraphQL sends a request body to the handler as key or user.
+func postGraphQL(t *testing.T, h *Handler, key *models.APIKey, user *models.User, body string) (int, graphqlResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if key != nil {
+		c.Set("api_key", key)
+	}
+	if user != nil {
+		c.Set("user", user)
+	}
+	h.GraphQL(c)
+
+	var resp graphqlResponse
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode %s: %v", w.Body, err)
+		}
+	}
+	return w.Code, resp
+}
+
+func TestGraphQL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	h := &Handler{DB: f.db}
+
+	ada := &models.Transcript{YouTubeURL: "https://youtu.be/ada", YouTubeID: "ada", Title: "Ada's talk", TranscriptText: "Go is fun.", Status: models.StatusCompleted, WordCount: 42, APIKeyID: &f.adaKey.ID}
+	lone := &models.Transcript{YouTubeURL: "https://youtu.be/lone", YouTubeID: "lone", Title: "Lone talk", Status: models.StatusPending, APIKeyID: &f.loneKey.ID}
+	for _, tr := range []*models.Transcript{ada, lone} {
+		if err := f.db.CreateTranscript(ctx, tr); err != nil {
+			t.Fatalf("CreateTranscript: %v", err)
+		}
+	}
+	summary := &models.Summary{TranscriptID: ada.ID, ModelUsed: "test", SummaryText: "About Go.", Length: "short", Style: "bullet", Status: models.StatusCompleted, KeyPoints: json.RawMessage(`["Go"]`)}
+	if err := f.db.CreateSummary(ctx, summary); err != nil {
+		t.Fatalf("CreateSummary: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  *models.APIKey
+		user *models.User
+		body string
+		want string // Expected data, or "" for none
+		errs []string
+	}{
+		{
+			name: "nested fields, variables, and fragments",
+			key:  f.adaKey,
+			body: `{"query": "query($id: ID!) { transcript(id: $id) { ...Basics summaries { summary_text key_points } } } fragment Basics on Transcript { title word_count }", "variables": {"id": "` + ada.ID + `"}}`,
+			want: `{"transcript":{"title":"Ada's talk","word_count":42,"summaries":[{"summary_text":"About Go.","key_points":["Go"]}]}}`,
+		},
+		{
+			name: "timestamps, nullable fields, and chat resolve",
+			key:  f.adaKey,
+			body: `{"query": "{ transcript(id: \"` + ada.ID + `\") { id status batch_id tags edited_at created_at chat { session { item_id api_key_id created_at } messages { id } } } audio_transcriptions { id created_at } pdf_extractions { id page_count } }"}`,
+		},
+		{
+			name: "lists hold the key's own items",
+			key:  f.loneKey,
+			body: `{"query": "{ transcripts { total_items data { title chat { session { id } } } } }"}`,
+			want: `{"transcripts":{"total_items":1,"data":[{"title":"Lone talk","chat":null}]}}`,
+		},
+		{
+			name: "lists for a user hold their linked keys' items",
+			user: f.ada,
+			body: `{"query": "{ transcripts { data { title } } }"}`,
+			want: `{"transcripts":{"data":[{"title":"Ada's talk"}]}}`,
+		},
+		{
+			name: "other tenants' items are refused",
+			user: f.bob,
+			body: `{"query": "{ transcript(id: \"` + ada.ID + `\") { title } }"}`,
+			want: `{"transcript":null}`,
+			errs: []string{"you can only view your own or your organization's transcripts"},
+		},
+		{
+			name: "introspection",
+			key:  f.adaKey,
+			body: `{"query": "{ __type(name: \"Batch\") { fields { name } } }"}`,
+			want: `{"__type":{"fields":[{"name":"id"},{"name":"status"},{"name":"total_count"},{"name":"completed_count"},{"name":"failed_count"},{"name":"created_at"},{"name":"updated_at"},{"name":"transcripts"}]}}`,
+		},
+		{
+			name: "unknown fields fail validation",
+			key:  f.adaKey,
+			body: `{"query": "{ transcript(id: \"x\") { text_key } }"}`,
+			errs: []string{`Cannot query field "text_key" on type "Transcript".`},
+		},
+		{
+			name: "syntax errors",
+			key:  f.adaKey,
+			body: `{"query": "{ transcript(id: \"x\") { title }"}`,
+			errs: []string{`syntax error: unexpected "", expecting Ident`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, resp := postGraphQL(t, h, tt.key, tt.user, tt.body)
+			if code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", code)
+			}
+			if tt.want != "" && string(resp.Data) != tt.want {
+				t.Errorf("data =\n%s\nwant\n%s", resp.Data, tt.want)
+			}
+			var got []string
+			for _, e := range resp.Errors {
+				got = append(got, e.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.errs, "\n") {
+				t.Errorf("errors = %q, want %q", got, tt.errs)
+			}
+		})
+	}
+}
+
+// TestGraphQLErrorShape verifies errors carry the spec's path, or for
+// invalid queries its locations.
+func TestGraphQLErrorShape(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := newAccessFixture(t)
+	h := &Handler{DB: f.db}
+
+	_, resp := postGraphQL(t, h, f.adaKey, nil, `{"query": "{ batch(id: \"missing\") { id } }"}`)
+	if len(resp.Errors) != 1 || len(resp.Errors[0].Path) != 1 || resp.Errors[0].Path[0] != "batch" {
+		t.Errorf("resolver errors = %+v, want one at path [batch]", resp.Errors)
+	}
+
+	_, resp = postGraphQL(t, h, f.adaKey, nil, `{"query": "{\n  batch(id: \"x\") { nope }\n}"}`)
+	if len(resp.Errors) != 1 || len(resp.Errors[0].Locations) != 1 || resp.Errors[0].Locations[0].Line != 2 {
+		t.Errorf("validation errors = %+v, want one on line 2", resp.Errors)
+	}
+}
+
+func TestGraphQLRequiresCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	if code, _ := postGraphQL(t, h, nil, nil, `{"query": "{ transcripts { total_items } }"}`); code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", code)
+	}
+	if code, _ := postGraphQL(t, h, &models.APIKey{ID: "k"}, nil, `{"query": " "}`); code != http.StatusBadRequest {
+		t.Errorf("empty query status = %d, want 400", code)
+	}
+}
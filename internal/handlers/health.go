@@ -10,14 +10,25 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/billing"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/fetch"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/mailer"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/notion"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/oauth"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/settings"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/tasks"
 	webhookservice "github.com/Shimizu-Technology/media-tools-api/internal/services/webhook"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
 )
@@ -33,9 +44,21 @@ type Handler struct {
 	WebhookService   *webhookservice.Service       // MTA-18: Webhook notifications
 	Summarizer       *summary.Service              // MTA-22: AI summary service
 	JWTSecret        string                        // MTA-20: JWT signing secret
+	AccessTokenTTL   time.Duration                 // Lifetime of access tokens (JWTs)
+	RefreshTokenTTL  time.Duration                 // Idle lifetime of a login session
 	AdminAPIKey      string                        // Admin key for protected bootstrap operations
 	OwnerAPIKeyID     string                       // Optional owner key ID override
 	OwnerAPIKeyPrefix string                       // Optional owner key prefix override
+	OAuth             *oauth.Client                // Google/GitHub sign-in; nil disables it
+	Mailer            mailer.Mailer                // Sends verification and password reset emails
+	AppURL            string                       // Frontend base URL for links in emails
+	CORSOrigins       *middleware.OriginMatcher    // Browser origins allowed to call the API
+	Settings          *settings.Service            // Admin-adjustable model, limits, workers, and flags
+	Tasks             *tasks.Client                // Pushes action items to task managers
+	Notion            *notion.Client               // Exports transcripts as Notion pages
+	Billing           *billing.Client              // Stripe usage billing; unconfigured disables it
+	Fetcher           *fetch.Client                // Downloads PDFs and articles from URLs
+	keyChecks         *checkCache                  // Recent OpenRouter/OpenAI key checks
 }
 
 // NewHandler creates a new handler with all dependencies.
@@ -50,6 +73,10 @@ func NewHandler(db *database.DB, wp *worker.Pool, at *audio.Transcriber, ws *web
 		AdminAPIKey:      adminAPIKey,
 		OwnerAPIKeyID:     ownerKeyID,
 		OwnerAPIKeyPrefix: ownerKeyPrefix,
+		Tasks:             tasks.New(),
+		Notion:            notion.New(),
+		Fetcher:           fetch.New(),
+		keyChecks:         newCheckCache(),
 	}
 }
 
@@ -69,3 +96,56 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 		Workers:  h.Worker.WorkerCount(),
 	})
 }
+
+// DeepHealthCheck probes every dependency: the database, yt-dlp, ffmpeg, the
+// OpenRouter and OpenAI API keys, queue capacity, and temp disk space. It
+// responds 503 if any check fails, for use as a readiness probe.
+// GET /api/v1/health/deep
+func (h *Handler) DeepHealthCheck(c *gin.Context) {
+	writeHealthChecks(c, h.probeDependencies(c.Request.Context()))
+}
+
+// Liveness reports that the process is up and serving requests. It touches
+// no dependencies, so a database outage doesn't get the process restarted.
+// GET /healthz
+func (h *Handler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness reports whether the instance can serve traffic: the database is
+// reachable, migrations are current, and the workers have started. It
+// responds 503 otherwise, so load balancers hold traffic back.
+// GET /readyz
+func (h *Handler) Readiness(c *gin.Context) {
+	ctx := c.Request.Context()
+	writeHealthChecks(c, []models.DependencyStatus{
+		checkDependency(ctx, "database", func(ctx context.Context) (string, error) {
+			return "", h.DB.HealthCheck(ctx)
+		}),
+		checkDependency(ctx, "migrations", func(ctx context.Context) (string, error) {
+			version, err := h.DB.CheckMigrations(ctx)
+			return "version " + intToStr(int(version)), err
+		}),
+		checkDependency(ctx, "workers", func(context.Context) (string, error) {
+			if !h.Worker.Started() {
+				return "", errors.New("workers not started")
+			}
+			return intToStr(h.Worker.WorkerCount()) + " workers", nil
+		}),
+	})
+}
+
+// writeHealthChecks responds 200 with status "ok" if no check failed, or 503
+// with status "unhealthy".
+func writeHealthChecks(c *gin.Context, checks []models.DependencyStatus) {
+	resp := models.DeepHealthResponse{Status: "ok", Checks: checks}
+	code := http.StatusOK
+	for _, check := range checks {
+		if check.Status == "error" {
+			resp.Status = "unhealthy"
+			code = http.StatusServiceUnavailable
+			break
+		}
+	}
+	c.JSON(code, resp)
+}
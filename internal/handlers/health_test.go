@@ -0,0 +1,54 @@
+// health_test.go contains tests for health and readiness responses.
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestWriteHealthChecks verifies any failing check turns the response into
+// a 503, while not_configured checks don't.
+func TestWriteHealthChecks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		checks     []models.DependencyStatus
+		wantCode   int
+		wantStatus string
+	}{
+		{
+			name:       "all ok",
+			checks:     []models.DependencyStatus{{Name: "database", Status: "ok"}, {Name: "openai", Status: "not_configured"}},
+			wantCode:   http.StatusOK,
+			wantStatus: "ok",
+		},
+		{
+			name:       "one failing",
+			checks:     []models.DependencyStatus{{Name: "database", Status: "ok"}, {Name: "migrations", Status: "error"}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "unhealthy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			writeHealthChecks(c, tt.checks)
+
+			var resp models.DeepHealthResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid body: %v", err)
+			}
+			if w.Code != tt.wantCode || resp.Status != tt.wantStatus || len(resp.Checks) != len(tt.checks) {
+				t.Errorf("got %d %+v, want %d %q", w.Code, resp, tt.wantCode, tt.wantStatus)
+			}
+		})
+	}
+}
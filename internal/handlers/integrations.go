@@ -0,0 +1,306 @@
+// integrations.go handles per-user integrations (Todoist, Linear, GitHub
+// Issues, and Notion) and pushing audio action items to the task managers.
+//
+// Credentials belong to a user (not an API key), so they're managed via
+// JWT-only routes, and are stored sealed with the server's ENCRYPTION_KEY. Pushing works with either auth method: API keys that
+// are linked to a user borrow that user's integrations.
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/notion"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/tasks"
+)
+
+// validateIntegration checks the provider name and its provider-specific config.
+func validateIntegration(provider string, config map[string]string) error {
+	if provider == notion.Provider {
+		return notion.ValidateConfig(config)
+	}
+	return tasks.ValidateConfig(provider, config)
+}
+
+// integrationUserID resolves the user whose integrations a request may use:
+// the logged-in user, or the user an API key is linked to.
+func integrationUserID(c *gin.Context) string {
+	if user := middleware.GetUser(c); user != nil {
+		return user.ID
+	}
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil && apiKey.UserID != nil {
+		return *apiKey.UserID
+	}
+	return ""
+}
+
+// ListIntegrations returns the authenticated user's configured integrations.
+// GET /api/v1/integrations
+func (h *Handler) ListIntegrations(c *gin.Context) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Login required to manage integrations",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	integrations, err := h.DB.ListIntegrations(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("Failed to list integrations: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list integrations",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if integrations == nil {
+		integrations = []models.Integration{}
+	}
+
+	c.JSON(http.StatusOK, integrations)
+}
+
+// SaveIntegration creates or replaces the user's integration for a provider.
+// PUT /api/v1/integrations/:provider
+func (h *Handler) SaveIntegration(c *gin.Context) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Login required to manage integrations",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	provider := c.Param("provider")
+	if !tasks.ValidProviders[provider] && provider != notion.Provider {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_provider",
+			Message: "Supported providers: todoist, linear, github, notion",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req models.SaveIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "token is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := validateIntegration(provider, req.Config); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_config",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	config := req.Config
+	if config == nil {
+		config = map[string]string{}
+	}
+	configJSON, _ := json.Marshal(config)
+
+	integration := &models.Integration{
+		UserID:   user.ID,
+		Provider: provider,
+		Token:    req.Token,
+		Config:   configJSON,
+	}
+	if err := h.DB.UpsertIntegration(c.Request.Context(), integration); err != nil {
+		if errors.Is(err, secretbox.ErrNoKey) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "encryption_not_configured",
+				Message: "Integrations need the server's ENCRYPTION_KEY to be set",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		log.Printf("Failed to save %s integration: %v", provider, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save integration",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, integration)
+}
+
+// DeleteIntegration removes the user's integration for a provider.
+// DELETE /api/v1/integrations/:provider
+func (h *Handler) DeleteIntegration(c *gin.Context) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Login required to manage integrations",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.DB.DeleteIntegration(c.Request.Context(), user.ID, c.Param("provider")); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Integration not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Integration removed"})
+}
+
+// PushActionItems sends an audio summary's action items to a task manager.
+// POST /api/v1/audio/transcriptions/:id/action-items/push
+//
+// Request body:
+//
+//	{
+//	  "provider": "github",  // todoist, linear, github
+//	  "items": [0, 2]        // optional — indexes into action_items; omit to push all
+//	}
+func (h *Handler) PushActionItems(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.PushActionItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil || !tasks.ValidProviders[req.Provider] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "provider is required (todoist, linear, github)",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Resolve the user who owns the integration credentials
+	userID := integrationUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "no_user",
+			Message: "Integrations belong to user accounts. Log in, or link this API key to a user.",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	at, err := h.DB.GetAudioTranscription(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Audio transcription not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only push action items from your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	var actionItems []string
+	if len(at.ActionItems) > 0 {
+		json.Unmarshal(at.ActionItems, &actionItems)
+	}
+	if at.SummaryStatus != "completed" || len(actionItems) == 0 {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "no_action_items",
+			Message: "This transcription has no action items. Generate a summary first.",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	// Pick the requested subset (or everything)
+	selected := actionItems
+	if len(req.Items) > 0 {
+		selected = make([]string, 0, len(req.Items))
+		for _, idx := range req.Items {
+			if idx < 0 || idx >= len(actionItems) {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:   "invalid_items",
+					Message: fmt.Sprintf("Item index %d is out of range (0-%d)", idx, len(actionItems)-1),
+					Code:    http.StatusBadRequest,
+				})
+				return
+			}
+			selected = append(selected, actionItems[idx])
+		}
+	}
+
+	integration, err := h.DB.GetIntegration(c.Request.Context(), userID, req.Provider)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "integration_not_configured",
+				Message: fmt.Sprintf("No %s integration configured. Add one via PUT /api/v1/integrations/%s", req.Provider, req.Provider),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		log.Printf("Failed to load %s integration: %v", req.Provider, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load integration",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	var config map[string]string
+	json.Unmarshal(integration.Config, &config)
+
+	source := at.OriginalName
+	if source == "" {
+		source = at.Filename
+	}
+	items := make([]tasks.Task, len(selected))
+	for i, title := range selected {
+		items[i] = tasks.Task{
+			Title:       title,
+			Description: fmt.Sprintf("Action item from audio transcription \"%s\" (%s).", source, at.ID),
+		}
+	}
+
+	results := h.Tasks.Push(c.Request.Context(), req.Provider, integration.Token, config, items)
+
+	resp := models.PushActionItemsResponse{Provider: req.Provider, Results: results}
+	for _, r := range results {
+		if r.Error != "" {
+			resp.Failed++
+		} else {
+			resp.Pushed++
+		}
+	}
+
+	status := http.StatusOK
+	if resp.Pushed == 0 {
+		status = http.StatusBadGateway // Every item failed upstream
+	}
+	c.JSON(status, resp)
+}
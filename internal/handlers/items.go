@@ -0,0 +1,131 @@
+// items.go serves a merged list of transcripts, audio transcriptions, and
+// PDF extractions, so dashboards can show everything in one call.
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ListItems returns a paginated list of all item types in a common shape.
+// GET /api/v1/items
+//
+// Query params: page, per_page, type (comma-separated transcript,audio,pdf),
+// status, search, sort_by (created_at, title, word_count), sort_dir,
+// date_from, date_to, cursor (next_cursor from the previous page).
+func (h *Handler) ListItems(c *gin.Context) {
+	params, types, ok := bindItemListParams(c)
+	if !ok {
+		return
+	}
+
+	// Filter by the authenticated API key (and its organization's keys), or
+	// the one an admin key names
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+	h.writeItemList(c, params, types)
+}
+
+// bindItemListParams reads and validates the item list query parameters.
+// On failure it writes the error response and returns false.
+func bindItemListParams(c *gin.Context) (models.ItemListParams, []string, bool) {
+	var params models.ItemListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid query parameters: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return params, nil, false
+	}
+
+	types, ok := parseItemTypes(params.Types)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_type",
+			Message: "type must be a comma-separated list of: transcript, audio, pdf",
+			Code:    http.StatusBadRequest,
+		})
+		return params, nil, false
+	}
+
+	var err error
+	if params.DateFrom, err = normalizeExportDate(params.DateFrom, false); err == nil {
+		params.DateTo, err = normalizeExportDate(params.DateTo, true)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Dates must be YYYY-MM-DD or RFC 3339",
+			Code:    http.StatusBadRequest,
+		})
+		return params, nil, false
+	}
+	return params, types, true
+}
+
+// writeItemList runs an item list query and writes the paginated response.
+func (h *Handler) writeItemList(c *gin.Context, params models.ItemListParams, types []string) {
+	items, total, err := h.DB.ListItems(c.Request.Context(), params, types)
+	if writeCursorError(c, err) {
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Failed to list items: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list items",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Ensure we return an empty array, not null
+	if items == nil {
+		items = []models.Item{}
+	}
+
+	perPage := params.PerPage
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.Item]{
+		Data:       items,
+		Page:       responsePage(params.Page, params.Cursor),
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+		NextCursor: database.NextItemCursor(params, items),
+	})
+}
+
+// parseItemTypes splits a comma-separated type filter, dropping duplicates.
+// An empty filter means all types and returns nil.
+func parseItemTypes(value string) ([]string, bool) {
+	var types []string
+	seen := map[string]bool{}
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		if !models.ValidExportItemTypes[t] {
+			return nil, false
+		}
+		seen[t] = true
+		types = append(types, t)
+	}
+	return types, true
+}
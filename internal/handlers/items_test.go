@@ -0,0 +1,32 @@
+// items_test.go contains tests for the unified items list helpers.
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseItemTypes verifies the comma-separated type filter.
+func TestParseItemTypes(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   []string
+		wantOK bool
+	}{
+		{"empty means all", "", nil, true},
+		{"single", "audio", []string{"audio"}, true},
+		{"several with spaces", "transcript, pdf", []string{"transcript", "pdf"}, true},
+		{"duplicates dropped", "pdf,pdf,,audio", []string{"pdf", "audio"}, true},
+		{"unknown type", "transcript,video", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseItemTypes(tt.value)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseItemTypes(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+// live.go transcribes microphone audio as it's recorded, over a WebSocket.
+//
+// GET /api/v1/audio/live — Stream audio chunks in, get interim text back
+//
+// The client sends the chunks a MediaRecorder produces as binary messages.
+// They're appended to a temp file, and every few seconds the recording so
+// far goes to Whisper and its text comes back as an "interim" message.
+// Sending {"type":"stop"} queues the recording as a normal audio
+// transcription: the client gets "queued", then "final" once the job
+// finishes, and the connection closes. Closing without stop discards it.
+//
+// Go Pattern: Whisper has no streaming API, so each interim pass
+// re-transcribes the whole recording. Passes are spaced out and never
+// overlap, which keeps the cost bounded as the recording grows.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/events"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/redact"
+)
+
+// liveProtocol is the subprotocol clients offer; browsers pass it (plus
+// their credential) as new WebSocket(url, ["mta.live.v1", ...]).
+const liveProtocol = "mta.live.v1"
+
+const (
+	liveInterimInterval = 5 * time.Second  // How often the recording so far is re-transcribed
+	liveIdleTimeout     = time.Minute      // Closes connections that stop sending audio
+	liveMessageLimit    = 1 << 20          // Largest audio chunk accepted (1MB)
+	liveWriteTimeout    = 10 * time.Second // How long a close frame may take to send
+)
+
+// liveUpgrader selects liveProtocol when the client offers it; browsers
+// refuse the connection when they offer subprotocols and none is. Any
+// origin may connect: credentials come in headers or subprotocols, never
+// cookies, and keys limited to origins are checked before the upgrade.
+var liveUpgrader = websocket.Upgrader{
+	Subprotocols: []string{liveProtocol},
+	CheckOrigin:  func(r *http.Request) bool { return true },
+}
+
+// LiveTranscribe upgrades to a WebSocket for live transcription.
+// GET /api/v1/audio/live
+//
+// Optional query params, checked before upgrading:
+//
+//	?format=webm              container of the audio chunks (mp3, wav, m4a, ogg, flac, webm)
+//	?language=en              Whisper language hint
+//	?translate=true           translate to English (keep_source=true keeps the original too)
+//	?redact=true              mask PII in interim text and the saved transcript
+//	?interim=false            skip interim passes; only the final transcript is made
+func (h *Handler) LiveTranscribe(c *gin.Context) {
+	if h.AudioTranscriber == nil || !h.AudioTranscriber.IsConfigured() {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "Audio transcription is not configured. Set the OPENAI_API_KEY environment variable to enable Whisper transcription.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	ext := "." + strings.ToLower(strings.TrimPrefix(c.DefaultQuery("format", "webm"), "."))
+	if !allowedAudioTypes[ext] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_file_type",
+			Message: fmt.Sprintf("Unsupported audio format '%s'. Supported formats: mp3, wav, m4a, ogg, flac, webm", ext),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	opts, apiErr := uploadTranscribeOptions(map[string]string{
+		"language":    c.Query("language"),
+		"translate":   c.Query("translate"),
+		"keep_source": c.Query("keep_source"),
+	})
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	redacted, apiErr := parseRedactFlag(c.Query("redact"))
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	interim, apiErr := parseFlag("interim", c.DefaultQuery("interim", "true"))
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		c.JSON(http.StatusUpgradeRequired, models.ErrorResponse{
+			Error:   "upgrade_required",
+			Message: "Connect with a WebSocket client",
+			Code:    http.StatusUpgradeRequired,
+		})
+		return
+	}
+
+	file, err := os.CreateTemp("", "mta-live-*"+ext)
+	if err != nil {
+		log.Printf("Failed to create live recording file: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to start recording",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	conn, err := liveUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return // Upgrade has already responded
+	}
+
+	s := &liveSession{h: h, c: c, conn: conn, file: file, ext: ext, opts: opts, redacted: redacted}
+	s.run(interim)
+}
+
+// liveSession is one live transcription connection.
+type liveSession struct {
+	h        *Handler
+	c        *gin.Context
+	conn     *websocket.Conn
+	file     *os.File // The recording so far
+	ext      string
+	opts     audio.TranscribeOptions
+	redacted bool
+
+	size    atomic.Int64 // Bytes written to file
+	writeMu sync.Mutex   // The interim loop and the reader both send messages
+}
+
+// run reads audio until the client stops or goes away.
+func (s *liveSession) run(interim bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	if interim {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.interimLoop(ctx)
+		}()
+	}
+	stopInterim := func() {
+		cancel()
+		wg.Wait()
+	}
+	defer stopInterim()
+
+	s.conn.SetReadLimit(liveMessageLimit)
+	for {
+		s.conn.SetReadDeadline(time.Now().Add(liveIdleTimeout))
+		msgType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) {
+				s.close(websocket.ClosePolicyViolation, "no audio received")
+			}
+			stopInterim()
+			s.discard()
+			return
+		}
+
+		switch msgType {
+		case websocket.BinaryMessage:
+			if s.size.Load()+int64(len(data)) > maxAudioSize {
+				s.fail(websocket.CloseMessageTooBig, &models.ErrorResponse{
+					Error:   "file_too_large",
+					Message: "Recordings are limited to 25MB",
+					Code:    http.StatusRequestEntityTooLarge,
+				})
+				stopInterim()
+				s.discard()
+				return
+			}
+			if _, err := s.file.Write(data); err != nil {
+				log.Printf("Failed to write live recording: %v", err)
+				s.fail(websocket.CloseInternalServerErr, &models.ErrorResponse{
+					Error:   "server_error",
+					Message: "Failed to save audio",
+					Code:    http.StatusInternalServerError,
+				})
+				stopInterim()
+				s.discard()
+				return
+			}
+			s.size.Add(int64(len(data)))
+		case websocket.TextMessage:
+			var msg struct {
+				Type string `json:"type"`
+			}
+			if json.Unmarshal(data, &msg) != nil || msg.Type != "stop" {
+				s.send(models.LiveTranscriptionMessage{
+					Type:    "error",
+					Error:   "invalid_message",
+					Message: `Send audio as binary messages, then {"type":"stop"} to finish`,
+				})
+				continue
+			}
+			stopInterim() // So the last interim pass is done with the file
+			s.finish()
+			return
+		}
+	}
+}
+
+// interimLoop re-transcribes the recording whenever new audio has arrived,
+// one pass at a time, until ctx is done.
+func (s *liveSession) interimLoop(ctx context.Context) {
+	ticker := time.NewTicker(liveInterimInterval)
+	defer ticker.Stop()
+
+	opts := s.opts
+	opts.KeepSource = false // Only the final transcript keeps the source text
+	var transcribed int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		size := s.size.Load()
+		if size == transcribed {
+			continue
+		}
+		transcribed = size
+
+		// The reader only sees bytes already written, so chunks can keep arriving
+		recording := io.NewSectionReader(s.file, 0, size)
+		result, err := s.h.AudioTranscriber.TranscribeWithOptions(ctx, recording, "live"+s.ext, opts)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("⚠️  Interim transcription failed: %v", err)
+			}
+			continue
+		}
+		text := result.Text
+		if s.redacted {
+			text = redact.New(nil).Text(text)
+		}
+		s.send(models.LiveTranscriptionMessage{
+			Type:     "interim",
+			Text:     text,
+			Language: result.Language,
+			Duration: result.Duration,
+		})
+	}
+}
+
+// finish queues the recording and waits for its transcription.
+func (s *liveSession) finish() {
+	size := s.size.Load()
+	if size == 0 {
+		s.fail(websocket.CloseNormalClosure, &models.ErrorResponse{
+			Error:   "empty_recording",
+			Message: "No audio was received",
+			Code:    http.StatusBadRequest,
+		})
+		s.discard()
+		return
+	}
+
+	// Named like an upload's temp file, which the worker removes when done
+	s.file.Close()
+	storedFilename := uuid.New().String() + s.ext
+	tempFilePath := filepath.Join(os.TempDir(), storedFilename)
+	if err := os.Rename(s.file.Name(), tempFilePath); err != nil {
+		log.Printf("Failed to save live recording: %v", err)
+		s.fail(websocket.CloseInternalServerErr, &models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to save recording",
+			Code:    http.StatusInternalServerError,
+		})
+		os.Remove(s.file.Name())
+		return
+	}
+
+	originalName := "live-" + time.Now().UTC().Format("20060102-150405") + s.ext
+	at, apiErr := s.h.queueAudio(s.c, tempFilePath, storedFilename, originalName, size, 0, s.redacted, s.opts, audioDetails{}, nil)
+	if apiErr != nil {
+		s.fail(websocket.CloseInternalServerErr, apiErr)
+		return
+	}
+	s.send(models.LiveTranscriptionMessage{Type: "queued", Transcription: at})
+
+	if at = s.waitForTranscription(at); at != nil {
+		s.send(models.LiveTranscriptionMessage{Type: "final", Transcription: at})
+	}
+	s.close(websocket.CloseNormalClosure, "")
+}
+
+// waitForTranscription waits for a queued transcription to complete or
+// fail, returning it as it then stands. It gives up after the longest job
+// timeout, returning it still pending, or when the client goes away,
+// returning nil.
+func (s *liveSession) waitForTranscription(at *models.AudioTranscription) *models.AudioTranscription {
+	updates, unsubscribe := s.h.Worker.Events().Subscribe(func(e events.Event) bool {
+		return e.Type == "audio" && e.ID == at.ID
+	})
+	defer unsubscribe()
+
+	// Nothing else is sent after stop, so a failed read means the client left
+	gone := make(chan struct{})
+	s.conn.SetReadDeadline(time.Time{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := s.conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	timeout := time.NewTimer(s.h.Worker.MaxJobTimeout())
+	defer timeout.Stop()
+	for {
+		// Checked after subscribing, so a job that finishes in between isn't missed
+		current, err := s.h.DB.GetAudioTranscription(context.Background(), at.ID)
+		if err == nil {
+			at = current
+		}
+		if at.Status == "completed" || at.Status == "failed" {
+			return at
+		}
+		select {
+		case <-updates:
+		case <-timeout.C:
+			return at
+		case <-gone:
+			return nil
+		}
+	}
+}
+
+// send writes a message, ignoring errors: a client that's gone is noticed
+// by the next read.
+func (s *liveSession) send(msg models.LiveTranscriptionMessage) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.WriteJSON(msg)
+}
+
+// fail sends an error message and closes the connection with code.
+func (s *liveSession) fail(code int, apiErr *models.ErrorResponse) {
+	s.send(models.LiveTranscriptionMessage{Type: "error", Error: apiErr.Error, Message: apiErr.Message})
+	s.close(code, apiErr.Error)
+}
+
+// close sends a close frame with code and reason, then closes the
+// connection. It's safe to call more than once.
+func (s *liveSession) close(code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	_ = s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(liveWriteTimeout))
+	s.conn.Close()
+}
+
+// discard removes the recording.
+func (s *liveSession) discard() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
@@ -0,0 +1,105 @@
+// live_test.go contains tests for live transcription's request checks.
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+)
+
+// TestLiveTranscribeValidation verifies bad options are rejected with a
+// JSON error before the connection is upgraded.
+func TestLiveTranscribeValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{AudioTranscriber: audio.NewTranscriber("test-key")}
+
+	tests := []struct {
+		name    string
+		query   string
+		upgrade bool
+		want    int
+	}{
+		{"unsupported format", "?format=aiff", true, http.StatusBadRequest},
+		{"bad language", "?language=english", true, http.StatusBadRequest},
+		{"keep_source without translate", "?keep_source=true", true, http.StatusBadRequest},
+		{"bad interim flag", "?interim=maybe", true, http.StatusBadRequest},
+		{"not a websocket", "?format=ogg", false, http.StatusUpgradeRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/audio/live"+tt.query, nil)
+			if tt.upgrade {
+				c.Request.Header.Set("Connection", "Upgrade")
+				c.Request.Header.Set("Upgrade", "websocket")
+			}
+			h.LiveTranscribe(c)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/audio/live", nil)
+	(&Handler{}).LiveTranscribe(c)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without Whisper: status = %d, want 503", w.Code)
+	}
+}
+
+// TestLiveTranscribeSession verifies the upgrade selects the live
+// subprotocol, bad text messages get an error reply, and stopping without
+// audio closes the connection normally.
+func TestLiveTranscribeSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{AudioTranscriber: audio.NewTranscriber("test-key")}
+	r := gin.New()
+	r.GET("/api/v1/audio/live", h.LiveTranscribe)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	dialer := websocket.Dialer{Subprotocols: []string{liveProtocol, "mta.key.test"}}
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/audio/live?interim=false"
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if got := conn.Subprotocol(); got != liveProtocol {
+		t.Errorf("Subprotocol() = %q, want %q", got, liveProtocol)
+	}
+
+	var msg models.LiveTranscriptionMessage
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("hello")); err != nil {
+		t.Fatalf("WriteMessage: %v", err)
+	}
+	if err := conn.ReadJSON(&msg); err != nil || msg.Type != "error" || msg.Error != "invalid_message" {
+		t.Errorf("reply to a bad message = %+v, %v; want invalid_message", msg, err)
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"stop"}`)); err != nil {
+		t.Fatalf("WriteMessage: %v", err)
+	}
+	if err := conn.ReadJSON(&msg); err != nil || msg.Error != "empty_recording" {
+		t.Errorf("reply to stop = %+v, %v; want empty_recording", msg, err)
+	}
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseNormalClosure || closeErr.Text != "empty_recording" {
+		t.Errorf("after stop: %v, want a normal close", err)
+	}
+}
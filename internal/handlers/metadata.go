@@ -0,0 +1,272 @@
+// metadata.go lets owners rename items and attach notes and tags, plus
+// custom metadata on audio.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// Limits on user-editable metadata.
+const (
+	maxTitleLength = 500
+	maxNotesLength = 10000
+	maxTags        = 20
+	maxTagLength   = 50
+
+	maxMetadataKeys        = 20
+	maxMetadataKeyLength   = 50
+	maxMetadataValueLength = 500
+)
+
+// UpdateTranscript edits a transcript's title, notes, or tags.
+// PATCH /api/v1/transcripts/:id
+func (h *Handler) UpdateTranscript(c *gin.Context) {
+	h.updateItem(c, "transcript")
+}
+
+// UpdateAudioTranscription edits an audio transcription's title, notes,
+// tags, content type, or metadata.
+// PATCH /api/v1/audio/transcriptions/:id
+func (h *Handler) UpdateAudioTranscription(c *gin.Context) {
+	h.updateItem(c, "audio")
+}
+
+// UpdatePDFExtraction edits a PDF extraction's title, notes, or tags.
+// PATCH /api/v1/pdf/extractions/:id
+func (h *Handler) UpdatePDFExtraction(c *gin.Context) {
+	h.updateItem(c, "pdf")
+}
+
+// updateItem is the shared implementation of the Update* handlers. It
+// responds with the updated item.
+//
+// Request body (all optional, at least one required):
+//
+//	{
+//	  "title": "Q3 planning call",
+//	  "notes": "Follow up with finance",
+//	  "tags": ["planning", "q3"],
+//	  "content_type": "meeting", // audio only
+//	  "metadata": {"customer": "Acme"}  // audio only; string values
+//	}
+func (h *Handler) updateItem(c *gin.Context, itemType string) {
+	id := c.Param("id")
+
+	var req models.UpdateItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Request body must be JSON with title, notes, tags, content_type, or metadata",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if msg := validateItemUpdate(itemType, &req); msg != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	item, apiKeyID, userID, err := h.loadItem(c.Request.Context(), itemType, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Item not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canManageItem(c, apiKeyID, userID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only edit your own items",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	if err := h.DB.UpdateItemMetadata(c.Request.Context(), itemType, id, req); err != nil {
+		log.Printf("❌ Failed to update %s %s: %v", itemType, id, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to update item",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if updated, _, _, err := h.loadItem(c.Request.Context(), itemType, id); err == nil {
+		item = updated
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// loadItem fetches a transcript, audio transcription, or PDF extraction
+// along with its owner IDs.
+func (h *Handler) loadItem(ctx context.Context, itemType, id string) (any, *string, *string, error) {
+	switch itemType {
+	case "transcript":
+		t, err := h.DB.GetTranscript(ctx, id)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return t, t.APIKeyID, t.UserID, nil
+	case "audio":
+		at, err := h.DB.GetAudioTranscription(ctx, id)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return at, at.APIKeyID, at.UserID, nil
+	default:
+		pe, err := h.DB.GetPDFExtraction(ctx, id)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return pe, pe.APIKeyID, pe.UserID, nil
+	}
+}
+
+// validateItemUpdate trims and checks an update in place, returning an
+// error message or "" if it's valid. Tags are trimmed and de-duplicated.
+func validateItemUpdate(itemType string, req *models.UpdateItemRequest) string {
+	if req.Title == nil && req.Notes == nil && req.Tags == nil && req.ContentType == nil && req.Metadata == nil {
+		return "Provide at least one of title, notes, tags, content_type, or metadata"
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		// Audio and PDFs fall back to the filename; transcripts have nothing to fall back to
+		if title == "" && itemType == "transcript" {
+			return "title can't be empty"
+		}
+		if utf8.RuneCountInString(title) > maxTitleLength {
+			return "title must be at most " + intToStr(maxTitleLength) + " characters"
+		}
+		req.Title = &title
+	}
+
+	if req.Notes != nil && utf8.RuneCountInString(*req.Notes) > maxNotesLength {
+		return "notes must be at most " + intToStr(maxNotesLength) + " characters"
+	}
+
+	if req.Tags != nil {
+		tags := []string{}
+		seen := map[string]bool{}
+		for _, tag := range *req.Tags {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || seen[tag] {
+				continue
+			}
+			if utf8.RuneCountInString(tag) > maxTagLength {
+				return "tags must be at most " + intToStr(maxTagLength) + " characters each"
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+		if len(tags) > maxTags {
+			return "at most " + intToStr(maxTags) + " tags are allowed"
+		}
+		req.Tags = &tags
+	}
+
+	if req.ContentType != nil {
+		if itemType != "audio" {
+			return "content_type can only be set on audio transcriptions"
+		}
+		if !models.ValidContentTypes[models.AudioContentType(*req.ContentType)] {
+			return "content_type must be one of: general, phone_call, meeting, voice_memo, interview, lecture"
+		}
+	}
+
+	if req.Metadata != nil {
+		if itemType != "audio" {
+			return "metadata can only be set on audio transcriptions"
+		}
+		metadata := map[string]string{}
+		for key, value := range *req.Metadata {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				return "metadata keys can't be empty"
+			}
+			if utf8.RuneCountInString(key) > maxMetadataKeyLength {
+				return "metadata keys must be at most " + intToStr(maxMetadataKeyLength) + " characters"
+			}
+			if utf8.RuneCountInString(value) > maxMetadataValueLength {
+				return "metadata values must be at most " + intToStr(maxMetadataValueLength) + " characters"
+			}
+			metadata[key] = value
+		}
+		if len(metadata) > maxMetadataKeys {
+			return "at most " + intToStr(maxMetadataKeys) + " metadata keys are allowed"
+		}
+		req.Metadata = &metadata
+	}
+
+	return ""
+}
+
+// audioDetails are the descriptive fields an audio upload can set on its
+// transcription. Metadata is a JSON object, or empty for none.
+type audioDetails struct {
+	Title    string
+	Notes    string
+	Metadata json.RawMessage
+}
+
+// uploadAudioDetails reads the optional title, notes, and metadata form
+// fields or upload metadata values, validated like a PATCH. metadata is a
+// JSON object of string values.
+func uploadAudioDetails(fields map[string]string) (audioDetails, *models.ErrorResponse) {
+	invalid := func(msg string) (audioDetails, *models.ErrorResponse) {
+		return audioDetails{}, &models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	var req models.UpdateItemRequest
+	if v := fields["title"]; v != "" {
+		req.Title = &v
+	}
+	if v := fields["notes"]; v != "" {
+		req.Notes = &v
+	}
+	if v := fields["metadata"]; v != "" {
+		var metadata map[string]string
+		if err := json.Unmarshal([]byte(v), &metadata); err != nil {
+			return invalid("metadata must be a JSON object of string values")
+		}
+		req.Metadata = &metadata
+	}
+	if req.Title == nil && req.Notes == nil && req.Metadata == nil {
+		return audioDetails{}, nil
+	}
+	if msg := validateItemUpdate("audio", &req); msg != "" {
+		return invalid(msg)
+	}
+
+	var details audioDetails
+	if req.Title != nil {
+		details.Title = *req.Title
+	}
+	if req.Notes != nil {
+		details.Notes = *req.Notes
+	}
+	if req.Metadata != nil {
+		details.Metadata, _ = json.Marshal(*req.Metadata)
+	}
+	return details, nil
+}
@@ -0,0 +1,100 @@
+// metadata_test.go contains tests for item metadata validation.
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestValidateItemUpdate verifies limits, per-type rules, and tag cleanup.
+func TestValidateItemUpdate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tags := func(t ...string) *[]string { return &t }
+
+	tests := []struct {
+		name     string
+		itemType string
+		req      models.UpdateItemRequest
+		wantErr  string
+		wantTags []string
+	}{
+		{"empty body", "transcript", models.UpdateItemRequest{}, "at least one", nil},
+		{"rename transcript", "transcript", models.UpdateItemRequest{Title: str("New name")}, "", nil},
+		{"blank transcript title", "transcript", models.UpdateItemRequest{Title: str("   ")}, "can't be empty", nil},
+		{"blank audio title resets", "audio", models.UpdateItemRequest{Title: str("")}, "", nil},
+		{"title too long", "pdf", models.UpdateItemRequest{Title: str(strings.Repeat("a", maxTitleLength+1))}, "title must be", nil},
+		{"notes too long", "pdf", models.UpdateItemRequest{Notes: str(strings.Repeat("n", maxNotesLength+1))}, "notes must be", nil},
+		{"tags cleaned", "audio", models.UpdateItemRequest{Tags: tags(" a ", "b", "a", "")}, "", []string{"a", "b"}},
+		{"tags cleared", "audio", models.UpdateItemRequest{Tags: tags()}, "", []string{}},
+		{"tag too long", "audio", models.UpdateItemRequest{Tags: tags(strings.Repeat("t", maxTagLength+1))}, "tags must be", nil},
+		{"content type on audio", "audio", models.UpdateItemRequest{ContentType: str("meeting")}, "", nil},
+		{"unknown content type", "audio", models.UpdateItemRequest{ContentType: str("podcast")}, "content_type must be", nil},
+		{"content type on pdf", "pdf", models.UpdateItemRequest{ContentType: str("meeting")}, "only be set on audio", nil},
+		{"metadata on audio", "audio", models.UpdateItemRequest{Metadata: &map[string]string{"customer": "Acme"}}, "", nil},
+		{"metadata on pdf", "pdf", models.UpdateItemRequest{Metadata: &map[string]string{"a": "b"}}, "only be set on audio", nil},
+		{"blank metadata key", "audio", models.UpdateItemRequest{Metadata: &map[string]string{" ": "b"}}, "keys can't be empty", nil},
+		{"metadata value too long", "audio", models.UpdateItemRequest{Metadata: &map[string]string{"a": strings.Repeat("v", maxMetadataValueLength+1)}}, "values must be", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateItemUpdate(tt.itemType, &tt.req)
+			if tt.wantErr == "" && got != "" {
+				t.Fatalf("validateItemUpdate() = %q, want valid", got)
+			}
+			if !strings.Contains(got, tt.wantErr) || (tt.wantErr != "" && got == "") {
+				t.Fatalf("validateItemUpdate() = %q, want error containing %q", got, tt.wantErr)
+			}
+			if tt.wantTags != nil && !reflect.DeepEqual(*tt.req.Tags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", *tt.req.Tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+// TestValidateItemUpdateTagCount verifies the tag limit counts unique tags.
+func TestValidateItemUpdateTagCount(t *testing.T) {
+	many := make([]string, maxTags+1)
+	for i := range many {
+		many[i] = "tag" + intToStr(i)
+	}
+	if got := validateItemUpdate("transcript", &models.UpdateItemRequest{Tags: &many}); !strings.Contains(got, "at most") {
+		t.Errorf("validateItemUpdate() = %q, want tag count error", got)
+	}
+
+	repeated := make([]string, maxTags+5)
+	for i := range repeated {
+		repeated[i] = "same"
+	}
+	if got := validateItemUpdate("transcript", &models.UpdateItemRequest{Tags: &repeated}); got != "" {
+		t.Errorf("validateItemUpdate() = %q, want duplicates collapsed", got)
+	}
+}
+
+// TestUploadAudioDetails verifies upload fields are parsed and validated,
+// and that no fields means no details.
+func TestUploadAudioDetails(t *testing.T) {
+	details, apiErr := uploadAudioDetails(map[string]string{
+		"title":    "  Call with Acme  ",
+		"notes":    "pricing",
+		"metadata": `{" customer ":"Acme","deal":"42"}`,
+	})
+	if apiErr != nil {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+	if details.Title != "Call with Acme" || details.Notes != "pricing" || string(details.Metadata) != `{"customer":"Acme","deal":"42"}` {
+		t.Errorf("details = %+v (metadata %s)", details, details.Metadata)
+	}
+
+	if details, apiErr := uploadAudioDetails(map[string]string{"language": "en"}); apiErr != nil || details.Metadata != nil || details.Title != "" {
+		t.Errorf("without fields: details = %+v, %+v; want empty", details, apiErr)
+	}
+	for _, metadata := range []string{`["a"]`, `{"n":1}`, `not json`} {
+		if _, apiErr := uploadAudioDetails(map[string]string{"metadata": metadata}); apiErr == nil || apiErr.Code != 400 {
+			t.Errorf("metadata %s: error = %+v, want 400", metadata, apiErr)
+		}
+	}
+}
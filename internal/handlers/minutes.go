@@ -0,0 +1,258 @@
+// minutes.go handles meeting minutes generation and export for
+// meeting-type audio transcriptions.
+//
+// Minutes are generated once (POST) and stored, then rendered on demand
+// (GET) as Markdown, Word, or PDF — re-exporting never costs another AI call.
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// GenerateMinutes creates meeting minutes from a meeting's summary and transcript.
+// POST /api/v1/audio/transcriptions/:id/minutes
+//
+// Requires content_type "meeting" and a completed summary.
+func (h *Handler) GenerateMinutes(c *gin.Context) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	at, ok := h.loadMinutesAudio(c)
+	if !ok {
+		return
+	}
+
+	if at.ContentType != models.ContentMeeting {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "unsupported_content_type",
+			Message: fmt.Sprintf("Minutes are only available for meeting content (this transcription is '%s')", at.ContentType),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if at.SummaryStatus != "completed" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "summary_required",
+			Message: "Summarize this meeting first (POST /api/v1/audio/transcriptions/:id/summarize)",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	var req models.GenerateMinutesRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	in := summary.MinutesInput{Transcript: at.TranscriptText, Summary: at.SummaryText}
+	json.Unmarshal(at.Decisions, &in.Decisions)
+	json.Unmarshal(at.ActionItems, &in.ActionItems)
+
+	minutes, err := h.Summarizer.GenerateMinutes(aiContext(c), in, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Minutes generation failed for %s: %v", at.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to generate minutes: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	minutesJSON, err := json.Marshal(minutes)
+	if err != nil {
+		log.Printf("Failed to marshal minutes for %s: %v", at.ID, err)
+		minutesJSON = []byte("{}")
+	}
+	at.Minutes = minutesJSON
+	at.MinutesModel = minutes.Model
+
+	if err := h.DB.UpdateAudioMinutes(c.Request.Context(), at); err != nil {
+		log.Printf("Failed to save minutes for %s: %v", at.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save minutes",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, minutes)
+}
+
+// ExportMinutes downloads the stored meeting minutes.
+// GET /api/v1/audio/transcriptions/:id/minutes?format=md|docx|pdf|json
+func (h *Handler) ExportMinutes(c *gin.Context) {
+	format := c.DefaultQuery("format", "md")
+	validFormats := map[string]bool{"md": true, "docx": true, "pdf": true, "json": true}
+	if !validFormats[format] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Supported formats: md, docx, pdf, json",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	at, ok := h.loadMinutesAudio(c)
+	if !ok {
+		return
+	}
+
+	var minutes summary.Minutes
+	// minutes_model is only set once minutes have been generated
+	if at.MinutesModel == "" || json.Unmarshal(at.Minutes, &minutes) != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "No minutes have been generated for this transcription",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, minutes)
+		return
+	}
+
+	doc := buildMinutesDocument(at, &minutes)
+	baseName := strings.TrimSuffix(at.OriginalName, filepath.Ext(at.OriginalName))
+
+	var (
+		data        []byte
+		contentType string
+		err         error
+	)
+	switch format {
+	case "md":
+		data, contentType = doc.Markdown(), "text/markdown; charset=utf-8"
+	case "docx":
+		data, err = doc.DOCX()
+		contentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	case "pdf":
+		data, err = doc.PDF()
+		contentType = "application/pdf"
+	}
+	if err != nil {
+		log.Printf("Failed to render minutes for %s as %s: %v", at.ID, format, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "export_failed",
+			Message: "Failed to render minutes",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_minutes.%s"`, sanitizeFilename(baseName), format))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// loadMinutesAudio fetches the audio transcription from the :id param and
+// checks ownership. On failure it writes the error response and returns false.
+func (h *Handler) loadMinutesAudio(c *gin.Context) (*models.AudioTranscription, bool) {
+	at, err := h.DB.GetAudioTranscription(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Audio transcription not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only access minutes for your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		})
+		return nil, false
+	}
+	return at, true
+}
+
+// buildMinutesDocument lays out the minutes in the conventional order:
+// header, attendees, agenda, decisions, action items, next steps.
+func buildMinutesDocument(at *models.AudioTranscription, m *summary.Minutes) *document.Document {
+	title := m.Title
+	if title == "" {
+		title = "Meeting Minutes"
+	}
+
+	doc := document.New(title).
+		Field("Date", at.CreatedAt.Format("January 2, 2006")).
+		Field("Recording", at.OriginalName).
+		Field("Duration", formatDuration(int(at.Duration)))
+
+	if len(m.Attendees) > 0 {
+		doc.Heading("Attendees").Bullets(m.Attendees)
+	}
+
+	if len(m.Agenda) > 0 {
+		doc.Heading("Agenda")
+		for i, t := range m.Agenda {
+			doc.Paragraph(fmt.Sprintf("%d. %s", i+1, t.Topic))
+			if t.Notes != "" {
+				doc.Bullets([]string{t.Notes})
+			}
+		}
+	}
+
+	if len(m.Decisions) > 0 {
+		doc.Heading("Decisions").Bullets(m.Decisions)
+	}
+
+	if len(m.ActionItems) > 0 {
+		items := make([]string, len(m.ActionItems))
+		for i, ai := range m.ActionItems {
+			item := ai.Task
+			if ai.Owner != "" {
+				item += " — " + ai.Owner
+			}
+			if ai.Due != "" {
+				item += " (due " + ai.Due + ")"
+			}
+			items[i] = item
+		}
+		doc.Heading("Action Items").Checkboxes(items)
+	}
+
+	if len(m.NextSteps) > 0 {
+		doc.Heading("Next Steps").Bullets(m.NextSteps)
+	}
+
+	return doc
+}
@@ -0,0 +1,172 @@
+// moments.go finds the most important moments of a video — timestamps with
+// a title and one-line description — for creators building chapter lists
+// and picking clips.
+//
+// Moments aren't stored: they're generated on request from the transcript's
+// caption timings, and repeats are answered from the AI completion cache.
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// Defaults and caps for key moments.
+const (
+	defaultMoments = 5
+	maxMoments     = 20
+
+	// momentBlockSeconds is how much of the video each timestamped line of
+	// the prompt covers; finer timings only cost prompt space.
+	momentBlockSeconds = 20
+)
+
+// CreateMoments identifies the most important moments of a completed
+// transcript.
+// POST /api/v1/transcripts/:id/moments
+//
+// Transcripts extracted before caption timings were stored get timestamps
+// estimated from word count and duration, flagged as approximate.
+func (h *Handler) CreateMoments(c *gin.Context) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.CreateMomentsRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+
+	if req.Count < 0 || req.Count > maxMoments {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("count must be 1-%d", maxMoments),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if req.Count == 0 {
+		req.Count = defaultMoments
+	}
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	t, ok := h.loadCompletedTranscript(c, "find moments in")
+	if !ok {
+		return
+	}
+
+	segments, approximate := transcriptTimings(t)
+
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	timed := export.TimedText(export.MergeSegments(segments, momentBlockSeconds))
+	result, err := h.Summarizer.FindMoments(ctx, timed, req.Count, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Key moments failed for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to find key moments: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.MomentsResponse{
+		TranscriptID: t.ID,
+		Moments:      keyMoments(result.Moments, t),
+		Approximate:  approximate,
+		Model:        result.Model,
+	})
+}
+
+// loadCompletedTranscript fetches the completed transcript in the :id param
+// for an AI feature, checking the caller may view it; what names the feature
+// in the forbidden message. On failure it writes the
+// error response and returns false.
+func (h *Handler) loadCompletedTranscript(c *gin.Context, what string) (*models.Transcript, bool) {
+	t, err := h.DB.GetTranscript(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Transcript not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only " + what + " your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return nil, false
+	}
+	if t.Status != models.StatusCompleted || t.TranscriptText == "" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "transcript_not_ready",
+			Message: "Transcript is not completed yet (status: " + string(t.Status) + ")",
+			Code:    http.StatusConflict,
+		})
+		return nil, false
+	}
+	return t, true
+}
+
+// transcriptTimings returns a transcript's stored caption segments, or
+// segments estimated from its text and duration when it has none, in which
+// case approximate is true.
+func transcriptTimings(t *models.Transcript) (segments []models.Segment, approximate bool) {
+	if segments := export.ParseSegments(t.Segments); len(segments) > 0 {
+		return segments, false
+	}
+	return export.ApproximateSegments(t.TranscriptText, float64(t.Duration)), true
+}
+
+// keyMoments links moments to the video, dropping any the model placed
+// past its end.
+func keyMoments(moments []summary.Moment, t *models.Transcript) []models.KeyMoment {
+	linked := []models.KeyMoment{}
+	for _, m := range moments {
+		if t.Duration > 0 && m.Seconds > t.Duration {
+			continue
+		}
+		linked = append(linked, models.KeyMoment{
+			Seconds:     m.Seconds,
+			Timestamp:   export.Clock(float64(m.Seconds)),
+			Title:       m.Title,
+			Description: m.Description,
+			URL:         videoURL(t.YouTubeID, m.Seconds),
+		})
+	}
+	return linked
+}
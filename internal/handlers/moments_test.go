@@ -0,0 +1,39 @@
+// moments_test.go tests linking key moments to the video and falling back
+// to estimated timings.
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+func TestKeyMoments(t *testing.T) {
+	tr := &models.Transcript{YouTubeID: "abc", Duration: 4000}
+	got := keyMoments([]summary.Moment{
+		{Seconds: 75, Title: "Demo", Description: "The live demo"},
+		{Seconds: 3723, Title: "Wrap-up", Description: "Closing thoughts"},
+		{Seconds: 4100, Title: "Past the end", Description: "Hallucinated"},
+	}, tr)
+
+	want := []models.KeyMoment{
+		{Seconds: 75, Timestamp: "1:15", Title: "Demo", Description: "The live demo", URL: "https://www.youtube.com/watch?v=abc&t=75s"},
+		{Seconds: 3723, Timestamp: "1:02:03", Title: "Wrap-up", Description: "Closing thoughts", URL: "https://www.youtube.com/watch?v=abc&t=3723s"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("keyMoments() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTranscriptTimings(t *testing.T) {
+	stored := &models.Transcript{Segments: []byte(`[{"start":1,"end":2,"text":"hi"}]`), TranscriptText: "hi"}
+	if segments, approximate := transcriptTimings(stored); approximate || len(segments) != 1 || segments[0].Start != 1 {
+		t.Errorf("stored timings = %+v (approximate %v), want the stored segment", segments, approximate)
+	}
+
+	older := &models.Transcript{TranscriptText: "one two three", Duration: 60}
+	if segments, approximate := transcriptTimings(older); !approximate || len(segments) != 1 || segments[0].End != 60 {
+		t.Errorf("estimated timings = %+v (approximate %v), want one segment spanning the video", segments, approximate)
+	}
+}
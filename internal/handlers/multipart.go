@@ -0,0 +1,235 @@
+// multipart.go streams multipart file uploads straight to temp files, so
+// concurrent large uploads don't each hold a copy of the file in memory.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+const (
+	// maxFormFieldSize caps each non-file form field (e.g. timeout_seconds).
+	maxFormFieldSize = 1 << 10
+
+	// multipartOverhead is slack on top of the file limit for boundaries,
+	// part headers, and form fields.
+	multipartOverhead = 1 << 20
+)
+
+// uploadedFile is a multipart file upload saved to a temp file. The caller
+// removes Path once done with it.
+type uploadedFile struct {
+	Path     string
+	Filename string            // The client's filename
+	Size     int64             // Bytes written to Path
+	Fields   map[string]string // The other (non-file) form fields
+}
+
+// receiveUpload streams the "file" part of a multipart request to a temp
+// file, reading it in small chunks rather than buffering the whole body.
+// accept vets the client's filename before any bytes are copied. kind
+// ("audio", "PDF") fills in error messages. Nothing is left on disk when it
+// returns an error.
+func receiveUpload(c *gin.Context, kind string, maxSize int64, accept func(filename string) *models.ErrorResponse) (*uploadedFile, *models.ErrorResponse) {
+	missing := &models.ErrorResponse{
+		Error:   "invalid_request",
+		Message: fmt.Sprintf("No %s file provided. Upload a file with the field name 'file'. Max size: %dMB.", kind, maxSize>>20),
+		Code:    http.StatusBadRequest,
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, missing
+	}
+
+	up := &uploadedFile{Fields: make(map[string]string)}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			up.remove()
+			return nil, uploadReadError(err, kind, maxSize)
+		}
+
+		if part.FormName() != "file" || part.FileName() == "" || up.Path != "" {
+			// A form field (or a second file, which is ignored)
+			if part.FileName() == "" {
+				value, _ := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+				up.Fields[part.FormName()] = string(value)
+			}
+			part.Close()
+			continue
+		}
+
+		if apiErr := accept(part.FileName()); apiErr != nil {
+			part.Close()
+			return nil, apiErr
+		}
+		if apiErr := up.save(part, kind, maxSize); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	if up.Path == "" {
+		return nil, missing
+	}
+	return up, nil
+}
+
+// receiveUploads streams every file part of a multipart request to its own
+// temp file, for batch uploads. accept vets each client filename before
+// its bytes are copied, returning the file's kind and size limit; maxTotal
+// caps the whole request. The form fields are returned alongside. Nothing
+// is left on disk when it returns an error.
+func receiveUploads(c *gin.Context, maxFiles int, maxTotal int64, accept func(filename string) (string, int64, *models.ErrorResponse)) ([]*uploadedFile, map[string]string, *models.ErrorResponse) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxTotal+multipartOverhead)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, nil, &models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Send the files as multipart/form-data, each with the field name 'files'",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	var files []*uploadedFile
+	fields := make(map[string]string)
+	fail := func(apiErr *models.ErrorResponse) ([]*uploadedFile, map[string]string, *models.ErrorResponse) {
+		for _, up := range files {
+			up.remove()
+		}
+		return nil, nil, apiErr
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fail(batchReadError(err, maxTotal))
+		}
+
+		if part.FileName() == "" {
+			value, _ := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+			fields[part.FormName()] = string(value)
+			part.Close()
+			continue
+		}
+		if len(files) == maxFiles {
+			part.Close()
+			return fail(&models.ErrorResponse{
+				Error:   "too_many_files",
+				Message: fmt.Sprintf("Maximum %d files per batch", maxFiles),
+				Code:    http.StatusBadRequest,
+			})
+		}
+
+		kind, maxSize, apiErr := accept(part.FileName())
+		if apiErr != nil {
+			part.Close()
+			return fail(apiErr)
+		}
+		up := &uploadedFile{Fields: fields}
+		if apiErr := up.save(part, kind, maxSize); apiErr != nil {
+			// A failed read may be the request outgrowing maxTotal, which
+			// the body reader keeps reporting
+			if _, err := c.Request.Body.Read(nil); err != nil {
+				if tooBig := batchReadError(err, maxTotal); tooBig.Error == "file_too_large" {
+					apiErr = tooBig
+				}
+			}
+			return fail(apiErr)
+		}
+		files = append(files, up)
+	}
+	return files, fields, nil
+}
+
+// batchReadError maps a failed read of a batch upload's body to a response.
+func batchReadError(err error, maxTotal int64) *models.ErrorResponse {
+	var tooBig *http.MaxBytesError
+	if errors.As(err, &tooBig) {
+		return &models.ErrorResponse{
+			Error:   "file_too_large",
+			Message: fmt.Sprintf("The files exceed the maximum batch size (%d MB in total).", maxTotal>>20),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return uploadReadError(err, "batch", maxTotal)
+}
+
+// save copies a file part to a new temp file, enforcing maxSize.
+func (up *uploadedFile) save(part *multipart.Part, kind string, maxSize int64) *models.ErrorResponse {
+	defer part.Close()
+
+	ext := strings.ToLower(filepath.Ext(part.FileName()))
+	f, err := os.CreateTemp("", "upload-*"+ext)
+	if err != nil {
+		log.Printf("Failed to create temp file: %v", err)
+		return &models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to process uploaded file",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+	up.Path = f.Name()
+	up.Filename = part.FileName()
+
+	// Read one byte past the limit to tell "exactly maxSize" from "too big"
+	up.Size, err = io.Copy(f, io.LimitReader(part, maxSize+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	switch {
+	case err != nil:
+		up.remove()
+		return uploadReadError(err, kind, maxSize)
+	case up.Size > maxSize:
+		up.remove()
+		return tooLarge(kind, maxSize)
+	}
+	return nil
+}
+
+// remove deletes the temp file, if one was written.
+func (up *uploadedFile) remove() {
+	if up.Path != "" {
+		os.Remove(up.Path)
+		up.Path = ""
+	}
+}
+
+// uploadReadError maps a failed read of the request body to a response.
+func uploadReadError(err error, kind string, maxSize int64) *models.ErrorResponse {
+	var tooBig *http.MaxBytesError
+	if errors.As(err, &tooBig) {
+		return tooLarge(kind, maxSize)
+	}
+	return &models.ErrorResponse{
+		Error:   "read_error",
+		Message: "Failed to read uploaded file",
+		Code:    http.StatusBadRequest,
+	}
+}
+
+func tooLarge(kind string, maxSize int64) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Error:   "file_too_large",
+		Message: fmt.Sprintf("The %s file exceeds the maximum size (%d MB).", kind, maxSize>>20),
+		Code:    http.StatusBadRequest,
+	}
+}
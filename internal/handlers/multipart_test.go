@@ -0,0 +1,137 @@
+// multipart_test.go contains tests for streaming multipart uploads.
+package handlers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestReceiveUpload verifies the file part lands on disk with the other
+// form fields captured, and bad or oversized files leave nothing behind.
+func TestReceiveUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	acceptMP3 := func(filename string) *models.ErrorResponse {
+		if !strings.HasSuffix(filename, ".mp3") {
+			return &models.ErrorResponse{Error: "invalid_file_type", Code: http.StatusBadRequest}
+		}
+		return nil
+	}
+	tests := []struct {
+		name      string
+		filename  string // Empty sends no file part
+		content   string
+		wantError string
+	}{
+		{name: "ok", filename: "call.mp3", content: "ID3 audio"},
+		{name: "at the limit", filename: "call.mp3", content: strings.Repeat("a", 16)},
+		{name: "too large", filename: "call.mp3", content: strings.Repeat("a", 17), wantError: "file_too_large"},
+		{name: "wrong type", filename: "call.exe", content: "MZ", wantError: "invalid_file_type"},
+		{name: "no file", wantError: "invalid_request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			if tt.filename != "" {
+				fw, _ := mw.CreateFormFile("file", tt.filename)
+				fw.Write([]byte(tt.content))
+			}
+			mw.WriteField("timeout_seconds", "120") // After the file, as some clients send it
+			mw.Close()
+
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", &body)
+			c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+
+			up, apiErr := receiveUpload(c, "audio", 16, acceptMP3)
+			if tt.wantError != "" {
+				if apiErr == nil || apiErr.Error != tt.wantError {
+					t.Fatalf("error = %+v, want %s", apiErr, tt.wantError)
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatalf("unexpected error: %+v", apiErr)
+			}
+			defer up.remove()
+
+			got, err := os.ReadFile(up.Path)
+			if err != nil || string(got) != tt.content || up.Size != int64(len(tt.content)) {
+				t.Errorf("saved %q (%d bytes, err %v), want %q", got, up.Size, err, tt.content)
+			}
+			if up.Filename != tt.filename || up.Fields["timeout_seconds"] != "120" {
+				t.Errorf("filename %q, fields %v", up.Filename, up.Fields)
+			}
+		})
+	}
+}
+
+// TestReceiveUploads verifies a batch's files each land on disk, and that
+// too many files or too many bytes in total are rejected.
+func TestReceiveUploads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accept := func(filename string) (string, int64, *models.ErrorResponse) {
+		itemType, _, apiErr := batchItemType(filename)
+		return itemType, 1 << 20, apiErr
+	}
+	big := strings.Repeat("a", 600<<10)
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantError string
+		wantMsg   string
+	}{
+		{name: "ok", files: map[string]string{"call.mp3": "ID3 audio", "deck.pdf": "%PDF-1.7"}},
+		{name: "too many", files: map[string]string{"a.mp3": "a", "b.mp3": "b", "c.mp3": "c"}, wantError: "too_many_files"},
+		{name: "wrong type", files: map[string]string{"call.exe": "MZ"}, wantError: "invalid_file_type"},
+		{name: "batch too large", files: map[string]string{"a.mp3": big, "b.mp3": big}, wantError: "file_too_large", wantMsg: "batch size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			mw.WriteField("tables", "true")
+			for name, content := range tt.files {
+				fw, _ := mw.CreateFormFile("files", name)
+				fw.Write([]byte(content))
+			}
+			mw.Close()
+
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", &body)
+			c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+
+			ups, fields, apiErr := receiveUploads(c, 2, 16, accept)
+			if tt.wantError != "" {
+				if apiErr == nil || apiErr.Error != tt.wantError || !strings.Contains(apiErr.Message, tt.wantMsg) {
+					t.Fatalf("error = %+v, want %s", apiErr, tt.wantError)
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatalf("unexpected error: %+v", apiErr)
+			}
+			if len(ups) != len(tt.files) || fields["tables"] != "true" {
+				t.Fatalf("got %d files, fields %v", len(ups), fields)
+			}
+			for _, up := range ups {
+				got, err := os.ReadFile(up.Path)
+				if err != nil || string(got) != tt.files[up.Filename] {
+					t.Errorf("%s: saved %q (err %v), want %q", up.Filename, got, err, tt.files[up.Filename])
+				}
+				up.remove()
+			}
+		})
+	}
+}
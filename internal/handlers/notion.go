@@ -0,0 +1,102 @@
+// notion.go exports transcripts to the user's Notion integration.
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/notion"
+)
+
+// ExportTranscriptToNotion creates a page for a transcript in the user's
+// configured Notion database: metadata, the latest summary, then the transcript.
+// POST /api/v1/transcripts/:id/export/notion
+func (h *Handler) ExportTranscriptToNotion(c *gin.Context) {
+	userID := integrationUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "no_user",
+			Message: "Integrations belong to user accounts. Log in, or link this API key to a user.",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	t, err := h.DB.GetTranscript(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Transcript not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only export your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if t.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Transcript is not completed (status: " + string(t.Status) + ")",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	integration, err := h.DB.GetIntegration(c.Request.Context(), userID, notion.Provider)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "integration_not_configured",
+				Message: "No notion integration configured. Add one via PUT /api/v1/integrations/notion",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		log.Printf("Failed to load notion integration: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load integration",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	var config map[string]string
+	json.Unmarshal(integration.Config, &config)
+
+	var latest *models.Summary
+	if s, err := h.DB.GetLatestSummary(c.Request.Context(), t.ID); err == nil {
+		latest = s
+	}
+
+	page, err := h.Notion.CreatePage(c.Request.Context(), integration.Token, config["database_id"], export.TranscriptDocument(t, latest))
+	if err != nil {
+		log.Printf("Notion export failed for transcript %s: %v", t.ID, err)
+		message := err.Error()
+		if page != nil {
+			// The page exists but appending the rest of the content failed
+			message += " (partial page: " + page.URL + ")"
+		}
+		c.JSON(http.StatusBadGateway, models.ErrorResponse{
+			Error:   "notion_error",
+			Message: message,
+			Code:    http.StatusBadGateway,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.NotionExportResponse{PageID: page.ID, PageURL: page.URL})
+}
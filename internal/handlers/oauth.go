@@ -0,0 +1,200 @@
+// oauth.go handles signing in with Google and GitHub. A successful sign-in
+// issues the same JWT as /auth/login.
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// oauthStateCookie holds the state sent to the provider, so the callback can
+// tell the sign-in was started from this browser (CSRF protection).
+const oauthStateCookie = "oauth_state"
+
+// oauthStateTTL is how long a user has to finish signing in at the provider.
+const oauthStateTTL = 10 * 60 // seconds
+
+// ListOAuthProviders returns the providers users can sign in with.
+// GET /api/v1/auth/oauth
+func (h *Handler) ListOAuthProviders(c *gin.Context) {
+	providers := []string{}
+	if h.OAuth != nil {
+		providers = h.OAuth.Providers()
+	}
+	c.JSON(http.StatusOK, gin.H{"providers": providers})
+}
+
+// StartOAuth redirects the browser to the provider's consent page.
+// GET /api/v1/auth/oauth/:provider
+func (h *Handler) StartOAuth(c *gin.Context) {
+	provider := c.Param("provider")
+	if h.OAuth == nil || !h.OAuth.Enabled(provider) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "provider_not_configured",
+			Message: "Sign-in with " + provider + " isn't configured",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("❌ Failed to generate OAuth state: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_error",
+			Message: "Failed to start sign-in",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	state := hex.EncodeToString(b)
+
+	authURL, err := h.OAuth.AuthCodeURL(provider, state)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "oauth_error",
+			Message: "Failed to start sign-in",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Lax so the cookie comes back on the provider's top-level redirect.
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, oauthStateTTL, "/api/v1/auth/oauth", "", isHTTPS(c), true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OAuthCallback finishes sign-in: it checks the state, asks the provider who
+// signed in, and finds or creates the user. A provider account already
+// linked signs in as its user. Otherwise the provider must have verified the
+// email: an existing account with that email is linked, or a new
+// (passwordless) account is created.
+// GET /api/v1/auth/oauth/:provider/callback
+//
+// Responds like /auth/login, or redirects to OAUTH_SUCCESS_REDIRECT with
+// #token=<jwt> when that's configured.
+func (h *Handler) OAuthCallback(c *gin.Context) {
+	provider := c.Param("provider")
+	if h.OAuth == nil || !h.OAuth.Enabled(provider) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "provider_not_configured",
+			Message: "Sign-in with " + provider + " isn't configured",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	if reason := c.Query("error"); reason != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "oauth_denied",
+			Message: "Sign-in was cancelled or denied: " + reason,
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	state, _ := c.Cookie(oauthStateCookie)
+	c.SetCookie(oauthStateCookie, "", -1, "/api/v1/auth/oauth", "", isHTTPS(c), true)
+	if !validOAuthState(state, c.Query("state")) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_state",
+			Message: "Sign-in expired or was started in another browser; try again",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	identity, err := h.OAuth.Identify(ctx, provider, c.Query("code"))
+	if err != nil {
+		log.Printf("❌ OAuth sign-in with %s failed: %v", provider, err)
+		c.JSON(http.StatusBadGateway, models.ErrorResponse{
+			Error:   "oauth_failed",
+			Message: "Couldn't complete sign-in with " + provider,
+			Code:    http.StatusBadGateway,
+		})
+		return
+	}
+
+	user, err := h.DB.GetUserByIdentity(ctx, provider, identity.Subject)
+	if err != nil {
+		if !identity.EmailVerified {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "email_not_verified",
+				Message: "Verify your primary email with " + provider + " before signing in",
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+
+		user, err = h.DB.GetUserByEmail(ctx, identity.Email)
+		if err != nil {
+			user = &models.User{Email: identity.Email, Name: identity.Name}
+			if err := h.DB.CreateUser(ctx, user); err != nil {
+				log.Printf("❌ Failed to create user: %v", err)
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+					Error:   "database_error",
+					Message: "Failed to create account",
+					Code:    http.StatusInternalServerError,
+				})
+				return
+			}
+		}
+
+		if err := h.DB.LinkUserIdentity(ctx, user.ID, provider, identity.Subject, identity.Email); err != nil {
+			log.Printf("❌ %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to link your " + provider + " account",
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		// The provider verified this email, so there's no need to send a link.
+		if err := h.DB.MarkEmailVerified(ctx, user.ID); err != nil {
+			log.Printf("❌ %v", err)
+		}
+	}
+
+	resp, err := h.issueTokens(c, user)
+	if err != nil {
+		log.Printf("❌ Failed to generate token: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "token_error",
+			Message: "Failed to generate token",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if redirect := h.OAuth.SuccessRedirect(); redirect != "" {
+		// The fragment never reaches a server, so the tokens stay out of logs.
+		c.Redirect(http.StatusFound, redirect+"#token="+url.QueryEscape(resp.Token)+
+			"&refresh_token="+url.QueryEscape(resp.RefreshToken))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// validOAuthState reports whether the state the provider echoed back matches
+// the one in the browser's cookie.
+func validOAuthState(cookie, returned string) bool {
+	return cookie != "" && subtle.ConstantTimeCompare([]byte(cookie), []byte(returned)) == 1
+}
+
+// isHTTPS reports whether the client reached us over HTTPS, directly or
+// through a TLS-terminating proxy.
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
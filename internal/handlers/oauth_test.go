@@ -0,0 +1,83 @@
+// oauth_test.go contains tests for OAuth sign-in handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/oauth"
+)
+
+// TestOAuthRoutes verifies unconfigured providers are rejected, sign-in
+// redirects to the provider with a state cookie, and callbacks without a
+// matching state are refused before contacting the provider.
+func TestOAuthRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{OAuth: oauth.New(oauth.Config{GitHubClientID: "hid", RedirectBaseURL: "https://api.example.com"})}
+
+	r := gin.New()
+	r.GET("/api/v1/auth/oauth/:provider", h.StartOAuth)
+	r.GET("/api/v1/auth/oauth/:provider/callback", h.OAuthCallback)
+
+	tests := []struct {
+		name   string
+		path   string
+		cookie string
+		want   int
+	}{
+		{"start, unconfigured provider", "/api/v1/auth/oauth/google", "", http.StatusNotFound},
+		{"start", "/api/v1/auth/oauth/github", "", http.StatusFound},
+		{"callback, unconfigured provider", "/api/v1/auth/oauth/google/callback?code=c&state=s", "s", http.StatusNotFound},
+		{"callback, denied", "/api/v1/auth/oauth/github/callback?error=access_denied", "", http.StatusBadRequest},
+		{"callback, no state cookie", "/api/v1/auth/oauth/github/callback?code=c&state=s", "", http.StatusBadRequest},
+		{"callback, wrong state", "/api/v1/auth/oauth/github/callback?code=c&state=s", "other", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: oauthStateCookie, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusFound {
+				loc := w.Header().Get("Location")
+				cookie := w.Header().Get("Set-Cookie")
+				if !strings.HasPrefix(loc, "https://github.com/login/oauth/authorize?") {
+					t.Errorf("Location = %q", loc)
+				}
+				if !strings.HasPrefix(cookie, oauthStateCookie+"=") || !strings.Contains(cookie, "HttpOnly") {
+					t.Errorf("Set-Cookie = %q", cookie)
+				}
+			}
+		})
+	}
+}
+
+// TestValidOAuthState verifies the returned state must match a non-empty
+// cookie exactly.
+func TestValidOAuthState(t *testing.T) {
+	tests := []struct {
+		cookie, returned string
+		want             bool
+	}{
+		{"abc", "abc", true},
+		{"abc", "abd", false},
+		{"", "", false},
+		{"abc", "", false},
+	}
+	for _, tt := range tests {
+		if got := validOAuthState(tt.cookie, tt.returned); got != tt.want {
+			t.Errorf("validOAuthState(%q, %q) = %v, want %v", tt.cookie, tt.returned, got, tt.want)
+		}
+	}
+}
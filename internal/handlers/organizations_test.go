@@ -0,0 +1,110 @@
+// organizations_test.go contains tests for organization roles and item
+// visibility.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestCanManageOrgMember verifies admins manage members and admins, owners
+// manage everyone, and members manage no one.
+func TestCanManageOrgMember(t *testing.T) {
+	tests := []struct {
+		actor, role string
+		want        bool
+	}{
+		{"owner", "owner", true},
+		{"owner", "admin", true},
+		{"owner", "member", true},
+		{"admin", "owner", false},
+		{"admin", "admin", true},
+		{"admin", "member", true},
+		{"member", "member", false},
+		{"", "member", false},
+	}
+	for _, tt := range tests {
+		if got := canManageOrgMember(tt.actor, tt.role); got != tt.want {
+			t.Errorf("canManageOrgMember(%q, %q) = %v, want %v", tt.actor, tt.role, got, tt.want)
+		}
+	}
+}
+
+// TestOrganizationHandlersRequireUser verifies organization endpoints
+// reject requests without a logged-in user before touching the database.
+func TestOrganizationHandlersRequireUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	handlers := map[string]gin.HandlerFunc{
+		"create":            h.CreateOrganization,
+		"list":              h.ListOrganizations,
+		"get":               h.GetOrganization,
+		"members":           h.ListOrgMembers,
+		"invite":            h.CreateOrgInvitation,
+		"accept invitation": h.AcceptOrgInvitation,
+		"create key":        h.CreateOrgAPIKey,
+		"items":             h.ListOrgItems,
+	}
+	for name, handle := range handlers {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/orgs", nil)
+
+		handle(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, w.Code)
+		}
+	}
+}
+
+// TestCanViewItem verifies keys see their own and unowned items, keys
+// outside an organization don't see other keys' items, admin keys aren't
+// restricted, and users only see their own and unowned items.
+func TestCanViewItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{OwnerAPIKeyID: "owner"}
+	key1, key2 := "key-1", "key-2"
+	user1, user2 := "user-1", "user-2"
+
+	tests := []struct {
+		name      string
+		key       *models.APIKey
+		user      *models.User
+		owner     *string
+		ownerUser *string
+		want      bool
+	}{
+		{name: "own item", key: &models.APIKey{ID: key1}, owner: &key1, want: true},
+		{name: "unowned item", key: &models.APIKey{ID: key1}, want: true},
+		{name: "other key, no organization", key: &models.APIKey{ID: key1}, owner: &key2, want: false},
+		{name: "admin key", key: &models.APIKey{ID: key1, Role: models.KeyRoleAdmin}, owner: &key2, want: true},
+		{name: "owner key", key: &models.APIKey{ID: "owner"}, owner: &key2, want: true},
+		{name: "jwt request, own item", user: &models.User{ID: user1}, ownerUser: &user1, want: true},
+		{name: "jwt request, other user's item", user: &models.User{ID: user1}, ownerUser: &user2, want: false},
+		{name: "jwt request, unowned item", user: &models.User{ID: user1}, want: true},
+		{name: "no credentials", owner: &key2, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			// The auth middleware's context keys
+			if tt.key != nil {
+				c.Set("api_key", tt.key)
+			}
+			if tt.user != nil {
+				c.Set("user", tt.user)
+			}
+			if got := h.canViewItem(c, tt.owner, tt.ownerUser); got != tt.want {
+				t.Errorf("canViewItem() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// originals.go serves signed download links for the original uploads of
+// audio transcriptions and PDF extractions, when STORAGE_BACKEND keeps them.
+//
+// GET /api/v1/audio/transcriptions/:id/original — Signed link to the uploaded audio
+// GET /api/v1/pdf/extractions/:id/original      — Signed link to the uploaded PDF
+// GET /api/v1/originals/:key                    — Download via a local signed link
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/storage"
+)
+
+// originalURLTTL is how long a signed original download link stays valid.
+const originalURLTTL = 15 * time.Minute
+
+// GetAudioOriginal returns a signed download link for an uploaded audio file.
+// GET /api/v1/audio/transcriptions/:id/original
+func (h *Handler) GetAudioOriginal(c *gin.Context) {
+	at, err := h.DB.GetAudioTranscription(c.Request.Context(), c.Param("id"))
+	if err != nil || !h.canManageItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Audio transcription not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	h.writeOriginalURL(c, at.Filename, at.OriginalStoredAt)
+}
+
+// GetPDFOriginal returns a signed download link for an uploaded PDF.
+// GET /api/v1/pdf/extractions/:id/original
+func (h *Handler) GetPDFOriginal(c *gin.Context) {
+	pe, err := h.DB.GetPDFExtraction(c.Request.Context(), c.Param("id"))
+	if err != nil || !h.canManageItem(c, pe.APIKeyID, pe.UserID) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "PDF extraction not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	h.writeOriginalURL(c, pe.Filename, pe.OriginalStoredAt)
+}
+
+// writeOriginalURL signs a download link for key, or explains why there
+// is no original to download.
+func (h *Handler) writeOriginalURL(c *gin.Context, key string, storedAt *time.Time) {
+	store := h.Worker.Originals()
+	if store == nil || storedAt == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "original_not_available",
+			Message: "The original upload was not kept or has passed its retention period",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	expiresAt := time.Now().Add(originalURLTTL)
+	url, err := store.SignedURL(key, originalURLTTL)
+	if err != nil {
+		log.Printf("❌ Failed to sign original URL for %s: %v", key, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to create download link",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.OriginalURLResponse{URL: url, ExpiresAt: expiresAt})
+}
+
+// ServeOriginal streams an original upload from local storage. The signed
+// query string is the credential, so this route needs no API key.
+// GET /api/v1/originals/:key?expires=...&signature=...
+func (h *Handler) ServeOriginal(c *gin.Context) {
+	local, ok := h.Worker.Originals().(*storage.Local)
+	if !ok {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Original not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	key := c.Param("key")
+	if err := local.Verify(key, c.Query("expires"), c.Query("signature")); err != nil {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "invalid_signature",
+			Message: "Download link is invalid or has expired: " + err.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	path, err := local.Path(key)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Original not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	c.FileAttachment(path, key)
+}
+
+// deleteOriginal removes a deleted item's original upload, if one was kept.
+func (h *Handler) deleteOriginal(ctx context.Context, key string, storedAt *time.Time) {
+	store := h.Worker.Originals()
+	if store == nil || storedAt == nil {
+		return
+	}
+	if err := store.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("⚠️  Failed to delete original %s: %v", key, err)
+	}
+}
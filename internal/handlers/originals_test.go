@@ -0,0 +1,61 @@
+// originals_test.go contains tests for signed original downloads.
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/storage"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+// TestServeOriginal verifies a signed local link downloads the file, and
+// tampered or expired links are refused.
+func TestServeOriginal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	local, err := storage.NewLocal(t.TempDir(), "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := local.Put(context.Background(), "abc.pdf", strings.NewReader("%PDF"), 4, "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+	wp := worker.NewPool(1, 1, nil, nil, nil)
+	wp.SetOriginalStore(local, 0)
+	h := &Handler{Worker: wp}
+
+	r := gin.New()
+	r.GET("/api/v1/originals/:key", h.ServeOriginal)
+
+	valid, _ := local.SignedURL("abc.pdf", time.Minute)
+	expired, _ := local.SignedURL("abc.pdf", -time.Minute)
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+	}{
+		{"valid", valid, http.StatusOK},
+		{"expired", expired, http.StatusForbidden},
+		{"tampered", strings.Replace(valid, "abc.pdf", "xyz.pdf", 1), http.StatusForbidden},
+		{"unsigned", "/api/v1/originals/abc.pdf", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusOK && w.Body.String() != "%PDF" {
+				t.Errorf("body = %q, want the stored file", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// pagination.go holds helpers shared by the paginated list handlers.
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// writeCursorError responds 400 and returns true if err is a bad cursor.
+func writeCursorError(c *gin.Context, err error) bool {
+	if !errors.Is(err, database.ErrInvalidCursor) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   "invalid_cursor",
+		Message: "cursor is malformed or belongs to a different list; pass next_cursor back unchanged",
+		Code:    http.StatusBadRequest,
+	})
+	return true
+}
+
+// responsePage is the page number to report for a list request. Pages
+// fetched by cursor have no fixed position, so they report 0.
+func responsePage(page int, cursor string) int {
+	if cursor != "" {
+		return 0
+	}
+	if page < 1 {
+		return 1
+	}
+	return page
+}
@@ -0,0 +1,62 @@
+// pagination_test.go contains tests for the list pagination helpers.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+)
+
+// TestResponsePage verifies cursor pages report 0 and others default to 1.
+func TestResponsePage(t *testing.T) {
+	tests := []struct {
+		page   int
+		cursor string
+		want   int
+	}{
+		{0, "", 1},
+		{3, "", 3},
+		{3, "abc", 0},
+	}
+
+	for _, tt := range tests {
+		if got := responsePage(tt.page, tt.cursor); got != tt.want {
+			t.Errorf("responsePage(%d, %q) = %d, want %d", tt.page, tt.cursor, got, tt.want)
+		}
+	}
+}
+
+// TestWriteCursorError verifies only cursor errors become a 400.
+func TestWriteCursorError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		err       error
+		wantWrite bool
+	}{
+		{"nil", nil, false},
+		{"other error", errors.New("connection refused"), false},
+		{"invalid cursor", database.ErrInvalidCursor, true},
+		{"wrapped", fmt.Errorf("list: %w", database.ErrInvalidCursor), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			if got := writeCursorError(c, tt.err); got != tt.wantWrite {
+				t.Fatalf("writeCursorError() = %v, want %v", got, tt.wantWrite)
+			}
+			if tt.wantWrite && w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", w.Code)
+			}
+		})
+	}
+}
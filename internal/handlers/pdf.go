@@ -1,24 +1,35 @@
 // pdf.go handles PDF text extraction HTTP endpoints (MTA-17).
 //
 // POST /api/v1/pdf/extract — Upload PDF file for text extraction
+// POST /api/v1/pdf/extract-url — Download a PDF and extract it (pdf_url.go)
 // GET  /api/v1/pdf/extractions/:id — Get extraction result by ID
-// GET  /api/v1/pdf/extractions — List recent extractions
+// GET  /api/v1/pdf/extractions/:id/pages/:n — Get one page's text
+// GET  /api/v1/pdf/extractions/:id/tables — Download detected tables (pdf_tables.go)
+// GET  /api/v1/pdf/extractions — List extractions (paginated)
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/redact"
 )
 
 // maxPDFSize is the max upload size for PDF files (50MB).
@@ -28,55 +39,95 @@ const maxPDFSize = 50 << 20 // 50MB
 // POST /api/v1/pdf/extract
 //
 // Accepts multipart file upload with field name "file".
-// Only .pdf files are accepted. Processing is synchronous.
+// .pdf and .epub files are accepted. Processing is synchronous.
+// An optional "pages" field ("10-25", "7", or "10-") extracts only those pages,
+// "tables=true" also detects tables, and "markdown=true" also keeps the text
+// as Markdown, with headings, lists, and tables; these apply only to PDFs.
 func (h *Handler) ExtractPDF(c *gin.Context) {
-	// Limit request body size
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxPDFSize)
+	// Stream the upload to disk; the PDF library reads it from there
+	up, apiErr := receiveUpload(c, "PDF", maxPDFSize, func(filename string) *models.ErrorResponse {
+		if ext := strings.ToLower(filepath.Ext(filename)); ext != ".pdf" && ext != ".epub" {
+			return &models.ErrorResponse{
+				Error:   "invalid_file_type",
+				Message: fmt.Sprintf("Unsupported file format '%s'. Only .pdf and .epub files are accepted.", ext),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		return nil
+	})
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defer up.remove()
 
-	// Get the uploaded file
-	file, header, err := c.Request.FormFile("file")
+	redacted, apiErr := parseRedactFlag(up.Fields["redact"])
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	opts, err := pdfservice.ParsePageRange(up.Fields["pages"])
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "No PDF file provided. Upload a file with the field name 'file'. Max size: 50MB.",
+			Error:   "invalid_pages",
+			Message: err.Error(),
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	defer file.Close()
-
-	// Validate file extension
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if ext != ".pdf" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_file_type",
-			Message: fmt.Sprintf("Unsupported file format '%s'. Only .pdf files are accepted.", ext),
-			Code:    http.StatusBadRequest,
-		})
+	if opts.Tables, apiErr = parseFlag("tables", up.Fields["tables"]); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if opts.Markdown, apiErr = parseFlag("markdown", up.Fields["markdown"]); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
 		return
 	}
 
-	// Read the entire file into memory for the PDF library
-	// Go Pattern: io.ReadAll reads the entire reader into a byte slice.
-	// For PDFs up to 50MB this is fine — the pdf library needs random access.
-	data, err := io.ReadAll(file)
+	file, err := os.Open(up.Path)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "read_error",
-			Message: "Failed to read uploaded file",
-			Code:    http.StatusBadRequest,
+		log.Printf("Failed to open temp file: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to process uploaded file",
+			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
+	defer file.Close()
+
+	var pe *models.PDFExtraction
+	if strings.EqualFold(filepath.Ext(up.Filename), ".epub") {
+		if opts != (pdfservice.ExtractOptions{}) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "pages, tables, and markdown apply only to PDFs",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		pe, apiErr = h.extractEPUB(c, file, up.Size, up.Filename, redacted)
+	} else {
+		pe, apiErr = h.extractPDF(c, file, up.Size, up.Filename, "", redacted, opts)
+	}
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
 
+	c.JSON(http.StatusOK, pe)
+}
+
+// extractPDF validates and extracts an uploaded PDF, saving the result.
+// Shared by direct and resumable uploads, and PDFs fetched from sourceURL.
+func (h *Handler) extractPDF(c *gin.Context, r io.ReaderAt, size int64, originalName, sourceURL string, redacted bool, opts pdfservice.ExtractOptions) (*models.PDFExtraction, *models.ErrorResponse) {
 	// Validate PDF magic bytes
-	if !pdfservice.ValidatePDF(data) {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+	if !pdfservice.ValidatePDF(r) {
+		return nil, &models.ErrorResponse{
 			Error:   "invalid_pdf",
 			Message: "The uploaded file does not appear to be a valid PDF",
 			Code:    http.StatusBadRequest,
-		})
-		return
+		}
 	}
 
 	// Generate a unique filename for storage reference
@@ -89,49 +140,128 @@ func (h *Handler) ExtractPDF(c *gin.Context) {
 	}
 
 	// Extract text from the PDF (synchronous — PDFs process fast)
-	result, err := pdfservice.Extract(data)
+	start := time.Now()
+	result, err := pdfservice.ExtractWithOptions(r, size, opts)
+	if errors.Is(err, pdfservice.ErrPageRange) {
+		return nil, &models.ErrorResponse{
+			Error:   "invalid_pages",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
 	if err != nil {
-		log.Printf("PDF extraction failed for %s: %v", header.Filename, err)
+		log.Printf("PDF extraction failed for %s: %v", originalName, err)
 
 		// Save the failed record
 		pe := &models.PDFExtraction{
 			Filename:     storedFilename,
-			OriginalName: header.Filename,
+			OriginalName: originalName,
+			SourceType:   models.SourcePDF,
+			SourceURL:    sourceURL,
 			Status:       "failed",
 			ErrorMessage: err.Error(),
 			APIKeyID:     apiKeyID,
 		}
-		h.DB.CreatePDFExtraction(c.Request.Context(), pe)
+		if err := h.DB.CreatePDFExtraction(c.Request.Context(), pe); err == nil {
+			h.storePDFOriginal(c, pe, r, size)
+		}
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "extraction_failed",
 			Message: "PDF text extraction failed: " + err.Error(),
 			Code:    http.StatusInternalServerError,
-		})
-		return
+		}
 	}
 
 	// Save the successful extraction
 	pe := &models.PDFExtraction{
-		Filename:     storedFilename,
-		OriginalName: header.Filename,
-		PageCount:    result.PageCount,
-		TextContent:  result.Text,
-		WordCount:    result.WordCount,
-		Status:       "completed",
-		APIKeyID:     apiKeyID,
+		Filename:        storedFilename,
+		OriginalName:    originalName,
+		SourceType:      models.SourcePDF,
+		SourceURL:       sourceURL,
+		PageCount:       result.PageCount,
+		FirstPage:       result.FirstPage,
+		LastPage:        result.LastPage,
+		TextContent:     result.Text,
+		MarkdownContent: result.Markdown,
+		WordCount:       result.WordCount,
+		Status:          "completed",
+		APIKeyID:        apiKeyID,
+		Usage:           models.ProcessingUsage{ProcessingMS: time.Since(start).Milliseconds()}.JSON(),
+	}
+	setPDFTables(pe, result.Tables)
+	setPDFStructure(pe, result.Info, result.Outline)
+	if h.saveExtraction(c, pe, redacted) {
+		h.storePDFOriginal(c, pe, r, size)
+	}
+
+	return pe, nil
+}
+
+// saveExtraction stores a finished extraction — from a PDF, an EPUB, or an
+// article — masking PII first if redacted is set. It reports whether the
+// record was saved; a failed save is logged, and the result is still
+// returned to the client.
+func (h *Handler) saveExtraction(c *gin.Context, pe *models.PDFExtraction, redacted bool) bool {
+	var redactor *redact.Redactor
+	if redacted {
+		redactor = h.redactor(c, pe.TextContent)
+		pe.TextContent = redactor.Text(pe.TextContent)
+		redactPDFStructured(redactor, pe)
 	}
 
 	if err := h.DB.CreatePDFExtraction(c.Request.Context(), pe); err != nil {
 		log.Printf("Failed to save PDF extraction record: %v", err)
-		// Still return the result even if DB save fails
+		return false
 	}
+	if redactor != nil {
+		pe.Redaction = h.saveRedaction(c, "pdf", pe.ID, redactor)
+	}
+	if h.WebhookService != nil {
+		h.WebhookService.NotifySearchMatches(c.Request.Context(), "pdf", pe.ID)
+	}
+	return true
+}
 
-	c.JSON(http.StatusOK, pe)
+// setPDFTables stores detected tables on an extraction, replacing any it had.
+func setPDFTables(pe *models.PDFExtraction, tables []pdfservice.Table) {
+	pe.Tables, pe.TableCount = nil, len(tables)
+	if len(tables) > 0 {
+		pe.Tables, _ = json.Marshal(tables)
+	}
+}
+
+// setPDFStructure stores a PDF's document info and outline on an
+// extraction, replacing any it had.
+func setPDFStructure(pe *models.PDFExtraction, info pdfservice.DocumentInfo, outline []pdfservice.OutlineItem) {
+	pe.DocumentInfo, _ = json.Marshal(info)
+	pe.Outline = nil
+	if len(outline) > 0 {
+		pe.Outline, _ = json.Marshal(outline)
+	}
+}
+
+// redactPDFStructured masks the extraction's Markdown and JSON fields —
+// tables, document info, and outline — alongside its text.
+func redactPDFStructured(redactor *redact.Redactor, pe *models.PDFExtraction) {
+	pe.MarkdownContent = redactor.Text(pe.MarkdownContent)
+	pe.Tables = redactor.JSON(pe.Tables)
+	pe.DocumentInfo = redactor.JSON(pe.DocumentInfo)
+	pe.Outline = redactor.JSON(pe.Outline)
+}
+
+// storePDFOriginal keeps the uploaded PDF, if originals are kept. Failures
+// are logged; the extraction itself already succeeded or failed.
+func (h *Handler) storePDFOriginal(c *gin.Context, pe *models.PDFExtraction, r io.ReaderAt, size int64) {
+	if err := h.Worker.StoreOriginal(c.Request.Context(), "pdf", pe.ID, pe.Filename, io.NewSectionReader(r, 0, size), size); err != nil {
+		log.Printf("⚠️  Failed to store original for PDF %s: %v", pe.ID, err)
+	}
 }
 
 // GetPDFExtraction retrieves a single PDF extraction by ID.
 // GET /api/v1/pdf/extractions/:id
+//
+// Supports If-None-Match / If-Modified-Since; unchanged extractions get a 304.
 func (h *Handler) GetPDFExtraction(c *gin.Context) {
 	id := c.Param("id")
 
@@ -144,20 +274,117 @@ func (h *Handler) GetPDFExtraction(c *gin.Context) {
 		})
 		return
 	}
+	if !h.canViewItem(c, pe.APIKeyID, pe.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view your own or your organization's extractions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, pe)
+	writeConditionalJSON(c, pe, pe.UpdatedAt)
 }
 
-// ListPDFExtractions returns recent PDF extractions for the authenticated API key.
-// GET /api/v1/pdf/extractions
+// GetPDFPage returns the text of one page of a PDF extraction.
+// GET /api/v1/pdf/extractions/:id/pages/:n
+//
+// Pages are numbered from 1. A page outside the extracted range, or one
+// whose text couldn't be extracted, is 404 page_not_extracted.
+func (h *Handler) GetPDFPage(c *gin.Context) {
+	pe, err := h.DB.GetPDFExtraction(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "PDF extraction not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, pe.APIKeyID, pe.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view your own or your organization's extractions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	n, err := strconv.Atoi(c.Param("n"))
+	if err != nil || n < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_page",
+			Message: "Page must be a number starting at 1",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if pe.Status != "completed" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "PDF extraction is not completed (status: " + pe.Status + ")",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+	if n > pe.PageCount {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "page_not_found",
+			Message: fmt.Sprintf("The document has %d pages", pe.PageCount),
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	text, ok := pdfservice.PageText(pe.TextContent, pe.FirstPage, n)
+	if !ok {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "page_not_extracted",
+			Message: fmt.Sprintf("Page %d has no extracted text (pages %d-%d were extracted)", n, pe.FirstPage, pe.LastPage),
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	writeConditionalJSON(c, models.PDFPage{
+		ExtractionID: pe.ID,
+		Page:         n,
+		PageCount:    pe.PageCount,
+		Text:         text,
+		WordCount:    len(strings.Fields(text)),
+	}, pe.UpdatedAt)
+}
+
+// ListPDFExtractions returns a paginated list of PDF extractions.
+// GET /api/v1/pdf/extractions?page=1&per_page=20&status=completed&search=invoice
+//
+// Takes the same paging, search, date range, and sort parameters as
+// GET /transcripts; sort_by is created_at, page_count, or word_count.
+// Extracted text is omitted unless ?include=text is passed.
 func (h *Handler) ListPDFExtractions(c *gin.Context) {
-	// Get the API key from context to filter by owner
-	var apiKeyID *string
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		apiKeyID = &apiKey.ID
+	var params models.PDFListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid query parameters: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Filter by the authenticated API key, or the one an admin key names
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
 	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+	params.IncludeText = includeText(c)
 
-	extractions, err := h.DB.ListPDFExtractions(c.Request.Context(), 50, apiKeyID)
+	extractions, total, err := h.DB.ListPDFExtractions(c.Request.Context(), params)
+	if writeCursorError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to list PDF extractions: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -172,7 +399,19 @@ func (h *Handler) ListPDFExtractions(c *gin.Context) {
 		extractions = []models.PDFExtraction{}
 	}
 
-	c.JSON(http.StatusOK, extractions)
+	perPage := params.PerPage
+	if perPage < 1 {
+		perPage = 20
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.PDFExtraction]{
+		Data:       extractions,
+		Page:       responsePage(params.Page, params.Cursor),
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+		NextCursor: database.NextPDFCursor(params, extractions),
+	})
 }
 
 // DeletePDFExtraction removes a PDF extraction by ID.
@@ -180,19 +419,18 @@ func (h *Handler) ListPDFExtractions(c *gin.Context) {
 func (h *Handler) DeletePDFExtraction(c *gin.Context) {
 	id := c.Param("id")
 
+	pe, err := h.DB.GetPDFExtraction(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "PDF extraction not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
 	// Verify ownership: only delete if it belongs to the authenticated API key
 	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		pe, err := h.DB.GetPDFExtraction(c.Request.Context(), id)
-		if err != nil {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "not_found",
-				Message: "PDF extraction not found",
-				Code:    http.StatusNotFound,
-			})
-			return
-		}
-
-		// Check ownership
 		if pe.APIKeyID != nil && *pe.APIKeyID != apiKey.ID {
 			c.JSON(http.StatusForbidden, models.ErrorResponse{
 				Error:   "forbidden",
@@ -212,5 +450,7 @@ func (h *Handler) DeletePDFExtraction(c *gin.Context) {
 		return
 	}
 
+	h.deleteOriginal(c.Request.Context(), pe.Filename, pe.OriginalStoredAt)
+
 	c.JSON(http.StatusOK, gin.H{"message": "PDF extraction deleted"})
 }
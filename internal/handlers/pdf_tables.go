@@ -0,0 +1,108 @@
+// pdf_tables.go serves the tables detected in PDF extractions.
+//
+// Tables are detected at extraction time when the upload (or reprocess
+// request) sets tables=true, and stored on the extraction, so downloads
+// don't read the PDF again.
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+)
+
+// GetPDFTables downloads the tables detected in a PDF extraction.
+// GET /api/v1/pdf/extractions/:id/tables?format=json|csv&table=N
+//
+// JSON returns every table (or just table N). A CSV file holds one table,
+// so format=csv needs table=N unless exactly one table was found.
+func (h *Handler) GetPDFTables(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Supported formats: json, csv",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	pe, err := h.DB.GetPDFExtraction(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "PDF extraction not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, pe.APIKeyID, pe.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view your own or your organization's extractions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	tables := []pdfservice.Table{}
+	if len(pe.Tables) > 0 {
+		json.Unmarshal(pe.Tables, &tables)
+	}
+
+	n := 0
+	if v := c.Query("table"); v != "" {
+		if n, err = strconv.Atoi(v); err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_table",
+				Message: "table must be a number starting at 1",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	} else if format == "csv" && len(tables) == 1 {
+		n = 1
+	}
+
+	switch {
+	case n > len(tables):
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "table_not_found",
+			Message: noTablesMessage(len(tables)),
+			Code:    http.StatusNotFound,
+		})
+		return
+	case n > 0:
+		tables = tables[n-1 : n]
+	case format == "csv":
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "table_required",
+			Message: fmt.Sprintf("A CSV file holds one table; pass table=1 to %d", len(tables)),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, tables)
+		return
+	}
+	baseName := strings.TrimSuffix(pe.DisplayName(), filepath.Ext(pe.DisplayName()))
+	sendCSV(c, tables[0].Rows, fmt.Sprintf("%s-table-%d", sanitizeFilename(baseName), n))
+}
+
+// noTablesMessage explains a table number past the end of the list.
+func noTablesMessage(count int) string {
+	if count == 0 {
+		return "No tables were detected. Extract with tables=true to detect them."
+	}
+	return fmt.Sprintf("The extraction has %d tables", count)
+}
@@ -0,0 +1,457 @@
+// pipelines.go lets API keys define pipelines — ordered steps such as
+// extract → summarize → tag → webhook → notion — and run them on YouTube
+// videos. The worker starts each step when the one before it finishes;
+// runs report their progress step by step.
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/transcript"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+// Pipeline limits.
+const (
+	maxPipelineNameLength = 100
+	maxPipelineSteps      = 10
+	pipelineRunListLimit  = 50
+)
+
+// CreatePipeline saves a pipeline for the authenticated API key.
+// POST /api/v1/pipelines
+//
+// Request body:
+//
+//	{"name": "Lectures", "steps": [{"type": "extract"}, {"type": "summarize", "summary": {"length": "short"}},
+//	  {"type": "tag", "tags": ["lecture"]}, {"type": "webhook"}, {"type": "notion"}]}
+//
+// Steps run in order, and a pipeline always starts with extract; it's
+// added if left out.
+func (h *Handler) CreatePipeline(c *gin.Context) {
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Pipelines require API key authentication",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	req, ok := h.bindPipeline(c, apiKey)
+	if !ok {
+		return
+	}
+	steps, _ := json.Marshal(req.Steps)
+	p := &models.Pipeline{APIKeyID: apiKey.ID, Name: req.Name, Steps: steps}
+	if err := h.DB.CreatePipeline(c.Request.Context(), p); err != nil {
+		log.Printf("❌ Failed to create pipeline: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save pipeline",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// ListPipelines returns the pipelines of the authenticated API key.
+// GET /api/v1/pipelines
+func (h *Handler) ListPipelines(c *gin.Context) {
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Pipelines require API key authentication",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	pipelines, err := h.DB.ListPipelines(c.Request.Context(), apiKey.ID)
+	if err != nil {
+		log.Printf("❌ Failed to list pipelines: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list pipelines",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if pipelines == nil {
+		pipelines = []models.Pipeline{}
+	}
+
+	c.JSON(http.StatusOK, pipelines)
+}
+
+// GetPipeline returns a single pipeline.
+// GET /api/v1/pipelines/:id
+func (h *Handler) GetPipeline(c *gin.Context) {
+	p, ok := h.loadPipeline(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// UpdatePipeline replaces a pipeline's name and steps. Runs already
+// underway keep the steps they started with.
+// PUT /api/v1/pipelines/:id
+func (h *Handler) UpdatePipeline(c *gin.Context) {
+	p, ok := h.loadPipeline(c)
+	if !ok {
+		return
+	}
+	req, ok := h.bindPipeline(c, middleware.GetAPIKey(c))
+	if !ok {
+		return
+	}
+
+	p.Name = req.Name
+	p.Steps, _ = json.Marshal(req.Steps)
+	if err := h.DB.UpdatePipeline(c.Request.Context(), p); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save pipeline",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// DeletePipeline removes a pipeline and its runs' history.
+// DELETE /api/v1/pipelines/:id
+func (h *Handler) DeletePipeline(c *gin.Context) {
+	p, ok := h.loadPipeline(c)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeletePipeline(c.Request.Context(), p.ID); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Pipeline not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Pipeline deleted"})
+}
+
+// StartPipelineRun runs a pipeline on a YouTube video.
+// POST /api/v1/pipelines/:id/runs
+//
+// Request body: {"url": "https://www.youtube.com/watch?v=..."} or {"video_id": "..."}
+//
+// A transcript of the video this key already extracted is reused; otherwise
+// a new one is extracted. Summarize steps pick up the caller's AI defaults
+// and spend limit now, when the run starts.
+func (h *Handler) StartPipelineRun(c *gin.Context) {
+	p, ok := h.loadPipeline(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreatePipelineRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Provide either 'url' or 'video_id' in the request body",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	source := req.URL
+	if source == "" {
+		source = req.VideoID
+	}
+	youtubeURL, videoID, err := transcript.ParseYouTubeURL(source)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_url",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	steps, apiErr := h.pipelineRunSteps(c, p)
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	ctx := c.Request.Context()
+	t, created, err := h.pipelineTranscript(c, youtubeURL, videoID)
+	if err != nil {
+		log.Printf("❌ Failed to create transcript record: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create transcript record",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	run := &models.PipelineRun{
+		PipelineID:   p.ID,
+		APIKeyID:     p.APIKeyID,
+		TranscriptID: &t.ID,
+		Status:       string(models.StatusPending),
+	}
+	if userID := integrationUserID(c); userID != "" {
+		run.UserID = &userID
+	}
+	run.Steps, _ = json.Marshal(steps)
+	if err := h.DB.CreatePipelineRun(ctx, run); err != nil {
+		log.Printf("❌ Failed to create pipeline run: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to start pipeline run",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	job := worker.Job{ID: run.ID, Type: worker.JobPipelineStep, Priority: jobPriority(c), CreatedAt: time.Now()}
+	if err := h.Worker.Submit(job); err != nil {
+		log.Printf("⚠️  Failed to queue pipeline run %s: %v", run.ID, err)
+		// Fail the records rather than leave them pending forever
+		run.Status = string(models.StatusFailed)
+		run.ErrorMessage = "Job queue is full, please try again later"
+		h.DB.UpdatePipelineRun(ctx, run)
+		if created {
+			t.Status = models.StatusFailed
+			t.ErrorMessage = run.ErrorMessage
+			h.DB.UpdateTranscript(ctx, t)
+		}
+		c.JSON(http.StatusServiceUnavailable, h.queueFull(c))
+		return
+	}
+
+	h.setQueueHeaders(c)
+	c.JSON(http.StatusAccepted, run)
+}
+
+// ListPipelineRuns returns a pipeline's most recent runs, newest first.
+// GET /api/v1/pipelines/:id/runs
+func (h *Handler) ListPipelineRuns(c *gin.Context) {
+	p, ok := h.loadPipeline(c)
+	if !ok {
+		return
+	}
+
+	runs, err := h.DB.ListPipelineRuns(c.Request.Context(), p.ID, pipelineRunListLimit)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list pipeline runs",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if runs == nil {
+		runs = []models.PipelineRun{}
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// GetPipelineRun returns a run's status and the progress of each step.
+// GET /api/v1/pipelines/:id/runs/:run_id
+func (h *Handler) GetPipelineRun(c *gin.Context) {
+	p, ok := h.loadPipeline(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.DB.GetPipelineRun(c.Request.Context(), c.Param("run_id"))
+	if err != nil || run.PipelineID != p.ID {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Pipeline run not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// loadPipeline fetches the pipeline named by the :id param, writing a 404
+// and returning false unless it belongs to the caller's API key.
+func (h *Handler) loadPipeline(c *gin.Context) (*models.Pipeline, bool) {
+	apiKey := middleware.GetAPIKey(c)
+	p, err := h.DB.GetPipeline(c.Request.Context(), c.Param("id"))
+	if err != nil || apiKey == nil || p.APIKeyID != apiKey.ID {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Pipeline not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	return p, true
+}
+
+// bindPipeline reads and validates a pipeline request body, writing a 400
+// and returning false if it's invalid.
+func (h *Handler) bindPipeline(c *gin.Context, apiKey *models.APIKey) (*models.PipelineRequest, bool) {
+	var req models.PipelineRequest
+	msg := "name and steps are required"
+	if err := c.ShouldBindJSON(&req); err == nil {
+		msg = h.validatePipeline(apiKey, &req)
+	}
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		})
+		return nil, false
+	}
+	return &req, true
+}
+
+// validatePipeline trims and checks a pipeline request in place, returning
+// an error message or "" if it's valid. A leading extract step is added if
+// it's missing, and options that don't apply to a step are dropped.
+func (h *Handler) validatePipeline(apiKey *models.APIKey, req *models.PipelineRequest) string {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || utf8.RuneCountInString(req.Name) > maxPipelineNameLength {
+		return "name is required and must be at most " + intToStr(maxPipelineNameLength) + " characters"
+	}
+
+	if len(req.Steps) == 0 || req.Steps[0].Type != models.PipelineStepExtract {
+		req.Steps = append([]models.PipelineStep{{Type: models.PipelineStepExtract}}, req.Steps...)
+	}
+	if len(req.Steps) > maxPipelineSteps {
+		return "a pipeline can have at most " + intToStr(maxPipelineSteps) + " steps"
+	}
+
+	for i := range req.Steps {
+		step := &req.Steps[i]
+		prefix := fmt.Sprintf("step %d (%s): ", i+1, step.Type)
+		if !models.ValidPipelineSteps[step.Type] {
+			return fmt.Sprintf("step %d: type must be one of: extract, summarize, tag, webhook, notion", i+1)
+		}
+		if step.Type != models.PipelineStepSummarize {
+			step.Summary = nil
+		}
+		if step.Type != models.PipelineStepTag {
+			step.Tags = nil
+		}
+
+		switch step.Type {
+		case models.PipelineStepExtract:
+			if i > 0 {
+				return prefix + "extract can only be the first step"
+			}
+		case models.PipelineStepSummarize:
+			if opts := step.Summary; opts != nil {
+				if apiErr := h.modelError(opts.Model, opts.FallbackModels); apiErr != nil {
+					return prefix + apiErr.Message
+				}
+				if apiErr := languageError(opts.Language); apiErr != nil {
+					return prefix + apiErr.Message
+				}
+			}
+		case models.PipelineStepTag:
+			update := models.UpdateItemRequest{Tags: &step.Tags}
+			if msg := validateItemUpdate("transcript", &update); msg != "" {
+				return prefix + msg
+			}
+			if len(*update.Tags) == 0 {
+				return prefix + "tags are required"
+			}
+			step.Tags = *update.Tags
+		case models.PipelineStepNotion:
+			if apiKey.UserID == nil {
+				return prefix + "Notion export needs an API key linked to a user account"
+			}
+		}
+	}
+	return ""
+}
+
+// pipelineRunSteps copies a pipeline's steps for a new run, each pending.
+// Summarize steps get the caller's AI defaults and spend limit applied, as
+// on POST /summaries.
+func (h *Handler) pipelineRunSteps(c *gin.Context, p *models.Pipeline) ([]models.PipelineRunStep, *models.ErrorResponse) {
+	var steps []models.PipelineStep
+	if err := json.Unmarshal(p.Steps, &steps); err != nil {
+		log.Printf("❌ Invalid steps in pipeline %s: %v", p.ID, err)
+		return nil, &models.ErrorResponse{
+			Error:   "invalid_pipeline",
+			Message: "This pipeline's steps can't be read; save it again",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+
+	runSteps := make([]models.PipelineRunStep, len(steps))
+	for i, step := range steps {
+		if step.Type == models.PipelineStepSummarize {
+			req := models.CreateSummaryRequest{}
+			if o := step.Summary; o != nil {
+				req = models.CreateSummaryRequest{Model: o.Model, FallbackModels: o.FallbackModels, Length: o.Length, Style: o.Style, Language: o.Language}
+			}
+			payload, apiErr := h.summaryPayload(c, req)
+			if apiErr != nil {
+				return nil, apiErr
+			}
+			step.Summary = &models.SummaryOptions{
+				Model:          payload.Model,
+				FallbackModels: payload.FallbackModels,
+				Length:         payload.Length,
+				Style:          payload.Style,
+				Language:       payload.Language,
+			}
+		}
+		runSteps[i] = models.PipelineRunStep{PipelineStep: step, Status: string(models.StatusPending)}
+	}
+	return runSteps, nil
+}
+
+// pipelineTranscript returns the transcript a run works on: the caller's
+// key's completed transcript of the video, or else a new pending record for
+// the run's extract step. created reports a new record.
+func (h *Handler) pipelineTranscript(c *gin.Context, youtubeURL, videoID string) (t *models.Transcript, created bool, err error) {
+	apiKey := middleware.GetAPIKey(c)
+	existing, _ := h.DB.GetTranscriptByYouTubeID(c.Request.Context(), videoID)
+	if existing != nil && existing.Status == models.StatusCompleted && existing.Redaction == nil &&
+		existing.APIKeyID != nil && *existing.APIKeyID == apiKey.ID {
+		return existing, false, nil
+	}
+
+	t = &models.Transcript{
+		YouTubeURL: youtubeURL,
+		YouTubeID:  videoID,
+		Status:     models.StatusPending,
+		APIKeyID:   &apiKey.ID,
+	}
+	if err := h.DB.CreateTranscript(c.Request.Context(), t); err != nil {
+		return nil, false, err
+	}
+	return t, true, nil
+}
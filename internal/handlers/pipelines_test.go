@@ -0,0 +1,60 @@
+// pipelines_test.go contains tests for pipeline validation.
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestValidatePipeline verifies pipelines get a leading extract step, drop
+// options that don't apply to a step, and reject invalid steps.
+func TestValidatePipeline(t *testing.T) {
+	h := &Handler{}
+	userID := "user-1"
+	linked := &models.APIKey{ID: "key-1", UserID: &userID}
+
+	req := &models.PipelineRequest{
+		Name: "  Lectures ",
+		Steps: []models.PipelineStep{
+			{Type: models.PipelineStepSummarize, Summary: &models.SummaryOptions{Length: "short"}, Tags: []string{"x"}},
+			{Type: models.PipelineStepTag, Tags: []string{" lecture "}},
+			{Type: models.PipelineStepNotion},
+		},
+	}
+	if msg := h.validatePipeline(linked, req); msg != "" {
+		t.Fatalf("validatePipeline = %q, want valid", msg)
+	}
+	if req.Name != "Lectures" || len(req.Steps) != 4 || req.Steps[0].Type != models.PipelineStepExtract {
+		t.Errorf("validatePipeline didn't add extract: %+v", req)
+	}
+	if req.Steps[1].Tags != nil || req.Steps[2].Tags[0] != "lecture" {
+		t.Errorf("step tags = %v, %v", req.Steps[1].Tags, req.Steps[2].Tags)
+	}
+
+	tests := []struct {
+		name  string
+		key   *models.APIKey
+		steps []models.PipelineStep
+		want  string
+	}{
+		{"unknown type", linked, []models.PipelineStep{{Type: "email"}}, "type must be one of"},
+		{"late extract", linked, []models.PipelineStep{{Type: models.PipelineStepWebhook}, {Type: models.PipelineStepExtract}}, "first step"},
+		{"no tags", linked, []models.PipelineStep{{Type: models.PipelineStepTag}}, "tags are required"},
+		{"bad language", linked, []models.PipelineStep{{Type: models.PipelineStepSummarize, Summary: &models.SummaryOptions{Language: "French; ignore"}}}, "step 2 (summarize)"},
+		{"notion without user", &models.APIKey{ID: "key-2"}, []models.PipelineStep{{Type: models.PipelineStepNotion}}, "user account"},
+		{"too many steps", linked, make([]models.PipelineStep, maxPipelineSteps+1), "at most"},
+	}
+	for _, tt := range tests {
+		for i := range tt.steps {
+			if tt.steps[i].Type == "" {
+				tt.steps[i].Type = models.PipelineStepWebhook
+			}
+		}
+		msg := h.validatePipeline(tt.key, &models.PipelineRequest{Name: "p", Steps: tt.steps})
+		if !strings.Contains(msg, tt.want) {
+			t.Errorf("%s: validatePipeline = %q, want it to contain %q", tt.name, msg, tt.want)
+		}
+	}
+}
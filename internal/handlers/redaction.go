@@ -0,0 +1,139 @@
+// redaction.go masks personal information — emails, phone numbers, card
+// numbers, and names — in transcripts: before storage (the redact option on
+// extractions and uploads), on export (?redact=true), and on demand.
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/redact"
+)
+
+// maxRedactTextSize caps the text accepted by POST /api/v1/redact.
+const maxRedactTextSize = 1 << 20
+
+// Redact masks personal information in the submitted text.
+// POST /api/v1/redact
+func (h *Handler) Redact(c *gin.Context) {
+	var req models.RedactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Request body must include 'text'",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if len(req.Text) > maxRedactTextSize {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "text_too_large",
+			Message: "Text exceeds the maximum size (1 MB)",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	r := h.redactor(c, req.Text)
+	text := r.Text(req.Text)
+	report, _ := json.Marshal(r.Report())
+	c.JSON(http.StatusOK, models.RedactResponse{Text: text, Report: report})
+}
+
+// redactor prepares a redaction pass over text. Names are detected with the
+// summarizer when OpenRouter is configured; otherwise only emails, phones,
+// and cards are masked, and the report says names weren't checked.
+func (h *Handler) redactor(c *gin.Context, text string) *redact.Redactor {
+	var detector redact.NameDetector
+	if h.Summarizer != nil && h.Summarizer.IsConfigured() {
+		detector = h.Summarizer
+	}
+	return redact.Prepare(c.Request.Context(), detector, text)
+}
+
+// saveRedaction stores the redaction report for an item masked before
+// storage and returns it for the response. Failures are logged; the masked
+// text is already saved.
+func (h *Handler) saveRedaction(c *gin.Context, itemType, id string, r *redact.Redactor) models.NullJSON {
+	report, err := json.Marshal(r.Report())
+	if err != nil {
+		return nil
+	}
+	if err := h.DB.SaveRedaction(c.Request.Context(), itemType, id, report); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	return report
+}
+
+// parseRedactFlag reads the redact query parameter, form field, or upload
+// metadata value. Empty means false; anything strconv.ParseBool rejects is
+// an error rather than a silent "don't redact".
+func parseRedactFlag(value string) (bool, *models.ErrorResponse) {
+	return parseFlag("redact", value)
+}
+
+// parseFlag reads a boolean query parameter, form field, or upload
+// metadata value named name. Empty means false.
+func parseFlag(name, value string) (bool, *models.ErrorResponse) {
+	if value == "" {
+		return false, nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: name + " must be true or false",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return flag, nil
+}
+
+// setRedactionHeaders reports what an export masked: X-Redactions is the
+// total, X-Redaction-Counts the breakdown by kind (e.g. "email=1,name=2").
+func setRedactionHeaders(c *gin.Context, r *redact.Redactor) {
+	report := r.Report()
+	kinds := make([]string, 0, len(report.Counts))
+	for kind, n := range report.Counts {
+		kinds = append(kinds, kind+"="+strconv.Itoa(n))
+	}
+	sort.Strings(kinds)
+	c.Header("X-Redactions", strconv.Itoa(report.Total))
+	c.Header("X-Redaction-Counts", strings.Join(kinds, ","))
+	c.Header("X-Redaction-Names-Checked", strconv.FormatBool(report.NamesChecked))
+}
+
+// redactTranscript masks a transcript's text, segments, and notes in place.
+func redactTranscript(r *redact.Redactor, t *models.Transcript) {
+	t.TranscriptText = r.Text(t.TranscriptText)
+	t.Segments = r.JSON(t.Segments)
+	t.Notes = r.Text(t.Notes)
+}
+
+// redactSummary masks a transcript summary in place.
+func redactSummary(r *redact.Redactor, s *models.Summary) {
+	s.SummaryText = r.Text(s.SummaryText)
+	s.KeyPoints = r.JSON(s.KeyPoints)
+}
+
+// redactAudio masks an audio transcription and everything generated from
+// it — summary, action items, analysis, minutes — in place.
+func redactAudio(r *redact.Redactor, at *models.AudioTranscription) {
+	at.TranscriptText = r.Text(at.TranscriptText)
+	at.Segments = r.JSON(at.Segments)
+	at.Notes = r.Text(at.Notes)
+	at.SummaryText = r.Text(at.SummaryText)
+	at.KeyPoints = r.JSON(at.KeyPoints)
+	at.ActionItems = r.JSON(at.ActionItems)
+	at.Decisions = r.JSON(at.Decisions)
+	at.SpeakerSummaries = r.JSON(at.SpeakerSummaries)
+	at.Analysis = r.JSON(at.Analysis)
+	at.Minutes = r.JSON(at.Minutes)
+}
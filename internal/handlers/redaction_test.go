@@ -0,0 +1,129 @@
+// redaction_test.go contains tests for the redaction endpoint and options.
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/redact"
+)
+
+// TestParseRedactFlag verifies the redact option accepts booleans and
+// rejects anything else instead of quietly not redacting.
+func TestParseRedactFlag(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{value: "", want: false},
+		{value: "true", want: true},
+		{value: "1", want: true},
+		{value: "false", want: false},
+		{value: "yes", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, apiErr := parseRedactFlag(tt.value)
+		if (apiErr != nil) != tt.wantErr {
+			t.Errorf("parseRedactFlag(%q) error = %v, wantErr %v", tt.value, apiErr, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseRedactFlag(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestRedact verifies POST /redact masks PII and reports it. With no
+// summarizer configured, names aren't checked.
+func TestRedact(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+		wantText string
+	}{
+		{
+			name:     "masks email and phone",
+			body:     `{"text":"Reach me at kai@example.com or (671) 555-0123."}`,
+			wantCode: http.StatusOK,
+			wantText: "Reach me at [EMAIL] or [PHONE].",
+		},
+		{name: "missing text", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "malformed", body: `{"text":`, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/redact", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.Redact(c)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var resp models.RedactResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if resp.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", resp.Text, tt.wantText)
+			}
+			var report redact.Report
+			if err := json.Unmarshal(resp.Report, &report); err != nil {
+				t.Fatalf("invalid report: %v", err)
+			}
+			if report.Total != 2 || report.NamesChecked {
+				t.Errorf("report = %+v, want 2 redactions with names unchecked", report)
+			}
+		})
+	}
+}
+
+// TestRedactAudio verifies exports mask generated content as well as the
+// transcript, and report it in headers.
+func TestRedactAudio(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	at := &models.AudioTranscription{
+		TranscriptText: "Email ana@example.com",
+		Segments:       models.NullJSON(`[{"start":0,"end":1.5,"text":"Email ana@example.com"}]`),
+		SummaryText:    "Follow up at 671-555-0123",
+		ActionItems:    json.RawMessage(`[{"task":"Call 671-555-0123"}]`),
+	}
+	r := redact.New([]string{"Ana"})
+	redactAudio(r, at)
+
+	for field, got := range map[string]string{
+		"transcript":   at.TranscriptText,
+		"segments":     string(at.Segments),
+		"summary":      at.SummaryText,
+		"action items": string(at.ActionItems),
+	} {
+		if strings.Contains(got, "ana@example.com") || strings.Contains(got, "555-0123") {
+			t.Errorf("%s still contains PII: %s", field, got)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	setRedactionHeaders(c, r)
+	if got := w.Header().Get("X-Redactions"); got != "4" {
+		t.Errorf("X-Redactions = %q, want 4", got)
+	}
+	if got := w.Header().Get("X-Redaction-Counts"); got != "credit_card=0,email=2,name=0,phone=2" {
+		t.Errorf("X-Redaction-Counts = %q", got)
+	}
+}
@@ -0,0 +1,336 @@
+// reprocess.go re-runs transcription or extraction from a kept original,
+// so callers can try different options without uploading the file again.
+//
+// POST /api/v1/audio/transcriptions/:id/reprocess — Re-transcribe (async)
+// POST /api/v1/pdf/extractions/:id/reprocess      — Re-extract (sync)
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/redact"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+// maxPromptLength caps transcription prompts; Whisper only reads the last
+// 224 tokens anyway.
+const maxPromptLength = 1000
+
+// languageCode matches ISO-639-1 (and the few ISO-639-3) codes Whisper accepts.
+var languageCode = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// uploadTranscribeOptions reads the language, translate, and keep_source
+// form fields or upload metadata values, validated like a reprocess request.
+// An empty language means auto-detect.
+func uploadTranscribeOptions(fields map[string]string) (audio.TranscribeOptions, *models.ErrorResponse) {
+	translate, apiErr := parseFlag("translate", fields["translate"])
+	if apiErr != nil {
+		return audio.TranscribeOptions{}, apiErr
+	}
+	keepSource, apiErr := parseFlag("keep_source", fields["keep_source"])
+	if apiErr != nil {
+		return audio.TranscribeOptions{}, apiErr
+	}
+	return transcribeOptions(models.ReprocessAudioRequest{
+		Language:   strings.ToLower(strings.TrimSpace(fields["language"])),
+		Translate:  translate,
+		KeepSource: keepSource,
+	})
+}
+
+// ReprocessAudio re-transcribes an audio file from its kept original.
+// POST /api/v1/audio/transcriptions/:id/reprocess
+//
+// Returns 202 with the transcription reset to pending; poll it like a new upload.
+func (h *Handler) ReprocessAudio(c *gin.Context) {
+	at, err := h.DB.GetAudioTranscription(c.Request.Context(), c.Param("id"))
+	if err != nil || !h.canManageItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Audio transcription not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	if h.AudioTranscriber == nil || !h.AudioTranscriber.IsConfigured() {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "Audio transcription is not configured. Set the OPENAI_API_KEY environment variable to enable Whisper transcription.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.ReprocessAudioRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+	opts, apiErr := transcribeOptions(req)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, *apiErr)
+		return
+	}
+	timeout, apiErr := h.jobTimeout(req.TimeoutSeconds)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, *apiErr)
+		return
+	}
+
+	if at.Status == "pending" || at.Status == "processing" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Transcription is still " + at.Status + "; wait for it to finish before reprocessing",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+	if !h.originalKept(c, at.OriginalStoredAt) {
+		return
+	}
+
+	// Reset to pending so pollers see the new run; restore on a full queue
+	previous := *at
+	at.Status = "pending"
+	at.ErrorMessage = ""
+	if err := h.DB.UpdateAudioTranscription(c.Request.Context(), at); err != nil {
+		log.Printf("❌ Failed to reset audio transcription %s: %v", at.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to update transcription record",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	payloadJSON, _ := json.Marshal(worker.AudioPayload{
+		AudioID:      at.ID,
+		OriginalName: at.OriginalName,
+		Options:      opts,
+		Redact:       req.Redact || at.Redaction != nil, // Never un-redact stored text
+	})
+	job := worker.Job{
+		ID:        at.ID,
+		Type:      worker.JobAudioTranscription,
+		Payload:   payloadJSON,
+		Timeout:   timeout,
+		Priority:  jobPriority(c),
+		CreatedAt: time.Now(),
+	}
+	if err := h.Worker.Submit(job); err != nil {
+		h.DB.UpdateAudioTranscription(c.Request.Context(), &previous)
+		c.JSON(http.StatusServiceUnavailable, h.queueFull(c))
+		return
+	}
+
+	log.Printf("🔁 Audio reprocess queued: %s (%s)", at.ID, at.OriginalName)
+	h.setQueueHeaders(c)
+	c.JSON(http.StatusAccepted, at)
+}
+
+// ReprocessPDF re-extracts a PDF's text from its kept original.
+// POST /api/v1/pdf/extractions/:id/reprocess
+func (h *Handler) ReprocessPDF(c *gin.Context) {
+	pe, err := h.DB.GetPDFExtraction(c.Request.Context(), c.Param("id"))
+	if err != nil || !h.canManageItem(c, pe.APIKeyID, pe.UserID) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "PDF extraction not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	var req models.ReprocessPDFRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+	if req.FirstPage < 0 || req.LastPage < 0 || (req.LastPage > 0 && req.FirstPage > req.LastPage) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "first_page and last_page must be positive, with first_page <= last_page",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if !h.originalKept(c, pe.OriginalStoredAt) {
+		return
+	}
+
+	file, size, err := h.downloadOriginal(c, pe.Filename, maxPDFSize)
+	if err != nil {
+		log.Printf("❌ Failed to read original for PDF %s: %v", pe.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "storage_error",
+			Message: "Failed to read the stored original",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	start := time.Now()
+	result, err := pdfservice.ExtractWithOptions(file, size, pdfservice.ExtractOptions{
+		FirstPage: req.FirstPage,
+		LastPage:  req.LastPage,
+		Tables:    req.Tables,
+		Markdown:  req.Markdown,
+	})
+	if errors.Is(err, pdfservice.ErrPageRange) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_pages",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err != nil {
+		pe.Status = "failed"
+		pe.ErrorMessage = err.Error()
+		h.DB.UpdatePDFExtraction(c.Request.Context(), pe)
+
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "extraction_failed",
+			Message: "PDF text extraction failed: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	pe.PageCount = result.PageCount
+	pe.FirstPage = result.FirstPage
+	pe.LastPage = result.LastPage
+	setPDFTables(pe, result.Tables)
+	setPDFStructure(pe, result.Info, result.Outline)
+	pe.TextContent = result.Text
+	pe.MarkdownContent = result.Markdown
+	pe.WordCount = result.WordCount
+	pe.Usage = models.ProcessingUsage{ProcessingMS: time.Since(start).Milliseconds()}.JSON()
+	pe.Status = "completed"
+	pe.ErrorMessage = ""
+
+	// Never un-redact stored text
+	var redactor *redact.Redactor
+	if req.Redact || pe.Redaction != nil {
+		redactor = h.redactor(c, pe.TextContent)
+		pe.TextContent = redactor.Text(pe.TextContent)
+		redactPDFStructured(redactor, pe)
+	}
+
+	if err := h.DB.UpdatePDFExtraction(c.Request.Context(), pe); err != nil {
+		log.Printf("❌ Failed to save reprocessed PDF %s: %v", pe.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save the extraction",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if redactor != nil {
+		pe.Redaction = h.saveRedaction(c, "pdf", pe.ID, redactor)
+	}
+	if h.WebhookService != nil {
+		h.WebhookService.NotifySearchMatches(c.Request.Context(), "pdf", pe.ID)
+	}
+
+	c.JSON(http.StatusOK, pe)
+}
+
+// transcribeOptions validates a reprocess request's transcription options.
+// Only whisper-1 translates, and keep_source only applies to translations.
+func transcribeOptions(req models.ReprocessAudioRequest) (audio.TranscribeOptions, *models.ErrorResponse) {
+	invalid := func(msg string) (audio.TranscribeOptions, *models.ErrorResponse) {
+		return audio.TranscribeOptions{}, &models.ErrorResponse{Error: "invalid_request", Message: msg, Code: http.StatusBadRequest}
+	}
+	switch {
+	case req.Model != "" && !audio.ValidModels[req.Model]:
+		return invalid("model must be one of whisper-1, gpt-4o-transcribe, gpt-4o-mini-transcribe, gpt-4o-transcribe-diarize")
+	case req.Diarize && req.Model != "" && req.Model != audio.DiarizeModel:
+		return invalid("diarize requires the " + audio.DiarizeModel + " model")
+	case req.Language != "" && !languageCode.MatchString(req.Language):
+		return invalid("language must be an ISO-639-1 code such as \"en\"")
+	case len(req.Prompt) > maxPromptLength:
+		return invalid("prompt must be at most " + intToStr(maxPromptLength) + " characters")
+	case req.Translate && (req.Diarize || req.Model != "" && req.Model != audio.DefaultModel):
+		return invalid("translate requires the " + audio.DefaultModel + " model")
+	case req.KeepSource && !req.Translate:
+		return invalid("keep_source requires translate")
+	}
+	return audio.TranscribeOptions{
+		Model:      req.Model,
+		Language:   req.Language,
+		Prompt:     req.Prompt,
+		Diarize:    req.Diarize || req.Model == audio.DiarizeModel,
+		Translate:  req.Translate,
+		KeepSource: req.KeepSource,
+	}, nil
+}
+
+// bindOptionalJSON binds a request body that may be empty, writing a 400
+// for malformed JSON.
+func bindOptionalJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return false
+	}
+	return true
+}
+
+// originalKept writes a 409 and returns false when there's no original to
+// reprocess from.
+func (h *Handler) originalKept(c *gin.Context, storedAt *time.Time) bool {
+	if h.Worker.Originals() != nil && storedAt != nil {
+		return true
+	}
+	c.JSON(http.StatusConflict, models.ErrorResponse{
+		Error:   "original_not_available",
+		Message: "The original upload was not kept or has passed its retention period; upload the file again",
+		Code:    http.StatusConflict,
+	})
+	return false
+}
+
+// downloadOriginal copies a kept original to a temp file, refusing anything
+// over limit. The caller closes and removes the file.
+func (h *Handler) downloadOriginal(c *gin.Context, key string, limit int64) (*os.File, int64, error) {
+	rc, err := h.Worker.Originals().Open(c.Request.Context(), key)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rc.Close()
+
+	f, err := os.CreateTemp("", "original-*"+filepath.Ext(key))
+	if err != nil {
+		return nil, 0, err
+	}
+	size, err := io.Copy(f, io.LimitReader(rc, limit+1))
+	if err == nil && size > limit {
+		err = errors.New("original is larger than the upload limit")
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, 0, err
+	}
+	return f, size, nil
+}
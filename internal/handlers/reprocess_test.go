@@ -0,0 +1,91 @@
+// reprocess_test.go contains tests for reprocess request validation.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+)
+
+// TestTranscribeOptions verifies reprocess options are validated, and that
+// picking the diarize model turns diarization on.
+func TestTranscribeOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.ReprocessAudioRequest
+		want    audio.TranscribeOptions
+		wantErr bool
+	}{
+		{name: "empty", req: models.ReprocessAudioRequest{}},
+		{
+			name: "language and prompt",
+			req:  models.ReprocessAudioRequest{Language: "ja", Prompt: "Shimizu"},
+			want: audio.TranscribeOptions{Language: "ja", Prompt: "Shimizu"},
+		},
+		{
+			name: "diarize model",
+			req:  models.ReprocessAudioRequest{Model: audio.DiarizeModel},
+			want: audio.TranscribeOptions{Model: audio.DiarizeModel, Diarize: true},
+		},
+		{
+			name: "translate keeping source",
+			req:  models.ReprocessAudioRequest{Language: "ja", Translate: true, KeepSource: true},
+			want: audio.TranscribeOptions{Language: "ja", Translate: true, KeepSource: true},
+		},
+		{name: "unknown model", req: models.ReprocessAudioRequest{Model: "whisper-2"}, wantErr: true},
+		{name: "diarize with other model", req: models.ReprocessAudioRequest{Model: "whisper-1", Diarize: true}, wantErr: true},
+		{name: "language name", req: models.ReprocessAudioRequest{Language: "English"}, wantErr: true},
+		{name: "long prompt", req: models.ReprocessAudioRequest{Prompt: strings.Repeat("a", maxPromptLength+1)}, wantErr: true},
+		{name: "translate with other model", req: models.ReprocessAudioRequest{Model: "gpt-4o-transcribe", Translate: true}, wantErr: true},
+		{name: "translate with diarize", req: models.ReprocessAudioRequest{Diarize: true, Translate: true}, wantErr: true},
+		{name: "keep source without translate", req: models.ReprocessAudioRequest{KeepSource: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, apiErr := transcribeOptions(tt.req)
+			if (apiErr != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", apiErr, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("options = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBindOptionalJSON verifies an empty body is accepted but malformed
+// JSON gets a 400.
+func TestBindOptionalJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		body   string
+		wantOK bool
+	}{
+		{"empty", "", true},
+		{"object", `{"first_page": 2}`, true},
+		{"malformed", `{"first_page":`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			var req models.ReprocessPDFRequest
+			if ok := bindOptionalJSON(c, &req); ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !tt.wantOK && w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// retention.go handles retention policy settings and the purge audit log.
+// A policy applies to whoever the request authenticated as: the API key, or
+// the logged-in user.
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxRetentionDays caps retention policies at ten years.
+const maxRetentionDays = 3650
+
+// GetRetention returns the caller's retention policy.
+// GET /api/v1/retention
+func (h *Handler) GetRetention(c *gin.Context) {
+	scope, ownerID, ok := retentionOwner(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.DB.GetRetentionPolicy(c.Request.Context(), scope, ownerID)
+	if err != nil {
+		log.Printf("❌ Failed to get retention policy: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to get retention policy",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdateRetention sets how many days the caller's items are kept.
+// PUT /api/v1/retention
+func (h *Handler) UpdateRetention(c *gin.Context) {
+	scope, ownerID, ok := retentionOwner(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateRetentionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	days := 0
+	if req.RetentionDays != nil {
+		days = *req.RetentionDays
+	}
+	if days < 0 || days > maxRetentionDays {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "retention_days must be between 1 and " + strconv.Itoa(maxRetentionDays) + ", or null to keep items forever",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.DB.SetRetentionPolicy(c.Request.Context(), scope, ownerID, days); err != nil {
+		log.Printf("❌ Failed to set retention policy: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save retention policy",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	h.GetRetention(c)
+}
+
+// ListPurges returns the caller's items deleted by retention, newest first.
+// GET /api/v1/retention/purges
+func (h *Handler) ListPurges(c *gin.Context) {
+	limit, ok := purgeLimit(c)
+	if !ok {
+		return
+	}
+
+	var apiKeyID, userID *string
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		apiKeyID = &apiKey.ID
+	} else if user := middleware.GetUser(c); user != nil {
+		userID = &user.ID
+	} else {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	h.listPurges(c, apiKeyID, userID, limit)
+}
+
+// AdminListPurges returns every item deleted by retention, newest first.
+// GET /api/v1/admin/purges
+func (h *Handler) AdminListPurges(c *gin.Context) {
+	limit, ok := purgeLimit(c)
+	if !ok {
+		return
+	}
+	h.listPurges(c, nil, nil, limit)
+}
+
+func (h *Handler) listPurges(c *gin.Context, apiKeyID, userID *string, limit int) {
+	purges, err := h.DB.ListPurges(c.Request.Context(), apiKeyID, userID, limit)
+	if err != nil {
+		log.Printf("❌ Failed to list purges: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list purges",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if purges == nil {
+		purges = []models.Purge{}
+	}
+
+	c.JSON(http.StatusOK, purges)
+}
+
+// retentionOwner returns whose policy a request manages: the API key if it
+// used one, otherwise the logged-in user. It writes a 401 if neither.
+func retentionOwner(c *gin.Context) (scope, ownerID string, ok bool) {
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		return "api_key", apiKey.ID, true
+	}
+	if user := middleware.GetUser(c); user != nil {
+		return "user", user.ID, true
+	}
+	c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Authentication required",
+		Code:    http.StatusUnauthorized,
+	})
+	return "", "", false
+}
+
+// purgeLimit reads the limit query parameter (1-200, default 50).
+func purgeLimit(c *gin.Context) (int, bool) {
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 200 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_params",
+				Message: "limit must be between 1 and 200",
+				Code:    http.StatusBadRequest,
+			})
+			return 0, false
+		}
+		limit = n
+	}
+	return limit, true
+}
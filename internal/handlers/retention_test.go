@@ -0,0 +1,100 @@
+// retention_test.go contains tests for retention policy request handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestRetentionOwner verifies API key requests manage the key's policy,
+// JWT requests the user's, and anything else gets a 401.
+func TestRetentionOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		key       *models.APIKey
+		user      *models.User
+		wantScope string
+		wantOwner string
+		wantOK    bool
+	}{
+		{name: "api key", key: &models.APIKey{ID: "key-1"}, wantScope: "api_key", wantOwner: "key-1", wantOK: true},
+		{name: "user", user: &models.User{ID: "user-1"}, wantScope: "user", wantOwner: "user-1", wantOK: true},
+		{name: "neither", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			// The auth middleware's context keys
+			if tt.key != nil {
+				c.Set("api_key", tt.key)
+			}
+			if tt.user != nil {
+				c.Set("user", tt.user)
+			}
+
+			scope, owner, ok := retentionOwner(c)
+			if ok != tt.wantOK || scope != tt.wantScope || owner != tt.wantOwner {
+				t.Errorf("retentionOwner() = %q, %q, %v; want %q, %q, %v", scope, owner, ok, tt.wantScope, tt.wantOwner, tt.wantOK)
+			}
+			if !tt.wantOK && w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401", w.Code)
+			}
+		})
+	}
+}
+
+// TestUpdateRetentionValidation verifies out-of-range policies are
+// rejected before anything is saved.
+func TestUpdateRetentionValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	for _, body := range []string{`{"retention_days": -1}`, `{"retention_days": 3651}`, `{"retention_days": "90"}`} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/retention", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("api_key", &models.APIKey{ID: "key-1"})
+
+		h.UpdateRetention(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, w.Code)
+		}
+	}
+}
+
+// TestPurgeLimit verifies the purge log's limit parameter.
+func TestPurgeLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query  string
+		want   int
+		wantOK bool
+	}{
+		{"", 50, true},
+		{"?limit=10", 10, true},
+		{"?limit=200", 200, true},
+		{"?limit=0", 0, false},
+		{"?limit=201", 0, false},
+		{"?limit=ten", 0, false},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/retention/purges"+tt.query, nil)
+		got, ok := purgeLimit(c)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("purgeLimit(%q) = %d, %v; want %d, %v", tt.query, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// saved_searches.go lets API keys save full-text searches, re-run them, and
+// get a search.matched webhook when a newly processed item matches one —
+// handy for watching channels or call logs for a topic.
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxSavedSearchNameLength caps saved search names.
+const maxSavedSearchNameLength = 100
+
+// CreateSavedSearch saves a search query and item type filters.
+// POST /api/v1/searches
+//
+// Request body:
+//
+//	{"name": "Budget talk", "query": "budget Q3", "types": ["audio"], "notify": true}
+//
+// With notify set, each transcript, audio transcription, or PDF of this API
+// key that matches when it finishes processing sends a search.matched event
+// to the key's webhooks subscribed to it.
+func (h *Handler) CreateSavedSearch(c *gin.Context) {
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Saved searches require API key authentication",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "name and query are required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	types, msg := validateSavedSearch(&req)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	s := &models.SavedSearch{
+		APIKeyID: apiKey.ID,
+		Name:     req.Name,
+		Query:    req.Query,
+		Types:    types,
+		Notify:   req.Notify,
+	}
+	if err := h.DB.CreateSavedSearch(c.Request.Context(), s); err != nil {
+		log.Printf("❌ Failed to create saved search: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save search",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, s)
+}
+
+// ListSavedSearches returns the saved searches of the authenticated API key.
+// GET /api/v1/searches
+func (h *Handler) ListSavedSearches(c *gin.Context) {
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Saved searches require API key authentication",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	searches, err := h.DB.ListSavedSearches(c.Request.Context(), apiKey.ID)
+	if err != nil {
+		log.Printf("❌ Failed to list saved searches: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list saved searches",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if searches == nil {
+		searches = []models.SavedSearch{}
+	}
+
+	c.JSON(http.StatusOK, searches)
+}
+
+// RunSavedSearch runs a saved search and returns the same results as
+// GET /api/v1/search/text.
+// GET /api/v1/searches/:id/results?page=1&per_page=20
+func (h *Handler) RunSavedSearch(c *gin.Context) {
+	s, ok := h.loadSavedSearch(c)
+	if !ok {
+		return
+	}
+
+	var params models.TextSearchParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid search parameters",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	params.Query = s.Query
+	params.APIKeyID = &s.APIKeyID
+
+	h.writeSearchResults(c, params, s.Types)
+}
+
+// DeleteSavedSearch removes a saved search.
+// DELETE /api/v1/searches/:id
+func (h *Handler) DeleteSavedSearch(c *gin.Context) {
+	s, ok := h.loadSavedSearch(c)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteSavedSearch(c.Request.Context(), s.ID); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Saved search not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Saved search deleted"})
+}
+
+// loadSavedSearch fetches the saved search named by the :id param, writing
+// a 404 and returning false unless it belongs to the caller's API key.
+func (h *Handler) loadSavedSearch(c *gin.Context) (*models.SavedSearch, bool) {
+	apiKey := middleware.GetAPIKey(c)
+	s, err := h.DB.GetSavedSearch(c.Request.Context(), c.Param("id"))
+	if err != nil || apiKey == nil || s.APIKeyID != apiKey.ID {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Saved search not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	return s, true
+}
+
+// validateSavedSearch trims and checks a create request, returning its
+// deduplicated item types and an error message, or "" if it's valid.
+func validateSavedSearch(req *models.CreateSavedSearchRequest) ([]string, string) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Name == "" || utf8.RuneCountInString(req.Name) > maxSavedSearchNameLength {
+		return nil, "name is required and must be at most " + intToStr(maxSavedSearchNameLength) + " characters"
+	}
+	if req.Query == "" || utf8.RuneCountInString(req.Query) > maxSearchQueryLength {
+		return nil, "query is required and must be at most " + intToStr(maxSearchQueryLength) + " characters"
+	}
+
+	types, ok := parseItemTypes(strings.Join(req.Types, ","))
+	if !ok {
+		return nil, "types must only contain: transcript, audio, pdf"
+	}
+	if types == nil {
+		types = []string{}
+	}
+	return types, ""
+}
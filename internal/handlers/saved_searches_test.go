@@ -0,0 +1,46 @@
+// saved_searches_test.go contains tests for saved search validation.
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestValidateSavedSearch verifies limits, trimming, and type filters.
+func TestValidateSavedSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       models.CreateSavedSearchRequest
+		wantErr   string
+		wantTypes []string
+	}{
+		{"all types", models.CreateSavedSearchRequest{Name: "Budget", Query: "budget"}, "", []string{}},
+		{"types deduplicated", models.CreateSavedSearchRequest{Name: "Budget", Query: "budget", Types: []string{"audio", "pdf", "audio"}}, "", []string{"audio", "pdf"}},
+		{"blank name", models.CreateSavedSearchRequest{Name: "  ", Query: "budget"}, "name is required", nil},
+		{"name too long", models.CreateSavedSearchRequest{Name: strings.Repeat("n", maxSavedSearchNameLength+1), Query: "budget"}, "name is required", nil},
+		{"blank query", models.CreateSavedSearchRequest{Name: "Budget", Query: " "}, "query is required", nil},
+		{"query too long", models.CreateSavedSearchRequest{Name: "Budget", Query: strings.Repeat("q", maxSearchQueryLength+1)}, "query is required", nil},
+		{"unknown type", models.CreateSavedSearchRequest{Name: "Budget", Query: "budget", Types: []string{"video"}}, "types must", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types, msg := validateSavedSearch(&tt.req)
+			if tt.wantErr != "" {
+				if !strings.Contains(msg, tt.wantErr) {
+					t.Fatalf("validateSavedSearch() error = %q, want %q", msg, tt.wantErr)
+				}
+				return
+			}
+			if msg != "" {
+				t.Fatalf("validateSavedSearch() error = %q", msg)
+			}
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("types = %v, want %v", types, tt.wantTypes)
+			}
+		})
+	}
+}
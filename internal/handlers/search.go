@@ -0,0 +1,100 @@
+// search.go serves full-text search across every item type.
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxSearchQueryLength caps the q parameter of text search.
+const maxSearchQueryLength = 500
+
+// SearchText searches transcripts, audio transcriptions, and PDF extractions
+// by title and text, ranked by relevance.
+// GET /api/v1/search/text?q=kubernetes&type=transcript,pdf&page=1&per_page=20
+//
+// q accepts web search syntax: "quoted phrases", OR, and -excluded words.
+// Each result carries an HTML-escaped snippet with matches in <mark> tags.
+func (h *Handler) SearchText(c *gin.Context) {
+	var params models.TextSearchParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid search parameters",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" || utf8.RuneCountInString(params.Query) > maxSearchQueryLength {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "q is required and must be at most " + intToStr(maxSearchQueryLength) + " characters",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	types, ok := parseItemTypes(params.Types)
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_type",
+			Message: "type must be a comma-separated list of: transcript, audio, pdf",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Filter by the authenticated API key, or the one an admin key names
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+
+	h.writeSearchResults(c, params, types)
+}
+
+// writeSearchResults runs a text search and writes the paginated results.
+func (h *Handler) writeSearchResults(c *gin.Context, params models.TextSearchParams, types []string) {
+	results, total, err := h.DB.SearchText(c.Request.Context(), params, types)
+	if err != nil {
+		log.Printf("Text search failed: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "search_failed",
+			Message: "Search failed",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if results == nil {
+		results = []models.SearchResult{}
+	}
+
+	page := params.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := params.PerPage
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.SearchResult]{
+		Data:       results,
+		Page:       page,
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+	})
+}
@@ -0,0 +1,201 @@
+// sessions.go handles login sessions. Logging in returns a short-lived
+// access token (JWT) and a refresh token. The refresh token is swapped for
+// a new pair on every use; only its hash is stored, so sessions can be
+// listed and revoked server-side.
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxUserAgentLength caps the User-Agent stored with a session.
+const maxUserAgentLength = 512
+
+// RefreshToken swaps a refresh token for a new access token and refresh
+// token. The old refresh token stops working; presenting it again revokes
+// the session, since only a copy could still have it.
+// POST /api/v1/auth/refresh
+func (h *Handler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "refresh_token is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	refreshToken, err := generateUserToken("mts_")
+	if err != nil {
+		log.Printf("❌ Failed to generate refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "token_error",
+			Message: "Failed to refresh token",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tokenHash := middleware.HashAPIKey(req.RefreshToken)
+	session, err := h.DB.RotateUserSession(ctx, tokenHash, middleware.HashAPIKey(refreshToken), h.RefreshTokenTTL)
+	if err != nil {
+		if revoked, err := h.DB.RevokeReusedSession(ctx, tokenHash); err != nil {
+			log.Printf("❌ %v", err)
+		} else if revoked {
+			log.Printf("⚠️  Refresh token reused; revoked its session")
+		}
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "invalid_refresh_token",
+			Message: "Refresh token is invalid, expired, or revoked; log in again",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	user, err := h.DB.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not found",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	token, err := middleware.GenerateJWT(user, session.ID, h.JWTSecret, h.AccessTokenTTL)
+	if err != nil {
+		log.Printf("❌ Failed to refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "token_error",
+			Message: "Failed to refresh token",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(h.AccessTokenTTL.Seconds()),
+		User:         *user,
+	})
+}
+
+// Logout revokes the session holding a refresh token. Its access tokens
+// stop working immediately. The refresh token is the credential, so this
+// works even after the access token has expired.
+// POST /api/v1/auth/logout
+func (h *Handler) Logout(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "refresh_token is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.DB.RevokeSessionByToken(c.Request.Context(), middleware.HashAPIKey(req.RefreshToken)); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to log out",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// ListSessions returns the user's live sessions, flagging the one making
+// the request.
+// GET /api/v1/auth/sessions
+func (h *Handler) ListSessions(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.DB.ListUserSessions(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list sessions",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if sessions == nil {
+		sessions = []models.UserSession{}
+	}
+
+	current := middleware.GetSessionID(c)
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession signs the user out of one of their sessions.
+// DELETE /api/v1/auth/sessions/:id
+func (h *Handler) RevokeSession(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.RevokeUserSession(c.Request.Context(), user.ID, c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Session not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// issueTokens starts a session for a user who just logged in and returns
+// their first access and refresh tokens.
+func (h *Handler) issueTokens(c *gin.Context, user *models.User) (*models.AuthResponse, error) {
+	refreshToken, err := generateUserToken("mts_")
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	session, err := h.DB.CreateUserSession(c.Request.Context(), user.ID,
+		middleware.HashAPIKey(refreshToken), userAgent, c.ClientIP(), h.RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := middleware.GenerateJWT(user, session.ID, h.JWTSecret, h.AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(h.AccessTokenTTL.Seconds()),
+		User:         *user,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// sessions_test.go contains tests for refresh token and session handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestSessionHandlersRejectBadInput verifies requests without a refresh
+// token or a logged-in user are rejected before the database is touched.
+func TestSessionHandlersRejectBadInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	tests := []struct {
+		name   string
+		handle gin.HandlerFunc
+		body   string
+		want   int
+	}{
+		{"refresh, no token", h.RefreshToken, `{}`, http.StatusBadRequest},
+		{"refresh, no body", h.RefreshToken, ``, http.StatusBadRequest},
+		{"logout, no token", h.Logout, `{}`, http.StatusBadRequest},
+		{"list, not logged in", h.ListSessions, ``, http.StatusUnauthorized},
+		{"revoke, not logged in", h.RevokeSession, ``, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			tt.handle(c)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// settings.go lets admins view and change runtime settings — the default
+// model, rate limits, worker count, and feature flags — without a redeploy.
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/settings"
+)
+
+// AdminListSettings returns every runtime setting with its current and
+// configured value.
+// GET /api/v1/admin/settings
+func (h *Handler) AdminListSettings(c *gin.Context) {
+	c.JSON(http.StatusOK, h.Settings.List())
+}
+
+// AdminUpdateSettings overrides one or more settings, e.g.
+// {"worker_count": 6, "default_model": "openai/gpt-4o-mini"}. Nothing
+// changes unless every value is valid. Other instances pick the change up
+// at their next reload.
+// PATCH /api/v1/admin/settings
+func (h *Handler) AdminUpdateSettings(c *gin.Context) {
+	var changes map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&changes); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Body must be a JSON object of settings to change",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if raw, ok := changes["default_model"]; ok && h.Summarizer != nil {
+		// Malformed values are left for Update to reject
+		var model string
+		if json.Unmarshal(raw, &model) == nil && !h.Summarizer.ModelAllowed(strings.TrimSpace(model)) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_setting",
+				Message: "invalid setting: default_model must be in the model catalog (GET /api/v1/models)",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	if err := h.Settings.Update(c.Request.Context(), changes); err != nil {
+		if errors.Is(err, settings.ErrInvalidSetting) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_setting",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save settings",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.Settings.List())
+}
+
+// AdminResetSetting removes a setting's override, restoring the configured
+// value.
+// DELETE /api/v1/admin/settings/:key
+func (h *Handler) AdminResetSetting(c *gin.Context) {
+	if err := h.Settings.Reset(c.Request.Context(), c.Param("key")); err != nil {
+		if errors.Is(err, settings.ErrUnknownSetting) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "Setting not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to reset setting",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.Settings.List())
+}
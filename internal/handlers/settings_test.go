@@ -0,0 +1,51 @@
+// settings_test.go contains tests for the runtime settings endpoints.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/settings"
+)
+
+// TestAdminSettingsRejectBadInput verifies malformed bodies, invalid values,
+// and unknown keys are turned away before anything is stored.
+func TestAdminSettingsRejectBadInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{Settings: settings.New(nil, settings.Values{WorkerCount: 3, DefaultTier: "free"})}
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		key       string
+		handle    gin.HandlerFunc
+		wantCode  int
+		wantError string
+	}{
+		{"not an object", http.MethodPatch, `[1]`, "", h.AdminUpdateSettings, http.StatusBadRequest, "invalid_request"},
+		{"bad value", http.MethodPatch, `{"worker_count": 0}`, "", h.AdminUpdateSettings, http.StatusBadRequest, "invalid_setting"},
+		{"unknown key", http.MethodPatch, `{"jwt_secret": "x"}`, "", h.AdminUpdateSettings, http.StatusBadRequest, "invalid_setting"},
+		{"reset unknown key", http.MethodDelete, ``, "jwt_secret", h.AdminResetSetting, http.StatusNotFound, "not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, "/api/v1/admin/settings", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "key", Value: tt.key}}
+
+			tt.handle(c)
+
+			if w.Code != tt.wantCode || !strings.Contains(w.Body.String(), `"error":"`+tt.wantError+`"`) {
+				t.Errorf("status = %d, body = %s; want %d %s", w.Code, w.Body.String(), tt.wantCode, tt.wantError)
+			}
+		})
+	}
+}
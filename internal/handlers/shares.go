@@ -0,0 +1,379 @@
+// shares.go handles public share links.
+//
+// A share link is a random token that grants read-only access to one
+// transcript, audio transcription, or PDF extraction without an API key,
+// so results can be sent to people who don't have an account. Links can
+// expire and can be revoked. Like API keys, only the token's SHA-256 hash
+// is stored — the URL is shown once, when the link is created.
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxShareExpiryHours caps how long a share link can live (one year).
+const maxShareExpiryHours = 24 * 365
+
+// shareSource is an item that can be shared, plus what's needed to check
+// who may share it.
+type shareSource struct {
+	item     models.SharedItem
+	ready    bool
+	apiKeyID *string
+	userID   *string
+}
+
+// loadShareSource fetches a transcript, audio transcription, or PDF
+// extraction as its public view.
+func (h *Handler) loadShareSource(ctx context.Context, itemType, id string) (*shareSource, error) {
+	switch itemType {
+	case "transcript":
+		t, err := h.DB.GetTranscript(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return &shareSource{
+			item: models.SharedItem{
+				Type: itemType, Title: t.Title, SourceURL: t.YouTubeURL, Language: t.Language,
+				Duration: float64(t.Duration), WordCount: t.WordCount, Text: t.TranscriptText, CreatedAt: t.CreatedAt,
+			},
+			ready:    t.Status == models.StatusCompleted,
+			apiKeyID: t.APIKeyID,
+			userID:   t.UserID,
+		}, nil
+	case "audio":
+		at, err := h.DB.GetAudioTranscription(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return &shareSource{
+			item: models.SharedItem{
+				Type: itemType, Title: at.DisplayName(), Language: at.Language,
+				Duration: at.Duration, WordCount: at.WordCount, Text: at.TranscriptText, CreatedAt: at.CreatedAt,
+			},
+			ready:    at.Status == "completed",
+			apiKeyID: at.APIKeyID,
+			userID:   at.UserID,
+		}, nil
+	case "pdf":
+		pe, err := h.DB.GetPDFExtraction(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return &shareSource{
+			item: models.SharedItem{
+				Type: itemType, Title: pe.DisplayName(), PageCount: pe.PageCount,
+				WordCount: pe.WordCount, Text: pe.TextContent, CreatedAt: pe.CreatedAt,
+			},
+			ready:    pe.Status == "completed",
+			apiKeyID: pe.APIKeyID,
+			userID:   pe.UserID,
+		}, nil
+	}
+	return nil, errors.New("unknown item type " + itemType)
+}
+
+// canManageItem reports whether the caller owns a record with these owner
+// IDs. API keys manage their own records and unowned ones. Logged-in users
+// manage their own records and those created by keys linked to them, but
+// not unowned ones: items created over the API carry no user, so leaving
+// them open would let any account change every tenant's items.
+func (h *Handler) canManageItem(c *gin.Context, apiKeyID, userID *string) bool {
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		return apiKeyID == nil || *apiKeyID == apiKey.ID
+	}
+	user := middleware.GetUser(c)
+	switch {
+	case user == nil:
+		return false
+	case userID != nil:
+		return *userID == user.ID
+	case apiKeyID == nil:
+		return false
+	}
+	keyUserID, err := h.DB.GetAPIKeyUserID(c.Request.Context(), *apiKeyID)
+	return err == nil && keyUserID != nil && *keyUserID == user.ID
+}
+
+// CreateTranscriptShare creates a public link to a transcript.
+// POST /api/v1/transcripts/:id/share
+func (h *Handler) CreateTranscriptShare(c *gin.Context) {
+	h.createShareLink(c, "transcript")
+}
+
+// CreateAudioShare creates a public link to an audio transcription.
+// POST /api/v1/audio/transcriptions/:id/share
+func (h *Handler) CreateAudioShare(c *gin.Context) {
+	h.createShareLink(c, "audio")
+}
+
+// CreatePDFShare creates a public link to a PDF extraction.
+// POST /api/v1/pdf/extractions/:id/share
+func (h *Handler) CreatePDFShare(c *gin.Context) {
+	h.createShareLink(c, "pdf")
+}
+
+// createShareLink is the shared implementation of the Create*Share handlers.
+//
+// Request body (optional):
+//
+//	{"expires_in_hours": 72}
+func (h *Handler) createShareLink(c *gin.Context, itemType string) {
+	var req models.CreateShareLinkRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	if req.ExpiresInHours < 0 || req.ExpiresInHours > maxShareExpiryHours {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_expiry",
+			Message: "expires_in_hours must be between 0 (never) and " + intToStr(maxShareExpiryHours),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	src, err := h.loadShareSource(c.Request.Context(), itemType, c.Param("id"))
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Failed to load %s %s for sharing: %v", itemType, c.Param("id"), err)
+		}
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Item not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canManageItem(c, src.apiKeyID, src.userID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only share your own items",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if !src.ready {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "not_ready",
+			Message: "Only completed items can be shared",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		log.Printf("❌ Failed to generate share token: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_error",
+			Message: "Failed to generate share link",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	link := &models.ShareLink{
+		TokenHash:   middleware.HashAPIKey(token),
+		TokenPrefix: token[:8] + "...",
+		ItemType:    itemType,
+		ItemID:      c.Param("id"),
+	}
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		link.APIKeyID = &apiKey.ID
+	}
+	if user := middleware.GetUser(c); user != nil {
+		link.UserID = &user.ID
+	}
+	if req.ExpiresInHours > 0 {
+		expires := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		link.ExpiresAt = &expires
+	}
+
+	if err := h.DB.CreateShareLink(c.Request.Context(), link); err != nil {
+		log.Printf("Failed to create share link: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create share link",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreateShareLinkResponse{
+		ShareLink: *link,
+		URL:       "/api/v1/shared/" + token,
+	})
+}
+
+// ListShareLinks returns the caller's share links, including revoked and
+// expired ones.
+// GET /api/v1/shares
+func (h *Handler) ListShareLinks(c *gin.Context) {
+	var apiKeyID, userID *string
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		apiKeyID = &apiKey.ID
+	} else if user := middleware.GetUser(c); user != nil {
+		userID = &user.ID
+	}
+
+	links, err := h.DB.ListShareLinks(c.Request.Context(), apiKeyID, userID)
+	if err != nil {
+		log.Printf("Failed to list share links: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list share links",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if links == nil {
+		links = []models.ShareLink{}
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// RevokeShareLink disables a share link immediately.
+// DELETE /api/v1/shares/:id
+func (h *Handler) RevokeShareLink(c *gin.Context) {
+	link, err := h.DB.GetShareLink(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Share link not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	// Links are always owned by whoever created them, so unlike items
+	// there's no "unowned" case to allow.
+	if (link.APIKeyID == nil && link.UserID == nil) || !h.canManageItem(c, link.APIKeyID, link.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only revoke your own share links",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	if err := h.DB.RevokeShareLink(c.Request.Context(), link.ID); err != nil {
+		log.Printf("Failed to revoke share link %s: %v", link.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to revoke share link",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Share link revoked"})
+}
+
+// ViewSharedItem serves a shared item without authentication. Browsers get
+// a minimal HTML page; everything else gets JSON. ?format=json|html
+// overrides the Accept header.
+// GET /api/v1/shared/:token
+func (h *Handler) ViewSharedItem(c *gin.Context) {
+	// The token is a credential: keep it out of Referer headers and caches,
+	// and keep shared pages out of search engines.
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex")
+
+	notFound := models.ErrorResponse{
+		Error:   "not_found",
+		Message: "This share link doesn't exist, has expired, or was revoked",
+		Code:    http.StatusNotFound,
+	}
+
+	link, err := h.DB.GetActiveShareLinkByHash(c.Request.Context(), middleware.HashAPIKey(c.Param("token")))
+	if err != nil {
+		c.JSON(http.StatusNotFound, notFound)
+		return
+	}
+	src, err := h.loadShareSource(c.Request.Context(), link.ItemType, link.ItemID)
+	if err != nil {
+		// The item was deleted after it was shared
+		c.JSON(http.StatusNotFound, notFound)
+		return
+	}
+
+	item := src.item
+	item.ExpiresAt = link.ExpiresAt
+
+	if sharedFormat(c) == gin.MIMEHTML {
+		c.Status(http.StatusOK)
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		if err := sharedItemPage.Execute(c.Writer, item); err != nil {
+			log.Printf("Failed to render shared item %s: %v", link.ID, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// sharedFormat picks HTML or JSON for ViewSharedItem.
+func sharedFormat(c *gin.Context) string {
+	switch c.Query("format") {
+	case "html":
+		return gin.MIMEHTML
+	case "json":
+		return gin.MIMEJSON
+	}
+	// JSON is offered first so clients sending */* (curl, fetch) get JSON;
+	// browsers list text/html explicitly and get the page.
+	return c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML)
+}
+
+// generateShareToken creates a share token: "shr_" + 48 random hex characters.
+// Longer than an API key since it's the only thing protecting a public URL.
+func generateShareToken() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "shr_" + hex.EncodeToString(bytes), nil
+}
+
+// sharedItemPage is the HTML view of a shared item.
+// Go Pattern: html/template escapes every value, so transcript text can't
+// inject markup into the page.
+var sharedItemPage = template.Must(template.New("shared").Funcs(template.FuncMap{
+	"duration": func(seconds float64) string { return formatDuration(int(seconds)) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1.0">
+  <meta name="robots" content="noindex">
+  <title>{{if .Title}}{{.Title}}{{else}}Shared {{.Type}}{{end}} — Media Tools</title>
+  <style>
+    body { max-width: 720px; margin: 40px auto; padding: 0 20px; font-family: system-ui, sans-serif; line-height: 1.6; color: #222; }
+    .meta { color: #666; font-size: 0.9em; }
+    .text { white-space: pre-wrap; }
+  </style>
+</head>
+<body>
+  <h1>{{if .Title}}{{.Title}}{{else}}Shared {{.Type}}{{end}}</h1>
+  <p class="meta">
+    {{if .SourceURL}}<a href="{{.SourceURL}}" rel="noopener noreferrer">{{.SourceURL}}</a> · {{end}}
+    {{if .Duration}}{{duration .Duration}} · {{end}}
+    {{if .PageCount}}{{.PageCount}} pages · {{end}}
+    {{.WordCount}} words
+    {{if .ExpiresAt}}<br>Link expires {{.ExpiresAt.UTC.Format "Jan 2, 2006 15:04 UTC"}}{{end}}
+  </p>
+  <div class="text">{{.Text}}</div>
+</body>
+</html>
+`))
@@ -0,0 +1,82 @@
+// shares_test.go contains tests for public share link helpers.
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestSharedFormat verifies browsers get HTML and API clients get JSON.
+func TestSharedFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		want   string
+	}{
+		{"browser", "/", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", gin.MIMEHTML},
+		{"curl", "/", "*/*", gin.MIMEJSON},
+		{"no accept header", "/", "", gin.MIMEJSON},
+		{"explicit json", "/", "application/json", gin.MIMEJSON},
+		{"query overrides browser", "/?format=json", "text/html", gin.MIMEJSON},
+		{"query overrides curl", "/?format=html", "*/*", gin.MIMEHTML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+			if got := sharedFormat(c); got != tt.want {
+				t.Errorf("sharedFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateShareToken verifies tokens are prefixed and unique.
+func TestGenerateShareToken(t *testing.T) {
+	a, err := generateShareToken()
+	if err != nil {
+		t.Fatalf("generateShareToken() error = %v", err)
+	}
+	b, _ := generateShareToken()
+
+	if !strings.HasPrefix(a, "shr_") || len(a) != 52 {
+		t.Errorf("token %q: want shr_ + 48 hex chars", a)
+	}
+	if a == b {
+		t.Error("two tokens were identical")
+	}
+}
+
+// TestSharedItemPageEscapes verifies item text can't inject markup.
+func TestSharedItemPageEscapes(t *testing.T) {
+	var out strings.Builder
+	item := models.SharedItem{
+		Type:      "pdf",
+		Title:     "<script>alert(1)</script>",
+		Text:      "a < b & <b>bold</b>",
+		WordCount: 5,
+	}
+	if err := sharedItemPage.Execute(&out, item); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	html := out.String()
+	if strings.Contains(html, "<script>alert") || strings.Contains(html, "<b>bold") {
+		t.Errorf("unescaped markup in page:\n%s", html)
+	}
+	if !strings.Contains(html, "a &lt; b &amp; &lt;b&gt;bold&lt;/b&gt;") {
+		t.Errorf("escaped text missing from page:\n%s", html)
+	}
+}
@@ -0,0 +1,229 @@
+// social.go repurposes a transcript's or recording's summary into social
+// posts — a tweet thread, a LinkedIn post, a newsletter blurb — in a chosen
+// tone and within each platform's character limit.
+//
+// Posts aren't stored; repeats are answered from the AI completion cache.
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// socialPlatformOrder is the order posts come back in when no platforms
+// are named.
+var socialPlatformOrder = []string{"twitter", "linkedin", "newsletter"}
+
+// defaultSocialTone is the tone used when none is given.
+const defaultSocialTone = "professional"
+
+// CreateTranscriptSocialPosts writes social posts from a transcript's
+// latest summary.
+// POST /api/v1/transcripts/:id/social
+func (h *Handler) CreateTranscriptSocialPosts(c *gin.Context) {
+	req, ok := h.bindSocialRequest(c)
+	if !ok {
+		return
+	}
+
+	t, err := h.DB.GetTranscript(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Transcript not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only write posts about your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	s, err := h.DB.GetLatestSummary(c.Request.Context(), t.ID)
+	if err != nil {
+		log.Printf("Failed to load summary for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load the transcript's summary",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if s == nil {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "summary_required",
+			Message: "Summarize this transcript first (POST /api/v1/summaries)",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	in := summary.SocialInput{Source: "YouTube video", Title: t.Title, Summary: s.SummaryText, URL: t.YouTubeURL}
+	json.Unmarshal(s.KeyPoints, &in.KeyPoints)
+	h.writeSocialPosts(c, t.ID, in, req)
+}
+
+// CreateAudioSocialPosts writes social posts from an audio transcription's
+// summary.
+// POST /api/v1/audio/transcriptions/:id/social
+func (h *Handler) CreateAudioSocialPosts(c *gin.Context) {
+	req, ok := h.bindSocialRequest(c)
+	if !ok {
+		return
+	}
+
+	at, err := h.DB.GetAudioTranscription(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Audio transcription not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, at.APIKeyID, at.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only write posts about your own or your organization's transcriptions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if at.SummaryStatus != "completed" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "summary_required",
+			Message: "Summarize this recording first (POST /api/v1/audio/transcriptions/:id/summarize)",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	source := "audio recording"
+	if at.ContentType != "" && at.ContentType != models.ContentGeneral {
+		source = fmt.Sprintf("audio recording (%s)", strings.ReplaceAll(string(at.ContentType), "_", " "))
+	}
+	in := summary.SocialInput{Source: source, Title: at.DisplayName(), Summary: at.SummaryText}
+	json.Unmarshal(at.KeyPoints, &in.KeyPoints)
+	h.writeSocialPosts(c, at.ID, in, req)
+}
+
+// bindSocialRequest reads and checks the optional request body, filling in
+// defaults. On failure it writes the error response and returns false.
+func (h *Handler) bindSocialRequest(c *gin.Context) (models.CreateSocialPostsRequest, bool) {
+	var req models.CreateSocialPostsRequest
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return req, false
+	}
+
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+	platforms, apiErr := socialPlatforms(req.Platforms)
+	if apiErr == nil {
+		apiErr = socialToneError(req.Tone)
+	}
+	if apiErr == nil {
+		apiErr = h.modelError(req.Model, req.FallbackModels)
+	}
+	if apiErr == nil {
+		apiErr = languageError(req.Language)
+	}
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return req, false
+	}
+	req.Platforms = platforms
+	fillDefault(&req.Tone, defaultSocialTone)
+
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return req, false
+	}
+	return req, true
+}
+
+// writeSocialPosts generates the posts and writes the response.
+func (h *Handler) writeSocialPosts(c *gin.Context, itemID string, in summary.SocialInput, req models.CreateSocialPostsRequest) {
+	ctx := aiContext(c)
+	if req.ForceRefresh {
+		ctx = summary.WithForceRefresh(ctx)
+	}
+	result, err := h.Summarizer.GenerateSocialPosts(ctx, in, req.Platforms, req.Tone, summary.Options{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Social post generation failed for %s: %v", itemID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to write posts: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// socialPlatforms checks the requested platforms, returning them
+// de-duplicated in socialPlatformOrder, or all of them when none are named.
+func socialPlatforms(requested []string) ([]string, *models.ErrorResponse) {
+	if len(requested) == 0 {
+		return socialPlatformOrder, nil
+	}
+	wanted := make(map[string]bool)
+	for _, p := range requested {
+		if _, ok := summary.SocialPlatforms[p]; !ok {
+			return nil, &models.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "platforms must be from: " + strings.Join(socialPlatformOrder, ", "),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		wanted[p] = true
+	}
+	var platforms []string
+	for _, p := range socialPlatformOrder {
+		if wanted[p] {
+			platforms = append(platforms, p)
+		}
+	}
+	return platforms, nil
+}
+
+// socialToneError reports an unknown tone; empty means the default.
+func socialToneError(tone string) *models.ErrorResponse {
+	if _, ok := summary.SocialTones[tone]; ok || tone == "" {
+		return nil
+	}
+	tones := make([]string, 0, len(summary.SocialTones))
+	for t := range summary.SocialTones {
+		tones = append(tones, t)
+	}
+	sort.Strings(tones)
+	return &models.ErrorResponse{
+		Error:   "invalid_request",
+		Message: "tone must be one of: " + strings.Join(tones, ", "),
+		Code:    http.StatusBadRequest,
+	}
+}
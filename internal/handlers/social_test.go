@@ -0,0 +1,43 @@
+// social_test.go tests checking the platforms and tone of a social posts
+// request.
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSocialPlatforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested []string
+		want      []string
+		wantError bool
+	}{
+		{"default", nil, []string{"twitter", "linkedin", "newsletter"}, false},
+		{"reordered and repeated", []string{"newsletter", "twitter", "newsletter"}, []string{"twitter", "newsletter"}, false},
+		{"unknown", []string{"twitter", "myspace"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, apiErr := socialPlatforms(tt.requested)
+			if (apiErr != nil) != tt.wantError {
+				t.Fatalf("socialPlatforms() error = %v, want error %v", apiErr, tt.wantError)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("socialPlatforms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocialToneError(t *testing.T) {
+	for _, tone := range []string{"", "casual", "witty"} {
+		if apiErr := socialToneError(tone); apiErr != nil {
+			t.Errorf("socialToneError(%q) = %v, want nil", tone, apiErr)
+		}
+	}
+	if apiErr := socialToneError("angry"); apiErr == nil {
+		t.Error("socialToneError(\"angry\") = nil, want an error")
+	}
+}
@@ -0,0 +1,185 @@
+// spend.go enforces per-key monthly AI spend limits and lets admins set
+// them. Once a key reaches its limit, AI requests are either rejected with
+// 402 or served by the budget model, depending on the limit's action.
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/spend"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// GetKeySpend returns an API key's AI spend this month and its limit. The
+// same callers as for GetKeyAISettings may see it.
+// GET /api/v1/keys/:id/spend
+func (h *Handler) GetKeySpend(c *gin.Context) {
+	keyID := c.Param("id")
+	if !h.canEditKeySettings(c, keyID) {
+		return
+	}
+
+	status, err := h.DB.GetKeySpend(c.Request.Context(), keyID, time.Now())
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to get AI spend",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// SetKeySpendLimit caps an API key's monthly AI spend. Requires the admin
+// role; keys the caller doesn't manage are reported as not found.
+// PUT /api/v1/keys/:id/spend-limit
+func (h *Handler) SetKeySpendLimit(c *gin.Context) {
+	var req models.SetSpendLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.MonthlyLimitUSD <= 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "monthly_limit_usd must be a positive amount",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if req.Action == "" {
+		req.Action = models.SpendActionBlock
+	}
+	if req.Action != models.SpendActionBlock && req.Action != models.SpendActionDowngrade {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "action must be block or downgrade",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	keyID := c.Param("id")
+	if !h.canManageSpendLimit(c, keyID) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	err := h.DB.SetSpendLimit(ctx, keyID, req.MonthlyLimitUSD, req.Action)
+	var status *models.SpendStatus
+	if err == nil {
+		status, err = h.DB.GetKeySpend(ctx, keyID, time.Now())
+	}
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to set spend limit",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// DeleteKeySpendLimit removes an API key's spend limit. Requires the admin
+// role.
+// DELETE /api/v1/keys/:id/spend-limit
+func (h *Handler) DeleteKeySpendLimit(c *gin.Context) {
+	keyID := c.Param("id")
+	if !h.canManageSpendLimit(c, keyID) {
+		return
+	}
+
+	if err := h.DB.DeleteSpendLimit(c.Request.Context(), keyID); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to delete spend limit",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Spend limit removed"})
+}
+
+// canManageSpendLimit reports whether the caller may change a key's spend
+// limit: any key for an unscoped admin key, its organization's keys for a
+// scoped one, and for a user the keys of organizations they administer —
+// but not their own keys, or a limit would be theirs to lift. Otherwise it
+// writes a 404.
+func (h *Handler) canManageSpendLimit(c *gin.Context, keyID string) bool {
+	ctx := c.Request.Context()
+	allowed := false
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		keyOrgID, err := h.DB.GetAPIKeyOrgID(ctx, keyID)
+		managed := h.managedOrgID(apiKey)
+		allowed = err == nil && (managed == nil || (keyOrgID != nil && *keyOrgID == *managed))
+	} else if user := middleware.GetUser(c); user != nil {
+		ok, err := h.DB.UserAdministersAPIKey(ctx, user.ID, keyID)
+		if err != nil {
+			log.Printf("❌ %v", err)
+		}
+		allowed = ok
+	}
+	if !allowed {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+	}
+	return allowed
+}
+
+// applySpendLimit holds an AI request to the caller's key's monthly spend
+// limit. Under the limit, nothing changes. Over it, a downgrade limit
+// swaps the request's model for the budget model, without fallbacks, and
+// a block limit returns a 402 error. The owner key is exempt, and lookup
+// failures are logged and let the request through.
+func (h *Handler) applySpendLimit(c *gin.Context, model *string, fallbacks *[]string) *models.ErrorResponse {
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil || h.DB == nil || h.isOwnerRequest(c) {
+		return nil
+	}
+
+	ctx := c.Request.Context()
+	status, err := h.DB.GetKeySpend(ctx, apiKey.ID, time.Now())
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return nil
+	}
+	if !spend.Exceeded(status) {
+		return nil
+	}
+
+	if status.Action == models.SpendActionDowngrade && h.Summarizer != nil {
+		if budget := h.Summarizer.BudgetModel(ctx); budget != "" {
+			*model = budget
+			*fallbacks = []string{}
+			c.Header("X-Spend-Limit", "downgraded")
+			return nil
+		}
+	}
+	return &models.ErrorResponse{
+		Error:   "spend_limit_exceeded",
+		Message: fmt.Sprintf("This API key has used its $%.2f monthly AI spend limit; it resets at the start of next month (UTC)", *status.LimitUSD),
+		Code:    http.StatusPaymentRequired,
+	}
+}
+
+// aiContext returns the request's context, tagged so what its AI calls
+// cost counts toward the caller's key's spend.
+func aiContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		ctx = summary.WithSpendKey(ctx, apiKey.ID)
+	}
+	return ctx
+}
@@ -0,0 +1,94 @@
+// stats.go serves usage statistics for the dashboard.
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxStatsDays caps the range GET /api/v1/stats covers.
+const maxStatsDays = 366
+
+// GetStats returns the caller's usage: totals, per-type totals, and a daily
+// series of items submitted, minutes transcribed, words extracted, failure
+// rate, and average processing latency.
+// GET /api/v1/stats?date_from=2026-01-01&date_to=2026-01-31
+//
+// Dates are UTC days, both inclusive; the default is the last 30 days.
+func (h *Handler) GetStats(c *gin.Context) {
+	var params models.StatsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid query parameters: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	from, to, msg := statsRange(params, time.Now())
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: msg,
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Scope to the caller: their API key (or the one an admin key names),
+	// or their account when signed in
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+
+	stats, err := h.DB.GetUsageStats(c.Request.Context(), params, from, to)
+	if err != nil {
+		log.Printf("❌ Failed to compute stats: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to compute stats",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// statsRange resolves the date range of a stats request as UTC midnights,
+// returning an error message, or "" if the range is valid.
+func statsRange(params models.StatsParams, now time.Time) (time.Time, time.Time, string) {
+	const layout = "2006-01-02"
+	invalid := "date_from and date_to must be YYYY-MM-DD, in order, at most " + intToStr(maxStatsDays) + " days apart"
+
+	to := now.UTC().Truncate(24 * time.Hour)
+	if params.DateTo != "" {
+		t, err := time.Parse(layout, params.DateTo)
+		if err != nil {
+			return time.Time{}, time.Time{}, invalid
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -29)
+	if params.DateFrom != "" {
+		f, err := time.Parse(layout, params.DateFrom)
+		if err != nil {
+			return time.Time{}, time.Time{}, invalid
+		}
+		from = f
+	}
+
+	if from.After(to) || to.Sub(from) >= maxStatsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, invalid
+	}
+	return from, to, ""
+}
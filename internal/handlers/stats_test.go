@@ -0,0 +1,52 @@
+// stats_test.go contains tests for stats date range handling.
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestStatsRange verifies defaults and rejects bad or oversized ranges.
+func TestStatsRange(t *testing.T) {
+	now := time.Date(2026, 3, 31, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		params   models.StatsParams
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{"default last 30 days", models.StatsParams{}, "2026-03-02", "2026-03-31", false},
+		{"from only", models.StatsParams{DateFrom: "2026-03-20"}, "2026-03-20", "2026-03-31", false},
+		{"explicit range", models.StatsParams{DateFrom: "2026-01-01", DateTo: "2026-01-31"}, "2026-01-01", "2026-01-31", false},
+		{"single day", models.StatsParams{DateFrom: "2026-02-01", DateTo: "2026-02-01"}, "2026-02-01", "2026-02-01", false},
+		{"full year", models.StatsParams{DateFrom: "2025-01-01", DateTo: "2026-01-01"}, "2025-01-01", "2026-01-01", false},
+		{"too long", models.StatsParams{DateFrom: "2025-01-01", DateTo: "2026-01-02"}, "", "", true},
+		{"reversed", models.StatsParams{DateFrom: "2026-02-02", DateTo: "2026-02-01"}, "", "", true},
+		{"bad date", models.StatsParams{DateTo: "March 1"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, msg := statsRange(tt.params, now)
+			if tt.wantErr {
+				if msg == "" {
+					t.Fatalf("statsRange() = %v..%v, want error", from, to)
+				}
+				return
+			}
+			if msg != "" {
+				t.Fatalf("statsRange() error = %q", msg)
+			}
+			if got := from.Format("2006-01-02"); got != tt.wantFrom {
+				t.Errorf("from = %s, want %s", got, tt.wantFrom)
+			}
+			if got := to.Format("2006-01-02"); got != tt.wantTo {
+				t.Errorf("to = %s, want %s", got, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// study.go handles study aid generation — flashcards and multiple-choice
+// quizzes built from lecture transcripts — and their Anki export.
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// Defaults and caps for study aid generation. The caps keep a single
+// request from asking the model for an unreasonably long response.
+const (
+	defaultFlashcards = 10
+	maxFlashcards     = 50
+	defaultQuestions  = 5
+	maxQuestions      = 25
+)
+
+// CreateStudyAid generates flashcards and a quiz for a completed transcript.
+// POST /api/v1/transcripts/:id/study
+//
+// Generation is synchronous (like audio summaries) — the response contains
+// the stored study aid.
+func (h *Handler) CreateStudyAid(c *gin.Context) {
+	if h.Summarizer == nil {
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "AI generation is not configured. Set the OPENROUTER_API_KEY environment variable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	var req models.CreateStudyAidRequest
+	c.ShouldBindJSON(&req) // Optional body — ok if empty
+
+	if req.NumFlashcards < 0 || req.NumFlashcards > maxFlashcards ||
+		req.NumQuestions < 0 || req.NumQuestions > maxQuestions {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("num_flashcards must be 0-%d and num_questions must be 0-%d", maxFlashcards, maxQuestions),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if req.NumFlashcards == 0 {
+		req.NumFlashcards = defaultFlashcards
+	}
+	if req.NumQuestions == 0 {
+		req.NumQuestions = defaultQuestions
+	}
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+
+	t, ok := h.loadStudyTranscript(c)
+	if !ok {
+		return
+	}
+	if t.Status != models.StatusCompleted || t.TranscriptText == "" {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "transcript_not_ready",
+			Message: "Transcript is not completed yet (status: " + string(t.Status) + ")",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	result, err := h.Summarizer.GenerateStudyAid(aiContext(c), t.TranscriptText, summary.StudyOptions{
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		NumFlashcards:  req.NumFlashcards,
+		NumQuestions:   req.NumQuestions,
+		Language:       req.Language,
+	})
+	if err != nil {
+		log.Printf("Study aid generation failed for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_failed",
+			Message: "Failed to generate study aid: " + err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	flashcardsJSON, _ := json.Marshal(result.Flashcards)
+	quizJSON, _ := json.Marshal(result.Quiz)
+
+	aid := &models.StudyAid{
+		TranscriptID: t.ID,
+		ModelUsed:    result.Model,
+		Flashcards:   flashcardsJSON,
+		Quiz:         quizJSON,
+	}
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		aid.APIKeyID = &apiKey.ID
+	}
+
+	if err := h.DB.CreateStudyAid(c.Request.Context(), aid); err != nil {
+		log.Printf("Failed to save study aid for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to save study aid",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, aid)
+}
+
+// GetStudyAid returns the most recent study aid for a transcript.
+// GET /api/v1/transcripts/:id/study?format=json|anki
+//
+// format=anki downloads the flashcards and quiz as an Anki-importable CSV.
+func (h *Handler) GetStudyAid(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "anki" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Supported formats: json, anki",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	t, ok := h.loadStudyTranscript(c)
+	if !ok {
+		return
+	}
+
+	aid, err := h.DB.GetLatestStudyAid(c.Request.Context(), t.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error:   "not_found",
+				Message: "No study aid has been generated for this transcript",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		log.Printf("Failed to load study aid for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to load study aid",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, aid)
+		return
+	}
+
+	data, err := studyAidToAnkiCSV(aid)
+	if err != nil {
+		log.Printf("Failed to build Anki export for %s: %v", t.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "export_failed",
+			Message: "Failed to build Anki export",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	filename := sanitizeFilename(t.Title)
+	if filename == "" {
+		filename = t.YouTubeID
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s - flashcards.csv"`, filename))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// loadStudyTranscript fetches the transcript from the :id param and checks
+// ownership. On failure it writes the error response and returns false.
+func (h *Handler) loadStudyTranscript(c *gin.Context) (*models.Transcript, bool) {
+	t, err := h.DB.GetTranscript(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Transcript not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only access study aids for your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return nil, false
+	}
+	return t, true
+}
+
+// studyAidToAnkiCSV converts a study aid into CSV rows of front,back,tags.
+//
+// Anki's importer maps columns to note fields and renders them as HTML, so
+// quiz options are joined with <br> to show one per line on the card front.
+func studyAidToAnkiCSV(aid *models.StudyAid) ([]byte, error) {
+	var flashcards []summary.Flashcard
+	if err := json.Unmarshal(aid.Flashcards, &flashcards); err != nil {
+		return nil, fmt.Errorf("invalid flashcards JSON: %w", err)
+	}
+	var quiz []summary.QuizQuestion
+	if err := json.Unmarshal(aid.Quiz, &quiz); err != nil {
+		return nil, fmt.Errorf("invalid quiz JSON: %w", err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	for _, f := range flashcards {
+		w.Write([]string{f.Question, f.Answer, "flashcard"})
+	}
+	for _, q := range quiz {
+		if q.AnswerIndex < 0 || q.AnswerIndex >= len(q.Options) {
+			continue
+		}
+		front := []string{q.Question}
+		for i, opt := range q.Options {
+			front = append(front, fmt.Sprintf("%c) %s", 'A'+i, opt))
+		}
+		back := fmt.Sprintf("%c) %s", 'A'+q.AnswerIndex, q.Options[q.AnswerIndex])
+		if q.Explanation != "" {
+			back += "<br><br>" + q.Explanation
+		}
+		w.Write([]string{strings.Join(front, "<br>"), back, "quiz"})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,136 @@
+// summaries.go lists, fetches, and deletes summaries on their own, rather
+// than through the transcript they summarize. A summary belongs to whoever
+// owns its transcript.
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ListSummaries returns a paginated list of summaries, newest first.
+// GET /api/v1/summaries?model=openai/gpt-4o-mini&length=short&date_from=2025-01-01
+func (h *Handler) ListSummaries(c *gin.Context) {
+	var params models.SummaryListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_params",
+			Message: "Invalid query parameters: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	// Filter by the authenticated API key, or the one an admin key names
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+
+	summaries, total, err := h.DB.ListSummaries(c.Request.Context(), params)
+	if err != nil {
+		log.Printf("❌ Failed to list summaries: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list summaries",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Ensure we return an empty array, not null
+	if summaries == nil {
+		summaries = []models.Summary{}
+	}
+
+	page, perPage := params.Page, params.PerPage
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	c.JSON(http.StatusOK, models.PaginatedResponse[models.Summary]{
+		Data:       summaries,
+		Page:       page,
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+	})
+}
+
+// GetSummary returns a single summary. The caller must be able to see its
+// transcript.
+// GET /api/v1/summaries/:id
+func (h *Handler) GetSummary(c *gin.Context) {
+	s, t, ok := h.loadSummary(c)
+	if !ok {
+		return
+	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view summaries of your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, s)
+}
+
+// DeleteSummary removes a summary. Only the transcript's owner may delete
+// it.
+// DELETE /api/v1/summaries/:id
+func (h *Handler) DeleteSummary(c *gin.Context) {
+	s, t, ok := h.loadSummary(c)
+	if !ok {
+		return
+	}
+	if !h.canManageItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only delete summaries of your own transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	if err := h.DB.DeleteSummary(c.Request.Context(), s.ID); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to delete summary",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Summary deleted"})
+}
+
+// loadSummary fetches the summary named in the URL and its transcript,
+// writing a 404 if either is missing.
+func (h *Handler) loadSummary(c *gin.Context) (*models.Summary, *models.Transcript, bool) {
+	ctx := c.Request.Context()
+	s, err := h.DB.GetSummary(ctx, c.Param("id"))
+	var t *models.Transcript
+	if err == nil {
+		t, err = h.DB.GetTranscript(ctx, s.TranscriptID)
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Summary not found",
+			Code:    http.StatusNotFound,
+		})
+		return nil, nil, false
+	}
+	return s, t, true
+}
@@ -0,0 +1,52 @@
+// tiers.go lists the API key tiers, lets the admin move keys between them,
+// and gives callers' jobs their tier's queue priority. A key's new tier
+// applies from its next request.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ListTiers returns the tiers API keys can be on, smallest first.
+// GET /api/v1/tiers
+func (h *Handler) ListTiers(c *gin.Context) {
+	c.JSON(http.StatusOK, models.Tiers)
+}
+
+// jobPriority reports whether the caller's tier puts its jobs ahead of
+// other tiers'.
+func jobPriority(c *gin.Context) bool {
+	apiKey := middleware.GetAPIKey(c)
+	return apiKey != nil && models.LookupTier(apiKey.Tier).Priority
+}
+
+// AdminSetKeyTier moves an API key to another tier.
+// PUT /api/v1/admin/keys/:id/tier
+func (h *Handler) AdminSetKeyTier(c *gin.Context) {
+	var req models.SetKeyTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "tier must be free, pro, or enterprise",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	key, err := h.DB.SetAPIKeyTier(c.Request.Context(), c.Param("id"), req.Tier)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
@@ -0,0 +1,29 @@
+// timeouts.go validates per-request job timeout overrides.
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// minJobTimeout is the shortest timeout a request may ask for.
+const minJobTimeout = 30 * time.Second
+
+// jobTimeout validates a timeout_seconds override against the worker pool's
+// cap. Zero keeps the job type's configured timeout.
+func (h *Handler) jobTimeout(seconds int) (time.Duration, *models.ErrorResponse) {
+	if seconds == 0 {
+		return 0, nil
+	}
+	max := h.Worker.MaxJobTimeout()
+	if seconds < int(minJobTimeout/time.Second) || seconds > int(max/time.Second) {
+		return 0, &models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "timeout_seconds must be between " + intToStr(int(minJobTimeout/time.Second)) + " and " + intToStr(int(max/time.Second)),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
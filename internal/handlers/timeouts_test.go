@@ -0,0 +1,35 @@
+// timeouts_test.go contains tests for job timeout overrides.
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+// TestJobTimeout verifies overrides are bounded by the minimum and the
+// worker pool's cap, and zero keeps the default.
+func TestJobTimeout(t *testing.T) {
+	h := &Handler{Worker: worker.NewPool(1, 1, nil, nil, nil)}
+	h.Worker.SetJobTimeouts(nil, time.Hour)
+
+	tests := []struct {
+		seconds int
+		want    time.Duration
+		wantErr bool
+	}{
+		{0, 0, false},
+		{30, 30 * time.Second, false},
+		{3600, time.Hour, false},
+		{29, 0, true},
+		{3601, 0, true},
+		{-1, 0, true},
+	}
+	for _, tt := range tests {
+		got, apiErr := h.jobTimeout(tt.seconds)
+		if got != tt.want || (apiErr != nil) != tt.wantErr {
+			t.Errorf("jobTimeout(%d) = %v, %v; want %v, err %v", tt.seconds, got, apiErr, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"log"
 	"math"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/transcript"
@@ -43,6 +45,18 @@ func (h *Handler) CreateTranscript(c *gin.Context) {
 		return
 	}
 
+	t, apiErr, status := h.startTranscript(c, req)
+	if apiErr != nil {
+		c.JSON(status, *apiErr)
+		return
+	}
+	c.JSON(status, t)
+}
+
+// startTranscript creates a pending transcript record and queues its
+// extraction. A video that's already been extracted is returned as-is with
+// 200; new records come back with 202. Shared by REST and GraphQL.
+func (h *Handler) startTranscript(c *gin.Context, req models.CreateTranscriptRequest) (*models.Transcript, *models.ErrorResponse, int) {
 	// Parse the YouTube URL to extract the video ID
 	var youtubeURL, videoID string
 	var err error
@@ -54,20 +68,29 @@ func (h *Handler) CreateTranscript(c *gin.Context) {
 	}
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "invalid_url",
 			Message: err.Error(),
 			Code:    http.StatusBadRequest,
-		})
-		return
+		}, http.StatusBadRequest
 	}
 
-	// Check if we already have a transcript for this video
+	timeout, apiErr := h.jobTimeout(req.TimeoutSeconds)
+	if apiErr != nil {
+		return nil, apiErr, http.StatusBadRequest
+	}
+	autoSummary, apiErr := h.autoSummary(c, req.AutoSummarizeOptions)
+	if apiErr != nil {
+		return nil, apiErr, apiErr.Code
+	}
+
+	// Check if we already have a transcript for this video. A redacted copy
+	// only stands in for a redact request, and vice versa.
 	existing, _ := h.DB.GetTranscriptByYouTubeID(c.Request.Context(), videoID)
-	if existing != nil && existing.Status == models.StatusCompleted {
+	if existing != nil && existing.Status == models.StatusCompleted && (existing.Redaction != nil) == req.Redact {
 		// Return the existing transcript instead of re-extracting
-		c.JSON(http.StatusOK, existing)
-		return
+		h.chainSummary(c, existing, autoSummary)
+		return existing, nil, http.StatusOK
 	}
 
 	// Get the API key from context (set by auth middleware)
@@ -86,12 +109,11 @@ func (h *Handler) CreateTranscript(c *gin.Context) {
 
 	if err := h.DB.CreateTranscript(c.Request.Context(), t); err != nil {
 		log.Printf("❌ Failed to create transcript record: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "database_error",
 			Message: "Failed to create transcript record",
 			Code:    http.StatusInternalServerError,
-		})
-		return
+		}, http.StatusInternalServerError
 	}
 
 	// Submit extraction job to the worker pool
@@ -101,29 +123,45 @@ func (h *Handler) CreateTranscript(c *gin.Context) {
 	job := worker.Job{
 		ID:        t.ID,
 		Type:      worker.JobTranscriptExtraction,
+		Timeout:   timeout,
+		Priority:  jobPriority(c),
 		CreatedAt: time.Now(),
 	}
+	chained := h.chainSummary(c, t, autoSummary)
+	if req.Redact || chained != nil {
+		job.Payload, _ = json.Marshal(worker.TranscriptPayload{Redact: req.Redact, Summary: chained})
+	}
 
 	if err := h.Worker.Submit(job); err != nil {
 		if h.isOwnerRequest(c) {
 			ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
 			defer cancel()
 			if err := h.Worker.SubmitBlocking(ctx, job); err == nil {
-				c.JSON(http.StatusAccepted, t)
-				return
+				h.setQueueHeaders(c)
+				return t, nil, http.StatusAccepted
 			}
 		}
 		log.Printf("⚠️  Failed to queue extraction job: %v", err)
-		// The transcript record exists but extraction didn't start.
-		// The client can retry or the transcript will stay "pending".
+		// Fail the record rather than leave it pending forever; the client
+		// resubmits after Retry-After.
+		t.Status = models.StatusFailed
+		t.ErrorMessage = "Job queue is full, please try again later"
+		h.DB.UpdateTranscript(c.Request.Context(), t)
+		if chained != nil {
+			h.DB.SetSummaryStatus(c.Request.Context(), chained.SummaryID, models.StatusFailed, t.ErrorMessage)
+		}
+		return nil, h.queueFull(c), http.StatusServiceUnavailable
 	}
 
 	// Return 202 Accepted — the work is happening in the background
-	c.JSON(http.StatusAccepted, t)
+	h.setQueueHeaders(c)
+	return t, nil, http.StatusAccepted
 }
 
 // GetTranscript retrieves a single transcript by ID.
 // GET /api/v1/transcripts/:id
+//
+// Supports If-None-Match / If-Modified-Since; unchanged transcripts get a 304.
 func (h *Handler) GetTranscript(c *gin.Context) {
 	id := c.Param("id")
 
@@ -136,12 +174,24 @@ func (h *Handler) GetTranscript(c *gin.Context) {
 		})
 		return
 	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, t)
+	writeConditionalJSON(c, t, t.UpdatedAt)
 }
 
 // ListTranscripts returns a paginated list of transcripts.
 // GET /api/v1/transcripts?page=1&per_page=20&status=completed&search=golang
+//
+// Add format=csv to download every matching transcript as a spreadsheet.
+// Transcript text and segments are omitted unless include=text is passed,
+// which keeps list pages small; fetch /transcripts/:id for one full transcript.
 func (h *Handler) ListTranscripts(c *gin.Context) {
 	// Go Pattern: ShouldBindQuery reads query parameters into a struct
 	// using the `form` tags. Similar to Express's req.query but type-safe.
@@ -155,12 +205,24 @@ func (h *Handler) ListTranscripts(c *gin.Context) {
 		return
 	}
 
-	// Filter by the authenticated API key
-	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
-		params.APIKeyID = &apiKey.ID
+	// Filter by the authenticated API key, or the one an admin key names
+	apiKeyID, userID, ok := h.listScope(c)
+	if !ok {
+		return
+	}
+	params.APIKeyID = apiKeyID
+	params.UserID = userID
+	params.IncludeText = includeText(c)
+
+	if c.Query("format") == "csv" {
+		h.exportTranscriptsCSV(c, params)
+		return
 	}
 
 	transcripts, total, err := h.DB.ListTranscripts(c.Request.Context(), params)
+	if writeCursorError(c, err) {
+		return
+	}
 	if err != nil {
 		log.Printf("❌ Failed to list transcripts: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -180,20 +242,28 @@ func (h *Handler) ListTranscripts(c *gin.Context) {
 	if perPage < 1 {
 		perPage = 20
 	}
-	page := params.Page
-	if page < 1 {
-		page = 1
-	}
 
 	c.JSON(http.StatusOK, models.PaginatedResponse[models.Transcript]{
 		Data:       transcripts,
-		Page:       page,
+		Page:       responsePage(params.Page, params.Cursor),
 		PerPage:    perPage,
 		TotalItems: total,
 		TotalPages: int(math.Ceil(float64(total) / float64(perPage))),
+		NextCursor: database.NextTranscriptCursor(params, transcripts),
 	})
 }
 
+// includeText reports whether a list request opted in to full text bodies
+// with ?include=text (a comma-separated list, so include=text,foo works too).
+func includeText(c *gin.Context) bool {
+	for _, field := range strings.Split(c.Query("include"), ",") {
+		if strings.TrimSpace(field) == "text" {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateSummary generates an AI summary for a transcript.
 // POST /api/v1/summaries
 //
@@ -203,7 +273,8 @@ func (h *Handler) ListTranscripts(c *gin.Context) {
 //	  "transcript_id": "uuid-here",
 //	  "length": "medium",      // optional: short, medium, detailed
 //	  "style": "bullet",       // optional: bullet, narrative, academic
-//	  "model": "openai/gpt-4o" // optional: override default model
+//	  "model": "openai/gpt-4o", // optional: override default model
+//	  "fallback_models": ["openai/gpt-4o-mini"] // optional: override fallback chain
 //	}
 func (h *Handler) CreateSummary(c *gin.Context) {
 	var req models.CreateSummaryRequest
@@ -216,77 +287,211 @@ func (h *Handler) CreateSummary(c *gin.Context) {
 		return
 	}
 
+	accepted, apiErr, status := h.startSummary(c, req)
+	if apiErr != nil {
+		c.JSON(status, *apiErr)
+		return
+	}
+	c.JSON(status, accepted)
+}
+
+// startSummary validates a summary request and queues the generation job.
+// Shared by REST and GraphQL.
+func (h *Handler) startSummary(c *gin.Context, req models.CreateSummaryRequest) (gin.H, *models.ErrorResponse, int) {
+	timeout, apiErr := h.jobTimeout(req.TimeoutSeconds)
+	if apiErr != nil {
+		return nil, apiErr, http.StatusBadRequest
+	}
+	payload, apiErr := h.summaryPayload(c, req)
+	if apiErr != nil {
+		return nil, apiErr, apiErr.Code
+	}
+
 	// Verify the transcript exists and is completed
 	t, err := h.DB.GetTranscript(c.Request.Context(), req.TranscriptID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "not_found",
 			Message: "Transcript not found",
 			Code:    http.StatusNotFound,
-		})
-		return
+		}, http.StatusNotFound
+	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		return nil, &models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only summarize your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		}, http.StatusForbidden
 	}
 
 	if t.Status != models.StatusCompleted {
-		c.JSON(http.StatusConflict, models.ErrorResponse{
+		return nil, &models.ErrorResponse{
 			Error:   "transcript_not_ready",
 			Message: "Transcript is still being processed (status: " + string(t.Status) + ")",
 			Code:    http.StatusConflict,
-		})
-		return
+		}, http.StatusConflict
 	}
 
-	// Set defaults
-	if req.Length == "" {
-		req.Length = "medium"
+	// Create a pending summary so the caller can follow its progress
+	ctx := c.Request.Context()
+	if apiErr := h.pendingSummary(ctx, payload); apiErr != nil {
+		return nil, apiErr, apiErr.Code
 	}
-	if req.Style == "" {
-		req.Style = "bullet"
-	}
-
-	// Submit summary generation job
-	payload, _ := json.Marshal(worker.SummaryPayload{
-		TranscriptID: req.TranscriptID,
-		Model:        req.Model,
-		Length:        req.Length,
-		Style:        req.Style,
-	})
 
+	data, _ := json.Marshal(payload)
 	job := worker.Job{
 		ID:        req.TranscriptID, // Use transcript ID as job reference
 		Type:      worker.JobSummaryGeneration,
-		Payload:   payload,
+		Payload:   data,
+		Timeout:   timeout,
+		Priority:  jobPriority(c),
 		CreatedAt: time.Now(),
 	}
 
+	accepted := gin.H{
+		"message":       "Summary generation started",
+		"summary_id":    payload.SummaryID,
+		"status":        models.StatusPending,
+		"transcript_id": req.TranscriptID,
+		"length":        payload.Length,
+		"style":         payload.Style,
+	}
+
 	if err := h.Worker.Submit(job); err != nil {
 		if h.isOwnerRequest(c) {
-			ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+			waitCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
 			defer cancel()
-			if err := h.Worker.SubmitBlocking(ctx, job); err == nil {
-				c.JSON(http.StatusAccepted, gin.H{
-					"message":       "Summary generation started",
-					"transcript_id": req.TranscriptID,
-					"length":        req.Length,
-					"style":         req.Style,
-				})
-				return
+			if err := h.Worker.SubmitBlocking(waitCtx, job); err == nil {
+				h.setQueueHeaders(c)
+				return accepted, nil, http.StatusAccepted
 			}
 		}
-		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
-			Error:   "queue_full",
-			Message: "Job queue is full, try again later",
-			Code:    http.StatusServiceUnavailable,
-		})
-		return
+		// Nothing will process the placeholder; don't leave it pending
+		if err := h.DB.DeleteSummary(ctx, payload.SummaryID); err != nil {
+			log.Printf("⚠️  Failed to delete unqueued summary %s: %v", payload.SummaryID, err)
+		}
+		return nil, h.queueFull(c), http.StatusServiceUnavailable
 	}
 
-	c.JSON(http.StatusAccepted, gin.H{
-		"message":       "Summary generation started",
-		"transcript_id": req.TranscriptID,
-		"length":        req.Length,
-		"style":         req.Style,
-	})
+	h.setQueueHeaders(c)
+	return accepted, nil, http.StatusAccepted
+}
+
+// summaryPayload validates a summary request's options and fills in the
+// caller's defaults and spend limit, returning the generation job's
+// payload. Its cost is counted toward the caller's key.
+func (h *Handler) summaryPayload(c *gin.Context, req models.CreateSummaryRequest) (*worker.SummaryPayload, *models.ErrorResponse) {
+	if apiErr := h.modelError(req.Model, req.FallbackModels); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := languageError(req.Language); apiErr != nil {
+		return nil, apiErr
+	}
+
+	// Set defaults: the caller's saved AI settings, then the server's
+	defaults := h.aiDefaults(c)
+	fillDefault(&req.Model, defaults.Model)
+	fillDefault(&req.Language, defaults.Language)
+	fillDefault(&req.Length, defaults.Length)
+	fillDefault(&req.Style, defaults.Style)
+	fillDefault(&req.Length, "medium")
+	fillDefault(&req.Style, "bullet")
+	if apiErr := h.applySpendLimit(c, &req.Model, &req.FallbackModels); apiErr != nil {
+		return nil, apiErr
+	}
+
+	var spendKeyID string
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		spendKeyID = apiKey.ID
+	}
+	return &worker.SummaryPayload{
+		TranscriptID:   req.TranscriptID,
+		Model:          req.Model,
+		FallbackModels: req.FallbackModels,
+		Length:         req.Length,
+		Style:          req.Style,
+		Language:       req.Language,
+		ForceRefresh:   req.ForceRefresh,
+		APIKeyID:       spendKeyID,
+	}, nil
+}
+
+// pendingSummary creates the pending summary a generation job will fill in
+// and records its ID in the payload.
+func (h *Handler) pendingSummary(ctx context.Context, payload *worker.SummaryPayload) *models.ErrorResponse {
+	placeholder := &models.Summary{
+		TranscriptID: payload.TranscriptID,
+		Length:       payload.Length,
+		Style:        payload.Style,
+		KeyPoints:    []byte("[]"),
+		Status:       models.StatusPending,
+	}
+	if err := h.DB.CreateSummary(ctx, placeholder); err != nil {
+		log.Printf("❌ Failed to create summary for transcript %s: %v", payload.TranscriptID, err)
+		return &models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create summary",
+			Code:    http.StatusInternalServerError,
+		}
+	}
+	payload.SummaryID = placeholder.ID
+	return nil
+}
+
+// autoSummary validates a transcript request's auto_summarize options,
+// returning the summary job to chain onto extraction, or nil if none was
+// asked for. The caller's tier must include AI features.
+func (h *Handler) autoSummary(c *gin.Context, opts models.AutoSummarizeOptions) (*worker.SummaryPayload, *models.ErrorResponse) {
+	if !opts.AutoSummarize {
+		return nil, nil
+	}
+	if apiErr := middleware.CheckFeature(middleware.GetAPIKey(c), models.FeatureAI, h.OwnerAPIKeyID, h.OwnerAPIKeyPrefix); apiErr != nil {
+		return nil, apiErr
+	}
+	req := models.CreateSummaryRequest{}
+	if o := opts.SummaryOptions; o != nil {
+		req = models.CreateSummaryRequest{
+			Model:          o.Model,
+			FallbackModels: o.FallbackModels,
+			Length:         o.Length,
+			Style:          o.Style,
+			Language:       o.Language,
+		}
+	}
+	return h.summaryPayload(c, req)
+}
+
+// chainSummary sets up the automatic summary of transcript t. An
+// extracted transcript's summary is queued now; otherwise the summary job
+// is returned to ride in the extraction job's payload. Failures don't fail
+// the transcript: they're logged and the summary is marked failed.
+func (h *Handler) chainSummary(c *gin.Context, t *models.Transcript, s *worker.SummaryPayload) *worker.SummaryPayload {
+	if s == nil {
+		return nil
+	}
+	ctx := c.Request.Context()
+	chained := *s
+	chained.TranscriptID = t.ID
+	if apiErr := h.pendingSummary(ctx, &chained); apiErr != nil {
+		return nil
+	}
+	if t.Status != models.StatusCompleted {
+		return &chained
+	}
+
+	data, _ := json.Marshal(chained)
+	job := worker.Job{
+		ID:        t.ID,
+		Type:      worker.JobSummaryGeneration,
+		Payload:   data,
+		Priority:  jobPriority(c),
+		CreatedAt: time.Now(),
+	}
+	if err := h.Worker.Submit(job); err != nil {
+		log.Printf("⚠️  Failed to queue summary of transcript %s: %v", t.ID, err)
+		h.DB.SetSummaryStatus(ctx, chained.SummaryID, models.StatusFailed, "Job queue is full, please try again later")
+	}
+	return nil
 }
 
 // GetSummariesByTranscript returns all summaries for a transcript.
@@ -294,6 +499,24 @@ func (h *Handler) CreateSummary(c *gin.Context) {
 func (h *Handler) GetSummariesByTranscript(c *gin.Context) {
 	transcriptID := c.Param("id")
 
+	t, err := h.DB.GetTranscript(c.Request.Context(), transcriptID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Transcript not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if !h.canViewItem(c, t.APIKeyID, t.UserID) {
+		c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You can only view your own or your organization's transcripts",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
 	summaries, err := h.DB.GetSummariesByTranscript(c.Request.Context(), transcriptID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -0,0 +1,563 @@
+// uploads.go implements resumable uploads with the tus protocol (tus.io),
+// so a large audio file or PDF survives a dropped connection. Clients send
+// the file in PATCH chunks and resume from HEAD's Upload-Offset after an
+// interruption. Once every byte has arrived, the upload is processed like a
+// direct upload to /audio/transcribe or /pdf/extract.
+//
+// OPTIONS /api/v1/uploads     — Protocol discovery
+// POST    /api/v1/uploads     — Create an upload (Upload-Length, Upload-Metadata)
+// HEAD    /api/v1/uploads/:id — Current offset, to resume from
+// PATCH   /api/v1/uploads/:id — Append a chunk at Upload-Offset
+// DELETE  /api/v1/uploads/:id — Abandon an upload
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+)
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,termination"
+
+	// uploadTTL is how long an unfinished upload can be resumed.
+	uploadTTL = 24 * time.Hour
+
+	// tusContentType is the required Content-Type of PATCH requests.
+	tusContentType = "application/offset+octet-stream"
+)
+
+// uploadDir holds the partial files, named by upload ID. Resuming needs the
+// same disk, so multi-instance deployments should route by upload ID or
+// share this directory.
+var uploadDir = filepath.Join(os.TempDir(), "media-tools-uploads")
+
+// uploadLocks keeps two PATCHes from writing the same file at once.
+var uploadLocks sync.Map // upload ID → *sync.Mutex
+
+// UploadOptions describes the server's tus support.
+// OPTIONS /api/v1/uploads
+func (h *Handler) UploadOptions(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+	c.Header("Tus-Version", tusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	c.Header("Tus-Max-Size", strconv.Itoa(maxPDFSize))
+	c.Status(http.StatusNoContent)
+}
+
+// CreateUpload starts a resumable upload.
+// POST /api/v1/uploads
+//
+// Upload-Metadata must include filename; type (audio or pdf) is inferred
+// from the extension when omitted, and timeout_seconds, redact, language,
+// translate, keep_source, title, notes, and metadata work like the direct
+// upload form fields.
+func (h *Handler) CreateUpload(c *gin.Context) {
+	if !checkTusVersion(c) {
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Upload-Length header is required (deferred lengths aren't supported)",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	meta, err := parseUploadMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid Upload-Metadata: " + err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	u, apiErr := newUpload(meta, length)
+	if apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr)
+		return
+	}
+	if apiErr := middleware.CheckFeature(middleware.GetAPIKey(c), u.ItemType, h.OwnerAPIKeyID, h.OwnerAPIKeyPrefix); apiErr != nil {
+		c.JSON(apiErr.Code, *apiErr) // The audio and pdf features are named for the item types
+		return
+	}
+	if _, apiErr := h.jobTimeout(u.TimeoutSeconds); apiErr != nil {
+		c.JSON(http.StatusBadRequest, *apiErr)
+		return
+	}
+	if apiKey := middleware.GetAPIKey(c); apiKey != nil {
+		u.APIKeyID = &apiKey.ID
+	} else if user := middleware.GetUser(c); user != nil {
+		u.UserID = &user.ID
+	}
+	u.ExpiresAt = time.Now().Add(uploadTTL)
+
+	h.deleteExpiredUploads(c)
+
+	if err := os.MkdirAll(uploadDir, 0o755); err != nil {
+		log.Printf("❌ Failed to create upload dir: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to create upload",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if err := h.DB.CreateUpload(c.Request.Context(), u); err != nil {
+		log.Printf("❌ Failed to create upload: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create upload",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	f, err := os.Create(uploadPath(u.ID))
+	if err != nil {
+		log.Printf("❌ Failed to create upload file: %v", err)
+		h.DB.DeleteUpload(c.Request.Context(), u.ID)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to create upload",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	f.Close()
+
+	c.Header("Location", "/api/v1/uploads/"+u.ID)
+	c.Header("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusCreated)
+}
+
+// HeadUpload reports how much of an upload has arrived.
+// HEAD /api/v1/uploads/:id
+func (h *Handler) HeadUpload(c *gin.Context) {
+	if !checkTusVersion(c) {
+		return
+	}
+	u, ok := h.loadUpload(c)
+	if !ok {
+		return
+	}
+
+	setUploadHeaders(c, u)
+	c.Header("Upload-Length", strconv.FormatInt(u.Length, 10))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+}
+
+// PatchUpload appends a chunk to an upload. The chunk that completes the
+// upload also processes it; if processing fails (say, the queue is full),
+// an empty PATCH at the final offset retries it.
+// PATCH /api/v1/uploads/:id
+func (h *Handler) PatchUpload(c *gin.Context) {
+	if !checkTusVersion(c) {
+		return
+	}
+	if c.ContentType() != tusContentType {
+		c.JSON(http.StatusUnsupportedMediaType, models.ErrorResponse{
+			Error:   "invalid_content_type",
+			Message: "Content-Type must be " + tusContentType,
+			Code:    http.StatusUnsupportedMediaType,
+		})
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Upload-Offset header is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	u, ok := h.loadUpload(c)
+	if !ok {
+		return
+	}
+
+	mu, _ := uploadLocks.LoadOrStore(u.ID, &sync.Mutex{})
+	if !mu.(*sync.Mutex).TryLock() {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "upload_locked",
+			Message: "Another request is writing to this upload",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+	defer mu.(*sync.Mutex).Unlock()
+
+	// Re-read under the lock, in case a request that just finished moved it
+	if u, err = h.DB.GetUpload(c.Request.Context(), u.ID); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Upload not found or expired",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if offset != u.Offset {
+		setUploadHeaders(c, u)
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "offset_mismatch",
+			Message: fmt.Sprintf("Upload-Offset is %d but the upload is at %d; resume from HEAD's Upload-Offset", offset, u.Offset),
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	if u.Offset < u.Length {
+		n, err := appendChunk(uploadPath(u.ID), u.Offset, io.LimitReader(c.Request.Body, u.Length-u.Offset))
+		// Keep whatever arrived, even from an interrupted chunk — that's what
+		// lets the client resume instead of resending it. A dropped connection
+		// cancels the request context, so don't let that skip the save.
+		if n > 0 {
+			if err := h.DB.AdvanceUpload(context.WithoutCancel(c.Request.Context()), u.ID, u.Offset, u.Offset+n); err != nil {
+				log.Printf("❌ Failed to save offset for upload %s: %v", u.ID, err)
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+					Error:   "database_error",
+					Message: "Failed to save upload progress",
+					Code:    http.StatusInternalServerError,
+				})
+				return
+			}
+			u.Offset += n
+		}
+		if err != nil {
+			log.Printf("⚠️  Upload %s chunk interrupted at %d/%d: %v", u.ID, u.Offset, u.Length, err)
+			setUploadHeaders(c, u)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "upload_interrupted",
+				Message: "The chunk didn't arrive completely; resume from Upload-Offset",
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+	}
+
+	if u.Offset == u.Length && u.ItemID == nil {
+		if apiErr := h.finishUpload(c, u); apiErr != nil {
+			setUploadHeaders(c, u)
+			c.JSON(apiErr.Code, *apiErr)
+			return
+		}
+	}
+
+	setUploadHeaders(c, u)
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteUpload abandons an upload and discards its data.
+// DELETE /api/v1/uploads/:id
+func (h *Handler) DeleteUpload(c *gin.Context) {
+	if !checkTusVersion(c) {
+		return
+	}
+	u, ok := h.loadUpload(c)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteUpload(c.Request.Context(), u.ID); err != nil {
+		log.Printf("❌ Failed to delete upload %s: %v", u.ID, err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to delete upload",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	removeUploadFile(u.ID)
+	c.Header("Tus-Resumable", tusVersion)
+	c.Status(http.StatusNoContent)
+}
+
+// finishUpload processes a complete upload, recording the item it became.
+// The upload's file is kept until processing succeeds, so it can be retried.
+func (h *Handler) finishUpload(c *gin.Context, u *models.Upload) *models.ErrorResponse {
+	path := uploadPath(u.ID)
+
+	var itemID string
+	switch u.ItemType {
+	case "audio":
+		// Link rather than move, so the upload survives a full queue
+		storedFilename := uuid.New().String() + strings.ToLower(filepath.Ext(u.Filename))
+		tempFilePath := filepath.Join(os.TempDir(), storedFilename)
+		if err := linkOrCopy(path, tempFilePath); err != nil {
+			log.Printf("❌ Failed to stage upload %s: %v", u.ID, err)
+			return &models.ErrorResponse{
+				Error:   "server_error",
+				Message: "Failed to process uploaded file",
+				Code:    http.StatusInternalServerError,
+			}
+		}
+		opts := audio.TranscribeOptions{Language: u.Language, Translate: u.Translate, KeepSource: u.KeepSource}
+		details := audioDetails{Title: u.Title, Notes: u.Notes, Metadata: u.Metadata}
+		at, apiErr := h.queueAudio(c, tempFilePath, storedFilename, u.Filename, u.Length, time.Duration(u.TimeoutSeconds)*time.Second, u.Redact, opts, details, nil)
+		if apiErr != nil {
+			return apiErr
+		}
+		itemID = at.ID
+	case "pdf":
+		file, err := os.Open(path)
+		if err != nil {
+			log.Printf("❌ Failed to read upload %s: %v", u.ID, err)
+			return &models.ErrorResponse{
+				Error:   "read_error",
+				Message: "Failed to read uploaded file",
+				Code:    http.StatusInternalServerError,
+			}
+		}
+		pe, apiErr := h.extractPDF(c, file, u.Length, u.Filename, "", u.Redact, pdfservice.ExtractOptions{})
+		file.Close()
+		if apiErr != nil {
+			return apiErr
+		}
+		itemID = pe.ID
+	}
+
+	if err := h.DB.CompleteUpload(c.Request.Context(), u.ID, itemID); err != nil {
+		log.Printf("⚠️  Failed to record item for upload %s: %v", u.ID, err)
+	}
+	u.ItemID = &itemID
+	removeUploadFile(u.ID)
+	uploadLocks.Delete(u.ID)
+	return nil
+}
+
+// loadUpload fetches the upload named in the path, writing a 404 if it
+// doesn't exist, has expired, or belongs to someone else.
+func (h *Handler) loadUpload(c *gin.Context) (*models.Upload, bool) {
+	u, err := h.DB.GetUpload(c.Request.Context(), c.Param("id"))
+	if err != nil || !h.canManageItem(c, u.APIKeyID, u.UserID) {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Upload not found or expired",
+			Code:    http.StatusNotFound,
+		})
+		return nil, false
+	}
+	return u, true
+}
+
+// deleteExpiredUploads drops uploads nobody resumed in time, along with
+// their files. It runs on upload creation, so there's no separate sweeper.
+func (h *Handler) deleteExpiredUploads(c *gin.Context) {
+	ids, err := h.DB.DeleteExpiredUploads(c.Request.Context())
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return
+	}
+	for _, id := range ids {
+		removeUploadFile(id)
+	}
+}
+
+// newUpload validates an upload's metadata and size against the limits
+// for its type.
+func newUpload(meta map[string]string, length int64) (*models.Upload, *models.ErrorResponse) {
+	filename := meta["filename"]
+	if filename == "" {
+		filename = meta["name"] // Uppy's key
+	}
+	if filename == "" {
+		return nil, &models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Upload-Metadata must include filename",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	itemType := meta["type"]
+	if itemType == "" {
+		itemType = "audio"
+		if ext == ".pdf" {
+			itemType = "pdf"
+		}
+	}
+
+	var maxSize int64
+	switch {
+	case itemType == "audio" && allowedAudioTypes[ext]:
+		maxSize = maxAudioSize
+	case itemType == "pdf" && ext == ".pdf":
+		maxSize = maxPDFSize
+	default:
+		return nil, &models.ErrorResponse{
+			Error:   "invalid_file_type",
+			Message: fmt.Sprintf("Unsupported file '%s'. Audio: mp3, wav, m4a, ogg, flac, webm. PDF: pdf.", filename),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if length > maxSize {
+		return nil, &models.ErrorResponse{
+			Error:   "file_too_large",
+			Message: fmt.Sprintf("File size (%.1f MB) exceeds maximum (%d MB).", float64(length)/(1024*1024), maxSize>>20),
+			Code:    http.StatusRequestEntityTooLarge,
+		}
+	}
+
+	timeoutSeconds := 0
+	if v := meta["timeout_seconds"]; v != "" {
+		var err error
+		if timeoutSeconds, err = strconv.Atoi(v); err != nil {
+			timeoutSeconds = -1 // Rejected by jobTimeout
+		}
+	}
+
+	redacted, apiErr := parseRedactFlag(meta["redact"])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	opts, apiErr := uploadTranscribeOptions(meta)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	var details audioDetails
+	if itemType == "audio" {
+		if details, apiErr = uploadAudioDetails(meta); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	return &models.Upload{
+		ItemType:       itemType,
+		Filename:       filename,
+		Length:         length,
+		TimeoutSeconds: timeoutSeconds,
+		Redact:         redacted,
+		Language:       opts.Language,
+		Translate:      opts.Translate,
+		KeepSource:     opts.KeepSource,
+		Title:          details.Title,
+		Notes:          details.Notes,
+		Metadata:       details.Metadata,
+	}, nil
+}
+
+// parseUploadMetadata decodes a tus Upload-Metadata header: comma-separated
+// "key base64value" pairs, where the value may be omitted.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	meta := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("value for %q isn't base64", key)
+		}
+		meta[key] = string(value)
+	}
+	return meta, nil
+}
+
+// checkTusVersion rejects requests for a tus version this server doesn't
+// speak, and marks the response as tus.
+func checkTusVersion(c *gin.Context) bool {
+	c.Header("Tus-Resumable", tusVersion)
+	if v := c.GetHeader("Tus-Resumable"); v != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		c.JSON(http.StatusPreconditionFailed, models.ErrorResponse{
+			Error:   "unsupported_version",
+			Message: "Tus-Resumable must be " + tusVersion,
+			Code:    http.StatusPreconditionFailed,
+		})
+		return false
+	}
+	return true
+}
+
+// setUploadHeaders reports an upload's progress, and the item it became
+// once complete.
+func setUploadHeaders(c *gin.Context, u *models.Upload) {
+	c.Header("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	c.Header("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+	if u.ItemID != nil {
+		c.Header("X-Item-Type", u.ItemType)
+		c.Header("X-Item-ID", *u.ItemID)
+	}
+}
+
+// appendChunk writes r to path starting at offset, dropping any bytes past
+// offset left by an earlier interrupted write. It returns how many bytes
+// were written, even on error.
+func appendChunk(path string, offset int64, r io.Reader) (int64, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if err := f.Truncate(offset); err != nil {
+		return 0, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if syncErr := f.Sync(); err == nil {
+		err = syncErr
+	}
+	return n, err
+}
+
+// linkOrCopy makes dst a copy of src, hard-linking when both are on the
+// same filesystem.
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+func uploadPath(id string) string {
+	return filepath.Join(uploadDir, id)
+}
+
+func removeUploadFile(id string) {
+	if err := os.Remove(uploadPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("⚠️  Failed to remove upload file %s: %v", id, err)
+	}
+}
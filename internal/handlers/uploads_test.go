@@ -0,0 +1,96 @@
+// uploads_test.go contains tests for resumable (tus) uploads.
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseUploadMetadata verifies tus metadata pairs are decoded, values
+// may be omitted, and non-base64 values are rejected.
+func TestParseUploadMetadata(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"", map[string]string{}, false},
+		{"filename bWVldGluZy5tcDM=,type YXVkaW8=", map[string]string{"filename": "meeting.mp3", "type": "audio"}, false},
+		{"filename bWVldGluZy5tcDM=, is_confidential", map[string]string{"filename": "meeting.mp3", "is_confidential": ""}, false},
+		{"filename meeting.mp3", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseUploadMetadata(tt.header)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseUploadMetadata(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("parseUploadMetadata(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+		for k, v := range tt.want {
+			if got[k] != v {
+				t.Errorf("parseUploadMetadata(%q)[%q] = %q, want %q", tt.header, k, got[k], v)
+			}
+		}
+	}
+}
+
+// TestNewUpload verifies the item type is inferred from the extension and
+// each type's format and size limits apply.
+func TestNewUpload(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     map[string]string
+		length   int64
+		wantType string
+		wantCode int
+	}{
+		{"audio", map[string]string{"filename": "call.M4A"}, 1 << 20, "audio", 0},
+		{"pdf by extension", map[string]string{"name": "notes.pdf"}, 1 << 20, "pdf", 0},
+		{"no filename", map[string]string{"type": "audio"}, 1, "", http.StatusBadRequest},
+		{"type mismatch", map[string]string{"filename": "notes.pdf", "type": "audio"}, 1, "", http.StatusBadRequest},
+		{"unsupported", map[string]string{"filename": "video.mp4"}, 1, "", http.StatusBadRequest},
+		{"audio too large", map[string]string{"filename": "call.mp3"}, maxAudioSize + 1, "", http.StatusRequestEntityTooLarge},
+		{"pdf within its limit", map[string]string{"filename": "book.pdf"}, maxAudioSize + 1, "pdf", 0},
+		{"audio details", map[string]string{"filename": "call.mp3", "title": "Acme", "metadata": `{"deal":"42"}`}, 1, "audio", 0},
+		{"bad audio metadata", map[string]string{"filename": "call.mp3", "metadata": "{"}, 1, "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, apiErr := newUpload(tt.meta, tt.length)
+			if tt.wantCode != 0 {
+				if apiErr == nil || apiErr.Code != tt.wantCode {
+					t.Fatalf("error = %+v, want code %d", apiErr, tt.wantCode)
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatalf("unexpected error: %+v", apiErr)
+			}
+			if u.ItemType != tt.wantType || u.Length != tt.length {
+				t.Errorf("upload = %+v, want type %q", u, tt.wantType)
+			}
+		})
+	}
+}
+
+// TestAppendChunk verifies chunks land at the offset, and bytes past it
+// from an interrupted write are dropped.
+func TestAppendChunk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload")
+	if err := os.WriteFile(path, []byte("hello, partial"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := appendChunk(path, 7, strings.NewReader("world"))
+	if err != nil || n != 5 {
+		t.Fatalf("appendChunk = %d, %v; want 5, nil", n, err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != "hello, world" {
+		t.Errorf("file = %q, want %q", got, "hello, world")
+	}
+}
@@ -0,0 +1,233 @@
+// userkeys.go handles self-service API keys: logged-in users creating,
+// renaming, restricting, and revoking keys linked to their account.
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ListMyAPIKeys returns the API keys linked to the user (without the raw
+// key values), including revoked ones.
+// GET /api/v1/me/keys
+func (h *Handler) ListMyAPIKeys(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.DB.ListUserAPIKeys(c.Request.Context(), user.ID)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list API keys",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if keys == nil {
+		keys = []models.APIKey{}
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateMyAPIKey creates a member key with the default rate limit and links
+// it to the user. Users can hold up to the max_keys_per_user setting.
+// POST /api/v1/me/keys
+//
+// Response includes the raw key — it's only shown once.
+func (h *Handler) CreateMyAPIKey(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	var req models.UserAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "name is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	origins, ips, ok := bindKeyRestrictions(c, req.AllowedOrigins, req.AllowedIPs)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	current := h.Settings.Current()
+	if current.MaxKeysPerUser > 0 {
+		count, err := h.DB.CountUserAPIKeys(ctx, user.ID)
+		if err != nil {
+			log.Printf("❌ %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to check your API keys",
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		if count >= current.MaxKeysPerUser {
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Error:   "key_limit_reached",
+				Message: fmt.Sprintf("You can have at most %d active API keys; revoke one first", current.MaxKeysPerUser),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+	}
+
+	key, rawKey, err := newAPIKey(models.CreateAPIKeyRequest{Name: req.Name}, current.DefaultTier)
+	if err != nil {
+		log.Printf("❌ Failed to generate API key: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_error",
+			Message: "Failed to generate API key",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	key.AllowedOrigins, key.AllowedIPs = origins, ips
+
+	if err := h.DB.CreateAPIKey(ctx, key); err != nil {
+		log.Printf("❌ Failed to create API key: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create API key",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if err := h.DB.LinkAPIKeyToUser(ctx, key.ID, user.ID); err != nil {
+		// Don't leave a working key behind that the user can't see or revoke.
+		log.Printf("❌ Failed to link API key %s to user: %v", key.ID, err)
+		if err := h.DB.RevokeAPIKey(ctx, key.ID); err != nil {
+			log.Printf("❌ Failed to revoke unlinked API key %s: %v", key.ID, err)
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to create API key",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	key.UserID = &user.ID
+
+	c.JSON(http.StatusCreated, models.CreateAPIKeyResponse{
+		APIKey: *key,
+		RawKey: rawKey,
+	})
+}
+
+// RenameMyAPIKey renames one of the user's API keys.
+// PATCH /api/v1/me/keys/:id
+func (h *Handler) RenameMyAPIKey(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	var req models.UserAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "name is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	key, err := h.DB.RenameUserAPIKey(c.Request.Context(), user.ID, c.Param("id"), req.Name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// SetMyAPIKeyRestrictions replaces the origin and IP allowlists of one of
+// the user's API keys. Empty lists lift a restriction.
+// PUT /api/v1/me/keys/:id/restrictions
+func (h *Handler) SetMyAPIKeyRestrictions(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	var req models.KeyRestrictionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "allowed_origins and allowed_ips must be lists",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	origins, ips, ok := bindKeyRestrictions(c, req.AllowedOrigins, req.AllowedIPs)
+	if !ok {
+		return
+	}
+
+	key, err := h.DB.SetUserAPIKeyRestrictions(c.Request.Context(), user.ID, c.Param("id"), origins, ips)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// RevokeMyAPIKey deactivates one of the user's API keys.
+// DELETE /api/v1/me/keys/:id
+func (h *Handler) RevokeMyAPIKey(c *gin.Context) {
+	user, ok := requireUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.DB.RevokeUserAPIKey(c.Request.Context(), user.ID, c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
+
+// requireUser returns the logged-in user. Without one it writes a 401 and
+// returns false.
+func requireUser(c *gin.Context) (*models.User, bool) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return nil, false
+	}
+	return user, true
+}
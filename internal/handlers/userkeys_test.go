@@ -0,0 +1,108 @@
+// userkeys_test.go contains tests for self-service API key handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestUserKeyHandlersRequireUser verifies the /me/keys endpoints reject
+// requests without a logged-in user before touching the database.
+func TestUserKeyHandlersRequireUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+
+	handlers := map[string]gin.HandlerFunc{
+		"list":     h.ListMyAPIKeys,
+		"create":   h.CreateMyAPIKey,
+		"rename":   h.RenameMyAPIKey,
+		"restrict": h.SetMyAPIKeyRestrictions,
+		"revoke":   h.RevokeMyAPIKey,
+	}
+	for name, handle := range handlers {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/me/keys", strings.NewReader(`{"name":"k"}`))
+
+		handle(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, w.Code)
+		}
+	}
+}
+
+// TestNewAPIKey verifies new keys default to the member role and the
+// default tier, and store only the hash of the raw key.
+func TestNewAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      models.CreateAPIKeyRequest
+		wantRole string
+		wantTier string
+	}{
+		{"defaults", models.CreateAPIKeyRequest{Name: "k"}, models.KeyRoleMember, models.TierFree},
+		{"admin", models.CreateAPIKeyRequest{Name: "k", Role: models.KeyRoleAdmin}, models.KeyRoleAdmin, models.TierFree},
+		{"chosen tier", models.CreateAPIKeyRequest{Name: "k", Tier: models.TierPro}, models.KeyRoleMember, models.TierPro},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, rawKey, err := newAPIKey(tt.req, models.TierFree)
+			if err != nil {
+				t.Fatalf("newAPIKey() error = %v", err)
+			}
+			if key.Role != tt.wantRole {
+				t.Errorf("Role = %q, want %q", key.Role, tt.wantRole)
+			}
+			if key.Tier != tt.wantTier {
+				t.Errorf("Tier = %q, want %q", key.Tier, tt.wantTier)
+			}
+			if !strings.HasPrefix(rawKey, "mta_") || key.KeyHash == rawKey {
+				t.Errorf("raw key %q / hash %q: want an mta_ key stored only as a hash", rawKey, key.KeyHash)
+			}
+		})
+	}
+}
+
+// TestKeyRestrictionsValidated verifies bad allowlists are rejected with a
+// 400 before a key is created or updated.
+func TestKeyRestrictionsValidated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+	user := &models.User{ID: "u1"}
+
+	tests := []struct {
+		name   string
+		handle gin.HandlerFunc
+		body   string
+	}{
+		{"create, bad origin", h.CreateAPIKey, `{"name":"k","allowed_origins":["app.example.com"]}`},
+		{"create, bad CIDR", h.CreateAPIKey, `{"name":"k","allowed_ips":["10.0.0.0/99"]}`},
+		{"create mine, bad IP", h.CreateMyAPIKey, `{"name":"k","allowed_ips":["nope"]}`},
+		{"restrict, bad origin", h.SetAPIKeyRestrictions, `{"allowed_origins":["https://app.example.com/path"]}`},
+		{"restrict mine, bad CIDR", h.SetMyAPIKeyRestrictions, `{"allowed_ips":["1.2.3.4/40"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/keys", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("user", user)
+
+			tt.handle(c)
+
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_restrictions") {
+				t.Errorf("status = %d, body = %s; want 400 invalid_restrictions", w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// verification.go handles email verification and password resets. Both
+// email the user a one-time token; only its hash is stored.
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/mailer"
+)
+
+// Token lifetimes. Reset tokens are short-lived since they grant access.
+const (
+	verifyEmailTTL   = 48 * time.Hour
+	resetPasswordTTL = time.Hour
+)
+
+// mailTimeout bounds sending an email after the response has gone out.
+const mailTimeout = 30 * time.Second
+
+// SendVerificationEmail emails the user a new verification link, replacing
+// any earlier one.
+// POST /api/v1/auth/verify-email/send
+func (h *Handler) SendVerificationEmail(c *gin.Context) {
+	user := middleware.GetUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+	if user.EmailVerifiedAt != nil {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "already_verified",
+			Message: "Your email is already verified",
+			Code:    http.StatusConflict,
+		})
+		return
+	}
+
+	if err := h.sendUserToken(c.Request.Context(), user, models.TokenPurposeVerifyEmail); err != nil {
+		log.Printf("❌ Failed to send verification email: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "mail_error",
+			Message: "Failed to send verification email",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Verification email sent"})
+}
+
+// VerifyEmail confirms the user's email with the token from their
+// verification email.
+// POST /api/v1/auth/verify-email
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "token is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	userID, err := h.DB.ConsumeUserToken(ctx, models.TokenPurposeVerifyEmail, middleware.HashAPIKey(req.Token))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_token",
+			Message: "This verification link is invalid, expired, or already used",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := h.DB.MarkEmailVerified(ctx, userID); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to verify email",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+}
+
+// RequestPasswordReset emails a reset link if an account exists for the
+// email. It always responds the same way, and sends in the background, so
+// it can't be used to find out which emails have accounts.
+// POST /api/v1/auth/password-reset
+func (h *Handler) RequestPasswordReset(c *gin.Context) {
+	var req models.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "A valid email is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+		user, err := h.DB.GetUserByEmail(ctx, req.Email)
+		if err != nil {
+			return
+		}
+		if err := h.sendUserToken(ctx, user, models.TokenPurposeResetPassword); err != nil {
+			log.Printf("❌ Failed to send password reset email: %v", err)
+		}
+	}()
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If an account exists for that email, a reset link is on its way"})
+}
+
+// ResetPassword sets a new password with the token from a reset email.
+// Receiving the email proves the user owns it, so it's also marked verified.
+// All of the user's sessions are signed out.
+// POST /api/v1/auth/password-reset/confirm
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req models.ConfirmPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "token and password (min 8 chars) are required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("❌ Failed to hash password: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "server_error",
+			Message: "Failed to reset password",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	userID, err := h.DB.ConsumeUserToken(ctx, models.TokenPurposeResetPassword, middleware.HashAPIKey(req.Token))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_token",
+			Message: "This reset link is invalid, expired, or already used",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := h.DB.UpdateUserPassword(ctx, userID, string(hash)); err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to reset password",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if err := h.DB.MarkEmailVerified(ctx, userID); err != nil {
+		log.Printf("❌ %v", err)
+	}
+	// Whoever knew the old password may still be logged in somewhere.
+	if err := h.DB.RevokeUserSessions(ctx, userID); err != nil {
+		log.Printf("❌ %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password updated; log in with your new password"})
+}
+
+// sendVerificationEmailAsync sends a new user their verification link after
+// the response has gone out.
+func (h *Handler) sendVerificationEmailAsync(user *models.User) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+		if err := h.sendUserToken(ctx, user, models.TokenPurposeVerifyEmail); err != nil {
+			log.Printf("❌ Failed to send verification email: %v", err)
+		}
+	}()
+}
+
+// sendUserToken creates a one-time token for purpose and emails the user a
+// link containing it.
+func (h *Handler) sendUserToken(ctx context.Context, user *models.User, purpose string) error {
+	if h.Mailer == nil {
+		return fmt.Errorf("no mailer configured")
+	}
+
+	prefix, ttl := "mtv_", verifyEmailTTL
+	if purpose == models.TokenPurposeResetPassword {
+		prefix, ttl = "mtr_", resetPasswordTTL
+	}
+	token, err := generateUserToken(prefix)
+	if err != nil {
+		return err
+	}
+	if err := h.DB.CreateUserToken(ctx, user.ID, purpose, middleware.HashAPIKey(token), ttl); err != nil {
+		return err
+	}
+	return h.Mailer.Send(ctx, userTokenMessage(h.AppURL, user, purpose, token, ttl))
+}
+
+// userTokenMessage writes the email for a verification or reset token.
+// Links point at the frontend; the token is included for API clients too.
+func userTokenMessage(appURL string, user *models.User, purpose, token string, ttl time.Duration) mailer.Message {
+	greeting := "Hi,"
+	if user.Name != "" {
+		greeting = "Hi " + user.Name + ","
+	}
+	if purpose == models.TokenPurposeResetPassword {
+		return mailer.Message{
+			To:      user.Email,
+			Subject: "Reset your Media Tools password",
+			Body: fmt.Sprintf("%s\n\nReset your password here (the link expires in %s):\n\n%s/reset-password?token=%s\n\n"+
+				"Or POST the token to /api/v1/auth/password-reset/confirm: %s\n\n"+
+				"If you didn't ask for this, you can ignore this email.\n",
+				greeting, expiresIn(ttl), appURL, url.QueryEscape(token), token),
+		}
+	}
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your Media Tools email",
+		Body: fmt.Sprintf("%s\n\nConfirm your email here (the link expires in %s):\n\n%s/verify-email?token=%s\n\n"+
+			"Or POST the token to /api/v1/auth/verify-email: %s\n",
+			greeting, expiresIn(ttl), appURL, url.QueryEscape(token), token),
+	}
+}
+
+// expiresIn describes a token lifetime in whole hours.
+func expiresIn(ttl time.Duration) string {
+	if hours := int(ttl.Hours()); hours != 1 {
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return "1 hour"
+}
+
+// generateUserToken creates a one-time email token.
+// Format: prefix + 32 random hex characters.
+func generateUserToken(prefix string) (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,91 @@
+// verification_test.go contains tests for email verification and password
+// reset handling.
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestVerificationHandlersRejectBadInput verifies requests are validated
+// before the database or mailer is touched.
+func TestVerificationHandlersRejectBadInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &Handler{}
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		handle gin.HandlerFunc
+		body   string
+		user   *models.User
+		want   int
+	}{
+		{"send, not logged in", h.SendVerificationEmail, "", nil, http.StatusUnauthorized},
+		{"send, already verified", h.SendVerificationEmail, "", &models.User{ID: "u1", EmailVerifiedAt: &now}, http.StatusConflict},
+		{"verify, no token", h.VerifyEmail, `{}`, nil, http.StatusBadRequest},
+		{"reset request, bad email", h.RequestPasswordReset, `{"email":"nope"}`, nil, http.StatusBadRequest},
+		{"reset, short password", h.ResetPassword, `{"token":"mtr_x","password":"short"}`, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.user != nil {
+				c.Set("user", tt.user)
+			}
+
+			tt.handle(c)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// TestUserTokenMessage verifies emails link to the right frontend page with
+// the token and state when the link expires.
+func TestUserTokenMessage(t *testing.T) {
+	user := &models.User{Email: "a@example.com", Name: "Ann"}
+
+	tests := []struct {
+		purpose     string
+		ttl         time.Duration
+		wantSubject string
+		wantLink    string
+		wantExpiry  string
+	}{
+		{models.TokenPurposeVerifyEmail, verifyEmailTTL, "Verify your Media Tools email", "https://app.example.com/verify-email?token=mtv_abc", "48 hours"},
+		{models.TokenPurposeResetPassword, resetPasswordTTL, "Reset your Media Tools password", "https://app.example.com/reset-password?token=mtr_abc", "1 hour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purpose, func(t *testing.T) {
+			token := "mtv_abc"
+			if tt.purpose == models.TokenPurposeResetPassword {
+				token = "mtr_abc"
+			}
+			msg := userTokenMessage("https://app.example.com", user, tt.purpose, token, tt.ttl)
+
+			if msg.To != user.Email || msg.Subject != tt.wantSubject {
+				t.Errorf("To/Subject = %q / %q", msg.To, msg.Subject)
+			}
+			for _, want := range []string{"Hi Ann,", tt.wantLink, tt.wantExpiry} {
+				if !strings.Contains(msg.Body, want) {
+					t.Errorf("body missing %q:\n%s", want, msg.Body)
+				}
+			}
+		})
+	}
+}
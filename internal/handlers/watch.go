@@ -0,0 +1,48 @@
+// watch.go shows admins what the watch folder has picked up.
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// AdminListWatchedFiles returns the files the watch folder picked up and
+// the items they became, newest first.
+// GET /api/v1/admin/watched-files?limit=50
+func (h *Handler) AdminListWatchedFiles(c *gin.Context) {
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 200 {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_params",
+				Message: "limit must be between 1 and 200",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		limit = n
+	}
+
+	files, err := h.DB.ListWatchedFiles(c.Request.Context(), limit)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list watched files",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if files == nil {
+		files = []models.WatchedFile{}
+	}
+
+	c.JSON(http.StatusOK, files)
+}
@@ -2,13 +2,17 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
 	webhookservice "github.com/Shimizu-Technology/media-tools-api/internal/services/webhook"
 )
 
@@ -47,6 +51,18 @@ func (h *Handler) CreateWebhook(c *gin.Context) {
 		}
 	}
 
+	if req.PayloadMode == "" {
+		req.PayloadMode = models.WebhookPayloadFull
+	}
+	if err := webhookservice.ValidatePayloadSettings(req.PayloadMode, req.PayloadFields, req.MaxPayloadBytes); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_payload_settings",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
 	// Generate HMAC secret
 	secret, err := webhookservice.GenerateSecret()
 	if err != nil {
@@ -60,11 +76,18 @@ func (h *Handler) CreateWebhook(c *gin.Context) {
 	}
 
 	wh := &models.Webhook{
-		APIKeyID: apiKey.ID,
-		URL:      req.URL,
-		Events:   req.Events,
-		Secret:   secret,
-		Active:   true,
+		APIKeyID:        apiKey.ID,
+		URL:             req.URL,
+		Events:          req.Events,
+		Secret:          secret,
+		Active:          true,
+		PayloadMode:     req.PayloadMode,
+		PayloadFields:   req.PayloadFields,
+		MaxPayloadBytes: req.MaxPayloadBytes,
+	}
+	creds := models.WebhookCredentials{Headers: req.Headers, BasicAuth: req.BasicAuth}
+	if !h.setWebhookCredentials(c, wh, creds) {
+		return
 	}
 
 	if err := h.DB.CreateWebhook(c.Request.Context(), wh); err != nil {
@@ -79,12 +102,17 @@ func (h *Handler) CreateWebhook(c *gin.Context) {
 
 	// Return webhook with secret (only shown once, like API keys)
 	c.JSON(http.StatusCreated, gin.H{
-		"id":         wh.ID,
-		"url":        wh.URL,
-		"events":     wh.Events,
-		"secret":     secret, // Shown once for verification setup
-		"active":     wh.Active,
-		"created_at": wh.CreatedAt,
+		"id":                wh.ID,
+		"url":               wh.URL,
+		"events":            wh.Events,
+		"secret":            secret, // Shown once for verification setup
+		"active":            wh.Active,
+		"payload_mode":      wh.PayloadMode,
+		"payload_fields":    payloadFields(wh),
+		"max_payload_bytes": wh.MaxPayloadBytes,
+		"header_names":      wh.HeaderNames,
+		"has_basic_auth":    wh.HasBasicAuth,
+		"created_at":        wh.CreatedAt,
 	})
 }
 
@@ -118,22 +146,81 @@ func (h *Handler) ListWebhooks(c *gin.Context) {
 	c.JSON(http.StatusOK, webhooks)
 }
 
-// UpdateWebhook toggles a webhook's active state.
+// UpdateWebhook changes a webhook's active state, payload settings, or
+// credentials.
 // PATCH /api/v1/webhooks/:id
 func (h *Handler) UpdateWebhook(c *gin.Context) {
 	id := c.Param("id")
 
 	var req models.UpdateWebhookRequest
-	if err := c.ShouldBindJSON(&req); err != nil || req.Active == nil {
+	if err := c.ShouldBindJSON(&req); err != nil ||
+		(req.Active == nil && req.PayloadMode == nil && req.PayloadFields == nil && req.MaxPayloadBytes == nil &&
+			req.Headers == nil && req.BasicAuth == nil) {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "invalid_request",
-			Message: "active field is required (true/false)",
+			Message: "Set at least one of active, payload_mode, payload_fields, max_payload_bytes, headers, or basic_auth",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	wh, err := h.DB.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Webhook not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	if req.Active != nil {
+		wh.Active = *req.Active
+	}
+	if req.PayloadMode != nil {
+		wh.PayloadMode = *req.PayloadMode
+	}
+	if req.PayloadFields != nil {
+		wh.PayloadFields = *req.PayloadFields
+	}
+	if req.MaxPayloadBytes != nil {
+		wh.MaxPayloadBytes = *req.MaxPayloadBytes
+	}
+	if err := webhookservice.ValidatePayloadSettings(wh.PayloadMode, wh.PayloadFields, wh.MaxPayloadBytes); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_payload_settings",
+			Message: err.Error(),
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
 
-	if err := h.DB.UpdateWebhookActive(c.Request.Context(), id, *req.Active); err != nil {
+	if req.Headers != nil || req.BasicAuth != nil {
+		creds, err := h.WebhookService.Credentials(*wh)
+		if err != nil {
+			log.Printf("❌ Failed to open webhook credentials: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "credentials_error",
+				Message: "Failed to read the webhook's current credentials",
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		if req.Headers != nil {
+			creds.Headers = *req.Headers
+		}
+		if req.BasicAuth != nil {
+			creds.BasicAuth = req.BasicAuth
+			if req.BasicAuth.Username == "" {
+				creds.BasicAuth = nil
+			}
+		}
+		if !h.setWebhookCredentials(c, wh, creds) {
+			return
+		}
+	}
+
+	if err := h.DB.UpdateWebhook(c.Request.Context(), wh); err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Error:   "not_found",
 			Message: "Webhook not found",
@@ -142,7 +229,148 @@ func (h *Handler) UpdateWebhook(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Webhook updated", "active": *req.Active})
+	c.JSON(http.StatusOK, gin.H{
+		"message":           "Webhook updated",
+		"active":            wh.Active,
+		"payload_mode":      wh.PayloadMode,
+		"payload_fields":    payloadFields(wh),
+		"max_payload_bytes": wh.MaxPayloadBytes,
+		"header_names":      headerNames(wh),
+		"has_basic_auth":    wh.HasBasicAuth,
+	})
+}
+
+// RotateWebhookSecret issues a new HMAC secret for a webhook. For the grace
+// window deliveries carry a signature with the old secret too, in
+// X-Webhook-Signature-Previous, so receivers can switch over without
+// rejecting events.
+// POST /api/v1/webhooks/:id/rotate-secret
+func (h *Handler) RotateWebhookSecret(c *gin.Context) {
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Webhook management requires API key authentication",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	// The body is optional
+	var req models.RotateWebhookSecretRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+	graceHours := webhookservice.DefaultSecretGraceHours
+	if req.GraceHours != nil {
+		graceHours = *req.GraceHours
+	}
+	if graceHours < 0 || graceHours > webhookservice.MaxSecretGraceHours {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("grace_hours must be between 0 and %d", webhookservice.MaxSecretGraceHours),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	wh, err := h.DB.GetWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil || wh.APIKeyID != apiKey.ID {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Webhook not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	secret, err := webhookservice.GenerateSecret()
+	if err != nil {
+		log.Printf("❌ Failed to generate webhook secret: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "generation_error",
+			Message: "Failed to generate webhook secret",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	var graceUntil *time.Time
+	if graceHours > 0 {
+		t := time.Now().Add(time.Duration(graceHours) * time.Hour)
+		graceUntil = &t
+	}
+
+	if err := h.DB.RotateWebhookSecret(c.Request.Context(), wh, secret, graceUntil); err != nil {
+		log.Printf("❌ Failed to rotate webhook secret: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to rotate webhook secret",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":                         wh.ID,
+		"secret":                     secret, // Shown once, like the original
+		"previous_secret_expires_at": graceUntil,
+	})
+}
+
+// setWebhookCredentials validates creds and seals them into wh, writing an
+// error response and returning false if that fails.
+func (h *Handler) setWebhookCredentials(c *gin.Context, wh *models.Webhook, creds models.WebhookCredentials) bool {
+	if err := webhookservice.NormalizeCredentials(&creds); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "invalid_credentials",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return false
+	}
+	if err := h.WebhookService.SetCredentials(wh, creds); err != nil {
+		if errors.Is(err, secretbox.ErrNoKey) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "encryption_not_configured",
+				Message: "Webhook headers and basic auth need the server's ENCRYPTION_KEY to be set",
+				Code:    http.StatusBadRequest,
+			})
+			return false
+		}
+		log.Printf("❌ Failed to seal webhook credentials: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "credentials_error",
+			Message: "Failed to store webhook credentials",
+			Code:    http.StatusInternalServerError,
+		})
+		return false
+	}
+	return true
+}
+
+// headerNames returns a webhook's custom header names, as an empty list
+// rather than null when there are none.
+func headerNames(wh *models.Webhook) []string {
+	if wh.HeaderNames == nil {
+		return []string{}
+	}
+	return wh.HeaderNames
+}
+
+// payloadFields returns a webhook's payload fields, as an empty list rather
+// than null when there are none.
+func payloadFields(wh *models.Webhook) []string {
+	if wh.PayloadFields == nil {
+		return []string{}
+	}
+	return wh.PayloadFields
 }
 
 // DeleteWebhook removes a webhook.
@@ -191,3 +419,53 @@ func (h *Handler) ListWebhookDeliveries(c *gin.Context) {
 
 	c.JSON(http.StatusOK, deliveries)
 }
+
+// GetWebhookDelivery returns one of a webhook's deliveries with every
+// attempt made at it, including the start of each response body.
+// GET /api/v1/webhooks/:id/deliveries/:deliveryId
+func (h *Handler) GetWebhookDelivery(c *gin.Context) {
+	apiKey := middleware.GetAPIKey(c)
+	if apiKey == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Webhook management requires API key authentication",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	wh, err := h.DB.GetWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil || wh.APIKeyID != apiKey.ID {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Webhook not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	delivery, err := h.DB.GetWebhookDelivery(c.Request.Context(), wh.ID, c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "not_found",
+			Message: "Delivery not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	attempts, err := h.DB.ListWebhookDeliveryAttempts(c.Request.Context(), delivery.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "database_error",
+			Message: "Failed to list delivery attempts",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if attempts == nil {
+		attempts = []models.WebhookDeliveryAttempt{}
+	}
+
+	c.JSON(http.StatusOK, models.WebhookDeliveryDetail{WebhookDelivery: *delivery, History: attempts})
+}
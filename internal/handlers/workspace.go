@@ -27,18 +27,24 @@ func (h *Handler) GetWorkspace(c *gin.Context) {
 	transcripts, err := h.DB.GetWorkspaceTranscripts(c.Request.Context(), user.ID)
 	if err != nil {
 		log.Printf("Failed to get workspace transcripts: %v", err)
+	}
+	if transcripts == nil {
 		transcripts = []models.Transcript{}
 	}
 
 	audio, err := h.DB.GetWorkspaceAudio(c.Request.Context(), user.ID)
 	if err != nil {
 		log.Printf("Failed to get workspace audio: %v", err)
+	}
+	if audio == nil {
 		audio = []models.AudioTranscription{}
 	}
 
 	pdfs, err := h.DB.GetWorkspacePDFs(c.Request.Context(), user.ID)
 	if err != nil {
 		log.Printf("Failed to get workspace PDFs: %v", err)
+	}
+	if pdfs == nil {
 		pdfs = []models.PDFExtraction{}
 	}
 
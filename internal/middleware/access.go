@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// CanViewItem reports whether a caller may read an item with these owner
+// IDs. API keys see their own items, unowned ones, and those created by
+// other keys in the same organization; admin keys not limited to an
+// organization, like the owner key, see every item. Logged-in users see
+// their own items, those created by keys linked to them, and unowned ones.
+// Callers with neither see nothing.
+func CanViewItem(ctx context.Context, db *database.DB, apiKey *models.APIKey, user *models.User, ownerKeyID, ownerKeyPrefix string, itemAPIKeyID, itemUserID *string) bool {
+	switch {
+	case apiKey != nil:
+		if itemAPIKeyID == nil || *itemAPIKeyID == apiKey.ID {
+			return true
+		}
+		if KeyRole(apiKey, ownerKeyID, ownerKeyPrefix) == models.KeyRoleAdmin &&
+			(IsOwnerAPIKey(apiKey, ownerKeyID, ownerKeyPrefix) || apiKey.OrgID == nil) {
+			return true
+		}
+		if apiKey.OrgID == nil {
+			return false
+		}
+		orgID, err := db.GetAPIKeyOrgID(ctx, *itemAPIKeyID)
+		return err == nil && orgID != nil && *orgID == *apiKey.OrgID
+
+	case user != nil:
+		if itemUserID != nil {
+			return *itemUserID == user.ID
+		}
+		if itemAPIKeyID == nil {
+			return true
+		}
+		keyUserID, err := db.GetAPIKeyUserID(ctx, *itemAPIKeyID)
+		return err == nil && keyUserID != nil && *keyUserID == user.ID
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+//go:build cgo
+
+// access_test.go contains tests for item visibility, run against an
+// in-memory SQLite database.
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestCanViewItem verifies organization keys see each other's items, and
+// users see the items of keys linked to them but not other keys' items.
+func TestCanViewItem(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.New("sqlite::memory:")
+	if err != nil {
+		t.Fatalf("database.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.RunMigrations("../../migrations"); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+
+	ada := &models.User{Email: "ada@example.com", PasswordHash: "x", Name: "Ada"}
+	if err := db.CreateUser(ctx, ada); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	org := &models.Organization{Name: "Acme"}
+	if err := db.CreateOrganization(ctx, org, ada.ID); err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	newKey := func(prefix string, orgID *string) *models.APIKey {
+		key := &models.APIKey{KeyHash: "hash-" + prefix, KeyPrefix: prefix, Name: prefix, Active: true,
+			Tier: models.TierFree, Role: models.KeyRoleMember, OrgID: orgID}
+		if err := db.CreateAPIKey(ctx, key); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+		return key
+	}
+	orgKey1, orgKey2 := newKey("mta_org1", &org.ID), newKey("mta_org2", &org.ID)
+	adaKey, otherKey := newKey("mta_ada", nil), newKey("mta_other", nil)
+	if err := db.LinkAPIKeyToUser(ctx, adaKey.ID, ada.ID); err != nil {
+		t.Fatalf("LinkAPIKeyToUser: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		apiKey *models.APIKey
+		user   *models.User
+		owner  *string
+		want   bool
+	}{
+		{"organization key", orgKey1, nil, &orgKey2.ID, true},
+		{"key outside the organization", orgKey1, nil, &otherKey.ID, false},
+		{"user, linked key's item", nil, ada, &adaKey.ID, true},
+		{"user, other key's item", nil, ada, &otherKey.ID, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanViewItem(ctx, db, tt.apiKey, tt.user, "", "", tt.owner, nil); got != tt.want {
+				t.Errorf("CanViewItem() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// AdminAuth returns middleware that requires the X-Admin-Key header to match
+// adminKey. Admin routes expose data across every API key, so unlike key
+// creation they stay closed when no admin key is configured.
+func AdminAuth(adminKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if adminKey == "" {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "admin_disabled",
+				Message: "Admin endpoints are disabled; set ADMIN_API_KEY to enable them",
+				Code:    http.StatusForbidden,
+			})
+			c.Abort()
+			return
+		}
+
+		provided := c.GetHeader("X-Admin-Key")
+		if provided == "" {
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "X-Admin-Key header is required",
+				Code:    http.StatusUnauthorized,
+			})
+			c.Abort()
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(adminKey)) != 1 {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "forbidden",
+				Message: "Invalid admin key",
+				Code:    http.StatusForbidden,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
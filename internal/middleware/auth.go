@@ -32,6 +32,7 @@ const apiKeyContextKey contextKey = "api_key"
 // 3. Look up the hash in the database
 // 4. If valid, store the key info in the request context
 // 5. If invalid, return 401 Unauthorized
+// 6. If the key is restricted to other origins or IPs, return 403 Forbidden
 func APIKeyAuth(db *database.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Read the API key from the header
@@ -58,6 +59,9 @@ func APIKeyAuth(db *database.DB) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if !enforceKeyRestrictions(c, apiKey) {
+			return
+		}
 
 		// Store the API key info in Gin's context for later use
 		// Go Pattern: Gin uses its own context (different from context.Context).
@@ -0,0 +1,39 @@
+// billing.go refuses API keys suspended because their Stripe subscription
+// is unpaid. Suspended keys can still open the billing portal to pay.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// RequireBillingStanding returns middleware that rejects suspended API
+// keys with 402 Payment Required. It runs after DualAuth and before
+// Impersonate, so it checks the caller's own key; JWT requests pass
+// through.
+func RequireBillingStanding() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiErr := CheckBillingStanding(GetAPIKey(c)); apiErr != nil {
+			c.JSON(apiErr.Code, *apiErr)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// CheckBillingStanding returns a 402 error if apiKey is suspended. It's
+// RequireBillingStanding without the Gin plumbing, for GraphQL and gRPC.
+func CheckBillingStanding(apiKey *models.APIKey) *models.ErrorResponse {
+	if apiKey == nil || !apiKey.BillingSuspended {
+		return nil
+	}
+	return &models.ErrorResponse{
+		Error:   "billing_suspended",
+		Message: "This API key is suspended because its subscription is unpaid; update your payment method at GET /api/v1/billing/portal",
+		Code:    http.StatusPaymentRequired,
+	}
+}
@@ -0,0 +1,273 @@
+// compress.go compresses responses for clients that accept it.
+//
+// Transcripts, search results, and exports are hundreds of KB of plain
+// text that shrink several times over, which matters most to mobile
+// clients. Only text-like responses of at least a minimum size are
+// compressed: media and PDFs are compressed already, and tiny bodies grow.
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Compress returns middleware that gzip- or deflate-compresses responses
+// of at least minSize bytes with a text-like content type, honoring the
+// request's Accept-Encoding.
+//
+// The first minSize bytes are held back to decide, then the response
+// streams. Flushing decides early, so server-sent events go out as
+// they're written (text/event-stream is never compressed).
+func Compress(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       negotiateEncoding(c.GetHeader("Accept-Encoding")),
+			minSize:        minSize,
+			status:         http.StatusOK,
+		}
+		c.Writer = w
+		defer func() {
+			c.Writer = w.ResponseWriter
+			if p := recover(); p != nil {
+				panic(p) // Drop the held-back body; Recovery answers instead
+			}
+			w.close()
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header,
+// by q-value with gzip preferred on ties. It returns "" when the client
+// accepts neither.
+func negotiateEncoding(header string) string {
+	q := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		weight := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		q[coding] = weight
+	}
+
+	weight := func(coding string) float64 {
+		if w, ok := q[coding]; ok {
+			return w
+		}
+		return q["*"] // 0 when absent
+	}
+	gz, df := weight("gzip"), weight("deflate")
+	switch {
+	case gz > 0 && gz >= df:
+		return "gzip"
+	case df > 0:
+		return "deflate"
+	}
+	return ""
+}
+
+// compressibleTypes are the non-text media types worth compressing.
+var compressibleTypes = map[string]bool{
+	"application/json":                  true,
+	"application/graphql-response+json": true,
+	"application/javascript":            true,
+	"application/x-ndjson":              true,
+	"application/xml":                   true,
+	"application/yaml":                  true,
+	"application/x-yaml":                true,
+	"image/svg+xml":                     true,
+}
+
+// compressibleType reports whether a Content-Type is worth compressing.
+func compressibleType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	switch {
+	case mediaType == "text/event-stream":
+		return false // Compressing would hold events back in the encoder
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	return compressibleTypes[mediaType]
+}
+
+var (
+	gzipWriters  = sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }}
+	flateWriters = sync.Pool{New: func() any {
+		w, _ := flate.NewWriter(io.Discard, flate.DefaultCompression)
+		return w
+	}}
+)
+
+// encoder is the part of gzip.Writer and flate.Writer the middleware uses.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// compressWriter holds back the start of a response until it knows whether
+// to compress it, then writes through an encoder or straight out.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string // "gzip", "deflate", or "" when the client accepts neither
+	minSize  int
+
+	status  int    // Held back until decided
+	written bool   // Whether the handler has started the response
+	buf     []byte // Held-back body, until decided
+	decided bool
+	enc     encoder // Set when compressing
+}
+
+// decide writes the held-back status, headers, and body, compressing if
+// the response qualifies.
+func (w *compressWriter) decide() {
+	w.decided = true
+	h := w.Header()
+	if w.compressible() {
+		h.Add("Vary", "Accept-Encoding")
+		if w.encoding != "" && len(w.buf) >= w.minSize {
+			h.Set("Content-Encoding", w.encoding)
+			h.Del("Content-Length")
+			// The compressed body isn't byte-identical, so its validator is weak
+			if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+				h.Set("ETag", "W/"+etag)
+			}
+			if w.encoding == "gzip" {
+				w.enc = gzipWriters.Get().(*gzip.Writer)
+			} else {
+				w.enc = flateWriters.Get().(*flate.Writer)
+			}
+			w.enc.Reset(w.ResponseWriter)
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) > 0 {
+		w.write(buf)
+	}
+}
+
+// compressible reports whether the response is one to compress, size aside.
+func (w *compressWriter) compressible() bool {
+	switch w.status {
+	case http.StatusNoContent, http.StatusPartialContent, http.StatusNotModified:
+		return false
+	}
+	h := w.Header()
+	if w.status < 200 || h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	return compressibleType(h.Get("Content-Type"))
+}
+
+// write sends body bytes once the response is decided.
+func (w *compressWriter) write(p []byte) (int, error) {
+	if w.enc != nil {
+		return w.enc.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// close finishes the response after the handler returns.
+func (w *compressWriter) close() {
+	if !w.decided {
+		w.decide()
+	}
+	if w.enc == nil {
+		return
+	}
+	_ = w.enc.Close()
+	switch enc := w.enc.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(enc)
+	case *flate.Writer:
+		flateWriters.Put(enc)
+	}
+	w.enc = nil
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code) // Too late; gin logs the warning
+		return
+	}
+	w.status = code
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		return w.write(p)
+	}
+	w.written = true
+	if !w.compressible() {
+		w.decide() // Nothing to hold back for
+		return w.write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		w.decide()
+	}
+	return len(p), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Status() int {
+	if w.decided {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *compressWriter) Written() bool {
+	return w.decided || w.written
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide()
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection underneath.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,143 @@
+// compress_test.go contains tests for response compression.
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestNegotiateEncoding verifies Accept-Encoding parsing, q-values, and the
+// preference for gzip.
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip, deflate, br", "gzip"},
+		{"deflate, gzip", "gzip"},
+		{"GZIP", "gzip"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"gzip; q=0, deflate; q=0", ""},
+		{"*", "gzip"},
+		{"*;q=0.5, gzip;q=0", "deflate"},
+		{"gzip;q=bogus", ""},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+// TestCompress verifies which responses are compressed, and that
+// compressed bodies decode to the original.
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	large := strings.Repeat("the gopher floats through space. ", 100)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		handler        gin.HandlerFunc
+		wantEncoding   string
+		wantVary       bool
+	}{
+		{"large json", "gzip", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"text": large}) }, "gzip", true},
+		{"deflate", "deflate", func(c *gin.Context) { c.String(http.StatusOK, large) }, "deflate", true},
+		{"not accepted", "", func(c *gin.Context) { c.String(http.StatusOK, large) }, "", true},
+		{"small", "gzip", func(c *gin.Context) { c.String(http.StatusOK, "hi") }, "", true},
+		{"binary", "gzip", func(c *gin.Context) { c.Data(http.StatusOK, "audio/mpeg", []byte(large)) }, "", false},
+		{"event stream", "gzip", func(c *gin.Context) { c.Data(http.StatusOK, "text/event-stream", []byte(large)) }, "", false},
+		{"no content", "gzip", func(c *gin.Context) { c.Status(http.StatusNoContent) }, "", false},
+		{"many small writes", "gzip", func(c *gin.Context) {
+			c.Header("Content-Type", "text/csv")
+			for i := 0; i < 100; i++ {
+				_, _ = c.Writer.WriteString("a,b,c\n")
+			}
+		}, "gzip", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Compress(256))
+			r.GET("/", tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get("Vary") == "Accept-Encoding"; got != tt.wantVary {
+				t.Errorf("Vary = %q, want Accept-Encoding: %v", rec.Header().Get("Vary"), tt.wantVary)
+			}
+
+			// The decoded body matches what the handler sends uncompressed
+			plain := httptest.NewRecorder()
+			uncompressed := gin.New()
+			uncompressed.GET("/", tt.handler)
+			uncompressed.ServeHTTP(plain, httptest.NewRequest(http.MethodGet, "/", nil))
+			if rec.Code != plain.Code {
+				t.Errorf("status = %d, want %d", rec.Code, plain.Code)
+			}
+
+			var body io.Reader = rec.Body
+			switch tt.wantEncoding {
+			case "gzip":
+				zr, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader: %v", err)
+				}
+				body = zr
+			case "deflate":
+				body = flate.NewReader(rec.Body)
+			}
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if string(got) != plain.Body.String() {
+				t.Errorf("decoded body differs: got %d bytes, want %d", len(got), plain.Body.Len())
+			}
+		})
+	}
+}
+
+// TestCompressWeakensETag verifies compressed responses carry a weak ETag
+// and no Content-Length.
+func TestCompressWeakensETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compress(16))
+	r.GET("/", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.String(http.StatusOK, strings.Repeat("x", 100))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if got := rec.Header().Get("ETag"); got != `W/"abc"` {
+		t.Errorf("ETag = %q, want W/\"abc\"", got)
+	}
+	if rec.Header().Get("Content-Length") != "" {
+		t.Errorf("Content-Length = %q on a compressed response", rec.Header().Get("Content-Length"))
+	}
+}
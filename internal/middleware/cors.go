@@ -6,20 +6,207 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 )
 
-// CORS returns configured CORS middleware.
-func CORS(allowedOrigins []string) gin.HandlerFunc {
+// CORS returns configured CORS middleware. Origins are checked against the
+// matcher on every request, so changes to it apply immediately.
+func CORS(origins *OriginMatcher) gin.HandlerFunc {
 	return cors.New(cors.Config{
-		AllowOrigins:     allowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", "X-Admin-Key"},
-		ExposeHeaders:    []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "Content-Length"},
+		AllowOriginFunc: origins.Allowed,
+		AllowMethods:    []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", "X-Admin-Key", "X-Impersonate-Key", "If-None-Match", "If-Modified-Since",
+			"Tus-Resumable", "Upload-Length", "Upload-Offset", "Upload-Metadata"},
+		ExposeHeaders: []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "ETag", "Content-Length", "Retry-After", "X-Queue-Depth", "X-Queue-Capacity", "X-Queue-Utilization",
+			"Location", "Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size", "Upload-Offset", "Upload-Length", "Upload-Expires", "X-Item-Type", "X-Item-ID",
+			"X-Redactions", "X-Redaction-Counts", "X-Redaction-Names-Checked", "X-Impersonating"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour, // Cache preflight responses
 	})
 }
+
+// OriginMatcher decides which browser origins may call the API. Patterns
+// are exact origins ("https://app.example.com"), wildcard subdomains
+// ("https://*.example.com", matching any depth but not example.com itself),
+// or "*" for any origin. The configured patterns are fixed; patterns added
+// by admins are stored in the database and swapped in at runtime, so new
+// preview domains work without a restart. Safe for concurrent use.
+type OriginMatcher struct {
+	configured []originPattern
+	added      atomic.Pointer[[]originPattern]
+}
+
+// originPattern is a parsed origin pattern. For wildcards, suffix is what
+// follows the "*" (".example.com", plus any port).
+type originPattern struct {
+	raw       string
+	anyOrigin bool
+	scheme    string
+	suffix    string
+	wildcard  bool
+}
+
+// NewOriginMatcher creates a matcher for the configured patterns.
+func NewOriginMatcher(patterns []string) (*OriginMatcher, error) {
+	configured, err := parseOriginPatterns(patterns)
+	if err != nil {
+		return nil, err
+	}
+	m := &OriginMatcher{configured: configured}
+	m.added.Store(&[]originPattern{})
+	return m, nil
+}
+
+// Allowed reports whether a browser on origin may call the API.
+func (m *OriginMatcher) Allowed(origin string) bool {
+	normalized, err := normalizeOrigin(origin)
+	if err != nil {
+		return false
+	}
+	for _, patterns := range [][]originPattern{m.configured, *m.added.Load()} {
+		for _, p := range patterns {
+			if p.matches(normalized) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Configured returns the patterns from configuration.
+func (m *OriginMatcher) Configured() []string {
+	patterns := make([]string, len(m.configured))
+	for i, p := range m.configured {
+		patterns[i] = p.raw
+	}
+	return patterns
+}
+
+// SetAdded replaces the patterns added at runtime. Invalid patterns are
+// skipped and logged, so one bad row can't lock every frontend out.
+func (m *OriginMatcher) SetAdded(patterns []string) {
+	added := make([]originPattern, 0, len(patterns))
+	for _, raw := range patterns {
+		p, err := parseOriginPattern(raw)
+		if err != nil {
+			log.Printf("⚠️  Skipping CORS origin: %v", err)
+			continue
+		}
+		added = append(added, p)
+	}
+	m.added.Store(&added)
+}
+
+// Reload loads the patterns added at runtime from the database.
+func (m *OriginMatcher) Reload(ctx context.Context, db *database.DB) error {
+	origins, err := db.ListCORSOrigins(ctx)
+	if err != nil {
+		return err
+	}
+	patterns := make([]string, len(origins))
+	for i, o := range origins {
+		patterns[i] = o.Origin
+	}
+	m.SetAdded(patterns)
+	return nil
+}
+
+// Watch reloads the added patterns every interval until ctx is done, so
+// origins added through another instance reach this one too.
+func (m *OriginMatcher) Watch(ctx context.Context, db *database.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Reload(ctx, db); err != nil {
+				log.Printf("⚠️  Failed to reload CORS origins: %v", err)
+			}
+		}
+	}
+}
+
+// NormalizeOriginPattern validates an origin pattern and returns it in the
+// form it's matched in.
+func NormalizeOriginPattern(raw string) (string, error) {
+	p, err := parseOriginPattern(raw)
+	if err != nil {
+		return "", err
+	}
+	return p.raw, nil
+}
+
+func parseOriginPatterns(raw []string) ([]originPattern, error) {
+	patterns := make([]originPattern, 0, len(raw))
+	for _, r := range raw {
+		p, err := parseOriginPattern(r)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// parseOriginPattern parses "*", an exact origin, or a wildcard subdomain
+// pattern. A wildcard must cover a whole leading label and sit above a
+// registrable-looking domain, so "https://*.com" is refused.
+func parseOriginPattern(raw string) (originPattern, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "*" {
+		return originPattern{raw: raw, anyOrigin: true}, nil
+	}
+
+	scheme, rest, ok := strings.Cut(raw, "://*.")
+	if !ok {
+		if strings.Contains(raw, "*") {
+			return originPattern{}, fmt.Errorf("invalid origin pattern %q: a wildcard must be the first label, e.g. https://*.example.com", raw)
+		}
+		origin, err := normalizeOrigin(raw)
+		if err != nil {
+			return originPattern{}, err
+		}
+		return originPattern{raw: origin}, nil
+	}
+
+	// Normalize the concrete part by parsing it as an origin
+	origin, err := normalizeOrigin(scheme + "://wildcard." + rest)
+	if err != nil || strings.Contains(rest, "*") {
+		return originPattern{}, fmt.Errorf("invalid origin pattern %q: use e.g. https://*.example.com", raw)
+	}
+	scheme, host, _ := strings.Cut(origin, "://")
+	suffix := strings.TrimPrefix(host, "wildcard")
+	domain, _, _ := strings.Cut(suffix, ":")
+	if strings.Count(domain, ".") < 2 {
+		return originPattern{}, fmt.Errorf("invalid origin pattern %q: the wildcard must sit under a domain, e.g. https://*.example.com", raw)
+	}
+	return originPattern{raw: scheme + "://*" + suffix, scheme: scheme, suffix: suffix, wildcard: true}, nil
+}
+
+// matches reports whether a normalized origin fits the pattern.
+func (p originPattern) matches(origin string) bool {
+	switch {
+	case p.anyOrigin:
+		return true
+	case !p.wildcard:
+		return origin == p.raw
+	}
+	rest, ok := strings.CutPrefix(origin, p.scheme+"://")
+	if !ok {
+		return false
+	}
+	sub, ok := strings.CutSuffix(rest, p.suffix)
+	return ok && sub != "" && !strings.HasSuffix(sub, ".") && !strings.ContainsAny(sub, ":/")
+}
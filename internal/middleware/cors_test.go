@@ -0,0 +1,132 @@
+// cors_test.go contains tests for CORS origin patterns and the middleware.
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestNormalizeOriginPattern verifies origin patterns are validated and
+// normalized, and that wildcards must sit under a domain.
+func TestNormalizeOriginPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"any", "*", "*", false},
+		{"exact", "https://App.Example.com", "https://app.example.com", false},
+		{"default port stripped", "https://app.example.com:443", "https://app.example.com", false},
+		{"wildcard", "https://*.example.com", "https://*.example.com", false},
+		{"wildcard with port", "http://*.example.com:8080", "http://*.example.com:8080", false},
+		{"wildcard default port stripped", " https://*.Example.com:443 ", "https://*.example.com", false},
+		{"wildcard over TLD", "https://*.com", "", true},
+		{"wildcard mid-label", "https://app-*.example.com", "", true},
+		{"second wildcard", "https://*.*.example.com", "", true},
+		{"wildcard with path", "https://*.example.com/app", "", true},
+		{"no scheme", "app.example.com", "", true},
+		{"bad scheme", "ftp://app.example.com", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeOriginPattern(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeOriginPattern(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeOriginPattern(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOriginMatcherAllowed verifies exact and wildcard matching, and that
+// origins added at runtime replace the previous set.
+func TestOriginMatcherAllowed(t *testing.T) {
+	m, err := NewOriginMatcher([]string{"https://app.example.com", "https://*.preview.example.com"})
+	if err != nil {
+		t.Fatalf("NewOriginMatcher() error = %v", err)
+	}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://app.example.com", true},
+		{"https://APP.example.com:443", true},
+		{"http://app.example.com", false},
+		{"https://other.example.com", false},
+		{"https://feature-x.preview.example.com", true},
+		{"https://a.b.preview.example.com", true},
+		{"https://preview.example.com", false},
+		{"https://evilpreview.example.com", false},
+		{"http://feature-x.preview.example.com", false},
+		{"https://feature-x.preview.example.com:8443", false},
+		{"https://feature-x.preview.example.com.evil.com", false},
+		{"null", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := m.Allowed(tt.origin); got != tt.want {
+			t.Errorf("Allowed(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+
+	m.SetAdded([]string{"https://*.vercel-preview.dev", "not an origin"})
+	if !m.Allowed("https://branch.vercel-preview.dev") {
+		t.Error("added wildcard origin not allowed")
+	}
+	if !m.Allowed("https://app.example.com") {
+		t.Error("configured origin no longer allowed after SetAdded")
+	}
+
+	m.SetAdded(nil)
+	if m.Allowed("https://branch.vercel-preview.dev") {
+		t.Error("removed origin still allowed")
+	}
+
+	if _, err := NewOriginMatcher([]string{"https://*.com"}); err == nil {
+		t.Error("NewOriginMatcher() accepted an invalid pattern")
+	}
+}
+
+// TestCORSPreflight verifies preflight requests get CORS headers only for
+// allowed origins.
+func TestCORSPreflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m, err := NewOriginMatcher([]string{"https://*.example.com"})
+	if err != nil {
+		t.Fatalf("NewOriginMatcher() error = %v", err)
+	}
+	r := gin.New()
+	r.Use(CORS(m))
+	r.GET("/api/v1/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		origin      string
+		wantAllowed bool
+	}{
+		{"https://pr-42.example.com", true},
+		{"https://example.org", false},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/health", nil)
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		r.ServeHTTP(w, req)
+
+		got := w.Header().Get("Access-Control-Allow-Origin")
+		if tt.wantAllowed && got != tt.origin {
+			t.Errorf("%s: Access-Control-Allow-Origin = %q, want the origin echoed", tt.origin, got)
+		}
+		if !tt.wantAllowed && (got != "" || w.Code != http.StatusForbidden) {
+			t.Errorf("%s: status %d, Access-Control-Allow-Origin = %q, want 403 without the header", tt.origin, w.Code, got)
+		}
+	}
+}
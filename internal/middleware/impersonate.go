@@ -0,0 +1,106 @@
+// impersonate.go lets admin keys act on another API key's data for
+// support, recording each time they do in the audit log.
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// ImpersonateHeader names the API key an admin key's request runs as.
+const ImpersonateHeader = "X-Impersonate-Key"
+
+// Impersonate returns middleware that runs a request as another API key
+// when an admin key sends that key's ID in X-Impersonate-Key. It runs after
+// DualAuth and the rate limiter, so the admin key's own limit applies.
+// Every impersonated request is audit logged; if logging fails, the request
+// is refused.
+func Impersonate(db *database.DB, ownerKeyID, ownerKeyPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		targetID := c.GetHeader(ImpersonateHeader)
+		if targetID == "" {
+			c.Next()
+			return
+		}
+
+		target, apiErr := AdminTargetKey(c, db, targetID, ownerKeyID, ownerKeyPrefix)
+		if apiErr != nil {
+			c.JSON(apiErr.Code, *apiErr)
+			c.Abort()
+			return
+		}
+		actor := GetAPIKey(c)
+		if err := RecordAudit(c, db, actor, target, models.AuditActionImpersonate); err != nil {
+			log.Printf("❌ %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to record impersonation in the audit log",
+				Code:    http.StatusInternalServerError,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(string(apiKeyContextKey), target)
+		c.Header("X-Impersonating", target.ID)
+		c.Next()
+	}
+}
+
+// AdminTargetKey loads the API key an admin key wants to act on. Admin keys
+// limited to an organization can only act on that organization's keys; the
+// owner key and unscoped admin keys can act on any other than the owner key
+// and admin keys. It returns an error response for other callers and for
+// unknown, out-of-reach, or privileged keys.
+func AdminTargetKey(c *gin.Context, db *database.DB, keyID, ownerKeyID, ownerKeyPrefix string) (*models.APIKey, *models.ErrorResponse) {
+	actor := GetAPIKey(c)
+	if actor == nil || KeyRole(actor, ownerKeyID, ownerKeyPrefix) != models.KeyRoleAdmin {
+		return nil, &models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "Acting on another API key's data requires an admin key",
+			Code:    http.StatusForbidden,
+		}
+	}
+
+	target, err := db.GetAPIKeyByID(c.Request.Context(), keyID)
+	scoped := !IsOwnerAPIKey(actor, ownerKeyID, ownerKeyPrefix) && actor.OrgID != nil
+	if err != nil || (scoped && (target.OrgID == nil || *target.OrgID != *actor.OrgID)) {
+		return nil, &models.ErrorResponse{
+			Error:   "key_not_found",
+			Message: "API key not found",
+			Code:    http.StatusNotFound,
+		}
+	}
+	if privilegedKey(target, ownerKeyID, ownerKeyPrefix) {
+		return nil, &models.ErrorResponse{
+			Error:   "forbidden",
+			Message: "Admin keys can't act on the owner key or other admin keys",
+			Code:    http.StatusForbidden,
+		}
+	}
+	return target, nil
+}
+
+// privilegedKey reports whether key is the owner key or an admin key, whose
+// data no other key may act on: acting as one would grant its privileges.
+func privilegedKey(key *models.APIKey, ownerKeyID, ownerKeyPrefix string) bool {
+	return KeyRole(key, ownerKeyID, ownerKeyPrefix) == models.KeyRoleAdmin
+}
+
+// RecordAudit logs an admin key acting on target's data with the request's
+// method, path, and client IP.
+func RecordAudit(c *gin.Context, db *database.DB, actor, target *models.APIKey, action string) error {
+	return db.RecordAudit(c.Request.Context(), &models.AuditEntry{
+		ActorAPIKeyID:  &actor.ID,
+		TargetAPIKeyID: &target.ID,
+		Action:         action,
+		Method:         c.Request.Method,
+		Path:           c.Request.URL.RequestURI(),
+		IP:             c.ClientIP(),
+	})
+}
@@ -0,0 +1,81 @@
+// impersonate_test.go contains tests for admin key impersonation.
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestImpersonateRequiresAdmin verifies requests without the header pass
+// through as the caller, and only admin keys may impersonate.
+func TestImpersonateRequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	member := &models.APIKey{ID: "key-1", Role: models.KeyRoleMember}
+
+	tests := []struct {
+		name     string
+		apiKey   *models.APIKey
+		target   string
+		wantCode int
+	}{
+		{"no header", member, "", http.StatusOK},
+		{"member key", member, "key-2", http.StatusForbidden},
+		{"jwt request", nil, "key-2", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.apiKey != nil {
+					c.Set(string(apiKeyContextKey), tt.apiKey)
+				}
+			})
+			r.Use(Impersonate(nil, "", ""))
+			r.GET("/", func(c *gin.Context) {
+				if key := GetAPIKey(c); tt.apiKey != nil && key != tt.apiKey {
+					t.Errorf("request ran as %v, want the caller's key", key)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.target != "" {
+				req.Header.Set(ImpersonateHeader, tt.target)
+			}
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+// TestPrivilegedKey verifies the owner key and admin keys can't be
+// impersonated, whether the owner key is matched by ID or prefix.
+func TestPrivilegedKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  *models.APIKey
+		want bool
+	}{
+		{"member key", &models.APIKey{ID: "key-2", KeyPrefix: "mta_bbb", Role: models.KeyRoleMember}, false},
+		{"key without a role", &models.APIKey{ID: "key-2", KeyPrefix: "mta_bbb"}, false},
+		{"admin key", &models.APIKey{ID: "key-2", KeyPrefix: "mta_bbb", Role: models.KeyRoleAdmin}, true},
+		{"owner key by ID", &models.APIKey{ID: "owner", KeyPrefix: "mta_bbb", Role: models.KeyRoleMember}, true},
+		{"owner key by prefix", &models.APIKey{ID: "key-2", KeyPrefix: "mta_own", Role: models.KeyRoleMember}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := privilegedKey(tt.key, "owner", "mta_own"); got != tt.want {
+				t.Errorf("privilegedKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,27 +9,33 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 )
 
-const userContextKey = "user"
+const (
+	userContextKey    = "user"
+	sessionContextKey = "session_id"
+)
 
 // JWTClaims extends standard JWT claims with user info.
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	SessionID string `json:"sid,omitempty"` // Login session; revoking it ends the token
 	jwt.RegisteredClaims
 }
 
-// GenerateJWT creates a new JWT token for a user.
-func GenerateJWT(user *models.User, secret string) (string, error) {
+// GenerateJWT creates a short-lived access token for a user's session.
+func GenerateJWT(user *models.User, sessionID, secret string, ttl time.Duration) (string, error) {
 	claims := JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:    user.ID,
+		Email:     user.Email,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(72 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Subject:   user.ID,
 		},
@@ -82,18 +88,18 @@ func JWTAuth(db *database.DB, jwtSecret string) gin.HandlerFunc {
 		}
 
 		// Look up the user
-		user, err := db.GetUserByID(c.Request.Context(), claims.UserID)
+		user, err := userForClaims(c, db, claims)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
 				Error:   "unauthorized",
-				Message: "User not found",
+				Message: "User not found or session revoked",
 				Code:    http.StatusUnauthorized,
 			})
 			c.Abort()
 			return
 		}
 
-		c.Set(userContextKey, user)
+		setUser(c, user, claims)
 		c.Next()
 	}
 }
@@ -105,10 +111,18 @@ func DualAuth(db *database.DB, jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Try API key first
 		rawKey := c.GetHeader("X-API-Key")
+		if rawKey == "" {
+			rawKey = websocketCredential(c.Request, wsKeyProtocol)
+		}
 		if rawKey != "" {
 			keyHash := HashAPIKey(rawKey)
 			apiKey, err := db.GetAPIKeyByHash(c.Request.Context(), keyHash)
 			if err == nil {
+				// A real key used from the wrong place is refused outright
+				// rather than falling back to JWT auth.
+				if !enforceKeyRestrictions(c, apiKey) {
+					return
+				}
 				c.Set(string(apiKeyContextKey), apiKey)
 				go db.UpdateAPIKeyLastUsed(c.Request.Context(), apiKey.ID)
 				c.Next()
@@ -117,14 +131,16 @@ func DualAuth(db *database.DB, jwtSecret string) gin.HandlerFunc {
 		}
 
 		// Try JWT token
-		authHeader := c.GetHeader("Authorization")
-		if authHeader != "" && strings.HasPrefix(authHeader, "Bearer ") {
-			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		tokenString, hasBearer := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !hasBearer {
+			tokenString = websocketCredential(c.Request, wsBearerProtocol)
+		}
+		if tokenString != "" {
 			claims, err := ParseJWT(tokenString, jwtSecret)
 			if err == nil {
-				user, err := db.GetUserByID(c.Request.Context(), claims.UserID)
+				user, err := userForClaims(c, db, claims)
 				if err == nil {
-					c.Set(userContextKey, user)
+					setUser(c, user, claims)
 					c.Next()
 					return
 				}
@@ -141,6 +157,53 @@ func DualAuth(db *database.DB, jwtSecret string) gin.HandlerFunc {
 	}
 }
 
+// Browsers can't set headers on a WebSocket, so upgrade requests may carry
+// their credential as a subprotocol instead:
+//
+//	new WebSocket(url, ["mta.live.v1", "mta.key." + apiKey])
+const (
+	wsKeyProtocol    = "mta.key."    // Followed by an API key
+	wsBearerProtocol = "mta.bearer." // Followed by an access token
+)
+
+// websocketCredential returns the credential after prefix in a WebSocket
+// upgrade's subprotocols, or "" if there isn't one.
+func websocketCredential(r *http.Request, prefix string) string {
+	if !websocket.IsWebSocketUpgrade(r) {
+		return ""
+	}
+	for _, protocol := range websocket.Subprotocols(r) {
+		if credential, ok := strings.CutPrefix(protocol, prefix); ok {
+			return credential
+		}
+	}
+	return ""
+}
+
+// userForClaims loads the user an access token belongs to, failing if the
+// token's session has been revoked. Tokens issued before sessions existed
+// have no session and only expire.
+func userForClaims(c *gin.Context, db *database.DB, claims *JWTClaims) (*models.User, error) {
+	if claims.SessionID == "" {
+		return db.GetUserByID(c.Request.Context(), claims.UserID)
+	}
+	return db.GetUserBySession(c.Request.Context(), claims.UserID, claims.SessionID)
+}
+
+// setUser stores the authenticated user and their session in the context.
+func setUser(c *gin.Context, user *models.User, claims *JWTClaims) {
+	c.Set(userContextKey, user)
+	if claims.SessionID != "" {
+		c.Set(sessionContextKey, claims.SessionID)
+	}
+}
+
+// GetSessionID returns the login session of the authenticated user, or ""
+// when the request used an API key or a token without a session.
+func GetSessionID(c *gin.Context) string {
+	return c.GetString(sessionContextKey)
+}
+
 // GetUser retrieves the authenticated user from the request context.
 func GetUser(c *gin.Context) *models.User {
 	val, exists := c.Get(userContextKey)
@@ -0,0 +1,142 @@
+// jwt_test.go contains tests for access token generation and validation.
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestGenerateJWT verifies access tokens carry their session and lifetime,
+// and only parse with the right secret.
+func TestGenerateJWT(t *testing.T) {
+	user := &models.User{ID: "u1", Email: "a@example.com"}
+
+	tests := []struct {
+		name      string
+		sessionID string
+		ttl       time.Duration
+		secret    string
+		wantErr   bool
+	}{
+		{"with session", "s1", 15 * time.Minute, "secret", false},
+		{"without session", "", time.Hour, "secret", false},
+		{"wrong secret", "s1", 15 * time.Minute, "other", true},
+		{"expired", "s1", -time.Minute, "secret", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := GenerateJWT(user, tt.sessionID, "secret", tt.ttl)
+			if err != nil {
+				t.Fatalf("GenerateJWT() error = %v", err)
+			}
+
+			claims, err := ParseJWT(token, tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJWT() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if claims.UserID != user.ID || claims.SessionID != tt.sessionID {
+				t.Errorf("claims = %q/%q, want %q/%q", claims.UserID, claims.SessionID, user.ID, tt.sessionID)
+			}
+			if got := time.Until(claims.ExpiresAt.Time); got > tt.ttl || got < tt.ttl-time.Minute {
+				t.Errorf("expires in %v, want about %v", got, tt.ttl)
+			}
+		})
+	}
+}
+
+// TestJWTAuthRejectsBadTokens verifies missing, forged, and expired tokens
+// are turned away before the database is consulted.
+func TestJWTAuthRejectsBadTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := &models.User{ID: "u1"}
+	forged, _ := GenerateJWT(user, "s1", "other", time.Minute)
+	expired, _ := GenerateJWT(user, "s1", "secret", -time.Minute)
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"missing", ""},
+		{"not bearer", "Basic abc"},
+		{"forged", "Bearer " + forged},
+		{"expired", "Bearer " + expired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/auth/me", JWTAuth(nil, "secret"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+}
+
+// TestGetSessionID verifies the session is only recorded for tokens that
+// have one.
+func TestGetSessionID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := &models.User{ID: "u1"}
+
+	for _, sessionID := range []string{"s1", ""} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		setUser(c, user, &JWTClaims{UserID: user.ID, SessionID: sessionID})
+
+		if got := GetSessionID(c); got != sessionID {
+			t.Errorf("GetSessionID() = %q, want %q", got, sessionID)
+		}
+		if GetUser(c) != user {
+			t.Errorf("GetUser() did not return the user")
+		}
+	}
+}
+
+// TestWebSocketCredential verifies credentials are only read from the
+// subprotocols of WebSocket upgrades.
+func TestWebSocketCredential(t *testing.T) {
+	tests := []struct {
+		name    string
+		upgrade bool
+		offered string
+		prefix  string
+		want    string
+	}{
+		{"api key", true, "mta.live.v1, mta.key.mta_abc", wsKeyProtocol, "mta_abc"},
+		{"bearer token", true, "mta.live.v1, mta.bearer.eyJ.x.y", wsBearerProtocol, "eyJ.x.y"},
+		{"other prefix", true, "mta.live.v1, mta.key.mta_abc", wsBearerProtocol, ""},
+		{"not an upgrade", false, "mta.key.mta_abc", wsKeyProtocol, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/audio/live", nil)
+			req.Header.Set("Sec-WebSocket-Protocol", tt.offered)
+			if tt.upgrade {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "websocket")
+			}
+			if got := websocketCredential(req, tt.prefix); got != tt.want {
+				t.Errorf("websocketCredential() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,9 @@
 // ratelimit.go implements per-API-key rate limiting using a token bucket algorithm.
 //
 // How token bucket works:
-// - Each API key gets a "bucket" with N tokens (= rate_limit from the database)
+// - Each API key gets a "bucket" with N tokens (= its tier's rate limit)
 // - Each request consumes 1 token
-// - Tokens refill at a steady rate (rate_limit tokens per hour)
+// - Tokens refill at a steady rate (N tokens per hour)
 // - If the bucket is empty, the request is rejected with 429 Too Many Requests
 //
 // This is more sophisticated than a simple counter because it smooths out
@@ -81,7 +81,7 @@ func (rl *RateLimiter) RateLimit() gin.HandlerFunc {
 		}
 
 		// Check rate limit — this returns all info atomically to avoid race conditions
-		result := rl.allow(apiKey.ID, apiKey.RateLimit)
+		result := rl.allow(apiKey.ID, models.LookupTier(apiKey.Tier).RateLimit)
 		if !result.allowed {
 			// Add headers even for rejected requests so clients know their limits
 			c.Header("X-RateLimit-Limit", formatFloat(result.limit))
@@ -104,6 +104,16 @@ func (rl *RateLimiter) RateLimit() gin.HandlerFunc {
 	}
 }
 
+// Allow reports whether apiKey may make another request, consuming a token
+// if so. It's RateLimit without the Gin plumbing, for the gRPC server, and
+// shares the same buckets so a key's limit covers both APIs.
+func (rl *RateLimiter) Allow(apiKey *models.APIKey) bool {
+	if IsOwnerAPIKey(apiKey, rl.ownerKeyID, rl.ownerKeyPrefix) {
+		return true
+	}
+	return rl.allow(apiKey.ID, models.LookupTier(apiKey.Tier).RateLimit).allowed
+}
+
 // allow checks if a request should be allowed, consuming a token if so.
 // Returns the result atomically to avoid race conditions between checking
 // the limit and reading the bucket for headers.
@@ -121,6 +131,12 @@ func (rl *RateLimiter) allow(keyID string, rateLimit int) allowResult {
 			lastRefill: time.Now(),
 		}
 		rl.buckets[keyID] = b
+	} else if b.maxTokens != float64(rateLimit) {
+		// The key moved to another tier: resize its bucket, keeping the
+		// tokens it has used
+		b.tokens += float64(rateLimit) - b.maxTokens
+		b.maxTokens = float64(rateLimit)
+		b.refillRate = float64(rateLimit) / 3600.0
 	}
 
 	// Refill tokens based on elapsed time
@@ -0,0 +1,170 @@
+// restrictions.go enforces per-key origin and IP allowlists, so a key that
+// leaks from a frontend can't be replayed from anywhere else.
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// maxKeyRestrictions caps the entries in each of a key's allowlists.
+const maxKeyRestrictions = 20
+
+// KeyRestrictionError explains why a restricted key was refused.
+type KeyRestrictionError struct {
+	Code    string // "origin_not_allowed" or "ip_not_allowed"
+	Message string
+}
+
+func (e *KeyRestrictionError) Error() string { return e.Message }
+
+// CheckKeyRestrictions returns an error if apiKey may not be used from
+// clientIP or origin. Keys without allowlists are usable from anywhere. An
+// origin-restricted key needs an origin, so it only works from browsers on
+// those sites.
+func CheckKeyRestrictions(apiKey *models.APIKey, clientIP, origin string) *KeyRestrictionError {
+	if len(apiKey.AllowedIPs) > 0 && !ipAllowed(apiKey.AllowedIPs, clientIP) {
+		return &KeyRestrictionError{
+			Code:    "ip_not_allowed",
+			Message: fmt.Sprintf("This API key can't be used from IP address %s", clientIP),
+		}
+	}
+	if len(apiKey.AllowedOrigins) > 0 {
+		if origin == "" {
+			return &KeyRestrictionError{
+				Code:    "origin_not_allowed",
+				Message: "This API key only works from its allowed origins, and the request has no Origin header",
+			}
+		}
+		if !slices.Contains(apiKey.AllowedOrigins, origin) {
+			return &KeyRestrictionError{
+				Code:    "origin_not_allowed",
+				Message: fmt.Sprintf("This API key can't be used from origin %s", origin),
+			}
+		}
+	}
+	return nil
+}
+
+// enforceKeyRestrictions writes a 403 and aborts if the request comes from
+// somewhere apiKey isn't allowed. It reports whether the request may go on.
+func enforceKeyRestrictions(c *gin.Context, apiKey *models.APIKey) bool {
+	err := CheckKeyRestrictions(apiKey, c.ClientIP(), RequestOrigin(c.Request))
+	if err == nil {
+		return true
+	}
+	c.JSON(http.StatusForbidden, models.ErrorResponse{
+		Error:   err.Code,
+		Message: err.Message,
+		Code:    http.StatusForbidden,
+	})
+	c.Abort()
+	return false
+}
+
+// RequestOrigin returns the normalized origin a request was made from: its
+// Origin header, or the origin of its Referer when browsers leave Origin out
+// (same-origin GETs). It's "" for requests with neither.
+func RequestOrigin(r *http.Request) string {
+	for _, raw := range []string{r.Header.Get("Origin"), r.Header.Get("Referer")} {
+		if raw == "" || raw == "null" {
+			continue
+		}
+		if u, err := url.Parse(raw); err == nil {
+			if origin, err := normalizeOrigin(u.Scheme + "://" + u.Host); err == nil {
+				return origin
+			}
+		}
+	}
+	return ""
+}
+
+// NormalizeKeyRestrictions validates a key's allowlists and puts them in the
+// form the checks compare against: origins as lowercase scheme://host[:port]
+// without default ports, and IPs as CIDR ranges (a bare IP becomes a /32 or
+// /128).
+func NormalizeKeyRestrictions(origins, ips []string) (pq.StringArray, pq.StringArray, error) {
+	if len(origins) > maxKeyRestrictions || len(ips) > maxKeyRestrictions {
+		return nil, nil, fmt.Errorf("at most %d allowed origins and %d allowed IP ranges", maxKeyRestrictions, maxKeyRestrictions)
+	}
+
+	normOrigins := pq.StringArray{}
+	for _, raw := range origins {
+		origin, err := normalizeOrigin(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !slices.Contains(normOrigins, origin) {
+			normOrigins = append(normOrigins, origin)
+		}
+	}
+
+	normIPs := pq.StringArray{}
+	for _, raw := range ips {
+		prefix, err := parseCIDR(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		if s := prefix.String(); !slices.Contains(normIPs, s) {
+			normIPs = append(normIPs, s)
+		}
+	}
+	return normOrigins, normIPs, nil
+}
+
+// normalizeOrigin parses an origin such as "https://app.example.com".
+func normalizeOrigin(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", fmt.Errorf("invalid origin %q: use scheme://host[:port], e.g. https://app.example.com", raw)
+	}
+
+	host := strings.ToLower(u.Host)
+	if (u.Scheme == "https" && strings.HasSuffix(host, ":443")) || (u.Scheme == "http" && strings.HasSuffix(host, ":80")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	return u.Scheme + "://" + host, nil
+}
+
+// parseCIDR parses a CIDR range or a single IP address.
+func parseCIDR(raw string) (netip.Prefix, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.Contains(raw, "/") {
+		prefix, err := netip.ParsePrefix(raw)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid IP range %q: use CIDR notation, e.g. 203.0.113.0/24", raw)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q", raw)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// ipAllowed reports whether clientIP falls in one of the CIDR ranges.
+func ipAllowed(allowed []string, clientIP string) bool {
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, cidr := range allowed {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+// restrictions_test.go contains tests for per-key origin and IP allowlists.
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestCheckKeyRestrictions verifies keys are only usable from their allowed
+// IP ranges and origins, and unrestricted keys from anywhere.
+func TestCheckKeyRestrictions(t *testing.T) {
+	unrestricted := &models.APIKey{}
+	office := &models.APIKey{AllowedIPs: []string{"203.0.113.0/24", "2001:db8::/32"}}
+	frontend := &models.APIKey{AllowedOrigins: []string{"https://app.example.com"}}
+
+	tests := []struct {
+		name     string
+		key      *models.APIKey
+		clientIP string
+		origin   string
+		wantCode string
+	}{
+		{"unrestricted", unrestricted, "198.51.100.7", "", ""},
+		{"IP in range", office, "203.0.113.42", "", ""},
+		{"IPv6 in range", office, "2001:db8::1", "", ""},
+		{"IPv4-mapped IPv6 in range", office, "::ffff:203.0.113.42", "", ""},
+		{"IP out of range", office, "198.51.100.7", "", "ip_not_allowed"},
+		{"unparseable IP", office, "", "", "ip_not_allowed"},
+		{"allowed origin", frontend, "198.51.100.7", "https://app.example.com", ""},
+		{"other origin", frontend, "198.51.100.7", "https://evil.example.com", "origin_not_allowed"},
+		{"no origin", frontend, "198.51.100.7", "", "origin_not_allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckKeyRestrictions(tt.key, tt.clientIP, tt.origin)
+			got := ""
+			if err != nil {
+				got = err.Code
+			}
+			if got != tt.wantCode {
+				t.Errorf("CheckKeyRestrictions() = %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+// TestNormalizeKeyRestrictions verifies allowlists are validated and put in
+// the form requests are compared against.
+func TestNormalizeKeyRestrictions(t *testing.T) {
+	tests := []struct {
+		name        string
+		origins     []string
+		ips         []string
+		wantOrigins []string
+		wantIPs     []string
+		wantErr     bool
+	}{
+		{"empty", nil, nil, []string{}, []string{}, false},
+		{
+			"normalized",
+			[]string{"HTTPS://App.Example.com/", "https://app.example.com:443", "http://localhost:5173"},
+			[]string{"203.0.113.9/24", "198.51.100.7", "2001:db8::1"},
+			[]string{"https://app.example.com", "http://localhost:5173"},
+			[]string{"203.0.113.0/24", "198.51.100.7/32", "2001:db8::1/128"},
+			false,
+		},
+		{"origin with path", []string{"https://app.example.com/login"}, nil, nil, nil, true},
+		{"origin without scheme", []string{"app.example.com"}, nil, nil, nil, true},
+		{"bad CIDR", nil, []string{"203.0.113.0/33"}, nil, nil, true},
+		{"bad IP", nil, []string{"not-an-ip"}, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origins, ips, err := NormalizeKeyRestrictions(tt.origins, tt.ips)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeKeyRestrictions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !slices.Equal(origins, tt.wantOrigins) || !slices.Equal(ips, tt.wantIPs) {
+				t.Errorf("got %v %v, want %v %v", origins, ips, tt.wantOrigins, tt.wantIPs)
+			}
+		})
+	}
+}
+
+// TestRequestOrigin verifies the origin comes from the Origin header, or the
+// Referer when browsers leave Origin out.
+func TestRequestOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		referer string
+		want    string
+	}{
+		{"origin header", "https://App.example.com", "", "https://app.example.com"},
+		{"referer fallback", "", "https://app.example.com/transcripts?page=2", "https://app.example.com"},
+		{"null origin uses referer", "null", "https://app.example.com/", "https://app.example.com"},
+		{"neither", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/transcripts", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.referer != "" {
+				r.Header.Set("Referer", tt.referer)
+			}
+			if got := RequestOrigin(r); got != tt.want {
+				t.Errorf("RequestOrigin() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEnforceKeyRestrictions verifies refused keys get a 403 explaining why.
+func TestEnforceKeyRestrictions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key := &models.APIKey{AllowedOrigins: []string{"https://app.example.com"}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/transcripts", nil)
+	c.Request.Header.Set("Origin", "https://evil.example.com")
+
+	if enforceKeyRestrictions(c, key) {
+		t.Fatal("enforceKeyRestrictions() allowed a request from another origin")
+	}
+	if w.Code != http.StatusForbidden || !c.IsAborted() {
+		t.Errorf("status = %d, aborted = %v; want 403, aborted", w.Code, c.IsAborted())
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// RequireRole returns middleware that rejects callers whose role ranks below
+// role. It runs after DualAuth. API keys carry their own role, and the owner
+// key is always an admin. A JWT user counts as an admin when they are an
+// owner or admin of at least one organization; handlers then limit them to
+// those organizations.
+func RequireRole(db *database.DB, role, ownerKeyID, ownerKeyPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var callerRole string
+		if apiKey := GetAPIKey(c); apiKey != nil {
+			callerRole = KeyRole(apiKey, ownerKeyID, ownerKeyPrefix)
+		} else if user := GetUser(c); user != nil {
+			callerRole = models.KeyRoleMember
+			isAdmin, err := db.IsOrgAdmin(c.Request.Context(), user.ID)
+			if err != nil {
+				log.Printf("❌ %v", err)
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+					Error:   "database_error",
+					Message: "Failed to check your role",
+					Code:    http.StatusInternalServerError,
+				})
+				c.Abort()
+				return
+			}
+			if isAdmin {
+				callerRole = models.KeyRoleAdmin
+			}
+		} else {
+			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Authentication required",
+				Code:    http.StatusUnauthorized,
+			})
+			c.Abort()
+			return
+		}
+
+		if models.KeyRoleRank[callerRole] < models.KeyRoleRank[role] {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "forbidden",
+				Message: "This operation requires the " + role + " role",
+				Code:    http.StatusForbidden,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// KeyRole returns an API key's effective role. The owner key is always an
+// admin, whatever its stored role.
+func KeyRole(apiKey *models.APIKey, ownerKeyID, ownerKeyPrefix string) string {
+	if IsOwnerAPIKey(apiKey, ownerKeyID, ownerKeyPrefix) {
+		return models.KeyRoleAdmin
+	}
+	if apiKey.Role == "" {
+		return models.KeyRoleMember
+	}
+	return apiKey.Role
+}
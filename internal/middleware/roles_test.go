@@ -0,0 +1,74 @@
+// roles_test.go contains tests for route role requirements.
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestRequireRole verifies admin routes admit admin keys and the owner key
+// and turn away member keys and unauthenticated callers.
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	orgID := "org1"
+
+	tests := []struct {
+		name   string
+		apiKey *models.APIKey
+		want   int
+	}{
+		{"no caller", nil, http.StatusUnauthorized},
+		{"member key", &models.APIKey{ID: "k1", Role: models.KeyRoleMember}, http.StatusForbidden},
+		{"key without a role", &models.APIKey{ID: "k1"}, http.StatusForbidden},
+		{"admin key", &models.APIKey{ID: "k1", Role: models.KeyRoleAdmin}, http.StatusOK},
+		{"organization admin key", &models.APIKey{ID: "k1", Role: models.KeyRoleAdmin, OrgID: &orgID}, http.StatusOK},
+		{"owner key", &models.APIKey{ID: "owner", Role: models.KeyRoleMember}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/keys", func(c *gin.Context) {
+				if tt.apiKey != nil {
+					c.Set(string(apiKeyContextKey), tt.apiKey)
+				}
+			}, RequireRole(nil, models.KeyRoleAdmin, "owner", ""), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/keys", nil))
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// TestKeyRole verifies the owner key is always an admin and keys without a
+// stored role are members.
+func TestKeyRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiKey *models.APIKey
+		want   string
+	}{
+		{"stored admin", &models.APIKey{ID: "k1", Role: models.KeyRoleAdmin}, models.KeyRoleAdmin},
+		{"stored member", &models.APIKey{ID: "k1", Role: models.KeyRoleMember}, models.KeyRoleMember},
+		{"no stored role", &models.APIKey{ID: "k1"}, models.KeyRoleMember},
+		{"owner by ID", &models.APIKey{ID: "owner", Role: models.KeyRoleMember}, models.KeyRoleAdmin},
+		{"owner by prefix", &models.APIKey{ID: "k2", KeyPrefix: "mta_abcd..."}, models.KeyRoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyRole(tt.apiKey, "owner", "mta_abcd..."); got != tt.want {
+				t.Errorf("KeyRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
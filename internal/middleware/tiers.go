@@ -0,0 +1,100 @@
+// tiers.go holds API keys to their tier's features and monthly quota. The
+// tier's rate limit is applied by the rate limiter, and its queue priority
+// by the worker pool.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// RequireFeature returns middleware that rejects API keys whose tier
+// doesn't include feature. It runs after DualAuth; JWT requests and the
+// owner key pass through.
+func RequireFeature(feature, ownerKeyID, ownerKeyPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiErr := CheckFeature(GetAPIKey(c), feature, ownerKeyID, ownerKeyPrefix); apiErr != nil {
+			c.JSON(apiErr.Code, *apiErr)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireQuota returns middleware that rejects API keys that have used
+// their tier's monthly quota of new items, and tells the others how much
+// is left in X-Quota-Limit and X-Quota-Remaining. It runs after DualAuth;
+// JWT requests and the owner key pass through.
+func RequireQuota(db *database.DB, ownerKeyID, ownerKeyPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := GetAPIKey(c)
+		limit, remaining, apiErr := CheckQuota(c.Request.Context(), db, apiKey, ownerKeyID, ownerKeyPrefix)
+		if limit > 0 {
+			c.Header("X-Quota-Limit", strconv.Itoa(limit))
+			c.Header("X-Quota-Remaining", strconv.Itoa(remaining))
+		}
+		if apiErr != nil {
+			c.JSON(apiErr.Code, *apiErr)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// CheckFeature returns a 403 error if apiKey's tier doesn't include
+// feature. It's RequireFeature without the Gin plumbing, for GraphQL and
+// gRPC.
+func CheckFeature(apiKey *models.APIKey, feature, ownerKeyID, ownerKeyPrefix string) *models.ErrorResponse {
+	if apiKey == nil || IsOwnerAPIKey(apiKey, ownerKeyID, ownerKeyPrefix) {
+		return nil
+	}
+	tier := models.LookupTier(apiKey.Tier)
+	if tier.HasFeature(feature) {
+		return nil
+	}
+	return &models.ErrorResponse{
+		Error:   "feature_not_in_tier",
+		Message: fmt.Sprintf("The %s tier doesn't include %s; ask an admin to upgrade this API key", tier.Name, feature),
+		Code:    http.StatusForbidden,
+	}
+}
+
+// CheckQuota returns apiKey's monthly quota and how many items it has
+// left this month, with a 402 error if none are left. A limit of 0 means
+// the key's quota is unlimited, or it couldn't be checked: lookup failures
+// are logged and let the request through.
+func CheckQuota(ctx context.Context, db *database.DB, apiKey *models.APIKey, ownerKeyID, ownerKeyPrefix string) (limit, remaining int, apiErr *models.ErrorResponse) {
+	if apiKey == nil || IsOwnerAPIKey(apiKey, ownerKeyID, ownerKeyPrefix) {
+		return 0, 0, nil
+	}
+	tier := models.LookupTier(apiKey.Tier)
+	if tier.MonthlyQuota == 0 {
+		return 0, 0, nil
+	}
+
+	used, err := db.CountMonthlyItems(ctx, apiKey.ID, time.Now())
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return 0, 0, nil
+	}
+	remaining = max(tier.MonthlyQuota-used, 0)
+	if remaining == 0 {
+		return tier.MonthlyQuota, 0, &models.ErrorResponse{
+			Error:   "quota_exceeded",
+			Message: fmt.Sprintf("This API key has used its %s tier's %d items this month; it resets at the start of next month (UTC)", tier.Name, tier.MonthlyQuota),
+			Code:    http.StatusPaymentRequired,
+		}
+	}
+	return tier.MonthlyQuota, remaining, nil
+}
@@ -0,0 +1,55 @@
+// tiers_test.go contains tests for tier feature checks and tier-based rate
+// limits.
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestCheckFeature verifies keys are held to their tier's features, while
+// JWT requests and the owner key aren't.
+func TestCheckFeature(t *testing.T) {
+	tests := []struct {
+		name    string
+		apiKey  *models.APIKey
+		feature string
+		want    int
+	}{
+		{"free tier without pipelines", &models.APIKey{ID: "k", Tier: models.TierFree}, models.FeaturePipelines, http.StatusForbidden},
+		{"free tier with audio", &models.APIKey{ID: "k", Tier: models.TierFree}, models.FeatureAudio, 0},
+		{"pro tier with pipelines", &models.APIKey{ID: "k", Tier: models.TierPro}, models.FeaturePipelines, 0},
+		{"unknown tier is free", &models.APIKey{ID: "k", Tier: "gold"}, models.FeatureWebhooks, http.StatusForbidden},
+		{"owner key", &models.APIKey{ID: "owner", Tier: models.TierFree}, models.FeaturePipelines, 0},
+		{"jwt request", nil, models.FeaturePipelines, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := 0
+			if apiErr := CheckFeature(tt.apiKey, tt.feature, "owner", ""); apiErr != nil {
+				got = apiErr.Code
+			}
+			if got != tt.want {
+				t.Errorf("CheckFeature() code = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRateLimitFollowsTier verifies a key's bucket is sized by its tier,
+// and resized when the key moves to another, keeping the tokens it used.
+func TestRateLimitFollowsTier(t *testing.T) {
+	rl := &RateLimiter{buckets: make(map[string]*bucket)}
+	free := models.LookupTier(models.TierFree).RateLimit
+	pro := models.LookupTier(models.TierPro).RateLimit
+
+	if r := rl.allow("k", free); !r.allowed || r.limit != float64(free) {
+		t.Fatalf("first request = %+v, want allowed with limit %d", r, free)
+	}
+	r := rl.allow("k", pro)
+	if !r.allowed || r.limit != float64(pro) || r.remaining < float64(pro-2) || r.remaining >= float64(pro-1) {
+		t.Errorf("after the upgrade = %+v, want limit %d with 2 tokens used", r, pro)
+	}
+}
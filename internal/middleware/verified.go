@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// RequireVerifiedEmail returns middleware that rejects callers who haven't
+// verified their email, or passes everything through while enabled returns
+// false. It runs after DualAuth.
+func RequireVerifiedEmail(db *database.DB, enabled func() bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled() {
+			c.Next()
+			return
+		}
+
+		verified, err := EmailVerified(c, db)
+		if err != nil {
+			log.Printf("❌ %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:   "database_error",
+				Message: "Failed to check your email verification",
+				Code:    http.StatusInternalServerError,
+			})
+			c.Abort()
+			return
+		}
+		if !verified {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error:   "email_not_verified",
+				Message: "Verify your email to use AI features; POST /api/v1/auth/verify-email/send to get a new link",
+				Code:    http.StatusForbidden,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// EmailVerified reports whether the caller's email is verified. API keys
+// linked to a user follow that user; keys with no user (issued by an admin)
+// always count as verified.
+func EmailVerified(c *gin.Context, db *database.DB) (bool, error) {
+	if user := GetUser(c); user != nil {
+		return user.EmailVerifiedAt != nil, nil
+	}
+	apiKey := GetAPIKey(c)
+	if apiKey == nil || apiKey.UserID == nil {
+		return true, nil
+	}
+	user, err := db.GetUserByID(c.Request.Context(), *apiKey.UserID)
+	if err != nil {
+		return false, err
+	}
+	return user.EmailVerifiedAt != nil, nil
+}
@@ -0,0 +1,54 @@
+// verified_test.go contains tests for the verified-email requirement.
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestRequireVerifiedEmail verifies unverified users are turned away only
+// when the requirement is enabled, and keys without a user always pass.
+func TestRequireVerifiedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		user    *models.User
+		apiKey  *models.APIKey
+		want    int
+	}{
+		{"disabled, unverified user", false, &models.User{ID: "u1"}, nil, http.StatusOK},
+		{"unverified user", true, &models.User{ID: "u1"}, nil, http.StatusForbidden},
+		{"verified user", true, &models.User{ID: "u1", EmailVerifiedAt: &now}, nil, http.StatusOK},
+		{"key without a user", true, nil, &models.APIKey{ID: "k1"}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/summaries", func(c *gin.Context) {
+				if tt.user != nil {
+					c.Set(userContextKey, tt.user)
+				}
+				if tt.apiKey != nil {
+					c.Set(string(apiKeyContextKey), tt.apiKey)
+				}
+			}, RequireVerifiedEmail(nil, func() bool { return tt.enabled }), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/summaries", nil))
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@
 package models
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // TranscriptStatus represents the processing state of a transcript.
@@ -18,25 +22,103 @@ const (
 
 // Transcript represents a YouTube video transcript stored in the database.
 type Transcript struct {
-	ID             string           `json:"id" db:"id"`
-	YouTubeURL     string           `json:"youtube_url" db:"youtube_url"`
-	YouTubeID      string           `json:"youtube_id" db:"youtube_id"`
-	Title          string           `json:"title" db:"title"`
-	ChannelName    string           `json:"channel_name" db:"channel_name"`
-	Duration       int              `json:"duration" db:"duration"`
-	Language       string           `json:"language" db:"language"`
-	TranscriptText string           `json:"transcript_text" db:"transcript_text"`
-	WordCount      int              `json:"word_count" db:"word_count"`
-	Status         TranscriptStatus `json:"status" db:"status"`
-	ErrorMessage   string           `json:"error_message,omitempty" db:"error_message"`
-	BatchID        *string          `json:"batch_id,omitempty" db:"batch_id"`
-	UserID         *string          `json:"user_id,omitempty" db:"user_id"`
-	APIKeyID       *string          `json:"api_key_id,omitempty" db:"api_key_id"`
-	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+	ID               string           `json:"id" db:"id"`
+	YouTubeURL       string           `json:"youtube_url" db:"youtube_url"`
+	YouTubeID        string           `json:"youtube_id" db:"youtube_id"`
+	Title            string           `json:"title" db:"title"`
+	ChannelName      string           `json:"channel_name" db:"channel_name"`
+	Duration         int              `json:"duration" db:"duration"`
+	Language         string           `json:"language" db:"language"`
+	TranscriptText   string           `json:"transcript_text" db:"transcript_text"`
+	WordCount        int              `json:"word_count" db:"word_count"`
+	Status           TranscriptStatus `json:"status" db:"status"`
+	ErrorMessage     string           `json:"error_message,omitempty" db:"error_message"`
+	BatchID          *string          `json:"batch_id,omitempty" db:"batch_id"`
+	UserID           *string          `json:"user_id,omitempty" db:"user_id"`
+	APIKeyID         *string          `json:"api_key_id,omitempty" db:"api_key_id"`
+	Segments         NullJSON         `json:"segments,omitempty" db:"segments"` // []Segment with real caption timings
+	Notes            string           `json:"notes" db:"notes"`
+	Tags             json.RawMessage  `json:"tags,omitempty" db:"tags"`           // []string
+	OriginalText     *string          `json:"-" db:"original_text"`               // Extracted text, kept once corrected
+	TextKey          *string          `json:"-" db:"text_key"`                    // Storage key of transcript_text, when offloaded
+	OriginalTextKey  *string          `json:"-" db:"original_text_key"`           // Storage key of original_text, when offloaded
+	OriginalSegments NullJSON         `json:"-" db:"original_segments"`           // Extracted segments, kept once corrected
+	EditedAt         *time.Time       `json:"edited_at,omitempty" db:"edited_at"` // Last manual correction
+	Redaction        NullJSON         `json:"redaction,omitempty" db:"redaction"` // redact.Report, when PII was masked before storage
+	Usage            NullJSON         `json:"usage,omitempty" db:"usage"`         // ProcessingUsage of the extraction
+	ContentHash      *string          `json:"-" db:"content_hash"`                // SHA-256 of the extracted text, for finding duplicates
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// Segment is one timed stretch of a transcript, taken from the source
+// captions or Whisper's verbose output. Times are in seconds.
+type Segment struct {
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Text    string  `json:"text"`
+	Speaker string  `json:"speaker,omitempty"`
 }
 
-// Batch represents a group of transcript extraction requests.
+// NullJSON is raw JSON from a nullable JSONB column. It works like
+// json.RawMessage, but scans SQL NULL as nil (json.RawMessage can't scan
+// NULL at all), so nil still means "not set".
+type NullJSON []byte
+
+// Scan implements sql.Scanner.
+func (j *NullJSON) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append(NullJSON(nil), v...)
+	case string:
+		*j = NullJSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into NullJSON", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, storing nil as NULL.
+func (j NullJSON) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return []byte(j), nil
+}
+
+// MarshalJSON returns j as-is, or null when it's nil.
+func (j NullJSON) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(j).MarshalJSON()
+}
+
+// UnmarshalJSON stores a copy of data.
+func (j *NullJSON) UnmarshalJSON(data []byte) error {
+	return (*json.RawMessage)(j).UnmarshalJSON(data)
+}
+
+// ProcessingUsage is what processing an item took: how long the work ran
+// and what it consumed, for understanding spend per item. It's stored in
+// the item's usage column once processing finishes; items processed before
+// it was recorded have none.
+type ProcessingUsage struct {
+	ProcessingMS     int64   `json:"processing_ms"`             // Extraction, transcription, or generation
+	WhisperMinutes   int     `json:"whisper_minutes,omitempty"` // Whisper minutes billed, rounded up per file
+	SummaryMS        int64   `json:"summary_ms,omitempty"`      // An audio transcription's AI summary
+	PromptTokens     int     `json:"prompt_tokens,omitempty"`   // AI completion tokens, across every model call
+	CompletionTokens int     `json:"completion_tokens,omitempty"`
+	CostUSD          float64 `json:"cost_usd,omitempty"` // What the AI completions cost
+}
+
+// JSON encodes u for an item's usage column.
+func (u ProcessingUsage) JSON() NullJSON {
+	data, _ := json.Marshal(u) // Numbers and a struct always marshal
+	return data
+}
+
+// Batch represents a group of transcript extraction requests, or of
+// uploaded audio files and PDFs.
 type Batch struct {
 	ID             string           `json:"id" db:"id"`
 	Status         TranscriptStatus `json:"status" db:"status"`
@@ -49,15 +131,19 @@ type Batch struct {
 
 // Summary represents an AI-generated summary of a transcript.
 type Summary struct {
-	ID           string          `json:"id" db:"id"`
-	TranscriptID string          `json:"transcript_id" db:"transcript_id"`
-	ModelUsed    string          `json:"model_used" db:"model_used"`
-	PromptUsed   string          `json:"prompt_used" db:"prompt_used"`
-	SummaryText  string          `json:"summary_text" db:"summary_text"`
-	KeyPoints    json.RawMessage `json:"key_points" db:"key_points"`
-	Length       string          `json:"length" db:"length"`
-	Style        string          `json:"style" db:"style"`
-	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	ID           string           `json:"id" db:"id"`
+	TranscriptID string           `json:"transcript_id" db:"transcript_id"`
+	ModelUsed    string           `json:"model_used" db:"model_used"`
+	PromptUsed   string           `json:"prompt_used" db:"prompt_used"`
+	SummaryText  string           `json:"summary_text" db:"summary_text"`
+	KeyPoints    json.RawMessage  `json:"key_points" db:"key_points"`
+	Length       string           `json:"length" db:"length"`
+	Style        string           `json:"style" db:"style"`
+	Stale        bool             `json:"stale" db:"stale"`   // Transcript was corrected after this was generated
+	Status       TranscriptStatus `json:"status" db:"status"` // pending → processing → completed or failed
+	ErrorMessage string           `json:"error_message,omitempty" db:"error_message"`
+	Usage        NullJSON         `json:"usage,omitempty" db:"usage"` // ProcessingUsage of the generation
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
 }
 
 // Transcript chat models for AI Q&A (MTA-27)
@@ -67,17 +153,38 @@ type TranscriptChatSession struct {
 	ItemType     string    `json:"item_type" db:"item_type"` // transcript, audio, pdf
 	ItemID       string    `json:"item_id" db:"item_id"`
 	APIKeyID     *string   `json:"api_key_id,omitempty" db:"api_key_id"`
+	UserID       *string   `json:"user_id,omitempty" db:"user_id"` // Set for logged-in users' sessions
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+
+	// Older turns condensed to keep prompts within context limits; the
+	// summary covers messages up to and including SummarizedThroughID
+	HistorySummary      string  `json:"history_summary,omitempty" db:"history_summary"`
+	SummarizedThroughID *string `json:"-" db:"summarized_through_id"`
 }
 
 type TranscriptChatMessage struct {
-	ID        string    `json:"id" db:"id"`
-	SessionID string    `json:"session_id" db:"session_id"`
-	Role      string    `json:"role" db:"role"` // "user" or "assistant"
-	Content   string    `json:"content" db:"content"`
-	ModelUsed string    `json:"model_used,omitempty" db:"model_used"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID              string     `json:"id" db:"id"`
+	SessionID       string     `json:"session_id" db:"session_id"`
+	Role            string     `json:"role" db:"role"` // "user" or "assistant"
+	Content         string     `json:"content" db:"content"`
+	ModelUsed       string     `json:"model_used,omitempty" db:"model_used"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	Feedback        *string    `json:"feedback,omitempty" db:"feedback"` // "up" or "down" on assistant answers
+	FeedbackComment string     `json:"feedback_comment,omitempty" db:"feedback_comment"`
+	FeedbackAt      *time.Time `json:"feedback_at,omitempty" db:"feedback_at"`
+
+	// A YouTube transcript answer's cited timestamps, and its content with
+	// each one linked to that moment of the video. Worked out on each read.
+	Citations     []ChatCitation `json:"citations,omitempty" db:"-"`
+	LinkedContent string         `json:"linked_content,omitempty" db:"-"`
+}
+
+// ChatCitation is a timestamp cited in a chat answer, like [12:34].
+type ChatCitation struct {
+	Text    string `json:"text"`    // As written in the answer, e.g. "[12:34]"
+	Seconds int    `json:"seconds"` // Offset into the video
+	URL     string `json:"url"`     // Deep link to that moment
 }
 
 // APIKey represents an API key for authentication.
@@ -87,39 +194,230 @@ type APIKey struct {
 	KeyPrefix  string     `json:"key_prefix" db:"key_prefix"`
 	Name       string     `json:"name" db:"name"`
 	Active     bool       `json:"active" db:"active"`
-	RateLimit  int        `json:"rate_limit" db:"rate_limit"`
+	Tier       string     `json:"tier" db:"tier"` // See Tiers
 	UserID     *string    `json:"user_id,omitempty" db:"user_id"`
+	OrgID      *string    `json:"org_id,omitempty" db:"org_id"` // Organization-scoped keys share visibility
+	Role       string     `json:"role" db:"role"`               // admin keys manage other keys and webhooks
 	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+
+	// Where the key may be used from; empty means anywhere
+	AllowedOrigins pq.StringArray `json:"allowed_origins,omitempty" db:"allowed_origins"` // e.g. https://app.example.com
+	AllowedIPs     pq.StringArray `json:"allowed_ips,omitempty" db:"allowed_ips"`         // CIDR ranges
+
+	// Stripe billing: usage is reported to the customer, and the key is
+	// suspended while its subscription is unpaid
+	StripeCustomerID *string `json:"stripe_customer_id,omitempty" db:"stripe_customer_id"`
+	BillingSuspended bool    `json:"billing_suspended" db:"billing_suspended"`
 }
 
 // --- Request/Response DTOs ---
 
 type CreateTranscriptRequest struct {
-	URL     string `json:"url" binding:"required_without=VideoID"`
-	VideoID string `json:"video_id" binding:"required_without=URL"`
+	URL            string `json:"url" binding:"required_without=VideoID"`
+	VideoID        string `json:"video_id" binding:"required_without=URL"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // Overrides the extraction job timeout
+	Redact         bool   `json:"redact,omitempty"`          // Mask PII in the transcript before it's stored
+	AutoSummarizeOptions
+}
+
+// AutoSummarizeOptions ask for a summary job to be chained onto a
+// transcript's extraction, so it starts as soon as the transcript is ready.
+type AutoSummarizeOptions struct {
+	AutoSummarize  bool            `json:"auto_summarize,omitempty"`
+	SummaryOptions *SummaryOptions `json:"summary_options,omitempty"` // Defaults to the caller's saved AI settings
+}
+
+// SummaryOptions are the options of an automatic summary, as on
+// POST /api/v1/summaries.
+type SummaryOptions struct {
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models"` // null uses the server default; [] means none
+	Length         string   `json:"length,omitempty"`
+	Style          string   `json:"style,omitempty"`
+	Language       string   `json:"language,omitempty"`
 }
 
 type CreateSummaryRequest struct {
-	TranscriptID string `json:"transcript_id" binding:"required"`
-	Model        string `json:"model,omitempty"`
-	Length       string `json:"length,omitempty"`
-	Style        string `json:"style,omitempty"`
+	TranscriptID   string   `json:"transcript_id" binding:"required"`
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"` // Ordered fallback chain; overrides the server default
+	Length         string   `json:"length,omitempty"`
+	Style          string   `json:"style,omitempty"`
+	Language       string   `json:"language,omitempty"`        // Language to write in, e.g. "French"
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty"` // Overrides the summary job timeout
+	ForceRefresh   bool     `json:"force_refresh,omitempty"`   // Regenerate even if an identical request is cached
 }
 
 type CreateChatMessageRequest struct {
-	Message string `json:"message" binding:"required"`
-	Model   string `json:"model,omitempty"`
+	Message        string   `json:"message" binding:"required"`
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`      // Language to answer in
+	ForceRefresh   bool     `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// AISettings are a key's or user's defaults for AI requests that leave
+// these options out. Empty fields fall through: a key's settings win over
+// its user's, which win over the server defaults.
+type AISettings struct {
+	Model    string `json:"model" db:"model"`
+	Length   string `json:"length" db:"length"`     // short, medium, detailed
+	Style    string `json:"style" db:"style"`       // bullet, narrative, academic
+	Language string `json:"language" db:"language"` // e.g. "French"
+}
+
+// UpdateAISettingsRequest changes AI defaults. Omitted fields are kept and
+// "" clears one.
+type UpdateAISettingsRequest struct {
+	Model    *string `json:"model"`
+	Length   *string `json:"length"`
+	Style    *string `json:"style"`
+	Language *string `json:"language"`
+}
+
+// Spend limit actions: what happens to a key's AI requests once it reaches
+// its monthly limit.
+const (
+	SpendActionBlock     = "block"     // Reject with 402
+	SpendActionDowngrade = "downgrade" // Serve with the budget model instead
+)
+
+// SpendStatus is an API key's AI spend for a calendar month (UTC),
+// computed from the tokens its completions used. Cached answers are free.
+type SpendStatus struct {
+	APIKeyID         string   `json:"api_key_id"`
+	Month            string   `json:"month"` // e.g. "2026-10"
+	Requests         int      `json:"requests" db:"requests"`
+	PromptTokens     int64    `json:"prompt_tokens" db:"prompt_tokens"`
+	CompletionTokens int64    `json:"completion_tokens" db:"completion_tokens"`
+	SpentUSD         float64  `json:"spent_usd" db:"spent_usd"`
+	LimitUSD         *float64 `json:"limit_usd"`        // nil when unlimited
+	Action           string   `json:"action,omitempty"` // block or downgrade, with a limit
+}
+
+// SetSpendLimitRequest caps a key's monthly AI spend.
+type SetSpendLimitRequest struct {
+	MonthlyLimitUSD float64 `json:"monthly_limit_usd" binding:"required"`
+	Action          string  `json:"action,omitempty"` // block (default) or downgrade
+}
+
+// ChatFeedbackRequest rates an assistant answer.
+type ChatFeedbackRequest struct {
+	Rating  string `json:"rating" binding:"required"` // "up" or "down"
+	Comment string `json:"comment,omitempty"`
 }
 
 type ChatResponse struct {
-	Session  TranscriptChatSession  `json:"session"`
-	Messages []TranscriptChatMessage `json:"messages"`
+	Session            TranscriptChatSession   `json:"session"`
+	Messages           []TranscriptChatMessage `json:"messages"`
+	NextCursor         string                  `json:"next_cursor,omitempty"`         // Fetches the page of older messages
+	SuggestedQuestions []string                `json:"suggested_questions,omitempty"` // Starter prompts, on the newest page
 }
 
 type CreateAPIKeyRequest struct {
-	Name      string `json:"name" binding:"required"`
-	RateLimit int    `json:"rate_limit,omitempty"`
+	Name string `json:"name" binding:"required"`
+	Tier string `json:"tier,omitempty" binding:"omitempty,oneof=free pro enterprise"` // Defaults to the default_tier setting
+	Role string `json:"role,omitempty" binding:"omitempty,oneof=admin member"`        // Defaults to member
+
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+	AllowedIPs     []string `json:"allowed_ips,omitempty"`
+}
+
+// UserAPIKeyRequest creates or renames one of a user's own API keys. The
+// restrictions only apply when creating; see KeyRestrictionsRequest.
+type UserAPIKeyRequest struct {
+	Name string `json:"name" binding:"required"`
+
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+	AllowedIPs     []string `json:"allowed_ips,omitempty"`
+}
+
+// KeyRestrictionsRequest replaces where an API key may be used from. Empty
+// lists lift the restriction.
+type KeyRestrictionsRequest struct {
+	AllowedOrigins []string `json:"allowed_origins"`
+	AllowedIPs     []string `json:"allowed_ips"`
+}
+
+// API key roles. Admin keys manage other API keys and webhooks; an admin key
+// that belongs to an organization only manages that organization's keys.
+const (
+	KeyRoleAdmin  = "admin"
+	KeyRoleMember = "member"
+)
+
+// KeyRoleRank orders key roles so route requirements can compare them.
+var KeyRoleRank = map[string]int{KeyRoleMember: 1, KeyRoleAdmin: 2}
+
+// API key tiers. A key's tier bundles its rate limit, monthly job quota,
+// queue priority, and the features it may use; see Tiers.
+const (
+	TierFree       = "free"
+	TierPro        = "pro"
+	TierEnterprise = "enterprise"
+)
+
+// Features tiers can include.
+const (
+	FeatureAI        = "ai"        // Summaries, chat, study aids, analysis, and minutes
+	FeatureAudio     = "audio"     // Audio transcription
+	FeaturePDF       = "pdf"       // PDF extraction
+	FeatureBatch     = "batch"     // Batch transcript extraction
+	FeatureWebhooks  = "webhooks"  // Webhook management
+	FeaturePipelines = "pipelines" // Running pipelines
+)
+
+// Tier is what an API key on a tier gets.
+type Tier struct {
+	Name         string   `json:"name"`
+	RateLimit    int      `json:"rate_limit"`    // Requests per hour
+	MonthlyQuota int      `json:"monthly_quota"` // Transcripts, audio transcriptions, and PDF extractions per calendar month (UTC); 0 means unlimited
+	Priority     bool     `json:"priority"`      // Jobs are picked up ahead of other tiers'
+	Features     []string `json:"features"`
+}
+
+// Tiers are the tiers keys can be on, smallest first.
+var Tiers = []Tier{
+	{Name: TierFree, RateLimit: 100, MonthlyQuota: 500, Features: []string{FeatureAI, FeatureAudio, FeaturePDF, FeatureBatch}},
+	{Name: TierPro, RateLimit: 1000, MonthlyQuota: 10000, Features: []string{FeatureAI, FeatureAudio, FeaturePDF, FeatureBatch, FeatureWebhooks, FeaturePipelines}},
+	{Name: TierEnterprise, RateLimit: 10000, Priority: true, Features: []string{FeatureAI, FeatureAudio, FeaturePDF, FeatureBatch, FeatureWebhooks, FeaturePipelines}},
+}
+
+// LookupTier returns the named tier, or the free tier for unknown names.
+func LookupTier(name string) Tier {
+	for _, t := range Tiers {
+		if t.Name == name {
+			return t
+		}
+	}
+	return Tiers[0]
+}
+
+// HasFeature reports whether the tier includes feature.
+func (t Tier) HasFeature(feature string) bool {
+	for _, f := range t.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// SetKeyTierRequest moves an API key to another tier.
+type SetKeyTierRequest struct {
+	Tier string `json:"tier" binding:"required,oneof=free pro enterprise"`
+}
+
+// SetKeyBillingRequest links an API key to a Stripe customer, or unlinks
+// it when StripeCustomerID is empty.
+type SetKeyBillingRequest struct {
+	StripeCustomerID string `json:"stripe_customer_id" binding:"omitempty,startswith=cus_,max=255"`
+}
+
+// BillingPortalResponse is a link to the Stripe customer portal.
+type BillingPortalResponse struct {
+	URL string `json:"url"`
 }
 
 type CreateAPIKeyResponse struct {
@@ -130,37 +428,135 @@ type CreateAPIKeyResponse struct {
 // --- Batch DTOs ---
 
 type CreateBatchRequest struct {
-	URLs []string `json:"urls" binding:"required,min=1,max=10"`
+	URLs           []string `json:"urls" binding:"required,min=1,max=10"`
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty"` // Overrides each extraction job's timeout
+	AutoSummarizeOptions
+}
+
+// CreateUploadBatchRequest is the JSON request body for POST
+// /api/v1/batches/upload, which downloads the files instead of receiving
+// them.
+type CreateUploadBatchRequest struct {
+	URLs           []string `json:"urls" binding:"required,min=1,max=10"` // Public http(s) URLs of audio files and PDFs
+	Redact         bool     `json:"redact,omitempty"`                     // Mask PII
+	Tables         bool     `json:"tables,omitempty"`                     // Detect tables in PDFs
+	Markdown       bool     `json:"markdown,omitempty"`                   // Keep PDFs' text as Markdown too
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty"`            // Overrides each job's timeout
 }
 
+// BatchResponse is a new batch and its items. Upload batches have audio
+// transcriptions and PDF extractions; their transcripts are empty.
 type BatchResponse struct {
-	Batch       Batch        `json:"batch"`
-	Transcripts []Transcript `json:"transcripts"`
+	Batch               Batch                `json:"batch"`
+	Transcripts         []Transcript         `json:"transcripts"`
+	AudioTranscriptions []AudioTranscription `json:"audio_transcriptions,omitempty"`
+	PDFExtractions      []PDFExtraction      `json:"pdf_extractions,omitempty"`
 }
 
 type BatchStatusResponse struct {
-	Batch       Batch        `json:"batch"`
-	Transcripts []Transcript `json:"transcripts"`
+	Batch               Batch                `json:"batch"`
+	Transcripts         []Transcript         `json:"transcripts"`
+	AudioTranscriptions []AudioTranscription `json:"audio_transcriptions,omitempty"`
+	PDFExtractions      []PDFExtraction      `json:"pdf_extractions,omitempty"`
+}
+
+// DuplicateGroup is a set of transcripts of the same video, matched by
+// youtube_id or by identical extracted text. Redacted and unredacted
+// copies are never grouped, as each stands in only for its own kind of
+// request.
+type DuplicateGroup struct {
+	YouTubeID   string       `json:"youtube_id"`
+	MatchedBy   []string     `json:"matched_by"`  // "youtube_id" and/or "content_hash"
+	KeepID      string       `json:"keep_id"`     // Suggested keeper: the oldest completed transcript
+	Transcripts []Transcript `json:"transcripts"` // Oldest first, without their text
+}
+
+// DuplicateGroupsResponse lists the caller's duplicate transcripts.
+type DuplicateGroupsResponse struct {
+	Groups []DuplicateGroup `json:"groups"`
+}
+
+// MergeTranscriptsRequest names the duplicates to fold into a transcript.
+type MergeTranscriptsRequest struct {
+	DuplicateIDs []string `json:"duplicate_ids" binding:"required,min=1,max=50"`
+}
+
+// MergeTranscriptsResponse is the kept transcript after a merge.
+type MergeTranscriptsResponse struct {
+	Transcript Transcript `json:"transcript"`
+	MergedIDs  []string   `json:"merged_ids"` // The deleted duplicates
 }
 
 type TranscriptListParams struct {
-	Page     int              `form:"page"`
-	PerPage  int              `form:"per_page"`
-	Status   TranscriptStatus `form:"status"`
-	Search   string           `form:"search"`
-	SortBy   string           `form:"sort_by"`
-	SortDir  string           `form:"sort_dir"`
-	DateFrom string           `form:"date_from"`
-	DateTo   string           `form:"date_to"`
-	APIKeyID *string          // Filter by owning API key (set internally, not from form)
+	Page        int              `form:"page"`
+	PerPage     int              `form:"per_page"`
+	Status      TranscriptStatus `form:"status"`
+	Search      string           `form:"search"`
+	SortBy      string           `form:"sort_by"`
+	SortDir     string           `form:"sort_dir"`
+	DateFrom    string           `form:"date_from"`
+	DateTo      string           `form:"date_to"`
+	Cursor      string           `form:"cursor"` // next_cursor from the previous page; replaces page
+	APIKeyID    *string          // Filter by owning API key (set internally, not from form)
+	UserID      *string          // Without a key, filter by owning user and their keys (set internally)
+	IncludeText bool             // Return transcript_text and segments (set from ?include=text)
+}
+
+// SummaryListParams holds the query parameters for GET /api/v1/summaries.
+type SummaryListParams struct {
+	Page         int              `form:"page"`
+	PerPage      int              `form:"per_page"`
+	TranscriptID string           `form:"transcript_id"`
+	Model        string           `form:"model"` // Matches model_used
+	Length       string           `form:"length"`
+	Style        string           `form:"style"`
+	Status       TranscriptStatus `form:"status"`
+	DateFrom     string           `form:"date_from"`
+	DateTo       string           `form:"date_to"`
+	APIKeyID     *string          // Filter by the transcript's owning API key (set internally, not from form)
+	UserID       *string          // Without a key, filter by the transcript's owning user and their keys (set internally)
+}
+
+// AudioListParams holds the query parameters for GET /api/v1/audio/transcriptions.
+type AudioListParams struct {
+	Page        int     `form:"page"`
+	PerPage     int     `form:"per_page"`
+	Status      string  `form:"status"`
+	ContentType string  `form:"content_type"`
+	Search      string  `form:"search"`  // Matches title and original filename
+	SortBy      string  `form:"sort_by"` // created_at, duration, or word_count
+	SortDir     string  `form:"sort_dir"`
+	DateFrom    string  `form:"date_from"`
+	DateTo      string  `form:"date_to"`
+	Cursor      string  `form:"cursor"` // next_cursor from the previous page; replaces page
+	APIKeyID    *string // Filter by owning API key (set internally, not from form)
+	UserID      *string // Without a key, filter by owning user and their keys (set internally)
+	IncludeText bool    // Return transcript_text and segments (set from ?include=text)
+}
+
+// PDFListParams holds the query parameters for GET /api/v1/pdf/extractions.
+type PDFListParams struct {
+	Page        int     `form:"page"`
+	PerPage     int     `form:"per_page"`
+	Status      string  `form:"status"`
+	Search      string  `form:"search"`  // Matches title and original filename
+	SortBy      string  `form:"sort_by"` // created_at, page_count, or word_count
+	SortDir     string  `form:"sort_dir"`
+	DateFrom    string  `form:"date_from"`
+	DateTo      string  `form:"date_to"`
+	Cursor      string  `form:"cursor"` // next_cursor from the previous page; replaces page
+	APIKeyID    *string // Filter by owning API key (set internally, not from form)
+	UserID      *string // Without a key, filter by owning user and their keys (set internally)
+	IncludeText bool    // Return text_content (set from ?include=text)
 }
 
 type PaginatedResponse[T any] struct {
-	Data       []T `json:"data"`
-	Page       int `json:"page"`
-	PerPage    int `json:"per_page"`
-	TotalItems int `json:"total_items"`
-	TotalPages int `json:"total_pages"`
+	Data       []T    `json:"data"`
+	Page       int    `json:"page"` // 0 when the page was fetched by cursor
+	PerPage    int    `json:"per_page"`
+	TotalItems int    `json:"total_items"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"` // Pass as ?cursor= for the next page; absent on the last page
 }
 
 // --- Audio Transcription Models (MTA-16, MTA-22/24/25/26) ---
@@ -191,12 +587,17 @@ type AudioTranscription struct {
 	ID             string           `json:"id" db:"id"`
 	Filename       string           `json:"filename" db:"filename"`
 	OriginalName   string           `json:"original_name" db:"original_name"`
+	Title          string           `json:"title,omitempty" db:"title"` // User-set name; see DisplayName
 	Duration       float64          `json:"duration" db:"duration"`
 	Language       string           `json:"language" db:"language"`
+	LanguageHint   string           `json:"language_hint,omitempty" db:"language_hint"` // ISO-639-1 code the caller gave Whisper
 	TranscriptText string           `json:"transcript_text" db:"transcript_text"`
+	Translated     bool             `json:"translated,omitempty" db:"translated"`   // transcript_text is Whisper's English translation
+	SourceText     string           `json:"source_text,omitempty" db:"source_text"` // Original-language transcript, when translated with keep_source
 	WordCount      int              `json:"word_count" db:"word_count"`
 	Status         string           `json:"status" db:"status"`
 	ErrorMessage   string           `json:"error_message,omitempty" db:"error_message"`
+	BatchID        *string          `json:"batch_id,omitempty" db:"batch_id"`
 	ContentType    AudioContentType `json:"content_type" db:"content_type"`
 	SummaryText    string           `json:"summary_text,omitempty" db:"summary_text"`
 	KeyPoints      json.RawMessage  `json:"key_points" db:"key_points"`
@@ -204,16 +605,60 @@ type AudioTranscription struct {
 	Decisions      json.RawMessage  `json:"decisions" db:"decisions"`
 	SummaryModel   string           `json:"summary_model,omitempty" db:"summary_model"`
 	SummaryStatus  string           `json:"summary_status" db:"summary_status"`
+	Analysis       json.RawMessage  `json:"analysis,omitempty" db:"analysis"` // Sentiment/talk-time analysis for calls and meetings
+	AnalysisModel  string           `json:"analysis_model,omitempty" db:"analysis_model"`
+	AnalysisStatus string           `json:"analysis_status" db:"analysis_status"`
+	Minutes        json.RawMessage  `json:"minutes,omitempty" db:"minutes"` // Structured meeting minutes (meeting content type only)
+	MinutesModel   string           `json:"minutes_model,omitempty" db:"minutes_model"`
 	UserID         *string          `json:"user_id,omitempty" db:"user_id"`
 	APIKeyID       *string          `json:"api_key_id,omitempty" db:"api_key_id"`
-	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	Segments       NullJSON         `json:"segments,omitempty" db:"segments"` // []Segment from Whisper
+	TextKey        *string          `json:"-" db:"text_key"`                  // Storage key of transcript_text, when offloaded
+	Notes          string           `json:"notes" db:"notes"`
+	Tags           json.RawMessage  `json:"tags,omitempty" db:"tags"`           // []string
+	Metadata       json.RawMessage  `json:"metadata,omitempty" db:"metadata"`   // map[string]string of custom fields
+	Redaction      NullJSON         `json:"redaction,omitempty" db:"redaction"` // redact.Report, when PII was masked before storage
+	Usage          NullJSON         `json:"usage,omitempty" db:"usage"`         // ProcessingUsage of the transcription and its AI summary
+	// How sure Whisper was of the language it detected, 0-1; nil when it was given a hint or didn't detect one
+	LanguageConfidence *float64 `json:"language_confidence,omitempty" db:"language_confidence"`
+	// []SpeakerSummary — what each speaker covered, committed to, and asked; diarized audio only
+	SpeakerSummaries json.RawMessage `json:"speaker_summaries,omitempty" db:"speaker_summaries"`
+	// When the uploaded file was kept in storage; nil if it wasn't, or retention removed it
+	OriginalStoredAt *time.Time `json:"original_stored_at,omitempty" db:"original_stored_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// DisplayName is the user-set title, or the uploaded filename if none.
+func (at *AudioTranscription) DisplayName() string {
+	if at.Title != "" {
+		return at.Title
+	}
+	return at.OriginalName
 }
 
 // SummarizeAudioRequest is the request body for POST /api/v1/audio/transcriptions/:id/summarize
 type SummarizeAudioRequest struct {
-	ContentType string `json:"content_type,omitempty"` // phone_call, meeting, voice_memo, etc.
-	Model       string `json:"model,omitempty"`        // Override AI model
-	Length      string `json:"length,omitempty"`       // short, medium, detailed
+	ContentType    string   `json:"content_type,omitempty"`    // phone_call, meeting, voice_memo, etc.
+	Model          string   `json:"model,omitempty"`           // Override AI model
+	FallbackModels []string `json:"fallback_models,omitempty"` // Override the fallback model chain
+	Length         string   `json:"length,omitempty"`          // short, medium, detailed
+	Language       string   `json:"language,omitempty"`        // Language to write in
+	ForceRefresh   bool     `json:"force_refresh,omitempty"`   // Regenerate even if an identical request is cached
+}
+
+// AnalyzeAudioRequest is the request body for POST /api/v1/audio/transcriptions/:id/analyze
+type AnalyzeAudioRequest struct {
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`
+}
+
+// GenerateMinutesRequest is the request body for POST /api/v1/audio/transcriptions/:id/minutes
+type GenerateMinutesRequest struct {
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`
 }
 
 // AudioSearchParams for searching audio transcriptions (MTA-25).
@@ -222,34 +667,196 @@ type AudioSearchParams struct {
 	ContentType string `form:"content_type"`
 	Page        int    `form:"page"`
 	PerPage     int    `form:"per_page"`
+	Cursor      string `form:"cursor"` // next_cursor from the previous page; replaces page
+	IncludeText bool   // Return transcript_text and segments (set from ?include=text)
+}
+
+// AudioSearchResult is one hit from GET /api/v1/audio/transcriptions/search.
+// Rank, Snippet, and MatchCount are only set when the search has a query.
+type AudioSearchResult struct {
+	AudioTranscription
+	Rank       float64 `json:"rank,omitempty" db:"rank"`
+	Snippet    string  `json:"snippet,omitempty" db:"snippet"`         // HTML-escaped, matches wrapped in <mark>
+	MatchCount int     `json:"match_count,omitempty" db:"match_count"` // Occurrences of the query's words
 }
 
 // --- PDF Extraction Models (MTA-17) ---
 
 type PDFExtraction struct {
-	ID           string    `json:"id" db:"id"`
-	Filename     string    `json:"filename" db:"filename"`
-	OriginalName string    `json:"original_name" db:"original_name"`
-	PageCount    int       `json:"page_count" db:"page_count"`
-	TextContent  string    `json:"text_content" db:"text_content"`
-	WordCount    int       `json:"word_count" db:"word_count"`
-	Status       string    `json:"status" db:"status"`
-	ErrorMessage string    `json:"error_message,omitempty" db:"error_message"`
-	UserID       *string   `json:"user_id,omitempty" db:"user_id"`
-	APIKeyID     *string   `json:"api_key_id,omitempty" db:"api_key_id"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ID           string          `json:"id" db:"id"`
+	Filename     string          `json:"filename" db:"filename"`
+	OriginalName string          `json:"original_name" db:"original_name"`
+	Title        string          `json:"title,omitempty" db:"title"`           // User-set name; see DisplayName
+	SourceType   string          `json:"source_type" db:"source_type"`         // SourcePDF, SourceEPUB, or SourceArticle
+	SourceURL    string          `json:"source_url,omitempty" db:"source_url"` // Where a fetched PDF or article came from
+	PageCount    int             `json:"page_count" db:"page_count"`
+	FirstPage    int             `json:"first_page,omitempty" db:"first_page"` // Pages extracted; the whole document unless a range was requested
+	LastPage     int             `json:"last_page,omitempty" db:"last_page"`
+	Tables       json.RawMessage `json:"-" db:"tables"` // []pdf.Table; served by GET /pdf/extractions/:id/tables
+	TableCount   int             `json:"table_count" db:"table_count"`
+	DocumentInfo json.RawMessage `json:"document_info,omitempty" db:"document_info"` // pdf.DocumentInfo: the PDF's own title, author, and dates
+	Outline      json.RawMessage `json:"outline,omitempty" db:"outline"`             // []pdf.OutlineItem: bookmarks, with page numbers
+	TextContent  string          `json:"text_content" db:"text_content"`
+	// The text as Markdown, with headings, lists, and tables; only when requested with markdown=true
+	MarkdownContent string          `json:"markdown_content,omitempty" db:"markdown_content"`
+	WordCount       int             `json:"word_count" db:"word_count"`
+	Status          string          `json:"status" db:"status"`
+	ErrorMessage    string          `json:"error_message,omitempty" db:"error_message"`
+	BatchID         *string         `json:"batch_id,omitempty" db:"batch_id"`
+	UserID          *string         `json:"user_id,omitempty" db:"user_id"`
+	APIKeyID        *string         `json:"api_key_id,omitempty" db:"api_key_id"`
+	Notes           string          `json:"notes" db:"notes"`
+	Tags            json.RawMessage `json:"tags,omitempty" db:"tags"`           // []string
+	Redaction       NullJSON        `json:"redaction,omitempty" db:"redaction"` // redact.Report, when PII was masked before storage
+	Usage           NullJSON        `json:"usage,omitempty" db:"usage"`         // ProcessingUsage of the extraction
+	// When the uploaded PDF was kept in storage; nil if it wasn't, or retention removed it
+	OriginalStoredAt *time.Time `json:"original_stored_at,omitempty" db:"original_stored_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Extraction source types. EPUB chapters are stored as pages, and an
+// article is one page.
+const (
+	SourcePDF     = "pdf"
+	SourceEPUB    = "epub"
+	SourceArticle = "article"
+)
+
+// DisplayName is the user-set title, or the uploaded filename if none.
+func (pe *PDFExtraction) DisplayName() string {
+	if pe.Title != "" {
+		return pe.Title
+	}
+	return pe.OriginalName
+}
+
+// PDFPage is one page's text, returned by GET /api/v1/pdf/extractions/:id/pages/:n.
+type PDFPage struct {
+	ExtractionID string `json:"extraction_id"`
+	Page         int    `json:"page"`
+	PageCount    int    `json:"page_count"`
+	Text         string `json:"text"`
+	WordCount    int    `json:"word_count"`
+}
+
+// StoredOriginal identifies an upload whose original file is in storage.
+type StoredOriginal struct {
+	Type     string `db:"type"` // "audio" or "pdf"
+	ID       string `db:"id"`
+	Filename string `db:"filename"` // The storage key
+}
+
+// OriginalURLResponse is a signed, expiring link to download an original upload.
+type OriginalURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ReprocessAudioRequest is the request body for POST /api/v1/audio/transcriptions/:id/reprocess.
+// All fields are optional; an empty body re-runs whisper-1 with language detection.
+type ReprocessAudioRequest struct {
+	Model          string `json:"model,omitempty"`           // whisper-1, gpt-4o-transcribe, gpt-4o-mini-transcribe, gpt-4o-transcribe-diarize
+	Language       string `json:"language,omitempty"`        // ISO-639-1 hint, e.g. "en"
+	Prompt         string `json:"prompt,omitempty"`          // Names and jargon to spell correctly
+	Diarize        bool   `json:"diarize,omitempty"`         // Label speakers in segments
+	Translate      bool   `json:"translate,omitempty"`       // Translate to English (whisper-1 only)
+	KeepSource     bool   `json:"keep_source,omitempty"`     // With translate, also keep the original-language transcript
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // Override the audio job timeout
+	Redact         bool   `json:"redact,omitempty"`          // Mask PII; already-redacted items stay redacted
+}
+
+// LiveTranscriptionMessage is a JSON message sent to the client of
+// GET /api/v1/audio/live. Type says which fields are set:
+// interim (Text, Language, Duration), queued and final (Transcription),
+// or error (Error, Message).
+type LiveTranscriptionMessage struct {
+	Type          string              `json:"type"`
+	Text          string              `json:"text,omitempty"`
+	Language      string              `json:"language,omitempty"`
+	Duration      float64             `json:"duration,omitempty"`
+	Transcription *AudioTranscription `json:"transcription,omitempty"`
+	Error         string              `json:"error,omitempty"`
+	Message       string              `json:"message,omitempty"`
+}
+
+// ExtractPDFURLRequest is the request body for POST /api/v1/pdf/extract-url.
+type ExtractPDFURLRequest struct {
+	URL      string `json:"url" binding:"required"` // Public http(s) URL of the PDF
+	Pages    string `json:"pages,omitempty"`        // "10-25", "7", or "10-"; empty = every page
+	Tables   bool   `json:"tables,omitempty"`       // Also detect tables
+	Markdown bool   `json:"markdown,omitempty"`     // Also keep the text as Markdown
+	Redact   bool   `json:"redact,omitempty"`       // Mask PII
+}
+
+// ExtractArticleRequest is the request body for POST /api/v1/articles/extract.
+type ExtractArticleRequest struct {
+	URL    string `json:"url" binding:"required"` // Public http(s) URL of the web page
+	Redact bool   `json:"redact,omitempty"`       // Mask PII
+}
+
+// ReprocessPDFRequest is the request body for POST /api/v1/pdf/extractions/:id/reprocess.
+type ReprocessPDFRequest struct {
+	FirstPage int  `json:"first_page,omitempty"` // 1-based; 0 = first page
+	LastPage  int  `json:"last_page,omitempty"`  // Inclusive; 0 = last page
+	Redact    bool `json:"redact,omitempty"`     // Mask PII; already-redacted items stay redacted
+	Tables    bool `json:"tables,omitempty"`     // Detect tables; without it, stored tables are cleared
+	Markdown  bool `json:"markdown,omitempty"`   // Keep the text as Markdown; without it, stored Markdown is cleared
 }
 
 // --- Webhook Models (MTA-18) ---
 
 type Webhook struct {
-	ID        string    `json:"id" db:"id"`
-	APIKeyID  string    `json:"api_key_id" db:"api_key_id"`
-	URL       string    `json:"url" db:"url"`
-	Events    []string  `json:"events" db:"events"`
-	Secret    string    `json:"-" db:"secret"`
-	Active    bool      `json:"active" db:"active"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID              string    `json:"id" db:"id"`
+	APIKeyID        string    `json:"api_key_id" db:"api_key_id"`
+	URL             string    `json:"url" db:"url"`
+	Events          []string  `json:"events" db:"events"`
+	Secret          string    `json:"-" db:"secret"`
+	PreviousSecret  string    `json:"-" db:"previous_secret"` // Also signs deliveries until PreviousSecretExpiresAt
+	Active          bool      `json:"active" db:"active"`
+	PayloadMode     string    `json:"payload_mode" db:"payload_mode"`           // full, metadata, or fields
+	PayloadFields   []string  `json:"payload_fields" db:"payload_fields"`       // The data fields sent in fields mode
+	MaxPayloadBytes int       `json:"max_payload_bytes" db:"max_payload_bytes"` // 0 uses the server default
+	Credentials     string    `json:"-" db:"credentials"`                       // Sealed WebhookCredentials; empty when none
+	HeaderNames     []string  `json:"header_names" db:"header_names"`           // Names of the custom headers, never their values
+	HasBasicAuth    bool      `json:"has_basic_auth" db:"has_basic_auth"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+
+	// When dual signing after a secret rotation ends
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty" db:"previous_secret_expires_at"`
+}
+
+// RotateWebhookSecretRequest sets how long deliveries stay signed with the
+// old secret too.
+type RotateWebhookSecretRequest struct {
+	GraceHours *int `json:"grace_hours"` // Default 24; 0 stops using the old secret at once
+}
+
+// WebhookCredentials are the custom headers and basic auth sent with each
+// delivery. They're stored encrypted in Webhook.Credentials.
+type WebhookCredentials struct {
+	Headers   map[string]string `json:"headers,omitempty"`
+	BasicAuth *WebhookBasicAuth `json:"basic_auth,omitempty"`
+}
+
+type WebhookBasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Webhook payload modes: the whole event data, the data without item text
+// and other bulky fields, or only the fields a webhook lists.
+const (
+	WebhookPayloadFull     = "full"
+	WebhookPayloadMetadata = "metadata"
+	WebhookPayloadFields   = "fields"
+)
+
+// ValidWebhookPayloadModes for validation.
+var ValidWebhookPayloadModes = map[string]bool{
+	WebhookPayloadFull:     true,
+	WebhookPayloadMetadata: true,
+	WebhookPayloadFields:   true,
 }
 
 type WebhookDelivery struct {
@@ -265,10 +872,31 @@ type WebhookDelivery struct {
 	DeliveredAt  *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
 }
 
+// WebhookDeliveryAttempt is one HTTP request made for a delivery.
+type WebhookDeliveryAttempt struct {
+	ID                string    `json:"id" db:"id"`
+	DeliveryID        string    `json:"delivery_id" db:"delivery_id"`
+	Attempt           int       `json:"attempt" db:"attempt"`
+	ResponseCode      int       `json:"response_code" db:"response_code"` // 0 when no response arrived
+	ResponseBody      string    `json:"response_body" db:"response_body"` // The start of the receiver's response
+	ResponseTruncated bool      `json:"response_truncated" db:"response_truncated"`
+	LatencyMS         int       `json:"latency_ms" db:"latency_ms"`
+	Error             string    `json:"error,omitempty" db:"error"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// WebhookDeliveryDetail is a delivery with each of its attempts, oldest
+// first.
+type WebhookDeliveryDetail struct {
+	WebhookDelivery
+	History []WebhookDeliveryAttempt `json:"history"`
+}
+
 type WebhookPayload struct {
 	Event     string      `json:"event"`
 	Data      interface{} `json:"data"`
 	Timestamp time.Time   `json:"timestamp"`
+	Truncated bool        `json:"truncated,omitempty"` // Bulky fields were dropped to fit the size limit
 }
 
 var ValidWebhookEvents = map[string]bool{
@@ -279,25 +907,48 @@ var ValidWebhookEvents = map[string]bool{
 	"pdf.completed":        true,
 	"pdf.failed":           true,
 	"batch.completed":      true,
+	"export.completed":     true,
+	"export.failed":        true,
+	"search.matched":       true, // A newly completed item matches a saved search
+	"spend.warning":        true, // A key has used 80% of its monthly AI spend limit
+	"pipeline.webhook":     true, // A pipeline run reached a webhook step
+	"digest.ready":         true, // A user's weekly digest was generated
 }
 
 type CreateWebhookRequest struct {
-	URL    string   `json:"url" binding:"required"`
-	Events []string `json:"events" binding:"required,min=1"`
+	URL             string   `json:"url" binding:"required"`
+	Events          []string `json:"events" binding:"required,min=1"`
+	PayloadMode     string   `json:"payload_mode,omitempty"` // Default full
+	PayloadFields   []string `json:"payload_fields,omitempty"`
+	MaxPayloadBytes int      `json:"max_payload_bytes,omitempty"`
+	// Sent with each delivery; stored encrypted and never returned
+	Headers   map[string]string `json:"headers,omitempty"`
+	BasicAuth *WebhookBasicAuth `json:"basic_auth,omitempty"`
 }
 
+// UpdateWebhookRequest changes any of a webhook's settings; omitted fields
+// are unchanged.
 type UpdateWebhookRequest struct {
-	Active *bool `json:"active"`
+	Active          *bool     `json:"active"`
+	PayloadMode     *string   `json:"payload_mode"`
+	PayloadFields   *[]string `json:"payload_fields"`
+	MaxPayloadBytes *int      `json:"max_payload_bytes"`
+	// Replace all custom headers; {} removes them
+	Headers *map[string]string `json:"headers"`
+	// Replace the basic auth credentials; an empty username removes them
+	BasicAuth *WebhookBasicAuth `json:"basic_auth"`
 }
 
 // --- User Auth Models (MTA-20) ---
 
 type User struct {
-	ID           string    `json:"id" db:"id"`
-	Email        string    `json:"email" db:"email"`
-	PasswordHash string    `json:"-" db:"password_hash"`
-	Name         string    `json:"name" db:"name"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ID                  string     `json:"id" db:"id"`
+	Email               string     `json:"email" db:"email"`
+	PasswordHash        string     `json:"-" db:"password_hash"`
+	Name                string     `json:"name" db:"name"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" db:"deletion_scheduled_at"` // Set while account erasure is pending
+	EmailVerifiedAt     *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
 }
 
 type RegisterRequest struct {
@@ -311,9 +962,183 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// One-time user token purposes.
+const (
+	TokenPurposeVerifyEmail   = "verify_email"
+	TokenPurposeResetPassword = "reset_password"
+)
+
+// VerifyEmailRequest confirms an email address with the token from the
+// verification email.
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PasswordResetRequest asks for a password reset email.
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ConfirmPasswordResetRequest sets a new password with the token from the
+// reset email.
+type ConfirmPasswordResetRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
 type AuthResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"` // Seconds until the access token expires
+	User         User   `json:"user"`
+}
+
+// RefreshTokenRequest carries a refresh token, to rotate it or to log out.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// UserSession is one login: a device or browser holding a refresh token.
+type UserSession struct {
+	ID                string     `json:"id" db:"id"`
+	UserID            string     `json:"-" db:"user_id"`
+	RefreshTokenHash  string     `json:"-" db:"refresh_token_hash"`
+	PreviousTokenHash *string    `json:"-" db:"previous_token_hash"`
+	UserAgent         string     `json:"user_agent" db:"user_agent"`
+	IPAddress         string     `json:"ip_address" db:"ip_address"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt        time.Time  `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt         time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	Current           bool       `json:"current" db:"-"` // The session making the request
+}
+
+// CORSOrigin is a browser origin pattern allowed at runtime, on top of the
+// CORS_ORIGIN configuration.
+type CORSOrigin struct {
+	ID        string    `json:"id" db:"id"`
+	Origin    string    `json:"origin" db:"origin"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CORSOriginRequest adds an origin pattern, e.g. "https://*.example.com".
+type CORSOriginRequest struct {
+	Origin string `json:"origin" binding:"required"`
+}
+
+// CORSOriginsResponse lists the origins allowed from configuration and the
+// ones added at runtime.
+type CORSOriginsResponse struct {
+	Configured []string     `json:"configured"`
+	Added      []CORSOrigin `json:"added"`
+}
+
+// SettingOverride is an admin's runtime change to a setting.
+type SettingOverride struct {
+	Key       string    `json:"key" db:"key"`
+	Value     string    `json:"value" db:"value"` // JSON-encoded
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// RuntimeSetting describes a setting admins can change at runtime.
+type RuntimeSetting struct {
+	Key         string      `json:"key"`
+	Value       interface{} `json:"value"`
+	Default     interface{} `json:"default"` // The configured value
+	Overridden  bool        `json:"overridden"`
+	Description string      `json:"description"`
+	UpdatedAt   *time.Time  `json:"updated_at,omitempty"`
+}
+
+// AccountDeletionResponse is returned when account erasure is scheduled.
+type AccountDeletionResponse struct {
+	Message             string    `json:"message"`
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+// AccountErasure summarizes an erased account: what was deleted, and the
+// stored files the caller still has to remove.
+type AccountErasure struct {
+	UserID      string
+	Items       int      // Transcripts, audio transcriptions, and PDF extractions
+	Originals   []string // Storage keys of uploaded originals
+	ExportFiles []string // Paths of export archives on disk
+}
+
+// AccountChat is one chat session with its messages, for account exports.
+type AccountChat struct {
+	TranscriptChatSession
+	Messages []TranscriptChatMessage `json:"messages"`
+}
+
+// --- Organization Models ---
+
+// Organization roles, from most to least privileged.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+// OrgRoleRank orders roles so permission checks can compare them.
+var OrgRoleRank = map[string]int{OrgRoleMember: 1, OrgRoleAdmin: 2, OrgRoleOwner: 3}
+
+// Organization is a team that shares API keys and items.
+type Organization struct {
+	ID        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Role      string    `json:"role,omitempty" db:"role"` // The caller's role, when listed for a user
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OrgMember is a user's membership in an organization.
+type OrgMember struct {
+	OrgID     string    `json:"org_id" db:"org_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Role      string    `json:"role" db:"role"`
+	Email     string    `json:"email,omitempty" db:"email"`
+	Name      string    `json:"name,omitempty" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OrgInvitation invites someone, by email, to join an organization.
+type OrgInvitation struct {
+	ID         string     `json:"id" db:"id"`
+	OrgID      string     `json:"org_id" db:"org_id"`
+	Email      string     `json:"email" db:"email"`
+	Role       string     `json:"role" db:"role"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	InvitedBy  *string    `json:"invited_by,omitempty" db:"invited_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+}
+
+// CreateOrganizationRequest is the body for POST /api/v1/orgs.
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateOrgInvitationRequest is the body for POST /api/v1/orgs/:id/invitations.
+type CreateOrgInvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role"` // Default: member
+}
+
+// CreateOrgInvitationResponse includes the invitation token — it's only shown once.
+type CreateOrgInvitationResponse struct {
+	OrgInvitation
 	Token string `json:"token"`
-	User  User   `json:"user"`
+}
+
+// AcceptOrgInvitationRequest is the body for POST /api/v1/orgs/invitations/accept.
+type AcceptOrgInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UpdateOrgMemberRequest is the body for PATCH /api/v1/orgs/:id/members/:user_id.
+type UpdateOrgMemberRequest struct {
+	Role string `json:"role" binding:"required"`
 }
 
 // --- Workspace Models (MTA-20) ---
@@ -337,6 +1162,845 @@ type WorkspaceResponse struct {
 	PDFs        []PDFExtraction      `json:"pdfs"`
 }
 
+// --- Task Manager Integration Models ---
+
+// Integration stores a user's credentials for an external task manager.
+// The token is write-only — it's never returned in API responses.
+type Integration struct {
+	ID        string          `json:"id" db:"id"`
+	UserID    string          `json:"user_id" db:"user_id"`
+	Provider  string          `json:"provider" db:"provider"` // todoist, linear, github
+	Token     string          `json:"-" db:"token"`
+	Config    json.RawMessage `json:"config" db:"config"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// SaveIntegrationRequest is the request body for PUT /api/v1/integrations/:provider
+type SaveIntegrationRequest struct {
+	Token  string            `json:"token" binding:"required"`
+	Config map[string]string `json:"config,omitempty"` // github: repo; linear: team_id; todoist: project_id (optional)
+}
+
+// PushActionItemsRequest is the request body for POST /api/v1/audio/transcriptions/:id/action-items/push
+type PushActionItemsRequest struct {
+	Provider string `json:"provider" binding:"required"`
+	Items    []int  `json:"items,omitempty"` // Zero-based indexes into action_items; empty = push all
+}
+
+// PushedActionItem is the outcome of pushing one action item.
+type PushedActionItem struct {
+	Title      string `json:"title"`
+	ExternalID string `json:"external_id,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// PushActionItemsResponse reports per-item results so partial failures are visible.
+type PushActionItemsResponse struct {
+	Provider string             `json:"provider"`
+	Pushed   int                `json:"pushed"`
+	Failed   int                `json:"failed"`
+	Results  []PushedActionItem `json:"results"`
+}
+
+// NotionExportResponse is returned after a page is created in Notion.
+type NotionExportResponse struct {
+	PageID  string `json:"page_id"`
+	PageURL string `json:"page_url"`
+}
+
+// --- Bulk Export Models ---
+
+// ExportItem references one item to include in a bulk export.
+type ExportItem struct {
+	Type string `json:"type"` // transcript, audio, pdf
+	ID   string `json:"id"`
+}
+
+// ValidExportItemTypes for validation.
+var ValidExportItemTypes = map[string]bool{"transcript": true, "audio": true, "pdf": true}
+
+// Export is a bulk ZIP export built in the background.
+type Export struct {
+	ID           string          `json:"id" db:"id"`
+	APIKeyID     *string         `json:"api_key_id,omitempty" db:"api_key_id"`
+	UserID       *string         `json:"user_id,omitempty" db:"user_id"`
+	Kind         string          `json:"kind" db:"kind"` // items or account
+	Status       string          `json:"status" db:"status"`
+	Format       string          `json:"format" db:"format"`
+	Items        json.RawMessage `json:"items" db:"items"`
+	ItemCount    int             `json:"item_count" db:"item_count"`
+	SkippedCount int             `json:"skipped_count" db:"skipped_count"`
+	FilePath     string          `json:"-" db:"file_path"`
+	FileSize     int64           `json:"file_size" db:"file_size"`
+	ErrorMessage string          `json:"error_message,omitempty" db:"error_message"`
+	DownloadURL  string          `json:"download_url,omitempty" db:"-"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	CompletedAt  *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Export kinds. Account exports bundle everything a user owns — items,
+// chats, and API key metadata — for data portability requests.
+const (
+	ExportKindItems   = "items"
+	ExportKindAccount = "account"
+)
+
+// ExportFilter selects the caller's completed items by type and creation date.
+type ExportFilter struct {
+	Types    []string `json:"types,omitempty"`     // Default: all types
+	DateFrom string   `json:"date_from,omitempty"` // RFC 3339 or YYYY-MM-DD
+	DateTo   string   `json:"date_to,omitempty"`
+}
+
+// CreateExportRequest is the request body for POST /api/v1/exports.
+// Exactly one of Items, Filter, or Collection selects what to export.
+type CreateExportRequest struct {
+	Format     string        `json:"format" binding:"required"`
+	Items      []ExportItem  `json:"items,omitempty"`
+	Filter     *ExportFilter `json:"filter,omitempty"`
+	Collection string        `json:"collection,omitempty"` // "workspace" — the logged-in user's saved items
+}
+
+// --- Study Aid Models ---
+
+// StudyAid holds AI-generated flashcards and a multiple-choice quiz for a transcript.
+// Flashcards and Quiz are stored as JSONB so the shape can evolve without migrations.
+type StudyAid struct {
+	ID           string          `json:"id" db:"id"`
+	TranscriptID string          `json:"transcript_id" db:"transcript_id"`
+	APIKeyID     *string         `json:"api_key_id,omitempty" db:"api_key_id"`
+	ModelUsed    string          `json:"model_used" db:"model_used"`
+	Flashcards   json.RawMessage `json:"flashcards" db:"flashcards"` // [{"question","answer"}]
+	Quiz         json.RawMessage `json:"quiz" db:"quiz"`             // [{"question","options","answer_index","explanation"}]
+	Stale        bool            `json:"stale" db:"stale"`           // Transcript was corrected after this was generated
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// CreateStudyAidRequest is the request body for POST /api/v1/transcripts/:id/study
+type CreateStudyAidRequest struct {
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	NumFlashcards  int      `json:"num_flashcards,omitempty"` // Default 10, max 50
+	NumQuestions   int      `json:"num_questions,omitempty"`  // Default 5, max 25
+	Language       string   `json:"language,omitempty"`
+}
+
+// CreateMomentsRequest is the request body for POST /api/v1/transcripts/:id/moments
+type CreateMomentsRequest struct {
+	Count          int      `json:"count,omitempty"` // Default 5, max 20
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	ForceRefresh   bool     `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// KeyMoment is one of a video's most important moments, linked to the
+// point in the video where it starts.
+type KeyMoment struct {
+	Seconds     int    `json:"seconds"`
+	Timestamp   string `json:"timestamp"` // m:ss, or h:mm:ss from an hour on
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+// MomentsResponse is returned by POST /api/v1/transcripts/:id/moments.
+// Approximate is set when the transcript has no stored timings, so the
+// timestamps were estimated from its word count and duration.
+type MomentsResponse struct {
+	TranscriptID string      `json:"transcript_id"`
+	Moments      []KeyMoment `json:"moments"`
+	Approximate  bool        `json:"approximate"`
+	Model        string      `json:"model"`
+}
+
+// CreateChaptersRequest is the request body for POST /api/v1/transcripts/:id/chapters
+type CreateChaptersRequest struct {
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	ForceRefresh   bool     `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// VideoChapter is one entry of a YouTube chapter list.
+type VideoChapter struct {
+	Seconds   int    `json:"seconds"`
+	Timestamp string `json:"timestamp"` // 00:00, or 0:00:00 for videos over an hour
+	Title     string `json:"title"`
+}
+
+// ChaptersResponse is returned by POST /api/v1/transcripts/:id/chapters.
+// ChaptersText and Text are ready to paste into YouTube: the chapter list
+// alone, and the whole description with chapters and hashtags.
+type ChaptersResponse struct {
+	TranscriptID string         `json:"transcript_id"`
+	Chapters     []VideoChapter `json:"chapters"`
+	ChaptersText string         `json:"chapters_text"`
+	Description  string         `json:"description"`
+	Hashtags     []string       `json:"hashtags"`
+	Text         string         `json:"text"`
+	Approximate  bool           `json:"approximate"` // Timings were estimated; see MomentsResponse
+	Model        string         `json:"model"`
+}
+
+// CreateSocialPostsRequest is the request body for
+// POST /api/v1/transcripts/:id/social and /api/v1/audio/transcriptions/:id/social
+type CreateSocialPostsRequest struct {
+	Platforms      []string `json:"platforms,omitempty"` // twitter, linkedin, newsletter; default all
+	Tone           string   `json:"tone,omitempty"`      // professional (default), casual, enthusiastic, educational, witty
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	ForceRefresh   bool     `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// Article is a long-form article draft generated from a transcript.
+// Content is stored as JSONB so the shape can evolve without migrations.
+type Article struct {
+	ID           string          `json:"id" db:"id"`
+	TranscriptID string          `json:"transcript_id" db:"transcript_id"`
+	APIKeyID     *string         `json:"api_key_id,omitempty" db:"api_key_id"`
+	ModelUsed    string          `json:"model_used" db:"model_used"`
+	Content      json.RawMessage `json:"content" db:"content"` // {"title_options","introduction","sections","pull_quotes","conclusion"}
+	Stale        bool            `json:"stale" db:"stale"`     // Transcript was corrected after this was generated
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// CreateArticleRequest is the request body for POST /api/v1/transcripts/:id/article
+type CreateArticleRequest struct {
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	ForceRefresh   bool     `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// ItemRef names a transcript, audio transcription, or PDF extraction.
+type ItemRef struct {
+	ItemType string `json:"item_type" binding:"required,oneof=transcript audio pdf"`
+	ItemID   string `json:"item_id" binding:"required"`
+}
+
+// CompareItemsRequest is the request body for POST /api/v1/analysis/compare
+type CompareItemsRequest struct {
+	Items          []ItemRef `json:"items" binding:"required,min=2,max=5,dive"`
+	Model          string    `json:"model,omitempty"`
+	FallbackModels []string  `json:"fallback_models,omitempty"`
+	Language       string    `json:"language,omitempty"`
+	ForceRefresh   bool      `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// ComparedItem is one source of a comparison; the comparison refers to it
+// by Number.
+type ComparedItem struct {
+	Number   int    `json:"number"`
+	ItemType string `json:"item_type"`
+	ItemID   string `json:"item_id"`
+	Title    string `json:"title"`
+}
+
+// DigestSettings is a user's weekly digest subscription. A digest is
+// delivered by each of email and webhook that's on.
+type DigestSettings struct {
+	Enabled bool `json:"enabled" db:"-"`
+	Email   bool `json:"email" db:"email"`
+	Webhook bool `json:"webhook" db:"webhook"` // digest.ready events to the user's webhooks
+}
+
+// Digest is a report on the items a user processed over a period.
+type Digest struct {
+	ID          string          `json:"id" db:"id"`
+	UserID      string          `json:"user_id" db:"user_id"`
+	PeriodStart time.Time       `json:"period_start" db:"period_start"`
+	PeriodEnd   time.Time       `json:"period_end" db:"period_end"`
+	ItemCount   int             `json:"item_count" db:"item_count"`
+	ModelUsed   string          `json:"model_used" db:"model_used"`
+	Content     json.RawMessage `json:"content" db:"content"` // {"overview","items","themes","action_items","quotes"}
+	DeliveredAt *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// DigestItem is an item covered by a digest. Themes, action items, and
+// quotes refer to it by Number.
+type DigestItem struct {
+	Number    int       `json:"number"`
+	ItemType  string    `json:"item_type"`
+	ItemID    string    `json:"item_id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DigestActionItem is an action item from one of a digest's items.
+type DigestActionItem struct {
+	Item int    `json:"item"`
+	Task string `json:"task"`
+}
+
+// CreateDigestRequest is the request body for POST /api/v1/me/digests
+type CreateDigestRequest struct {
+	Days           int      `json:"days,omitempty"` // How far back to look; default 7
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	ForceRefresh   bool     `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// --- Entity Models ---
+
+// ValidEntityTypes are the kinds of entities extracted from items.
+var ValidEntityTypes = map[string]bool{"person": true, "company": true, "product": true, "place": true}
+
+// Entity is a person, company, product, or place mentioned in items, with
+// how many of the caller's items mention it and how often.
+type Entity struct {
+	ID           string `json:"id" db:"id"`
+	Name         string `json:"name" db:"name"`
+	Type         string `json:"type" db:"entity_type"`
+	ItemCount    int    `json:"item_count" db:"item_count"`
+	MentionCount int    `json:"mention_count" db:"mention_count"`
+}
+
+// ItemEntity is an entity found in one item.
+type ItemEntity struct {
+	EntityID string `json:"entity_id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Mentions int    `json:"mentions"`
+	Context  string `json:"context"` // Where it's first mentioned
+}
+
+// EntityMention is an item that mentions an entity, in
+// GET /api/v1/entities/:id/mentions.
+type EntityMention struct {
+	ItemType  string    `json:"item_type" db:"item_type"`
+	ItemID    string    `json:"item_id" db:"item_id"`
+	Title     string    `json:"title" db:"title"`
+	Mentions  int       `json:"mentions" db:"mention_count"`
+	Context   string    `json:"context" db:"context"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"` // When the item was created
+}
+
+// EntityListParams holds query parameters for GET /api/v1/entities.
+type EntityListParams struct {
+	Page     int     `form:"page"`
+	PerPage  int     `form:"per_page"`
+	Type     string  `form:"type"`   // person, company, product, or place (default all)
+	Search   string  `form:"search"` // Matches name
+	APIKeyID *string // Count only items of this key and its organization (set internally)
+	UserID   *string // Without a key, count only items of this user and their keys (set internally)
+}
+
+// EntityMentionParams holds query parameters for
+// GET /api/v1/entities/:id/mentions.
+type EntityMentionParams struct {
+	Page     int     `form:"page"`
+	PerPage  int     `form:"per_page"`
+	APIKeyID *string // Only items of this key and its organization (set internally)
+	UserID   *string // Without a key, only items of this user and their keys (set internally)
+}
+
+// ExtractEntitiesRequest is the request body for POST .../:id/entities
+type ExtractEntitiesRequest struct {
+	Model          string   `json:"model,omitempty"`
+	FallbackModels []string `json:"fallback_models,omitempty"`
+	ForceRefresh   bool     `json:"force_refresh,omitempty"` // Regenerate even if an identical request is cached
+}
+
+// ExtractEntitiesResponse lists the entities found in an item. They replace
+// any found before.
+type ExtractEntitiesResponse struct {
+	ItemType  string       `json:"item_type"`
+	ItemID    string       `json:"item_id"`
+	Entities  []ItemEntity `json:"entities"`
+	ModelUsed string       `json:"model_used"`
+}
+
+// --- Unified Item Models ---
+
+// Item is the common shape of a transcript, audio transcription, or PDF
+// extraction in GET /api/v1/items.
+type Item struct {
+	Type      string    `json:"type" db:"type"` // transcript, audio, pdf
+	ID        string    `json:"id" db:"id"`
+	Title     string    `json:"title" db:"title"` // Video title, or the uploaded filename
+	Status    string    `json:"status" db:"status"`
+	WordCount int       `json:"word_count" db:"word_count"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ItemListParams holds query parameters for GET /api/v1/items.
+type ItemListParams struct {
+	Page     int     `form:"page"`
+	PerPage  int     `form:"per_page"`
+	Types    string  `form:"type"` // Comma-separated: transcript,audio,pdf (default all)
+	Status   string  `form:"status"`
+	Search   string  `form:"search"` // Matches title
+	SortBy   string  `form:"sort_by"`
+	SortDir  string  `form:"sort_dir"`
+	DateFrom string  `form:"date_from"`
+	DateTo   string  `form:"date_to"`
+	Cursor   string  `form:"cursor"` // next_cursor from the previous page; replaces page
+	APIKeyID *string // Filter by owning API key (set internally, not from form)
+	UserID   *string // Without a key, filter by owning user and their keys (set internally)
+	OrgID    *string // Filter by the organization's API keys (set internally)
+}
+
+// --- Transcript Correction Models ---
+
+// CorrectTranscriptRequest is the body for PUT /api/v1/transcripts/:id/text.
+// Send either transcript_text (optionally with matching segments) or
+// segment_edits, not both.
+type CorrectTranscriptRequest struct {
+	TranscriptText *string       `json:"transcript_text"`
+	Segments       []Segment     `json:"segments"` // With transcript_text; omit to drop timings
+	SegmentEdits   []SegmentEdit `json:"segment_edits"`
+}
+
+// SegmentEdit replaces the text (and optionally speaker) of one segment.
+type SegmentEdit struct {
+	Index   int     `json:"index"`
+	Text    string  `json:"text"`
+	Speaker *string `json:"speaker"`
+}
+
+// TranscriptEdit is one manual correction, holding the version it produced.
+type TranscriptEdit struct {
+	ID             string    `json:"id" db:"id"`
+	TranscriptID   string    `json:"transcript_id" db:"transcript_id"`
+	TranscriptText string    `json:"transcript_text" db:"transcript_text"`
+	Segments       NullJSON  `json:"segments,omitempty" db:"segments"`
+	WordCount      int       `json:"word_count" db:"word_count"`
+	APIKeyID       *string   `json:"api_key_id,omitempty" db:"api_key_id"`
+	UserID         *string   `json:"user_id,omitempty" db:"user_id"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// TranscriptHistory is the response for GET /api/v1/transcripts/:id/edits.
+type TranscriptHistory struct {
+	TranscriptID     string           `json:"transcript_id"`
+	OriginalText     string           `json:"original_text"`
+	OriginalSegments NullJSON         `json:"original_segments,omitempty"`
+	Edits            []TranscriptEdit `json:"edits"` // Newest first
+}
+
+// --- Item Metadata Models ---
+
+// UpdateItemRequest is the body for PATCH on a transcript, audio
+// transcription, or PDF extraction. Omitted fields are left unchanged.
+type UpdateItemRequest struct {
+	Title       *string            `json:"title"`
+	Notes       *string            `json:"notes"`
+	Tags        *[]string          `json:"tags"`         // Replaces the whole list
+	ContentType *string            `json:"content_type"` // Audio only
+	Metadata    *map[string]string `json:"metadata"`     // Audio only; replaces the whole map
+}
+
+// --- Search Models ---
+
+// SearchResult is one hit from GET /api/v1/search/text.
+type SearchResult struct {
+	Type      string    `json:"type" db:"type"` // transcript, audio, or pdf
+	ID        string    `json:"id" db:"id"`
+	Title     string    `json:"title" db:"title"`
+	Status    string    `json:"status" db:"status"`
+	Rank      float64   `json:"rank" db:"rank"`
+	Snippet   string    `json:"snippet" db:"snippet"` // HTML-escaped, matches wrapped in <mark>
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// TextSearchParams holds the query parameters for GET /api/v1/search/text.
+type TextSearchParams struct {
+	Query    string  `form:"q"`
+	Types    string  `form:"type"` // Comma-separated: transcript,audio,pdf (default all)
+	Page     int     `form:"page"`
+	PerPage  int     `form:"per_page"`
+	APIKeyID *string // Filter by owning API key (set internally, not from form)
+	UserID   *string // Without a key, filter by owning user and their keys (set internally)
+	OrgID    *string // Filter by the organization's API keys (set internally)
+}
+
+// SavedSearch is a stored full-text query with optional item type filters.
+// With Notify set, items that match it when they finish processing fire a
+// search.matched webhook.
+type SavedSearch struct {
+	ID            string     `json:"id" db:"id"`
+	APIKeyID      string     `json:"api_key_id" db:"api_key_id"`
+	Name          string     `json:"name" db:"name"`
+	Query         string     `json:"query" db:"query"`
+	Types         []string   `json:"types" db:"types"` // Empty means all item types
+	Notify        bool       `json:"notify" db:"notify"`
+	LastMatchedAt *time.Time `json:"last_matched_at,omitempty" db:"last_matched_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateSavedSearchRequest is the request body for POST /api/v1/searches.
+type CreateSavedSearchRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Query  string   `json:"query" binding:"required"`
+	Types  []string `json:"types"`
+	Notify bool     `json:"notify"`
+}
+
+// SavedSearchMatch is the data of a search.matched webhook.
+type SavedSearchMatch struct {
+	SavedSearchID string `json:"saved_search_id"`
+	Name          string `json:"name"`
+	Query         string `json:"query"`
+	ItemType      string `json:"item_type"`
+	ItemID        string `json:"item_id"`
+	ItemTitle     string `json:"item_title"`
+	APIKeyID      string `json:"-"` // Owner whose webhooks are notified
+}
+
+// --- Stats Models ---
+
+// StatsParams holds the query parameters for GET /api/v1/stats.
+type StatsParams struct {
+	DateFrom string  `form:"date_from"` // YYYY-MM-DD, UTC (default 29 days before date_to)
+	DateTo   string  `form:"date_to"`   // YYYY-MM-DD, UTC, inclusive (default today)
+	APIKeyID *string // Scope to an API key (set internally, not from form)
+	UserID   *string // Scope to a user when there's no API key (set internally)
+}
+
+// StatsBucket is one day's activity for one item type, as aggregated by
+// the database.
+type StatsBucket struct {
+	Date           time.Time `db:"date"`
+	Type           string    `db:"type"`
+	Items          int       `db:"items"`
+	Completed      int       `db:"completed"`
+	Failed         int       `db:"failed"`
+	Seconds        float64   `db:"seconds"`         // Media duration of completed items
+	Words          int64     `db:"words"`           // Words in completed items
+	LatencySeconds float64   `db:"latency_seconds"` // Sum of processing latencies
+	LatencyCount   int       `db:"latency_count"`   // Items with a known latency
+}
+
+// StatsTotals summarizes activity over a period.
+type StatsTotals struct {
+	Items                int      `json:"items"`
+	Completed            int      `json:"completed"`
+	Failed               int      `json:"failed"`
+	FailureRate          float64  `json:"failure_rate"` // failed / (completed + failed)
+	MinutesTranscribed   float64  `json:"minutes_transcribed"`
+	WordsExtracted       int64    `json:"words_extracted"`
+	AvgProcessingSeconds *float64 `json:"avg_processing_seconds"` // null when no latency is known
+}
+
+// DailyStats is one UTC day of StatsTotals.
+type DailyStats struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	StatsTotals
+}
+
+// UsageStats is the response body of GET /api/v1/stats.
+type UsageStats struct {
+	DateFrom string                 `json:"date_from"`
+	DateTo   string                 `json:"date_to"`
+	Totals   StatsTotals            `json:"totals"`
+	ByType   map[string]StatsTotals `json:"by_type"`
+	Daily    []DailyStats           `json:"daily"`
+}
+
+// --- Admin Models ---
+
+// AdminOverview is the response body of GET /api/v1/admin/overview.
+type AdminOverview struct {
+	Queue QueueStats                `json:"queue"`
+	Items map[string]map[string]int `json:"items"` // Item type → status → count
+}
+
+// QueueStats describes the in-memory worker queue.
+type QueueStats struct {
+	Depth    int `json:"depth"`    // Jobs waiting for a worker
+	Capacity int `json:"capacity"` // Depth at which new jobs are rejected
+	Workers  int `json:"workers"`
+}
+
+// APIKeyVolume is one API key's processing volume.
+type APIKeyVolume struct {
+	ID         string     `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	KeyPrefix  string     `json:"key_prefix" db:"key_prefix"`
+	Active     bool       `json:"active" db:"active"`
+	Tier       string     `json:"tier" db:"tier"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	Items      int        `json:"items" db:"items"`
+	Failed     int        `json:"failed" db:"failed"`
+	Items30d   int        `json:"items_30d" db:"items_30d"` // Items submitted in the last 30 days
+	Words      int64      `json:"words" db:"words"`
+}
+
+// FailedItem is a transcript, audio transcription, or PDF extraction that
+// failed, with its error.
+type FailedItem struct {
+	Type         string    `json:"type" db:"type"`
+	ID           string    `json:"id" db:"id"`
+	Title        string    `json:"title" db:"title"`
+	ErrorMessage string    `json:"error_message" db:"error_message"`
+	APIKeyID     *string   `json:"api_key_id,omitempty" db:"api_key_id"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// WebhookHealth summarizes a webhook's deliveries over the last 24 hours.
+type WebhookHealth struct {
+	ID              string     `json:"id" db:"id"`
+	APIKeyID        string     `json:"api_key_id" db:"api_key_id"`
+	URL             string     `json:"url" db:"url"`
+	Active          bool       `json:"active" db:"active"`
+	Delivered       int        `json:"delivered" db:"delivered"`
+	Failed          int        `json:"failed" db:"failed"`
+	Pending         int        `json:"pending" db:"pending"`
+	LastDeliveredAt *time.Time `json:"last_delivered_at,omitempty" db:"last_delivered_at"`
+	LastError       *string    `json:"last_error,omitempty" db:"last_error"`
+}
+
+// ChatFeedbackStats summarizes feedback on chat answers from one model
+// about one item type.
+type ChatFeedbackStats struct {
+	Model    string   `json:"model" db:"model"`
+	ItemType string   `json:"item_type" db:"item_type"`
+	Answers  int      `json:"answers" db:"answers"`
+	Rated    int      `json:"rated" db:"rated"`
+	Up       int      `json:"up" db:"up"`
+	Down     int      `json:"down" db:"down"`
+	Approval *float64 `json:"approval,omitempty" db:"-"` // Share of rated answers rated up
+}
+
+// DependencyStatus reports whether an external dependency is usable.
+type DependencyStatus struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, error, or not_configured
+	Detail  string `json:"detail,omitempty"`
+	Latency int64  `json:"latency_ms,omitempty"` // Time the check took
+}
+
+// --- Share Link Models ---
+
+// ShareLink grants public read-only access to one transcript, audio
+// transcription, or PDF extraction. Only the token's hash is stored.
+type ShareLink struct {
+	ID          string     `json:"id" db:"id"`
+	TokenHash   string     `json:"-" db:"token_hash"`
+	TokenPrefix string     `json:"token_prefix" db:"token_prefix"`
+	ItemType    string     `json:"item_type" db:"item_type"` // transcript, audio, pdf
+	ItemID      string     `json:"item_id" db:"item_id"`
+	APIKeyID    *string    `json:"api_key_id,omitempty" db:"api_key_id"`
+	UserID      *string    `json:"user_id,omitempty" db:"user_id"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	ViewCount   int        `json:"view_count" db:"view_count"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Upload is a resumable (tus) upload of an audio file or PDF. Once all
+// Length bytes have arrived it's processed like a direct upload.
+type Upload struct {
+	ID             string          `json:"id" db:"id"`
+	ItemType       string          `json:"item_type" db:"item_type"` // audio, pdf
+	Filename       string          `json:"filename" db:"filename"`
+	Length         int64           `json:"length" db:"upload_length"`
+	Offset         int64           `json:"offset" db:"upload_offset"`
+	TimeoutSeconds int             `json:"timeout_seconds,omitempty" db:"timeout_seconds"`
+	Redact         bool            `json:"redact,omitempty" db:"redact"`           // Mask PII before the result is stored
+	Language       string          `json:"language,omitempty" db:"language"`       // Whisper language hint for audio
+	Translate      bool            `json:"translate,omitempty" db:"translate"`     // Translate audio to English
+	KeepSource     bool            `json:"keep_source,omitempty" db:"keep_source"` // Also keep the original-language transcript
+	Title          string          `json:"title,omitempty" db:"title"`             // Audio title, notes, and metadata, set on the transcription
+	Notes          string          `json:"notes,omitempty" db:"notes"`
+	Metadata       json.RawMessage `json:"metadata,omitempty" db:"metadata"`
+	ItemID         *string         `json:"item_id,omitempty" db:"item_id"` // The transcription or extraction, once complete
+	APIKeyID       *string         `json:"api_key_id,omitempty" db:"api_key_id"`
+	UserID         *string         `json:"user_id,omitempty" db:"user_id"`
+	ExpiresAt      time.Time       `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// CreateShareLinkRequest is the request body for POST /api/v1/{item}/:id/share.
+type CreateShareLinkRequest struct {
+	ExpiresInHours int `json:"expires_in_hours,omitempty"` // 0 = never expires
+}
+
+// CreateShareLinkResponse includes the public URL — the token is only shown once.
+type CreateShareLinkResponse struct {
+	ShareLink
+	URL string `json:"url"`
+}
+
+// SharedItem is the public, read-only view served at a share link.
+type SharedItem struct {
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	SourceURL string     `json:"source_url,omitempty"`
+	Language  string     `json:"language,omitempty"`
+	Duration  float64    `json:"duration_seconds,omitempty"`
+	PageCount int        `json:"page_count,omitempty"`
+	WordCount int        `json:"word_count"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// --- Retention Models ---
+
+// RetentionPolicy is how long the caller's items are kept. Scope is
+// "api_key" or "user", whichever the request authenticated as.
+type RetentionPolicy struct {
+	Scope         string     `json:"scope" db:"-"`
+	RetentionDays *int       `json:"retention_days" db:"retention_days"` // nil = keep forever
+	UpdatedAt     *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// UpdateRetentionRequest is the request body for PUT /api/v1/retention.
+type UpdateRetentionRequest struct {
+	RetentionDays *int `json:"retention_days"` // null or 0 keeps items forever
+}
+
+// Purge is one audit log entry for an item deleted by a retention policy.
+type Purge struct {
+	ID            string    `json:"id" db:"id"`
+	ItemType      string    `json:"item_type" db:"item_type"` // transcript, audio, pdf
+	ItemID        string    `json:"item_id" db:"item_id"`
+	Title         string    `json:"title" db:"title"`
+	APIKeyID      *string   `json:"api_key_id,omitempty" db:"api_key_id"`
+	UserID        *string   `json:"user_id,omitempty" db:"user_id"`
+	RetentionDays int       `json:"retention_days" db:"retention_days"`
+	OriginalKey   *string   `json:"-" db:"original_key"` // Stored original to delete along with the item
+	ItemCreatedAt time.Time `json:"item_created_at" db:"item_created_at"`
+	PurgedAt      time.Time `json:"purged_at" db:"purged_at"`
+}
+
+// --- Watch Folder Models ---
+
+// WatchedFile records a file the watch folder picked up and the item it
+// became. ItemID is nil when the file couldn't be processed.
+type WatchedFile struct {
+	ID           string    `json:"id" db:"id"`
+	Path         string    `json:"path" db:"path"`
+	Size         int64     `json:"size" db:"size"`
+	ItemType     string    `json:"item_type" db:"item_type"` // audio, pdf
+	ItemID       *string   `json:"item_id,omitempty" db:"item_id"`
+	ErrorMessage string    `json:"error_message,omitempty" db:"error_message"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// --- Audit Log Models ---
+
+// Audit log actions.
+const (
+	AuditActionImpersonate = "impersonate" // A request sent with X-Impersonate-Key
+	AuditActionQuery       = "query"       // A list of another key's items (?api_key_id=)
+)
+
+// AuditEntry records an admin key acting on another key's data.
+type AuditEntry struct {
+	ID             string    `json:"id" db:"id"`
+	ActorAPIKeyID  *string   `json:"actor_api_key_id" db:"actor_api_key_id"`
+	TargetAPIKeyID *string   `json:"target_api_key_id" db:"target_api_key_id"`
+	Action         string    `json:"action" db:"action"`
+	Method         string    `json:"method" db:"method"`
+	Path           string    `json:"path" db:"path"`
+	IP             string    `json:"ip" db:"ip"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// AuditLogParams filters GET /api/v1/admin/audit-log.
+type AuditLogParams struct {
+	ActorAPIKeyID  string `form:"actor_api_key_id"`
+	TargetAPIKeyID string `form:"target_api_key_id"`
+	Action         string `form:"action" binding:"omitempty,oneof=impersonate query"`
+	Limit          int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// --- Redaction Models ---
+
+// RedactRequest is the request body for POST /api/v1/redact.
+type RedactRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// RedactResponse is the masked text and a report of what was masked.
+// Report is a redact.Report; it never contains the masked values.
+type RedactResponse struct {
+	Text   string          `json:"text"`
+	Report json.RawMessage `json:"report"`
+}
+
+// --- Pipeline Models ---
+
+// Pipeline step types. Every pipeline starts by extracting the transcript.
+const (
+	PipelineStepExtract   = "extract"
+	PipelineStepSummarize = "summarize"
+	PipelineStepTag       = "tag"
+	PipelineStepWebhook   = "webhook" // Sends a pipeline.webhook event to the key's webhooks
+	PipelineStepNotion    = "notion"  // Exports the transcript and its summary to Notion
+)
+
+// ValidPipelineSteps for validation.
+var ValidPipelineSteps = map[string]bool{
+	PipelineStepExtract:   true,
+	PipelineStepSummarize: true,
+	PipelineStepTag:       true,
+	PipelineStepWebhook:   true,
+	PipelineStepNotion:    true,
+}
+
+// PipelineStep is one step of a pipeline.
+type PipelineStep struct {
+	Type    string          `json:"type"`
+	Summary *SummaryOptions `json:"summary,omitempty"` // summarize only
+	Tags    []string        `json:"tags,omitempty"`    // tag only: added to the transcript's tags
+}
+
+// PipelineRunStep is a step of a pipeline run and how far it got.
+type PipelineRunStep struct {
+	PipelineStep
+	Status    string `json:"status"` // pending, processing, completed, failed
+	Error     string `json:"error,omitempty"`
+	SummaryID string `json:"summary_id,omitempty"` // summarize: the summary it created
+	NotionURL string `json:"notion_url,omitempty"` // notion: the page it created
+}
+
+// Pipeline is an API key's stored sequence of processing steps.
+type Pipeline struct {
+	ID        string          `json:"id" db:"id"`
+	APIKeyID  string          `json:"api_key_id" db:"api_key_id"`
+	Name      string          `json:"name" db:"name"`
+	Steps     json.RawMessage `json:"steps" db:"steps"` // []PipelineStep
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// PipelineRun is one run of a pipeline on a video.
+type PipelineRun struct {
+	ID           string          `json:"id" db:"id"`
+	PipelineID   string          `json:"pipeline_id" db:"pipeline_id"`
+	APIKeyID     string          `json:"api_key_id" db:"api_key_id"`
+	UserID       *string         `json:"-" db:"user_id"` // Whose Notion integration notion steps use
+	TranscriptID *string         `json:"transcript_id,omitempty" db:"transcript_id"`
+	Status       string          `json:"status" db:"status"`
+	CurrentStep  int             `json:"current_step" db:"current_step"` // Index into Steps
+	Steps        json.RawMessage `json:"steps" db:"steps"`               // []PipelineRunStep
+	ErrorMessage string          `json:"error_message,omitempty" db:"error_message"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// PipelineRequest is the request body for POST and PUT /api/v1/pipelines.
+type PipelineRequest struct {
+	Name  string         `json:"name" binding:"required"`
+	Steps []PipelineStep `json:"steps" binding:"required,min=1"`
+}
+
+// CreatePipelineRunRequest is the request body for
+// POST /api/v1/pipelines/:id/runs.
+type CreatePipelineRunRequest struct {
+	URL     string `json:"url" binding:"required_without=VideoID"`
+	VideoID string `json:"video_id" binding:"required_without=URL"`
+}
+
 // --- Common Response Types ---
 
 type ErrorResponse struct {
@@ -351,3 +2015,10 @@ type HealthResponse struct {
 	Database string `json:"database"`
 	Workers  int    `json:"workers"`
 }
+
+// DeepHealthResponse reports every dependency for readiness probes.
+// Status is "ok", or "unhealthy" if any check has an error.
+type DeepHealthResponse struct {
+	Status string             `json:"status"`
+	Checks []DependencyStatus `json:"checks"`
+}
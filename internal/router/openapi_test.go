@@ -0,0 +1,595 @@
+//go:build cgo
+
+// openapi_test.go checks the embedded OpenAPI spec against the router: every
+// route is documented, every documented operation is routed, and live
+// responses match the schemas the spec gives them. It keeps /api/docs from
+// drifting as handlers change.
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-yaml"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/mailer"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/settings"
+	webhookservice "github.com/Shimizu-Technology/media-tools-api/internal/services/webhook"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
+)
+
+const (
+	testJWTSecret = "test-jwt-secret-at-least-32-characters"
+	testAdminKey  = "test-admin-key"
+)
+
+// undocumentedRoutes are served but deliberately left out of the spec.
+var undocumentedRoutes = map[string]bool{
+	"GET /api/docs":              true, // Swagger UI for the spec itself
+	"GET /api/docs/openapi.yaml": true,
+}
+
+// commonResponses are statuses any authenticated operation can return, which
+// the spec describes once under components/responses rather than on every
+// operation.
+var commonResponses = map[int]string{
+	http.StatusUnauthorized:    "Unauthorized",
+	http.StatusTooManyRequests: "RateLimited",
+}
+
+// spec is the parts of an OpenAPI document the contract tests read.
+type spec struct {
+	Servers    []server             `yaml:"servers"`
+	Paths      map[string]*pathItem `yaml:"paths"`
+	Components struct {
+		Schemas   map[string]*schema   `yaml:"schemas"`
+		Responses map[string]*response `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+type server struct {
+	URL string `yaml:"url"`
+}
+
+// pathItem is a path in the spec and its operations.
+type pathItem struct {
+	Servers []server   `yaml:"servers"`
+	Get     *operation `yaml:"get"`
+	Post    *operation `yaml:"post"`
+	Put     *operation `yaml:"put"`
+	Patch   *operation `yaml:"patch"`
+	Delete  *operation `yaml:"delete"`
+	Head    *operation `yaml:"head"`
+	Options *operation `yaml:"options"`
+}
+
+// operation is one method of a path in the spec.
+type operation struct {
+	Servers   []server             `yaml:"servers"`
+	Responses map[string]*response `yaml:"responses"`
+}
+
+type response struct {
+	Ref     string `yaml:"$ref"`
+	Content map[string]struct {
+		Schema *schema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+// schema is the subset of OpenAPI 3.0 schemas the spec uses.
+type schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Nullable             bool               `yaml:"nullable"`
+	Enum                 []any              `yaml:"enum"`
+	Properties           map[string]*schema `yaml:"properties"`
+	Required             []string           `yaml:"required"`
+	Items                *schema            `yaml:"items"`
+	AdditionalProperties any                `yaml:"additionalProperties"` // true, or a schema
+	AllOf                []*schema          `yaml:"allOf"`
+	OneOf                []*schema          `yaml:"oneOf"`
+	AnyOf                []*schema          `yaml:"anyOf"`
+}
+
+// documentedOp is an operation and its full path, e.g. GET /api/v1/transcripts/{id}.
+type documentedOp struct {
+	method, path string
+	op           *operation
+}
+
+// loadSpec parses openapi.yaml and lists its operations under their full
+// paths: the server URL, which paths and operations can override, joined to
+// the path.
+func loadSpec(t *testing.T) (*spec, []documentedOp) {
+	t.Helper()
+	data, err := os.ReadFile("../handlers/openapi.yaml")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var s spec
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		t.Fatalf("parse openapi.yaml: %v", err)
+	}
+
+	var ops []documentedOp
+	for path, item := range s.Paths {
+		base := s.Servers
+		if len(item.Servers) > 0 {
+			base = item.Servers
+		}
+		methods := map[string]*operation{
+			"GET": item.Get, "POST": item.Post, "PUT": item.Put, "PATCH": item.Patch,
+			"DELETE": item.Delete, "HEAD": item.Head, "OPTIONS": item.Options,
+		}
+		for method, op := range methods {
+			if op == nil {
+				continue
+			}
+			servers := base
+			if len(op.Servers) > 0 {
+				servers = op.Servers
+			}
+			full := strings.TrimSuffix(servers[0].URL, "/") + path
+			ops = append(ops, documentedOp{method, full, op})
+		}
+	}
+	return &s, ops
+}
+
+// ginParam matches a gin path parameter like ":id".
+var ginParam = regexp.MustCompile(`:([^/]+)`)
+
+// TestSpecCoversRoutes verifies every route is in the spec, and every
+// operation in the spec is routed.
+func TestSpecCoversRoutes(t *testing.T) {
+	r, _ := setupTestRouter(t)
+	_, ops := loadSpec(t)
+
+	documented := make(map[string]bool)
+	for _, op := range ops {
+		documented[op.method+" "+op.path] = true
+	}
+	routed := make(map[string]bool)
+	for _, route := range r.Routes() {
+		key := route.Method + " " + ginParam.ReplaceAllString(route.Path, "{$1}")
+		routed[key] = true
+		if !documented[key] && !undocumentedRoutes[route.Method+" "+route.Path] {
+			t.Errorf("%s is routed but not in openapi.yaml", key)
+		}
+	}
+	for key := range documented {
+		if !routed[key] {
+			t.Errorf("%s is in openapi.yaml but not routed", key)
+		}
+	}
+}
+
+// TestResponsesMatchSpec calls endpoints across the API and checks each
+// response's status is documented and its JSON body matches the schema the
+// spec gives it.
+func TestResponsesMatchSpec(t *testing.T) {
+	r, db := setupTestRouter(t)
+	s, ops := loadSpec(t)
+	ctx := context.Background()
+
+	// send makes a request and checks the response against the spec,
+	// returning the decoded body.
+	send := func(method, path string, headers map[string]string, body any, wantStatus int) map[string]any {
+		t.Helper()
+		var reader *bytes.Reader
+		if body != nil {
+			data, _ := json.Marshal(body)
+			reader = bytes.NewReader(data)
+		} else {
+			reader = bytes.NewReader(nil)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != wantStatus {
+			t.Errorf("%s %s = %d, want %d: %s", method, path, w.Code, wantStatus, w.Body.String())
+			return nil
+		}
+		if op := findOperation(ops, method, strings.SplitN(path, "?", 2)[0]); op == nil {
+			t.Errorf("%s %s matches no operation in openapi.yaml", method, path)
+		} else {
+			v := &validator{spec: s}
+			v.checkResponse(op, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes())
+			for _, err := range v.errs {
+				t.Errorf("%s %s (%d): %s", method, path, w.Code, err)
+			}
+		}
+		var decoded map[string]any
+		json.Unmarshal(w.Body.Bytes(), &decoded)
+		return decoded
+	}
+
+	admin := map[string]string{"X-Admin-Key": testAdminKey}
+	created := send("POST", "/api/v1/keys", admin, map[string]string{"name": "contract", "tier": "enterprise", "role": "admin"}, http.StatusCreated)
+	if created == nil {
+		t.FailNow()
+	}
+	key := map[string]string{"X-API-Key": created["raw_key"].(string)}
+	keyID := created["id"].(string)
+	auth := send("POST", "/api/v1/auth/register", nil, map[string]string{"email": "ada@example.com", "password": "correct-horse", "name": "Ada"}, http.StatusCreated)
+	if auth == nil {
+		t.FailNow()
+	}
+	user := map[string]string{"Authorization": "Bearer " + auth["token"].(string)}
+
+	// Items of each type, owned by the key
+	transcript := send("POST", "/api/v1/transcripts", key, map[string]string{"url": "https://www.youtube.com/watch?v=dQw4w9WgXcQ"}, http.StatusAccepted)
+	at := &models.AudioTranscription{Filename: "a.mp3", OriginalName: "standup.mp3", Status: "completed",
+		TranscriptText: "We agreed to ship the release on Friday.", WordCount: 8, APIKeyID: &keyID}
+	if err := db.CreateAudioTranscription(ctx, at); err != nil {
+		t.Fatalf("CreateAudioTranscription: %v", err)
+	}
+	pe := &models.PDFExtraction{Filename: "p.pdf", OriginalName: "spec.pdf", Status: "completed",
+		TextContent: "Release checklist", WordCount: 2, PageCount: 1, FirstPage: 1, LastPage: 1, APIKeyID: &keyID,
+		Tables: []byte(`[{"page":1,"rows":[["Item","Owner"],["Tests","Ada"]]}]`), TableCount: 1,
+		DocumentInfo: []byte(`{"title":"Release checklist","created_at":"2024-03-15T14:30:00Z"}`),
+		Outline:      []byte(`[{"title":"Checklist","page":1,"children":[{"title":"Tests","page":1}]}]`)}
+	if err := db.CreatePDFExtraction(ctx, pe); err != nil {
+		t.Fatalf("CreatePDFExtraction: %v", err)
+	}
+
+	send("GET", "/api/v1/health", nil, nil, http.StatusOK)
+	send("GET", "/healthz", nil, nil, http.StatusOK)
+	send("GET", "/api/v1/tiers", key, nil, http.StatusOK)
+	send("GET", "/api/v1/keys", key, nil, http.StatusOK)
+
+	send("GET", "/api/v1/transcripts/"+transcript["id"].(string), key, nil, http.StatusOK)
+	send("GET", "/api/v1/transcripts", key, nil, http.StatusOK)
+	send("GET", "/api/v1/transcripts/00000000-0000-0000-0000-000000000000", key, nil, http.StatusNotFound)
+	send("GET", "/api/v1/transcripts", nil, nil, http.StatusUnauthorized)
+	send("GET", "/api/v1/audio/transcriptions", key, nil, http.StatusOK)
+	send("GET", "/api/v1/audio/transcriptions/"+at.ID, key, nil, http.StatusOK)
+	send("GET", "/api/v1/audio/transcriptions/search?q=release", key, nil, http.StatusOK)
+	send("GET", "/api/v1/audio/live", key, nil, http.StatusServiceUnavailable) // No Whisper key in tests
+	send("PATCH", "/api/v1/audio/transcriptions/"+at.ID, key, map[string]any{"title": "Standup", "tags": []string{"team"}}, http.StatusOK)
+	send("GET", "/api/v1/audio/transcriptions/"+at.ID+"/minutes?format=json", key, nil, http.StatusNotFound)
+	send("GET", "/api/v1/pdf/extractions", key, nil, http.StatusOK)
+	send("GET", "/api/v1/pdf/extractions/"+pe.ID, key, nil, http.StatusOK)
+	send("GET", "/api/v1/pdf/extractions/"+pe.ID+"/pages/1", key, nil, http.StatusOK)
+	send("POST", "/api/v1/pdf/extract-url", key, map[string]any{"url": "http://127.0.0.1/report.pdf"}, http.StatusBadRequest)
+	send("POST", "/api/v1/articles/extract", key, map[string]any{"url": "http://127.0.0.1/post"}, http.StatusBadRequest)
+	send("GET", "/api/v1/pdf/extractions/"+pe.ID+"/tables", key, nil, http.StatusOK)
+	send("GET", "/api/v1/pdf/extractions/"+pe.ID+"/tables?format=csv", key, nil, http.StatusOK)
+	send("PATCH", "/api/v1/pdf/extractions/"+pe.ID, key, map[string]any{"notes": "Signed copy"}, http.StatusOK)
+	send("GET", "/api/v1/transcripts/"+transcript["id"].(string)+"/study", key, nil, http.StatusNotFound)
+	send("GET", "/api/v1/items", key, nil, http.StatusOK)
+	send("GET", "/api/v1/search/text?q=release", key, nil, http.StatusOK)
+	send("GET", "/api/v1/stats", key, nil, http.StatusOK)
+	send("POST", "/api/v1/searches", key, map[string]any{"name": "Releases", "query": "release"}, http.StatusCreated)
+	send("GET", "/api/v1/searches", key, nil, http.StatusOK)
+	send("GET", "/api/v1/summaries", key, nil, http.StatusOK)
+	send("GET", "/api/v1/pipelines", key, nil, http.StatusOK)
+	send("GET", "/api/v1/retention", key, nil, http.StatusOK)
+	send("GET", "/api/v1/shares", key, nil, http.StatusOK)
+	send("GET", "/api/v1/keys/"+keyID+"/spend", key, nil, http.StatusOK)
+	hook := send("POST", "/api/v1/webhooks", key, map[string]any{"url": "https://example.com/hook", "events": []string{"audio.completed"},
+		"headers": map[string]string{"X-Gateway-Key": "gw"}, "basic_auth": map[string]string{"username": "hooks", "password": "pw"}}, http.StatusCreated)
+	send("GET", "/api/v1/webhooks", key, nil, http.StatusOK)
+	send("GET", "/api/v1/webhooks/deliveries", key, nil, http.StatusOK)
+	if hook != nil {
+		send("PATCH", "/api/v1/webhooks/"+hook["id"].(string), key, map[string]bool{"active": false}, http.StatusOK)
+		delivery := &models.WebhookDelivery{WebhookID: hook["id"].(string), Event: "audio.completed", Payload: `{}`, Status: "failed", Attempts: 1, LastError: "HTTP 502", ResponseCode: 502}
+		if err := db.CreateWebhookDelivery(ctx, delivery); err != nil {
+			t.Fatalf("CreateWebhookDelivery: %v", err)
+		}
+		attempt := &models.WebhookDeliveryAttempt{DeliveryID: delivery.ID, Attempt: 1, ResponseCode: 502, ResponseBody: "Bad Gateway", LatencyMS: 12}
+		if err := db.CreateWebhookDeliveryAttempt(ctx, attempt); err != nil {
+			t.Fatalf("CreateWebhookDeliveryAttempt: %v", err)
+		}
+		send("GET", "/api/v1/webhooks/"+hook["id"].(string)+"/deliveries/"+delivery.ID, key, nil, http.StatusOK)
+		send("POST", "/api/v1/webhooks/"+hook["id"].(string)+"/rotate-secret", key, nil, http.StatusOK)
+		send("GET", "/api/v1/webhooks", key, nil, http.StatusOK)
+		send("POST", "/api/v1/webhooks/"+hook["id"].(string)+"/rotate-secret", key, map[string]int{"grace_hours": 0}, http.StatusOK)
+		send("POST", "/api/v1/webhooks/"+hook["id"].(string)+"/rotate-secret", key, map[string]int{"grace_hours": 1000}, http.StatusBadRequest)
+		send("GET", "/api/v1/webhooks/"+hook["id"].(string)+"/deliveries/"+hook["id"].(string), key, nil, http.StatusNotFound)
+		send("PATCH", "/api/v1/webhooks/"+hook["id"].(string), key, map[string]any{"headers": map[string]string{}, "basic_auth": map[string]string{"username": ""}}, http.StatusOK)
+		send("DELETE", "/api/v1/webhooks/"+hook["id"].(string), key, nil, http.StatusOK)
+	}
+
+	send("POST", "/api/v1/auth/login", nil, map[string]string{"email": "ada@example.com", "password": "correct-horse"}, http.StatusOK)
+	send("GET", "/api/v1/auth/me", user, nil, http.StatusOK)
+	send("GET", "/api/v1/auth/sessions", user, nil, http.StatusOK)
+	send("GET", "/api/v1/me/keys", user, nil, http.StatusOK)
+	send("GET", "/api/v1/me/settings", user, nil, http.StatusOK)
+	send("POST", "/api/v1/orgs", user, map[string]string{"name": "Acme"}, http.StatusCreated)
+	send("GET", "/api/v1/orgs", user, nil, http.StatusOK)
+	send("PUT", "/api/v1/integrations/github", user, map[string]any{"token": "ghp_test", "config": map[string]string{"repo": "acme/roadmap"}}, http.StatusOK)
+	send("PUT", "/api/v1/integrations/linear", user, map[string]any{"token": "lin_test"}, http.StatusBadRequest)
+	send("GET", "/api/v1/integrations", user, nil, http.StatusOK)
+	send("DELETE", "/api/v1/integrations/github", user, nil, http.StatusOK)
+	send("POST", "/api/v1/workspace", user, map[string]string{"item_type": "pdf", "item_id": pe.ID}, http.StatusCreated)
+	send("GET", "/api/v1/workspace", user, nil, http.StatusOK)
+	send("DELETE", "/api/v1/workspace/pdf/"+pe.ID, user, nil, http.StatusOK)
+
+	send("GET", "/api/v1/admin/overview", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/keys", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/failures", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/webhooks", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/purges", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/watched-files", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/audit-log", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/cors-origins", admin, nil, http.StatusOK)
+	send("GET", "/api/v1/admin/settings", admin, nil, http.StatusOK)
+
+	send("DELETE", "/api/v1/transcripts/"+transcript["id"].(string), key, nil, http.StatusOK)
+	send("DELETE", "/api/v1/audio/transcriptions/"+at.ID, key, nil, http.StatusOK)
+	send("DELETE", "/api/v1/pdf/extractions/"+pe.ID, key, nil, http.StatusOK)
+}
+
+// findOperation returns the operation whose path template matches path,
+// preferring literal segments over parameters, as the router does
+// (/audio/transcriptions/search over /audio/transcriptions/{id}).
+func findOperation(ops []documentedOp, method, path string) *operation {
+	segments := strings.Split(path, "/")
+	var best *operation
+	bestLiterals := -1
+	for _, op := range ops {
+		template := strings.Split(op.path, "/")
+		if op.method != method || len(template) != len(segments) {
+			continue
+		}
+		literals := 0
+		for i, part := range template {
+			if strings.HasPrefix(part, "{") {
+				continue
+			}
+			if part != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals {
+			best, bestLiterals = op.op, literals
+		}
+	}
+	return best
+}
+
+// validator checks JSON values against the spec's schemas, collecting
+// what doesn't match.
+//
+// It's stricter than OpenAPI about one thing: an object property the schema
+// doesn't declare is an error unless the schema allows additionalProperties,
+// since an undocumented field is exactly the drift this catches.
+type validator struct {
+	spec *spec
+	errs []string
+}
+
+func (v *validator) errorf(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Sprintf(format, args...))
+}
+
+// checkResponse checks a response's status is documented and, when the
+// spec gives its JSON body a schema, that the body matches it.
+func (v *validator) checkResponse(op *operation, status int, contentType string, body []byte) {
+	resp, ok := op.Responses[fmt.Sprint(status)]
+	if !ok {
+		resp, ok = op.Responses["default"]
+	}
+	if !ok && commonResponses[status] != "" {
+		resp, ok = &response{Ref: "#/components/responses/" + commonResponses[status]}, true
+	}
+	if !ok {
+		v.errorf("status %d isn't documented", status)
+		return
+	}
+	if ref := resp.Ref; ref != "" {
+		resp = v.spec.Components.Responses[strings.TrimPrefix(ref, "#/components/responses/")]
+		if resp == nil {
+			v.errorf("unresolved response %s", ref)
+			return
+		}
+	}
+	if !strings.HasPrefix(contentType, "application/json") {
+		return
+	}
+	media, ok := resp.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		v.errorf("invalid JSON: %v", err)
+		return
+	}
+	v.check(media.Schema, value, "body")
+}
+
+// resolve follows a schema's $ref, which may point into another schema,
+// e.g. #/components/schemas/KeyRestrictions/properties/allowed_ips.
+func (v *validator) resolve(s *schema) *schema {
+	for s != nil && s.Ref != "" {
+		parts := strings.Split(strings.TrimPrefix(s.Ref, "#/components/schemas/"), "/")
+		next := v.spec.Components.Schemas[parts[0]]
+		for i := 1; next != nil && i < len(parts); i++ {
+			switch parts[i] {
+			case "properties":
+				i++
+				if i < len(parts) {
+					next = v.resolve(next).Properties[parts[i]]
+				}
+			case "items":
+				next = v.resolve(next).Items
+			default:
+				next = nil
+			}
+		}
+		if next == nil {
+			v.errorf("unresolved schema %s", s.Ref)
+		}
+		s = next
+	}
+	return s
+}
+
+// objectShape merges the properties and required fields of a schema and
+// its allOf members.
+func (v *validator) objectShape(s *schema) (props map[string]*schema, required []string, open bool) {
+	props = make(map[string]*schema)
+	var walk func(s *schema)
+	walk = func(s *schema) {
+		s = v.resolve(s)
+		if s == nil {
+			return
+		}
+		for name, p := range s.Properties {
+			props[name] = p
+		}
+		required = append(required, s.Required...)
+		if s.AdditionalProperties != nil && s.AdditionalProperties != false {
+			open = true
+		}
+		for _, member := range s.AllOf {
+			walk(member)
+		}
+	}
+	walk(s)
+	return props, required, open
+}
+
+// check validates value (decoded JSON) against s, at the named location.
+func (v *validator) check(s *schema, value any, at string) {
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+	if value == nil {
+		if !s.Nullable && (s.Type != "" || len(s.Properties) > 0) {
+			v.errorf("%s is null, but the schema isn't nullable", at)
+		}
+		return
+	}
+
+	if alternatives := append(slices.Clone(s.OneOf), s.AnyOf...); len(alternatives) > 0 {
+		for _, alt := range alternatives {
+			trial := &validator{spec: v.spec}
+			trial.check(alt, value, at)
+			if len(trial.errs) == 0 {
+				return
+			}
+		}
+		v.errorf("%s matches none of the oneOf/anyOf schemas", at)
+		return
+	}
+
+	typ := s.Type
+	if typ == "" && (len(s.Properties) > 0 || len(s.AllOf) > 0) {
+		typ = "object"
+	}
+	switch typ {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.errorf("%s is %T, want an object", at, value)
+			return
+		}
+		props, required, open := v.objectShape(s)
+		for _, name := range required {
+			if _, ok := obj[name]; !ok {
+				v.errorf("%s is missing required %q", at, name)
+			}
+		}
+		for name, field := range obj {
+			if p, ok := props[name]; ok {
+				v.check(p, field, at+"."+name)
+			} else if extra, ok := s.AdditionalProperties.(map[string]any); ok && len(extra) > 0 {
+				// A schema for additional properties: not worth decoding here
+			} else if !open && len(props) > 0 {
+				v.errorf("%s has undocumented property %q", at, name)
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			v.errorf("%s is %T, want an array", at, value)
+			return
+		}
+		for i, elem := range arr {
+			v.check(s.Items, elem, fmt.Sprintf("%s[%d]", at, i))
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			v.errorf("%s is %T, want a string", at, value)
+			return
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				v.errorf("%s = %q isn't a date-time", at, str)
+			}
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != float64(int64(n)) {
+			v.errorf("%s = %v, want an integer", at, value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			v.errorf("%s is %T, want a number", at, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.errorf("%s is %T, want a boolean", at, value)
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(value) }) {
+		v.errorf("%s = %v isn't one of %v", at, value, s.Enum)
+	}
+}
+
+// setupTestRouter builds the router against an in-memory SQLite database,
+// with admin endpoints enabled and no external services configured.
+func setupTestRouter(t *testing.T) (*gin.Engine, *database.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db, err := database.New("sqlite::memory:")
+	if err != nil {
+		t.Fatalf("database.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.RunMigrations("../../migrations"); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+
+	origins, _ := middleware.NewOriginMatcher(nil)
+	rs := settings.New(db, settings.Values{DefaultTier: models.TierFree, MaxKeysPerUser: 10, WorkerCount: 1})
+	wp := worker.NewPool(1, 100, db, nil, nil) // Not started, so jobs stay queued
+	ws := webhookservice.New(db)
+	box, _ := secretbox.New("test-encryption-key")
+	ws.SetSecretBox(box)
+	db.SetSecretBox(box)
+	r := Setup(db, wp, nil, ws, nil, nil, nil, mailer.LogMailer{},
+		middleware.NewRateLimiter("", ""), testJWTSecret, testAdminKey, "", "", "http://localhost:5173",
+		15*time.Minute, 720*time.Hour, rs, origins, nil, 0)
+	return r, db
+}
@@ -6,33 +6,59 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/handlers"
 	"github.com/Shimizu-Technology/media-tools-api/internal/middleware"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/billing"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/mailer"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/oauth"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/settings"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
 	webhookservice "github.com/Shimizu-Technology/media-tools-api/internal/services/webhook"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/worker"
 )
 
 // Setup creates and configures the Gin router with all routes.
-func Setup(db *database.DB, wp *worker.Pool, at *audio.Transcriber, ws *webhookservice.Service, sum *summary.Service, jwtSecret, adminAPIKey, ownerKeyID, ownerKeyPrefix string, allowedOrigins []string) *gin.Engine {
+// The rate limiter is passed in so the gRPC server can share its buckets.
+func Setup(db *database.DB, wp *worker.Pool, at *audio.Transcriber, ws *webhookservice.Service, sum *summary.Service, oc *oauth.Client, bc *billing.Client, mail mailer.Mailer, rateLimiter *middleware.RateLimiter, jwtSecret, adminAPIKey, ownerKeyID, ownerKeyPrefix, appURL string, accessTokenTTL, refreshTokenTTL time.Duration, rs *settings.Service, corsOrigins *middleware.OriginMatcher, trustedProxies []string, compressMinBytes int) *gin.Engine {
 	r := gin.Default()
 
-	// Set max multipart form size to 30MB (slightly above our 25MB limit for headers/overhead)
-	r.MaxMultipartMemory = 30 << 20 // 30MB
+	// Only believe X-Forwarded-For from our own proxies, so clients can't
+	// spoof their IP past per-key allowlists. Config has validated the list.
+	_ = r.SetTrustedProxies(trustedProxies)
 
-	r.Use(middleware.CORS(allowedOrigins))
+	// Uploads stream straight to temp files (see handlers/multipart.go), so
+	// there's no MaxMultipartMemory to tune.
+	r.Use(middleware.CORS(corsOrigins))
+	if compressMinBytes > 0 {
+		r.Use(middleware.Compress(compressMinBytes))
+	}
 
 	h := handlers.NewHandler(db, wp, at, ws, sum, jwtSecret, adminAPIKey, ownerKeyID, ownerKeyPrefix)
-	rateLimiter := middleware.NewRateLimiter(ownerKeyID, ownerKeyPrefix)
+	h.AccessTokenTTL = accessTokenTTL
+	h.RefreshTokenTTL = refreshTokenTTL
+	h.OAuth = oc
+	h.Billing = bc
+	h.Mailer = mail
+	h.AppURL = appURL
+	h.Settings = rs
+	h.CORSOrigins = corsOrigins
 
 	// --- Public Routes (no auth required) ---
 	r.GET("/api/v1/health", h.HealthCheck)
+	r.GET("/api/v1/health/deep", h.DeepHealthCheck)
+	r.GET("/healthz", h.Liveness) // Orchestrator liveness probe
+	r.GET("/readyz", h.Readiness) // Orchestrator readiness probe
 	r.POST("/api/v1/keys", h.CreateAPIKey)
+	r.GET("/api/v1/shared/:token", h.ViewSharedItem) // Public share links — the token is the credential
+	r.GET("/api/v1/originals/:key", h.ServeOriginal) // Signed original downloads — the signature is the credential
+	r.OPTIONS("/api/v1/uploads", h.UploadOptions)    // tus discovery — clients probe before authenticating
 
 	// API Documentation (MTA-10)
 	r.GET("/api/docs", h.ServeSwaggerUI)
@@ -41,71 +67,279 @@ func Setup(db *database.DB, wp *worker.Pool, at *audio.Transcriber, ws *webhooks
 	// --- Auth Routes (MTA-20) — public ---
 	r.POST("/api/v1/auth/register", h.Register)
 	r.POST("/api/v1/auth/login", h.Login)
+	r.POST("/api/v1/auth/refresh", h.RefreshToken) // The refresh token is the credential
+	r.POST("/api/v1/auth/logout", h.Logout)
+	r.GET("/api/v1/auth/oauth", h.ListOAuthProviders)
+	r.GET("/api/v1/auth/oauth/:provider", h.StartOAuth)
+	r.GET("/api/v1/auth/oauth/:provider/callback", h.OAuthCallback)
+	r.POST("/api/v1/auth/verify-email", h.VerifyEmail)
+	r.POST("/api/v1/auth/password-reset", h.RequestPasswordReset)
+	r.POST("/api/v1/auth/password-reset/confirm", h.ResetPassword)
+
+	requireVerified := middleware.RequireVerifiedEmail(db, func() bool { return rs.Current().RequireVerifiedEmail }) // AI features, when the require_verified_email setting is on
 
 	// --- JWT-protected routes (MTA-20) ---
 	jwtProtected := r.Group("/api/v1")
 	jwtProtected.Use(middleware.JWTAuth(db, jwtSecret))
 	{
 		jwtProtected.GET("/auth/me", h.GetMe)
-		jwtProtected.POST("/auth/refresh", h.RefreshToken)
+		jwtProtected.DELETE("/auth/me", h.DeleteAccount)
+		jwtProtected.POST("/auth/me/export", h.ExportAccount)
+		jwtProtected.POST("/auth/me/cancel-deletion", h.CancelAccountDeletion)
+		jwtProtected.GET("/auth/sessions", h.ListSessions)
+		jwtProtected.DELETE("/auth/sessions/:id", h.RevokeSession)
+		jwtProtected.POST("/auth/verify-email/send", h.SendVerificationEmail)
 		jwtProtected.GET("/workspace", h.GetWorkspace)
 		jwtProtected.POST("/workspace", h.SaveToWorkspace)
 		jwtProtected.DELETE("/workspace/:type/:id", h.RemoveFromWorkspace)
+		jwtProtected.GET("/integrations", h.ListIntegrations)
+		jwtProtected.PUT("/integrations/:provider", h.SaveIntegration)
+		jwtProtected.DELETE("/integrations/:provider", h.DeleteIntegration)
+
+		// Self-service API keys linked to the user
+		jwtProtected.GET("/me/keys", h.ListMyAPIKeys)
+		jwtProtected.POST("/me/keys", h.CreateMyAPIKey)
+		jwtProtected.PATCH("/me/keys/:id", h.RenameMyAPIKey)
+		jwtProtected.PUT("/me/keys/:id/restrictions", h.SetMyAPIKeyRestrictions)
+		jwtProtected.DELETE("/me/keys/:id", h.RevokeMyAPIKey)
+		jwtProtected.GET("/me/settings", h.GetMyAISettings)
+		jwtProtected.PATCH("/me/settings", h.UpdateMyAISettings)
+
+		// Weekly digests of everything the user processed
+		jwtProtected.GET("/me/digest", h.GetDigestSettings)
+		jwtProtected.PUT("/me/digest", h.UpdateDigestSettings)
+		jwtProtected.POST("/me/digests", requireVerified, h.CreateDigest)
+		jwtProtected.GET("/me/digests", h.ListDigests)
+		jwtProtected.GET("/me/digests/:id", h.GetDigest)
+
+		// Organizations — team workspaces sharing API keys and items
+		jwtProtected.POST("/orgs", h.CreateOrganization)
+		jwtProtected.GET("/orgs", h.ListOrganizations)
+		jwtProtected.GET("/orgs/:id", h.GetOrganization)
+		jwtProtected.DELETE("/orgs/:id", h.DeleteOrganization)
+		jwtProtected.GET("/orgs/:id/members", h.ListOrgMembers)
+		jwtProtected.PATCH("/orgs/:id/members/:user_id", h.UpdateOrgMember)
+		jwtProtected.DELETE("/orgs/:id/members/:user_id", h.RemoveOrgMember)
+		jwtProtected.POST("/orgs/:id/invitations", h.CreateOrgInvitation)
+		jwtProtected.GET("/orgs/:id/invitations", h.ListOrgInvitations)
+		jwtProtected.DELETE("/orgs/:id/invitations/:invitation_id", h.DeleteOrgInvitation)
+		jwtProtected.POST("/orgs/:id/keys", h.CreateOrgAPIKey)
+		jwtProtected.GET("/orgs/:id/keys", h.ListOrgAPIKeys)
+		jwtProtected.DELETE("/orgs/:id/keys/:key_id", h.RevokeOrgAPIKey)
+		jwtProtected.GET("/orgs/:id/items", h.ListOrgItems)
+		jwtProtected.POST("/invitations/accept", h.AcceptOrgInvitation)
+	}
+
+	// --- Admin routes (X-Admin-Key) — system-wide operational views ---
+	admin := r.Group("/api/v1/admin")
+	admin.Use(middleware.AdminAuth(adminAPIKey))
+	{
+		admin.GET("/overview", h.AdminOverview)
+		admin.GET("/keys", h.AdminListKeyVolumes)
+		admin.PUT("/keys/:id/tier", h.AdminSetKeyTier)
+		admin.PUT("/keys/:id/billing", h.AdminSetKeyBilling)
+		admin.GET("/failures", h.AdminListFailures)
+		admin.GET("/webhooks", h.AdminWebhookHealth)
+		admin.GET("/dependencies", h.AdminDependencies)
+		admin.GET("/purges", h.AdminListPurges)
+		admin.GET("/watched-files", h.AdminListWatchedFiles)
+		admin.GET("/audit-log", h.AdminListAuditLog)
+		admin.GET("/chat-feedback", h.AdminChatFeedback)
+		admin.GET("/cors-origins", h.AdminListCORSOrigins)
+		admin.POST("/cors-origins", h.AdminAddCORSOrigin)
+		admin.DELETE("/cors-origins/:id", h.AdminDeleteCORSOrigin)
+		admin.GET("/settings", h.AdminListSettings)
+		admin.PATCH("/settings", h.AdminUpdateSettings)
+		admin.DELETE("/settings/:key", h.AdminResetSetting)
 	}
 
 	// --- Protected Routes (API key OR JWT — backward compatible) ---
 	protected := r.Group("/api/v1")
 	protected.Use(middleware.DualAuth(db, jwtSecret))
 	protected.Use(rateLimiter.RateLimit())
+	// Keys suspended for an unpaid subscription, checked before impersonation
+	protected.Use(middleware.RequireBillingStanding())
+	protected.Use(middleware.Impersonate(db, ownerKeyID, ownerKeyPrefix)) // Admin keys acting as another key (X-Impersonate-Key), audit logged
+	requireAdmin := middleware.RequireRole(db, models.KeyRoleAdmin, ownerKeyID, ownerKeyPrefix)
+	// What API keys' tiers allow: features, and new items up to a monthly quota
+	requireQuota := middleware.RequireQuota(db, ownerKeyID, ownerKeyPrefix)
+	requireFeature := func(feature string) gin.HandlerFunc {
+		return middleware.RequireFeature(feature, ownerKeyID, ownerKeyPrefix)
+	}
+	requireAI := requireFeature(models.FeatureAI)
 	{
 		// Transcript endpoints
-		protected.POST("/transcripts", h.CreateTranscript)
+		protected.POST("/transcripts", requireQuota, h.CreateTranscript)
 		protected.GET("/transcripts", h.ListTranscripts)
+		protected.GET("/transcripts/duplicates", h.ListDuplicateTranscripts)
 		protected.GET("/transcripts/:id", h.GetTranscript)
+		protected.PATCH("/transcripts/:id", h.UpdateTranscript)
+		protected.PUT("/transcripts/:id/text", h.CorrectTranscript)
+		protected.GET("/transcripts/:id/edits", h.GetTranscriptEdits)
 		protected.DELETE("/transcripts/:id", h.DeleteTranscript)
+		protected.POST("/transcripts/:id/merge", h.MergeTranscripts)
 		protected.GET("/transcripts/:id/summaries", h.GetSummariesByTranscript)
 		protected.GET("/transcripts/:id/chat", h.GetTranscriptChat)
-		protected.POST("/transcripts/:id/chat", h.PostTranscriptChat)
+		protected.POST("/transcripts/:id/chat", requireAI, requireVerified, h.PostTranscriptChat)
 		protected.GET("/transcripts/:id/export", h.ExportTranscript)
+		protected.POST("/transcripts/:id/export/notion", h.ExportTranscriptToNotion)
+		protected.POST("/transcripts/:id/study", requireAI, requireVerified, h.CreateStudyAid)
+		protected.GET("/transcripts/:id/study", h.GetStudyAid)
+		protected.POST("/transcripts/:id/moments", requireAI, requireVerified, h.CreateMoments)
+		protected.POST("/transcripts/:id/chapters", requireAI, requireVerified, h.CreateChapters)
+		protected.POST("/transcripts/:id/social", requireAI, requireVerified, h.CreateTranscriptSocialPosts)
+		protected.POST("/transcripts/:id/article", requireAI, requireVerified, h.CreateArticle)
+		protected.GET("/transcripts/:id/article", h.GetArticle)
+		protected.POST("/transcripts/:id/entities", requireAI, requireVerified, h.ExtractTranscriptEntities)
+		protected.POST("/transcripts/:id/share", h.CreateTranscriptShare)
+
+		// All item types in one list
+		protected.GET("/items", h.ListItems)
+		protected.GET("/stats", h.GetStats)
+		protected.POST("/analysis/compare", requireAI, requireVerified, h.CompareItems)
+		protected.GET("/entities", h.ListEntities)
+		protected.GET("/entities/:id/mentions", h.ListEntityMentions)
+		protected.GET("/search/text", h.SearchText)
+		protected.POST("/searches", h.CreateSavedSearch)
+		protected.GET("/searches", h.ListSavedSearches)
+		protected.GET("/searches/:id/results", h.RunSavedSearch)
+		protected.DELETE("/searches/:id", h.DeleteSavedSearch)
+
+		// Tiers keys can be on
+		protected.GET("/tiers", h.ListTiers)
+
+		// Pipelines: stored step sequences run on videos
+		protected.POST("/pipelines", requireFeature(models.FeaturePipelines), h.CreatePipeline)
+		protected.GET("/pipelines", h.ListPipelines)
+		protected.GET("/pipelines/:id", h.GetPipeline)
+		protected.PUT("/pipelines/:id", requireFeature(models.FeaturePipelines), h.UpdatePipeline)
+		protected.DELETE("/pipelines/:id", h.DeletePipeline)
+		protected.POST("/pipelines/:id/runs", requireFeature(models.FeaturePipelines), requireVerified, requireQuota, h.StartPipelineRun)
+		protected.GET("/pipelines/:id/runs", h.ListPipelineRuns)
+		protected.GET("/pipelines/:id/runs/:run_id", h.GetPipelineRun)
 
 		// Batch processing (MTA-8)
-		protected.POST("/transcripts/batch", h.CreateBatch)
+		protected.POST("/transcripts/batch", requireFeature(models.FeatureBatch), requireQuota, h.CreateBatch)
+		protected.POST("/batches/upload", requireFeature(models.FeatureBatch), requireQuota, h.CreateUploadBatch)
 		protected.GET("/batches/:id", h.GetBatch)
 
+		// Bulk exports
+		protected.POST("/exports", h.CreateExport)
+		protected.GET("/exports/:id", h.GetExport)
+		protected.GET("/exports/:id/download", h.DownloadExport)
+
 		// Summary endpoints
-		protected.POST("/summaries", h.CreateSummary)
+		protected.POST("/summaries", requireAI, requireVerified, h.CreateSummary)
+		protected.GET("/summaries", h.ListSummaries)
+		protected.GET("/summaries/:id", h.GetSummary)
+		protected.DELETE("/summaries/:id", h.DeleteSummary)
+
+		// PII redaction for arbitrary text
+		protected.POST("/redact", h.Redact)
+
+		// Retention policy for the caller's key or user, and its purge log
+		protected.GET("/retention", h.GetRetention)
+		protected.PUT("/retention", h.UpdateRetention)
+		protected.GET("/retention/purges", h.ListPurges)
 
-		// API key management
-		protected.GET("/keys", h.ListAPIKeys)
-		protected.DELETE("/keys/:id", h.RevokeAPIKey)
+		// API key management — admin keys, or users who administer an organization
+		protected.GET("/keys", requireAdmin, h.ListAPIKeys)
+		protected.DELETE("/keys/:id", requireAdmin, h.RevokeAPIKey)
+		protected.PUT("/keys/:id/restrictions", requireAdmin, h.SetAPIKeyRestrictions)
 
-		// Audio transcription endpoints (MTA-16, MTA-22, MTA-25, MTA-26)
-		protected.POST("/audio/transcribe", h.TranscribeAudio)
+		// AI defaults — a key's own, or those of keys the caller manages
+		protected.GET("/keys/:id/settings", h.GetKeyAISettings)
+		protected.PATCH("/keys/:id/settings", h.UpdateKeyAISettings)
+
+		// AI spend this month, and monthly limits set by admins
+		protected.GET("/keys/:id/spend", h.GetKeySpend)
+		protected.PUT("/keys/:id/spend-limit", requireAdmin, h.SetKeySpendLimit)
+		protected.DELETE("/keys/:id/spend-limit", requireAdmin, h.DeleteKeySpendLimit)
+
+		// Audio transcription endpoints (MTA-16, MTA-22, MTA-25, MTA-26); /audio/live is a WebSocket
+		protected.POST("/audio/transcribe", requireFeature(models.FeatureAudio), requireQuota, h.TranscribeAudio)
+		protected.GET("/audio/live", requireFeature(models.FeatureAudio), requireQuota, h.LiveTranscribe)
 		protected.GET("/audio/transcriptions/search", h.SearchAudioTranscriptions) // MTA-25: must be before :id
 		protected.GET("/audio/transcriptions/:id", h.GetAudioTranscription)
+		protected.PATCH("/audio/transcriptions/:id", h.UpdateAudioTranscription)
 		protected.DELETE("/audio/transcriptions/:id", h.DeleteAudioTranscription)
-		protected.GET("/audio/transcriptions/:id/export", h.ExportAudioTranscription) // MTA-26
-		protected.POST("/audio/transcriptions/:id/summarize", h.SummarizeAudio)       // MTA-22
+		protected.GET("/audio/transcriptions/:id/export", h.ExportAudioTranscription)                       // MTA-26
+		protected.POST("/audio/transcriptions/:id/summarize", requireAI, requireVerified, h.SummarizeAudio) // MTA-22
+		protected.POST("/audio/transcriptions/:id/analyze", requireAI, requireVerified, h.AnalyzeAudio)
+		protected.POST("/audio/transcriptions/:id/action-items/push", h.PushActionItems)
+		protected.POST("/audio/transcriptions/:id/minutes", requireAI, requireVerified, h.GenerateMinutes)
+		protected.GET("/audio/transcriptions/:id/minutes", h.ExportMinutes)
+		protected.POST("/audio/transcriptions/:id/social", requireAI, requireVerified, h.CreateAudioSocialPosts)
+		protected.POST("/audio/transcriptions/:id/entities", requireAI, requireVerified, h.ExtractAudioEntities)
 		protected.GET("/audio/transcriptions/:id/chat", h.GetAudioChat)
-		protected.POST("/audio/transcriptions/:id/chat", h.PostAudioChat)
+		protected.POST("/audio/transcriptions/:id/chat", requireAI, requireVerified, h.PostAudioChat)
+		protected.POST("/audio/transcriptions/:id/share", h.CreateAudioShare)
+		protected.GET("/audio/transcriptions/:id/original", h.GetAudioOriginal)
+		protected.POST("/audio/transcriptions/:id/reprocess", h.ReprocessAudio)
 		protected.GET("/audio/transcriptions", h.ListAudioTranscriptions)
 
 		// PDF extraction endpoints (MTA-17)
-		protected.POST("/pdf/extract", h.ExtractPDF)
+		protected.POST("/pdf/extract", requireFeature(models.FeaturePDF), requireQuota, h.ExtractPDF)
+		protected.POST("/pdf/extract-url", requireFeature(models.FeaturePDF), requireQuota, h.ExtractPDFFromURL)
+		protected.POST("/articles/extract", requireFeature(models.FeaturePDF), requireQuota, h.ExtractArticle)
+
+		// Resumable uploads (tus) for audio and PDFs
+		protected.POST("/uploads", requireQuota, h.CreateUpload) // Checks the audio or pdf feature once it knows which
+		protected.HEAD("/uploads/:id", h.HeadUpload)
+		protected.PATCH("/uploads/:id", h.PatchUpload)
+		protected.DELETE("/uploads/:id", h.DeleteUpload)
 		protected.GET("/pdf/extractions/:id", h.GetPDFExtraction)
+		protected.GET("/pdf/extractions/:id/pages/:n", h.GetPDFPage)
+		protected.GET("/pdf/extractions/:id/tables", h.GetPDFTables)
+		protected.PATCH("/pdf/extractions/:id", h.UpdatePDFExtraction)
 		protected.DELETE("/pdf/extractions/:id", h.DeletePDFExtraction)
 		protected.GET("/pdf/extractions/:id/chat", h.GetPDFChat)
-		protected.POST("/pdf/extractions/:id/chat", h.PostPDFChat)
+		protected.POST("/pdf/extractions/:id/chat", requireAI, requireVerified, h.PostPDFChat)
+		protected.POST("/pdf/extractions/:id/entities", requireAI, requireVerified, h.ExtractPDFEntities)
+		protected.POST("/pdf/extractions/:id/share", h.CreatePDFShare)
+		protected.GET("/pdf/extractions/:id/original", h.GetPDFOriginal)
+		protected.POST("/pdf/extractions/:id/reprocess", h.ReprocessPDF)
 		protected.GET("/pdf/extractions", h.ListPDFExtractions)
 
-		// Webhook management (MTA-18)
-		protected.POST("/webhooks", h.CreateWebhook)
-		protected.GET("/webhooks", h.ListWebhooks)
-		protected.GET("/webhooks/deliveries", h.ListWebhookDeliveries)
-		protected.PATCH("/webhooks/:id", h.UpdateWebhook)
-		protected.DELETE("/webhooks/:id", h.DeleteWebhook)
+		// AI models requests may pick
+		protected.GET("/models", h.ListModels)
+
+		// Chat answer feedback
+		protected.POST("/chat/messages/:id/feedback", h.PostChatFeedback)
+		protected.GET("/chat/sessions/:id/export", h.ExportChatSession)
+
+		// Webhook management (MTA-18) — admin keys on tiers with webhooks
+		protected.POST("/webhooks", requireAdmin, requireFeature(models.FeatureWebhooks), h.CreateWebhook)
+		protected.GET("/webhooks", requireAdmin, h.ListWebhooks)
+		protected.GET("/webhooks/deliveries", requireAdmin, h.ListWebhookDeliveries)
+		protected.PATCH("/webhooks/:id", requireAdmin, requireFeature(models.FeatureWebhooks), h.UpdateWebhook)
+		protected.DELETE("/webhooks/:id", requireAdmin, h.DeleteWebhook)
+		protected.POST("/webhooks/:id/rotate-secret", requireAdmin, requireFeature(models.FeatureWebhooks), h.RotateWebhookSecret)
+		protected.GET("/webhooks/:id/deliveries/:deliveryId", requireAdmin, h.GetWebhookDelivery)
+
+		// Share link management
+		protected.GET("/shares", h.ListShareLinks)
+		protected.DELETE("/shares/:id", h.RevokeShareLink)
+
+		// Live job status stream (SSE) — replaces polling GET /transcripts/:id
+		protected.GET("/events", h.StreamEvents)
 	}
 
+	// --- Billing (Stripe) — the webhook's signature is its credential, and
+	// the portal is outside the protected group so suspended keys can pay ---
+	r.POST("/api/v1/billing/webhook", h.StripeWebhook)
+	billingGroup := r.Group("/api/v1/billing")
+	billingGroup.Use(middleware.DualAuth(db, jwtSecret))
+	billingGroup.Use(rateLimiter.RateLimit())
+	billingGroup.GET("/portal", h.BillingPortal)
+
+	// --- GraphQL (same auth, rate limits, and billing standing as the REST API) ---
+	gql := r.Group("/api")
+	gql.Use(middleware.DualAuth(db, jwtSecret))
+	gql.Use(rateLimiter.RateLimit())
+	gql.Use(middleware.RequireBillingStanding())
+	gql.POST("/graphql", h.GraphQL)
+
 	// --- Static Frontend Serving (SPA) ---
 	// In production/Docker, the Go server serves the React frontend.
 	// In development, Vite runs separately on :5173 and proxies API calls here.
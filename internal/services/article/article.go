@@ -0,0 +1,350 @@
+// Package article turns HTML into plain text: whole documents (EPUB
+// chapters) with Text, and web pages with Extract, which first strips the
+// navigation, sidebars, and other boilerplate around the article.
+//
+// Extract follows the approach of Mozilla's Readability: paragraphs score
+// the elements that contain them, link-heavy elements are penalized, and
+// the best-scoring element is taken as the article body.
+package article
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+// ErrNoContent is returned when a page has no readable text.
+var ErrNoContent = errors.New("no article text found on the page")
+
+// Article is the readable content of a web page.
+type Article struct {
+	Title  string
+	Byline string // Author, from the page's metadata
+	Site   string // Site name, from the page's metadata
+	Text   string // Paragraphs separated by blank lines
+}
+
+// minParagraphLen is the shortest text that counts as a paragraph when
+// scoring; shorter ones are captions, buttons, and bylines.
+const minParagraphLen = 25
+
+// skipTags never hold article text.
+var skipTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Svg: true, atom.Canvas: true, atom.Object: true,
+	atom.Form: true, atom.Button: true, atom.Select: true, atom.Input: true, atom.Textarea: true,
+	atom.Head: true,
+}
+
+// boilerplateTags are page furniture around the article.
+var boilerplateTags = map[atom.Atom]bool{
+	atom.Nav: true, atom.Header: true, atom.Footer: true, atom.Aside: true, atom.Menu: true,
+}
+
+// Class and id patterns that mark an element as boilerplate, unless the
+// element also looks like content.
+var (
+	unlikelyRe = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|footer|menu|modal|nav|newsletter|popup|promo|related|remark|replies|share|shoutbox|sidebar|social|sponsor|subscribe|ad-break|advert`)
+	likelyRe   = regexp.MustCompile(`(?i)article|body|content|entry|main|page|post|story|text`)
+)
+
+// blockTags start a new paragraph in the text.
+var blockTags = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Blockquote: true, atom.Dd: true, atom.Details: true,
+	atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Hr: true, atom.Li: true, atom.Main: true, atom.Ol: true, atom.P: true, atom.Pre: true,
+	atom.Section: true, atom.Table: true, atom.Tr: true, atom.Ul: true, atom.Body: true,
+}
+
+// Extract reads a web page and returns its article. contentType is the
+// response's Content-Type, used to find the page's encoding.
+func Extract(r io.Reader, contentType string) (*Article, error) {
+	doc, err := parse(r, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Article{
+		Title:  metaContent(doc, "og:title"),
+		Byline: metaContent(doc, "author"),
+		Site:   metaContent(doc, "og:site_name"),
+	}
+	if a.Title == "" {
+		if title := find(doc, atom.Title); title != nil {
+			a.Title = collapse(textOf(title))
+		}
+	}
+
+	removeBoilerplate(doc)
+	root := bestCandidate(doc)
+	if root == nil {
+		root = find(doc, atom.Body)
+	}
+	if root != nil {
+		a.Text = render(root)
+	}
+	if a.Text == "" {
+		return nil, ErrNoContent
+	}
+	return a, nil
+}
+
+// Text converts a whole HTML document to plain text, keeping everything but
+// scripts and styles. title is the document's first heading, or its <title>.
+func Text(r io.Reader) (title, text string, err error) {
+	doc, err := parse(r, "text/html; charset=utf-8")
+	if err != nil {
+		return "", "", err
+	}
+	for _, a := range []atom.Atom{atom.H1, atom.H2, atom.H3, atom.Title} {
+		if n := find(doc, a); n != nil {
+			if title = collapse(textOf(n)); title != "" {
+				break
+			}
+		}
+	}
+	if body := find(doc, atom.Body); body != nil {
+		text = render(body)
+	}
+	return title, text, nil
+}
+
+// parse decodes and parses an HTML document.
+func parse(r io.Reader, contentType string) (*html.Node, error) {
+	utf8Reader, err := charset.NewReader(r, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("decoding page: %w", err)
+	}
+	doc, err := html.Parse(utf8Reader)
+	if err != nil {
+		return nil, fmt.Errorf("parsing page: %w", err)
+	}
+	return doc, nil
+}
+
+// metaContent returns the content of the <meta> tag with the given name or
+// property.
+func metaContent(doc *html.Node, name string) string {
+	var content string
+	walk(doc, func(n *html.Node) bool {
+		if content == "" && n.DataAtom == atom.Meta && (attr(n, "property") == name || attr(n, "name") == name) {
+			content = collapse(attr(n, "content"))
+		}
+		return content == ""
+	})
+	return content
+}
+
+// removeBoilerplate deletes the elements that can't be part of the article.
+func removeBoilerplate(doc *html.Node) {
+	var remove []*html.Node
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if skipTags[n.DataAtom] || boilerplateTags[n.DataAtom] || isHidden(n) {
+			remove = append(remove, n)
+			return false
+		}
+		if n.DataAtom != atom.Body && n.DataAtom != atom.Article && n.DataAtom != atom.Main {
+			match := attr(n, "class") + " " + attr(n, "id")
+			if unlikelyRe.MatchString(match) && !likelyRe.MatchString(match) {
+				remove = append(remove, n)
+				return false
+			}
+		}
+		return true
+	})
+	for _, n := range remove {
+		n.Parent.RemoveChild(n)
+	}
+}
+
+// isHidden reports whether an element is hidden from readers.
+func isHidden(n *html.Node) bool {
+	style := strings.ReplaceAll(attr(n, "style"), " ", "")
+	return hasAttr(n, "hidden") || attr(n, "aria-hidden") == "true" ||
+		strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden")
+}
+
+// bestCandidate scores each element by the paragraphs inside it and returns
+// the best, or nil if the page has no paragraphs.
+func bestCandidate(doc *html.Node) *html.Node {
+	scores := make(map[*html.Node]float64)
+	walk(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.P && n.DataAtom != atom.Pre && n.DataAtom != atom.Td && n.DataAtom != atom.Blockquote {
+			return true
+		}
+		text := collapse(textOf(n))
+		if utf8.RuneCountInString(text) < minParagraphLen {
+			return false
+		}
+		// One point per paragraph, per comma, and per 100 characters, up to 3
+		score := 1 + float64(strings.Count(text, ",")) + min(float64(utf8.RuneCountInString(text))/100, 3)
+		if parent := n.Parent; parent != nil {
+			scores[parent] += score
+			if grandparent := parent.Parent; grandparent != nil {
+				scores[grandparent] += score / 2
+			}
+		}
+		return false
+	})
+
+	var best *html.Node
+	var bestScore float64
+	for n, score := range scores {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		score *= 1 - linkDensity(n)
+		if n.DataAtom == atom.Article || n.DataAtom == atom.Main {
+			score *= 1.25
+		}
+		if best == nil || score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+	return best
+}
+
+// linkDensity is the share of an element's text that is link text.
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(collapse(textOf(n)))
+	if total == 0 {
+		return 0
+	}
+	var linked int
+	walk(n, func(c *html.Node) bool {
+		if c.DataAtom == atom.A {
+			linked += utf8.RuneCountInString(collapse(textOf(c)))
+			return false
+		}
+		return true
+	})
+	return float64(linked) / float64(total)
+}
+
+// render converts an element to plain text: one paragraph per block
+// element, list items prefixed "- ", and preformatted text kept as is.
+func render(root *html.Node) string {
+	var paragraphs []string
+	var current strings.Builder
+	flush := func() {
+		if p := collapse(current.String()); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+		current.Reset()
+	}
+
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			current.WriteString(n.Data)
+			return
+		case n.Type != html.ElementNode && n.Type != html.DocumentNode:
+			return
+		case skipTags[n.DataAtom]:
+			return
+		case n.DataAtom == atom.Br:
+			current.WriteString(" ")
+			return
+		case n.DataAtom == atom.Pre:
+			flush()
+			if p := strings.Trim(textOf(n), "\n"); strings.TrimSpace(p) != "" {
+				paragraphs = append(paragraphs, p)
+			}
+			return
+		}
+
+		block := blockTags[n.DataAtom]
+		if block {
+			flush()
+			if n.DataAtom == atom.Li {
+				current.WriteString("- ")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+		if block {
+			if current.String() == "- " {
+				current.Reset() // An empty list item
+			}
+			flush()
+		}
+	}
+	visit(root)
+	flush()
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// textOf concatenates the text inside n.
+func textOf(n *html.Node) string {
+	var b strings.Builder
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+		return !skipTags[c.DataAtom]
+	})
+	return b.String()
+}
+
+// find returns the first element of type a, in document order.
+func find(doc *html.Node, a atom.Atom) *html.Node {
+	var found *html.Node
+	walk(doc, func(n *html.Node) bool {
+		if found == nil && n.Type == html.ElementNode && n.DataAtom == a {
+			found = n
+		}
+		return found == nil
+	})
+	return found
+}
+
+// walk visits n and its descendants depth first. visit returns false to
+// skip a node's children.
+func walk(n *html.Node, visit func(*html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling // visit may detach c
+		walk(c, visit)
+		c = next
+	}
+}
+
+// attr returns the value of n's attribute key.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasAttr reports whether n has attribute key.
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// collapse trims s and replaces each run of whitespace with one space.
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,101 @@
+package article
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const blogPost = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Why We Rewrote Our Scheduler | Example Blog</title>
+  <meta property="og:title" content="Why We Rewrote Our Scheduler">
+  <meta name="author" content="Jane Doe">
+  <meta property="og:site_name" content="Example Blog">
+  <script>var tracking = "ignore me";</script>
+</head>
+<body>
+  <header><a href="/">Home</a> <a href="/about">About</a></header>
+  <nav><ul><li><a href="/posts">All posts</a></li></ul></nav>
+  <div class="sidebar-widget"><p>Subscribe to our newsletter for weekly updates, tips, and more.</p></div>
+  <article>
+    <h1>Why We Rewrote Our Scheduler</h1>
+    <p>Our old scheduler handled a few hundred jobs a day, which was fine, until it wasn't.</p>
+    <p>Last spring, traffic grew tenfold, and jobs started piling up behind slow ones.</p>
+    <ul><li>Jobs now run in priority order</li><li>Retries back off</li></ul>
+    <pre>workers: 8
+queue: redis</pre>
+    <p style="display: none">Hidden text that readers never see, so it shouldn't count.</p>
+    <p>The rewrite took six weeks, and the queue hasn't backed up since.</p>
+  </article>
+  <div id="comments"><p>Great post, thanks for sharing all of these details with us!</p></div>
+  <footer>© 2024 Example</footer>
+</body>
+</html>`
+
+func TestExtract(t *testing.T) {
+	a, err := Extract(strings.NewReader(blogPost), "text/html; charset=utf-8")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if a.Title != "Why We Rewrote Our Scheduler" || a.Byline != "Jane Doe" || a.Site != "Example Blog" {
+		t.Errorf("Extract metadata = %q, %q, %q", a.Title, a.Byline, a.Site)
+	}
+
+	want := "Why We Rewrote Our Scheduler\n\n" +
+		"Our old scheduler handled a few hundred jobs a day, which was fine, until it wasn't.\n\n" +
+		"Last spring, traffic grew tenfold, and jobs started piling up behind slow ones.\n\n" +
+		"- Jobs now run in priority order\n\n- Retries back off\n\n" +
+		"workers: 8\nqueue: redis\n\n" +
+		"The rewrite took six weeks, and the queue hasn't backed up since."
+	if a.Text != want {
+		t.Errorf("Extract text = %q\nwant %q", a.Text, want)
+	}
+}
+
+func TestExtractTitleFallback(t *testing.T) {
+	page := `<html><head><title> Plain   Page </title></head><body><div><p>Short but real content, with a comma.</p></div></body></html>`
+	a, err := Extract(strings.NewReader(page), "text/html")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if a.Title != "Plain Page" || a.Text != "Short but real content, with a comma." {
+		t.Errorf("Extract = %q, %q", a.Title, a.Text)
+	}
+}
+
+func TestExtractCharset(t *testing.T) {
+	// "Café" in ISO-8859-1
+	page := "<html><body><p>Caf\xe9 opening hours, menus, and the story behind it.</p></body></html>"
+	a, err := Extract(strings.NewReader(page), "text/html; charset=iso-8859-1")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if !strings.HasPrefix(a.Text, "Café") {
+		t.Errorf("Extract text = %q", a.Text)
+	}
+}
+
+func TestExtractNoContent(t *testing.T) {
+	page := `<html><body><nav><a href="/">Home</a></nav><script>app()</script></body></html>`
+	if _, err := Extract(strings.NewReader(page), "text/html"); !errors.Is(err, ErrNoContent) {
+		t.Errorf("Extract error = %v, want ErrNoContent", err)
+	}
+}
+
+func TestText(t *testing.T) {
+	chapter := `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml"><head><title>ch01</title><style>p{}</style></head>
+<body><h2>Chapter One</h2><p>It was a bright cold day in April.</p><nav>Kept in chapters</nav></body></html>`
+	title, text, err := Text(strings.NewReader(chapter))
+	if err != nil {
+		t.Fatalf("Text: %v", err)
+	}
+	if title != "Chapter One" {
+		t.Errorf("Text title = %q", title)
+	}
+	if want := "Chapter One\n\nIt was a bright cold day in April.\n\nKept in chapters"; text != want {
+		t.Errorf("Text = %q, want %q", text, want)
+	}
+}
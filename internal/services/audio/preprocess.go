@@ -0,0 +1,106 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Preprocessing modes, set with AUDIO_PREPROCESS.
+const (
+	PreprocessNormalize = "normalize" // Loudness normalization, downsampled to 16 kHz mono
+	PreprocessTrim      = "trim"      // normalize, plus long silences removed
+)
+
+// PreprocessedExt is the extension of preprocessed audio, so Whisper knows
+// its format.
+const PreprocessedExt = ".mp3"
+
+// Preprocessor runs audio through ffmpeg before it's sent to Whisper.
+// Normalizing loudness helps with quiet or uneven phone recordings, and
+// Whisper resamples to 16 kHz mono anyway, so downsampling first shrinks
+// uploads without losing accuracy. Whisper bills by duration, so trimming
+// silence cuts cost — but segment timings then refer to the trimmed audio.
+type Preprocessor struct {
+	ffmpegPath string
+	mode       string
+}
+
+// NewPreprocessor creates a Preprocessor running ffmpeg at ffmpegPath in
+// the given mode (PreprocessNormalize or PreprocessTrim).
+func NewPreprocessor(ffmpegPath, mode string) *Preprocessor {
+	return &Preprocessor{ffmpegPath: ffmpegPath, mode: mode}
+}
+
+// Mode returns the preprocessing mode.
+func (p *Preprocessor) Mode() string {
+	return p.mode
+}
+
+// Version runs ffmpeg -version, confirming the binary is installed and runs.
+func (p *Preprocessor) Version(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, p.ffmpegPath, "-version").Output()
+	if err != nil {
+		return "", fmt.Errorf("ffmpeg not runnable: %w", err)
+	}
+	version, _, _ := strings.Cut(string(out), "\n")
+	return strings.TrimSpace(strings.TrimPrefix(version, "ffmpeg version ")), nil
+}
+
+// filters returns the ffmpeg audio filter chain for the mode.
+func (p *Preprocessor) filters() string {
+	filters := []string{"loudnorm=I=-16:TP=-1.5:LRA=11"} // EBU R128 speech levels
+	if p.mode == PreprocessTrim {
+		// After normalizing, so one threshold suits quiet and loud recordings.
+		// Leading silence goes, and any gap over a second is cut to half a second.
+		filters = append(filters, "silenceremove=start_periods=1:start_threshold=-50dB:"+
+			"stop_periods=-1:stop_duration=1:stop_threshold=-50dB:stop_silence=0.5")
+	}
+	return strings.Join(filters, ",")
+}
+
+// args returns the ffmpeg arguments converting input to output.
+func (p *Preprocessor) args(input, output string) []string {
+	return []string{
+		"-hide_banner", "-loglevel", "error", "-nostdin", "-y",
+		"-i", input,
+		"-vn", // Drop cover art and video tracks
+		"-af", p.filters(),
+		"-ac", "1", "-ar", "16000",
+		"-c:a", "libmp3lame", "-b:a", "48k",
+		output,
+	}
+}
+
+// Process converts the audio file at inputPath and returns the path of the
+// result, a temp file with PreprocessedExt that the caller removes.
+//
+// Go Pattern: exec.CommandContext kills ffmpeg if ctx is done, so a job
+// timeout also stops a stuck conversion.
+func (p *Preprocessor) Process(ctx context.Context, inputPath string) (string, error) {
+	out, err := os.CreateTemp("", "mta-preprocessed-*"+PreprocessedExt)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	out.Close()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.ffmpegPath, p.args(inputPath, out.Name())...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		os.Remove(out.Name())
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("ffmpeg failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("ffmpeg failed: %w", err)
+	}
+
+	if info, err := os.Stat(out.Name()); err != nil || info.Size() == 0 {
+		os.Remove(out.Name())
+		return "", fmt.Errorf("ffmpeg produced no audio")
+	}
+	return out.Name(), nil
+}
@@ -0,0 +1,51 @@
+package audio
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPreprocessFilters verifies only trim mode removes silence, and both
+// modes downsample to 16 kHz mono.
+func TestPreprocessFilters(t *testing.T) {
+	tests := []struct {
+		mode     string
+		wantTrim bool
+	}{
+		{PreprocessNormalize, false},
+		{PreprocessTrim, true},
+	}
+	for _, tt := range tests {
+		p := NewPreprocessor("ffmpeg", tt.mode)
+		filters := p.filters()
+		if !strings.HasPrefix(filters, "loudnorm") {
+			t.Errorf("%s: filters = %q, want loudnorm first", tt.mode, filters)
+		}
+		if got := strings.Contains(filters, "silenceremove"); got != tt.wantTrim {
+			t.Errorf("%s: filters = %q, trims silence = %v", tt.mode, filters, got)
+		}
+		args := strings.Join(p.args("in.m4a", "out.mp3"), " ")
+		if !strings.Contains(args, "-ac 1 -ar 16000") || !strings.HasSuffix(args, "out.mp3") {
+			t.Errorf("%s: args = %q", tt.mode, args)
+		}
+	}
+}
+
+// TestPreprocessMissingFFmpeg verifies a failed run reports an error and
+// leaves no temp file behind.
+func TestPreprocessMissingFFmpeg(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	p := NewPreprocessor(filepath.Join(tmp, "no-ffmpeg"), PreprocessNormalize)
+	if _, err := p.Process(context.Background(), "in.m4a"); err == nil {
+		t.Fatal("Process succeeded without ffmpeg")
+	}
+	if leftover, _ := filepath.Glob(filepath.Join(tmp, "mta-preprocessed-*")); len(leftover) > 0 {
+		t.Errorf("temp files left behind: %v", leftover)
+	}
+	if _, err := p.Version(context.Background()); err == nil {
+		t.Error("Version succeeded without ffmpeg")
+	}
+}
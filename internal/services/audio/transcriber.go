@@ -14,19 +14,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/transcript"
 )
 
 // TranscriptionResult holds the output from a Whisper API call.
 type TranscriptionResult struct {
-	Text     string  `json:"text"`
-	Language string  `json:"language"`
-	Duration float64 `json:"duration"`
+	Text     string           `json:"text"`
+	Language string           `json:"language"`
+	Duration float64          `json:"duration"`
+	Segments []models.Segment `json:"segments"`
+	// How sure the model was of the language it detected, 0-1; nil when it
+	// was given a hint or doesn't detect languages
+	LanguageConfidence *float64 `json:"language_confidence,omitempty"`
+	// The original-language transcript, for translations with KeepSource
+	SourceText string `json:"source_text,omitempty"`
 }
 
 // whisperResponse is the JSON shape returned by the Whisper API
@@ -35,8 +43,84 @@ type whisperResponse struct {
 	Text     string  `json:"text"`
 	Language string  `json:"language"`
 	Duration float64 `json:"duration"`
+	Segments []struct {
+		Start      float64 `json:"start"`
+		End        float64 `json:"end"`
+		Text       string  `json:"text"`
+		AvgLogprob float64 `json:"avg_logprob"`
+	} `json:"segments"`
+}
+
+// diarizedResponse is the JSON shape returned for response_format
+// "diarized_json"; segments carry a speaker label instead of a language.
+type diarizedResponse struct {
+	Text     string  `json:"text"`
+	Duration float64 `json:"duration"`
+	Segments []struct {
+		Start   float64 `json:"start"`
+		End     float64 `json:"end"`
+		Text    string  `json:"text"`
+		Speaker string  `json:"speaker"`
+	} `json:"segments"`
+}
+
+// Transcription models. Only whisper-1 returns timed segments and a detected
+// language; the gpt-4o models return plain text.
+const (
+	DefaultModel = "whisper-1"
+	DiarizeModel = "gpt-4o-transcribe-diarize"
+)
+
+// ValidModels lists the models a caller may pick.
+var ValidModels = map[string]bool{
+	DefaultModel:             true,
+	"gpt-4o-transcribe":      true,
+	"gpt-4o-mini-transcribe": true,
+	DiarizeModel:             true,
 }
 
+// TranscribeOptions tunes a transcription. The zero value is whisper-1 with
+// language detection.
+type TranscribeOptions struct {
+	Model      string `json:"model,omitempty"`       // One of ValidModels; empty = DefaultModel
+	Language   string `json:"language,omitempty"`    // ISO-639-1 hint, e.g. "en"
+	Prompt     string `json:"prompt,omitempty"`      // Spellings and context to guide the model
+	Diarize    bool   `json:"diarize,omitempty"`     // Label speakers; implies DiarizeModel
+	Translate  bool   `json:"translate,omitempty"`   // Translate to English; implies DefaultModel
+	KeepSource bool   `json:"keep_source,omitempty"` // With Translate, also transcribe in the original language
+}
+
+// model returns the model to request, resolving Diarize and Translate.
+// Only whisper-1 translates.
+func (o TranscribeOptions) model() string {
+	switch {
+	case o.Diarize:
+		return DiarizeModel
+	case o.Translate:
+		return DefaultModel
+	case o.Model != "":
+		return o.Model
+	default:
+		return DefaultModel
+	}
+}
+
+// responseFormat returns the richest format the model supports.
+func (o TranscribeOptions) responseFormat() string {
+	switch o.model() {
+	case DefaultModel:
+		return "verbose_json"
+	case DiarizeModel:
+		return "diarized_json"
+	default:
+		return "json"
+	}
+}
+
+// defaultTranscribeTimeout bounds a Whisper request when the caller's
+// context has no deadline.
+const defaultTranscribeTimeout = 5 * time.Minute
+
 // Transcriber handles audio transcription via the OpenAI Whisper API.
 type Transcriber struct {
 	apiKey     string
@@ -47,10 +131,9 @@ type Transcriber struct {
 func NewTranscriber(apiKey string) *Transcriber {
 	return &Transcriber{
 		apiKey: apiKey,
-		httpClient: &http.Client{
-			// Whisper can take a while for long audio files
-			Timeout: 5 * time.Minute,
-		},
+		// Requests are bounded by their context instead of a client timeout,
+		// so jobs can give long recordings more time; see Transcribe.
+		httpClient: &http.Client{},
 	}
 }
 
@@ -59,47 +142,125 @@ func (t *Transcriber) IsConfigured() bool {
 	return t.apiKey != ""
 }
 
+// CheckKey verifies OpenAI accepts the API key by listing models, which is
+// free, rather than transcribing anything.
+func (t *Transcriber) CheckKey(ctx context.Context) error {
+	if !t.IsConfigured() {
+		return fmt.Errorf("OpenAI API key not configured")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("OpenAI unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("OpenAI rejected the API key (HTTP %d)", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("OpenAI returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
 // Transcribe sends an audio file to the Whisper API and returns the transcription.
-//
-// Go Pattern: We build a multipart form body manually. In Go, multipart.Writer
-// handles the boundary generation and MIME encoding — similar to FormData in JS.
 func (t *Transcriber) Transcribe(ctx context.Context, audioData io.Reader, filename string) (*TranscriptionResult, error) {
+	return t.TranscribeWithOptions(ctx, audioData, filename, TranscribeOptions{})
+}
+
+// TranscribeWithOptions transcribes with a chosen model, language hint,
+// prompt, or speaker diarization, or translates to English.
+func (t *Transcriber) TranscribeWithOptions(ctx context.Context, audioData io.Reader, filename string, opts TranscribeOptions) (*TranscriptionResult, error) {
 	if !t.IsConfigured() {
 		return nil, fmt.Errorf("OpenAI API key not configured; set OPENAI_API_KEY environment variable")
 	}
 
-	// Build multipart form body
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
+	// Whisper can take a while for long audio files; callers without a
+	// deadline get defaultTranscribeTimeout.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTranscribeTimeout)
+		defer cancel()
+	}
 
-	// Add the audio file
-	part, err := writer.CreateFormFile("file", filename)
+	if opts.Translate {
+		return t.translate(ctx, audioData, filename, opts)
+	}
+	respBody, err := t.post(ctx, "transcriptions", audioData, filename, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, err
 	}
+	return parseTranscription(respBody, opts)
+}
 
-	if _, err := io.Copy(part, audioData); err != nil {
-		return nil, fmt.Errorf("failed to copy audio data: %w", err)
+// translate asks Whisper for an English translation. With KeepSource, the
+// audio is buffered so a second request can transcribe it in its original
+// language; that pass supplies the detected language and confidence, which
+// the translation endpoint doesn't report.
+func (t *Transcriber) translate(ctx context.Context, audioData io.Reader, filename string, opts TranscribeOptions) (*TranscriptionResult, error) {
+	var data []byte
+	if opts.KeepSource {
+		var err error
+		if data, err = io.ReadAll(audioData); err != nil {
+			return nil, fmt.Errorf("failed to read audio data: %w", err)
+		}
+		audioData = bytes.NewReader(data)
 	}
 
-	// Add the model parameter (whisper-1 is currently the only model)
-	if err := writer.WriteField("model", "whisper-1"); err != nil {
-		return nil, fmt.Errorf("failed to write model field: %w", err)
+	respBody, err := t.post(ctx, "translations", audioData, filename, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	// Request verbose JSON for language detection and duration
-	if err := writer.WriteField("response_format", "verbose_json"); err != nil {
-		return nil, fmt.Errorf("failed to write response_format field: %w", err)
+	result, err := parseTranscription(respBody, opts)
+	if err != nil {
+		return nil, err
+	}
+	// The reported language is the translation's, not the recording's
+	result.Language, result.LanguageConfidence = opts.Language, nil
+	if !opts.KeepSource {
+		return result, nil
 	}
 
-	// Close the writer to finalize the multipart body
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	sourceOpts := opts
+	sourceOpts.Translate, sourceOpts.KeepSource = false, false
+	respBody, err = t.post(ctx, "transcriptions", bytes.NewReader(data), filename, sourceOpts)
+	if err != nil {
+		return nil, fmt.Errorf("source transcription failed: %w", err)
+	}
+	source, err := parseTranscription(respBody, sourceOpts)
+	if err != nil {
+		return nil, err
 	}
+	result.SourceText = source.Text
+	result.Language, result.LanguageConfidence = source.Language, source.LanguageConfidence
+	return result, nil
+}
+
+// post sends audio to a Whisper endpoint ("transcriptions" or
+// "translations") and returns the response body.
+//
+// Go Pattern: multipart.Writer handles the boundary generation and MIME
+// encoding — similar to FormData in JS. It writes into an io.Pipe whose
+// other end is the request body, so the audio streams to the API as it's
+// read and a large recording is never held in memory.
+func (t *Transcriber) post(ctx context.Context, endpoint string, audioData io.Reader, filename string, opts TranscribeOptions) ([]byte, error) {
+	body, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		// An error here fails the request as its body is read
+		pw.CloseWithError(writeForm(writer, audioData, filename, opts))
+	}()
 
 	// Create the HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/audio/transcriptions", &body)
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/audio/"+endpoint, body)
 	if err != nil {
+		body.Close() // Stops the writer
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -123,18 +284,114 @@ func (t *Transcriber) Transcribe(ctx context.Context, audioData io.Reader, filen
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("Whisper API returned status %d: %s", resp.StatusCode, string(respBody))
 	}
+	return respBody, nil
+}
+
+// writeForm writes the multipart form for a Whisper request: the audio file
+// and the fields opts calls for.
+func writeForm(writer *multipart.Writer, audioData io.Reader, filename string, opts TranscribeOptions) error {
+	// Add the audio file
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+
+	if _, err := io.Copy(part, audioData); err != nil {
+		return fmt.Errorf("failed to copy audio data: %w", err)
+	}
+
+	// Request the richest response the model supports: verbose JSON gives
+	// language detection and segments, diarized JSON gives speakers
+	fields := [][2]string{
+		{"model", opts.model()},
+		{"response_format", opts.responseFormat()},
+	}
+	if opts.Language != "" && !opts.Translate { // Translations are always to English
+		fields = append(fields, [2]string{"language", opts.Language})
+	}
+	if opts.Prompt != "" && !opts.Diarize { // The diarize model doesn't take prompts
+		fields = append(fields, [2]string{"prompt", opts.Prompt})
+	}
+	if opts.Diarize {
+		fields = append(fields, [2]string{"chunking_strategy", "auto"}) // Required for audio over 30s
+	}
+	for _, f := range fields {
+		if err := writer.WriteField(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to write %s field: %w", f[0], err)
+		}
+	}
+
+	// Close the writer to finalize the multipart body
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
+// parseTranscription reads a response in the format opts requested.
+// Models that don't detect the language report the caller's hint.
+func parseTranscription(body []byte, opts TranscribeOptions) (*TranscriptionResult, error) {
+	if opts.responseFormat() == "diarized_json" {
+		var resp diarizedResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse diarized response: %w", err)
+		}
+		segments := make([]models.Segment, 0, len(resp.Segments))
+		for _, seg := range resp.Segments {
+			if text := strings.TrimSpace(seg.Text); text != "" {
+				segments = append(segments, models.Segment{Start: seg.Start, End: seg.End, Text: text, Speaker: seg.Speaker})
+			}
+		}
+		return &TranscriptionResult{Text: resp.Text, Language: opts.Language, Duration: resp.Duration, Segments: segments}, nil
+	}
 
-	// Parse the response
 	var whisperResp whisperResponse
-	if err := json.Unmarshal(respBody, &whisperResp); err != nil {
+	if err := json.Unmarshal(body, &whisperResp); err != nil {
 		return nil, fmt.Errorf("failed to parse Whisper response: %w", err)
 	}
 
-	return &TranscriptionResult{
+	segments := make([]models.Segment, 0, len(whisperResp.Segments))
+	for _, seg := range whisperResp.Segments {
+		if text := strings.TrimSpace(seg.Text); text != "" {
+			segments = append(segments, models.Segment{Start: seg.Start, End: seg.End, Text: text})
+		}
+	}
+
+	result := &TranscriptionResult{
 		Text:     whisperResp.Text,
 		Language: whisperResp.Language,
 		Duration: whisperResp.Duration,
-	}, nil
+		Segments: segments,
+	}
+	switch {
+	case result.Language == "":
+		result.Language = opts.Language
+	case opts.Language == "":
+		result.LanguageConfidence = detectionConfidence(whisperResp)
+	}
+	return result, nil
+}
+
+// detectionConfidence estimates how sure Whisper was of the language it
+// detected: the mean token probability across segments, weighted by their
+// length. Whisper reports no score for the detection itself, but a wrong
+// language shows up as low-probability text. It returns nil without
+// segments to go on.
+func detectionConfidence(resp whisperResponse) *float64 {
+	var weighted, total float64
+	for _, seg := range resp.Segments {
+		length := seg.End - seg.Start
+		if length <= 0 || strings.TrimSpace(seg.Text) == "" {
+			continue
+		}
+		weighted += math.Exp(seg.AvgLogprob) * length
+		total += length
+	}
+	if total == 0 {
+		return nil
+	}
+	confidence := math.Round(weighted/total*100) / 100
+	return &confidence
 }
 
 // CountWords counts the number of words in a text string.
@@ -160,6 +417,7 @@ func (a *WhisperAdapter) TranscribeForYouTube(ctx context.Context, audioData io.
 		Text:     result.Text,
 		Language: result.Language,
 		Duration: result.Duration,
+		Segments: result.Segments,
 	}, nil
 }
 
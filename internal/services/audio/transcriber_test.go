@@ -0,0 +1,156 @@
+// transcriber_test.go contains tests for transcription options, requests,
+// and parsing.
+package audio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestTranscribeOptionsFormat verifies each model gets a response format it
+// supports, and diarize and translate override the model.
+func TestTranscribeOptionsFormat(t *testing.T) {
+	tests := []struct {
+		opts       TranscribeOptions
+		wantModel  string
+		wantFormat string
+	}{
+		{TranscribeOptions{}, DefaultModel, "verbose_json"},
+		{TranscribeOptions{Model: "gpt-4o-transcribe"}, "gpt-4o-transcribe", "json"},
+		{TranscribeOptions{Diarize: true}, DiarizeModel, "diarized_json"},
+		{TranscribeOptions{Model: "gpt-4o-transcribe", Translate: true}, DefaultModel, "verbose_json"},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.model(); got != tt.wantModel {
+			t.Errorf("%+v: model = %q, want %q", tt.opts, got, tt.wantModel)
+		}
+		if got := tt.opts.responseFormat(); got != tt.wantFormat {
+			t.Errorf("%+v: format = %q, want %q", tt.opts, got, tt.wantFormat)
+		}
+	}
+}
+
+// TestParseTranscription verifies speakers are kept from diarized output,
+// the language hint fills in when the model doesn't detect one, and a
+// detected language comes with a confidence.
+func TestParseTranscription(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		opts         TranscribeOptions
+		wantLanguage string
+		wantSpeaker  string
+		wantSegments int
+		wantConf     float64 // 0 = no confidence reported
+	}{
+		{
+			name:         "verbose",
+			body:         `{"text":"hi there","language":"english","duration":2.5,"segments":[{"start":0,"end":2.5,"text":" hi there ","avg_logprob":-0.1}]}`,
+			wantLanguage: "english",
+			wantSegments: 1,
+			wantConf:     0.9,
+		},
+		{
+			name:         "verbose weighs long segments",
+			body:         `{"text":"a b","language":"french","segments":[{"start":0,"end":1,"text":"a","avg_logprob":-2.3},{"start":1,"end":10,"text":"b","avg_logprob":-0.05}]}`,
+			wantLanguage: "french",
+			wantSegments: 2,
+			wantConf:     0.87,
+		},
+		{
+			name:         "verbose with hint",
+			body:         `{"text":"hola","language":"spanish","segments":[{"start":0,"end":1,"text":"hola","avg_logprob":-0.1}]}`,
+			opts:         TranscribeOptions{Language: "es"},
+			wantLanguage: "spanish",
+			wantSegments: 1,
+		},
+		{
+			name:         "plain json with hint",
+			body:         `{"text":"hola"}`,
+			opts:         TranscribeOptions{Model: "gpt-4o-transcribe", Language: "es"},
+			wantLanguage: "es",
+		},
+		{
+			name:         "diarized",
+			body:         `{"text":"hi. hello.","duration":4,"segments":[{"start":0,"end":1,"text":"hi.","speaker":"A"},{"start":1,"end":4,"text":" ","speaker":"B"}]}`,
+			opts:         TranscribeOptions{Diarize: true},
+			wantSpeaker:  "A",
+			wantSegments: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTranscription([]byte(tt.body), tt.opts)
+			if err != nil {
+				t.Fatalf("parseTranscription: %v", err)
+			}
+			if got.Language != tt.wantLanguage || len(got.Segments) != tt.wantSegments {
+				t.Fatalf("got language %q, %d segments; want %q, %d", got.Language, len(got.Segments), tt.wantLanguage, tt.wantSegments)
+			}
+			var conf float64
+			if got.LanguageConfidence != nil {
+				conf = *got.LanguageConfidence
+			}
+			if conf != tt.wantConf {
+				t.Errorf("language confidence = %v, want %v", conf, tt.wantConf)
+			}
+			if tt.wantSegments > 0 && got.Segments[0].Speaker != tt.wantSpeaker {
+				t.Errorf("speaker = %q, want %q", got.Segments[0].Speaker, tt.wantSpeaker)
+			}
+		})
+	}
+}
+
+// roundTripFunc is an http.RoundTripper for stubbing the Whisper API.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// TestPostStreamsForm verifies the multipart form streamed to Whisper
+// holds the audio and fields, and that a failed audio read fails the request.
+func TestPostStreamsForm(t *testing.T) {
+	ctx := context.Background()
+	var form map[string]string
+	tr := &Transcriber{apiKey: "sk-test", httpClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		defer req.Body.Close()
+		if req.ContentLength > 0 {
+			t.Errorf("ContentLength = %d, want the body streamed", req.ContentLength)
+		}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			return nil, err
+		}
+		form = map[string]string{"model": req.FormValue("model"), "language": req.FormValue("language")}
+		file, header, err := req.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		data, _ := io.ReadAll(file)
+		form["file"] = header.Filename + ": " + string(data)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"text": "hi"}`))}, nil
+	})}}
+
+	body, err := tr.post(ctx, "transcriptions", strings.NewReader("RIFF audio"), "a.wav", TranscribeOptions{Language: "fr"})
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if string(body) != `{"text": "hi"}` {
+		t.Errorf("body = %s", body)
+	}
+	want := map[string]string{"model": DefaultModel, "language": "fr", "file": "a.wav: RIFF audio"}
+	for k, v := range want {
+		if form[k] != v {
+			t.Errorf("form %s = %q, want %q", k, form[k], v)
+		}
+	}
+
+	readErr := errors.New("disk went away")
+	_, err = tr.post(ctx, "transcriptions", io.MultiReader(strings.NewReader("RIFF"), iotest.ErrReader(readErr)), "a.wav", TranscribeOptions{})
+	if !errors.Is(err, readErr) {
+		t.Errorf("post with a failing reader = %v, want %v", err, readErr)
+	}
+}
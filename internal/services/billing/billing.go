@@ -0,0 +1,229 @@
+// Package billing connects API keys to Stripe: it reports their metered
+// usage, opens the customer portal, and reads subscription webhooks.
+//
+// Usage is reported as Stripe meter events. Create a meter in the Stripe
+// dashboard for each of MeterTranscriptionMinutes and MeterAITokens, using
+// the constant as its event name, and attach it to a metered price. Keys
+// are linked to a Stripe customer by an admin; usage of unlinked keys isn't
+// reported.
+package billing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Meter event names usage is reported under.
+const (
+	MeterTranscriptionMinutes = "transcription_minutes" // Whisper minutes, rounded up per file
+	MeterAITokens             = "ai_tokens"             // Prompt plus completion tokens
+)
+
+// webhookTolerance is how old a webhook's signed timestamp can be before
+// it's rejected as a replay.
+const webhookTolerance = 5 * time.Minute
+
+// Config holds the Stripe credentials. Billing is disabled without a
+// secret key.
+type Config struct {
+	SecretKey     string // sk_live_... or sk_test_...
+	WebhookSecret string // whsec_..., for verifying webhook signatures
+	ReturnURL     string // Where the customer portal sends customers back to
+}
+
+// Client calls the Stripe API.
+type Client struct {
+	httpClient    *http.Client
+	baseURL       string
+	secretKey     string
+	webhookSecret string
+	returnURL     string
+}
+
+// New creates a client for the Stripe API.
+func New(cfg Config) *Client {
+	return &Client{
+		httpClient:    &http.Client{Timeout: 15 * time.Second},
+		baseURL:       "https://api.stripe.com/v1",
+		secretKey:     cfg.SecretKey,
+		webhookSecret: cfg.WebhookSecret,
+		returnURL:     cfg.ReturnURL,
+	}
+}
+
+// IsConfigured reports whether a Stripe secret key is set.
+func (c *Client) IsConfigured() bool {
+	return c != nil && c.secretKey != ""
+}
+
+// ReportUsage records value units of meter for a customer. identifier
+// makes the report idempotent: Stripe ignores repeats of an identifier.
+func (c *Client) ReportUsage(ctx context.Context, customerID, meter string, value int64, identifier string, at time.Time) error {
+	form := url.Values{
+		"event_name":                  {meter},
+		"payload[stripe_customer_id]": {customerID},
+		"payload[value]":              {strconv.FormatInt(value, 10)},
+		"identifier":                  {identifier},
+		"timestamp":                   {strconv.FormatInt(at.Unix(), 10)},
+	}
+	var ignored struct{}
+	return c.post(ctx, "/billing/meter_events", form, &ignored)
+}
+
+// PortalURL creates a customer portal session, where customers update
+// their payment method and see invoices, and returns its URL.
+func (c *Client) PortalURL(ctx context.Context, customerID string) (string, error) {
+	form := url.Values{"customer": {customerID}}
+	if c.returnURL != "" {
+		form.Set("return_url", c.returnURL)
+	}
+	var session struct {
+		URL string `json:"url"`
+	}
+	if err := c.post(ctx, "/billing_portal/sessions", form, &session); err != nil {
+		return "", err
+	}
+	return session.URL, nil
+}
+
+// post sends a form-encoded request to the Stripe API and decodes the
+// JSON response into out.
+func (c *Client) post(ctx context.Context, path string, form url.Values, out interface{}) error {
+	if !c.IsConfigured() {
+		return fmt.Errorf("stripe is not configured; set STRIPE_SECRET_KEY")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("stripe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Stripe errors look like {"error":{"type":"...","message":"..."}}
+		var apiErr struct {
+			Error struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("stripe returned %d (%s): %s", resp.StatusCode, apiErr.Error.Type, apiErr.Error.Message)
+		}
+		return fmt.Errorf("stripe returned %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode stripe response: %w", err)
+	}
+	return nil
+}
+
+// Event is a Stripe webhook event.
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// Subscription is the part of a Stripe subscription that decides whether
+// its customer's keys are suspended.
+type Subscription struct {
+	ID       string `json:"id"`
+	Customer string `json:"customer"`
+	Status   string `json:"status"`
+}
+
+// ParseSubscription decodes the subscription a customer.subscription.*
+// event is about.
+func ParseSubscription(event *Event) (*Subscription, error) {
+	var sub Subscription
+	if err := json.Unmarshal(event.Data.Object, &sub); err != nil || sub.Customer == "" {
+		return nil, fmt.Errorf("event %s has no subscription", event.ID)
+	}
+	return &sub, nil
+}
+
+// Delinquent reports whether the subscription has gone unpaid: its keys
+// are suspended until it's active again.
+func (s *Subscription) Delinquent() bool {
+	switch s.Status {
+	case "past_due", "unpaid", "canceled", "incomplete_expired":
+		return true
+	}
+	return false
+}
+
+// ParseWebhook verifies a webhook's Stripe-Signature header against the
+// webhook secret and decodes the event. Signatures older than five minutes
+// are rejected so captured webhooks can't be replayed.
+func (c *Client) ParseWebhook(payload []byte, signature string, now time.Time) (*Event, error) {
+	if c == nil || c.webhookSecret == "" {
+		return nil, fmt.Errorf("stripe webhooks are not configured; set STRIPE_WEBHOOK_SECRET")
+	}
+
+	// The header looks like "t=1700000000,v1=<hex>,v1=<hex>"; there's more
+	// than one v1 while the secret is being rolled.
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return nil, fmt.Errorf("malformed Stripe-Signature header")
+	}
+	if age := now.Sub(time.Unix(signedAt, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return nil, fmt.Errorf("webhook timestamp is outside the %s tolerance", webhookTolerance)
+	}
+
+	mac := hmac.New(sha256.New, []byte(c.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+	verified := false
+	for _, s := range signatures {
+		if sig, err := hex.DecodeString(s); err == nil && hmac.Equal(sig, expected) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("webhook signature doesn't match")
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	return &event, nil
+}
@@ -0,0 +1,135 @@
+// billing_test.go verifies usage reports and portal sessions against a fake
+// Stripe API, and webhook signature checks.
+package billing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStripeRequests(t *testing.T) {
+	var meterForm, portalForm map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"Invalid API Key provided"}}`))
+			return
+		}
+		r.ParseForm()
+		form := make(map[string]string)
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+		switch r.URL.Path {
+		case "/billing/meter_events":
+			meterForm = form
+			w.Write([]byte(`{"object":"billing.meter_event"}`))
+		case "/billing_portal/sessions":
+			portalForm = form
+			w.Write([]byte(`{"url":"https://billing.stripe.com/p/session/abc"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(Config{SecretKey: "sk_test", ReturnURL: "https://app.example.com"})
+	c.baseURL = srv.URL
+	ctx := context.Background()
+
+	at := time.Unix(1700000000, 0)
+	if err := c.ReportUsage(ctx, "cus_1", MeterAITokens, 1234, "id-1", at); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+	want := map[string]string{
+		"event_name":                  "ai_tokens",
+		"payload[stripe_customer_id]": "cus_1",
+		"payload[value]":              "1234",
+		"identifier":                  "id-1",
+		"timestamp":                   "1700000000",
+	}
+	for k, v := range want {
+		if meterForm[k] != v {
+			t.Errorf("meter event %s = %q, want %q", k, meterForm[k], v)
+		}
+	}
+
+	url, err := c.PortalURL(ctx, "cus_1")
+	if err != nil || url != "https://billing.stripe.com/p/session/abc" {
+		t.Fatalf("PortalURL = %q, %v", url, err)
+	}
+	if portalForm["customer"] != "cus_1" || portalForm["return_url"] != "https://app.example.com" {
+		t.Errorf("portal session form = %v", portalForm)
+	}
+
+	bad := New(Config{SecretKey: "sk_wrong"})
+	bad.baseURL = srv.URL
+	if _, err := bad.PortalURL(ctx, "cus_1"); err == nil || !strings.Contains(err.Error(), "Invalid API Key") {
+		t.Errorf("PortalURL with a bad key = %v, want Stripe's error", err)
+	}
+	if err := New(Config{}).ReportUsage(ctx, "cus_1", MeterAITokens, 1, "id", at); err == nil {
+		t.Error("ReportUsage without a secret key succeeded")
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	c := New(Config{WebhookSecret: "whsec_test"})
+	now := time.Unix(1700000000, 0)
+	payload := []byte(`{"id":"evt_1","type":"customer.subscription.updated","data":{"object":{"id":"sub_1","customer":"cus_1","status":"past_due"}}}`)
+
+	sign := func(secret string, at time.Time) string {
+		ts := strconv.FormatInt(at.Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(ts + "."))
+		mac.Write(payload)
+		return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	event, err := c.ParseWebhook(payload, sign("whsec_test", now), now)
+	if err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	sub, err := ParseSubscription(event)
+	if err != nil || sub.Customer != "cus_1" || !sub.Delinquent() {
+		t.Errorf("ParseSubscription = %+v, %v; want cus_1, delinquent", sub, err)
+	}
+
+	// A rolled secret sends two signatures; either may match
+	rolled := sign("whsec_old", now) + ",v1=" + strings.SplitN(sign("whsec_test", now), "v1=", 2)[1]
+	if _, err := c.ParseWebhook(payload, rolled, now); err != nil {
+		t.Errorf("ParseWebhook with two signatures: %v", err)
+	}
+
+	for name, header := range map[string]string{
+		"wrong secret": sign("whsec_other", now),
+		"stale":        sign("whsec_test", now.Add(-10*time.Minute)),
+		"malformed":    "v1=abc",
+		"missing":      "",
+	} {
+		if _, err := c.ParseWebhook(payload, header, now); err == nil {
+			t.Errorf("ParseWebhook(%s) succeeded", name)
+		}
+	}
+	if _, err := New(Config{}).ParseWebhook(payload, sign("", now), now); err == nil {
+		t.Error("ParseWebhook without a webhook secret succeeded")
+	}
+}
+
+func TestDelinquent(t *testing.T) {
+	for status, want := range map[string]bool{
+		"active": false, "trialing": false, "incomplete": false,
+		"past_due": true, "unpaid": true, "canceled": true, "incomplete_expired": true,
+	} {
+		if got := (&Subscription{Status: status}).Delinquent(); got != want {
+			t.Errorf("Delinquent(%s) = %v, want %v", status, got, want)
+		}
+	}
+}
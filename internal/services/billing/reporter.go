@@ -0,0 +1,49 @@
+// reporter.go reports API keys' usage to the Stripe customers they're
+// linked to.
+package billing
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+)
+
+// Reporter reports metered usage for API keys. Reporting never fails the
+// work being billed: keys without a Stripe customer are skipped, and
+// errors are logged.
+type Reporter struct {
+	db     *database.DB
+	client *Client
+}
+
+// NewReporter creates a Reporter, or returns nil when Stripe isn't
+// configured. A nil Reporter reports nothing.
+func NewReporter(db *database.DB, client *Client) *Reporter {
+	if !client.IsConfigured() {
+		return nil
+	}
+	return &Reporter{db: db, client: client}
+}
+
+// Report records value units of meter against the Stripe customer keyID is
+// linked to.
+func (r *Reporter) Report(ctx context.Context, keyID, meter string, value int64) {
+	if r == nil || keyID == "" || value <= 0 {
+		return
+	}
+	customerID, err := r.db.GetAPIKeyStripeCustomer(ctx, keyID)
+	if err != nil {
+		log.Printf("⚠️  Failed to report %s usage for key %s: %v", meter, keyID, err)
+		return
+	}
+	if customerID == "" {
+		return
+	}
+	if err := r.client.ReportUsage(ctx, customerID, meter, value, uuid.NewString(), time.Now()); err != nil {
+		log.Printf("⚠️  Failed to report %d %s for key %s to Stripe: %v", value, meter, keyID, err)
+	}
+}
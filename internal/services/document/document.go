@@ -0,0 +1,85 @@
+// Package document renders simple structured documents (headings, paragraphs,
+// bullet lists) to Markdown, DOCX, and PDF.
+//
+// Go Pattern: Build the content once as a small tree of blocks, then hand it
+// to a writer per output format. Handlers never deal with file formats
+// directly — they describe WHAT goes in the document, not HOW it's encoded.
+//
+// Everything here uses only the standard library. DOCX is a zip of XML
+// parts, and the PDF writer uses the built-in Helvetica fonts, so no font
+// files or third-party packages are needed.
+package document
+
+// BlockKind identifies how a block is rendered.
+type BlockKind int
+
+const (
+	Heading   BlockKind = iota // Section heading (## in Markdown)
+	Paragraph                  // Plain paragraph
+	Bullet                     // Bulleted list item
+	Checkbox                   // Unchecked to-do item
+	Field                      // "Label: value" metadata line
+	PageBreak                  // Start a new page (horizontal rule in Markdown)
+)
+
+// Block is one unit of content.
+type Block struct {
+	Kind  BlockKind
+	Label string // Only used by Field
+	Text  string
+}
+
+// Document is an ordered list of blocks under a title.
+type Document struct {
+	Title  string
+	Blocks []Block
+}
+
+// New creates an empty document with a title.
+func New(title string) *Document {
+	return &Document{Title: title}
+}
+
+// Heading appends a section heading.
+func (d *Document) Heading(text string) *Document {
+	return d.add(Block{Kind: Heading, Text: text})
+}
+
+// Paragraph appends a paragraph of text.
+func (d *Document) Paragraph(text string) *Document {
+	return d.add(Block{Kind: Paragraph, Text: text})
+}
+
+// Bullets appends one bullet per item.
+func (d *Document) Bullets(items []string) *Document {
+	for _, item := range items {
+		d.add(Block{Kind: Bullet, Text: item})
+	}
+	return d
+}
+
+// Checkboxes appends one unchecked to-do per item.
+func (d *Document) Checkboxes(items []string) *Document {
+	for _, item := range items {
+		d.add(Block{Kind: Checkbox, Text: item})
+	}
+	return d
+}
+
+// Field appends a "Label: value" metadata line. Empty values are skipped.
+func (d *Document) Field(label, value string) *Document {
+	if value == "" {
+		return d
+	}
+	return d.add(Block{Kind: Field, Label: label, Text: value})
+}
+
+// PageBreak starts a new page — e.g. after a title page.
+func (d *Document) PageBreak() *Document {
+	return d.add(Block{Kind: PageBreak})
+}
+
+func (d *Document) add(b Block) *Document {
+	d.Blocks = append(d.Blocks, b)
+	return d
+}
@@ -0,0 +1,175 @@
+// document_test.go checks that each writer produces well-formed output.
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func sampleDoc() *Document {
+	return New("Weekly Sync").
+		Field("Date", "March 3, 2025").
+		Heading("Decisions").
+		Bullets([]string{"Ship v2 <on> Friday & celebrate"}).
+		Heading("Action Items").
+		Checkboxes([]string{"Alice — write release notes"}).
+		Paragraph("Closing (remarks) with a backslash \\ inside.")
+}
+
+func TestMarkdown(t *testing.T) {
+	got := string(sampleDoc().Markdown())
+	for _, want := range []string{
+		"# Weekly Sync\n",
+		"**Date:** March 3, 2025  \n",
+		"## Decisions\n",
+		"- Ship v2 <on> Friday & celebrate\n",
+		"- [ ] Alice — write release notes\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDOCX(t *testing.T) {
+	data, err := sampleDoc().DOCX()
+	if err != nil {
+		t.Fatalf("DOCX() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a valid zip: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+	if !strings.Contains(files["word/document.xml"], "Ship v2 &lt;on&gt; Friday &amp; celebrate") {
+		t.Error("document.xml text is not XML-escaped")
+	}
+}
+
+func TestPDF(t *testing.T) {
+	doc := sampleDoc()
+	// Enough paragraphs to force a second page
+	for i := 0; i < 60; i++ {
+		doc.Paragraph(fmt.Sprintf("Filler paragraph number %d.", i))
+	}
+
+	data, err := doc.PDF()
+	if err != nil {
+		t.Fatalf("PDF() error = %v", err)
+	}
+	pdf := string(data)
+
+	if !strings.HasPrefix(pdf, "%PDF-1.4\n") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Fatal("missing PDF header or trailer")
+	}
+	if !strings.Contains(pdf, `(Closing \(remarks\) with a backslash \\ inside.)`) {
+		t.Error("parentheses and backslashes are not escaped")
+	}
+	if strings.Contains(pdf, "/Count 1 ") {
+		t.Error("expected the document to span multiple pages")
+	}
+
+	// Every xref entry must point at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(pdf)
+	xrefAt, _ := strconv.Atoi(startxref[1])
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(pdf[xrefAt:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		if want := fmt.Sprintf("%d 0 obj", i+1); !strings.HasPrefix(pdf[off:], want) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, pdf[off:off+10], want)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     []string
+	}{
+		{"fits on one line", "hello world", 500, []string{"hello world"}},
+		{"wraps between words", "hello world", 40, []string{"hello", "world"}},
+		{"keeps explicit newlines", "a\nb", 500, []string{"a", "b"}},
+		{"hard-splits long words", "mmmmmm", 20, []string{"mm", "mm", "mm"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapText(tt.text, 10, tt.maxWidth)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("wrapText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageBreak(t *testing.T) {
+	doc := New("Title Page").PageBreak().Paragraph("First page of content.")
+
+	t.Run("builder", func(t *testing.T) {
+		if len(doc.Blocks) != 2 || doc.Blocks[0].Kind != PageBreak {
+			t.Errorf("Blocks = %+v, want a page break then a paragraph", doc.Blocks)
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		got := string(doc.Markdown())
+		if !strings.Contains(got, "---\n\nFirst page of content.") {
+			t.Errorf("markdown has no rule before the content:\n%s", got)
+		}
+	})
+
+	t.Run("docx", func(t *testing.T) {
+		data, err := doc.DOCX()
+		if err != nil {
+			t.Fatalf("DOCX() error = %v", err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("not a valid zip: %v", err)
+		}
+		for _, f := range zr.File {
+			if f.Name != "word/document.xml" {
+				continue
+			}
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			if !strings.Contains(string(b), `<w:br w:type="page"/>`) {
+				t.Error("document.xml has no page break")
+			}
+			return
+		}
+		t.Error("missing part word/document.xml")
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		data, err := doc.PDF()
+		if err != nil {
+			t.Fatalf("PDF() error = %v", err)
+		}
+		if !strings.Contains(string(data), "/Count 2 ") {
+			t.Error("expected the title and the content on separate pages")
+		}
+	})
+}
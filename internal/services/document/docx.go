@@ -0,0 +1,101 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// DOCX renders the document as a Word (.docx) file.
+//
+// A .docx is a zip archive of XML parts. Only three parts are required:
+// the content types manifest, the package relationships, and the document
+// body. Formatting is applied directly to runs (bold, size) so we don't need
+// a styles.xml or numbering.xml.
+func (d *Document) DOCX() ([]byte, error) {
+	var body strings.Builder
+	body.WriteString(docxParagraph(d.Title, true, 36, ""))
+
+	for _, b := range d.Blocks {
+		switch b.Kind {
+		case Heading:
+			body.WriteString(docxParagraph(b.Text, true, 28, ""))
+		case Paragraph:
+			body.WriteString(docxParagraph(b.Text, false, 22, ""))
+		case Bullet:
+			body.WriteString(docxParagraph("• "+b.Text, false, 22, "360"))
+		case Checkbox:
+			body.WriteString(docxParagraph("☐ "+b.Text, false, 22, "360"))
+		case Field:
+			body.WriteString(fmt.Sprintf(`<w:p>%s%s</w:p>`,
+				docxRun(b.Label+": ", true, 22), docxRun(b.Text, false, 22)))
+		case PageBreak:
+			body.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+		}
+	}
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`},
+		{"word/document.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			body.String() + `</w:body></w:document>`},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, p := range parts {
+		w, err := zw.Create(p.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", p.name, err)
+		}
+		if _, err := w.Write([]byte(p.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", p.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize docx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// docxParagraph builds a <w:p> with a single run. size is in half-points
+// (22 = 11pt). indent is in twentieths of a point ("" = none).
+func docxParagraph(text string, bold bool, size int, indent string) string {
+	props := ""
+	if indent != "" {
+		props = fmt.Sprintf(`<w:pPr><w:ind w:left="%s"/></w:pPr>`, indent)
+	}
+	return fmt.Sprintf(`<w:p>%s%s</w:p>`, props, docxRun(text, bold, size))
+}
+
+// docxRun builds a <w:r> text run. Newlines become explicit line breaks.
+func docxRun(text string, bold bool, size int) string {
+	var props strings.Builder
+	props.WriteString("<w:rPr>")
+	if bold {
+		props.WriteString("<w:b/>")
+	}
+	props.WriteString(fmt.Sprintf(`<w:sz w:val="%d"/></w:rPr>`, size))
+
+	var runs strings.Builder
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			runs.WriteString("<w:br/>")
+		}
+		runs.WriteString(`<w:t xml:space="preserve">`)
+		xml.EscapeText(&runs, []byte(line))
+		runs.WriteString("</w:t>")
+	}
+	return "<w:r>" + props.String() + runs.String() + "</w:r>"
+}
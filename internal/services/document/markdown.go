@@ -0,0 +1,46 @@
+package document
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the document as GitHub-flavored Markdown.
+func (d *Document) Markdown() []byte {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n\n", d.Title))
+
+	prev := BlockKind(-1)
+	for _, b := range d.Blocks {
+		// Close a run of list items / fields with a blank line
+		if isListLike(prev) && !isListLike(b.Kind) {
+			sb.WriteString("\n")
+		}
+
+		switch b.Kind {
+		case Heading:
+			sb.WriteString(fmt.Sprintf("## %s\n\n", b.Text))
+		case Paragraph:
+			sb.WriteString(b.Text + "\n\n")
+		case Bullet:
+			sb.WriteString(fmt.Sprintf("- %s\n", b.Text))
+		case Checkbox:
+			sb.WriteString(fmt.Sprintf("- [ ] %s\n", b.Text))
+		case Field:
+			// Two trailing spaces force a Markdown line break
+			sb.WriteString(fmt.Sprintf("**%s:** %s  \n", b.Label, b.Text))
+		case PageBreak:
+			sb.WriteString("---\n\n")
+		}
+		prev = b.Kind
+	}
+	if isListLike(prev) {
+		sb.WriteString("\n")
+	}
+
+	return []byte(sb.String())
+}
+
+func isListLike(k BlockKind) bool {
+	return k == Bullet || k == Checkbox || k == Field
+}
@@ -0,0 +1,216 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page geometry in PDF points (1/72 inch) — US Letter with 1" margins.
+const (
+	pageWidth    = 612.0
+	pageHeight   = 792.0
+	pageMargin   = 72.0
+	contentWidth = pageWidth - 2*pageMargin
+)
+
+// pdfLine is a single positioned line of text on a page.
+type pdfLine struct {
+	text   string
+	font   string // "F1" (Helvetica) or "F2" (Helvetica-Bold)
+	size   float64
+	indent float64
+	y      float64
+}
+
+// PDF renders the document as a PDF using the standard Helvetica fonts.
+//
+// How it works:
+//  1. Lay out every block into wrapped lines, starting a new page when
+//     the cursor reaches the bottom margin.
+//  2. Write each page as a content stream of text operators.
+//  3. Emit the object table (catalog, pages, fonts, page streams) and the
+//     cross-reference table that PDF readers use to find each object.
+func (d *Document) PDF() ([]byte, error) {
+	var pages [][]pdfLine
+	var current []pdfLine
+	y := pageHeight - pageMargin
+
+	newPage := func() {
+		pages = append(pages, current)
+		current = nil
+		y = pageHeight - pageMargin
+	}
+
+	emit := func(text, font string, size, indent, spaceBefore float64) {
+		leading := size * 1.4
+		maxWidth := contentWidth - indent
+		for i, line := range wrapText(text, size, maxWidth) {
+			gap := leading
+			if i == 0 {
+				gap += spaceBefore
+			}
+			if y-gap < pageMargin && len(current) > 0 {
+				newPage()
+				gap = leading
+			}
+			y -= gap
+			current = append(current, pdfLine{text: line, font: font, size: size, indent: indent, y: y})
+		}
+	}
+
+	emit(d.Title, "F2", 18, 0, 0)
+	for _, b := range d.Blocks {
+		switch b.Kind {
+		case Heading:
+			emit(b.Text, "F2", 14, 0, 12)
+		case Paragraph:
+			emit(b.Text, "F1", 11, 0, 6)
+		case Bullet:
+			emit("• "+b.Text, "F1", 11, 18, 2)
+		case Checkbox:
+			emit("[  ] "+b.Text, "F1", 11, 18, 2)
+		case Field:
+			emit(b.Label+": "+b.Text, "F1", 11, 0, 0)
+		case PageBreak:
+			newPage()
+		}
+	}
+	if len(current) > 0 || len(pages) == 0 {
+		pages = append(pages, current)
+	}
+
+	return writePDF(pages), nil
+}
+
+// writePDF serializes laid-out pages into a complete PDF file.
+func writePDF(pages [][]pdfLine) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	// Object numbering: 1 catalog, 2 pages, 3-4 fonts, then (page, content) pairs
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range pages {
+		var content bytes.Buffer
+		for _, l := range lines {
+			fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+				l.font, l.size, pageMargin+l.indent, l.y, pdfEscape(l.text))
+		}
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+i*2))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// wrapText breaks text into lines no wider than maxWidth at the given font size.
+// Explicit newlines are preserved; words longer than a line are hard-split.
+func wrapText(text string, size, maxWidth float64) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if textWidth(candidate, size) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// Hard-split words that can't fit on a line by themselves
+			for textWidth(word, size) > maxWidth {
+				cut := len([]rune(word))
+				for cut > 1 && textWidth(string([]rune(word)[:cut]), size) > maxWidth {
+					cut--
+				}
+				lines = append(lines, string([]rune(word)[:cut]))
+				word = string([]rune(word)[cut:])
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// textWidth returns the rendered width of s in points using Helvetica metrics.
+func textWidth(s string, size float64) float64 {
+	units := 0
+	for _, r := range s {
+		if r >= 32 && r < 127 {
+			units += helveticaWidths[r-32]
+		} else {
+			units += 556 // Average glyph width for anything outside ASCII
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// helveticaWidths are the Helvetica glyph widths (in 1/1000 em) for ASCII 32-126,
+// taken from the standard Adobe font metrics.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space-/
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0-?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @-O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P-_
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // `-o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p-~
+}
+
+// winAnsi maps common typographic characters to their WinAnsiEncoding bytes.
+var winAnsi = map[rune]byte{
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '…': 0x85,
+	'€': 0x80,
+}
+
+// pdfEscape encodes text as a PDF literal string in WinAnsiEncoding.
+// Characters the built-in fonts can't display become '?'.
+func pdfEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteByte('\\')
+			sb.WriteByte(byte(r))
+		case r >= 32 && r < 127:
+			sb.WriteByte(byte(r))
+		case winAnsi[r] != 0:
+			sb.WriteString(fmt.Sprintf("\\%03o", winAnsi[r]))
+		case r >= 0xA0 && r <= 0xFF:
+			// Latin-1 supplement maps 1:1 onto WinAnsiEncoding
+			sb.WriteString(fmt.Sprintf("\\%03o", r))
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
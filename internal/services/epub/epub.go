@@ -0,0 +1,189 @@
+// Package epub reads the text of EPUB e-books.
+//
+// An EPUB is a zip: META-INF/container.xml names the package document
+// (.opf), whose manifest lists the book's files and whose spine gives the
+// reading order. Each spine entry is an XHTML chapter, converted to text
+// with the article package.
+package epub
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/article"
+)
+
+// ErrInvalid is returned for files that aren't readable EPUBs.
+var ErrInvalid = errors.New("not a valid EPUB")
+
+// maxEntrySize caps how much of one file in the zip is read, so a small
+// upload can't expand into gigabytes.
+const maxEntrySize = 20 << 20
+
+// maxChapters caps the chapters read from one book.
+const maxChapters = 2000
+
+// Book is an e-book's metadata and text.
+type Book struct {
+	Title    string
+	Author   string
+	Language string
+	Chapters []Chapter // In reading order; chapters without text are left out
+}
+
+// Chapter is one chapter's text.
+type Chapter struct {
+	Title string // The chapter's first heading, or its <title>
+	Text  string
+}
+
+// container is META-INF/container.xml.
+type container struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// packageDoc is the .opf package document.
+type packageDoc struct {
+	Metadata struct {
+		Titles    []string `xml:"title"`
+		Creators  []string `xml:"creator"`
+		Languages []string `xml:"language"`
+	} `xml:"metadata"`
+	Manifest []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// Read parses an EPUB and extracts its chapters.
+func Read(r io.ReaderAt, size int64) (*Book, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var c container
+	if err := decodeXML(files, "META-INF/container.xml", &c); err != nil {
+		return nil, err
+	}
+	if len(c.Rootfiles) == 0 {
+		return nil, fmt.Errorf("%w: container.xml names no package document", ErrInvalid)
+	}
+	opfPath := c.Rootfiles[0].FullPath
+	var pkg packageDoc
+	if err := decodeXML(files, opfPath, &pkg); err != nil {
+		return nil, err
+	}
+
+	book := &Book{
+		Title:    first(pkg.Metadata.Titles),
+		Author:   strings.Join(trimAll(pkg.Metadata.Creators), ", "),
+		Language: first(pkg.Metadata.Languages),
+	}
+
+	hrefs := make(map[string]string, len(pkg.Manifest))
+	for _, item := range pkg.Manifest {
+		if item.MediaType == "application/xhtml+xml" || item.MediaType == "text/html" {
+			hrefs[item.ID] = item.Href
+		}
+	}
+	for _, ref := range pkg.Spine {
+		if len(book.Chapters) >= maxChapters {
+			break
+		}
+		href, ok := hrefs[ref.IDRef]
+		if !ok {
+			continue // Images and other non-text spine entries
+		}
+		f := files[resolve(opfPath, href)]
+		if f == nil {
+			continue
+		}
+		ch, err := readChapter(f)
+		if err != nil {
+			return nil, err
+		}
+		if ch.Text != "" {
+			book.Chapters = append(book.Chapters, ch)
+		}
+	}
+	return book, nil
+}
+
+// readChapter converts one XHTML file to text.
+func readChapter(f *zip.File) (Chapter, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return Chapter{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	defer rc.Close()
+	title, text, err := article.Text(io.LimitReader(rc, maxEntrySize))
+	if err != nil {
+		return Chapter{}, fmt.Errorf("reading %s: %w", f.Name, err)
+	}
+	return Chapter{Title: title, Text: text}, nil
+}
+
+// decodeXML decodes the named file in the zip into v.
+func decodeXML(files map[string]*zip.File, name string, v any) error {
+	f := files[name]
+	if f == nil {
+		return fmt.Errorf("%w: missing %s", ErrInvalid, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, maxEntrySize)).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalid, name, err)
+	}
+	return nil
+}
+
+// resolve turns a manifest href, relative to the package document, into a
+// path in the zip.
+func resolve(opfPath, href string) string {
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	href, _, _ = strings.Cut(href, "#")
+	return path.Join(path.Dir(opfPath), href)
+}
+
+// first returns the first non-blank value.
+func first(values []string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// trimAll returns the non-blank values, trimmed.
+func trimAll(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
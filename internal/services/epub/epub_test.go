@@ -0,0 +1,91 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// buildEPUB zips files into an EPUB.
+func buildEPUB(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	data := buildEPUB(t, map[string]string{
+		"mimetype": "application/epub+zip",
+		"META-INF/container.xml": `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`,
+		"OEBPS/content.opf": `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>The Field Guide</dc:title>
+    <dc:creator>Ana Cruz</dc:creator>
+    <dc:creator>Ben Ito</dc:creator>
+    <dc:language>en</dc:language>
+  </metadata>
+  <manifest>
+    <item id="cover" href="images/cover.jpg" media-type="image/jpeg"/>
+    <item id="blank" href="text/blank.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch1" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch2" href="text/ch2.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine>
+    <itemref idref="cover"/>
+    <itemref idref="blank"/>
+    <itemref idref="ch2"/>
+    <itemref idref="ch1"/>
+  </spine>
+</package>`,
+		"OEBPS/text/blank.xhtml":     `<html xmlns="http://www.w3.org/1999/xhtml"><body><div> </div></body></html>`,
+		"OEBPS/text/chapter 1.xhtml": `<html xmlns="http://www.w3.org/1999/xhtml"><body><h1>Birds</h1><p>Sparrows.</p></body></html>`,
+		"OEBPS/text/ch2.xhtml":       `<html xmlns="http://www.w3.org/1999/xhtml"><head><title>Trees</title></head><body><p>Oaks.</p></body></html>`,
+	})
+
+	book, err := Read(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if book.Title != "The Field Guide" || book.Author != "Ana Cruz, Ben Ito" || book.Language != "en" {
+		t.Errorf("Read metadata = %q, %q, %q", book.Title, book.Author, book.Language)
+	}
+	want := []Chapter{
+		{Title: "Trees", Text: "Oaks."}, // Spine order, not manifest order
+		{Title: "Birds", Text: "Birds\n\nSparrows."},
+	}
+	if !reflect.DeepEqual(book.Chapters, want) {
+		t.Errorf("Read chapters = %+v, want %+v", book.Chapters, want)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := map[string][]byte{
+		"not a zip":         []byte("%PDF-1.4"),
+		"missing container": buildEPUB(t, map[string]string{"mimetype": "application/epub+zip"}),
+		"missing package": buildEPUB(t, map[string]string{
+			"META-INF/container.xml": `<container><rootfiles><rootfile full-path="content.opf"/></rootfiles></container>`,
+		}),
+	}
+	for name, data := range tests {
+		if _, err := Read(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: Read error = %v, want ErrInvalid", name, err)
+		}
+	}
+}
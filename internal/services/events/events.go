@@ -0,0 +1,98 @@
+// Package events is an in-process pub/sub hub for job status changes.
+//
+// The worker pool publishes an Event whenever a job moves between states
+// (pending → processing → completed/failed), and the SSE endpoint
+// subscribes on behalf of each connected client. Nothing is persisted:
+// a client that connects late should fetch current state over REST first,
+// then follow the stream for changes.
+//
+// Go Pattern: Each subscriber gets its own buffered channel. Publish never
+// blocks — if a subscriber falls behind and its buffer is full, the event
+// is dropped for that subscriber rather than stalling a worker goroutine.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// bufferSize is how many undelivered events a subscriber can fall behind by.
+const bufferSize = 64
+
+// Event is a single job status change.
+type Event struct {
+	Type      string    `json:"type"` // transcript, audio, summary, export, or pipeline
+	ID        string    `json:"id"`   // Record ID
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Who owns the job, so the stream only shows callers their own work.
+	APIKeyID *string `json:"-"`
+	UserID   *string `json:"-"`
+}
+
+// Broker fans events out to subscribers.
+type Broker struct {
+	mu   sync.RWMutex
+	subs map[*subscription]struct{}
+}
+
+type subscription struct {
+	ch    chan Event
+	match func(Event) bool
+}
+
+// NewBroker creates an empty broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[*subscription]struct{})}
+}
+
+// Subscribe registers a subscriber that receives every event match accepts
+// (all events if match is nil). Call the returned function to unsubscribe;
+// it closes the channel and is safe to call more than once.
+func (b *Broker) Subscribe(match func(Event) bool) (<-chan Event, func()) {
+	sub := &subscription{ch: make(chan Event, bufferSize), match: match}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, sub)
+			b.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+}
+
+// Publish delivers an event to every matching subscriber without blocking.
+func (b *Broker) Publish(e Event) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+
+	// Holding the read lock means unsubscribe (which takes the write lock)
+	// can't close a channel while we're sending on it.
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subs {
+		if sub.match != nil && !sub.match(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default: // Subscriber is too far behind; drop rather than block
+		}
+	}
+}
+
+// Subscribers returns how many subscribers are connected.
+func (b *Broker) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
@@ -0,0 +1,69 @@
+// events_test.go contains tests for the status event broker.
+package events
+
+import (
+	"testing"
+)
+
+// TestPublishFiltersAndStamps verifies subscribers only receive matching
+// events and that events get a timestamp.
+func TestPublishFiltersAndStamps(t *testing.T) {
+	b := NewBroker()
+	all, unsubAll := b.Subscribe(nil)
+	defer unsubAll()
+	audioOnly, unsubAudio := b.Subscribe(func(e Event) bool { return e.Type == "audio" })
+	defer unsubAudio()
+
+	b.Publish(Event{Type: "transcript", ID: "t1", Status: "processing"})
+	b.Publish(Event{Type: "audio", ID: "a1", Status: "completed"})
+
+	if got := len(all); got != 2 {
+		t.Fatalf("unfiltered subscriber got %d events, want 2", got)
+	}
+	if got := len(audioOnly); got != 1 {
+		t.Fatalf("filtered subscriber got %d events, want 1", got)
+	}
+	e := <-audioOnly
+	if e.ID != "a1" || e.Status != "completed" {
+		t.Errorf("event = %+v", e)
+	}
+	if e.Timestamp.IsZero() {
+		t.Error("event was not timestamped")
+	}
+}
+
+// TestSlowSubscriberDoesNotBlock verifies Publish drops events for a full
+// subscriber instead of blocking the worker that published them.
+func TestSlowSubscriberDoesNotBlock(t *testing.T) {
+	b := NewBroker()
+	ch, unsubscribe := b.Subscribe(nil)
+	defer unsubscribe()
+
+	for i := 0; i < bufferSize*2; i++ {
+		b.Publish(Event{Type: "transcript", ID: "t", Status: "processing"})
+	}
+	if got := len(ch); got != bufferSize {
+		t.Errorf("buffered %d events, want %d", got, bufferSize)
+	}
+}
+
+// TestUnsubscribe verifies unsubscribing closes the channel, is idempotent,
+// and stops delivery.
+func TestUnsubscribe(t *testing.T) {
+	b := NewBroker()
+	ch, unsubscribe := b.Subscribe(nil)
+	if got := b.Subscribers(); got != 1 {
+		t.Fatalf("Subscribers() = %d, want 1", got)
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-ch; ok {
+		t.Error("channel still open after unsubscribe")
+	}
+	if got := b.Subscribers(); got != 0 {
+		t.Errorf("Subscribers() = %d, want 0", got)
+	}
+	b.Publish(Event{Type: "export", ID: "e1"}) // Must not panic on the closed channel
+}
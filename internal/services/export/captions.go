@@ -0,0 +1,140 @@
+// captions.go renders stored segments as WebVTT and SubRip subtitles, and
+// as timestamped text for prompts.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// speakerPrefixRe matches a speaker label at the start of a cue, such as
+// "Alice: ..." or "[Speaker 2]: ...".
+var speakerPrefixRe = regexp.MustCompile(`^\[?([A-Za-z][\w .'-]{0,39}?)\]?:\s+(.+)$`)
+
+// ParseSegments decodes a stored segments column.
+// Returns nil for records processed before timings were kept.
+func ParseSegments(raw []byte) []models.Segment {
+	var segments []models.Segment
+	if len(raw) == 0 || json.Unmarshal(raw, &segments) != nil {
+		return nil
+	}
+	return segments
+}
+
+// VTT renders segments as a WebVTT file. With speakers set, each cue's
+// speaker (or a leading "Name:" label in its text) becomes a <v> voice tag.
+func VTT(segments []models.Segment, speakers bool) []byte {
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n\n")
+
+	escaper := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	for i, seg := range segments {
+		text := seg.Text
+		speaker := seg.Speaker
+		if speakers && speaker == "" {
+			if m := speakerPrefixRe.FindStringSubmatch(text); m != nil {
+				speaker, text = m[1], m[2]
+			}
+		}
+
+		text = escaper.Replace(text)
+		if speakers && speaker != "" {
+			text = fmt.Sprintf("<v %s>%s", escaper.Replace(speaker), text)
+		}
+
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n",
+			i+1, cueTime(seg.Start, '.'), cueTime(seg.End, '.'), text)
+	}
+	return []byte(sb.String())
+}
+
+// SRT renders segments as a SubRip file.
+func SRT(segments []models.Segment) []byte {
+	var sb strings.Builder
+	for i, seg := range segments {
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n",
+			i+1, cueTime(seg.Start, ','), cueTime(seg.End, ','), seg.Text)
+	}
+	return []byte(sb.String())
+}
+
+// TimedText renders segments as one "[m:ss] text" line each, for prompts
+// that ask the model to cite where in the video it found an answer.
+func TimedText(segments []models.Segment) string {
+	var sb strings.Builder
+	for _, seg := range segments {
+		fmt.Fprintf(&sb, "[%s] %s\n", Clock(seg.Start), seg.Text)
+	}
+	return sb.String()
+}
+
+// Clock formats seconds as m:ss, or h:mm:ss from an hour on, the way
+// timestamps read in a video player.
+func Clock(seconds float64) string {
+	s := int(seconds)
+	if s < 0 {
+		s = 0
+	}
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// MergeSegments joins consecutive segments into blocks spanning about span
+// seconds, so timed text for a long video spends fewer characters on
+// timestamps. Speakers are dropped.
+func MergeSegments(segments []models.Segment, span float64) []models.Segment {
+	var merged []models.Segment
+	for _, seg := range segments {
+		if n := len(merged); n > 0 && seg.Start-merged[n-1].Start < span {
+			merged[n-1].End = seg.End
+			merged[n-1].Text += " " + seg.Text
+			continue
+		}
+		merged = append(merged, models.Segment{Start: seg.Start, End: seg.End, Text: seg.Text})
+	}
+	return merged
+}
+
+// ApproximateSegments spreads text evenly over duration seconds in cues of
+// about ten words, for records processed before timings were kept. With no
+// duration it assumes ~150 words a minute.
+func ApproximateSegments(text string, duration float64) []models.Segment {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	const wordsPerCue = 10
+	if duration <= 0 {
+		duration = float64(len(words)) / 150.0 * 60.0
+	}
+	secondsPerWord := duration / float64(len(words))
+
+	segments := make([]models.Segment, 0, (len(words)+wordsPerCue-1)/wordsPerCue)
+	for i := 0; i < len(words); i += wordsPerCue {
+		end := min(i+wordsPerCue, len(words))
+		segments = append(segments, models.Segment{
+			Start: float64(i) * secondsPerWord,
+			End:   float64(end) * secondsPerWord,
+			Text:  strings.Join(words[i:end], " "),
+		})
+	}
+	return segments
+}
+
+// cueTime formats seconds as HH:MM:SS followed by sep and milliseconds.
+// WebVTT uses "." and SRT uses ",".
+func cueTime(seconds float64, sep byte) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	ms := int64(math.Round(seconds * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d",
+		ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
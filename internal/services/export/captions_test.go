@@ -0,0 +1,119 @@
+// captions_test.go checks WebVTT and SRT rendering from stored segments,
+// and the timed text built from them.
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+func TestVTT(t *testing.T) {
+	segments := []models.Segment{
+		{Start: 0, End: 2.5, Text: "Alice: Welcome <everyone> & hi"},
+		{Start: 3661.0049, End: 3662, Text: "Thanks", Speaker: "Bob"},
+	}
+
+	tests := []struct {
+		name     string
+		speakers bool
+		want     string
+	}{
+		{
+			name: "plain",
+			want: "WEBVTT\n\n" +
+				"1\n00:00:00.000 --> 00:00:02.500\nAlice: Welcome &lt;everyone&gt; &amp; hi\n\n" +
+				"2\n01:01:01.005 --> 01:01:02.000\nThanks\n\n",
+		},
+		{
+			name:     "speaker voice tags",
+			speakers: true,
+			want: "WEBVTT\n\n" +
+				"1\n00:00:00.000 --> 00:00:02.500\n<v Alice>Welcome &lt;everyone&gt; &amp; hi\n\n" +
+				"2\n01:01:01.005 --> 01:01:02.000\n<v Bob>Thanks\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(VTT(segments, tt.speakers)); got != tt.want {
+				t.Errorf("VTT() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSRT(t *testing.T) {
+	got := string(SRT([]models.Segment{{Start: 1.2, End: 4, Text: "Hello"}}))
+	want := "1\n00:00:01,200 --> 00:00:04,000\nHello\n\n"
+	if got != want {
+		t.Errorf("SRT() = %q, want %q", got, want)
+	}
+}
+
+func TestParseSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  json.RawMessage
+		want int
+	}{
+		{"null column", nil, 0},
+		{"stored segments", json.RawMessage(`[{"start":0,"end":1,"text":"hi"}]`), 1},
+		{"malformed", json.RawMessage(`{"oops"`), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSegments(tt.raw); len(got) != tt.want {
+				t.Errorf("ParseSegments() returned %d segments, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestTimedText(t *testing.T) {
+	segments := []models.Segment{
+		{Start: 5.9, Text: "Welcome"},
+		{Start: 754, Text: "Channels"},
+		{Start: 3723, Text: "Wrap-up"},
+	}
+	want := "[0:05] Welcome\n[12:34] Channels\n[1:02:03] Wrap-up\n"
+	if got := TimedText(segments); got != want {
+		t.Errorf("TimedText() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMergeSegments(t *testing.T) {
+	segments := []models.Segment{
+		{Start: 0, End: 4, Text: "one", Speaker: "Alice"},
+		{Start: 4, End: 29, Text: "two"},
+		{Start: 30, End: 35, Text: "three"},
+	}
+	got := MergeSegments(segments, 30)
+	want := []models.Segment{
+		{Start: 0, End: 29, Text: "one two"},
+		{Start: 30, End: 35, Text: "three"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("MergeSegments() = %+v, want %+v", got, want)
+	}
+}
+
+func TestApproximateSegments(t *testing.T) {
+	text := "a b c d e f g h i j k l m n o"
+	got := ApproximateSegments(text, 30)
+	if len(got) != 2 {
+		t.Fatalf("got %d segments, want 2", len(got))
+	}
+	if got[1].Start != 20 || got[1].End != 30 || got[1].Text != "k l m n o" {
+		t.Errorf("second segment = %+v, want 20-30 \"k l m n o\"", got[1])
+	}
+	// 15 words at ~150 words a minute
+	if got := ApproximateSegments(text, 0); got[1].End != 6 {
+		t.Errorf("without a duration, end = %v, want 6", got[1].End)
+	}
+	if got := ApproximateSegments(" ", 30); got != nil {
+		t.Errorf("empty text = %+v, want nil", got)
+	}
+}
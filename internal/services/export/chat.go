@@ -0,0 +1,121 @@
+// chat.go renders chat conversations as downloadable files, headed by the
+// metadata of the item they're about, so Q&A sessions can be saved and
+// shared.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+)
+
+// ChatFormats lists the formats chat conversations export to.
+var ChatFormats = map[string]bool{"md": true, "json": true, "txt": true}
+
+// ChatSource is the item a conversation is about, for its export header.
+type ChatSource struct {
+	ItemType string  `json:"item_type"` // transcript, audio, pdf
+	ItemID   string  `json:"item_id"`
+	Title    string  `json:"title"`
+	Fields   []Field `json:"metadata"`
+}
+
+// Field is one labeled line of item metadata.
+type Field struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// chatTimeFormat stamps each message in md and txt exports.
+const chatTimeFormat = "2006-01-02 15:04 MST"
+
+// TranscriptChatSource describes a YouTube transcript for a chat export.
+func TranscriptChatSource(t *models.Transcript) ChatSource {
+	title := t.Title
+	if title == "" {
+		title = t.YouTubeID
+	}
+	return ChatSource{ItemType: "transcript", ItemID: t.ID, Title: title, Fields: []Field{
+		{"Source", "YouTube transcript"},
+		{"Channel", t.ChannelName},
+		{"Duration", FormatDuration(t.Duration)},
+		{"URL", t.YouTubeURL},
+	}}
+}
+
+// AudioChatSource describes an audio transcription for a chat export.
+func AudioChatSource(at *models.AudioTranscription) ChatSource {
+	return ChatSource{ItemType: "audio", ItemID: at.ID, Title: at.DisplayName(), Fields: []Field{
+		{"Source", "Audio transcription"},
+		{"File", at.OriginalName},
+		{"Duration", FormatDuration(int(at.Duration))},
+		{"Date", at.CreatedAt.Format("January 2, 2006 3:04 PM")},
+	}}
+}
+
+// PDFChatSource describes a PDF extraction for a chat export.
+func PDFChatSource(pe *models.PDFExtraction) ChatSource {
+	return ChatSource{ItemType: "pdf", ItemID: pe.ID, Title: pe.DisplayName(), Fields: []Field{
+		{"Source", "PDF extraction"},
+		{"File", pe.OriginalName},
+		{"Pages", fmt.Sprintf("%d", pe.PageCount)},
+	}}
+}
+
+// ChatFile renders a conversation, oldest message first, in the given
+// format: md and txt read as a transcript of the Q&A under a metadata
+// header; json carries the session, source, and messages as records.
+func ChatFile(session *models.TranscriptChatSession, source ChatSource, messages []models.TranscriptChatMessage, format string) (File, error) {
+	base := SafeName(source.Title + " - chat")
+	switch format {
+	case "md":
+		doc := document.New("Chat: " + source.Title)
+		for _, f := range source.Fields {
+			if f.Value != "" {
+				doc.Field(f.Label, f.Value)
+			}
+		}
+		doc.Field("Messages", fmt.Sprintf("%d", len(messages))).PageBreak()
+		for _, m := range messages {
+			doc.Heading(chatSpeaker(m) + " · " + m.CreatedAt.Format(chatTimeFormat)).Paragraph(m.Content)
+		}
+		return File{Name: base + ".md", Data: doc.Markdown()}, nil
+	case "txt":
+		var sb strings.Builder
+		sb.WriteString("Chat: " + source.Title + "\n")
+		for _, f := range source.Fields {
+			if f.Value != "" {
+				sb.WriteString(fmt.Sprintf("%s: %s\n", f.Label, f.Value))
+			}
+		}
+		for _, m := range messages {
+			sb.WriteString(fmt.Sprintf("\n[%s] %s:\n%s\n", m.CreatedAt.Format(chatTimeFormat), chatSpeaker(m), m.Content))
+		}
+		return File{Name: base + ".txt", Data: []byte(sb.String())}, nil
+	case "json":
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"session":  session,
+			"source":   source,
+			"messages": messages,
+		}, "", "  ")
+		if err != nil {
+			return File{}, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return File{Name: base + ".json", Data: data}, nil
+	}
+	return File{}, fmt.Errorf("unsupported format %q", format)
+}
+
+// chatSpeaker labels a message's author, with the model behind an answer.
+func chatSpeaker(m models.TranscriptChatMessage) string {
+	if m.Role == "user" {
+		return "You"
+	}
+	if m.ModelUsed != "" {
+		return "Assistant (" + m.ModelUsed + ")"
+	}
+	return "Assistant"
+}
@@ -0,0 +1,62 @@
+// chat_test.go checks chat conversation rendering.
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+func TestChatFile(t *testing.T) {
+	source := TranscriptChatSource(&models.Transcript{
+		ID: "t1", Title: "Go: Channels", ChannelName: "Gopher Con", Duration: 65,
+		YouTubeURL: "https://www.youtube.com/watch?v=abc",
+	})
+	asked := time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)
+	messages := []models.TranscriptChatMessage{
+		{Role: "user", Content: "What is select for?", CreatedAt: asked},
+		{Role: "assistant", Content: "Waiting on several channels.", ModelUsed: "m1", CreatedAt: asked.Add(time.Minute)},
+	}
+	session := &models.TranscriptChatSession{ID: "s1", ItemType: "transcript", ItemID: "t1"}
+
+	tests := []struct {
+		format   string
+		wantName string
+		contains []string
+	}{
+		{"md", "Go- Channels - chat.md", []string{
+			"# Chat: Go: Channels\n", "**Channel:** Gopher Con  \n", "**Messages:** 2  \n",
+			"## You · 2025-03-03 10:00 UTC\n\nWhat is select for?\n",
+			"## Assistant (m1) · 2025-03-03 10:01 UTC\n\nWaiting on several channels.\n",
+		}},
+		{"txt", "Go- Channels - chat.txt", []string{
+			"Chat: Go: Channels\nSource: YouTube transcript\n", "Duration: 1m 5s\n",
+			"[2025-03-03 10:00 UTC] You:\nWhat is select for?\n",
+		}},
+		{"json", "Go- Channels - chat.json", []string{
+			`"item_type": "transcript"`, `"label": "URL"`, `"content": "Waiting on several channels."`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, err := ChatFile(session, source, messages, tt.format)
+			if err != nil {
+				t.Fatalf("ChatFile() error = %v", err)
+			}
+			if f.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", f.Name, tt.wantName)
+			}
+			for _, want := range tt.contains {
+				if !bytes.Contains(f.Data, []byte(want)) {
+					t.Errorf("%s output missing %q in:\n%s", tt.format, want, f.Data)
+				}
+			}
+		})
+	}
+
+	if _, err := ChatFile(session, source, messages, "pdf"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
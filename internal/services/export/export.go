@@ -0,0 +1,202 @@
+// Package export turns stored items (transcripts, audio transcriptions, PDF
+// extractions) into downloadable files, and bundles many files into a ZIP.
+//
+// It's shared by the HTTP handlers (single-item downloads) and the worker
+// pool (bulk exports), which is why it lives outside the handlers package.
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+)
+
+// Formats lists the formats supported by bulk exports.
+var Formats = map[string]bool{"txt": true, "md": true, "json": true, "pdf": true}
+
+// File is a rendered export ready to download or add to an archive.
+type File struct {
+	Name string // Relative path inside an archive, including extension
+	Data []byte
+}
+
+// TranscriptDocument lays out a YouTube transcript: a title page with video
+// metadata, then the summary (if any), then the full transcript.
+func TranscriptDocument(t *models.Transcript, s *models.Summary) *document.Document {
+	doc := document.New(t.Title).
+		Field("Channel", t.ChannelName).
+		Field("Duration", FormatDuration(t.Duration)).
+		Field("Words", fmt.Sprintf("%d", t.WordCount)).
+		Field("Language", t.Language).
+		Field("URL", t.YouTubeURL).
+		Field("Extracted", t.CreatedAt.Format("January 2, 2006")).
+		PageBreak()
+
+	if s != nil {
+		doc.Heading("Summary").Paragraph(s.SummaryText)
+		var keyPoints []string
+		json.Unmarshal(s.KeyPoints, &keyPoints)
+		if len(keyPoints) > 0 {
+			doc.Heading("Key Points").Bullets(keyPoints)
+		}
+	}
+
+	return doc.Heading("Transcript").Paragraph(t.TranscriptText)
+}
+
+// AudioDocument lays out an audio transcription: a title page with recording
+// metadata, then the summary sections, then the transcript.
+func AudioDocument(at *models.AudioTranscription) *document.Document {
+	doc := document.New(at.DisplayName()).
+		Field("Date", at.CreatedAt.Format("January 2, 2006 3:04 PM")).
+		Field("Duration", FormatDuration(int(at.Duration))).
+		Field("Language", at.Language).
+		Field("Words", fmt.Sprintf("%d", at.WordCount)).
+		Field("Content type", string(at.ContentType)).
+		PageBreak()
+
+	if at.SummaryText != "" {
+		doc.Heading("Summary").Paragraph(at.SummaryText)
+
+		var keyPoints, actionItems, decisions []string
+		json.Unmarshal(at.KeyPoints, &keyPoints)
+		json.Unmarshal(at.ActionItems, &actionItems)
+		json.Unmarshal(at.Decisions, &decisions)
+		if len(keyPoints) > 0 {
+			doc.Heading("Key Points").Bullets(keyPoints)
+		}
+		if len(actionItems) > 0 {
+			doc.Heading("Action Items").Checkboxes(actionItems)
+		}
+		if len(decisions) > 0 {
+			doc.Heading("Decisions").Bullets(decisions)
+		}
+	}
+
+	return doc.Heading("Full Transcript").Paragraph(at.TranscriptText)
+}
+
+// PDFExtractionDocument lays out extracted PDF text with its metadata.
+func PDFExtractionDocument(pe *models.PDFExtraction) *document.Document {
+	return document.New(pe.DisplayName()).
+		Field("Pages", fmt.Sprintf("%d", pe.PageCount)).
+		Field("Words", fmt.Sprintf("%d", pe.WordCount)).
+		Field("Extracted", pe.CreatedAt.Format("January 2, 2006")).
+		Heading("Text").
+		Paragraph(pe.TextContent)
+}
+
+// TranscriptFile renders a transcript in the given format.
+func TranscriptFile(t *models.Transcript, s *models.Summary, format string) (File, error) {
+	name := t.Title
+	if name == "" {
+		name = t.YouTubeID
+	}
+	return render(name, format, TranscriptDocument(t, s), t.TranscriptText, t)
+}
+
+// AudioFile renders an audio transcription in the given format.
+func AudioFile(at *models.AudioTranscription, format string) (File, error) {
+	return render(at.OriginalName, format, AudioDocument(at), at.TranscriptText, at)
+}
+
+// PDFExtractionFile renders a PDF extraction in the given format. The md
+// format of an extraction kept as Markdown is that Markdown, so the PDF's
+// headings, lists, and tables carry over.
+func PDFExtractionFile(pe *models.PDFExtraction, format string) (File, error) {
+	if format == "md" && pe.MarkdownContent != "" {
+		return File{Name: SafeName(pe.OriginalName) + ".md", Data: []byte(pe.MarkdownContent)}, nil
+	}
+	return render(pe.OriginalName, format, PDFExtractionDocument(pe), pe.TextContent, pe)
+}
+
+// render picks the right encoding for a format. text is the raw body used
+// for txt; v is the full record used for json.
+func render(name, format string, doc *document.Document, text string, v interface{}) (File, error) {
+	base := SafeName(name)
+	switch format {
+	case "txt":
+		return File{Name: base + ".txt", Data: []byte(text)}, nil
+	case "md":
+		return File{Name: base + ".md", Data: doc.Markdown()}, nil
+	case "json":
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return File{}, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return File{Name: base + ".json", Data: data}, nil
+	case "pdf":
+		data, err := doc.PDF()
+		if err != nil {
+			return File{}, err
+		}
+		return File{Name: base + ".pdf", Data: data}, nil
+	}
+	return File{}, fmt.Errorf("unsupported format %q", format)
+}
+
+// WriteZip writes files into a ZIP archive. Duplicate names get a numeric
+// suffix so nothing is silently overwritten.
+func WriteZip(w io.Writer, files []File) error {
+	zw := zip.NewWriter(w)
+	seen := make(map[string]int)
+
+	for _, f := range files {
+		name := f.Name
+		if n := seen[name]; n > 0 {
+			ext := ""
+			if i := strings.LastIndex(name, "."); i > strings.LastIndex(name, "/") {
+				name, ext = name[:i], name[i:]
+			}
+			name = fmt.Sprintf("%s (%d)%s", name, n+1, ext)
+		}
+		seen[f.Name]++
+
+		fw, err := zw.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", name, err)
+		}
+		if _, err := fw.Write(f.Data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// SafeName strips characters that are unsafe in file and archive entry names.
+// Path separators are removed so an item title can't escape its folder.
+func SafeName(name string) string {
+	replacer := strings.NewReplacer(
+		"/", "-", "\\", "-", ":", "-", "*", "-",
+		"?", "", "\"", "", "<", "", ">", "", "|", "-",
+		"\n", " ", "\r", "", "\t", " ",
+	)
+	name = strings.TrimSpace(replacer.Replace(name))
+	name = strings.Trim(name, ".") // No "..", no hidden files
+	if r := []rune(name); len(r) > 100 {
+		name = string(r[:100])
+	}
+	if name == "" {
+		name = "untitled"
+	}
+	return name
+}
+
+// FormatDuration converts seconds to a human-readable string like "1h 5m 30s".
+func FormatDuration(seconds int) string {
+	h := seconds / 3600
+	m := (seconds % 3600) / 60
+	s := seconds % 60
+	if h > 0 {
+		return fmt.Sprintf("%dh %dm %ds", h, m, s)
+	}
+	if m > 0 {
+		return fmt.Sprintf("%dm %ds", m, s)
+	}
+	return fmt.Sprintf("%ds", s)
+}
@@ -0,0 +1,118 @@
+// export_test.go checks archive naming and per-format rendering.
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+func TestWriteZip(t *testing.T) {
+	files := []File{
+		{Name: "audio/call.txt", Data: []byte("one")},
+		{Name: "audio/call.txt", Data: []byte("two")},
+		{Name: "audio/call.txt", Data: []byte("three")},
+		{Name: "pdfs/notes", Data: []byte("no extension")},
+		{Name: "pdfs/notes", Data: []byte("again")},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteZip(&buf, files); err != nil {
+		t.Fatalf("WriteZip() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+
+	want := []string{"audio/call.txt", "audio/call (2).txt", "audio/call (3).txt", "pdfs/notes", "pdfs/notes (2)"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestSafeName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Weekly Sync", "Weekly Sync"},
+		{"path separators", "../../etc/passwd", "-..-etc-passwd"},
+		{"reserved characters", `Q&A: "Why?" <live>`, "Q&A- Why live"},
+		{"empty", "  ", "untitled"},
+		{"only dots", "...", "untitled"},
+		{"long names are truncated by rune", strings.Repeat("é", 150), strings.Repeat("é", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SafeName(tt.in); got != tt.want {
+				t.Errorf("SafeName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAudioFile(t *testing.T) {
+	at := &models.AudioTranscription{
+		OriginalName:   "standup.m4a",
+		TranscriptText: "Alice: shipped the fix.",
+		SummaryText:    "Short standup.",
+	}
+
+	tests := []struct {
+		format   string
+		wantName string
+		contains string
+	}{
+		{"txt", "standup.m4a.txt", "Alice: shipped the fix."},
+		{"md", "standup.m4a.md", "Short standup."},
+		{"json", "standup.m4a.json", `"original_name": "standup.m4a"`},
+		{"pdf", "standup.m4a.pdf", "%PDF-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, err := AudioFile(at, tt.format)
+			if err != nil {
+				t.Fatalf("AudioFile() error = %v", err)
+			}
+			if f.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", f.Name, tt.wantName)
+			}
+			if !bytes.Contains(f.Data, []byte(tt.contains)) {
+				t.Errorf("%s output missing %q", tt.format, tt.contains)
+			}
+		})
+	}
+
+	if _, err := AudioFile(at, "docx"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestPDFExtractionFileMarkdown(t *testing.T) {
+	pe := &models.PDFExtraction{OriginalName: "report.pdf", TextContent: "Results\nUp 5%"}
+	if f, err := PDFExtractionFile(pe, "md"); err != nil || !bytes.Contains(f.Data, []byte("## Text")) {
+		t.Errorf("PDFExtractionFile(md) = %q, %v; want the rendered document", f.Data, err)
+	}
+
+	pe.MarkdownContent = "# Results\n\n- Up 5%"
+	f, err := PDFExtractionFile(pe, "md")
+	if err != nil || f.Name != "report.pdf.md" || string(f.Data) != pe.MarkdownContent {
+		t.Errorf("PDFExtractionFile(md) = %q %q, %v; want the stored Markdown", f.Name, f.Data, err)
+	}
+	if f, err := PDFExtractionFile(pe, "txt"); err != nil || string(f.Data) != pe.TextContent {
+		t.Errorf("PDFExtractionFile(txt) = %q, %v; want the plain text", f.Data, err)
+	}
+}
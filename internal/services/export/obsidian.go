@@ -0,0 +1,190 @@
+// obsidian.go renders Obsidian-flavored Markdown: YAML frontmatter that
+// Dataview and the Properties panel understand, headings that are safe to
+// target with [[Note#Heading]] links, and optional summary callouts.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// obsidianNote collects what goes into a note before it's written out.
+type obsidianNote struct {
+	title      string
+	properties [][2]string // Ordered frontmatter key/value pairs (values unquoted)
+	tags       []string
+	callouts   []callout
+	body       string
+}
+
+// callout is an Obsidian callout block, e.g. "> [!summary] Summary".
+type callout struct {
+	kind, title string
+	text        string   // Paragraph content, if any
+	items       []string // List items, if any
+	checkboxes  bool     // Render items as tasks
+}
+
+// TranscriptObsidian renders a transcript as an Obsidian note. With callouts
+// set, the summary and key points are included as callouts above the transcript.
+func TranscriptObsidian(t *models.Transcript, s *models.Summary, callouts bool) []byte {
+	note := obsidianNote{
+		title: t.Title,
+		properties: [][2]string{
+			{"title", t.Title},
+			{"source", t.YouTubeURL},
+			{"channel", t.ChannelName},
+			{"duration", FormatDuration(t.Duration)},
+			{"words", fmt.Sprintf("%d", t.WordCount)},
+			{"language", t.Language},
+			{"date", t.CreatedAt.Format("2006-01-02")},
+		},
+		tags: []string{"media-tools", "youtube", t.ChannelName},
+		body: t.TranscriptText,
+	}
+
+	if callouts && s != nil {
+		note.callouts = append(note.callouts, callout{kind: "summary", title: "Summary", text: s.SummaryText})
+		var keyPoints []string
+		json.Unmarshal(s.KeyPoints, &keyPoints)
+		if len(keyPoints) > 0 {
+			note.callouts = append(note.callouts, callout{kind: "note", title: "Key Points", items: keyPoints})
+		}
+	}
+
+	return note.render("Transcript")
+}
+
+// AudioObsidian renders an audio transcription as an Obsidian note. With
+// callouts set, the summary, key points, action items (as tasks), and
+// decisions are included as callouts above the transcript.
+func AudioObsidian(at *models.AudioTranscription, callouts bool) []byte {
+	note := obsidianNote{
+		title: at.DisplayName(),
+		properties: [][2]string{
+			{"title", at.DisplayName()},
+			{"source", at.OriginalName},
+			{"content_type", string(at.ContentType)},
+			{"duration", FormatDuration(int(at.Duration))},
+			{"words", fmt.Sprintf("%d", at.WordCount)},
+			{"language", at.Language},
+			{"date", at.CreatedAt.Format("2006-01-02")},
+		},
+		tags: []string{"media-tools", "audio", string(at.ContentType)},
+		body: at.TranscriptText,
+	}
+
+	if callouts && at.SummaryText != "" {
+		note.callouts = append(note.callouts, callout{kind: "summary", title: "Summary", text: at.SummaryText})
+
+		var keyPoints, actionItems, decisions []string
+		json.Unmarshal(at.KeyPoints, &keyPoints)
+		json.Unmarshal(at.ActionItems, &actionItems)
+		json.Unmarshal(at.Decisions, &decisions)
+		if len(keyPoints) > 0 {
+			note.callouts = append(note.callouts, callout{kind: "note", title: "Key Points", items: keyPoints})
+		}
+		if len(actionItems) > 0 {
+			note.callouts = append(note.callouts, callout{kind: "todo", title: "Action Items", items: actionItems, checkboxes: true})
+		}
+		if len(decisions) > 0 {
+			note.callouts = append(note.callouts, callout{kind: "success", title: "Decisions", items: decisions})
+		}
+	}
+
+	return note.render("Full Transcript")
+}
+
+// render writes the frontmatter, title, callouts, and transcript section.
+func (n obsidianNote) render(transcriptHeading string) []byte {
+	var sb strings.Builder
+
+	sb.WriteString("---\n")
+	for _, p := range n.properties {
+		if p[1] == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", p[0], yamlString(p[1]))
+	}
+	sb.WriteString("tags:\n")
+	seen := make(map[string]bool)
+	for _, tag := range n.tags {
+		tag = obsidianTag(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			fmt.Fprintf(&sb, "  - %s\n", tag)
+		}
+	}
+	sb.WriteString("---\n\n")
+
+	fmt.Fprintf(&sb, "# %s\n\n", wikiHeading(n.title))
+
+	for _, c := range n.callouts {
+		fmt.Fprintf(&sb, "> [!%s] %s\n", c.kind, c.title)
+		if c.text != "" {
+			for _, line := range strings.Split(strings.TrimSpace(c.text), "\n") {
+				sb.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+			}
+		}
+		for _, item := range c.items {
+			if c.checkboxes {
+				fmt.Fprintf(&sb, "> - [ ] %s\n", item)
+			} else {
+				fmt.Fprintf(&sb, "> - %s\n", item)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "## %s\n\n", transcriptHeading)
+	sb.WriteString(strings.TrimSpace(n.body))
+	sb.WriteString("\n")
+
+	return []byte(sb.String())
+}
+
+// wikiHeading strips characters that break [[Note#Heading]] links or would
+// be parsed as tags, block references, or aliases inside a heading.
+func wikiHeading(text string) string {
+	text = strings.NewReplacer(
+		"#", "", "|", "-", "^", "", "[", "(", "]", ")", ":", " -", "\\", "-", "/", "-",
+	).Replace(text)
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return "Untitled"
+	}
+	return text
+}
+
+// obsidianTag converts free text to a valid tag: lowercase, no spaces, and
+// only letters, digits, "-", "_", and "/" (for nested tags).
+func obsidianTag(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case r == ' ' || r == '.' || r == '-':
+			if !strings.HasSuffix(sb.String(), "-") {
+				sb.WriteRune('-')
+			}
+		case r == '_' || r == '/' ||
+			(r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r > 127:
+			sb.WriteRune(r)
+		}
+	}
+	tag := strings.Trim(sb.String(), "-/")
+	// Tags must contain at least one non-numeric character
+	if strings.Trim(tag, "0123456789") == "" {
+		return ""
+	}
+	return tag
+}
+
+// yamlString quotes a value for YAML frontmatter. Always double-quoting
+// keeps titles like "Q&A: Part 1" or "yes" from being misread by the parser.
+func yamlString(value string) string {
+	value = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", " ", "\r", "").Replace(value)
+	return "\"" + value + "\""
+}
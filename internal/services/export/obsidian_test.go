@@ -0,0 +1,88 @@
+// obsidian_test.go checks frontmatter, heading, and callout rendering.
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+func TestTranscriptObsidian(t *testing.T) {
+	tr := &models.Transcript{
+		Title:          `Go #1: "Channels" | [Live]`,
+		YouTubeURL:     "https://www.youtube.com/watch?v=abc",
+		ChannelName:    "Gopher Con",
+		Duration:       3725,
+		WordCount:      12,
+		Language:       "en",
+		TranscriptText: "Welcome to the talk.",
+		CreatedAt:      time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC),
+	}
+	s := &models.Summary{SummaryText: "About channels.", KeyPoints: json.RawMessage(`["Use select"]`)}
+
+	t.Run("frontmatter and body", func(t *testing.T) {
+		got := string(TranscriptObsidian(tr, s, false))
+		for _, want := range []string{
+			"---\ntitle: \"Go #1: \\\"Channels\\\" | [Live]\"\n",
+			"source: \"https://www.youtube.com/watch?v=abc\"\n",
+			"duration: \"1h 2m 5s\"\n",
+			"date: \"2025-03-03\"\n",
+			"tags:\n  - media-tools\n  - youtube\n  - gopher-con\n---\n",
+			"# Go 1 - \"Channels\" - (Live)\n",
+			"## Transcript\n\nWelcome to the talk.\n",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("missing %q in:\n%s", want, got)
+			}
+		}
+		if strings.Contains(got, "[!summary]") {
+			t.Error("callouts rendered without being requested")
+		}
+	})
+
+	t.Run("summary callouts", func(t *testing.T) {
+		got := string(TranscriptObsidian(tr, s, true))
+		want := "> [!summary] Summary\n> About channels.\n\n> [!note] Key Points\n> - Use select\n\n## Transcript"
+		if !strings.Contains(got, want) {
+			t.Errorf("missing callouts in:\n%s", got)
+		}
+	})
+}
+
+func TestAudioObsidianActionItems(t *testing.T) {
+	at := &models.AudioTranscription{
+		OriginalName: "standup.m4a",
+		ContentType:  models.ContentMeeting,
+		SummaryText:  "Short standup.",
+		ActionItems:  json.RawMessage(`["Ship it"]`),
+	}
+	got := string(AudioObsidian(at, true))
+	if !strings.Contains(got, "> [!todo] Action Items\n> - [ ] Ship it\n") {
+		t.Errorf("action items should render as tasks:\n%s", got)
+	}
+	if !strings.Contains(got, "  - meeting\n") {
+		t.Errorf("content type tag missing:\n%s", got)
+	}
+}
+
+func TestObsidianTag(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Gopher Con", "gopher-con"},
+		{"phone_call", "phone_call"},
+		{"Mr. Beast!", "mr-beast"},
+		{"lo-fi  beats", "lo-fi-beats"},
+		{"2024", ""}, // Purely numeric tags aren't valid
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := obsidianTag(tt.in); got != tt.want {
+			t.Errorf("obsidianTag(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
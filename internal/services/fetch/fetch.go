@@ -0,0 +1,167 @@
+// Package fetch downloads documents from user-supplied URLs — PDFs to
+// extract, web pages to read as articles.
+//
+// The URLs come from API callers, so the client refuses to connect to
+// loopback, private, and link-local addresses. The check runs on the
+// resolved IP at dial time, so a public name pointing inside the network
+// (or redirecting there) is refused too.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Errors from Client.Get that are the caller's fault, as opposed to the
+// remote server's.
+var (
+	ErrInvalidURL  = errors.New("url must be an absolute http or https URL")
+	ErrBlockedHost = errors.New("url points to a private or local address")
+	ErrContentType = errors.New("url returned the wrong kind of content")
+	ErrTooLarge    = errors.New("download is larger than the size limit")
+)
+
+// timeout bounds a whole download, redirects included.
+const timeout = 60 * time.Second
+
+// maxRedirects is how many redirects a download may follow.
+const maxRedirects = 5
+
+// Client downloads from public URLs.
+type Client struct {
+	client *http.Client
+}
+
+// Result describes a finished download.
+type Result struct {
+	Name        string // From Content-Disposition, else the last segment of the final URL
+	URL         string // Final URL, after redirects
+	ContentType string // As sent, with parameters such as charset
+	Size        int64  // Bytes written
+}
+
+// New creates a Client that only reaches public addresses.
+func New() *Client {
+	return newClient(false)
+}
+
+// newClient creates a Client; tests allow private addresses to reach a
+// local server.
+func newClient(allowPrivate bool) *Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return ErrBlockedHost
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would make the dialed address the proxy's
+	transport.DialContext = dialer.DialContext
+
+	return &Client{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrInvalidURL
+			}
+			return nil
+		},
+	}}
+}
+
+// publicIP reports whether ip is routable on the public internet.
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// Get downloads rawURL into w, reading at most maxSize bytes. The response's
+// media type must be one of contentTypes.
+func (c *Client) Get(ctx context.Context, rawURL string, w io.Writer, maxSize int64, contentTypes ...string) (*Result, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	req.Header.Set("Accept", strings.Join(contentTypes, ", "))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrBlockedHost):
+			return nil, ErrBlockedHost
+		case errors.Is(err, ErrInvalidURL):
+			return nil, ErrInvalidURL // A redirect to another scheme
+		}
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("download failed: server returned %s", resp.Status)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !slices.Contains(contentTypes, mediaType) {
+		return nil, fmt.Errorf("%w: Content-Type is %q", ErrContentType, resp.Header.Get("Content-Type"))
+	}
+	if resp.ContentLength > maxSize {
+		return nil, ErrTooLarge
+	}
+
+	// Read one byte past the limit to tell "exactly maxSize" from "too big"
+	n, err := io.Copy(w, io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	if n > maxSize {
+		return nil, ErrTooLarge
+	}
+	return &Result{
+		Name:        fileName(resp),
+		URL:         resp.Request.URL.String(),
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        n,
+	}, nil
+}
+
+// fileName names a download after its Content-Disposition filename or the
+// last segment of its final URL, falling back to the host name.
+func fileName(resp *http.Response) string {
+	var name string
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		name = path.Base(strings.ReplaceAll(params["filename"], `\`, "/"))
+	}
+	if name == "" || name == "." || name == "/" {
+		name = path.Base(resp.Request.URL.Path)
+	}
+	if name == "" || name == "." || name == "/" {
+		name = resp.Request.URL.Hostname()
+	}
+	return name
+}
@@ -0,0 +1,126 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var pdfTypes = []string{"application/pdf", "application/octet-stream"}
+
+func TestGet(t *testing.T) {
+	doc := []byte("%PDF-1.4 test document")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/papers/2401.00001":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write(doc)
+		case "/download":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Disposition", `attachment; filename="Annual Report.pdf"`)
+			w.Write(doc)
+		case "/moved":
+			http.Redirect(w, r, "/papers/2401.00001", http.StatusFound)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := newClient(true)
+	tests := []struct {
+		name     string
+		path     string
+		maxSize  int64
+		wantName string
+		wantErr  error
+	}{
+		{"pdf", "/papers/2401.00001", 1 << 20, "2401.00001", nil},
+		{"content disposition", "/download", 1 << 20, "Annual Report.pdf", nil},
+		{"redirect", "/moved", 1 << 20, "2401.00001", nil},
+		{"exactly the limit", "/papers/2401.00001", int64(len(doc)), "2401.00001", nil},
+		{"too large", "/papers/2401.00001", int64(len(doc)) - 1, "", ErrTooLarge},
+		{"wrong content type", "/page", 1 << 20, "", ErrContentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			res, err := c.Get(context.Background(), srv.URL+tt.path, &buf, tt.maxSize, pdfTypes...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Fetch error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Fetch: %v", err)
+			}
+			if res.Name != tt.wantName || res.Size != int64(len(doc)) || !bytes.Equal(buf.Bytes(), doc) {
+				t.Errorf("Get = %q, %d bytes; want %q, %d", res.Name, res.Size, tt.wantName, len(doc))
+			}
+			if res.URL != srv.URL+"/papers/2401.00001" && res.URL != srv.URL+"/download" {
+				t.Errorf("Get URL = %q", res.URL)
+			}
+		})
+	}
+
+	if _, err := c.Get(context.Background(), srv.URL+"/missing", &bytes.Buffer{}, 1<<20, pdfTypes...); err == nil {
+		t.Error("Get of a 404 succeeded")
+	}
+}
+
+func TestGetRejects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer srv.Close()
+
+	c := New()
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{srv.URL, ErrBlockedHost}, // Loopback
+		{"http://169.254.169.254/latest/meta-data", ErrBlockedHost},
+		{"ftp://example.com/paper.pdf", ErrInvalidURL},
+		{"/paper.pdf", ErrInvalidURL},
+		{"not a url", ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		if _, err := c.Get(context.Background(), tt.url, &bytes.Buffer{}, 1<<20, pdfTypes...); !errors.Is(err, tt.want) {
+			t.Errorf("Get(%q) error = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.0.0.5", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+	}
+	for _, tt := range tests {
+		if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Package mailer sends transactional email: verification links, password
+// resets, and weekly digests.
+//
+// Go Pattern: Mailer is a one-method interface with two implementations —
+// SMTP for real delivery and a logger for development, which prints each
+// message instead of sending it. Callers don't know which one they have.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config selects and configures a backend.
+type Config struct {
+	Backend string // "smtp", or "log" (the default) to print messages instead
+
+	From string // Sender address, e.g. "Media Tools <no-reply@example.com>"
+
+	// SMTP backend
+	Host     string
+	Port     string
+	Username string // Optional; enables PLAIN auth
+	Password string
+}
+
+// New returns the configured mailer.
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Backend {
+	case "", "log":
+		return LogMailer{}, nil
+	case "smtp":
+		if cfg.Host == "" || cfg.From == "" {
+			return nil, fmt.Errorf("smtp mailer requires SMTP_HOST and MAIL_FROM")
+		}
+		port := cfg.Port
+		if port == "" {
+			port = "587"
+		}
+		return &SMTPMailer{
+			addr:     net.JoinHostPort(cfg.Host, port),
+			host:     cfg.Host,
+			username: cfg.Username,
+			password: cfg.Password,
+			from:     cfg.From,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown mailer %q (want smtp or log)", cfg.Backend)
+	}
+}
+
+// LogMailer prints messages to the server log. It's meant for development:
+// in production it would write reset tokens to the logs.
+type LogMailer struct{}
+
+// Send logs the message.
+func (LogMailer) Send(_ context.Context, msg Message) error {
+	log.Printf("📧 To: %s | Subject: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// SMTPMailer sends messages through an SMTP server, upgrading to TLS with
+// STARTTLS when the server offers it.
+type SMTPMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// Send delivers the message. net/smtp doesn't take a context, so ctx is
+// only checked before connecting.
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	if err := smtp.SendMail(m.addr, auth, envelopeAddress(m.from), []string{msg.To}, compose(m.from, msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// compose builds an RFC 5322 message. Header values are stripped of line
+// breaks so a crafted address or subject can't inject headers.
+func compose(from string, msg Message) []byte {
+	clean := strings.NewReplacer("\r", "", "\n", "")
+	var b strings.Builder
+	b.WriteString("From: " + clean.Replace(from) + "\r\n")
+	b.WriteString("To: " + clean.Replace(msg.To) + "\r\n")
+	b.WriteString("Subject: " + clean.Replace(msg.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// envelopeAddress returns the bare address from "Name <addr>".
+func envelopeAddress(from string) string {
+	if i := strings.LastIndex(from, "<"); i >= 0 {
+		return strings.TrimSuffix(from[i+1:], ">")
+	}
+	return from
+}
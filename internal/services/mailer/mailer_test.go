@@ -0,0 +1,52 @@
+// mailer_test.go contains tests for mailer selection and message building.
+package mailer
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNew verifies backend selection and SMTP config validation.
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"default logs", Config{}, false},
+		{"log", Config{Backend: "log"}, false},
+		{"smtp", Config{Backend: "smtp", Host: "smtp.example.com", From: "a@example.com"}, false},
+		{"smtp without host", Config{Backend: "smtp", From: "a@example.com"}, true},
+		{"smtp without from", Config{Backend: "smtp", Host: "smtp.example.com"}, true},
+		{"unknown", Config{Backend: "carrier-pigeon"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestCompose verifies headers can't be injected through the subject or
+// recipient and the body uses CRLF line endings.
+func TestCompose(t *testing.T) {
+	raw := string(compose("Media Tools <no-reply@example.com>", Message{
+		To:      "a@example.com\r\nBcc: victim@example.com",
+		Subject: "Reset\nX-Evil: 1",
+		Body:    "line one\nline two",
+	}))
+
+	headers, body, _ := strings.Cut(raw, "\r\n\r\n")
+	if strings.Contains(headers, "\r\nBcc:") || strings.Contains(headers, "\r\nX-Evil:") {
+		t.Errorf("header injection in:\n%s", headers)
+	}
+	if body != "line one\r\nline two" {
+		t.Errorf("body = %q", body)
+	}
+	if got := envelopeAddress("Media Tools <no-reply@example.com>"); got != "no-reply@example.com" {
+		t.Errorf("envelopeAddress() = %q", got)
+	}
+}
@@ -0,0 +1,309 @@
+// Package notion exports documents to a Notion database as pages.
+//
+// A page mirrors the Markdown export: metadata fields, then headings,
+// paragraphs, and lists as native Notion blocks. Fields whose label matches
+// a column in the target database (e.g. "Channel", "URL", "Words") are also
+// set as page properties, so the database can be sorted and filtered.
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+)
+
+// Provider is the integration provider name for Notion.
+const Provider = "notion"
+
+// apiVersion pins the Notion API version the request shapes below target.
+const apiVersion = "2022-06-28"
+
+// Notion API limits.
+const (
+	maxTextLength    = 2000 // Characters per rich text item
+	maxBlocksPerCall = 100  // Children per create/append request
+)
+
+// Client creates pages via the Notion API.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// New creates a client pointing at the public Notion API.
+func New() *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    "https://api.notion.com/v1",
+	}
+}
+
+// ValidateConfig checks that a target database is configured.
+func ValidateConfig(config map[string]string) error {
+	if strings.TrimSpace(config["database_id"]) == "" {
+		return fmt.Errorf("notion requires config.database_id (the database pages are created in)")
+	}
+	return nil
+}
+
+// Page is a created Notion page.
+type Page struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// block is a Notion block object, e.g. {"type": "paragraph", "paragraph": {...}}.
+type block map[string]interface{}
+
+// CreatePage creates a page for doc in the given database.
+// Blocks past Notion's per-request limit are appended in follow-up calls.
+func (c *Client) CreatePage(ctx context.Context, token, databaseID string, doc *document.Document) (*Page, error) {
+	// The title column can have any name, so look it up along with the
+	// other columns' types before building properties.
+	var db struct {
+		Properties map[string]struct {
+			Type string `json:"type"`
+		} `json:"properties"`
+	}
+	if err := c.do(ctx, "GET", "/databases/"+databaseID, token, nil, &db); err != nil {
+		return nil, err
+	}
+	columns := make(map[string]string, len(db.Properties))
+	for name, p := range db.Properties {
+		columns[name] = p.Type
+	}
+
+	properties, err := buildProperties(doc, columns)
+	if err != nil {
+		return nil, err
+	}
+	blocks := buildBlocks(doc)
+
+	first := blocks
+	if len(first) > maxBlocksPerCall {
+		first = first[:maxBlocksPerCall]
+	}
+	body := map[string]interface{}{
+		"parent":     map[string]string{"database_id": databaseID},
+		"properties": properties,
+		"children":   first,
+	}
+
+	var page Page
+	if err := c.do(ctx, "POST", "/pages", token, body, &page); err != nil {
+		return nil, err
+	}
+
+	for rest := blocks[len(first):]; len(rest) > 0; {
+		n := len(rest)
+		if n > maxBlocksPerCall {
+			n = maxBlocksPerCall
+		}
+		var ignored struct{}
+		if err := c.do(ctx, "PATCH", "/blocks/"+page.ID+"/children", token,
+			map[string]interface{}{"children": rest[:n]}, &ignored); err != nil {
+			return &page, fmt.Errorf("page created but content is incomplete: %w", err)
+		}
+		rest = rest[n:]
+	}
+
+	return &page, nil
+}
+
+// buildProperties sets the title column plus any column whose name matches
+// a document field (case-insensitively) and whose type can hold its value.
+func buildProperties(doc *document.Document, columns map[string]string) (map[string]interface{}, error) {
+	properties := make(map[string]interface{})
+	byName := make(map[string]string, len(columns))
+	for name, typ := range columns {
+		if typ == "title" {
+			properties[name] = map[string]interface{}{"title": richText(doc.Title)}
+		}
+		byName[strings.ToLower(name)] = name
+	}
+	if len(properties) == 0 {
+		return nil, fmt.Errorf("notion database has no title column")
+	}
+
+	for _, b := range doc.Blocks {
+		if b.Kind != document.Field {
+			continue
+		}
+		name, ok := byName[strings.ToLower(b.Label)]
+		if !ok {
+			continue
+		}
+		if value := propertyValue(columns[name], b.Text); value != nil {
+			properties[name] = value
+		}
+	}
+	return properties, nil
+}
+
+// propertyValue converts a field's text to a Notion property value.
+// Returns nil when the column type can't represent the value.
+func propertyValue(columnType, text string) interface{} {
+	switch columnType {
+	case "rich_text":
+		return map[string]interface{}{"rich_text": richText(text)}
+	case "url":
+		return map[string]interface{}{"url": text}
+	case "select":
+		// Select option names can't contain commas
+		return map[string]interface{}{"select": map[string]string{"name": strings.ReplaceAll(text, ",", "")}}
+	case "number":
+		if n, err := strconv.ParseFloat(text, 64); err == nil {
+			return map[string]interface{}{"number": n}
+		}
+	case "date":
+		for _, layout := range []string{"January 2, 2006 3:04 PM", "January 2, 2006"} {
+			if t, err := time.Parse(layout, text); err == nil {
+				return map[string]interface{}{"date": map[string]string{"start": t.Format("2006-01-02")}}
+			}
+		}
+	}
+	return nil
+}
+
+// buildBlocks converts document blocks to Notion blocks, mirroring the
+// Markdown export. The document title becomes the page title, not a block.
+func buildBlocks(doc *document.Document) []block {
+	var blocks []block
+	for _, b := range doc.Blocks {
+		switch b.Kind {
+		case document.Heading:
+			blocks = append(blocks, textBlock("heading_2", b.Text))
+		case document.Paragraph:
+			// Long paragraphs (whole transcripts) become a run of paragraphs
+			// so each stays under the rich text length limit.
+			for _, chunk := range splitText(b.Text, maxTextLength) {
+				blocks = append(blocks, textBlock("paragraph", chunk))
+			}
+		case document.Bullet:
+			blocks = append(blocks, textBlock("bulleted_list_item", b.Text))
+		case document.Checkbox:
+			blocks = append(blocks, block{
+				"object": "block",
+				"type":   "to_do",
+				"to_do":  map[string]interface{}{"rich_text": richText(b.Text), "checked": false},
+			})
+		case document.Field:
+			label := map[string]interface{}{
+				"type":        "text",
+				"text":        map[string]string{"content": b.Label + ": "},
+				"annotations": map[string]bool{"bold": true},
+			}
+			blocks = append(blocks, block{
+				"object":    "block",
+				"type":      "paragraph",
+				"paragraph": map[string]interface{}{"rich_text": append([]interface{}{label}, richText(b.Text)...)},
+			})
+		case document.PageBreak:
+			blocks = append(blocks, block{"object": "block", "type": "divider", "divider": map[string]interface{}{}})
+		}
+	}
+	return blocks
+}
+
+// textBlock builds a block whose only content is rich text.
+func textBlock(kind, text string) block {
+	return block{
+		"object": "block",
+		"type":   kind,
+		kind:     map[string]interface{}{"rich_text": richText(text)},
+	}
+}
+
+// richText splits text into rich text items within the length limit.
+func richText(text string) []interface{} {
+	chunks := splitText(text, maxTextLength)
+	items := make([]interface{}, 0, len(chunks))
+	for _, chunk := range chunks {
+		items = append(items, map[string]interface{}{
+			"type": "text",
+			"text": map[string]string{"content": chunk},
+		})
+	}
+	return items
+}
+
+// splitText breaks text into chunks of at most limit runes, preferring to
+// break at whitespace. Empty text yields a single empty chunk.
+func splitText(text string, limit int) []string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= limit {
+		return []string{string(runes)}
+	}
+
+	var chunks []string
+	for len(runes) > limit {
+		cut := limit
+		for i := limit; i > limit/2; i-- {
+			if runes[i] == ' ' || runes[i] == '\n' {
+				cut = i
+				break
+			}
+		}
+		chunks = append(chunks, strings.TrimSpace(string(runes[:cut])))
+		runes = []rune(strings.TrimSpace(string(runes[cut:])))
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+	return chunks
+}
+
+// do sends a request to the Notion API and decodes the JSON response into out.
+func (c *Client) do(ctx context.Context, method, path, token string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Notion-Version", apiVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Notion errors look like {"object":"error","code":"...","message":"..."}
+		var apiErr struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("notion returned %d (%s): %s", resp.StatusCode, apiErr.Code, apiErr.Message)
+		}
+		return fmt.Errorf("notion returned %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
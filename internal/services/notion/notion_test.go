@@ -0,0 +1,142 @@
+// notion_test.go verifies page creation against a fake Notion API.
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+)
+
+func TestCreatePage(t *testing.T) {
+	var created map[string]interface{}
+	var appended []int // Number of blocks in each append call
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" || r.Header.Get("Notion-Version") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"object":"error","code":"unauthorized","message":"API token is invalid."}`))
+			return
+		}
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/databases/db1":
+			w.Write([]byte(`{"properties":{
+				"Title":{"type":"title"},
+				"channel":{"type":"rich_text"},
+				"URL":{"type":"url"},
+				"Words":{"type":"number"},
+				"Extracted":{"type":"date"},
+				"Duration":{"type":"number"}
+			}}`))
+		case r.Method == "POST" && r.URL.Path == "/pages":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"id":"page1","url":"https://www.notion.so/page1"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/blocks/page1/children":
+			var body struct {
+				Children []interface{} `json:"children"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			appended = append(appended, len(body.Children))
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"object":"error","code":"object_not_found","message":"Could not find database."}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := New()
+	c.baseURL = srv.URL
+
+	// ~250 paragraphs of 2000 characters forces two follow-up append calls
+	doc := document.New("Go Concurrency").
+		Field("Channel", "GopherCon").
+		Field("Duration", "1h 2m 3s").
+		Field("Words", "4200").
+		Field("URL", "https://youtube.com/watch?v=abc").
+		Field("Extracted", "March 3, 2025").
+		PageBreak().
+		Heading("Summary").Paragraph("Channels and goroutines.").
+		Heading("Key Points").Bullets([]string{"Share memory by communicating"}).
+		Heading("Transcript").Paragraph(strings.Repeat("word ", 100000))
+
+	page, err := c.CreatePage(context.Background(), "tok", "db1", doc)
+	if err != nil {
+		t.Fatalf("CreatePage() error = %v", err)
+	}
+	if page.URL != "https://www.notion.so/page1" {
+		t.Errorf("URL = %q", page.URL)
+	}
+
+	props := created["properties"].(map[string]interface{})
+	if _, ok := props["Title"]; !ok {
+		t.Error("title column not set")
+	}
+	if _, ok := props["channel"]; !ok {
+		t.Error("field labels should match columns case-insensitively")
+	}
+	if words := props["Words"].(map[string]interface{})["number"]; words != 4200.0 {
+		t.Errorf("Words = %v, want 4200", words)
+	}
+	if date := props["Extracted"].(map[string]interface{})["date"].(map[string]interface{})["start"]; date != "2025-03-03" {
+		t.Errorf("Extracted = %v, want 2025-03-03", date)
+	}
+	if _, ok := props["Duration"]; ok {
+		t.Error("non-numeric Duration should not be set on a number column")
+	}
+
+	if n := len(created["children"].([]interface{})); n != maxBlocksPerCall {
+		t.Errorf("create call sent %d blocks, want %d", n, maxBlocksPerCall)
+	}
+	if len(appended) != 2 || appended[0] != maxBlocksPerCall {
+		t.Errorf("append calls = %v, want two calls starting with a full batch", appended)
+	}
+
+	// Errors surface Notion's message
+	_, err = c.CreatePage(context.Background(), "tok", "missing", doc)
+	if err == nil || !strings.Contains(err.Error(), "Could not find database") {
+		t.Errorf("expected not-found error, got %v", err)
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{"short", "hello world", 20, []string{"hello world"}},
+		{"breaks at whitespace", "aaaa bbbb cccc", 10, []string{"aaaa bbbb", "cccc"}},
+		{"hard break without whitespace", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"empty", "", 10, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitText(tt.text, tt.limit)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitText() = %q, want %q", got, tt.want)
+			}
+			for _, chunk := range got {
+				if len([]rune(chunk)) > tt.limit {
+					t.Errorf("chunk %q exceeds limit %d", chunk, tt.limit)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	if err := ValidateConfig(map[string]string{}); err == nil {
+		t.Error("expected error without database_id")
+	}
+	if err := ValidateConfig(map[string]string{"database_id": "db1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+// Package oauth implements the OAuth2 authorization code flow for signing in
+// with Google and GitHub.
+//
+// The flow is: redirect the browser to AuthCodeURL, then pass the code the
+// provider sends back to the callback to Identify, which exchanges it for an
+// access token and fetches the account's ID, name, and email — and whether
+// the provider has verified that email.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Provider names, as used in /auth/oauth/:provider.
+const (
+	Google = "google"
+	GitHub = "github"
+)
+
+// Config holds client credentials per provider. A provider without a client
+// ID is disabled.
+type Config struct {
+	GoogleClientID     string
+	GoogleClientSecret string
+	GitHubClientID     string
+	GitHubClientSecret string
+
+	// RedirectBaseURL is this API's public URL; callbacks go to
+	// <RedirectBaseURL>/api/v1/auth/oauth/<provider>/callback.
+	RedirectBaseURL string
+
+	// SuccessRedirectURL, if set, is where the callback sends the browser
+	// with the JWT in the URL fragment (#token=...). Otherwise the callback
+	// responds with JSON like /auth/login.
+	SuccessRedirectURL string
+}
+
+// Identity is the signed-in account as reported by the provider.
+type Identity struct {
+	Subject       string // Provider's stable user ID
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// provider holds one provider's endpoints and credentials.
+type provider struct {
+	clientID     string
+	clientSecret string
+	authURL      string
+	tokenURL     string
+	scopes       []string
+	apiURL       string // Base URL for identity lookups
+	identify     func(ctx context.Context, c *Client, p *provider, token string) (*Identity, error)
+}
+
+// Client runs OAuth flows for the configured providers.
+type Client struct {
+	httpClient      *http.Client
+	providers       map[string]*provider
+	redirectBase    string
+	successRedirect string
+}
+
+// New creates a client for the providers with credentials in cfg.
+func New(cfg Config) *Client {
+	c := &Client{
+		httpClient:      &http.Client{Timeout: 15 * time.Second},
+		providers:       make(map[string]*provider),
+		redirectBase:    strings.TrimRight(cfg.RedirectBaseURL, "/"),
+		successRedirect: cfg.SuccessRedirectURL,
+	}
+	if cfg.GoogleClientID != "" {
+		c.providers[Google] = &provider{
+			clientID:     cfg.GoogleClientID,
+			clientSecret: cfg.GoogleClientSecret,
+			authURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+			tokenURL:     "https://oauth2.googleapis.com/token",
+			scopes:       []string{"openid", "email", "profile"},
+			apiURL:       "https://openidconnect.googleapis.com",
+			identify:     identifyGoogle,
+		}
+	}
+	if cfg.GitHubClientID != "" {
+		c.providers[GitHub] = &provider{
+			clientID:     cfg.GitHubClientID,
+			clientSecret: cfg.GitHubClientSecret,
+			authURL:      "https://github.com/login/oauth/authorize",
+			tokenURL:     "https://github.com/login/oauth/access_token",
+			scopes:       []string{"read:user", "user:email"},
+			apiURL:       "https://api.github.com",
+			identify:     identifyGitHub,
+		}
+	}
+	return c
+}
+
+// Providers returns the names of the configured providers, sorted.
+func (c *Client) Providers() []string {
+	names := make([]string, 0, len(c.providers))
+	for name := range c.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enabled reports whether a provider is configured.
+func (c *Client) Enabled(name string) bool {
+	_, ok := c.providers[name]
+	return ok
+}
+
+// SuccessRedirect returns the URL the callback sends the browser to after
+// sign-in, or "" to respond with JSON.
+func (c *Client) SuccessRedirect() string {
+	return c.successRedirect
+}
+
+// AuthCodeURL returns the provider's consent page URL. state is echoed back
+// to the callback and must be checked there.
+func (c *Client) AuthCodeURL(name, state string) (string, error) {
+	p, ok := c.providers[name]
+	if !ok {
+		return "", fmt.Errorf("oauth provider %q is not configured", name)
+	}
+	q := url.Values{
+		"client_id":     {p.clientID},
+		"redirect_uri":  {c.callbackURL(name)},
+		"response_type": {"code"},
+		"scope":         {strings.Join(p.scopes, " ")},
+		"state":         {state},
+	}
+	return p.authURL + "?" + q.Encode(), nil
+}
+
+// Identify exchanges an authorization code for an access token and returns
+// the account it belongs to.
+func (c *Client) Identify(ctx context.Context, name, code string) (*Identity, error) {
+	p, ok := c.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("oauth provider %q is not configured", name)
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.callbackURL(name)},
+		"client_id":     {p.clientID},
+		"client_secret": {p.clientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json") // GitHub defaults to form encoding
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := c.do(req, name, &token); err != nil {
+		return nil, err
+	}
+	// GitHub reports a bad code with 200 and an error field.
+	if token.Error != "" {
+		return nil, fmt.Errorf("%s token exchange failed (%s): %s", name, token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("%s returned no access token", name)
+	}
+
+	return p.identify(ctx, c, p, token.AccessToken)
+}
+
+// callbackURL is the redirect URI registered with the provider.
+func (c *Client) callbackURL(name string) string {
+	return c.redirectBase + "/api/v1/auth/oauth/" + name + "/callback"
+}
+
+// identifyGoogle reads the OpenID Connect userinfo endpoint.
+func identifyGoogle(ctx context.Context, c *Client, p *provider, token string) (*Identity, error) {
+	var info struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := c.get(ctx, Google, p.apiURL+"/v1/userinfo", token, &info); err != nil {
+		return nil, err
+	}
+	if info.Sub == "" {
+		return nil, fmt.Errorf("google returned no user ID")
+	}
+	return &Identity{
+		Subject:       info.Sub,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified && info.Email != "",
+		Name:          info.Name,
+	}, nil
+}
+
+// identifyGitHub reads the user profile, then the email list, since the
+// profile's public email may be empty or unverified.
+func identifyGitHub(ctx context.Context, c *Client, p *provider, token string) (*Identity, error) {
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := c.get(ctx, GitHub, p.apiURL+"/user", token, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("github returned no user ID")
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := c.get(ctx, GitHub, p.apiURL+"/user/emails", token, &emails); err != nil {
+		return nil, err
+	}
+
+	id := &Identity{Subject: strconv.FormatInt(user.ID, 10), Name: user.Name}
+	if id.Name == "" {
+		id.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			id.Email, id.EmailVerified = e.Email, e.Verified
+			break
+		}
+	}
+	return id, nil
+}
+
+// get makes an authenticated GET to a provider API.
+func (c *Client) get(ctx context.Context, name, rawURL, token string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	return c.do(req, name, out)
+}
+
+// do sends a request and decodes a JSON response.
+func (c *Client) do(req *http.Request, name string, out interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %d", name, resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", name, err)
+	}
+	return nil
+}
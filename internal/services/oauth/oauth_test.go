@@ -0,0 +1,156 @@
+// oauth_test.go verifies sign-in flows against fake Google and GitHub APIs.
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeProvider serves a token endpoint that accepts code "good" and the
+// given identity endpoints, which require the issued token.
+func fakeProvider(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			r.ParseForm()
+			if r.Form.Get("code") != "good" || r.Form.Get("client_secret") != "secret" {
+				w.Write([]byte(`{"error":"bad_verification_code","error_description":"The code is incorrect."}`))
+				return
+			}
+			if !strings.HasSuffix(r.Form.Get("redirect_uri"), "/api/v1/auth/oauth/"+r.URL.Query().Get("p")+"/callback") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"access_token":"tok","token_type":"bearer"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+// testClient points a provider's endpoints at srv.
+func testClient(name string, srv *httptest.Server) *Client {
+	c := New(Config{
+		GoogleClientID: "gid", GoogleClientSecret: "secret",
+		GitHubClientID: "hid", GitHubClientSecret: "secret",
+		RedirectBaseURL: "https://api.example.com/",
+	})
+	p := c.providers[name]
+	p.tokenURL = srv.URL + "/token?p=" + name
+	p.apiURL = srv.URL
+	return c
+}
+
+// TestIdentify verifies each provider's identity is read from its API,
+// including whether the email is verified.
+func TestIdentify(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		routes   map[string]string
+		code     string
+		want     *Identity
+		wantErr  bool
+	}{
+		{
+			name:     "google",
+			provider: Google,
+			routes:   map[string]string{"/v1/userinfo": `{"sub":"123","email":"a@example.com","email_verified":true,"name":"Ann"}`},
+			code:     "good",
+			want:     &Identity{Subject: "123", Email: "a@example.com", EmailVerified: true, Name: "Ann"},
+		},
+		{
+			name:     "google unverified email",
+			provider: Google,
+			routes:   map[string]string{"/v1/userinfo": `{"sub":"123","email":"a@example.com","email_verified":false}`},
+			code:     "good",
+			want:     &Identity{Subject: "123", Email: "a@example.com"},
+		},
+		{
+			name:     "github primary email",
+			provider: GitHub,
+			routes: map[string]string{
+				"/user": `{"id":42,"login":"octo","name":""}`,
+				"/user/emails": `[{"email":"old@example.com","primary":false,"verified":true},
+					{"email":"octo@example.com","primary":true,"verified":true}]`,
+			},
+			code: "good",
+			want: &Identity{Subject: "42", Email: "octo@example.com", EmailVerified: true, Name: "octo"},
+		},
+		{
+			name:     "github unverified primary email",
+			provider: GitHub,
+			routes: map[string]string{
+				"/user":        `{"id":42,"login":"octo","name":"Octo Cat"}`,
+				"/user/emails": `[{"email":"octo@example.com","primary":true,"verified":false}]`,
+			},
+			code: "good",
+			want: &Identity{Subject: "42", Email: "octo@example.com", Name: "Octo Cat"},
+		},
+		{
+			name:     "bad code",
+			provider: GitHub,
+			routes:   map[string]string{},
+			code:     "bad",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeProvider(t, tt.routes)
+			defer srv.Close()
+
+			got, err := testClient(tt.provider, srv).Identify(context.Background(), tt.provider, tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Identify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Identify() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAuthCodeURL verifies the consent URL carries the client ID, callback,
+// scopes, and state, and unconfigured providers are rejected.
+func TestAuthCodeURL(t *testing.T) {
+	c := New(Config{GitHubClientID: "hid", RedirectBaseURL: "https://api.example.com/"})
+
+	if got := c.Providers(); !reflect.DeepEqual(got, []string{GitHub}) {
+		t.Errorf("Providers() = %v, want [github]", got)
+	}
+	if _, err := c.AuthCodeURL(Google, "s"); err == nil {
+		t.Error("AuthCodeURL(google) succeeded without Google credentials")
+	}
+
+	raw, err := c.AuthCodeURL(GitHub, "state123")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	u, _ := url.Parse(raw)
+	q := u.Query()
+	if u.Host != "github.com" || q.Get("client_id") != "hid" || q.Get("state") != "state123" {
+		t.Errorf("AuthCodeURL() = %s", raw)
+	}
+	if got := q.Get("redirect_uri"); got != "https://api.example.com/api/v1/auth/oauth/github/callback" {
+		t.Errorf("redirect_uri = %q", got)
+	}
+	if got := q.Get("scope"); got != "read:user user:email" {
+		t.Errorf("scope = %q", got)
+	}
+}
@@ -6,8 +6,10 @@
 package pdf
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"strings"
 
 	"github.com/ledongthuc/pdf"
@@ -18,44 +20,102 @@ type ExtractionResult struct {
 	Text      string // Extracted text content
 	PageCount int    // Number of pages
 	WordCount int    // Word count
+	FirstPage int    // First and last page extracted; 0 for a document with no pages
+	LastPage  int
+	Tables    []Table // Detected tables, when ExtractOptions.Tables is set
+	Markdown  string  // Text with headings, lists, and tables, when ExtractOptions.Markdown is set
+	Info      DocumentInfo
+	Outline   []OutlineItem // Bookmarks, for a table of contents
+}
+
+// ErrPageRange is returned when the requested pages start past the end of
+// the document.
+var ErrPageRange = errors.New("page range is outside the document")
+
+// ExtractOptions limits extraction to a page range. Zero values mean the
+// first and last page. Tables also runs table detection, and Markdown also
+// renders the text as Markdown; either reads each page's layout a second
+// time.
+type ExtractOptions struct {
+	FirstPage int  `json:"first_page,omitempty"`
+	LastPage  int  `json:"last_page,omitempty"`
+	Tables    bool `json:"tables,omitempty"`
+	Markdown  bool `json:"markdown,omitempty"`
+}
+
+// pageRange clamps the options to a document with pageCount pages.
+func (o ExtractOptions) pageRange(pageCount int) (first, last int) {
+	first, last = max(o.FirstPage, 1), pageCount
+	if o.LastPage > 0 && o.LastPage < pageCount {
+		last = o.LastPage
+	}
+	return first, last
 }
 
 // Extract reads a PDF from the given reader and extracts all text content.
+func Extract(r io.ReaderAt, size int64) (*ExtractionResult, error) {
+	return ExtractWithOptions(r, size, ExtractOptions{})
+}
+
+// ExtractWithOptions extracts the text of the pages opts selects.
+// PageCount is always the whole document's. Each page after the first page
+// of the document is preceded by a "--- Page N ---" line; see PageText.
 //
-// Go Pattern: We accept io.ReaderAt + size instead of a filename because
-// the data comes from an HTTP upload (in memory), not a file on disk.
+// Go Pattern: We accept io.ReaderAt + size instead of a byte slice so an
+// upload can be read straight from its temp file (*os.File is a ReaderAt).
 // The pdf library requires ReaderAt for random access to the PDF structure.
-func Extract(data []byte) (*ExtractionResult, error) {
-	reader := bytes.NewReader(data)
-	size := int64(len(data))
-
+func ExtractWithOptions(r io.ReaderAt, size int64, opts ExtractOptions) (*ExtractionResult, error) {
 	// Open the PDF reader
-	pdfReader, err := pdf.NewReader(reader, size)
+	pdfReader, err := pdf.NewReader(r, size)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open PDF: %w", err)
 	}
 
 	pageCount := pdfReader.NumPage()
+	info, outline, err := documentStructure(pdfReader, pageCount)
+	if err != nil {
+		log.Printf("⚠️  PDF outline extraction failed: %v", err)
+	}
 	if pageCount == 0 {
 		return &ExtractionResult{
 			Text:      "",
 			PageCount: 0,
 			WordCount: 0,
+			Info:      info,
 		}, nil
 	}
 
-	// Extract text from each page
+	// Extract text from each selected page
+	first, last := opts.pageRange(pageCount)
+	if first > last {
+		return nil, fmt.Errorf("%w: it has %d pages", ErrPageRange, pageCount)
+	}
 	var allText strings.Builder
-	for i := 1; i <= pageCount; i++ {
+	var tables []Table
+	var mdPages []markdownPage
+	for i := first; i <= last; i++ {
 		page := pdfReader.Page(i)
 		if page.V.IsNull() {
 			continue
 		}
 
+		if opts.Tables || opts.Markdown {
+			glyphs, err := pageGlyphs(page, i)
+			if err != nil {
+				log.Printf("⚠️  PDF layout extraction failed: %v", err)
+			}
+			if opts.Tables {
+				tables = append(tables, detectTables(i, glyphs)...)
+			}
+			if opts.Markdown {
+				mdPages = append(mdPages, markdownPage{n: i, lines: groupLines(glyphs)})
+			}
+		}
+
 		text, err := page.GetPlainText(nil)
 		if err != nil {
 			// Log but don't fail — some pages may have images only
-			allText.WriteString(fmt.Sprintf("\n--- Page %d (text extraction failed) ---\n", i))
+			allText.WriteString(fmt.Sprintf("\n--- Page %d %s ---\n", i, failedPageNote))
 			continue
 		}
 
@@ -67,11 +127,21 @@ func Extract(data []byte) (*ExtractionResult, error) {
 
 	extractedText := strings.TrimSpace(allText.String())
 	wordCount := countWords(extractedText)
+	var markdown string
+	if opts.Markdown {
+		markdown = renderMarkdown(mdPages)
+	}
 
 	return &ExtractionResult{
 		Text:      extractedText,
 		PageCount: pageCount,
 		WordCount: wordCount,
+		FirstPage: first,
+		LastPage:  last,
+		Tables:    tables,
+		Markdown:  markdown,
+		Info:      info,
+		Outline:   outline,
 	}, nil
 }
 
@@ -82,7 +152,9 @@ func countWords(text string) int {
 }
 
 // ValidatePDF checks if the data looks like a valid PDF by checking the magic bytes.
-func ValidatePDF(data []byte) bool {
+func ValidatePDF(r io.ReaderAt) bool {
 	// PDF files start with "%PDF-"
-	return len(data) >= 5 && string(data[:5]) == "%PDF-"
+	magic := make([]byte, 5)
+	n, _ := r.ReadAt(magic, 0)
+	return n == 5 && string(magic) == "%PDF-"
 }
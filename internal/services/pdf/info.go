@@ -0,0 +1,203 @@
+package pdf
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// DocumentInfo is the PDF's own metadata, from its Info dictionary.
+type DocumentInfo struct {
+	Title      string     `json:"title,omitempty"`
+	Author     string     `json:"author,omitempty"`
+	Subject    string     `json:"subject,omitempty"`
+	Keywords   string     `json:"keywords,omitempty"`
+	Creator    string     `json:"creator,omitempty"`  // Application the document was written in
+	Producer   string     `json:"producer,omitempty"` // Application that made the PDF
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+}
+
+// OutlineItem is one bookmark in the document outline.
+type OutlineItem struct {
+	Title    string        `json:"title"`
+	Page     int           `json:"page,omitempty"` // 0 when the bookmark doesn't point at a page
+	Children []OutlineItem `json:"children,omitempty"`
+}
+
+// Outlines are linked lists in the file, so a malformed one can loop;
+// these bound the walk.
+const (
+	maxOutlineItems = 5000
+	maxOutlineDepth = 16
+)
+
+// documentInfo reads the Info dictionary.
+func documentInfo(r *pdf.Reader) DocumentInfo {
+	info := r.Trailer().Key("Info")
+	text := func(key string) string { return strings.TrimSpace(info.Key(key).Text()) }
+	return DocumentInfo{
+		Title:      text("Title"),
+		Author:     text("Author"),
+		Subject:    text("Subject"),
+		Keywords:   text("Keywords"),
+		Creator:    text("Creator"),
+		Producer:   text("Producer"),
+		CreatedAt:  parsePDFDate(text("CreationDate")),
+		ModifiedAt: parsePDFDate(text("ModDate")),
+	}
+}
+
+// pdfDateRe matches a PDF date, "D:YYYYMMDDHHmmSSOHH'mm'", where everything
+// after the year is optional.
+var pdfDateRe = regexp.MustCompile(`^(?:D:)?(\d{4})(\d{2})?(\d{2})?(\d{2})?(\d{2})?(\d{2})?(?:([+-])(\d{2})'?(\d{2})?'?|Z.*)?$`)
+
+// parsePDFDate parses a PDF date, returning nil if it's missing or malformed.
+func parsePDFDate(s string) *time.Time {
+	m := pdfDateRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil
+	}
+	part := func(i, def int) int {
+		if m[i] == "" {
+			return def
+		}
+		n, _ := strconv.Atoi(m[i])
+		return n
+	}
+	loc := time.UTC
+	if m[7] != "" {
+		offset := part(8, 0)*3600 + part(9, 0)*60
+		if m[7] == "-" {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	t := time.Date(part(1, 0), time.Month(part(2, 1)), part(3, 1), part(4, 0), part(5, 0), part(6, 0), 0, loc)
+	if t.Month() != time.Month(part(2, 1)) || t.Day() != part(3, 1) {
+		return nil // Out-of-range fields, which time.Date would normalize
+	}
+	t = t.UTC()
+	return &t
+}
+
+// outline reads the bookmark tree, resolving each bookmark to a page number.
+func outline(r *pdf.Reader, pageCount int) []OutlineItem {
+	root := r.Trailer().Key("Root")
+
+	// Bookmarks point at page objects; number them once
+	pages := make(map[uint64]int, pageCount)
+	for i := 1; i <= pageCount; i++ {
+		if id := objectID(r.Page(i).V); id != 0 {
+			pages[id] = i
+		}
+	}
+
+	w := outlineWalker{root: root, pages: pages}
+	return w.items(root.Key("Outlines").Key("First"), 0)
+}
+
+// outlineWalker walks an outline tree within the item and depth limits.
+type outlineWalker struct {
+	root  pdf.Value
+	pages map[uint64]int
+	count int
+}
+
+// items reads a list of sibling bookmarks starting at first.
+func (w *outlineWalker) items(first pdf.Value, depth int) []OutlineItem {
+	if depth >= maxOutlineDepth {
+		return nil
+	}
+	var items []OutlineItem
+	for item := first; item.Kind() == pdf.Dict && w.count < maxOutlineItems; item = item.Key("Next") {
+		w.count++
+		items = append(items, OutlineItem{
+			Title:    strings.TrimSpace(item.Key("Title").Text()),
+			Page:     w.page(item),
+			Children: w.items(item.Key("First"), depth+1),
+		})
+	}
+	return items
+}
+
+// page resolves a bookmark's destination — direct, named, or through a
+// GoTo action — to a page number.
+func (w *outlineWalker) page(item pdf.Value) int {
+	dest := item.Key("Dest")
+	if dest.IsNull() {
+		if action := item.Key("A"); action.Key("S").Name() == "GoTo" {
+			dest = action.Key("D")
+		}
+	}
+	switch dest.Kind() {
+	case pdf.Name:
+		dest = w.root.Key("Dests").Key(dest.Name())
+	case pdf.String:
+		dest = lookupName(w.root.Key("Names").Key("Dests"), dest.RawString(), 0)
+	}
+	if dest.Kind() == pdf.Dict {
+		dest = dest.Key("D")
+	}
+	if dest.Kind() != pdf.Array {
+		return 0
+	}
+	return w.pages[objectID(dest.Index(0))]
+}
+
+// lookupName finds key in a PDF name tree.
+func lookupName(node pdf.Value, key string, depth int) pdf.Value {
+	if depth >= maxOutlineDepth {
+		return pdf.Value{}
+	}
+	names := node.Key("Names")
+	for i := 0; i+1 < names.Len(); i += 2 {
+		if names.Index(i).RawString() == key {
+			return names.Index(i + 1)
+		}
+	}
+	kids := node.Key("Kids")
+	for i := 0; i < kids.Len(); i++ {
+		kid := kids.Index(i)
+		if limits := kid.Key("Limits"); limits.Len() == 2 &&
+			(key < limits.Index(0).RawString() || key > limits.Index(1).RawString()) {
+			continue
+		}
+		if v := lookupName(kid, key, depth+1); !v.IsNull() {
+			return v
+		}
+	}
+	return pdf.Value{}
+}
+
+// objectID identifies the indirect object v was read from, so a bookmark's
+// page reference can be matched to a page. The library doesn't export it,
+// so it's read by reflection; 0 means v isn't a resolved object.
+func objectID(v pdf.Value) uint64 {
+	if v.IsNull() {
+		return 0
+	}
+	ptr := reflect.ValueOf(v).FieldByName("ptr")
+	if !ptr.IsValid() || ptr.Kind() != reflect.Struct || ptr.NumField() != 2 {
+		return 0
+	}
+	return ptr.Field(0).Uint()<<16 | ptr.Field(1).Uint()
+}
+
+// documentStructure reads the document info and outline. The PDF library
+// panics on some malformed files; those get whatever was read before.
+func documentStructure(r *pdf.Reader, pageCount int) (info DocumentInfo, items []OutlineItem, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("reading document structure: %v", rec)
+		}
+	}()
+	info = documentInfo(r)
+	items = outline(r, pageCount)
+	return info, items, nil
+}
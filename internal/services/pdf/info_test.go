@@ -0,0 +1,115 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// buildPDF assembles a PDF from numbered object bodies (object 1 first),
+// with a correct cross-reference table.
+func buildPDF(trailer string, objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailer, xref)
+	return b.Bytes()
+}
+
+func TestExtractDocumentStructure(t *testing.T) {
+	data := buildPDF("/Root 1 0 R /Info 11 0 R",
+		"<< /Type /Catalog /Pages 2 0 R /Outlines 6 0 R /Dests << /appendix [5 0 R /Fit] >> >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R 5 0 R] /Count 3 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+		"<< /Type /Outlines /First 7 0 R /Last 10 0 R /Count 3 >>",
+		"<< /Title (Introduction) /Parent 6 0 R /Next 10 0 R /First 8 0 R /Dest [3 0 R /XYZ 0 792 0] >>",
+		"<< /Title (Background) /Parent 7 0 R /Next 9 0 R /A << /S /GoTo /D [4 0 R /Fit] >> >>",
+		"<< /Title (Website) /Parent 7 0 R /A << /S /URI /URI (https://example.com) >> >>",
+		"<< /Title (Appendix) /Parent 6 0 R /Dest /appendix >>",
+		"<< /Title (Annual Report) /Author (Jane Doe) /CreationDate (D:20240315093000-05'00') >>",
+	)
+
+	result, err := Extract(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	if result.Info.Title != "Annual Report" || result.Info.Author != "Jane Doe" {
+		t.Errorf("Info = %+v", result.Info)
+	}
+	wantCreated := time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)
+	if result.Info.CreatedAt == nil || !result.Info.CreatedAt.Equal(wantCreated) {
+		t.Errorf("CreatedAt = %v, want %v", result.Info.CreatedAt, wantCreated)
+	}
+
+	want := []OutlineItem{
+		{Title: "Introduction", Page: 1, Children: []OutlineItem{
+			{Title: "Background", Page: 2},
+			{Title: "Website"},
+		}},
+		{Title: "Appendix", Page: 3},
+	}
+	if !reflect.DeepEqual(result.Outline, want) {
+		t.Errorf("Outline = %+v, want %+v", result.Outline, want)
+	}
+}
+
+func TestExtractOutlineCycle(t *testing.T) {
+	// Each bookmark's Next points at the other
+	data := buildPDF("/Root 1 0 R",
+		"<< /Type /Catalog /Pages 2 0 R /Outlines 4 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+		"<< /Type /Outlines /First 5 0 R >>",
+		"<< /Title (A) /Next 6 0 R >>",
+		"<< /Title (B) /Next 5 0 R >>",
+	)
+
+	result, err := Extract(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(result.Outline) != maxOutlineItems {
+		t.Errorf("got %d outline items, want the %d-item limit", len(result.Outline), maxOutlineItems)
+	}
+}
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // RFC 3339, or "" for nil
+	}{
+		{"D:20240315093000-05'00'", "2024-03-15T14:30:00Z"},
+		{"D:20240315093000+05'30", "2024-03-15T04:00:00Z"},
+		{"D:20240315093000Z", "2024-03-15T09:30:00Z"},
+		{"D:20240315093000Z00'00'", "2024-03-15T09:30:00Z"},
+		{"D:2024", "2024-01-01T00:00:00Z"},
+		{"20240315", "2024-03-15T00:00:00Z"},
+		{"D:20241345", ""},
+		{"March 2024", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := parsePDFDate(tt.in)
+		var s string
+		if got != nil {
+			s = got.Format(time.RFC3339)
+		}
+		if s != tt.want {
+			t.Errorf("parsePDFDate(%q) = %q, want %q", tt.in, s, tt.want)
+		}
+	}
+}
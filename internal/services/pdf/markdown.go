@@ -0,0 +1,245 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Markdown conversion works on the same glyph lines as table detection.
+// The most common font size is body text; lines in bigger sizes are
+// headings, ranked by size, and short all-bold lines are the lowest
+// heading level. Lines starting with a bullet or number are list items,
+// runs of table lines become Markdown tables, and the rest join into
+// paragraphs, split where the vertical gap between lines widens.
+const (
+	headingScale   = 1.15 // Lines at least this much bigger than body text are headings
+	maxHeadingLen  = 120  // Longer lines are text, however they're styled
+	paragraphGapEm = 1.8  // A gap between baselines wider than this many font sizes starts a new paragraph
+	maxHeadingRank = 4    // Heading levels used, from # to ####
+)
+
+var (
+	bulletRe     = regexp.MustCompile(`^(?:[•◦▪‣·●○■□]\s*|[-–*]\s+)`)
+	numberedRe   = regexp.MustCompile(`^\(?(\d{1,3})[.)]\s+`)
+	pageNumberRe = regexp.MustCompile(`(?i)^(?:page\s+)?\d+(?:\s*(?:of|/)\s*\d+)?$`)
+)
+
+// markdownPage is one page's lines, kept until every page is read so
+// heading sizes are ranked across the whole document.
+type markdownPage struct {
+	n     int
+	lines []line
+}
+
+// block is one rendered Markdown block.
+type block struct {
+	text    string
+	list    bool // List items are separated by single newlines
+	heading int  // Heading level; 0 for other blocks
+	size    float64
+}
+
+// renderMarkdown renders pages as Markdown. Each page after the first
+// starts with a "<!-- Page N -->" comment, which keeps page numbers for
+// grounding without showing up in rendered output.
+func renderMarkdown(pages []markdownPage) string {
+	body := bodySize(pages)
+	ranks := headingRanks(pages, body)
+
+	var b strings.Builder
+	for i, p := range pages {
+		if i > 0 || p.n > 1 {
+			fmt.Fprintf(&b, "\n\n<!-- Page %d -->\n\n", p.n)
+		}
+		b.WriteString(renderPage(p.lines, body, ranks))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// bodySize is the font size of the most text, rounded to half a point.
+func bodySize(pages []markdownPage) float64 {
+	chars := make(map[float64]int)
+	for _, p := range pages {
+		for _, l := range p.lines {
+			chars[roundSize(l.size)] += len([]rune(lineText(l)))
+		}
+	}
+	body, most := 0.0, -1
+	for size, n := range chars {
+		if n > most || (n == most && size < body) {
+			body, most = size, n
+		}
+	}
+	return body
+}
+
+// headingRanks maps each heading font size to its level, biggest first.
+func headingRanks(pages []markdownPage, body float64) map[float64]int {
+	seen := make(map[float64]bool)
+	for _, p := range pages {
+		for _, l := range p.lines {
+			if size := roundSize(l.size); size >= body*headingScale && len([]rune(lineText(l))) <= maxHeadingLen {
+				seen[size] = true
+			}
+		}
+	}
+	sizes := make([]float64, 0, len(seen))
+	for size := range seen {
+		sizes = append(sizes, size)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(sizes)))
+
+	ranks := make(map[float64]int, len(sizes))
+	for i, size := range sizes {
+		ranks[size] = min(i+1, maxHeadingRank-1)
+	}
+	return ranks
+}
+
+// renderPage renders one page's lines.
+func renderPage(lines []line, body float64, ranks map[float64]int) string {
+	boldRank := min(len(ranks)+1, maxHeadingRank)
+	var blocks []block
+	var prev *line
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+		text := lineText(l)
+		if text == "" {
+			continue
+		}
+		// Page numbers in the header or footer
+		if (i == 0 || i == len(lines)-1) && pageNumberRe.MatchString(text) {
+			continue
+		}
+
+		if len(l.cells) > 1 {
+			if run := tableRun(lines[i:]); len(run) > 1 {
+				if t, ok := buildTable(0, run); ok {
+					blocks = append(blocks, block{text: markdownTable(t.Rows)})
+					i += len(run) - 1
+					prev = &lines[i]
+					continue
+				}
+			}
+		}
+
+		gap := math.Inf(1)
+		if prev != nil {
+			gap = prev.y - l.y
+		}
+		prev = &lines[i]
+		near := gap <= paragraphGapEm*math.Max(l.size, body)
+		last := len(blocks) - 1
+
+		level := ranks[roundSize(l.size)]
+		if level == 0 && l.bold && roundSize(l.size) >= body && len([]rune(text)) <= maxHeadingLen && !endsSentence(text) {
+			level = boldRank
+		}
+		switch {
+		case level > 0:
+			// A heading wrapped onto a second line
+			if last >= 0 && near && blocks[last].heading == level && blocks[last].size == l.size {
+				blocks[last].text += " " + text
+				continue
+			}
+			blocks = append(blocks, block{text: strings.Repeat("#", level) + " " + text, heading: level, size: l.size})
+		case bulletRe.MatchString(text):
+			blocks = append(blocks, block{text: "- " + bulletRe.ReplaceAllString(text, ""), list: true})
+		case numberedRe.MatchString(text):
+			m := numberedRe.FindStringSubmatch(text)
+			blocks = append(blocks, block{text: m[1] + ". " + text[len(m[0]):], list: true})
+		case last >= 0 && near && blocks[last].heading == 0 && !strings.HasPrefix(blocks[last].text, "|"):
+			blocks[last].text = joinLine(blocks[last].text, text)
+		default:
+			blocks = append(blocks, block{text: escapeMarkdown(text)})
+		}
+	}
+
+	var b strings.Builder
+	for i, bl := range blocks {
+		if i > 0 {
+			if bl.list && blocks[i-1].list {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(bl.text)
+	}
+	return b.String()
+}
+
+// tableRun returns the run of multi-cell lines that lines starts with.
+func tableRun(lines []line) []line {
+	n := 0
+	for n < len(lines) && len(lines[n].cells) > 1 {
+		if n > 0 && lines[n-1].y-lines[n].y > lineGapEm*math.Max(lines[n-1].size, lines[n].size) {
+			break
+		}
+		n++
+	}
+	return lines[:n]
+}
+
+// markdownTable renders rows as a Markdown table, the first row as the header.
+func markdownTable(rows [][]string) string {
+	var b strings.Builder
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, c := range row {
+			cells[j] = strings.ReplaceAll(c, "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |")
+		if i == 0 {
+			b.WriteString("\n|" + strings.Repeat(" --- |", len(row)))
+		}
+		if i < len(rows)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// lineText is a line's text, its cells separated by spaces.
+func lineText(l line) string {
+	texts := make([]string, len(l.cells))
+	for i, c := range l.cells {
+		texts[i] = c.text
+	}
+	return strings.TrimSpace(strings.Join(texts, " "))
+}
+
+// joinLine appends the next line of a paragraph, rejoining words
+// hyphenated across the break.
+func joinLine(paragraph, next string) string {
+	if strings.HasSuffix(paragraph, "-") && !strings.HasSuffix(paragraph, " -") {
+		if r := []rune(next); len(r) > 0 && unicode.IsLower(r[0]) {
+			return strings.TrimSuffix(paragraph, "-") + next
+		}
+	}
+	return paragraph + " " + next
+}
+
+// endsSentence reports whether text ends like a sentence rather than a title.
+func endsSentence(text string) bool {
+	return strings.HasSuffix(text, ".") || strings.HasSuffix(text, ",") || strings.HasSuffix(text, ";")
+}
+
+// escapeMarkdown keeps a paragraph that starts like a heading or quote
+// from rendering as one.
+func escapeMarkdown(text string) string {
+	if strings.HasPrefix(text, "#") || strings.HasPrefix(text, ">") {
+		return `\` + text
+	}
+	return text
+}
+
+// roundSize rounds a font size to half a point, so sizes that differ only
+// by rounding in the PDF compare equal.
+func roundSize(size float64) float64 {
+	return math.Round(size*2) / 2
+}
@@ -0,0 +1,58 @@
+package pdf
+
+import (
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// styled is glyphs in a given font and size, half the size wide per character.
+func styled(s string, x, y, size float64, font string) []pdf.Text {
+	var out []pdf.Text
+	for i, r := range s {
+		out = append(out, pdf.Text{Font: font, FontSize: size, X: x + float64(i)*size/2, Y: y, W: size / 2, S: string(r)})
+	}
+	return out
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	pages := []markdownPage{
+		{n: 1, lines: groupLines(page(
+			styled("Annual Report", 50, 760, 20, "Helvetica"),
+			glyphs("The year brought steady growth in every re-", 50, 730),
+			glyphs("gion, and two new products.", 50, 718),
+			glyphs("# of stores grew too.", 50, 690),
+			styled("Highlights", 50, 665, 10, "Helvetica-Bold"),
+			glyphs("• Revenue up 12%", 50, 650),
+			glyphs("- Costs flat", 50, 638),
+			glyphs("2) Hire staff", 50, 626),
+			glyphs("Region", 50, 600), glyphs("Q1", 200, 600), glyphs("Q2", 300, 600),
+			glyphs("North|South", 50, 588), glyphs("1,200", 190, 588), glyphs("1,350", 290, 588),
+			glyphs("Totals are unaudited.", 50, 560),
+			glyphs("1", 300, 40),
+		))},
+		{n: 2, lines: groupLines(page(
+			glyphs("Page 2 of 2", 50, 780),
+			styled("Outlook", 50, 750, 14, "Helvetica"),
+			glyphs("More of the same.", 50, 725),
+		))},
+	}
+
+	want := "# Annual Report\n\n" +
+		"The year brought steady growth in every region, and two new products.\n\n" +
+		"\\# of stores grew too.\n\n" +
+		"### Highlights\n\n" +
+		"- Revenue up 12%\n" +
+		"- Costs flat\n" +
+		"2. Hire staff\n\n" +
+		"| Region | Q1 | Q2 |\n" +
+		"| --- | --- | --- |\n" +
+		"| North\\|South | 1,200 | 1,350 |\n\n" +
+		"Totals are unaudited.\n\n" +
+		"<!-- Page 2 -->\n\n" +
+		"## Outlook\n\n" +
+		"More of the same."
+	if got := renderMarkdown(pages); got != want {
+		t.Errorf("renderMarkdown =\n%s\nwant\n%s", got, want)
+	}
+}
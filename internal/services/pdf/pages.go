@@ -0,0 +1,77 @@
+package pdf
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// failedPageNote follows the page number in the marker of a page whose text
+// couldn't be extracted.
+const failedPageNote = "(text extraction failed)"
+
+// pageMarkerRe matches the line ExtractWithOptions writes before each page.
+var pageMarkerRe = regexp.MustCompile(`(?m)^--- Page (\d+)( \(text extraction failed\))? ---$`)
+
+// ParsePageRange parses a page range such as "10-25", "7" (one page), or
+// "10-" (to the end of the document). An empty string selects every page.
+func ParsePageRange(s string) (ExtractOptions, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ExtractOptions{}, nil
+	}
+
+	firstStr, lastStr, isRange := strings.Cut(s, "-")
+	first, err := strconv.Atoi(strings.TrimSpace(firstStr))
+	if err != nil || first < 1 {
+		return ExtractOptions{}, fmt.Errorf("invalid page range %q: pages start at 1", s)
+	}
+	if !isRange {
+		return ExtractOptions{FirstPage: first, LastPage: first}, nil
+	}
+	if lastStr = strings.TrimSpace(lastStr); lastStr == "" {
+		return ExtractOptions{FirstPage: first}, nil
+	}
+	last, err := strconv.Atoi(lastStr)
+	if err != nil || last < first {
+		return ExtractOptions{}, fmt.Errorf("invalid page range %q: use FIRST-LAST with FIRST <= LAST", s)
+	}
+	return ExtractOptions{FirstPage: first, LastPage: last}, nil
+}
+
+// JoinPages joins the text of pages 1 through len(pages) with the page
+// markers ExtractWithOptions writes, so PageText can find each page. It's
+// for documents paged some other way, such as EPUB chapters.
+func JoinPages(pages []string) string {
+	var b strings.Builder
+	for i, text := range pages {
+		if i > 0 {
+			fmt.Fprintf(&b, "\n--- Page %d ---\n", i+1)
+		}
+		b.WriteString(strings.TrimSpace(text))
+	}
+	return b.String()
+}
+
+// PageText returns page n's text from text extracted by ExtractWithOptions,
+// where firstPage is the first page that was extracted. ok is false if the
+// page wasn't extracted, or its text couldn't be.
+func PageText(text string, firstPage, n int) (pageText string, ok bool) {
+	// Every page has a marker line except page 1, which starts the text
+	current, start, failed := 0, 0, false
+	if firstPage == 1 {
+		current = 1
+	}
+	for _, m := range pageMarkerRe.FindAllStringSubmatchIndex(text, -1) {
+		if current == n && !failed {
+			return strings.TrimSpace(text[start:m[0]]), true
+		}
+		current, _ = strconv.Atoi(text[m[2]:m[3]])
+		start, failed = m[1], m[4] >= 0
+	}
+	if current == n && !failed {
+		return strings.TrimSpace(text[start:]), true
+	}
+	return "", false
+}
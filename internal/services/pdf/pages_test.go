@@ -0,0 +1,67 @@
+package pdf
+
+import "testing"
+
+func TestParsePageRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ExtractOptions
+		wantErr bool
+	}{
+		{"", ExtractOptions{}, false},
+		{"10-25", ExtractOptions{FirstPage: 10, LastPage: 25}, false},
+		{" 7 ", ExtractOptions{FirstPage: 7, LastPage: 7}, false},
+		{"10-", ExtractOptions{FirstPage: 10}, false},
+		{"0-5", ExtractOptions{}, true},
+		{"25-10", ExtractOptions{}, true},
+		{"a-b", ExtractOptions{}, true},
+		{"-5", ExtractOptions{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePageRange(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParsePageRange(%q) = %+v, %v; want %+v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPageText(t *testing.T) {
+	whole := "Intro\n--- Page 2 ---\nMiddle\n--- Page 3 " + failedPageNote + " ---\n\n--- Page 4 ---\nEnd"
+	ranged := "--- Page 10 ---\nTen\n--- Page 11 ---\nEleven"
+
+	tests := []struct {
+		name   string
+		text   string
+		first  int
+		n      int
+		want   string
+		wantOK bool
+	}{
+		{"first page", whole, 1, 1, "Intro", true},
+		{"middle page", whole, 1, 2, "Middle", true},
+		{"failed page", whole, 1, 3, "", false},
+		{"last page", whole, 1, 4, "End", true},
+		{"past the end", whole, 1, 5, "", false},
+		{"range start", ranged, 10, 10, "Ten", true},
+		{"range end", ranged, 10, 11, "Eleven", true},
+		{"before range", ranged, 10, 1, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PageText(tt.text, tt.first, tt.n)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("PageText(%d) = %q, %v; want %q, %v", tt.n, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestJoinPages(t *testing.T) {
+	pages := []string{"One", " Two\n", "Three"}
+	text := JoinPages(pages)
+	for i, want := range []string{"One", "Two", "Three"} {
+		if got, ok := PageText(text, 1, i+1); !ok || got != want {
+			t.Errorf("PageText(%d) = %q, %v; want %q", i+1, got, ok, want)
+		}
+	}
+}
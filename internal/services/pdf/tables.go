@@ -0,0 +1,213 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Table is a table detected on a page. The first row is usually the header.
+type Table struct {
+	Page int        `json:"page"`
+	Rows [][]string `json:"rows"`
+}
+
+// Table detection works on glyph positions, since plain-text extraction
+// loses the layout. A line whose glyphs have a wide horizontal gap splits
+// into cells; a run of consecutive multi-cell lines is a table, with its
+// columns found by merging the cells' horizontal extents across the run.
+// Tables drawn as images (scans) aren't detected.
+const (
+	cellGapEm   = 1.2 // A gap wider than this many font sizes starts a new cell
+	wordGapEm   = 0.2 // A gap wider than this within a cell is a space
+	lineGapEm   = 2.5 // Lines further apart than this don't belong to one table
+	maxCellText = 40  // Average cell length above which a "table" is really two-column prose
+)
+
+// cell is a run of text on one line and its horizontal extent.
+type cell struct {
+	x0, x1 float64
+	text   string
+}
+
+// line is a row of glyphs sharing a baseline, split into cells.
+type line struct {
+	y, size float64
+	bold    bool // Every glyph is in a bold font
+	cells   []cell
+}
+
+// pageGlyphs reads the positioned text on a page, for table detection and
+// Markdown. The PDF library panics on some malformed content streams; such
+// pages have no glyphs.
+func pageGlyphs(page pdf.Page, n int) (glyphs []pdf.Text, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			glyphs, err = nil, fmt.Errorf("page %d: %v", n, r)
+		}
+	}()
+	return page.Content().Text, nil
+}
+
+// detectTables finds the tables among a page's glyphs.
+func detectTables(page int, glyphs []pdf.Text) []Table {
+	var tables []Table
+	var run []line
+	flush := func() {
+		if t, ok := buildTable(page, run); ok {
+			tables = append(tables, t)
+		}
+		run = nil
+	}
+
+	for _, l := range groupLines(glyphs) {
+		if len(l.cells) < 2 {
+			flush()
+			continue
+		}
+		if len(run) > 0 {
+			prev := run[len(run)-1]
+			if prev.y-l.y > lineGapEm*math.Max(prev.size, l.size) {
+				flush()
+			}
+		}
+		run = append(run, l)
+	}
+	flush()
+	return tables
+}
+
+// groupLines groups glyphs into lines, top to bottom, and each line into cells.
+func groupLines(glyphs []pdf.Text) []line {
+	sorted := make([]pdf.Text, 0, len(glyphs))
+	for _, g := range glyphs {
+		if g.S != "" {
+			sorted = append(sorted, g)
+		}
+	}
+	// PDF y grows upwards, so higher lines come first
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Y != sorted[j].Y {
+			return sorted[i].Y > sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+
+	var lines []line
+	var current []pdf.Text
+	for _, g := range sorted {
+		if len(current) > 0 && math.Abs(current[0].Y-g.Y) > 0.3*fontSize(current[0]) {
+			lines = append(lines, splitCells(current))
+			current = nil
+		}
+		current = append(current, g)
+	}
+	if len(current) > 0 {
+		lines = append(lines, splitCells(current))
+	}
+	return lines
+}
+
+// splitCells splits one line's glyphs into cells at wide gaps.
+func splitCells(glyphs []pdf.Text) line {
+	sort.SliceStable(glyphs, func(i, j int) bool { return glyphs[i].X < glyphs[j].X })
+
+	l := line{y: glyphs[0].Y, size: fontSize(glyphs[0]), bold: true}
+	var sb strings.Builder
+	var cur cell
+	started, space := false, false
+	for _, g := range glyphs {
+		if strings.TrimSpace(g.S) == "" {
+			space = true
+			continue
+		}
+		l.bold = l.bold && isBold(g.Font)
+		gap := g.X - cur.x1
+		switch {
+		case !started:
+			cur, started = cell{x0: g.X}, true
+		case gap > cellGapEm*l.size:
+			cur.text = sb.String()
+			l.cells = append(l.cells, cur)
+			sb.Reset()
+			cur = cell{x0: g.X}
+		case space || gap > wordGapEm*l.size:
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(g.S)
+		cur.x1 = g.X + g.W
+		space = false
+	}
+	if started {
+		cur.text = sb.String()
+		l.cells = append(l.cells, cur)
+	}
+	return l
+}
+
+// buildTable lays a run of multi-cell lines out in columns. ok is false if
+// the run is too small to be a table or looks like prose.
+func buildTable(page int, run []line) (Table, bool) {
+	if len(run) < 2 {
+		return Table{}, false
+	}
+
+	// Columns are the merged extents of every cell in the run, so left- and
+	// right-aligned cells of one column land together
+	var extents []cell
+	chars, cells := 0, 0
+	for _, l := range run {
+		for _, c := range l.cells {
+			extents = append(extents, c)
+			chars += len([]rune(c.text))
+			cells++
+		}
+	}
+	if chars/cells > maxCellText {
+		return Table{}, false
+	}
+	sort.Slice(extents, func(i, j int) bool { return extents[i].x0 < extents[j].x0 })
+	var columns []cell
+	for _, e := range extents {
+		if n := len(columns); n > 0 && e.x0 <= columns[n-1].x1 {
+			columns[n-1].x1 = math.Max(columns[n-1].x1, e.x1)
+			continue
+		}
+		columns = append(columns, cell{x0: e.x0, x1: e.x1})
+	}
+	if len(columns) < 2 {
+		return Table{}, false
+	}
+
+	t := Table{Page: page, Rows: make([][]string, len(run))}
+	for i, l := range run {
+		row := make([]string, len(columns))
+		for _, c := range l.cells {
+			col := sort.Search(len(columns), func(k int) bool { return columns[k].x1 >= c.x0 })
+			if row[col] != "" {
+				row[col] += " "
+			}
+			row[col] += c.text
+		}
+		t.Rows[i] = row
+	}
+	return t, true
+}
+
+// isBold reports whether a font name is a bold face, such as
+// "Helvetica-Bold" or "ABCDEF+Arial,Bold".
+func isBold(font string) bool {
+	font = strings.ToLower(font)
+	return strings.Contains(font, "bold") || strings.Contains(font, "black") || strings.Contains(font, "heavy")
+}
+
+// fontSize is a glyph's font size, with a fallback for fonts that report 0.
+func fontSize(g pdf.Text) float64 {
+	if g.FontSize <= 0 {
+		return 10
+	}
+	return g.FontSize
+}
@@ -0,0 +1,73 @@
+package pdf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// glyphs lays out s at x on baseline y, 5pt per character at 10pt.
+func glyphs(s string, x, y float64) []pdf.Text {
+	var out []pdf.Text
+	for i, r := range s {
+		out = append(out, pdf.Text{FontSize: 10, X: x + float64(i)*5, Y: y, W: 5, S: string(r)})
+	}
+	return out
+}
+
+// page joins text runs into one page's glyphs.
+func page(runs ...[]pdf.Text) []pdf.Text {
+	var out []pdf.Text
+	for _, r := range runs {
+		out = append(out, r...)
+	}
+	return out
+}
+
+func TestDetectTables(t *testing.T) {
+	tests := []struct {
+		name   string
+		glyphs []pdf.Text
+		want   []Table
+	}{
+		{
+			name: "financial table under a heading",
+			glyphs: page(
+				glyphs("Quarterly results", 50, 700),
+				glyphs("Region", 50, 680), glyphs("Q1", 200, 680), glyphs("Q2", 300, 680),
+				glyphs("North America", 50, 668), glyphs("1,200", 190, 668), glyphs("1,350", 290, 668),
+				glyphs("Europe", 50, 656), glyphs("980", 200, 656), // Q2 left blank
+				glyphs("Totals are unaudited.", 50, 630),
+			),
+			want: []Table{{Page: 3, Rows: [][]string{
+				{"Region", "Q1", "Q2"},
+				{"North America", "1,200", "1,350"},
+				{"Europe", "980", ""},
+			}}},
+		},
+		{
+			name:   "plain prose",
+			glyphs: page(glyphs("One line of text.", 50, 700), glyphs("And another one.", 50, 688)),
+		},
+		{
+			name: "two-column prose",
+			glyphs: page(
+				glyphs("The quarter opened with strong demand across", 50, 700), glyphs("Meanwhile the supply chain recovered slowly", 320, 700),
+				glyphs("every region, led by new enterprise accounts", 50, 688), glyphs("and shipping costs stayed above forecast", 320, 688),
+			),
+		},
+		{
+			name:   "single multi-cell line",
+			glyphs: page(glyphs("Page", 50, 20), glyphs("3", 500, 20)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectTables(3, tt.glyphs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectTables() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
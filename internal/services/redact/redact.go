@@ -0,0 +1,238 @@
+// Package redact masks personal information — email addresses, phone
+// numbers, credit card numbers, and people's names — in transcript text.
+//
+// Emails, phones, and cards are found with regular expressions. Names can't
+// be, so they come from a NameDetector (an LLM doing named-entity
+// recognition) and are then masked wherever they appear. Every pass produces
+// a Report counting what was masked; the masked values themselves are never
+// kept.
+package redact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kinds of personal information, as used in Report.Counts.
+const (
+	KindEmail = "email"
+	KindPhone = "phone"
+	KindCard  = "credit_card"
+	KindName  = "name"
+)
+
+// masks replace each kind of match in the redacted text.
+var masks = map[string]string{
+	KindEmail: "[EMAIL]",
+	KindPhone: "[PHONE]",
+	KindCard:  "[CARD]",
+	KindName:  "[NAME]",
+}
+
+var (
+	emailPattern = regexp.MustCompile(`(?i)[a-z0-9._%+-]+@[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}`)
+
+	// 13-19 digits, optionally grouped with spaces or dashes. Matches are
+	// Luhn-checked so order numbers and the like are left alone.
+	cardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+	// International numbers with a + prefix, or North American ones like
+	// (671) 555-0123 and 671.555.0123.
+	phonePattern = regexp.MustCompile(`\+\d{1,3}(?:[ .-]?\(?\d{1,4}\)?){2,5}\b|(?:\(\d{3}\) ?|\b\d{3}[ .-])\d{3}[ .-]\d{4}\b`)
+)
+
+// NameDetector finds the names of people mentioned in text.
+type NameDetector interface {
+	DetectNames(ctx context.Context, text string) ([]string, error)
+}
+
+// Report summarizes a redaction pass.
+type Report struct {
+	Counts       map[string]int `json:"counts"`               // Matches masked, by kind
+	Total        int            `json:"total"`                // Sum of Counts
+	NamesChecked bool           `json:"names_checked"`        // False when name detection was unavailable or failed
+	NameError    string         `json:"name_error,omitempty"` // Why name detection failed
+	RedactedAt   time.Time      `json:"redacted_at"`
+}
+
+// Redactor masks personal information and tallies what it masked.
+// It is not safe for concurrent use.
+type Redactor struct {
+	names  *regexp.Regexp // nil when there are no names to mask
+	report Report
+}
+
+// New returns a Redactor that masks emails, phones, and cards, plus the
+// given names wherever they appear as whole words.
+func New(names []string) *Redactor {
+	r := &Redactor{report: Report{
+		Counts:     map[string]int{KindEmail: 0, KindPhone: 0, KindCard: 0, KindName: 0},
+		RedactedAt: time.Now().UTC(),
+	}}
+
+	var quoted []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			quoted = append(quoted, regexp.QuoteMeta(name))
+		}
+	}
+	if len(quoted) > 0 {
+		// Longest first, so "Anna Lee" wins over "Anna"
+		sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+		r.names = regexp.MustCompile(strings.Join(quoted, "|"))
+	}
+	return r
+}
+
+// Prepare runs name detection over text (when a detector is given) and
+// returns a Redactor for it. A failed detection still returns a Redactor
+// that masks the pattern-matched kinds; the report records the failure.
+func Prepare(ctx context.Context, detector NameDetector, text string) *Redactor {
+	if detector == nil || strings.TrimSpace(text) == "" {
+		r := New(nil)
+		r.report.NamesChecked = detector != nil
+		return r
+	}
+	names, err := detector.DetectNames(ctx, text)
+	r := New(names)
+	if err != nil {
+		r.report.NameError = err.Error()
+	} else {
+		r.report.NamesChecked = true
+	}
+	return r
+}
+
+// Text returns s with personal information masked.
+func (r *Redactor) Text(s string) string {
+	s = r.replace(s, emailPattern, KindEmail, nil)
+	s = r.replace(s, cardPattern, KindCard, luhnValid)
+	s = r.replace(s, phonePattern, KindPhone, nil)
+	if r.names != nil {
+		s = r.maskNames(s)
+	}
+	return s
+}
+
+// JSON masks every string value in a JSON document, such as stored
+// segments or key points. Numbers and structure are left untouched.
+// Invalid JSON is returned as is.
+func (r *Redactor) JSON(raw []byte) []byte {
+	if len(raw) == 0 {
+		return raw
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber() // Keep segment timings exactly as stored
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return raw
+	}
+	out, err := json.Marshal(r.walk(v))
+	if err != nil {
+		return raw
+	}
+	return out
+}
+
+// walk masks the strings in a decoded JSON value.
+func (r *Redactor) walk(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return r.Text(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = r.walk(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = r.walk(v[k])
+		}
+	}
+	return v
+}
+
+// Report returns what has been masked so far.
+func (r *Redactor) Report() Report {
+	report := r.report
+	report.Counts = make(map[string]int, len(r.report.Counts))
+	for kind, n := range r.report.Counts {
+		report.Counts[kind] = n
+		report.Total += n
+	}
+	return report
+}
+
+// replace masks each match of pattern that passes valid (if given).
+func (r *Redactor) replace(s string, pattern *regexp.Regexp, kind string, valid func(string) bool) string {
+	return pattern.ReplaceAllStringFunc(s, func(match string) string {
+		if valid != nil && !valid(match) {
+			return match
+		}
+		r.report.Counts[kind]++
+		return masks[kind]
+	})
+}
+
+// maskNames masks names that stand as whole words. Go's \b only knows
+// ASCII, so the boundaries are checked by hand for names like "José".
+func (r *Redactor) maskNames(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range r.names.FindAllStringIndex(s, -1) {
+		if !wordBoundary(s, loc[0], loc[1]) {
+			continue
+		}
+		b.WriteString(s[last:loc[0]])
+		b.WriteString(masks[KindName])
+		last = loc[1]
+		r.report.Counts[KindName]++
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// wordBoundary reports whether s[start:end] isn't part of a longer word.
+func wordBoundary(s string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(s[:start]); start > 0 && isWordRune(before) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(s[end:]); end < len(s) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_'
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum that
+// every payment card number carries.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
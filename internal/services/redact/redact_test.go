@@ -0,0 +1,158 @@
+// redact_test.go contains tests for PII masking and redaction reports.
+package redact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestText checks each kind of PII is masked and counted, and that
+// look-alikes are left alone.
+func TestText(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		in    string
+		want  string
+		kind  string
+		count int
+	}{
+		{
+			name:  "email",
+			in:    "Write to jane.doe+news@example.co.uk today.",
+			want:  "Write to [EMAIL] today.",
+			kind:  KindEmail,
+			count: 1,
+		},
+		{
+			name:  "north american phone",
+			in:    "Call (671) 555-0123 or 671.555.0199.",
+			want:  "Call [PHONE] or [PHONE].",
+			kind:  KindPhone,
+			count: 2,
+		},
+		{
+			name:  "international phone",
+			in:    "Our London office is +44 20 7946 0958.",
+			want:  "Our London office is [PHONE].",
+			kind:  KindPhone,
+			count: 1,
+		},
+		{
+			name:  "card number with spaces",
+			in:    "Card 4111 1111 1111 1111 on file.",
+			want:  "Card [CARD] on file.",
+			kind:  KindCard,
+			count: 1,
+		},
+		{
+			name:  "digits failing the Luhn check are not a card",
+			in:    "Order 1234567812345678 shipped.",
+			want:  "Order 1234567812345678 shipped.",
+			kind:  KindCard,
+			count: 0,
+		},
+		{
+			name:  "timestamps and years are not phones",
+			in:    "At 12:30 in 2019-2020 we met.",
+			want:  "At 12:30 in 2019-2020 we met.",
+			kind:  KindPhone,
+			count: 0,
+		},
+		{
+			name:  "names as whole words only",
+			names: []string{"Ann", "Ann Lee", "José"},
+			in:    "Ann Lee and José met Annabel and Ann.",
+			want:  "[NAME] and [NAME] met Annabel and [NAME].",
+			kind:  KindName,
+			count: 3,
+		},
+		{
+			name:  "names with regex characters",
+			names: []string{"J.R. (Bob)"},
+			in:    "Ask J.R. (Bob) first.",
+			want:  "Ask [NAME] first.",
+			kind:  KindName,
+			count: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.names)
+			if got := r.Text(tt.in); got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+			report := r.Report()
+			if report.Counts[tt.kind] != tt.count {
+				t.Errorf("Counts[%s] = %d, want %d", tt.kind, report.Counts[tt.kind], tt.count)
+			}
+			if report.Total != tt.count {
+				t.Errorf("Total = %d, want %d", report.Total, tt.count)
+			}
+		})
+	}
+}
+
+// TestJSON checks string values are masked while numbers keep their
+// exact stored form.
+func TestJSON(t *testing.T) {
+	r := New([]string{"Maria"})
+	raw := json.RawMessage(`[{"start":1234.5678901,"end":12.5,"text":"Maria said email me at m@x.io"}]`)
+
+	got := r.JSON(raw)
+	want := `[{"end":12.5,"start":1234.5678901,"text":"[NAME] said email me at [EMAIL]"}]`
+	if string(got) != want {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+	if r.Report().Total != 2 {
+		t.Errorf("Total = %d, want 2", r.Report().Total)
+	}
+
+	if got := r.JSON(json.RawMessage(`not json`)); string(got) != "not json" {
+		t.Errorf("invalid JSON = %s, want it unchanged", got)
+	}
+}
+
+type fakeDetector struct {
+	names []string
+	err   error
+}
+
+func (f fakeDetector) DetectNames(ctx context.Context, text string) ([]string, error) {
+	return f.names, f.err
+}
+
+// TestPrepare checks the report records whether names were checked.
+func TestPrepare(t *testing.T) {
+	tests := []struct {
+		name        string
+		detector    NameDetector
+		wantChecked bool
+		wantError   bool
+		wantText    string
+	}{
+		{"no detector", nil, false, false, "Kai called [PHONE]"},
+		{"detector", fakeDetector{names: []string{"Kai"}}, true, false, "[NAME] called [PHONE]"},
+		{"detector fails", fakeDetector{err: errors.New("rate limited")}, false, true, "Kai called [PHONE]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := "Kai called 555-123-4567"
+			r := Prepare(context.Background(), tt.detector, in)
+			if got := r.Text(in); got != tt.wantText {
+				t.Errorf("Text() = %q, want %q", got, tt.wantText)
+			}
+			report := r.Report()
+			if report.NamesChecked != tt.wantChecked {
+				t.Errorf("NamesChecked = %v, want %v", report.NamesChecked, tt.wantChecked)
+			}
+			if (report.NameError != "") != tt.wantError {
+				t.Errorf("NameError = %q, wantError %v", report.NameError, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package secretbox encrypts small secrets, like the credentials webhooks
+// send to their receivers, before they are stored in the database.
+//
+// Values are sealed with AES-256-GCM under a key derived from the server's
+// ENCRYPTION_KEY, with a fresh random nonce each time, and stored as
+// base64 text. Changing the key makes everything sealed under the old one
+// unreadable.
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrNoKey is returned when a Box is needed but no key is configured.
+var ErrNoKey = errors.New("ENCRYPTION_KEY is not set")
+
+// Box seals and opens values with a single key.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New creates a Box for a key of any length; the AES key is its SHA-256
+// hash. An empty key returns ErrNoKey.
+func New(key string) (*Box, error) {
+	if key == "" {
+		return nil, ErrNoKey
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Seal encrypts plaintext and returns it as base64 text.
+func (b *Box) Seal(plaintext []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := b.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts text from Seal. It fails if the text was sealed under a
+// different key or has been tampered with.
+func (b *Box) Open(sealed string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed value: %w", err)
+	}
+	if len(data) < b.aead.NonceSize() {
+		return nil, errors.New("invalid sealed value: too short")
+	}
+	nonce, ciphertext := data[:b.aead.NonceSize()], data[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt sealed value; was ENCRYPTION_KEY changed?")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,55 @@
+// secretbox_test.go contains tests for sealing and opening secrets.
+package secretbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	box, err := New("test-key")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	sealed, err := box.Seal([]byte("Bearer s3cret"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if strings.Contains(sealed, "s3cret") {
+		t.Errorf("sealed value %q contains the plaintext", sealed)
+	}
+	again, _ := box.Seal([]byte("Bearer s3cret"))
+	if again == sealed {
+		t.Error("sealing twice gave the same text; nonce not random")
+	}
+
+	got, err := box.Open(sealed)
+	if err != nil || string(got) != "Bearer s3cret" {
+		t.Errorf("Open = %q, %v", got, err)
+	}
+
+	other, _ := New("other-key")
+	if _, err := other.Open(sealed); err == nil {
+		t.Error("Open under a different key succeeded")
+	}
+	tampered := []byte(sealed)
+	if tampered[20] == 'A' {
+		tampered[20] = 'B'
+	} else {
+		tampered[20] = 'A'
+	}
+	if _, err := box.Open(string(tampered)); err == nil {
+		t.Error("Open of tampered text succeeded")
+	}
+	if _, err := box.Open("!!"); err == nil {
+		t.Error("Open of invalid base64 succeeded")
+	}
+}
+
+func TestNewWithoutKey(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, ErrNoKey) {
+		t.Errorf("New(\"\") error = %v, want ErrNoKey", err)
+	}
+}
@@ -0,0 +1,279 @@
+// Package settings lets admins change selected settings at runtime — the
+// default model, default API key tier, worker count, and feature flags — instead of
+// redeploying for every tweak.
+//
+// Values start from configuration. Admin overrides are stored in the
+// database; every instance reloads them periodically and hands changes to
+// the services that use them.
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// Values are the adjustable settings in effect.
+type Values struct {
+	DefaultModel         string // Model for AI features when a request doesn't pick one
+	DefaultTier          string // Tier for new keys that don't pick one
+	MaxKeysPerUser       int    // Active self-service keys per user; 0 means no limit
+	WorkerCount          int    // Background worker goroutines
+	RequireVerifiedEmail bool   // AI features need a verified email
+}
+
+// Errors returned for bad changes.
+var (
+	ErrUnknownSetting = errors.New("unknown setting") // The key isn't adjustable
+	ErrInvalidSetting = errors.New("invalid setting") // An unknown key or a bad value in an update
+)
+
+// definition describes one adjustable setting: set validates a JSON value
+// and stores it in Values, get reads it back.
+type definition struct {
+	key         string
+	description string
+	set         func(v *Values, raw json.RawMessage) error
+	get         func(v Values) interface{}
+}
+
+var definitions = []definition{
+	{
+		key:         "default_model",
+		description: "Model for AI features when a request doesn't choose one",
+		set: func(v *Values, raw json.RawMessage) error {
+			var model string
+			if err := json.Unmarshal(raw, &model); err != nil {
+				return errors.New("must be a string")
+			}
+			model = strings.TrimSpace(model)
+			if model == "" || len(model) > 200 || strings.ContainsAny(model, " \t\r\n") {
+				return errors.New("must be a model ID such as openai/gpt-4o-mini")
+			}
+			v.DefaultModel = model
+			return nil
+		},
+		get: func(v Values) interface{} { return v.DefaultModel },
+	},
+	{
+		key:         "default_tier",
+		description: "Tier for new API keys that don't pick one (free, pro, or enterprise)",
+		set: func(v *Values, raw json.RawMessage) error {
+			var tier string
+			if err := json.Unmarshal(raw, &tier); err != nil {
+				return errors.New("must be a string")
+			}
+			for _, t := range models.Tiers {
+				if t.Name == tier {
+					v.DefaultTier = tier
+					return nil
+				}
+			}
+			return errors.New("must be free, pro, or enterprise")
+		},
+		get: func(v Values) interface{} { return v.DefaultTier },
+	},
+	{
+		key:         "max_keys_per_user",
+		description: "Active self-service API keys per user (0 for no limit)",
+		set:         intSetter(0, 1000, func(v *Values, n int) { v.MaxKeysPerUser = n }),
+		get:         func(v Values) interface{} { return v.MaxKeysPerUser },
+	},
+	{
+		key:         "worker_count",
+		description: "Background workers processing jobs on each instance",
+		set:         intSetter(1, 64, func(v *Values, n int) { v.WorkerCount = n }),
+		get:         func(v Values) interface{} { return v.WorkerCount },
+	},
+	{
+		key:         "require_verified_email",
+		description: "Whether AI features need a verified email",
+		set: func(v *Values, raw json.RawMessage) error {
+			var enabled bool
+			if err := json.Unmarshal(raw, &enabled); err != nil {
+				return errors.New("must be true or false")
+			}
+			v.RequireVerifiedEmail = enabled
+			return nil
+		},
+		get: func(v Values) interface{} { return v.RequireVerifiedEmail },
+	},
+}
+
+// intSetter validates whole numbers between lo and hi.
+func intSetter(lo, hi int, assign func(v *Values, n int)) func(*Values, json.RawMessage) error {
+	return func(v *Values, raw json.RawMessage) error {
+		var n int
+		if err := json.Unmarshal(raw, &n); err != nil || n < lo || n > hi {
+			return fmt.Errorf("must be a whole number from %d to %d", lo, hi)
+		}
+		assign(v, n)
+		return nil
+	}
+}
+
+// lookup finds a setting's definition by key.
+func lookup(key string) (definition, bool) {
+	for _, d := range definitions {
+		if d.key == key {
+			return d, true
+		}
+	}
+	return definition{}, false
+}
+
+// state is a consistent snapshot of the effective values and the overrides
+// they came from.
+type state struct {
+	values    Values
+	overrides map[string]models.SettingOverride
+}
+
+// Service holds the effective settings. Safe for concurrent use.
+type Service struct {
+	db       *database.DB
+	defaults Values
+	state    atomic.Pointer[state]
+	mu       sync.Mutex // Serializes applying new state
+	onChange []func(Values)
+}
+
+// New creates a service whose values start at defaults, the configured
+// values, until Reload picks up overrides.
+func New(db *database.DB, defaults Values) *Service {
+	s := &Service{db: db, defaults: defaults}
+	s.state.Store(&state{values: defaults})
+	return s
+}
+
+// Current returns the settings in effect.
+func (s *Service) Current() Values {
+	return s.state.Load().values
+}
+
+// OnChange registers fn to be called with the new values whenever they
+// change. Register before the first Reload.
+func (s *Service) OnChange(fn func(Values)) {
+	s.onChange = append(s.onChange, fn)
+}
+
+// List describes every adjustable setting.
+func (s *Service) List() []models.RuntimeSetting {
+	st := s.state.Load()
+	list := make([]models.RuntimeSetting, len(definitions))
+	for i, d := range definitions {
+		list[i] = models.RuntimeSetting{
+			Key:         d.key,
+			Value:       d.get(st.values),
+			Default:     d.get(s.defaults),
+			Description: d.description,
+		}
+		if o, ok := st.overrides[d.key]; ok {
+			list[i].Overridden = true
+			list[i].UpdatedAt = &o.UpdatedAt
+		}
+	}
+	return list
+}
+
+// Update validates and stores overrides, then applies them. Nothing is
+// stored unless every value is valid.
+func (s *Service) Update(ctx context.Context, changes map[string]json.RawMessage) error {
+	if len(changes) == 0 {
+		return fmt.Errorf("%w: no settings given", ErrInvalidSetting)
+	}
+	v := s.Current()
+	stored := make(map[string]string, len(changes))
+	for key, raw := range changes {
+		d, ok := lookup(key)
+		if !ok {
+			return fmt.Errorf("%w: %q isn't adjustable", ErrInvalidSetting, key)
+		}
+		if err := d.set(&v, raw); err != nil {
+			return fmt.Errorf("%w: %s %v", ErrInvalidSetting, key, err)
+		}
+		encoded, _ := json.Marshal(d.get(v))
+		stored[key] = string(encoded)
+	}
+
+	if err := s.db.SetSettingOverrides(ctx, stored); err != nil {
+		return err
+	}
+	return s.Reload(ctx)
+}
+
+// Reset removes a setting's override, restoring its configured value.
+// Resetting a setting that isn't overridden does nothing.
+func (s *Service) Reset(ctx context.Context, key string) error {
+	if _, ok := lookup(key); !ok {
+		return fmt.Errorf("%w %q", ErrUnknownSetting, key)
+	}
+	if err := s.db.DeleteSettingOverride(ctx, key); err != nil {
+		return err
+	}
+	return s.Reload(ctx)
+}
+
+// Reload reads the overrides from the database and applies them.
+func (s *Service) Reload(ctx context.Context) error {
+	overrides, err := s.db.ListSettingOverrides(ctx)
+	if err != nil {
+		return err
+	}
+	s.apply(overrides)
+	return nil
+}
+
+// apply lays overrides over the defaults and notifies OnChange callbacks if
+// the values changed. Stored values that no longer validate (say, after a
+// limit was tightened) are skipped and logged.
+func (s *Service) apply(overrides []models.SettingOverride) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := &state{values: s.defaults, overrides: make(map[string]models.SettingOverride, len(overrides))}
+	for _, o := range overrides {
+		d, ok := lookup(o.Key)
+		if !ok {
+			continue
+		}
+		if err := d.set(&next.values, json.RawMessage(o.Value)); err != nil {
+			log.Printf("⚠️  Ignoring runtime setting %s: %v", o.Key, err)
+			continue
+		}
+		next.overrides[o.Key] = o
+	}
+
+	prev := s.state.Swap(next)
+	if prev.values != next.values {
+		for _, fn := range s.onChange {
+			fn(next.values)
+		}
+	}
+}
+
+// Watch reloads the overrides every interval until ctx is done, so changes
+// made through another instance reach this one too.
+func (s *Service) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Reload(ctx); err != nil {
+				log.Printf("⚠️  Failed to reload runtime settings: %v", err)
+			}
+		}
+	}
+}
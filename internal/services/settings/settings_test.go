@@ -0,0 +1,111 @@
+// settings_test.go contains tests for runtime settings: validation,
+// layering overrides over configured values, and change notification.
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+var testDefaults = Values{
+	DefaultModel:   "anthropic/claude-4.5-sonnet",
+	DefaultTier:    "free",
+	MaxKeysPerUser: 10,
+	WorkerCount:    3,
+}
+
+// TestApplyOverrides verifies stored overrides replace configured values,
+// invalid or unknown ones are skipped, and callbacks run only on change.
+func TestApplyOverrides(t *testing.T) {
+	s := New(nil, testDefaults)
+	var calls []Values
+	s.OnChange(func(v Values) { calls = append(calls, v) })
+
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	overrides := []models.SettingOverride{
+		{Key: "worker_count", Value: "6", UpdatedAt: updated},
+		{Key: "require_verified_email", Value: "true", UpdatedAt: updated},
+		{Key: "default_tier", Value: `"gold"`, UpdatedAt: updated}, // Not a tier
+		{Key: "retired_setting", Value: `"x"`, UpdatedAt: updated},
+	}
+	s.apply(overrides)
+
+	got := s.Current()
+	if got.WorkerCount != 6 || !got.RequireVerifiedEmail {
+		t.Errorf("Current() = %+v, want worker_count 6 and require_verified_email on", got)
+	}
+	if got.DefaultTier != "free" || got.DefaultModel != testDefaults.DefaultModel {
+		t.Errorf("Current() = %+v, want the other settings at their configured values", got)
+	}
+	if len(calls) != 1 || calls[0] != got {
+		t.Errorf("OnChange called %d times with %+v, want once with the new values", len(calls), calls)
+	}
+
+	for _, setting := range s.List() {
+		wantOverridden := setting.Key == "worker_count" || setting.Key == "require_verified_email"
+		if setting.Overridden != wantOverridden {
+			t.Errorf("%s: Overridden = %v, want %v", setting.Key, setting.Overridden, wantOverridden)
+		}
+		if setting.Key == "worker_count" && (setting.Value != 6 || setting.Default != 3) {
+			t.Errorf("worker_count: value %v, default %v; want 6 and 3", setting.Value, setting.Default)
+		}
+	}
+
+	s.apply(overrides)
+	if len(calls) != 1 {
+		t.Errorf("OnChange called again for unchanged values")
+	}
+
+	s.apply(nil)
+	if s.Current() != testDefaults || len(calls) != 2 {
+		t.Errorf("after removing overrides: Current() = %+v after %d calls, want the defaults after 2", s.Current(), len(calls))
+	}
+}
+
+// TestUpdateValidates verifies bad changes are rejected before anything is
+// stored.
+func TestUpdateValidates(t *testing.T) {
+	s := New(nil, testDefaults) // No database: valid changes would panic
+
+	tests := []struct {
+		name    string
+		changes string
+	}{
+		{"empty", `{}`},
+		{"unknown key", `{"port": 9090}`},
+		{"worker count too high", `{"worker_count": 65}`},
+		{"worker count not a number", `{"worker_count": "6"}`},
+		{"unknown tier", `{"default_tier": "gold"}`},
+		{"tier not a string", `{"default_tier": 1}`},
+		{"negative key limit", `{"max_keys_per_user": -1}`},
+		{"model with spaces", `{"default_model": "gpt 4"}`},
+		{"empty model", `{"default_model": " "}`},
+		{"flag not a bool", `{"require_verified_email": "yes"}`},
+		{"one bad among good", `{"worker_count": 4, "default_model": ""}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changes map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(tt.changes), &changes); err != nil {
+				t.Fatal(err)
+			}
+			err := s.Update(context.Background(), changes)
+			if !errors.Is(err, ErrInvalidSetting) {
+				t.Errorf("Update() error = %v, want ErrInvalidSetting", err)
+			}
+		})
+	}
+
+	if err := s.Reset(context.Background(), "port"); !errors.Is(err, ErrUnknownSetting) {
+		t.Errorf("Reset(unknown) error = %v, want ErrUnknownSetting", err)
+	}
+	if s.Current() != testDefaults {
+		t.Errorf("Current() = %+v, want the defaults untouched", s.Current())
+	}
+}
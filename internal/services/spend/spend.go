@@ -0,0 +1,81 @@
+// Package spend keeps API keys' AI spending in check. It records what each
+// key's completions cost and sends a spend.warning webhook the first time
+// in a month a key passes WarnFraction of its monthly limit, so a surprise
+// OpenRouter bill is seen coming before requests start being blocked or
+// downgraded. Completions' tokens are also reported to Stripe for keys
+// billed by usage.
+package spend
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/billing"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// WarnFraction is the share of its monthly limit a key can spend before
+// it's warned.
+const WarnFraction = 0.8
+
+// Notifier sends events to an API key's webhooks.
+type Notifier interface {
+	NotifyAPIKeyEvent(ctx context.Context, apiKeyID, event string, data interface{})
+}
+
+// Tracker records AI spend and warns keys nearing their limit. It
+// implements summary.SpendRecorder.
+type Tracker struct {
+	db       *database.DB
+	notifier Notifier
+	billing  *billing.Reporter // Reports tokens to Stripe; nil skips it
+}
+
+// New creates a Tracker. notifier may be nil to skip warnings.
+func New(db *database.DB, notifier Notifier) *Tracker {
+	return &Tracker{db: db, notifier: notifier}
+}
+
+// SetBillingReporter reports completions' tokens as metered usage. A nil
+// reporter reports nothing.
+func (t *Tracker) SetBillingReporter(r *billing.Reporter) {
+	t.billing = r
+}
+
+// RecordAISpend records a completion's cost and sends a spend.warning
+// event if it took the key past WarnFraction of its limit this month.
+func (t *Tracker) RecordAISpend(ctx context.Context, keyID, model string, usage summary.Usage) error {
+	if err := t.db.RecordAISpend(ctx, keyID, model, usage.PromptTokens, usage.CompletionTokens, usage.CostUSD); err != nil {
+		return err
+	}
+	if t.billing != nil {
+		// Off the request path: Stripe is slower than the database
+		go t.billing.Report(context.WithoutCancel(ctx), keyID, billing.MeterAITokens, int64(usage.PromptTokens+usage.CompletionTokens))
+	}
+	if t.notifier == nil {
+		return nil
+	}
+
+	status, err := t.db.GetKeySpend(ctx, keyID, time.Now())
+	if err != nil || !ShouldWarn(status) {
+		return err
+	}
+	first, err := t.db.MarkSpendWarned(ctx, keyID, status.Month)
+	if err != nil || !first {
+		return err
+	}
+	t.notifier.NotifyAPIKeyEvent(ctx, keyID, "spend.warning", status)
+	return nil
+}
+
+// ShouldWarn reports whether a key has spent WarnFraction of its limit.
+func ShouldWarn(status *models.SpendStatus) bool {
+	return status.LimitUSD != nil && status.SpentUSD >= WarnFraction**status.LimitUSD
+}
+
+// Exceeded reports whether a key has reached its limit.
+func Exceeded(status *models.SpendStatus) bool {
+	return status.LimitUSD != nil && status.SpentUSD >= *status.LimitUSD
+}
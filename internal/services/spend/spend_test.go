@@ -0,0 +1,33 @@
+// spend_test.go tests when keys are warned about and held to their limits.
+package spend
+
+import (
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// TestThresholds verifies the warning and limit thresholds, and that keys
+// without a limit never hit either.
+func TestThresholds(t *testing.T) {
+	limit := 10.0
+	tests := []struct {
+		name         string
+		status       models.SpendStatus
+		wantWarn     bool
+		wantExceeded bool
+	}{
+		{"no limit", models.SpendStatus{SpentUSD: 1000}, false, false},
+		{"under 80%", models.SpendStatus{SpentUSD: 7.99, LimitUSD: &limit}, false, false},
+		{"at 80%", models.SpendStatus{SpentUSD: 8, LimitUSD: &limit}, true, false},
+		{"at the limit", models.SpendStatus{SpentUSD: 10, LimitUSD: &limit}, true, true},
+	}
+	for _, tt := range tests {
+		if got := ShouldWarn(&tt.status); got != tt.wantWarn {
+			t.Errorf("%s: ShouldWarn = %v, want %v", tt.name, got, tt.wantWarn)
+		}
+		if got := Exceeded(&tt.status); got != tt.wantExceeded {
+			t.Errorf("%s: Exceeded = %v, want %v", tt.name, got, tt.wantExceeded)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package storage persists original uploads (audio files and PDFs) so they
+// can be downloaded or reprocessed later.
+//
+// Go Pattern: Store is a small interface with two implementations — local
+// disk for single-server setups and S3 for everything else. GCS, R2, and
+// MinIO work through the S3 backend with a custom endpoint. Callers don't
+// know or care which one they have.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("object not found")
+
+// Store saves objects under keys (the stored filename of an upload) and
+// hands out signed, expiring download URLs for them.
+type Store interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// Config selects and configures a backend.
+type Config struct {
+	Backend string // "local" or "s3"; empty disables storage
+
+	// Local backend
+	Dir        string // Where objects are written
+	SigningKey string // Signs local download URLs
+
+	// S3 backend
+	Bucket          string
+	Region          string
+	Endpoint        string // Defaults to AWS; set for GCS, R2, or MinIO
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // Address the bucket in the path instead of the host
+}
+
+// New returns the configured store, or nil if storage is disabled.
+func New(cfg Config) (Store, error) {
+	var (
+		store Store
+		err   error
+	)
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case "local":
+		store, err = NewLocal(cfg.Dir, cfg.SigningKey)
+	case "s3":
+		store, err = NewS3(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (use local or s3)", cfg.Backend)
+	}
+	if err != nil {
+		return nil, err // Don't hand back a typed nil inside the interface
+	}
+	return store, nil
+}
+
+// validKey rejects keys that could escape the storage root. Keys are the
+// generated stored filenames of uploads, like "<uuid>.pdf".
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, ".") || filepath.Base(key) != key || strings.ContainsAny(key, `/\`) {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// analysis.go produces conversation analysis for phone calls and meetings:
+// sentiment per segment, talk-time per speaker, and flagged risk phrases.
+//
+// Sentiment and risk phrases come from the model. Talk-time is computed
+// locally from speaker labels ("Alice: ...") because word counts are exact
+// and don't need an LLM. Plain Whisper output has no speaker labels, so
+// talk-time is only reported when the transcript has been diarized.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SentimentSegment is the sentiment of one stretch of the conversation.
+type SentimentSegment struct {
+	Excerpt   string  `json:"excerpt"`
+	Speaker   string  `json:"speaker,omitempty"`
+	Sentiment string  `json:"sentiment"` // positive, neutral, negative
+	Score     float64 `json:"score"`     // -1.0 (very negative) to 1.0 (very positive)
+}
+
+// RiskPhrase is a statement worth a second look — a complaint, legal threat,
+// cancellation intent, unapproved commitment, and so on.
+type RiskPhrase struct {
+	Phrase   string `json:"phrase"`
+	Speaker  string `json:"speaker,omitempty"`
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// SpeakerTalkTime is one speaker's share of the conversation, by word count.
+type SpeakerTalkTime struct {
+	Speaker string  `json:"speaker"`
+	Words   int     `json:"words"`
+	Ratio   float64 `json:"ratio"` // 0.0-1.0
+}
+
+// AnalysisResult holds the full conversation analysis.
+type AnalysisResult struct {
+	OverallSentiment string             `json:"overall_sentiment"`
+	Segments         []SentimentSegment `json:"segments"`
+	TalkTime         []SpeakerTalkTime  `json:"talk_time"`
+	RiskPhrases      []RiskPhrase       `json:"risk_phrases"`
+	Model            string             `json:"-"`
+}
+
+var validSentiments = map[string]bool{"positive": true, "neutral": true, "negative": true}
+
+// AnalyzeConversation runs sentiment and risk analysis over a call or meeting transcript.
+func (s *Service) AnalyzeConversation(ctx context.Context, transcriptText, contentType string, opts Options) (*AnalysisResult, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Analyzing %s conversation using %s", contentType, model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: "You are an expert conversation analyst. You assess tone objectively and flag statements that carry business, legal, or relationship risk."},
+		{Role: "user", Content: buildAnalysisPrompt(transcriptText, contentType)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.complete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseAnalysisOutput(content)
+	if err != nil {
+		return nil, err
+	}
+	result.TalkTime = speakerTalkTime(transcriptText)
+	result.Model = servedBy
+	return result, nil
+}
+
+// buildAnalysisPrompt constructs the prompt for conversation analysis.
+func buildAnalysisPrompt(transcript, contentType string) string {
+	label := "conversation"
+	switch contentType {
+	case "phone_call":
+		label = "phone call"
+	case "meeting":
+		label = "meeting"
+	}
+
+	return fmt.Sprintf(`Analyze the sentiment of the following %s transcription.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "overall_sentiment": "positive",
+  "segments": [
+    {"excerpt": "Short quote from the segment", "speaker": "Alice", "sentiment": "neutral", "score": 0.1}
+  ],
+  "risk_phrases": [
+    {"phrase": "Exact quote", "speaker": "Bob", "category": "cancellation", "reason": "Customer threatens to cancel"}
+  ]
+}
+
+Rules:
+- Split the conversation into 5-15 segments in chronological order, each covering a shift in topic or tone
+- "sentiment" must be one of: positive, neutral, negative
+- "score" ranges from -1.0 (very negative) to 1.0 (very positive)
+- "speaker" is only included when the transcript labels speakers
+- "risk_phrases" flags complaints, legal threats, cancellation intent, compliance issues, or commitments that may need approval (empty array if none)
+- Quote the transcript exactly in "excerpt" and "phrase"
+
+**Transcript:**
+%s`, label, truncateTranscript(transcript))
+}
+
+// parseAnalysisOutput extracts and normalizes the analysis JSON from the AI response.
+func parseAnalysisOutput(content string) (*AnalysisResult, error) {
+	var structured AnalysisResult
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &structured); err != nil {
+		return nil, fmt.Errorf("model returned invalid analysis JSON: %w", err)
+	}
+
+	result := &AnalysisResult{
+		OverallSentiment: strings.ToLower(structured.OverallSentiment),
+		Segments:         []SentimentSegment{},
+		TalkTime:         []SpeakerTalkTime{},
+		RiskPhrases:      []RiskPhrase{},
+	}
+	if !validSentiments[result.OverallSentiment] {
+		result.OverallSentiment = "neutral"
+	}
+
+	for _, seg := range structured.Segments {
+		seg.Sentiment = strings.ToLower(seg.Sentiment)
+		if strings.TrimSpace(seg.Excerpt) == "" || !validSentiments[seg.Sentiment] {
+			continue
+		}
+		// Clamp scores — models occasionally drift outside the requested range
+		if seg.Score > 1 {
+			seg.Score = 1
+		} else if seg.Score < -1 {
+			seg.Score = -1
+		}
+		result.Segments = append(result.Segments, seg)
+	}
+	for _, rp := range structured.RiskPhrases {
+		if strings.TrimSpace(rp.Phrase) != "" {
+			result.RiskPhrases = append(result.RiskPhrases, rp)
+		}
+	}
+
+	return result, nil
+}
+
+// speakerLabelRe matches a diarized line such as "Alice: ..." or "[Speaker 2]: ...".
+var speakerLabelRe = regexp.MustCompile(`^\[?([A-Za-z][\w .'-]{0,39}?)\]?:\s+(.+)$`)
+
+// speakerTalkTime computes each speaker's share of words from speaker-labelled lines.
+// Returns an empty slice unless most lines are labelled and at least two
+// speakers are present — otherwise the "labels" are probably just prose.
+func speakerTalkTime(transcript string) []SpeakerTalkTime {
+	words := make(map[string]int)
+	var order []string
+	lines, labelled, total := 0, 0, 0
+
+	for _, line := range strings.Split(transcript, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines++
+
+		m := speakerLabelRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		labelled++
+
+		speaker := strings.TrimSpace(m[1])
+		if _, ok := words[speaker]; !ok {
+			order = append(order, speaker)
+		}
+		n := len(strings.Fields(m[2]))
+		words[speaker] += n
+		total += n
+	}
+
+	if len(order) < 2 || total == 0 || labelled*2 < lines {
+		return []SpeakerTalkTime{}
+	}
+
+	talkTime := make([]SpeakerTalkTime, 0, len(order))
+	for _, speaker := range order {
+		talkTime = append(talkTime, SpeakerTalkTime{
+			Speaker: speaker,
+			Words:   words[speaker],
+			Ratio:   float64(words[speaker]) / float64(total),
+		})
+	}
+	// Go Pattern: sort.SliceStable keeps first-appearance order for ties.
+	sort.SliceStable(talkTime, func(i, j int) bool { return talkTime[i].Words > talkTime[j].Words })
+	return talkTime
+}
@@ -0,0 +1,83 @@
+// analysis_test.go tests speaker talk-time and analysis output parsing.
+package summary
+
+import "testing"
+
+func TestSpeakerTalkTime(t *testing.T) {
+	tests := []struct {
+		name       string
+		transcript string
+		want       []SpeakerTalkTime
+	}{
+		{
+			name:       "two labelled speakers",
+			transcript: "Alice: hi there how are you\nBob: good thanks\nAlice: great",
+			want: []SpeakerTalkTime{
+				{Speaker: "Alice", Words: 6, Ratio: 6.0 / 8},
+				{Speaker: "Bob", Words: 2, Ratio: 2.0 / 8},
+			},
+		},
+		{
+			name:       "bracketed labels",
+			transcript: "[Speaker 1]: one two\n[Speaker 2]: three four five six",
+			want: []SpeakerTalkTime{
+				{Speaker: "Speaker 2", Words: 4, Ratio: 4.0 / 6},
+				{Speaker: "Speaker 1", Words: 2, Ratio: 2.0 / 6},
+			},
+		},
+		{
+			name:       "plain whisper output has no talk time",
+			transcript: "So the plan is simple: we ship on Friday and review Monday.",
+			want:       []SpeakerTalkTime{},
+		},
+		{
+			name:       "single speaker is not diarization",
+			transcript: "Alice: hello\nAlice: still me",
+			want:       []SpeakerTalkTime{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := speakerTalkTime(tt.transcript)
+			if len(got) != len(tt.want) {
+				t.Fatalf("speakerTalkTime() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("speaker %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseAnalysisOutput(t *testing.T) {
+	content := "```json\n" + `{
+		"overall_sentiment": "Negative",
+		"segments": [
+			{"excerpt": "this is unacceptable", "sentiment": "NEGATIVE", "score": -3},
+			{"excerpt": "", "sentiment": "neutral", "score": 0},
+			{"excerpt": "ok", "sentiment": "meh", "score": 0}
+		],
+		"risk_phrases": [{"phrase": "I'll cancel", "category": "cancellation", "reason": "churn"}, {"phrase": " "}]
+	}` + "\n```"
+
+	got, err := parseAnalysisOutput(content)
+	if err != nil {
+		t.Fatalf("parseAnalysisOutput() error = %v", err)
+	}
+	if got.OverallSentiment != "negative" {
+		t.Errorf("overall = %q, want negative", got.OverallSentiment)
+	}
+	if len(got.Segments) != 1 || got.Segments[0].Score != -1 {
+		t.Errorf("segments = %+v, want one clamped to -1", got.Segments)
+	}
+	if len(got.RiskPhrases) != 1 {
+		t.Errorf("risk phrases = %+v, want 1", got.RiskPhrases)
+	}
+
+	if _, err := parseAnalysisOutput("no json here"); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+}
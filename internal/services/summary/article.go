@@ -0,0 +1,215 @@
+// article.go turns a transcript into a long-form article draft: title
+// options, an introduction, headed sections, pull quotes, and a conclusion.
+//
+// Long transcripts are written in two passes so nothing past the usual
+// prompt limit is lost: notes and verbatim quotes are taken from each chunk
+// of the transcript, then the article is written from all the notes.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// Chunking for article drafts. A transcript that fits in one chunk is
+// written from directly; longer ones are split into chunks that grow past
+// articleChunkSize when needed to keep to about maxArticleChunks.
+const (
+	articleChunkSize = 12000
+	maxArticleChunks = 12
+	maxPullQuotes    = 5
+)
+
+// ArticleSection is one headed section of an article.
+type ArticleSection struct {
+	Heading    string   `json:"heading"`
+	Paragraphs []string `json:"paragraphs"`
+}
+
+// Article is a long-form article draft.
+type Article struct {
+	TitleOptions []string         `json:"title_options"`
+	Introduction string           `json:"introduction"`
+	Sections     []ArticleSection `json:"sections"`
+	PullQuotes   []string         `json:"pull_quotes"`
+	Conclusion   string           `json:"conclusion"`
+	Model        string           `json:"-"`
+}
+
+// articleNotes is what the first pass takes from one chunk.
+type articleNotes struct {
+	Points []string `json:"points"`
+	Quotes []string `json:"quotes"`
+}
+
+// GenerateArticle writes an article draft from a transcript. title is the
+// video's title, for context.
+func (s *Service) GenerateArticle(ctx context.Context, title, transcript string, opts Options) (*Article, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	chain := s.modelChain(model, opts.FallbackModels)
+
+	chunks := chunkText(transcript, articleChunkBytes(len(transcript)))
+	log.Printf("🤖 Writing article draft from %d chunk(s) using %s", len(chunks), model)
+
+	source := "**Transcript:**\n" + transcript
+	if len(chunks) > 1 {
+		var notes strings.Builder
+		for i, chunk := range chunks {
+			n, err := s.articleChunkNotes(ctx, chain, chunk, i+1, len(chunks))
+			if err != nil {
+				return nil, fmt.Errorf("notes for part %d of %d: %w", i+1, len(chunks), err)
+			}
+			fmt.Fprintf(&notes, "Part %d of %d\nPoints:\n- %s\nQuotes:\n- %s\n\n",
+				i+1, len(chunks), strings.Join(n.Points, "\n- "), strings.Join(n.Quotes, "\n- "))
+		}
+		source = "**Notes from the transcript, in order:**\n" + notes.String()
+	}
+
+	messages := []chatMessage{
+		{Role: "system", Content: articleSystemPrompt},
+		{Role: "user", Content: buildArticlePrompt(title, source)},
+	}
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, chain, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := parseArticleOutput(content, transcript)
+	if err != nil {
+		return nil, err
+	}
+	article.Model = servedBy
+	return article, nil
+}
+
+// articleChunkBytes is the chunk size for a transcript of n bytes.
+func articleChunkBytes(n int) int {
+	return max(articleChunkSize, (n+maxArticleChunks-1)/maxArticleChunks)
+}
+
+const articleSystemPrompt = "You are an editor who turns talks, interviews, and videos into well-structured articles for a blog."
+
+// articleChunkNotes runs the first pass over one chunk of a long transcript.
+// Notes stay in the transcript's language; the article pass translates.
+func (s *Service) articleChunkNotes(ctx context.Context, chain []string, chunk string, part, parts int) (*articleNotes, error) {
+	messages := []chatMessage{
+		{Role: "system", Content: articleSystemPrompt},
+		{Role: "user", Content: fmt.Sprintf(`This is part %d of %d of a transcript. Take notes for writing an article about the whole transcript.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "points": ["A key idea, fact, example, or argument, in one or two sentences"],
+  "quotes": ["A striking sentence copied word for word from the transcript"]
+}
+
+Rules:
+- 5-15 points, in the order they come up
+- Up to 3 quotes, copied exactly; leave out quotes that only make sense in context
+
+**Transcript part %d:**
+%s`, part, parts, part, chunk)},
+	}
+	content, _, err := s.cachedComplete(ctx, chain, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	var n articleNotes
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &n); err != nil {
+		return nil, fmt.Errorf("model returned invalid notes JSON: %w", err)
+	}
+	return &n, nil
+}
+
+// buildArticlePrompt constructs the prompt for the article itself, from the
+// transcript or from notes on it.
+func buildArticlePrompt(title, source string) string {
+	if title == "" {
+		title = "(untitled)"
+	}
+	return fmt.Sprintf(`Write a long-form article draft based on the video below. It should read as a standalone article, not a summary of a video.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "title_options": ["Title 1", "Title 2", "Title 3"],
+  "introduction": "One or two paragraphs that draw the reader in",
+  "sections": [
+    {"heading": "Section heading", "paragraphs": ["Paragraph 1", "Paragraph 2"]}
+  ],
+  "pull_quotes": ["A striking sentence from the transcript, word for word"],
+  "conclusion": "A closing paragraph"
+}
+
+Rules:
+- Give 3-5 title options in different styles (direct, question, how-to, bold claim)
+- Write 4-8 sections that build on each other, each with 2-4 paragraphs
+- Pull quotes must be copied word for word from the transcript or the quotes in the notes; give up to %d
+- Stay true to the content; don't invent facts, numbers, or quotes
+
+**Video title:** %s
+
+%s`, maxPullQuotes, title, source)
+}
+
+// parseArticleOutput extracts the article JSON, drops empty sections and
+// pull quotes that aren't in the transcript, and normalizes empty lists.
+func parseArticleOutput(content, transcript string) (*Article, error) {
+	var a Article
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &a); err != nil {
+		return nil, fmt.Errorf("model returned invalid article JSON: %w", err)
+	}
+
+	sections := []ArticleSection{}
+	for _, sec := range a.Sections {
+		var paragraphs []string
+		for _, p := range sec.Paragraphs {
+			if p = strings.TrimSpace(p); p != "" {
+				paragraphs = append(paragraphs, p)
+			}
+		}
+		if len(paragraphs) > 0 {
+			sections = append(sections, ArticleSection{Heading: strings.TrimSpace(sec.Heading), Paragraphs: paragraphs})
+		}
+	}
+	a.Sections = sections
+	if len(a.Sections) == 0 {
+		return nil, fmt.Errorf("model returned an article with no sections")
+	}
+
+	if a.TitleOptions == nil {
+		a.TitleOptions = []string{}
+	}
+
+	// Pull quotes are presented as the speaker's words, so keep only ones
+	// the transcript actually contains
+	source := normalizeQuote(transcript)
+	quotes := []string{}
+	for _, q := range a.PullQuotes {
+		q = strings.Trim(strings.TrimSpace(q), `"“”`)
+		if nq := normalizeQuote(q); nq != "" && strings.Contains(source, nq) && len(quotes) < maxPullQuotes {
+			quotes = append(quotes, q)
+		}
+	}
+	a.PullQuotes = quotes
+	return &a, nil
+}
+
+// normalizeQuote lowercases text and reduces it to words separated by
+// single spaces, so quotes match the transcript despite punctuation.
+func normalizeQuote(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
+	}), " ")
+}
@@ -0,0 +1,45 @@
+// article_test.go tests sizing transcript chunks for article drafts and
+// parsing the drafts from model output.
+package summary
+
+import "testing"
+
+func TestArticleChunkBytes(t *testing.T) {
+	if got := articleChunkBytes(5000); got != articleChunkSize {
+		t.Errorf("articleChunkBytes(5000) = %d, want %d", got, articleChunkSize)
+	}
+	// A very long transcript gets bigger chunks rather than more of them
+	n := 40 * articleChunkSize
+	if got := articleChunkBytes(n); got*maxArticleChunks < n {
+		t.Errorf("articleChunkBytes(%d) = %d, needing more than %d chunks", n, got, maxArticleChunks)
+	}
+}
+
+func TestParseArticleOutput(t *testing.T) {
+	transcript := "So I said, honestly, the best code is the code you delete. Then we moved on."
+	content := "```json\n" + `{
+		"title_options": ["Delete More Code"],
+		"introduction": "Intro.",
+		"sections": [
+			{"heading": "Why", "paragraphs": ["One.", " "]},
+			{"heading": "Empty", "paragraphs": []}
+		],
+		"pull_quotes": ["“Honestly, the best code is the code you delete.”", "Code is a liability."],
+		"conclusion": "End."
+	}` + "\n```"
+
+	got, err := parseArticleOutput(content, transcript)
+	if err != nil {
+		t.Fatalf("parseArticleOutput() error = %v", err)
+	}
+	if len(got.Sections) != 1 || len(got.Sections[0].Paragraphs) != 1 {
+		t.Errorf("sections = %+v, want the one non-empty section", got.Sections)
+	}
+	if len(got.PullQuotes) != 1 || got.PullQuotes[0] != "Honestly, the best code is the code you delete." {
+		t.Errorf("pull quotes = %q, want only the one in the transcript", got.PullQuotes)
+	}
+
+	if _, err := parseArticleOutput(`{"title_options": ["x"], "sections": []}`, transcript); err == nil {
+		t.Error("expected error for an article with no sections")
+	}
+}
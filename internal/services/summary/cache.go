@@ -0,0 +1,75 @@
+// cache.go caches summary and chat completions, keyed by a content hash
+// of the request, so repeats within the TTL cost nothing.
+package summary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// Cache stores completions by a hash of their request, so an identical
+// summary or chat request is answered without paying OpenRouter again.
+// The database implements it.
+type Cache interface {
+	GetCachedCompletion(ctx context.Context, key string) (content, model string, ok bool, err error)
+	CacheCompletion(ctx context.Context, key, content, model string, ttl time.Duration) error
+}
+
+// SetCache caches summary and chat completions in cache for ttl.
+// A ttl of 0 turns caching off.
+func (s *Service) SetCache(cache Cache, ttl time.Duration) {
+	s.cache = cache
+	s.cacheTTL = ttl
+}
+
+type forceRefreshKey struct{}
+
+// WithForceRefresh returns a context whose generations skip cached
+// completions, calling OpenRouter and caching the fresh result instead.
+func WithForceRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceRefreshKey{}, true)
+}
+
+// cacheKey hashes everything that determines a completion: the models
+// tried, in order, and the full prompt.
+func cacheKey(chain []string, messages []chatMessage) string {
+	h := sha256.New()
+	_ = json.NewEncoder(h).Encode(struct {
+		Models   []string      `json:"models"`
+		Messages []chatMessage `json:"messages"`
+	}{chain, messages})
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedComplete is complete, answered from the cache when an identical
+// request was completed within the TTL. Cache failures are logged and
+// fall through to OpenRouter.
+func (s *Service) cachedComplete(ctx context.Context, chain []string, messages []chatMessage) (string, string, error) {
+	if s.cache == nil || s.cacheTTL <= 0 {
+		return s.complete(ctx, chain, messages)
+	}
+
+	key := cacheKey(chain, messages)
+	if refresh, _ := ctx.Value(forceRefreshKey{}).(bool); !refresh {
+		content, model, ok, err := s.cache.GetCachedCompletion(ctx, key)
+		if err != nil {
+			log.Printf("⚠️  AI cache lookup failed: %v", err)
+		} else if ok {
+			log.Printf("💾 Using cached completion from %s", model)
+			return content, model, nil
+		}
+	}
+
+	content, model, err := s.complete(ctx, chain, messages)
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.cache.CacheCompletion(ctx, key, content, model, s.cacheTTL); err != nil {
+		log.Printf("⚠️  Failed to cache completion: %v", err)
+	}
+	return content, model, nil
+}
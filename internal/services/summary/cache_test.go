@@ -0,0 +1,94 @@
+// cache_test.go verifies that identical summary and chat requests are
+// answered from the completion cache.
+package summary
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memCache is an in-memory Cache.
+type memCache struct {
+	mu      sync.Mutex
+	entries map[string][2]string // key → content, model
+}
+
+func (c *memCache) GetCachedCompletion(_ context.Context, key string) (string, string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	return e[0], e[1], ok, nil
+}
+
+func (c *memCache) CacheCompletion(_ context.Context, key, content, model string, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = [2]string{content, model}
+	return nil
+}
+
+// TestCachedCompletions verifies which repeated requests reach OpenRouter.
+func TestCachedCompletions(t *testing.T) {
+	srv, calls := fakeOpenRouter(t, map[string][]int{})
+	s := newTestService(srv.URL)
+	s.SetCache(&memCache{entries: map[string][2]string{}}, time.Hour)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		run       func() (string, error)
+		wantCalls int // Total OpenRouter calls after this step
+	}{
+		{"first summary", func() (string, error) {
+			r, err := s.Summarize(ctx, "the transcript", Options{Length: "short"})
+			return modelOf(r), err
+		}, 1},
+		{"identical summary is cached", func() (string, error) {
+			r, err := s.Summarize(ctx, "the transcript", Options{Length: "short"})
+			return modelOf(r), err
+		}, 1},
+		{"different options miss", func() (string, error) {
+			r, err := s.Summarize(ctx, "the transcript", Options{Length: "detailed"})
+			return modelOf(r), err
+		}, 2},
+		{"different model misses", func() (string, error) {
+			r, err := s.Summarize(ctx, "the transcript", Options{Length: "short", Model: "other/model"})
+			return modelOf(r), err
+		}, 3},
+		{"force refresh skips the cache", func() (string, error) {
+			r, err := s.Summarize(WithForceRefresh(ctx), "the transcript", Options{Length: "short"})
+			return modelOf(r), err
+		}, 4},
+		{"first chat", func() (string, error) {
+			_, model, err := s.ChatTranscript(ctx, "video transcript", "the transcript", "", []ChatMessage{{Role: "user", Content: "why?"}}, Options{})
+			return model, err
+		}, 5},
+		{"identical chat is cached", func() (string, error) {
+			_, model, err := s.ChatTranscript(ctx, "video transcript", "the transcript", "", []ChatMessage{{Role: "user", Content: "why?"}}, Options{})
+			return model, err
+		}, 5},
+	}
+
+	for _, tt := range tests {
+		model, err := tt.run()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if model == "" {
+			t.Errorf("%s: no model reported", tt.name)
+		}
+		if len(*calls) != tt.wantCalls {
+			t.Errorf("%s: %d OpenRouter calls, want %d", tt.name, len(*calls), tt.wantCalls)
+		}
+	}
+}
+
+// modelOf returns the model that served a summary, if any.
+func modelOf(r *Result) string {
+	if r == nil {
+		return ""
+	}
+	return r.Model
+}
@@ -0,0 +1,158 @@
+// catalog.go limits which OpenRouter models clients may pick, and describes
+// them, so a typo in a model override is rejected up front instead of
+// surfacing as a cryptic OpenRouter error.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// openRouterModelsURL lists every model OpenRouter serves. It's free to call.
+const openRouterModelsURL = "https://openrouter.ai/api/v1/models"
+
+// catalogTTL is how long model details from OpenRouter are reused.
+const catalogTTL = time.Hour
+
+// defaultAllowedModels are the models clients may pick when
+// OPENROUTER_ALLOWED_MODELS isn't set, besides the configured default and
+// fallbacks.
+var defaultAllowedModels = []string{
+	"anthropic/claude-4.5-sonnet-20250929",
+	"anthropic/claude-4.5-opus-20251124",
+	"openai/gpt-4o",
+	"openai/gpt-4o-mini",
+	"google/gemini-2.5-flash",
+}
+
+// ModelInfo describes a model clients may pick. Details are missing when
+// OpenRouter couldn't be reached.
+type ModelInfo struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	ContextLength int           `json:"context_length,omitempty"`
+	Pricing       *ModelPricing `json:"pricing,omitempty"`
+	Default       bool          `json:"default,omitempty"` // Used when a request doesn't pick a model
+}
+
+// ModelPricing is a model's price in USD per token, as OpenRouter reports it.
+type ModelPricing struct {
+	Prompt     string `json:"prompt"`
+	Completion string `json:"completion"`
+}
+
+// SetAllowedModels limits model overrides to models, plus the default and
+// fallback models. An empty list allows defaultAllowedModels.
+func (s *Service) SetAllowedModels(models []string) {
+	s.allowedModels = models
+}
+
+// AllowedModels returns the models clients may pick: the default model,
+// then the fallbacks, then the rest of the allowlist.
+func (s *Service) AllowedModels() []string {
+	allowed := s.allowedModels
+	if len(allowed) == 0 {
+		allowed = defaultAllowedModels
+	}
+	return s.modelChain(s.Model(), append(append([]string{}, s.fallbackModels...), allowed...))
+}
+
+// ModelAllowed reports whether clients may pick model.
+func (s *Service) ModelAllowed(model string) bool {
+	for _, m := range s.AllowedModels() {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckModels returns an error naming the first of a request's model and
+// fallback overrides that clients may not pick. Empty overrides are fine.
+func (s *Service) CheckModels(model string, fallbacks []string) error {
+	for _, m := range append([]string{model}, fallbacks...) {
+		if m != "" && !s.ModelAllowed(m) {
+			return fmt.Errorf("model %q isn't available; GET /api/v1/models lists the models you can use", m)
+		}
+	}
+	return nil
+}
+
+// Catalog describes the models clients may pick, with names, context
+// sizes, and pricing from OpenRouter. If OpenRouter can't be reached, the
+// last details fetched are used, or just the model IDs.
+func (s *Service) Catalog(ctx context.Context) []ModelInfo {
+	details := s.modelDetails(ctx)
+	defaultModel := s.Model()
+
+	var catalog []ModelInfo
+	for _, id := range s.AllowedModels() {
+		info, ok := details[id]
+		if !ok {
+			info = ModelInfo{ID: id, Name: id}
+		}
+		info.Default = id == defaultModel
+		catalog = append(catalog, info)
+	}
+	return catalog
+}
+
+// modelDetails returns OpenRouter's model details by ID, refetching them
+// once they're older than catalogTTL.
+func (s *Service) modelDetails(ctx context.Context) map[string]ModelInfo {
+	s.catalogMu.Lock()
+	defer s.catalogMu.Unlock()
+
+	if s.catalog != nil && time.Since(s.catalogFetched) < catalogTTL {
+		return s.catalog
+	}
+	details, err := s.fetchModelDetails(ctx)
+	if err != nil {
+		log.Printf("⚠️  Failed to fetch OpenRouter model catalog: %v", err)
+		return s.catalog
+	}
+	s.catalog = details
+	s.catalogFetched = time.Now()
+	return details
+}
+
+// fetchModelDetails lists every model OpenRouter serves.
+func (s *Service) fetchModelDetails(ctx context.Context) (map[string]ModelInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.modelsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OpenRouter unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenRouter returned HTTP %d", resp.StatusCode)
+	}
+
+	var list struct {
+		Data []ModelInfo `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode model catalog: %w", err)
+	}
+	details := make(map[string]ModelInfo, len(list.Data))
+	for _, m := range list.Data {
+		if m.Name == "" {
+			m.Name = m.ID
+		}
+		details[m.ID] = m
+	}
+	return details, nil
+}
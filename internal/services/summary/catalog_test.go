@@ -0,0 +1,95 @@
+// catalog_test.go tests the model allowlist and the catalog built from
+// OpenRouter's model details.
+package summary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCheckModels verifies which model overrides are accepted.
+func TestCheckModels(t *testing.T) {
+	s := New("test-key", "primary/model")
+	s.SetFallbackModels([]string{"fallback/model"})
+	s.SetAllowedModels([]string{"allowed/model"})
+
+	tests := []struct {
+		name      string
+		model     string
+		fallbacks []string
+		wantErr   string
+	}{
+		{"no overrides", "", nil, ""},
+		{"default model", "primary/model", nil, ""},
+		{"fallback model", "fallback/model", nil, ""},
+		{"allowlisted model and fallback", "allowed/model", []string{"primary/model"}, ""},
+		{"typo", "alowed/model", nil, `"alowed/model"`},
+		{"unknown fallback", "allowed/model", []string{"other/model"}, `"other/model"`},
+		{"built-in list is replaced", "openai/gpt-4o-mini", nil, `"openai/gpt-4o-mini"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.CheckModels(tt.model, tt.fallbacks)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckModels() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckModels() = %v, want an error naming %s", err, tt.wantErr)
+			}
+		})
+	}
+
+	s.SetAllowedModels(nil)
+	if !s.ModelAllowed("openai/gpt-4o-mini") {
+		t.Error("built-in models aren't allowed without an allowlist")
+	}
+}
+
+// TestCatalog verifies the catalog lists allowed models with OpenRouter's
+// details, and falls back to the last details fetched.
+func TestCatalog(t *testing.T) {
+	up := true
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if !up {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"data": [
+			{"id": "primary/model", "name": "Primary", "context_length": 128000, "pricing": {"prompt": "0.000001", "completion": "0.000002"}},
+			{"id": "not/allowed", "name": "Not Allowed"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	s := New("test-key", "primary/model")
+	s.SetAllowedModels([]string{"allowed/model"})
+	s.modelsURL = srv.URL
+
+	want := []ModelInfo{
+		{ID: "primary/model", Name: "Primary", ContextLength: 128000, Pricing: &ModelPricing{Prompt: "0.000001", Completion: "0.000002"}, Default: true},
+		{ID: "allowed/model", Name: "allowed/model"},
+	}
+	if got := s.Catalog(context.Background()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog() = %+v, want %+v", got, want)
+	}
+
+	// Details are reused, and kept if a refetch fails
+	s.Catalog(context.Background())
+	if fetches != 1 {
+		t.Errorf("%d fetches, want 1", fetches)
+	}
+	up = false
+	s.catalogFetched = s.catalogFetched.Add(-2 * catalogTTL)
+	if got := s.Catalog(context.Background()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog() after a failed fetch = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,139 @@
+// chapters.go writes YouTube chapter lists and video descriptions from a
+// timed transcript, for creators to paste into YouTube Studio.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Chapter is one chapter of a video, starting Seconds in.
+type Chapter struct {
+	Seconds int    `json:"seconds"`
+	Title   string `json:"title"`
+}
+
+// ChaptersResult holds a video's chapters, in order, and its description.
+type ChaptersResult struct {
+	Chapters    []Chapter `json:"chapters"`
+	Description string    `json:"description"`
+	Hashtags    []string  `json:"hashtags"`
+	Model       string    `json:"model"`
+}
+
+// GenerateChapters splits a video into chapters from timed text (the
+// "[m:ss] text" lines export.TimedText renders) and writes a description
+// for it. title is the video's current title, for context.
+func (s *Service) GenerateChapters(ctx context.Context, title, timedText string, opts Options) (*ChaptersResult, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Generating chapters and description using %s", model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: "You are a YouTube channel manager who writes chapter lists and search-friendly video descriptions."},
+		{Role: "user", Content: buildChaptersPrompt(title, timedText)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseChaptersOutput(content)
+	if err != nil {
+		return nil, err
+	}
+	result.Model = servedBy
+	return result, nil
+}
+
+// buildChaptersPrompt constructs the prompt for chapters and a description.
+func buildChaptersPrompt(title, timedText string) string {
+	if title == "" {
+		title = "(untitled)"
+	}
+	return fmt.Sprintf(`Write YouTube chapters and a description for the video below.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "chapters": [
+    {"timestamp": "0:00", "title": "Intro"},
+    {"timestamp": "2:15", "title": "Setting up the project"}
+  ],
+  "description": "2-3 short paragraphs",
+  "hashtags": ["#topic1", "#topic2", "#topic3"]
+}
+
+Rules for chapters:
+- Each line of the transcript starts with the time it's spoken, like [12:34]
+- Start a chapter where a new topic or section begins, using that line's timestamp exactly as written
+- The first chapter starts at 0:00
+- Aim for one chapter every 2-5 minutes of video; never make a chapter shorter than 10 seconds
+- Titles are 2-6 words, specific to the content, without numbering
+
+Rules for the description:
+- Open with a sentence that says what viewers will learn or see, using the words people would search for
+- Follow with what the video covers, in plain language; no timestamps, links, or calls to subscribe
+- Give 3 hashtags about the topic
+
+**Video title:** %s
+
+**Transcript:**
+%s`, title, fitTimeline(timedText))
+}
+
+// parseChaptersOutput extracts the chapters JSON, dropping chapters without
+// a readable timestamp or title, and sorts the rest.
+func parseChaptersOutput(content string) (*ChaptersResult, error) {
+	var out struct {
+		Chapters []struct {
+			Timestamp string `json:"timestamp"`
+			Title     string `json:"title"`
+		} `json:"chapters"`
+		Description string   `json:"description"`
+		Hashtags    []string `json:"hashtags"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &out); err != nil {
+		return nil, fmt.Errorf("model returned invalid chapters JSON: %w", err)
+	}
+
+	result := &ChaptersResult{
+		Chapters:    []Chapter{},
+		Description: strings.TrimSpace(out.Description),
+		Hashtags:    []string{},
+	}
+	for _, ch := range out.Chapters {
+		seconds, ok := parseTimestamp(ch.Timestamp)
+		title := strings.TrimSpace(ch.Title)
+		if !ok || title == "" {
+			continue
+		}
+		result.Chapters = append(result.Chapters, Chapter{Seconds: seconds, Title: title})
+	}
+	if len(result.Chapters) == 0 {
+		return nil, fmt.Errorf("model returned no chapters with valid timestamps")
+	}
+	sort.SliceStable(result.Chapters, func(i, j int) bool {
+		return result.Chapters[i].Seconds < result.Chapters[j].Seconds
+	})
+
+	for _, tag := range out.Hashtags {
+		tag = strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(tag), "#")), "")
+		if tag != "" {
+			result.Hashtags = append(result.Hashtags, "#"+tag)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,37 @@
+// chapters_test.go tests parsing chapters and descriptions from model output.
+package summary
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChaptersOutput(t *testing.T) {
+	content := "```json\n" + `{
+		"chapters": [
+			{"timestamp": "2:15", "title": " Setup "},
+			{"timestamp": "0:00", "title": "Intro"},
+			{"timestamp": "later", "title": "No time"},
+			{"timestamp": "5:00", "title": ""}
+		],
+		"description": " Learn Go channels. ",
+		"hashtags": ["#golang", "Go Routines", " ", "#"]
+	}` + "\n```"
+
+	got, err := parseChaptersOutput(content)
+	if err != nil {
+		t.Fatalf("parseChaptersOutput() error = %v", err)
+	}
+	want := &ChaptersResult{
+		Chapters:    []Chapter{{Seconds: 0, Title: "Intro"}, {Seconds: 135, Title: "Setup"}},
+		Description: "Learn Go channels.",
+		Hashtags:    []string{"#golang", "#GoRoutines"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseChaptersOutput() = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseChaptersOutput(`{"chapters": [], "description": "x"}`); err == nil {
+		t.Error("expected error for output with no chapters")
+	}
+}
@@ -0,0 +1,97 @@
+// chat_test.go verifies that condensed chat history reaches the prompts.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recordingOpenRouter returns a test server that answers every request
+// with answer, and the messages of the last request it received.
+func recordingOpenRouter(t *testing.T, answer string) (*httptest.Server, *[]chatMessage) {
+	t.Helper()
+	var last []chatMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request body: %v", err)
+		}
+		last = req.Messages
+		body, _ := json.Marshal(map[string]any{"choices": []any{map[string]any{"message": map[string]string{"content": answer}}}})
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &last
+}
+
+// TestCondenseChat verifies the running summary and new turns are sent,
+// and the condensed summary comes back trimmed.
+func TestCondenseChat(t *testing.T) {
+	srv, last := recordingOpenRouter(t, "  Ada asked about gophers.  ")
+	s := newTestService(srv.URL)
+
+	got, err := s.CondenseChat(context.Background(), "Earlier, Ada said hello.", []ChatMessage{
+		{Role: "user", Content: "What are gophers?"},
+		{Role: "assistant", Content: "Burrowing rodents."},
+	})
+	if err != nil {
+		t.Fatalf("CondenseChat: %v", err)
+	}
+	if got != "Ada asked about gophers." {
+		t.Errorf("CondenseChat = %q", got)
+	}
+	prompt := (*last)[len(*last)-1].Content
+	for _, want := range []string{"Earlier, Ada said hello.", "user: What are gophers?", "assistant: Burrowing rodents."} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt %q is missing %q", prompt, want)
+		}
+	}
+}
+
+// TestChatTranscriptHistorySummary verifies a history summary is sent as
+// context ahead of the recent messages, and left out when empty.
+func TestChatTranscriptHistorySummary(t *testing.T) {
+	srv, last := recordingOpenRouter(t, "answer")
+	s := newTestService(srv.URL)
+	question := []ChatMessage{{Role: "user", Content: "And then?"}}
+
+	tests := []struct {
+		summary      string
+		wantMessages int
+	}{
+		{"", 3},
+		{"Ada asked about gophers.", 4},
+	}
+	for _, tt := range tests {
+		if _, _, err := s.ChatTranscript(context.Background(), "video transcript", "text", tt.summary, question, Options{}); err != nil {
+			t.Fatalf("ChatTranscript: %v", err)
+		}
+		if len(*last) != tt.wantMessages {
+			t.Fatalf("summary %q: sent %d messages, want %d", tt.summary, len(*last), tt.wantMessages)
+		}
+		if tt.summary != "" && !strings.Contains((*last)[2].Content, tt.summary) {
+			t.Errorf("third message = %q, want the history summary", (*last)[2].Content)
+		}
+	}
+}
+
+// TestChatTranscriptTimestamps verifies timestamped content asks the model
+// to cite timestamps, and plain content doesn't.
+func TestChatTranscriptTimestamps(t *testing.T) {
+	srv, last := recordingOpenRouter(t, "answer")
+	s := newTestService(srv.URL)
+	question := []ChatMessage{{Role: "user", Content: "When?"}}
+
+	for _, timestamps := range []bool{false, true} {
+		if _, _, err := s.ChatTranscript(context.Background(), "video transcript", "[0:05] text", "", question, Options{Timestamps: timestamps}); err != nil {
+			t.Fatalf("ChatTranscript: %v", err)
+		}
+		if got := strings.Contains((*last)[0].Content, "cite the timestamp"); got != timestamps {
+			t.Errorf("Timestamps %v: system prompt %q", timestamps, (*last)[0].Content)
+		}
+	}
+}
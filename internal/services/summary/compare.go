@@ -0,0 +1,183 @@
+// compare.go compares several sources — transcripts, recordings, PDFs — in
+// one analysis: the themes they share, where they contradict each other,
+// and what only one of them says.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// compareBudget is the prompt space shared by the sources of a comparison;
+// each gets an equal share, like truncateTranscript's limit for one.
+const compareBudget = 40000
+
+// CompareSource is one source of a comparison.
+type CompareSource struct {
+	Label string // Title and kind, e.g. "Q3 earnings call (audio recording)"
+	Text  string
+}
+
+// CommonTheme is a theme several sources discuss. Sources are 1-based
+// source numbers, in the order given.
+type CommonTheme struct {
+	Theme       string `json:"theme"`
+	Description string `json:"description"`
+	Sources     []int  `json:"sources"`
+}
+
+// SourcePosition is what one source says on a contested topic.
+type SourcePosition struct {
+	Source   int    `json:"source"`
+	Position string `json:"position"`
+}
+
+// Contradiction is a topic the sources disagree on.
+type Contradiction struct {
+	Topic     string           `json:"topic"`
+	Positions []SourcePosition `json:"positions"`
+}
+
+// UniquePoints are the points only one source makes.
+type UniquePoints struct {
+	Source int      `json:"source"`
+	Points []string `json:"points"`
+}
+
+// Comparison is the result of comparing sources.
+type Comparison struct {
+	Overview       string          `json:"overview"`
+	CommonThemes   []CommonTheme   `json:"common_themes"`
+	Contradictions []Contradiction `json:"contradictions"`
+	UniquePoints   []UniquePoints  `json:"unique_points"`
+	Model          string          `json:"model"`
+}
+
+// CompareSources compares two or more sources.
+func (s *Service) CompareSources(ctx context.Context, sources []CompareSource, opts Options) (*Comparison, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Comparing %d sources using %s", len(sources), model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: "You are an analyst who compares sources side by side — calls, interviews, reviews, reports — and reports precisely where they agree and differ."},
+		{Role: "user", Content: buildComparePrompt(sources)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison, err := parseCompareOutput(content, len(sources))
+	if err != nil {
+		return nil, err
+	}
+	comparison.Model = servedBy
+	return comparison, nil
+}
+
+// buildComparePrompt constructs the prompt for a comparison, numbering the
+// sources from 1.
+func buildComparePrompt(sources []CompareSource) string {
+	share := compareBudget / len(sources)
+	var texts strings.Builder
+	for i, src := range sources {
+		text := src.Text
+		if len(text) > share {
+			text = text[:share] + "\n[Truncated due to length...]"
+		}
+		fmt.Fprintf(&texts, "### Source %d: %s\n%s\n\n", i+1, src.Label, text)
+	}
+
+	return fmt.Sprintf(`Compare the %d sources below.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "overview": "2-3 sentences on how the sources relate overall",
+  "common_themes": [
+    {"theme": "Short name", "description": "What the sources say about it", "sources": [1, 2]}
+  ],
+  "contradictions": [
+    {"topic": "What they disagree on", "positions": [{"source": 1, "position": "What source 1 says"}, {"source": 2, "position": "What source 2 says"}]}
+  ],
+  "unique_points": [
+    {"source": 1, "points": ["Something only source 1 says"]}
+  ]
+}
+
+Rules:
+- Refer to sources only by their number
+- A common theme needs at least 2 sources; a contradiction needs positions from at least 2
+- Contradictions are real disagreements on facts, figures, or opinions, not differences in emphasis
+- Give each source's 1-5 most significant unique points
+- Use empty arrays where there's nothing to report
+
+%s`, len(sources), texts.String())
+}
+
+// parseCompareOutput extracts the comparison JSON, dropping references to
+// sources that don't exist and entries left with too few sources.
+func parseCompareOutput(content string, numSources int) (*Comparison, error) {
+	var c Comparison
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &c); err != nil {
+		return nil, fmt.Errorf("model returned invalid comparison JSON: %w", err)
+	}
+	valid := func(n int) bool { return n >= 1 && n <= numSources }
+
+	themes := []CommonTheme{}
+	for _, t := range c.CommonThemes {
+		var sources []int
+		seen := make(map[int]bool)
+		for _, n := range t.Sources {
+			if valid(n) && !seen[n] {
+				seen[n] = true
+				sources = append(sources, n)
+			}
+		}
+		if t.Theme != "" && len(sources) >= 2 {
+			t.Sources = sources
+			themes = append(themes, t)
+		}
+	}
+	c.CommonThemes = themes
+
+	contradictions := []Contradiction{}
+	for _, con := range c.Contradictions {
+		var positions []SourcePosition
+		for _, p := range con.Positions {
+			if valid(p.Source) && p.Position != "" {
+				positions = append(positions, p)
+			}
+		}
+		if con.Topic != "" && len(positions) >= 2 {
+			con.Positions = positions
+			contradictions = append(contradictions, con)
+		}
+	}
+	c.Contradictions = contradictions
+
+	unique := []UniquePoints{}
+	for _, u := range c.UniquePoints {
+		if valid(u.Source) && len(u.Points) > 0 {
+			unique = append(unique, u)
+		}
+	}
+	c.UniquePoints = unique
+
+	if c.Overview == "" && len(c.CommonThemes) == 0 && len(c.Contradictions) == 0 && len(c.UniquePoints) == 0 {
+		return nil, fmt.Errorf("model returned an empty comparison")
+	}
+	return &c, nil
+}
@@ -0,0 +1,55 @@
+// compare_test.go tests building comparison prompts and parsing comparisons
+// from model output.
+package summary
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCompareOutput(t *testing.T) {
+	content := "```json\n" + `{
+		"overview": "Both calls cover margins.",
+		"common_themes": [
+			{"theme": "Margins", "description": "Both discuss margins", "sources": [1, 2, 2]},
+			{"theme": "Solo", "description": "Only one source", "sources": [1, 9]}
+		],
+		"contradictions": [
+			{"topic": "Guidance", "positions": [{"source": 1, "position": "Up"}, {"source": 2, "position": "Down"}]},
+			{"topic": "Bad", "positions": [{"source": 1, "position": "Up"}, {"source": 3, "position": "Down"}]}
+		],
+		"unique_points": [
+			{"source": 2, "points": ["New product"]},
+			{"source": 0, "points": ["Nobody"]}
+		]
+	}` + "\n```"
+
+	got, err := parseCompareOutput(content, 2)
+	if err != nil {
+		t.Fatalf("parseCompareOutput() error = %v", err)
+	}
+	if len(got.CommonThemes) != 1 || len(got.CommonThemes[0].Sources) != 2 {
+		t.Errorf("common themes = %+v, want Margins from sources 1 and 2", got.CommonThemes)
+	}
+	if len(got.Contradictions) != 1 || got.Contradictions[0].Topic != "Guidance" {
+		t.Errorf("contradictions = %+v, want only Guidance", got.Contradictions)
+	}
+	if len(got.UniquePoints) != 1 || got.UniquePoints[0].Source != 2 {
+		t.Errorf("unique points = %+v, want only source 2's", got.UniquePoints)
+	}
+
+	if _, err := parseCompareOutput(`{"common_themes": []}`, 2); err == nil {
+		t.Error("expected error for an empty comparison")
+	}
+}
+
+func TestBuildComparePrompt(t *testing.T) {
+	long := strings.Repeat("a", compareBudget)
+	prompt := buildComparePrompt([]CompareSource{{Label: "Call A", Text: long}, {Label: "Call B", Text: "short"}})
+	if !strings.Contains(prompt, "### Source 1: Call A") || !strings.Contains(prompt, "### Source 2: Call B\nshort") {
+		t.Errorf("prompt doesn't number and label the sources:\n%.300s", prompt)
+	}
+	if len(prompt) > compareBudget+5000 {
+		t.Errorf("prompt is %d bytes; the long source wasn't cut to its share", len(prompt))
+	}
+}
@@ -0,0 +1,171 @@
+// digest.go writes the AI part of a weekly digest: an overview of
+// everything a user processed, the top themes across it, and notable
+// quotes. Action items are gathered from the items' summaries as is.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Limits for digests. digestBudget is the prompt space shared by the
+// items, like compareBudget.
+const (
+	digestBudget    = 40000
+	maxDigestQuotes = 5
+)
+
+// DigestSource is one item in a digest.
+type DigestSource struct {
+	Label   string // Title and kind, e.g. "Team standup (audio recording)"
+	Summary string // The item's AI summary, if it has one
+	Text    string
+}
+
+// DigestTheme is a theme that ran through the period's items. Items are
+// 1-based item numbers, in the order given.
+type DigestTheme struct {
+	Theme       string `json:"theme"`
+	Description string `json:"description"`
+	Items       []int  `json:"items"`
+}
+
+// DigestQuote is a sentence quoted word for word from one item.
+type DigestQuote struct {
+	Quote string `json:"quote"`
+	Item  int    `json:"item"`
+}
+
+// Digest is the AI-written part of a digest.
+type Digest struct {
+	Overview string        `json:"overview"`
+	Themes   []DigestTheme `json:"themes"`
+	Quotes   []DigestQuote `json:"quotes"`
+	Model    string        `json:"-"`
+}
+
+// GenerateDigest summarizes a period's items as a whole.
+func (s *Service) GenerateDigest(ctx context.Context, sources []DigestSource, opts Options) (*Digest, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Writing digest of %d items using %s", len(sources), model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: "You write concise weekly briefings that pull together everything someone watched, heard, and read."},
+		{Role: "user", Content: buildDigestPrompt(sources)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := parseDigestOutput(content, sources)
+	if err != nil {
+		return nil, err
+	}
+	digest.Model = servedBy
+	return digest, nil
+}
+
+// buildDigestPrompt constructs the prompt for a digest, numbering the items
+// from 1. Each gets an equal share of the budget: its summary first, then
+// as much of its text as fits.
+func buildDigestPrompt(sources []DigestSource) string {
+	share := digestBudget / len(sources)
+	var texts strings.Builder
+	for i, src := range sources {
+		fmt.Fprintf(&texts, "### Item %d: %s\n", i+1, src.Label)
+		room := share
+		if src.Summary != "" {
+			summary := src.Summary
+			if len(summary) > room/2 {
+				summary = summary[:room/2]
+			}
+			fmt.Fprintf(&texts, "Summary: %s\n", summary)
+			room -= len(summary)
+		}
+		text := src.Text
+		if len(text) > room {
+			text = text[:room] + "\n[Truncated due to length...]"
+		}
+		fmt.Fprintf(&texts, "Text:\n%s\n\n", text)
+	}
+
+	return fmt.Sprintf(`Write a weekly digest of the %d items below.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "overview": "3-5 sentences on what the week covered",
+  "themes": [
+    {"theme": "Short name", "description": "What came up about it", "items": [1, 2]}
+  ],
+  "quotes": [
+    {"quote": "A notable sentence copied word for word", "item": 1}
+  ]
+}
+
+Rules:
+- Refer to items only by their number
+- Give the 3-6 most important themes, most significant first
+- Quotes must be copied word for word from an item's text; give up to %d
+- Stay true to the content; don't invent facts
+
+%s`, len(sources), maxDigestQuotes, texts.String())
+}
+
+// parseDigestOutput extracts the digest JSON, dropping references to items
+// that don't exist and quotes that aren't in the item they're credited to.
+func parseDigestOutput(content string, sources []DigestSource) (*Digest, error) {
+	var d Digest
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &d); err != nil {
+		return nil, fmt.Errorf("model returned invalid digest JSON: %w", err)
+	}
+	valid := func(n int) bool { return n >= 1 && n <= len(sources) }
+
+	themes := []DigestTheme{}
+	for _, t := range d.Themes {
+		items := []int{}
+		seen := make(map[int]bool)
+		for _, n := range t.Items {
+			if valid(n) && !seen[n] {
+				seen[n] = true
+				items = append(items, n)
+			}
+		}
+		if t.Theme != "" {
+			t.Items = items
+			themes = append(themes, t)
+		}
+	}
+	d.Themes = themes
+
+	quotes := []DigestQuote{}
+	for _, q := range d.Quotes {
+		q.Quote = strings.Trim(strings.TrimSpace(q.Quote), `"“”`)
+		nq := normalizeQuote(q.Quote)
+		if !valid(q.Item) || nq == "" || len(quotes) == maxDigestQuotes {
+			continue
+		}
+		if strings.Contains(normalizeQuote(sources[q.Item-1].Text), nq) {
+			quotes = append(quotes, q)
+		}
+	}
+	d.Quotes = quotes
+
+	if d.Overview == "" && len(d.Themes) == 0 {
+		return nil, fmt.Errorf("model returned an empty digest")
+	}
+	return &d, nil
+}
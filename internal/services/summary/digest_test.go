@@ -0,0 +1,58 @@
+// digest_test.go tests building digest prompts and parsing digests from
+// model output.
+package summary
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDigestOutput(t *testing.T) {
+	sources := []DigestSource{
+		{Label: "Standup", Text: "We ship on Friday, no matter what."},
+		{Label: "Review", Text: "The battery lasts two days."},
+	}
+	content := "```json\n" + `{
+		"overview": "A week of launches.",
+		"themes": [
+			{"theme": "Launch", "description": "Shipping Friday", "items": [1, 1, 7]},
+			{"theme": "", "description": "Unnamed", "items": [2]}
+		],
+		"quotes": [
+			{"quote": "\"We ship on Friday no matter what\"", "item": 1},
+			{"quote": "We ship on Friday", "item": 2},
+			{"quote": "Made up words", "item": 2},
+			{"quote": "The battery lasts two days.", "item": 3}
+		]
+	}` + "\n```"
+
+	got, err := parseDigestOutput(content, sources)
+	if err != nil {
+		t.Fatalf("parseDigestOutput() error = %v", err)
+	}
+	if len(got.Themes) != 1 || len(got.Themes[0].Items) != 1 || got.Themes[0].Items[0] != 1 {
+		t.Errorf("themes = %+v, want Launch from item 1", got.Themes)
+	}
+	if len(got.Quotes) != 1 || got.Quotes[0].Quote != "We ship on Friday no matter what" {
+		t.Errorf("quotes = %+v, want only item 1's, unquoted", got.Quotes)
+	}
+
+	if _, err := parseDigestOutput(`{"quotes": []}`, sources); err == nil {
+		t.Error("expected error for an empty digest")
+	}
+}
+
+func TestBuildDigestPrompt(t *testing.T) {
+	long := strings.Repeat("a", digestBudget)
+	prompt := buildDigestPrompt([]DigestSource{
+		{Label: "Call", Summary: "A call about pricing.", Text: long},
+		{Label: "Memo", Text: "short"},
+	})
+	if !strings.Contains(prompt, "### Item 1: Call\nSummary: A call about pricing.\nText:") ||
+		!strings.Contains(prompt, "### Item 2: Memo\nText:\nshort") {
+		t.Errorf("prompt doesn't number and label the items:\n%.300s", prompt)
+	}
+	if len(prompt) > digestBudget+5000 {
+		t.Errorf("prompt is %d bytes; the long item wasn't cut to its share", len(prompt))
+	}
+}
@@ -0,0 +1,180 @@
+// entities.go finds the people, companies, products, and places an item
+// mentions, so items can be browsed by who and what they discuss.
+//
+// The model only names the entities; how often each is mentioned, and the
+// sentence it's first mentioned in, are counted from the text itself.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Chunking for entity extraction. Like name detection it covers the whole
+// text, in chunks that grow past entityChunkSize when needed to keep to
+// about maxEntityChunks.
+const (
+	entityChunkSize  = 12000
+	maxEntityChunks  = 20
+	maxEntityContext = 300
+)
+
+// Entity is a person, company, product, or place mentioned in a text.
+type Entity struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Mentions int    `json:"mentions"`
+	Context  string `json:"context"`
+}
+
+// entityTypes are the kinds of entities extracted.
+var entityTypes = map[string]bool{"person": true, "company": true, "product": true, "place": true}
+
+// ExtractEntities returns the distinct entities mentioned in text, most
+// mentioned first, and the model that found them. title is the item's
+// title, for context.
+func (s *Service) ExtractEntities(ctx context.Context, title, text string, opts Options) ([]Entity, string, error) {
+	if s.apiKey == "" {
+		return nil, "", fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	chain := s.modelChain(model, opts.FallbackModels)
+
+	chunks := chunkText(text, max(entityChunkSize, (len(text)+maxEntityChunks-1)/maxEntityChunks))
+	log.Printf("🤖 Extracting entities from %d chunk(s) using %s", len(chunks), model)
+
+	var (
+		found    []Entity
+		servedBy string
+	)
+	for _, chunk := range chunks {
+		messages := []chatMessage{
+			{Role: "system", Content: "You are a named-entity recognizer. You find the people, companies, products, and places in text and reply only with JSON."},
+			{Role: "user", Content: buildEntitiesPrompt(title, chunk)},
+		}
+		content, by, err := s.cachedComplete(ctx, chain, messages)
+		if err != nil {
+			return nil, "", err
+		}
+		entities, err := parseEntitiesOutput(content)
+		if err != nil {
+			return nil, "", err
+		}
+		found = append(found, entities...)
+		servedBy = by
+	}
+	return countEntities(text, found), servedBy, nil
+}
+
+// buildEntitiesPrompt constructs the prompt for one chunk of entity
+// extraction.
+func buildEntitiesPrompt(title, chunk string) string {
+	return fmt.Sprintf(`List the people, companies, products, and places mentioned in the text below, from "%s".
+
+**Important:** Respond with valid JSON in this exact format:
+{"entities": [{"name": "Jane Doe", "type": "person"}, {"name": "Acme", "type": "company"}]}
+
+Rules:
+- type is one of person, company, product, or place
+- Give each name exactly as it's written in the text, in its fullest form
+- List each entity once
+- Skip generic references like "the CEO" or "our app"
+- Return {"entities": []} if there are none
+
+**Text:**
+%s`, title, chunk)
+}
+
+// parseEntitiesOutput extracts the entity list from the AI response,
+// dropping blanks and entities of unknown types.
+func parseEntitiesOutput(content string) ([]Entity, error) {
+	var structured struct {
+		Entities []Entity `json:"entities"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &structured); err != nil {
+		return nil, fmt.Errorf("model returned invalid entities JSON: %w", err)
+	}
+	entities := []Entity{}
+	for _, e := range structured.Entities {
+		e.Name = strings.Join(strings.Fields(e.Name), " ")
+		e.Type = strings.ToLower(strings.TrimSpace(e.Type))
+		if len([]rune(e.Name)) < 2 || len(e.Name) > 255 || !entityTypes[e.Type] {
+			continue
+		}
+		entities = append(entities, Entity{Name: e.Name, Type: e.Type})
+	}
+	return entities, nil
+}
+
+// countEntities merges entities found in several chunks, matching them by
+// type and lowercased name, and fills in how often each appears in text and
+// where first. Entities that don't appear in text at all are dropped as
+// made up.
+func countEntities(text string, found []Entity) []Entity {
+	seen := make(map[string]bool)
+	entities := []Entity{}
+	for _, e := range found {
+		key := e.Type + "\x00" + strings.ToLower(e.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		matches := findMentions(text, e.Name)
+		if len(matches) == 0 {
+			continue
+		}
+		e.Mentions = len(matches)
+		e.Context = mentionContext(text, matches[0][0], matches[0][1])
+		entities = append(entities, e)
+	}
+	// Stable, so ties keep the order the model found them in
+	sort.SliceStable(entities, func(i, j int) bool { return entities[i].Mentions > entities[j].Mentions })
+	return entities
+}
+
+// findMentions returns where name appears in text as a whole word, in any
+// case.
+func findMentions(text, name string) [][]int {
+	var matches [][]int
+	for _, m := range regexp.MustCompile(`(?i)`+regexp.QuoteMeta(name)).FindAllStringIndex(text, -1) {
+		before, _ := utf8.DecodeLastRuneInString(text[:m[0]])
+		after, _ := utf8.DecodeRuneInString(text[m[1]:])
+		if !isWordRune(before) && !isWordRune(after) {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// isWordRune reports whether r is part of a word; utf8.RuneError, for the
+// start or end of the text, isn't.
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsNumber(r))
+}
+
+// mentionContext returns the sentence around text[start:end], cut to about
+// maxEntityContext bytes.
+func mentionContext(text string, start, end int) string {
+	from := max(0, start-maxEntityContext/2)
+	if i := strings.LastIndexAny(text[from:start], ".!?"); i >= 0 {
+		from += i + 1
+	}
+	to := min(len(text), end+maxEntityContext/2)
+	if i := strings.IndexAny(text[end:to], ".!?"); i >= 0 {
+		to = end + i + 1
+	}
+	// Don't split a multi-byte character at either edge
+	return strings.ToValidUTF8(strings.Join(strings.Fields(text[from:to]), " "), "")
+}
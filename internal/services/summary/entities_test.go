@@ -0,0 +1,75 @@
+// entities_test.go tests entity extraction output parsing and how mentions
+// are counted from the text.
+package summary
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEntitiesOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Entity
+		wantErr bool
+	}{
+		{
+			name:    "plain JSON",
+			content: `{"entities":[{"name":"Jane Doe","type":"person"},{"name":"Acme","type":"Company"}]}`,
+			want:    []Entity{{Name: "Jane Doe", Type: "person"}, {Name: "Acme", Type: "company"}},
+		},
+		{
+			name:    "drops unknown types and blanks",
+			content: "```json\n{\"entities\": [{\"name\": \"Tuesday\", \"type\": \"date\"}, {\"name\": \" \", \"type\": \"place\"}, {\"name\": \"New   York\", \"type\": \"place\"}]}\n```",
+			want:    []Entity{{Name: "New York", Type: "place"}},
+		},
+		{
+			name:    "not JSON",
+			content: "None found.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEntitiesOutput(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("entities = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCountEntities verifies entities are merged across chunks, counted as
+// whole words in any case, and dropped when they aren't in the text.
+func TestCountEntities(t *testing.T) {
+	text := "We met Ada at Acme. Later ada showed the Acme prototype to Adam! Nobody from Globex came."
+	found := []Entity{
+		{Name: "Acme", Type: "company"},
+		{Name: "Ada", Type: "person"},
+		{Name: "ADA", Type: "person"},
+		{Name: "Initech", Type: "company"},
+	}
+
+	got := countEntities(text, found)
+	want := []Entity{
+		{Name: "Acme", Type: "company", Mentions: 2, Context: "We met Ada at Acme."},
+		{Name: "Ada", Type: "person", Mentions: 2, Context: "We met Ada at Acme."},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countEntities = %+v, want %+v", got, want)
+	}
+}
+
+func TestMentionContext(t *testing.T) {
+	text := "First sentence. The second one names Globex\nin passing. Third."
+	start := len("First sentence. The second one names ")
+	got := mentionContext(text, start, start+len("Globex"))
+	if want := "The second one names Globex in passing."; got != want {
+		t.Errorf("mentionContext = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+// minutes.go turns a meeting transcript and its summary into structured
+// meeting minutes: attendees, agenda topics, decisions, action items with
+// owners, and next steps.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// MinutesTopic is one agenda item and what was discussed.
+type MinutesTopic struct {
+	Topic string `json:"topic"`
+	Notes string `json:"notes"`
+}
+
+// MinutesActionItem is a task with its owner and due date, when mentioned.
+type MinutesActionItem struct {
+	Task  string `json:"task"`
+	Owner string `json:"owner,omitempty"`
+	Due   string `json:"due,omitempty"`
+}
+
+// Minutes is the structured content of a meeting minutes document.
+type Minutes struct {
+	Title       string              `json:"title"`
+	Attendees   []string            `json:"attendees"`
+	Agenda      []MinutesTopic      `json:"agenda"`
+	Decisions   []string            `json:"decisions"`
+	ActionItems []MinutesActionItem `json:"action_items"`
+	NextSteps   []string            `json:"next_steps"`
+	Model       string              `json:"-"`
+}
+
+// MinutesInput is what we already know about the meeting from its summary.
+type MinutesInput struct {
+	Transcript  string
+	Summary     string
+	Decisions   []string
+	ActionItems []string
+}
+
+// GenerateMinutes produces structured meeting minutes.
+func (s *Service) GenerateMinutes(ctx context.Context, in MinutesInput, opts Options) (*Minutes, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Generating meeting minutes using %s", model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: getAudioSystemPrompt("meeting")},
+		{Role: "user", Content: buildMinutesPrompt(in)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.complete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	minutes, err := parseMinutesOutput(content)
+	if err != nil {
+		return nil, err
+	}
+	minutes.Model = servedBy
+	return minutes, nil
+}
+
+// buildMinutesPrompt constructs the prompt for minutes generation. The existing
+// summary is included so the minutes stay consistent with it.
+func buildMinutesPrompt(in MinutesInput) string {
+	var known strings.Builder
+	if in.Summary != "" {
+		known.WriteString("Summary: " + in.Summary + "\n")
+	}
+	if len(in.Decisions) > 0 {
+		known.WriteString("Decisions: " + strings.Join(in.Decisions, "; ") + "\n")
+	}
+	if len(in.ActionItems) > 0 {
+		known.WriteString("Action items: " + strings.Join(in.ActionItems, "; ") + "\n")
+	}
+
+	return fmt.Sprintf(`Write formal meeting minutes for the following meeting.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "title": "Short meeting title",
+  "attendees": ["Name 1", "Name 2"],
+  "agenda": [{"topic": "Topic discussed", "notes": "1-3 sentences on the discussion"}],
+  "decisions": ["Decision 1"],
+  "action_items": [{"task": "What needs doing", "owner": "Name", "due": "Friday"}],
+  "next_steps": ["Next step 1"]
+}
+
+Rules:
+- Only list attendees who are named or clearly identified in the transcript (empty array if none)
+- Order agenda topics as they came up in the meeting
+- Use "owner" and "due" only when the transcript states them; otherwise omit the field
+- Keep decisions and action items consistent with the existing summary below
+- Use empty arrays for sections with no content
+
+**Existing summary:**
+%s
+**Transcript:**
+%s`, known.String(), truncateTranscript(in.Transcript))
+}
+
+// parseMinutesOutput extracts the minutes JSON and normalizes empty sections to [].
+func parseMinutesOutput(content string) (*Minutes, error) {
+	var m Minutes
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &m); err != nil {
+		return nil, fmt.Errorf("model returned invalid minutes JSON: %w", err)
+	}
+
+	// Go Pattern: nil slices marshal to null — normalize so clients always get arrays
+	if m.Attendees == nil {
+		m.Attendees = []string{}
+	}
+	if m.Agenda == nil {
+		m.Agenda = []MinutesTopic{}
+	}
+	if m.Decisions == nil {
+		m.Decisions = []string{}
+	}
+	if m.ActionItems == nil {
+		m.ActionItems = []MinutesActionItem{}
+	}
+	if m.NextSteps == nil {
+		m.NextSteps = []string{}
+	}
+	if m.Title == "" && len(m.Agenda) == 0 && len(m.Decisions) == 0 && len(m.ActionItems) == 0 {
+		return nil, fmt.Errorf("model returned empty minutes")
+	}
+	return &m, nil
+}
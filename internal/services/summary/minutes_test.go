@@ -0,0 +1,21 @@
+// minutes_test.go tests parsing of model output into meeting minutes.
+package summary
+
+import "testing"
+
+func TestParseMinutesOutput(t *testing.T) {
+	got, err := parseMinutesOutput("```json\n" + `{"title":"Sync","action_items":[{"task":"Ship","owner":"Ana"}]}` + "\n```")
+	if err != nil {
+		t.Fatalf("parseMinutesOutput() error = %v", err)
+	}
+	if got.Attendees == nil || got.Agenda == nil || got.NextSteps == nil {
+		t.Error("missing sections should be normalized to empty slices")
+	}
+	if len(got.ActionItems) != 1 || got.ActionItems[0].Owner != "Ana" {
+		t.Errorf("action items = %+v", got.ActionItems)
+	}
+
+	if _, err := parseMinutesOutput(`{"attendees":["Ana"]}`); err == nil {
+		t.Error("expected error for minutes with no content")
+	}
+}
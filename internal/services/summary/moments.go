@@ -0,0 +1,158 @@
+// moments.go picks the most important moments of a video from its timed
+// transcript, each with a timestamp, a short title, and a one-line
+// description, for chapter lists and clip suggestions.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Moment is one key moment, starting Seconds into the video.
+type Moment struct {
+	Seconds     int    `json:"seconds"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// MomentsResult holds the moments found, in video order.
+type MomentsResult struct {
+	Moments []Moment `json:"moments"`
+	Model   string   `json:"model"`
+}
+
+// timestampRe matches the m:ss or h:mm:ss timestamps the prompt asks for.
+var timestampRe = regexp.MustCompile(`^\[?(?:(\d{1,2}):)?(\d{1,3}):(\d{2})\]?$`)
+
+// FindMoments picks up to count key moments from timed text, the
+// "[m:ss] text" lines export.TimedText renders.
+func (s *Service) FindMoments(ctx context.Context, timedText string, count int, opts Options) (*MomentsResult, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Finding %d key moments using %s", count, model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: "You are a video editor who finds the moments in a video that viewers most want to jump to or share."},
+		{Role: "user", Content: buildMomentsPrompt(timedText, count)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	moments, err := parseMomentsOutput(content, count)
+	if err != nil {
+		return nil, err
+	}
+	return &MomentsResult{Moments: moments, Model: servedBy}, nil
+}
+
+// buildMomentsPrompt constructs the prompt for finding key moments.
+func buildMomentsPrompt(timedText string, count int) string {
+	return fmt.Sprintf(`Find the %d most important moments in this video: key points, turns in the discussion, memorable quotes, and demonstrations worth clipping.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "moments": [
+    {"timestamp": "12:34", "title": "Short title (under 8 words)", "description": "One sentence on what happens and why it matters"}
+  ]
+}
+
+Rules:
+- Each line of the transcript starts with the time it's spoken, like [12:34]
+- Use the timestamp of the line where the moment starts, exactly as written
+- Spread the moments across the whole video and list them in order
+- Return fewer than %d moments if the video doesn't have that many
+
+**Transcript:**
+%s`, count, count, fitTimeline(timedText))
+}
+
+// fitTimeline caps timed text like truncateTranscript does, but by
+// shortening every line rather than cutting off the end, so moments can
+// come from anywhere in a long video.
+func fitTimeline(timedText string) string {
+	const maxLen = 15000
+	if len(timedText) <= maxLen {
+		return timedText
+	}
+	lines := strings.Split(strings.TrimSpace(timedText), "\n")
+	budget := maxLen / len(lines)
+	for i, line := range lines {
+		if len(line) <= budget {
+			continue
+		}
+		// Cut at a word boundary, keeping at least the timestamp
+		cut := strings.LastIndex(line[:budget], " ")
+		if stamp := strings.IndexByte(line, ']') + 1; cut < stamp {
+			cut = stamp
+		}
+		lines[i] = line[:cut] + " …"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseMomentsOutput extracts the moments JSON, dropping moments without a
+// readable timestamp or at a time already taken, and sorts the rest.
+func parseMomentsOutput(content string, count int) ([]Moment, error) {
+	var out struct {
+		Moments []struct {
+			Timestamp   string `json:"timestamp"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"moments"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &out); err != nil {
+		return nil, fmt.Errorf("model returned invalid moments JSON: %w", err)
+	}
+
+	moments := []Moment{}
+	seen := make(map[int]bool)
+	for _, m := range out.Moments {
+		seconds, ok := parseTimestamp(m.Timestamp)
+		if !ok || seen[seconds] || (m.Title == "" && m.Description == "") {
+			continue
+		}
+		seen[seconds] = true
+		moments = append(moments, Moment{Seconds: seconds, Title: m.Title, Description: m.Description})
+	}
+	if len(moments) == 0 {
+		return nil, fmt.Errorf("model returned no moments with valid timestamps")
+	}
+
+	sort.Slice(moments, func(i, j int) bool { return moments[i].Seconds < moments[j].Seconds })
+	if len(moments) > count {
+		moments = moments[:count]
+	}
+	return moments, nil
+}
+
+// parseTimestamp converts an m:ss or h:mm:ss timestamp to seconds.
+func parseTimestamp(ts string) (int, bool) {
+	m := timestampRe.FindStringSubmatch(strings.TrimSpace(ts))
+	if m == nil {
+		return 0, false
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	secs, _ := strconv.Atoi(m[3])
+	if secs >= 60 || (m[1] != "" && minutes >= 60) {
+		return 0, false
+	}
+	return hours*3600 + minutes*60 + secs, true
+}
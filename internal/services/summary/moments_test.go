@@ -0,0 +1,53 @@
+// moments_test.go tests parsing key moments from model output and fitting
+// long timed transcripts into the prompt.
+package summary
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMomentsOutput(t *testing.T) {
+	content := "Here you go:\n```json\n" + `{"moments":[
+		{"timestamp":"1:02:03","title":"Wrap-up","description":"Closing thoughts"},
+		{"timestamp":"0:05","title":"Intro","description":"Welcome"},
+		{"timestamp":"[12:34]","title":"Demo","description":"The live demo"},
+		{"timestamp":"0:05","title":"Again","description":"Same time"},
+		{"timestamp":"1:75","title":"Bad","description":"Not a time"},
+		{"timestamp":"","title":"None","description":"No time"}
+	]}` + "\n```"
+
+	got, err := parseMomentsOutput(content, 2)
+	if err != nil {
+		t.Fatalf("parseMomentsOutput() error = %v", err)
+	}
+	want := []Moment{
+		{Seconds: 5, Title: "Intro", Description: "Welcome"},
+		{Seconds: 754, Title: "Demo", Description: "The live demo"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("moments = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseMomentsOutput(`{"moments":[{"timestamp":"soon","title":"x"}]}`, 5); err == nil {
+		t.Error("expected error when no moment has a valid timestamp")
+	}
+}
+
+func TestFitTimeline(t *testing.T) {
+	short := "[0:00] hello\n"
+	if got := fitTimeline(short); got != short {
+		t.Errorf("fitTimeline() changed short text to %q", got)
+	}
+
+	line := "[1:00] " + strings.Repeat("word ", 100)
+	long := strings.Repeat(line+"\n", 100)
+	got := fitTimeline(long)
+	if len(got) > 15000+100*len(" …") {
+		t.Errorf("fitTimeline() = %d bytes, want about 15000", len(got))
+	}
+	lines := strings.Split(got, "\n")
+	if len(lines) != 100 || !strings.HasPrefix(lines[99], "[1:00] word") {
+		t.Errorf("fitTimeline() kept %d lines, last %q; want all 100 shortened", len(lines), lines[len(lines)-1])
+	}
+}
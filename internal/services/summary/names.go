@@ -0,0 +1,104 @@
+// names.go finds the names of people mentioned in a transcript, so the
+// redaction pass can mask them alongside the pattern-matched PII.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// nameChunkSize is how much text goes into each name detection request.
+// Unlike summaries, redaction can't settle for a truncated transcript.
+const nameChunkSize = 12000
+
+// DetectNames returns the distinct personal names mentioned in text,
+// asking the model one chunk at a time so long transcripts are fully covered.
+func (s *Service) DetectNames(ctx context.Context, text string) ([]string, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	chunks := chunkText(text, nameChunkSize)
+	log.Printf("🤖 Detecting names in %d chunk(s) using %s", len(chunks), s.Model())
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, chunk := range chunks {
+		messages := []chatMessage{
+			{Role: "system", Content: "You are a named-entity recognizer. You find the names of people in text and reply only with JSON."},
+			{Role: "user", Content: buildNamesPrompt(chunk)},
+		}
+		content, _, err := s.complete(ctx, s.modelChain(s.Model(), nil), messages)
+		if err != nil {
+			return nil, err
+		}
+		found, err := parseNamesOutput(content)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range found {
+			if key := strings.ToLower(name); !seen[key] {
+				seen[key] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// buildNamesPrompt constructs the prompt for one chunk of name detection.
+func buildNamesPrompt(chunk string) string {
+	return fmt.Sprintf(`List every name of a person mentioned in the text below.
+
+**Important:** Respond with valid JSON in this exact format:
+{"names": ["Jane Doe", "Bob"]}
+
+Rules:
+- Include first names, last names, and full names exactly as written
+- Do not include companies, places, products, or titles
+- Return {"names": []} if there are none
+
+**Text:**
+%s`, chunk)
+}
+
+// parseNamesOutput extracts the name list from the AI response, dropping
+// blanks and one-letter fragments that would mask too much.
+func parseNamesOutput(content string) ([]string, error) {
+	var structured struct {
+		Names []string `json:"names"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &structured); err != nil {
+		return nil, fmt.Errorf("model returned invalid names JSON: %w", err)
+	}
+	names := []string{}
+	for _, name := range structured.Names {
+		name = strings.TrimSpace(name)
+		if len([]rune(name)) < 2 {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// chunkText splits text into pieces of at most size bytes, breaking on
+// whitespace so words aren't cut in half.
+func chunkText(text string, size int) []string {
+	var chunks []string
+	for len(text) > size {
+		cut := strings.LastIndexAny(text[:size], " \n\t")
+		if cut <= 0 {
+			cut = size
+		}
+		chunks = append(chunks, text[:cut])
+		text = strings.TrimLeft(text[cut:], " \n\t")
+	}
+	if strings.TrimSpace(text) != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
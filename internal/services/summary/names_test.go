@@ -0,0 +1,74 @@
+// names_test.go tests name detection output parsing and text chunking.
+package summary
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseNamesOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "plain JSON",
+			content: `{"names":["Jane Doe","Bob"]}`,
+			want:    []string{"Jane Doe", "Bob"},
+		},
+		{
+			name:    "wrapped in markdown fence",
+			content: "```json\n{\"names\": [\"Alice\"]}\n```",
+			want:    []string{"Alice"},
+		},
+		{
+			name:    "drops blanks and single letters",
+			content: `{"names":["  ","J","Ken "]}`,
+			want:    []string{"Ken"},
+		},
+		{
+			name:    "no names",
+			content: `{"names":[]}`,
+			want:    []string{},
+		},
+		{
+			name:    "not JSON",
+			content: "I couldn't find any.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNamesOutput(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("names = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkText(t *testing.T) {
+	text := strings.Repeat("word ", 10) // 50 bytes
+	chunks := chunkText(text, 12)
+	for _, c := range chunks {
+		if len(c) > 12 {
+			t.Errorf("chunk %q is longer than 12 bytes", c)
+		}
+		if strings.HasPrefix(c, " ") {
+			t.Errorf("chunk %q starts with whitespace", c)
+		}
+	}
+	if got := strings.Join(strings.Fields(strings.Join(chunks, " ")), " "); got != strings.TrimSpace(text) {
+		t.Errorf("rejoined chunks = %q, want the original words", got)
+	}
+	if got := chunkText("   ", 12); len(got) != 0 {
+		t.Errorf("blank text gave %d chunks, want 0", len(got))
+	}
+}
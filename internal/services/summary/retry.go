@@ -0,0 +1,244 @@
+// retry.go sends chat completion requests to OpenRouter with automatic
+// retries and an ordered fallback model chain.
+//
+// How it works:
+//  1. Try the primary model.
+//  2. On a retryable failure (429, 5xx, network error), wait with exponential
+//     backoff and try the same model again, up to maxRetries times.
+//  3. If the model still fails (or fails in a way retrying won't fix, like
+//     "model not found"), move on to the next model in the chain.
+//  4. Return the content along with the model that actually served it, and
+//     record what the completion cost (see spend.go).
+package summary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// openRouterURL is the OpenRouter chat completions endpoint.
+const openRouterURL = "https://openrouter.ai/api/v1/chat/completions"
+
+// openRouterKeyURL describes the API key making the request. It's free to call.
+const openRouterKeyURL = "https://openrouter.ai/api/v1/auth/key"
+
+// requestTimeout bounds a single OpenRouter request when the caller's
+// context has no deadline. LLMs can be slow.
+const requestTimeout = 120 * time.Second
+
+// defaultMaxRetries is how many times a single model is retried before
+// falling back to the next one in the chain.
+const defaultMaxRetries = 2
+
+// apiError is returned when OpenRouter responds with a non-200 status.
+// Keeping the status code lets the retry loop decide what to do next.
+type apiError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // Parsed from the Retry-After header (429s), if present
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("OpenRouter returned %d: %s", e.StatusCode, e.Body)
+}
+
+// retryable reports whether retrying the SAME model might succeed.
+func (e *apiError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// fatal reports whether NO model can succeed — e.g. a bad API key.
+// There's no point walking the fallback chain in that case.
+func (e *apiError) fatal() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden ||
+		e.StatusCode == http.StatusPaymentRequired
+}
+
+// SetFallbackModels configures the default ordered fallback chain used when
+// the primary model fails. Per-request overrides replace this list.
+func (s *Service) SetFallbackModels(models []string) {
+	s.fallbackModels = models
+}
+
+// SetMaxRetries configures how many times each model is retried on 429/5xx.
+func (s *Service) SetMaxRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	s.maxRetries = n
+}
+
+// modelChain builds the ordered, de-duplicated list of models to try.
+// A non-nil override replaces the configured fallbacks (an empty, non-nil
+// slice disables fallback for that request).
+func (s *Service) modelChain(primary string, override []string) []string {
+	fallbacks := s.fallbackModels
+	if override != nil {
+		fallbacks = override
+	}
+
+	chain := make([]string, 0, len(fallbacks)+1)
+	seen := make(map[string]bool)
+	for _, m := range append([]string{primary}, fallbacks...) {
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		chain = append(chain, m)
+	}
+	return chain
+}
+
+// complete runs a chat completion across the model chain.
+// Returns the response content and the model that served it.
+func (s *Service) complete(ctx context.Context, chain []string, messages []chatMessage) (string, string, error) {
+	if len(chain) == 0 {
+		return "", "", fmt.Errorf("no model configured")
+	}
+
+	var lastErr error
+	for i, model := range chain {
+		if i > 0 {
+			log.Printf("↪️  Falling back to model %s after error: %v", model, lastErr)
+		}
+
+		content, usage, err := s.completeWithRetry(ctx, model, messages)
+		if err == nil {
+			s.recordSpend(ctx, model, usage)
+			return content, model, nil
+		}
+		lastErr = err
+
+		// Stop early if the caller gave up or the error affects every model
+		if ctx.Err() != nil {
+			return "", "", ctx.Err()
+		}
+		var apiErr *apiError
+		if errors.As(err, &apiErr) && apiErr.fatal() {
+			return "", "", err
+		}
+	}
+
+	if len(chain) > 1 {
+		return "", "", fmt.Errorf("all %d models failed; last error: %w", len(chain), lastErr)
+	}
+	return "", "", lastErr
+}
+
+// completeWithRetry sends the request to a single model, retrying with
+// exponential backoff on transient failures.
+func (s *Service) completeWithRetry(ctx context.Context, model string, messages []chatMessage) (string, Usage, error) {
+	var lastErr error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := s.retryBackoff << (attempt - 1) // 1x, 2x, 4x...
+			var apiErr *apiError
+			if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > delay {
+				delay = apiErr.RetryAfter
+			}
+			log.Printf("🔁 Retrying %s in %s (attempt %d/%d): %v", model, delay, attempt+1, s.maxRetries+1, lastErr)
+
+			// Go Pattern: select on a timer and ctx.Done() so a cancelled
+			// request doesn't sit around waiting for the backoff to expire.
+			select {
+			case <-ctx.Done():
+				return "", Usage{}, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		content, usage, err := s.send(ctx, model, messages)
+		if err == nil {
+			return content, usage, nil
+		}
+		lastErr = err
+
+		var apiErr *apiError
+		if errors.As(err, &apiErr) && !apiErr.retryable() {
+			return "", Usage{}, err // 4xx — retrying the same model won't help
+		}
+		if ctx.Err() != nil {
+			return "", Usage{}, ctx.Err()
+		}
+	}
+	return "", Usage{}, lastErr
+}
+
+// send performs a single chat completion request against one model,
+// returning the reply and the tokens it used.
+func (s *Service) send(ctx context.Context, model string, messages []chatMessage) (string, Usage, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+	}
+
+	jsonBody, err := json.Marshal(chatRequest{Model: model, Messages: messages, Usage: &usageRequest{Include: true}})
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.baseURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("HTTP-Referer", "https://github.com/Shimizu-Technology/media-tools-api")
+	req.Header.Set("X-Title", "Media Tools API")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("OpenRouter request failed: %w", err)
+	}
+	defer resp.Body.Close() // Go Pattern: ALWAYS close response bodies!
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiError{StatusCode: resp.StatusCode, Body: string(body)}
+		if secs, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && secs > 0 {
+			apiErr.RetryAfter = time.Duration(secs) * time.Second
+		}
+		return "", Usage{}, apiErr
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return "", Usage{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// OpenRouter sometimes reports upstream provider failures inside a 200
+	// response. Surface the embedded code so the retry logic can act on it.
+	if chatResp.Error != nil {
+		if chatResp.Error.Code != 0 {
+			return "", Usage{}, &apiError{StatusCode: chatResp.Error.Code, Body: chatResp.Error.Message}
+		}
+		return "", Usage{}, fmt.Errorf("OpenRouter error: %s", chatResp.Error.Message)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return "", Usage{}, fmt.Errorf("no response from model")
+	}
+
+	var usage Usage
+	if u := chatResp.Usage; u != nil {
+		usage = Usage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens}
+		if u.Cost != nil {
+			usage.CostUSD, usage.costKnown = *u.Cost, true
+		}
+	}
+	return chatResp.Choices[0].Message.Content, usage, nil
+}
@@ -0,0 +1,188 @@
+// retry_test.go verifies the retry and fallback model chain.
+//
+// Go Pattern: net/http/httptest spins up a real HTTP server on localhost,
+// so we can exercise the full request/response path without hitting
+// OpenRouter. Each test scripts the server's responses per model.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// fakeOpenRouter returns a test server that replies with the scripted
+// status codes for each model, in order. Once a model's script runs out
+// it returns 200 with a canned answer.
+func fakeOpenRouter(t *testing.T, script map[string][]int) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("bad request body: %v", err)
+		}
+
+		mu.Lock()
+		calls = append(calls, req.Model)
+		var status int
+		if codes := script[req.Model]; len(codes) > 0 {
+			status = codes[0]
+			script[req.Model] = codes[1:]
+		}
+		mu.Unlock()
+
+		if status != 0 && status != http.StatusOK {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error":{"message":"boom"}}`))
+			return
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"answer from ` + req.Model + `"}}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func newTestService(url string) *Service {
+	s := New("test-key", "primary/model")
+	s.baseURL = url
+	s.retryBackoff = 0 // Don't slow tests down
+	return s
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name      string
+		script    map[string][]int
+		fallbacks []string
+		wantModel string
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "primary succeeds first try",
+			script:    map[string][]int{},
+			wantModel: "primary/model",
+			wantCalls: []string{"primary/model"},
+		},
+		{
+			name:      "retries 429 on same model",
+			script:    map[string][]int{"primary/model": {429, 503}},
+			wantModel: "primary/model",
+			wantCalls: []string{"primary/model", "primary/model", "primary/model"},
+		},
+		{
+			name:      "falls back after retries are exhausted",
+			script:    map[string][]int{"primary/model": {500, 500, 500}},
+			fallbacks: []string{"backup/model"},
+			wantModel: "backup/model",
+			wantCalls: []string{"primary/model", "primary/model", "primary/model", "backup/model"},
+		},
+		{
+			name:      "non-retryable 4xx skips straight to fallback",
+			script:    map[string][]int{"primary/model": {404}},
+			fallbacks: []string{"backup/model"},
+			wantModel: "backup/model",
+			wantCalls: []string{"primary/model", "backup/model"},
+		},
+		{
+			name:      "auth errors stop the chain",
+			script:    map[string][]int{"primary/model": {401}},
+			fallbacks: []string{"backup/model"},
+			wantCalls: []string{"primary/model"},
+			wantErr:   true,
+		},
+		{
+			name:      "every model fails",
+			script:    map[string][]int{"primary/model": {400}, "backup/model": {400}},
+			fallbacks: []string{"backup/model"},
+			wantCalls: []string{"primary/model", "backup/model"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := fakeOpenRouter(t, tt.script)
+			s := newTestService(srv.URL)
+			s.SetFallbackModels(tt.fallbacks)
+
+			content, model, err := s.complete(context.Background(), s.modelChain("primary/model", nil), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("complete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if model != tt.wantModel {
+					t.Errorf("served by %q, want %q", model, tt.wantModel)
+				}
+				if content != "answer from "+tt.wantModel {
+					t.Errorf("content = %q", content)
+				}
+			}
+			if !reflect.DeepEqual(*calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestModelChain(t *testing.T) {
+	s := New("test-key", "primary/model")
+	s.SetFallbackModels([]string{"a/model", "primary/model", "b/model"})
+
+	tests := []struct {
+		name     string
+		primary  string
+		override []string
+		want     []string
+	}{
+		{"configured fallbacks are de-duplicated", "primary/model", nil, []string{"primary/model", "a/model", "b/model"}},
+		{"override replaces configured fallbacks", "primary/model", []string{"c/model"}, []string{"primary/model", "c/model"}},
+		{"empty override disables fallback", "primary/model", []string{}, []string{"primary/model"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.modelChain(tt.primary, tt.override)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("modelChain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheckKey verifies key check responses map to errors.
+func TestCheckKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"valid key", http.StatusOK, false},
+		{"rejected key", http.StatusUnauthorized, true},
+		{"server error", http.StatusBadGateway, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+					t.Errorf("Authorization = %q", got)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			s := newTestService(srv.URL)
+			s.keyURL = srv.URL
+			if err := s.CheckKey(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("CheckKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
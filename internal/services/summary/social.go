@@ -0,0 +1,261 @@
+// social.go repurposes a summary into posts for social platforms — a tweet
+// thread, a LinkedIn post, a newsletter blurb — in a chosen tone, kept
+// within each platform's character limit.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+)
+
+// SocialPlatform describes what a platform's posts look like.
+type SocialPlatform struct {
+	Label  string // How the prompt names it
+	Limit  int    // Characters allowed per post, or per tweet in a thread
+	Thread bool   // Posted as a numbered series of parts
+	Guide  string // Style guidance for the prompt
+}
+
+// SocialPlatforms lists the platforms posts can be written for.
+var SocialPlatforms = map[string]SocialPlatform{
+	"twitter": {
+		Label:  "X/Twitter thread",
+		Limit:  280,
+		Thread: true,
+		Guide:  "3-8 tweets; the first hooks the reader, the last sums up. Don't number the tweets",
+	},
+	"linkedin": {
+		Label: "LinkedIn post",
+		Limit: 3000,
+		Guide: "800-1500 characters; a strong first line, short paragraphs, and a question or takeaway to close",
+	},
+	"newsletter": {
+		Label: "Newsletter blurb",
+		Limit: 600,
+		Guide: "2-4 sentences introducing the content to subscribers and why it's worth their time",
+	},
+}
+
+// SocialTones lists the tones posts can be written in.
+var SocialTones = map[string]string{
+	"professional": "professional and clear",
+	"casual":       "casual and conversational",
+	"enthusiastic": "enthusiastic and energetic",
+	"educational":  "educational, explaining ideas simply",
+	"witty":        "witty and playful, without being flippant",
+}
+
+// threadNumberWidth is the room left in each tweet for a "10/12 " prefix.
+const threadNumberWidth = 6
+
+// SocialInput is the content posts are written from.
+type SocialInput struct {
+	Source    string // What the content is, e.g. "YouTube video"
+	Title     string
+	Summary   string
+	KeyPoints []string
+	URL       string // Link to include, if any
+}
+
+// SocialPost is one platform's post, ready to paste.
+type SocialPost struct {
+	Platform   string   `json:"platform"`
+	Parts      []string `json:"parts"`      // The tweets of a thread; one part for other platforms
+	Text       string   `json:"text"`       // Parts joined by blank lines
+	Characters int      `json:"characters"` // Length of the longest part
+	Limit      int      `json:"limit"`      // Characters allowed per part
+}
+
+// SocialResult holds the generated posts, in the order platforms were asked for.
+type SocialResult struct {
+	Tone  string       `json:"tone"`
+	Posts []SocialPost `json:"posts"`
+	Model string       `json:"model"`
+}
+
+// GenerateSocialPosts writes a post for each platform (keys of
+// SocialPlatforms) in tone (a key of SocialTones).
+func (s *Service) GenerateSocialPosts(ctx context.Context, in SocialInput, platforms []string, tone string, opts Options) (*SocialResult, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Writing %s posts for %s using %s", tone, strings.Join(platforms, ", "), model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: "You are a social media manager who turns long-form content into posts people want to read and share."},
+		{Role: "user", Content: buildSocialPrompt(in, platforms, tone)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := parseSocialOutput(content, platforms)
+	if err != nil {
+		return nil, err
+	}
+	return &SocialResult{Tone: tone, Posts: posts, Model: servedBy}, nil
+}
+
+// buildSocialPrompt constructs the prompt for social posts.
+func buildSocialPrompt(in SocialInput, platforms []string, tone string) string {
+	var specs strings.Builder
+	for _, name := range platforms {
+		p := SocialPlatforms[name]
+		unit := "the whole post"
+		if p.Thread {
+			unit = "each tweet"
+		}
+		fmt.Fprintf(&specs, "- \"%s\" (%s): %s. At most %d characters for %s\n", name, p.Label, p.Guide, p.Limit, unit)
+	}
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "Source: %s\n", in.Source)
+	if in.Title != "" {
+		fmt.Fprintf(&content, "Title: %s\n", in.Title)
+	}
+	if in.URL != "" {
+		fmt.Fprintf(&content, "Link: %s\n", in.URL)
+	}
+	fmt.Fprintf(&content, "Summary: %s\n", truncateTranscript(in.Summary))
+	if len(in.KeyPoints) > 0 {
+		content.WriteString("Key points:\n- " + strings.Join(in.KeyPoints, "\n- ") + "\n")
+	}
+
+	return fmt.Sprintf(`Repurpose the content below into social media posts. Write in a %s tone.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "posts": [
+    {"platform": "twitter", "parts": ["First tweet", "Second tweet"]},
+    {"platform": "linkedin", "parts": ["The whole post"]}
+  ]
+}
+
+Write one post for each of these platforms:
+%s
+Rules:
+- Stay true to the content; don't invent facts, numbers, or quotes
+- Include the link once, where it reads naturally, if one is given
+- Use at most 3 hashtags per post, and only where the platform expects them
+
+**Content:**
+%s`, SocialTones[tone], specs.String(), content.String())
+}
+
+// parseSocialOutput extracts the posts JSON and fits each requested
+// platform's post to its limit. Platforms the model skipped are an error.
+func parseSocialOutput(content string, platforms []string) ([]SocialPost, error) {
+	var out struct {
+		Posts []struct {
+			Platform string   `json:"platform"`
+			Parts    []string `json:"parts"`
+		} `json:"posts"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &out); err != nil {
+		return nil, fmt.Errorf("model returned invalid posts JSON: %w", err)
+	}
+
+	byPlatform := make(map[string][]string)
+	for _, p := range out.Posts {
+		for _, part := range p.Parts {
+			if part = strings.TrimSpace(part); part != "" {
+				byPlatform[p.Platform] = append(byPlatform[p.Platform], part)
+			}
+		}
+	}
+
+	posts := make([]SocialPost, 0, len(platforms))
+	for _, name := range platforms {
+		parts := byPlatform[name]
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("model returned no %s post", name)
+		}
+		posts = append(posts, fitPost(name, parts))
+	}
+	return posts, nil
+}
+
+// fitPost keeps a post within its platform's limit. Threads split long
+// tweets at word boundaries and are numbered; other posts are joined and
+// cut at the last sentence or word that fits.
+func fitPost(platform string, parts []string) SocialPost {
+	p := SocialPlatforms[platform]
+	if p.Thread {
+		var tweets []string
+		for _, part := range parts {
+			if utf8.RuneCountInString(part) <= p.Limit-threadNumberWidth {
+				tweets = append(tweets, part)
+				continue
+			}
+			tweets = append(tweets, splitWords(part, p.Limit-threadNumberWidth)...)
+		}
+		if len(tweets) > 1 {
+			for i := range tweets {
+				tweets[i] = fmt.Sprintf("%d/%d %s", i+1, len(tweets), tweets[i])
+			}
+		}
+		parts = tweets
+	} else {
+		parts = []string{cutToLimit(strings.Join(parts, "\n\n"), p.Limit)}
+	}
+
+	post := SocialPost{Platform: platform, Parts: parts, Text: strings.Join(parts, "\n\n"), Limit: p.Limit}
+	for _, part := range parts {
+		post.Characters = max(post.Characters, utf8.RuneCountInString(part))
+	}
+	return post
+}
+
+// splitWords breaks text into chunks of at most limit characters at word
+// boundaries. A single word longer than limit gets a chunk of its own.
+func splitWords(text string, limit int) []string {
+	var chunks []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= limit:
+			current += " " + word
+		default:
+			chunks = append(chunks, current)
+			current = word
+		}
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// cutToLimit shortens text to at most limit characters, ending at the last
+// sentence that fits, or the last word plus an ellipsis.
+func cutToLimit(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	cut := string(runes[:limit])
+	if i := strings.LastIndexAny(cut, ".!?"); i > len(cut)/2 {
+		return cut[:i+1]
+	}
+	// Leave room for the ellipsis
+	cut = string(runes[:limit-1])
+	if i := strings.LastIndexAny(cut, " \n"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " \n,;:") + "…"
+}
@@ -0,0 +1,59 @@
+// social_test.go tests fitting generated social posts to platform limits.
+package summary
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseSocialOutput(t *testing.T) {
+	long := strings.Repeat("word ", 70) // 350 characters
+	content := `{"posts": [
+		{"platform": "linkedin", "parts": ["Para one.", " ", "Para two."]},
+		{"platform": "twitter", "parts": ["Hook", "` + long + `"]}
+	]}`
+
+	posts, err := parseSocialOutput(content, []string{"twitter", "linkedin"})
+	if err != nil {
+		t.Fatalf("parseSocialOutput() error = %v", err)
+	}
+	if len(posts) != 2 || posts[0].Platform != "twitter" || posts[1].Platform != "linkedin" {
+		t.Fatalf("posts = %+v, want twitter then linkedin", posts)
+	}
+
+	thread := posts[0]
+	if len(thread.Parts) != 3 || thread.Parts[0] != "1/3 Hook" || !strings.HasPrefix(thread.Parts[2], "3/3 word") {
+		t.Errorf("thread parts = %q, want the long tweet split and all numbered", thread.Parts)
+	}
+	if thread.Characters > 280 || thread.Limit != 280 {
+		t.Errorf("thread characters = %d of %d, want within 280", thread.Characters, thread.Limit)
+	}
+	if posts[1].Text != "Para one.\n\nPara two." || len(posts[1].Parts) != 1 {
+		t.Errorf("linkedin = %+v, want one part joining the paragraphs", posts[1])
+	}
+
+	if _, err := parseSocialOutput(content, []string{"newsletter"}); err == nil {
+		t.Error("expected error when a requested platform is missing")
+	}
+}
+
+func TestCutToLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"fits", "Short.", "Short."},
+		{"sentence", "First sentence here. Second one is long", "First sentence here."},
+		{"word", "one two three four five six seven eight", "one two three four…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cutToLimit(tt.text, 20)
+			if got != tt.want || utf8.RuneCountInString(got) > 20 {
+				t.Errorf("cutToLimit() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
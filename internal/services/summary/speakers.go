@@ -0,0 +1,158 @@
+// speakers.go produces per-speaker sections for diarized calls and meetings:
+// what each person covered, what they committed to, and the questions they
+// raised.
+//
+// Speakers are taken from the transcript's labels ("Alice: ..."), the same
+// way talk-time is, so the model can only report on people who actually
+// spoke. Transcripts without labels get no per-speaker sections.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// SpeakerSummary is one speaker's part in the conversation.
+type SpeakerSummary struct {
+	Speaker     string   `json:"speaker"`
+	Summary     string   `json:"summary"`
+	Commitments []string `json:"commitments"` // Things this speaker agreed or promised to do
+	Questions   []string `json:"questions"`   // Questions this speaker raised
+}
+
+// Speakers returns the labelled speakers of a diarized transcript in order
+// of first appearance, or nil if the transcript isn't diarized.
+func Speakers(transcript string) []string {
+	talkTime := speakerTalkTime(transcript)
+	if len(talkTime) == 0 {
+		return nil
+	}
+	// speakerTalkTime orders by words spoken; restore first-appearance order
+	seen := make(map[string]bool, len(talkTime))
+	for _, tt := range talkTime {
+		seen[tt.Speaker] = true
+	}
+	speakers := make([]string, 0, len(talkTime))
+	for _, line := range strings.Split(transcript, "\n") {
+		m := speakerLabelRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		if speaker := strings.TrimSpace(m[1]); seen[speaker] {
+			speakers = append(speakers, speaker)
+			delete(seen, speaker)
+		}
+	}
+	return speakers
+}
+
+// SummarizeSpeakers generates a section per speaker for a speaker-labelled
+// transcript. The returned model is the one that served the request.
+func (s *Service) SummarizeSpeakers(ctx context.Context, transcript string, opts Options) ([]SpeakerSummary, string, error) {
+	if s.apiKey == "" {
+		return nil, "", fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	speakers := Speakers(transcript)
+	if len(speakers) == 0 {
+		return nil, "", fmt.Errorf("transcript has no speaker labels")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Summarizing %d speakers using %s", len(speakers), model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: getAudioSystemPrompt(opts.ContentType)},
+		{Role: "user", Content: buildSpeakerPrompt(transcript, speakers)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := parseSpeakerOutput(content, speakers)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, servedBy, nil
+}
+
+// buildSpeakerPrompt constructs the prompt for per-speaker summaries.
+func buildSpeakerPrompt(transcript string, speakers []string) string {
+	return fmt.Sprintf(`Summarize each speaker's part in the following conversation.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "speakers": [
+    {
+      "speaker": "Alice",
+      "summary": "1-2 sentences on what this speaker covered",
+      "commitments": ["Send the revised quote by Friday"],
+      "questions": ["Can the delivery date move up a week?"]
+    }
+  ]
+}
+
+Rules:
+- Include exactly these speakers, using the names as written: %s
+- "commitments" lists only things the speaker themselves agreed or promised to do (empty array if none)
+- "questions" lists the questions the speaker asked, rephrased to stand alone (empty array if none)
+- Do not attribute one speaker's statements to another
+
+**Transcript:**
+%s`, strings.Join(speakers, ", "), truncateTranscript(transcript))
+}
+
+// parseSpeakerOutput extracts the per-speaker JSON and keeps only sections
+// for known speakers, in transcript order. Names are matched case-insensitively
+// and reported as they appear in the transcript.
+func parseSpeakerOutput(content string, speakers []string) ([]SpeakerSummary, error) {
+	var structured struct {
+		Speakers []SpeakerSummary `json:"speakers"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &structured); err != nil {
+		return nil, fmt.Errorf("model returned invalid speaker JSON: %w", err)
+	}
+
+	byName := make(map[string]SpeakerSummary, len(structured.Speakers))
+	for _, sp := range structured.Speakers {
+		key := strings.ToLower(strings.TrimSpace(sp.Speaker))
+		if _, dup := byName[key]; !dup {
+			byName[key] = sp
+		}
+	}
+
+	result := make([]SpeakerSummary, 0, len(speakers))
+	for _, name := range speakers {
+		sp, ok := byName[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		sp.Speaker = name
+		sp.Summary = strings.TrimSpace(sp.Summary)
+		sp.Commitments = nonEmpty(sp.Commitments)
+		sp.Questions = nonEmpty(sp.Questions)
+		result = append(result, sp)
+	}
+	return result, nil
+}
+
+// nonEmpty trims items and drops blank ones, always returning a non-nil slice.
+func nonEmpty(items []string) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
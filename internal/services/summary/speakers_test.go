@@ -0,0 +1,53 @@
+// speakers_test.go tests speaker detection and per-speaker output parsing.
+package summary
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpeakers(t *testing.T) {
+	tests := []struct {
+		name       string
+		transcript string
+		want       []string
+	}{
+		{"first appearance order", "Bob: hi\nAlice: hello there how are you\nBob: fine", []string{"Bob", "Alice"}},
+		{"not diarized", "We ship on Friday: that is the plan.", nil},
+		{"single speaker", "Alice: one\nAlice: two", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Speakers(tt.transcript); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Speakers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpeakerOutput(t *testing.T) {
+	content := "```json\n" + `{
+		"speakers": [
+			{"speaker": "bob", "summary": " Asked about pricing. ", "questions": ["Is there a discount?", " "]},
+			{"speaker": "Carol", "summary": "Never spoke", "commitments": ["Made up"]},
+			{"speaker": "Alice", "summary": "Ran the call", "commitments": ["Send the quote by Friday"]},
+			{"speaker": "Alice", "summary": "Duplicate"}
+		]
+	}` + "\n```"
+
+	got, err := parseSpeakerOutput(content, []string{"Alice", "Bob"})
+	if err != nil {
+		t.Fatalf("parseSpeakerOutput() error = %v", err)
+	}
+	want := []SpeakerSummary{
+		{Speaker: "Alice", Summary: "Ran the call", Commitments: []string{"Send the quote by Friday"}, Questions: []string{}},
+		{Speaker: "Bob", Summary: "Asked about pricing.", Commitments: []string{}, Questions: []string{"Is there a discount?"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSpeakerOutput() = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseSpeakerOutput("not json", []string{"Alice"}); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
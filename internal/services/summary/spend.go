@@ -0,0 +1,145 @@
+// spend.go accounts for what completions cost, per API key, so keys can be
+// held to monthly spend limits.
+//
+// OpenRouter reports each completion's token counts and, when asked, its
+// cost in USD. When it doesn't report a cost, it's worked out from the
+// model's per-token pricing in the catalog. Cached completions cost nothing
+// and aren't recorded.
+package summary
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+)
+
+// Usage is what a completion used: its tokens and what they cost.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	CostUSD          float64
+	costKnown        bool // OpenRouter reported CostUSD
+}
+
+// SpendRecorder stores what each API key's completions cost.
+type SpendRecorder interface {
+	RecordAISpend(ctx context.Context, keyID, model string, usage Usage) error
+}
+
+// SetSpendRecorder records the cost of completions made for API keys (see
+// WithSpendKey) in recorder.
+func (s *Service) SetSpendRecorder(recorder SpendRecorder) {
+	s.spend = recorder
+}
+
+// SetBudgetModel sets the model requests are downgraded to once a key
+// reaches its spend limit. Empty picks the cheapest model in the catalog.
+func (s *Service) SetBudgetModel(model string) {
+	s.budgetModel = model
+}
+
+type spendKeyKey struct{}
+
+// WithSpendKey returns a context whose completions count toward the spend
+// of API key keyID.
+func WithSpendKey(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, spendKeyKey{}, keyID)
+}
+
+// Tally adds up the usage of the completions made with a context from
+// WithTally, so a job can record what the item it produced cost.
+type Tally struct {
+	mu    sync.Mutex
+	usage Usage
+}
+
+type tallyKey struct{}
+
+// WithTally returns a context whose completions are added to t.
+func WithTally(ctx context.Context, t *Tally) context.Context {
+	return context.WithValue(ctx, tallyKey{}, t)
+}
+
+// Usage returns the total so far.
+func (t *Tally) Usage() Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.usage
+}
+
+func (t *Tally) add(u Usage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.usage.PromptTokens += u.PromptTokens
+	t.usage.CompletionTokens += u.CompletionTokens
+	t.usage.CostUSD += u.CostUSD
+}
+
+// BudgetModel returns the model to downgrade requests to once a key
+// reaches its spend limit: the configured budget model, or else the
+// cheapest priced model clients may pick. It's empty if no model has
+// known pricing.
+func (s *Service) BudgetModel(ctx context.Context) string {
+	if s.budgetModel != "" {
+		return s.budgetModel
+	}
+
+	cheapest, cheapestPrice := "", 0.0
+	for _, m := range s.Catalog(ctx) {
+		price, ok := tokenPrice(m.Pricing)
+		if ok && (cheapest == "" || price < cheapestPrice) {
+			cheapest, cheapestPrice = m.ID, price
+		}
+	}
+	return cheapest
+}
+
+// recordSpend records what a completion served by model cost, if the
+// context names an API key, and adds it to the context's Tally, if any.
+// Failures are logged; accounting never fails a request.
+func (s *Service) recordSpend(ctx context.Context, model string, usage Usage) {
+	keyID, _ := ctx.Value(spendKeyKey{}).(string)
+	tally, _ := ctx.Value(tallyKey{}).(*Tally)
+	if (s.spend == nil || keyID == "") && tally == nil {
+		return
+	}
+	if !usage.costKnown {
+		usage.CostUSD = s.estimateCost(ctx, model, usage)
+	}
+	if tally != nil {
+		tally.add(usage)
+	}
+	if s.spend == nil || keyID == "" {
+		return
+	}
+	if err := s.spend.RecordAISpend(ctx, keyID, model, usage); err != nil {
+		log.Printf("⚠️  Failed to record AI spend for key %s: %v", keyID, err)
+	}
+}
+
+// estimateCost prices usage with model's per-token pricing, or 0 if the
+// catalog doesn't have it.
+func (s *Service) estimateCost(ctx context.Context, model string, usage Usage) float64 {
+	info, ok := s.modelDetails(ctx)[model]
+	if !ok || info.Pricing == nil {
+		return 0
+	}
+	prompt, _ := strconv.ParseFloat(info.Pricing.Prompt, 64)
+	completion, _ := strconv.ParseFloat(info.Pricing.Completion, 64)
+	return float64(usage.PromptTokens)*prompt + float64(usage.CompletionTokens)*completion
+}
+
+// tokenPrice returns a rough price for comparing models: the sum of the
+// prompt and completion prices per token.
+func tokenPrice(p *ModelPricing) (float64, bool) {
+	if p == nil {
+		return 0, false
+	}
+	prompt, err1 := strconv.ParseFloat(p.Prompt, 64)
+	completion, err2 := strconv.ParseFloat(p.Completion, 64)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return prompt + completion, true
+}
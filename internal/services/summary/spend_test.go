@@ -0,0 +1,116 @@
+// spend_test.go tests per-key spend accounting and the budget model.
+package summary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// spendLog records spend in memory.
+type spendLog struct {
+	keys   []string
+	usages []Usage
+}
+
+func (l *spendLog) RecordAISpend(_ context.Context, keyID, _ string, usage Usage) error {
+	l.keys = append(l.keys, keyID)
+	l.usages = append(l.usages, usage)
+	return nil
+}
+
+// TestRecordSpend verifies completions made for a key record their tokens
+// and cost — OpenRouter's when it reports one, otherwise priced from the
+// catalog — and that completions without a key aren't recorded.
+func TestRecordSpend(t *testing.T) {
+	reported := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"data":[{"id":"primary/model","pricing":{"prompt":"0.001","completion":"0.002"}}]}`))
+			return
+		}
+		usage := `"usage":{"prompt_tokens":100,"completion_tokens":50}`
+		if reported {
+			usage = `"usage":{"prompt_tokens":100,"completion_tokens":50,"cost":0.5}`
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"ok"}}],` + usage + `}`))
+	}))
+	defer srv.Close()
+
+	s := newTestService(srv.URL)
+	s.modelsURL = srv.URL
+	log := &spendLog{}
+	s.SetSpendRecorder(log)
+	chain := s.modelChain("primary/model", nil)
+
+	if _, _, err := s.complete(context.Background(), chain, nil); err != nil {
+		t.Fatalf("complete() without a key: %v", err)
+	}
+	ctx := WithSpendKey(context.Background(), "key-1")
+	if _, _, err := s.complete(ctx, chain, nil); err != nil {
+		t.Fatalf("complete(): %v", err)
+	}
+	reported = false
+	if _, _, err := s.complete(ctx, chain, nil); err != nil {
+		t.Fatalf("complete() without a reported cost: %v", err)
+	}
+
+	if len(log.usages) != 2 || log.keys[0] != "key-1" {
+		t.Fatalf("recorded %v %+v, want two records for key-1", log.keys, log.usages)
+	}
+	if u := log.usages[0]; u.PromptTokens != 100 || u.CompletionTokens != 50 || u.CostUSD != 0.5 {
+		t.Errorf("reported usage = %+v", u)
+	}
+	if got, want := log.usages[1].CostUSD, 100*0.001+50*0.002; got != want {
+		t.Errorf("estimated cost = %v, want %v", got, want)
+	}
+}
+
+// TestTally verifies a context's tally adds up every completion made with
+// it, with or without a spend key or recorder.
+func TestTally(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices":[{"message":{"content":"ok"}}],"usage":{"prompt_tokens":100,"completion_tokens":50,"cost":0.25}}`))
+	}))
+	defer srv.Close()
+
+	s := newTestService(srv.URL)
+	chain := s.modelChain("primary/model", nil)
+
+	tally := &Tally{}
+	ctx := WithTally(context.Background(), tally)
+	for i := 0; i < 2; i++ {
+		if _, _, err := s.complete(ctx, chain, nil); err != nil {
+			t.Fatalf("complete(): %v", err)
+		}
+	}
+	if u := tally.Usage(); u.PromptTokens != 200 || u.CompletionTokens != 100 || u.CostUSD != 0.5 {
+		t.Errorf("tally = %+v, want 200 prompt and 100 completion tokens costing 0.5", u)
+	}
+}
+
+// TestBudgetModel verifies the configured budget model wins, and that
+// otherwise the cheapest priced model is picked.
+func TestBudgetModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[
+			{"id":"primary/model","pricing":{"prompt":"0.00001","completion":"0.00003"}},
+			{"id":"cheap/model","pricing":{"prompt":"0.0000001","completion":"0.0000004"}},
+			{"id":"unpriced/model"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	s := New("test-key", "primary/model")
+	s.modelsURL = srv.URL
+	s.SetAllowedModels([]string{"cheap/model", "unpriced/model"})
+
+	if got := s.BudgetModel(context.Background()); got != "cheap/model" {
+		t.Errorf("BudgetModel() = %q, want cheap/model", got)
+	}
+	s.SetBudgetModel("chosen/model")
+	if got := s.BudgetModel(context.Background()); got != "chosen/model" {
+		t.Errorf("BudgetModel() = %q, want chosen/model", got)
+	}
+}
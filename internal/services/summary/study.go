@@ -0,0 +1,133 @@
+// study.go generates study aids — flashcards and multiple-choice quizzes —
+// from lecture-style transcripts.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Flashcard is a single question/answer pair.
+type Flashcard struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// QuizQuestion is a multiple-choice question with the index of the correct option.
+type QuizQuestion struct {
+	Question    string   `json:"question"`
+	Options     []string `json:"options"`
+	AnswerIndex int      `json:"answer_index"`
+	Explanation string   `json:"explanation,omitempty"`
+}
+
+// StudyOptions configures study aid generation.
+type StudyOptions struct {
+	Model          string
+	FallbackModels []string
+	NumFlashcards  int
+	NumQuestions   int
+	Language       string // Language to write in; empty leaves it to the model
+}
+
+// StudyResult holds the generated flashcards and quiz.
+type StudyResult struct {
+	Flashcards []Flashcard    `json:"flashcards"`
+	Quiz       []QuizQuestion `json:"quiz"`
+	Model      string         `json:"model"`
+}
+
+// GenerateStudyAid creates flashcards and a multiple-choice quiz from transcript text.
+func (s *Service) GenerateStudyAid(ctx context.Context, transcriptText string, opts StudyOptions) (*StudyResult, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	log.Printf("🤖 Generating %d flashcards and %d quiz questions using %s", opts.NumFlashcards, opts.NumQuestions, model)
+
+	messages := []chatMessage{
+		{Role: "system", Content: getAudioSystemPrompt("lecture")},
+		{Role: "user", Content: buildStudyPrompt(transcriptText, opts)},
+	}
+
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.complete(ctx, s.modelChain(model, opts.FallbackModels), messages)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseStudyOutput(content)
+	if err != nil {
+		return nil, err
+	}
+	result.Model = servedBy
+	return result, nil
+}
+
+// buildStudyPrompt constructs the prompt for flashcard and quiz generation.
+func buildStudyPrompt(transcript string, opts StudyOptions) string {
+	return fmt.Sprintf(`Create study materials from the following lecture transcript.
+
+**Important:** Respond with valid JSON in this exact format:
+{
+  "flashcards": [
+    {"question": "What is X?", "answer": "X is ..."}
+  ],
+  "quiz": [
+    {"question": "Which of the following ...?", "options": ["A", "B", "C", "D"], "answer_index": 0, "explanation": "Why A is correct"}
+  ]
+}
+
+Rules:
+- Write exactly %d flashcards and %d quiz questions
+- Flashcards should test key concepts, definitions, and facts — keep answers short
+- Each quiz question must have exactly 4 options with one correct answer
+- "answer_index" is the zero-based index of the correct option
+- Only use information found in the transcript
+
+**Transcript:**
+%s`, opts.NumFlashcards, opts.NumQuestions, truncateTranscript(transcript))
+}
+
+// parseStudyOutput extracts and validates the study aid JSON from the AI response.
+// Unlike summaries there's no useful plain-text fallback, so malformed output is an error.
+func parseStudyOutput(content string) (*StudyResult, error) {
+	var structured struct {
+		Flashcards []Flashcard    `json:"flashcards"`
+		Quiz       []QuizQuestion `json:"quiz"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &structured); err != nil {
+		return nil, fmt.Errorf("model returned invalid study aid JSON: %w", err)
+	}
+
+	result := &StudyResult{
+		Flashcards: []Flashcard{},
+		Quiz:       []QuizQuestion{},
+	}
+	for _, f := range structured.Flashcards {
+		if strings.TrimSpace(f.Question) == "" || strings.TrimSpace(f.Answer) == "" {
+			continue
+		}
+		result.Flashcards = append(result.Flashcards, f)
+	}
+	// Drop quiz questions whose answer doesn't point at a real option
+	for _, q := range structured.Quiz {
+		if strings.TrimSpace(q.Question) == "" || q.AnswerIndex < 0 || q.AnswerIndex >= len(q.Options) {
+			continue
+		}
+		result.Quiz = append(result.Quiz, q)
+	}
+
+	if len(result.Flashcards) == 0 && len(result.Quiz) == 0 {
+		return nil, fmt.Errorf("model returned no usable flashcards or quiz questions")
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+// study_test.go tests parsing of model output into flashcards and quizzes.
+package summary
+
+import "testing"
+
+func TestParseStudyOutput(t *testing.T) {
+	tests := []struct {
+		name           string
+		content        string
+		wantFlashcards int
+		wantQuiz       int
+		wantErr        bool
+	}{
+		{
+			name:           "plain JSON",
+			content:        `{"flashcards":[{"question":"Q1","answer":"A1"}],"quiz":[{"question":"Q","options":["a","b","c","d"],"answer_index":2}]}`,
+			wantFlashcards: 1,
+			wantQuiz:       1,
+		},
+		{
+			name:           "wrapped in markdown fence",
+			content:        "Here you go:\n```json\n{\"flashcards\":[{\"question\":\"Q1\",\"answer\":\"A1\"},{\"question\":\"Q2\",\"answer\":\"A2\"}],\"quiz\":[]}\n```",
+			wantFlashcards: 2,
+			wantQuiz:       0,
+		},
+		{
+			name:           "drops quiz with out-of-range answer and blank cards",
+			content:        `{"flashcards":[{"question":"","answer":"A"},{"question":"Q","answer":"A"}],"quiz":[{"question":"Q","options":["a","b"],"answer_index":5}]}`,
+			wantFlashcards: 1,
+			wantQuiz:       0,
+		},
+		{
+			name:    "not JSON",
+			content: "Sorry, I can't help with that.",
+			wantErr: true,
+		},
+		{
+			name:    "valid JSON but nothing usable",
+			content: `{"flashcards":[],"quiz":[]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStudyOutput(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStudyOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Flashcards) != tt.wantFlashcards {
+				t.Errorf("flashcards = %d, want %d", len(got.Flashcards), tt.wantFlashcards)
+			}
+			if len(got.Quiz) != tt.wantQuiz {
+				t.Errorf("quiz = %d, want %d", len(got.Quiz), tt.wantQuiz)
+			}
+		})
+	}
+}
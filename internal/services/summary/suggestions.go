@@ -0,0 +1,82 @@
+// suggestions.go generates starter questions for a chat about an item, so
+// the UI has something to offer before the user has typed anything.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// maxSuggestedQuestions caps how many suggested questions are kept.
+const maxSuggestedQuestions = 5
+
+// SuggestQuestions returns 3–5 questions a reader might ask about the
+// item, and the model that wrote them.
+func (s *Service) SuggestQuestions(ctx context.Context, contextLabel, text string) ([]string, string, error) {
+	if s.apiKey == "" {
+		return nil, "", fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
+	}
+
+	log.Printf("🤖 Suggesting chat questions using %s", s.Model())
+	messages := []chatMessage{
+		{Role: "system", Content: "You suggest questions a reader could ask an assistant about a document and reply only with JSON."},
+		{Role: "user", Content: buildSuggestionsPrompt(contextLabel, text)},
+	}
+	content, model, err := s.complete(ctx, s.modelChain(s.Model(), nil), messages)
+	if err != nil {
+		return nil, "", err
+	}
+	questions, err := parseSuggestionsOutput(content)
+	if err != nil {
+		return nil, "", err
+	}
+	return questions, model, nil
+}
+
+// buildSuggestionsPrompt constructs the prompt for suggested questions.
+func buildSuggestionsPrompt(contextLabel, text string) string {
+	return fmt.Sprintf(`Suggest 3 to 5 short questions someone might ask about this %s.
+
+**Important:** Respond with valid JSON in this exact format:
+{"questions": ["What are the main takeaways?", "Who decided on the budget?"]}
+
+Rules:
+- Each question must be answerable from the text
+- Keep each question under 15 words
+- Cover different parts of the text rather than rephrasing one question
+
+**Text:**
+%s`, contextLabel, truncateTranscript(text))
+}
+
+// parseSuggestionsOutput extracts the questions from the AI response,
+// dropping blanks and duplicates and keeping at most five.
+func parseSuggestionsOutput(content string) ([]string, error) {
+	var structured struct {
+		Questions []string `json:"questions"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONObject(content)), &structured); err != nil {
+		return nil, fmt.Errorf("model returned invalid questions JSON: %w", err)
+	}
+	seen := make(map[string]bool)
+	questions := []string{}
+	for _, q := range structured.Questions {
+		q = strings.TrimSpace(q)
+		key := strings.ToLower(q)
+		if q == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		questions = append(questions, q)
+		if len(questions) == maxSuggestedQuestions {
+			break
+		}
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("model suggested no questions")
+	}
+	return questions, nil
+}
@@ -0,0 +1,59 @@
+// suggestions_test.go tests suggested chat question output parsing.
+package summary
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSuggestionsOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "plain JSON",
+			content: `{"questions":["What was decided?","Who attended?","What's next?"]}`,
+			want:    []string{"What was decided?", "Who attended?", "What's next?"},
+		},
+		{
+			name:    "wrapped in markdown fence",
+			content: "```json\n{\"questions\": [\"Why?\"]}\n```",
+			want:    []string{"Why?"},
+		},
+		{
+			name:    "drops blanks and duplicates",
+			content: `{"questions":[" Why? ","","why?","How?"]}`,
+			want:    []string{"Why?", "How?"},
+		},
+		{
+			name:    "keeps at most five",
+			content: `{"questions":["1?","2?","3?","4?","5?","6?"]}`,
+			want:    []string{"1?", "2?", "3?", "4?", "5?"},
+		},
+		{
+			name:    "no questions",
+			content: `{"questions":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			content: "Here are some questions.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSuggestionsOutput(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
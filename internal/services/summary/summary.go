@@ -6,42 +6,107 @@
 package summary
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Service handles AI summary generation.
 type Service struct {
-	apiKey     string
-	model      string
-	httpClient *http.Client
+	apiKey         string
+	model          atomic.Pointer[string] // Default model; admins can change it at runtime
+	fallbackModels []string               // Ordered models tried when the primary fails
+	maxRetries     int                    // Retries per model on 429/5xx
+	retryBackoff   time.Duration          // Base delay, doubled on each retry
+	baseURL        string
+	keyURL         string // Checked by CheckKey
+	httpClient     *http.Client
+	cache          Cache // Set by SetCache; nil disables caching
+	cacheTTL       time.Duration
+	allowedModels  []string // Set by SetAllowedModels
+	modelsURL      string   // Model details for Catalog
+	catalogMu      sync.Mutex
+	catalog        map[string]ModelInfo // Last details fetched, by ID
+	catalogFetched time.Time
+	spend          SpendRecorder // Set by SetSpendRecorder; nil skips spend accounting
+	budgetModel    string        // Set by SetBudgetModel
 }
 
 // New creates a new summary service.
 func New(apiKey, defaultModel string) *Service {
-	return &Service{
-		apiKey: apiKey,
-		model:  defaultModel,
-		// Go Pattern: Always configure timeouts on HTTP clients.
-		// The default http.Client has NO timeout — requests can hang forever!
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second, // LLMs can be slow
-		},
+	s := &Service{
+		apiKey:       apiKey,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: 1 * time.Second,
+		baseURL:      openRouterURL,
+		keyURL:       openRouterKeyURL,
+		modelsURL:    openRouterModelsURL,
+		// Go Pattern: Always bound HTTP requests — the default http.Client has
+		// NO timeout. Here the context does it: jobs pass their own deadline,
+		// and send falls back to requestTimeout for callers without one.
+		httpClient: &http.Client{},
+	}
+	s.SetModel(defaultModel)
+	return s
+}
+
+// Model returns the default model, used when a request doesn't pick one.
+func (s *Service) Model() string {
+	return *s.model.Load()
+}
+
+// SetModel changes the default model for requests that start afterwards.
+func (s *Service) SetModel(model string) {
+	s.model.Store(&model)
+}
+
+// IsConfigured returns true if the OpenRouter API key is set.
+func (s *Service) IsConfigured() bool {
+	return s.apiKey != ""
+}
+
+// CheckKey verifies OpenRouter accepts the API key, using the free key
+// info endpoint rather than a completion.
+func (s *Service) CheckKey(ctx context.Context) error {
+	if !s.IsConfigured() {
+		return fmt.Errorf("OpenRouter API key not configured")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.keyURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("OpenRouter unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("OpenRouter rejected the API key (HTTP %d)", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("OpenRouter returned HTTP %d", resp.StatusCode)
+	}
+	return nil
 }
 
 // Options configures how the summary should be generated.
 type Options struct {
-	Model       string // Override the default model
-	Length      string // "short", "medium", "detailed"
-	Style       string // "bullet", "narrative", "academic"
-	ContentType string // "general", "phone_call", "meeting", "voice_memo", "interview", "lecture" (MTA-24)
+	Model          string   // Override the default model
+	FallbackModels []string // Override the configured fallback chain (nil = use default)
+	Length         string   // "short", "medium", "detailed"
+	Style          string   // "bullet", "narrative", "academic"
+	ContentType    string   // "general", "phone_call", "meeting", "voice_memo", "interview", "lecture" (MTA-24)
+	Language       string   // Language to write in, e.g. "French"; empty leaves it to the model
+	Timestamps     bool     // Chat: the text is "[m:ss] ..." lines, and answers cite them
 }
 
 // AudioResult holds the structured output from an audio transcription summary (MTA-22).
@@ -67,6 +132,12 @@ type Result struct {
 type chatRequest struct {
 	Model    string        `json:"model"`
 	Messages []chatMessage `json:"messages"`
+	Usage    *usageRequest `json:"usage,omitempty"`
+}
+
+// usageRequest asks OpenRouter to report what a completion cost.
+type usageRequest struct {
+	Include bool `json:"include"`
 }
 
 type chatMessage struct {
@@ -81,6 +152,11 @@ type chatResponse struct {
 		} `json:"message"`
 	} `json:"choices"`
 	Model string `json:"model"`
+	Usage *struct {
+		PromptTokens     int      `json:"prompt_tokens"`
+		CompletionTokens int      `json:"completion_tokens"`
+		Cost             *float64 `json:"cost"` // USD, when OpenRouter reports it
+	} `json:"usage"`
 	Error *struct {
 		Message string `json:"message"`
 		Code    int    `json:"code"`
@@ -100,7 +176,7 @@ func (s *Service) Summarize(ctx context.Context, transcriptText string, opts Opt
 	}
 
 	// Use provided model or fall back to default
-	model := s.model
+	model := s.Model()
 	if opts.Model != "" {
 		model = opts.Model
 	}
@@ -118,99 +194,66 @@ func (s *Service) Summarize(ctx context.Context, transcriptText string, opts Opt
 
 	log.Printf("🤖 Generating %s %s summary using %s", opts.Length, opts.Style, model)
 
-	// Make the API request
-	reqBody := chatRequest{
-		Model: model,
-		Messages: []chatMessage{
-			{
-				Role:    "system",
-				Content: "You are a precise and insightful content summarizer. You extract key information from video transcripts and present it clearly.",
-			},
-			{
-				Role:    "user",
-				Content: prompt,
-			},
+	messages := []chatMessage{
+		{
+			Role:    "system",
+			Content: "You are a precise and insightful content summarizer. You extract key information from video transcripts and present it clearly.",
+		},
+		{
+			Role:    "user",
+			Content: prompt,
 		},
 	}
 
-	jsonBody, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Build the HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST",
-		"https://openrouter.ai/api/v1/chat/completions",
-		bytes.NewReader(jsonBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("HTTP-Referer", "https://github.com/Shimizu-Technology/media-tools-api")
-	req.Header.Set("X-Title", "Media Tools API")
-
-	// Send the request
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("OpenRouter request failed: %w", err)
-	}
-	defer resp.Body.Close() // Go Pattern: ALWAYS close response bodies!
-
-	body, err := io.ReadAll(resp.Body)
+	// Send the request, retrying and falling back to other models as needed
+	messages = withLanguage(messages, opts.Language)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("OpenRouter returned %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
 
-	// Parse the response
-	var chatResp chatResponse
-	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if chatResp.Error != nil {
-		return nil, fmt.Errorf("OpenRouter error: %s", chatResp.Error.Message)
-	}
-
-	if len(chatResp.Choices) == 0 {
-		return nil, fmt.Errorf("no response from model")
-	}
-
-	content := chatResp.Choices[0].Message.Content
-
 	// Try to parse structured output (JSON with summary + key_points)
 	result := parseStructuredOutput(content)
-	result.Model = model
+	result.Model = servedBy
 	result.Prompt = prompt
 
 	return result, nil
 }
 
 // ChatTranscript answers a user question using transcript context.
-func (s *Service) ChatTranscript(ctx context.Context, contextLabel, transcriptText string, messages []ChatMessage, modelOverride string) (string, string, error) {
+// historySummary condenses turns older than messages, if there are any.
+// Only the model, fallback, and language options apply. The second return
+// value is the model that actually served the answer, which may be a
+// fallback if the requested model failed.
+func (s *Service) ChatTranscript(ctx context.Context, contextLabel, transcriptText, historySummary string, messages []ChatMessage, opts Options) (string, string, error) {
 	if s.apiKey == "" {
 		return "", "", fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
 	}
 
-	model := s.model
-	if modelOverride != "" {
-		model = modelOverride
+	model := s.Model()
+	if opts.Model != "" {
+		model = opts.Model
 	}
 
 	systemPrompt := "You are a helpful assistant that answers questions about a " + contextLabel + ". " +
 		"Only use information from the content. If the answer is not in the content, say you don't know."
+	if opts.Timestamps {
+		systemPrompt += " Each line of the content starts with the timestamp it's spoken at, like [12:34]. " +
+			"After each statement in your answer, cite the timestamp of the line it comes from in the same " +
+			"square-bracket form, e.g. [12:34]."
+	}
 	transcriptContext := buildTranscriptContext(transcriptText)
 
 	reqMessages := []chatMessage{
 		{Role: "system", Content: systemPrompt},
 		{Role: "system", Content: transcriptContext},
 	}
+	if historySummary != "" {
+		reqMessages = append(reqMessages, chatMessage{
+			Role:    "system",
+			Content: "Summary of the earlier conversation:\n" + historySummary,
+		})
+	}
 	for _, msg := range messages {
 		if msg.Content == "" {
 			continue
@@ -221,57 +264,42 @@ func (s *Service) ChatTranscript(ctx context.Context, contextLabel, transcriptTe
 		reqMessages = append(reqMessages, chatMessage{Role: msg.Role, Content: msg.Content})
 	}
 
-	reqBody := chatRequest{
-		Model:    model,
-		Messages: reqMessages,
-	}
-
-	jsonBody, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to marshal request: %w", err)
-	}
+	reqMessages = withLanguage(reqMessages, opts.Language)
+	return s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), reqMessages)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST",
-		"https://openrouter.ai/api/v1/chat/completions",
-		bytes.NewReader(jsonBody),
-	)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to create request: %w", err)
+// CondenseChat folds older chat turns into a running summary of the
+// conversation, so long sessions can keep their context in a bounded
+// prompt. previousSummary is the summary so far, if any.
+func (s *Service) CondenseChat(ctx context.Context, previousSummary string, messages []ChatMessage) (string, error) {
+	if s.apiKey == "" {
+		return "", fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
 	}
 
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("HTTP-Referer", "https://github.com/Shimizu-Technology/media-tools-api")
-	req.Header.Set("X-Title", "Media Tools API")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return "", "", fmt.Errorf("OpenRouter request failed: %w", err)
+	var b strings.Builder
+	if previousSummary != "" {
+		b.WriteString("Summary so far:\n" + previousSummary + "\n\n")
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to read response: %w", err)
+	b.WriteString("Conversation to add:\n")
+	for _, msg := range messages {
+		b.WriteString(msg.Role + ": " + msg.Content + "\n\n")
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", "", fmt.Errorf("OpenRouter returned %d: %s", resp.StatusCode, string(body))
+	reqMessages := []chatMessage{
+		{
+			Role: "system",
+			Content: "You condense chat history. Rewrite the summary so far to also cover the new conversation, " +
+				"keeping the questions asked, the answers given, and any facts, names, or preferences the user " +
+				"stated. Write compact prose of at most 300 words with no preamble.",
+		},
+		{Role: "user", Content: b.String()},
 	}
 
-	var chatResp chatResponse
-	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return "", "", fmt.Errorf("failed to parse response: %w", err)
-	}
-	if chatResp.Error != nil {
-		return "", "", fmt.Errorf("OpenRouter error: %s", chatResp.Error.Message)
-	}
-	if len(chatResp.Choices) == 0 {
-		return "", "", fmt.Errorf("no response from model")
+	content, _, err := s.complete(ctx, s.modelChain(s.Model(), nil), reqMessages)
+	if err != nil {
+		return "", err
 	}
-
-	content := chatResp.Choices[0].Message.Content
-	return content, model, nil
+	return strings.TrimSpace(content), nil
 }
 
 // SummarizeAudio generates a structured summary of audio transcription text (MTA-22).
@@ -281,7 +309,7 @@ func (s *Service) SummarizeAudio(ctx context.Context, transcriptText string, opt
 		return nil, fmt.Errorf("OpenRouter API key not configured; set OPENROUTER_API_KEY")
 	}
 
-	model := s.model
+	model := s.Model()
 	if opts.Model != "" {
 		model = opts.Model
 	}
@@ -297,63 +325,19 @@ func (s *Service) SummarizeAudio(ctx context.Context, transcriptText string, opt
 
 	log.Printf("🤖 Generating %s audio summary (%s) using %s", opts.Length, opts.ContentType, model)
 
-	reqBody := chatRequest{
-		Model: model,
-		Messages: []chatMessage{
-			{Role: "system", Content: systemPrompt},
-			{Role: "user", Content: prompt},
-		},
-	}
-
-	jsonBody, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST",
-		"https://openrouter.ai/api/v1/chat/completions",
-		bytes.NewReader(jsonBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("HTTP-Referer", "https://github.com/Shimizu-Technology/media-tools-api")
-	req.Header.Set("X-Title", "Media Tools API")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("OpenRouter request failed: %w", err)
+	messages := []chatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: prompt},
 	}
-	defer resp.Body.Close()
+	messages = withLanguage(messages, opts.Language)
 
-	body, err := io.ReadAll(resp.Body)
+	content, servedBy, err := s.cachedComplete(ctx, s.modelChain(model, opts.FallbackModels), messages)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("OpenRouter returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	var chatResp chatResponse
-	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, err
 	}
 
-	if chatResp.Error != nil {
-		return nil, fmt.Errorf("OpenRouter error: %s", chatResp.Error.Message)
-	}
-
-	if len(chatResp.Choices) == 0 {
-		return nil, fmt.Errorf("no response from model")
-	}
-
-	content := chatResp.Choices[0].Message.Content
 	result := parseAudioOutput(content)
-	result.Model = model
+	result.Model = servedBy
 
 	return result, nil
 }
@@ -598,3 +582,52 @@ func parseStructuredOutput(content string) *Result {
 		KeyPoints: []string{},
 	}
 }
+
+// withLanguage asks for replies in language, if set, with an instruction
+// after the leading system messages. JSON keys and fixed values such as
+// sentiments stay as the prompt gives them, so parsing still works.
+func withLanguage(messages []chatMessage, language string) []chatMessage {
+	if language == "" {
+		return messages
+	}
+	i := 0
+	for i < len(messages) && messages[i].Role == "system" {
+		i++
+	}
+	instruction := chatMessage{
+		Role:    "system",
+		Content: fmt.Sprintf("Write your reply in %s, except JSON keys and values the prompt limits to a fixed list, which stay exactly as given.", language),
+	}
+	return append(messages[:i:i], append([]chatMessage{instruction}, messages[i:]...)...)
+}
+
+// truncateTranscript caps transcript text to keep prompts within model token limits.
+func truncateTranscript(transcript string) string {
+	const maxLen = 15000
+	if len(transcript) > maxLen {
+		return transcript[:maxLen] + "\n\n[Transcript truncated due to length...]"
+	}
+	return transcript
+}
+
+// extractJSONObject returns the first balanced { ... } block in content.
+// Models often wrap JSON in markdown fences or add a sentence before it.
+// Returns content unchanged if no object is found.
+func extractJSONObject(content string) string {
+	start := -1
+	braceCount := 0
+	for i, c := range content {
+		if c == '{' {
+			if braceCount == 0 {
+				start = i
+			}
+			braceCount++
+		} else if c == '}' && braceCount > 0 {
+			braceCount--
+			if braceCount == 0 {
+				return content[start : i+1]
+			}
+		}
+	}
+	return content
+}
@@ -0,0 +1,226 @@
+// Package tasks pushes action items to external task managers
+// (Todoist, Linear, and GitHub Issues).
+//
+// Each provider is a small function that creates ONE task over HTTP. Push
+// loops over the items and records a result per item, so one bad item
+// doesn't stop the rest from being created.
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// Supported providers.
+const (
+	ProviderTodoist = "todoist"
+	ProviderLinear  = "linear"
+	ProviderGitHub  = "github"
+)
+
+// ValidProviders for validation.
+var ValidProviders = map[string]bool{
+	ProviderTodoist: true,
+	ProviderLinear:  true,
+	ProviderGitHub:  true,
+}
+
+// Task is a single item to create in the external system.
+type Task struct {
+	Title       string
+	Description string
+}
+
+// Client sends tasks to external providers.
+type Client struct {
+	httpClient *http.Client
+	todoistURL string
+	linearURL  string
+	githubURL  string
+}
+
+// New creates a task client pointing at the public provider APIs.
+func New() *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		todoistURL: "https://api.todoist.com/rest/v2",
+		linearURL:  "https://api.linear.app/graphql",
+		githubURL:  "https://api.github.com",
+	}
+}
+
+// ValidateConfig checks that the provider-specific settings are present.
+func ValidateConfig(provider string, config map[string]string) error {
+	switch provider {
+	case ProviderGitHub:
+		parts := strings.Split(config["repo"], "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("github requires config.repo in the form \"owner/name\"")
+		}
+	case ProviderLinear:
+		if config["team_id"] == "" {
+			return fmt.Errorf("linear requires config.team_id")
+		}
+	case ProviderTodoist:
+		// project_id is optional — tasks land in the Inbox without it
+	default:
+		return fmt.Errorf("unsupported provider %q", provider)
+	}
+	return nil
+}
+
+// Push creates each task with the given provider and returns one result per task.
+func (c *Client) Push(ctx context.Context, provider, token string, config map[string]string, items []Task) []models.PushedActionItem {
+	results := make([]models.PushedActionItem, 0, len(items))
+	for _, item := range items {
+		var (
+			id, url string
+			err     error
+		)
+		switch provider {
+		case ProviderTodoist:
+			id, url, err = c.createTodoistTask(ctx, token, config, item)
+		case ProviderLinear:
+			id, url, err = c.createLinearIssue(ctx, token, config, item)
+		case ProviderGitHub:
+			id, url, err = c.createGitHubIssue(ctx, token, config, item)
+		default:
+			err = fmt.Errorf("unsupported provider %q", provider)
+		}
+
+		result := models.PushedActionItem{Title: item.Title, ExternalID: id, URL: url}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// createTodoistTask creates a task via the Todoist REST API.
+func (c *Client) createTodoistTask(ctx context.Context, token string, config map[string]string, item Task) (string, string, error) {
+	body := map[string]string{"content": item.Title, "description": item.Description}
+	if config["project_id"] != "" {
+		body["project_id"] = config["project_id"]
+	}
+
+	var resp struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	err := c.postJSON(ctx, c.todoistURL+"/tasks", map[string]string{"Authorization": "Bearer " + token}, body, &resp)
+	return resp.ID, resp.URL, err
+}
+
+// createLinearIssue creates an issue via Linear's GraphQL API.
+func (c *Client) createLinearIssue(ctx context.Context, token string, config map[string]string, item Task) (string, string, error) {
+	body := map[string]interface{}{
+		"query": `mutation IssueCreate($input: IssueCreateInput!) {
+			issueCreate(input: $input) { success issue { identifier url } }
+		}`,
+		"variables": map[string]interface{}{
+			"input": map[string]string{
+				"teamId":      config["team_id"],
+				"title":       item.Title,
+				"description": item.Description,
+			},
+		},
+	}
+
+	var resp struct {
+		Data struct {
+			IssueCreate struct {
+				Success bool `json:"success"`
+				Issue   struct {
+					Identifier string `json:"identifier"`
+					URL        string `json:"url"`
+				} `json:"issue"`
+			} `json:"issueCreate"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	// Linear personal API keys are sent as-is, without a "Bearer" prefix
+	if err := c.postJSON(ctx, c.linearURL, map[string]string{"Authorization": token}, body, &resp); err != nil {
+		return "", "", err
+	}
+	// GraphQL reports errors in the body with a 200 status
+	if len(resp.Errors) > 0 {
+		return "", "", fmt.Errorf("linear: %s", resp.Errors[0].Message)
+	}
+	if !resp.Data.IssueCreate.Success {
+		return "", "", fmt.Errorf("linear: issue was not created")
+	}
+	return resp.Data.IssueCreate.Issue.Identifier, resp.Data.IssueCreate.Issue.URL, nil
+}
+
+// createGitHubIssue opens an issue via the GitHub REST API.
+func (c *Client) createGitHubIssue(ctx context.Context, token string, config map[string]string, item Task) (string, string, error) {
+	body := map[string]string{"title": item.Title, "body": item.Description}
+	headers := map[string]string{
+		"Authorization": "Bearer " + token,
+		"Accept":        "application/vnd.github+json",
+	}
+
+	var resp struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	err := c.postJSON(ctx, c.githubURL+"/repos/"+config["repo"]+"/issues", headers, body, &resp)
+	if err != nil {
+		return "", "", err
+	}
+	return fmt.Sprintf("#%d", resp.Number), resp.HTMLURL, nil
+}
+
+// postJSON sends a JSON body and decodes a JSON response into out.
+// Any non-2xx status is returned as an error including the response body.
+func (c *Client) postJSON(ctx context.Context, url string, headers map[string]string, body, out interface{}) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MediaToolsAPI/1.0")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read a bounded amount — we only need IDs and URLs back
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(respBody))
+		if len(msg) > 300 {
+			msg = msg[:300] + "..."
+		}
+		return fmt.Errorf("provider returned %d: %s", resp.StatusCode, msg)
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// tasks_test.go verifies each provider's request shape against a fake API.
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPush(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/todoist/tasks":
+			if r.Header.Get("Authorization") != "Bearer tok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"id":"123","url":"https://todoist.com/showTask?id=123"}`))
+		case "/linear":
+			if r.Header.Get("Authorization") != "tok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"data":{"issueCreate":{"success":true,"issue":{"identifier":"ENG-1","url":"https://linear.app/x/issue/ENG-1"}}}}`))
+		case "/github/repos/acme/app/issues":
+			if body["title"] == "fail me" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"message":"Validation Failed"}`))
+				return
+			}
+			w.Write([]byte(`{"number":42,"html_url":"https://github.com/acme/app/issues/42"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := New()
+	c.todoistURL = srv.URL + "/todoist"
+	c.linearURL = srv.URL + "/linear"
+	c.githubURL = srv.URL + "/github"
+
+	tests := []struct {
+		name     string
+		provider string
+		config   map[string]string
+		items    []Task
+		wantIDs  []string
+		wantErrs []bool
+	}{
+		{"todoist", ProviderTodoist, nil, []Task{{Title: "Call Bob"}}, []string{"123"}, []bool{false}},
+		{"linear", ProviderLinear, map[string]string{"team_id": "t1"}, []Task{{Title: "Fix bug"}}, []string{"ENG-1"}, []bool{false}},
+		{
+			name:     "github with partial failure",
+			provider: ProviderGitHub,
+			config:   map[string]string{"repo": "acme/app"},
+			items:    []Task{{Title: "Ship it"}, {Title: "fail me"}},
+			wantIDs:  []string{"#42", ""},
+			wantErrs: []bool{false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := c.Push(context.Background(), tt.provider, "tok", tt.config, tt.items)
+			if len(results) != len(tt.items) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.items))
+			}
+			for i, r := range results {
+				if r.ExternalID != tt.wantIDs[i] {
+					t.Errorf("result %d id = %q, want %q", i, r.ExternalID, tt.wantIDs[i])
+				}
+				if (r.Error != "") != tt.wantErrs[i] {
+					t.Errorf("result %d error = %q, wantErr %v", i, r.Error, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		provider string
+		config   map[string]string
+		wantErr  bool
+	}{
+		{ProviderTodoist, nil, false},
+		{ProviderLinear, map[string]string{"team_id": "abc"}, false},
+		{ProviderLinear, nil, true},
+		{ProviderGitHub, map[string]string{"repo": "owner/name"}, false},
+		{ProviderGitHub, map[string]string{"repo": "owner"}, true},
+		{ProviderGitHub, map[string]string{"repo": "owner/name/extra"}, true},
+		{"jira", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			if err := ValidateConfig(tt.provider, tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig(%q, %v) error = %v, wantErr %v", tt.provider, tt.config, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
-	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 )
 
 // Extractor defines the interface for transcript extraction.
@@ -38,6 +40,7 @@ type Result struct {
 	Language     string
 	Transcript   string
 	WordCount    int
+	Segments     []models.Segment // Timed cues from the captions or Whisper; nil if unavailable
 }
 
 // WhisperResult holds the output from a Whisper API call.
@@ -45,6 +48,7 @@ type WhisperResult struct {
 	Text     string
 	Language string
 	Duration float64
+	Segments []models.Segment
 }
 
 // WhisperTranscriber is an interface for audio transcription (used as fallback).
@@ -82,6 +86,15 @@ func (e *YtDlpExtractor) SetWhisperFallback(w WhisperTranscriber) {
 	e.whisper = w
 }
 
+// Version runs yt-dlp --version, confirming the binary is installed and runs.
+func (e *YtDlpExtractor) Version(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, e.ytDlpPath, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("yt-dlp not runnable: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // buildBaseArgs returns the common yt-dlp arguments including proxy if configured.
 func (e *YtDlpExtractor) buildBaseArgs() []string {
 	args := []string{
@@ -125,7 +138,7 @@ func (e *YtDlpExtractor) Extract(ctx context.Context, videoID string) (*Result,
 	// Step 2: Try subtitle extraction first
 	if metadataErr == nil {
 		log.Printf("📝 Extracting transcript for: %s", metadata.Title)
-		transcript, lang, err := e.getTranscript(ctx, url)
+		transcript, segments, lang, err := e.getTranscript(ctx, url)
 		if err == nil {
 			// Success! Clean up and return
 			cleaned := cleanTranscript(transcript)
@@ -138,6 +151,7 @@ func (e *YtDlpExtractor) Extract(ctx context.Context, videoID string) (*Result,
 				Language:    lang,
 				Transcript:  cleaned,
 				WordCount:   wordCount,
+				Segments:    segments,
 			}, nil
 		}
 		log.Printf("⚠️  Subtitle extraction failed: %v", err)
@@ -170,9 +184,9 @@ func (e *YtDlpExtractor) extractWithWhisper(ctx context.Context, url, videoID st
 	audioPath := filepath.Join(tmpDir, "audio.mp3")
 
 	// Download audio using yt-dlp
+	// The download and transcription are bounded by the job's deadline; long
+	// lectures need more than any fixed limit here would allow.
 	log.Printf("📥 Downloading audio for Whisper transcription...")
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-	defer cancel()
 
 	// Build command with base args (includes proxy if configured)
 	args := e.buildBaseArgs()
@@ -244,6 +258,7 @@ func (e *YtDlpExtractor) extractWithWhisper(ctx context.Context, url, videoID st
 		Language:    result.Language,
 		Transcript:  cleaned,
 		WordCount:   wordCount,
+		Segments:    result.Segments,
 	}, nil
 }
 
@@ -289,17 +304,15 @@ func (e *YtDlpExtractor) getMetadata(ctx context.Context, url string) (*ytDlpMet
 }
 
 // getTranscript extracts the subtitle text using yt-dlp.
-// Returns the transcript text and the language code.
-func (e *YtDlpExtractor) getTranscript(ctx context.Context, url string) (string, string, error) {
-	// Go Pattern: We use a context with timeout to prevent hanging processes.
-	ctx, cancel := context.WithTimeout(ctx, 90*time.Second)
-	defer cancel() // Always call cancel to release resources
-
+// Returns the transcript text, its timed cues, and the language code.
+func (e *YtDlpExtractor) getTranscript(ctx context.Context, url string) (string, []models.Segment, string, error) {
+	// Go Pattern: exec.CommandContext kills yt-dlp once ctx is done, so the
+	// job's deadline keeps it from hanging.
 	// Go Pattern: os.MkdirTemp creates a unique temporary directory.
 	// This is safer than writing to /tmp directly — no filename collisions.
 	tmpDir, err := os.MkdirTemp("", "mta-subs-*")
 	if err != nil {
-		return "", "", fmt.Errorf("failed to create temp directory: %w", err)
+		return "", nil, "", fmt.Errorf("failed to create temp directory: %w", err)
 	}
 	defer os.RemoveAll(tmpDir) // Clean up when done, no matter what
 
@@ -356,11 +369,11 @@ func (e *YtDlpExtractor) getTranscript(ctx context.Context, url string) (string,
 
 		text := parseVTT(string(content))
 		if text != "" {
-			return text, lang, nil
+			return text, parseVTTCues(string(content)), lang, nil
 		}
 	}
 
-	return "", "", fmt.Errorf("no subtitles available for this video")
+	return "", nil, "", fmt.Errorf("no subtitles available for this video")
 }
 
 // parseVTT extracts plain text from a WebVTT subtitle file.
@@ -410,6 +423,82 @@ func parseVTT(vtt string) string {
 	return strings.Join(textLines, " ")
 }
 
+// cueTimingRegex matches a cue timing line such as
+// "00:00:01.000 --> 00:00:04.000 align:start position:0%". Hours are optional
+// in WebVTT, and SRT files use a comma before the milliseconds.
+var cueTimingRegex = regexp.MustCompile(`^((?:\d+:)?\d{2}:\d{2}[.,]\d{3})\s+-->\s+((?:\d+:)?\d{2}:\d{2}[.,]\d{3})`)
+
+// parseVTTCues extracts timed cues from a WebVTT (or SRT) subtitle file.
+//
+// YouTube's auto-generated captions "roll": each line appears in two or
+// three consecutive cues. Like parseVTT, we keep only the first appearance
+// of each line, so every cue holds the text that was new at that moment and
+// cues with nothing new are dropped.
+func parseVTTCues(vtt string) []models.Segment {
+	tagRegex := regexp.MustCompile(`<[^>]+>`)
+	seen := make(map[string]bool)
+	var segments []models.Segment
+
+	var current *models.Segment
+	var lines []string
+	flush := func() {
+		if current != nil {
+			text := cleanTranscript(strings.Join(lines, " "))
+			if text != "" {
+				current.Text = text
+				segments = append(segments, *current)
+			}
+		}
+		current, lines = nil, nil
+	}
+
+	for _, raw := range strings.Split(strings.ReplaceAll(vtt, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+
+		if m := cueTimingRegex.FindStringSubmatch(line); m != nil {
+			flush()
+			start, startErr := parseCueTime(m[1])
+			end, endErr := parseCueTime(m[2])
+			if startErr == nil && endErr == nil {
+				current = &models.Segment{Start: start, End: end}
+			}
+			continue
+		}
+		if raw == "" {
+			flush() // A truly blank line ends the cue
+			continue
+		}
+		// Skip YouTube's whitespace-only padding lines (not cue breaks), plus
+		// anything outside a cue: the header, NOTE/STYLE blocks, cue identifiers
+		if line == "" || current == nil {
+			continue
+		}
+
+		line = strings.TrimSpace(tagRegex.ReplaceAllString(line, ""))
+		if line != "" && !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	return segments
+}
+
+// parseCueTime converts "HH:MM:SS.mmm" or "MM:SS.mmm" to seconds.
+func parseCueTime(ts string) (float64, error) {
+	parts := strings.Split(strings.Replace(ts, ",", ".", 1), ":")
+	var total float64
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, err
+		}
+		total = total*60 + v
+	}
+	return total, nil
+}
+
 // cleanTranscript normalizes whitespace and cleans up common transcript artifacts.
 func cleanTranscript(text string) string {
 	// Remove common auto-caption artifacts FIRST (before collapsing whitespace)
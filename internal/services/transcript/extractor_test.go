@@ -8,7 +8,10 @@
 package transcript
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 )
 
 // TestParseYouTubeURL tests all supported YouTube URL formats.
@@ -204,6 +207,63 @@ Test content`,
 	}
 }
 
+// TestParseVTTCues tests that cue timings survive parsing.
+func TestParseVTTCues(t *testing.T) {
+	tests := []struct {
+		name string
+		vtt  string
+		want []models.Segment
+	}{
+		{
+			name: "simple cues",
+			vtt: `WEBVTT
+
+00:00:01.000 --> 00:00:04.000
+Hello, welcome to the video.
+
+00:00:04.500 --> 00:01:08.250
+Today we talk about Go.`,
+			want: []models.Segment{
+				{Start: 1, End: 4, Text: "Hello, welcome to the video."},
+				{Start: 4.5, End: 68.25, Text: "Today we talk about Go."},
+			},
+		},
+		{
+			name: "rolling auto-captions keep only new lines",
+			// YouTube pads cues with lines holding a single space
+			vtt: "WEBVTT\nKind: captions\nLanguage: en\n\n" +
+				"00:00:00.000 --> 00:00:02.350 align:start position:0%\n \nhello<00:00:00.320><c> world</c>\n\n" +
+				"00:00:02.350 --> 00:00:02.360 align:start position:0%\nhello world\n \n\n" +
+				"00:00:02.360 --> 00:00:05.000 align:start position:0%\nhello world\nthis is [Music] new\n",
+			want: []models.Segment{
+				{Start: 0, End: 2.35, Text: "hello world"},
+				{Start: 2.36, End: 5, Text: "this is new"},
+			},
+		},
+		{
+			name: "cue identifiers, short timestamps, and SRT commas",
+			vtt: `1
+00:01.500 --> 00:03,000
+Short form`,
+			want: []models.Segment{{Start: 1.5, End: 3, Text: "Short form"}},
+		},
+		{
+			name: "empty VTT",
+			vtt:  "WEBVTT",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseVTTCues(tt.vtt)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseVTTCues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestCleanTranscript tests transcript text cleanup.
 func TestCleanTranscript(t *testing.T) {
 	tests := []struct {
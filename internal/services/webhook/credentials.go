@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+)
+
+// Limits on a webhook's custom headers.
+const (
+	MaxCustomHeaders     = 20
+	MaxHeaderValueLength = 4096
+)
+
+// reservedHeaders are set by the delivery itself and can't be overridden.
+var reservedHeaders = map[string]bool{
+	"Content-Type":                 true,
+	"Content-Length":               true,
+	"Host":                         true,
+	"User-Agent":                   true,
+	"Connection":                   true,
+	"Transfer-Encoding":            true,
+	"X-Webhook-Signature":          true,
+	"X-Webhook-Signature-Previous": true,
+}
+
+// SetSecretBox sets the box webhook credentials are sealed with. Without
+// one, webhooks can't have custom headers or basic auth.
+func (s *Service) SetSecretBox(box *secretbox.Box) {
+	s.box = box
+}
+
+// SetCredentials seals creds into a webhook and records which headers and
+// whether basic auth are set. Empty creds clear them. It returns
+// secretbox.ErrNoKey if there are creds to store but no box is set.
+func (s *Service) SetCredentials(wh *models.Webhook, creds models.WebhookCredentials) error {
+	wh.HeaderNames = []string{}
+	for name := range creds.Headers {
+		wh.HeaderNames = append(wh.HeaderNames, name)
+	}
+	sort.Strings(wh.HeaderNames)
+	wh.HasBasicAuth = creds.BasicAuth != nil
+
+	if len(creds.Headers) == 0 && creds.BasicAuth == nil {
+		wh.Credentials = ""
+		return nil
+	}
+	if s.box == nil {
+		return secretbox.ErrNoKey
+	}
+	credsJSON, err := json.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook credentials: %w", err)
+	}
+	sealed, err := s.box.Seal(credsJSON)
+	if err != nil {
+		return err
+	}
+	wh.Credentials = sealed
+	return nil
+}
+
+// Credentials opens a webhook's sealed credentials.
+func (s *Service) Credentials(wh models.Webhook) (models.WebhookCredentials, error) {
+	var creds models.WebhookCredentials
+	if wh.Credentials == "" {
+		return creds, nil
+	}
+	if s.box == nil {
+		return creds, secretbox.ErrNoKey
+	}
+	credsJSON, err := s.box.Open(wh.Credentials)
+	if err != nil {
+		return creds, err
+	}
+	if err := json.Unmarshal(credsJSON, &creds); err != nil {
+		return creds, fmt.Errorf("invalid webhook credentials: %w", err)
+	}
+	return creds, nil
+}
+
+// NormalizeCredentials canonicalizes header names (x-api-key becomes
+// X-Api-Key) and checks the headers and basic auth can be sent. The error
+// is meant for the client.
+func NormalizeCredentials(creds *models.WebhookCredentials) error {
+	if len(creds.Headers) > MaxCustomHeaders {
+		return fmt.Errorf("headers can list at most %d headers", MaxCustomHeaders)
+	}
+	headers := make(map[string]string, len(creds.Headers))
+	for name, value := range creds.Headers {
+		if !validHeaderName(name) {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		canonical := http.CanonicalHeaderKey(name)
+		if reservedHeaders[canonical] {
+			return fmt.Errorf("header %s is set by the delivery and can't be overridden", canonical)
+		}
+		if _, dup := headers[canonical]; dup {
+			return fmt.Errorf("header %s is listed more than once", canonical)
+		}
+		if value == "" || len(value) > MaxHeaderValueLength || strings.ContainsAny(value, "\r\n\x00") {
+			return fmt.Errorf("header %s must have a value of at most %d characters on one line", canonical, MaxHeaderValueLength)
+		}
+		headers[canonical] = value
+	}
+	if len(headers) == 0 {
+		headers = nil
+	}
+	creds.Headers = headers
+
+	if ba := creds.BasicAuth; ba != nil {
+		if ba.Username == "" || strings.Contains(ba.Username, ":") {
+			return fmt.Errorf("basic_auth username is required and can't contain a colon")
+		}
+		if len(ba.Username) > 255 || len(ba.Password) > 1024 {
+			return fmt.Errorf("basic_auth username or password is too long")
+		}
+		if _, ok := headers["Authorization"]; ok {
+			return fmt.Errorf("set an Authorization header or basic_auth, not both")
+		}
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is an HTTP token.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > 0x7e || r <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+)
+
+func TestNormalizeCredentials(t *testing.T) {
+	basic := &models.WebhookBasicAuth{Username: "hooks", Password: "pw"}
+	tests := []struct {
+		name  string
+		creds models.WebhookCredentials
+		ok    bool
+	}{
+		{"empty", models.WebhookCredentials{}, true},
+		{"headers", models.WebhookCredentials{Headers: map[string]string{"authorization": "Bearer t", "X-Gateway-Key": "k"}}, true},
+		{"basic auth", models.WebhookCredentials{Headers: map[string]string{"X-Gateway-Key": "k"}, BasicAuth: basic}, true},
+		{"reserved", models.WebhookCredentials{Headers: map[string]string{"x-webhook-signature": "forged"}}, false},
+		{"content type", models.WebhookCredentials{Headers: map[string]string{"Content-Type": "text/plain"}}, false},
+		{"bad name", models.WebhookCredentials{Headers: map[string]string{"X Key": "k"}}, false},
+		{"newline", models.WebhookCredentials{Headers: map[string]string{"X-Key": "k\r\nX-Other: v"}}, false},
+		{"empty value", models.WebhookCredentials{Headers: map[string]string{"X-Key": ""}}, false},
+		{"duplicate", models.WebhookCredentials{Headers: map[string]string{"x-key": "a", "X-Key": "b"}}, false},
+		{"both auths", models.WebhookCredentials{Headers: map[string]string{"Authorization": "Bearer t"}, BasicAuth: basic}, false},
+		{"no username", models.WebhookCredentials{BasicAuth: &models.WebhookBasicAuth{Password: "pw"}}, false},
+		{"colon", models.WebhookCredentials{BasicAuth: &models.WebhookBasicAuth{Username: "a:b"}}, false},
+	}
+	for _, tt := range tests {
+		err := NormalizeCredentials(&tt.creds)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: NormalizeCredentials = %v", tt.name, err)
+		}
+	}
+
+	creds := models.WebhookCredentials{Headers: map[string]string{"x-gateway-key": "k"}}
+	if err := NormalizeCredentials(&creds); err != nil || creds.Headers["X-Gateway-Key"] != "k" {
+		t.Errorf("headers not canonicalized: %v, %v", creds.Headers, err)
+	}
+}
+
+func TestCredentialsDelivered(t *testing.T) {
+	s := New(nil)
+	wh := &models.Webhook{}
+	creds := models.WebhookCredentials{
+		Headers:   map[string]string{"X-Gateway-Key": "gw-123", "X-Tenant": "acme"},
+		BasicAuth: &models.WebhookBasicAuth{Username: "hooks", Password: "pw"},
+	}
+	if err := s.SetCredentials(wh, creds); !errors.Is(err, secretbox.ErrNoKey) {
+		t.Fatalf("SetCredentials without a box = %v, want ErrNoKey", err)
+	}
+
+	box, _ := secretbox.New("test-key")
+	s.SetSecretBox(box)
+	if err := s.SetCredentials(wh, creds); err != nil {
+		t.Fatalf("SetCredentials: %v", err)
+	}
+	if wh.Credentials == "" || !wh.HasBasicAuth || !reflect.DeepEqual(wh.HeaderNames, []string{"X-Gateway-Key", "X-Tenant"}) {
+		t.Errorf("webhook = %+v", wh)
+	}
+	opened, err := s.Credentials(*wh)
+	if err != nil || !reflect.DeepEqual(opened, creds) {
+		t.Fatalf("Credentials = %+v, %v", opened, err)
+	}
+
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+	wh.URL = server.URL
+	wh.Secret = "secret"
+	if result := s.deliver(context.Background(), *wh, opened, []byte(`{}`)); result.Error != "" || result.ResponseCode != http.StatusOK {
+		t.Fatalf("deliver = %+v", result)
+	}
+	if got.Get("X-Gateway-Key") != "gw-123" || got.Get("X-Tenant") != "acme" {
+		t.Errorf("custom headers not sent: %v", got)
+	}
+	if user, pass, ok := (&http.Request{Header: got}).BasicAuth(); !ok || user != "hooks" || pass != "pw" {
+		t.Errorf("basic auth = %q, %q, %v", user, pass, ok)
+	}
+	if got.Get("X-Webhook-Signature") != SignPayload([]byte(`{}`), "secret") {
+		t.Error("signature missing alongside credentials")
+	}
+
+	// Clearing needs no box
+	s.SetSecretBox(nil)
+	if err := s.SetCredentials(wh, models.WebhookCredentials{}); err != nil || wh.Credentials != "" || wh.HasBasicAuth || len(wh.HeaderNames) != 0 {
+		t.Errorf("clearing credentials: %+v, %v", wh, err)
+	}
+}
@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// Limits on a webhook's max_payload_bytes. Webhooks that don't set one get
+// DefaultMaxPayloadBytes.
+const (
+	DefaultMaxPayloadBytes = 1 << 20  // 1 MiB
+	MinPayloadBytes        = 1 << 10  // 1 KiB
+	MaxPayloadBytes        = 10 << 20 // 10 MiB
+)
+
+// bulkyFields are left out of metadata payloads, at any depth: item text
+// and the AI output derived from it.
+var bulkyFields = map[string]bool{
+	"transcript_text": true,
+	"text_content":    true,
+	"segments":        true,
+	"summary_text":    true,
+	"prompt_used":     true,
+	"key_points":      true,
+	"action_items":    true,
+	"decisions":       true,
+	"analysis":        true,
+	"minutes":         true,
+}
+
+// errPayloadTooLarge is returned when even a metadata payload is over a
+// webhook's size limit.
+type errPayloadTooLarge struct {
+	size, limit int
+}
+
+func (e errPayloadTooLarge) Error() string {
+	return fmt.Sprintf("payload is %d bytes, over the webhook's %d-byte limit", e.size, e.limit)
+}
+
+// buildPayload encodes an event for a webhook, shaped by its payload mode.
+// data is the event data as decoded from JSON. If the payload is over the
+// webhook's size limit, bulky fields are dropped and it's marked truncated.
+func buildPayload(wh models.Webhook, event string, data interface{}, timestamp time.Time) ([]byte, error) {
+	switch wh.PayloadMode {
+	case models.WebhookPayloadMetadata:
+		data = stripBulky(data)
+	case models.WebhookPayloadFields:
+		data = pickFields(data, wh.PayloadFields)
+	}
+
+	payload := models.WebhookPayload{Event: event, Data: data, Timestamp: timestamp}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	limit := wh.MaxPayloadBytes
+	if limit <= 0 {
+		limit = DefaultMaxPayloadBytes
+	}
+	if len(payloadJSON) <= limit {
+		return payloadJSON, nil
+	}
+
+	payload.Data = stripBulky(data)
+	payload.Truncated = true
+	if payloadJSON, err = json.Marshal(payload); err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	if len(payloadJSON) > limit {
+		return nil, errPayloadTooLarge{len(payloadJSON), limit}
+	}
+	return payloadJSON, nil
+}
+
+// stripBulky returns a copy of data without bulkyFields.
+func stripBulky(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if !bulkyFields[key] {
+				out[key] = stripBulky(value)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = stripBulky(value)
+		}
+		return out
+	default:
+		return data
+	}
+}
+
+// pickFields returns only the listed fields of data, plus its id so
+// receivers can fetch the rest. A dotted field like "transcript.title"
+// selects inside a nested object. Data that isn't an object is returned
+// unchanged.
+func pickFields(data interface{}, fields []string) interface{} {
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return data
+	}
+	out := make(map[string]interface{})
+	if id, ok := obj["id"]; ok {
+		out["id"] = id
+	}
+	for _, field := range fields {
+		pickField(obj, out, strings.Split(field, "."))
+	}
+	return out
+}
+
+// pickField copies the value at path from src to dst, creating the nested
+// objects along the way.
+func pickField(src, dst map[string]interface{}, path []string) {
+	value, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		dst[path[0]] = value
+		return
+	}
+	nested, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	next, ok := dst[path[0]].(map[string]interface{})
+	if !ok {
+		next = make(map[string]interface{})
+		dst[path[0]] = next
+	}
+	pickField(nested, next, path[1:])
+}
+
+// ValidatePayloadSettings checks a webhook's payload mode, fields, and size
+// limit. The error is meant for the client.
+func ValidatePayloadSettings(mode string, fields []string, maxBytes int) error {
+	if !models.ValidWebhookPayloadModes[mode] {
+		return fmt.Errorf("payload_mode must be full, metadata, or fields")
+	}
+	if mode == models.WebhookPayloadFields && len(fields) == 0 {
+		return fmt.Errorf("payload_fields is required when payload_mode is fields")
+	}
+	if len(fields) > 50 {
+		return fmt.Errorf("payload_fields can list at most 50 fields")
+	}
+	for _, field := range fields {
+		if field == "" || strings.HasPrefix(field, ".") || strings.HasSuffix(field, ".") || strings.Contains(field, "..") {
+			return fmt.Errorf("invalid payload field %q", field)
+		}
+	}
+	if maxBytes != 0 && (maxBytes < MinPayloadBytes || maxBytes > MaxPayloadBytes) {
+		return fmt.Errorf("max_payload_bytes must be between %d and %d, or 0 for the default (%d)", MinPayloadBytes, MaxPayloadBytes, DefaultMaxPayloadBytes)
+	}
+	return nil
+}
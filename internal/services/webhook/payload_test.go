@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+// decodeData builds a payload for wh and returns its data and truncated flag.
+func decodeData(t *testing.T, wh models.Webhook, data interface{}) (map[string]interface{}, bool) {
+	t.Helper()
+	payloadJSON, err := buildPayload(wh, "transcript.completed", data, time.Now())
+	if err != nil {
+		t.Fatalf("buildPayload: %v", err)
+	}
+	var payload struct {
+		Event     string                 `json:"event"`
+		Data      map[string]interface{} `json:"data"`
+		Truncated bool                   `json:"truncated"`
+	}
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		t.Fatalf("invalid payload %s: %v", payloadJSON, err)
+	}
+	if payload.Event != "transcript.completed" {
+		t.Errorf("event = %q", payload.Event)
+	}
+	return payload.Data, payload.Truncated
+}
+
+func TestBuildPayload(t *testing.T) {
+	item := func() interface{} {
+		return map[string]interface{}{
+			"id":              "t1",
+			"title":           "A Talk",
+			"status":          "completed",
+			"transcript_text": "words words words",
+			"segments":        []interface{}{map[string]interface{}{"start": 0.0, "text": "words"}},
+			"summary": map[string]interface{}{
+				"id":           "s1",
+				"summary_text": "short",
+				"model_used":   "m",
+			},
+		}
+	}
+
+	data, truncated := decodeData(t, models.Webhook{PayloadMode: models.WebhookPayloadFull}, item())
+	if data["transcript_text"] != "words words words" || truncated {
+		t.Errorf("full payload = %v (truncated %v)", data, truncated)
+	}
+
+	data, _ = decodeData(t, models.Webhook{PayloadMode: models.WebhookPayloadMetadata}, item())
+	if _, ok := data["transcript_text"]; ok {
+		t.Error("metadata payload kept transcript_text")
+	}
+	if _, ok := data["segments"]; ok {
+		t.Error("metadata payload kept segments")
+	}
+	summary := data["summary"].(map[string]interface{})
+	if _, ok := summary["summary_text"]; ok || summary["model_used"] != "m" || data["title"] != "A Talk" {
+		t.Errorf("metadata payload = %v", data)
+	}
+
+	wh := models.Webhook{PayloadMode: models.WebhookPayloadFields, PayloadFields: []string{"status", "summary.model_used", "missing", "title.nested"}}
+	data, _ = decodeData(t, wh, item())
+	want := `{"id":"t1","status":"completed","summary":{"model_used":"m"}}`
+	if got, _ := json.Marshal(data); string(got) != want {
+		t.Errorf("fields payload = %s, want %s", got, want)
+	}
+
+	// Over the limit, bulky fields are dropped
+	big := item().(map[string]interface{})
+	big["transcript_text"] = strings.Repeat("x", 2000)
+	data, truncated = decodeData(t, models.Webhook{PayloadMode: models.WebhookPayloadFull, MaxPayloadBytes: 1024}, big)
+	if _, ok := data["transcript_text"]; ok || !truncated || data["title"] != "A Talk" {
+		t.Errorf("oversized payload = %v (truncated %v)", data, truncated)
+	}
+
+	// And if that isn't enough, nothing is sent
+	big["title"] = strings.Repeat("x", 2000)
+	if _, err := buildPayload(models.Webhook{MaxPayloadBytes: 1024}, "transcript.completed", big, time.Now()); err == nil {
+		t.Error("buildPayload sent a payload over the limit")
+	}
+}
+
+func TestValidatePayloadSettings(t *testing.T) {
+	tests := []struct {
+		mode     string
+		fields   []string
+		maxBytes int
+		ok       bool
+	}{
+		{"full", nil, 0, true},
+		{"metadata", nil, 4096, true},
+		{"fields", []string{"title", "transcript.title"}, 0, true},
+		{"fields", nil, 0, false},
+		{"fields", []string{"transcript..title"}, 0, false},
+		{"summary", nil, 0, false},
+		{"full", nil, 100, false},
+		{"full", nil, 11 << 20, false},
+	}
+	for _, tt := range tests {
+		err := ValidatePayloadSettings(tt.mode, tt.fields, tt.maxBytes)
+		if (err == nil) != tt.ok {
+			t.Errorf("ValidatePayloadSettings(%q, %v, %d) = %v", tt.mode, tt.fields, tt.maxBytes, err)
+		}
+	}
+}
@@ -10,19 +10,34 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/secretbox"
+)
+
+// MaxResponseCapture is how much of a receiver's response body each
+// delivery attempt keeps.
+const MaxResponseCapture = 4 << 10
+
+// How long, in hours, deliveries stay signed with a webhook's old secret
+// after it's rotated.
+const (
+	DefaultSecretGraceHours = 24
+	MaxSecretGraceHours     = 7 * 24
 )
 
 // Service handles webhook notification delivery.
 type Service struct {
 	db         *database.DB
 	client     *http.Client
-	shutdownCh chan struct{} // Signals pending deliveries to stop
+	box        *secretbox.Box // Seals webhook credentials; nil if ENCRYPTION_KEY is unset
+	shutdownCh chan struct{}  // Signals pending deliveries to stop
 }
 
 // New creates a new webhook service.
@@ -58,41 +73,111 @@ func SignPayload(payload []byte, secret string) string {
 	return hex.EncodeToString(mac.Sum(nil))
 }
 
-// NotifyEvent sends webhook notifications for a given event to all registered webhooks.
+// NotifyEvent sends webhook notifications for an event about an item to
+// its owner's webhooks: the API key that created it, or any key of the user
+// who owns it. Other tenants' webhooks never see it.
 // Delivery happens asynchronously with retry logic.
-func (s *Service) NotifyEvent(ctx context.Context, event string, data interface{}) {
-	webhooks, err := s.db.GetActiveWebhooksForEvent(ctx, event)
+func (s *Service) NotifyEvent(ctx context.Context, apiKeyID, userID *string, event string, data interface{}) {
+	webhooks, err := s.db.GetActiveWebhooksForOwner(ctx, apiKeyID, userID, event)
 	if err != nil {
 		log.Printf("⚠️  Failed to get webhooks for event %s: %v", event, err)
 		return
 	}
 
-	if len(webhooks) == 0 {
+	s.send(event, data, webhooks)
+}
+
+// NotifyAPIKeyEvent sends an event about an API key to that key's own
+// webhooks that subscribe to it.
+func (s *Service) NotifyAPIKeyEvent(ctx context.Context, apiKeyID, event string, data interface{}) {
+	webhooks, err := s.db.GetActiveWebhooksForAPIKeyEvent(ctx, apiKeyID, event)
+	if err != nil {
+		log.Printf("⚠️  Failed to get webhooks for event %s: %v", event, err)
 		return
 	}
 
-	payload := models.WebhookPayload{
-		Event:     event,
-		Data:      data,
-		Timestamp: time.Now().UTC(),
+	s.send(event, data, webhooks)
+}
+
+// NotifySearchMatches checks a newly completed item against saved searches
+// with notify set and sends a search.matched event to each matching search
+// owner's webhooks. itemType is transcript, audio, or pdf.
+func (s *Service) NotifySearchMatches(ctx context.Context, itemType, itemID string) {
+	matches, err := s.db.MatchSavedSearches(ctx, itemType, itemID)
+	if err != nil {
+		log.Printf("⚠️  Failed to match saved searches for %s %s: %v", itemType, itemID, err)
+		return
+	}
+
+	for _, m := range matches {
+		webhooks, err := s.db.GetActiveWebhooksForAPIKeyEvent(ctx, m.APIKeyID, "search.matched")
+		if err != nil {
+			log.Printf("⚠️  Failed to get webhooks for saved search %s: %v", m.SavedSearchID, err)
+			continue
+		}
+		s.send("search.matched", m, webhooks)
+	}
+}
+
+// send delivers an event to the given webhooks in the background, each
+// getting the payload its settings call for.
+func (s *Service) send(event string, data interface{}, webhooks []models.Webhook) {
+	if len(webhooks) == 0 {
+		return
 	}
 
-	payloadJSON, err := json.Marshal(payload)
+	// Round-trip the data through JSON so payload modes can pick fields out
+	// of it by name
+	dataJSON, err := json.Marshal(data)
 	if err != nil {
 		log.Printf("⚠️  Failed to marshal webhook payload: %v", err)
 		return
 	}
+	var generic interface{}
+	if err := json.Unmarshal(dataJSON, &generic); err != nil {
+		log.Printf("⚠️  Failed to marshal webhook payload: %v", err)
+		return
+	}
+	timestamp := time.Now().UTC()
 
 	for _, wh := range webhooks {
+		payloadJSON, err := buildPayload(wh, event, generic, timestamp)
+		if err != nil {
+			go s.recordUndeliverable(wh, event, err)
+			continue
+		}
+		// Without its credentials the receiver would only reject it
+		creds, err := s.Credentials(wh)
+		if err != nil {
+			go s.recordUndeliverable(wh, event, fmt.Errorf("can't read webhook credentials: %w", err))
+			continue
+		}
 		// Fire and forget — each delivery runs in its own goroutine
-		go s.deliverWithRetry(wh, event, payloadJSON)
+		go s.deliverWithRetry(wh, creds, event, payloadJSON)
+	}
+}
+
+// recordUndeliverable records a failed delivery for an event that couldn't
+// be sent at all, so it shows up in the webhook's delivery history.
+func (s *Service) recordUndeliverable(wh models.Webhook, event string, err error) {
+	log.Printf("❌ Webhook not sent: %s → %s: %v", event, wh.URL, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	delivery := &models.WebhookDelivery{
+		WebhookID: wh.ID,
+		Event:     event,
+		Status:    "failed",
+		LastError: err.Error(),
+	}
+	if err := s.db.CreateWebhookDelivery(ctx, delivery); err != nil {
+		log.Printf("⚠️  Failed to create webhook delivery record: %v", err)
 	}
 }
 
 // deliverWithRetry attempts to deliver a webhook with exponential backoff.
 // Retries: 3 attempts with delays of 1s, 5s, 30s.
 // Delivery respects shutdown signals for graceful termination.
-func (s *Service) deliverWithRetry(wh models.Webhook, event string, payloadJSON []byte) {
+func (s *Service) deliverWithRetry(wh models.Webhook, creds models.WebhookCredentials, event string, payloadJSON []byte) {
 	// Create a context with a generous timeout for the entire retry sequence
 	// (up to ~40 seconds of retries + delivery time)
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
@@ -135,10 +220,15 @@ func (s *Service) deliverWithRetry(wh models.Webhook, event string, payloadJSON
 		}
 
 		delivery.Attempts = attempt + 1
-		statusCode, err := s.deliver(ctx, wh, payloadJSON)
+		result := s.deliver(ctx, wh, creds, payloadJSON)
+		result.DeliveryID, result.Attempt = delivery.ID, attempt+1
+		if err := s.db.CreateWebhookDeliveryAttempt(ctx, &result); err != nil {
+			log.Printf("⚠️  Failed to record delivery attempt: %v", err)
+		}
+		statusCode := result.ResponseCode
 		delivery.ResponseCode = statusCode
 
-		if err == nil && statusCode >= 200 && statusCode < 300 {
+		if result.Error == "" && statusCode >= 200 && statusCode < 300 {
 			// Success
 			delivery.Status = "success"
 			now := time.Now()
@@ -152,8 +242,8 @@ func (s *Service) deliverWithRetry(wh models.Webhook, event string, payloadJSON
 		}
 
 		// Record the error
-		if err != nil {
-			delivery.LastError = err.Error()
+		if result.Error != "" {
+			delivery.LastError = result.Error
 		} else {
 			delivery.LastError = fmt.Sprintf("HTTP %d", statusCode)
 		}
@@ -174,11 +264,22 @@ func (s *Service) deliverWithRetry(wh models.Webhook, event string, payloadJSON
 	log.Printf("❌ Webhook delivery failed permanently: %s → %s", event, wh.URL)
 }
 
-// deliver sends a single webhook HTTP request with context support.
-func (s *Service) deliver(ctx context.Context, wh models.Webhook, payloadJSON []byte) (int, error) {
+// deliver sends a single webhook HTTP request with context support and
+// returns what happened, with the start of the response body.
+func (s *Service) deliver(ctx context.Context, wh models.Webhook, creds models.WebhookCredentials, payloadJSON []byte) models.WebhookDeliveryAttempt {
+	var result models.WebhookDeliveryAttempt
 	req, err := http.NewRequestWithContext(ctx, "POST", wh.URL, bytes.NewReader(payloadJSON))
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		result.Error = fmt.Sprintf("failed to create request: %v", err)
+		return result
+	}
+
+	// Custom headers first, so they can't replace the ones below
+	for name, value := range creds.Headers {
+		req.Header.Set(name, value)
+	}
+	if creds.BasicAuth != nil {
+		req.SetBasicAuth(creds.BasicAuth.Username, creds.BasicAuth.Password)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -189,12 +290,30 @@ func (s *Service) deliver(ctx context.Context, wh models.Webhook, payloadJSON []
 		signature := SignPayload(payloadJSON, wh.Secret)
 		req.Header.Set("X-Webhook-Signature", signature)
 	}
+	// And with the old secret while a rotation's grace window is open
+	if wh.PreviousSecret != "" && wh.PreviousSecretExpiresAt != nil && time.Now().Before(*wh.PreviousSecretExpiresAt) {
+		req.Header.Set("X-Webhook-Signature-Previous", SignPayload(payloadJSON, wh.PreviousSecret))
+	}
 
+	start := time.Now()
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("request failed: %w", err)
+		result.LatencyMS = int(time.Since(start).Milliseconds())
+		result.Error = fmt.Sprintf("request failed: %v", err)
+		return result
 	}
 	defer resp.Body.Close()
 
-	return resp.StatusCode, nil
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxResponseCapture+1))
+	result.LatencyMS = int(time.Since(start).Milliseconds())
+	result.ResponseCode = resp.StatusCode
+	if len(body) > MaxResponseCapture {
+		body, result.ResponseTruncated = body[:MaxResponseCapture], true
+	}
+	// Stored as text, so binary or cut-off UTF-8 is replaced
+	result.ResponseBody = strings.ToValidUTF8(strings.ReplaceAll(string(body), "\x00", ""), "\uFFFD")
+	if err != nil {
+		result.ResponseTruncated = true
+	}
+	return result
 }
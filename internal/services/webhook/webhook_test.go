@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+)
+
+func TestDeliverCapturesResponse(t *testing.T) {
+	body := `{"error": "unknown tenant"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	s := New(nil)
+	wh := models.Webhook{URL: server.URL}
+	result := s.deliver(context.Background(), wh, models.WebhookCredentials{}, []byte(`{}`))
+	if result.ResponseCode != http.StatusUnprocessableEntity || result.ResponseBody != body || result.ResponseTruncated || result.Error != "" {
+		t.Errorf("deliver = %+v", result)
+	}
+
+	body = strings.Repeat("x", MaxResponseCapture+100)
+	result = s.deliver(context.Background(), wh, models.WebhookCredentials{}, []byte(`{}`))
+	if len(result.ResponseBody) != MaxResponseCapture || !result.ResponseTruncated {
+		t.Errorf("deliver kept %d bytes (truncated %v), want %d", len(result.ResponseBody), result.ResponseTruncated, MaxResponseCapture)
+	}
+
+	server.Close()
+	result = s.deliver(context.Background(), wh, models.WebhookCredentials{}, []byte(`{}`))
+	if result.ResponseCode != 0 || result.Error == "" {
+		t.Errorf("deliver to a closed server = %+v", result)
+	}
+}
+
+func TestDeliverDualSigns(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	s := New(nil)
+	payload := []byte(`{"event":"audio.completed"}`)
+	later := time.Now().Add(time.Hour)
+	wh := models.Webhook{URL: server.URL, Secret: "new", PreviousSecret: "old", PreviousSecretExpiresAt: &later}
+	s.deliver(context.Background(), wh, models.WebhookCredentials{}, payload)
+	if got.Get("X-Webhook-Signature") != SignPayload(payload, "new") || got.Get("X-Webhook-Signature-Previous") != SignPayload(payload, "old") {
+		t.Errorf("signatures during the grace window = %q, %q", got.Get("X-Webhook-Signature"), got.Get("X-Webhook-Signature-Previous"))
+	}
+
+	earlier := time.Now().Add(-time.Minute)
+	wh.PreviousSecretExpiresAt = &earlier
+	s.deliver(context.Background(), wh, models.WebhookCredentials{}, payload)
+	if got.Get("X-Webhook-Signature-Previous") != "" {
+		t.Error("old secret still signing after the grace window")
+	}
+}
@@ -0,0 +1,110 @@
+// accounts.go handles account data exports and erases accounts whose
+// deletion grace period has passed.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
+)
+
+// eraseBatchSize is how many due accounts are erased per sweep.
+const eraseBatchSize = 20
+
+// SetDeletionGrace sets how long after an account deletion request the
+// account is erased. Until then the user can cancel.
+func (p *Pool) SetDeletionGrace(grace time.Duration) {
+	p.deletionGrace = grace
+}
+
+// DeletionGrace returns the account deletion grace period.
+func (p *Pool) DeletionGrace() time.Duration {
+	return p.deletionGrace
+}
+
+// renderAccountFiles renders the account-level records of an account
+// export: the profile, API key metadata, workspace, and chat history.
+// The user's items are rendered separately, like any other export item.
+func (p *Pool) renderAccountFiles(ctx context.Context, e *models.Export) ([]export.File, error) {
+	if e.UserID == nil {
+		return nil, fmt.Errorf("account export has no user")
+	}
+	userID := *e.UserID
+
+	user, err := p.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := p.db.ListUserAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := p.db.GetWorkspaceItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	chats, err := p.db.ListAccountChats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []struct {
+		name string
+		v    interface{}
+	}{
+		{"account.json", user},
+		{"api_keys.json", keys},
+		{"workspace.json", workspace},
+		{"chats.json", chats},
+	}
+	files := make([]export.File, 0, len(records))
+	for _, r := range records {
+		data, err := json.MarshalIndent(r.v, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", r.name, err)
+		}
+		files = append(files, export.File{Name: r.name, Data: data})
+	}
+	return files, nil
+}
+
+// eraseDueAccounts erases accounts whose deletion grace period has
+// passed, then removes their stored originals and export archives.
+func (p *Pool) eraseDueAccounts() {
+	ids, err := p.db.ListUsersDueForDeletion(p.ctx, eraseBatchSize)
+	if err != nil {
+		log.Printf("⚠️  Account erasure failed: %v", err)
+		return
+	}
+	for _, id := range ids {
+		erasure, err := p.db.EraseUser(p.ctx, id)
+		if err != nil {
+			log.Printf("❌ Failed to erase account %s: %v", id, err)
+			continue
+		}
+		if erasure == nil {
+			continue // Cancelled since it was listed
+		}
+		for _, key := range erasure.Originals {
+			if p.originals == nil {
+				break
+			}
+			if err := p.originals.Delete(p.ctx, key); err != nil {
+				log.Printf("⚠️  Failed to delete original %s of erased account %s: %v", key, id, err)
+			}
+		}
+		for _, path := range erasure.ExportFiles {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Printf("⚠️  Failed to delete export %s of erased account %s: %v", path, id, err)
+			}
+		}
+		log.Printf("🧹 Erased account %s (%d items, %d originals, %d exports)",
+			id, erasure.Items, len(erasure.Originals), len(erasure.ExportFiles))
+	}
+}
@@ -0,0 +1,273 @@
+// digest.go builds weekly digests of what users processed — top themes,
+// every action item, notable quotes — and delivers them to subscribers by
+// email and webhook.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/mailer"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// DigestPeriod is how much time a scheduled digest covers.
+const DigestPeriod = 7 * 24 * time.Hour
+
+// Limits for digests: the most items one covers, and how many users'
+// digests are built per sweep.
+const (
+	maxDigestItems  = 30
+	digestBatchSize = 20
+)
+
+// digestContent is what's stored as a digest's content.
+type digestContent struct {
+	Overview    string                    `json:"overview"`
+	Items       []models.DigestItem       `json:"items"`
+	Themes      []summary.DigestTheme     `json:"themes"`
+	ActionItems []models.DigestActionItem `json:"action_items"`
+	Quotes      []summary.DigestQuote     `json:"quotes"`
+}
+
+// SetDigests sets how often subscribers are checked for a due weekly
+// digest, and the mailer digests are emailed with. An interval of 0 (the
+// default) never sends them.
+func (p *Pool) SetDigests(interval time.Duration, m mailer.Mailer) {
+	p.digestInterval = interval
+	p.mailer = m
+}
+
+// sweepDigests sends due digests until the pool stops.
+func (p *Pool) sweepDigests() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.digestInterval)
+	defer ticker.Stop()
+	for {
+		p.sendDueDigests()
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDueDigests builds, stores, and delivers a digest of the past week
+// for each subscriber without one. Quiet weeks are stored with no items,
+// which marks them done, but not delivered.
+func (p *Pool) sendDueDigests() {
+	end := time.Now()
+	start := end.Add(-DigestPeriod)
+	ids, err := p.db.ListUsersDueForDigest(p.ctx, start, digestBatchSize)
+	if err != nil {
+		log.Printf("⚠️  Digest sweep failed: %v", err)
+		return
+	}
+
+	for _, userID := range ids {
+		settings, err := p.db.ResolveAISettings(p.ctx, nil, &userID)
+		if err != nil {
+			log.Printf("⚠️  Failed to load AI settings for digest of user %s: %v", userID, err)
+			continue
+		}
+		digest, err := p.BuildDigest(p.ctx, userID, start, end, summary.Options{
+			Model:    settings.Model,
+			Language: settings.Language,
+		})
+		if err == nil {
+			err = p.db.CreateDigest(p.ctx, digest)
+		}
+		if err != nil {
+			log.Printf("⚠️  Failed to build digest for user %s: %v", userID, err)
+			continue
+		}
+		if digest.ItemCount > 0 {
+			p.deliverDigest(digest)
+		}
+	}
+}
+
+// BuildDigest builds a digest of a user's items completed between start
+// and end, without storing it. A period with no items gives a digest with
+// ItemCount 0 and no AI call.
+func (p *Pool) BuildDigest(ctx context.Context, userID string, start, end time.Time, opts summary.Options) (*models.Digest, error) {
+	refs, err := p.db.ListDigestItems(ctx, userID, start, end, maxDigestItems)
+	if err != nil {
+		return nil, err
+	}
+
+	content := digestContent{
+		Items:       []models.DigestItem{},
+		Themes:      []summary.DigestTheme{},
+		ActionItems: []models.DigestActionItem{},
+		Quotes:      []summary.DigestQuote{},
+	}
+	var sources []summary.DigestSource
+	for _, ref := range refs {
+		src, item, actions, err := p.digestSource(ctx, ref)
+		if err != nil {
+			// Deleted since it was listed
+			log.Printf("⚠️  Skipping %s %s in digest: %v", ref.Type, ref.ID, err)
+			continue
+		}
+		item.Number = len(content.Items) + 1
+		content.Items = append(content.Items, item)
+		for _, task := range actions {
+			content.ActionItems = append(content.ActionItems, models.DigestActionItem{Item: item.Number, Task: task})
+		}
+		sources = append(sources, src)
+	}
+
+	digest := &models.Digest{
+		UserID:      userID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		ItemCount:   len(content.Items),
+	}
+	if len(sources) > 0 {
+		if p.summarizer == nil {
+			return nil, fmt.Errorf("AI generation is not configured")
+		}
+		result, err := p.summarizer.GenerateDigest(ctx, sources, opts)
+		if err != nil {
+			return nil, err
+		}
+		content.Overview, content.Themes, content.Quotes = result.Overview, result.Themes, result.Quotes
+		digest.ModelUsed = result.Model
+	}
+	digest.Content, _ = json.Marshal(content)
+	return digest, nil
+}
+
+// digestSource loads an item for a digest: its text and summary for the
+// AI, how it's listed, and its action items.
+func (p *Pool) digestSource(ctx context.Context, ref models.ExportItem) (summary.DigestSource, models.DigestItem, []string, error) {
+	item := models.DigestItem{ItemType: ref.Type, ItemID: ref.ID}
+	var (
+		src     summary.DigestSource
+		actions []string
+	)
+	switch ref.Type {
+	case "transcript":
+		t, err := p.db.GetTranscript(ctx, ref.ID)
+		if err != nil {
+			return src, item, nil, err
+		}
+		item.Title, item.CreatedAt = t.Title, t.CreatedAt
+		if item.Title == "" {
+			item.Title = t.YouTubeID
+		}
+		src = summary.DigestSource{Label: item.Title + " (YouTube video)", Text: t.TranscriptText}
+		if s, err := p.db.GetLatestSummary(ctx, t.ID); err == nil && s != nil {
+			src.Summary = s.SummaryText
+		}
+	case "audio":
+		at, err := p.db.GetAudioTranscription(ctx, ref.ID)
+		if err != nil {
+			return src, item, nil, err
+		}
+		item.Title, item.CreatedAt = at.DisplayName(), at.CreatedAt
+		src = summary.DigestSource{Label: item.Title + " (audio recording)", Summary: at.SummaryText, Text: at.TranscriptText}
+		json.Unmarshal(at.ActionItems, &actions) // Absent until summarized
+	default:
+		pe, err := p.db.GetPDFExtraction(ctx, ref.ID)
+		if err != nil {
+			return src, item, nil, err
+		}
+		item.Title, item.CreatedAt = pe.DisplayName(), pe.CreatedAt
+		src = summary.DigestSource{Label: item.Title + " (PDF document)", Text: pe.TextContent}
+	}
+	return src, item, actions, nil
+}
+
+// deliverDigest sends a digest the ways its user subscribed to, and
+// records the delivery if any way worked.
+func (p *Pool) deliverDigest(d *models.Digest) {
+	settings, err := p.db.GetDigestSettings(p.ctx, d.UserID)
+	if err != nil {
+		log.Printf("⚠️  Failed to load digest settings for user %s: %v", d.UserID, err)
+		return
+	}
+
+	delivered := false
+	if settings.Webhook && p.webhooks != nil {
+		p.notifyWebhook("digest.ready", d, nil, &d.UserID)
+		delivered = true
+	}
+	if settings.Email && p.mailer != nil {
+		if err := p.emailDigest(d); err != nil {
+			log.Printf("⚠️  Failed to email digest %s: %v", d.ID, err)
+		} else {
+			delivered = true
+		}
+	}
+	if delivered {
+		if err := p.db.MarkDigestDelivered(p.ctx, d.ID); err != nil {
+			log.Printf("⚠️  %v", err)
+		}
+	}
+}
+
+// emailDigest emails a digest to its user.
+func (p *Pool) emailDigest(d *models.Digest) error {
+	user, err := p.db.GetUserByID(p.ctx, d.UserID)
+	if err != nil {
+		return err
+	}
+	var content digestContent
+	if err := json.Unmarshal(d.Content, &content); err != nil {
+		return fmt.Errorf("invalid digest content: %w", err)
+	}
+	return p.mailer.Send(p.ctx, mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Your weekly digest: %d items", d.ItemCount),
+		Body:    digestEmail(d, &content),
+	})
+}
+
+// digestEmail renders a digest as a plain-text email.
+func digestEmail(d *models.Digest, c *digestContent) string {
+	titles := make(map[int]string, len(c.Items))
+	for _, it := range c.Items {
+		titles[it.Number] = it.Title
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your digest for %s – %s\n\n", d.PeriodStart.Format("Jan 2"), d.PeriodEnd.Format("Jan 2, 2006"))
+	if c.Overview != "" {
+		b.WriteString(c.Overview + "\n\n")
+	}
+	if len(c.Themes) > 0 {
+		b.WriteString("TOP THEMES\n")
+		for _, t := range c.Themes {
+			fmt.Fprintf(&b, "- %s: %s\n", t.Theme, t.Description)
+		}
+		b.WriteString("\n")
+	}
+	if len(c.ActionItems) > 0 {
+		b.WriteString("ACTION ITEMS\n")
+		for _, a := range c.ActionItems {
+			fmt.Fprintf(&b, "- %s (%s)\n", a.Task, titles[a.Item])
+		}
+		b.WriteString("\n")
+	}
+	if len(c.Quotes) > 0 {
+		b.WriteString("NOTABLE QUOTES\n")
+		for _, q := range c.Quotes {
+			fmt.Fprintf(&b, "- “%s” (%s)\n", q.Quote, titles[q.Item])
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("ITEMS\n")
+	for _, it := range c.Items {
+		fmt.Fprintf(&b, "%d. %s (%s, %s)\n", it.Number, it.Title, it.ItemType, it.CreatedAt.Format("Jan 2"))
+	}
+	return b.String()
+}
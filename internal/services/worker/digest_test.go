@@ -0,0 +1,44 @@
+// digest_test.go contains tests for rendering weekly digest emails.
+package worker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
+)
+
+// TestDigestEmail verifies the email lists each section with the items
+// their entries came from, and leaves out empty sections.
+func TestDigestEmail(t *testing.T) {
+	end := time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)
+	d := &models.Digest{PeriodStart: end.Add(-DigestPeriod), PeriodEnd: end, ItemCount: 2}
+	content := &digestContent{
+		Overview: "A week of planning.",
+		Items: []models.DigestItem{
+			{Number: 1, ItemType: "audio", Title: "Standup", CreatedAt: end.Add(-48 * time.Hour)},
+			{Number: 2, ItemType: "pdf", Title: "Roadmap.pdf", CreatedAt: end.Add(-24 * time.Hour)},
+		},
+		Themes:      []summary.DigestTheme{{Theme: "Launch", Description: "Dates were set", Items: []int{1, 2}}},
+		ActionItems: []models.DigestActionItem{{Item: 1, Task: "Book the venue"}},
+		Quotes:      []summary.DigestQuote{},
+	}
+
+	got := digestEmail(d, content)
+	for _, want := range []string{
+		"Your digest for Mar 2 – Mar 9, 2026",
+		"A week of planning.",
+		"- Launch: Dates were set",
+		"- Book the venue (Standup)",
+		"2. Roadmap.pdf (pdf, Mar 8)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("email missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "NOTABLE QUOTES") {
+		t.Errorf("email has an empty quotes section:\n%s", got)
+	}
+}
@@ -0,0 +1,222 @@
+// export.go handles bulk export jobs: load each requested item, render it
+// in the chosen format, and bundle everything into a ZIP on disk.
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
+)
+
+// processExport handles bulk ZIP export jobs.
+// Items that were deleted, aren't completed, or belong to another API key
+// or user are skipped rather than failing the whole export.
+func (p *Pool) processExport(job Job) error {
+	ctx := p.ctx
+
+	e, err := p.db.GetExport(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get export: %w", err)
+	}
+
+	e.Status = "processing"
+	if err := p.db.UpdateExport(ctx, e); err != nil {
+		log.Printf("⚠️  Failed to update export status to processing: %v", err)
+	}
+	p.publishStatus("export", e.ID, e.Status, "", e.APIKeyID, e.UserID)
+
+	fail := func(err error) error {
+		now := time.Now()
+		e.Status = "failed"
+		e.ErrorMessage = err.Error()
+		e.CompletedAt = &now
+		p.db.UpdateExport(ctx, e)
+		p.notifyWebhook("export.failed", e, e.APIKeyID, e.UserID)
+		p.publishStatus("export", e.ID, e.Status, e.ErrorMessage, e.APIKeyID, e.UserID)
+		return err
+	}
+
+	var items []models.ExportItem
+	if err := json.Unmarshal(e.Items, &items); err != nil {
+		return fail(fmt.Errorf("invalid export items: %w", err))
+	}
+
+	jobCtx, cancel := p.jobContext(job)
+	defer cancel()
+
+	// An API key can export its own items and its organization's; a user
+	// can export their own items and those of the keys linked to them
+	var visible map[string]bool
+	if e.APIKeyID != nil {
+		ids, err := p.db.VisibleAPIKeyIDs(ctx, *e.APIKeyID)
+		if err != nil {
+			return fail(err)
+		}
+		visible = make(map[string]bool, len(ids))
+		for _, id := range ids {
+			visible[id] = true
+		}
+	} else if e.UserID != nil {
+		keys, err := p.db.ListUserAPIKeys(ctx, *e.UserID)
+		if err != nil {
+			return fail(err)
+		}
+		visible = make(map[string]bool, len(keys))
+		for _, k := range keys {
+			visible[k.ID] = true
+		}
+	}
+
+	var files []export.File
+	if e.Kind == models.ExportKindAccount {
+		account, err := p.renderAccountFiles(jobCtx, e)
+		if err != nil {
+			return fail(fmt.Errorf("failed to export account data: %w", err))
+		}
+		files = append(files, account...)
+	}
+	for _, item := range items {
+		if jobCtx.Err() != nil {
+			return fail(p.timeoutError(jobCtx, job, jobCtx.Err()))
+		}
+		f, err := p.renderExportItem(e, visible, item)
+		if err != nil {
+			log.Printf("⚠️  Export %s: skipping %s %s: %v", e.ID, item.Type, item.ID, err)
+			e.SkippedCount++
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return fail(fmt.Errorf("none of the %d requested items could be exported", len(items)))
+	}
+
+	if err := os.MkdirAll(p.exportDir, 0o755); err != nil {
+		return fail(fmt.Errorf("failed to create export directory: %w", err))
+	}
+	path := filepath.Join(p.exportDir, e.ID+".zip")
+	out, err := os.Create(path)
+	if err != nil {
+		return fail(fmt.Errorf("failed to create archive: %w", err))
+	}
+	if err := export.WriteZip(out, files); err != nil {
+		out.Close()
+		os.Remove(path)
+		return fail(err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(path)
+		return fail(fmt.Errorf("failed to write archive: %w", err))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fail(fmt.Errorf("failed to stat archive: %w", err))
+	}
+
+	now := time.Now()
+	e.Status = "completed"
+	e.FilePath = path
+	e.FileSize = info.Size()
+	e.CompletedAt = &now
+	if err := p.db.UpdateExport(ctx, e); err != nil {
+		return fmt.Errorf("failed to save export: %w", err)
+	}
+
+	p.notifyWebhook("export.completed", e, e.APIKeyID, e.UserID)
+	p.publishStatus("export", e.ID, e.Status, "", e.APIKeyID, e.UserID)
+	log.Printf("✅ Export completed: %s (%d files, %d skipped, %d bytes)",
+		e.ID, len(files), e.SkippedCount, e.FileSize)
+	return nil
+}
+
+// renderExportItem loads one item and renders it into a folder named after its type.
+// visible holds the API keys whose items the export may include.
+func (p *Pool) renderExportItem(e *models.Export, visible map[string]bool, item models.ExportItem) (export.File, error) {
+	ctx := p.ctx
+
+	var (
+		f      export.File
+		err    error
+		folder string
+	)
+
+	switch item.Type {
+	case "transcript":
+		t, getErr := p.db.GetTranscript(ctx, item.ID)
+		if getErr != nil {
+			return f, getErr
+		}
+		if err := checkExportable(e, visible, t.APIKeyID, t.UserID, string(t.Status)); err != nil {
+			return f, err
+		}
+
+		// Include the most recent summary, like the single-item PDF export
+		s, _ := p.db.GetLatestSummary(ctx, t.ID)
+		folder = "transcripts"
+		f, err = export.TranscriptFile(t, s, e.Format)
+	case "audio":
+		at, getErr := p.db.GetAudioTranscription(ctx, item.ID)
+		if getErr != nil {
+			return f, getErr
+		}
+		if err := checkExportable(e, visible, at.APIKeyID, at.UserID, at.Status); err != nil {
+			return f, err
+		}
+		folder = "audio"
+		f, err = export.AudioFile(at, e.Format)
+	case "pdf":
+		pe, getErr := p.db.GetPDFExtraction(ctx, item.ID)
+		if getErr != nil {
+			return f, getErr
+		}
+		if err := checkExportable(e, visible, pe.APIKeyID, pe.UserID, pe.Status); err != nil {
+			return f, err
+		}
+		folder = "pdfs"
+		f, err = export.PDFExtractionFile(pe, e.Format)
+	default:
+		return f, fmt.Errorf("unknown item type %q", item.Type)
+	}
+	if err != nil {
+		return f, err
+	}
+
+	f.Name = folder + "/" + f.Name
+	return f, nil
+}
+
+// checkExportable rejects items that aren't finished processing, and applies
+// the handlers' ownership rules. An item owned by an API key can only be
+// exported by that key or another key in its organization (visible). An
+// export a user created without a key (user_id set) can only include
+// unowned items, the user's own, and those of keys linked to the user
+// (visible). Account exports were scoped to the user's items when they
+// were created, and include them in any status.
+func checkExportable(e *models.Export, visible map[string]bool, ownerKey, ownerUser *string, status string) error {
+	if e.Kind == models.ExportKindAccount {
+		return nil
+	}
+	if status != "completed" {
+		return fmt.Errorf("item is %s", status)
+	}
+	switch {
+	case e.APIKeyID != nil:
+		if ownerKey != nil && !visible[*ownerKey] {
+			return fmt.Errorf("item belongs to another API key")
+		}
+	case e.UserID != nil:
+		ownsDirectly := ownerUser != nil && *ownerUser == *e.UserID
+		ownsByKey := ownerKey != nil && visible[*ownerKey]
+		if (ownerUser != nil || ownerKey != nil) && !ownsDirectly && !ownsByKey {
+			return fmt.Errorf("item belongs to another user")
+		}
+	}
+	return nil
+}
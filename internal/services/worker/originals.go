@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"path/filepath"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/storage"
+)
+
+// originalsSweepInterval is how often originals past retention are deleted.
+const originalsSweepInterval = time.Hour
+
+// SetOriginalStore keeps uploaded originals in store, deleting them after
+// retention (0 keeps them until the item is deleted).
+func (p *Pool) SetOriginalStore(store storage.Store, retention time.Duration) {
+	p.originals = store
+	p.retention = retention
+}
+
+// Originals returns the store for uploaded originals, or nil if they
+// aren't kept.
+func (p *Pool) Originals() storage.Store {
+	return p.originals
+}
+
+// StoreOriginal saves an upload under its stored filename and marks the
+// item as having its original. It does nothing if originals aren't kept.
+func (p *Pool) StoreOriginal(ctx context.Context, itemType, id, key string, body io.Reader, size int64) error {
+	if p.originals == nil {
+		return nil
+	}
+	if err := p.originals.Put(ctx, key, body, size, mime.TypeByExtension(filepath.Ext(key))); err != nil {
+		return err
+	}
+	return p.db.MarkOriginalStored(ctx, itemType, id)
+}
+
+// openOriginal reads a kept original.
+func (p *Pool) openOriginal(ctx context.Context, key string) (io.ReadCloser, error) {
+	if p.originals == nil {
+		return nil, errors.New("original storage is not configured")
+	}
+	return p.originals.Open(ctx, key)
+}
+
+// sweepOriginals deletes originals past retention until the pool stops.
+func (p *Pool) sweepOriginals() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(originalsSweepInterval)
+	defer ticker.Stop()
+	for {
+		p.deleteExpiredOriginals()
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deleteExpiredOriginals removes originals stored before the retention
+// cutoff, a batch at a time.
+func (p *Pool) deleteExpiredOriginals() {
+	cutoff := time.Now().Add(-p.retention)
+	for {
+		expired, err := p.db.ListExpiredOriginals(p.ctx, cutoff, 100)
+		if err != nil {
+			log.Printf("⚠️  Failed to list expired originals: %v", err)
+			return
+		}
+		for _, o := range expired {
+			if err := p.originals.Delete(p.ctx, o.Filename); err != nil {
+				log.Printf("⚠️  Failed to delete original %s: %v", o.Filename, err)
+				return // Storage is unhappy; try again next sweep
+			}
+			if err := p.db.ClearOriginalStored(p.ctx, o.Type, o.ID); err != nil {
+				log.Printf("⚠️  Failed to clear original for %s %s: %v", o.Type, o.ID, err)
+				return
+			}
+		}
+		if len(expired) < 100 {
+			if len(expired) > 0 {
+				log.Printf("🧹 Deleted %d originals past retention", len(expired))
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// pdf.go extracts PDFs submitted in upload batches. A single upload is
+// extracted while the client waits, since PDFs process fast; a batch of
+// them is queued instead, so the request returns once the files arrive.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+)
+
+// PDFPayload is the data needed for a PDF extraction job.
+type PDFPayload struct {
+	PDFID        string                    `json:"pdf_id"`
+	TempFilePath string                    `json:"temp_file_path"` // Removed once the job finishes
+	Options      pdfservice.ExtractOptions `json:"options"`
+	Redact       bool                      `json:"redact"` // Mask PII before the text is stored
+}
+
+// processPDFExtraction handles PDF extraction jobs.
+func (p *Pool) processPDFExtraction(job Job) error {
+	ctx := p.ctx
+
+	var payload PDFPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid PDF payload: %w", err)
+	}
+	defer os.Remove(payload.TempFilePath)
+
+	pe, err := p.db.GetPDFExtraction(ctx, payload.PDFID)
+	if err != nil {
+		return fmt.Errorf("failed to get PDF extraction: %w", err)
+	}
+	defer p.updateBatch(pe.BatchID, pe.APIKeyID, pe.UserID)
+
+	pe.Status = "processing"
+	if err := p.db.UpdatePDFExtraction(ctx, pe); err != nil {
+		log.Printf("⚠️  Failed to update PDF status to processing: %v", err)
+	}
+	p.publishStatus("pdf", pe.ID, pe.Status, "", pe.APIKeyID, pe.UserID)
+
+	start := time.Now()
+	result, err := p.extractPDF(ctx, pe, payload)
+	if err != nil {
+		pe.Status = "failed"
+		pe.ErrorMessage = err.Error()
+		p.db.UpdatePDFExtraction(ctx, pe)
+		p.notifyWebhook("pdf.failed", pe, pe.APIKeyID, pe.UserID)
+		p.publishStatus("pdf", pe.ID, pe.Status, pe.ErrorMessage, pe.APIKeyID, pe.UserID)
+		return fmt.Errorf("extraction failed: %w", err)
+	}
+
+	pe.PageCount = result.PageCount
+	pe.FirstPage = result.FirstPage
+	pe.LastPage = result.LastPage
+	pe.TextContent = result.Text
+	pe.MarkdownContent = result.Markdown
+	pe.WordCount = result.WordCount
+	pe.TableCount = len(result.Tables)
+	if len(result.Tables) > 0 {
+		pe.Tables, _ = json.Marshal(result.Tables)
+	}
+	pe.DocumentInfo, _ = json.Marshal(result.Info)
+	if len(result.Outline) > 0 {
+		pe.Outline, _ = json.Marshal(result.Outline)
+	}
+	pe.Usage = models.ProcessingUsage{ProcessingMS: time.Since(start).Milliseconds()}.JSON()
+	pe.Status = "completed"
+
+	if payload.Redact {
+		r := p.redactor(ctx, pe.TextContent)
+		pe.TextContent = r.Text(pe.TextContent)
+		pe.MarkdownContent = r.Text(pe.MarkdownContent)
+		pe.Tables = r.JSON(pe.Tables)
+		pe.DocumentInfo = r.JSON(pe.DocumentInfo)
+		pe.Outline = r.JSON(pe.Outline)
+		pe.Redaction = p.saveRedaction(ctx, "pdf", pe.ID, r)
+	}
+
+	if err := p.db.UpdatePDFExtraction(ctx, pe); err != nil {
+		return fmt.Errorf("failed to save PDF extraction: %w", err)
+	}
+
+	p.notifyWebhook("pdf.completed", pe, pe.APIKeyID, pe.UserID)
+	p.notifySearchMatches("pdf", pe.ID)
+	p.publishStatus("pdf", pe.ID, pe.Status, "", pe.APIKeyID, pe.UserID)
+	log.Printf("✅ PDF extraction completed: %s (%d pages, %d words)", pe.OriginalName, pe.PageCount, pe.WordCount)
+	return nil
+}
+
+// extractPDF keeps the uploaded original, then extracts its text.
+func (p *Pool) extractPDF(ctx context.Context, pe *models.PDFExtraction, payload PDFPayload) (*pdfservice.ExtractionResult, error) {
+	file, err := os.Open(payload.TempFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	if !pdfservice.ValidatePDF(file) {
+		return nil, errors.New("the file does not appear to be a valid PDF")
+	}
+	if err := p.StoreOriginal(ctx, "pdf", pe.ID, pe.Filename, io.NewSectionReader(file, 0, info.Size()), info.Size()); err != nil {
+		log.Printf("⚠️  Failed to store original for PDF %s: %v", pe.ID, err)
+	}
+	return pdfservice.ExtractWithOptions(file, info.Size(), payload.Options)
+}
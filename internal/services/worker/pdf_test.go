@@ -0,0 +1,90 @@
+//go:build cgo
+
+// pdf_test.go contains tests for batch PDF extraction jobs, run against an
+// in-memory SQLite database.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/document"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+)
+
+// TestProcessPDFExtraction verifies queued PDFs are extracted, with how
+// long that took, or saved as failed, and their batch's counts follow.
+func TestProcessPDFExtraction(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.New("sqlite::memory:")
+	if err != nil {
+		t.Fatalf("database.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.RunMigrations("../../../migrations"); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	p := NewPool(1, 10, db, nil, nil)
+
+	batch := &models.Batch{Status: models.StatusPending, TotalCount: 2}
+	if err := db.CreateBatch(ctx, batch); err != nil {
+		t.Fatalf("CreateBatch: %v", err)
+	}
+	doc, err := document.New("Field Notes").Paragraph("Sparrows nest in the eaves.").PDF()
+	if err != nil {
+		t.Fatalf("PDF: %v", err)
+	}
+	queue := func(name string, data []byte) (*models.PDFExtraction, Job) {
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		pe := &models.PDFExtraction{Filename: name, OriginalName: name, Status: "pending", BatchID: &batch.ID}
+		if err := db.CreatePDFExtraction(ctx, pe); err != nil {
+			t.Fatalf("CreatePDFExtraction: %v", err)
+		}
+		payload, _ := json.Marshal(PDFPayload{PDFID: pe.ID, TempFilePath: path, Options: pdfservice.ExtractOptions{Markdown: true}})
+		return pe, Job{ID: pe.ID, Type: JobPDFExtraction, Payload: payload}
+	}
+	process := func(pe *models.PDFExtraction, job Job) *models.PDFExtraction {
+		p.processPDFExtraction(job)
+		var payload PDFPayload
+		json.Unmarshal(job.Payload, &payload)
+		if _, err := os.Stat(payload.TempFilePath); !os.IsNotExist(err) {
+			t.Errorf("temp file for %s still exists", pe.OriginalName)
+		}
+		got, err := db.GetPDFExtraction(ctx, pe.ID)
+		if err != nil {
+			t.Fatalf("GetPDFExtraction: %v", err)
+		}
+		return got
+	}
+	notes, notesJob := queue("notes.pdf", doc)
+	bogus, bogusJob := queue("bogus.pdf", []byte("not a PDF"))
+
+	notes = process(notes, notesJob)
+	if notes.Status != "completed" || notes.PageCount != 1 || !strings.Contains(notes.TextContent, "Sparrows") || !strings.Contains(notes.MarkdownContent, "Sparrows") {
+		t.Errorf("valid PDF extracted as %+v", notes)
+	}
+	var usage models.ProcessingUsage
+	if err := json.Unmarshal(notes.Usage, &usage); err != nil || usage.ProcessingMS < 0 || usage.WhisperMinutes != 0 {
+		t.Errorf("usage = %s (%v), want the extraction time", notes.Usage, err)
+	}
+	if b, _ := db.GetBatch(ctx, batch.ID); b.Status != models.StatusProcessing || b.CompletedCount != 1 {
+		t.Errorf("batch after one PDF = %+v, want processing", b)
+	}
+
+	bogus = process(bogus, bogusJob)
+	if bogus.Status != "failed" || bogus.ErrorMessage == "" || bogus.Usage != nil {
+		t.Errorf("invalid PDF extracted as %+v, want failed", bogus)
+	}
+	if b, _ := db.GetBatch(ctx, batch.ID); b.Status != models.StatusCompleted || b.CompletedCount != 1 || b.FailedCount != 1 {
+		t.Errorf("batch after both PDFs = %+v, want completed with one failure", b)
+	}
+}
@@ -0,0 +1,263 @@
+// pipeline.go runs pipelines: an API key's ordered steps for a video,
+// each started when the one before it finishes.
+//
+// A pipeline_step job runs a run's steps from its current one. Tag,
+// webhook, and notion steps finish within that job. Extract and summarize
+// steps queue their own job and stop there; when that job finishes it
+// resumes the run, which queues the next pipeline_step job. Every job a
+// run queues has the priority of the one that started it.
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/export"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/notion"
+)
+
+// errStepQueued stops a run at a step whose own job will resume it.
+var errStepQueued = errors.New("step queued")
+
+// SetNotionClient sets the client notion steps export with.
+func (p *Pool) SetNotionClient(c *notion.Client) {
+	p.notion = c
+}
+
+// processPipelineStep runs a pipeline run from its current step until it
+// completes, fails, or waits on a queued job.
+func (p *Pool) processPipelineStep(job Job) error {
+	ctx := p.ctx
+
+	run, err := p.db.GetPipelineRun(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline run: %w", err)
+	}
+	if run.Status == string(models.StatusCompleted) || run.Status == string(models.StatusFailed) {
+		return nil
+	}
+	var steps []models.PipelineRunStep
+	if err := json.Unmarshal(run.Steps, &steps); err != nil {
+		return p.failPipeline(run, steps, fmt.Errorf("invalid pipeline steps: %w", err))
+	}
+
+	if run.Status != string(models.StatusProcessing) {
+		run.Status = string(models.StatusProcessing)
+		p.publishStatus("pipeline", run.ID, run.Status, "", &run.APIKeyID, run.UserID)
+	}
+	for run.CurrentStep < len(steps) {
+		step := &steps[run.CurrentStep]
+		step.Status = string(models.StatusProcessing)
+		err := p.runPipelineStep(run, step, job.Priority)
+		if errors.Is(err, errStepQueued) {
+			return p.savePipelineRun(run, steps)
+		}
+		if err != nil {
+			return p.failPipeline(run, steps, err)
+		}
+		step.Status = string(models.StatusCompleted)
+		run.CurrentStep++
+	}
+
+	run.Status = string(models.StatusCompleted)
+	if err := p.savePipelineRun(run, steps); err != nil {
+		return err
+	}
+	p.publishStatus("pipeline", run.ID, run.Status, "", &run.APIKeyID, run.UserID)
+	return nil
+}
+
+// runPipelineStep runs one step of a run. It returns errStepQueued once a
+// step's own job is queued, with the given priority.
+func (p *Pool) runPipelineStep(run *models.PipelineRun, step *models.PipelineRunStep, priority bool) error {
+	ctx := p.ctx
+	if run.TranscriptID == nil {
+		return errors.New("the run's transcript was deleted")
+	}
+	t, err := p.db.GetTranscript(ctx, *run.TranscriptID)
+	if err != nil {
+		return fmt.Errorf("the run's transcript was deleted")
+	}
+	if step.Type != models.PipelineStepExtract && t.Status != models.StatusCompleted {
+		return fmt.Errorf("transcript is not ready (status: %s)", t.Status)
+	}
+
+	switch step.Type {
+	case models.PipelineStepExtract:
+		switch t.Status {
+		case models.StatusCompleted:
+			return nil // Reused an existing transcript
+		case models.StatusFailed:
+			return fmt.Errorf("extraction failed: %s", t.ErrorMessage)
+		}
+		payload, _ := json.Marshal(TranscriptPayload{PipelineRunID: run.ID})
+		return p.queuePipelineJob(Job{ID: t.ID, Type: JobTranscriptExtraction, Payload: payload, Priority: priority, CreatedAt: time.Now()})
+
+	case models.PipelineStepSummarize:
+		opts := step.Summary
+		if opts == nil {
+			opts = &models.SummaryOptions{}
+		}
+		s := &models.Summary{
+			TranscriptID: t.ID,
+			Length:       opts.Length,
+			Style:        opts.Style,
+			KeyPoints:    []byte("[]"),
+			Status:       models.StatusPending,
+		}
+		if err := p.db.CreateSummary(ctx, s); err != nil {
+			return fmt.Errorf("failed to create summary: %w", err)
+		}
+		step.SummaryID = s.ID
+		payload, _ := json.Marshal(SummaryPayload{
+			TranscriptID:   t.ID,
+			Model:          opts.Model,
+			FallbackModels: opts.FallbackModels,
+			Length:         opts.Length,
+			Style:          opts.Style,
+			Language:       opts.Language,
+			SummaryID:      s.ID,
+			APIKeyID:       run.APIKeyID,
+			PipelineRunID:  run.ID,
+		})
+		err := p.queuePipelineJob(Job{ID: t.ID, Type: JobSummaryGeneration, Payload: payload, Priority: priority, CreatedAt: time.Now()})
+		if !errors.Is(err, errStepQueued) {
+			p.db.SetSummaryStatus(ctx, s.ID, models.StatusFailed, err.Error())
+		}
+		return err
+
+	case models.PipelineStepTag:
+		return p.db.UpdateItemMetadata(ctx, "transcript", t.ID, models.UpdateItemRequest{Tags: mergeTags(t.Tags, step.Tags)})
+
+	case models.PipelineStepWebhook:
+		if p.webhooks != nil {
+			latest, _ := p.db.GetLatestSummary(ctx, t.ID)
+			p.webhooks.NotifyAPIKeyEvent(ctx, run.APIKeyID, "pipeline.webhook", map[string]interface{}{
+				"pipeline_run": run,
+				"transcript":   t,
+				"summary":      latest,
+			})
+		}
+		return nil
+
+	case models.PipelineStepNotion:
+		url, err := p.exportToNotion(run, t)
+		step.NotionURL = url
+		return err
+	}
+	return fmt.Errorf("unknown step type %q", step.Type)
+}
+
+// exportToNotion creates a page for the transcript and its latest summary
+// in the Notion integration of the run's user, returning its URL.
+func (p *Pool) exportToNotion(run *models.PipelineRun, t *models.Transcript) (string, error) {
+	if p.notion == nil {
+		return "", errors.New("Notion export isn't available")
+	}
+	if run.UserID == nil {
+		return "", errors.New("Notion export needs an API key linked to a user")
+	}
+	ctx := p.ctx
+	integration, err := p.db.GetIntegration(ctx, *run.UserID, notion.Provider)
+	if err != nil {
+		return "", errors.New("no notion integration configured")
+	}
+	var config map[string]string
+	json.Unmarshal(integration.Config, &config)
+
+	latest, _ := p.db.GetLatestSummary(ctx, t.ID)
+	page, err := p.notion.CreatePage(ctx, integration.Token, config["database_id"], export.TranscriptDocument(t, latest))
+	if page != nil {
+		return page.URL, err
+	}
+	return "", err
+}
+
+// queuePipelineJob queues the job of an extract or summarize step,
+// returning errStepQueued, or an error if the queue is full.
+func (p *Pool) queuePipelineJob(job Job) error {
+	if err := p.Submit(job); err != nil {
+		return err
+	}
+	return errStepQueued
+}
+
+// resumePipeline continues a run after its extract or summarize step's job
+// finishes: it fails the run if the job failed (stepErr), or else queues
+// the run's next step with the job's priority.
+func (p *Pool) resumePipeline(runID string, stepErr error, priority bool) {
+	if runID == "" {
+		return
+	}
+	run, err := p.db.GetPipelineRun(p.ctx, runID)
+	if err != nil {
+		log.Printf("⚠️  Failed to resume pipeline run %s: %v", runID, err)
+		return
+	}
+	var steps []models.PipelineRunStep
+	if err := json.Unmarshal(run.Steps, &steps); err != nil || run.CurrentStep >= len(steps) {
+		log.Printf("⚠️  Pipeline run %s has no step to resume", runID)
+		return
+	}
+	if stepErr != nil {
+		p.failPipeline(run, steps, stepErr)
+		return
+	}
+
+	steps[run.CurrentStep].Status = string(models.StatusCompleted)
+	run.CurrentStep++
+	if err := p.savePipelineRun(run, steps); err != nil {
+		log.Printf("⚠️  %v", err)
+		return
+	}
+	if err := p.Submit(Job{ID: run.ID, Type: JobPipelineStep, Priority: priority, CreatedAt: time.Now()}); err != nil {
+		p.failPipeline(run, steps, err)
+	}
+}
+
+// failPipeline marks a run and its current step failed, returning err.
+func (p *Pool) failPipeline(run *models.PipelineRun, steps []models.PipelineRunStep, err error) error {
+	if run.CurrentStep < len(steps) {
+		steps[run.CurrentStep].Status = string(models.StatusFailed)
+		steps[run.CurrentStep].Error = err.Error()
+	}
+	run.Status = string(models.StatusFailed)
+	run.ErrorMessage = err.Error()
+	if saveErr := p.savePipelineRun(run, steps); saveErr != nil {
+		log.Printf("⚠️  %v", saveErr)
+	}
+	p.publishStatus("pipeline", run.ID, run.Status, run.ErrorMessage, &run.APIKeyID, run.UserID)
+	return fmt.Errorf("pipeline run failed: %w", err)
+}
+
+// savePipelineRun stores a run's progress.
+func (p *Pool) savePipelineRun(run *models.PipelineRun, steps []models.PipelineRunStep) error {
+	if steps != nil {
+		run.Steps, _ = json.Marshal(steps)
+	}
+	return p.db.UpdatePipelineRun(p.ctx, run)
+}
+
+// mergeTags adds tags to an item's stored tags, skipping ones it has.
+func mergeTags(stored json.RawMessage, tags []string) *[]string {
+	var merged []string
+	json.Unmarshal(stored, &merged)
+	seen := make(map[string]bool, len(merged))
+	for _, tag := range merged {
+		seen[tag] = true
+	}
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	if merged == nil {
+		merged = []string{}
+	}
+	return &merged
+}
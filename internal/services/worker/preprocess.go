@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+)
+
+// SetAudioPreprocessor runs audio through ffmpeg before it's sent to
+// Whisper. A nil preprocessor sends audio as uploaded.
+func (p *Pool) SetAudioPreprocessor(pre *audio.Preprocessor) {
+	p.preprocessor = pre
+}
+
+// AudioPreprocessor returns the ffmpeg preprocessor, or nil if audio is
+// sent as uploaded.
+func (p *Pool) AudioPreprocessor() *audio.Preprocessor {
+	return p.preprocessor
+}
+
+// preprocessAudio returns the audio to send Whisper for file, its filename,
+// and a func that removes any temp files. ffmpeg reads from disk (some
+// containers can't be streamed), so a stored original is spooled to a temp
+// file first. If preprocessing fails the audio is sent as uploaded; an
+// error means the audio itself couldn't be read.
+func (p *Pool) preprocessAudio(ctx context.Context, id string, file io.Reader, filename string) (io.Reader, string, func(), error) {
+	var temps []*os.File
+	cleanup := func() {
+		for _, f := range temps {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}
+	if p.preprocessor == nil {
+		return file, filename, cleanup, nil
+	}
+
+	input, ok := file.(*os.File)
+	if !ok {
+		spool, err := os.CreateTemp("", "mta-audio-*"+filepath.Ext(filename))
+		if err != nil {
+			log.Printf("⚠️  Skipping audio preprocessing for %s: %v", id, err)
+			return file, filename, cleanup, nil
+		}
+		temps = append(temps, spool)
+		if _, err := io.Copy(spool, file); err != nil {
+			cleanup()
+			return nil, "", func() {}, fmt.Errorf("failed to read audio: %w", err)
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			cleanup()
+			return nil, "", func() {}, fmt.Errorf("failed to rewind audio: %w", err)
+		}
+		input = spool
+	}
+
+	path, err := p.preprocessor.Process(ctx, input.Name())
+	if err != nil {
+		log.Printf("⚠️  Audio preprocessing failed for %s, sending it as uploaded: %v", id, err)
+		return input, filename, cleanup, nil
+	}
+	processed, err := os.Open(path)
+	if err != nil {
+		os.Remove(path)
+		log.Printf("⚠️  Can't read preprocessed audio for %s, sending it as uploaded: %v", id, err)
+		return input, filename, cleanup, nil
+	}
+	temps = append(temps, processed)
+	return processed, strings.TrimSuffix(filename, filepath.Ext(filename)) + audio.PreprocessedExt, cleanup, nil
+}
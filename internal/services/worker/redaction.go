@@ -0,0 +1,37 @@
+// redaction.go masks personal information in transcripts before they're
+// stored, for jobs submitted with the redact option.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/redact"
+)
+
+// redactor prepares a redaction pass over text. Names are detected with the
+// summarizer when OpenRouter is configured; otherwise only emails, phones,
+// and cards are masked, and the report says names weren't checked.
+func (p *Pool) redactor(ctx context.Context, text string) *redact.Redactor {
+	var detector redact.NameDetector
+	if p.summarizer != nil && p.summarizer.IsConfigured() {
+		detector = p.summarizer
+	}
+	return redact.Prepare(ctx, detector, text)
+}
+
+// saveRedaction stores the redaction report for an item and returns it
+// for the in-memory record. Failures are logged; the masked text is what
+// matters and is saved either way.
+func (p *Pool) saveRedaction(ctx context.Context, itemType, id string, r *redact.Redactor) models.NullJSON {
+	report, err := json.Marshal(r.Report())
+	if err != nil {
+		return nil
+	}
+	if err := p.db.SaveRedaction(ctx, itemType, id, report); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	return report
+}
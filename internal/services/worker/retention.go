@@ -0,0 +1,63 @@
+// retention.go enforces per-key and per-user retention policies, deleting
+// items once they're older than their owner's policy allows.
+package worker
+
+import (
+	"log"
+	"time"
+)
+
+// purgeBatchSize is how many items of each type are purged per query.
+const purgeBatchSize = 100
+
+// SetPurgeInterval sets how often retention policies are enforced.
+// 0 (the default) never purges.
+func (p *Pool) SetPurgeInterval(interval time.Duration) {
+	p.purgeInterval = interval
+}
+
+// sweepRetention purges expired items, and erases accounts whose deletion
+// grace period has passed, until the pool stops.
+func (p *Pool) sweepRetention() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.purgeInterval)
+	defer ticker.Stop()
+	for {
+		p.purgeExpiredItems()
+		p.eraseDueAccounts()
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeExpiredItems deletes items past retention a batch at a time, along
+// with their stored originals. The database logs each purge as it deletes.
+func (p *Pool) purgeExpiredItems() {
+	total := 0
+	for {
+		purged, err := p.db.PurgeExpiredItems(p.ctx, purgeBatchSize)
+		for _, item := range purged {
+			if item.OriginalKey != nil && p.originals != nil {
+				if err := p.originals.Delete(p.ctx, *item.OriginalKey); err != nil {
+					log.Printf("⚠️  Failed to delete original %s of purged %s %s: %v", *item.OriginalKey, item.ItemType, item.ItemID, err)
+				}
+			}
+		}
+		total += len(purged)
+		if err != nil {
+			log.Printf("⚠️  Retention purge failed: %v", err)
+			break
+		}
+		// Fewer than a batch means no item type has more waiting
+		if len(purged) < purgeBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("🧹 Purged %d items past retention", total)
+	}
+}
@@ -0,0 +1,219 @@
+// watch.go runs the watch folder for self-hosted installs: a directory
+// (say, a NAS share of recordings) is scanned periodically, and new audio
+// files and PDFs are processed as if they'd been uploaded. Files are read
+// in place and never moved or deleted; each path is processed once.
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	pdfservice "github.com/Shimizu-Technology/media-tools-api/internal/services/pdf"
+)
+
+// watchSettle is how long a file must go unmodified before it's picked
+// up, so files still being copied in aren't read half-written.
+const watchSettle = 30 * time.Second
+
+// maxWatchedAudioSize is the largest audio file Whisper accepts (25MB).
+const maxWatchedAudioSize = 25 << 20
+
+// watchedTypes maps the extensions the watch folder picks up to the item
+// type they become: the formats the upload endpoints accept.
+var watchedTypes = map[string]string{
+	".mp3":  "audio",
+	".wav":  "audio",
+	".m4a":  "audio",
+	".ogg":  "audio",
+	".flac": "audio",
+	".webm": "audio",
+	".pdf":  "pdf",
+}
+
+// SetWatchFolder scans dir every interval for new audio files and PDFs.
+// Their items belong to the API key apiKeyID, or to no key when it's nil.
+// An empty dir (the default) turns the watch folder off.
+func (p *Pool) SetWatchFolder(dir string, interval time.Duration, apiKeyID *string) {
+	// Paths are recorded absolute, so they stay the same if the working
+	// directory changes between runs
+	if abs, err := filepath.Abs(dir); dir != "" && err == nil {
+		dir = abs
+	}
+	p.watchDir = dir
+	p.watchInterval = interval
+	p.watchKeyID = apiKeyID
+}
+
+// watchFolder scans the watch folder until the pool stops.
+func (p *Pool) watchFolder() {
+	defer p.wg.Done()
+
+	if p.audioTranscriber == nil || !p.audioTranscriber.IsConfigured() {
+		log.Printf("⚠️  Watch folder %s: audio files are skipped until OPENAI_API_KEY is set", p.watchDir)
+	}
+
+	ticker := time.NewTicker(p.watchInterval)
+	defer ticker.Stop()
+	var seen map[string]bool
+	for {
+		// The record of processed files is loaded once, and retried on
+		// later ticks if the database isn't reachable
+		if seen == nil {
+			var err error
+			if seen, err = p.db.ListWatchedPaths(p.ctx); err != nil {
+				log.Printf("⚠️  Watch folder: %v", err)
+			}
+		}
+		if seen != nil {
+			p.scanWatchFolder(seen)
+		}
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanWatchFolder processes the files under the watch folder that aren't
+// in seen, adding them to it. Hidden files and directories are skipped.
+func (p *Pool) scanWatchFolder(seen map[string]bool) {
+	audioEnabled := p.audioTranscriber != nil && p.audioTranscriber.IsConfigured()
+	picked := 0
+	err := filepath.WalkDir(p.watchDir, func(path string, d fs.DirEntry, err error) error {
+		if p.ctx.Err() != nil {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			log.Printf("⚠️  Watch folder: %v", err)
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != p.watchDir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || seen[path] {
+			return nil
+		}
+		itemType, ok := watchedTypes[strings.ToLower(filepath.Ext(path))]
+		if !ok || (itemType == "audio" && !audioEnabled) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || time.Since(info.ModTime()) < watchSettle {
+			return nil
+		}
+
+		f := &models.WatchedFile{Path: path, Size: info.Size(), ItemType: itemType}
+		if itemType == "audio" {
+			p.watchAudio(f)
+		} else {
+			p.watchPDF(f)
+		}
+		if err := p.db.RecordWatchedFile(p.ctx, f); err != nil {
+			log.Printf("⚠️  Watch folder: %v", err)
+		}
+		seen[path] = true // Even unrecorded, so it isn't processed twice
+		picked++
+		return nil
+	})
+	if err != nil {
+		log.Printf("⚠️  Watch folder scan failed: %v", err)
+	}
+	if picked > 0 {
+		log.Printf("📂 Watch folder: picked up %d new files", picked)
+	}
+}
+
+// watchAudio queues a watched audio file for transcription, waiting for
+// room in the queue. Problems are noted in f.ErrorMessage.
+func (p *Pool) watchAudio(f *models.WatchedFile) {
+	if f.Size > maxWatchedAudioSize {
+		f.ErrorMessage = fmt.Sprintf("audio files over %d MB can't be transcribed", maxWatchedAudioSize>>20)
+		return
+	}
+
+	at := &models.AudioTranscription{
+		Filename:     uuid.New().String() + strings.ToLower(filepath.Ext(f.Path)),
+		OriginalName: filepath.Base(f.Path),
+		Status:       "pending",
+		APIKeyID:     p.watchKeyID,
+	}
+	if err := p.db.CreateAudioTranscription(p.ctx, at); err != nil {
+		f.ErrorMessage = err.Error()
+		return
+	}
+	f.ItemID = &at.ID
+
+	payload, _ := json.Marshal(AudioPayload{
+		AudioID:      at.ID,
+		SourcePath:   f.Path,
+		OriginalName: at.OriginalName,
+	})
+	job := Job{
+		ID:        at.ID,
+		Type:      JobAudioTranscription,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+	if err := p.SubmitBlocking(p.ctx, job); err != nil {
+		at.Status = "failed"
+		at.ErrorMessage = "Failed to queue transcription: " + err.Error()
+		p.db.UpdateAudioTranscription(p.ctx, at)
+		f.ErrorMessage = at.ErrorMessage
+	}
+}
+
+// watchPDF extracts a watched PDF's text, saving the extraction whether it
+// succeeds or fails. Problems are noted in f.ErrorMessage.
+func (p *Pool) watchPDF(f *models.WatchedFile) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		f.ErrorMessage = err.Error()
+		return
+	}
+	defer file.Close()
+
+	pe := &models.PDFExtraction{
+		Filename:     uuid.New().String() + ".pdf",
+		OriginalName: filepath.Base(f.Path),
+		Status:       "completed",
+		APIKeyID:     p.watchKeyID,
+	}
+	if !pdfservice.ValidatePDF(file) {
+		err = errors.New("the file does not appear to be a valid PDF")
+	} else {
+		var result *pdfservice.ExtractionResult
+		if result, err = pdfservice.Extract(file, f.Size); err == nil {
+			pe.PageCount = result.PageCount
+			pe.TextContent = result.Text
+			pe.WordCount = result.WordCount
+		}
+	}
+	if err != nil {
+		pe.Status = "failed"
+		pe.ErrorMessage = err.Error()
+		f.ErrorMessage = "PDF text extraction failed: " + err.Error()
+	}
+
+	if err := p.db.CreatePDFExtraction(p.ctx, pe); err != nil {
+		f.ErrorMessage = err.Error()
+		return
+	}
+	f.ItemID = &pe.ID
+	if pe.Status == "completed" {
+		p.notifySearchMatches("pdf", pe.ID)
+	}
+}
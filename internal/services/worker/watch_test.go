@@ -0,0 +1,100 @@
+//go:build cgo
+
+// watch_test.go contains tests for the watch folder, run against an
+// in-memory SQLite database.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/database"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+)
+
+// TestScanWatchFolder verifies new audio files are queued and PDFs
+// extracted once each, while unsupported, hidden, and still-changing files
+// are left alone.
+func TestScanWatchFolder(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.New("sqlite::memory:")
+	if err != nil {
+		t.Fatalf("database.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.RunMigrations("../../../migrations"); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+
+	dir := t.TempDir()
+	settled := time.Now().Add(-time.Hour)
+	write := func(name string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte("not really media"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		os.Chtimes(path, modTime, modTime)
+		return path
+	}
+	memo := write("calls/memo.m4a", settled)
+	scan := write("scan.PDF", settled)
+	write("notes.txt", settled)
+	write(".hidden.mp3", settled)
+	write(".sync/partial.mp3", settled)
+	write("copying.wav", time.Now())
+
+	p := NewPool(1, 10, db, nil, nil)
+	p.SetAudioTranscriber(audio.NewTranscriber("sk-test"))
+	p.SetWatchFolder(dir, time.Minute, nil)
+
+	seen, err := db.ListWatchedPaths(ctx)
+	if err != nil {
+		t.Fatalf("ListWatchedPaths: %v", err)
+	}
+	p.scanWatchFolder(seen)
+	p.scanWatchFolder(seen) // Nothing new the second time
+
+	files, err := db.ListWatchedFiles(ctx, 10)
+	if err != nil || len(files) != 2 {
+		t.Fatalf("ListWatchedFiles = %+v, %v; want the audio file and the PDF", files, err)
+	}
+	byPath := map[string]int{}
+	for i, f := range files {
+		byPath[f.Path] = i
+	}
+
+	// The audio file is queued to be read in place
+	audioFile := files[byPath[memo]]
+	if audioFile.ItemType != "audio" || audioFile.ItemID == nil || audioFile.ErrorMessage != "" {
+		t.Errorf("audio file recorded as %+v", audioFile)
+	}
+	if p.QueueSize() != 1 {
+		t.Fatalf("QueueSize = %d, want 1", p.QueueSize())
+	}
+	var payload AudioPayload
+	json.Unmarshal((<-p.jobs).Payload, &payload)
+	if payload.SourcePath != memo || payload.TempFilePath != "" || payload.OriginalName != "memo.m4a" {
+		t.Errorf("audio payload = %+v, want %s read in place", payload, memo)
+	}
+
+	// The PDF isn't valid, so its extraction is saved as failed
+	pdfFile := files[byPath[scan]]
+	if pdfFile.ItemType != "pdf" || pdfFile.ItemID == nil || pdfFile.ErrorMessage == "" {
+		t.Errorf("PDF recorded as %+v, want a failed extraction", pdfFile)
+	}
+	if pe, err := db.GetPDFExtraction(ctx, *pdfFile.ItemID); err != nil || pe.Status != "failed" || pe.OriginalName != "scan.PDF" {
+		t.Errorf("PDF extraction = %+v, %v; want a failed scan.PDF", pe, err)
+	}
+
+	// A restart picks up where the last run left off
+	seen, _ = db.ListWatchedPaths(ctx)
+	p.scanWatchFolder(seen)
+	if files, _ := db.ListWatchedFiles(ctx, 10); len(files) != 2 {
+		t.Errorf("rescan after restart recorded %d files, want 2", len(files))
+	}
+}
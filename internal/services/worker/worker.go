@@ -17,15 +17,24 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Shimizu-Technology/media-tools-api/internal/database"
 	"github.com/Shimizu-Technology/media-tools-api/internal/models"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/billing"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/events"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/mailer"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/notion"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/storage"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/summary"
 	"github.com/Shimizu-Technology/media-tools-api/internal/services/transcript"
 	webhookservice "github.com/Shimizu-Technology/media-tools-api/internal/services/webhook"
@@ -38,42 +47,110 @@ const (
 	JobTranscriptExtraction  JobType = "transcript_extraction"
 	JobSummaryGeneration     JobType = "summary_generation"
 	JobAudioTranscription    JobType = "audio_transcription"
+	JobPDFExtraction         JobType = "pdf_extraction"
+	JobBulkExport            JobType = "bulk_export"
+	JobPipelineStep          JobType = "pipeline_step"
 )
 
+// DefaultJobTimeouts bounds how long each job type may run, unless
+// overridden with SetJobTimeouts or per job.
+var DefaultJobTimeouts = map[JobType]time.Duration{
+	JobTranscriptExtraction: 10 * time.Minute,
+	JobSummaryGeneration:    5 * time.Minute,
+	JobAudioTranscription:   10 * time.Minute,
+	JobPDFExtraction:        5 * time.Minute,
+	JobBulkExport:           30 * time.Minute,
+	JobPipelineStep:         2 * time.Minute,
+}
+
+// DefaultMaxJobTimeout caps per-job timeout overrides.
+const DefaultMaxJobTimeout = 2 * time.Hour
+
 // Job represents a unit of work to be processed by a worker.
 type Job struct {
 	ID        string          // The database record ID
 	Type      JobType
 	Payload   json.RawMessage // Flexible payload — different job types need different data
+	Timeout   time.Duration   // Overrides the job type's timeout when set
+	Priority  bool            // Picked up ahead of other jobs (tiers with queue priority)
 	CreatedAt time.Time
 }
 
 // SummaryPayload is the data needed for a summary generation job.
 type SummaryPayload struct {
-	TranscriptID string `json:"transcript_id"`
-	Model        string `json:"model"`
-	Length       string `json:"length"`
-	Style        string `json:"style"`
-	SummaryID    string `json:"summary_id"`
+	TranscriptID   string   `json:"transcript_id"`
+	Model          string   `json:"model"`
+	FallbackModels []string `json:"fallback_models"`
+	Length         string   `json:"length"`
+	Style          string   `json:"style"`
+	Language       string   `json:"language,omitempty"`
+	SummaryID      string   `json:"summary_id"`
+	ForceRefresh   bool     `json:"force_refresh,omitempty"`
+	APIKeyID       string   `json:"api_key_id,omitempty"` // Key whose AI spend the summary counts toward
+	PipelineRunID  string   `json:"pipeline_run_id,omitempty"` // Pipeline run to resume when the summary is done
+}
+
+// TranscriptPayload is the optional data for a transcript extraction job.
+// Jobs without a payload are extracted as is.
+type TranscriptPayload struct {
+	Redact        bool            `json:"redact"`
+	Summary       *SummaryPayload `json:"summary,omitempty"`         // Summary job to queue once extraction completes
+	PipelineRunID string          `json:"pipeline_run_id,omitempty"` // Pipeline run to resume once extraction completes
 }
 
 // AudioPayload is the data needed for an audio transcription job.
 // We store the temp file path instead of file bytes to avoid memory issues with large files.
 type AudioPayload struct {
-	AudioID      string `json:"audio_id"`
-	TempFilePath string `json:"temp_file_path"`
-	OriginalName string `json:"original_name"`
+	AudioID      string                  `json:"audio_id"`
+	TempFilePath string                  `json:"temp_file_path"` // Empty when reprocessing the stored original
+	SourcePath   string                  `json:"source_path"`    // A watch folder file, read in place and kept
+	OriginalName string                  `json:"original_name"`
+	Options      audio.TranscribeOptions `json:"options"`
+	Redact       bool                    `json:"redact"` // Mask PII in the transcript before it's stored
 }
 
+// ErrQueueFull is returned by Submit when the job queue has no room.
+var ErrQueueFull = errors.New("job queue is full; try again later")
+
+// Bounds for RetryAfter. defaultJobDuration stands in for the average job
+// duration until a job has finished.
+const (
+	defaultJobDuration = 30 * time.Second
+	minRetryAfter      = time.Second
+	maxRetryAfter      = 5 * time.Minute
+)
+
 // Pool manages a pool of worker goroutines.
 type Pool struct {
 	jobs            chan Job
+	priority        chan Job // Priority jobs, which workers take first
 	workers         int
+	workerMu        sync.Mutex              // Guards workers and quits while resizing
+	quits           []chan struct{}         // One per running worker; closed to retire it
+	nextWorkerID    int
 	db              *database.DB
 	extractor       transcript.Extractor
 	summarizer      *summary.Service
 	audioTranscriber *audio.Transcriber // Audio transcription via Whisper
+	preprocessor    *audio.Preprocessor     // ffmpeg pass before Whisper; nil sends audio as uploaded
 	webhooks        *webhookservice.Service // MTA-18: webhook notifications
+	notion          *notion.Client          // Notion exports for pipeline steps
+	billing         *billing.Reporter       // Reports Whisper minutes to Stripe; nil skips it
+	exportDir       string                  // Where bulk ZIP exports are written
+	events          *events.Broker          // Live status changes for the SSE stream
+	started         atomic.Bool             // Set between Start and Stop
+	avgJobNanos     atomic.Int64            // Moving average job duration, for backpressure estimates
+	timeouts        map[JobType]time.Duration
+	maxTimeout      time.Duration           // Cap on Job.Timeout overrides
+	originals       storage.Store           // Keeps uploaded originals; nil discards them
+	retention       time.Duration           // How long originals are kept; 0 = forever
+	purgeInterval   time.Duration           // How often retention policies are enforced; 0 = never
+	deletionGrace   time.Duration           // How long account deletions wait before erasure
+	watchDir        string                  // Scanned for new audio and PDFs; "" = off
+	watchInterval   time.Duration           // How often the watch folder is scanned
+	watchKeyID      *string                 // API key watched files' items belong to
+	digestInterval  time.Duration           // How often due weekly digests are sent; 0 = never
+	mailer          mailer.Mailer           // Emails digests; nil skips email delivery
 	wg              sync.WaitGroup
 	ctx             context.Context
 	cancel          context.CancelFunc
@@ -89,25 +166,110 @@ func (p *Pool) SetAudioTranscriber(at *audio.Transcriber) {
 	p.audioTranscriber = at
 }
 
-// notifyWebhook fires a webhook event if the service is configured.
-func (p *Pool) notifyWebhook(event string, data interface{}) {
+// SetBillingReporter reports audio transcriptions' minutes as metered
+// usage. A nil reporter reports nothing.
+func (p *Pool) SetBillingReporter(r *billing.Reporter) {
+	p.billing = r
+}
+
+// SetExportDir sets the directory bulk export archives are written to.
+func (p *Pool) SetExportDir(dir string) {
+	p.exportDir = dir
+}
+
+// notifyWebhook fires a webhook event about an item to its owner's
+// webhooks, if the service is configured.
+func (p *Pool) notifyWebhook(event string, data interface{}, apiKeyID, userID *string) {
 	if p.webhooks != nil {
-		p.webhooks.NotifyEvent(p.ctx, event, data)
+		p.webhooks.NotifyEvent(p.ctx, apiKeyID, userID, event, data)
 	}
 }
 
+// notifySearchMatches alerts saved searches that match a newly completed
+// item, if the webhook service is configured.
+func (p *Pool) notifySearchMatches(itemType, id string) {
+	if p.webhooks != nil {
+		p.webhooks.NotifySearchMatches(p.ctx, itemType, id)
+	}
+}
+
+// Events returns the broker that status changes are published to.
+func (p *Pool) Events() *events.Broker {
+	return p.events
+}
+
+// publishStatus announces a job status change to live subscribers.
+func (p *Pool) publishStatus(jobType, id, status, errMsg string, apiKeyID, userID *string) {
+	p.events.Publish(events.Event{
+		Type:     jobType,
+		ID:       id,
+		Status:   status,
+		Error:    errMsg,
+		APIKeyID: apiKeyID,
+		UserID:   userID,
+	})
+}
+
 // NewPool creates a new worker pool.
 func NewPool(workers, queueSize int, db *database.DB, ext transcript.Extractor, sum *summary.Service) *Pool {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Pool{
+	p := &Pool{
 		jobs:       make(chan Job, queueSize), // Buffered channel
+		priority:   make(chan Job, queueSize),
 		workers:    workers,
 		db:         db,
 		extractor:  ext,
 		summarizer: sum,
+		events:     events.NewBroker(),
 		ctx:        ctx,
 		cancel:     cancel,
 	}
+	p.SetJobTimeouts(nil, DefaultMaxJobTimeout)
+	return p
+}
+
+// SetJobTimeouts sets how long each job type may run, falling back to
+// DefaultJobTimeouts for types that aren't set, and the cap on per-job
+// overrides.
+func (p *Pool) SetJobTimeouts(timeouts map[JobType]time.Duration, max time.Duration) {
+	p.timeouts = make(map[JobType]time.Duration, len(DefaultJobTimeouts))
+	for jobType, d := range DefaultJobTimeouts {
+		if timeouts[jobType] > 0 {
+			d = timeouts[jobType]
+		}
+		p.timeouts[jobType] = d
+	}
+	p.maxTimeout = max
+}
+
+// MaxJobTimeout returns the longest timeout a job may request.
+func (p *Pool) MaxJobTimeout() time.Duration {
+	return p.maxTimeout
+}
+
+// jobTimeout returns how long a job may run: its own override, capped, or
+// its type's timeout.
+func (p *Pool) jobTimeout(job Job) time.Duration {
+	if job.Timeout > 0 {
+		return min(job.Timeout, p.maxTimeout)
+	}
+	return p.timeouts[job.Type]
+}
+
+// jobContext bounds a job's slow external work (yt-dlp, Whisper, LLM calls)
+// by its timeout. Status updates keep using p.ctx, so a job that times out
+// can still be marked failed.
+func (p *Pool) jobContext(job Job) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(p.ctx, p.jobTimeout(job))
+}
+
+// timeoutError explains a job failure caused by its deadline. yt-dlp killed
+// at the deadline otherwise only reports "signal: killed".
+func (p *Pool) timeoutError(jobCtx context.Context, job Job, err error) error {
+	if errors.Is(jobCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", p.jobTimeout(job))
+	}
+	return err
 }
 
 // Start launches the worker goroutines.
@@ -115,9 +277,27 @@ func NewPool(workers, queueSize int, db *database.DB, ext transcript.Extractor,
 // Each worker runs in its own goroutine, reading from the shared jobs channel.
 func (p *Pool) Start() {
 	log.Printf("🚀 Starting %d background workers", p.workers)
+	p.workerMu.Lock()
 	for i := 0; i < p.workers; i++ {
+		p.startWorker()
+	}
+	p.started.Store(true)
+	p.workerMu.Unlock()
+	if p.originals != nil && p.retention > 0 {
 		p.wg.Add(1)
-		go p.worker(i) // Launch worker goroutine
+		go p.sweepOriginals()
+	}
+	if p.purgeInterval > 0 {
+		p.wg.Add(1)
+		go p.sweepRetention()
+	}
+	if p.watchDir != "" && p.watchInterval > 0 {
+		p.wg.Add(1)
+		go p.watchFolder()
+	}
+	if p.digestInterval > 0 {
+		p.wg.Add(1)
+		go p.sweepDigests()
 	}
 }
 
@@ -125,8 +305,12 @@ func (p *Pool) Start() {
 // Go Pattern: Close the channel + cancel the context + wait for completion.
 func (p *Pool) Stop() {
 	log.Println("⏹️  Stopping workers...")
+	p.workerMu.Lock()
+	p.started.Store(false) // No more resizing
+	p.workerMu.Unlock()
 	p.cancel()     // Signal all workers to stop
 	close(p.jobs)  // Close the channel (workers will drain remaining jobs)
+	close(p.priority)
 	p.wg.Wait()    // Wait for all workers to finish
 	log.Println("✅ All workers stopped")
 }
@@ -137,11 +321,11 @@ func (p *Pool) Submit(job Job) error {
 	// Go Pattern: `select` with `default` makes channel operations non-blocking.
 	// Without default, sending to a full channel would block the HTTP handler.
 	select {
-	case p.jobs <- job:
+	case p.queueFor(job) <- job:
 		log.Printf("📥 Job queued: %s (type: %s)", job.ID, job.Type)
 		return nil
 	default:
-		return fmt.Errorf("job queue is full; try again later")
+		return ErrQueueFull
 	}
 }
 
@@ -149,7 +333,7 @@ func (p *Pool) Submit(job Job) error {
 // or the provided context is canceled.
 func (p *Pool) SubmitBlocking(ctx context.Context, job Job) error {
 	select {
-	case p.jobs <- job:
+	case p.queueFor(job) <- job:
 		log.Printf("📥 Job queued (blocking): %s (type: %s)", job.ID, job.Type)
 		return nil
 	case <-ctx.Done():
@@ -157,26 +341,143 @@ func (p *Pool) SubmitBlocking(ctx context.Context, job Job) error {
 	}
 }
 
-// QueueSize returns the current number of jobs in the queue.
+// queueFor returns the channel a job waits in. Priority jobs have a queue
+// of their own, the same size as the main one.
+func (p *Pool) queueFor(job Job) chan Job {
+	if job.Priority {
+		return p.priority
+	}
+	return p.jobs
+}
+
+// QueueSize returns the current number of jobs in the queue, priority
+// jobs included.
 func (p *Pool) QueueSize() int {
-	return len(p.jobs)
+	return len(p.jobs) + len(p.priority)
+}
+
+// QueueCapacity returns how many jobs the queue holds before Submit fails.
+func (p *Pool) QueueCapacity() int {
+	return cap(p.jobs)
+}
+
+// RetryAfter estimates how long a client should wait before resubmitting
+// a job the full queue rejected, in whole seconds.
+func (p *Pool) RetryAfter() time.Duration {
+	return estimateRetryAfter(p.QueueSize(), p.workers, time.Duration(p.avgJobNanos.Load()))
+}
+
+// estimateRetryAfter is the time for each worker to finish its current job
+// and its share of the queued ones, at the average job duration.
+func estimateRetryAfter(depth, workers int, avgJob time.Duration) time.Duration {
+	if avgJob <= 0 {
+		avgJob = defaultJobDuration
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	wait := avgJob * time.Duration(depth/workers+1)
+	wait = min(max(wait, minRetryAfter), maxRetryAfter)
+	return (wait + time.Second - 1).Truncate(time.Second)
+}
+
+// recordJobDuration folds a finished job into the moving average, weighting
+// the latest job at 1/5 so the estimate follows load changes without
+// swinging on one slow job.
+func (p *Pool) recordJobDuration(d time.Duration) {
+	for {
+		old := p.avgJobNanos.Load()
+		avg := int64(d)
+		if old != 0 {
+			avg = old + (int64(d)-old)/5
+		}
+		if p.avgJobNanos.CompareAndSwap(old, avg) {
+			return
+		}
+	}
+}
+
+// Extractor returns the transcript extractor the workers use.
+func (p *Pool) Extractor() transcript.Extractor {
+	return p.extractor
+}
+
+// Started reports whether the workers are running.
+func (p *Pool) Started() bool {
+	return p.started.Load()
 }
 
 // WorkerCount returns the number of workers.
 func (p *Pool) WorkerCount() int {
+	p.workerMu.Lock()
+	defer p.workerMu.Unlock()
 	return p.workers
 }
 
+// SetWorkerCount grows or shrinks the pool while it runs. Retired workers
+// finish their current job first. Before Start it just sets the count.
+func (p *Pool) SetWorkerCount(n int) {
+	if n < 1 {
+		n = 1
+	}
+	p.workerMu.Lock()
+	defer p.workerMu.Unlock()
+	if n == p.workers {
+		return
+	}
+	if p.started.Load() {
+		log.Printf("👷 Resizing worker pool from %d to %d", p.workers, n)
+		for len(p.quits) < n {
+			p.startWorker()
+		}
+		for len(p.quits) > n {
+			last := len(p.quits) - 1
+			close(p.quits[last])
+			p.quits = p.quits[:last]
+		}
+	}
+	p.workers = n
+}
+
+// startWorker launches one more worker goroutine. The caller holds workerMu.
+func (p *Pool) startWorker() {
+	quit := make(chan struct{})
+	p.quits = append(p.quits, quit)
+	p.wg.Add(1)
+	go p.worker(p.nextWorkerID, quit) // Launch worker goroutine
+	p.nextWorkerID++
+}
+
 // worker is the main loop for each worker goroutine.
-// It reads jobs from the channel and processes them.
-func (p *Pool) worker(id int) {
+// It reads jobs from the channel and processes them until the channel is
+// closed or quit is, when the pool shrinks.
+func (p *Pool) worker(id int, quit <-chan struct{}) {
 	defer p.wg.Done() // Signal completion when this worker exits
 
 	log.Printf("👷 Worker %d started", id)
 
-	// Go Pattern: `range` over a channel reads values until the channel is closed.
-	// This is the idiomatic way to consume from a channel.
-	for job := range p.jobs {
+	// Go Pattern: `select` waits on several channels at once — here, the
+	// next job or the signal to retire. It picks at random among ready
+	// channels, so priority jobs are checked for first on their own.
+	for {
+		var job Job
+		var ok bool
+		select {
+		case job, ok = <-p.priority:
+		default:
+			select {
+			case <-quit:
+				log.Printf("👷 Worker %d retired", id)
+				return
+			case job, ok = <-p.priority:
+			case job, ok = <-p.jobs:
+			}
+		}
+		if !ok {
+			log.Printf("👷 Worker %d stopped", id)
+			return
+		}
+
 		// Check if we should stop
 		select {
 		case <-p.ctx.Done():
@@ -190,6 +491,7 @@ func (p *Pool) worker(id int) {
 
 		// Go Pattern: Error handling — each job type has its own handler.
 		// We use a switch statement (like a match/case in other languages).
+		start := time.Now()
 		var err error
 		switch job.Type {
 		case JobTranscriptExtraction:
@@ -198,24 +500,38 @@ func (p *Pool) worker(id int) {
 			err = p.processSummary(job)
 		case JobAudioTranscription:
 			err = p.processAudioTranscription(job)
+		case JobPDFExtraction:
+			err = p.processPDFExtraction(job)
+		case JobBulkExport:
+			err = p.processExport(job)
+		case JobPipelineStep:
+			err = p.processPipelineStep(job)
 		default:
 			log.Printf("❌ Worker %d: unknown job type: %s", id, job.Type)
 		}
 
+		p.recordJobDuration(time.Since(start))
+
 		if err != nil {
 			log.Printf("❌ Worker %d: job %s failed: %v", id, job.ID, err)
 		} else {
 			log.Printf("✅ Worker %d: job %s completed", id, job.ID)
 		}
 	}
-
-	log.Printf("👷 Worker %d stopped", id)
 }
 
 // processTranscript handles transcript extraction jobs.
-func (p *Pool) processTranscript(job Job) error {
+func (p *Pool) processTranscript(job Job) (err error) {
 	ctx := p.ctx
 
+	var payload TranscriptPayload
+	if len(job.Payload) > 0 {
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			log.Printf("⚠️  Invalid transcript payload for %s: %v", job.ID, err)
+		}
+	}
+	defer func() { p.resumePipeline(payload.PipelineRunID, err, job.Priority) }()
+
 	// Get the transcript record from the database
 	t, err := p.db.GetTranscript(ctx, job.ID)
 	if err != nil {
@@ -227,17 +543,26 @@ func (p *Pool) processTranscript(job Job) error {
 	if err := p.db.UpdateTranscript(ctx, t); err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
+	p.publishStatus("transcript", t.ID, string(t.Status), "", t.APIKeyID, t.UserID)
 
 	// Extract the transcript
-	result, err := p.extractor.Extract(ctx, t.YouTubeID)
+	jobCtx, cancel := p.jobContext(job)
+	defer cancel()
+	start := time.Now()
+	result, err := p.extractor.Extract(jobCtx, t.YouTubeID)
 	if err != nil {
+		err = p.timeoutError(jobCtx, job, err)
 		t.Status = models.StatusFailed
 		t.ErrorMessage = err.Error()
 		p.db.UpdateTranscript(ctx, t)
-		p.notifyWebhook("transcript.failed", t) // MTA-18
+		p.notifyWebhook("transcript.failed", t, t.APIKeyID, t.UserID) // MTA-18
+		p.publishStatus("transcript", t.ID, string(t.Status), t.ErrorMessage, t.APIKeyID, t.UserID)
 		if t.BatchID != nil {
 			p.db.UpdateBatchCounts(ctx, *t.BatchID)
 		}
+		if payload.Summary != nil {
+			p.failSummary(payload.Summary.SummaryID, "Transcript extraction failed", t.APIKeyID, t.UserID)
+		}
 		return fmt.Errorf("extraction failed: %w", err)
 	}
 
@@ -247,30 +572,73 @@ func (p *Pool) processTranscript(job Job) error {
 	t.Language = result.Language
 	t.TranscriptText = result.Transcript
 	t.WordCount = result.WordCount
+	t.Segments = marshalSegments(result.Segments)
+	t.Usage = models.ProcessingUsage{ProcessingMS: time.Since(start).Milliseconds()}.JSON()
 	t.Status = models.StatusCompleted
 
+	// Mask PII before anything is stored, when requested
+	if payload.Redact {
+		r := p.redactor(jobCtx, t.TranscriptText)
+		t.TranscriptText = r.Text(t.TranscriptText)
+		t.Segments = r.JSON(t.Segments)
+		t.Redaction = p.saveRedaction(ctx, "transcript", t.ID, r)
+	}
+
 	if err := p.db.UpdateTranscript(ctx, t); err != nil {
 		return fmt.Errorf("failed to save transcript: %w", err)
 	}
 
-	p.notifyWebhook("transcript.completed", t) // MTA-18
+	p.notifyWebhook("transcript.completed", t, t.APIKeyID, t.UserID) // MTA-18
+	p.notifySearchMatches("transcript", t.ID)
+	p.publishStatus("transcript", t.ID, string(t.Status), "", t.APIKeyID, t.UserID)
 
-	if t.BatchID != nil {
-		if err := p.db.UpdateBatchCounts(ctx, *t.BatchID); err != nil {
-			log.Printf("⚠️  Failed to update batch counts for %s: %v", *t.BatchID, err)
-		}
-		// Check if batch completed
-		batch, batchErr := p.db.GetBatch(ctx, *t.BatchID)
-		if batchErr == nil && batch.Status == models.StatusCompleted {
-			p.notifyWebhook("batch.completed", batch)
-		}
-	}
+	p.updateBatch(t.BatchID, t.APIKeyID, t.UserID)
 
+	if payload.Summary != nil {
+		p.chainSummary(t, *payload.Summary, job.Priority)
+	}
 	return nil
 }
 
-// processSummary handles AI summary generation jobs.
-func (p *Pool) processSummary(job Job) error {
+// updateBatch recounts the batch an item belongs to, if any, after the
+// item finished, and announces the batch once every item is done. Batches
+// are owned by whoever submitted their items.
+func (p *Pool) updateBatch(batchID, apiKeyID, userID *string) {
+	if batchID == nil {
+		return
+	}
+	if err := p.db.UpdateBatchCounts(p.ctx, *batchID); err != nil {
+		log.Printf("⚠️  Failed to update batch counts for %s: %v", *batchID, err)
+	}
+	batch, err := p.db.GetBatch(p.ctx, *batchID)
+	if err == nil && batch.Status == models.StatusCompleted {
+		p.notifyWebhook("batch.completed", batch, apiKeyID, userID)
+	}
+}
+
+// chainSummary queues the summary job requested with a transcript
+// (auto_summarize) now that the transcript is ready, with the priority of
+// the extraction job.
+func (p *Pool) chainSummary(t *models.Transcript, s SummaryPayload, priority bool) {
+	s.TranscriptID = t.ID
+	data, _ := json.Marshal(s)
+	job := Job{
+		ID:        t.ID,
+		Type:      JobSummaryGeneration,
+		Payload:   data,
+		Priority:  priority,
+		CreatedAt: time.Now(),
+	}
+	if err := p.Submit(job); err != nil {
+		log.Printf("⚠️  Failed to queue summary of transcript %s: %v", t.ID, err)
+		p.failSummary(s.SummaryID, "Job queue is full, please try again later", t.APIKeyID, t.UserID)
+	}
+}
+
+// processSummary handles AI summary generation jobs. Summaries requested
+// through the API have a pending placeholder, which is moved through
+// processing to completed or failed; otherwise a new summary is created.
+func (p *Pool) processSummary(job Job) (err error) {
 	ctx := p.ctx
 
 	// Parse the job payload
@@ -278,26 +646,51 @@ func (p *Pool) processSummary(job Job) error {
 	if err := json.Unmarshal(job.Payload, &payload); err != nil {
 		return fmt.Errorf("invalid summary payload: %w", err)
 	}
+	defer func() { p.resumePipeline(payload.PipelineRunID, err, job.Priority) }()
 
 	// Get the transcript text
 	t, err := p.db.GetTranscript(ctx, payload.TranscriptID)
 	if err != nil {
+		p.failSummary(payload.SummaryID, "Transcript not found", nil, nil)
 		return fmt.Errorf("transcript not found: %w", err)
 	}
 
 	if t.Status != models.StatusCompleted {
+		p.failSummary(payload.SummaryID, "Transcript is not ready (status: "+string(t.Status)+")", t.APIKeyID, t.UserID)
 		return fmt.Errorf("transcript not ready (status: %s)", t.Status)
 	}
 
+	if payload.SummaryID != "" {
+		if err := p.db.SetSummaryStatus(ctx, payload.SummaryID, models.StatusProcessing, ""); err != nil {
+			log.Printf("⚠️  Failed to update summary status to processing: %v", err)
+		}
+		p.publishStatus("summary", payload.SummaryID, string(models.StatusProcessing), "", t.APIKeyID, t.UserID)
+	}
+
 	// Generate the summary
 	opts := summary.Options{
-		Model:  payload.Model,
-		Length: payload.Length,
-		Style:  payload.Style,
+		Model:          payload.Model,
+		FallbackModels: payload.FallbackModels,
+		Length:         payload.Length,
+		Style:          payload.Style,
+		Language:       payload.Language,
 	}
 
-	result, err := p.summarizer.Summarize(ctx, t.TranscriptText, opts)
+	jobCtx, cancel := p.jobContext(job)
+	defer cancel()
+	if payload.ForceRefresh {
+		jobCtx = summary.WithForceRefresh(jobCtx)
+	}
+	if payload.APIKeyID != "" {
+		jobCtx = summary.WithSpendKey(jobCtx, payload.APIKeyID)
+	}
+	tally := &summary.Tally{}
+	jobCtx = summary.WithTally(jobCtx, tally)
+	start := time.Now()
+	result, err := p.summarizer.Summarize(jobCtx, t.TranscriptText, opts)
 	if err != nil {
+		err = p.timeoutError(jobCtx, job, err)
+		p.failSummary(payload.SummaryID, err.Error(), t.APIKeyID, t.UserID)
 		return fmt.Errorf("summary generation failed: %w", err)
 	}
 
@@ -313,15 +706,30 @@ func (p *Pool) processSummary(job Job) error {
 		KeyPoints:    keyPointsJSON,
 		Length:       payload.Length,
 		Style:        payload.Style,
+		Status:       models.StatusCompleted,
+		Usage:        aiUsage(time.Since(start), tally).JSON(),
 	}
 
 	// If we have a pre-created summary ID, update it; otherwise create new
-	if payload.SummaryID != "" {
-		// Update existing placeholder
+	if payload.SummaryID == "" {
 		return p.db.CreateSummary(ctx, s)
 	}
+	if err := p.db.UpdateSummary(ctx, s); err != nil {
+		return err
+	}
+	p.publishStatus("summary", s.ID, string(s.Status), "", t.APIKeyID, t.UserID)
+	return nil
+}
 
-	return p.db.CreateSummary(ctx, s)
+// failSummary marks a summary placeholder failed, if the job has one.
+func (p *Pool) failSummary(id, errMsg string, apiKeyID, userID *string) {
+	if id == "" {
+		return
+	}
+	if err := p.db.SetSummaryStatus(p.ctx, id, models.StatusFailed, errMsg); err != nil {
+		log.Printf("⚠️  Failed to mark summary %s failed: %v", id, err)
+	}
+	p.publishStatus("summary", id, string(models.StatusFailed), errMsg, apiKeyID, userID)
 }
 
 // processAudioTranscription handles audio transcription jobs via Whisper API.
@@ -339,12 +747,42 @@ func (p *Pool) processAudioTranscription(job Job) error {
 	if err != nil {
 		return fmt.Errorf("failed to get audio transcription: %w", err)
 	}
+	defer p.updateBatch(at.BatchID, at.APIKeyID, at.UserID)
 
 	// Update status to processing
 	at.Status = "processing"
 	if err := p.db.UpdateAudioTranscription(ctx, at); err != nil {
 		log.Printf("⚠️  Failed to update audio status to processing: %v", err)
 	}
+	p.publishStatus("audio", at.ID, at.Status, "", at.APIKeyID, at.UserID)
+
+	// Watch folder files are read where they are, and left there
+	if payload.SourcePath != "" {
+		file, err := os.Open(payload.SourcePath)
+		if err != nil {
+			at.Status = "failed"
+			at.ErrorMessage = "Failed to read watched file: " + err.Error()
+			p.db.UpdateAudioTranscription(ctx, at)
+			p.publishStatus("audio", at.ID, at.Status, at.ErrorMessage, at.APIKeyID, at.UserID)
+			return fmt.Errorf("failed to open watched file: %w", err)
+		}
+		defer file.Close()
+		return p.transcribeAudio(ctx, job, at, file, payload)
+	}
+
+	// Reprocessing reads the stored original; new uploads read the temp file
+	if payload.TempFilePath == "" {
+		original, err := p.openOriginal(ctx, at.Filename)
+		if err != nil {
+			at.Status = "failed"
+			at.ErrorMessage = "Failed to read stored original: " + err.Error()
+			p.db.UpdateAudioTranscription(ctx, at)
+			p.publishStatus("audio", at.ID, at.Status, at.ErrorMessage, at.APIKeyID, at.UserID)
+			return fmt.Errorf("failed to open stored original: %w", err)
+		}
+		defer original.Close()
+		return p.transcribeAudio(ctx, job, at, original, payload)
+	}
 
 	// Open the temp file
 	file, err := os.Open(payload.TempFilePath)
@@ -352,6 +790,7 @@ func (p *Pool) processAudioTranscription(job Job) error {
 		at.Status = "failed"
 		at.ErrorMessage = "Failed to read uploaded file: " + err.Error()
 		p.db.UpdateAudioTranscription(ctx, at)
+		p.publishStatus("audio", at.ID, at.Status, at.ErrorMessage, at.APIKeyID, at.UserID)
 		return fmt.Errorf("failed to open temp file: %w", err)
 	}
 	defer func() {
@@ -360,40 +799,124 @@ func (p *Pool) processAudioTranscription(job Job) error {
 		os.Remove(payload.TempFilePath)
 	}()
 
+	// Keep the original before transcribing, so it can be reprocessed later
+	if info, err := file.Stat(); err == nil && p.originals != nil {
+		if err := p.StoreOriginal(ctx, "audio", at.ID, at.Filename, file, info.Size()); err != nil {
+			log.Printf("⚠️  Failed to store original for audio %s: %v", at.ID, err)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind temp file: %w", err)
+		}
+	}
+
+	return p.transcribeAudio(ctx, job, at, file, payload)
+}
+
+// transcribeAudio runs the transcription and saves the result.
+func (p *Pool) transcribeAudio(ctx context.Context, job Job, at *models.AudioTranscription, file io.Reader, payload AudioPayload) error {
+
 	// Check if transcriber is configured
 	if p.audioTranscriber == nil || !p.audioTranscriber.IsConfigured() {
 		at.Status = "failed"
 		at.ErrorMessage = "Audio transcription is not configured. Set OPENAI_API_KEY."
 		p.db.UpdateAudioTranscription(ctx, at)
+		p.publishStatus("audio", at.ID, at.Status, at.ErrorMessage, at.APIKeyID, at.UserID)
 		return fmt.Errorf("audio transcriber not configured")
 	}
 
+	jobCtx, cancel := p.jobContext(job)
+	defer cancel()
+	start := time.Now()
+
+	// Normalize (and maybe trim) with ffmpeg first, if configured
+	audioData, filename, cleanup, err := p.preprocessAudio(jobCtx, at.ID, file, payload.OriginalName)
+	defer cleanup()
+	if err != nil {
+		at.Status = "failed"
+		at.ErrorMessage = err.Error()
+		p.db.UpdateAudioTranscription(ctx, at)
+		p.publishStatus("audio", at.ID, at.Status, at.ErrorMessage, at.APIKeyID, at.UserID)
+		return err
+	}
+
 	// Call the Whisper API
-	result, err := p.audioTranscriber.Transcribe(ctx, file, payload.OriginalName)
+	result, err := p.audioTranscriber.TranscribeWithOptions(jobCtx, audioData, filename, payload.Options)
 	if err != nil {
+		err = p.timeoutError(jobCtx, job, err)
 		log.Printf("❌ Whisper transcription failed for %s: %v", payload.OriginalName, err)
 		at.Status = "failed"
 		at.ErrorMessage = err.Error()
 		p.db.UpdateAudioTranscription(ctx, at)
-		p.notifyWebhook("audio.failed", at)
+		p.notifyWebhook("audio.failed", at, at.APIKeyID, at.UserID)
+		p.publishStatus("audio", at.ID, at.Status, at.ErrorMessage, at.APIKeyID, at.UserID)
 		return fmt.Errorf("transcription failed: %w", err)
 	}
 
 	// Update the record with results
 	at.TranscriptText = result.Text
 	at.Language = result.Language
-	at.Duration = result.Duration
+	at.LanguageHint = payload.Options.Language
+	at.LanguageConfidence = result.LanguageConfidence
+	at.Translated = payload.Options.Translate
+	at.SourceText = result.SourceText
+	if result.Duration > 0 || at.Duration == 0 { // Plain-text models don't report duration
+		at.Duration = result.Duration
+	}
 	at.WordCount = audio.CountWords(result.Text)
+	at.Segments = marshalSegments(result.Segments)
 	at.Status = "completed"
+	whisperMinutes := int(math.Ceil(at.Duration / 60))
+	at.Usage = models.ProcessingUsage{
+		ProcessingMS:   time.Since(start).Milliseconds(),
+		WhisperMinutes: whisperMinutes,
+	}.JSON()
+
+	if payload.Redact {
+		r := p.redactor(jobCtx, at.TranscriptText)
+		at.TranscriptText = r.Text(at.TranscriptText)
+		at.SourceText = r.Text(at.SourceText)
+		at.Segments = r.JSON(at.Segments)
+		at.Redaction = p.saveRedaction(ctx, "audio", at.ID, r)
+	}
 
 	if err := p.db.UpdateAudioTranscription(ctx, at); err != nil {
 		log.Printf("⚠️  Failed to save audio transcription result: %v", err)
 		return fmt.Errorf("failed to save transcription: %w", err)
 	}
 
-	p.notifyWebhook("audio.completed", at)
+	if at.APIKeyID != nil {
+		p.billing.Report(ctx, *at.APIKeyID, billing.MeterTranscriptionMinutes, int64(whisperMinutes))
+	}
+	p.notifyWebhook("audio.completed", at, at.APIKeyID, at.UserID)
+	p.notifySearchMatches("audio", at.ID)
+	p.publishStatus("audio", at.ID, at.Status, "", at.APIKeyID, at.UserID)
 	log.Printf("✅ Audio transcription completed: %s (%s, %.0fs, %d words)",
 		payload.OriginalName, result.Language, result.Duration, at.WordCount)
 
 	return nil
 }
+
+// aiUsage is the usage of AI generation that took elapsed and made the
+// completions in tally.
+func aiUsage(elapsed time.Duration, tally *summary.Tally) models.ProcessingUsage {
+	used := tally.Usage()
+	return models.ProcessingUsage{
+		ProcessingMS:     elapsed.Milliseconds(),
+		PromptTokens:     used.PromptTokens,
+		CompletionTokens: used.CompletionTokens,
+		CostUSD:          used.CostUSD,
+	}
+}
+
+// marshalSegments encodes timed segments for storage.
+// Returns nil (stored as NULL) when there are none.
+func marshalSegments(segments []models.Segment) models.NullJSON {
+	if len(segments) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(segments)
+	if err != nil {
+		return nil
+	}
+	return data
+}
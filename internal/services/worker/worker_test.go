@@ -0,0 +1,204 @@
+// worker_test.go contains tests for worker pool backpressure estimates,
+// job timeouts, pool resizing, the priority queue, export item checks, and
+// audio preprocessing fallback.
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shimizu-Technology/media-tools-api/internal/models"
+	"github.com/Shimizu-Technology/media-tools-api/internal/services/audio"
+)
+
+// TestEstimateRetryAfter verifies the estimate scales with queue depth per
+// worker, rounds up to whole seconds, and stays within bounds.
+func TestEstimateRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		depth   int
+		workers int
+		avgJob  time.Duration
+		want    time.Duration
+	}{
+		{"no history uses default", 0, 3, 0, 30 * time.Second},
+		{"queued jobs per worker", 9, 3, 10 * time.Second, 40 * time.Second},
+		{"rounds up", 0, 3, 1500 * time.Millisecond, 2 * time.Second},
+		{"at least a second", 0, 3, time.Millisecond, time.Second},
+		{"capped", 100, 1, time.Minute, 5 * time.Minute},
+		{"no workers", 1, 0, 10 * time.Second, 20 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := estimateRetryAfter(tt.depth, tt.workers, tt.avgJob); got != tt.want {
+				t.Errorf("estimateRetryAfter(%d, %d, %v) = %v, want %v", tt.depth, tt.workers, tt.avgJob, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRecordJobDuration verifies the moving average starts at the first
+// job and moves a fifth of the way toward each later one.
+func TestRecordJobDuration(t *testing.T) {
+	p := &Pool{}
+	p.recordJobDuration(10 * time.Second)
+	if got := time.Duration(p.avgJobNanos.Load()); got != 10*time.Second {
+		t.Errorf("after first job = %v, want 10s", got)
+	}
+	p.recordJobDuration(20 * time.Second)
+	if got := time.Duration(p.avgJobNanos.Load()); got != 12*time.Second {
+		t.Errorf("after second job = %v, want 12s", got)
+	}
+}
+
+// TestJobTimeout verifies configured timeouts fill in per type, and job
+// overrides win but are capped.
+func TestJobTimeout(t *testing.T) {
+	p := NewPool(1, 1, nil, nil, nil)
+	p.SetJobTimeouts(map[JobType]time.Duration{JobTranscriptExtraction: time.Hour}, 90*time.Minute)
+
+	tests := []struct {
+		name string
+		job  Job
+		want time.Duration
+	}{
+		{"configured type", Job{Type: JobTranscriptExtraction}, time.Hour},
+		{"default type", Job{Type: JobSummaryGeneration}, DefaultJobTimeouts[JobSummaryGeneration]},
+		{"override", Job{Type: JobSummaryGeneration, Timeout: 20 * time.Minute}, 20 * time.Minute},
+		{"override capped", Job{Type: JobAudioTranscription, Timeout: 3 * time.Hour}, 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.jobTimeout(tt.job); got != tt.want {
+				t.Errorf("jobTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheckExportable verifies item exports skip unfinished items, items
+// of keys outside the organization, and other users' items, while account
+// exports include everything the user owns.
+func TestCheckExportable(t *testing.T) {
+	key1, key2, key3 := "key-1", "key-2", "key-3"
+	visible := map[string]bool{key1: true, key3: true} // key-1 and key-3 share an organization
+	user1, user2 := "user-1", "user-2"
+	userKeys := map[string]bool{key1: true} // key-1 is linked to user-1
+
+	tests := []struct {
+		name      string
+		export    models.Export
+		visible   map[string]bool
+		ownerKey  *string
+		ownerUser *string
+		status    string
+		wantErr   bool
+	}{
+		{"completed", models.Export{APIKeyID: &key1}, visible, &key1, nil, "completed", false},
+		{"pending", models.Export{APIKeyID: &key1}, visible, &key1, nil, "pending", true},
+		{"other key", models.Export{APIKeyID: &key1}, visible, &key2, nil, "completed", true},
+		{"organization key", models.Export{APIKeyID: &key1}, visible, &key3, nil, "completed", false},
+		{"user, own item", models.Export{UserID: &user1}, userKeys, nil, &user1, "completed", false},
+		{"user, linked key's item", models.Export{UserID: &user1}, userKeys, &key1, nil, "completed", false},
+		{"user, unowned item", models.Export{UserID: &user1}, userKeys, nil, nil, "completed", false},
+		{"user, other user's item", models.Export{UserID: &user1}, userKeys, nil, &user2, "completed", true},
+		{"user, other key's item", models.Export{UserID: &user1}, userKeys, &key2, nil, "completed", true},
+		{"account, pending", models.Export{Kind: models.ExportKindAccount}, nil, &key1, nil, "pending", false},
+		{"account, failed", models.Export{Kind: models.ExportKindAccount}, nil, nil, nil, "failed", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkExportable(&tt.export, tt.visible, tt.ownerKey, tt.ownerUser, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkExportable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSetWorkerCount verifies a running pool grows and shrinks, and still
+// stops cleanly with retired workers.
+func TestSetWorkerCount(t *testing.T) {
+	p := NewPool(2, 10, nil, nil, nil)
+	p.SetWorkerCount(3) // Before Start, only the count changes
+	p.Start()
+
+	for _, n := range []int{5, 1, 0, 4} {
+		p.SetWorkerCount(n)
+		want := max(n, 1)
+		if got := p.WorkerCount(); got != want {
+			t.Errorf("SetWorkerCount(%d): WorkerCount() = %d, want %d", n, got, want)
+		}
+		if got := len(p.quits); got != want {
+			t.Errorf("SetWorkerCount(%d): %d running workers, want %d", n, got, want)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop() didn't return")
+	}
+}
+
+// TestPriorityQueue verifies priority jobs wait in their own queue, which
+// counts toward the queue size, and that a full main queue doesn't turn
+// them away.
+func TestPriorityQueue(t *testing.T) {
+	p := NewPool(1, 1, nil, nil, nil)
+
+	if err := p.Submit(Job{ID: "a"}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := p.Submit(Job{ID: "b"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Submit to a full queue = %v, want ErrQueueFull", err)
+	}
+	if err := p.Submit(Job{ID: "c", Priority: true}); err != nil {
+		t.Fatalf("Submit(priority): %v", err)
+	}
+	if got := p.QueueSize(); got != 2 {
+		t.Errorf("QueueSize() = %d, want 2", got)
+	}
+	if job := <-p.priority; job.ID != "c" {
+		t.Errorf("priority queue held %q, want c", job.ID)
+	}
+}
+
+// TestPreprocessAudioFallback verifies streamed audio is spooled for ffmpeg,
+// sent as uploaded when preprocessing fails, and the spool is removed.
+func TestPreprocessAudioFallback(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	p := NewPool(1, 1, nil, nil, nil)
+
+	// Without a preprocessor the audio passes straight through
+	in := strings.NewReader("audio")
+	got, name, cleanup, err := p.preprocessAudio(context.Background(), "a1", in, "call.m4a")
+	cleanup()
+	if err != nil || got != io.Reader(in) || name != "call.m4a" {
+		t.Fatalf("no preprocessor: %v, %q, %v", got, name, err)
+	}
+
+	p.SetAudioPreprocessor(audio.NewPreprocessor(filepath.Join(tmp, "no-ffmpeg"), audio.PreprocessTrim))
+	got, name, cleanup, err = p.preprocessAudio(context.Background(), "a1", strings.NewReader("audio"), "call.m4a")
+	if err != nil || name != "call.m4a" {
+		t.Fatalf("failed preprocessing: %q, %v", name, err)
+	}
+	if data, _ := io.ReadAll(got); string(data) != "audio" {
+		t.Errorf("fallback audio = %q, want the upload", data)
+	}
+	cleanup()
+	if leftover, _ := filepath.Glob(filepath.Join(tmp, "mta-*")); len(leftover) > 0 {
+		t.Errorf("temp files left behind: %v", leftover)
+	}
+}
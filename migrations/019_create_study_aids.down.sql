@@ -0,0 +1,3 @@
+-- Rollback migration 019: drop study aids
+
+DROP TABLE IF EXISTS study_aids;
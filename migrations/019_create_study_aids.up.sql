@@ -0,0 +1,14 @@
+-- Migration 019: Study aids (flashcards + quizzes) generated from transcripts
+-- Each generation is kept so users can compare or re-export earlier sets.
+
+CREATE TABLE IF NOT EXISTS study_aids (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transcript_id  UUID NOT NULL REFERENCES transcripts(id) ON DELETE CASCADE,
+    api_key_id     UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    model_used     TEXT NOT NULL DEFAULT '',
+    flashcards     JSONB NOT NULL DEFAULT '[]',
+    quiz           JSONB NOT NULL DEFAULT '[]',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_study_aids_transcript_id ON study_aids(transcript_id);
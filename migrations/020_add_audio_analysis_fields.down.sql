@@ -0,0 +1,7 @@
+-- Rollback migration 020
+ALTER TABLE audio_transcriptions DROP CONSTRAINT IF EXISTS check_audio_analysis_status;
+
+ALTER TABLE audio_transcriptions
+    DROP COLUMN IF EXISTS analysis,
+    DROP COLUMN IF EXISTS analysis_model,
+    DROP COLUMN IF EXISTS analysis_status;
@@ -0,0 +1,12 @@
+-- Migration 020: Conversation analysis for phone calls and meetings
+-- Stores per-segment sentiment, talk-time per speaker, and flagged risk phrases
+-- next to the existing summary fields.
+
+ALTER TABLE audio_transcriptions
+    ADD COLUMN IF NOT EXISTS analysis         JSONB        NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS analysis_model   VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS analysis_status  VARCHAR(20)  NOT NULL DEFAULT 'none';
+
+ALTER TABLE audio_transcriptions
+    ADD CONSTRAINT check_audio_analysis_status
+    CHECK (analysis_status IN ('none', 'processing', 'completed', 'failed'));
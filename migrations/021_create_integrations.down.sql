@@ -0,0 +1,3 @@
+-- Rollback migration 021: drop task manager integrations
+
+DROP TABLE IF EXISTS integrations;
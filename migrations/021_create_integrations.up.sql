@@ -0,0 +1,16 @@
+-- Migration 021: Per-user task manager integrations
+-- Stores credentials for pushing action items to Todoist, Linear, or GitHub Issues.
+-- One integration per provider per user.
+
+CREATE TABLE IF NOT EXISTS integrations (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider    VARCHAR(20) NOT NULL CHECK (provider IN ('todoist', 'linear', 'github')),
+    token       TEXT NOT NULL,
+    config      JSONB NOT NULL DEFAULT '{}',  -- Provider settings, e.g. {"repo": "owner/name"}
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, provider)
+);
+
+CREATE INDEX IF NOT EXISTS idx_integrations_user_id ON integrations(user_id);
@@ -0,0 +1,4 @@
+-- Rollback migration 022
+ALTER TABLE audio_transcriptions
+    DROP COLUMN IF EXISTS minutes,
+    DROP COLUMN IF EXISTS minutes_model;
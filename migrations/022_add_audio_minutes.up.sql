@@ -0,0 +1,7 @@
+-- Migration 022: Meeting minutes for meeting-type audio transcriptions
+-- Stores the structured minutes (attendees, agenda, decisions, owned action items,
+-- next steps) so they can be re-exported as md/docx/pdf without another AI call.
+
+ALTER TABLE audio_transcriptions
+    ADD COLUMN IF NOT EXISTS minutes        JSONB        NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS minutes_model  VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Rollback migration 023: drop bulk exports
+
+DROP TABLE IF EXISTS exports;
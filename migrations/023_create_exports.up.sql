@@ -0,0 +1,22 @@
+-- Migration 023: Bulk ZIP exports
+-- A background job renders the requested items and writes a ZIP archive to disk.
+
+CREATE TABLE IF NOT EXISTS exports (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    api_key_id     UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    user_id        UUID REFERENCES users(id) ON DELETE SET NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending'
+                   CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    format         VARCHAR(10) NOT NULL,
+    items          JSONB NOT NULL DEFAULT '[]',  -- [{"type": "transcript", "id": "..."}]
+    item_count     INTEGER NOT NULL DEFAULT 0,
+    skipped_count  INTEGER NOT NULL DEFAULT 0,   -- Items deleted or not ready when the job ran
+    file_path      TEXT NOT NULL DEFAULT '',
+    file_size      BIGINT NOT NULL DEFAULT 0,
+    error_message  TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_exports_api_key_id ON exports(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_exports_user_id ON exports(user_id);
@@ -0,0 +1,4 @@
+-- Rollback migration 024: drop stored segments
+
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS segments;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS segments;
@@ -0,0 +1,6 @@
+-- Migration 024: Store timed segments for caption exports
+-- Each is a JSON array of {"start", "end", "text", "speaker"} with times in seconds.
+-- NULL for records processed before timings were kept.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS segments JSONB;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS segments JSONB;
@@ -0,0 +1,6 @@
+-- Rollback migration 025: remove Notion integrations
+
+DELETE FROM integrations WHERE provider = 'notion';
+ALTER TABLE integrations DROP CONSTRAINT IF EXISTS integrations_provider_check;
+ALTER TABLE integrations ADD CONSTRAINT integrations_provider_check
+    CHECK (provider IN ('todoist', 'linear', 'github'));
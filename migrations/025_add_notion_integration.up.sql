@@ -0,0 +1,6 @@
+-- Migration 025: Allow Notion integrations
+-- Notion stores {"database_id": "..."} in config; transcripts are exported there as pages.
+
+ALTER TABLE integrations DROP CONSTRAINT IF EXISTS integrations_provider_check;
+ALTER TABLE integrations ADD CONSTRAINT integrations_provider_check
+    CHECK (provider IN ('todoist', 'linear', 'github', 'notion'));
@@ -0,0 +1,3 @@
+-- Rollback migration 026: drop public share links
+
+DROP TABLE IF EXISTS share_links;
@@ -0,0 +1,21 @@
+-- Migration 026: Public share links
+-- A share link grants read-only access to one item without auth. Only the
+-- SHA-256 hash of the token is stored, like api_keys.
+
+CREATE TABLE IF NOT EXISTS share_links (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_hash    VARCHAR(64) NOT NULL UNIQUE,
+    token_prefix  VARCHAR(20) NOT NULL,
+    item_type     VARCHAR(20) NOT NULL CHECK (item_type IN ('transcript', 'audio', 'pdf')),
+    item_id       UUID NOT NULL,
+    api_key_id    UUID REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id       UUID REFERENCES users(id) ON DELETE CASCADE,
+    expires_at    TIMESTAMPTZ,                  -- NULL = never expires
+    revoked_at    TIMESTAMPTZ,
+    view_count    INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_item ON share_links(item_type, item_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_api_key_id ON share_links(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_user_id ON share_links(user_id);
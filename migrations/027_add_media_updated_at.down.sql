@@ -0,0 +1,6 @@
+-- Rollback migration 027: drop updated_at from audio transcriptions and PDF extractions
+
+DROP TRIGGER IF EXISTS update_audio_transcriptions_updated_at ON audio_transcriptions;
+DROP TRIGGER IF EXISTS update_pdf_extractions_updated_at ON pdf_extractions;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE pdf_extractions DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,22 @@
+-- Migration 027: Track updated_at on audio transcriptions and PDF extractions
+-- Single-item GETs send it as Last-Modified for conditional requests.
+
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- Existing rows haven't changed since they were created as far as we know
+UPDATE audio_transcriptions SET updated_at = created_at;
+UPDATE pdf_extractions SET updated_at = created_at;
+
+-- Reuse the trigger function from migration 011
+DROP TRIGGER IF EXISTS update_audio_transcriptions_updated_at ON audio_transcriptions;
+CREATE TRIGGER update_audio_transcriptions_updated_at
+    BEFORE UPDATE ON audio_transcriptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_pdf_extractions_updated_at ON pdf_extractions;
+CREATE TRIGGER update_pdf_extractions_updated_at
+    BEFORE UPDATE ON pdf_extractions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Rollback migration 028: drop user-editable item metadata
+
+ALTER TABLE transcripts DROP COLUMN IF EXISTS notes, DROP COLUMN IF EXISTS tags;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS title, DROP COLUMN IF EXISTS notes, DROP COLUMN IF EXISTS tags;
+ALTER TABLE pdf_extractions DROP COLUMN IF EXISTS title, DROP COLUMN IF EXISTS notes, DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,17 @@
+-- Migration 028: User-editable metadata on transcripts, audio, and PDFs
+-- Set with PATCH on each item. Audio and PDFs get a title too; empty means
+-- fall back to the uploaded filename.
+
+ALTER TABLE transcripts
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags  JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE audio_transcriptions
+    ADD COLUMN IF NOT EXISTS title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags  JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE pdf_extractions
+    ADD COLUMN IF NOT EXISTS title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags  JSONB NOT NULL DEFAULT '[]';
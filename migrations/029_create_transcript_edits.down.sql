@@ -0,0 +1,10 @@
+-- Rollback migration 029: drop transcript corrections
+-- Corrected text stays in transcripts.transcript_text; the originals are lost.
+
+ALTER TABLE study_aids DROP COLUMN IF EXISTS stale;
+ALTER TABLE summaries DROP COLUMN IF EXISTS stale;
+DROP TABLE IF EXISTS transcript_edits;
+ALTER TABLE transcripts
+    DROP COLUMN IF EXISTS original_text,
+    DROP COLUMN IF EXISTS original_segments,
+    DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,26 @@
+-- Migration 029: Manual transcript corrections
+-- The first correction copies the extracted text and segments into
+-- original_*, so the auto-generated version is never lost. Every
+-- correction is kept in transcript_edits as the version it produced.
+
+ALTER TABLE transcripts
+    ADD COLUMN IF NOT EXISTS original_text     TEXT,
+    ADD COLUMN IF NOT EXISTS original_segments JSONB,
+    ADD COLUMN IF NOT EXISTS edited_at         TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS transcript_edits (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transcript_id   UUID NOT NULL REFERENCES transcripts(id) ON DELETE CASCADE,
+    transcript_text TEXT NOT NULL,
+    segments        JSONB,
+    word_count      INTEGER NOT NULL DEFAULT 0,
+    api_key_id      UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    user_id         UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcript_edits_transcript_id ON transcript_edits(transcript_id, created_at DESC);
+
+-- Summaries and study aids made from the old text are flagged, not deleted
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS stale BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE study_aids ADD COLUMN IF NOT EXISTS stale BOOLEAN NOT NULL DEFAULT FALSE;
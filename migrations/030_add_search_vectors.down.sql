@@ -0,0 +1,9 @@
+-- Rollback migration 030: drop full-text search vectors
+
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_fts
+    ON audio_transcriptions
+    USING GIN (to_tsvector('english', transcript_text || ' ' || summary_text));
+
+ALTER TABLE transcripts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE pdf_extractions DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,31 @@
+-- Migration 030: Full-text search vectors on transcripts, audio, and PDFs
+-- Generated columns keep the vectors in sync on every write. Titles and
+-- filenames weigh more than body text in ranking. Bodies are capped at
+-- 500,000 characters so very long PDFs stay under tsvector's 1MB limit.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('english', channel_name), 'C') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B')
+    ) STORED;
+
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title || ' ' || original_name), 'A') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B') ||
+        setweight(to_tsvector('english', summary_text), 'C')
+    ) STORED;
+
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title || ' ' || original_name), 'A') ||
+        setweight(to_tsvector('english', left(text_content, 500000)), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_transcripts_search ON transcripts USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_search ON audio_transcriptions USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_search ON pdf_extractions USING GIN (search_vector);
+
+-- Audio search now uses search_vector, so the expression index from 009 is unused
+DROP INDEX IF EXISTS idx_audio_transcriptions_fts;
@@ -0,0 +1,3 @@
+-- Rollback migration 031: drop saved searches
+
+DROP TABLE IF EXISTS saved_searches;
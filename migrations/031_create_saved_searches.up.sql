@@ -0,0 +1,18 @@
+-- Migration 031: Saved searches
+-- A saved search is a full-text query plus item type filters. With notify
+-- set, each newly completed item that matches it fires a search.matched
+-- webhook to the owning API key.
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    api_key_id      UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    query           TEXT NOT NULL,
+    types           TEXT[] NOT NULL DEFAULT '{}',          -- Empty means all item types
+    notify          BOOLEAN NOT NULL DEFAULT FALSE,
+    last_matched_at TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_api_key_id ON saved_searches(api_key_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_notify ON saved_searches(api_key_id) WHERE notify = TRUE;
@@ -0,0 +1,4 @@
+-- Rollback migration 032: drop processed_at
+
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS processed_at;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS processed_at;
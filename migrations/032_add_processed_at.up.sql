@@ -0,0 +1,7 @@
+-- Migration 032: Record when transcripts and audio finish processing
+-- processed_at is set when a job reaches completed or failed, so the stats
+-- endpoint can report processing latency (processed_at - created_at).
+-- Existing rows are left NULL: updated_at may have moved since they finished.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS processed_at TIMESTAMPTZ;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS processed_at TIMESTAMPTZ;
@@ -0,0 +1,6 @@
+-- Rollback migration 033: drop original_stored_at
+
+DROP INDEX IF EXISTS idx_pdf_original_stored_at;
+DROP INDEX IF EXISTS idx_audio_original_stored_at;
+ALTER TABLE pdf_extractions DROP COLUMN IF EXISTS original_stored_at;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS original_stored_at;
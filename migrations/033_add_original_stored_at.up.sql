@@ -0,0 +1,10 @@
+-- Migration 033: Track uploads whose original file is kept in storage
+-- original_stored_at is set once the uploaded audio file or PDF is saved
+-- under its stored filename, and cleared when retention deletes it.
+
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS original_stored_at TIMESTAMPTZ;
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS original_stored_at TIMESTAMPTZ;
+
+-- The retention sweep looks for the oldest stored originals
+CREATE INDEX IF NOT EXISTS idx_audio_original_stored_at ON audio_transcriptions (original_stored_at) WHERE original_stored_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_pdf_original_stored_at ON pdf_extractions (original_stored_at) WHERE original_stored_at IS NOT NULL;
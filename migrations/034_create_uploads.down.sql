@@ -0,0 +1,3 @@
+-- Rollback migration 034: drop resumable uploads
+
+DROP TABLE IF EXISTS uploads;
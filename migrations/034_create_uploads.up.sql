@@ -0,0 +1,20 @@
+-- Migration 034: Resumable (tus) uploads
+-- Tracks how many bytes of each in-progress upload have arrived. The bytes
+-- themselves are appended to a file named by the upload ID; once complete,
+-- the upload becomes an audio transcription or PDF extraction (item_id).
+
+CREATE TABLE IF NOT EXISTS uploads (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_type        VARCHAR(20) NOT NULL CHECK (item_type IN ('audio', 'pdf')),
+    filename         TEXT NOT NULL,                -- Client's filename from Upload-Metadata
+    upload_length    BIGINT NOT NULL CHECK (upload_length >= 0),
+    upload_offset    BIGINT NOT NULL DEFAULT 0,
+    timeout_seconds  INTEGER NOT NULL DEFAULT 0,   -- Audio job timeout override; 0 = default
+    item_id          UUID,                         -- Set once the upload is complete and processed
+    api_key_id       UUID REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id          UUID REFERENCES users(id) ON DELETE CASCADE,
+    expires_at       TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_expires_at ON uploads(expires_at);
@@ -0,0 +1,6 @@
+-- Rollback migration 035: drop redaction
+
+ALTER TABLE uploads DROP COLUMN IF EXISTS redact;
+ALTER TABLE pdf_extractions DROP COLUMN IF EXISTS redaction;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS redaction;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS redaction;
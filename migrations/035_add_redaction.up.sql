@@ -0,0 +1,11 @@
+-- Migration 035: Record PII redaction applied before storage
+-- redaction holds the redaction report (counts by kind, whether names were
+-- checked) for items whose text was masked when saved. NULL means the text
+-- is stored as extracted.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS redaction JSONB;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS redaction JSONB;
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS redaction JSONB;
+
+-- Resumable uploads carry the redact option until they're processed
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS redact BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+-- Rollback migration 036: drop retention policies and the purge log
+
+DROP TABLE IF EXISTS purges;
+DROP TABLE IF EXISTS retention_policies;
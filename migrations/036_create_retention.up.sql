@@ -0,0 +1,33 @@
+-- Migration 036: Data retention policies and the purge audit log
+-- A retention policy belongs to one API key or one user; items they own are
+-- deleted once older than retention_days, unless saved to a workspace. When
+-- both the key's and the user's policy apply, the shorter one wins.
+
+CREATE TABLE IF NOT EXISTS retention_policies (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    api_key_id      UUID UNIQUE REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id         UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    retention_days  INTEGER NOT NULL CHECK (retention_days > 0),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((api_key_id IS NULL) <> (user_id IS NULL))
+);
+
+-- One row per purged item. Owner IDs aren't foreign keys so the log
+-- outlives revoked keys and deleted users.
+CREATE TABLE IF NOT EXISTS purges (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_type        VARCHAR(20) NOT NULL CHECK (item_type IN ('transcript', 'audio', 'pdf')),
+    item_id          UUID NOT NULL,
+    title            TEXT NOT NULL DEFAULT '',
+    api_key_id       UUID,
+    user_id          UUID,
+    retention_days   INTEGER NOT NULL,             -- The policy that expired the item
+    original_key     TEXT,                         -- Stored original deleted with the item, if any
+    item_created_at  TIMESTAMPTZ NOT NULL,
+    purged_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_purges_purged_at ON purges(purged_at DESC);
+CREATE INDEX IF NOT EXISTS idx_purges_api_key_id ON purges(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_purges_user_id ON purges(user_id);
@@ -0,0 +1,5 @@
+-- Rollback migration 037: drop account deletion scheduling and export kinds
+
+ALTER TABLE exports DROP COLUMN IF EXISTS kind;
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
@@ -0,0 +1,12 @@
+-- Migration 037: Account data export and deletion
+-- deletion_scheduled_at is set when a user asks for their account to be
+-- erased; the worker erases it once that time passes, unless cancelled.
+-- Account exports reuse the exports table, told apart by kind.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at
+    ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+ALTER TABLE exports ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'items'
+    CHECK (kind IN ('items', 'account'));
@@ -0,0 +1,7 @@
+-- Rollback migration 038: drop organizations, memberships, and invitations
+
+DROP INDEX IF EXISTS idx_api_keys_org_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS org_id;
+DROP TABLE IF EXISTS org_invitations;
+DROP TABLE IF EXISTS org_memberships;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,38 @@
+-- Migration 038: Organizations and team workspaces
+-- Users join organizations as owner, admin, or member. API keys created for
+-- an organization share visibility of everything the organization's keys
+-- create. Invitations are accepted with a one-time token; only its SHA-256
+-- hash is stored, like api_keys.
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS org_memberships (
+    org_id      UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role        VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_memberships_user_id ON org_memberships(user_id);
+
+CREATE TABLE IF NOT EXISTS org_invitations (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id       UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email        TEXT NOT NULL,
+    role         VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    token_hash   TEXT NOT NULL UNIQUE,
+    invited_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    accepted_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_invitations_org_id ON org_invitations(org_id);
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_api_keys_org_id ON api_keys(org_id) WHERE org_id IS NOT NULL;
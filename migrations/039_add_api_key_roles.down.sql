@@ -0,0 +1,2 @@
+-- Rollback migration 039: Remove API key roles
+ALTER TABLE api_keys DROP COLUMN IF EXISTS role;
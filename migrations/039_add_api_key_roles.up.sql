@@ -0,0 +1,8 @@
+-- Migration 039: API key roles
+-- Only admin keys manage other API keys and webhooks. Existing keys become
+-- members; the owner key (OWNER_API_KEY_ID / OWNER_API_KEY_PREFIX) is always
+-- treated as an admin, so a deployment keeps at least one way in.
+
+ALTER TABLE api_keys
+    ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'member'
+        CHECK (role IN ('admin', 'member'));
@@ -0,0 +1,4 @@
+-- Rollback migration 040: drop OAuth sign-in identities
+
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+DROP TABLE IF EXISTS user_identities;
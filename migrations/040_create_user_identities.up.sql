@@ -0,0 +1,15 @@
+-- Migration 040: OAuth sign-in identities
+-- Links a Google or GitHub account (by the provider's stable user ID) to a
+-- user. Users created through OAuth have an empty password_hash, so they
+-- can't log in with a password until one is set.
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider    VARCHAR(20) NOT NULL,
+    subject     VARCHAR(255) NOT NULL,                 -- Provider's user ID
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email       VARCHAR(255) NOT NULL DEFAULT '',      -- Verified email at link time
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
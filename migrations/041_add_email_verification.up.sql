@@ -0,0 +1,24 @@
+-- Migration 041: Email verification and password reset
+-- users.email_verified_at is set once a user follows their verification
+-- link (or signs in with a provider that verified the email). One-time
+-- tokens for both flows live in user_tokens; only their SHA-256 hash is
+-- stored, like api_keys.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+
+-- Provider-verified emails count as verified
+UPDATE users SET email_verified_at = NOW()
+WHERE email_verified_at IS NULL
+  AND id IN (SELECT user_id FROM user_identities);
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose     VARCHAR(20) NOT NULL CHECK (purpose IN ('verify_email', 'reset_password')),
+    token_hash  TEXT NOT NULL UNIQUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL,
+    used_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_id ON user_tokens(user_id, purpose);
@@ -0,0 +1,5 @@
+-- Rollback migration 042: drop login sessions
+
+DROP INDEX IF EXISTS idx_user_sessions_previous_token_hash;
+DROP INDEX IF EXISTS idx_user_sessions_user_id;
+DROP TABLE IF EXISTS user_sessions;
@@ -0,0 +1,21 @@
+-- Migration 042: Login sessions with refresh tokens
+-- Each login creates a session holding the hash of its current refresh
+-- token. Refreshing rotates the token; the previous hash is kept so a
+-- replayed (stolen) token can be spotted and the session revoked. Access
+-- tokens carry the session ID, so revoking a session ends them too.
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id              UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    refresh_token_hash   TEXT NOT NULL UNIQUE,
+    previous_token_hash  TEXT,
+    user_agent           TEXT NOT NULL DEFAULT '',
+    ip_address           TEXT NOT NULL DEFAULT '',
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at           TIMESTAMPTZ NOT NULL,
+    revoked_at           TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_previous_token_hash ON user_sessions(previous_token_hash);
@@ -0,0 +1,4 @@
+-- Rollback migration 043: drop per-key origin and IP restrictions
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS allowed_ips;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS allowed_origins;
@@ -0,0 +1,7 @@
+-- Migration 043: Per-key origin and IP restrictions
+-- A key with allowed_origins only works from those browser origins, and a
+-- key with allowed_ips only from those CIDR ranges, so a key leaked from a
+-- frontend can't be replayed from elsewhere. Empty lists mean unrestricted.
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS allowed_origins TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS allowed_ips TEXT[] NOT NULL DEFAULT '{}';
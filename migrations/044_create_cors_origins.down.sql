@@ -0,0 +1,3 @@
+-- Rollback migration 044: drop runtime CORS origins
+
+DROP TABLE IF EXISTS cors_origins;
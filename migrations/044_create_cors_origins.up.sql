@@ -0,0 +1,10 @@
+-- Migration 044: CORS origins added at runtime
+-- Origins here are allowed on top of CORS_ORIGIN. Admins add and remove
+-- them through the API (e.g. when a preview deployment goes up), and every
+-- instance picks changes up without a restart.
+
+CREATE TABLE IF NOT EXISTS cors_origins (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    origin      TEXT NOT NULL UNIQUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+-- Rollback migration 045: drop runtime settings
+
+DROP TABLE IF EXISTS runtime_settings;
@@ -0,0 +1,10 @@
+-- Migration 045: Runtime settings
+-- Admin overrides of selected settings (default model, rate limits, worker
+-- count, feature flags). Values are JSON; a missing row means the setting
+-- uses its configured value.
+
+CREATE TABLE IF NOT EXISTS runtime_settings (
+    key         TEXT PRIMARY KEY,
+    value       TEXT NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+-- Rollback migration 046: drop offloaded text keys
+-- Offloaded bodies stay in storage, and their rows are left with empty text.
+
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS text_key;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS original_text_key;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS text_key;
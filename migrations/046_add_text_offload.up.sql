@@ -0,0 +1,7 @@
+-- Migration 046: Offload large transcript bodies to object storage
+-- When a body is offloaded, transcript_text (or original_text) is left empty
+-- and the key of the storage object holding it is recorded here.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS text_key TEXT;
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS original_text_key TEXT;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS text_key TEXT;
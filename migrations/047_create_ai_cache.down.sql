@@ -0,0 +1,3 @@
+-- Rollback migration 047: drop the AI completion cache
+
+DROP TABLE IF EXISTS ai_cache;
@@ -0,0 +1,14 @@
+-- Migration 047: AI completion cache
+-- Completions keyed by a SHA-256 hash of the model chain and messages, so
+-- identical summary and chat requests don't pay OpenRouter twice. Rows
+-- past expires_at are ignored and pruned as new completions are cached.
+
+CREATE TABLE IF NOT EXISTS ai_cache (
+    key         TEXT PRIMARY KEY,
+    content     TEXT NOT NULL,
+    model       TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_cache_expires_at ON ai_cache (expires_at);
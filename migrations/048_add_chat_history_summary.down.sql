@@ -0,0 +1,5 @@
+-- Rollback migration 048: drop chat history summaries
+
+DROP INDEX IF EXISTS idx_chat_messages_session_created;
+ALTER TABLE transcript_chat_sessions DROP COLUMN IF EXISTS summarized_through_id;
+ALTER TABLE transcript_chat_sessions DROP COLUMN IF EXISTS history_summary;
@@ -0,0 +1,10 @@
+-- Migration 048: Rolling chat history summaries
+-- Long chat sessions condense their older turns into history_summary so
+-- prompts stay within context limits. The summary covers messages up to
+-- and including summarized_through_id.
+
+ALTER TABLE transcript_chat_sessions ADD COLUMN IF NOT EXISTS history_summary TEXT NOT NULL DEFAULT '';
+ALTER TABLE transcript_chat_sessions ADD COLUMN IF NOT EXISTS summarized_through_id UUID;
+
+-- Keyset pagination over a session's history
+CREATE INDEX IF NOT EXISTS idx_chat_messages_session_created ON transcript_chat_messages (session_id, created_at, id);
@@ -0,0 +1,5 @@
+-- Rollback migration 049: drop chat answer feedback
+
+ALTER TABLE transcript_chat_messages DROP COLUMN IF EXISTS feedback_at;
+ALTER TABLE transcript_chat_messages DROP COLUMN IF EXISTS feedback_comment;
+ALTER TABLE transcript_chat_messages DROP COLUMN IF EXISTS feedback;
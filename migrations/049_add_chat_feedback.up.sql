@@ -0,0 +1,7 @@
+-- Migration 049: Chat answer feedback
+-- Users rate assistant answers up or down, optionally with a comment, so
+-- answer quality can be compared by model and item type.
+
+ALTER TABLE transcript_chat_messages ADD COLUMN IF NOT EXISTS feedback VARCHAR(4) CHECK (feedback IN ('up', 'down'));
+ALTER TABLE transcript_chat_messages ADD COLUMN IF NOT EXISTS feedback_comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE transcript_chat_messages ADD COLUMN IF NOT EXISTS feedback_at TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+-- Migration 050: Suggested chat questions
+-- chat_suggestions holds the starter questions generated for an item's
+-- chat, and chat_suggestions_hash the SHA-256 of the text they were
+-- generated from, so corrected or reprocessed text gets fresh ones.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS chat_suggestions JSONB;
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS chat_suggestions_hash TEXT;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS chat_suggestions JSONB;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS chat_suggestions_hash TEXT;
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS chat_suggestions JSONB;
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS chat_suggestions_hash TEXT;
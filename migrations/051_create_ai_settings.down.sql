@@ -0,0 +1,3 @@
+-- Rollback migration 051: drop per-key and per-user AI defaults
+
+DROP TABLE IF EXISTS ai_settings;
@@ -0,0 +1,16 @@
+-- Migration 051: Per-key and per-user AI defaults
+-- Model, length, style, and language applied when an AI request leaves
+-- them out. Each row belongs to exactly one API key or one user; empty
+-- values fall through to the user's settings, then the server defaults.
+
+CREATE TABLE IF NOT EXISTS ai_settings (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    api_key_id  UUID UNIQUE REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id     UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    model       TEXT NOT NULL DEFAULT '',
+    length      TEXT NOT NULL DEFAULT '',
+    style       TEXT NOT NULL DEFAULT '',
+    language    TEXT NOT NULL DEFAULT '',
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((api_key_id IS NULL) <> (user_id IS NULL))
+);
@@ -0,0 +1,4 @@
+-- Rollback migration 052: drop AI spend accounting and limits
+
+DROP TABLE IF EXISTS spend_limits;
+DROP TABLE IF EXISTS ai_usage;
@@ -0,0 +1,25 @@
+-- Migration 052: AI spend accounting and per-key monthly limits
+-- ai_usage records the tokens and cost of each completion made for an API
+-- key. spend_limits caps a key's spend per calendar month (UTC): once it's
+-- reached, requests are blocked or downgraded to the budget model.
+-- warned_month is the last month a spend.warning webhook went out.
+
+CREATE TABLE IF NOT EXISTS ai_usage (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    api_key_id         UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    model              TEXT NOT NULL,
+    prompt_tokens      INTEGER NOT NULL DEFAULT 0,
+    completion_tokens  INTEGER NOT NULL DEFAULT 0,
+    cost_usd           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_usage_key_created ON ai_usage (api_key_id, created_at);
+
+CREATE TABLE IF NOT EXISTS spend_limits (
+    api_key_id         UUID PRIMARY KEY REFERENCES api_keys(id) ON DELETE CASCADE,
+    monthly_limit_usd  DOUBLE PRECISION NOT NULL CHECK (monthly_limit_usd > 0),
+    action             VARCHAR(10) NOT NULL DEFAULT 'block' CHECK (action IN ('block', 'downgrade')),
+    warned_month       VARCHAR(7) NOT NULL DEFAULT '',
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+-- Migration 053: Summary status tracking
+-- Summaries are created as pending placeholders when they're requested and
+-- move through processing to completed or failed, so clients can follow a
+-- summary by ID. Existing summaries were all generated successfully.
+
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'completed'
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed'));
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS error_message TEXT NOT NULL DEFAULT '';
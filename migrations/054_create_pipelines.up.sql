@@ -0,0 +1,34 @@
+-- Migration 054: Pipelines
+-- A pipeline is an API key's ordered list of steps to run on a YouTube
+-- video: extract, then any of summarize, tag, webhook, and notion. Each run
+-- keeps a copy of the steps with their progress, so editing a pipeline
+-- doesn't change runs already underway. user_id is whose Notion
+-- integration a notion step exports to.
+
+CREATE TABLE IF NOT EXISTS pipelines (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    api_key_id  UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    steps       JSONB NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipelines_api_key ON pipelines (api_key_id);
+
+CREATE TABLE IF NOT EXISTS pipeline_runs (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pipeline_id    UUID NOT NULL REFERENCES pipelines(id) ON DELETE CASCADE,
+    api_key_id     UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id        UUID REFERENCES users(id) ON DELETE SET NULL,
+    transcript_id  UUID REFERENCES transcripts(id) ON DELETE SET NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending'
+                   CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    current_step   INTEGER NOT NULL DEFAULT 0,
+    steps          JSONB NOT NULL DEFAULT '[]',
+    error_message  TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_runs_pipeline_created ON pipeline_runs (pipeline_id, created_at);
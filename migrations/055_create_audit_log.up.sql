@@ -0,0 +1,18 @@
+-- Migration 055: Audit log
+-- Records admin keys acting on other keys' data: impersonating a key with
+-- the X-Impersonate-Key header, or listing its items with ?api_key_id=.
+-- Entries outlive the keys they name.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_api_key_id   UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    target_api_key_id  UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    action             VARCHAR(30) NOT NULL,
+    method             VARCHAR(10) NOT NULL DEFAULT '',
+    path               TEXT NOT NULL DEFAULT '',
+    ip                 VARCHAR(64) NOT NULL DEFAULT '',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log (target_api_key_id, created_at);
@@ -0,0 +1,15 @@
+-- Migration 056: API key tiers
+-- A key's tier (free, pro, enterprise) bundles its rate limit, monthly job
+-- quota, queue priority, and features, replacing the per-key rate_limit.
+-- Existing keys get the smallest tier allowing at least their old limit.
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tier VARCHAR(20) NOT NULL DEFAULT 'free'
+    CHECK (tier IN ('free', 'pro', 'enterprise'));
+
+UPDATE api_keys SET tier = CASE
+    WHEN rate_limit > 1000 THEN 'enterprise'
+    WHEN rate_limit > 100 THEN 'pro'
+    ELSE 'free'
+END;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS rate_limit;
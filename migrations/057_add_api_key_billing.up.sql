@@ -0,0 +1,9 @@
+-- Migration 057: Stripe billing for API keys
+-- A key linked to a Stripe customer has its metered usage reported to
+-- Stripe, and is suspended while that customer's subscription is unpaid.
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS stripe_customer_id VARCHAR(255);
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS billing_suspended BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_stripe_customer ON api_keys(stripe_customer_id)
+    WHERE stripe_customer_id IS NOT NULL;
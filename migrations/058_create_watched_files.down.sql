@@ -0,0 +1,3 @@
+-- Rollback migration 058: Watch folder
+
+DROP TABLE IF EXISTS watched_files;
@@ -0,0 +1,16 @@
+-- Migration 058: Watch folder
+-- Records every file the watch folder picked up, so each is processed
+-- once, across restarts, and admins can see what became of it. Entries
+-- outlive the items they created.
+
+CREATE TABLE IF NOT EXISTS watched_files (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    path           TEXT NOT NULL UNIQUE,
+    size           BIGINT NOT NULL DEFAULT 0,
+    item_type      VARCHAR(20) NOT NULL,
+    item_id        UUID,
+    error_message  TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_watched_files_created ON watched_files (created_at);
@@ -0,0 +1,5 @@
+-- Rollback migration 059: Webhook payload modes
+
+ALTER TABLE webhooks DROP COLUMN IF EXISTS max_payload_bytes;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS payload_fields;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS payload_mode;
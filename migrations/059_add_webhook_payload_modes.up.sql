@@ -0,0 +1,8 @@
+-- Migration 059: Webhook payload modes
+-- Webhooks can receive the full item, its metadata without the bulky text
+-- fields, or an allowlist of fields. max_payload_bytes caps what is sent;
+-- 0 uses the server default.
+
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS payload_mode VARCHAR(20) NOT NULL DEFAULT 'full';
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS payload_fields TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS max_payload_bytes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Rollback migration 060: Webhook custom headers and basic auth
+
+ALTER TABLE webhooks DROP COLUMN IF EXISTS has_basic_auth;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS header_names;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS credentials;
@@ -0,0 +1,9 @@
+-- Migration 060: Webhook custom headers and basic auth
+-- Static headers and basic auth credentials sent with each delivery, for
+-- receivers behind gateways that require them. The values are sealed with
+-- the server's ENCRYPTION_KEY in credentials; header_names and
+-- has_basic_auth say what is set without revealing it.
+
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS credentials TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS header_names TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS has_basic_auth BOOLEAN NOT NULL DEFAULT FALSE;
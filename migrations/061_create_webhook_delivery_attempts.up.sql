@@ -0,0 +1,18 @@
+-- Migration 061: Webhook delivery attempts
+-- One row per HTTP attempt at a delivery, with the receiver's status code,
+-- the start of its response body, and how long it took, for debugging
+-- receivers that reject or time out.
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_id         UUID NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt             INTEGER NOT NULL,
+    response_code       INTEGER NOT NULL DEFAULT 0,              -- 0 when no response arrived
+    response_body       TEXT NOT NULL DEFAULT '',                -- First 4 KiB
+    response_truncated  BOOLEAN NOT NULL DEFAULT FALSE,
+    latency_ms          INTEGER NOT NULL DEFAULT 0,
+    error               TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempt);
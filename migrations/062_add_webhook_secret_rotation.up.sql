@@ -0,0 +1,7 @@
+-- Migration 062: Webhook secret rotation
+-- After a rotation, deliveries are also signed with the previous secret
+-- until previous_secret_expires_at, so receivers can switch secrets without
+-- rejecting events.
+
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS previous_secret VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS previous_secret_expires_at TIMESTAMPTZ;
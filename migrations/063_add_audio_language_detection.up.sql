@@ -0,0 +1,9 @@
+-- Migration 063: Audio language hints and detection confidence
+-- language_hint is the ISO-639-1 code the caller told Whisper to expect;
+-- language_confidence (0-1) estimates how sure Whisper was when it detected
+-- the language itself, and is NULL when it didn't. Resumable uploads carry
+-- the hint until they complete.
+
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS language_hint VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS language_confidence DOUBLE PRECISION;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS language VARCHAR(10) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- Rollback migration 064: Whisper translate-to-English mode
+
+ALTER TABLE uploads DROP COLUMN IF EXISTS keep_source;
+ALTER TABLE uploads DROP COLUMN IF EXISTS translate;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS source_text;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS translated;
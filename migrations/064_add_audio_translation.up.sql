@@ -0,0 +1,10 @@
+-- Migration 064: Whisper translate-to-English mode
+-- translated marks transcriptions whose transcript_text is Whisper's English
+-- translation; source_text holds the original-language transcript when a
+-- second pass was requested, and is empty otherwise. Resumable uploads carry
+-- both options until they complete.
+
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS translated BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS source_text TEXT NOT NULL DEFAULT '';
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS translate BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS keep_source BOOLEAN NOT NULL DEFAULT FALSE;
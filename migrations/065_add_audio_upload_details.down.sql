@@ -0,0 +1,16 @@
+-- Rollback migration 065: titles, notes, and custom metadata on audio uploads
+
+DROP INDEX IF EXISTS idx_audio_transcriptions_search;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title || ' ' || original_name), 'A') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B') ||
+        setweight(to_tsvector('english', summary_text), 'C')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_search ON audio_transcriptions USING GIN (search_vector);
+
+ALTER TABLE uploads DROP COLUMN IF EXISTS metadata;
+ALTER TABLE uploads DROP COLUMN IF EXISTS notes;
+ALTER TABLE uploads DROP COLUMN IF EXISTS title;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,21 @@
+-- Migration 065: Titles, notes, and custom metadata on audio uploads
+-- metadata is a flat JSON object of string values set at upload time (or
+-- with PATCH). The search vector is rebuilt so notes and metadata values
+-- are searchable alongside the title and transcript. Resumable uploads
+-- carry all three until they complete.
+
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS title TEXT NOT NULL DEFAULT '';
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+DROP INDEX IF EXISTS idx_audio_transcriptions_search;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title || ' ' || original_name), 'A') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B') ||
+        setweight(to_tsvector('english', notes) || jsonb_to_tsvector('english', metadata, '["string"]'), 'B') ||
+        setweight(to_tsvector('english', summary_text), 'C')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_search ON audio_transcriptions USING GIN (search_vector);
@@ -0,0 +1,6 @@
+-- Migration 066: Per-speaker summaries for diarized audio
+-- Stores what each speaker covered, committed to, and asked, generated
+-- alongside the summary when the transcript has speaker labels.
+
+ALTER TABLE audio_transcriptions
+    ADD COLUMN IF NOT EXISTS speaker_summaries JSONB NOT NULL DEFAULT '[]';
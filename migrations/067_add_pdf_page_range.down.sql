@@ -0,0 +1,4 @@
+-- Rollback migration 067
+ALTER TABLE pdf_extractions
+    DROP COLUMN IF EXISTS first_page,
+    DROP COLUMN IF EXISTS last_page;
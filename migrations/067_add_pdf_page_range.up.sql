@@ -0,0 +1,8 @@
+-- Migration 067: Record which pages of a PDF were extracted
+-- Extractions can cover a page range; existing ones covered the whole document.
+
+ALTER TABLE pdf_extractions
+    ADD COLUMN IF NOT EXISTS first_page INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_page  INTEGER NOT NULL DEFAULT 0;
+
+UPDATE pdf_extractions SET first_page = 1, last_page = page_count WHERE page_count > 0;
@@ -0,0 +1,4 @@
+-- Rollback migration 068
+ALTER TABLE pdf_extractions
+    DROP COLUMN IF EXISTS tables,
+    DROP COLUMN IF EXISTS table_count;
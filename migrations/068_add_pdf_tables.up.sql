@@ -0,0 +1,7 @@
+-- Migration 068: Tables detected in PDF extractions
+-- Stored as JSON ([{page, rows}]) so they can be downloaded as JSON or CSV
+-- without reading the PDF again.
+
+ALTER TABLE pdf_extractions
+    ADD COLUMN IF NOT EXISTS tables      JSONB   NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS table_count INTEGER NOT NULL DEFAULT 0;
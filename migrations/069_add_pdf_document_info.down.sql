@@ -0,0 +1,4 @@
+-- Rollback migration 069
+ALTER TABLE pdf_extractions
+    DROP COLUMN IF EXISTS document_info,
+    DROP COLUMN IF EXISTS outline;
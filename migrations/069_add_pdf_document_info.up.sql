@@ -0,0 +1,7 @@
+-- Migration 069: PDF document metadata and outline
+-- The title, author, and dates from the PDF's Info dictionary, and its
+-- bookmark tree with page numbers, so clients can build a table of contents.
+
+ALTER TABLE pdf_extractions
+    ADD COLUMN IF NOT EXISTS document_info JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS outline       JSONB NOT NULL DEFAULT '[]';
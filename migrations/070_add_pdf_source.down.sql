@@ -0,0 +1,4 @@
+-- Rollback migration 070
+ALTER TABLE pdf_extractions
+    DROP COLUMN IF EXISTS source_type,
+    DROP COLUMN IF EXISTS source_url;
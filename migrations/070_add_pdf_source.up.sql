@@ -0,0 +1,8 @@
+-- Migration 070: Sources of extractions
+-- Extractions now also come from EPUB uploads and web articles, and PDFs can
+-- be fetched from a URL. source_type says which; source_url is where a
+-- fetched PDF or article was downloaded from.
+
+ALTER TABLE pdf_extractions
+    ADD COLUMN IF NOT EXISTS source_type TEXT NOT NULL DEFAULT 'pdf',
+    ADD COLUMN IF NOT EXISTS source_url  TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Rollback migration 071
+ALTER TABLE pdf_extractions
+    DROP COLUMN IF EXISTS markdown_content;
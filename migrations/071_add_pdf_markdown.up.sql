@@ -0,0 +1,6 @@
+-- Migration 071: Markdown extractions
+-- Extractions requested with markdown=true also keep the text as Markdown,
+-- with headings, lists, and tables preserved. Empty when not requested.
+
+ALTER TABLE pdf_extractions
+    ADD COLUMN IF NOT EXISTS markdown_content TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- Rollback migration 072
+DROP INDEX IF EXISTS idx_pdf_extractions_batch_id;
+DROP INDEX IF EXISTS idx_audio_transcriptions_batch_id;
+ALTER TABLE pdf_extractions DROP COLUMN IF EXISTS batch_id;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS batch_id;
@@ -0,0 +1,10 @@
+-- Migration 072: Batches of audio files and PDFs
+-- Batches used to hold only transcripts. Uploaded audio files and PDFs can
+-- now be submitted together too, so both tables link to a batch the same
+-- way transcripts do.
+
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS batch_id UUID REFERENCES batches(id) ON DELETE SET NULL;
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS batch_id UUID REFERENCES batches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_batch_id ON audio_transcriptions(batch_id) WHERE batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_batch_id ON pdf_extractions(batch_id) WHERE batch_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Rollback migration 073: drop usage
+
+ALTER TABLE summaries DROP COLUMN IF EXISTS usage;
+ALTER TABLE pdf_extractions DROP COLUMN IF EXISTS usage;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS usage;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS usage;
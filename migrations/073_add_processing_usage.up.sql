@@ -0,0 +1,9 @@
+-- Migration 073: Record what processing each item took
+-- usage holds how long extraction, transcription, or generation ran and
+-- what it consumed: Whisper minutes, and the tokens and cost of AI
+-- completions. NULL means the item was processed before it was recorded.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS usage JSONB;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS usage JSONB;
+ALTER TABLE pdf_extractions ADD COLUMN IF NOT EXISTS usage JSONB;
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS usage JSONB;
@@ -0,0 +1,4 @@
+-- Rollback migration 074: drop content_hash
+
+DROP INDEX IF EXISTS idx_transcripts_content_hash;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS content_hash;
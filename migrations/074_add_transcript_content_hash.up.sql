@@ -0,0 +1,14 @@
+-- Migration 074: Content hashes for finding duplicate transcripts
+-- content_hash is the hex SHA-256 of the extracted transcript text, so
+-- copies of a video extracted more than once can be matched even when
+-- their youtube_id differs (e.g. a re-upload). Stored, uncorrected text is
+-- hashed here; other transcripts get one when next extracted.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+UPDATE transcripts
+SET content_hash = encode(sha256(convert_to(transcript_text, 'UTF8')), 'hex')
+WHERE content_hash IS NULL AND status = 'completed' AND transcript_text <> '' AND edited_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transcripts_content_hash
+    ON transcripts(content_hash) WHERE content_hash IS NOT NULL;
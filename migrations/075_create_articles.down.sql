@@ -0,0 +1,3 @@
+-- Rollback migration 075: drop article drafts
+
+DROP TABLE IF EXISTS articles;
@@ -0,0 +1,16 @@
+-- Migration 075: Article drafts generated from transcripts
+-- Each generation is kept, like study aids. The draft — title options,
+-- sections, pull quotes — is stored as JSONB so its shape can evolve
+-- without migrations.
+
+CREATE TABLE IF NOT EXISTS articles (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transcript_id  UUID NOT NULL REFERENCES transcripts(id) ON DELETE CASCADE,
+    api_key_id     UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    model_used     TEXT NOT NULL DEFAULT '',
+    content        JSONB NOT NULL DEFAULT '{}',
+    stale          BOOLEAN NOT NULL DEFAULT FALSE,               -- Transcript was corrected after generation
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_articles_transcript_id ON articles(transcript_id, created_at DESC);
@@ -0,0 +1,26 @@
+-- Migration 076: Weekly digests
+-- Users opt in to a weekly report on everything they processed — top
+-- themes, action items, notable quotes — delivered by email and/or
+-- webhook. Each digest is kept; its content is JSONB, like article drafts.
+-- A digest with no items marks a quiet week as done without delivering it.
+
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id     UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email       BOOLEAN NOT NULL DEFAULT TRUE,   -- Send each digest to the user's email address
+    webhook     BOOLEAN NOT NULL DEFAULT FALSE,  -- Send a digest.ready event to the user's webhooks
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS digests (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start  TIMESTAMPTZ NOT NULL,
+    period_end    TIMESTAMPTZ NOT NULL,
+    item_count    INTEGER NOT NULL DEFAULT 0,
+    model_used    TEXT NOT NULL DEFAULT '',
+    content       JSONB NOT NULL DEFAULT '{}',
+    delivered_at  TIMESTAMPTZ,                   -- Set once sent by email or webhook
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_digests_user_id ON digests(user_id, period_end DESC);
@@ -0,0 +1,27 @@
+-- Migration 077: Entities mentioned in items
+-- People, companies, products, and places extracted from items, each linked
+-- to the items that mention it. Like share_links, mentions point at items by
+-- type and ID without a foreign key; listings join the item tables, so
+-- deleted items drop out.
+
+CREATE TABLE IF NOT EXISTS entities (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name             VARCHAR(255) NOT NULL,
+    entity_type      VARCHAR(20) NOT NULL CHECK (entity_type IN ('person', 'company', 'product', 'place')),
+    normalized_name  VARCHAR(255) NOT NULL,    -- Lowercased, for matching
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (entity_type, normalized_name)
+);
+
+CREATE TABLE IF NOT EXISTS entity_mentions (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_id      UUID NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+    item_type      VARCHAR(20) NOT NULL CHECK (item_type IN ('transcript', 'audio', 'pdf')),
+    item_id        UUID NOT NULL,
+    mention_count  INTEGER NOT NULL DEFAULT 1,
+    context        TEXT NOT NULL DEFAULT '',  -- The sentence it's first mentioned in
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (entity_id, item_type, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_mentions_item ON entity_mentions(item_type, item_id);
@@ -0,0 +1,9 @@
+-- Migration 078: Chat sessions held by logged-in users
+-- Sessions were keyed only by API key, so every logged-in user chatting
+-- about an item shared its one key-less session. user_id gives each user
+-- their own; key-less sessions from before have no user and are left for
+-- the item's owner to erase.
+
+ALTER TABLE transcript_chat_sessions ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_chat_sessions_user_id ON transcript_chat_sessions(user_id);
@@ -0,0 +1,25 @@
+-- Rollback migration 079: offloaded transcript bodies in search vectors
+
+DROP INDEX IF EXISTS idx_transcripts_search;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE transcripts ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('english', channel_name), 'C') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_transcripts_search ON transcripts USING GIN (search_vector);
+
+DROP INDEX IF EXISTS idx_audio_transcriptions_search;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title || ' ' || original_name), 'A') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B') ||
+        setweight(to_tsvector('english', notes) || jsonb_to_tsvector('english', metadata, '["string"]'), 'B') ||
+        setweight(to_tsvector('english', summary_text), 'C')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_search ON audio_transcriptions USING GIN (search_vector);
+
+ALTER TABLE transcripts DROP COLUMN IF EXISTS offloaded_vector;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS offloaded_vector;
@@ -0,0 +1,31 @@
+-- Migration 079: Keep offloaded transcript bodies searchable
+-- An offloaded body leaves transcript_text empty, so the generated search
+-- vectors lost it. offloaded_vector holds the body's vector, computed from
+-- the text as it's offloaded, and the search vectors take it in. It's NULL
+-- for bodies kept in the row.
+
+ALTER TABLE transcripts ADD COLUMN IF NOT EXISTS offloaded_vector tsvector;
+ALTER TABLE audio_transcriptions ADD COLUMN IF NOT EXISTS offloaded_vector tsvector;
+
+DROP INDEX IF EXISTS idx_transcripts_search;
+ALTER TABLE transcripts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE transcripts ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('english', channel_name), 'C') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B') ||
+        COALESCE(offloaded_vector, ''::tsvector)
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_transcripts_search ON transcripts USING GIN (search_vector);
+
+DROP INDEX IF EXISTS idx_audio_transcriptions_search;
+ALTER TABLE audio_transcriptions DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title || ' ' || original_name), 'A') ||
+        setweight(to_tsvector('english', left(transcript_text, 500000)), 'B') ||
+        COALESCE(offloaded_vector, ''::tsvector) ||
+        setweight(to_tsvector('english', notes) || jsonb_to_tsvector('english', metadata, '["string"]'), 'B') ||
+        setweight(to_tsvector('english', summary_text), 'C')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_search ON audio_transcriptions USING GIN (search_vector);
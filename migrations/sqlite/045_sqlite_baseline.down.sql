@@ -0,0 +1,31 @@
+-- Rollback migration 045: drop the SQLite baseline schema
+
+DROP TABLE IF EXISTS runtime_settings;
+DROP TABLE IF EXISTS cors_origins;
+DROP TABLE IF EXISTS user_sessions;
+DROP TABLE IF EXISTS user_tokens;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS purges;
+DROP TABLE IF EXISTS retention_policies;
+DROP TABLE IF EXISTS uploads;
+DROP TABLE IF EXISTS saved_searches;
+DROP TABLE IF EXISTS transcript_edits;
+DROP TABLE IF EXISTS share_links;
+DROP TABLE IF EXISTS exports;
+DROP TABLE IF EXISTS integrations;
+DROP TABLE IF EXISTS study_aids;
+DROP TABLE IF EXISTS transcript_chat_messages;
+DROP TABLE IF EXISTS transcript_chat_sessions;
+DROP TABLE IF EXISTS workspace_items;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS pdf_extractions;
+DROP TABLE IF EXISTS audio_transcriptions;
+DROP TABLE IF EXISTS summaries;
+DROP TABLE IF EXISTS transcripts;
+DROP TABLE IF EXISTS batches;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS org_invitations;
+DROP TABLE IF EXISTS org_memberships;
+DROP TABLE IF EXISTS organizations;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,512 @@
+-- Migration 045: SQLite baseline
+-- The PostgreSQL schema as of migration 045, for the embedded SQLite
+-- backend used in local development and tests. From here on, every schema
+-- change needs a migration in both migrations/ and migrations/sqlite/,
+-- under the same number.
+--
+-- Type mapping: UUID → TEXT, TIMESTAMPTZ → TIMESTAMP (stored as UTC text),
+-- JSONB → TEXT, TEXT[] → TEXT holding a PostgreSQL array literal.
+-- NOW() and gen_random_uuid() are registered by the driver. search_vector
+-- is plain lowercased text, searched with LIKE instead of tsvector
+-- matching.
+
+CREATE TABLE IF NOT EXISTS users (
+    id                     TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    email                  VARCHAR(255) NOT NULL UNIQUE,
+    password_hash          VARCHAR(255) NOT NULL,
+    name                   VARCHAR(255) NOT NULL DEFAULT '',
+    created_at             TIMESTAMP NOT NULL DEFAULT (NOW()),
+    deletion_scheduled_at  TIMESTAMP,
+    email_verified_at      TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at
+    ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    name        TEXT NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE IF NOT EXISTS org_memberships (
+    org_id      TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id     TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role        VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_memberships_user_id ON org_memberships(user_id);
+
+CREATE TABLE IF NOT EXISTS org_invitations (
+    id           TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    org_id       TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email        TEXT NOT NULL,
+    role         VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    token_hash   TEXT NOT NULL UNIQUE,
+    invited_by   TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT (NOW()),
+    expires_at   TIMESTAMP NOT NULL,
+    accepted_at  TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_invitations_org_id ON org_invitations(org_id);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id               TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    key_hash         VARCHAR(64) NOT NULL UNIQUE,
+    key_prefix       VARCHAR(12) NOT NULL,
+    name             VARCHAR(100) NOT NULL,
+    active           BOOLEAN NOT NULL DEFAULT TRUE,
+    rate_limit       INTEGER NOT NULL DEFAULT 100,
+    created_at       TIMESTAMP NOT NULL DEFAULT (NOW()),
+    last_used_at     TIMESTAMP,
+    user_id          TEXT REFERENCES users(id) ON DELETE SET NULL,
+    org_id           TEXT REFERENCES organizations(id) ON DELETE SET NULL,
+    role             VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member')),
+    allowed_origins  TEXT NOT NULL DEFAULT '{}',
+    allowed_ips      TEXT NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys(key_hash) WHERE active = TRUE;
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_api_keys_org_id ON api_keys(org_id) WHERE org_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_api_keys_created_at ON api_keys(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS batches (
+    id              TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    total_count     INTEGER NOT NULL DEFAULT 0,
+    completed_count INTEGER NOT NULL DEFAULT 0,
+    failed_count    INTEGER NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP NOT NULL DEFAULT (NOW()),
+    updated_at      TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_batches_status ON batches(status);
+CREATE INDEX IF NOT EXISTS idx_batches_created_at ON batches(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_batches_updated_at ON batches(updated_at DESC);
+
+CREATE TABLE IF NOT EXISTS transcripts (
+    id                 TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    youtube_url        TEXT NOT NULL,
+    youtube_id         VARCHAR(20) NOT NULL,
+    title              TEXT NOT NULL DEFAULT '',
+    channel_name       TEXT NOT NULL DEFAULT '',
+    duration           INTEGER NOT NULL DEFAULT 0,
+    language           VARCHAR(50) NOT NULL DEFAULT 'en',
+    transcript_text    TEXT NOT NULL DEFAULT '',
+    word_count         INTEGER NOT NULL DEFAULT 0,
+    status             VARCHAR(20) NOT NULL DEFAULT 'pending'
+                       CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    error_message      TEXT NOT NULL DEFAULT '',
+    created_at         TIMESTAMP NOT NULL DEFAULT (NOW()),
+    updated_at         TIMESTAMP NOT NULL DEFAULT (NOW()),
+    batch_id           TEXT REFERENCES batches(id) ON DELETE SET NULL,
+    user_id            TEXT REFERENCES users(id) ON DELETE SET NULL,
+    api_key_id         TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    segments           TEXT,
+    notes              TEXT NOT NULL DEFAULT '',
+    tags               TEXT NOT NULL DEFAULT '[]',
+    original_text      TEXT,
+    original_segments  TEXT,
+    edited_at          TIMESTAMP,
+    processed_at       TIMESTAMP,
+    redaction          TEXT,
+    search_vector      TEXT GENERATED ALWAYS AS (
+        lower(title || ' ' || channel_name || ' ' || transcript_text)
+    ) VIRTUAL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcripts_youtube_id ON transcripts(youtube_id);
+CREATE INDEX IF NOT EXISTS idx_transcripts_status ON transcripts(status);
+CREATE INDEX IF NOT EXISTS idx_transcripts_created_at ON transcripts(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transcripts_batch_id ON transcripts(batch_id) WHERE batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transcripts_user_id ON transcripts(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transcripts_batch_status ON transcripts(batch_id, status) WHERE batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transcripts_user_created ON transcripts(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transcripts_api_key_id ON transcripts(api_key_id);
+
+CREATE TABLE IF NOT EXISTS summaries (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    transcript_id  TEXT NOT NULL REFERENCES transcripts(id) ON DELETE CASCADE,
+    model_used     VARCHAR(100) NOT NULL,
+    prompt_used    TEXT NOT NULL DEFAULT '',
+    summary_text   TEXT NOT NULL DEFAULT '',
+    key_points     TEXT DEFAULT '[]',
+    length         VARCHAR(20) NOT NULL DEFAULT 'medium',
+    style          VARCHAR(20) NOT NULL DEFAULT 'bullet',
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW()),
+    stale          BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_summaries_transcript_id ON summaries(transcript_id);
+CREATE INDEX IF NOT EXISTS idx_summaries_transcript_created ON summaries(transcript_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_summaries_created_at ON summaries(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS audio_transcriptions (
+    id                  TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    filename            VARCHAR(255) NOT NULL,
+    original_name       VARCHAR(500) NOT NULL,
+    duration            REAL NOT NULL DEFAULT 0,
+    language            VARCHAR(50) NOT NULL DEFAULT '',
+    transcript_text     TEXT NOT NULL DEFAULT '',
+    word_count          INTEGER NOT NULL DEFAULT 0,
+    status              VARCHAR(20) NOT NULL DEFAULT 'pending'
+                        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    error_message       TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMP NOT NULL DEFAULT (NOW()),
+    user_id             TEXT REFERENCES users(id) ON DELETE SET NULL,
+    content_type        VARCHAR(30) NOT NULL DEFAULT 'general',
+    summary_text        TEXT NOT NULL DEFAULT '',
+    key_points          TEXT NOT NULL DEFAULT '[]',
+    action_items        TEXT NOT NULL DEFAULT '[]',
+    decisions           TEXT NOT NULL DEFAULT '[]',
+    summary_model       VARCHAR(100) NOT NULL DEFAULT '',
+    summary_status      VARCHAR(20) NOT NULL DEFAULT 'none'
+                        CHECK (summary_status IN ('none', 'processing', 'completed', 'failed')),
+    api_key_id          TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    analysis            TEXT NOT NULL DEFAULT '{}',
+    analysis_model      VARCHAR(100) NOT NULL DEFAULT '',
+    analysis_status     VARCHAR(20) NOT NULL DEFAULT 'none'
+                        CHECK (analysis_status IN ('none', 'processing', 'completed', 'failed')),
+    minutes             TEXT NOT NULL DEFAULT '{}',
+    minutes_model       VARCHAR(100) NOT NULL DEFAULT '',
+    segments            TEXT,
+    updated_at          TIMESTAMP NOT NULL DEFAULT (NOW()),
+    title               TEXT NOT NULL DEFAULT '',
+    notes               TEXT NOT NULL DEFAULT '',
+    tags                TEXT NOT NULL DEFAULT '[]',
+    processed_at        TIMESTAMP,
+    original_stored_at  TIMESTAMP,
+    redaction           TEXT,
+    search_vector       TEXT GENERATED ALWAYS AS (
+        lower(title || ' ' || original_name || ' ' || transcript_text || ' ' || summary_text)
+    ) VIRTUAL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_status ON audio_transcriptions(status);
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_created_at ON audio_transcriptions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_user_id ON audio_transcriptions(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_content_type ON audio_transcriptions(content_type);
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_filename ON audio_transcriptions(filename);
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_api_key_id ON audio_transcriptions(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_audio_original_stored_at ON audio_transcriptions(original_stored_at) WHERE original_stored_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS pdf_extractions (
+    id                  TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    filename            VARCHAR(255) NOT NULL,
+    original_name       VARCHAR(500) NOT NULL,
+    page_count          INTEGER NOT NULL DEFAULT 0,
+    text_content        TEXT NOT NULL DEFAULT '',
+    word_count          INTEGER NOT NULL DEFAULT 0,
+    status              VARCHAR(20) NOT NULL DEFAULT 'completed'
+                        CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    error_message       TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMP NOT NULL DEFAULT (NOW()),
+    user_id             TEXT REFERENCES users(id) ON DELETE SET NULL,
+    api_key_id          TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    updated_at          TIMESTAMP NOT NULL DEFAULT (NOW()),
+    title               TEXT NOT NULL DEFAULT '',
+    notes               TEXT NOT NULL DEFAULT '',
+    tags                TEXT NOT NULL DEFAULT '[]',
+    original_stored_at  TIMESTAMP,
+    redaction           TEXT,
+    search_vector       TEXT GENERATED ALWAYS AS (
+        lower(title || ' ' || original_name || ' ' || text_content)
+    ) VIRTUAL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_created_at ON pdf_extractions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_user_id ON pdf_extractions(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_status ON pdf_extractions(status);
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_filename ON pdf_extractions(filename);
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_api_key_id ON pdf_extractions(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_pdf_original_stored_at ON pdf_extractions(original_stored_at) WHERE original_stored_at IS NOT NULL;
+
+-- updated_at triggers. Statements that set updated_at themselves win.
+CREATE TRIGGER IF NOT EXISTS update_transcripts_updated_at
+    AFTER UPDATE ON transcripts FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE transcripts SET updated_at = NOW() WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_batches_updated_at
+    AFTER UPDATE ON batches FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE batches SET updated_at = NOW() WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_audio_transcriptions_updated_at
+    AFTER UPDATE ON audio_transcriptions FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE audio_transcriptions SET updated_at = NOW() WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS update_pdf_extractions_updated_at
+    AFTER UPDATE ON pdf_extractions FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE pdf_extractions SET updated_at = NOW() WHERE id = NEW.id;
+END;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    api_key_id  TEXT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    url         TEXT NOT NULL,
+    events      TEXT NOT NULL DEFAULT '{}',
+    secret      VARCHAR(64) NOT NULL DEFAULT '',
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_api_key_id ON webhooks(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_webhooks_active ON webhooks(active) WHERE active = TRUE;
+CREATE INDEX IF NOT EXISTS idx_webhooks_created_at ON webhooks(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id            TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    webhook_id    TEXT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event         VARCHAR(50) NOT NULL,
+    payload       TEXT NOT NULL DEFAULT '{}',
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending'
+                  CHECK (status IN ('pending', 'success', 'failed')),
+    attempts      INTEGER NOT NULL DEFAULT 0,
+    last_error    TEXT NOT NULL DEFAULT '',
+    response_code INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMP NOT NULL DEFAULT (NOW()),
+    delivered_at  TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_created ON webhook_deliveries(webhook_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS workspace_items (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    user_id     TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_type   VARCHAR(20) NOT NULL,
+    item_id     TEXT NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_items_user_id ON workspace_items(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workspace_items_unique ON workspace_items(user_id, item_type, item_id);
+
+CREATE TABLE IF NOT EXISTS transcript_chat_sessions (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    transcript_id  TEXT REFERENCES transcripts(id) ON DELETE CASCADE,
+    api_key_id     TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW()),
+    updated_at     TIMESTAMP NOT NULL DEFAULT (NOW()),
+    item_type      VARCHAR(20) NOT NULL DEFAULT 'transcript'
+                   CHECK (item_type IN ('transcript', 'audio', 'pdf')),
+    item_id        TEXT NOT NULL,
+    UNIQUE (transcript_id, api_key_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_sessions_transcript_id ON transcript_chat_sessions(transcript_id);
+CREATE INDEX IF NOT EXISTS idx_chat_sessions_api_key_id ON transcript_chat_sessions(api_key_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chat_sessions_item_unique ON transcript_chat_sessions(item_type, item_id, api_key_id);
+CREATE INDEX IF NOT EXISTS idx_chat_sessions_item_id ON transcript_chat_sessions(item_id);
+
+CREATE TABLE IF NOT EXISTS transcript_chat_messages (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    session_id  TEXT NOT NULL REFERENCES transcript_chat_sessions(id) ON DELETE CASCADE,
+    role        VARCHAR(20) NOT NULL CHECK (role IN ('user', 'assistant')),
+    content     TEXT NOT NULL,
+    model_used  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_session_id ON transcript_chat_messages(session_id);
+CREATE INDEX IF NOT EXISTS idx_chat_messages_created_at ON transcript_chat_messages(created_at);
+
+CREATE TABLE IF NOT EXISTS study_aids (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    transcript_id  TEXT NOT NULL REFERENCES transcripts(id) ON DELETE CASCADE,
+    api_key_id     TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    model_used     TEXT NOT NULL DEFAULT '',
+    flashcards     TEXT NOT NULL DEFAULT '[]',
+    quiz           TEXT NOT NULL DEFAULT '[]',
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW()),
+    stale          BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_study_aids_transcript_id ON study_aids(transcript_id);
+
+CREATE TABLE IF NOT EXISTS integrations (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    user_id     TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider    VARCHAR(20) NOT NULL CHECK (provider IN ('todoist', 'linear', 'github', 'notion')),
+    token       TEXT NOT NULL,
+    config      TEXT NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    UNIQUE (user_id, provider)
+);
+
+CREATE INDEX IF NOT EXISTS idx_integrations_user_id ON integrations(user_id);
+
+CREATE TABLE IF NOT EXISTS exports (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    api_key_id     TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    user_id        TEXT REFERENCES users(id) ON DELETE SET NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending'
+                   CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    format         VARCHAR(10) NOT NULL,
+    items          TEXT NOT NULL DEFAULT '[]',
+    item_count     INTEGER NOT NULL DEFAULT 0,
+    skipped_count  INTEGER NOT NULL DEFAULT 0,
+    file_path      TEXT NOT NULL DEFAULT '',
+    file_size      INTEGER NOT NULL DEFAULT 0,
+    error_message  TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW()),
+    completed_at   TIMESTAMP,
+    kind           VARCHAR(20) NOT NULL DEFAULT 'items' CHECK (kind IN ('items', 'account'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_exports_api_key_id ON exports(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_exports_user_id ON exports(user_id);
+
+CREATE TABLE IF NOT EXISTS share_links (
+    id            TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    token_hash    VARCHAR(64) NOT NULL UNIQUE,
+    token_prefix  VARCHAR(20) NOT NULL,
+    item_type     VARCHAR(20) NOT NULL CHECK (item_type IN ('transcript', 'audio', 'pdf')),
+    item_id       TEXT NOT NULL,
+    api_key_id    TEXT REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id       TEXT REFERENCES users(id) ON DELETE CASCADE,
+    expires_at    TIMESTAMP,
+    revoked_at    TIMESTAMP,
+    view_count    INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_item ON share_links(item_type, item_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_api_key_id ON share_links(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_user_id ON share_links(user_id);
+
+CREATE TABLE IF NOT EXISTS transcript_edits (
+    id              TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    transcript_id   TEXT NOT NULL REFERENCES transcripts(id) ON DELETE CASCADE,
+    transcript_text TEXT NOT NULL,
+    segments        TEXT,
+    word_count      INTEGER NOT NULL DEFAULT 0,
+    api_key_id      TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    user_id         TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcript_edits_transcript_id ON transcript_edits(transcript_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id              TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    api_key_id      TEXT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    query           TEXT NOT NULL,
+    types           TEXT NOT NULL DEFAULT '{}',
+    notify          BOOLEAN NOT NULL DEFAULT FALSE,
+    last_matched_at TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_api_key_id ON saved_searches(api_key_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_notify ON saved_searches(api_key_id) WHERE notify = TRUE;
+
+CREATE TABLE IF NOT EXISTS uploads (
+    id               TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    item_type        VARCHAR(20) NOT NULL CHECK (item_type IN ('audio', 'pdf')),
+    filename         TEXT NOT NULL,
+    upload_length    INTEGER NOT NULL CHECK (upload_length >= 0),
+    upload_offset    INTEGER NOT NULL DEFAULT 0,
+    timeout_seconds  INTEGER NOT NULL DEFAULT 0,
+    item_id          TEXT,
+    api_key_id       TEXT REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id          TEXT REFERENCES users(id) ON DELETE CASCADE,
+    expires_at       TIMESTAMP NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT (NOW()),
+    redact           BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_expires_at ON uploads(expires_at);
+
+CREATE TABLE IF NOT EXISTS retention_policies (
+    id              TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    api_key_id      TEXT UNIQUE REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id         TEXT UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    retention_days  INTEGER NOT NULL CHECK (retention_days > 0),
+    created_at      TIMESTAMP NOT NULL DEFAULT (NOW()),
+    updated_at      TIMESTAMP NOT NULL DEFAULT (NOW()),
+    CHECK ((api_key_id IS NULL) <> (user_id IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS purges (
+    id               TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    item_type        VARCHAR(20) NOT NULL CHECK (item_type IN ('transcript', 'audio', 'pdf')),
+    item_id          TEXT NOT NULL,
+    title            TEXT NOT NULL DEFAULT '',
+    api_key_id       TEXT,
+    user_id          TEXT,
+    retention_days   INTEGER NOT NULL,
+    original_key     TEXT,
+    item_created_at  TIMESTAMP NOT NULL,
+    purged_at        TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_purges_purged_at ON purges(purged_at DESC);
+CREATE INDEX IF NOT EXISTS idx_purges_api_key_id ON purges(api_key_id);
+CREATE INDEX IF NOT EXISTS idx_purges_user_id ON purges(user_id);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider    VARCHAR(20) NOT NULL,
+    subject     VARCHAR(255) NOT NULL,
+    user_id     TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email       VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    user_id     TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose     VARCHAR(20) NOT NULL CHECK (purpose IN ('verify_email', 'reset_password')),
+    token_hash  TEXT NOT NULL UNIQUE,
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    expires_at  TIMESTAMP NOT NULL,
+    used_at     TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_id ON user_tokens(user_id, purpose);
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id                   TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    user_id              TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    refresh_token_hash   TEXT NOT NULL UNIQUE,
+    previous_token_hash  TEXT,
+    user_agent           TEXT NOT NULL DEFAULT '',
+    ip_address           TEXT NOT NULL DEFAULT '',
+    created_at           TIMESTAMP NOT NULL DEFAULT (NOW()),
+    last_used_at         TIMESTAMP NOT NULL DEFAULT (NOW()),
+    expires_at           TIMESTAMP NOT NULL,
+    revoked_at           TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_previous_token_hash ON user_sessions(previous_token_hash);
+
+CREATE TABLE IF NOT EXISTS cors_origins (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    origin      TEXT NOT NULL UNIQUE,
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE IF NOT EXISTS runtime_settings (
+    key         TEXT PRIMARY KEY,
+    value       TEXT NOT NULL,
+    updated_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
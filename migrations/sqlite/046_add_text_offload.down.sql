@@ -0,0 +1,5 @@
+-- Rollback migration 046: drop offloaded text keys
+
+ALTER TABLE audio_transcriptions DROP COLUMN text_key;
+ALTER TABLE transcripts DROP COLUMN original_text_key;
+ALTER TABLE transcripts DROP COLUMN text_key;
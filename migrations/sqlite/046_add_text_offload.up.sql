@@ -0,0 +1,5 @@
+-- Migration 046: Offload large transcript bodies to object storage
+
+ALTER TABLE transcripts ADD COLUMN text_key TEXT;
+ALTER TABLE transcripts ADD COLUMN original_text_key TEXT;
+ALTER TABLE audio_transcriptions ADD COLUMN text_key TEXT;
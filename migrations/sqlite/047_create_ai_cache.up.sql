@@ -0,0 +1,11 @@
+-- Migration 047: AI completion cache
+
+CREATE TABLE IF NOT EXISTS ai_cache (
+    key         TEXT PRIMARY KEY,
+    content     TEXT NOT NULL,
+    model       TEXT NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    expires_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_cache_expires_at ON ai_cache (expires_at);
@@ -0,0 +1,6 @@
+-- Migration 048: Rolling chat history summaries
+
+ALTER TABLE transcript_chat_sessions ADD COLUMN history_summary TEXT NOT NULL DEFAULT '';
+ALTER TABLE transcript_chat_sessions ADD COLUMN summarized_through_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_session_created ON transcript_chat_messages (session_id, created_at, id);
@@ -0,0 +1,5 @@
+-- Migration 049: Chat answer feedback
+
+ALTER TABLE transcript_chat_messages ADD COLUMN feedback VARCHAR(4) CHECK (feedback IN ('up', 'down'));
+ALTER TABLE transcript_chat_messages ADD COLUMN feedback_comment TEXT NOT NULL DEFAULT '';
+ALTER TABLE transcript_chat_messages ADD COLUMN feedback_at TIMESTAMP;
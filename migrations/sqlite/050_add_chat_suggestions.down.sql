@@ -0,0 +1,8 @@
+-- Rollback migration 050: drop suggested chat questions
+
+ALTER TABLE pdf_extractions DROP COLUMN chat_suggestions_hash;
+ALTER TABLE pdf_extractions DROP COLUMN chat_suggestions;
+ALTER TABLE audio_transcriptions DROP COLUMN chat_suggestions_hash;
+ALTER TABLE audio_transcriptions DROP COLUMN chat_suggestions;
+ALTER TABLE transcripts DROP COLUMN chat_suggestions_hash;
+ALTER TABLE transcripts DROP COLUMN chat_suggestions;
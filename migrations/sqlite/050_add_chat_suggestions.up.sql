@@ -0,0 +1,8 @@
+-- Migration 050: Suggested chat questions
+
+ALTER TABLE transcripts ADD COLUMN chat_suggestions TEXT;
+ALTER TABLE transcripts ADD COLUMN chat_suggestions_hash TEXT;
+ALTER TABLE audio_transcriptions ADD COLUMN chat_suggestions TEXT;
+ALTER TABLE audio_transcriptions ADD COLUMN chat_suggestions_hash TEXT;
+ALTER TABLE pdf_extractions ADD COLUMN chat_suggestions TEXT;
+ALTER TABLE pdf_extractions ADD COLUMN chat_suggestions_hash TEXT;
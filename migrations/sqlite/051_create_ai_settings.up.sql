@@ -0,0 +1,13 @@
+-- Migration 051: Per-key and per-user AI defaults
+
+CREATE TABLE IF NOT EXISTS ai_settings (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    api_key_id  TEXT UNIQUE REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id     TEXT UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    model       TEXT NOT NULL DEFAULT '',
+    length      TEXT NOT NULL DEFAULT '',
+    style       TEXT NOT NULL DEFAULT '',
+    language    TEXT NOT NULL DEFAULT '',
+    updated_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    CHECK ((api_key_id IS NULL) <> (user_id IS NULL))
+);
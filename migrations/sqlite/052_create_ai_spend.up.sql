@@ -0,0 +1,21 @@
+-- Migration 052: AI spend accounting and per-key monthly limits
+
+CREATE TABLE IF NOT EXISTS ai_usage (
+    id                 TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    api_key_id         TEXT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    model              TEXT NOT NULL,
+    prompt_tokens      INTEGER NOT NULL DEFAULT 0,
+    completion_tokens  INTEGER NOT NULL DEFAULT 0,
+    cost_usd           REAL NOT NULL DEFAULT 0,
+    created_at         TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_usage_key_created ON ai_usage (api_key_id, created_at);
+
+CREATE TABLE IF NOT EXISTS spend_limits (
+    api_key_id         TEXT PRIMARY KEY REFERENCES api_keys(id) ON DELETE CASCADE,
+    monthly_limit_usd  REAL NOT NULL CHECK (monthly_limit_usd > 0),
+    action             VARCHAR(10) NOT NULL DEFAULT 'block' CHECK (action IN ('block', 'downgrade')),
+    warned_month       VARCHAR(7) NOT NULL DEFAULT '',
+    updated_at         TIMESTAMP NOT NULL DEFAULT (NOW())
+);
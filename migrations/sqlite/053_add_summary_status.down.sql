@@ -0,0 +1,5 @@
+-- Rollback migration 053: drop summary status tracking
+
+DELETE FROM summaries WHERE status <> 'completed';
+ALTER TABLE summaries DROP COLUMN error_message;
+ALTER TABLE summaries DROP COLUMN status;
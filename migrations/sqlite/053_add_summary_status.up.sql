@@ -0,0 +1,5 @@
+-- Migration 053: Summary status tracking
+
+ALTER TABLE summaries ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'completed'
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed'));
+ALTER TABLE summaries ADD COLUMN error_message TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,29 @@
+-- Migration 054: Pipelines
+
+CREATE TABLE IF NOT EXISTS pipelines (
+    id          TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    api_key_id  TEXT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    steps       TEXT NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW()),
+    updated_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipelines_api_key ON pipelines (api_key_id);
+
+CREATE TABLE IF NOT EXISTS pipeline_runs (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    pipeline_id    TEXT NOT NULL REFERENCES pipelines(id) ON DELETE CASCADE,
+    api_key_id     TEXT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    user_id        TEXT REFERENCES users(id) ON DELETE SET NULL,
+    transcript_id  TEXT REFERENCES transcripts(id) ON DELETE SET NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending'
+                   CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    current_step   INTEGER NOT NULL DEFAULT 0,
+    steps          TEXT NOT NULL DEFAULT '[]',
+    error_message  TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW()),
+    updated_at     TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_runs_pipeline_created ON pipeline_runs (pipeline_id, created_at);
@@ -0,0 +1,15 @@
+-- Migration 055: Audit log
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id                 TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    actor_api_key_id   TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    target_api_key_id  TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    action             VARCHAR(30) NOT NULL,
+    method             VARCHAR(10) NOT NULL DEFAULT '',
+    path               TEXT NOT NULL DEFAULT '',
+    ip                 VARCHAR(64) NOT NULL DEFAULT '',
+    created_at         TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log (target_api_key_id, created_at);
@@ -0,0 +1,11 @@
+-- Rollback migration 056: restore per-key rate limits from tiers
+
+ALTER TABLE api_keys ADD COLUMN rate_limit INTEGER NOT NULL DEFAULT 100;
+
+UPDATE api_keys SET rate_limit = CASE tier
+    WHEN 'enterprise' THEN 10000
+    WHEN 'pro' THEN 1000
+    ELSE 100
+END;
+
+ALTER TABLE api_keys DROP COLUMN tier;
@@ -0,0 +1,12 @@
+-- Migration 056: API key tiers
+
+ALTER TABLE api_keys ADD COLUMN tier VARCHAR(20) NOT NULL DEFAULT 'free'
+    CHECK (tier IN ('free', 'pro', 'enterprise'));
+
+UPDATE api_keys SET tier = CASE
+    WHEN rate_limit > 1000 THEN 'enterprise'
+    WHEN rate_limit > 100 THEN 'pro'
+    ELSE 'free'
+END;
+
+ALTER TABLE api_keys DROP COLUMN rate_limit;
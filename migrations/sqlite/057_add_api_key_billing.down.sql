@@ -0,0 +1,5 @@
+-- Rollback migration 057: Stripe billing for API keys
+
+DROP INDEX IF EXISTS idx_api_keys_stripe_customer;
+ALTER TABLE api_keys DROP COLUMN billing_suspended;
+ALTER TABLE api_keys DROP COLUMN stripe_customer_id;
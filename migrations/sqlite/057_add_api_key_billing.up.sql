@@ -0,0 +1,7 @@
+-- Migration 057: Stripe billing for API keys
+
+ALTER TABLE api_keys ADD COLUMN stripe_customer_id VARCHAR(255);
+ALTER TABLE api_keys ADD COLUMN billing_suspended BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_stripe_customer ON api_keys(stripe_customer_id)
+    WHERE stripe_customer_id IS NOT NULL;
@@ -0,0 +1,13 @@
+-- Migration 058: Watch folder
+
+CREATE TABLE IF NOT EXISTS watched_files (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    path           TEXT NOT NULL UNIQUE,
+    size           BIGINT NOT NULL DEFAULT 0,
+    item_type      VARCHAR(20) NOT NULL,
+    item_id        TEXT,
+    error_message  TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_watched_files_created ON watched_files (created_at);
@@ -0,0 +1,5 @@
+-- Rollback migration 059: Webhook payload modes
+
+ALTER TABLE webhooks DROP COLUMN max_payload_bytes;
+ALTER TABLE webhooks DROP COLUMN payload_fields;
+ALTER TABLE webhooks DROP COLUMN payload_mode;
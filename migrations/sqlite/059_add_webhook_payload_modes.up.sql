@@ -0,0 +1,5 @@
+-- Migration 059: Webhook payload modes
+
+ALTER TABLE webhooks ADD COLUMN payload_mode VARCHAR(20) NOT NULL DEFAULT 'full';
+ALTER TABLE webhooks ADD COLUMN payload_fields TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE webhooks ADD COLUMN max_payload_bytes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Rollback migration 060: Webhook custom headers and basic auth
+
+ALTER TABLE webhooks DROP COLUMN has_basic_auth;
+ALTER TABLE webhooks DROP COLUMN header_names;
+ALTER TABLE webhooks DROP COLUMN credentials;
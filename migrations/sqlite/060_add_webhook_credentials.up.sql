@@ -0,0 +1,5 @@
+-- Migration 060: Webhook custom headers and basic auth
+
+ALTER TABLE webhooks ADD COLUMN credentials TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN header_names TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE webhooks ADD COLUMN has_basic_auth BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+-- Rollback migration 061: drop webhook delivery attempts
+
+DROP TABLE IF EXISTS webhook_delivery_attempts;
@@ -0,0 +1,15 @@
+-- Migration 061: Webhook delivery attempts
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id                  TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    delivery_id         TEXT NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt             INTEGER NOT NULL,
+    response_code       INTEGER NOT NULL DEFAULT 0,
+    response_body       TEXT NOT NULL DEFAULT '',
+    response_truncated  BOOLEAN NOT NULL DEFAULT FALSE,
+    latency_ms          INTEGER NOT NULL DEFAULT 0,
+    error               TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempt);
@@ -0,0 +1,4 @@
+-- Rollback migration 062: Webhook secret rotation
+
+ALTER TABLE webhooks DROP COLUMN previous_secret_expires_at;
+ALTER TABLE webhooks DROP COLUMN previous_secret;
@@ -0,0 +1,4 @@
+-- Migration 062: Webhook secret rotation
+
+ALTER TABLE webhooks ADD COLUMN previous_secret VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN previous_secret_expires_at TIMESTAMP;
@@ -0,0 +1,5 @@
+-- Rollback migration 063: Audio language hints and detection confidence
+
+ALTER TABLE uploads DROP COLUMN language;
+ALTER TABLE audio_transcriptions DROP COLUMN language_confidence;
+ALTER TABLE audio_transcriptions DROP COLUMN language_hint;
@@ -0,0 +1,5 @@
+-- Migration 063: Audio language hints and detection confidence
+
+ALTER TABLE audio_transcriptions ADD COLUMN language_hint VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE audio_transcriptions ADD COLUMN language_confidence REAL;
+ALTER TABLE uploads ADD COLUMN language VARCHAR(10) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- Rollback migration 064: Whisper translate-to-English mode
+
+ALTER TABLE uploads DROP COLUMN keep_source;
+ALTER TABLE uploads DROP COLUMN translate;
+ALTER TABLE audio_transcriptions DROP COLUMN source_text;
+ALTER TABLE audio_transcriptions DROP COLUMN translated;
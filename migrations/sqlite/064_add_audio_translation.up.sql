@@ -0,0 +1,6 @@
+-- Migration 064: Whisper translate-to-English mode
+
+ALTER TABLE audio_transcriptions ADD COLUMN translated BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE audio_transcriptions ADD COLUMN source_text TEXT NOT NULL DEFAULT '';
+ALTER TABLE uploads ADD COLUMN translate BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE uploads ADD COLUMN keep_source BOOLEAN NOT NULL DEFAULT FALSE;
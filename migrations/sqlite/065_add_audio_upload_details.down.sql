@@ -0,0 +1,11 @@
+-- Rollback migration 065: titles, notes, and custom metadata on audio uploads
+
+ALTER TABLE audio_transcriptions DROP COLUMN search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector TEXT GENERATED ALWAYS AS (
+    lower(title || ' ' || original_name || ' ' || transcript_text || ' ' || summary_text)
+) VIRTUAL;
+
+ALTER TABLE uploads DROP COLUMN metadata;
+ALTER TABLE uploads DROP COLUMN notes;
+ALTER TABLE uploads DROP COLUMN title;
+ALTER TABLE audio_transcriptions DROP COLUMN metadata;
@@ -0,0 +1,11 @@
+-- Migration 065: Titles, notes, and custom metadata on audio uploads
+
+ALTER TABLE audio_transcriptions ADD COLUMN metadata TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE uploads ADD COLUMN title TEXT NOT NULL DEFAULT '';
+ALTER TABLE uploads ADD COLUMN notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE uploads ADD COLUMN metadata TEXT NOT NULL DEFAULT '{}';
+
+ALTER TABLE audio_transcriptions DROP COLUMN search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector TEXT GENERATED ALWAYS AS (
+    lower(title || ' ' || original_name || ' ' || transcript_text || ' ' || summary_text || ' ' || notes || ' ' || metadata)
+) VIRTUAL;
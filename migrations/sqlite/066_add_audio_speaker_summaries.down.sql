@@ -0,0 +1,2 @@
+-- Rollback migration 066
+ALTER TABLE audio_transcriptions DROP COLUMN speaker_summaries;
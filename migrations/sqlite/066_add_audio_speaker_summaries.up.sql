@@ -0,0 +1,3 @@
+-- Migration 066: Per-speaker summaries for diarized audio
+
+ALTER TABLE audio_transcriptions ADD COLUMN speaker_summaries TEXT NOT NULL DEFAULT '[]';
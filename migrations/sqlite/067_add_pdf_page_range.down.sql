@@ -0,0 +1,3 @@
+-- Rollback migration 067
+ALTER TABLE pdf_extractions DROP COLUMN last_page;
+ALTER TABLE pdf_extractions DROP COLUMN first_page;
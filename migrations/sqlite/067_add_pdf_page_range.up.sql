@@ -0,0 +1,6 @@
+-- Migration 067: Record which pages of a PDF were extracted
+
+ALTER TABLE pdf_extractions ADD COLUMN first_page INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pdf_extractions ADD COLUMN last_page INTEGER NOT NULL DEFAULT 0;
+
+UPDATE pdf_extractions SET first_page = 1, last_page = page_count WHERE page_count > 0;
@@ -0,0 +1,3 @@
+-- Rollback migration 068
+ALTER TABLE pdf_extractions DROP COLUMN table_count;
+ALTER TABLE pdf_extractions DROP COLUMN tables;
@@ -0,0 +1,4 @@
+-- Migration 068: Tables detected in PDF extractions
+
+ALTER TABLE pdf_extractions ADD COLUMN tables TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE pdf_extractions ADD COLUMN table_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Rollback migration 069
+ALTER TABLE pdf_extractions DROP COLUMN outline;
+ALTER TABLE pdf_extractions DROP COLUMN document_info;
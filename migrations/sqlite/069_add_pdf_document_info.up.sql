@@ -0,0 +1,4 @@
+-- Migration 069: PDF document metadata and outline
+
+ALTER TABLE pdf_extractions ADD COLUMN document_info TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE pdf_extractions ADD COLUMN outline TEXT NOT NULL DEFAULT '[]';
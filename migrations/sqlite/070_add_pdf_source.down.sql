@@ -0,0 +1,3 @@
+-- Rollback migration 070
+ALTER TABLE pdf_extractions DROP COLUMN source_url;
+ALTER TABLE pdf_extractions DROP COLUMN source_type;
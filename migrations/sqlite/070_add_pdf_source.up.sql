@@ -0,0 +1,4 @@
+-- Migration 070: Sources of extractions
+
+ALTER TABLE pdf_extractions ADD COLUMN source_type TEXT NOT NULL DEFAULT 'pdf';
+ALTER TABLE pdf_extractions ADD COLUMN source_url TEXT NOT NULL DEFAULT '';
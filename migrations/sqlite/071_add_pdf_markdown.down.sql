@@ -0,0 +1,2 @@
+-- Rollback migration 071
+ALTER TABLE pdf_extractions DROP COLUMN markdown_content;
@@ -0,0 +1,3 @@
+-- Migration 071: Markdown extractions
+
+ALTER TABLE pdf_extractions ADD COLUMN markdown_content TEXT NOT NULL DEFAULT '';
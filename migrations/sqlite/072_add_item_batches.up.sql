@@ -0,0 +1,7 @@
+-- Migration 072: Batches of audio files and PDFs
+
+ALTER TABLE audio_transcriptions ADD COLUMN batch_id TEXT REFERENCES batches(id) ON DELETE SET NULL;
+ALTER TABLE pdf_extractions ADD COLUMN batch_id TEXT REFERENCES batches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_audio_transcriptions_batch_id ON audio_transcriptions(batch_id) WHERE batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_pdf_extractions_batch_id ON pdf_extractions(batch_id) WHERE batch_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Rollback migration 073
+ALTER TABLE summaries DROP COLUMN usage;
+ALTER TABLE pdf_extractions DROP COLUMN usage;
+ALTER TABLE audio_transcriptions DROP COLUMN usage;
+ALTER TABLE transcripts DROP COLUMN usage;
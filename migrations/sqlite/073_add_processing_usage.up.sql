@@ -0,0 +1,6 @@
+-- Migration 073: Record what processing each item took
+
+ALTER TABLE transcripts ADD COLUMN usage TEXT;
+ALTER TABLE audio_transcriptions ADD COLUMN usage TEXT;
+ALTER TABLE pdf_extractions ADD COLUMN usage TEXT;
+ALTER TABLE summaries ADD COLUMN usage TEXT;
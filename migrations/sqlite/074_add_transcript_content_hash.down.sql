@@ -0,0 +1,3 @@
+-- Rollback migration 074
+DROP INDEX IF EXISTS idx_transcripts_content_hash;
+ALTER TABLE transcripts DROP COLUMN content_hash;
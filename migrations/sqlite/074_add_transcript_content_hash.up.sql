@@ -0,0 +1,5 @@
+-- Migration 074: Content hashes for finding duplicate transcripts
+
+ALTER TABLE transcripts ADD COLUMN content_hash VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_transcripts_content_hash ON transcripts(content_hash);
@@ -0,0 +1,13 @@
+-- Migration 075: Article drafts generated from transcripts
+
+CREATE TABLE IF NOT EXISTS articles (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    transcript_id  TEXT NOT NULL REFERENCES transcripts(id) ON DELETE CASCADE,
+    api_key_id     TEXT REFERENCES api_keys(id) ON DELETE SET NULL,
+    model_used     TEXT NOT NULL DEFAULT '',
+    content        TEXT NOT NULL DEFAULT '{}',
+    stale          BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_articles_transcript_id ON articles(transcript_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- Rollback migration 076: drop weekly digests
+
+DROP TABLE IF EXISTS digests;
+DROP TABLE IF EXISTS digest_subscriptions;
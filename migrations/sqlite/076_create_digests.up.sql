@@ -0,0 +1,22 @@
+-- Migration 076: Weekly digests
+
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id     TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email       BOOLEAN NOT NULL DEFAULT TRUE,
+    webhook     BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE IF NOT EXISTS digests (
+    id            TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    user_id       TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start  TIMESTAMP NOT NULL,
+    period_end    TIMESTAMP NOT NULL,
+    item_count    INTEGER NOT NULL DEFAULT 0,
+    model_used    TEXT NOT NULL DEFAULT '',
+    content       TEXT NOT NULL DEFAULT '{}',
+    delivered_at  TIMESTAMP,
+    created_at    TIMESTAMP NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX IF NOT EXISTS idx_digests_user_id ON digests(user_id, period_end DESC);
@@ -0,0 +1,4 @@
+-- Rollback migration 077: drop entities
+
+DROP TABLE IF EXISTS entity_mentions;
+DROP TABLE IF EXISTS entities;
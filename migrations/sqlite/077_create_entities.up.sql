@@ -0,0 +1,23 @@
+-- Migration 077: Entities mentioned in items
+
+CREATE TABLE IF NOT EXISTS entities (
+    id               TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    name             TEXT NOT NULL,
+    entity_type      TEXT NOT NULL CHECK (entity_type IN ('person', 'company', 'product', 'place')),
+    normalized_name  TEXT NOT NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT (NOW()),
+    UNIQUE (entity_type, normalized_name)
+);
+
+CREATE TABLE IF NOT EXISTS entity_mentions (
+    id             TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    entity_id      TEXT NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+    item_type      TEXT NOT NULL CHECK (item_type IN ('transcript', 'audio', 'pdf')),
+    item_id        TEXT NOT NULL,
+    mention_count  INTEGER NOT NULL DEFAULT 1,
+    context        TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMP NOT NULL DEFAULT (NOW()),
+    UNIQUE (entity_id, item_type, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_mentions_item ON entity_mentions(item_type, item_id);
@@ -0,0 +1,4 @@
+-- Rollback migration 078: drop chat session users
+
+DROP INDEX IF EXISTS idx_chat_sessions_user_id;
+ALTER TABLE transcript_chat_sessions DROP COLUMN user_id;
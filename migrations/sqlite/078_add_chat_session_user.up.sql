@@ -0,0 +1,5 @@
+-- Migration 078: Chat sessions held by logged-in users
+
+ALTER TABLE transcript_chat_sessions ADD COLUMN user_id TEXT REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_chat_sessions_user_id ON transcript_chat_sessions(user_id);
@@ -0,0 +1,14 @@
+-- Rollback migration 079: offloaded transcript bodies in search vectors
+
+ALTER TABLE transcripts DROP COLUMN search_vector;
+ALTER TABLE transcripts ADD COLUMN search_vector TEXT GENERATED ALWAYS AS (
+    lower(title || ' ' || channel_name || ' ' || transcript_text)
+) VIRTUAL;
+
+ALTER TABLE audio_transcriptions DROP COLUMN search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector TEXT GENERATED ALWAYS AS (
+    lower(title || ' ' || original_name || ' ' || transcript_text || ' ' || summary_text || ' ' || notes || ' ' || metadata)
+) VIRTUAL;
+
+ALTER TABLE transcripts DROP COLUMN offloaded_vector;
+ALTER TABLE audio_transcriptions DROP COLUMN offloaded_vector;
@@ -0,0 +1,16 @@
+-- Migration 079: Keep offloaded transcript bodies searchable
+-- offloaded_vector holds an offloaded body's lowercased text, which the
+-- search vectors take in; it's NULL for bodies kept in the row.
+
+ALTER TABLE transcripts ADD COLUMN offloaded_vector TEXT;
+ALTER TABLE audio_transcriptions ADD COLUMN offloaded_vector TEXT;
+
+ALTER TABLE transcripts DROP COLUMN search_vector;
+ALTER TABLE transcripts ADD COLUMN search_vector TEXT GENERATED ALWAYS AS (
+    lower(title || ' ' || channel_name || ' ' || transcript_text) || ' ' || COALESCE(offloaded_vector, '')
+) VIRTUAL;
+
+ALTER TABLE audio_transcriptions DROP COLUMN search_vector;
+ALTER TABLE audio_transcriptions ADD COLUMN search_vector TEXT GENERATED ALWAYS AS (
+    lower(title || ' ' || original_name || ' ' || transcript_text || ' ' || summary_text || ' ' || notes || ' ' || metadata) || ' ' || COALESCE(offloaded_vector, '')
+) VIRTUAL;
@@ -0,0 +1,606 @@
+// Media Tools gRPC API.
+//
+// Served on GRPC_PORT (plaintext HTTP/2) when that variable is set,
+// alongside the standard grpc.health.v1 and server reflection services.
+// Authenticate with the same API keys as the REST API, sent as
+// "x-api-key" metadata. Every call counts against the key's rate limit.
+//
+// The Go stubs next to this file are generated with "make proto"; generate
+// a client in another language with e.g.:
+//
+//   protoc --python_out=. --grpc_python_out=. proto/mediatools/v1/media_tools.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: mediatools/v1/media_tools.proto
+
+package mediatoolsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TranscriptStatus int32
+
+const (
+	TranscriptStatus_TRANSCRIPT_STATUS_UNSPECIFIED TranscriptStatus = 0
+	TranscriptStatus_TRANSCRIPT_STATUS_PENDING     TranscriptStatus = 1
+	TranscriptStatus_TRANSCRIPT_STATUS_PROCESSING  TranscriptStatus = 2
+	TranscriptStatus_TRANSCRIPT_STATUS_COMPLETED   TranscriptStatus = 3
+	TranscriptStatus_TRANSCRIPT_STATUS_FAILED      TranscriptStatus = 4
+)
+
+// Enum value maps for TranscriptStatus.
+var (
+	TranscriptStatus_name = map[int32]string{
+		0: "TRANSCRIPT_STATUS_UNSPECIFIED",
+		1: "TRANSCRIPT_STATUS_PENDING",
+		2: "TRANSCRIPT_STATUS_PROCESSING",
+		3: "TRANSCRIPT_STATUS_COMPLETED",
+		4: "TRANSCRIPT_STATUS_FAILED",
+	}
+	TranscriptStatus_value = map[string]int32{
+		"TRANSCRIPT_STATUS_UNSPECIFIED": 0,
+		"TRANSCRIPT_STATUS_PENDING":     1,
+		"TRANSCRIPT_STATUS_PROCESSING":  2,
+		"TRANSCRIPT_STATUS_COMPLETED":   3,
+		"TRANSCRIPT_STATUS_FAILED":      4,
+	}
+)
+
+func (x TranscriptStatus) Enum() *TranscriptStatus {
+	p := new(TranscriptStatus)
+	*p = x
+	return p
+}
+
+func (x TranscriptStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TranscriptStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_mediatools_v1_media_tools_proto_enumTypes[0].Descriptor()
+}
+
+func (TranscriptStatus) Type() protoreflect.EnumType {
+	return &file_mediatools_v1_media_tools_proto_enumTypes[0]
+}
+
+func (x TranscriptStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TranscriptStatus.Descriptor instead.
+func (TranscriptStatus) EnumDescriptor() ([]byte, []int) {
+	return file_mediatools_v1_media_tools_proto_rawDescGZIP(), []int{0}
+}
+
+type Transcript struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	YoutubeUrl      string                 `protobuf:"bytes,2,opt,name=youtube_url,json=youtubeUrl,proto3" json:"youtube_url,omitempty"`
+	YoutubeId       string                 `protobuf:"bytes,3,opt,name=youtube_id,json=youtubeId,proto3" json:"youtube_id,omitempty"`
+	Title           string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	ChannelName     string                 `protobuf:"bytes,5,opt,name=channel_name,json=channelName,proto3" json:"channel_name,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,6,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Language        string                 `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	// Only set when the request asks for it (include_text).
+	TranscriptText string                 `protobuf:"bytes,8,opt,name=transcript_text,json=transcriptText,proto3" json:"transcript_text,omitempty"`
+	WordCount      int32                  `protobuf:"varint,9,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	Status         TranscriptStatus       `protobuf:"varint,10,opt,name=status,proto3,enum=mediatools.v1.TranscriptStatus" json:"status,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	BatchId        string                 `protobuf:"bytes,12,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Transcript) Reset() {
+	*x = Transcript{}
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transcript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transcript) ProtoMessage() {}
+
+func (x *Transcript) ProtoReflect() protoreflect.Message {
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transcript.ProtoReflect.Descriptor instead.
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return file_mediatools_v1_media_tools_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Transcript) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Transcript) GetYoutubeUrl() string {
+	if x != nil {
+		return x.YoutubeUrl
+	}
+	return ""
+}
+
+func (x *Transcript) GetYoutubeId() string {
+	if x != nil {
+		return x.YoutubeId
+	}
+	return ""
+}
+
+func (x *Transcript) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Transcript) GetChannelName() string {
+	if x != nil {
+		return x.ChannelName
+	}
+	return ""
+}
+
+func (x *Transcript) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *Transcript) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Transcript) GetTranscriptText() string {
+	if x != nil {
+		return x.TranscriptText
+	}
+	return ""
+}
+
+func (x *Transcript) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *Transcript) GetStatus() TranscriptStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TranscriptStatus_TRANSCRIPT_STATUS_UNSPECIFIED
+}
+
+func (x *Transcript) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Transcript) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *Transcript) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Transcript) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SubmitTranscriptRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A YouTube URL or bare video ID.
+	Url           string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTranscriptRequest) Reset() {
+	*x = SubmitTranscriptRequest{}
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTranscriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTranscriptRequest) ProtoMessage() {}
+
+func (x *SubmitTranscriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTranscriptRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTranscriptRequest) Descriptor() ([]byte, []int) {
+	return file_mediatools_v1_media_tools_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitTranscriptRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type GetTranscriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeText   bool                   `protobuf:"varint,2,opt,name=include_text,json=includeText,proto3" json:"include_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTranscriptRequest) Reset() {
+	*x = GetTranscriptRequest{}
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTranscriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTranscriptRequest) ProtoMessage() {}
+
+func (x *GetTranscriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTranscriptRequest.ProtoReflect.Descriptor instead.
+func (*GetTranscriptRequest) Descriptor() ([]byte, []int) {
+	return file_mediatools_v1_media_tools_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTranscriptRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetTranscriptRequest) GetIncludeText() bool {
+	if x != nil {
+		return x.IncludeText
+	}
+	return false
+}
+
+type SearchTranscriptsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matched against title and channel name. Empty returns everything.
+	Query         string           `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Status        TranscriptStatus `protobuf:"varint,2,opt,name=status,proto3,enum=mediatools.v1.TranscriptStatus" json:"status,omitempty"`
+	Page          int32            `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                         // Defaults to 1
+	PageSize      int32            `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // Defaults to 20, max 100
+	IncludeText   bool             `protobuf:"varint,5,opt,name=include_text,json=includeText,proto3" json:"include_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTranscriptsRequest) Reset() {
+	*x = SearchTranscriptsRequest{}
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTranscriptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTranscriptsRequest) ProtoMessage() {}
+
+func (x *SearchTranscriptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTranscriptsRequest.ProtoReflect.Descriptor instead.
+func (*SearchTranscriptsRequest) Descriptor() ([]byte, []int) {
+	return file_mediatools_v1_media_tools_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchTranscriptsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTranscriptsRequest) GetStatus() TranscriptStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TranscriptStatus_TRANSCRIPT_STATUS_UNSPECIFIED
+}
+
+func (x *SearchTranscriptsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchTranscriptsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchTranscriptsRequest) GetIncludeText() bool {
+	if x != nil {
+		return x.IncludeText
+	}
+	return false
+}
+
+type SearchTranscriptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transcripts   []*Transcript          `protobuf:"bytes,1,rep,name=transcripts,proto3" json:"transcripts,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTranscriptsResponse) Reset() {
+	*x = SearchTranscriptsResponse{}
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTranscriptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTranscriptsResponse) ProtoMessage() {}
+
+func (x *SearchTranscriptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mediatools_v1_media_tools_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTranscriptsResponse.ProtoReflect.Descriptor instead.
+func (*SearchTranscriptsResponse) Descriptor() ([]byte, []int) {
+	return file_mediatools_v1_media_tools_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchTranscriptsResponse) GetTranscripts() []*Transcript {
+	if x != nil {
+		return x.Transcripts
+	}
+	return nil
+}
+
+func (x *SearchTranscriptsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchTranscriptsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchTranscriptsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+var File_mediatools_v1_media_tools_proto protoreflect.FileDescriptor
+
+const file_mediatools_v1_media_tools_proto_rawDesc = "" +
+	"\n" +
+	"\x1fmediatools/v1/media_tools.proto\x12\rmediatools.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x04\n" +
+	"\n" +
+	"Transcript\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vyoutube_url\x18\x02 \x01(\tR\n" +
+	"youtubeUrl\x12\x1d\n" +
+	"\n" +
+	"youtube_id\x18\x03 \x01(\tR\tyoutubeId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12!\n" +
+	"\fchannel_name\x18\x05 \x01(\tR\vchannelName\x12)\n" +
+	"\x10duration_seconds\x18\x06 \x01(\x05R\x0fdurationSeconds\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x12'\n" +
+	"\x0ftranscript_text\x18\b \x01(\tR\x0etranscriptText\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\t \x01(\x05R\twordCount\x127\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x1f.mediatools.v1.TranscriptStatusR\x06status\x12#\n" +
+	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x12\x19\n" +
+	"\bbatch_id\x18\f \x01(\tR\abatchId\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"+\n" +
+	"\x17SubmitTranscriptRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"I\n" +
+	"\x14GetTranscriptRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\finclude_text\x18\x02 \x01(\bR\vincludeText\"\xbd\x01\n" +
+	"\x18SearchTranscriptsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x127\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1f.mediatools.v1.TranscriptStatusR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12!\n" +
+	"\finclude_text\x18\x05 \x01(\bR\vincludeText\"\x9f\x01\n" +
+	"\x19SearchTranscriptsResponse\x12;\n" +
+	"\vtranscripts\x18\x01 \x03(\v2\x19.mediatools.v1.TranscriptR\vtranscripts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize*\xb5\x01\n" +
+	"\x10TranscriptStatus\x12!\n" +
+	"\x1dTRANSCRIPT_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19TRANSCRIPT_STATUS_PENDING\x10\x01\x12 \n" +
+	"\x1cTRANSCRIPT_STATUS_PROCESSING\x10\x02\x12\x1f\n" +
+	"\x1bTRANSCRIPT_STATUS_COMPLETED\x10\x03\x12\x1c\n" +
+	"\x18TRANSCRIPT_STATUS_FAILED\x10\x042\xf1\x02\n" +
+	"\n" +
+	"MediaTools\x12U\n" +
+	"\x10SubmitTranscript\x12&.mediatools.v1.SubmitTranscriptRequest\x1a\x19.mediatools.v1.Transcript\x12O\n" +
+	"\rGetTranscript\x12#.mediatools.v1.GetTranscriptRequest\x1a\x19.mediatools.v1.Transcript\x12S\n" +
+	"\x0fWatchTranscript\x12#.mediatools.v1.GetTranscriptRequest\x1a\x19.mediatools.v1.Transcript0\x01\x12f\n" +
+	"\x11SearchTranscripts\x12'.mediatools.v1.SearchTranscriptsRequest\x1a(.mediatools.v1.SearchTranscriptsResponseBPZNgithub.com/Shimizu-Technology/media-tools-api/proto/mediatools/v1;mediatoolsv1b\x06proto3"
+
+var (
+	file_mediatools_v1_media_tools_proto_rawDescOnce sync.Once
+	file_mediatools_v1_media_tools_proto_rawDescData []byte
+)
+
+func file_mediatools_v1_media_tools_proto_rawDescGZIP() []byte {
+	file_mediatools_v1_media_tools_proto_rawDescOnce.Do(func() {
+		file_mediatools_v1_media_tools_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mediatools_v1_media_tools_proto_rawDesc), len(file_mediatools_v1_media_tools_proto_rawDesc)))
+	})
+	return file_mediatools_v1_media_tools_proto_rawDescData
+}
+
+var file_mediatools_v1_media_tools_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mediatools_v1_media_tools_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_mediatools_v1_media_tools_proto_goTypes = []any{
+	(TranscriptStatus)(0),             // 0: mediatools.v1.TranscriptStatus
+	(*Transcript)(nil),                // 1: mediatools.v1.Transcript
+	(*SubmitTranscriptRequest)(nil),   // 2: mediatools.v1.SubmitTranscriptRequest
+	(*GetTranscriptRequest)(nil),      // 3: mediatools.v1.GetTranscriptRequest
+	(*SearchTranscriptsRequest)(nil),  // 4: mediatools.v1.SearchTranscriptsRequest
+	(*SearchTranscriptsResponse)(nil), // 5: mediatools.v1.SearchTranscriptsResponse
+	(*timestamppb.Timestamp)(nil),     // 6: google.protobuf.Timestamp
+}
+var file_mediatools_v1_media_tools_proto_depIdxs = []int32{
+	0, // 0: mediatools.v1.Transcript.status:type_name -> mediatools.v1.TranscriptStatus
+	6, // 1: mediatools.v1.Transcript.created_at:type_name -> google.protobuf.Timestamp
+	6, // 2: mediatools.v1.Transcript.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 3: mediatools.v1.SearchTranscriptsRequest.status:type_name -> mediatools.v1.TranscriptStatus
+	1, // 4: mediatools.v1.SearchTranscriptsResponse.transcripts:type_name -> mediatools.v1.Transcript
+	2, // 5: mediatools.v1.MediaTools.SubmitTranscript:input_type -> mediatools.v1.SubmitTranscriptRequest
+	3, // 6: mediatools.v1.MediaTools.GetTranscript:input_type -> mediatools.v1.GetTranscriptRequest
+	3, // 7: mediatools.v1.MediaTools.WatchTranscript:input_type -> mediatools.v1.GetTranscriptRequest
+	4, // 8: mediatools.v1.MediaTools.SearchTranscripts:input_type -> mediatools.v1.SearchTranscriptsRequest
+	1, // 9: mediatools.v1.MediaTools.SubmitTranscript:output_type -> mediatools.v1.Transcript
+	1, // 10: mediatools.v1.MediaTools.GetTranscript:output_type -> mediatools.v1.Transcript
+	1, // 11: mediatools.v1.MediaTools.WatchTranscript:output_type -> mediatools.v1.Transcript
+	5, // 12: mediatools.v1.MediaTools.SearchTranscripts:output_type -> mediatools.v1.SearchTranscriptsResponse
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_mediatools_v1_media_tools_proto_init() }
+func file_mediatools_v1_media_tools_proto_init() {
+	if File_mediatools_v1_media_tools_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mediatools_v1_media_tools_proto_rawDesc), len(file_mediatools_v1_media_tools_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mediatools_v1_media_tools_proto_goTypes,
+		DependencyIndexes: file_mediatools_v1_media_tools_proto_depIdxs,
+		EnumInfos:         file_mediatools_v1_media_tools_proto_enumTypes,
+		MessageInfos:      file_mediatools_v1_media_tools_proto_msgTypes,
+	}.Build()
+	File_mediatools_v1_media_tools_proto = out.File
+	file_mediatools_v1_media_tools_proto_goTypes = nil
+	file_mediatools_v1_media_tools_proto_depIdxs = nil
+}
@@ -0,0 +1,263 @@
+// Media Tools gRPC API.
+//
+// Served on GRPC_PORT (plaintext HTTP/2) when that variable is set,
+// alongside the standard grpc.health.v1 and server reflection services.
+// Authenticate with the same API keys as the REST API, sent as
+// "x-api-key" metadata. Every call counts against the key's rate limit.
+//
+// The Go stubs next to this file are generated with "make proto"; generate
+// a client in another language with e.g.:
+//
+//   protoc --python_out=. --grpc_python_out=. proto/mediatools/v1/media_tools.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: mediatools/v1/media_tools.proto
+
+package mediatoolsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MediaTools_SubmitTranscript_FullMethodName  = "/mediatools.v1.MediaTools/SubmitTranscript"
+	MediaTools_GetTranscript_FullMethodName     = "/mediatools.v1.MediaTools/GetTranscript"
+	MediaTools_WatchTranscript_FullMethodName   = "/mediatools.v1.MediaTools/WatchTranscript"
+	MediaTools_SearchTranscripts_FullMethodName = "/mediatools.v1.MediaTools/SearchTranscripts"
+)
+
+// MediaToolsClient is the client API for MediaTools service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MediaToolsClient interface {
+	// Starts extraction for a YouTube video. If the video has already been
+	// transcribed, the completed transcript is returned instead.
+	SubmitTranscript(ctx context.Context, in *SubmitTranscriptRequest, opts ...grpc.CallOption) (*Transcript, error)
+	// Returns a single transcript.
+	GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*Transcript, error)
+	// Sends the transcript's current state, then again every time its status
+	// changes. The stream ends once the transcript is completed or failed.
+	WatchTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transcript], error)
+	// Searches the caller's transcripts by title or channel name.
+	SearchTranscripts(ctx context.Context, in *SearchTranscriptsRequest, opts ...grpc.CallOption) (*SearchTranscriptsResponse, error)
+}
+
+type mediaToolsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMediaToolsClient(cc grpc.ClientConnInterface) MediaToolsClient {
+	return &mediaToolsClient{cc}
+}
+
+func (c *mediaToolsClient) SubmitTranscript(ctx context.Context, in *SubmitTranscriptRequest, opts ...grpc.CallOption) (*Transcript, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transcript)
+	err := c.cc.Invoke(ctx, MediaTools_SubmitTranscript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaToolsClient) GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*Transcript, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transcript)
+	err := c.cc.Invoke(ctx, MediaTools_GetTranscript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaToolsClient) WatchTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transcript], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaTools_ServiceDesc.Streams[0], MediaTools_WatchTranscript_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetTranscriptRequest, Transcript]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaTools_WatchTranscriptClient = grpc.ServerStreamingClient[Transcript]
+
+func (c *mediaToolsClient) SearchTranscripts(ctx context.Context, in *SearchTranscriptsRequest, opts ...grpc.CallOption) (*SearchTranscriptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTranscriptsResponse)
+	err := c.cc.Invoke(ctx, MediaTools_SearchTranscripts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MediaToolsServer is the server API for MediaTools service.
+// All implementations must embed UnimplementedMediaToolsServer
+// for forward compatibility.
+type MediaToolsServer interface {
+	// Starts extraction for a YouTube video. If the video has already been
+	// transcribed, the completed transcript is returned instead.
+	SubmitTranscript(context.Context, *SubmitTranscriptRequest) (*Transcript, error)
+	// Returns a single transcript.
+	GetTranscript(context.Context, *GetTranscriptRequest) (*Transcript, error)
+	// Sends the transcript's current state, then again every time its status
+	// changes. The stream ends once the transcript is completed or failed.
+	WatchTranscript(*GetTranscriptRequest, grpc.ServerStreamingServer[Transcript]) error
+	// Searches the caller's transcripts by title or channel name.
+	SearchTranscripts(context.Context, *SearchTranscriptsRequest) (*SearchTranscriptsResponse, error)
+	mustEmbedUnimplementedMediaToolsServer()
+}
+
+// UnimplementedMediaToolsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMediaToolsServer struct{}
+
+func (UnimplementedMediaToolsServer) SubmitTranscript(context.Context, *SubmitTranscriptRequest) (*Transcript, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitTranscript not implemented")
+}
+func (UnimplementedMediaToolsServer) GetTranscript(context.Context, *GetTranscriptRequest) (*Transcript, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTranscript not implemented")
+}
+func (UnimplementedMediaToolsServer) WatchTranscript(*GetTranscriptRequest, grpc.ServerStreamingServer[Transcript]) error {
+	return status.Error(codes.Unimplemented, "method WatchTranscript not implemented")
+}
+func (UnimplementedMediaToolsServer) SearchTranscripts(context.Context, *SearchTranscriptsRequest) (*SearchTranscriptsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchTranscripts not implemented")
+}
+func (UnimplementedMediaToolsServer) mustEmbedUnimplementedMediaToolsServer() {}
+func (UnimplementedMediaToolsServer) testEmbeddedByValue()                    {}
+
+// UnsafeMediaToolsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MediaToolsServer will
+// result in compilation errors.
+type UnsafeMediaToolsServer interface {
+	mustEmbedUnimplementedMediaToolsServer()
+}
+
+func RegisterMediaToolsServer(s grpc.ServiceRegistrar, srv MediaToolsServer) {
+	// If the following call panics, it indicates UnimplementedMediaToolsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MediaTools_ServiceDesc, srv)
+}
+
+func _MediaTools_SubmitTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaToolsServer).SubmitTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaTools_SubmitTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaToolsServer).SubmitTranscript(ctx, req.(*SubmitTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaTools_GetTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaToolsServer).GetTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaTools_GetTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaToolsServer).GetTranscript(ctx, req.(*GetTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaTools_WatchTranscript_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTranscriptRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaToolsServer).WatchTranscript(m, &grpc.GenericServerStream[GetTranscriptRequest, Transcript]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaTools_WatchTranscriptServer = grpc.ServerStreamingServer[Transcript]
+
+func _MediaTools_SearchTranscripts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTranscriptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaToolsServer).SearchTranscripts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaTools_SearchTranscripts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaToolsServer).SearchTranscripts(ctx, req.(*SearchTranscriptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MediaTools_ServiceDesc is the grpc.ServiceDesc for MediaTools service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MediaTools_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mediatools.v1.MediaTools",
+	HandlerType: (*MediaToolsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitTranscript",
+			Handler:    _MediaTools_SubmitTranscript_Handler,
+		},
+		{
+			MethodName: "GetTranscript",
+			Handler:    _MediaTools_GetTranscript_Handler,
+		},
+		{
+			MethodName: "SearchTranscripts",
+			Handler:    _MediaTools_SearchTranscripts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTranscript",
+			Handler:       _MediaTools_WatchTranscript_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mediatools/v1/media_tools.proto",
+}